@@ -172,6 +172,9 @@ const (
 	LogSinkDBLoggerFlushInterval = "LOGSINK_DBLOGGER_FLUSH_INTERVAL"
 	LogSinkRateLimitBurst        = "LOGSINK_RATELIMIT_BURST"
 	LogSinkRateLimitRefill       = "LOGSINK_RATELIMIT_REFILL"
+	LogSinkPingPeriod            = "LOGSINK_PING_PERIOD"
+	LogSinkPongDelay             = "LOGSINK_PONG_DELAY"
+	LogSinkIdleTimeout           = "LOGSINK_IDLE_TIMEOUT"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -3,6 +3,26 @@
 
 package agentbootstrap
 
+import (
+	"github.com/juju/juju/mongo"
+)
+
 var (
 	MachineJobFromParams = machineJobFromParams
 )
+
+var (
+	FreeDiskMB   = &freeDiskMB
+	MaxOpenFiles = &maxOpenFiles
+	HostArch     = &hostArch
+	Now          = &now
+)
+
+func PreflightCheckParams(dataDir string, mongoVersion mongo.Version, caCert, cert string) error {
+	return preflightCheck(preflightParams{
+		DataDir:      dataDir,
+		MongoVersion: mongoVersion,
+		CACert:       caCert,
+		Cert:         cert,
+	})
+}
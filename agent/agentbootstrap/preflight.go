@@ -0,0 +1,248 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbootstrap
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+)
+
+const (
+	// minFreeDiskMB is the least free space, in MB, needed on the
+	// volume holding the agent's data directory, for the mongo
+	// database files and the raft log.
+	minFreeDiskMB = 1024
+
+	// certBackdate is how far before their creation the validity of
+	// the certificates generated by the client starts.
+	certBackdate = 7 * 24 * time.Hour
+
+	// maxClockSkew is how far the machine's clock may be behind the
+	// clock of the client that started the bootstrap.
+	maxClockSkew = 10 * time.Minute
+
+	// nrOpenPath holds the kernel's limit on the number of files a
+	// process may open, which bounds the limits set by the init system.
+	nrOpenPath = "/proc/sys/fs/nr_open"
+)
+
+// The following functions inspect the bootstrap machine, and may
+// be replaced in tests.
+var (
+	// freeDiskMB returns the free space, in MB, on the volume
+	// holding the path.
+	freeDiskMB = diskFreeMB
+
+	// maxOpenFiles returns the most files the kernel allows a
+	// process to open.
+	maxOpenFiles = kernelMaxOpenFiles
+
+	hostArch = arch.HostArch
+
+	now = time.Now
+)
+
+// preflightParams holds what the pre-flight checks need to know
+// about the bootstrap machine's agent configuration.
+type preflightParams struct {
+	DataDir      string
+	MongoVersion mongo.Version
+	CACert       string
+	Cert         string
+}
+
+// PreflightCheck returns an error describing every reason the
+// bootstrap machine, with the given agent configuration, is unsuitable
+// for running a controller. It is called before mongo is started, so
+// that bootstrap fails before it initialises any state rather than
+// leaving a half-initialised controller behind. Checks which cannot
+// be made on the machine are logged and skipped.
+func PreflightCheck(c agent.Config) error {
+	info, ok := c.StateServingInfo()
+	if !ok {
+		return errors.New("bootstrap machine config has no state serving info")
+	}
+	return preflightCheck(preflightParams{
+		DataDir:      c.DataDir(),
+		MongoVersion: c.MongoVersion(),
+		CACert:       c.CACert(),
+		Cert:         info.Cert,
+	})
+}
+
+func preflightCheck(p preflightParams) error {
+	var problems []string
+	for _, check := range []func(preflightParams) error{
+		checkDiskSpace,
+		checkStorageEngine,
+		checkOpenFiles,
+		checkClock,
+	} {
+		err := check(p)
+		if errors.IsNotSupported(err) {
+			logger.Warningf("skipping pre-flight check: %v", err)
+			continue
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"bootstrap machine is not suitable for a controller:\n  %s",
+		strings.Join(problems, "\n  "),
+	)
+}
+
+// checkDiskSpace returns an error if there is not enough space on the
+// volume holding the data directory for the controller's databases.
+func checkDiskSpace(p preflightParams) error {
+	free, err := freeDiskMB(p.DataDir)
+	if errors.IsNotSupported(err) {
+		return err
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot get free space for %s", p.DataDir)
+	}
+	if free < minFreeDiskMB {
+		return errors.Errorf(
+			"only %d MB is free on the volume holding %s, but mongo and raft need at least %d MB; "+
+				"free some space, or bootstrap with a larger root-disk constraint",
+			free, p.DataDir, minFreeDiskMB,
+		)
+	}
+	return nil
+}
+
+// checkStorageEngine returns an error if mongo's storage engine is
+// not available with its version, or on the machine's architecture.
+func checkStorageEngine(p preflightParams) error {
+	v := p.MongoVersion
+	switch v.StorageEngine {
+	case mongo.WiredTiger:
+		if hostArch := hostArch(); hostArch == arch.I386 || hostArch == arch.ARM {
+			return errors.Errorf(
+				"mongo's %s storage engine needs a 64-bit machine, but the bootstrap machine is %s; "+
+					"bootstrap with a 64-bit arch constraint, such as arch=amd64",
+				v.StorageEngine, hostArch,
+			)
+		}
+	case mongo.MMAPV1:
+		if v.NewerThan(mongo.Version{Major: 4, Minor: 2}) >= 0 {
+			return errors.Errorf(
+				"mongo %d.%d does not support the %s storage engine; "+
+					"bootstrap on a series whose mongo supports %s",
+				v.Major, v.Minor, v.StorageEngine, mongo.WiredTiger,
+			)
+		}
+	}
+	return nil
+}
+
+// checkOpenFiles returns an error if the kernel will not let the
+// init system give the juju-db service its limit on open files.
+// Under systemd, the limits in /etc/security/limits.conf do not
+// apply to services.
+func checkOpenFiles(p preflightParams) error {
+	limit, err := maxOpenFiles()
+	if errors.IsNotSupported(err) {
+		return err
+	}
+	if err != nil {
+		return errors.Annotate(err, "cannot get open files limit")
+	}
+	if limit < mongo.OpenFilesLimit {
+		return errors.Errorf(
+			"the kernel allows a process at most %d open files, but the %s service needs %d; "+
+				"raise fs.nr_open with sysctl",
+			limit, mongo.ServiceName, mongo.OpenFilesLimit,
+		)
+	}
+	return nil
+}
+
+// kernelMaxOpenFiles returns the most files the kernel allows a
+// process to open.
+func kernelMaxOpenFiles() (uint64, error) {
+	data, err := ioutil.ReadFile(nrOpenPath)
+	if os.IsNotExist(err) {
+		return 0, errors.NotSupportedf("reading %s", nrOpenPath)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	limit, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return limit, errors.Annotatef(err, "parsing %s", nrOpenPath)
+}
+
+// checkClock returns an error if the machine's clock is outside the
+// validity periods of the controller's certificates, or is behind the
+// clock of the client that generated the controller certificate at
+// the start of the bootstrap. The controller certificate's validity
+// starts some time before it was generated, to allow for clients
+// whose clocks are behind the controller's.
+func checkClock(p preflightParams) error {
+	t := now()
+	for _, cert := range []struct {
+		name      string
+		pem       string
+		generated bool
+	}{
+		{"CA", p.CACert, false},
+		{"controller", p.Cert, true},
+	} {
+		if cert.pem == "" {
+			continue
+		}
+		parsed, err := parseCertificate(cert.pem)
+		if err != nil {
+			// The certificate is checked properly when it is used;
+			// here it only provides a time to compare with.
+			logger.Warningf("cannot check clock against %s certificate: %v", cert.name, err)
+			continue
+		}
+		var skew string
+		switch {
+		case t.Before(parsed.NotBefore):
+			skew = fmt.Sprintf("earlier than the start of the %s certificate's validity (%s)",
+				cert.name, parsed.NotBefore.UTC().Format(time.RFC3339))
+		case cert.generated && t.Before(parsed.NotBefore.Add(certBackdate-maxClockSkew)):
+			skew = fmt.Sprintf("earlier than when the %s certificate was generated (%s)",
+				cert.name, parsed.NotBefore.Add(certBackdate).UTC().Format(time.RFC3339))
+		case t.After(parsed.NotAfter):
+			skew = fmt.Sprintf("later than the expiry of the %s certificate (%s)",
+				cert.name, parsed.NotAfter.UTC().Format(time.RFC3339))
+		default:
+			continue
+		}
+		return errors.Errorf(
+			"the machine's clock (%s) is %s; check the machine's time synchronisation (NTP)",
+			t.UTC().Format(time.RFC3339), skew,
+		)
+	}
+	return nil
+}
+
+func parseCertificate(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, errors.NotValidf("certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return cert, errors.Trace(err)
+}
@@ -0,0 +1,98 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbootstrap_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent/agentbootstrap"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/testing"
+)
+
+type preflightSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&preflightSuite{})
+
+func (s *preflightSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(agentbootstrap.FreeDiskMB, func(string) (uint64, error) { return 10240, nil })
+	s.PatchValue(agentbootstrap.MaxOpenFiles, func() (uint64, error) { return 1048576, nil })
+	s.PatchValue(agentbootstrap.HostArch, func() string { return arch.AMD64 })
+	s.PatchValue(agentbootstrap.Now, time.Now)
+}
+
+func (s *preflightSuite) check() error {
+	return agentbootstrap.PreflightCheckParams("/var/lib/juju", mongo.Mongo40wt, testing.CACert, testing.ServerCert)
+}
+
+func (s *preflightSuite) TestSuitable(c *gc.C) {
+	c.Assert(s.check(), jc.ErrorIsNil)
+}
+
+func (s *preflightSuite) TestDiskSpace(c *gc.C) {
+	s.PatchValue(agentbootstrap.FreeDiskMB, func(string) (uint64, error) { return 100, nil })
+	c.Assert(s.check(), gc.ErrorMatches, `bootstrap machine is not suitable for a controller:
+  only 100 MB is free on the volume holding /var/lib/juju, but mongo and raft need at least 1024 MB; .*`)
+}
+
+func (s *preflightSuite) TestStorageEngine(c *gc.C) {
+	s.PatchValue(agentbootstrap.HostArch, func() string { return arch.I386 })
+	c.Assert(s.check(), gc.ErrorMatches, `(?s).*mongo's wiredTiger storage engine needs a 64-bit machine, but the bootstrap machine is i386; .*`)
+
+	err := agentbootstrap.PreflightCheckParams("/var/lib/juju", mongo.Version{Major: 4, Minor: 2, StorageEngine: mongo.MMAPV1}, "", "")
+	c.Assert(err, gc.ErrorMatches, `(?s).*mongo 4.2 does not support the mmapv1 storage engine; .*`)
+}
+
+func (s *preflightSuite) TestOpenFiles(c *gc.C) {
+	s.PatchValue(agentbootstrap.MaxOpenFiles, func() (uint64, error) { return 32768, nil })
+	c.Assert(s.check(), gc.ErrorMatches, `(?s).*the kernel allows a process at most 32768 open files, but the juju-db service needs 64000; raise fs.nr_open with sysctl`)
+}
+
+func (s *preflightSuite) TestClock(c *gc.C) {
+	s.PatchValue(agentbootstrap.Now, func() time.Time {
+		return time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+	c.Assert(s.check(), gc.ErrorMatches, `(?s).*the machine's clock \(1990-01-01T00:00:00Z\) is earlier than the start of the CA certificate's validity .*; check the machine's time synchronisation \(NTP\)`)
+
+	s.PatchValue(agentbootstrap.Now, func() time.Time {
+		return time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+	c.Assert(s.check(), gc.ErrorMatches, `(?s).*is later than the expiry of the CA certificate .*`)
+
+	// The controller certificate's validity starts before it was
+	// generated, but the clock may not be much earlier than that.
+	s.PatchValue(agentbootstrap.Now, func() time.Time {
+		return time.Now().Add(-time.Hour)
+	})
+	c.Assert(s.check(), gc.ErrorMatches, `(?s).*is earlier than when the controller certificate was generated .*`)
+
+	s.PatchValue(agentbootstrap.Now, func() time.Time {
+		return time.Now().Add(-time.Minute)
+	})
+	c.Assert(s.check(), jc.ErrorIsNil)
+}
+
+func (s *preflightSuite) TestAllProblemsReported(c *gc.C) {
+	s.PatchValue(agentbootstrap.FreeDiskMB, func(string) (uint64, error) { return 100, nil })
+	s.PatchValue(agentbootstrap.MaxOpenFiles, func() (uint64, error) { return 32768, nil })
+	c.Assert(s.check(), gc.ErrorMatches, `bootstrap machine is not suitable for a controller:
+  only 100 MB is free .*
+  the kernel allows a process at most 32768 open files, .*`)
+}
+
+func (s *preflightSuite) TestUnsupportedChecksSkipped(c *gc.C) {
+	s.PatchValue(agentbootstrap.FreeDiskMB, func(string) (uint64, error) {
+		return 0, errors.NotSupportedf("free disk space")
+	})
+	c.Assert(s.check(), jc.ErrorIsNil)
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package agentbootstrap
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// diskFreeMB returns the free space, in MB, on the volume holding the
+// path, as available to unprivileged users.
+func diskFreeMB(path string) (uint64, error) {
+	// Note: golang.org/x/sys/unix is avoided here for the same
+	// reasons as in container/lxd (lp:1632541).
+	statfs := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return uint64(statfs.Bsize) * statfs.Bavail / (1024 * 1024), nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbootstrap
+
+import (
+	"github.com/juju/errors"
+)
+
+// diskFreeMB returns the free space, in MB, on the volume holding the
+// path.
+func diskFreeMB(path string) (uint64, error) {
+	return 0, errors.NotSupportedf("free disk space on windows")
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionnotifier provides the client side of the API used by
+// the action notifier worker.
+package actionnotifier
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionNotifierFacade = "ActionNotifier"
+
+// Notification describes a finished action whose completion is to be
+// posted to a notification URL.
+type Notification struct {
+	// URL is the URL to which the notification is posted.
+	URL string
+
+	// ActionID is the id of the action.
+	ActionID string
+
+	// Receiver is the id of the unit or machine that ran the action.
+	Receiver string
+
+	// Name is the name of the action.
+	Name string
+
+	// Status is the final status of the action.
+	Status string
+
+	// Message holds any error message from the action.
+	Message string
+
+	// Output holds the results of the action.
+	Output map[string]interface{}
+
+	// Enqueued, Started and Completed record when the action was
+	// queued, started running and finished.
+	Enqueued  time.Time
+	Started   time.Time
+	Completed time.Time
+}
+
+// Client provides access to the ActionNotifier API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side ActionNotifier facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, actionNotifierFacade)}
+}
+
+// WatchFinishedActions returns a watcher that reports the ids of
+// actions in the model as they finish.
+func (c *Client) WatchFinishedActions() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchFinishedActions", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// PendingNotifications returns the notifications yet to be posted for
+// the actions with the given ids. Actions without a notification to
+// post are omitted.
+func (c *Client) PendingNotifications(ids []string) ([]Notification, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(ids))}
+	for i, id := range ids {
+		args.Entities[i].Tag = names.NewActionTag(id).String()
+	}
+	var results params.ActionNotifications
+	if err := c.facade.FacadeCall("PendingNotifications", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	var notifications []Notification
+	for i, result := range results.Results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The action has been pruned.
+				continue
+			}
+			return nil, errors.Annotatef(result.Error, "action %q", ids[i])
+		}
+		if result.NotifyURL == "" || result.Action == nil || result.Action.Action == nil {
+			continue
+		}
+		receiver, err := names.ParseTag(result.Action.Action.Receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		notifications = append(notifications, Notification{
+			URL:       result.NotifyURL,
+			ActionID:  ids[i],
+			Receiver:  receiver.Id(),
+			Name:      result.Action.Action.Name,
+			Status:    result.Action.Status,
+			Message:   result.Action.Message,
+			Output:    result.Action.Output,
+			Enqueued:  result.Action.Enqueued,
+			Started:   result.Action.Started,
+			Completed: result.Action.Completed,
+		})
+	}
+	return notifications, nil
+}
+
+// SetNotified records that the notification for the
+// action with the given id has been posted.
+func (c *Client) SetNotified(id string) error {
+	args := params.Entities{Entities: []params.Entity{{Tag: names.NewActionTag(id).String()}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetNotified", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionnotifier_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionnotifier"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+const (
+	actionID  = "11111111-1111-1111-1111-111111111111"
+	actionID2 = "22222222-2222-2222-2222-222222222222"
+	actionID3 = "33333333-3333-3333-3333-333333333333"
+)
+
+func (s *clientSuite) TestPendingNotifications(c *gc.C) {
+	completed := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionNotifier")
+		c.Check(request, gc.Equals, "PendingNotifications")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{
+			{Tag: "action-" + actionID}, {Tag: "action-" + actionID2}, {Tag: "action-" + actionID3},
+		}})
+		*(result.(*params.ActionNotifications)) = params.ActionNotifications{
+			Results: []params.ActionNotification{{
+				NotifyURL: "https://chat.example.com/hooks/juju",
+				Action: &params.ActionResult{
+					Action: &params.Action{
+						Tag:      "action-" + actionID,
+						Receiver: "unit-mysql-0",
+						Name:     "backup",
+					},
+					Status:    "completed",
+					Output:    map[string]interface{}{"file": "backup.tgz"},
+					Completed: completed,
+				},
+			}, {
+				// No notification to post.
+			}, {
+				Error: &params.Error{Code: params.CodeNotFound, Message: "pruned"},
+			}},
+		}
+		return nil
+	})
+
+	client := actionnotifier.NewClient(apiCaller)
+	notifications, err := client.PendingNotifications([]string{actionID, actionID2, actionID3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(notifications, jc.DeepEquals, []actionnotifier.Notification{{
+		URL:       "https://chat.example.com/hooks/juju",
+		ActionID:  actionID,
+		Receiver:  "mysql/0",
+		Name:      "backup",
+		Status:    "completed",
+		Output:    map[string]interface{}{"file": "backup.tgz"},
+		Completed: completed,
+	}})
+}
+
+func (s *clientSuite) TestPendingNotificationsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ActionNotifications)) = params.ActionNotifications{
+			Results: []params.ActionNotification{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+
+	client := actionnotifier.NewClient(apiCaller)
+	_, err := client.PendingNotifications([]string{actionID})
+	c.Assert(err, gc.ErrorMatches, `action "`+actionID+`": boom`)
+}
+
+func (s *clientSuite) TestSetNotified(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionNotifier")
+		c.Check(request, gc.Equals, "SetNotified")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "action-" + actionID}}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	client := actionnotifier.NewClient(apiCaller)
+	err := client.SetNotified(actionID)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -208,6 +208,9 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	}
 
 	client := rpc.NewConn(jsoncodec.New(dialResult.conn), nil)
+	if opts.WarningHandler != nil {
+		client.SetWarningHandler(opts.WarningHandler)
+	}
 	client.Start(ctx)
 
 	bakeryClient := opts.BakeryClient
@@ -292,6 +292,12 @@ type SetCharmConfig struct {
 	// facade version 2
 	ConfigSettingsYAML string `json:"config-settings-yaml,omitempty"`
 
+	// ConfigMap maps the names of config options of the current charm
+	// to the names of the new charm's options that their values are
+	// carried over to. This field is only understood by Application
+	// facade version 12 and greater.
+	ConfigMap map[string]string `json:"config-map,omitempty"`
+
 	// Force forces the use of the charm in the following scenarios:
 	// overriding a lxd profile upgrade.
 	// In the future, we should deprecate ForceSeries and ForceUnits and just
@@ -344,6 +350,7 @@ func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
 		Channel:            string(cfg.CharmID.Channel),
 		ConfigSettings:     cfg.ConfigSettings,
 		ConfigSettingsYAML: cfg.ConfigSettingsYAML,
+		ConfigMap:          cfg.ConfigMap,
 		Force:              cfg.Force,
 		ForceSeries:        cfg.ForceSeries,
 		ForceUnits:         cfg.ForceUnits,
@@ -772,6 +779,60 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", args, nil)
 }
 
+// MergeBindings merges the given endpoint bindings with the existing
+// bindings of the application. It returns a description of each machine
+// hosting the application's units which is not connected to a space the
+// endpoints are to be bound to. Unless force is true, the bindings are
+// not changed when there are any such machines.
+func (c *Client) MergeBindings(application string, bindings map[string]string, force bool) ([]string, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.New("this juju controller does not support changing endpoint bindings")
+	}
+	args := params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Bindings:       bindings,
+			Force:          force,
+		}},
+	}
+	var results params.ApplicationMergeBindingsResults
+	if err := c.facade.FacadeCall("MergeBindings", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Problems, result.Error
+	}
+	return result.Problems, nil
+}
+
+// CredentialAccesses returns the hooks of the given application which
+// have recently been given the model's cloud credential because the
+// application was trusted, most recent first.
+func (c *Client) CredentialAccesses(application string) ([]params.CredentialAccess, error) {
+	if c.BestAPIVersion() < 13 {
+		return nil, errors.NotSupportedf("credential access history on this juju controller")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.CredentialAccessResults
+	if err := c.facade.FacadeCall("CredentialAccesses", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Accesses, nil
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -82,6 +82,81 @@ func (s *applicationSuite) TestSetApplicationMetricCredentialsFails(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestMergeBindings(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "MergeBindings")
+				c.Check(a, jc.DeepEquals, params.ApplicationMergeBindingsArgs{
+					Args: []params.ApplicationMergeBindings{{
+						ApplicationTag: "application-mysql",
+						Bindings:       map[string]string{"db": "internal"},
+						Force:          true,
+					}},
+				})
+				result := response.(*params.ApplicationMergeBindingsResults)
+				result.Results = []params.ApplicationMergeBindingsResult{{
+					Problems: []string{"machine 1 is not connected"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+	problems, err := client.MergeBindings("mysql", map[string]string{"db": "internal"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []string{"machine 1 is not connected"})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestMergeBindingsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	_, err := client.MergeBindings("mysql", map[string]string{"db": "internal"}, false)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support changing endpoint bindings")
+}
+
+func (s *applicationSuite) TestCredentialAccesses(c *gc.C) {
+	now := time.Now()
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "CredentialAccesses")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-mysql"}},
+				})
+				result := response.(*params.CredentialAccessResults)
+				result.Results = []params.CredentialAccessResult{{
+					Accesses: []params.CredentialAccess{{Unit: "mysql/0", Hook: "install", Time: now}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	accesses, err := client.CredentialAccesses("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, jc.DeepEquals, []params.CredentialAccess{{Unit: "mysql/0", Hook: "install", Time: now}})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestCredentialAccessesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	_, err := client.CredentialAccesses("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDeploy(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -270,6 +345,7 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 			"c": "d",
 		})
 		c.Assert(args.ConfigSettingsYAML, gc.Equals, "yaml")
+		c.Assert(args.ConfigMap, jc.DeepEquals, map[string]string{"e": "f"})
 		c.Assert(args.Force, gc.Equals, true)
 		c.Assert(args.ForceSeries, gc.Equals, true)
 		c.Assert(args.ForceUnits, gc.Equals, true)
@@ -292,6 +368,7 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 			"c": "d",
 		},
 		ConfigSettingsYAML: "yaml",
+		ConfigMap:          map[string]string{"e": "f"},
 		Force:              true,
 		ForceSeries:        true,
 		ForceUnits:         true,
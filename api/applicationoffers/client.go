@@ -32,6 +32,27 @@ func NewClient(st base.APICallCloser) *Client {
 
 // Offer prepares application's endpoints for consumption.
 func (c *Client) Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error) {
+	return c.offer(modelUUID, application, endpoints, offerName, desc, nil, nil)
+}
+
+// OfferWithConsumerACLs prepares application's endpoints for consumption
+// only by models whose egress networks fall within the given CIDRs, and
+// which are hosted by the controllers with the given UUIDs. Either
+// restriction may be empty.
+func (c *Client) OfferWithConsumerACLs(
+	modelUUID, application string, endpoints []string, offerName string, desc string,
+	allowedCIDRs, allowedControllers []string,
+) ([]params.ErrorResult, error) {
+	if len(allowedCIDRs)+len(allowedControllers) > 0 && c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("restricting offer consumers on this version of Juju")
+	}
+	return c.offer(modelUUID, application, endpoints, offerName, desc, allowedCIDRs, allowedControllers)
+}
+
+func (c *Client) offer(
+	modelUUID, application string, endpoints []string, offerName string, desc string,
+	allowedCIDRs, allowedControllers []string,
+) ([]params.ErrorResult, error) {
 	// TODO(wallyworld) - support endpoint aliases
 	ep := make(map[string]string)
 	for _, name := range endpoints {
@@ -44,6 +65,9 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, offerN
 			ApplicationDescription: desc,
 			Endpoints:              ep,
 			OfferName:              offerName,
+
+			AllowedConsumerCIDRs:       allowedCIDRs,
+			AllowedConsumerControllers: allowedControllers,
 		},
 	}
 	out := params.ErrorResults{}
@@ -254,6 +278,14 @@ func (c *Client) FindApplicationOffers(filters ...crossmodel.ApplicationOfferFil
 
 // GetConsumeDetails returns details necessary to consue an offer at a given URL.
 func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, error) {
+	return c.GetConsumeDetailsForController(urlStr, "")
+}
+
+// GetConsumeDetailsForController returns details necessary to consume an
+// offer at a given URL from a model hosted by the controller with the
+// given UUID. The controller is declared in the returned macaroon, which
+// is needed to consume offers restricted to specific controllers.
+func (c *Client) GetConsumeDetailsForController(urlStr, controllerUUID string) (params.ConsumeOfferDetails, error) {
 
 	url, err := crossmodel.ParseOfferURL(urlStr)
 	if err != nil {
@@ -265,7 +297,15 @@ func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, e
 
 	found := params.ConsumeOfferDetailsResults{}
 
-	err = c.facade.FacadeCall("GetConsumeDetails", params.OfferURLs{[]string{urlStr}}, &found)
+	urls := params.OfferURLs{[]string{urlStr}}
+	if controllerUUID != "" && c.BestAPIVersion() >= 3 {
+		err = c.facade.FacadeCall("GetConsumeDetails", params.ConsumeOfferDetailsArg{
+			OfferURLs:              urls,
+			ConsumerControllerUUID: controllerUUID,
+		}, &found)
+	} else {
+		err = c.facade.FacadeCall("GetConsumeDetails", urls, &found)
+	}
 	if err != nil {
 		return params.ConsumeOfferDetails{}, errors.Trace(err)
 	}
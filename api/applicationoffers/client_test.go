@@ -98,6 +98,59 @@ func (s *crossmodelMockSuite) TestOfferFacadeCallError(c *gc.C) {
 	c.Assert(results, gc.IsNil)
 }
 
+func (s *crossmodelMockSuite) TestOfferWithConsumerACLs(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Check(objType, gc.Equals, "ApplicationOffers")
+				c.Check(request, gc.Equals, "Offer")
+				args, ok := a.(params.AddApplicationOffers)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Offers, gc.HasLen, 1)
+				c.Check(args.Offers[0].AllowedConsumerCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+				c.Check(args.Offers[0].AllowedConsumerControllers, jc.DeepEquals, []string{"controller-uuid"})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			}),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	results, err := client.OfferWithConsumerACLs(
+		"uuid", "mysql", []string{"db"}, "hosted-mysql", "",
+		[]string{"10.0.0.0/8"}, []string{"controller-uuid"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestOfferWithConsumerACLsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			}),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.OfferWithConsumerACLs(
+		"uuid", "mysql", []string{"db"}, "hosted-mysql", "",
+		[]string{"10.0.0.0/8"}, nil,
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *crossmodelMockSuite) TestList(c *gc.C) {
 	offerName := "hosted-db2"
 	url := fmt.Sprintf("fred/model.%s", offerName)
@@ -637,6 +690,34 @@ func (s *crossmodelMockSuite) TestGetConsumeDetails(c *gc.C) {
 	})
 }
 
+func (s *crossmodelMockSuite) TestGetConsumeDetailsForController(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "GetConsumeDetails")
+				c.Assert(a, jc.DeepEquals, params.ConsumeOfferDetailsArg{
+					OfferURLs:              params.OfferURLs{OfferURLs: []string{"me/prod.app"}},
+					ConsumerControllerUUID: testing.ControllerTag.Id(),
+				})
+				if results, ok := result.(*params.ConsumeOfferDetailsResults); ok {
+					results.Results = []params.ConsumeOfferDetailsResult{{}}
+				}
+				return nil
+			}),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.GetConsumeDetailsForController("me/prod.app", testing.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *crossmodelMockSuite) TestGetConsumeDetailsBadURL(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -125,6 +125,7 @@ type UserModelSummary struct {
 	ModelUserAccess    string
 	UserLastConnection *time.Time
 	Counts             []EntityCount
+	Quotas             []EntityCount
 	AgentVersion       *version.Number
 	Error              error
 	Migration          *MigrationSummary
@@ -43,6 +43,33 @@ func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the application config of the specified application.
+// Controllers older than facade version 2 do not support it.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching application config")
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.NotValidf("application name %q", appName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
 // ApplicationPassword holds parameters for setting
 // an application password.
 type ApplicationPassword struct {
@@ -110,20 +137,45 @@ type OperatorProvisioningInfo struct {
 	CharmStorage storage.KubernetesFilesystemParams
 }
 
-// OperatorProvisioningInfo returns the info needed to provision an operator.
-func (c *Client) OperatorProvisioningInfo() (OperatorProvisioningInfo, error) {
-	var result params.OperatorProvisioningInfo
-	if err := c.facade.FacadeCall("OperatorProvisioningInfo", nil, &result); err != nil {
+// OperatorProvisioningInfo returns the info needed to provision
+// the operator of the specified application. Controllers older than
+// facade version 2 do not support per-application operator images,
+// and return the same info for every application.
+func (c *Client) OperatorProvisioningInfo(appName string) (OperatorProvisioningInfo, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		var result params.OperatorProvisioningInfo
+		if err := c.facade.FacadeCall("OperatorProvisioningInfo", nil, &result); err != nil {
+			return OperatorProvisioningInfo{}, err
+		}
+		return operatorProvisioningInfoFromParams(result), nil
+	}
+	if !names.IsValidApplication(appName) {
+		return OperatorProvisioningInfo{}, errors.NotValidf("application name %q", appName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+	var results params.OperatorProvisioningInfoResults
+	if err := c.facade.FacadeCall("OperatorProvisioningInfo", args, &results); err != nil {
 		return OperatorProvisioningInfo{}, err
 	}
-	info := OperatorProvisioningInfo{
-		ImagePath:    result.ImagePath,
-		Version:      result.Version,
-		APIAddresses: result.APIAddresses,
-		Tags:         result.Tags,
-		CharmStorage: filesystemFromParams(result.CharmStorage),
+	if n := len(results.Results); n != 1 {
+		return OperatorProvisioningInfo{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return OperatorProvisioningInfo{}, maybeNotFound(err)
+	}
+	return operatorProvisioningInfoFromParams(*results.Results[0].Result), nil
+}
+
+func operatorProvisioningInfoFromParams(in params.OperatorProvisioningInfo) OperatorProvisioningInfo {
+	return OperatorProvisioningInfo{
+		ImagePath:    in.ImagePath,
+		Version:      in.Version,
+		APIAddresses: in.APIAddresses,
+		Tags:         in.Tags,
+		CharmStorage: filesystemFromParams(in.CharmStorage),
 	}
-	return info, nil
 }
 
 func filesystemFromParams(in params.KubernetesFilesystemParams) storage.KubernetesFilesystemParams {
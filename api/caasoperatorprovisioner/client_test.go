@@ -155,30 +155,36 @@ func (s *provisionerSuite) TestLifeCount(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
 
-func (s *provisionerSuite) OperatorProvisioningInfo(c *gc.C) {
+func (s *provisionerSuite) TestOperatorProvisioningInfo(c *gc.C) {
 	vers := version.MustParse("2.99.0")
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
 		c.Check(id, gc.Equals, "")
 		c.Assert(request, gc.Equals, "OperatorProvisioningInfo")
-		c.Assert(a, gc.IsNil)
-		c.Assert(result, gc.FitsTypeOf, &params.OperatorProvisioningInfo{})
-		*(result.(*params.OperatorProvisioningInfo)) = params.OperatorProvisioningInfo{
-			ImagePath:    "juju-operator-image",
-			Version:      vers,
-			APIAddresses: []string{"10.0.0.1:1"},
-			Tags:         map[string]string{"foo": "bar"},
-			CharmStorage: params.KubernetesFilesystemParams{
-				Size:        10,
-				Provider:    "kubernetes",
-				StorageName: "stor",
-				Tags:        map[string]string{"model": "model-tag"},
-				Attributes:  map[string]interface{}{"key": "value"},
-			},
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.OperatorProvisioningInfoResults{})
+		*(result.(*params.OperatorProvisioningInfoResults)) = params.OperatorProvisioningInfoResults{
+			Results: []params.OperatorProvisioningInfoResult{{
+				Result: &params.OperatorProvisioningInfo{
+					ImagePath:    "juju-operator-image",
+					Version:      vers,
+					APIAddresses: []string{"10.0.0.1:1"},
+					Tags:         map[string]string{"foo": "bar"},
+					CharmStorage: params.KubernetesFilesystemParams{
+						Size:        10,
+						Provider:    "kubernetes",
+						StorageName: "stor",
+						Tags:        map[string]string{"model": "model-tag"},
+						Attributes:  map[string]interface{}{"key": "value"},
+					},
+				},
+			}},
 		}
 		return nil
 	})
-	info, err := client.OperatorProvisioningInfo()
+	info, err := client.OperatorProvisioningInfo("gitlab")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(info, jc.DeepEquals, caasoperatorprovisioner.OperatorProvisioningInfo{
 		ImagePath:    "juju-operator-image",
@@ -194,3 +200,75 @@ func (s *provisionerSuite) OperatorProvisioningInfo(c *gc.C) {
 		},
 	})
 }
+
+func (s *provisionerSuite) TestOperatorProvisioningInfoNotFound(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		*(result.(*params.OperatorProvisioningInfoResults)) = params.OperatorProvisioningInfoResults{
+			Results: []params.OperatorProvisioningInfoResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: `application "gitlab" not found`},
+			}},
+		}
+		return nil
+	})
+	_, err := client.OperatorProvisioningInfo("gitlab")
+	c.Assert(err, gc.ErrorMatches, `application "gitlab" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *provisionerSuite) TestOperatorProvisioningInfoV1(c *gc.C) {
+	vers := version.MustParse("2.99.0")
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Assert(request, gc.Equals, "OperatorProvisioningInfo")
+			c.Assert(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.OperatorProvisioningInfo{})
+			*(result.(*params.OperatorProvisioningInfo)) = params.OperatorProvisioningInfo{
+				ImagePath: "juju-operator-image",
+				Version:   vers,
+			}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := caasoperatorprovisioner.NewClient(apiCaller)
+	info, err := client.OperatorProvisioningInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ImagePath, gc.Equals, "juju-operator-image")
+	c.Assert(info.Version, gc.Equals, vers)
+}
+
+func (s *provisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "WatchApplicationsConfig")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *provisionerSuite) TestWatchApplicationConfigV1(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := caasoperatorprovisioner.NewClient(apiCaller)
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
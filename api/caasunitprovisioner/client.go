@@ -4,7 +4,10 @@
 package caasunitprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/proxy"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -143,12 +146,37 @@ func (c *Client) WatchPodSpec(application string) (watcher.NotifyWatcher, error)
 	return w, nil
 }
 
+// WatchProxySettings returns a NotifyWatcher that notifies when the
+// model's proxy settings, included in the provisioning info of every
+// application, may have changed.
+func (c *Client) WatchProxySettings() (watcher.NotifyWatcher, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("WatchProxySettings() (need V4+)")
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchProxySettings", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // DeploymentInfo holds deployment info from charm metadata.
 type DeploymentInfo struct {
 	DeploymentType string
 	ServiceType    string
 }
 
+// SchedulingInfo holds the constraints on where an
+// application's pods are scheduled relative to each other.
+type SchedulingInfo struct {
+	SpreadAcrossNodes bool
+	SpreadAcrossZones bool
+}
+
 // ProvisioningInfo holds unit provisioning info.
 type ProvisioningInfo struct {
 	DeploymentInfo DeploymentInfo
@@ -157,6 +185,8 @@ type ProvisioningInfo struct {
 	Filesystems    []storage.KubernetesFilesystemParams
 	Devices        []devices.KubernetesDeviceParams
 	Tags           map[string]string
+	Scheduling     SchedulingInfo
+	ProxySettings  proxy.Settings
 }
 
 // ProvisioningInfo returns the provisioning info for the specified CAAS
@@ -190,6 +220,21 @@ func (c *Client) ProvisioningInfo(appName string) (*ProvisioningInfo, error) {
 			ServiceType:    result.DeploymentInfo.ServiceType,
 		}
 	}
+	if result.Scheduling != nil {
+		info.Scheduling = SchedulingInfo{
+			SpreadAcrossNodes: result.Scheduling.SpreadAcrossNodes,
+			SpreadAcrossZones: result.Scheduling.SpreadAcrossZones,
+		}
+	}
+
+	if result.ProxySettings != nil {
+		info.ProxySettings = proxy.Settings{
+			Http:    result.ProxySettings.HTTP,
+			Https:   result.ProxySettings.HTTPS,
+			Ftp:     result.ProxySettings.FTP,
+			NoProxy: result.ProxySettings.NoProxy,
+		}
+	}
 
 	for _, fs := range result.Filesystems {
 		fsInfo, err := filesystemFromParams(fs)
@@ -277,10 +322,43 @@ func maybeNotFound(err *params.Error) error {
 }
 
 // UpdateUnits updates the state model to reflect the state of the units
-// as reported by the cloud.
-func (c *Client) UpdateUnits(arg params.UpdateApplicationUnits) error {
-	var result params.ErrorResults
+// as reported by the cloud. Each unit is updated independently; the
+// returned slice holds the error, if any, from updating each unit, in
+// the order the units were supplied. Controllers that cannot report
+// per-unit results update all of the units or none of them, and only
+// an overall error is returned.
+func (c *Client) UpdateUnits(arg params.UpdateApplicationUnits) ([]error, error) {
 	args := params.UpdateApplicationUnitArgs{Args: []params.UpdateApplicationUnits{arg}}
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, c.updateUnitsV1(args)
+	}
+
+	var result params.UpdateApplicationUnitResults
+	err := c.facade.FacadeCall("UpdateApplicationsUnits", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Args), len(result.Results))
+	}
+	if err := result.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	unitResults := result.Results[0].Units
+	if len(unitResults) != len(arg.Units) {
+		return nil, errors.Errorf("expected %d unit result(s), got %d", len(arg.Units), len(unitResults))
+	}
+	unitErrors := make([]error, len(unitResults))
+	for i, r := range unitResults {
+		if r.Error != nil {
+			unitErrors[i] = r.Error
+		}
+	}
+	return unitErrors, nil
+}
+
+func (c *Client) updateUnitsV1(args params.UpdateApplicationUnitArgs) error {
+	var result params.ErrorResults
 	err := c.facade.FacadeCall("UpdateApplicationsUnits", args, &result)
 	if err != nil {
 		return errors.Trace(err)
@@ -292,7 +370,10 @@ func (c *Client) UpdateUnits(arg params.UpdateApplicationUnits) error {
 		return nil
 	}
 	if params.IsCodeForbidden(result.Results[0].Error) {
-		return errors.NewForbidden(result.Results[0].Error, "")
+		// Older controllers report a scale change superseded by
+		// one already applied as forbidden; the units are otherwise
+		// updated, so this is not an error.
+		return nil
 	}
 	return maybeNotFound(result.Results[0].Error)
 }
@@ -317,6 +398,39 @@ func (c *Client) UpdateApplicationService(arg params.UpdateApplicationServiceArg
 	return maybeNotFound(result.Results[0].Error)
 }
 
+// CleanupOrphanedStorage destroys the filesystems of the specified
+// application that were last mounted by the pod of a unit which no
+// longer exists, and returns the tags of the destroyed filesystems.
+func (c *Client) CleanupOrphanedStorage(appName string, force bool, maxWait *time.Duration) ([]names.FilesystemTag, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotImplementedf("CleanupOrphanedStorage() (need V3+)")
+	}
+	var result params.CleanupOrphanedStorageResults
+	args := params.CleanupOrphanedStorageArgs{Args: []params.CleanupOrphanedStorageArg{{
+		ApplicationTag: names.NewApplicationTag(appName).String(),
+		Force:          force,
+		MaxWait:        maxWait,
+	}}}
+	if err := c.facade.FacadeCall("CleanupOrphanedStorage", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Args), len(result.Results))
+	}
+	var destroyed []names.FilesystemTag
+	for _, tagString := range result.Results[0].Filesystems {
+		tag, err := names.ParseFilesystemTag(tagString)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		destroyed = append(destroyed, tag)
+	}
+	if err := result.Results[0].Error; err != nil {
+		return destroyed, maybeNotFound(err)
+	}
+	return destroyed, nil
+}
+
 // SetOperatorStatus updates the provisioning status of an operator.
 func (c *Client) SetOperatorStatus(appName string, status status.Status, message string, data map[string]interface{}) error {
 	var result params.ErrorResults
@@ -4,7 +4,10 @@
 package caasunitprovisioner_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/proxy"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -72,6 +75,11 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 							Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 						},
 					},
+					Scheduling: &params.KubernetesSchedulingParams{SpreadAcrossZones: true},
+					ProxySettings: &params.ProxyConfig{
+						HTTP:    "http://proxy.example.com",
+						NoProxy: "10.0.0.0/8",
+					},
 				},
 			}},
 		}
@@ -108,6 +116,11 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 			Count:      3,
 			Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 		}},
+		Scheduling: caasunitprovisioner.SchedulingInfo{SpreadAcrossZones: true},
+		ProxySettings: proxy.Settings{
+			Http:    "http://proxy.example.com",
+			NoProxy: "10.0.0.0/8",
+		},
 	})
 }
 
@@ -289,6 +302,36 @@ func (s *unitprovisionerSuite) TestWatchPodSpec(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *unitprovisionerSuite) TestWatchProxySettings(c *gc.C) {
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchProxySettings")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "FAIL"},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	})
+	watcher, err := client.WatchProxySettings()
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestWatchProxySettingsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.WatchProxySettings()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitprovisionerSuite) TestApplicationConfig(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
@@ -339,7 +382,7 @@ func (s *unitprovisionerSuite) TestUpdateUnits(c *gc.C) {
 		}
 		return nil
 	})
-	err := client.UpdateUnits(params.UpdateApplicationUnits{
+	unitErrors, err := client.UpdateUnits(params.UpdateApplicationUnits{
 		ApplicationTag: names.NewApplicationTag("app").String(),
 		Units: []params.ApplicationUnitParams{
 			{ProviderId: "uuid", UnitTag: "unit-gitlab-0", Address: "address", Ports: []string{"port"},
@@ -347,6 +390,7 @@ func (s *unitprovisionerSuite) TestUpdateUnits(c *gc.C) {
 		},
 	})
 	c.Check(err, jc.ErrorIsNil)
+	c.Check(unitErrors, gc.IsNil)
 	c.Check(called, jc.IsTrue)
 }
 
@@ -360,7 +404,7 @@ func (s *unitprovisionerSuite) TestUpdateUnitsCount(c *gc.C) {
 		}
 		return nil
 	})
-	err := client.UpdateUnits(params.UpdateApplicationUnits{
+	_, err := client.UpdateUnits(params.UpdateApplicationUnits{
 		ApplicationTag: names.NewApplicationTag("app").String(),
 		Units: []params.ApplicationUnitParams{
 			{ProviderId: "uuid", Address: "address"},
@@ -369,6 +413,105 @@ func (s *unitprovisionerSuite) TestUpdateUnitsCount(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
 }
 
+func (s *unitprovisionerSuite) TestUpdateUnitsV1Forbidden(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Code: params.CodeForbidden, Message: "stale"}}},
+		}
+		return nil
+	})
+	unitErrors, err := client.UpdateUnits(params.UpdateApplicationUnits{
+		ApplicationTag: names.NewApplicationTag("app").String(),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(unitErrors, gc.IsNil)
+}
+
+func (s *unitprovisionerSuite) TestUpdateUnitsPerUnitResults(c *gc.C) {
+	var called bool
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(version, gc.Equals, 2)
+			c.Assert(request, gc.Equals, "UpdateApplicationsUnits")
+			c.Assert(result, gc.FitsTypeOf, &params.UpdateApplicationUnitResults{})
+			*(result.(*params.UpdateApplicationUnitResults)) = params.UpdateApplicationUnitResults{
+				Results: []params.UpdateApplicationUnitResult{{
+					Units: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	})
+	unitErrors, err := client.UpdateUnits(params.UpdateApplicationUnits{
+		ApplicationTag: names.NewApplicationTag("app").String(),
+		Units: []params.ApplicationUnitParams{
+			{ProviderId: "uuid"}, {ProviderId: "another-uuid"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(unitErrors, gc.HasLen, 2)
+	c.Check(unitErrors[0], jc.ErrorIsNil)
+	c.Check(unitErrors[1], gc.ErrorMatches, "boom")
+}
+
+func (s *unitprovisionerSuite) TestUpdateUnitsApplicationError(c *gc.C) {
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.UpdateApplicationUnitResults)) = params.UpdateApplicationUnitResults{
+				Results: []params.UpdateApplicationUnitResult{{
+					Error: &params.Error{Code: params.CodeNotFound, Message: "app not found"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	})
+	_, err := client.UpdateUnits(params.UpdateApplicationUnits{
+		ApplicationTag: names.NewApplicationTag("app").String(),
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *unitprovisionerSuite) TestCleanupOrphanedStorage(c *gc.C) {
+	maxWait := time.Minute
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(version, gc.Equals, 3)
+			c.Assert(request, gc.Equals, "CleanupOrphanedStorage")
+			c.Assert(a, jc.DeepEquals, params.CleanupOrphanedStorageArgs{
+				Args: []params.CleanupOrphanedStorageArg{{
+					ApplicationTag: "application-gitlab",
+					Force:          true,
+					MaxWait:        &maxWait,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.CleanupOrphanedStorageResults{})
+			*(result.(*params.CleanupOrphanedStorageResults)) = params.CleanupOrphanedStorageResults{
+				Results: []params.CleanupOrphanedStorageResult{{
+					Filesystems: []string{"filesystem-gitlab-0-0"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	})
+	destroyed, err := client.CleanupOrphanedStorage("gitlab", true, &maxWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyed, jc.DeepEquals, []names.FilesystemTag{names.NewFilesystemTag("gitlab/0/0")})
+}
+
+func (s *unitprovisionerSuite) TestCleanupOrphanedStorageNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.CleanupOrphanedStorage("gitlab", false, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitprovisionerSuite) TestUpdateApplicationService(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
@@ -62,6 +62,23 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 	return &result, nil
 }
 
+// StatusSummary returns aggregate counts of the entities in the model,
+// without gathering the status of each of them. A NotSupported error
+// is returned if the controller cannot provide a summary.
+func (c *Client) StatusSummary() (*params.ModelStatusSummary, error) {
+	var result params.FullStatus
+	p := params.StatusParams{SummaryOnly: true}
+	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+		return nil, err
+	}
+	// Older controllers ignore the summary-only flag
+	// and do not return a summary.
+	if result.Summary == nil {
+		return nil, errors.NotSupportedf("model status summary")
+	}
+	return result.Summary, nil
+}
+
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
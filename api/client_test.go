@@ -613,16 +613,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:  []string{"a", "b"},
+		IncludeModule:  []string{"c", "d"},
+		ExcludeEntity:  []string{"e", "f"},
+		ExcludeModule:  []string{"g", "h"},
+		Limit:          100,
+		Backlog:        200,
+		Level:          loggo.ERROR,
+		Replay:         true,
+		NoTail:         true,
+		StartTime:      time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:        time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+		IncludeMessage: []string{"timed out"},
+		ExcludeMessage: []string{"retrying"},
+		LimitBytes:     4096,
 	}
 
 	client := s.APIState.Client()
@@ -634,16 +638,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":  params.IncludeEntity,
+		"includeModule":  params.IncludeModule,
+		"excludeEntity":  params.ExcludeEntity,
+		"excludeModule":  params.ExcludeModule,
+		"maxLines":       {"100"},
+		"backlog":        {"200"},
+		"level":          {"ERROR"},
+		"replay":         {"true"},
+		"noTail":         {"true"},
+		"startTime":      {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":        {"2016-11-30T12:48:00Z"},
+		"includeMessage": {"timed out"},
+		"excludeMessage": {"retrying"},
+		"maxBytes":       {"4096"},
 	})
 }
 
@@ -871,3 +879,40 @@ func (s *IsolatedClientSuite) TestFindAllErrorsOnOlderController(c *gc.C) {
 	_, err := client.FindTools(0, 0, "", "", "proposed")
 	c.Assert(err, gc.ErrorMatches, "passing agent-stream not supported by the controller")
 }
+
+func (s *IsolatedClientSuite) TestStatusSummary(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "FullStatus")
+			c.Check(arg, jc.DeepEquals, params.StatusParams{SummaryOnly: true})
+			*(result.(*params.FullStatus)) = params.FullStatus{
+				Summary: &params.ModelStatusSummary{
+					UnitCount:       2,
+					UnitAgentStatus: map[string]int{"idle": 2},
+				},
+			}
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+	summary, err := client.StatusSummary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summary, jc.DeepEquals, &params.ModelStatusSummary{
+		UnitCount:       2,
+		UnitAgentStatus: map[string]int{"idle": 2},
+	})
+}
+
+func (s *IsolatedClientSuite) TestStatusSummaryNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+	_, err := client.StatusSummary()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
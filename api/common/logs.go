@@ -53,14 +53,29 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, limits the records returned to those with a
+	// log time before EndTime.
+	EndTime time.Time
+	// IncludeMessage lists regular expressions, matched by the server,
+	// that log messages must match one of to be included.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions, matched by the server,
+	// that exclude any log messages matching them.
+	ExcludeMessage []string
+	// LimitBytes, if non-zero, limits the total size of the log
+	// messages returned. Once the next message would exceed it, the
+	// socket is closed.
+	LimitBytes uint64
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,6 +95,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.LimitBytes > 0 {
+		attrs.Set("maxBytes", fmt.Sprint(args.LimitBytes))
+	}
 	return attrs
 }
 
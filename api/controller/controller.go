@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
@@ -268,6 +269,7 @@ type MigrationSpec struct {
 	TargetUser            string
 	TargetPassword        string
 	TargetMacaroons       []macaroon.Slice
+	TargetUserMap         map[string]string
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -288,6 +290,9 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
+	if err := coremigration.ValidateUserMap(s.TargetUserMap); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -318,6 +323,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				AuthTag:         names.NewUserTag(spec.TargetUser).String(),
 				Password:        spec.TargetPassword,
 				Macaroons:       macsJSON,
+				UserMap:         spec.TargetUserMap,
 			},
 		}},
 	}
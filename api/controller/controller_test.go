@@ -89,6 +89,12 @@ func (s *Suite) TestInitiateMigrationEmptyCACert(c *gc.C) {
 	s.checkInitiateMigration(c, spec)
 }
 
+func (s *Suite) TestInitiateMigrationUserMap(c *gc.C) {
+	spec := makeSpec()
+	spec.TargetUserMap = map[string]string{"bob@external": "bob"}
+	s.checkInitiateMigration(c, spec)
+}
+
 func (s *Suite) checkInitiateMigration(c *gc.C, spec controller.MigrationSpec) {
 	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
@@ -123,6 +129,7 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 				AuthTag:         names.NewUserTag(spec.TargetUser).String(),
 				Password:        spec.TargetPassword,
 				Macaroons:       string(macsJSON),
+				UserMap:         spec.TargetUserMap,
 			},
 		}},
 	}
@@ -171,6 +178,16 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestInitiateMigrationUserMapValidationError(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.TargetUserMap = map[string]string{"bob": "not valid"}
+	id, err := client.InitiateMigration(spec)
+	c.Check(id, gc.Equals, "")
+	c.Check(err, gc.ErrorMatches, `client-side validation failed: target user "not valid" in UserMap not valid`)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
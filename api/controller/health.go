@@ -0,0 +1,26 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ControllerHealth returns a summary of the health of the controller's
+// mongo replica set, raft cluster and API servers.
+func (c *Client) ControllerHealth() (params.ControllerHealthResult, error) {
+	var result params.ControllerHealthResult
+	if c.BestAPIVersion() < 9 {
+		return result, errors.NotSupportedf("ControllerHealth not supported by this version of Juju")
+	}
+	if err := c.facade.FacadeCall("ControllerHealth", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result, errors.Trace(result.Error)
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestControllerHealthPriorV9(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "ControllerHealth not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestControllerHealth(c *gc.C) {
+	expected := params.ControllerHealthResult{
+		APIServers: []params.APIServerHealth{{
+			MachineId:    "0",
+			AgentVersion: "2.7.0",
+			Reporting:    true,
+		}},
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			MachineId: "0",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ControllerHealth")
+			c.Check(arg, gc.IsNil)
+			c.Check(result, gc.FitsTypeOf, &params.ControllerHealthResult{})
+			*(result.(*params.ControllerHealthResult)) = expected
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *Suite) TestControllerHealthResultError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			result.(*params.ControllerHealthResult).Error = &params.Error{Message: "boom"}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
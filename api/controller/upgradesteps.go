@@ -0,0 +1,24 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// UpgradeStepsProgress returns the progress of the upgrade steps run
+// by each agent in the controller model while upgrading to the model's
+// agent version.
+func (c *Client) UpgradeStepsProgress() (params.UpgradeStepsProgressResult, error) {
+	var result params.UpgradeStepsProgressResult
+	if c.BestAPIVersion() < 10 {
+		return result, errors.NotSupportedf("UpgradeStepsProgress not supported by this version of Juju")
+	}
+	if err := c.facade.FacadeCall("UpgradeStepsProgress", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestUpgradeStepsProgressPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.UpgradeStepsProgress()
+	c.Assert(err, gc.ErrorMatches, "UpgradeStepsProgress not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestUpgradeStepsProgress(c *gc.C) {
+	expected := params.UpgradeStepsProgressResult{
+		Version: version.MustParse("2.7.1"),
+		Steps: []params.UpgradeStepProgress{{
+			Tag:    "machine-0",
+			Step:   "add model-uuid to upgrade steps",
+			Status: "running",
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpgradeStepsProgress")
+			c.Check(arg, gc.IsNil)
+			c.Check(result, gc.FitsTypeOf, &params.UpgradeStepsProgressResult{})
+			*(result.(*params.UpgradeStepsProgressResult)) = expected
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.UpgradeStepsProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
@@ -0,0 +1,28 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// VerifyModels checks every model in the controller for dangling
+// references between documents. If fix is true, issues of the given
+// kinds (or all kinds, if none are given) are repaired.
+func (c *Client) VerifyModels(fix bool, fixKinds ...string) ([]params.VerifyModelResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("VerifyModels not supported by this version of Juju")
+	}
+	args := params.VerifyModelsArgs{
+		Fix:      fix,
+		FixKinds: fixKinds,
+	}
+	var results params.VerifyModelsResults
+	if err := c.facade.FacadeCall("VerifyModels", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
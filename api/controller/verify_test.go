@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestVerifyModelsPriorV8(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.VerifyModels(false)
+	c.Assert(err, gc.ErrorMatches, "VerifyModels not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestVerifyModelsCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.VerifyModels(false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestVerifyModels(c *gc.C) {
+	expected := []params.VerifyModelResult{{
+		ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Issues: []params.IntegrityIssue{{
+			Kind:      "unit-missing-machine",
+			Entity:    "mysql/0",
+			Reference: "0",
+			Repaired:  true,
+		}},
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VerifyModels")
+			c.Check(arg, jc.DeepEquals, params.VerifyModelsArgs{
+				Fix:      true,
+				FixKinds: []string{"unit-missing-machine"},
+			})
+			c.Check(result, gc.FitsTypeOf, &params.VerifyModelsResults{})
+
+			out := result.(*params.VerifyModelsResults)
+			out.Results = expected
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	results, err := client.VerifyModels(true, "unit-missing-machine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
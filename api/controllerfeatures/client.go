@@ -0,0 +1,52 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerfeatures provides access to the feature flags
+// enabled on a controller.
+package controllerfeatures
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the controller features API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the controller
+// features API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ControllerFeatures")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Features returns the feature flags enabled on the controller, and
+// the recorded changes to them, most recent first.
+func (c *Client) Features() (params.ControllerFeaturesResult, error) {
+	var result params.ControllerFeaturesResult
+	err := c.facade.FacadeCall("Features", nil, &result)
+	return result, errors.Trace(err)
+}
+
+// SetFeatures enables or disables each of the named feature flags.
+func (c *Client) SetFeatures(enabled bool, features ...string) error {
+	args := params.SetControllerFeatureArgs{
+		Args: make([]params.SetControllerFeatureArg, len(features)),
+	}
+	for i, feature := range features {
+		args.Args[i] = params.SetControllerFeatureArg{
+			Feature: feature,
+			Enabled: enabled,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetFeatures", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerfeatures_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllerfeatures"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type controllerFeaturesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&controllerFeaturesSuite{})
+
+func (s *controllerFeaturesSuite) TestFeatures(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerFeatures")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Features")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ControllerFeaturesResult{})
+		*(result.(*params.ControllerFeaturesResult)) = params.ControllerFeaturesResult{
+			Features: []string{"legacy-leases"},
+		}
+		return nil
+	})
+	client := controllerfeatures.NewClient(apiCaller)
+	result, err := client.Features()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Features, jc.DeepEquals, []string{"legacy-leases"})
+}
+
+func (s *controllerFeaturesSuite) TestSetFeatures(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ControllerFeatures")
+		c.Check(request, gc.Equals, "SetFeatures")
+		c.Check(arg, jc.DeepEquals, params.SetControllerFeatureArgs{Args: []params.SetControllerFeatureArg{
+			{Feature: "cmr-aware-bundles", Enabled: true},
+			{Feature: "Bad", Enabled: true},
+		}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `feature name "Bad" not valid`}},
+		}}
+		return nil
+	})
+	client := controllerfeatures.NewClient(apiCaller)
+	err := client.SetFeatures(true, "cmr-aware-bundles", "Bad")
+	c.Assert(err, gc.ErrorMatches, `feature name "Bad" not valid`)
+}
@@ -4,11 +4,15 @@
 package deployer
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/watcher"
 )
 
 const deployerFacade = "Deployer"
@@ -59,3 +63,54 @@ func (st *State) ConnectionInfo() (result params.DeployerConnectionValues, err e
 	err = st.facade.FacadeCall("ConnectionInfo", nil, &result)
 	return result, err
 }
+
+// UnitConstraints returns the constraints that limit the resources
+// used by the unit with the given tag: the current constraints of its
+// application, combined with the model constraints. It returns a
+// NotSupported error if the controller is too old to report them.
+func (st *State) UnitConstraints(tag names.UnitTag) (constraints.Value, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return constraints.Value{}, errors.NotSupportedf("unit constraints")
+	}
+	var results params.ConstraintsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("Constraints", args, &results)
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return constraints.Value{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return constraints.Value{}, result.Error
+	}
+	return result.Constraints, nil
+}
+
+// WatchUnitConstraints returns a NotifyWatcher that notifies of
+// changes to the constraints returned by UnitConstraints. It returns
+// a NotSupported error if the controller is too old to watch them.
+func (st *State) WatchUnitConstraints(tag names.UnitTag) (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("watching unit constraints")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := st.facade.FacadeCall("WatchConstraints", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
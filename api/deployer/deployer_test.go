@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/juju/testing"
@@ -222,6 +223,38 @@ func (s *deployerSuite) TestUnitSetPassword(c *gc.C) {
 	c.Assert(s.subordinate.PasswordValid("phony-12345678901234567890"), jc.IsTrue)
 }
 
+func (s *deployerSuite) TestUnitConstraints(c *gc.C) {
+	err := s.app0.SetConstraints(constraints.MustParse("cpu-quota=50 mem-limit=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := s.st.UnitConstraints(s.principal.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("cpu-quota=50 mem-limit=1G"))
+
+	cons, err = s.st.UnitConstraints(s.subordinate.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.Value{})
+
+	_, err = s.st.UnitConstraints(names.NewUnitTag("mysql/42"))
+	s.assertUnauthorized(c, err)
+}
+
+func (s *deployerSuite) TestWatchUnitConstraints(c *gc.C) {
+	w, err := s.st.WatchUnitConstraints(s.principal.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.app0.SetConstraints(constraints.MustParse("cpu-quota=50"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	_, err = s.st.WatchUnitConstraints(names.NewUnitTag("mysql/42"))
+	s.assertUnauthorized(c, err)
+}
+
 func (s *deployerSuite) TestUnitSetStatus(c *gc.C) {
 	unit, err := s.st.Unit(s.principal.Tag().(names.UnitTag))
 	c.Assert(err, jc.ErrorIsNil)
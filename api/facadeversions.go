@@ -11,15 +11,16 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       3,
+	"Action":                       4,
+	"ActionNotifier":               1,
 	"ActionPruner":                 1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  10,
-	"ApplicationOffers":            2,
+	"Application":                  13,
+	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
 	"Block":                        2,
@@ -27,20 +28,21 @@ var facadeVersions = map[string]int{
 	"CAASAgent":                    1,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
-	"CAASOperatorProvisioner":      1,
+	"CAASOperatorProvisioner":      2,
 	"CAASOperatorUpgrader":         1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          4,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        5,
-	"Controller":                   7,
+	"Controller":                   10,
+	"ControllerFeatures":           1,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
-	"Deployer":                     1,
+	"Deployer":                     3,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
@@ -48,23 +50,25 @@ var facadeVersions = map[string]int{
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   5,
 	"FirewallRules":                1,
+	"HelpTopics":                   1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
-	"InstancePoller":               3,
+	"InstancePoller":               5,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
+	"Labels":                       2,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               6,
+	"MachineManager":               7,
 	"MachineUndertaker":            1,
-	"Machiner":                     1,
+	"Machiner":                     2,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
 	"MetricsDebug":                 2,
@@ -76,7 +80,7 @@ var facadeVersions = map[string]int{
 	"MigrationTarget":              1,
 	"ModelConfig":                  2,
 	"ModelGeneration":              1,
-	"ModelManager":                 7,
+	"ModelManager":                 8,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
@@ -88,7 +92,7 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              1,
+	"RemoteRelations":              2,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
@@ -97,17 +101,18 @@ var facadeVersions = map[string]int{
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
-	"StorageProvisioner":           4,
+	"Storage":                      8,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       12,
+	"Uniter":                       16,
+	"Units":                        1,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
-	"UpgradeSteps":                 1,
-	"UserManager":                  2,
+	"UpgradeSteps":                 2,
+	"UserManager":                  3,
 	"VolumeAttachmentsWatcher":     2,
 	"VolumeAttachmentPlansWatcher": 1,
 }
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package helptopics provides access to the help topics
+// served by the controller.
+package helptopics
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the help topics API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the help topics API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "HelpTopics")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+func (c *Client) checkSupported() error {
+	if c.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("help topics on this controller")
+	}
+	return nil
+}
+
+// ListTopics returns the names and summaries of the help topics
+// served by the controller, along with the controller's version.
+func (c *Client) ListTopics() (params.HelpTopicList, error) {
+	if err := c.checkSupported(); err != nil {
+		return params.HelpTopicList{}, err
+	}
+	var result params.HelpTopicList
+	if err := c.facade.FacadeCall("ListTopics", nil, &result); err != nil {
+		return params.HelpTopicList{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Topic returns the named help topic, along with the
+// controller's version.
+func (c *Client) Topic(name string) (params.HelpTopic, string, error) {
+	if err := c.checkSupported(); err != nil {
+		return params.HelpTopic{}, "", err
+	}
+	args := params.HelpTopicNames{Names: []string{name}}
+	var results params.HelpTopicResults
+	if err := c.facade.FacadeCall("Topics", args, &results); err != nil {
+		return params.HelpTopic{}, "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.HelpTopic{}, "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.HelpTopic{}, "", result.Error
+	}
+	return *result.Result, results.Version, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/helptopics"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type helpTopicsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&helpTopicsSuite{})
+
+func newClient(f basetesting.APICallerFunc) *helptopics.Client {
+	return helptopics.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 1})
+}
+
+func (s *helpTopicsSuite) TestListTopics(c *gc.C) {
+	expected := params.HelpTopicList{
+		Version: "2.6.1",
+		Topics:  []params.HelpTopic{{Name: "constraints", Short: "Constraints"}},
+	}
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HelpTopics")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListTopics")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.HelpTopicList)) = expected
+		return nil
+	})
+	result, err := client.ListTopics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *helpTopicsSuite) TestTopic(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HelpTopics")
+		c.Check(request, gc.Equals, "Topics")
+		c.Check(arg, jc.DeepEquals, params.HelpTopicNames{Names: []string{"constraints"}})
+		*(result.(*params.HelpTopicResults)) = params.HelpTopicResults{
+			Version: "2.6.1",
+			Results: []params.HelpTopicResult{{
+				Result: &params.HelpTopic{Name: "constraints", Short: "Constraints", Doc: "arch\n"},
+			}},
+		}
+		return nil
+	})
+	topic, version, err := client.Topic("constraints")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(topic, jc.DeepEquals, params.HelpTopic{Name: "constraints", Short: "Constraints", Doc: "arch\n"})
+	c.Assert(version, gc.Equals, "2.6.1")
+}
+
+func (s *helpTopicsSuite) TestTopicNotFound(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HelpTopicResults)) = params.HelpTopicResults{
+			Results: []params.HelpTopicResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: `help topic "spam" not found`},
+			}},
+		}
+		return nil
+	})
+	_, _, err := client.Topic("spam")
+	c.Assert(err, gc.ErrorMatches, `help topic "spam" not found`)
+	c.Assert(params.IsCodeNotFound(err), jc.IsTrue)
+}
+
+func (s *helpTopicsSuite) TestNotSupported(c *gc.C) {
+	client := helptopics.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	))
+	_, err := client.ListTopics()
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+	_, _, err = client.Topic("constraints")
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+}
@@ -96,6 +96,27 @@ func (m *Machine) IsManual() (bool, error) {
 	return result.Result, nil
 }
 
+// Series returns the series of the machine.
+func (m *Machine) Series() (string, error) {
+	var results params.StringResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("Series", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		err := errors.Errorf("expected 1 result, got %d", len(results.Results))
+		return "", err
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // InstanceId returns the machine's instance id.
 func (m *Machine) InstanceId() (instance.Id, error) {
 	var results params.StringResults
@@ -188,3 +209,22 @@ func (m *Machine) SetProviderAddresses(addrs ...network.Address) error {
 	}
 	return result.OneError()
 }
+
+// SetInstanceUtilization records the resource utilization of the
+// machine's instance, as reported by the provider.
+func (m *Machine) SetInstanceUtilization(utilization instance.Utilization) error {
+	var result params.ErrorResults
+	args := params.SetMachinesUtilization{
+		Machines: []params.SetMachineUtilization{{
+			Tag: m.tag.String(),
+			Utilization: params.MachineUtilization{
+				CPUPercent:    utilization.CPUPercent,
+				MemoryPercent: utilization.MemoryPercent,
+			},
+		}}}
+	err := m.facade.FacadeCall("SetInstanceUtilization", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
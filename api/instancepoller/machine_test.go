@@ -72,6 +72,13 @@ var machineErrorTests = []struct {
 		return err
 	},
 	resultsRef: params.BoolResults{},
+}, {
+	method: "Series",
+	wrapper: func(m *instancepoller.Machine) error {
+		_, err := m.Series()
+		return err
+	},
+	resultsRef: params.StringResults{},
 }, {
 	method: "InstanceId",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -112,6 +119,12 @@ var machineErrorTests = []struct {
 		return m.SetProviderAddresses()
 	},
 	resultsRef: params.ErrorResults{},
+}, {
+	method: "SetInstanceUtilization",
+	wrapper: func(m *instancepoller.Machine) error {
+		return m.SetInstanceUtilization(instance.Utilization{})
+	},
+	resultsRef: params.ErrorResults{},
 }}
 
 func (s *MachineSuite) TestClientError(c *gc.C) {
@@ -188,6 +201,18 @@ func (s *MachineSuite) TestIsManualSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestSeriesSuccess(c *gc.C) {
+	results := params.StringResults{
+		Results: []params.StringResult{{Result: "win2016"}},
+	}
+	apiCaller := successAPICaller(c, "Series", entitiesArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	series, err := machine.Series()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(series, gc.Equals, "win2016")
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestInstanceIdSuccess(c *gc.C) {
 	results := params.StringResults{
 		Results: []params.StringResult{{Result: "i-foo"}},
@@ -261,6 +286,23 @@ func (s *MachineSuite) TestSetProviderAddressesSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestSetInstanceUtilizationSuccess(c *gc.C) {
+	cpu := 42.5
+	expectArgs := params.SetMachinesUtilization{
+		Machines: []params.SetMachineUtilization{{
+			Tag:         "machine-42",
+			Utilization: params.MachineUtilization{CPUPercent: &cpu},
+		}}}
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "SetInstanceUtilization", expectArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
+	err := machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &cpu})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) CheckClientError(c *gc.C, wf methodWrapper) {
 	apiCaller := clientErrorAPICaller(c, "", nil)
 	machine := instancepoller.NewMachine(apiCaller, s.tag, params.Alive)
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 )
 
@@ -179,6 +180,11 @@ type DialOpts struct {
 	// automatically verified. If the callback returns a non-nil error then
 	// the connection attempt will be aborted.
 	VerifyCA func(host, endpoint string, caCert *x509.Certificate) error
+
+	// WarningHandler is an optional callback that is invoked with any
+	// warnings, such as deprecation warnings, attached to the replies
+	// to API requests made on the connection.
+	WarningHandler rpc.WarningHandler
 }
 
 // IPAddrResolver implements a resolved from host name to the
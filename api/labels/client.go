@@ -0,0 +1,71 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package labels provides access to the freeform labels
+// attached to machines and units.
+package labels
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the labels API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the labels API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Labels")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Labels returns the labels attached to the machine or unit.
+func (c *Client) Labels(tag names.Tag) (map[string]string, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.LabelsResults
+	if err := c.facade.FacadeCall("Labels", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Labels, nil
+}
+
+// UpdateLabels sets and removes labels of the machine or unit.
+func (c *Client) UpdateLabels(tag names.Tag, set map[string]string, remove []string) error {
+	args := params.UpdateLabelsArgs{Args: []params.UpdateLabelsArg{{
+		Tag:    tag.String(),
+		Set:    set,
+		Remove: remove,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateLabels", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Select returns the ids of the machines, and the names of the units,
+// whose labels match the selector. It returns a NotSupported error if
+// the controller cannot select machines and units by their labels.
+func (c *Client) Select(selector string) (machines, units []string, _ error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, nil, errors.NotSupportedf("selecting by labels")
+	}
+	args := params.LabelSelectorArg{Selector: selector}
+	var result params.LabelSelectResult
+	if err := c.facade.FacadeCall("Select", args, &result); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return result.Machines, result.Units, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package labels_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/labels"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type labelsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (s *labelsSuite) TestLabels(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Labels")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Labels")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.LabelsResults{})
+		*(result.(*params.LabelsResults)) = params.LabelsResults{Results: []params.LabelsResult{{
+			Labels: map[string]string{"env": "canary"},
+		}}}
+		return nil
+	})
+	client := labels.NewClient(apiCaller)
+	result, err := client.Labels(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, map[string]string{"env": "canary"})
+}
+
+func (s *labelsSuite) TestLabelsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.LabelsResults)) = params.LabelsResults{Results: []params.LabelsResult{{
+			Error: &params.Error{Message: "permission denied"},
+		}}}
+		return nil
+	})
+	client := labels.NewClient(apiCaller)
+	_, err := client.Labels(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *labelsSuite) TestUpdateLabels(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Labels")
+		c.Check(request, gc.Equals, "UpdateLabels")
+		c.Check(arg, jc.DeepEquals, params.UpdateLabelsArgs{Args: []params.UpdateLabelsArg{{
+			Tag:    "machine-0",
+			Set:    map[string]string{"env": "canary"},
+			Remove: []string{"tier"},
+		}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: `label key "Tier" not valid`},
+		}}}
+		return nil
+	})
+	client := labels.NewClient(apiCaller)
+	err := client.UpdateLabels(names.NewMachineTag("0"), map[string]string{"env": "canary"}, []string{"tier"})
+	c.Assert(err, gc.ErrorMatches, `label key "Tier" not valid`)
+}
+
+func (s *labelsSuite) TestSelect(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Labels")
+			c.Check(request, gc.Equals, "Select")
+			c.Check(arg, jc.DeepEquals, params.LabelSelectorArg{Selector: "env=canary"})
+			c.Assert(result, gc.FitsTypeOf, &params.LabelSelectResult{})
+			*(result.(*params.LabelSelectResult)) = params.LabelSelectResult{
+				Machines: []string{"0"},
+				Units:    []string{"mysql/0"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := labels.NewClient(apiCaller)
+	machines, units, err := client.Select("env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []string{"0"})
+	c.Assert(units, jc.DeepEquals, []string{"mysql/0"})
+}
+
+func (s *labelsSuite) TestSelectNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := labels.NewClient(apiCaller)
+	_, _, err := client.Select("env=canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
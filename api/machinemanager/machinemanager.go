@@ -259,3 +259,41 @@ func (client *Client) GetUpgradeSeriesMessages(machineName, watcherId string) ([
 
 	return result.Result, nil
 }
+
+// NetworkStatus returns the FAN networking status last reported by each
+// of the specified machines.
+func (client *Client) NetworkStatus(machines ...string) ([]params.MachineNetworkStatusResult, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("NetworkStatus")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.MachineNetworkStatusResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.MachineNetworkStatusResults
+		if err := client.facade.FacadeCall("NetworkStatus", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
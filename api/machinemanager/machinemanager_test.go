@@ -248,3 +248,49 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNilWait(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expected)
 }
+
+func (s *MachinemanagerSuite) TestNetworkStatus(c *gc.C) {
+	expectedResults := []params.MachineNetworkStatusResult{{
+		Error: &params.Error{Message: `machine ID "!" not valid`},
+	}, {
+		Result: &params.MachineNetworkStatus{
+			FanBridges: []params.FanBridgeStatus{{
+				Name:     "fan-252",
+				Underlay: "10.0.0.0/16",
+				Overlay:  "252.0.0.0/8",
+			}},
+		},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "NetworkStatus")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-0-lxd-1"}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.MachineNetworkStatusResults{})
+				out := response.(*params.MachineNetworkStatusResults)
+				*out = params.MachineNetworkStatusResults{Results: expectedResults[1:]}
+				return nil
+			}),
+		})
+	results, err := client.NetworkStatus("!", "0", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *MachinemanagerSuite) TestNetworkStatusNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			}),
+		})
+	_, err := client.NetworkStatus("0")
+	c.Assert(err, gc.ErrorMatches, "NetworkStatus not supported")
+}
@@ -71,6 +71,24 @@ func (m *Machine) SetMachineAddresses(addresses []network.Address) error {
 	return result.OneError()
 }
 
+// SetNetworkStatus records the state of the machine's FAN networking.
+func (m *Machine) SetNetworkStatus(status params.MachineNetworkStatus) error {
+	if m.st.facade.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("SetNetworkStatus() (need V2+)")
+	}
+	var result params.ErrorResults
+	args := params.SetMachinesNetworkStatus{
+		Args: []params.SetMachineNetworkStatus{
+			{Tag: m.Tag().String(), Status: status},
+		},
+	}
+	err := m.st.facade.FacadeCall("SetNetworkStatus", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // EnsureDead sets the machine lifecycle to Dead if it is Alive or
 // Dying. It does nothing otherwise.
 func (m *Machine) EnsureDead() error {
@@ -156,6 +156,31 @@ func (s *machinerSuite) TestSetMachineAddresses(c *gc.C) {
 	c.Assert(s.machine.MachineAddresses(), jc.DeepEquals, expectAddresses)
 }
 
+func (s *machinerSuite) TestSetNetworkStatus(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetNetworkStatus(params.MachineNetworkStatus{
+		FanBridges: []params.FanBridgeStatus{{
+			Name:     "fan-252",
+			Underlay: "10.0.0.0/16",
+			Overlay:  "252.0.0.0/8",
+			Present:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.machine.NetworkStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.FanBridges, jc.DeepEquals, []state.FanBridgeStatus{{
+		Name:     "fan-252",
+		Underlay: "10.0.0.0/16",
+		Overlay:  "252.0.0.0/8",
+		Present:  true,
+	}})
+	c.Assert(status.FanErrors, gc.HasLen, 0)
+}
+
 func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
 	c.Assert(err, jc.ErrorIsNil)
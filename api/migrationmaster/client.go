@@ -109,6 +109,7 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			AuthTag:       authTag,
 			Password:      target.Password,
 			Macaroons:     macs,
+			UserMap:       target.UserMap,
 		},
 	}, nil
 }
@@ -141,12 +142,21 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	if err != nil {
 		return migration.ModelInfo{}, errors.Trace(err)
 	}
+	var users []names.UserTag
+	for _, userTag := range info.UserTags {
+		user, err := names.ParseUserTag(userTag)
+		if err != nil {
+			return migration.ModelInfo{}, errors.Trace(err)
+		}
+		users = append(users, user)
+	}
 	return migration.ModelInfo{
 		UUID:                   info.UUID,
 		Name:                   info.Name,
 		Owner:                  owner,
 		AgentVersion:           info.AgentVersion,
 		ControllerAgentVersion: info.ControllerAgentVersion,
+		Users:                  users,
 	}, nil
 }
 
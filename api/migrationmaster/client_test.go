@@ -93,6 +93,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 					AuthTag:       names.NewUserTag("admin").String(),
 					Password:      "secret",
 					Macaroons:     string(macsJSON),
+					UserMap:       map[string]string{"bob@external": "bob"},
 				},
 			},
 			MigrationId:      "id",
@@ -120,6 +121,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			CACert:        "cert",
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
+			UserMap:       map[string]string{"bob@external": "bob"},
 		},
 	})
 }
@@ -183,6 +185,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 			OwnerTag:               owner.String(),
 			AgentVersion:           version.MustParse("1.2.3"),
 			ControllerAgentVersion: version.MustParse("1.2.4"),
+			UserTags:               []string{owner.String(), "user-bob"},
 		}
 		return nil
 	})
@@ -198,6 +201,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 		Owner:                  owner,
 		AgentVersion:           version.MustParse("1.2.3"),
 		ControllerAgentVersion: version.MustParse("1.2.4"),
+		Users:                  []names.UserTag{owner, names.NewUserTag("bob")},
 	})
 }
 
@@ -50,6 +50,9 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
+	for _, user := range model.Users {
+		args.UserTags = append(args.UserTags, user.String())
+	}
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
@@ -62,6 +62,7 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		Name:                   "name",
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		Users:                  []names.UserTag{ownerTag, names.NewUserTag("bob")},
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 
@@ -71,6 +72,7 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		OwnerTag:               ownerTag.String(),
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		UserTags:               []string{"user-owner", "user-bob"},
 	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
@@ -239,6 +239,9 @@ func (c *Client) ListModelSummaries(user string, all bool) ([]base.UserModelSumm
 		for pos, count := range summary.Counts {
 			summaries[i].Counts[pos] = base.EntityCount{string(count.Entity), count.Count}
 		}
+		for _, quota := range summary.Quotas {
+			summaries[i].Quotas = append(summaries[i].Quotas, base.EntityCount{string(quota.Entity), quota.Count})
+		}
 		summaries[i].Status = base.Status{
 			Status: summary.Status.Status,
 			Info:   summary.Status.Info,
@@ -602,3 +605,29 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	}
 	return out.OneError()
 }
+
+// TransferModelOwnership makes owner the new owner of the given model.
+// If the model uses a cloud credential, credential must identify a
+// credential belonging to the new owner that the model can use instead.
+func (c *Client) TransferModelOwnership(model names.ModelTag, owner names.UserTag, credential names.CloudCredentialTag) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 8 {
+		return errors.NotImplementedf("TransferModelOwnership in version %v", bestVer)
+	}
+
+	in := params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag: model.String(),
+			OwnerTag: owner.String(),
+		}},
+	}
+	if credential != (names.CloudCredentialTag{}) {
+		in.Models[0].CloudCredentialTag = credential.String()
+	}
+
+	var out params.ErrorResults
+	err := c.facade.FacadeCall("TransferModelOwnership", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
@@ -556,6 +556,28 @@ func (s *modelmanagerSuite) TestListModelSummaries(c *gc.C) {
 	c.Assert(errors.Cause(results[1].Error), gc.ErrorMatches, "model error")
 }
 
+func (s *modelmanagerSuite) TestListModelSummariesQuotas(c *gc.C) {
+	testModelInfo := createModelSummary()
+	testModelInfo.Counts = []params.ModelEntityCount{{params.Machines, 2}}
+	testModelInfo.Quotas = []params.ModelEntityCount{{params.Machines, 5}, {params.Storage, 10}}
+
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.ModelSummaryResults)
+			out.Results = []params.ModelSummaryResult{{Result: testModelInfo}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	results, err := client.ListModelSummaries("commander", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Counts, jc.DeepEquals, []base.EntityCount{{"machines", 2}})
+	c.Assert(results[0].Quotas, jc.DeepEquals, []base.EntityCount{{"machines", 5}, {"storage", 10}})
+}
+
 func (s *modelmanagerSuite) TestListModelSummariesParsingErrors(c *gc.C) {
 	badOwnerInfo := createModelSummary()
 	badOwnerInfo.OwnerTag = "owner-user"
@@ -861,3 +883,64 @@ func (s *dumpModelSuite) TestDumpModelDBError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fake error")
 	c.Assert(out, gc.IsNil)
 }
+
+func (s *modelmanagerSuite) TestTransferModelOwnership(c *gc.C) {
+	credentialTag := names.NewCloudCredentialTag("foo/bob/bar")
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "TransferModelOwnership")
+			c.Check(arg, jc.DeepEquals, params.TransferModelOwnershipParams{
+				[]params.TransferModelOwnershipParam{{
+					ModelTag:           coretesting.ModelTag.String(),
+					OwnerTag:           "user-bob",
+					CloudCredentialTag: credentialTag.String(),
+				}},
+			})
+			c.Check(result, gc.FitsTypeOf, &params.ErrorResults{})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"), credentialTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestTransferModelOwnershipFailed(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{Error: common.ServerError(errors.New("transfer error"))}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"), names.CloudCredentialTag{})
+	c.Assert(err, gc.ErrorMatches, `transfer error`)
+}
+
+func (s *modelmanagerSuite) TestTransferModelOwnershipV7(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"), names.CloudCredentialTag{})
+	c.Assert(err, gc.ErrorMatches, `TransferModelOwnership in version 7 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
@@ -295,3 +295,19 @@ func (c *Client) SetRemoteApplicationStatus(applicationName string, status statu
 	}
 	return results.OneError()
 }
+
+// ModelEgressSubnets returns the egress subnets configured for the model.
+// Controllers which do not report them return no subnets.
+func (c *Client) ModelEgressSubnets() ([]string, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, nil
+	}
+	var result params.StringsResult
+	if err := c.facade.FacadeCall("ModelEgressSubnets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
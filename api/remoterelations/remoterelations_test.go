@@ -463,3 +463,42 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationStatus(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "FAIL")
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *remoteRelationsSuite) TestModelEgressSubnets(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelEgressSubnets")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.StringsResult{})
+			*(result.(*params.StringsResult)) = params.StringsResult{
+				Result: []string{"10.0.0.0/16"},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	subnets, err := client.ModelEgressSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, jc.DeepEquals, []string{"10.0.0.0/16"})
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestModelEgressSubnetsV1(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	subnets, err := client.ModelEgressSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, gc.HasLen, 0)
+}
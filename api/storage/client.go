@@ -355,3 +355,57 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// DetectOrphanedStorage returns the volumes that exist in the cloud but
+// are unknown to the model, and the model's volumes that no longer exist
+// in the cloud.
+func (c *Client) DetectOrphanedStorage() (params.OrphanedStorageResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.OrphanedStorageResult{}, errors.New("detecting orphaned storage is not supported by this version of Juju")
+	}
+	var result params.OrphanedStorageResult
+	if err := c.facade.FacadeCall("DetectOrphanedStorage", nil, &result); err != nil {
+		return params.OrphanedStorageResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// DestroyOrphanedVolumes destroys the specified volumes, which must
+// exist in the cloud but be unknown to the model.
+func (c *Client) DestroyOrphanedVolumes(volumes []params.OrphanedVolume) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("destroying orphaned volumes is not supported by this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.DestroyOrphanedVolumesParams{Volumes: volumes}
+	if err := c.facade.FacadeCall("DestroyOrphanedVolumes", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(volumes) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(volumes), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// Resize requests that the volumes of the specified storage instances
+// be grown to the given sizes, in MiB.
+func (c *Client) Resize(storage []params.ResizeStorageInstance) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.New("resizing storage is not supported by this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.ResizeStorage{Storage: storage}
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storage) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storage), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
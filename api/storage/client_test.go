@@ -1077,3 +1077,112 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestDetectOrphanedStorage(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DetectOrphanedStorage")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.OrphanedStorageResult{})
+			*(result.(*params.OrphanedStorageResult)) = params.OrphanedStorageResult{
+				Unknown: []params.OrphanedVolume{{Provider: "ebs", ProviderId: "vol-1"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7, APICallerFunc: apiCaller})
+	result, err := storageClient.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedStorageResult{
+		Unknown: []params.OrphanedVolume{{Provider: "ebs", ProviderId: "vol-1"}},
+	})
+}
+
+func (s *storageMockSuite) TestDetectOrphanedStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6, APICallerFunc: apiCaller})
+	_, err := storageClient.DetectOrphanedStorage()
+	c.Assert(err, gc.ErrorMatches, "detecting orphaned storage is not supported by this version of Juju")
+}
+
+func (s *storageMockSuite) TestDestroyOrphanedVolumes(c *gc.C) {
+	volumes := []params.OrphanedVolume{
+		{Provider: "ebs", ProviderId: "vol-1"},
+		{Provider: "ebs", ProviderId: "vol-2"},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DestroyOrphanedVolumes")
+			c.Check(a, jc.DeepEquals, params.DestroyOrphanedVolumesParams{Volumes: volumes})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			result.(*params.ErrorResults).Results = []params.ErrorResult{
+				{},
+				{Error: &params.Error{Message: "bar"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7, APICallerFunc: apiCaller})
+	results, err := storageClient.DestroyOrphanedVolumes(volumes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "bar"}},
+	})
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	args := []params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+		{Tag: "storage-data-1", Size: 4096},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ResizeStorage")
+			c.Check(a, jc.DeepEquals, params.ResizeStorage{Storage: args})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			result.(*params.ErrorResults).Results = []params.ErrorResult{
+				{},
+				{Error: &params.Error{Message: "bar"}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 8, APICallerFunc: apiCaller})
+	results, err := storageClient.Resize(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "bar"}},
+	})
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	storageClient := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7, APICallerFunc: apiCaller})
+	_, err := storageClient.Resize([]params.ResizeStorageInstance{{Tag: "storage-data-0", Size: 2048}})
+	c.Assert(err, gc.ErrorMatches, "resizing storage is not supported by this version of Juju")
+}
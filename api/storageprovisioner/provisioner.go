@@ -88,6 +88,13 @@ func (st *State) WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchVolumes", scope)
 }
 
+// WatchVolumeResizes watches for changes to model-scoped volumes, so
+// that requests to resize them may be acted upon. The scope must be
+// the model's tag.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
 // WatchVolumes watches for lifecycle changes to volumes scoped to the
 // entity with the specified tag.
 func (st *State) WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, error) {
@@ -301,6 +308,27 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. Volumes with no pending resize request
+// have a NotFound error in their result.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -91,3 +91,14 @@ func (api *API) ListSubnets(spaceTag *names.SpaceTag, zone string) ([]params.Sub
 	}
 	return response.Results, nil
 }
+
+// AllZones returns the availability zones known by the model,
+// and whether each is available for use.
+func (api *API) AllZones() ([]params.ZoneResult, error) {
+	var response params.ZoneResults
+	err := api.facade.FacadeCall("AllZones", nil, &response)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.Results, nil
+}
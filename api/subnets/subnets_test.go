@@ -214,3 +214,31 @@ func (s *SubnetsSuite) TestListSubnetsFails(c *gc.C) {
 	var expectedResults []params.Subnet
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *SubnetsSuite) TestAllZones(c *gc.C) {
+	zones := []params.ZoneResult{
+		{Name: "zone1", Available: true},
+		{Name: "zone2"},
+	}
+	s.prepareAPICall(c, apitesting.APICall{
+		Facade:  "Subnets",
+		Method:  "AllZones",
+		Results: params.ZoneResults{Results: zones},
+	})
+	results, err := s.api.AllZones()
+	c.Assert(s.apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, zones)
+}
+
+func (s *SubnetsSuite) TestAllZonesFails(c *gc.C) {
+	s.prepareAPICall(c, apitesting.APICall{
+		Facade: "Subnets",
+		Method: "AllZones",
+		Error:  errors.New("bang"),
+	})
+	results, err := s.api.AllZones()
+	c.Assert(s.apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(err, gc.ErrorMatches, "bang")
+	c.Assert(results, gc.IsNil)
+}
@@ -35,7 +35,7 @@ func (s *cloudNativeUniterSuite) setApplicationTrust(c *gc.C, trusted bool) {
 }
 
 func (s *cloudNativeUniterSuite) TestCloudSpecErrorWhenUnauthorized(c *gc.C) {
-	result, err := s.uniter.CloudSpec()
+	result, err := s.uniter.CloudSpec("install")
 	c.Check(err, gc.ErrorMatches, "permission denied")
 	c.Check(result, gc.IsNil)
 }
@@ -43,7 +43,7 @@ func (s *cloudNativeUniterSuite) TestCloudSpecErrorWhenUnauthorized(c *gc.C) {
 func (s *cloudNativeUniterSuite) TestGetCloudSpecReturnsSpecWhenTrusted(c *gc.C) {
 	s.setApplicationTrust(c, true)
 
-	result, err := s.uniter.CloudSpec()
+	result, err := s.uniter.CloudSpec("install")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(result.Name, gc.Equals, "dummy")
 
@@ -52,4 +52,9 @@ func (s *cloudNativeUniterSuite) TestGetCloudSpecReturnsSpecWhenTrusted(c *gc.C)
 		"password": "secret",
 	}
 	c.Check(result.Credential.Attributes, gc.DeepEquals, exp)
+
+	accesses, err := s.wordpressApplication.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 1)
+	c.Check(accesses[0].Hook, gc.Equals, "install")
 }
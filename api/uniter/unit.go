@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -76,14 +78,27 @@ func (u *Unit) Refresh() error {
 
 // SetUnitStatus sets the status of the unit.
 func (u *Unit) SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
+	return u.setUnitStatus(params.EntityStatusArgs{
+		Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data,
+	})
+}
+
+// SetUnitStatusAt sets the status of the unit as it was at the
+// specified time. It is used to report status that could not be sent
+// while the controller was unreachable.
+func (u *Unit) SetUnitStatusAt(unitStatus status.Status, info string, data map[string]interface{}, since time.Time) error {
+	return u.setUnitStatus(params.EntityStatusArgs{
+		Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data, Since: &since,
+	})
+}
+
+func (u *Unit) setUnitStatus(arg params.EntityStatusArgs) error {
 	if u.st.facade.BestAPIVersion() < 2 {
 		return errors.NotImplementedf("SetUnitStatus")
 	}
 	var result params.ErrorResults
 	args := params.SetStatus{
-		Entities: []params.EntityStatusArgs{
-			{Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data},
-		},
+		Entities: []params.EntityStatusArgs{arg},
 	}
 	err := u.st.facade.FacadeCall("SetUnitStatus", args, &result)
 	if err != nil {
@@ -116,11 +131,24 @@ func (u *Unit) UnitStatus() (params.StatusResult, error) {
 
 // SetAgentStatus sets the status of the unit agent.
 func (u *Unit) SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error {
+	return u.setAgentStatus(params.EntityStatusArgs{
+		Tag: u.tag.String(), Status: agentStatus.String(), Info: info, Data: data,
+	})
+}
+
+// SetAgentStatusAt sets the status of the unit agent as it was at the
+// specified time. It is used to report status that could not be sent
+// while the controller was unreachable.
+func (u *Unit) SetAgentStatusAt(agentStatus status.Status, info string, data map[string]interface{}, since time.Time) error {
+	return u.setAgentStatus(params.EntityStatusArgs{
+		Tag: u.tag.String(), Status: agentStatus.String(), Info: info, Data: data, Since: &since,
+	})
+}
+
+func (u *Unit) setAgentStatus(arg params.EntityStatusArgs) error {
 	var result params.ErrorResults
 	args := params.SetStatus{
-		Entities: []params.EntityStatusArgs{
-			{Tag: u.tag.String(), Status: agentStatus.String(), Info: info, Data: data},
-		},
+		Entities: []params.EntityStatusArgs{arg},
 	}
 	setStatusFacadeCall := "SetAgentStatus"
 	if u.st.facade.BestAPIVersion() < 2 {
@@ -149,6 +177,90 @@ func (u *Unit) AddMetrics(metrics []params.Metric) error {
 	return result.OneError()
 }
 
+// RecordOperations adds the given operations to the unit's operation
+// history.
+func (u *Unit) RecordOperations(ops []params.UnitOperation) error {
+	if u.st.facade.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("RecordOperations")
+	}
+	var result params.ErrorResults
+	args := params.UnitOperationsArgs{
+		Args: []params.UnitOperationsArg{{
+			Tag:        u.tag.String(),
+			Operations: ops,
+		}},
+	}
+	err := u.st.facade.FacadeCall("RecordOperations", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// SetPendingHooks records the hooks that the unit's agent is waiting
+// to run, in the order it expects to run them.
+func (u *Unit) SetPendingHooks(pending []string) error {
+	if u.st.facade.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("SetPendingHooks")
+	}
+	var result params.ErrorResults
+	args := params.UnitPendingHooksArgs{
+		Args: []params.UnitPendingHooksArg{{
+			Tag:     u.tag.String(),
+			Pending: pending,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetPendingHooks", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// Labels returns the labels attached to the unit.
+func (u *Unit) Labels() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 16 {
+		return nil, errors.NotSupportedf("Labels")
+	}
+	var results params.LabelsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("Labels", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Labels, nil
+}
+
+// UpdateLabels sets and removes labels of the unit, leaving any
+// other labels unchanged.
+func (u *Unit) UpdateLabels(set map[string]string, remove []string) error {
+	if u.st.facade.BestAPIVersion() < 16 {
+		return errors.NotSupportedf("UpdateLabels")
+	}
+	var result params.ErrorResults
+	args := params.UpdateLabelsArgs{
+		Args: []params.UpdateLabelsArg{{
+			Tag:    u.tag.String(),
+			Set:    set,
+			Remove: remove,
+		}},
+	}
+	err := u.st.facade.FacadeCall("UpdateLabels", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // AddMetricsBatches makes an api call to the uniter requesting it to store metrics batches in state.
 func (u *Unit) AddMetricBatches(batches []params.MetricBatch) (map[string]error, error) {
 	p := params.MetricBatchParams{
@@ -97,6 +97,41 @@ func (s *unitSuite) TestSetAgentStatus(c *gc.C) {
 	c.Assert(unitStatusInfo.Data, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestSetAgentStatusAt(c *gc.C) {
+	// A buffered status may not replace a more recent one.
+	allocated := time.Now().Add(-2 * time.Hour)
+	err := s.wordpressUnit.Agent().SetStatus(status.StatusInfo{Status: status.Allocating, Since: &allocated})
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	err = s.apiUnit.SetAgentStatusAt(status.Executing, "running install hook", nil, since)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.wordpressUnit.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Executing)
+	c.Assert(statusInfo.Message, gc.Equals, "running install hook")
+	c.Assert(statusInfo.Since, gc.NotNil)
+	c.Assert(statusInfo.Since.Equal(since), jc.IsTrue)
+}
+
+func (s *unitSuite) TestSetUnitStatusAt(c *gc.C) {
+	waiting := time.Now().Add(-2 * time.Hour)
+	err := s.wordpressUnit.SetStatus(status.StatusInfo{Status: status.Waiting, Since: &waiting})
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	err = s.apiUnit.SetUnitStatusAt(status.Maintenance, "installing", nil, since)
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Maintenance)
+	c.Assert(statusInfo.Message, gc.Equals, "installing")
+	c.Assert(statusInfo.Since, gc.NotNil)
+	c.Assert(statusInfo.Since.Equal(since), jc.IsTrue)
+}
+
 func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	statusInfo, err := s.wordpressUnit.Status()
 	c.Assert(err, jc.ErrorIsNil)
@@ -886,6 +921,53 @@ func (s *unitSuite) TestAddMetricsResultError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error adding metrics")
 }
 
+func (s *unitSuite) TestRecordOperations(c *gc.C) {
+	started := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordOperations([]params.UnitOperation{{
+		Kind:        "run-hook",
+		Description: "run install hook",
+		Started:     started,
+		Completed:   started.Add(time.Second),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.wordpressUnit.OperationHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Kind, gc.Equals, "run-hook")
+	c.Assert(history[0].Description, gc.Equals, "run install hook")
+}
+
+func (s *unitSuite) TestSetPendingHooks(c *gc.C) {
+	err := s.apiUnit.SetPendingHooks([]string{"leader-elected", "config-changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	queue, err := s.wordpressUnit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue.Pending, jc.DeepEquals, []string{"leader-elected", "config-changed"})
+}
+
+func (s *unitSuite) TestLabels(c *gc.C) {
+	err := s.wordpressUnit.UpdateLabels(map[string]string{"env": "canary"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels, err := s.apiUnit.Labels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, map[string]string{"env": "canary"})
+}
+
+func (s *unitSuite) TestUpdateLabels(c *gc.C) {
+	err := s.wordpressUnit.UpdateLabels(map[string]string{"env": "canary", "tier": "web"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.apiUnit.UpdateLabels(map[string]string{"env": "prod"}, []string{"tier"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.Labels(), jc.DeepEquals, map[string]string{"env": "prod"})
+}
+
 func (s *unitSuite) TestMeterStatus(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "GetMeterStatus",
 		func(results interface{}) error {
@@ -509,13 +509,18 @@ func (st *State) SetPodSpec(appName string, spec string) error {
 }
 
 // CloudSpec returns the cloud spec for the model that calling unit or
-// application resides in.
+// application resides in. The controller records the access against the
+// application, along with the name of the hook which requested it.
 // If the application has not been authorised to access its cloud spec,
 // then an authorisation error will be returned.
-func (st *State) CloudSpec() (*params.CloudSpec, error) {
+func (st *State) CloudSpec(hookName string) (*params.CloudSpec, error) {
 	var result params.CloudSpecResult
 
-	err := st.facade.FacadeCall("CloudSpec", nil, &result)
+	var args interface{}
+	if st.facade.BestAPIVersion() >= 15 {
+		args = params.HookCloudSpecArgs{Hook: hookName}
+	}
+	err := st.facade.FacadeCall("CloudSpec", args, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package units
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the units API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the units API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Units")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// UnitsInfo returns information about the given units.
+func (c *Client) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	var results params.UnitInfoResults
+	if err := c.facade.FacadeCall("UnitsInfo", entities(tags), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// OperationHistory returns the recent operations run by each of the
+// given units, most recent first.
+func (c *Client) OperationHistory(tags []names.UnitTag) ([]params.UnitOperationsResult, error) {
+	var results params.UnitOperationsResults
+	if err := c.facade.FacadeCall("OperationHistory", entities(tags), &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+func entities(tags []names.UnitTag) params.Entities {
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	return args
+}
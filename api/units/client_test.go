@@ -0,0 +1,88 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package units_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/units"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type unitsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&unitsSuite{})
+
+func (s *unitsSuite) TestUnitsInfo(c *gc.C) {
+	expected := []params.UnitInfoResult{{
+		Result: &params.UnitInfo{
+			Tag:         "unit-mysql-0",
+			Application: "mysql",
+			Machine:     "0",
+			Life:        "alive",
+		},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Units")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UnitsInfo")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			result.(*params.UnitInfoResults).Results = expected
+			return nil
+		},
+	)
+	client := units.NewClient(apiCaller)
+	results, err := client.UnitsInfo([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *unitsSuite) TestOperationHistory(c *gc.C) {
+	started := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	expected := []params.UnitOperationsResult{{
+		Operations: []params.UnitOperation{{
+			Kind:        "run-hook",
+			Description: "run install hook",
+			Started:     started,
+			Completed:   started.Add(time.Second),
+		}},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Units")
+			c.Check(request, gc.Equals, "OperationHistory")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			result.(*params.UnitOperationsResults).Results = expected
+			return nil
+		},
+	)
+	client := units.NewClient(apiCaller)
+	results, err := client.OperationHistory([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *unitsSuite) TestOperationHistoryWrongResultCount(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			return nil
+		},
+	)
+	client := units.NewClient(apiCaller)
+	_, err := client.OperationHistory([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
@@ -5,6 +5,7 @@ package upgradesteps
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -44,3 +45,86 @@ func (c *Client) ResetKVMMachineModificationStatusIdle(tag names.Tag) error {
 	}
 	return nil
 }
+
+func (c *Client) checkProgressSupported() error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("upgrade step progress by this controller")
+	}
+	return nil
+}
+
+// PendingUpgradeSteps returns the names of the upgrade steps that the
+// controller can run for the machine, and that have not yet completed
+// for its upgrade to the given version.
+func (c *Client) PendingUpgradeSteps(tag names.MachineTag, vers version.Number) ([]string, error) {
+	if err := c.checkProgressSupported(); err != nil {
+		return nil, err
+	}
+	var results params.PendingUpgradeStepsResults
+	args := params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: tag.String(), Version: vers}},
+	}
+	if err := c.facade.FacadeCall("PendingUpgradeSteps", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Steps, nil
+}
+
+// RunUpgradeStep asks the controller to run the named upgrade step for
+// the machine's upgrade to the given version.
+func (c *Client) RunUpgradeStep(tag names.MachineTag, vers version.Number, step string) error {
+	if err := c.checkProgressSupported(); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	args := params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: tag.String(), Version: vers, Step: step}},
+	}
+	if err := c.facade.FacadeCall("RunUpgradeStep", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SetUpgradeStepProgress records the status of an upgrade step run by
+// the machine agent while upgrading to the given version. The status
+// is one of "running", "completed" or "failed"; the message holds any
+// error from running the step.
+func (c *Client) SetUpgradeStepProgress(tag names.MachineTag, vers version.Number, step, status, message string) error {
+	if err := c.checkProgressSupported(); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	args := params.SetUpgradeStepProgressArgs{
+		Args: []params.SetUpgradeStepProgressArg{{
+			UpgradeStepArg: params.UpgradeStepArg{Tag: tag.String(), Version: vers, Step: step},
+			Status:         status,
+			Message:        message,
+		}},
+	}
+	if err := c.facade.FacadeCall("SetUpgradeStepProgress", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WriteAgentState uploads the state that unit agents keep between runs
+// to the controller, in a single call. It is used while upgrading to
+// move unit state off the machine's disk.
+func (c *Client) WriteAgentState(args []params.SetUnitStateArg) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("writing agent state to this controller")
+	}
+	var results params.ErrorResults
+	arg := params.SetUnitStateArgs{Args: args}
+	if err := c.facade.FacadeCall("WriteAgentState", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
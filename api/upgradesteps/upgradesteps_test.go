@@ -5,7 +5,9 @@ package upgradesteps_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -52,6 +54,104 @@ func (s *upgradeStepsSuite) TestResetKVMMachineModificationStatusIdleError(c *gc
 	c.Assert(err, gc.ErrorMatches, "did not find")
 }
 
+func (s *upgradeStepsSuite) TestPendingUpgradeSteps(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	fExp := s.fCaller.EXPECT()
+	fExp.BestAPIVersion().Return(2)
+	args := params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: version.MustParse("2.7.0")}},
+	}
+	resultSource := params.PendingUpgradeStepsResults{
+		Results: []params.PendingUpgradeStepsResult{{Steps: []string{"step-a", "step-b"}}},
+	}
+	fExp.FacadeCall("PendingUpgradeSteps", args, gomock.Any()).SetArg(2, resultSource)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	steps, err := client.PendingUpgradeSteps(s.tag.(names.MachineTag), version.MustParse("2.7.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(steps, jc.DeepEquals, []string{"step-a", "step-b"})
+}
+
+func (s *upgradeStepsSuite) TestRunUpgradeStep(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	fExp := s.fCaller.EXPECT()
+	fExp.BestAPIVersion().Return(2)
+	args := params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: version.MustParse("2.7.0"), Step: "step-a"}},
+	}
+	resultSource := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+	}
+	fExp.FacadeCall("RunUpgradeStep", args, gomock.Any()).SetArg(2, resultSource)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	err := client.RunUpgradeStep(s.tag.(names.MachineTag), version.MustParse("2.7.0"), "step-a")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *upgradeStepsSuite) TestSetUpgradeStepProgress(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	fExp := s.fCaller.EXPECT()
+	fExp.BestAPIVersion().Return(2)
+	args := params.SetUpgradeStepProgressArgs{
+		Args: []params.SetUpgradeStepProgressArg{{
+			UpgradeStepArg: params.UpgradeStepArg{Tag: s.tag.String(), Version: version.MustParse("2.7.0"), Step: "step-a"},
+			Status:         "completed",
+		}},
+	}
+	resultSource := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	fExp.FacadeCall("SetUpgradeStepProgress", args, gomock.Any()).SetArg(2, resultSource)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	err := client.SetUpgradeStepProgress(s.tag.(names.MachineTag), version.MustParse("2.7.0"), "step-a", "completed", "")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *upgradeStepsSuite) TestSetUpgradeStepProgressNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(1)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	err := client.SetUpgradeStepProgress(s.tag.(names.MachineTag), version.MustParse("2.7.0"), "step-a", "completed", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *upgradeStepsSuite) TestWriteAgentState(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	fExp := s.fCaller.EXPECT()
+	fExp.BestAPIVersion().Return(2)
+	args := []params.SetUnitStateArg{{
+		Tag:         "unit-wordpress-0",
+		UniterState: "started: true\n",
+	}, {
+		Tag:          "unit-mysql-0",
+		StorageState: "attached: true\n",
+	}}
+	resultSource := params.ErrorResults{Results: []params.ErrorResult{{}, {
+		Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+	}}}
+	fExp.FacadeCall("WriteAgentState", params.SetUnitStateArgs{Args: args}, gomock.Any()).SetArg(2, resultSource)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	err := client.WriteAgentState(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *upgradeStepsSuite) TestWriteAgentStateNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(1)
+
+	client := upgradesteps.NewClientFromFacade(s.fCaller)
+	err := client.WriteAgentState([]params.SetUnitStateArg{{Tag: "unit-wordpress-0"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *upgradeStepsSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.fCaller = mocks.NewMockFacadeCaller(ctrl)
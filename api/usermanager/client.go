@@ -6,6 +6,7 @@ package usermanager
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
 )
 
 var logger = loggo.GetLogger("juju.api.usermanager")
@@ -34,10 +36,25 @@ func NewClient(st base.APICallCloser) *Client {
 // AddUser creates a new local user in the controller, sharing with that user any specified models.
 func (c *Client) AddUser(
 	username, displayName, password string,
+) (_ names.UserTag, secretKey []byte, _ error) {
+	return c.AddUserWithGrants(username, displayName, password, nil, time.Time{})
+}
+
+// AddUserWithGrants creates a new local user in the controller, granting
+// the user the access to models specified by grants, keyed by model UUID,
+// as part of its creation. If expires is not zero, the user is disabled
+// automatically after that time.
+func (c *Client) AddUserWithGrants(
+	username, displayName, password string,
+	grants map[string]permission.Access,
+	expires time.Time,
 ) (_ names.UserTag, secretKey []byte, _ error) {
 	if !names.IsValidUser(username) {
 		return names.UserTag{}, nil, fmt.Errorf("invalid user name %q", username)
 	}
+	if (len(grants) > 0 || !expires.IsZero()) && c.BestAPIVersion() < 3 {
+		return names.UserTag{}, nil, errors.NotSupportedf("granting model access or setting expiry when adding a user with this controller")
+	}
 
 	userArgs := params.AddUsers{
 		Users: []params.AddUser{{
@@ -46,6 +63,15 @@ func (c *Client) AddUser(
 			Password:    password,
 		}},
 	}
+	for modelUUID, access := range grants {
+		userArgs.Users[0].ModelAccess = append(userArgs.Users[0].ModelAccess, params.UserModelAccess{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Access:   string(access),
+		})
+	}
+	if !expires.IsZero() {
+		userArgs.Users[0].Expires = &expires
+	}
 	var results params.AddUserResults
 	err := c.facade.FacadeCall("AddUser", userArgs, &results)
 	if err != nil {
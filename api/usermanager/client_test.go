@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/params"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -45,6 +48,23 @@ func (s *usermanagerSuite) TestAddUser(c *gc.C) {
 	c.Assert(user.PasswordValid("password"), jc.IsTrue)
 }
 
+func (s *usermanagerSuite) TestAddUserWithGrants(c *gc.C) {
+	expires := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	tag, secretKey, err := s.usermanager.AddUserWithGrants("foobar", "Foo Bar", "", map[string]permission.Access{
+		s.Model.UUID(): permission.ReadAccess,
+	}, expires)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secretKey, gc.NotNil)
+
+	user, err := s.State.User(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Expires().Equal(expires), jc.IsTrue)
+
+	access, err := s.State.UserAccess(tag, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.ReadAccess)
+}
+
 func (s *usermanagerSuite) TestAddExistingUser(c *gc.C) {
 	s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
 
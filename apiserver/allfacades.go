@@ -53,12 +53,15 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/controllerfeatures"
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/apiserver/facades/client/helptopics"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
-	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/keymanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/labels"
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
@@ -70,7 +73,9 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
+	"github.com/juju/juju/apiserver/facades/client/units"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/controller/actionnotifier"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
@@ -132,7 +137,9 @@ func AllFacades() *facade.Registry {
 
 	reg("Action", 2, action.NewActionAPIV2)
 	reg("Action", 3, action.NewActionAPIV3)
+	reg("Action", 4, action.NewActionAPIV4) // adds notify-url to Enqueue
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionNotifier", 1, actionnotifier.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -150,9 +157,13 @@ func AllFacades() *facade.Registry {
 	reg("Application", 8, application.NewFacadeV8)
 	reg("Application", 9, application.NewFacadeV9)   // ApplicationInfo; generational config; Force on App, Relation and Unit Removal.
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
+	reg("Application", 11, application.NewFacadeV11) // MergeBindings
+	reg("Application", 12, application.NewFacadeV12) // SetCharm ConfigMap
+	reg("Application", 13, application.NewFacadeV13) // CredentialAccesses
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // adds consumer network and controller restrictions to Offer
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
@@ -177,14 +188,22 @@ func AllFacades() *facade.Registry {
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
 	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
+	reg("CAASOperatorProvisioner", 2, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPIv2)
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
 	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
+	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacadeV2)
+	reg("CAASUnitProvisioner", 3, caasunitprovisioner.NewStateFacadeV3)
+	reg("CAASUnitProvisioner", 4, caasunitprovisioner.NewStateFacadeV4) // adds pod proxy settings and WatchProxySettings
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
 	reg("Controller", 5, controller.NewControllerAPIv5)
 	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("Controller", 7, controller.NewControllerAPIv7)
+	reg("Controller", 8, controller.NewControllerAPIv8)   // adds VerifyModels
+	reg("Controller", 9, controller.NewControllerAPIv9)   // adds ControllerHealth
+	reg("Controller", 10, controller.NewControllerAPIv10) // adds UpgradeStepsProgress
+	reg("ControllerFeatures", 1, controllerfeatures.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
@@ -192,13 +211,16 @@ func AllFacades() *facade.Registry {
 	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPI) // adds WatchModelCredential
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
-	reg("Deployer", 1, deployer.NewDeployerAPI)
+	reg("Deployer", 1, deployer.NewDeployerAPIV1)
+	reg("Deployer", 2, deployer.NewDeployerAPIV2) // Adds Constraints.
+	reg("Deployer", 3, deployer.NewDeployerAPI)   // Adds WatchConstraints.
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("HelpTopics", 1, helptopics.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -211,9 +233,13 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 1, instancemutater.NewFacadeV1)
 	reg("InstanceMutater", 2, instancemutater.NewFacadeV2)
 
-	reg("InstancePoller", 3, instancepoller.NewFacade)
+	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
+	reg("InstancePoller", 4, instancepoller.NewFacadeV4) // adds Series
+	reg("InstancePoller", 5, instancepoller.NewFacade)   // adds SetInstanceUtilization
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
+	reg("Labels", 1, labels.NewFacadeV1)
+	reg("Labels", 2, labels.NewFacade) // adds Select
 
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
 
@@ -227,9 +253,11 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
 	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7) // Adds NetworkStatus.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
-	reg("Machiner", 1, machine.NewMachinerAPI)
+	reg("Machiner", 1, machine.NewMachinerAPIV1)
+	reg("Machiner", 2, machine.NewMachinerAPI) // Adds SetNetworkStatus.
 
 	reg("MeterStatus", 1, meterstatus.NewMeterStatusFacade)
 	reg("MetricsAdder", 2, metricsadder.NewMetricsAdderAPI)
@@ -250,6 +278,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 5, modelmanager.NewFacadeV5) // adds ChangeModelCredential
 	reg("ModelManager", 6, modelmanager.NewFacadeV6) // adds cloud specific default config
 	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8) // Adds TransferModelOwnership
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -271,7 +300,8 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 1, proxyupdater.NewFacadeV1)
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
-	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
+	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPIV1)
+	reg("RemoteRelations", 2, remoterelations.NewStateRemoteRelationsAPI)
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
@@ -291,10 +321,13 @@ func AllFacades() *facade.Registry {
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; adde DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; adde DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPIV7) // Add DetectOrphanedStorage and DestroyOrphanedVolumes.
+	reg("Storage", 8, storage.NewStorageAPI)   // Add ResizeStorage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5) // Adds WatchVolumeResizes, ResizeVolumeParams
 	reg("Subnets", 2, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
@@ -307,13 +340,20 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 9, uniter.NewUniterAPIV9)
 	reg("Uniter", 10, uniter.NewUniterAPIV10)
 	reg("Uniter", 11, uniter.NewUniterAPIV11)
-	reg("Uniter", 12, uniter.NewUniterAPI)
+	reg("Uniter", 12, uniter.NewUniterAPIV12)
+	reg("Uniter", 13, uniter.NewUniterAPIV13)
+	reg("Uniter", 14, uniter.NewUniterAPIV14)
+	reg("Uniter", 15, uniter.NewUniterAPIV15) // adds hook name to CloudSpec
+	reg("Uniter", 16, uniter.NewUniterAPI)    // adds Labels and UpdateLabels
+	reg("Units", 1, units.NewFacade)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
 	reg("UpgradeSteps", 1, upgradesteps.NewFacadeV1)
+	reg("UpgradeSteps", 2, upgradesteps.NewFacadeV2) // adds PendingUpgradeSteps, RunUpgradeStep, SetUpgradeStepProgress, WriteAgentState
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("UserManager", 3, usermanager.NewUserManagerAPI) // Adds model access and expiry to AddUser
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
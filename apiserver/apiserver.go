@@ -40,6 +40,7 @@ import (
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -74,13 +75,15 @@ type Server struct {
 	newObserver            observer.ObserverFactory
 	allowModelAccess       bool
 	logSinkWriter          io.WriteCloser
-	logsinkRateLimitConfig logsink.RateLimitConfig
+	logsinkKeepaliveConfig logsink.KeepaliveConfig
 	dbloggers              dbloggers
+	requestLogs            *requestLogWriter
 	getAuditConfig         func() auditlog.Config
 	upgradeComplete        func() bool
 	restoreStatus          func() state.RestoreStatus
 	mux                    *apiserverhttp.Mux
 	metricsCollector       *Collector
+	imageScans             imageScans
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -262,7 +265,13 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		centralHub:   cfg.Hub,
 		presence:     cfg.Presence,
 		leaseManager: cfg.LeaseManager,
+		clock:        cfg.Clock,
 		logger:       loggo.GetLogger("juju.apiserver"),
+		logSinkRateLimit: logsink.RateLimitConfig{
+			Refill: cfg.LogSinkConfig.RateLimitRefill,
+			Burst:  cfg.LogSinkConfig.RateLimitBurst,
+			Clock:  cfg.Clock,
+		},
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -285,12 +294,8 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		allowModelAccess:              cfg.AllowModelAccess,
 		publicDNSName_:                cfg.PublicDNSName,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
-		logsinkRateLimitConfig: logsink.RateLimitConfig{
-			Refill: cfg.LogSinkConfig.RateLimitRefill,
-			Burst:  cfg.LogSinkConfig.RateLimitBurst,
-			Clock:  cfg.Clock,
-		},
-		getAuditConfig: cfg.GetAuditConfig,
+		logsinkKeepaliveConfig:        cfg.LogSinkConfig.keepaliveConfig(),
+		getAuditConfig:                cfg.GetAuditConfig,
 		dbloggers: dbloggers{
 			clock:                 cfg.Clock,
 			dbLoggerBufferSize:    cfg.LogSinkConfig.DBLoggerBufferSize,
@@ -298,6 +303,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		},
 		metricsCollector: cfg.MetricsCollector,
 	}
+	srv.imageScans.abort = srv.tomb.Dying()
 
 	// The auth context for authenticating access to application offers.
 	srv.offerAuthCtxt, err = newOfferAuthcontext(cfg.StatePool)
@@ -319,16 +325,30 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		}
 	}
 
+	requestLogger, err := state.NewRequestLogger(srv.shared.statePool.SystemState())
+	if err != nil {
+		return nil, errors.Annotate(err, "creating request logger")
+	}
+	srv.requestLogs = newRequestLogWriter(
+		requestLogger,
+		cfg.LogSinkConfig.DBLoggerBufferSize,
+		cfg.LogSinkConfig.DBLoggerFlushInterval,
+		cfg.Clock,
+	)
+
 	unsubscribe, err := cfg.Hub.Subscribe(apiserver.RestartTopic, func(string, map[string]interface{}) {
 		srv.tomb.Kill(dependency.ErrBounce)
 	})
 	if err != nil {
+		requestLogger.Close()
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
+		defer requestLogger.Close()
+		defer srv.requestLogs.Flush()
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
 		defer unsubscribe()
@@ -408,6 +428,10 @@ func (w logsinkMetricsCollectorWrapper) LogReadCount(modelUUID, state string) pr
 	return w.collector.LogReadCount.WithLabelValues(modelUUID, state)
 }
 
+func (w logsinkMetricsCollectorWrapper) LogShedCount(modelUUID string) prometheus.Counter {
+	return w.collector.LogShedCount.WithLabelValues(modelUUID)
+}
+
 // loop is the main loop for the server.
 func (srv *Server) loop(ready chan struct{}) error {
 	// for pat based handlers, they are matched in-order of being
@@ -424,6 +448,7 @@ func (srv *Server) loop(ready chan struct{}) error {
 	close(ready)
 	<-srv.tomb.Dying()
 	srv.wg.Wait() // wait for any outstanding requests to complete.
+	srv.imageScans.stop()
 	return tomb.ErrDying
 }
 
@@ -486,10 +511,22 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.UserTagKind, names.ApplicationTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
+	logStorageHealth := newLogStorageHealth(
+		srv.shared.statePool.SystemState(), srv.dataDir, srv.clock, logger,
+	)
+	srv.tomb.Go(func() error {
+		logStorageHealth.loop(srv.tomb.Dying())
+		return nil
+	})
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
-		&srv.logsinkRateLimitConfig,
+		srv.shared.logSinkRateLimiter,
+		&srv.logsinkKeepaliveConfig,
+		&logsink.AdmissionConfig{
+			Health: logStorageHealth,
+			Clock:  srv.clock,
+		},
 		logsinkMetricsCollectorWrapper{collector: srv.metricsCollector},
 		controllerModelUUID,
 	)
@@ -500,6 +537,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		newMigrationLogWriteCloserFunc(httpCtxt, &srv.dbloggers),
 		httpCtxt.stop(),
 		nil, // no rate-limiting
+		&srv.logsinkKeepaliveConfig,
+		nil, // migrated logs are never shed
 		logsinkMetricsCollectorWrapper{collector: srv.metricsCollector},
 		controllerModelUUID,
 	)
@@ -552,6 +591,43 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			}
 			return nil
 		},
+		ImageScannerFunc: func(req *http.Request) (*ImageScan, error) {
+			systemState := srv.shared.statePool.SystemState()
+			controllerConfig, err := systemState.ControllerConfig()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			scannerURL := controllerConfig.ResourceScannerURL()
+			if scannerURL == "" {
+				return nil, nil
+			}
+			modelUUID := httpcontext.RequestModelUUID(req)
+			if modelUUID == "" {
+				modelUUID = systemState.ModelUUID()
+			}
+			return &ImageScan{
+				Scanner: resources.NewWebhookImageScanner(scannerURL, imageScanClient, srv.clock),
+				Policy:  controllerConfig.ResourceScanPolicy(),
+				Start:   srv.imageScans.start,
+				Record: func(resourceID string, result resources.ImageScanResult) error {
+					// The request's state has been released by the
+					// time the scan completes.
+					st, err := srv.shared.statePool.Get(modelUUID)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					defer st.Release()
+					rst, err := st.Resources()
+					if err != nil {
+						return errors.Trace(err)
+					}
+					return errors.Trace(rst.SetImageScanResult(resourceID, result))
+				},
+			}, nil
+		},
+		ImageDigestResolverFunc: func(*http.Request) (resources.ImageDigestResolver, error) {
+			return resources.NewRegistryDigestResolver(imageRegistryClient), nil
+		},
 	}
 	unitResourcesHandler := &UnitResourcesHandler{
 		NewOpener: func(req *http.Request, tagKinds ...string) (resource.Opener, state.PoolHelper, error) {
@@ -820,7 +896,14 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 
 	connectionID := atomic.AddUint64(&srv.lastConnectionID, 1)
 
-	apiObserver := srv.newObserver()
+	apiObserver := observer.NewMultiplexer(
+		srv.newObserver(),
+		observer.NewRequestLogObserver(observer.RequestLogObserverContext{
+			Clock:     srv.clock,
+			Writer:    srv.requestLogs,
+			GetConfig: srv.shared.requestLogConfig,
+		}),
+	)
 	apiObserver.Join(req, connectionID)
 	defer apiObserver.Leave()
 
@@ -84,6 +84,7 @@ func (s *apiserverConfigFixture) SetUpTest(c *gc.C) {
 		},
 		PrometheusRegisterer: noopRegisterer{},
 		Cleanup:              func() {},
+		Clock:                clock.WallClock,
 	})
 	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, modelCache) })
 	c.Assert(err, jc.ErrorIsNil)
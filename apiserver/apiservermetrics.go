@@ -48,6 +48,12 @@ var MetricLogLabelNames = []string{
 	MetricLabelState,
 }
 
+// MetricLogShedLabelNames defines a series of labels for the LogShedCount
+// metric.
+var MetricLogShedLabelNames = []string{
+	MetricLabelModelUUID,
+}
+
 // Collector is a prometheus.Collector that collects metrics based
 // on apiserver status.
 type Collector struct {
@@ -58,6 +64,7 @@ type Collector struct {
 	PingFailureCount   *prometheus.CounterVec
 	LogWriteCount      *prometheus.CounterVec
 	LogReadCount       *prometheus.CounterVec
+	LogShedCount       *prometheus.CounterVec
 
 	DeprecatedAPIConnections     prometheus.Gauge
 	DeprecatedAPIRequestsTotal   *prometheus.CounterVec
@@ -110,6 +117,12 @@ func NewMetricsCollector() *Collector {
 			Name:      "log_read_count",
 			Help:      "Current number of log reads",
 		}, MetricLogLabelNames),
+		LogShedCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "log_shed_count",
+			Help:      "Current number of log records dropped under log storage pressure",
+		}, MetricLogShedLabelNames),
 
 		// TODO (stickupkid): remove post 2.6 release
 		DeprecatedAPIConnections: prometheus.NewGauge(prometheus.GaugeOpts{
@@ -142,6 +155,7 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.PingFailureCount.Describe(ch)
 	c.LogWriteCount.Describe(ch)
 	c.LogReadCount.Describe(ch)
+	c.LogShedCount.Describe(ch)
 
 	// TODO (stickupkid): remove post 2.6 release
 	c.DeprecatedAPIConnections.Describe(ch)
@@ -158,6 +172,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.PingFailureCount.Collect(ch)
 	c.LogWriteCount.Collect(ch)
 	c.LogReadCount.Collect(ch)
+	c.LogShedCount.Collect(ch)
 
 	// TODO (stickupkid): remove post 2.6 release
 	c.DeprecatedAPIConnections.Collect(ch)
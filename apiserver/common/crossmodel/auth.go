@@ -27,6 +27,10 @@ const (
 	sourcemodelKey = "source-model-uuid"
 	relationKey    = "relation-key"
 
+	// ConsumerControllerKey is the macaroon attribute declaring
+	// the controller hosting the model consuming an offer.
+	ConsumerControllerKey = "consumer-controller-uuid"
+
 	offerPermissionCaveat = "has-offer-permission"
 
 	// localOfferPermissionExpiryTime is used to expire offer macaroons.
@@ -112,6 +116,9 @@ func (a *AuthContext) CheckOfferAccessCaveat(caveat string) (*offerPermissionChe
 	if err := permission.ValidateOfferAccess(permission.Access(details.Permission)); err != nil {
 		return nil, errors.NotValidf("permission %q", details.Permission)
 	}
+	if details.ConsumerController != "" && !names.IsValidController(details.ConsumerController) {
+		return nil, errors.NotValidf("consumer-controller-uuid %q", details.ConsumerController)
+	}
 	return &details, nil
 }
 
@@ -140,6 +147,9 @@ func (a *AuthContext) CheckLocalAccessRequest(details *offerPermissionCheck) ([]
 	if details.Relation != "" {
 		firstPartyCaveats = append(firstPartyCaveats, checkers.DeclaredCaveat(relationKey, details.Relation))
 	}
+	if details.ConsumerController != "" {
+		firstPartyCaveats = append(firstPartyCaveats, checkers.DeclaredCaveat(ConsumerControllerKey, details.ConsumerController))
+	}
 	return firstPartyCaveats, nil
 }
 
@@ -185,13 +195,14 @@ func (api *AuthContext) hasModelAdminAccess(st Backend, userTag names.UserTag) (
 	return isAdmin, err
 }
 
-func (a *AuthContext) offerPermissionYaml(sourceModelUUID, username, offerURL, relationKey string, permission permission.Access) (string, error) {
+func (a *AuthContext) offerPermissionYaml(sourceModelUUID, username, offerURL, relationKey, consumerController string, permission permission.Access) (string, error) {
 	out, err := yaml.Marshal(offerPermissionCheck{
-		SourceModelUUID: sourceModelUUID,
-		User:            username,
-		OfferUUID:       offerURL,
-		Relation:        relationKey,
-		Permission:      string(permission),
+		SourceModelUUID:    sourceModelUUID,
+		User:               username,
+		OfferUUID:          offerURL,
+		Relation:           relationKey,
+		ConsumerController: consumerController,
+		Permission:         string(permission),
 	})
 	if err != nil {
 		return "", err
@@ -200,7 +211,9 @@ func (a *AuthContext) offerPermissionYaml(sourceModelUUID, username, offerURL, r
 }
 
 // CreateConsumeOfferMacaroon creates a macaroon that authorises access to the specified offer.
-func (a *AuthContext) CreateConsumeOfferMacaroon(offer *params.ApplicationOfferDetails, username string) (*macaroon.Macaroon, error) {
+// If consumerControllerUUID is not empty, the macaroon declares it as the controller
+// hosting the consuming model.
+func (a *AuthContext) CreateConsumeOfferMacaroon(offer *params.ApplicationOfferDetails, username, consumerControllerUUID string) (*macaroon.Macaroon, error) {
 	sourceModelTag, err := names.ParseModelTag(offer.SourceModelTag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -211,14 +224,16 @@ func (a *AuthContext) CreateConsumeOfferMacaroon(offer *params.ApplicationOfferD
 		return nil, errors.Trace(err)
 	}
 
-	return bakery.NewMacaroon(
-		[]checkers.Caveat{
-			checkers.TimeBeforeCaveat(expiryTime),
-			checkers.DeclaredCaveat(sourcemodelKey, sourceModelTag.Id()),
-			checkers.DeclaredCaveat(offeruuidKey, offer.OfferUUID),
-			checkers.DeclaredCaveat(usernameKey, username),
-		})
-
+	caveats := []checkers.Caveat{
+		checkers.TimeBeforeCaveat(expiryTime),
+		checkers.DeclaredCaveat(sourcemodelKey, sourceModelTag.Id()),
+		checkers.DeclaredCaveat(offeruuidKey, offer.OfferUUID),
+		checkers.DeclaredCaveat(usernameKey, username),
+	}
+	if consumerControllerUUID != "" {
+		caveats = append(caveats, checkers.DeclaredCaveat(ConsumerControllerKey, consumerControllerUUID))
+	}
+	return bakery.NewMacaroon(caveats)
 }
 
 // CreateRemoteRelationMacaroon creates a macaroon that authorises access to the specified relation.
@@ -247,6 +262,8 @@ type offerPermissionCheck struct {
 	OfferUUID       string `yaml:"offer-uuid"`
 	Relation        string `yaml:"relation-key"`
 	Permission      string `yaml:"permission"`
+
+	ConsumerController string `yaml:"consumer-controller-uuid,omitempty"`
 }
 
 type authenticator struct {
@@ -292,6 +309,7 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 		return nil, common.ErrPerm
 	}
 	relation := declared[relationKey]
+	consumerController := declared[ConsumerControllerKey]
 	attrs, err := a.bakery.CheckAny([]macaroon.Slice{mac}, requiredValues, checkers.TimeBefore)
 	if err == nil {
 		logger.Debugf("macaroon check ok, attr: %v", attrs)
@@ -305,7 +323,7 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 
 	logger.Debugf("generating discharge macaroon because: %v", err)
 	cause := err
-	authYaml, err := a.ctxt.offerPermissionYaml(a.sourceModelUUID, username, a.offerUUID, relation, permission.ConsumeAccess)
+	authYaml, err := a.ctxt.offerPermissionYaml(a.sourceModelUUID, username, a.offerUUID, relation, consumerController, permission.ConsumeAccess)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -314,6 +332,9 @@ func (a *authenticator) checkMacaroons(mac macaroon.Slice, requiredValues map[st
 		return nil, errors.Trace(err)
 	}
 	keys := []string{usernameKey}
+	if consumerController != "" {
+		keys = append(keys, ConsumerControllerKey)
+	}
 	for k := range requiredValues {
 		keys = append(keys, k)
 	}
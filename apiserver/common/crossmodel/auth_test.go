@@ -206,7 +206,7 @@ func (s *authSuite) TestCreateConsumeOfferMacaroon(c *gc.C) {
 		SourceModelTag: coretesting.ModelTag.String(),
 		OfferUUID:      "mysql-uuid",
 	}
-	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary")
+	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary", "")
 	c.Assert(err, jc.ErrorIsNil)
 	cav := mac.Caveats()
 	c.Assert(cav, gc.HasLen, 4)
@@ -216,6 +216,20 @@ func (s *authSuite) TestCreateConsumeOfferMacaroon(c *gc.C) {
 	c.Assert(cav[3].Id, jc.DeepEquals, []byte("declared username mary"))
 }
 
+func (s *authSuite) TestCreateConsumeOfferMacaroonWithConsumerController(c *gc.C) {
+	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary", coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	cav := mac.Caveats()
+	c.Assert(cav, gc.HasLen, 5)
+	c.Assert(cav[4].Id, jc.DeepEquals, []byte("declared consumer-controller-uuid "+coretesting.ControllerTag.Id()))
+}
+
 func (s *authSuite) TestCreateRemoteRelationMacaroon(c *gc.C) {
 	authContext, err := crossmodel.NewAuthContext(s.mockStatePool, s.bakery, s.bakery)
 	c.Assert(err, jc.ErrorIsNil)
@@ -302,7 +316,7 @@ func (s *authSuite) TestCheckOfferMacaroonsExpired(c *gc.C) {
 		SourceModelTag: coretesting.ModelTag.String(),
 		OfferURL:       "mysql-uuid",
 	}
-	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary")
+	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary", "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = authContext.Authenticator(
@@ -323,7 +337,7 @@ func (s *authSuite) TestCheckOfferMacaroonsDischargeRequired(c *gc.C) {
 		SourceModelTag: coretesting.ModelTag.String(),
 		OfferURL:       "mysql-uuid",
 	}
-	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary")
+	mac, err := authContext.CreateConsumeOfferMacaroon(offer, "mary", "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = authContext.Authenticator(
@@ -276,6 +276,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeForbidden
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
 	case IsDischargeRequiredError(err):
 		dischErr := errors.Cause(err).(*DischargeRequiredError)
 		code = params.CodeDischargeRequired
@@ -166,6 +166,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeMethodNotAllowed,
 	status:     http.StatusMethodNotAllowed,
 	helperFunc: params.IsMethodNotAllowed,
+}, {
+	err:        &state.ErrQuotaExceeded{Entity: "units", Key: "max-model-units", Limit: 2, Current: 2, Adding: 1},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
 }, {
 	err:    stderrors.New("an error"),
 	status: http.StatusInternalServerError,
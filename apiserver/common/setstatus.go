@@ -136,7 +136,12 @@ func NewStatusSetter(st state.EntityFinder, getCanModify GetAuthFunc) *StatusSet
 	}
 }
 
-func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
+// MaxBufferedStatusAge is how long ago a status, buffered by an agent
+// while it could not reach the controller, may have been set for it
+// to be recorded at the time it was set.
+const MaxBufferedStatusAge = 7 * 24 * time.Hour
+
+func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, since *time.Time, now time.Time) error {
 	entity, err := s.st.FindEntity(tag)
 	if err != nil {
 		return err
@@ -145,11 +150,21 @@ func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status
 	case *state.Application:
 		return ErrPerm
 	case status.StatusSetter:
+		updated := now
+		if since != nil && since.Before(now) {
+			// The status was buffered by an agent while it could
+			// not reach the controller, so record it at the time
+			// it was originally set.
+			if err := checkBufferedStatusTime(entity, *since, now); err != nil {
+				return err
+			}
+			updated = *since
+		}
 		sInfo := status.StatusInfo{
 			Status:  entityStatus,
 			Message: info,
 			Data:    data,
-			Since:   updated,
+			Since:   &updated,
 		}
 		return entity.SetStatus(sInfo)
 	default:
@@ -157,6 +172,30 @@ func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status
 	}
 }
 
+// checkBufferedStatusTime returns an error if a status buffered by an
+// agent, and originally set at the given time, may not be recorded:
+// it must have been set within MaxBufferedStatusAge, and not before
+// the entity's current status, which it would otherwise replace.
+func checkBufferedStatusTime(entity state.Entity, since, now time.Time) error {
+	if since.Before(now.Add(-MaxBufferedStatusAge)) {
+		return errors.NotValidf("status set at %s, more than %s ago", since.UTC().Format(time.RFC3339), MaxBufferedStatusAge)
+	}
+	getter, ok := entity.(status.StatusGetter)
+	if !ok {
+		return nil
+	}
+	current, err := getter.Status()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Since != nil && since.Before(*current.Since) {
+		return errors.NotValidf("status set at %s, before the current status", since.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
 // SetStatus sets the status of each given entity.
 func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -179,7 +218,7 @@ func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, er
 		}
 		err = ErrPerm
 		if canModify(tag) {
-			err = s.setEntityStatus(tag, status.Status(arg.Status), arg.Info, arg.Data, &now)
+			err = s.setEntityStatus(tag, status.Status(arg.Status), arg.Info, arg.Data, arg.Since, now)
 		}
 		result.Results[i].Error = ServerError(err)
 	}
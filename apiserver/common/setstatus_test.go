@@ -104,6 +104,65 @@ func (s *statusSetterSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(unitStatus.Status, gc.Equals, status.Active)
 }
 
+func (s *statusSetterSuite) TestSetStatusSince(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	started := time.Now().Add(-2 * time.Hour)
+	err := machine.SetStatus(status.StatusInfo{Status: status.Pending, Since: &started})
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	future := time.Now().Add(time.Hour)
+	for _, t := range []*time.Time{&since, &future} {
+		result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+			Tag:    machine.Tag().String(),
+			Status: status.Started.String(),
+			Since:  t,
+		}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Results, gc.HasLen, 1)
+		c.Assert(result.Results[0].Error, gc.IsNil)
+
+		err = machine.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		machineStatus, err := machine.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(machineStatus.Since, gc.NotNil)
+		if t == &since {
+			// A time in the past is recorded as is.
+			c.Assert(machineStatus.Since.Equal(since), jc.IsTrue)
+		} else {
+			// A time in the future is not trusted.
+			c.Assert(machineStatus.Since.Before(future), jc.IsTrue)
+		}
+	}
+}
+
+func (s *statusSetterSuite) TestSetStatusSinceInvalid(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := machine.SetStatus(status.StatusInfo{Status: status.Started, Message: "current"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	beforeCurrent := time.Now().Add(-time.Minute)
+	tooOld := time.Now().Add(-common.MaxBufferedStatusAge - time.Hour)
+	for _, t := range []*time.Time{&beforeCurrent, &tooOld} {
+		result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+			Tag:    machine.Tag().String(),
+			Status: status.Down.String(),
+			Since:  t,
+		}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Results, gc.HasLen, 1)
+		c.Assert(result.Results[0].Error, gc.ErrorMatches, "status set at .* not valid")
+	}
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Status, gc.Equals, status.Started)
+	c.Assert(machineStatus.Message, gc.Equals, "current")
+}
+
 func (s *statusSetterSuite) TestSetServiceStatus(c *gc.C) {
 	// Calls to set the status of a service should be going through the
 	// ServiceStatusSetter that checks for leadership, so permission denied
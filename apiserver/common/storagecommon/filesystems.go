@@ -125,6 +125,7 @@ func FilesystemAttachmentToState(in params.FilesystemAttachment) (names.MachineT
 	info := state.FilesystemAttachmentInfo{
 		in.Info.MountPoint,
 		in.Info.ReadOnly,
+		in.Info.MountOptions,
 	}
 	return machineTag, filesystemTag, info, nil
 }
@@ -148,6 +149,7 @@ func FilesystemAttachmentInfoFromState(info state.FilesystemAttachmentInfo) para
 	return params.FilesystemAttachmentInfo{
 		info.MountPoint,
 		info.ReadOnly,
+		info.MountOptions,
 	}
 }
 
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/logsink"
 )
 
 // These vars define how we rate limit incoming connections.
@@ -99,6 +101,27 @@ type LogSinkConfig struct {
 	// RateLimitRefill defines the rate at which log messages will be let
 	// through once the initial burst amount has been depleted.
 	RateLimitRefill time.Duration
+
+	// PingPeriod defines how often ping messages are sent to agents
+	// connected to the logsink endpoint.
+	PingPeriod time.Duration
+
+	// PongDelay defines how long to wait for a response to a ping
+	// before the connection is considered dead and closed.
+	PongDelay time.Duration
+
+	// IdleTimeout defines how long a logsink connection may go
+	// without sending a log message before it is closed. If it is
+	// zero, idle connections are never closed.
+	IdleTimeout time.Duration
+}
+
+func (cfg LogSinkConfig) keepaliveConfig() logsink.KeepaliveConfig {
+	return logsink.KeepaliveConfig{
+		PingPeriod:  cfg.PingPeriod,
+		PongDelay:   cfg.PongDelay,
+		IdleTimeout: cfg.IdleTimeout,
+	}
 }
 
 // Validate validates the logsink endpoint configuration.
@@ -115,15 +138,22 @@ func (cfg LogSinkConfig) Validate() error {
 	if cfg.RateLimitRefill <= 0 {
 		return errors.NotValidf("RateLimitRefill %s <= 0", cfg.RateLimitRefill)
 	}
+	if err := cfg.keepaliveConfig().Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
 // DefaultLogSinkConfig returns a LogSinkConfig with default values.
 func DefaultLogSinkConfig() LogSinkConfig {
+	keepalive := logsink.DefaultKeepaliveConfig()
 	return LogSinkConfig{
 		DBLoggerBufferSize:    defaultDBLoggerBufferSize,
 		DBLoggerFlushInterval: defaultDBLoggerFlushInterval,
 		RateLimitBurst:        defaultLogSinkRateLimitBurst,
 		RateLimitRefill:       defaultLogSinkRateLimitRefill,
+		PingPeriod:            keepalive.PingPeriod,
+		PongDelay:             keepalive.PongDelay,
+		IdleTimeout:           keepalive.IdleTimeout,
 	}
 }
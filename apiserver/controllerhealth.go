@@ -0,0 +1,118 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/pubsub/controller"
+)
+
+// nodeHealthTimeout is how long after its last health report a
+// controller machine is considered to have stopped reporting. The
+// peergrouper normally publishes a report every minute.
+const nodeHealthTimeout = 3 * time.Minute
+
+// controllerHealth records the health reports published over the
+// central hub by the peergrouper and raft workers on the controller
+// machines, so that they may be reported through the Controller facade.
+type controllerHealth struct {
+	clock  clock.Clock
+	logger loggo.Logger
+
+	mu    sync.Mutex
+	nodes map[string]facade.NodeHealth
+	raft  *facade.RaftHealth
+
+	unsubscribe []func()
+}
+
+func newControllerHealth(hub SharedHub, clock clock.Clock, logger loggo.Logger) (*controllerHealth, error) {
+	h := &controllerHealth{
+		clock:  clock,
+		logger: logger,
+		nodes:  make(map[string]facade.NodeHealth),
+	}
+	unsubscribe, err := hub.Subscribe(controller.NodeHealthTopic, h.onNodeHealth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	h.unsubscribe = append(h.unsubscribe, unsubscribe)
+	unsubscribe, err = hub.Subscribe(controller.RaftHealthTopic, h.onRaftHealth)
+	if err != nil {
+		h.Close()
+		return nil, errors.Trace(err)
+	}
+	h.unsubscribe = append(h.unsubscribe, unsubscribe)
+	return h, nil
+}
+
+// Close unsubscribes from the central hub.
+func (h *controllerHealth) Close() {
+	for _, unsubscribe := range h.unsubscribe {
+		unsubscribe()
+	}
+}
+
+func (h *controllerHealth) onNodeHealth(topic string, data controller.NodeHealthMessage, err error) {
+	if err != nil {
+		h.logger.Errorf("bad %s message data: %v", topic, err)
+		return
+	}
+	tag, err := names.ParseMachineTag(data.Origin)
+	if err != nil {
+		h.logger.Warningf("ignoring %s message from %q: %v", topic, data.Origin, err)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodes[tag.Id()] = facade.NodeHealth{
+		NodeHealthMessage: data,
+		Received:          h.clock.Now(),
+	}
+}
+
+func (h *controllerHealth) onRaftHealth(topic string, data controller.RaftHealthMessage, err error) {
+	if err != nil {
+		h.logger.Errorf("bad %s message data: %v", topic, err)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.raft = &facade.RaftHealth{
+		RaftHealthMessage: data,
+		Received:          h.clock.Now(),
+	}
+}
+
+// Nodes is part of the facade.ControllerHealth interface.
+func (h *controllerHealth) Nodes() map[string]facade.NodeHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	nodes := make(map[string]facade.NodeHealth, len(h.nodes))
+	for id, node := range h.nodes {
+		node.Reporting = now.Sub(node.Received) < nodeHealthTimeout
+		nodes[id] = node
+	}
+	return nodes
+}
+
+// Raft is part of the facade.ControllerHealth interface.
+func (h *controllerHealth) Raft() *facade.RaftHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.raft == nil {
+		return nil
+	}
+	raft := *h.raft
+	return &raft
+}
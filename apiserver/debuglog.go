@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -82,6 +83,14 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - only send logs on or after this RFC3339 time
+//   endTime -> string - only send logs before this RFC3339 time
+//   includeMessage -> []string - regular expressions, only send logs with
+//      - messages matching one of them
+//   excludeMessage -> []string - regular expressions, do not send logs
+//      - with messages matching any of them
+//   maxBytes -> uint - stop before sending more than this many bytes of
+//      - log messages
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -174,16 +183,20 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	maxBytes       uint64
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage []string
+	excludeMessage []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -197,6 +210,14 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.maxLines = uint(num)
 	}
 
+	if value := queryMap.Get("maxBytes"); value != "" {
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return params, errors.Errorf("maxBytes value %q is not a valid unsigned number", value)
+		}
+		params.maxBytes = num
+	}
+
 	if value := queryMap.Get("replay"); value != "" {
 		replay, err := strconv.ParseBool(value)
 		if err != nil {
@@ -239,10 +260,31 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		params.endTime = endTime
+	}
+	if !params.startTime.IsZero() && !params.endTime.IsZero() && !params.endTime.After(params.startTime) {
+		return params, errors.Errorf("end time must be after start time")
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+
+	// The patterns are matched by the database, but are checked
+	// here so that a bad pattern is reported to the client.
+	for _, pattern := range append(params.includeMessage, params.excludeMessage...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return params, errors.Errorf("message pattern %q is not a valid regular expression", pattern)
+		}
+	}
 
 	return params, nil
 }
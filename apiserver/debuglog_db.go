@@ -38,6 +38,7 @@ func handleDebugLogDBRequest(
 	socket.sendOk()
 
 	var lineCount uint
+	var byteCount uint64
 	for {
 		select {
 		case <-stop:
@@ -47,6 +48,11 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
+			byteCount += uint64(len(rec.Message))
+			if reqParams.maxBytes > 0 && byteCount > reqParams.maxBytes {
+				return nil
+			}
+
 			if err := socket.sendLogRecord(formatLogRecord(rec)); err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -61,14 +67,17 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		IncludeMessage: reqParams.includeMessage,
+		ExcludeMessage: reqParams.excludeMessage,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
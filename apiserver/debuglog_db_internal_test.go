@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -30,25 +31,28 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		includeMessage: []string{"timed out"},
+		excludeMessage: []string{"retrying"},
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -56,6 +60,8 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"timed out"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"retrying"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -169,6 +175,78 @@ func (s *debugLogDBIntSuite) TestMaxLines(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestMaxBytes(c *gc.C) {
+	// Set up a fake log tailer with a 5 log records ready to send,
+	// each with a 14 byte message.
+	tailer := newFakeLogTailer()
+	for i := 0; i < 5; i++ {
+		tailer.logsCh <- &state.LogRecord{
+			Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+			Entity:   "machine-99",
+			Module:   "some.where",
+			Location: "code.go:42",
+			Level:    loggo.INFO,
+			Message:  "stuff happened",
+		}
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{maxBytes: 40}, nil)
+
+	s.assertOutput(c, []string{
+		"ok", // sendOk() call needs to happen first.
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 stuff happened\n",
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 stuff happened\n",
+	})
+
+	// The tailer should stop by itself rather than exceed the byte limit.
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsTimeRangeAndMessages(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":      {"2016-11-30T10:51:00Z"},
+		"endTime":        {"2016-11-30T11:51:00Z"},
+		"includeMessage": {"timed out", "refused"},
+		"excludeMessage": {"retrying"},
+		"maxBytes":       {"1048576"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.startTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+	c.Assert(params.includeMessage, jc.DeepEquals, []string{"timed out", "refused"})
+	c.Assert(params.excludeMessage, jc.DeepEquals, []string{"retrying"})
+	c.Assert(params.maxBytes, gc.Equals, uint64(1048576))
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		query url.Values
+		err   string
+	}{{
+		query: url.Values{"endTime": {"yesterday"}},
+		err:   `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-30T10:51:00Z"},
+			"endTime":   {"2016-11-30T10:51:00Z"},
+		},
+		err: "end time must be after start time",
+	}, {
+		query: url.Values{"includeMessage": {"("}},
+		err:   `message pattern "\(" is not a valid regular expression`,
+	}, {
+		query: url.Values{"maxBytes": {"-1"}},
+		err:   `maxBytes value "-1" is not a valid unsigned number`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) runRequest(params debugLogParams, stop chan struct{}) chan error {
 	done := make(chan error)
 	go func() {
@@ -0,0 +1,36 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+)
+
+// deprecatedFacadeVersions holds the facade versions that will be
+// removed in a future release, keyed by facade name and version. The
+// values describe what clients should do instead, and are included in
+// the warnings attached to replies to requests made with them.
+var deprecatedFacadeVersions = map[string]map[int]string{}
+
+// warnDeprecatedFacadeVersion attaches a warning to the reply to the
+// request being served with ctx if the given facade version is
+// deprecated.
+func warnDeprecatedFacadeVersion(ctx context.Context, facadeName string, version int) {
+	advice, ok := deprecatedFacadeVersions[facadeName][version]
+	if !ok {
+		return
+	}
+	message := fmt.Sprintf("%s facade version %d is deprecated and will be removed in a future release", facadeName, version)
+	if advice != "" {
+		message += ": " + advice
+	}
+	rpc.Warn(ctx, rpc.Warning{
+		Code:    params.WarningDeprecatedFacadeVersion,
+		Message: message,
+	})
+}
@@ -25,6 +25,8 @@ var (
 	JSMimeType            = jsMimeType
 	GUIURLPathPrefix      = guiURLPathPrefix
 	SpritePath            = spritePath
+
+	DeprecatedFacadeVersions = &deprecatedFacadeVersions
 )
 
 func APIHandlerWithEntity(entity state.Entity) *apiHandler {
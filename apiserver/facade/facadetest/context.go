@@ -5,6 +5,7 @@ package facadetest
 
 import (
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -22,6 +23,9 @@ type Context struct {
 	Controller_ *cache.Controller
 	ID_         string
 
+	ControllerHealth_ facade.ControllerHealth
+	ControllerConfig_ controller.Config
+
 	LeadershipClaimer_ leadership.Claimer
 	LeadershipChecker_ leadership.Checker
 	LeadershipPinner_  leadership.Pinner
@@ -47,6 +51,16 @@ func (context Context) Hub() facade.Hub {
 	return context.Hub_
 }
 
+// ControllerHealth is part of the facade.Context interface.
+func (context Context) ControllerHealth() facade.ControllerHealth {
+	return context.ControllerHealth_
+}
+
+// ControllerConfig is part of the facade.Context interface.
+func (context Context) ControllerConfig() controller.Config {
+	return context.ControllerConfig_
+}
+
 // Controller is part of the facade.Context interface.
 func (context Context) Controller() *cache.Controller {
 	return context.Controller_
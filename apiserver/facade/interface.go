@@ -4,13 +4,17 @@
 package facade
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/permission"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -77,6 +81,14 @@ type Context interface {
 	// At least at this stage, facades only need to publish events.
 	Hub() Hub
 
+	// ControllerHealth returns the most recent health reports
+	// received from the controller machines.
+	ControllerHealth() ControllerHealth
+
+	// ControllerConfig returns the controller config held by the
+	// API server, which is kept up to date as the config changes.
+	ControllerConfig() controller.Config
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
@@ -187,3 +199,33 @@ type ModelPresence interface {
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
 }
+
+// ControllerHealth provides the health reports published by the
+// peergrouper and raft workers running on the controller machines.
+type ControllerHealth interface {
+	// Nodes returns the most recent report from each controller
+	// machine, keyed by machine id.
+	Nodes() map[string]NodeHealth
+
+	// Raft returns the most recent report from the raft leader,
+	// or nil if no report has been received.
+	Raft() *RaftHealth
+}
+
+// NodeHealth holds the health reported by a controller machine,
+// along with the time at which the report was received.
+type NodeHealth struct {
+	controllermsg.NodeHealthMessage
+	Received time.Time
+
+	// Reporting is true if the report was received recently
+	// enough that the machine is considered to still be reporting.
+	Reporting bool
+}
+
+// RaftHealth holds the health of the raft cluster reported by the
+// leader, along with the time at which the report was received.
+type RaftHealth struct {
+	controllermsg.RaftHealthMessage
+	Received time.Time
+}
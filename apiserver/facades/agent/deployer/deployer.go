@@ -6,12 +6,15 @@ package deployer
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // DeployerAPI provides access to the Deployer API facade.
@@ -23,11 +26,58 @@ type DeployerAPI struct {
 	*common.UnitsWatcher
 	*common.StatusSetter
 
-	st         *state.State
-	resources  facade.Resources
-	authorizer facade.Authorizer
+	st          *state.State
+	resources   facade.Resources
+	authorizer  facade.Authorizer
+	getAuthFunc common.GetAuthFunc
 }
 
+// DeployerAPIV2 implements version 2 of the Deployer API,
+// which lacks WatchConstraints.
+type DeployerAPIV2 struct {
+	*DeployerAPI
+}
+
+// DeployerAPIV1 implements version 1 of the Deployer API,
+// which lacks Constraints.
+type DeployerAPIV1 struct {
+	*DeployerAPIV2
+}
+
+// NewDeployerAPIV2 creates a new instance of version 2 of the
+// Deployer API.
+func NewDeployerAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*DeployerAPIV2, error) {
+	api, err := NewDeployerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &DeployerAPIV2{api}, nil
+}
+
+// NewDeployerAPIV1 creates a new instance of version 1 of the
+// Deployer API.
+func NewDeployerAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*DeployerAPIV1, error) {
+	api, err := NewDeployerAPIV2(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &DeployerAPIV1{api}, nil
+}
+
+// WatchConstraints isn't on the V2 API.
+func (*DeployerAPIV2) WatchConstraints(_, _ struct{}) {}
+
+// Constraints isn't on the V1 API.
+func (*DeployerAPIV1) Constraints(_, _ struct{}) {}
+
 // NewDeployerAPI creates a new server-side DeployerAPI facade.
 func NewDeployerAPI(
 	st *state.State,
@@ -69,6 +119,7 @@ func NewDeployerAPI(
 		st:              st,
 		resources:       resources,
 		authorizer:      authorizer,
+		getAuthFunc:     getAuthFunc,
 	}, nil
 }
 
@@ -90,6 +141,87 @@ func (d *DeployerAPI) SetStatus(args params.SetStatus) (params.ErrorResults, err
 	return d.StatusSetter.SetStatus(args)
 }
 
+// Constraints returns the current constraints of each given unit's
+// application, combined with the model constraints, so that the
+// resource limits they hold can be applied to the unit's agent.
+// Subordinate units have no constraints.
+func (d *DeployerAPI) Constraints(args params.Entities) (params.ConstraintsResults, error) {
+	result := params.ConstraintsResults{
+		Results: make([]params.ConstraintsResult, len(args.Entities)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := d.unit(entity.Tag, canAccess)
+		if err == nil && unit.IsPrincipal() {
+			result.Results[i].Constraints, err = d.unitConstraints(unit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchConstraints returns a NotifyWatcher for each given unit, which
+// notifies of changes to the constraints returned by Constraints.
+func (d *DeployerAPI) WatchConstraints(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := d.unit(entity.Tag, canAccess)
+		if err == nil {
+			result.Results[i].NotifyWatcherId, err = d.watchConstraints(unit)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (d *DeployerAPI) unit(tagString string, canAccess common.AuthFunc) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return d.st.Unit(tag.Id())
+}
+
+func (d *DeployerAPI) unitConstraints(unit *state.Unit) (constraints.Value, error) {
+	app, err := unit.Application()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	cons, err := app.Constraints()
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	return d.st.ResolveConstraints(cons)
+}
+
+func (d *DeployerAPI) watchConstraints(unit *state.Unit) (string, error) {
+	var app *state.Application
+	if unit.IsPrincipal() {
+		var err error
+		if app, err = unit.Application(); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	watchers := []state.NotifyWatcher{d.st.WatchModelConstraints()}
+	if app != nil {
+		watchers = append(watchers, app.WatchConstraints())
+	}
+	w := common.NewMultiNotifyWatcher(watchers...)
+	if _, ok := <-w.Changes(); !ok {
+		return "", watcher.EnsureErr(w)
+	}
+	return d.resources.Register(w), nil
+}
+
 // getAllUnits returns a list of all principal and subordinate units
 // assigned to the given machine.
 func getAllUnits(st *state.State, tag names.Tag) ([]string, error) {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -243,6 +244,87 @@ func (s *deployerSuite) TestLife(c *gc.C) {
 	})
 }
 
+func (s *deployerSuite) TestConstraints(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem-limit=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+	// The application's constraints are reported even though they
+	// were set after its units were added.
+	err = s.service0.SetConstraints(constraints.MustParse("cpu-quota=50 mem-limit=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql-1"},
+		{Tag: "unit-logging-0"},
+		{Tag: "unit-fake-42"},
+		{Tag: "machine-1"},
+	}}
+	result, err := s.deployer.Constraints(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConstraintsResults{
+		Results: []params.ConstraintsResult{
+			{Constraints: constraints.MustParse("cpu-quota=50 mem-limit=1G")},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Constraints: constraints.Value{}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *deployerSuite) TestConstraintsFromModel(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("mem-limit=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}}
+	result, err := s.deployer.Constraints(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConstraintsResults{
+		Results: []params.ConstraintsResult{
+			{Constraints: constraints.MustParse("mem-limit=2G")},
+		},
+	})
+}
+
+func (s *deployerSuite) TestWatchConstraints(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql-1"},
+		{Tag: "unit-logging-0"},
+		{Tag: "machine-1"},
+	}}
+	result, err := s.deployer.WatchConstraints(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "2"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+
+	// The initial event has been consumed.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.service0.SetConstraints(constraints.MustParse("cpu-quota=50"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetModelConstraints(constraints.MustParse("mem-limit=2G"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *deployerSuite) TestRemove(c *gc.C) {
 	c.Assert(s.principal0.Life(), gc.Equals, state.Alive)
 	c.Assert(s.subordinate0.Life(), gc.Equals, state.Alive)
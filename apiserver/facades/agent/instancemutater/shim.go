@@ -4,6 +4,9 @@
 package instancemutater
 
 import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/state"
 )
@@ -12,14 +15,26 @@ type instanceMutaterStateShim struct {
 	*state.State
 }
 
-// modelCacheShim is used as a shim between the
-// cache.PredicateStringsWatcher and cache.StringsWatcher to enable better mock testing.
+// modelCacheShim is used as a shim between the cache model
+// and the ModelCache interface to enable better mock testing.
 type modelCacheShim struct {
 	*cache.Model
 }
 
+// WatchMachines returns a watcher which notifies with the ids of the
+// model's machines as they are added and removed, using a cache
+// subscription for the machines which are not containers.
 func (s *modelCacheShim) WatchMachines() (cache.StringsWatcher, error) {
-	return s.Model.WatchMachines()
+	sub, err := s.Model.Subscribe(cache.EntityFilter{
+		Kind: cache.MachineEntity,
+		Predicate: func(e cache.EntityEvent) bool {
+			return !names.IsContainerMachine(e.Id)
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cache.NewEntityIdsWatcher(sub), nil
 }
 
 func (s modelCacheShim) Charm(charmURL string) (ModelCacheCharm, error) {
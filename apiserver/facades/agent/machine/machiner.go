@@ -35,6 +35,25 @@ type MachinerAPI struct {
 	getCanRead   common.GetAuthFunc
 }
 
+// MachinerAPIV1 implements version 1 of the Machiner API,
+// which lacks SetNetworkStatus.
+type MachinerAPIV1 struct {
+	*MachinerAPI
+}
+
+// NewMachinerAPIV1 creates a new instance of version 1 of the
+// Machiner API.
+func NewMachinerAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPIV1, error) {
+	api, err := NewMachinerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachinerAPIV1{api}, nil
+}
+
+// SetNetworkStatus isn't on the V1 API.
+func (*MachinerAPIV1) SetNetworkStatus(_, _ struct{}) {}
+
 // NewMachinerAPI creates a new instance of the Machiner API.
 func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPI, error) {
 	if !authorizer.AuthMachineAgent() {
@@ -98,6 +117,58 @@ func (api *MachinerAPI) SetMachineAddresses(args params.SetMachinesAddresses) (p
 	return results, nil
 }
 
+// SetNetworkStatus records the state of FAN networking reported
+// by each of the given machines.
+func (api *MachinerAPI) SetNetworkStatus(args params.SetMachinesNetworkStatus) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canModify, err := api.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canModify(tag) {
+			var m *state.Machine
+			m, err = api.getMachine(tag)
+			if err == nil {
+				err = m.SetNetworkStatus(networkStatusFromParams(arg.Status))
+			} else if errors.IsNotFound(err) {
+				err = common.ErrPerm
+			}
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func networkStatusFromParams(in params.MachineNetworkStatus) state.MachineNetworkStatus {
+	var out state.MachineNetworkStatus
+	for _, bridge := range in.FanBridges {
+		out.FanBridges = append(out.FanBridges, state.FanBridgeStatus{
+			Name:         bridge.Name,
+			Underlay:     bridge.Underlay,
+			Overlay:      bridge.Overlay,
+			LocalOverlay: bridge.LocalOverlay,
+			Present:      bridge.Present,
+			Up:           bridge.Up,
+		})
+	}
+	for _, fanErr := range in.FanErrors {
+		out.FanErrors = append(out.FanErrors, state.FanConfigError{
+			Time:    fanErr.Time,
+			Message: fanErr.Message,
+		})
+	}
+	return out
+}
+
 // Jobs returns the jobs assigned to the given entities.
 func (api *MachinerAPI) Jobs(args params.Entities) (params.JobsResults, error) {
 	result := params.JobsResults{
@@ -6,6 +6,7 @@ package machine_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -230,6 +231,56 @@ func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	c.Assert(s.machine1.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestSetNetworkStatus(c *gc.C) {
+	errTime := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	status := params.MachineNetworkStatus{
+		FanBridges: []params.FanBridgeStatus{{
+			Name:         "fan-252",
+			Underlay:     "10.0.0.0/16",
+			Overlay:      "252.0.0.0/8",
+			LocalOverlay: "252.5.0.0/16",
+			Present:      true,
+			Up:           true,
+		}},
+		FanErrors: []params.FanConfigError{{
+			Time:    errTime,
+			Message: "fanatic: exit status 1",
+		}},
+	}
+	args := params.SetMachinesNetworkStatus{Args: []params.SetMachineNetworkStatus{
+		{Tag: "machine-1", Status: status},
+		{Tag: "machine-0", Status: status},
+		{Tag: "machine-42", Status: status},
+	}}
+
+	result, err := s.machiner.SetNetworkStatus(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	obtained, err := s.machine1.NetworkStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.FanBridges, jc.DeepEquals, []state.FanBridgeStatus{{
+		Name:         "fan-252",
+		Underlay:     "10.0.0.0/16",
+		Overlay:      "252.0.0.0/8",
+		LocalOverlay: "252.5.0.0/16",
+		Present:      true,
+		Up:           true,
+	}})
+	c.Assert(obtained.FanErrors, jc.DeepEquals, []state.FanConfigError{{
+		Time:    errTime,
+		Message: "fanatic: exit status 1",
+	}})
+	_, err = s.machine0.NetworkStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *machinerSuite) TestJobs(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "machine-1"},
@@ -10,8 +10,10 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/imagemetadata"
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
+	"github.com/juju/juju/environs/simplestreams"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/state/cloudimagemetadata"
@@ -119,6 +121,44 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataPinnedInModelConfig(c *gc.C) {
+	// Pinned images take precedence over published metadata.
+	useTestImageData(c, testImagesData)
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"image-ids": "quantal/amd64=ami-pinned-amd64 quantal/arm64=ami-pinned-arm64 xenial/amd64=ami-xenial",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[0].SetConstraints(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	pinned := func(imageId, arch string) params.CloudImageMetadata {
+		return params.CloudImageMetadata{
+			ImageId:  imageId,
+			Stream:   "daily",
+			Version:  "12.10",
+			Series:   "quantal",
+			Arch:     arch,
+			Source:   "pinned",
+			Priority: simplestreams.CUSTOM_CLOUD_DATA,
+		}
+	}
+	expected := make([][]params.CloudImageMetadata, len(s.machines))
+	expected[0] = []params.CloudImageMetadata{pinned("ami-pinned-arm64", "arm64")}
+	for i := 1; i < len(s.machines); i++ {
+		expected[i] = []params.CloudImageMetadata{
+			pinned("ami-pinned-amd64", "amd64"),
+			pinned("ami-pinned-arm64", "arm64"),
+		}
+	}
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
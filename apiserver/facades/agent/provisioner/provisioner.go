@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/container"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/lxdprofile"
@@ -732,6 +733,19 @@ func commonApplicationMachineId(st *state.State, m *state.Machine) ([]string, er
 	applications := m.Principals()
 	var union set.Strings
 	for _, app := range applications {
+		// Only applications spread across zones take part
+		// in distributing machines across zones.
+		appName, err := names.UnitApplication(app)
+		if err != nil {
+			return nil, err
+		}
+		spread, _, err := state.ApplicationSpread(st, appName)
+		if err != nil {
+			return nil, err
+		}
+		if spread != coreapplication.SpreadZones {
+			continue
+		}
 		machines, err := state.ApplicationMachines(st, app)
 		if err != nil {
 			return nil, err
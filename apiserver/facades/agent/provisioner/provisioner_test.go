@@ -15,6 +15,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/container"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
@@ -1137,6 +1139,52 @@ func (s *withoutControllerSuite) TestDistributionGroupByMachineId(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestDistributionGroupByMachineIdSpread(c *gc.C) {
+	addUnits := func(name string, machines ...*state.Machine) *state.Application {
+		app := s.AddTestingApplication(c, name, s.AddTestingCharm(c, name))
+		for _, m := range machines {
+			unit, err := app.AddUnit(state.AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+			err = unit.AssignToMachine(m)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		return app
+	}
+	mysql := addUnits("mysql", s.machines[0], s.machines[3])
+	addUnits("wordpress", s.machines[0], s.machines[1])
+
+	// mysql is not spread across zones, so its machines
+	// are not in each other's distribution group.
+	setApplicationSpread(c, mysql, "none", "warn")
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: s.machines[3].Tag().String()},
+	}}
+	result, err := s.provisioner.DistributionGroupByMachineId(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"1"}},
+			{Result: []string{"0"}},
+			{Result: []string{}},
+		},
+	})
+}
+
+func setApplicationSpread(c *gc.C, app *state.Application, spread, policy string) {
+	schema := environschema.Fields{
+		"spread":        environschema.Attr{Type: environschema.Tstring},
+		"spread-policy": environschema.Attr{Type: environschema.Tstring},
+	}
+	err := app.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"spread":        spread,
+		"spread-policy": policy,
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *withoutControllerSuite) TestDistributionGroupByMachineIdControllerAuth(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "machine-0"},
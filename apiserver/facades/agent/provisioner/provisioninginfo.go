@@ -17,8 +17,10 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
@@ -102,6 +104,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	spreadPolicy, err := p.machineSpreadPolicy(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine machine spread policy")
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -116,9 +123,31 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		ControllerConfig:  controllerCfg,
 		CloudInitUserData: env.Config().CloudInitUserData(),
 		CharmLXDProfiles:  pNames,
+		SpreadPolicy:      spreadPolicy,
 	}, nil
 }
 
+// machineSpreadPolicy returns the spread policy to apply when choosing
+// an availability zone for the machine: "fail" if any application with
+// units on the machine is spread across zones and must fail when that
+// cannot be done, and "" otherwise.
+func (p *ProvisionerAPI) machineSpreadPolicy(m *state.Machine) (string, error) {
+	for _, unitName := range m.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		spread, policy, err := state.ApplicationSpread(p.st, appName)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if spread == coreapplication.SpreadZones && policy == coreapplication.SpreadPolicyFail {
+			return string(policy), nil
+		}
+	}
+	return "", nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
@@ -439,6 +468,13 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs.E
 		return nil, errors.Annotate(err, "could not construct image constraint")
 	}
 
+	// Images pinned in model config take precedence over any
+	// published image metadata.
+	if pinned := pinnedImageMetadata(imageConstraint, env.Config()); len(pinned) != 0 {
+		logger.Debugf("using pinned image metadata for provisioning: %v", pinned)
+		return pinned, nil
+	}
+
 	// Look for image metadata in state.
 	data, err := p.findImageMetadata(imageConstraint, env)
 	if err != nil {
@@ -449,6 +485,43 @@ func (p *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs.E
 	return data, nil
 }
 
+// pinnedImageMetadata returns image metadata for the images pinned by
+// the "image-ids" model config that match the constraint. If the
+// constraint does not specify an architecture, images pinned for any
+// architecture of the series are returned.
+func pinnedImageMetadata(constraint *imagemetadata.ImageConstraint, cfg *config.Config) []params.CloudImageMetadata {
+	var result []params.CloudImageMetadata
+	for _, mSeries := range constraint.Series {
+		arches := constraint.Arches
+		if len(arches) == 0 {
+			for key := range cfg.ImageIds() {
+				if parts := strings.SplitN(key, "/", 2); len(parts) == 2 && parts[0] == mSeries {
+					arches = append(arches, parts[1])
+				}
+			}
+			sort.Strings(arches)
+		}
+		version, _ := series.SeriesVersion(mSeries)
+		for _, arch := range arches {
+			imageId, ok := cfg.ImageId(mSeries, arch)
+			if !ok {
+				continue
+			}
+			result = append(result, params.CloudImageMetadata{
+				ImageId:  imageId,
+				Stream:   constraint.Stream,
+				Region:   constraint.Region,
+				Version:  version,
+				Series:   mSeries,
+				Arch:     arch,
+				Source:   "pinned",
+				Priority: simplestreams.CUSTOM_CLOUD_DATA,
+			})
+		}
+	}
+	return result
+}
+
 // constructImageConstraint returns model-specific criteria used to look for image metadata.
 func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs.Environ) (*imagemetadata.ImageConstraint, error) {
 	lookup := simplestreams.LookupParams{
@@ -22,6 +22,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -47,6 +52,11 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -872,6 +882,99 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// WatchVolumeResizes watches for changes to model-scoped volumes,
+// so that requests to resize them may be acted upon. Only the model
+// tag is accepted.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	canAccess, err := s.getScopeAuthFunc()
+	if err != nil {
+		return params.StringsWatchResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (string, []string, error) {
+		tag, err := names.ParseModelTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return "", nil, common.ErrPerm
+		}
+		w := s.sb.WatchModelVolumeResizes()
+		if changes, ok := <-w.Changes(); ok {
+			return s.resources.Register(w), changes, nil
+		}
+		return "", nil, watcher.EnsureErr(w)
+	}
+	for i, arg := range args.Entities {
+		var result params.StringsWatchResult
+		id, changes, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.StringsWatcherId = id
+			result.Changes = changes
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// ResizeVolumeParams returns the parameters for resizing the volumes
+// with the specified tags. A NotFound error is returned for volumes
+// that have no pending resize request.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if err != nil {
+			// Removed volumes are reported as not found, so
+			// that the provisioner can drop pending resizes.
+			return params.ResizeVolumeParams{}, err
+		}
+		size, ok := volume.ResizeSize()
+		if !ok || volume.Life() != state.Alive {
+			return params.ResizeVolumeParams{}, errors.NotFoundf(
+				"resize request for %s", names.ReadableString(tag),
+			)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		return params.ResizeVolumeParams{
+			Provider: string(provider),
+			VolumeId: volumeInfo.VolumeId,
+			Size:     size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		volumeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = volumeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -1121,9 +1224,11 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachmentParams(
 		}
 		var location string
 		var readOnly bool
+		var mountOptions []string
 		if filesystemAttachmentParams, ok := filesystemAttachment.Params(); ok {
 			location = filesystemAttachmentParams.Location
 			readOnly = filesystemAttachmentParams.ReadOnly
+			mountOptions = filesystemAttachmentParams.MountOptions
 		} else {
 			// Attachment parameters may be requested even if the
 			// attachment exists; i.e. for reattachment.
@@ -1133,6 +1238,7 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachmentParams(
 			}
 			location = filesystemAttachmentInfo.MountPoint
 			readOnly = filesystemAttachmentInfo.ReadOnly
+			mountOptions = filesystemAttachmentInfo.MountOptions
 		}
 		return params.FilesystemAttachmentParams{
 			FilesystemTag: filesystemAttachment.Filesystem().String(),
@@ -1143,8 +1249,9 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachmentParams(
 			// TODO(axw) dealias MountPoint. We now have
 			// Path, MountPoint and Location in different
 			// parts of the codebase.
-			MountPoint: location,
-			ReadOnly:   readOnly,
+			MountPoint:   location,
+			ReadOnly:     readOnly,
+			MountOptions: mountOptions,
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -54,7 +54,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv5
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -85,7 +85,7 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3))
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -117,7 +117,7 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3))
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -628,6 +628,61 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	storageTag := names.NewStorageTag("data/0")
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{storageVolume.Tag().String()},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: params.ResizeVolumeParams{
+				Provider: "modelscoped",
+				VolumeId: "zing",
+				Size:     1024,
+			},
+		}, {
+			Error: &params.Error{Message: `resize request for volume 2 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: `volume "42" not found`, Code: "not found"},
+		}},
+	})
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -887,6 +942,67 @@ func (s *iaasProvisionerSuite) TestFilesystemAttachmentParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestFilesystemAttachmentParamsMountOptions(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("inst-id"),
+		Filesystems: []state.HostFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "machinescoped", Size: 1024},
+			Attachment: state.FilesystemAttachmentParams{
+				Location:     "/srv",
+				MountOptions: []string{"noatime", "nodev"},
+			},
+		}, {
+			Filesystem: state.FilesystemParams{Pool: "modelscoped", Size: 2048},
+		}},
+	})
+	err := s.storageBackend.SetFilesystemInfo(names.NewFilesystemTag("1"), state.FilesystemInfo{
+		FilesystemId: "fsid",
+		Size:         2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetFilesystemAttachmentInfo(
+		names.NewMachineTag("0"),
+		names.NewFilesystemTag("1"),
+		state.FilesystemAttachmentInfo{
+			MountPoint:   "/in/the/place",
+			MountOptions: []string{"commit=60"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.FilesystemAttachmentParams(params.MachineStorageIds{
+		Ids: []params.MachineStorageId{{
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-0-0",
+		}, {
+			MachineTag:    "machine-0",
+			AttachmentTag: "filesystem-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.FilesystemAttachmentParamsResults{
+		Results: []params.FilesystemAttachmentParamsResult{
+			{Result: params.FilesystemAttachmentParams{
+				MachineTag:    "machine-0",
+				FilesystemTag: "filesystem-0-0",
+				InstanceId:    "inst-id",
+				Provider:      "machinescoped",
+				MountPoint:    "/srv",
+				MountOptions:  []string{"noatime", "nodev"},
+			}},
+			{Result: params.FilesystemAttachmentParams{
+				MachineTag:    "machine-0",
+				FilesystemTag: "filesystem-1",
+				InstanceId:    "inst-id",
+				FilesystemId:  "fsid",
+				Provider:      "modelscoped",
+				MountPoint:    "/in/the/place",
+				MountOptions:  []string{"commit=60"},
+			}},
+		},
+	})
+}
+
 func (s *iaasProvisionerSuite) TestSetVolumeAttachmentInfo(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
@@ -1014,6 +1130,38 @@ func (s *caasProvisionerSuite) TestWatchApplications(c *gc.C) {
 	wc.AssertChange("mysql")
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.Model.ModelTag().String()},
+		{"machine-0"},
+		{"environ-adb650da-b77b-4ee8-9cbb-d57a9a592847"},
+	}}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[0].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop it when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *iaasProvisionerSuite) TestWatchVolumes(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
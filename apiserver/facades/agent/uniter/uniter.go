@@ -37,10 +37,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v12) of the Uniter API,
-// Removes the embedded LXDProfileAPI, which in turn removes the following;
-// RemoveUpgradeCharmProfileData, WatchUnitLXDProfileUpgradeNotifications
-// and WatchLXDProfileUpgradeNotifications
+// UniterAPI implements the latest version (v16) of the Uniter API,
+// which adds Labels and UpdateLabels.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -70,11 +68,37 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
-// UniterAPIV11 implements the latest version (v11) of the Uniter API,
+// UniterAPIV15 implements version (v15) of the Uniter API,
+// which adds the hook name to CloudSpec.
+type UniterAPIV15 struct {
+	UniterAPI
+}
+
+// UniterAPIV14 implements version (v14) of the Uniter API,
+// which adds SetPendingHooks.
+type UniterAPIV14 struct {
+	UniterAPIV15
+}
+
+// UniterAPIV13 implements version (v13) of the Uniter API,
+// which adds RecordOperations.
+type UniterAPIV13 struct {
+	UniterAPIV14
+}
+
+// UniterAPIV12 implements version (v12) of the Uniter API,
+// Removes the embedded LXDProfileAPI, which in turn removes the following;
+// RemoveUpgradeCharmProfileData, WatchUnitLXDProfileUpgradeNotifications
+// and WatchLXDProfileUpgradeNotifications
+type UniterAPIV12 struct {
+	UniterAPIV13
+}
+
+// UniterAPIV11 implements version (v11) of the Uniter API,
 // which adds CloudAPIVersion.
 type UniterAPIV11 struct {
 	*LXDProfileAPI
-	UniterAPI
+	UniterAPIV12
 }
 
 // UniterAPIV10 adds WatchUnitLXDProfileUpgradeNotifications and
@@ -200,9 +224,53 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV15 creates an instance of the V15 uniter API.
+func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV15{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV14 creates an instance of the V14 uniter API.
+func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
+	uniterAPI, err := NewUniterAPIV15(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV14{
+		UniterAPIV15: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV13 creates an instance of the V13 uniter API.
+func NewUniterAPIV13(context facade.Context) (*UniterAPIV13, error) {
+	uniterAPI, err := NewUniterAPIV14(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV13{
+		UniterAPIV14: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV12 creates an instance of the V12 uniter API.
+func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
+	uniterAPI, err := NewUniterAPIV13(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{
+		UniterAPIV13: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV11 creates an instance of the V11 uniter API.
 func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV12(context)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +280,7 @@ func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
 	accessUnit := unitAccessor(authorizer, st)
 	return &UniterAPIV11{
 		LXDProfileAPI: NewExternalLXDProfileAPI(st, resources, authorizer, accessUnit, logger),
-		UniterAPI:     *uniterAPI,
+		UniterAPIV12:  *uniterAPI,
 	}, nil
 }
 
@@ -870,6 +938,146 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// RecordOperations isn't on the v12 API.
+func (u *UniterAPIV12) RecordOperations(_, _ struct{}) {}
+
+// RecordOperations adds the given operations to the operation history
+// of each unit.
+func (u *UniterAPI) RecordOperations(args params.UnitOperationsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		ops := make([]state.UnitOperation, len(arg.Operations))
+		for j, op := range arg.Operations {
+			ops[j] = state.UnitOperation{
+				Kind:        op.Kind,
+				Description: op.Description,
+				Started:     op.Started,
+				Completed:   op.Completed,
+				Error:       op.Error,
+			}
+		}
+		if err := unit.RecordOperations(ops...); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// Labels isn't on the v15 API.
+func (u *UniterAPIV15) Labels(_, _ struct{}) {}
+
+// Labels returns the labels attached to each given unit.
+func (u *UniterAPI) Labels(args params.Entities) (params.LabelsResults, error) {
+	result := params.LabelsResults{
+		Results: make([]params.LabelsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.LabelsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := u.labelledUnit(entity.Tag, canAccess)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Labels = unit.Labels()
+	}
+	return result, nil
+}
+
+// UpdateLabels isn't on the v15 API.
+func (u *UniterAPIV15) UpdateLabels(_, _ struct{}) {}
+
+// UpdateLabels sets and removes labels of each given unit.
+func (u *UniterAPI) UpdateLabels(args params.UpdateLabelsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		unit, err := u.labelledUnit(arg.Tag, canAccess)
+		if err == nil {
+			err = unit.UpdateLabels(arg.Set, arg.Remove)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// labelledUnit returns the unit with the given tag, if the
+// authenticated agent may access it.
+func (u *UniterAPI) labelledUnit(tagString string, canAccess common.AuthFunc) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return u.getUnit(tag)
+}
+
+// SetPendingHooks isn't on the v13 API.
+func (u *UniterAPIV13) SetPendingHooks(_, _ struct{}) {}
+
+// SetPendingHooks records the hooks that each unit's agent
+// is waiting to run.
+func (u *UniterAPI) SetPendingHooks(args params.UnitPendingHooksArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if err := unit.SetPendingHooks(arg.Pending); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
 // opened, for all given units.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
@@ -2519,7 +2727,20 @@ func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResult
 // authenticated unit or application resides.
 // A check is made beforehand to ensure that the request is made by an entity
 // that has been granted the appropriate trust.
-func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
+func (u *UniterAPIV14) CloudSpec() (params.CloudSpecResult, error) {
+	return u.cloudSpecForHook("")
+}
+
+// CloudSpec returns the cloud spec used by the model in which the
+// authenticated unit or application resides.
+// A check is made beforehand to ensure that the request is made by an entity
+// that has been granted the appropriate trust. Each access is recorded
+// against the application, along with the hook that requested it.
+func (u *UniterAPI) CloudSpec(args params.HookCloudSpecArgs) (params.CloudSpecResult, error) {
+	return u.cloudSpecForHook(args.Hook)
+}
+
+func (u *UniterAPI) cloudSpecForHook(hookName string) (params.CloudSpecResult, error) {
 	canAccess, err := u.accessCloudSpec()
 	if err != nil {
 		return params.CloudSpecResult{}, err
@@ -2527,10 +2748,37 @@ func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
 	if !canAccess() {
 		return params.CloudSpecResult{Error: common.ServerError(common.ErrPerm)}, nil
 	}
+	if err := u.recordCredentialAccess(hookName); err != nil {
+		return params.CloudSpecResult{Error: common.ServerError(err)}, nil
+	}
 
 	return u.cloudSpec.GetCloudSpec(u.m.Tag().(names.ModelTag)), nil
 }
 
+// recordCredentialAccess records that the authenticated unit or
+// application was given the model's cloud credential while running
+// the named hook.
+func (u *UniterAPI) recordCredentialAccess(hookName string) error {
+	var appName, unitName string
+	switch tag := u.auth.GetAuthTag().(type) {
+	case names.ApplicationTag:
+		appName = tag.Id()
+	case names.UnitTag:
+		unitName = tag.Id()
+		var err error
+		if appName, err = names.UnitApplication(unitName); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("expected names.UnitTag or names.ApplicationTag, got %T", tag)
+	}
+	app, err := u.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.RecordCredentialAccess(unitName, hookName))
+}
+
 // GoalStates returns information of charm units and relations.
 func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, error) {
 	result := params.GoalStateResults{
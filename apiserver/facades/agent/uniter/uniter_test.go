@@ -953,6 +953,104 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestLabels(c *gc.C) {
+	err := s.wordpressUnit.UpdateLabels(map[string]string{"env": "canary"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.Labels(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LabelsResults{
+		Results: []params.LabelsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Labels: map[string]string{"env": "canary"}},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdateLabels(c *gc.C) {
+	err := s.wordpressUnit.UpdateLabels(map[string]string{"env": "canary", "tier": "web"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UpdateLabelsArgs{Args: []params.UpdateLabelsArg{
+		{Tag: "unit-mysql-0", Set: map[string]string{"env": "prod"}},
+		{Tag: "unit-wordpress-0", Set: map[string]string{"env": "prod"}, Remove: []string{"tier"}},
+	}}
+	result, err := s.uniter.UpdateLabels(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.Labels(), jc.DeepEquals, map[string]string{"env": "prod"})
+}
+
+func (s *uniterSuite) TestRecordOperations(c *gc.C) {
+	started := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	op := params.UnitOperation{
+		Kind:        "run-hook",
+		Description: "run config-changed hook",
+		Started:     started,
+		Completed:   started.Add(time.Second),
+		Error:       "hook failed",
+	}
+	args := params.UnitOperationsArgs{Args: []params.UnitOperationsArg{
+		{Tag: "unit-mysql-0", Operations: []params.UnitOperation{op}},
+		{Tag: "unit-wordpress-0", Operations: []params.UnitOperation{op}},
+		{Tag: "unit-foo-42", Operations: []params.UnitOperation{op}},
+	}}
+	result, err := s.uniter.RecordOperations(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.OperationHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Kind, gc.Equals, "run-hook")
+	c.Assert(history[0].Description, gc.Equals, "run config-changed hook")
+	c.Assert(history[0].Started.Equal(started), jc.IsTrue)
+	c.Assert(history[0].Completed.Equal(started.Add(time.Second)), jc.IsTrue)
+	c.Assert(history[0].Error, gc.Equals, "hook failed")
+}
+
+func (s *uniterSuite) TestSetPendingHooks(c *gc.C) {
+	pending := []string{"config-changed", "update-status"}
+	args := params.UnitPendingHooksArgs{Args: []params.UnitPendingHooksArg{
+		{Tag: "unit-mysql-0", Pending: pending},
+		{Tag: "unit-wordpress-0", Pending: pending},
+		{Tag: "unit-foo-42", Pending: pending},
+	}}
+	result, err := s.uniter.SetPendingHooks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	queue, err := s.wordpressUnit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue.Pending, jc.DeepEquals, pending)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -4256,9 +4354,13 @@ func (s *uniterSuite) TestNetworkInfoCAASModelNoRelation(c *gc.C) {
 }
 
 func (s *uniterSuite) TestGetCloudSpecDeniesAccessWhenNotTrusted(c *gc.C) {
-	result, err := s.uniter.CloudSpec()
+	result, err := s.uniter.CloudSpec(params.HookCloudSpecArgs{Hook: "install"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, params.CloudSpecResult{Error: apiservertesting.ErrUnauthorized})
+
+	accesses, err := s.wordpress.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 0)
 }
 
 type cloudSpecUniterSuite struct {
@@ -4280,7 +4382,7 @@ func (s *cloudSpecUniterSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *cloudSpecUniterSuite) TestGetCloudSpecReturnsSpecWhenTrusted(c *gc.C) {
-	result, err := s.uniter.CloudSpec()
+	result, err := s.uniter.CloudSpec(params.HookCloudSpecArgs{})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result.Name, gc.Equals, "dummy")
@@ -4292,6 +4394,40 @@ func (s *cloudSpecUniterSuite) TestGetCloudSpecReturnsSpecWhenTrusted(c *gc.C) {
 	c.Assert(result.Result.Credential.Attributes, gc.DeepEquals, exp)
 }
 
+func (s *cloudSpecUniterSuite) TestGetCloudSpecRecordsAccess(c *gc.C) {
+	result, err := s.uniter.CloudSpec(params.HookCloudSpecArgs{Hook: "config-changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	accesses, err := s.wordpress.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 1)
+	c.Assert(accesses[0].Unit, gc.Equals, s.wordpressUnit.Name())
+	c.Assert(accesses[0].Hook, gc.Equals, "config-changed")
+}
+
+func (s *cloudSpecUniterSuite) TestGetCloudSpecDeniedOnceTrustRemoved(c *gc.C) {
+	result, err := s.uniter.CloudSpec(params.HookCloudSpecArgs{Hook: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	conf := map[string]interface{}{application.TrustConfigOptionName: false}
+	fields := map[string]environschema.Attr{application.TrustConfigOptionName: {Type: environschema.Tbool}}
+	defaults := map[string]interface{}{application.TrustConfigOptionName: false}
+	err = s.wordpress.UpdateApplicationConfig(conf, nil, fields, defaults)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.uniter.CloudSpec(params.HookCloudSpecArgs{Hook: "config-changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.CloudSpecResult{Error: apiservertesting.ErrUnauthorized})
+
+	// Only the access made while trusted is recorded.
+	accesses, err := s.wordpress.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 1)
+	c.Assert(accesses[0].Hook, gc.Equals, "install")
+}
+
 type fakeBroker struct {
 	caas.Broker
 }
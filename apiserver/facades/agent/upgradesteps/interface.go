@@ -4,6 +4,9 @@
 package upgradesteps
 
 import (
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
@@ -11,6 +14,8 @@ import (
 
 type UpgradeStepsState interface {
 	state.EntityFinder
+	SetUpgradeStepProgress(names.Tag, version.Number, string, state.UpgradeStepStatus, string) error
+	UpgradeStepsProgress(version.Number) ([]state.UpgradeStepProgress, error)
 }
 
 // Machine represents point of use methods from the state machine object
@@ -19,3 +24,9 @@ type Machine interface {
 	ModificationStatus() (status.StatusInfo, error)
 	SetModificationStatus(status.StatusInfo) error
 }
+
+// Unit represents point of use methods from the state unit object
+type Unit interface {
+	AssignedMachineId() (string, error)
+	SetState(state.UnitState) error
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/facades/agent/upgradesteps (interfaces: UpgradeStepsState,Machine)
+// Source: github.com/juju/juju/apiserver/facades/agent/upgradesteps (interfaces: UpgradeStepsState,Machine,Unit)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -9,6 +9,7 @@ import (
 	instance "github.com/juju/juju/core/instance"
 	status "github.com/juju/juju/core/status"
 	state "github.com/juju/juju/state"
+	version "github.com/juju/version"
 	names_v2 "gopkg.in/juju/names.v2"
 	reflect "reflect"
 )
@@ -49,6 +50,31 @@ func (mr *MockUpgradeStepsStateMockRecorder) FindEntity(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEntity", reflect.TypeOf((*MockUpgradeStepsState)(nil).FindEntity), arg0)
 }
 
+// SetUpgradeStepProgress mocks base method
+func (m *MockUpgradeStepsState) SetUpgradeStepProgress(arg0 names_v2.Tag, arg1 version.Number, arg2 string, arg3 state.UpgradeStepStatus, arg4 string) error {
+	ret := m.ctrl.Call(m, "SetUpgradeStepProgress", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUpgradeStepProgress indicates an expected call of SetUpgradeStepProgress
+func (mr *MockUpgradeStepsStateMockRecorder) SetUpgradeStepProgress(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUpgradeStepProgress", reflect.TypeOf((*MockUpgradeStepsState)(nil).SetUpgradeStepProgress), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpgradeStepsProgress mocks base method
+func (m *MockUpgradeStepsState) UpgradeStepsProgress(arg0 version.Number) ([]state.UpgradeStepProgress, error) {
+	ret := m.ctrl.Call(m, "UpgradeStepsProgress", arg0)
+	ret0, _ := ret[0].([]state.UpgradeStepProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeStepsProgress indicates an expected call of UpgradeStepsProgress
+func (mr *MockUpgradeStepsStateMockRecorder) UpgradeStepsProgress(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeStepsProgress", reflect.TypeOf((*MockUpgradeStepsState)(nil).UpgradeStepsProgress), arg0)
+}
+
 // MockMachine is a mock of Machine interface
 type MockMachine struct {
 	ctrl     *gomock.Controller
@@ -108,3 +134,51 @@ func (m *MockMachine) SetModificationStatus(arg0 status.StatusInfo) error {
 func (mr *MockMachineMockRecorder) SetModificationStatus(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetModificationStatus", reflect.TypeOf((*MockMachine)(nil).SetModificationStatus), arg0)
 }
+
+// MockUnit is a mock of Unit interface
+type MockUnit struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnitMockRecorder
+}
+
+// MockUnitMockRecorder is the mock recorder for MockUnit
+type MockUnitMockRecorder struct {
+	mock *MockUnit
+}
+
+// NewMockUnit creates a new mock instance
+func NewMockUnit(ctrl *gomock.Controller) *MockUnit {
+	mock := &MockUnit{ctrl: ctrl}
+	mock.recorder = &MockUnitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUnit) EXPECT() *MockUnitMockRecorder {
+	return m.recorder
+}
+
+// AssignedMachineId mocks base method
+func (m *MockUnit) AssignedMachineId() (string, error) {
+	ret := m.ctrl.Call(m, "AssignedMachineId")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignedMachineId indicates an expected call of AssignedMachineId
+func (mr *MockUnitMockRecorder) AssignedMachineId() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignedMachineId", reflect.TypeOf((*MockUnit)(nil).AssignedMachineId))
+}
+
+// SetState mocks base method
+func (m *MockUnit) SetState(arg0 state.UnitState) error {
+	ret := m.ctrl.Call(m, "SetState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetState indicates an expected call of SetState
+func (mr *MockUnitMockRecorder) SetState(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockUnit)(nil).SetState), arg0)
+}
@@ -4,8 +4,11 @@
 package upgradesteps
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -13,9 +16,10 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
 )
 
-//go:generate mockgen -package mocks -destination mocks/upgradesteps_mock.go github.com/juju/juju/apiserver/facades/agent/upgradesteps UpgradeStepsState,Machine
+//go:generate mockgen -package mocks -destination mocks/upgradesteps_mock.go github.com/juju/juju/apiserver/facades/agent/upgradesteps UpgradeStepsState,Machine,Unit
 //go:generate mockgen -package mocks -destination mocks/state_mock.go github.com/juju/juju/state EntityFinder,Entity
 
 var logger = loggo.GetLogger("juju.apiserver.upgradesteps")
@@ -24,6 +28,14 @@ type UpgradeStepsV1 interface {
 	ResetKVMMachineModificationStatusIdle(params.Entity) (params.ErrorResult, error)
 }
 
+type UpgradeStepsV2 interface {
+	UpgradeStepsV1
+	PendingUpgradeSteps(params.UpgradeStepArgs) (params.PendingUpgradeStepsResults, error)
+	RunUpgradeStep(params.UpgradeStepArgs) (params.ErrorResults, error)
+	SetUpgradeStepProgress(params.SetUpgradeStepProgressArgs) (params.ErrorResults, error)
+	WriteAgentState(params.SetUnitStateArgs) (params.ErrorResults, error)
+}
+
 type UpgradeStepsAPI struct {
 	st          UpgradeStepsState
 	resources   facade.Resources
@@ -31,17 +43,35 @@ type UpgradeStepsAPI struct {
 	getAuthFunc common.GetAuthFunc
 }
 
+// UpgradeStepsAPIV1 provides the v1 UpgradeSteps API. The only
+// difference between this and v2 is that v1 doesn't have the
+// PendingUpgradeSteps, RunUpgradeStep, SetUpgradeStepProgress and
+// WriteAgentState methods.
+type UpgradeStepsAPIV1 struct {
+	*UpgradeStepsAPI
+}
+
 // using apiserver/facades/client/cloud as an example.
 var (
-	_ UpgradeStepsV1 = (*UpgradeStepsAPI)(nil)
+	_ UpgradeStepsV2 = (*UpgradeStepsAPI)(nil)
+	_ UpgradeStepsV1 = (*UpgradeStepsAPIV1)(nil)
 )
 
-// NewFacadeV1 is used for API registration.
-func NewFacadeV1(ctx facade.Context) (*UpgradeStepsAPI, error) {
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*UpgradeStepsAPI, error) {
 	st := &upgradeStepsStateShim{State: ctx.State()}
 	return NewUpgradeStepsAPI(st, ctx.Resources(), ctx.Auth())
 }
 
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(ctx facade.Context) (*UpgradeStepsAPIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UpgradeStepsAPIV1{api}, nil
+}
+
 func NewUpgradeStepsAPI(st UpgradeStepsState,
 	resources facade.Resources,
 	authorizer facade.Authorizer,
@@ -59,6 +89,25 @@ func NewUpgradeStepsAPI(st UpgradeStepsState,
 	}, nil
 }
 
+// upgradeStep is an upgrade step run by the controller on behalf of
+// a machine agent.
+type upgradeStep struct {
+	// applies reports whether the step needs to be run for the machine.
+	applies func(Machine) bool
+
+	// run performs the step for the machine.
+	run func(Machine) error
+}
+
+// upgradeSteps holds the upgrade steps that agents may ask the
+// controller to run, keyed by name.
+var upgradeSteps = map[string]upgradeStep{
+	"reset-kvm-machine-modification-status-idle": {
+		applies: func(m Machine) bool { return m.ContainerType() == instance.KVM },
+		run:     resetKVMMachineModificationStatusIdle,
+	},
+}
+
 // ResetKVMMachineModificationStatusIdle sets the modification status
 // of a kvm machine to idle if it is in an error state before upgrade.
 // Related to lp:1829393.
@@ -78,23 +127,218 @@ func (api *UpgradeStepsAPI) ResetKVMMachineModificationStatusIdle(arg params.Ent
 		return result, errors.Trace(err)
 	}
 
+	result.Error = common.ServerError(resetKVMMachineModificationStatusIdle(m))
+	return result, nil
+}
+
+func resetKVMMachineModificationStatusIdle(m Machine) error {
 	if m.ContainerType() != instance.KVM {
 		// noop
-		return result, nil
+		return nil
 	}
 
 	modStatus, err := m.ModificationStatus()
 	if err != nil {
-		result.Error = common.ServerError(err)
-		return result, nil
+		return err
 	}
 
 	if modStatus.Status == status.Error {
-		err = m.SetModificationStatus(status.StatusInfo{Status: status.Idle})
-		result.Error = common.ServerError(err)
+		return m.SetModificationStatus(status.StatusInfo{Status: status.Idle})
 	}
+	return nil
+}
 
-	return result, nil
+// PendingUpgradeSteps isn't on the v1 API.
+func (api *UpgradeStepsAPIV1) PendingUpgradeSteps() {}
+
+// PendingUpgradeSteps returns the names of the upgrade steps that the
+// controller can run for each machine, and that have not yet completed
+// for the machine's upgrade to the given version.
+func (api *UpgradeStepsAPI) PendingUpgradeSteps(args params.UpgradeStepArgs) (params.PendingUpgradeStepsResults, error) {
+	results := params.PendingUpgradeStepsResults{
+		Results: make([]params.PendingUpgradeStepsResult, len(args.Args)),
+	}
+	canAccess, err := api.getAuthFunc()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		steps, err := api.pendingUpgradeSteps(canAccess, arg)
+		results.Results[i].Steps = steps
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *UpgradeStepsAPI) pendingUpgradeSteps(canAccess common.AuthFunc, arg params.UpgradeStepArg) ([]string, error) {
+	mTag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	m, err := api.getMachine(canAccess, mTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	completed, err := api.completedSteps(mTag, arg.Version)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var pending []string
+	for name, step := range upgradeSteps {
+		if !completed[name] && step.applies(m) {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+func (api *UpgradeStepsAPI) completedSteps(tag names.Tag, vers version.Number) (map[string]bool, error) {
+	progress, err := api.st.UpgradeStepsProgress(vers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	completed := make(map[string]bool)
+	for _, p := range progress {
+		if p.Tag == tag.String() && p.Status == state.UpgradeStepCompleted {
+			completed[p.Step] = true
+		}
+	}
+	return completed, nil
+}
+
+// RunUpgradeStep isn't on the v1 API.
+func (api *UpgradeStepsAPIV1) RunUpgradeStep() {}
+
+// RunUpgradeStep runs the named upgrade step for each machine,
+// recording the step's progress against the machine's upgrade to the
+// given version.
+func (api *UpgradeStepsAPI) RunUpgradeStep(args params.UpgradeStepArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := api.getAuthFunc()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := api.runUpgradeStep(canAccess, arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *UpgradeStepsAPI) runUpgradeStep(canAccess common.AuthFunc, arg params.UpgradeStepArg) error {
+	mTag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	m, err := api.getMachine(canAccess, mTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	step, ok := upgradeSteps[arg.Step]
+	if !ok {
+		return errors.NotFoundf("upgrade step %q", arg.Step)
+	}
+
+	setProgress := func(status state.UpgradeStepStatus, message string) error {
+		return api.st.SetUpgradeStepProgress(mTag, arg.Version, arg.Step, status, message)
+	}
+	if err := setProgress(state.UpgradeStepRunning, ""); err != nil {
+		return errors.Trace(err)
+	}
+	if err := step.run(m); err != nil {
+		logger.Errorf("upgrade step %q for %s failed: %v", arg.Step, mTag.Id(), err)
+		if err := setProgress(state.UpgradeStepFailed, err.Error()); err != nil {
+			logger.Warningf("cannot record failure of upgrade step %q: %v", arg.Step, err)
+		}
+		return errors.Trace(err)
+	}
+	return errors.Trace(setProgress(state.UpgradeStepCompleted, ""))
+}
+
+// SetUpgradeStepProgress isn't on the v1 API.
+func (api *UpgradeStepsAPIV1) SetUpgradeStepProgress() {}
+
+// SetUpgradeStepProgress records the status of upgrade steps run by
+// machine agents, so that the progress of an upgrade can be followed
+// step by step.
+func (api *UpgradeStepsAPI) SetUpgradeStepProgress(args params.SetUpgradeStepProgressArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := api.getAuthFunc()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		mTag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(mTag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = api.st.SetUpgradeStepProgress(
+			mTag, arg.Version, arg.Step, state.UpgradeStepStatus(arg.Status), arg.Message)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// WriteAgentState isn't on the v1 API.
+func (api *UpgradeStepsAPIV1) WriteAgentState() {}
+
+// WriteAgentState stores the state that unit agents previously kept
+// on their machine's disk in the controller. It is called by machine
+// agents while upgrading, for the units deployed to the machine.
+func (api *UpgradeStepsAPI) WriteAgentState(args params.SetUnitStateArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.writeAgentState(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *UpgradeStepsAPI) writeAgentState(arg params.SetUnitStateArg) error {
+	uTag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	u, err := api.getUnit(uTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(u.SetState(state.UnitState{
+		UniterState:   arg.UniterState,
+		RelationState: arg.RelationState,
+		StorageState:  arg.StorageState,
+	}))
+}
+
+// getUnit returns the unit with the given tag, provided that it is
+// deployed to the authenticated machine.
+func (api *UpgradeStepsAPI) getUnit(tag names.UnitTag) (Unit, error) {
+	entity, err := api.st.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, ok := entity.(Unit)
+	if !ok {
+		return nil, errors.NotValidf("unit entity")
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !api.authorizer.AuthOwner(names.NewMachineTag(machineId)) {
+		return nil, common.ErrPerm
+	}
+	return unit, nil
 }
 
 func (api *UpgradeStepsAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (Machine, error) {
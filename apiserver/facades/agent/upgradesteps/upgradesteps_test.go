@@ -8,6 +8,7 @@ import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -30,6 +31,7 @@ type upgradeStepsSuite struct {
 	authorizer *facademocks.MockAuthorizer
 	entity     *mocks.MockEntity
 	machine    *mocks.MockMachine
+	unit       *mocks.MockUnit
 	resources  *facademocks.MockResources
 	state      *mocks.MockUpgradeStepsState
 }
@@ -106,12 +108,195 @@ func (s *upgradeStepsSuite) TestResetKVMMachineModificationStatusIdleLXD(c *gc.C
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *upgradeStepsSuite) TestPendingUpgradeSteps(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	s.expectFindEntity()
+	s.expectContainerType(instance.KVM)
+	s.state.EXPECT().UpgradeStepsProgress(version.MustParse("2.7.0")).Return(nil, nil)
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.PendingUpgradeSteps(params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: version.MustParse("2.7.0")}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.PendingUpgradeStepsResults{
+		Results: []params.PendingUpgradeStepsResult{{
+			Steps: []string{"reset-kvm-machine-modification-status-idle"},
+		}},
+	})
+}
+
+func (s *upgradeStepsSuite) TestPendingUpgradeStepsCompleted(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	s.expectFindEntity()
+	s.state.EXPECT().UpgradeStepsProgress(version.MustParse("2.7.0")).Return([]state.UpgradeStepProgress{{
+		Tag:    s.tag.String(),
+		Step:   "reset-kvm-machine-modification-status-idle",
+		Status: state.UpgradeStepCompleted,
+	}}, nil)
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.PendingUpgradeSteps(params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: version.MustParse("2.7.0")}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.PendingUpgradeStepsResults{
+		Results: []params.PendingUpgradeStepsResult{{}},
+	})
+}
+
+func (s *upgradeStepsSuite) TestRunUpgradeStep(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	s.expectFindEntity()
+	s.expectContainerType(instance.KVM)
+	s.expectModificationStatus(status.Error)
+	s.expectSetModificationStatus(nil)
+	step := "reset-kvm-machine-modification-status-idle"
+	vers := version.MustParse("2.7.0")
+	gomock.InOrder(
+		s.state.EXPECT().SetUpgradeStepProgress(s.tag, vers, step, state.UpgradeStepRunning, ""),
+		s.state.EXPECT().SetUpgradeStepProgress(s.tag, vers, step, state.UpgradeStepCompleted, ""),
+	)
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.RunUpgradeStep(params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: vers, Step: step}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+}
+
+func (s *upgradeStepsSuite) TestRunUpgradeStepFailed(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	s.expectFindEntity()
+	s.expectContainerType(instance.KVM)
+	s.machine.EXPECT().ModificationStatus().Return(status.StatusInfo{}, errors.New("boom"))
+	step := "reset-kvm-machine-modification-status-idle"
+	vers := version.MustParse("2.7.0")
+	gomock.InOrder(
+		s.state.EXPECT().SetUpgradeStepProgress(s.tag, vers, step, state.UpgradeStepRunning, ""),
+		s.state.EXPECT().SetUpgradeStepProgress(s.tag, vers, step, state.UpgradeStepFailed, "boom"),
+	)
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.RunUpgradeStep(params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: vers, Step: step}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "boom")
+}
+
+func (s *upgradeStepsSuite) TestRunUpgradeStepUnknown(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	s.expectFindEntity()
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.RunUpgradeStep(params.UpgradeStepArgs{
+		Args: []params.UpgradeStepArg{{Tag: s.tag.String(), Version: version.MustParse("2.7.0"), Step: "bogus"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `upgrade step "bogus" not found`)
+}
+
+func (s *upgradeStepsSuite) TestSetUpgradeStepProgress(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	vers := version.MustParse("2.7.0")
+	s.state.EXPECT().SetUpgradeStepProgress(s.tag, vers, "migrate things", state.UpgradeStepFailed, "boom")
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.SetUpgradeStepProgress(params.SetUpgradeStepProgressArgs{
+		Args: []params.SetUpgradeStepProgressArg{{
+			UpgradeStepArg: params.UpgradeStepArg{Tag: s.tag.String(), Version: vers, Step: "migrate things"},
+			Status:         "failed",
+			Message:        "boom",
+		}, {
+			UpgradeStepArg: params.UpgradeStepArg{Tag: "machine-1-lxd-0", Version: vers, Step: "migrate things"},
+			Status:         "running",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *upgradeStepsSuite) TestWriteAgentState(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	unitTag := names.NewUnitTag("wordpress/0")
+	s.state.EXPECT().FindEntity(unitTag).Return(unitEntityShim{Unit: s.unit, Entity: s.entity}, nil)
+	s.unit.EXPECT().AssignedMachineId().Return("0/kvm/0", nil)
+	s.authorizer.EXPECT().AuthOwner(s.tag).Return(true)
+	s.unit.EXPECT().SetState(state.UnitState{
+		UniterState:   "started: true\n",
+		RelationState: map[int]string{1: "change-version: 2\n"},
+	}).Return(nil)
+	s.state.EXPECT().FindEntity(names.NewUnitTag("mysql/0")).Return(nil, errors.NotFoundf("unit"))
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.WriteAgentState(params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{
+			Tag:           unitTag.String(),
+			UniterState:   "started: true\n",
+			RelationState: map[int]string{1: "change-version: 2\n"},
+		}, {
+			Tag: "mysql-0",
+		}, {
+			Tag: "unit-mysql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *upgradeStepsSuite) TestWriteAgentStateOtherMachine(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthCalls()
+	unitTag := names.NewUnitTag("wordpress/0")
+	s.state.EXPECT().FindEntity(unitTag).Return(unitEntityShim{Unit: s.unit, Entity: s.entity}, nil)
+	s.unit.EXPECT().AssignedMachineId().Return("1", nil)
+	s.authorizer.EXPECT().AuthOwner(names.NewMachineTag("1")).Return(false)
+
+	s.setupFacadeAPI(c)
+
+	result, err := s.api.WriteAgentState(params.SetUnitStateArgs{
+		Args: []params.SetUnitStateArg{{Tag: unitTag.String(), UniterState: "started: true\n"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+}
+
 func (s *upgradeStepsSuite) setup(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
 	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
 	s.entity = mocks.NewMockEntity(ctrl)
 	s.machine = mocks.NewMockMachine(ctrl)
+	s.unit = mocks.NewMockUnit(ctrl)
 	s.state = mocks.NewMockUpgradeStepsState(ctrl)
 	s.resources = facademocks.NewMockResources(ctrl)
 
@@ -162,3 +347,8 @@ type machineEntityShim struct {
 	upgradesteps.Machine
 	state.Entity
 }
+
+type unitEntityShim struct {
+	upgradesteps.Unit
+	state.Entity
+}
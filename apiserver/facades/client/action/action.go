@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -32,6 +33,11 @@ type APIv2 struct {
 
 // APIv3 provides the Action API facade for version 3.
 type APIv3 struct {
+	*APIv4
+}
+
+// APIv4 provides the Action API facade for version 4.
+type APIv4 struct {
 	*ActionAPI
 }
 
@@ -46,13 +52,22 @@ func NewActionAPIV2(ctx facade.Context) (*APIv2, error) {
 
 // NewActionAPIV3 returns an initialized ActionAPI for version 3.
 func NewActionAPIV3(ctx facade.Context) (*APIv3, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV4(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv3{api}, nil
 }
 
+// NewActionAPIV4 returns an initialized ActionAPI for version 4.
+func NewActionAPIV4(ctx facade.Context) (*APIv4, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -203,7 +218,8 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
-// Action.
+// Action. If an Action has a notification URL, the controller posts
+// the Action's result to it once the Action finishes.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -243,13 +259,20 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		if action.NotifyURL != "" {
+			if err := actions.ValidateNotifyURL(action.NotifyURL); err != nil {
+				currentResult.Error = common.ServerError(err)
+				continue
+			}
+		}
+		enqueued, err := receiver.AddActionWithNotifyURL(action.Name, action.Parameters, action.NotifyURL)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
 
 		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued)
+		response.Results[i].Action.NotifyURL = action.NotifyURL
 	}
 	return response, nil
 }
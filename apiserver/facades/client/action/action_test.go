@@ -269,6 +269,39 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithNotifyURL(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{{
+			Receiver:  s.wordpressUnit.Tag().String(),
+			Name:      "fakeaction",
+			NotifyURL: "https://chat.example.com/hooks/juju",
+		}, {
+			Receiver:  s.wordpressUnit.Tag().String(),
+			Name:      "fakeaction",
+			NotifyURL: "http://chat.example.com/hooks/juju",
+		}, {
+			Receiver:  s.wordpressUnit.Tag().String(),
+			Name:      "fakeaction",
+			NotifyURL: "https://169.254.169.254/latest/meta-data",
+		}},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.NotifyURL, gc.Equals, "https://chat.example.com/hooks/juju")
+	c.Assert(res.Results[1].Error, gc.ErrorMatches,
+		`notification URL "http://chat.example.com/hooks/juju" \(expected an https URL\) not valid`)
+	c.Assert(res.Results[2].Error, gc.ErrorMatches,
+		`notification URL "https://169.254.169.254/latest/meta-data" \(local and private addresses are not allowed\) not valid`)
+
+	// Only the action with a valid notification URL was enqueued.
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].NotifyURL(), gc.Equals, "https://chat.example.com/hooks/juju")
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
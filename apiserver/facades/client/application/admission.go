@@ -0,0 +1,263 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/admission"
+	"github.com/juju/juju/core/constraints"
+)
+
+// admissionClient is the HTTP client used to consult the
+// controller's admission policy endpoint.
+var admissionClient = &http.Client{Timeout: 30 * time.Second}
+
+// newAdmissionChecker returns a checker for the admission policy
+// endpoint configured for the controller, or nil if there is none.
+func newAdmissionChecker(cfg controller.Config) admission.Checker {
+	policyURL := cfg.AdmissionPolicyURL()
+	if policyURL == "" {
+		return nil
+	}
+	return admission.NewWebhookChecker(policyURL, cfg.AdmissionFailurePolicy(), admissionClient)
+}
+
+// admit checks the request against the controller's admission
+// policy, and returns the policy's decision if it is allowed.
+func (api *APIBase) admit(req admission.Request) (admission.Decision, error) {
+	if api.admission == nil {
+		return admission.Decision{Allowed: true}, nil
+	}
+	req.ModelUUID = api.model.ModelTag().Id()
+	req.ModelName = api.model.Name()
+	if tag := api.authorizer.GetAuthTag(); tag != nil {
+		req.User = tag.String()
+	}
+	subject := req.Application
+	if subject == "" {
+		subject = strings.Join(req.Endpoints, " ")
+	}
+	decision, err := api.admission.Check(req)
+	if err != nil {
+		return admission.Decision{}, errors.Annotatef(err, "checking %s of %q against admission policy", req.Operation, subject)
+	}
+	if err := decision.Err(req.Operation); err != nil {
+		logger.Infof("%s of %q by %s: %v", req.Operation, subject, req.User, err)
+		return admission.Decision{}, err
+	}
+	if decision.Mutated() {
+		logger.Infof("admission policy changed %s of %q by %s", req.Operation, subject, req.User)
+	}
+	return decision, nil
+}
+
+// admitDeploy checks the deployment against the controller's admission
+// policy, applying any changes to its config or constraints required
+// by the policy.
+func (api *APIBase) admitDeploy(arg *params.ApplicationDeploy) error {
+	placement := make([]string, len(arg.Placement))
+	for i, p := range arg.Placement {
+		placement[i] = p.String()
+	}
+	decision, err := api.admit(admission.Request{
+		Operation:   admission.Deploy,
+		Application: arg.ApplicationName,
+		CharmURL:    arg.CharmURL,
+		Channel:     arg.Channel,
+		Series:      arg.Series,
+		NumUnits:    arg.NumUnits,
+		Config:      arg.Config,
+		ConfigYAML:  arg.ConfigYAML,
+		Constraints: arg.Constraints.String(),
+		Placement:   placement,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Constraints, err = applyAdmissionConstraints(arg.Constraints, decision.Constraints); err != nil {
+		return errors.Trace(err)
+	}
+	arg.Config, arg.ConfigYAML, err = applyAdmissionConfig(arg.ApplicationName, arg.Config, arg.ConfigYAML, decision.Config)
+	return errors.Trace(err)
+}
+
+// admitUpdate checks the update against the controller's admission
+// policy, applying any changes to its config or constraints required
+// by the policy.
+func (api *APIBase) admitUpdate(args *params.ApplicationUpdate) error {
+	req := admission.Request{
+		Operation:   admission.Update,
+		Application: args.ApplicationName,
+		CharmURL:    args.CharmURL,
+		Config:      args.SettingsStrings,
+		ConfigYAML:  args.SettingsYAML,
+		Force:       args.ForceCharmURL || args.ForceSeries || args.Force,
+	}
+	if args.MinUnits != nil {
+		req.NumUnits = *args.MinUnits
+	}
+	if args.Constraints != nil {
+		req.Constraints = args.Constraints.String()
+	}
+	decision, err := api.admit(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if decision.Constraints != nil {
+		var cons constraints.Value
+		if args.Constraints != nil {
+			cons = *args.Constraints
+		}
+		if cons, err = applyAdmissionConstraints(cons, decision.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+		args.Constraints = &cons
+	}
+	args.SettingsStrings, args.SettingsYAML, err = applyAdmissionConfig(
+		args.ApplicationName, args.SettingsStrings, args.SettingsYAML, decision.Config,
+	)
+	return errors.Trace(err)
+}
+
+// admitSetConfig checks the config change against the controller's
+// admission policy, and returns the config with any changes required
+// by the policy applied.
+func (api *APIBase) admitSetConfig(appName string, config map[string]string) (map[string]string, error) {
+	decision, err := api.admit(admission.Request{
+		Operation:   admission.SetConfig,
+		Application: appName,
+		Config:      config,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, _, err = applyAdmissionConfig(appName, config, "", decision.Config)
+	return config, errors.Trace(err)
+}
+
+// admitSetConstraints checks the constraints change against the
+// controller's admission policy, and returns the constraints the
+// policy requires.
+func (api *APIBase) admitSetConstraints(appName string, cons constraints.Value) (constraints.Value, error) {
+	decision, err := api.admit(admission.Request{
+		Operation:   admission.SetConstraints,
+		Application: appName,
+		Constraints: cons.String(),
+	})
+	if err != nil {
+		return constraints.Value{}, errors.Trace(err)
+	}
+	return applyAdmissionConstraints(cons, decision.Constraints)
+}
+
+// admitSetCharm checks the charm upgrade against the controller's
+// admission policy, applying any changes to its config required by
+// the policy.
+func (api *APIBase) admitSetCharm(args *params.ApplicationSetCharm) error {
+	decision, err := api.admit(admission.Request{
+		Operation:   admission.SetCharm,
+		Application: args.ApplicationName,
+		CharmURL:    args.CharmURL,
+		Channel:     args.Channel,
+		Config:      args.ConfigSettings,
+		ConfigYAML:  args.ConfigSettingsYAML,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args.ConfigSettings, args.ConfigSettingsYAML, err = applyAdmissionConfig(
+		args.ApplicationName, args.ConfigSettings, args.ConfigSettingsYAML, decision.Config,
+	)
+	return errors.Trace(err)
+}
+
+// admitRelation checks the change to the relation between the given
+// endpoints against the controller's admission policy.
+func (api *APIBase) admitRelation(op admission.Operation, endpoints []string, force bool) error {
+	_, err := api.admit(admission.Request{
+		Operation: op,
+		Endpoints: endpoints,
+		Force:     force,
+	})
+	return errors.Trace(err)
+}
+
+// admitResolve checks the resolving of the unit's error
+// against the controller's admission policy.
+func (api *APIBase) admitResolve(unitName string) error {
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = api.admit(admission.Request{
+		Operation:   admission.Resolve,
+		Application: appName,
+		Units:       []string{unitName},
+	})
+	return errors.Trace(err)
+}
+
+// applyAdmissionConstraints returns the constraints required by the
+// admission policy, if it requires any, and otherwise the given ones.
+func applyAdmissionConstraints(cons constraints.Value, required *string) (constraints.Value, error) {
+	if required == nil {
+		return cons, nil
+	}
+	cons, err := constraints.Parse(*required)
+	if err != nil {
+		return constraints.Value{}, errors.Annotate(err, "admission policy returned invalid constraints")
+	}
+	return cons, nil
+}
+
+// applyAdmissionConfig overrides the config of the named application
+// with the values required by the admission policy. The config YAML,
+// which takes precedence over the config map, is updated if it is set.
+func applyAdmissionConfig(
+	appName string, config map[string]string, configYAML string, overrides map[string]string,
+) (map[string]string, string, error) {
+	if len(overrides) == 0 {
+		return config, configYAML, nil
+	}
+	if configYAML == "" {
+		merged := make(map[string]string)
+		for k, v := range config {
+			merged[k] = v
+		}
+		for k, v := range overrides {
+			merged[k] = v
+		}
+		return merged, "", nil
+	}
+
+	var allSettings map[string]map[string]interface{}
+	if err := goyaml.Unmarshal([]byte(configYAML), &allSettings); err != nil {
+		return nil, "", errors.Annotate(err, "cannot parse settings data")
+	}
+	if allSettings == nil {
+		allSettings = make(map[string]map[string]interface{})
+	}
+	settings := allSettings[appName]
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	for k, v := range overrides {
+		settings[k] = v
+	}
+	allSettings[appName] = settings
+	out, err := goyaml.Marshal(allSettings)
+	if err != nil {
+		return nil, "", errors.Annotate(err, "cannot marshall charm settings")
+	}
+	return config, string(out), nil
+}
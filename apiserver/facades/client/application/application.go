@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"sort"
+	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/schema"
@@ -29,6 +32,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/admission"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -81,6 +85,24 @@ type APIv9 struct {
 // APIv10 provides the Application API facade for version 10.
 // It adds --force and --max-wait parameters to remove-saas.
 type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+// It adds MergeBindings.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// It adds ConfigMap to SetCharm.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds CredentialAccesses.
+type APIv13 struct {
 	*APIBase
 }
 
@@ -110,6 +132,10 @@ type APIBase struct {
 	registry              storage.ProviderRegistry
 	storageValidator      caas.StorageValidator
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
+
+	// admission, if set, is consulted before any change
+	// to the model's applications, units or relations.
+	admission admission.Checker
 }
 
 // NewFacadeV4 provides the signature required for facade registration
@@ -171,13 +197,37 @@ func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
 }
 
 func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV11(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv10{api}, nil
 }
 
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacadeV12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	facadeModel, err := ctx.State().Model()
 	if err != nil {
@@ -218,6 +268,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		registry,
 		resources,
 		storageValidator,
+		newAdmissionChecker(ctx.ControllerConfig()),
 	)
 }
 
@@ -234,6 +285,7 @@ func NewAPIBase(
 	registry storage.ProviderRegistry,
 	resources facade.Resources,
 	storageValidator caas.StorageValidator,
+	admissionChecker admission.Checker,
 ) (*APIBase, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -251,6 +303,7 @@ func NewAPIBase(
 		registry:              registry,
 		resources:             resources,
 		storageValidator:      storageValidator,
+		admission:             admissionChecker,
 	}, nil
 }
 
@@ -290,6 +343,13 @@ func (api *APIBase) SetMetricCredentials(args params.ApplicationMetricCredential
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if _, err := api.admit(admission.Request{
+			Operation:   admission.SetMetricCredentials,
+			Application: a.ApplicationName,
+		}); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		err = oneApplication.SetMetricCredentials(a.MetricCredentials)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
@@ -366,7 +426,10 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 	}
 
 	for i, arg := range args.Applications {
-		err := deployApplication(api.backend, api.model, api.stateCharm, arg, api.deployApplicationFunc, api.storagePoolManager, api.registry, api.storageValidator)
+		err := api.admitDeploy(&arg)
+		if err == nil {
+			err = deployApplication(api.backend, api.model, api.stateCharm, arg, api.deployApplicationFunc, api.storagePoolManager, api.registry, api.storageValidator)
+		}
 		result.Results[i].Error = common.ServerError(err)
 
 		if err != nil && len(arg.Resources) != 0 {
@@ -393,7 +456,8 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		// Spreading units only applies to machines.
+		return addSpreadSchemaAndDefaults(trustFields, trustDefaults)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -549,6 +613,16 @@ func deployApplication(
 	if err := checkMachinePlacement(backend, args); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkUnitQuotas(backend, modelType, args.NumUnits, args.Placement); err != nil {
+		return errors.Trace(err)
+	}
+
+	endpointBindings := args.EndpointBindings
+	if modelType == state.ModelTypeIAAS {
+		if endpointBindings, err = bindingsWithDefaultSpace(model, endpointBindings); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Try to find the charm URL in state first.
 	ch, err := backend.Charm(curl)
@@ -594,6 +668,11 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if modelType == state.ModelTypeCAAS {
+		if err := caas.ValidateConfig(applicationConfig.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -640,12 +719,36 @@ func deployApplication(
 		Storage:           args.Storage,
 		Devices:           args.Devices,
 		AttachStorage:     attachStorage,
-		EndpointBindings:  args.EndpointBindings,
+		EndpointBindings:  endpointBindings,
 		Resources:         args.Resources,
 	})
 	return errors.Trace(err)
 }
 
+// bindingsWithDefaultSpace returns the endpoint bindings with the
+// model's default space, if configured, as the default binding for
+// endpoints not explicitly bound. Bindings that already include a
+// default binding are returned unchanged.
+func bindingsWithDefaultSpace(model Model, bindings map[string]string) (map[string]string, error) {
+	if _, ok := bindings[""]; ok {
+		return bindings, nil
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaultSpace := cfg.DefaultSpace()
+	if defaultSpace == "" {
+		return bindings, nil
+	}
+	result := make(map[string]string, len(bindings)+1)
+	for endpoint, space := range bindings {
+		result[endpoint] = space
+	}
+	result[""] = defaultSpace
+	return result, nil
+}
+
 // checkMachinePlacement does a non-exhaustive validation of any supplied
 // placement directives.
 // If the placement scope is for a machine, ensure that the machine exists.
@@ -747,6 +850,7 @@ type setCharmParams struct {
 	Channel               csparams.Channel
 	ConfigSettingsStrings map[string]string
 	ConfigSettingsYAML    string
+	ConfigMap             map[string]string
 	ResourceIDs           map[string]string
 	StorageConstraints    map[string]params.StorageConstraints
 	Force                 forceParams
@@ -772,6 +876,9 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.admitUpdate(&args); err != nil {
+		return errors.Trace(err)
+	}
 	// Set the charm for the given application.
 	if args.CharmURL != "" {
 		// For now we do not support changing the channel through Update().
@@ -889,6 +996,14 @@ func (api *APIBase) updateOneApplicationSeries(arg params.UpdateSeriesArg) error
 	if arg.Series == app.Series() {
 		return nil // no-op
 	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.SetSeries,
+		Application: applicationTag.Id(),
+		Series:      arg.Series,
+		Force:       arg.Force,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 	return app.UpdateApplicationSeries(arg.Series, arg.Force)
 }
 
@@ -907,6 +1022,9 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.admitSetCharm(&args); err != nil {
+		return errors.Trace(err)
+	}
 	channel := csparams.Channel(args.Channel)
 	return api.setCharmWithAgentValidation(
 		setCharmParams{
@@ -915,6 +1033,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 			Channel:               channel,
 			ConfigSettingsStrings: args.ConfigSettings,
 			ConfigSettingsYAML:    args.ConfigSettingsYAML,
+			ConfigMap:             args.ConfigMap,
 			ResourceIDs:           args.ResourceIDs,
 			StorageConstraints:    args.StorageConstraints,
 			Force: forceParams{
@@ -1014,6 +1133,7 @@ func (api *APIBase) applicationSetCharm(
 		Charm:              api.stateCharm(stateCharm),
 		Channel:            params.Channel,
 		ConfigSettings:     settings,
+		ConfigMap:          params.ConfigMap,
 		ForceSeries:        force.ForceSeries,
 		ForceUnits:         force.ForceUnits,
 		Force:              force.Force,
@@ -1114,8 +1234,12 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 	if err != nil {
 		return err
 	}
+	options, err := api.admitSetConfig(p.ApplicationName, p.Options)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	// Validate the settings.
-	changes, err := ch.Config().ParseSettingsStrings(p.Options)
+	changes, err := ch.Config().ParseSettingsStrings(options)
 	if err != nil {
 		return err
 	}
@@ -1135,6 +1259,13 @@ func (api *APIBase) Unset(p params.ApplicationUnset) error {
 	if err != nil {
 		return err
 	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.UnsetConfig,
+		Application: p.ApplicationName,
+		Keys:        p.Options,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 	settings := make(charm.Settings)
 	for _, option := range p.Options {
 		settings[option] = nil
@@ -1194,9 +1325,139 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.Expose,
+		Application: args.ApplicationName,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 	return app.SetExposed()
 }
 
+// MergeBindings isn't on the v10 API.
+func (u *APIv10) MergeBindings(_, _ struct{}) {}
+
+// MergeBindings merges the given endpoint bindings with the existing
+// bindings of each application. The machines hosting an application's
+// units are checked for connectivity to the spaces the endpoints are
+// to be bound to; any that lack it are reported as problems, and the
+// bindings are then only changed if Force is set.
+func (api *APIBase) MergeBindings(args params.ApplicationMergeBindingsArgs) (params.ApplicationMergeBindingsResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ApplicationMergeBindingsResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ApplicationMergeBindingsResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationMergeBindingsResults{
+		Results: make([]params.ApplicationMergeBindingsResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		problems, err := api.mergeOneBindings(arg)
+		results.Results[i].Problems = problems
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) mergeOneBindings(arg params.ApplicationMergeBindings) ([]string, error) {
+	if api.modelType == state.ModelTypeCAAS {
+		return nil, errors.NotSupportedf("endpoint bindings for CAAS applications")
+	}
+	applicationTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(applicationTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoints := make([]string, 0, len(arg.Bindings))
+	for endpoint := range arg.Bindings {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.SetBindings,
+		Application: applicationTag.Id(),
+		Endpoints:   endpoints,
+		Force:       arg.Force,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems, err := api.bindingProblems(app, arg.Bindings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(problems) > 0 && !arg.Force {
+		return problems, errors.Errorf(
+			"cannot change endpoint bindings of application %q: %d machine(s) not connected to the new spaces",
+			applicationTag.Id(), len(problems),
+		)
+	}
+	if err := app.MergeBindings(arg.Bindings); err != nil {
+		return problems, errors.Trace(err)
+	}
+	return problems, nil
+}
+
+// bindingProblems returns a description of each machine hosting a unit
+// of the application which is not connected to all of the spaces named
+// in the bindings. Units on such machines cannot get addresses in the
+// spaces until the machine's network is reconfigured, or the machine
+// is replaced.
+func (api *APIBase) bindingProblems(app Application, bindings map[string]string) ([]string, error) {
+	spaces := set.NewStrings()
+	for _, space := range bindings {
+		if space != environs.DefaultSpaceName {
+			spaces.Add(space)
+		}
+	}
+	if spaces.IsEmpty() {
+		return nil, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineUnits := make(map[string][]string)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineUnits[machineId] = append(machineUnits[machineId], unit.Name())
+	}
+	machineIds := make([]string, 0, len(machineUnits))
+	for machineId := range machineUnits {
+		machineIds = append(machineIds, machineId)
+	}
+	sort.Strings(machineIds)
+
+	var problems []string
+	for _, machineId := range machineIds {
+		machine, err := api.backend.Machine(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineSpaces, err := machine.AllSpaces()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		missing := spaces.Difference(machineSpaces)
+		if missing.IsEmpty() {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf(
+			"machine %s (hosting %s) is not connected to space(s) %s",
+			machineId, strings.Join(machineUnits[machineId], ", "), network.QuoteSpaceSet(missing),
+		))
+	}
+	return problems, nil
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
@@ -1210,6 +1471,12 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.Unexpose,
+		Application: args.ApplicationName,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 	return app.ClearExposed()
 }
 
@@ -1236,6 +1503,18 @@ func (api *APIBase) AddUnits(args params.AddApplicationUnits) (params.AddApplica
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
+	placement := make([]string, len(args.Placement))
+	for i, p := range args.Placement {
+		placement[i] = p.String()
+	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.AddUnits,
+		Application: args.ApplicationName,
+		NumUnits:    args.NumUnits,
+		Placement:   placement,
+	}); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addApplicationUnits(api.backend, api.modelType, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
@@ -1289,6 +1568,9 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkUnitQuotas(backend, modelType, args.NumUnits, args.Placement); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return addUnits(
 		oneApplication,
 		args.ApplicationName,
@@ -1374,6 +1656,18 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		if !unit.IsPrincipal() {
 			return nil, errors.Errorf("unit %q is a subordinate", name)
 		}
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := api.admit(admission.Request{
+			Operation:   admission.RemoveUnit,
+			Application: appName,
+			Units:       []string{name},
+			Force:       arg.Force,
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.DestroyUnitInfo
 		unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
 		if err != nil {
@@ -1481,6 +1775,13 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 		if err != nil {
 			return nil, err
 		}
+		if _, err := api.admit(admission.Request{
+			Operation:   admission.RemoveApplication,
+			Application: tag.Id(),
+			Force:       arg.Force,
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, err
@@ -1577,6 +1878,14 @@ func (api *APIBase) DestroyConsumedApplications(args params.DestroyConsumedAppli
 		if arg.Force != nil {
 			force = *arg.Force
 		}
+		if _, err := api.admit(admission.Request{
+			Operation:   admission.RemoveApplication,
+			Application: appTag.Id(),
+			Force:       force,
+		}); err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
 		op := app.DestroyOperation(force)
 		if force {
 			op.MaxWait = common.MaxWait(arg.MaxWait)
@@ -1625,6 +1934,14 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if _, err := api.admit(admission.Request{
+			Operation:   admission.Scale,
+			Application: name,
+			NumUnits:    arg.Scale,
+			ScaleChange: arg.ScaleChange,
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -1697,7 +2014,11 @@ func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err != nil {
 		return err
 	}
-	return app.SetConstraints(args.Constraints)
+	cons, err := api.admitSetConstraints(args.ApplicationName, args.Constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetConstraints(cons)
 }
 
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
@@ -1732,6 +2053,13 @@ func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationRe
 	if err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	endpoints := make([]string, len(inEps))
+	for i, ep := range inEps {
+		endpoints[i] = ep.String()
+	}
+	if err := api.admitRelation(admission.AddRelation, endpoints, false); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	if rel, err = api.backend.AddRelation(inEps...); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
@@ -1783,6 +2111,9 @@ func (api *APIBase) DestroyRelation(args params.DestroyRelation) (err error) {
 		return err
 	}
 	force := args.Force != nil && *args.Force
+	if err := api.admitRelation(admission.RemoveRelation, strings.Fields(rel.Tag().Id()), force); err != nil {
+		return errors.Trace(err)
+	}
 	errs, err := rel.DestroyWithForce(force, common.MaxWait(args.MaxWait))
 	if len(errs) != 0 {
 		logger.Warningf("operational errors destroying relation %v: %v", rel.Tag().Id(), errs)
@@ -1812,6 +2143,9 @@ func (api *APIBase) SetRelationsSuspended(args params.RelationSuspendedArgs) (pa
 		if errors.IsNotFound(err) {
 			return errors.Errorf("cannot set suspend status for %q which is not associated with an offer", rel.Tag().Id())
 		}
+		if err := api.admitRelation(admission.SuspendRelation, strings.Fields(rel.Tag().Id()), false); err != nil {
+			return errors.Trace(err)
+		}
 		message := arg.Message
 		if !arg.Suspended {
 			message = ""
@@ -1864,6 +2198,16 @@ func (api *APIBase) consumeOne(arg params.ConsumeApplicationArg) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	appName := arg.ApplicationAlias
+	if appName == "" {
+		appName = arg.OfferName
+	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.Consume,
+		Application: appName,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Maybe save the details of the controller hosting the offer.
 	if arg.ControllerInfo != nil {
@@ -1885,10 +2229,6 @@ func (api *APIBase) consumeOne(arg params.ConsumeApplicationArg) error {
 		}
 	}
 
-	appName := arg.ApplicationAlias
-	if appName == "" {
-		appName = arg.OfferName
-	}
 	_, err = api.saveRemoteApplication(sourceModelTag, appName, arg.ApplicationOfferDetails, arg.Macaroon)
 	return err
 }
@@ -2146,6 +2486,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if arg.Config, err = api.admitSetConfig(arg.ApplicationName, arg.Config); err != nil {
+		return errors.Trace(err)
+	}
 
 	appConfigAttrs, charmConfig, err := splitApplicationAndCharmConfig(api.modelType, arg.Config)
 	if err != nil {
@@ -2156,6 +2499,12 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 		return errors.Trace(err)
 	}
 
+	if api.modelType == state.ModelTypeCAAS {
+		if err := caas.ValidateConfig(appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if len(appConfigAttrs) > 0 {
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
@@ -2224,6 +2573,13 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if _, err := api.admit(admission.Request{
+		Operation:   admission.UnsetConfig,
+		Application: arg.ApplicationName,
+		Keys:        arg.Options,
+	}); err != nil {
+		return errors.Trace(err)
+	}
 
 	configSchema, defaults, err := applicationConfigSchema(api.modelType)
 	if err != nil {
@@ -2272,6 +2628,9 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 			return params.ErrorResults{}, errors.Trace(err)
 		}
 		for _, u := range unitsWithErrors {
+			if err := api.admitResolve(u.Name()); err != nil {
+				return params.ErrorResults{}, errors.Trace(err)
+			}
 			if err := u.Resolve(p.Retry); err != nil {
 				return params.ErrorResults{}, errors.Annotatef(err, "resolve error for unit %q", u.UnitTag().Id())
 			}
@@ -2298,6 +2657,10 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		if err := api.admitResolve(tag.Id()); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		err = unit.Resolve(p.Retry)
 		result.Results[i].Error = common.ServerError(err)
 	}
@@ -2349,6 +2712,54 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// CredentialAccesses isn't on the v12 API.
+func (u *APIv12) CredentialAccesses(_, _ struct{}) {}
+
+// CredentialAccesses returns, for each application, the hooks which
+// have recently been given the model's cloud credential because the
+// application was trusted, most recent first.
+func (api *APIBase) CredentialAccesses(args params.Entities) (params.CredentialAccessResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CredentialAccessResults{}, errors.Trace(err)
+	}
+	results := params.CredentialAccessResults{
+		Results: make([]params.CredentialAccessResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		accesses, err := api.credentialAccesses(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Accesses = accesses
+	}
+	return results, nil
+}
+
+func (api *APIBase) credentialAccesses(appTag string) ([]params.CredentialAccess, error) {
+	tag, err := names.ParseApplicationTag(appTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	accesses, err := app.CredentialAccesses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.CredentialAccess, len(accesses))
+	for i, access := range accesses {
+		result[i] = params.CredentialAccess{
+			Unit: access.Unit,
+			Hook: access.Hook,
+			Time: access.Time,
+		}
+	}
+	return result, nil
+}
+
 // lxdCharmProfiler massages a *state.Charm into a LXDProfiler
 // inside of the core package.
 type lxdCharmProfiler struct {
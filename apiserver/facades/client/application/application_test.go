@@ -50,7 +50,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv10
+	applicationAPI *application.APIv13
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -87,7 +87,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv10 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv13 {
 	resources := common.NewResources()
 	c.Assert(resources.RegisterNamed("dataDir", common.StringResource(c.MkDir())), jc.ErrorIsNil)
 	storageAccess, err := application.GetStorageState(s.State)
@@ -109,9 +109,10 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv10 {
 		registry,
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Admission policy not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv10{api}
+	return &application.APIv13{api}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
@@ -134,7 +135,9 @@ func (s *applicationSuite) TestCharmConfigV8(c *gc.C) {
 	s.setUpConfigTest(c)
 	api := &application.APIv8{
 		APIv9: &application.APIv9{
-			APIv10: s.applicationAPI,
+			APIv10: &application.APIv10{
+				APIv11: &application.APIv11{&application.APIv12{s.applicationAPI}},
+			},
 		},
 	}
 	results, err := api.CharmConfig(params.Entities{
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -24,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/admission"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -51,10 +53,11 @@ type ApplicationSuite struct {
 	registry           *mockStorageRegistry
 
 	storageValidator *mockStorageValidator
+	admission        *mockAdmissionChecker
 	env              environs.Environ
 	blockChecker     mockBlockChecker
 	authorizer       apiservertesting.FakeAuthorizer
-	api              *application.APIv10
+	api              *application.APIv13
 	deployParams     map[string]application.DeployApplicationParams
 }
 
@@ -65,6 +68,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.storagePoolManager = &mockStoragePoolManager{storageType: k8s.K8s_ProviderType}
 	s.registry = &mockStorageRegistry{}
 	s.storageValidator = &mockStorageValidator{}
+	s.admission = &mockAdmissionChecker{decision: admission.Decision{Allowed: true}}
 	api, err := application.NewAPIBase(
 		&s.backend,
 		&s.backend,
@@ -82,9 +86,10 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.registry,
 		common.NewResources(),
 		s.storageValidator,
+		s.admission,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv10{api}
+	s.api = &application.APIv13{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -347,6 +352,86 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestCredentialAccesses(c *gc.C) {
+	now := time.Now().UTC()
+	s.backend.applications["postgresql"].credentialAccesses = []state.CredentialAccess{
+		{Unit: "postgresql/0", Hook: "config-changed", Time: now},
+		{Unit: "postgresql/1", Hook: "install", Time: now.Add(-time.Minute)},
+	}
+	results, err := s.api.CredentialAccesses(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-unknown"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.CredentialAccessResult{
+		Accesses: []params.CredentialAccess{
+			{Unit: "postgresql/0", Hook: "config-changed", Time: now},
+			{Unit: "postgresql/1", Hook: "install", Time: now.Add(-time.Minute)},
+		},
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetCharmConfigMap(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		ConfigMap:       map[string]string{"oldOption": "stringOption"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm:     &state.Charm{},
+		ConfigMap: map[string]string{"oldOption": "stringOption"},
+	})
+}
+
+func (s *ApplicationSuite) TestSetCharmAdmissionPolicyDenied(c *gc.C) {
+	s.admission.decision = admission.Decision{Reasons: []string{"unreviewed charm"}}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, "set-charm denied by admission policy: unreviewed charm")
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	s.admission.CheckCall(c, 0, "Check", admission.Request{
+		Operation:   admission.SetCharm,
+		ModelUUID:   s.model.uuid,
+		ModelName:   "testmodel",
+		User:        "user-admin",
+		Application: "postgresql",
+		CharmURL:    "cs:postgresql",
+	})
+	s.backend.CheckCallNames(c, "Application")
+}
+
+func (s *ApplicationSuite) TestSetCharmAdmissionPolicyConfig(c *gc.C) {
+	s.admission.decision = admission.Decision{
+		Allowed: true,
+		Config:  map[string]string{"stringOption": "enforced"},
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		ConfigSettingsYAML: `
+postgresql:
+  stringOption: value
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm:          &state.Charm{},
+		ConfigSettings: charm.Settings{"stringOption": "enforced"},
+	})
+}
+
 func (s *ApplicationSuite) TestLXDProfileSetCharmWithNewerAgentVersion(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -411,6 +496,20 @@ func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	s.relation.CheckCallNames(c, "DestroyWithForce")
 }
 
+func (s *ApplicationSuite) TestDestroyRelationAdmissionPolicyDenied(c *gc.C) {
+	s.admission.decision = admission.Decision{Reasons: []string{"databases are required"}}
+	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
+	c.Assert(err, gc.ErrorMatches, "remove-relation denied by admission policy: databases are required")
+	s.admission.CheckCall(c, 0, "Check", admission.Request{
+		Operation: admission.RemoveRelation,
+		ModelUUID: s.model.uuid,
+		ModelName: "testmodel",
+		User:      "user-admin",
+		Endpoints: []string{"wordpress:db", "mysql:db"},
+	})
+	s.relation.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyRelationNoRelationsFound(c *gc.C) {
 	s.backend.SetErrors(nil, errors.New("no relations found"))
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
@@ -701,6 +800,129 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployAdmissionPolicyDenied(c *gc.C) {
+	s.admission.SetErrors(nil, errors.New("boom"))
+	s.admission.decision = admission.Decision{Reasons: []string{"no latest", "no trust"}}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar-0",
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "deploy denied by admission policy: no latest; no trust")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `checking deploy of "bar" against admission policy: boom`)
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestDeployAdmissionPolicyMutates(c *gc.C) {
+	s.backend.charm = &mockCharm{
+		meta: &charm.Meta{},
+		config: &charm.Config{
+			Options: map[string]charm.Option{
+				"stringOption": {Type: "string"},
+				"intOption":    {Type: "int", Default: int(123)},
+			},
+		},
+	}
+	cons := "mem=4G"
+	s.admission.decision = admission.Decision{
+		Allowed:     true,
+		Config:      map[string]string{"intOption": "2"},
+		Constraints: &cons,
+	}
+	config := map[string]string{"stringOption": "fred"}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Config:          config,
+			Constraints:     constraints.MustParse("cores=2"),
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.deployParams["foo"].Constraints, jc.DeepEquals, constraints.MustParse("mem=4G"))
+	c.Assert(s.deployParams["foo"].CharmConfig, jc.DeepEquals, charm.Settings{
+		"stringOption": "fred",
+		"intOption":    int64(2),
+	})
+	// The caller's config is left untouched.
+	c.Assert(config, jc.DeepEquals, map[string]string{"stringOption": "fred"})
+	s.admission.CheckCall(c, 0, "Check", admission.Request{
+		Operation:   admission.Deploy,
+		ModelUUID:   s.model.uuid,
+		ModelName:   "testmodel",
+		User:        "user-admin",
+		Application: "foo",
+		CharmURL:    "local:foo-0",
+		NumUnits:    1,
+		Config:      config,
+		Constraints: "cores=2",
+		Placement:   []string{},
+	})
+}
+
+func (s *ApplicationSuite) TestDeployDefaultSpace(c *gc.C) {
+	s.model.cfg["default-space"] = "dmz"
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}, {
+			ApplicationName:  "bar",
+			CharmURL:         "local:bar-0",
+			NumUnits:         1,
+			EndpointBindings: map[string]string{"db": "internal"},
+		}, {
+			ApplicationName:  "baz",
+			CharmURL:         "local:baz-0",
+			NumUnits:         1,
+			EndpointBindings: map[string]string{"": "public"},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	for _, result := range results.Results {
+		c.Assert(result.Error, gc.IsNil)
+	}
+	c.Assert(s.deployParams["foo"].EndpointBindings, jc.DeepEquals, map[string]string{"": "dmz"})
+	c.Assert(s.deployParams["bar"].EndpointBindings, jc.DeepEquals, map[string]string{"": "dmz", "db": "internal"})
+	c.Assert(s.deployParams["baz"].EndpointBindings, jc.DeepEquals, map[string]string{"": "public"})
+}
+
+func (s *ApplicationSuite) TestDeployQuotaExceeded(c *gc.C) {
+	s.backend.SetErrors(&state.ErrQuotaExceeded{
+		Entity: "machines", Key: "max-model-machines", Limit: 2, Current: 1, Adding: 3,
+	})
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+			Placement:       []*instance.Placement{{Scope: "lxd"}},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `machines quota exceeded: model has 1 of the 2 allowed by "max-model-machines", cannot add 3 more`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	s.backend.CheckCall(c, 0, "CheckModelQuotas", state.ModelQuotaUsage{Units: 2, Machines: 3})
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	s.model.modelType = state.ModelTypeCAAS
 	s.backend.charm = &mockCharm{
@@ -854,6 +1076,22 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.SetErrors(nil, &state.ErrQuotaExceeded{
+		Entity: "units", Key: "max-model-units", Limit: 2, Current: 1, Adding: 2,
+	})
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `units quota exceeded: model has 1 of the 2 allowed by "max-model-units", cannot add 2 more`)
+	s.backend.CheckCallNames(c, "Application", "CheckModelQuotas")
+	s.backend.CheckCall(c, 1, "CheckModelQuotas", state.ModelQuotaUsage{Units: 2, Machines: 1})
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -899,6 +1137,28 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 	app.CheckCall(c, 0, "Scale", 5)
 }
 
+func (s *ApplicationSuite) TestScaleApplicationsAdmissionPolicyDenied(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.admission.decision = admission.Decision{Reasons: []string{"too many units"}}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          50,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "scale denied by admission policy: too many units")
+	s.admission.CheckCall(c, 0, "Check", admission.Request{
+		Operation:   admission.Scale,
+		ModelUUID:   s.model.uuid,
+		ModelName:   "testmodel",
+		User:        "user-admin",
+		Application: "postgresql",
+		NumUnits:    50,
+	})
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestScaleApplicationsBlocked(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	s.blockChecker.SetErrors(common.ServerError(common.OperationBlockedError("test block")))
@@ -1504,6 +1764,58 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	c.Check(s.backend.generation, gc.IsNil)
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidOperatorImagePath(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"juju-operator-image-path": "Bad Image!",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `invalid juju-operator-image-path: docker image path "Bad Image!" not valid`)
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAdmissionPolicyConfig(c *gc.C) {
+	s.admission.decision = admission.Decision{
+		Allowed: true,
+		Config:  map[string]string{"stringOption": "enforced"},
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.admission.CheckCall(c, 0, "Check", admission.Request{
+		Operation:   admission.SetConfig,
+		ModelUUID:   s.model.uuid,
+		ModelName:   "testmodel",
+		User:        "user-admin",
+		Application: "postgresql",
+		Config:      map[string]string{"stringOption": "stringVal"},
+	})
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "UpdateCharmConfig")
+	app.CheckCall(c, 1, "UpdateCharmConfig", model.GenerationMaster, charm.Settings{"stringOption": "enforced"})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAdmissionPolicyDenied(c *gc.C) {
+	s.admission.decision = admission.Decision{Reasons: []string{"option is locked"}}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "set-config denied by admission policy: option is locked")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
@@ -1674,6 +1986,103 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
 
+func (s *ApplicationSuite) TestExposeAdmissionPolicyDenied(c *gc.C) {
+	s.admission.decision = admission.Decision{Reasons: []string{"no public databases"}}
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, "expose denied by admission policy: no public databases")
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) setUpMergeBindings() {
+	s.backend.machines = map[string]*mockMachine{
+		"machine-0": {id: "machine-0", spaces: set.NewStrings("internal", "public")},
+		"machine-1": {id: "machine-1", spaces: set.NewStrings("public")},
+	}
+}
+
+func (s *ApplicationSuite) mergeBindingsCalls(app *mockApplication) []map[string]string {
+	var calls []map[string]string
+	for _, call := range app.Calls() {
+		if call.FuncName == "MergeBindings" {
+			calls = append(calls, call.Args[0].(map[string]string))
+		}
+	}
+	return calls
+}
+
+func (s *ApplicationSuite) TestMergeBindings(c *gc.C) {
+	s.setUpMergeBindings()
+	bindings := map[string]string{"db": "public"}
+	result, err := s.api.MergeBindings(params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       bindings,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ApplicationMergeBindingsResult{{}})
+
+	app := s.backend.applications["postgresql"]
+	c.Assert(s.mergeBindingsCalls(app), jc.DeepEquals, []map[string]string{bindings})
+}
+
+func (s *ApplicationSuite) TestMergeBindingsMachineNotConnected(c *gc.C) {
+	s.setUpMergeBindings()
+	result, err := s.api.MergeBindings(params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       map[string]string{"db": "internal"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Problems, jc.DeepEquals, []string{
+		`machine machine-1 (hosting postgresql/1) is not connected to space(s) "internal"`,
+	})
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`cannot change endpoint bindings of application "postgresql": 1 machine\(s\) not connected to the new spaces`)
+
+	app := s.backend.applications["postgresql"]
+	c.Assert(s.mergeBindingsCalls(app), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestMergeBindingsForce(c *gc.C) {
+	s.setUpMergeBindings()
+	bindings := map[string]string{"": "internal"}
+	result, err := s.api.MergeBindings(params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       bindings,
+			Force:          true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ApplicationMergeBindingsResult{{
+		Problems: []string{
+			`machine machine-1 (hosting postgresql/1) is not connected to space(s) "internal"`,
+		},
+	}})
+
+	app := s.backend.applications["postgresql"]
+	c.Assert(s.mergeBindingsCalls(app), jc.DeepEquals, []map[string]string{bindings})
+}
+
+func (s *ApplicationSuite) TestMergeBindingsCAAS(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.MergeBindings(params.ApplicationMergeBindingsArgs{
+		Args: []params.ApplicationMergeBindings{{
+			ApplicationTag: "application-postgresql",
+			Bindings:       map[string]string{"db": "internal"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "endpoint bindings for CAAS applications not supported")
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -6,6 +6,7 @@ package application
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
@@ -49,6 +50,7 @@ type Backend interface {
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 	Branch(string) (Generation, error)
+	CheckModelQuotas(state.ModelQuotaUsage) error
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -73,10 +75,12 @@ type Application interface {
 	ClearExposed() error
 	CharmConfig(string) (charm.Settings, error)
 	Constraints() (constraints.Value, error)
+	CredentialAccesses() ([]state.CredentialAccess, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
 	EndpointBindings() (map[string]string, error)
 	Endpoints() ([]state.Endpoint, error)
+	MergeBindings(map[string]string) error
 	IsExposed() bool
 	IsPrincipal() bool
 	IsRemote() bool
@@ -107,6 +111,7 @@ type Charm interface {
 // details on the methods, see the methods on state.Machine with
 // the same names.
 type Machine interface {
+	AllSpaces() (set.Strings, error)
 	IsLockedForSeriesUpgrade() (bool, error)
 	IsParentLockedForSeriesUpgrade() (bool, error)
 }
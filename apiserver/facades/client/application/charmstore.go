@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
@@ -117,6 +118,9 @@ func AddCharmWithAuthorizationAndRepo(st State, args params.AddCharmWithAuthoriz
 
 	// Get the repo from the constructor
 	repo, err := repoFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// Get the charm and its information from the store.
 	downloadedCharm, err := repo.Get(charmURL)
@@ -207,10 +211,41 @@ func AddCharmWithAuthorization(st State, args params.AddCharmWithAuthorization)
 			return nil, errors.Trace(err)
 		}
 		repo = config.SpecializeCharmRepo(repo, modelConfig).(*charmrepo.CharmStore)
-		return repo, nil
+		repo, err = mirroredCharmRepo(repo, controllerCfg, modelConfig, csparams.Channel(args.Channel))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Verify the archives against the charm store metadata, and
+		// their signatures if the controller has a trust keyring.
+		return charmstore.NewVerifyingRepo(repo, charmstore.VerifyParams{
+			TrustKeyring:     controllerCfg.CharmTrustKeyring(),
+			RequireSignature: controllerCfg.RequireSignedCharms(),
+		}), nil
 	})
 }
 
+// mirroredCharmRepo returns a charm repository that uses the
+// controller's charm store mirror in front of the given charm store
+// repository, according to the model's charm mirror mode.
+func mirroredCharmRepo(
+	repo charmrepo.Interface,
+	controllerCfg controller.Config,
+	modelConfig *config.Config,
+	channel csparams.Channel,
+) (charmrepo.Interface, error) {
+	mirror, offline, err := charmstore.OpenModelMirror(controllerCfg.CharmStoreMirror(), modelConfig.CharmMirrorMode())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mirror == nil {
+		return repo, nil
+	}
+	if offline {
+		repo = nil
+	}
+	return charmstore.NewMirroredRepo(mirror.WithChannel(channel), repo), nil
+}
+
 func openCSRepo(csURL string, args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
 	csClient, err := openCSClient(csURL, args)
 	if err != nil {
@@ -363,9 +398,15 @@ func ResolveCharms(st State, args params.ResolveCharms) (params.ResolveCharmResu
 	csParams := csclient.Params{
 		URL: controllerCfg.CharmStoreURL(),
 	}
-	repo := config.SpecializeCharmRepo(
-		NewCharmStoreRepo(csclient.New(csParams)),
-		envConfig)
+	repo, err := mirroredCharmRepo(
+		config.SpecializeCharmRepo(NewCharmStoreRepo(csclient.New(csParams)), envConfig),
+		controllerCfg,
+		envConfig,
+		csparams.NoChannel,
+	)
+	if err != nil {
+		return params.ResolveCharmResults{}, errors.Trace(err)
+	}
 
 	for _, ref := range args.References {
 		result := params.ResolveCharmResult{}
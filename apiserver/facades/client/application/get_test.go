@@ -28,7 +28,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv10
+	applicationAPI *application.APIv13
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -57,9 +57,10 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		&mockStorageRegistry{},
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Admission policy not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv10{api}
+	s.applicationAPI = &application.APIv13{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
@@ -127,6 +128,20 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
+			},
+			"spread": map[string]interface{}{
+				"value":       "zones",
+				"default":     "zones",
+				"description": "How the application's units are spread: across availability zones, across hosts, or not at all",
+				"source":      "default",
+				"type":        environschema.Tstring,
+			},
+			"spread-policy": map[string]interface{}{
+				"value":       "warn",
+				"default":     "warn",
+				"description": "Whether to warn or fail when the application's units cannot be spread as requested",
+				"source":      "default",
+				"type":        environschema.Tstring,
 			}},
 		Series: "quantal",
 	})
@@ -199,9 +214,10 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		&mockStorageRegistry{},
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Admission policy not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{api}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{api}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -281,6 +297,20 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"spread": map[string]interface{}{
+				"value":       "zones",
+				"default":     "zones",
+				"description": "How the application's units are spread: across availability zones, across hosts, or not at all",
+				"source":      "default",
+				"type":        "string",
+			},
+			"spread-policy": map[string]interface{}{
+				"value":       "warn",
+				"default":     "warn",
+				"description": "Whether to warn or fail when the application's units cannot be spread as requested",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -339,6 +369,20 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"spread": map[string]interface{}{
+				"value":       "zones",
+				"default":     "zones",
+				"description": "How the application's units are spread: across availability zones, across hosts, or not at all",
+				"source":      "default",
+				"type":        "string",
+			},
+			"spread-policy": map[string]interface{}{
+				"value":       "warn",
+				"default":     "warn",
+				"description": "Whether to warn or fail when the application's units cannot be spread as requested",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 	},
@@ -356,6 +400,20 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"spread": map[string]interface{}{
+				"value":       "zones",
+				"default":     "zones",
+				"description": "How the application's units are spread: across availability zones, across hosts, or not at all",
+				"source":      "default",
+				"type":        "string",
+			},
+			"spread-policy": map[string]interface{}{
+				"value":       "warn",
+				"default":     "warn",
+				"description": "Whether to warn or fail when the application's units cannot be spread as requested",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 	},
 }}
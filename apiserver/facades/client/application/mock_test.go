@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/admission"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -102,6 +103,8 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+
+	credentialAccesses []state.CredentialAccess
 }
 
 func (m *mockApplication) Name() string {
@@ -134,6 +137,11 @@ func (m *mockApplication) Constraints() (constraints.Value, error) {
 	return m.constraints, nil
 }
 
+func (m *mockApplication) CredentialAccesses() ([]state.CredentialAccess, error) {
+	m.MethodCall(m, "CredentialAccesses")
+	return m.credentialAccesses, m.NextErr()
+}
+
 func (m *mockApplication) Endpoints() ([]state.Endpoint, error) {
 	m.MethodCall(m, "Endpoints")
 	return m.endpoints, nil
@@ -144,6 +152,11 @@ func (m *mockApplication) EndpointBindings() (map[string]string, error) {
 	return m.bindings, m.NextErr()
 }
 
+func (m *mockApplication) MergeBindings(bindings map[string]string) error {
+	m.MethodCall(m, "MergeBindings", bindings)
+	return m.NextErr()
+}
+
 func (a *mockApplication) AllUnits() ([]application.Unit, error) {
 	a.MethodCall(a, "AllUnits")
 	if err := a.NextErr(); err != nil {
@@ -415,6 +428,11 @@ func (m *mockBackend) Machine(id string) (application.Machine, error) {
 	return nil, errors.NotFoundf("machine %q", id)
 }
 
+func (m *mockBackend) CheckModelQuotas(adding state.ModelQuotaUsage) error {
+	m.MethodCall(m, "CheckModelQuotas", adding)
+	return m.NextErr()
+}
+
 func newMockModel() mockModel {
 	return mockModel{
 		uuid:      utils.MustNewUUID().String(),
@@ -444,6 +462,10 @@ func (m *mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag(m.UUID())
 }
 
+func (m *mockModel) Name() string {
+	return "testmodel"
+}
+
 func (m *mockModel) Type() state.ModelType {
 	return m.modelType
 }
@@ -470,7 +492,13 @@ func (m *mockModel) AgentVersion() (version.Number, error) {
 type mockMachine struct {
 	jtesting.Stub
 
-	id string
+	id     string
+	spaces set.Strings
+}
+
+func (m *mockMachine) AllSpaces() (set.Strings, error) {
+	m.MethodCall(m, "AllSpaces")
+	return m.spaces, m.NextErr()
 }
 
 func (m *mockMachine) IsLockedForSeriesUpgrade() (bool, error) {
@@ -933,6 +961,16 @@ func (m *mockStorageValidator) ValidateStorageClass(config map[string]interface{
 	return m.NextErr()
 }
 
+type mockAdmissionChecker struct {
+	jtesting.Stub
+	decision admission.Decision
+}
+
+func (m *mockAdmissionChecker) Check(req admission.Request) (admission.Decision, error) {
+	m.MethodCall(m, "Check", req)
+	return m.decision, m.NextErr()
+}
+
 type mockGeneration struct {
 	jtesting.Stub
 }
@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+// checkUnitQuotas returns an error if adding the given number of units,
+// placed as directed, would take the model over the controller's
+// per-model unit or machine quotas. Checking all the units up front
+// means that an operation which would exceed a quota is rejected
+// before any of its units are added.
+//
+// Storage quotas depend on the charm's storage defaults, and are
+// checked by state as each unit is added.
+func checkUnitQuotas(
+	backend Backend,
+	modelType state.ModelType,
+	numUnits int,
+	placement []*instance.Placement,
+) error {
+	err := backend.CheckModelQuotas(state.ModelQuotaUsage{
+		Units:    numUnits,
+		Machines: newMachinesForUnits(modelType, numUnits, placement),
+	})
+	return errors.Trace(err)
+}
+
+// newMachinesForUnits returns the number of machines which will be
+// added to host the given number of units, placed as directed.
+// Units in models without machines, or placed on existing machines,
+// need no new machines; a unit placed in a new container on a new
+// machine needs two.
+func newMachinesForUnits(modelType state.ModelType, numUnits int, placement []*instance.Placement) int {
+	if modelType != state.ModelTypeIAAS {
+		return 0
+	}
+	n := numUnits
+	for i, p := range placement {
+		if i >= numUnits {
+			break
+		}
+		if p == nil {
+			continue
+		}
+		if p.Scope == instance.MachineScope {
+			n--
+			continue
+		}
+		if _, err := instance.ParseContainerType(p.Scope); err == nil && p.Directive == "" {
+			n++
+		}
+	}
+	return n
+}
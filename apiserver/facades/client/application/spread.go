@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	coreapplication "github.com/juju/juju/core/application"
+)
+
+var spreadFields = environschema.Fields{
+	coreapplication.SpreadConfigOptionName: {
+		Description: "How the application's units are spread: across availability zones, across hosts, or not at all",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+		Values: []interface{}{
+			string(coreapplication.SpreadZones),
+			string(coreapplication.SpreadHosts),
+			string(coreapplication.SpreadNone),
+		},
+	},
+	coreapplication.SpreadPolicyConfigOptionName: {
+		Description: "Whether to warn or fail when the application's units cannot be spread as requested",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+		Values: []interface{}{
+			string(coreapplication.SpreadPolicyWarn),
+			string(coreapplication.SpreadPolicyFail),
+		},
+	},
+}
+
+var spreadDefaults = schema.Defaults{
+	coreapplication.SpreadConfigOptionName:       string(coreapplication.DefaultSpread),
+	coreapplication.SpreadPolicyConfigOptionName: string(coreapplication.DefaultSpreadPolicy),
+}
+
+// addSpreadSchemaAndDefaults adds spread schema fields and defaults to an
+// existing set of schema fields and defaults.
+func addSpreadSchemaAndDefaults(extra environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
+	fields := make(environschema.Fields)
+	for name, field := range spreadFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := spreadFields[name]; ok {
+			return nil, nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	newDefaults := make(schema.Defaults)
+	for key, value := range spreadDefaults {
+		newDefaults[key] = value
+	}
+	for key, value := range defaults {
+		newDefaults[key] = value
+	}
+	return fields, newDefaults, nil
+}
@@ -40,6 +40,13 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3, which
+// supports restricting the consumers of an offer by network
+// and controller.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -122,8 +129,34 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
+	// Consumer restrictions aren't supported before V3,
+	// so don't honour any that are supplied.
+	for i := range all.Offers {
+		all.Offers[i].AllowedConsumerCIDRs = nil
+		all.Offers[i].AllowedConsumerControllers = nil
+	}
+	return api.offer(all)
+}
+
+// Offer makes application endpoints available for consumption at a
+// specified URL, optionally restricting the consuming models by network
+// and controller.
+func (api *OffersAPIV3) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
+	return api.offer(all)
+}
+
+func (api *OffersAPI) offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
 
 	for i, one := range all.Offers {
@@ -163,6 +196,9 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 		Endpoints:              addOfferParams.Endpoints,
 		Owner:                  api.Authorizer.GetAuthTag().Id(),
 		HasRead:                []string{common.EveryoneTagName},
+
+		AllowedConsumerCIDRs:       addOfferParams.AllowedConsumerCIDRs,
+		AllowedConsumerControllers: addOfferParams.AllowedConsumerControllers,
 	}
 	if result.OfferName == "" {
 		result.OfferName = result.ApplicationName
@@ -445,6 +481,21 @@ func (api *OffersAPI) FindApplicationOffers(filters params.OfferFilters) (params
 // GetConsumeDetails returns the details necessary to pass to another model to
 // consume the specified offers represented by the urls.
 func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOfferDetailsResults, error) {
+	return api.getConsumeDetails(args, "")
+}
+
+// GetConsumeDetails returns the details necessary to pass to another model to
+// consume the specified offers represented by the urls. The macaroons returned
+// declare the controller hosting the consuming model, so that offers which
+// restrict consumption to specific controllers can check it.
+func (api *OffersAPIV3) GetConsumeDetails(args params.ConsumeOfferDetailsArg) (params.ConsumeOfferDetailsResults, error) {
+	if args.ConsumerControllerUUID != "" && !names.IsValidController(args.ConsumerControllerUUID) {
+		return params.ConsumeOfferDetailsResults{}, errors.NotValidf("consumer controller %q", args.ConsumerControllerUUID)
+	}
+	return api.getConsumeDetails(args.OfferURLs, args.ConsumerControllerUUID)
+}
+
+func (api *OffersAPI) getConsumeDetails(args params.OfferURLs, consumerControllerUUID string) (params.ConsumeOfferDetailsResults, error) {
 	var consumeResults params.ConsumeOfferDetailsResults
 	results := make([]params.ConsumeOfferDetailsResult, len(args.OfferURLs))
 
@@ -473,7 +524,8 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 		offerDetails := &offer.ApplicationOfferDetails
 		results[i].Offer = offerDetails
 		results[i].ControllerInfo = controllerInfo
-		offerMacaroon, err := api.authContext.CreateConsumeOfferMacaroon(offerDetails, api.Authorizer.GetAuthTag().Id())
+		offerMacaroon, err := api.authContext.CreateConsumeOfferMacaroon(
+			offerDetails, api.Authorizer.GetAuthTag().Id(), consumerControllerUUID)
 		if err != nil {
 			results[i].Error = common.ServerError(err)
 			continue
@@ -122,6 +122,38 @@ func (s *applicationOffersSuite) TestOfferPermission(c *gc.C) {
 	s.assertOffer(c, common.ErrPerm)
 }
 
+func (s *applicationOffersSuite) assertOfferConsumerACLs(c *gc.C, offer func(params.AddApplicationOffers) (params.ErrorResults, error), expectCIDRs, expectControllers []string) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.mockState.applications = map[string]crossmodel.Application{
+		"test": &mockApplication{charm: &mockCharm{meta: &charm.Meta{Description: "blog"}}},
+	}
+	s.applicationOffers.addOffer = func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error) {
+		c.Check(offer.AllowedConsumerCIDRs, jc.DeepEquals, expectCIDRs)
+		c.Check(offer.AllowedConsumerControllers, jc.DeepEquals, expectControllers)
+		return &jujucrossmodel.ApplicationOffer{}, nil
+	}
+	errs, err := offer(params.AddApplicationOffers{Offers: []params.AddApplicationOffer{{
+		ModelTag:                   testing.ModelTag.String(),
+		OfferName:                  "offer-test",
+		ApplicationName:            "test",
+		Endpoints:                  map[string]string{"db": "db"},
+		AllowedConsumerCIDRs:       []string{"10.0.0.0/8"},
+		AllowedConsumerControllers: []string{testing.ControllerTag.Id()},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Combine(), jc.ErrorIsNil)
+	s.applicationOffers.CheckCallNames(c, addOffersBackendCall)
+}
+
+func (s *applicationOffersSuite) TestOfferConsumerACLs(c *gc.C) {
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	s.assertOfferConsumerACLs(c, api.Offer, []string{"10.0.0.0/8"}, []string{testing.ControllerTag.Id()})
+}
+
+func (s *applicationOffersSuite) TestOfferConsumerACLsIgnoredBeforeV3(c *gc.C) {
+	s.assertOfferConsumerACLs(c, s.api.Offer, nil, nil)
+}
+
 func (s *applicationOffersSuite) TestOfferSomeFail(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("admin")
 	s.addApplication(c, "one")
@@ -1159,6 +1191,39 @@ func (s *consumeSuite) TestConsumeDetailsWithPermission(c *gc.C) {
 	c.Check(cav[3].Condition, gc.Equals, "declared username someone")
 }
 
+func (s *consumeSuite) TestConsumeDetailsDeclaresConsumerController(c *gc.C) {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()]
+	st.(*mockState).users["someone"] = &mockUser{"someone"}
+	apiUser := names.NewUserTag("someone")
+	offer := names.NewApplicationOfferTag("hosted-mysql")
+	err := st.CreateOfferAccess(offer, apiUser, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer.Tag = apiUser
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	consumerController := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	results, err := api.GetConsumeDetails(params.ConsumeOfferDetailsArg{
+		OfferURLs:              params.OfferURLs{OfferURLs: []string{"fred/prod.hosted-mysql"}},
+		ConsumerControllerUUID: consumerController,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	cav := s.bakery.caveats[string(results.Results[0].Macaroon.Id())]
+	c.Assert(cav, gc.HasLen, 5)
+	c.Check(cav[4].Condition, gc.Equals, "declared consumer-controller-uuid "+consumerController)
+}
+
+func (s *consumeSuite) TestConsumeDetailsInvalidConsumerController(c *gc.C) {
+	api := &applicationoffers.OffersAPIV3{OffersAPIV2: s.api}
+	_, err := api.GetConsumeDetails(params.ConsumeOfferDetailsArg{
+		OfferURLs:              params.OfferURLs{OfferURLs: []string{"fred/prod.hosted-mysql"}},
+		ConsumerControllerUUID: "not-a-uuid",
+	})
+	c.Assert(err, gc.ErrorMatches, `consumer controller "not-a-uuid" not valid`)
+}
+
 func (s *consumeSuite) TestConsumeDetailsDefaultEndpoint(c *gc.C) {
 	s.setupOffer()
 
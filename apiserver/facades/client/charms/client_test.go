@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/charms"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -43,6 +44,9 @@ func (ctx *charmsSuiteContext) Presence() facade.Presence     { return nil }
 func (ctx *charmsSuiteContext) Hub() facade.Hub               { return nil }
 func (ctx *charmsSuiteContext) Controller() *cache.Controller { return nil }
 
+func (ctx *charmsSuiteContext) ControllerHealth() facade.ControllerHealth { return nil }
+func (ctx *charmsSuiteContext) ControllerConfig() controller.Config       { return nil }
+
 func (ctx *charmsSuiteContext) LeadershipClaimer(string) (leadership.Claimer, error) { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipChecker() (leadership.Checker, error)       { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipPinner(string) (leadership.Pinner, error)   { return nil, nil }
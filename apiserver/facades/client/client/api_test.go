@@ -214,6 +214,7 @@ var scenarioStatus = &params.FullStatus{
 				"logging-client":    "",
 				"logging-directory": "",
 			},
+			Health: &params.ApplicationHealth{Other: 2},
 		},
 		"mysql": {
 			Charm:         "local:quantal/mysql-1",
@@ -301,6 +302,8 @@ var scenarioStatus = &params.FullStatus{
 				"db":              "",
 				"db-client":       "",
 			},
+			Health:       &params.ApplicationHealth{Error: 1, Other: 1},
+			RollupHealth: &params.ApplicationHealth{Error: 1, Other: 1},
 		},
 	},
 	Relations: []params.RelationStatus{
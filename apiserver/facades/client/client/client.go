@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/environs"
@@ -47,6 +48,10 @@ type API struct {
 	statusSetter     *common.StatusSetter
 	toolsFinder      *common.ToolsFinder
 	leadershipReader leadership.Reader
+
+	// controller is the controller's cache of model entities,
+	// from which status summaries are served.
+	controller *cache.Controller
 }
 
 // TODO(wallyworld) - remove this method
@@ -189,6 +194,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		blockChecker,
 		state.CallContext(st),
 		leadershipReader,
+		ctx.Controller(),
 	)
 }
 
@@ -206,6 +212,7 @@ func NewClient(
 	blockChecker *common.BlockChecker,
 	callCtx context.ProviderCallContext,
 	leadershipReader leadership.Reader,
+	controller *cache.Controller,
 ) (*Client, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -221,6 +228,7 @@ func NewClient(
 			statusSetter:     statusSetter,
 			toolsFinder:      toolsFinder,
 			leadershipReader: leadershipReader,
+			controller:       controller,
 		},
 		newEnviron:  newEnviron,
 		check:       blockChecker,
@@ -11,6 +11,7 @@ import (
 var (
 	MatchPortRanges = matchPortRanges
 	MatchSubnet     = matchSubnet
+	MatchLabels     = matchLabels
 )
 
 func SetNewEnviron(c *Client, newEnviron func() (environs.BootstrapEnviron, error)) {
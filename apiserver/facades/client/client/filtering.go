@@ -13,6 +13,8 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/labels"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
@@ -194,6 +196,10 @@ func unitMatchPort(u *state.Unit, patterns []string) (bool, bool, error) {
 	return matchPortRanges(patterns, portRanges...)
 }
 
+func unitMatchLabels(u *state.Unit, patterns []string) (bool, bool, error) {
+	return matchLabels(patterns, u.Labels())
+}
+
 // buildApplicationMatcherShims adds matchers for application name, application units and
 // whether the application is exposed.
 func buildApplicationMatcherShims(a *state.Application, patterns ...string) (shims []closurePredicate, _ error) {
@@ -259,6 +265,10 @@ func buildMachineMatcherShims(m *state.Machine, patterns []string) (shims []clos
 	}
 	shims = append(shims, func() (bool, bool, error) { return matchSubnet(patterns, addrs...) })
 
+	// Look at machine labels.
+	machineLabels := m.Labels()
+	shims = append(shims, func() (bool, bool, error) { return matchLabels(patterns, machineLabels) })
+
 	// Units may be able to match the pattern. Ultimately defer to
 	// that logic, and guard against breaking the predicate-chain.
 	shims = append(shims, func() (bool, bool, error) { return false, true, nil })
@@ -276,6 +286,7 @@ func buildUnitMatcherShims(u *state.Unit, patterns []string) []closurePredicate
 		closeOver(unitMatchWorkloadStatus),
 		closeOver(unitMatchExposure),
 		closeOver(unitMatchPort),
+		closeOver(unitMatchLabels),
 	}
 }
 
@@ -312,6 +323,24 @@ func matchSubnet(patterns []string, addresses ...string) (bool, bool, error) {
 	return false, oneValidPattern, nil
 }
 
+func matchLabels(patterns []string, entityLabels map[string]string) (bool, bool, error) {
+	oneValidSelector := false
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, params.LabelSelectorPatternPrefix) {
+			continue
+		}
+		selector, err := labels.ParseSelector(strings.TrimPrefix(p, params.LabelSelectorPatternPrefix))
+		if err != nil {
+			return false, false, errors.Trace(err)
+		}
+		oneValidSelector = true
+		if selector.Matches(entityLabels) {
+			return true, true, nil
+		}
+	}
+	return false, oneValidSelector, nil
+}
+
 func matchExposure(patterns []string, s *state.Application) (bool, bool, error) {
 	if len(patterns) >= 1 && patterns[0] == "exposed" {
 		return s.IsExposed(), true, nil
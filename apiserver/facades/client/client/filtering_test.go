@@ -57,3 +57,26 @@ func (s *filteringUnitTests) TestMatchSubnet(c *gc.C) {
 	c.Check(ok, jc.IsTrue)
 	c.Check(match, jc.IsTrue)
 }
+
+func (s *filteringUnitTests) TestMatchLabels(c *gc.C) {
+	entityLabels := map[string]string{"env": "canary", "tier": "db"}
+
+	// Patterns which are not selectors are ignored.
+	match, ok, err := client.MatchLabels([]string{"env=canary"}, entityLabels)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(match, jc.IsFalse)
+
+	match, ok, err = client.MatchLabels([]string{"selector:env=canary,tier"}, entityLabels)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(match, jc.IsTrue)
+
+	match, ok, err = client.MatchLabels([]string{"selector:env!=canary", "selector:!tier"}, entityLabels)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(match, jc.IsFalse)
+
+	_, _, err = client.MatchLabels([]string{"selector:Env=canary"}, entityLabels)
+	c.Check(err, gc.ErrorMatches, `parsing selector "Env=canary": label key "Env" not valid`)
+}
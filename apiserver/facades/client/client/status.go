@@ -76,6 +76,13 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		}
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
+	if kind == status.KindWorkloadVersion {
+		versions, err := unit.WorkloadVersionHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(versions, status.KindWorkloadVersion)
+	}
 
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
@@ -141,7 +148,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		err = errors.NotValidf("%q requires a unit, got %T", kind, request.Tag)
 		switch kind {
-		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindWorkloadVersion:
 			var u names.UnitTag
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
@@ -173,6 +180,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}
 
 	var noStatus params.FullStatus
+	if args.SummaryOnly {
+		return c.summaryStatus()
+	}
 	var context statusContext
 
 	m, err := c.api.stateAccessor.Model()
@@ -365,6 +375,14 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine model status")
 	}
+	// The summary describes the whole model, so it is
+	// omitted when the status has been filtered.
+	var summary *params.ModelStatusSummary
+	if len(args.Patterns) == 0 {
+		if summary, err = c.modelSummary(); err != nil && !errors.IsNotFound(err) {
+			return noStatus, errors.Annotate(err, "cannot determine model summary")
+		}
+	}
 	return params.FullStatus{
 		Model:               modelStatus,
 		Machines:            context.processMachines(),
@@ -373,9 +391,68 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Offers:              context.processOffers(),
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
+		Summary:             summary,
+	}, nil
+}
+
+// summaryStatus returns the model information and the model summary,
+// without gathering the status of the model's entities.
+func (c *Client) summaryStatus() (params.FullStatus, error) {
+	summary, err := c.modelSummary()
+	if err != nil {
+		return params.FullStatus{}, errors.Annotate(err, "cannot determine model summary")
+	}
+	modelStatus, err := c.modelStatus()
+	if err != nil {
+		return params.FullStatus{}, errors.Annotate(err, "cannot determine model status")
+	}
+	timestamp, err := c.api.stateAccessor.ControllerTimestamp()
+	if err != nil {
+		return params.FullStatus{}, errors.Annotate(err, "could not fetch controller timestamp")
+	}
+	return params.FullStatus{
+		Model:               modelStatus,
+		ControllerTimestamp: timestamp,
+		Summary:             summary,
 	}, nil
 }
 
+// modelSummary returns the summary of the model maintained by the
+// controller's cache. A NotFound error is returned if the model is
+// not cached, or if the cache has not yet confirmed the model's
+// details with the database, as when it was restored from a snapshot.
+func (c *Client) modelSummary() (*params.ModelStatusSummary, error) {
+	if c.api.controller == nil {
+		return nil, errors.NotFoundf("model cache")
+	}
+	model, err := c.api.controller.Model(c.api.stateAccessor.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.IsStale() {
+		return nil, errors.NotFoundf("up to date model summary")
+	}
+	summary := model.Summary()
+	return &params.ModelStatusSummary{
+		ApplicationCount:    summary.ApplicationCount,
+		MachineCount:        summary.MachineCount,
+		ContainerCount:      summary.ContainerCount,
+		UnitCount:           summary.UnitCount,
+		UnitWorkloadStatus:  statusCounts(summary.UnitWorkloadStatus),
+		UnitAgentStatus:     statusCounts(summary.UnitAgentStatus),
+		MachineAgentStatus:  statusCounts(summary.MachineAgentStatus),
+		ApplicationVersions: summary.ApplicationVersions,
+	}, nil
+}
+
+func statusCounts(counts map[status.Status]int) map[string]int {
+	result := make(map[string]int, len(counts))
+	for s, count := range counts {
+		result[string(s)] = count
+	}
+	return result
+}
+
 // newToolsVersionAvailable will return a string representing a tools
 // version only if the latest check is newer than current tools.
 func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
@@ -949,6 +1026,19 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 		logger.Tracef("error fetching lxd profiles for %s: %q", machine.String(), err.Error())
 	}
 	status.LXDProfiles = lxdProfiles
+	status.Labels = machine.Labels()
+
+	utilization, err := machine.InstanceUtilization()
+	if err == nil {
+		if !utilization.IsEmpty() {
+			status.Utilization = &params.MachineUtilization{
+				CPUPercent:    utilization.CPUPercent,
+				MemoryPercent: utilization.MemoryPercent,
+			}
+		}
+	} else {
+		logger.Tracef("error fetching utilization for %s: %q", machine.String(), err.Error())
+	}
 
 	return
 }
@@ -1027,6 +1117,7 @@ func (context *statusContext) processApplications() map[string]params.Applicatio
 	for _, s := range context.allAppsUnitsCharmBindings.applications {
 		applicationsMap[s.Name()] = context.processApplication(s)
 	}
+	addApplicationHealth(applicationsMap)
 	return applicationsMap
 }
 
@@ -1276,6 +1367,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	for _, port := range unitPorts {
 		result.OpenedPorts = append(result.OpenedPorts, port.String())
 	}
+	result.Labels = unit.Labels()
 	if unit.IsPrincipal() {
 		result.Machine, _ = unit.AssignedMachineId()
 	}
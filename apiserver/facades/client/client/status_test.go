@@ -595,6 +595,27 @@ func (s *statusUnitTestSuite) TestMachineWithDisplayNameHasItsDisplayNameSent(c
 	c.Assert(status.Machines[machine.Id()].DisplayName, gc.Equals, "snowflake")
 }
 
+func (s *statusUnitTestSuite) TestMachineUtilization(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("i-123"),
+	})
+	other := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("i-456"),
+	})
+	cpu := 33.5
+	err := machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &cpu})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 2)
+	c.Assert(status.Machines[machine.Id()].Utilization, jc.DeepEquals, &params.MachineUtilization{
+		CPUPercent: &cpu,
+	})
+	c.Assert(status.Machines[other.Id()].Utilization, gc.IsNil)
+}
+
 func assertApplicationRelations(c *gc.C, appName string, expectedNumber int, relations []params.RelationStatus) {
 	c.Assert(relations, gc.HasLen, expectedNumber)
 	for _, relation := range relations {
@@ -797,6 +818,15 @@ func (s *CAASStatusSuite) assertUnitStatus(c *gc.C, appStatus params.Application
 	if info != "agent initializing" && info != "blocked" {
 		workloadVersion = "gitlab/latest"
 	}
+	health := &params.ApplicationHealth{}
+	switch status {
+	case "active":
+		health.Active, health.Score = 1, 100
+	case "blocked":
+		health.Blocked = 1
+	default:
+		health.Other = 1
+	}
 	c.Assert(appStatus, jc.DeepEquals, params.ApplicationStatus{
 		Charm:           curl.String(),
 		Series:          "kubernetes",
@@ -819,5 +849,6 @@ func (s *CAASStatusSuite) assertUnitStatus(c *gc.C, appStatus params.Application
 			Info:   info,
 		},
 		EndpointBindings: map[string]string{"server": "", "server-admin": ""},
+		Health:           health,
 	})
 }
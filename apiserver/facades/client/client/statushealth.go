@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// healthState is a unit's contribution to the health of its
+// application, ordered from healthy to unhealthy.
+type healthState int
+
+const (
+	healthActive healthState = iota
+	healthOther
+	healthBlocked
+	healthError
+)
+
+func unitHealthState(unit params.UnitStatus) healthState {
+	switch status.Status(unit.WorkloadStatus.Status) {
+	case status.Active:
+		return healthActive
+	case status.Blocked:
+		return healthBlocked
+	case status.Error:
+		return healthError
+	}
+	return healthOther
+}
+
+// healthCounter accumulates the health of an application's units.
+type healthCounter params.ApplicationHealth
+
+func (h *healthCounter) add(state healthState) {
+	switch state {
+	case healthActive:
+		h.Active++
+	case healthBlocked:
+		h.Blocked++
+	case healthError:
+		h.Error++
+	default:
+		h.Other++
+	}
+}
+
+func (h *healthCounter) result() *params.ApplicationHealth {
+	total := h.Active + h.Blocked + h.Error + h.Other
+	if total == 0 {
+		return nil
+	}
+	health := params.ApplicationHealth(*h)
+	health.Score = 100 * h.Active / total
+	return &health
+}
+
+// addApplicationHealth summarises the workload status of the units of
+// each application. The units of subordinate applications are found
+// among the subordinates of the principal units. Principal applications
+// with subordinate units also get a rollup, in which each principal unit
+// counts as the least healthy of itself and its subordinates.
+func addApplicationHealth(applications map[string]params.ApplicationStatus) {
+	health := make(map[string]*healthCounter)
+	counter := func(name string) *healthCounter {
+		if health[name] == nil {
+			health[name] = &healthCounter{}
+		}
+		return health[name]
+	}
+	rollups := make(map[string]*healthCounter)
+
+	for appName, app := range applications {
+		hasSubordinates := false
+		rollup := &healthCounter{}
+		for _, unit := range app.Units {
+			state := unitHealthState(unit)
+			counter(appName).add(state)
+
+			worst := state
+			for subName, sub := range unit.Subordinates {
+				hasSubordinates = true
+				subState := unitHealthState(sub)
+				subApp, err := names.UnitApplication(subName)
+				if err == nil {
+					counter(subApp).add(subState)
+				}
+				if subState > worst {
+					worst = subState
+				}
+			}
+			rollup.add(worst)
+		}
+		if hasSubordinates {
+			rollups[appName] = rollup
+		}
+	}
+
+	for appName, app := range applications {
+		if app.Err != nil {
+			continue
+		}
+		if h, ok := health[appName]; ok {
+			app.Health = h.result()
+		}
+		if h, ok := rollups[appName]; ok {
+			app.RollupHealth = h.result()
+		}
+		applications[appName] = app
+	}
+}
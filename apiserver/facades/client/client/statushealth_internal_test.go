@@ -0,0 +1,92 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type applicationHealthSuite struct{}
+
+var _ = gc.Suite(&applicationHealthSuite{})
+
+func unitWithStatus(status string, subordinates map[string]params.UnitStatus) params.UnitStatus {
+	return params.UnitStatus{
+		WorkloadStatus: params.DetailedStatus{Status: status},
+		Subordinates:   subordinates,
+	}
+}
+
+func (*applicationHealthSuite) TestHealth(c *gc.C) {
+	applications := map[string]params.ApplicationStatus{
+		"mysql": {
+			Units: map[string]params.UnitStatus{
+				"mysql/0": unitWithStatus("active", nil),
+				"mysql/1": unitWithStatus("active", nil),
+				"mysql/2": unitWithStatus("blocked", nil),
+				"mysql/3": unitWithStatus("maintenance", nil),
+			},
+		},
+		"empty": {
+			Units: map[string]params.UnitStatus{},
+		},
+	}
+	addApplicationHealth(applications)
+	c.Assert(applications["mysql"].Health, jc.DeepEquals, &params.ApplicationHealth{
+		Active:  2,
+		Blocked: 1,
+		Other:   1,
+		Score:   50,
+	})
+	c.Assert(applications["mysql"].RollupHealth, gc.IsNil)
+	c.Assert(applications["empty"].Health, gc.IsNil)
+}
+
+func (*applicationHealthSuite) TestRollupSubordinates(c *gc.C) {
+	applications := map[string]params.ApplicationStatus{
+		"wordpress": {
+			Units: map[string]params.UnitStatus{
+				"wordpress/0": unitWithStatus("active", map[string]params.UnitStatus{
+					"logging/0": unitWithStatus("error", nil),
+					"nrpe/0":    unitWithStatus("active", nil),
+				}),
+				"wordpress/1": unitWithStatus("active", map[string]params.UnitStatus{
+					"logging/1": unitWithStatus("active", nil),
+					"nrpe/1":    unitWithStatus("active", nil),
+				}),
+				"wordpress/2": unitWithStatus("blocked", map[string]params.UnitStatus{
+					"logging/2": unitWithStatus("waiting", nil),
+				}),
+			},
+		},
+		"logging": {SubordinateTo: []string{"wordpress"}},
+		"nrpe":    {SubordinateTo: []string{"wordpress"}},
+	}
+	addApplicationHealth(applications)
+	c.Assert(applications["wordpress"].Health, jc.DeepEquals, &params.ApplicationHealth{
+		Active:  2,
+		Blocked: 1,
+		Score:   66,
+	})
+	c.Assert(applications["wordpress"].RollupHealth, jc.DeepEquals, &params.ApplicationHealth{
+		Active:  1,
+		Blocked: 1,
+		Error:   1,
+		Score:   33,
+	})
+	c.Assert(applications["logging"].Health, jc.DeepEquals, &params.ApplicationHealth{
+		Active: 1,
+		Error:  1,
+		Other:  1,
+		Score:  33,
+	})
+	c.Assert(applications["logging"].RollupHealth, gc.IsNil)
+	c.Assert(applications["nrpe"].Health, jc.DeepEquals, &params.ApplicationHealth{
+		Active: 2,
+		Score:  100,
+	})
+}
@@ -45,6 +45,7 @@ func (s *statusHistoryTestSuite) SetUpTest(c *gc.C) {
 		nil,                           // blockChecker
 		context.NewCloudCallContext(), // ProviderCallContext
 		nil,
+		nil, // controller cache
 	)
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -154,6 +155,34 @@ func (s *statusHistoryTestSuite) TestStatusHistoryUnitOnly(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.unitHistory))
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryWorkloadVersion(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+	})
+	s.st.versionHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "1.0",
+		},
+		{
+			Status:  status.Active,
+			Message: "1.1",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkloadVersion.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.versionHistory))
+}
+
 func (s *statusHistoryTestSuite) TestStatusHistoryAgentOnly(c *gc.C) {
 	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
 		{
@@ -225,8 +254,9 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 
 type mockState struct {
 	client.Backend
-	unitHistory  []status.StatusInfo
-	agentHistory []status.StatusInfo
+	unitHistory    []status.StatusInfo
+	agentHistory   []status.StatusInfo
+	versionHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -246,14 +276,16 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 		return nil, errors.NotFoundf("%v", name)
 	}
 	return &mockUnit{
-		status: m.unitHistory,
-		agent:  &mockUnitAgent{m.agentHistory},
+		status:   m.unitHistory,
+		agent:    &mockUnitAgent{m.agentHistory},
+		versions: m.versionHistory,
 	}, nil
 }
 
 type mockUnit struct {
-	status statuses
-	agent  *mockUnitAgent
+	status   statuses
+	agent    *mockUnitAgent
+	versions statuses
 	client.Unit
 }
 
@@ -265,6 +297,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) WorkloadVersionHistory() status.StatusHistoryGetter {
+	return m.versions
+}
+
 type mockUnitAgent struct {
 	statuses
 }
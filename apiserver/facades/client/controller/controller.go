@@ -48,12 +48,32 @@ type ControllerAPI struct {
 	resources  facade.Resources
 	presence   facade.Presence
 	hub        facade.Hub
+	health     facade.ControllerHealth
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the UpgradeStepsProgress
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8 doesn't have the ControllerHealth method.
+type ControllerAPIv8 struct {
+	*ControllerAPIv9
+}
+
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the VerifyModels method.
+type ControllerAPIv7 struct {
+	*ControllerAPIv8
 }
 
 // ControllerAPIv6 provides the v6 Controller API. The only difference
 // between this and v7 is that v6 doesn't have the IdentityProviderURL method.
 type ControllerAPIv6 struct {
-	*ControllerAPI
+	*ControllerAPIv7
 }
 
 // ControllerAPIv5 provides the v5 Controller API. The only difference
@@ -74,14 +94,15 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv7 creates a new ControllerAPIv7.
-func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
 	resources := ctx.Resources()
 	presence := ctx.Presence()
 	hub := ctx.Hub()
+	health := ctx.ControllerHealth()
 
 	return NewControllerAPI(
 		st,
@@ -90,9 +111,37 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 		resources,
 		presence,
 		hub,
+		health,
 	)
 }
 
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
 // NewControllerAPIv6 creates a new ControllerAPIv6.
 func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
 	v7, err := NewControllerAPIv7(ctx)
@@ -138,6 +187,7 @@ func NewControllerAPI(
 	resources facade.Resources,
 	presence facade.Presence,
 	hub facade.Hub,
+	health facade.ControllerHealth,
 ) (*ControllerAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, errors.Trace(common.ErrPerm)
@@ -171,6 +221,7 @@ func NewControllerAPI(
 		resources:  resources,
 		presence:   presence,
 		hub:        hub,
+		health:     health,
 	}, nil
 }
 
@@ -547,6 +598,10 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		AuthTag:         authTag,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
+		UserMap:         specTarget.UserMap,
+	}
+	if err := coremigration.ValidateUserMap(targetInfo.UserMap); err != nil {
+		return "", errors.Trace(err)
 	}
 
 	// Check if the migration is likely to succeed.
@@ -660,7 +715,7 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 		return errors.Annotate(err, "connect to target controller")
 	}
 	defer conn.Close()
-	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt, targetInfo.UserMap)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -754,7 +809,11 @@ users to the destination controller or remove them from the current model:
 	return nil
 }
 
-func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, userList, error) {
+// makeModelInfo returns the model information sent to the target
+// controller, along with the users granted access to the model. Users
+// are reported by the names they will have on the target controller,
+// according to the input user map.
+func makeModelInfo(st, ctlrSt *state.State, userMap map[string]string) (coremigration.ModelInfo, userList, error) {
 	var empty coremigration.ModelInfo
 	var ul userList
 
@@ -768,8 +827,10 @@ func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, userList,
 		return empty, ul, errors.Trace(err)
 	}
 	ul.users = set.NewStrings()
-	for _, u := range users {
-		ul.users.Add(u.UserName)
+	userTags := make([]names.UserTag, len(users))
+	for i, u := range users {
+		userTags[i] = coremigration.MapUser(userMap, u.UserTag)
+		ul.users.Add(userTags[i].Id())
 	}
 
 	// Retrieve agent version for the model.
@@ -798,9 +859,10 @@ func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, userList,
 	return coremigration.ModelInfo{
 		UUID:                   model.UUID(),
 		Name:                   model.Name(),
-		Owner:                  model.Owner(),
+		Owner:                  coremigration.MapUser(userMap, model.Owner()),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		Users:                  userTags,
 	}, ul, nil
 }
 
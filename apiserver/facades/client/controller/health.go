@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ControllerHealth isn't on the v8 API.
+func (c *ControllerAPIv8) ControllerHealth() {}
+
+// ControllerHealth returns a summary of the health of the controller's
+// mongo replica set, raft cluster and API servers. The summary is
+// assembled from the reports most recently published by the peergrouper
+// and raft workers on the controller machines.
+func (c *ControllerAPI) ControllerHealth() (params.ControllerHealthResult, error) {
+	result := params.ControllerHealthResult{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	info, err := c.state.ControllerInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var nodes map[string]facade.NodeHealth
+	var raft *facade.RaftHealth
+	if c.health != nil {
+		nodes = c.health.Nodes()
+		raft = c.health.Raft()
+	}
+
+	// Include machines that are reporting health but are no longer
+	// recorded as controllers, so that they aren't silently hidden.
+	ids := make(map[string]bool)
+	for _, id := range info.MachineIds {
+		ids[id] = true
+	}
+	for id := range nodes {
+		ids[id] = true
+	}
+	machineIds := make([]string, 0, len(ids))
+	for id := range ids {
+		machineIds = append(machineIds, id)
+	}
+	sort.Strings(machineIds)
+
+	var latest *facade.NodeHealth
+	for _, id := range machineIds {
+		server := params.APIServerHealth{MachineId: id}
+		if node, ok := nodes[id]; ok {
+			received := node.Received
+			server.AgentVersion = node.AgentVersion
+			server.Reporting = node.Reporting
+			server.LastReport = &received
+			server.ClockSkew = node.Time.Sub(node.Received)
+			if latest == nil || node.Received.After(latest.Received) {
+				node := node
+				latest = &node
+			}
+		} else if version, err := c.machineAgentVersion(id); err == nil {
+			server.AgentVersion = version
+		} else if !errors.IsNotFound(err) {
+			return result, errors.Trace(err)
+		}
+		result.APIServers = append(result.APIServers, server)
+	}
+
+	// Each controller machine reports the replica set as it sees it;
+	// the most recent report is used.
+	if latest != nil {
+		for _, member := range latest.ReplicaSet {
+			health := params.ReplicaSetMemberHealth{
+				MachineId: member.MachineID,
+				Address:   member.Address,
+				State:     member.State,
+				Healthy:   member.Healthy,
+				Lag:       member.Lag,
+			}
+			if !member.LastHeartbeat.IsZero() {
+				heartbeat := member.LastHeartbeat
+				health.LastHeartbeat = &heartbeat
+			}
+			result.ReplicaSet = append(result.ReplicaSet, health)
+		}
+	}
+
+	if raft != nil {
+		result.Raft = &params.RaftHealth{
+			Leader:     raft.Leader,
+			Term:       raft.Term,
+			LastReport: raft.Received,
+		}
+		for _, server := range raft.Servers {
+			result.Raft.Servers = append(result.Raft.Servers, params.RaftServerHealth{
+				Id:       server.ID,
+				Address:  server.Address,
+				Suffrage: server.Suffrage,
+			})
+		}
+		sort.Slice(result.Raft.Servers, func(i, j int) bool {
+			return result.Raft.Servers[i].Id < result.Raft.Servers[j].Id
+		})
+	}
+	return result, nil
+}
+
+// machineAgentVersion returns the agent version recorded in state for
+// the given controller machine.
+func (c *ControllerAPI) machineAgentVersion(id string) (string, error) {
+	machine, err := c.state.Machine(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	tools, err := machine.AgentTools()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tools.Version.Number.String(), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	controllermsg "github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type fakeControllerHealth struct {
+	nodes map[string]facade.NodeHealth
+	raft  *facade.RaftHealth
+}
+
+func (h *fakeControllerHealth) Nodes() map[string]facade.NodeHealth {
+	return h.nodes
+}
+
+func (h *fakeControllerHealth) Raft() *facade.RaftHealth {
+	return h.raft
+}
+
+func (s *controllerSuite) newControllerWithHealth(c *gc.C, health facade.ControllerHealth) *controller.ControllerAPI {
+	api, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:            s.State,
+			StatePool_:        s.StatePool,
+			Resources_:        s.resources,
+			Auth_:             s.authorizer,
+			Hub_:              s.hub,
+			ControllerHealth_: health,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *controllerSuite) TestControllerHealth(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+
+	received := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	heartbeat := received.Add(-time.Minute)
+	health := &fakeControllerHealth{
+		nodes: map[string]facade.NodeHealth{
+			"0": {
+				NodeHealthMessage: controllermsg.NodeHealthMessage{
+					Origin:       "machine-0",
+					AgentVersion: "2.7.0",
+					Time:         received.Add(2 * time.Second),
+					ReplicaSet: []controllermsg.ReplicaSetMember{{
+						MachineID: "0",
+						Address:   "10.0.0.1:37017",
+						State:     "PRIMARY",
+						Healthy:   true,
+					}, {
+						MachineID:     "1",
+						Address:       "10.0.0.2:37017",
+						State:         "DOWN",
+						Lag:           5 * time.Second,
+						LastHeartbeat: heartbeat,
+					}},
+				},
+				Received:  received,
+				Reporting: true,
+			},
+		},
+		raft: &facade.RaftHealth{
+			RaftHealthMessage: controllermsg.RaftHealthMessage{
+				Leader: "0",
+				Term:   4,
+				Servers: []controllermsg.RaftServer{
+					{ID: "1", Address: "10.0.0.2:17070", Suffrage: "Voter"},
+					{ID: "0", Address: "10.0.0.1:17070", Suffrage: "Voter"},
+				},
+			},
+			Received: received,
+		},
+	}
+
+	result, err := s.newControllerWithHealth(c, health).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerHealthResult{
+		APIServers: []params.APIServerHealth{{
+			MachineId:    "0",
+			AgentVersion: "2.7.0",
+			Reporting:    true,
+			LastReport:   &received,
+			ClockSkew:    2 * time.Second,
+		}, {
+			// Machine 1 isn't reporting, so the version is read from state.
+			MachineId:    "1",
+			AgentVersion: jujuversion.Current.String(),
+		}},
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			MachineId: "0",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+		}, {
+			MachineId:     "1",
+			Address:       "10.0.0.2:37017",
+			State:         "DOWN",
+			Lag:           5 * time.Second,
+			LastHeartbeat: &heartbeat,
+		}},
+		Raft: &params.RaftHealth{
+			Leader: "0",
+			Term:   4,
+			Servers: []params.RaftServerHealth{
+				{Id: "0", Address: "10.0.0.1:17070", Suffrage: "Voter"},
+				{Id: "1", Address: "10.0.0.2:17070", Suffrage: "Voter"},
+			},
+			LastReport: received,
+		},
+	})
+}
+
+func (s *controllerSuite) TestControllerHealthNoReports(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+
+	result, err := s.newControllerWithHealth(c, &fakeControllerHealth{}).ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.APIServers, jc.DeepEquals, []params.APIServerHealth{{
+		MachineId:    "0",
+		AgentVersion: jujuversion.Current.String(),
+	}})
+	c.Assert(result.ReplicaSet, gc.HasLen, 0)
+	c.Assert(result.Raft, gc.IsNil)
+}
+
+func (s *controllerSuite) TestControllerHealthRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:            s.State,
+			StatePool_:        s.StatePool,
+			Resources_:        s.resources,
+			Auth_:             apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+			ControllerHealth_: &fakeControllerHealth{},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// UpgradeStepsProgress isn't on the v9 API.
+func (c *ControllerAPIv9) UpgradeStepsProgress() {}
+
+// UpgradeStepsProgress returns the progress of the upgrade steps run
+// by each agent in the controller model while upgrading to the model's
+// agent version.
+func (c *ControllerAPI) UpgradeStepsProgress() (params.UpgradeStepsProgressResult, error) {
+	result := params.UpgradeStepsProgressResult{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	cfg, err := c.state.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	vers, ok := cfg.AgentVersion()
+	if !ok {
+		return result, errors.New("agent version not set in model config")
+	}
+	progress, err := c.state.UpgradeStepsProgress(vers)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Version = vers
+	for _, p := range progress {
+		result.Steps = append(result.Steps, params.UpgradeStepProgress{
+			Tag:     p.Tag,
+			Step:    p.Step,
+			Status:  string(p.Status),
+			Message: p.Message,
+			Started: p.Started,
+			Updated: p.Updated,
+		})
+	}
+	return result, nil
+}
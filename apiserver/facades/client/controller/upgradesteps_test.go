@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
+)
+
+func (s *controllerSuite) TestUpgradeStepsProgress(c *gc.C) {
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	vers, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+
+	tag := names.NewMachineTag("0")
+	err = s.State.SetUpgradeStepProgress(tag, vers, "first step", state.UpgradeStepCompleted, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUpgradeStepProgress(tag, vers, "second step", state.UpgradeStepFailed, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	// Progress towards other versions is not reported.
+	other := jujuversion.Current
+	other.Major++
+	err = s.State.SetUpgradeStepProgress(tag, other, "other step", state.UpgradeStepRunning, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.UpgradeStepsProgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Version, gc.Equals, vers)
+	c.Assert(result.Steps, gc.HasLen, 2)
+	c.Check(result.Steps[0].Tag, gc.Equals, "machine-0")
+	c.Check(result.Steps[0].Step, gc.Equals, "first step")
+	c.Check(result.Steps[0].Status, gc.Equals, "completed")
+	c.Check(result.Steps[1], gc.DeepEquals, params.UpgradeStepProgress{
+		Tag:     "machine-0",
+		Step:    "second step",
+		Status:  "failed",
+		Message: "boom",
+		Started: result.Steps[1].Started,
+		Updated: result.Steps[1].Updated,
+	})
+}
+
+func (s *controllerSuite) TestUpgradeStepsProgressRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.UpgradeStepsProgress()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
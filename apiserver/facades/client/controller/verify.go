@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// VerifyModels isn't on the v7 API.
+func (c *ControllerAPIv7) VerifyModels() {}
+
+// VerifyModels checks every model in the controller for dangling
+// references between documents, optionally repairing them.
+func (c *ControllerAPI) VerifyModels(args params.VerifyModelsArgs) (params.VerifyModelsResults, error) {
+	result := params.VerifyModelsResults{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	fixKinds, err := integrityIssueKinds(args.FixKinds)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	modelUUIDs, err := c.state.AllModelUUIDs()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, modelUUID := range modelUUIDs {
+		issues, err := c.verifyModel(modelUUID, args.Fix, fixKinds)
+		if errors.IsNotFound(err) {
+			// This model could have been removed.
+			continue
+		}
+		result.Results = append(result.Results, params.VerifyModelResult{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Issues:   issues,
+			Error:    common.ServerError(err),
+		})
+	}
+	return result, nil
+}
+
+func (c *ControllerAPI) verifyModel(modelUUID string, fix bool, fixKinds []state.IntegrityIssueKind) ([]params.IntegrityIssue, error) {
+	st, err := c.statePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	issues, err := st.CheckIntegrity()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	repaired := make(map[string]bool)
+	if fix && len(issues) > 0 {
+		fixed, err := st.RepairIntegrity(fixKinds...)
+		for _, issue := range fixed {
+			repaired[issueKey(issue)] = true
+		}
+		if err != nil {
+			return integrityIssuesResult(issues, repaired), errors.Trace(err)
+		}
+	}
+	return integrityIssuesResult(issues, repaired), nil
+}
+
+// issueKey returns a string uniquely identifying the issue.
+func issueKey(issue state.IntegrityIssue) string {
+	return fmt.Sprintf("%s:%s:%s", issue.Kind, issue.Entity, issue.Reference)
+}
+
+func integrityIssuesResult(issues []state.IntegrityIssue, repaired map[string]bool) []params.IntegrityIssue {
+	result := make([]params.IntegrityIssue, len(issues))
+	for i, issue := range issues {
+		result[i] = params.IntegrityIssue{
+			Kind:      string(issue.Kind),
+			Entity:    issue.Entity,
+			Reference: issue.Reference,
+			Message:   issue.Message,
+			Repaired:  repaired[issueKey(issue)],
+		}
+	}
+	return result
+}
+
+func integrityIssueKinds(kinds []string) ([]state.IntegrityIssueKind, error) {
+	known := make(map[string]bool)
+	for _, kind := range state.AllIntegrityIssueKinds() {
+		known[string(kind)] = true
+	}
+	result := make([]state.IntegrityIssueKind, len(kinds))
+	for i, kind := range kinds {
+		if !known[kind] {
+			return nil, errors.NotValidf("integrity issue kind %q", kind)
+		}
+		result[i] = state.IntegrityIssueKind(kind)
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) makeUnitWithMissingMachine(c *gc.C) (string, string) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+
+	machines := s.State.MongoSession().DB("juju").C("machines")
+	err := machines.RemoveId(s.State.ModelUUID() + ":" + machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	return unit.Name(), machine.Id()
+}
+
+func (s *controllerSuite) TestVerifyModels(c *gc.C) {
+	unitName, machineId := s.makeUnitWithMissingMachine(c)
+
+	results, err := s.controller.VerifyModels(params.VerifyModelsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].ModelTag, gc.Equals, s.Model.ModelTag().String())
+	c.Assert(results.Results[0].Issues, jc.DeepEquals, []params.IntegrityIssue{{
+		Kind:      "unit-missing-machine",
+		Entity:    unitName,
+		Reference: machineId,
+		Message:   "unit " + unitName + " is assigned to missing machine " + machineId,
+	}})
+
+	// Checking doesn't repair anything.
+	results, err = s.controller.VerifyModels(params.VerifyModelsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Issues, gc.HasLen, 1)
+}
+
+func (s *controllerSuite) TestVerifyModelsFix(c *gc.C) {
+	s.makeUnitWithMissingMachine(c)
+
+	results, err := s.controller.VerifyModels(params.VerifyModelsArgs{
+		Fix:      true,
+		FixKinds: []string{"unit-missing-machine"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Issues, gc.HasLen, 1)
+	c.Assert(results.Results[0].Issues[0].Repaired, jc.IsTrue)
+
+	results, err = s.controller.VerifyModels(params.VerifyModelsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Issues, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestVerifyModelsInvalidKind(c *gc.C) {
+	_, err := s.controller.VerifyModels(params.VerifyModelsArgs{
+		Fix:      true,
+		FixKinds: []string{"bogus"},
+	})
+	c.Assert(err, gc.ErrorMatches, `integrity issue kind "bogus" not valid`)
+}
+
+func (s *controllerSuite) TestVerifyModelsRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.VerifyModels(params.VerifyModelsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerfeatures provides the facade used to enable and
+// disable feature flags on a running controller.
+package controllerfeatures
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.controllerfeatures")
+
+// validFeature matches the names of feature flags, eg "cmr-aware-bundles".
+var validFeature = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Backend defines the state functionality required by the
+// ControllerFeatures facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerConfig() (jujucontroller.Config, error)
+	ControllerFeatureChanges() ([]state.ControllerFeatureChange, error)
+	SetControllerFeature(feature string, enabled bool, user names.UserTag) error
+}
+
+// API implements the ControllerFeatures facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	hub        facade.Hub
+	apiUser    names.UserTag
+	isAdmin    bool
+}
+
+// NewFacade returns a new ControllerFeatures facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), ctx.Auth(), ctx.Hub())
+}
+
+// NewAPI returns a new ControllerFeatures facade using the backend.
+// Any user may see which feature flags are enabled, so that clients
+// can honour them, but only controller superusers may see the changes
+// made to them or change them.
+func NewAPI(backend Backend, authorizer facade.Authorizer, hub facade.Hub) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// AuthClient guarantees that the auth tag is a user tag.
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		hub:        hub,
+		apiUser:    apiUser,
+		isAdmin:    isAdmin,
+	}, nil
+}
+
+// Features returns the feature flags enabled on the controller. For
+// controller superusers, the recorded changes to the flags are also
+// returned, most recent first.
+func (api *API) Features() (params.ControllerFeaturesResult, error) {
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.ControllerFeaturesResult{}, errors.Trace(err)
+	}
+	result := params.ControllerFeaturesResult{
+		Features: cfg.Features().SortedValues(),
+	}
+	if !api.isAdmin {
+		return result, nil
+	}
+	changes, err := api.backend.ControllerFeatureChanges()
+	if err != nil {
+		return params.ControllerFeaturesResult{}, errors.Trace(err)
+	}
+	for _, change := range changes {
+		result.Changes = append(result.Changes, params.ControllerFeatureChange{
+			Feature: change.Feature,
+			Enabled: change.Enabled,
+			User:    change.User,
+			When:    change.When,
+		})
+	}
+	return result, nil
+}
+
+// SetFeatures enables or disables each of the feature flags. Each
+// change is recorded against the user making it. Workers watching
+// the controller config are notified of the new set of flags, so
+// no controller restart is needed.
+func (api *API) SetFeatures(args params.SetControllerFeatureArgs) (params.ErrorResults, error) {
+	if !api.isAdmin {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	changed := false
+	for i, arg := range args.Args {
+		if !validFeature.MatchString(arg.Feature) {
+			results[i].Error = common.ServerError(errors.NotValidf("feature name %q", arg.Feature))
+			continue
+		}
+		if err := api.backend.SetControllerFeature(arg.Feature, arg.Enabled, api.apiUser); err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		logger.Infof("feature %q enabled=%v by %q", arg.Feature, arg.Enabled, api.apiUser.Id())
+		changed = true
+	}
+	if !changed {
+		return params.ErrorResults{Results: results}, nil
+	}
+
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if _, err := api.hub.Publish(
+		controller.ConfigChanged,
+		controller.ConfigChangedMessage{cfg}); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
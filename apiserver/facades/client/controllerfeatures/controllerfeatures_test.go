@@ -0,0 +1,178 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerfeatures_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/controllerfeatures"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type controllerFeaturesSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	hub        *mockHub
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&controllerFeaturesSuite{})
+
+func (s *controllerFeaturesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		Stub:     &testing.Stub{},
+		features: []string{"legacy-leases"},
+	}
+	s.hub = &mockHub{Stub: &testing.Stub{}}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *controllerFeaturesSuite) newAPI(c *gc.C) *controllerfeatures.API {
+	api, err := controllerfeatures.NewAPI(s.backend, s.authorizer, s.hub)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *controllerFeaturesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := controllerfeatures.NewAPI(s.backend, s.authorizer, s.hub)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *controllerFeaturesSuite) TestFeaturesNonSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	result, err := s.newAPI(c).Features()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerFeaturesResult{
+		Features: []string{"legacy-leases"},
+	})
+	s.backend.CheckCallNames(c, "ControllerConfig")
+}
+
+func (s *controllerFeaturesSuite) TestSetFeaturesRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).SetFeatures(params.SetControllerFeatureArgs{
+		Args: []params.SetControllerFeatureArg{{Feature: "cmr-aware-bundles", Enabled: true}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *controllerFeaturesSuite) TestFeatures(c *gc.C) {
+	when := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.changes = []state.ControllerFeatureChange{{
+		Feature: "legacy-leases",
+		Enabled: true,
+		User:    "admin",
+		When:    when,
+	}}
+	result, err := s.newAPI(c).Features()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerFeaturesResult{
+		Features: []string{"legacy-leases"},
+		Changes: []params.ControllerFeatureChange{{
+			Feature: "legacy-leases",
+			Enabled: true,
+			User:    "admin",
+			When:    when,
+		}},
+	})
+}
+
+func (s *controllerFeaturesSuite) TestSetFeatures(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	results, err := s.newAPI(c).SetFeatures(params.SetControllerFeatureArgs{
+		Args: []params.SetControllerFeatureArg{
+			{Feature: "cmr-aware-bundles", Enabled: true},
+			{Feature: "legacy-leases", Enabled: false},
+			{Feature: "Not Valid", Enabled: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+		{Error: &params.Error{Message: `feature name "Not Valid" not valid`}},
+	}})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetControllerFeature", []interface{}{"cmr-aware-bundles", true, names.NewUserTag("admin")}},
+		{"SetControllerFeature", []interface{}{"legacy-leases", false, names.NewUserTag("admin")}},
+		{"ControllerConfig", nil},
+	})
+	s.hub.CheckCalls(c, []testing.StubCall{
+		{"Publish", []interface{}{controller.ConfigChanged, controller.ConfigChangedMessage{
+			Config: s.backend.config(),
+		}}},
+	})
+}
+
+func (s *controllerFeaturesSuite) TestSetFeaturesNoChangesNotPublished(c *gc.C) {
+	results, err := s.newAPI(c).SetFeatures(params.SetControllerFeatureArgs{
+		Args: []params.SetControllerFeatureArg{{Feature: "", Enabled: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `feature name "" not valid`)
+	s.backend.CheckNoCalls(c)
+	s.hub.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	*testing.Stub
+	features []string
+	changes  []state.ControllerFeatureChange
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) config() jujucontroller.Config {
+	features := make([]interface{}, len(b.features))
+	for i, f := range b.features {
+		features[i] = f
+	}
+	return jujucontroller.Config{jujucontroller.Features: features}
+}
+
+func (b *mockBackend) ControllerConfig() (jujucontroller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return b.config(), b.NextErr()
+}
+
+func (b *mockBackend) ControllerFeatureChanges() ([]state.ControllerFeatureChange, error) {
+	b.MethodCall(b, "ControllerFeatureChanges")
+	return b.changes, b.NextErr()
+}
+
+func (b *mockBackend) SetControllerFeature(feature string, enabled bool, user names.UserTag) error {
+	b.MethodCall(b, "SetControllerFeature", feature, enabled, user)
+	return b.NextErr()
+}
+
+type mockHub struct {
+	*testing.Stub
+}
+
+func (h *mockHub) Publish(topic string, data interface{}) (<-chan struct{}, error) {
+	h.MethodCall(h, "Publish", topic, data)
+	return nil, h.NextErr()
+}
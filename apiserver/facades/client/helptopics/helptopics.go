@@ -0,0 +1,92 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package helptopics provides the HelpTopics facade, which serves help
+// topics documenting the features of the controller. Clients use it so
+// that a newer client connected to an older controller shows the
+// documentation for the features that controller actually has.
+package helptopics
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// API implements the HelpTopics facade.
+type API struct {
+	version version.Number
+	topics  []Topic
+}
+
+// NewFacade creates a new HelpTopics facade serving the
+// help topics of the running version of Juju.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.Auth(), jujuversion.Current, AllTopics())
+}
+
+// NewAPI returns a new HelpTopics facade serving the given topics.
+func NewAPI(authorizer facade.Authorizer, version version.Number, topics []Topic) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		version: version,
+		topics:  topics,
+	}, nil
+}
+
+// ListTopics returns the names and summaries of the help topics
+// served by the controller.
+func (api *API) ListTopics() (params.HelpTopicList, error) {
+	result := params.HelpTopicList{
+		Version: api.version.String(),
+		Topics:  make([]params.HelpTopic, len(api.topics)),
+	}
+	for i, topic := range api.topics {
+		result.Topics[i] = params.HelpTopic{
+			Name:  topic.Name,
+			Short: topic.Short,
+		}
+	}
+	return result, nil
+}
+
+// Topics returns the named help topics.
+func (api *API) Topics(args params.HelpTopicNames) (params.HelpTopicResults, error) {
+	results := params.HelpTopicResults{
+		Version: api.version.String(),
+		Results: make([]params.HelpTopicResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		topic, err := api.topic(name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = topic
+	}
+	return results, nil
+}
+
+func (api *API) topic(name string) (*params.HelpTopic, error) {
+	for _, topic := range api.topics {
+		if topic.Name != name {
+			continue
+		}
+		doc, err := topic.Doc()
+		if err != nil {
+			return nil, errors.Annotatef(err, "rendering help topic %q", name)
+		}
+		return &params.HelpTopic{
+			Name:  topic.Name,
+			Short: topic.Short,
+			Doc:   doc,
+		}, nil
+	}
+	return nil, errors.NotFoundf("help topic %q", name)
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics_test
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/helptopics"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+)
+
+type helpTopicsSuite struct {
+	api *helptopics.API
+}
+
+var _ = gc.Suite(&helpTopicsSuite{})
+
+func (s *helpTopicsSuite) SetUpTest(c *gc.C) {
+	topics := []helptopics.Topic{{
+		Name:  "spam",
+		Short: "All about spam",
+		Doc: func() (string, error) {
+			return "spam, spam and spam\n", nil
+		},
+	}, {
+		Name:  "eggs",
+		Short: "All about eggs",
+		Doc: func() (string, error) {
+			return "", errors.New("boom")
+		},
+	}}
+	var err error
+	s.api, err = helptopics.NewAPI(
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("fred")},
+		version.MustParse("2.6.1"),
+		topics,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *helpTopicsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := helptopics.NewAPI(
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+		version.MustParse("2.6.1"),
+		nil,
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *helpTopicsSuite) TestListTopics(c *gc.C) {
+	result, err := s.api.ListTopics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HelpTopicList{
+		Version: "2.6.1",
+		Topics: []params.HelpTopic{
+			{Name: "spam", Short: "All about spam"},
+			{Name: "eggs", Short: "All about eggs"},
+		},
+	})
+}
+
+func (s *helpTopicsSuite) TestTopics(c *gc.C) {
+	results, err := s.api.Topics(params.HelpTopicNames{
+		Names: []string{"spam", "eggs", "ham"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HelpTopicResults{
+		Version: "2.6.1",
+		Results: []params.HelpTopicResult{{
+			Result: &params.HelpTopic{
+				Name:  "spam",
+				Short: "All about spam",
+				Doc:   "spam, spam and spam\n",
+			},
+		}, {
+			Error: &params.Error{Message: `rendering help topic "eggs": boom`},
+		}, {
+			Error: &params.Error{
+				Message: `help topic "ham" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}},
+	})
+}
+
+func (s *helpTopicsSuite) TestAllTopics(c *gc.C) {
+	for _, topic := range helptopics.AllTopics() {
+		c.Logf("topic %q", topic.Name)
+		c.Check(topic.Short, gc.Not(gc.Equals), "")
+		doc, err := topic.Doc()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(doc, gc.Not(gc.Equals), "")
+	}
+}
+
+func (s *helpTopicsSuite) topicDoc(c *gc.C, name string) string {
+	for _, topic := range helptopics.AllTopics() {
+		if topic.Name == name {
+			doc, err := topic.Doc()
+			c.Assert(err, jc.ErrorIsNil)
+			return doc
+		}
+	}
+	c.Fatalf("no %q topic", name)
+	return ""
+}
+
+func (s *helpTopicsSuite) TestModelConfigTopic(c *gc.C) {
+	doc := s.topicDoc(c, "model-config")
+	c.Check(doc, jc.Contains, "\ndefault-series (string)\n")
+	c.Check(doc, jc.Contains, "\nupdate-status-hook-interval (string)\n")
+}
+
+func (s *helpTopicsSuite) TestConstraintsTopic(c *gc.C) {
+	// Every constraint is documented.
+	doc := s.topicDoc(c, "constraints")
+	valueType := reflect.TypeOf(constraints.Value{})
+	for i := 0; i < valueType.NumField(); i++ {
+		name := strings.Split(valueType.Field(i).Tag.Get("json"), ",")[0]
+		c.Check(doc, jc.Contains, "\n"+name+"\n")
+	}
+}
+
+func (s *helpTopicsSuite) TestPlacementTopic(c *gc.C) {
+	doc := s.topicDoc(c, "placement")
+	c.Check(doc, jc.Contains, "\nzones=[<zone>,...]\n")
+	c.Check(doc, jc.Contains, "\nspread=node, spread=zone, spread=node|zone\n")
+	c.Check(doc, jc.Contains, "--spread")
+}
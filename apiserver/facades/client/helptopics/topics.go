@@ -0,0 +1,162 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helptopics
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// Topic describes a help topic served by the controller.
+type Topic struct {
+	// Name is the name used to ask for the topic.
+	Name string
+
+	// Short is a one line summary of the topic.
+	Short string
+
+	// Doc returns the text of the topic.
+	Doc func() (string, error)
+}
+
+// AllTopics returns the help topics documenting this version of Juju.
+func AllTopics() []Topic {
+	return []Topic{{
+		Name:  "constraints",
+		Short: "Constraints supported by the controller",
+		Doc:   staticDoc(constraintsDoc),
+	}, {
+		Name:  "model-config",
+		Short: "Model configuration keys supported by the controller",
+		Doc:   modelConfigDoc,
+	}, {
+		Name:  "placement",
+		Short: "Placement directives supported by the controller",
+		Doc:   staticDoc(placementDoc),
+	}}
+}
+
+func staticDoc(doc string) func() (string, error) {
+	return func() (string, error) {
+		return doc, nil
+	}
+}
+
+const constraintsDoc = `
+Constraints describe the machines that applications are deployed to.
+They are set with "juju deploy --constraints", "juju set-constraints"
+and "juju set-model-constraints", as space separated key=value pairs.
+Not every cloud supports every constraint.
+
+arch
+    The machine architecture, such as amd64 or arm64.
+container
+    The kind of container to place units in, such as lxd.
+cores
+    The minimum number of effective CPU cores. cpu-cores is an alias.
+cpu-power
+    The minimum CPU power, where 100 is a single 2007-era Xeon core.
+cpu-quota
+    The CPU time each unit on the machine may use, as a percentage of
+    a single CPU. It limits units rather than choosing the machine.
+gpus
+    The minimum number of GPUs attached to the machine.
+gpu-type
+    The kind of GPU to attach. The values accepted depend on the cloud.
+instance-lifecycle
+    The lifecycle of the instance, such as preemptible or spot.
+    Such instances may be stopped by the cloud at any time.
+instance-type
+    The cloud specific instance type to use.
+mem
+    The minimum memory, with an optional M, G, T or P suffix.
+mem-limit
+    The memory each unit on the machine may use, with an optional M,
+    G, T or P suffix. It limits units rather than choosing the machine.
+root-disk
+    The minimum root disk size, with an optional M, G, T or P suffix.
+root-disk-source
+    The storage the root disk is allocated from. The values accepted
+    depend on the cloud.
+spaces
+    A comma separated list of spaces the machine must, or with a "^"
+    prefix must not, be connected to.
+tags
+    A comma separated list of tags the machine must, or with a "^"
+    prefix must not, have. Only supported by MAAS.
+virt-type
+    The kind of virtualisation to use, such as kvm.
+zones
+    A comma separated list of availability zones the machine may be
+    placed in.
+`[1:]
+
+const placementDoc = `
+Placement directives choose where units and machines are created.
+They are given with the --to option of "juju deploy" and
+"juju add-unit", and as the argument of "juju add-machine".
+
+<machine>
+    An existing machine, such as 3.
+<container type>:<machine>
+    A new container on an existing machine, such as lxd:3.
+<container type>
+    A new container on a new machine, such as lxd.
+zone=<zone>
+    A new machine in the named availability zone.
+zones=[<zone>,...]
+    A new machine in any of the listed availability zones. Only used
+    in the "to" list of a bundle application.
+subnet=<subnet>
+    A new machine connected to the named subnet, where supported.
+<host>
+    A new machine on the named host, such as a MAAS node.
+spread=node, spread=zone, spread=node|zone
+    On Kubernetes models, keeps each pod of the application on a
+    different node, in a different availability zone, or both.
+
+Several directives may be given as a comma separated list, and are
+used in turn for each unit added.
+
+Units that are not placed are spread across machines according to the
+--spread option of "juju deploy": "zones" (the default) spreads them
+across availability zones, "hosts" keeps each unit on a different host
+machine, and "none" places them without regard to each other.
+`[1:]
+
+// modelConfigDoc documents the model configuration
+// keys known to this version of Juju.
+func modelConfigDoc() (string, error) {
+	fields, err := config.Schema(nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString(modelConfigIntro)
+	for _, name := range names {
+		attr := fields[name]
+		fmt.Fprintf(&buf, "\n%s (%s)\n", name, attr.Type)
+		if attr.Description != "" {
+			fmt.Fprintf(&buf, "    %s\n", attr.Description)
+		}
+	}
+	return buf.String(), nil
+}
+
+const modelConfigIntro = `
+Model configuration is viewed and changed with "juju model-config".
+The keys below are common to all clouds; clouds may support
+additional keys, which are listed by "juju model-config".
+`[1:]
@@ -177,13 +177,13 @@ func getReferenceController(st *state.State, machineIds []string) (*state.Machin
 	return cm, nil
 }
 
-// validateCurrentControllers checks for a scenario where there is no HA space
-// in controller configuration and more than one machine-local address on any
-// of the controller machines. An error is returned if it is detected.
-// When HA space is set, there are other code paths that ensure controllers
+// validateCurrentControllers checks for a scenario where there is no HA (or DB)
+// space in controller configuration and more than one machine-local address on
+// any of the controller machines. An error is returned if it is detected.
+// When either space is set, there are other code paths that ensure controllers
 // have at least one address in the space.
 func validateCurrentControllers(st *state.State, cfg controller.Config, machineIds []string) error {
-	if cfg.JujuHASpace() != "" {
+	if cfg.JujuHASpace() != "" || cfg.JujuDBSpace() != "" {
 		return nil
 	}
 
@@ -0,0 +1,158 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package labels provides the facade used to read and update the
+// freeform labels attached to machines and units.
+package labels
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/permission"
+)
+
+// API implements version 2 of the Labels facade,
+// which adds Select.
+type API struct {
+	backend    Backend
+	model      ModelCache
+	authorizer facade.Authorizer
+}
+
+// APIv1 implements version 1 of the Labels facade.
+type APIv1 struct {
+	*API
+}
+
+// NewFacade returns a new Labels facade for the model.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := ctx.Controller().Model(st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{st: st, m: m}, model, ctx.Auth())
+}
+
+// NewFacadeV1 returns a new version 1 Labels facade for the model.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewAPI returns a new Labels facade using the backend, and the
+// model cache to select machines and units by their labels.
+func NewAPI(backend Backend, model ModelCache, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		model:      model,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Labels returns the labels attached to each of the machines and units.
+func (api *API) Labels(args params.Entities) (params.LabelsResults, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.LabelsResults{}, errors.Trace(err)
+	}
+	results := make([]params.LabelsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		entity, err := api.entity(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Labels = entity.Labels()
+	}
+	return params.LabelsResults{Results: results}, nil
+}
+
+// UpdateLabels sets and removes labels of each of the machines and units.
+func (api *API) UpdateLabels(args params.UpdateLabelsArgs) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		entity, err := api.entity(arg.Tag)
+		if err == nil {
+			err = entity.UpdateLabels(arg.Set, arg.Remove)
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// Select returns the machines and units whose labels match the
+// selector, as known to the controller's model cache.
+func (api *API) Select(arg params.LabelSelectorArg) (params.LabelSelectResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.LabelSelectResult{}, errors.Trace(err)
+	}
+	selector, err := labels.ParseSelector(arg.Selector)
+	if err != nil {
+		return params.LabelSelectResult{}, errors.Trace(err)
+	}
+	var result params.LabelSelectResult
+	for id := range api.model.MachinesMatching(selector) {
+		result.Machines = append(result.Machines, id)
+	}
+	for name := range api.model.UnitsMatching(selector) {
+		result.Units = append(result.Units, name)
+	}
+	sort.Strings(result.Machines)
+	sort.Strings(result.Units)
+	return result, nil
+}
+
+// Select isn't on the v1 API.
+func (*APIv1) Select(_, _ struct{}) {}
+
+// entity returns the machine or unit with the tag.
+func (api *API) entity(tagString string) (LabelledEntity, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var entity LabelledEntity
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		entity, err = api.backend.Machine(tag.Id())
+	case names.UnitTag:
+		entity, err = api.backend.Unit(tag.Id())
+	default:
+		return nil, common.NotSupportedError(tag, "labels")
+	}
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	}
+	return entity, errors.Trace(err)
+}
@@ -0,0 +1,200 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package labels_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/labels"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/cache"
+	corelabels "github.com/juju/juju/core/labels"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type labelsSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	model      *mockModelCache
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (s *labelsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		Stub: &testing.Stub{},
+		entities: map[string]*mockEntity{
+			"machine-0":    {Stub: &testing.Stub{}, labels: map[string]string{"env": "canary"}},
+			"unit-mysql-0": {Stub: &testing.Stub{}},
+		},
+	}
+	s.model = &mockModelCache{
+		machines: map[string]map[string]string{
+			"0": {"env": "canary"},
+			"1": {"env": "prod"},
+		},
+		units: map[string]map[string]string{
+			"mysql/0": {"env": "canary"},
+			"mysql/1": nil,
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *labelsSuite) newAPI(c *gc.C) *labels.API {
+	api, err := labels.NewAPI(s.backend, s.model, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *labelsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := labels.NewAPI(s.backend, s.model, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *labelsSuite) TestLabels(c *gc.C) {
+	results, err := s.newAPI(c).Labels(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "machine-1"},
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.LabelsResults{Results: []params.LabelsResult{
+		{Labels: map[string]string{"env": "canary"}},
+		{},
+		{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		{Error: &params.Error{Message: `entity "application-mysql" does not support labels`}},
+	}})
+}
+
+func (s *labelsSuite) TestUpdateLabels(c *gc.C) {
+	s.backend.entities["unit-mysql-0"].SetErrors(errors.NotValidf(`label key "Env"`))
+	results, err := s.newAPI(c).UpdateLabels(params.UpdateLabelsArgs{Args: []params.UpdateLabelsArg{{
+		Tag:    "machine-0",
+		Set:    map[string]string{"tier": "db"},
+		Remove: []string{"env"},
+	}, {
+		Tag: "unit-mysql-0",
+		Set: map[string]string{"Env": "prod"},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `label key "Env" not valid`, Code: params.CodeNotValid}},
+	}})
+	s.backend.entities["machine-0"].CheckCall(c, 0, "UpdateLabels", map[string]string{"tier": "db"}, []string{"env"})
+}
+
+func (s *labelsSuite) TestUpdateLabelsRequiresWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).UpdateLabels(params.UpdateLabelsArgs{Args: []params.UpdateLabelsArg{{
+		Tag: "machine-0",
+		Set: map[string]string{"tier": "db"},
+	}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.entities["machine-0"].CheckNoCalls(c)
+}
+
+func (s *labelsSuite) TestSelect(c *gc.C) {
+	result, err := s.newAPI(c).Select(params.LabelSelectorArg{Selector: "env=canary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LabelSelectResult{
+		Machines: []string{"0"},
+		Units:    []string{"mysql/0"},
+	})
+
+	result, err = s.newAPI(c).Select(params.LabelSelectorArg{Selector: "env"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LabelSelectResult{
+		Machines: []string{"0", "1"},
+		Units:    []string{"mysql/0"},
+	})
+}
+
+func (s *labelsSuite) TestSelectInvalidSelector(c *gc.C) {
+	_, err := s.newAPI(c).Select(params.LabelSelectorArg{Selector: "env=canary,"})
+	c.Assert(err, gc.ErrorMatches, ".*not valid")
+}
+
+type mockModelCache struct {
+	machines map[string]map[string]string
+	units    map[string]map[string]string
+}
+
+func (m *mockModelCache) MachinesMatching(selector corelabels.Selector) map[string]cache.Machine {
+	machines := make(map[string]cache.Machine)
+	for id, labels := range m.machines {
+		if selector.Matches(labels) {
+			machines[id] = cache.Machine{}
+		}
+	}
+	return machines
+}
+
+func (m *mockModelCache) UnitsMatching(selector corelabels.Selector) map[string]cache.Unit {
+	units := make(map[string]cache.Unit)
+	for name, labels := range m.units {
+		if selector.Matches(labels) {
+			units[name] = cache.Unit{}
+		}
+	}
+	return units
+}
+
+type mockBackend struct {
+	*testing.Stub
+	entities map[string]*mockEntity
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Machine(id string) (labels.LabelledEntity, error) {
+	b.MethodCall(b, "Machine", id)
+	return b.entity(names.NewMachineTag(id))
+}
+
+func (b *mockBackend) Unit(name string) (labels.LabelledEntity, error) {
+	b.MethodCall(b, "Unit", name)
+	return b.entity(names.NewUnitTag(name))
+}
+
+func (b *mockBackend) entity(tag names.Tag) (labels.LabelledEntity, error) {
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	entity, ok := b.entities[tag.String()]
+	if !ok {
+		return nil, errors.NotFoundf("%s", names.ReadableString(tag))
+	}
+	return entity, nil
+}
+
+type mockEntity struct {
+	*testing.Stub
+	labels map[string]string
+}
+
+func (e *mockEntity) Labels() map[string]string {
+	return e.labels
+}
+
+func (e *mockEntity) UpdateLabels(set map[string]string, remove []string) error {
+	e.MethodCall(e, "UpdateLabels", set, remove)
+	return e.NextErr()
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package labels
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the labels facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Machine(id string) (LabelledEntity, error)
+	Unit(name string) (LabelledEntity, error)
+}
+
+// LabelledEntity is a machine or unit which may have labels attached.
+type LabelledEntity interface {
+	Labels() map[string]string
+	UpdateLabels(set map[string]string, remove []string) error
+}
+
+// ModelCache defines the model cache functionality required by the
+// labels facade.
+type ModelCache interface {
+	MachinesMatching(selector labels.Selector) map[string]cache.Machine
+	UnitsMatching(selector labels.Selector) map[string]cache.Unit
+}
+
+type stateShim struct {
+	st *state.State
+	m  *state.Model
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.m.ModelTag()
+}
+
+func (s stateShim) Machine(id string) (LabelledEntity, error) {
+	return s.st.Machine(id)
+}
+
+func (s stateShim) Unit(name string) (LabelledEntity, error) {
+	return s.st.Unit(name)
+}
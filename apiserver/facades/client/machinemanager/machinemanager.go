@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.machinemanager")
@@ -71,6 +72,12 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds NetworkStatus.
+type MachineManagerAPIV7 struct {
 	*MachineManagerAPI
 }
 
@@ -94,11 +101,20 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV7{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -156,6 +172,14 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	if err := mm.check.ChangeAllowed(); err != nil {
 		return results, errors.Trace(err)
 	}
+	// Check the quota for the whole request up front, so that a
+	// request which would exceed it adds no machines at all.
+	if err := mm.st.CheckModelQuotas(machineQuotaUsage(args.MachineParams)); err != nil {
+		for i := range results.Machines {
+			results.Machines[i].Error = common.ServerError(err)
+		}
+		return results, nil
+	}
 	for i, p := range args.MachineParams {
 		m, err := mm.addOneMachine(p)
 		results.Machines[i].Error = common.ServerError(err)
@@ -166,6 +190,34 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// machineQuotaUsage returns the number of machines, counted towards
+// the model's machine quota, which would be added for the given
+// params. A container on a new machine counts as two machines, and
+// controller machines are not counted.
+func machineQuotaUsage(args []params.AddMachineParams) state.ModelQuotaUsage {
+	var usage state.ModelQuotaUsage
+	for _, p := range args {
+		isController := false
+		for _, job := range p.Jobs {
+			if job == multiwatcher.JobManageModel {
+				isController = true
+			}
+		}
+		if isController {
+			continue
+		}
+		usage.Machines++
+		if p.ContainerType != "" && p.ParentId == "" {
+			usage.Machines++
+		} else if p.Placement != nil && p.Placement.Directive == "" {
+			if _, err := instance.ParseContainerType(p.Placement.Scope); err == nil {
+				usage.Machines++
+			}
+		}
+	}
+	return usage
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
@@ -593,6 +645,57 @@ func (mm *MachineManagerAPI) GetUpgradeSeriesMessages(args params.UpgradeSeriesN
 	return results, nil
 }
 
+// NetworkStatus returns the FAN networking status last reported by
+// each of the specified machines.
+func (mm *MachineManagerAPI) NetworkStatus(args params.Entities) (params.MachineNetworkStatusResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.MachineNetworkStatusResults{}, err
+	}
+	results := params.MachineNetworkStatusResults{
+		Results: make([]params.MachineNetworkStatusResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := mm.machineFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		networkStatus, err := machine.NetworkStatus()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = networkStatusToParams(networkStatus)
+	}
+	return results, nil
+}
+
+// NetworkStatus isn't on the V6 API.
+func (*MachineManagerAPIV6) NetworkStatus(_, _ struct{}) {}
+
+func networkStatusToParams(networkStatus state.MachineNetworkStatus) *params.MachineNetworkStatus {
+	result := &params.MachineNetworkStatus{
+		Updated: networkStatus.Updated,
+	}
+	for _, bridge := range networkStatus.FanBridges {
+		result.FanBridges = append(result.FanBridges, params.FanBridgeStatus{
+			Name:         bridge.Name,
+			Underlay:     bridge.Underlay,
+			Overlay:      bridge.Overlay,
+			LocalOverlay: bridge.LocalOverlay,
+			Present:      bridge.Present,
+			Up:           bridge.Up,
+		})
+	}
+	for _, fanErr := range networkStatus.FanErrors {
+		result.FanErrors = append(result.FanErrors, params.FanConfigError{
+			Time:    fanErr.Time,
+			Message: fanErr.Message,
+		})
+	}
+	return result
+}
+
 func (mm *MachineManagerAPI) machineFromTag(tag string) (Machine, error) {
 	machineTag, err := names.ParseMachineTag(tag)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
@@ -143,6 +144,31 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.SetErrors(&state.ErrQuotaExceeded{
+		Entity: "machines", Key: "max-model-machines", Limit: 2, Current: 1, Adding: 3,
+	})
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+		}, {
+			Series:        "trusty",
+			ContainerType: instance.LXD,
+		}, {
+			Series: "trusty",
+			Jobs:   []multiwatcher.MachineJob{multiwatcher.JobManageModel},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 3)
+	for _, result := range results.Machines {
+		c.Check(result.Error, gc.ErrorMatches, `machines quota exceeded: model has 1 of the 2 allowed by "max-model-machines", cannot add 3 more`)
+		c.Check(result.Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	}
+	s.st.CheckCall(c, 0, "CheckModelQuotas", state.ModelQuotaUsage{Machines: 3})
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	s.st.machines["0"] = &mockMachine{}
 	results, err := s.api.DestroyMachine(params.Entities{
@@ -450,7 +476,9 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{
+		MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{&machinemanager.MachineManagerAPIV7{s.api}},
+	}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -743,6 +771,66 @@ func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *MachineManagerSuite) TestNetworkStatus(c *gc.C) {
+	updated := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.st.machines["0"] = &mockMachine{
+		networkStatus: state.MachineNetworkStatus{
+			FanBridges: []state.FanBridgeStatus{{
+				Name:         "fan-252",
+				Underlay:     "10.0.0.0/16",
+				Overlay:      "252.0.0.0/8",
+				LocalOverlay: "252.5.0.0/16",
+				Present:      true,
+				Up:           true,
+			}},
+			FanErrors: []state.FanConfigError{{
+				Time:    updated.Add(-time.Minute),
+				Message: "fanatic: exit status 1",
+			}},
+			Updated: updated,
+		},
+	}
+	s.st.machines["1"] = &mockMachine{}
+	s.st.machines["1"].SetErrors(errors.NotFoundf(`network status for machine "1"`))
+
+	results, err := s.api.NetworkStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "application-foo"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MachineNetworkStatusResults{
+		Results: []params.MachineNetworkStatusResult{{
+			Result: &params.MachineNetworkStatus{
+				FanBridges: []params.FanBridgeStatus{{
+					Name:         "fan-252",
+					Underlay:     "10.0.0.0/16",
+					Overlay:      "252.0.0.0/8",
+					LocalOverlay: "252.5.0.0/16",
+					Present:      true,
+					Up:           true,
+				}},
+				FanErrors: []params.FanConfigError{{
+					Time:    updated.Add(-time.Minute),
+					Message: "fanatic: exit status 1",
+				}},
+				Updated: updated,
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `network status for machine "1" not found`,
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"application-foo" is not a valid machine tag`,
+			},
+		}},
+	})
+}
+
 // TestIsSeriesLessThan tests a validation method which is not very complicated
 // but complex enough to warrant being exported from an export test package for
 // testing.
@@ -840,6 +928,11 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) CheckModelQuotas(adding state.ModelQuotaUsage) error {
+	st.MethodCall(st, "CheckModelQuotas", adding)
+	return st.NextErr()
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -927,6 +1020,7 @@ type mockMachine struct {
 	unitAgentState status.Status
 	unitState      status.Status
 	isManager      bool
+	networkStatus  state.MachineNetworkStatus
 
 	unitsF func() ([]machinemanager.Unit, error)
 }
@@ -997,6 +1091,11 @@ func (m *mockMachine) CompleteUpgradeSeries() error {
 	return m.NextErr()
 }
 
+func (m *mockMachine) NetworkStatus() (state.MachineNetworkStatus, error) {
+	m.MethodCall(m, "NetworkStatus")
+	return m.networkStatus, m.NextErr()
+}
+
 func (m *mockMachine) IsManager() bool {
 	m.MethodCall(m, "IsManager")
 	return m.isManager
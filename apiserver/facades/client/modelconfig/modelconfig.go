@@ -4,6 +4,9 @@
 package modelconfig
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/rpc"
 )
 
 // NewFacade is used for API registration.
@@ -132,8 +136,9 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 }
 
 // ModelSet implements the server-side part of the
-// set-model-config CLI command.
-func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
+// set-model-config CLI command. Setting deprecated attributes
+// attaches a warning to the reply.
+func (c *ModelConfigAPI) ModelSet(ctx context.Context, args params.ModelSet) error {
 	if err := c.checkCanWrite(); err != nil {
 		return err
 	}
@@ -182,6 +187,13 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		return nil
 	}
 
+	for _, name := range config.DeprecatedAttributes(args.Config) {
+		rpc.Warn(ctx, rpc.Warning{
+			Code:    params.WarningDeprecatedConfig,
+			Message: fmt.Sprintf("model config attribute %q is deprecated and will be removed in a future release", name),
+		})
+	}
+
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
 	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
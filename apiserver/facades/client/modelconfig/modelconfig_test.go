@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"context"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -75,12 +78,27 @@ func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 			"some-key":  "value",
 			"other-key": "other value"},
 	}
-	err := s.api.ModelSet(params)
+	err := s.api.ModelSet(context.Background(), params)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertConfigValue(c, "some-key", "value")
 	s.assertConfigValue(c, "other-key", "other value")
 }
 
+func (s *modelconfigSuite) TestModelSetNoDeprecatedAttributes(c *gc.C) {
+	// ignore-machine-addresses is still used by the machiner,
+	// so setting it does not warn.
+	ctx, warnings := rpc.WithWarnings(context.Background())
+	err := s.api.ModelSet(ctx, params.ModelSet{
+		Config: map[string]interface{}{
+			"some-key":                 "value",
+			"ignore-machine-addresses": true,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "ignore-machine-addresses", true)
+	c.Assert(warnings.All(), gc.HasLen, 0)
+}
+
 func (s *modelconfigSuite) blockAllChanges(c *gc.C, msg string) {
 	s.backend.msg = msg
 	s.backend.b = state.ChangeBlock
@@ -95,7 +113,7 @@ func (s *modelconfigSuite) assertBlocked(c *gc.C, err error, msg string) {
 }
 
 func (s *modelconfigSuite) assertModelSetBlocked(c *gc.C, args map[string]interface{}, msg string) {
-	err := s.api.ModelSet(params.ModelSet{args})
+	err := s.api.ModelSet(context.Background(), params.ModelSet{args})
 	s.assertBlocked(c, err, msg)
 }
 
@@ -114,7 +132,7 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	args := params.ModelSet{
 		map[string]interface{}{"agent-version": "9.9.9"},
 	}
-	err = s.api.ModelSet(args)
+	err = s.api.ModelSet(context.Background(), args)
 	c.Assert(err, gc.ErrorMatches, "agent-version cannot be changed")
 
 	// It's okay to pass config back with the same agent-version.
@@ -122,7 +140,7 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Config["agent-version"], gc.NotNil)
 	args.Config["agent-version"] = result.Config["agent-version"].Value
-	err = s.api.ModelSet(args)
+	err = s.api.ModelSet(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -130,7 +148,7 @@ func (s *modelconfigSuite) TestAdminCanSetLogTrace(c *gc.C) {
 	args := params.ModelSet{
 		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
 	}
-	err := s.api.ModelSet(args)
+	err := s.api.ModelSet(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := s.api.ModelGet()
@@ -145,7 +163,7 @@ func (s *modelconfigSuite) TestUserCanSetLogNoTrace(c *gc.C) {
 	apiUser := names.NewUserTag("fred")
 	s.authorizer.Tag = apiUser
 	s.authorizer.HasWriteTag = apiUser
-	err := s.api.ModelSet(args)
+	err := s.api.ModelSet(context.Background(), args)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := s.api.ModelGet()
@@ -160,7 +178,7 @@ func (s *modelconfigSuite) TestUserReadAccess(c *gc.C) {
 	_, err := s.api.ModelGet()
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = s.api.ModelSet(params.ModelSet{})
+	err = s.api.ModelSet(context.Background(), params.ModelSet{})
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
 }
 
@@ -171,7 +189,7 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 	apiUser := names.NewUserTag("fred")
 	s.authorizer.Tag = apiUser
 	s.authorizer.HasWriteTag = apiUser
-	err := s.api.ModelSet(args)
+	err := s.api.ModelSet(context.Background(), args)
 	c.Assert(err, gc.ErrorMatches, `only controller admins can set a model's logging level to TRACE`)
 }
 
@@ -6,9 +6,19 @@ package modelmanager
 import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common/credentialcommon"
 )
 
 func AuthCheck(c *gc.C, mm *ModelManagerAPI, user names.UserTag) bool {
 	mm.authCheck(user)
 	return mm.isAdmin
 }
+
+var ValidateNewCredentialForModelFunc = &validateNewCredentialForModelFunc
+
+// SetCredentialBackend sets the function used by the facade to get
+// the backend for validating cloud credentials against a model.
+func SetCredentialBackend(mm *ModelManagerAPI, f func(string) (credentialcommon.PersistentBackend, func() bool, error)) {
+	mm.credentialBackend = f
+}
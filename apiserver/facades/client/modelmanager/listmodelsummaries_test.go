@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -155,6 +156,30 @@ func (s *ListModelsWithInfoSuite) TestListModelSummariesWithCoreCount(c *gc.C) {
 	c.Assert(result.Results[0].Result.Counts[0], jc.DeepEquals, params.ModelEntityCount{params.Cores, 43})
 }
 
+func (s *ListModelsWithInfoSuite) TestListModelSummariesWithStorageCount(c *gc.C) {
+	s.st.modelDetailsForUser = func() ([]state.ModelSummary, error) {
+		summary := s.st.model.getModelDetails()
+		summary.StorageCount = int64(7)
+		return []state.ModelSummary{summary}, nil
+	}
+	result, err := s.api.ListModelSummaries(params.ModelSummariesRequest{UserTag: s.adminUser.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Result.Counts[0], jc.DeepEquals, params.ModelEntityCount{params.Storage, 7})
+}
+
+func (s *ListModelsWithInfoSuite) TestListModelSummariesWithQuotas(c *gc.C) {
+	s.st.controllerAttrs = map[string]interface{}{
+		controller.MaxModelMachines: 10,
+		controller.MaxModelStorage:  20,
+	}
+	result, err := s.api.ListModelSummaries(params.ModelSummariesRequest{UserTag: s.adminUser.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Result.Quotas, jc.DeepEquals, []params.ModelEntityCount{
+		{params.Machines, 10},
+		{params.Storage, 20},
+	})
+}
+
 func (s *ListModelsWithInfoSuite) TestListModelSummariesWithMachineAndUserDetails(c *gc.C) {
 	now := time.Now()
 	s.st.modelDetailsForUser = func() ([]state.ModelSummary, error) {
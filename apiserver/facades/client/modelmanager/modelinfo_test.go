@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/credentialcommon"
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -598,6 +599,7 @@ type mockState struct {
 	block           state.BlockType
 	migration       *mockMigration
 	modelConfig     *config.Config
+	controllerAttrs map[string]interface{}
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
 }
@@ -740,9 +742,13 @@ func (st *mockState) IsController() bool {
 
 func (st *mockState) ControllerConfig() (controller.Config, error) {
 	st.MethodCall(st, "ControllerConfig")
-	return controller.Config{
+	cfg := controller.Config{
 		controller.ControllerUUIDKey: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
-	}, st.NextErr()
+	}
+	for k, v := range st.controllerAttrs {
+		cfg[k] = v
+	}
+	return cfg, st.NextErr()
 }
 
 func (st *mockState) ControllerNodes() ([]common.ControllerNode, error) {
@@ -1138,6 +1144,11 @@ func (m *mockModel) SetCloudCredential(tag names.CloudCredentialTag) (bool, erro
 	return m.setCloudCredentialF(tag)
 }
 
+func (m *mockModel) TransferOwnership(newOwner names.UserTag, credential names.CloudCredentialTag) error {
+	m.MethodCall(m, "TransferOwnership", newOwner, credential)
+	return m.NextErr()
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
@@ -1165,3 +1176,13 @@ func (m *mockMigration) StartTime() time.Time {
 func (m *mockMigration) EndTime() time.Time {
 	return m.end
 }
+
+type mockCredentialBackend struct {
+	credentialcommon.PersistentBackend
+
+	cred state.Credential
+}
+
+func (b *mockCredentialBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	return b.cred, nil
+}
@@ -22,10 +22,12 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/credentialcommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -39,6 +41,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV8 defines the methods on the version 8 facade for the
+// modelmanager API endpoint.
+type ModelManagerV8 interface {
+	ModelManagerV7
+	TransferModelOwnership(args params.TransferModelOwnershipParams) (params.ErrorResults, error)
+}
+
 // ModelManagerV7 defines the methods on the version 7 facade for the
 // modelmanager API endpoint.
 type ModelManagerV7 interface {
@@ -119,12 +128,22 @@ type ModelManagerAPI struct {
 	model       common.Model
 	getBroker   newCaasBrokerFunc
 	callContext context.ProviderCallContext
+
+	// credentialBackend returns the backend used to check that a
+	// cloud credential is valid for the model with the given UUID.
+	credentialBackend func(modelUUID string) (credentialcommon.PersistentBackend, func() bool, error)
+}
+
+// ModelManagerAPIV7 provides a way to wrap the different calls between
+// version 7 and version 8 of the model manager API
+type ModelManagerAPIV7 struct {
+	*ModelManagerAPI
 }
 
 // ModelManagerAPIV6 provides a way to wrap the different calls between
-// version 6 and version 6 of the model manager API
+// version 6 and version 7 of the model manager API
 type ModelManagerAPIV6 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV7
 }
 
 // ModelManagerAPIV5 provides a way to wrap the different calls between
@@ -152,7 +171,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV7 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
 	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
@@ -160,8 +180,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV7 is used for API registration.
-func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -187,7 +207,7 @@ func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
 	}
 	apiUser, _ := auth.GetAuthTag().(names.UserTag)
 
-	return NewModelManagerAPI(
+	api, err := NewModelManagerAPI(
 		common.NewUserAwareModelManagerBackend(model, pool, apiUser),
 		common.NewModelManagerBackend(ctrlModel, pool),
 		configGetter,
@@ -196,6 +216,26 @@ func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
 		model,
 		state.CallContext(st),
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.credentialBackend = func(modelUUID string) (credentialcommon.PersistentBackend, func() bool, error) {
+		modelState, err := pool.Get(modelUUID)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return credentialcommon.NewPersistentBackend(modelState.State), modelState.Release, nil
+	}
+	return api, nil
+}
+
+// NewFacadeV7 is used for API registration.
+func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
+	v8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV7{v8}, nil
 }
 
 // NewFacadeV6 is used for API registration.
@@ -835,6 +875,25 @@ func (m *ModelManagerAPI) DumpModelsDB(args params.Entities) params.MapResults {
 	return results
 }
 
+// modelQuotas returns the per-model limits set in the given
+// controller config, omitting those which are not set.
+func modelQuotas(cfg controller.Config) []params.ModelEntityCount {
+	var quotas []params.ModelEntityCount
+	for _, q := range []struct {
+		entity params.CountedEntity
+		limit  int
+	}{
+		{params.Machines, cfg.MaxModelMachines()},
+		{params.Units, cfg.MaxModelUnits()},
+		{params.Storage, cfg.MaxModelStorage()},
+	} {
+		if q.limit > 0 {
+			quotas = append(quotas, params.ModelEntityCount{q.entity, int64(q.limit)})
+		}
+	}
+	return quotas
+}
+
 // ListModelSummaries returns models that the specified user
 // has access to in the current server.  Controller admins (superuser)
 // can list models for any user.  Other users
@@ -856,6 +915,11 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 	if err != nil {
 		return result, errors.Trace(err)
 	}
+	controllerConfig, err := m.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	quotas := modelQuotas(controllerConfig)
 
 	for _, mi := range modelInfos {
 		summary := &params.ModelSummary{
@@ -883,6 +947,7 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 
 			Status:             common.EntityStatusFromState(mi.Status),
 			Counts:             []params.ModelEntityCount{},
+			Quotas:             quotas,
 			UserLastConnection: mi.UserLastConnection,
 		}
 
@@ -898,6 +963,10 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 			summary.Counts = append(summary.Counts, params.ModelEntityCount{params.Units, mi.UnitCount})
 		}
 
+		if mi.StorageCount > 0 {
+			summary.Counts = append(summary.Counts, params.ModelEntityCount{params.Storage, mi.StorageCount})
+		}
+
 		access, err := common.StateToParamsUserAccessPermission(mi.Access)
 		if err == nil {
 			summary.UserAccess = access
@@ -1576,6 +1645,115 @@ func (m *ModelManagerAPI) ChangeModelCredential(args params.ChangeModelCredentia
 	return params.ErrorResults{results}, nil
 }
 
+// TransferModelOwnership transfers the ownership of models to other users.
+// A controller superuser may transfer any model. Otherwise the transfer
+// needs the consent of both parties: the current owner consents by
+// granting the new owner admin access to the model, and the new owner
+// consents by making the call. Where a model uses a cloud credential, a
+// credential belonging to the new owner must be supplied, so that the
+// model does not continue to rely on the previous owner's credential,
+// and it is checked against the model's cloud resources as for
+// ChangeModelCredential.
+func (m *ModelManagerAPI) TransferModelOwnership(args params.TransferModelOwnershipParams) (params.ErrorResults, error) {
+	if err := m.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	controllerAdmin, err := m.authorizer.HasPermission(permission.SuperuserAccess, m.state.ControllerTag())
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	transferModel := func(arg params.TransferModelOwnershipParam) error {
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ownerTag, err := names.ParseUserTag(arg.OwnerTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var credentialTag names.CloudCredentialTag
+		if arg.CloudCredentialTag != "" {
+			if credentialTag, err = names.ParseCloudCredentialTag(arg.CloudCredentialTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+
+		model, releaser, err := m.state.GetModel(modelTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer releaser()
+
+		if !controllerAdmin {
+			if err := m.checkTransferConsent(model, ownerTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if credentialTag != (names.CloudCredentialTag{}) {
+			if err := m.validateTransferCredential(modelTag, credentialTag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return errors.Trace(model.TransferOwnership(ownerTag, credentialTag))
+	}
+
+	results := make([]params.ErrorResult, len(args.Models))
+	for i, arg := range args.Models {
+		if err := transferModel(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// checkTransferConsent returns an error unless the API user is the
+// new owner of the model, and has been granted admin access to the
+// model by its current owner.
+func (m *ModelManagerAPI) checkTransferConsent(model common.Model, newOwner names.UserTag) error {
+	if newOwner != m.apiUser {
+		return common.ErrPerm
+	}
+	access, err := m.state.UserAccess(newOwner, model.ModelTag())
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if access.Access != permission.AdminAccess || access.CreatedBy != model.Owner() {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// validateTransferCredential checks that the stored credential
+// with the given tag can be used by the model.
+func (m *ModelManagerAPI) validateTransferCredential(modelTag names.ModelTag, credentialTag names.CloudCredentialTag) error {
+	backend, releaser, err := m.credentialBackend(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	stored, err := backend.CloudCredential(credentialTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credential := jujucloud.NewCredential(jujucloud.AuthType(stored.AuthType), stored.Attributes)
+	results, err := validateNewCredentialForModelFunc(backend, m.callContext, credentialTag, &credential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := results.Combine(); err != nil {
+		return errors.Annotatef(err, "credential %q not valid for model %q", credentialTag.Id(), modelTag.Id())
+	}
+	return nil
+}
+
+var validateNewCredentialForModelFunc = credentialcommon.ValidateNewModelCredential
+
 // Mask out new methods from the old API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
@@ -1585,3 +1763,6 @@ func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
+
+// TransferModelOwnership did not exist prior to v8.
+func (*ModelManagerAPIV7) TransferModelOwnership(_, _ struct{}) {}
@@ -17,6 +17,7 @@ import (
 
 	// Register the providers for the field check test
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/credentialcommon"
 	"github.com/juju/juju/apiserver/facades/client/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -679,7 +680,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 			&modelmanager.ModelManagerAPIV4{
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
-						s.api,
+						&modelmanager.ModelManagerAPIV7{
+							s.api,
+						},
 					},
 				},
 			},
@@ -845,7 +848,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 		&modelmanager.ModelManagerAPIV4{
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
-					s.api,
+					&modelmanager.ModelManagerAPIV7{
+						s.api,
+					},
 				},
 			},
 		},
@@ -1681,7 +1686,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 			&modelmanager.ModelManagerAPIV4{
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
-						s.api,
+						&modelmanager.ModelManagerAPIV7{
+							s.api,
+						},
 					},
 				},
 			},
@@ -1718,7 +1725,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 		&modelmanager.ModelManagerAPIV4{
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
-					s.api,
+					&modelmanager.ModelManagerAPIV7{
+						s.api,
+					},
 				},
 			},
 		},
@@ -1863,6 +1872,151 @@ func (s *modelManagerSuite) TestChangeModelCredentialNotUpdated(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model deadbeef-0bad-400d-8000-4b1d0d06f00d already uses credential foo/bob/bar`)
 }
 
+func (s *modelManagerSuite) patchTransferCredentialValidation(c *gc.C, results params.ErrorResults) {
+	modelmanager.SetCredentialBackend(s.api, func(string) (credentialcommon.PersistentBackend, func() bool, error) {
+		return &mockCredentialBackend{cred: s.st.cred}, func() bool { return true }, nil
+	})
+	s.PatchValue(modelmanager.ValidateNewCredentialForModelFunc, func(
+		backend credentialcommon.PersistentBackend,
+		callCtx context.ProviderCallContext,
+		credentialTag names.CloudCredentialTag,
+		credential *cloud.Credential,
+	) (params.ErrorResults, error) {
+		c.Check(credentialTag, gc.Equals, names.NewCloudCredentialTag("dummy/bob/bar"))
+		return results, nil
+	})
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnership(c *gc.C) {
+	s.patchTransferCredentialValidation(c, params.ErrorResults{})
+	credentialTag := names.NewCloudCredentialTag("dummy/bob/bar")
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag:           s.st.ModelTag().String(),
+			OwnerTag:           names.NewUserTag("bob").String(),
+			CloudCredentialTag: credentialTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.model.CheckCall(c, 0, "TransferOwnership", names.NewUserTag("bob"), credentialTag)
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipInvalidCredential(c *gc.C) {
+	s.patchTransferCredentialValidation(c, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: common.ServerError(errors.New("instance missing"))}},
+	})
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag:           s.st.ModelTag().String(),
+			OwnerTag:           names.NewUserTag("bob").String(),
+			CloudCredentialTag: names.NewCloudCredentialTag("dummy/bob/bar").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `credential "dummy/bob/bar" not valid for model ".*": instance missing`)
+	s.st.model.CheckNoCalls(c)
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipByNewOwner(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	s.st.users = append(s.st.users, permission.UserAccess{
+		UserTag:   bob,
+		Object:    s.st.ModelTag(),
+		Access:    permission.AdminAccess,
+		CreatedBy: names.NewUserTag("admin"),
+	})
+	s.setAPIUser(c, bob)
+	s.patchTransferCredentialValidation(c, params.ErrorResults{})
+	credentialTag := names.NewCloudCredentialTag("dummy/bob/bar")
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag:           s.st.ModelTag().String(),
+			OwnerTag:           bob.String(),
+			CloudCredentialTag: credentialTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.model.CheckCallNames(c, "ModelTag", "Owner", "TransferOwnership")
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipNotGrantedByOwner(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	s.st.users = append(s.st.users, permission.UserAccess{
+		UserTag:   bob,
+		Object:    s.st.ModelTag(),
+		Access:    permission.AdminAccess,
+		CreatedBy: names.NewUserTag("add-model"),
+	})
+	s.setAPIUser(c, bob)
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag: s.st.ModelTag().String(),
+			OwnerTag: bob.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `permission denied`)
+	s.st.model.CheckCallNames(c, "ModelTag", "Owner")
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipBulkUninterrupted(c *gc.C) {
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{
+			{ModelTag: "bad-model-tag"},
+			{ModelTag: s.st.ModelTag().String(), OwnerTag: "bad-owner-tag"},
+			{ModelTag: s.st.ModelTag().String(), OwnerTag: names.NewUserTag("bob").String(), CloudCredentialTag: "bad-credential-tag"},
+			{ModelTag: s.st.ModelTag().String(), OwnerTag: names.NewUserTag("bob").String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"bad-model-tag" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bad-owner-tag" is not a valid tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"bad-credential-tag" is not a valid tag`)
+	c.Assert(results.Results[3].Error, gc.IsNil)
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipFails(c *gc.C) {
+	s.st.model.SetErrors(errors.New("model \"foo\" is already owned by \"bob\""))
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag: s.st.ModelTag().String(),
+			OwnerTag: names.NewUserTag("bob").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model "foo" is already owned by "bob"`)
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipUnauthorisedUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@remote"))
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag: s.st.ModelTag().String(),
+			OwnerTag: names.NewUserTag("bob@remote").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `permission denied`)
+	s.st.model.CheckCallNames(c, "ModelTag")
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipToOtherUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@remote"))
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		[]params.TransferModelOwnershipParam{{
+			ModelTag: s.st.ModelTag().String(),
+			OwnerTag: names.NewUserTag("mary").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `permission denied`)
+	s.st.model.CheckNoCalls(c)
+}
+
 type fakeProvider struct {
 	environs.CloudEnvironProvider
 }
@@ -70,8 +70,16 @@ func NewPublicFacade(st *state.State, _ facade.Resources, authorizer facade.Auth
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	newClient := func() (CharmStore, error) {
-		return charmstore.NewCachingClient(state.MacaroonCache{st}, controllerCfg.CharmStoreURL())
+		modelCfg, err := model.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return charmstore.NewModelCachingClient(state.MacaroonCache{st}, controllerCfg, modelCfg)
 	}
 	facade, err := NewFacade(rst, newClient)
 	if err != nil {
@@ -4,6 +4,9 @@
 package storage_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -53,6 +56,7 @@ type baseStorageSuite struct {
 
 	blocks      map[state.BlockType]state.Block
 	callContext context.ProviderCallContext
+	clock       *testclock.Clock
 }
 
 func (s *baseStorageSuite) SetUpTest(c *gc.C) {
@@ -69,13 +73,18 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.poolsInUse = []string{}
 
 	s.callContext = context.NewCloudCallContext()
-	s.api = storage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext)
-	s.apiCaas = storage.NewStorageAPIForTest(s.state, state.ModelTypeCAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext)
-	newAPI := storage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext)
+	s.clock = testclock.NewClock(time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.api = storage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext, s.clock)
+	s.apiCaas = storage.NewStorageAPIForTest(s.state, state.ModelTypeCAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext, s.clock)
+	newAPI := storage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext, s.clock)
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPIv7: storage.StorageAPIv7{
+						StorageAPI: *newAPI,
+					},
+				},
 			},
 		},
 	}
@@ -112,6 +121,7 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -320,6 +330,10 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
 	}
 }
 
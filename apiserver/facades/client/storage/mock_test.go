@@ -71,6 +71,7 @@ type mockStorageAccessor struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	resizeStorageInstance               func(names.StorageTag, uint64) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -189,11 +190,17 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
 	storage *names.StorageTag
 	info    *state.VolumeInfo
+	params  *state.VolumeParams
+	since   *time.Time
 	life    state.Life
 }
 
@@ -209,6 +216,9 @@ func (m *mockVolume) VolumeTag() names.VolumeTag {
 }
 
 func (m *mockVolume) Params() (state.VolumeParams, bool) {
+	if m.params != nil {
+		return *m.params, true
+	}
 	return state.VolumeParams{
 		Pool: "loop",
 		Size: 1024,
@@ -227,7 +237,7 @@ func (m *mockVolume) Life() state.Life {
 }
 
 func (m *mockVolume) Status() (status.StatusInfo, error) {
-	return status.StatusInfo{Status: status.Attached}, nil
+	return status.StatusInfo{Status: status.Attached, Since: m.since}, nil
 }
 
 type mockFilesystem struct {
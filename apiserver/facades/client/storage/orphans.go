@@ -0,0 +1,325 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// minOrphanedVolumeAge is how long before detection a volume unknown
+// to the model must have been created for it to be reported as
+// orphaned, so that volumes which have been created but not yet
+// recorded in the model are not mistaken for orphans.
+const minOrphanedVolumeAge = time.Hour
+
+// DetectOrphanedStorage compares the volumes known to the model with
+// the volumes that the model's dynamic, environ-scoped storage providers
+// report as tagged for the model. Providers that cannot list their
+// volumes are skipped. Volumes unknown to the model are only reported
+// if their storage provider can say when they were created, and they
+// were created before the minimum age and before any of the model's
+// pending volumes were added.
+func (a *StorageAPI) DetectOrphanedStorage() (params.OrphanedStorageResult, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.OrphanedStorageResult{}, errors.Trace(err)
+	}
+	result, err := a.detectOrphanedVolumes()
+	if err != nil {
+		return params.OrphanedStorageResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// DestroyOrphanedVolumes destroys volumes that exist in the cloud but
+// are unknown to the model. The volumes are detected again before any
+// are destroyed, so that a volume the model has since started using is
+// never removed.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) DestroyOrphanedVolumes(args params.DestroyOrphanedVolumesParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	orphans, err := a.detectOrphanedVolumes()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	unknown := make(map[params.OrphanedVolume]bool)
+	for _, v := range orphans.Unknown {
+		unknown[v] = true
+	}
+
+	results := make([]params.ErrorResult, len(args.Volumes))
+	var providerTypes []storage.ProviderType
+	byProvider := make(map[storage.ProviderType][]int)
+	for i, v := range args.Volumes {
+		key := params.OrphanedVolume{Provider: v.Provider, ProviderId: v.ProviderId}
+		if !unknown[key] {
+			results[i].Error = common.ServerError(errors.Errorf(
+				"volume %q of storage provider %q is not orphaned",
+				v.ProviderId, v.Provider,
+			))
+			continue
+		}
+		providerType := storage.ProviderType(v.Provider)
+		if _, ok := byProvider[providerType]; !ok {
+			providerTypes = append(providerTypes, providerType)
+		}
+		byProvider[providerType] = append(byProvider[providerType], i)
+	}
+
+	for _, providerType := range providerTypes {
+		indices := byProvider[providerType]
+		volumeIds := make([]string, len(indices))
+		for i, index := range indices {
+			volumeIds[i] = args.Volumes[index].ProviderId
+		}
+		errs, err := a.destroyVolumes(providerType, volumeIds)
+		if err != nil {
+			for _, index := range indices {
+				results[index].Error = common.ServerError(err)
+			}
+			continue
+		}
+		for i, err := range errs {
+			if err != nil {
+				results[indices[i]].Error = common.ServerError(err)
+			}
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) destroyVolumes(providerType storage.ProviderType, volumeIds []string) ([]error, error) {
+	volumeSource, err := a.environVolumeSource(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	errs, err := volumeSource.DestroyVolumes(a.callContext, volumeIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "destroying volumes")
+	}
+	if len(errs) != len(volumeIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(volumeIds), len(errs))
+	}
+	return errs, nil
+}
+
+// detectOrphanedVolumes returns the volumes that the storage providers
+// report for the model but that the model does not know about, and the
+// provisioned model volumes that the providers no longer report.
+func (a *StorageAPI) detectOrphanedVolumes() (params.OrphanedStorageResult, error) {
+	var result params.OrphanedStorageResult
+	providerTypes, cloudVolumes, err := a.listCloudVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	volumes, err := a.storageAccess.VolumeAccess().AllVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	known := make(map[storage.ProviderType]set.Strings)
+	pending := make(map[storage.ProviderType]time.Time)
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			if v.Life() != state.Dead {
+				if err := a.addPendingVolume(pending, v); err != nil {
+					return result, errors.Trace(err)
+				}
+			}
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		if info.VolumeId == "" {
+			// The volume cannot be matched with
+			// any of the volumes in the cloud.
+			continue
+		}
+		providerType, err := a.poolProviderType(info.Pool)
+		if err != nil {
+			return result, errors.Annotatef(err, "getting storage provider for %s", v.VolumeTag().Id())
+		}
+		listed, ok := cloudVolumes[providerType]
+		if !ok {
+			// The provider's volumes were not listed,
+			// so there is nothing to compare against.
+			continue
+		}
+		if known[providerType] == nil {
+			known[providerType] = set.NewStrings()
+		}
+		known[providerType].Add(info.VolumeId)
+		if v.Life() == state.Dead {
+			// The volume is being destroyed, so it
+			// is expected to disappear from the cloud.
+			continue
+		}
+		if !listed.Contains(info.VolumeId) {
+			result.Missing = append(result.Missing, params.OrphanedVolume{
+				Provider:   string(providerType),
+				ProviderId: info.VolumeId,
+				VolumeTag:  v.VolumeTag().String(),
+			})
+		}
+	}
+
+	for _, providerType := range providerTypes {
+		var unknown []string
+		for _, volumeId := range cloudVolumes[providerType].SortedValues() {
+			if volumeId == "" || known[providerType].Contains(volumeId) {
+				continue
+			}
+			unknown = append(unknown, volumeId)
+		}
+		cutoff := a.clock.Now().Add(-minOrphanedVolumeAge)
+		for _, since := range []time.Time{pending[providerType], pending[""]} {
+			if !since.IsZero() && since.Before(cutoff) {
+				cutoff = since
+			}
+		}
+		orphaned, err := a.volumesCreatedBefore(providerType, unknown, cutoff)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, volumeId := range orphaned {
+			result.Unknown = append(result.Unknown, params.OrphanedVolume{
+				Provider:   string(providerType),
+				ProviderId: volumeId,
+			})
+		}
+	}
+	return result, nil
+}
+
+// addPendingVolume records in pending when the unprovisioned volume
+// was added to the model, if that is earlier than for any other pending
+// volume of the same storage provider. Pending volumes whose provider
+// is not known are recorded against the empty provider type.
+func (a *StorageAPI) addPendingVolume(pending map[storage.ProviderType]time.Time, v state.Volume) error {
+	var providerType storage.ProviderType
+	if volumeParams, ok := v.Params(); ok {
+		var err error
+		providerType, err = a.poolProviderType(volumeParams.Pool)
+		if err != nil {
+			return errors.Annotatef(err, "getting storage provider for %s", v.VolumeTag().Id())
+		}
+	}
+	// A pending volume whose status doesn't say when
+	// it was added may have been added at any time.
+	since := time.Unix(0, 0)
+	if volumeStatus, err := v.Status(); err != nil {
+		return errors.Annotatef(err, "getting status of %s", v.VolumeTag().Id())
+	} else if volumeStatus.Since != nil {
+		since = *volumeStatus.Since
+	}
+	if earliest, ok := pending[providerType]; !ok || since.Before(earliest) {
+		pending[providerType] = since
+	}
+	return nil
+}
+
+// volumesCreatedBefore returns those of the specified volumes that the
+// storage provider reports were created before the cutoff. No volumes
+// are returned for providers that cannot report when their volumes
+// were created.
+func (a *StorageAPI) volumesCreatedBefore(providerType storage.ProviderType, volumeIds []string, cutoff time.Time) ([]string, error) {
+	if len(volumeIds) == 0 {
+		return nil, nil
+	}
+	volumeSource, err := a.environVolumeSource(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	timer, ok := volumeSource.(storage.VolumeCreationTimer)
+	if !ok {
+		return nil, nil
+	}
+	created, err := timer.VolumeCreationTimes(a.callContext, volumeIds)
+	if errors.IsNotSupported(err) || errors.IsNotImplemented(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting creation times of volumes of storage provider %q", providerType)
+	}
+	var old []string
+	for _, volumeId := range volumeIds {
+		if t, ok := created[volumeId]; ok && t.Before(cutoff) {
+			old = append(old, volumeId)
+		}
+	}
+	return old, nil
+}
+
+// listCloudVolumes returns the IDs of the volumes that each dynamic,
+// environ-scoped storage provider reports for the model, keyed by
+// provider type, along with the provider types that were listed.
+func (a *StorageAPI) listCloudVolumes() ([]storage.ProviderType, map[storage.ProviderType]set.Strings, error) {
+	providerTypes, err := a.registry.StorageProviderTypes()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var listed []storage.ProviderType
+	volumes := make(map[storage.ProviderType]set.Strings)
+	for _, providerType := range providerTypes {
+		volumeSource, err := a.environVolumeSource(providerType)
+		if errors.IsNotSupported(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		volumeIds, err := volumeSource.ListVolumes(a.callContext)
+		if errors.IsNotSupported(err) || errors.IsNotImplemented(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Annotatef(err, "listing volumes of storage provider %q", providerType)
+		}
+		listed = append(listed, providerType)
+		volumes[providerType] = set.NewStrings(volumeIds...)
+	}
+	return listed, volumes, nil
+}
+
+// environVolumeSource returns a volume source for the specified
+// provider, which must be dynamic and environ-scoped.
+func (a *StorageAPI) environVolumeSource(providerType storage.ProviderType) (storage.VolumeSource, error) {
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !provider.Dynamic() || provider.Scope() != storage.ScopeEnviron {
+		return nil, errors.NotSupportedf("volumes of storage provider %q", providerType)
+	}
+	cfg, err := storage.NewConfig(string(providerType), providerType, map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return provider.VolumeSource(cfg)
+}
+
+// poolProviderType returns the type of the storage provider
+// for the named pool, which may be a provider's default pool.
+func (a *StorageAPI) poolProviderType(poolName string) (storage.ProviderType, error) {
+	cfg, err := a.poolManager.Get(poolName)
+	if errors.IsNotFound(err) {
+		return storage.ProviderType(poolName), nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.Provider(), nil
+}
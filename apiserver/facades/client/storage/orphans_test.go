@@ -0,0 +1,207 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type orphansSuite struct {
+	baseStorageSuite
+
+	volumeSource *dummy.VolumeSource
+}
+
+var _ = gc.Suite(&orphansSuite{})
+
+func (s *orphansSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	s.volumeSource = &dummy.VolumeSource{
+		ListVolumesFunc: func(context.ProviderCallContext) ([]string, error) {
+			return []string{"vol-2", "vol-1", "vol-4"}, nil
+		},
+		VolumeCreationTimesFunc: func(_ context.ProviderCallContext, volumeIds []string) (map[string]time.Time, error) {
+			created := make(map[string]time.Time)
+			for _, volumeId := range volumeIds {
+				created[volumeId] = s.clock.Now().Add(-2 * time.Hour)
+			}
+			return created, nil
+		},
+	}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machinescoped"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeMachine,
+		IsDynamic:    true,
+	}
+
+	var err error
+	s.pools["fast"], err = storage.NewConfig("fast", "radiance", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-1", Pool: "radiance"}
+	missing := &mockVolume{
+		tag:  names.NewVolumeTag("23"),
+		info: &state.VolumeInfo{VolumeId: "vol-3", Pool: "fast"},
+	}
+	unprovisioned := &mockVolume{tag: names.NewVolumeTag("24")}
+	dead := &mockVolume{
+		tag:  names.NewVolumeTag("25"),
+		info: &state.VolumeInfo{VolumeId: "vol-4", Pool: "radiance"},
+		life: state.Dead,
+	}
+	noProviderId := &mockVolume{
+		tag:  names.NewVolumeTag("26"),
+		info: &state.VolumeInfo{Pool: "radiance"},
+	}
+	s.setModelVolumes(s.volume, missing, unprovisioned, dead, noProviderId)
+}
+
+func (s *orphansSuite) setModelVolumes(volumes ...state.Volume) {
+	s.storageAccessor.allVolumes = func() ([]state.Volume, error) {
+		s.stub.AddCall(allVolumesCall)
+		return volumes, nil
+	}
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorage(c *gc.C) {
+	result, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedStorageResult{
+		Unknown: []params.OrphanedVolume{{
+			Provider:   "radiance",
+			ProviderId: "vol-2",
+		}},
+		Missing: []params.OrphanedVolume{{
+			Provider:   "radiance",
+			ProviderId: "vol-3",
+			VolumeTag:  "volume-23",
+		}},
+	})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ListVolumes", []interface{}{s.callContext}},
+		{"VolumeCreationTimes", []interface{}{s.callContext, []string{"vol-2"}}},
+	})
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorageRecentlyCreated(c *gc.C) {
+	s.volumeSource.VolumeCreationTimesFunc = func(context.ProviderCallContext, []string) (map[string]time.Time, error) {
+		return map[string]time.Time{"vol-2": s.clock.Now().Add(-10 * time.Minute)}, nil
+	}
+	result, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unknown, gc.HasLen, 0)
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorageCreatedAfterPendingVolume(c *gc.C) {
+	// The unknown volume may be the cloud volume of a
+	// pending volume that was added before it was created.
+	since := s.clock.Now().Add(-3 * time.Hour)
+	pending := &mockVolume{
+		tag:    names.NewVolumeTag("27"),
+		params: &state.VolumeParams{Pool: "fast"},
+		since:  &since,
+	}
+	s.setModelVolumes(s.volume, pending)
+	result, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unknown, gc.HasLen, 0)
+
+	// Volumes created before the pending volume was added are orphans.
+	now := s.clock.Now()
+	pending.since = &now
+	result, err = s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unknown, jc.DeepEquals, []params.OrphanedVolume{
+		{Provider: "radiance", ProviderId: "vol-2"},
+		{Provider: "radiance", ProviderId: "vol-4"},
+	})
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorageCreationTimesNotImplemented(c *gc.C) {
+	s.volumeSource.VolumeCreationTimesFunc = nil
+	result, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unknown, gc.HasLen, 0)
+	c.Assert(result.Missing, gc.HasLen, 1)
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorageListNotImplemented(c *gc.C) {
+	s.volumeSource.ListVolumesFunc = func(context.ProviderCallContext) ([]string, error) {
+		return nil, errors.NotImplementedf("ListVolumes")
+	}
+	result, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedStorageResult{})
+}
+
+func (s *orphansSuite) TestDetectOrphanedStorageListError(c *gc.C) {
+	s.volumeSource.ListVolumesFunc = func(context.ProviderCallContext) ([]string, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.api.DetectOrphanedStorage()
+	c.Assert(err, gc.ErrorMatches, `listing volumes of storage provider "radiance": boom`)
+}
+
+func (s *orphansSuite) TestDestroyOrphanedVolumes(c *gc.C) {
+	s.volumeSource.DestroyVolumesFunc = func(_ context.ProviderCallContext, volumeIds []string) ([]error, error) {
+		return make([]error, len(volumeIds)), nil
+	}
+	results, err := s.api.DestroyOrphanedVolumes(params.DestroyOrphanedVolumesParams{
+		Volumes: []params.OrphanedVolume{
+			{Provider: "radiance", ProviderId: "vol-2"},
+			{Provider: "radiance", ProviderId: "vol-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `volume "vol-1" of storage provider "radiance" is not orphaned`}},
+	})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ListVolumes", []interface{}{s.callContext}},
+		{"VolumeCreationTimes", []interface{}{s.callContext, []string{"vol-2"}}},
+		{"DestroyVolumes", []interface{}{s.callContext, []string{"vol-2"}}},
+	})
+}
+
+func (s *orphansSuite) TestDestroyOrphanedVolumesError(c *gc.C) {
+	s.volumeSource.DestroyVolumesFunc = func(context.ProviderCallContext, []string) ([]error, error) {
+		return []error{errors.New("in use")}, nil
+	}
+	results, err := s.api.DestroyOrphanedVolumes(params.DestroyOrphanedVolumesParams{
+		Volumes: []params.OrphanedVolume{{Provider: "radiance", ProviderId: "vol-2"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: "in use"}},
+	})
+}
+
+func (s *orphansSuite) TestDestroyOrphanedVolumesBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDestroyOrphanedVolumesBlocked")
+	_, err := s.api.DestroyOrphanedVolumes(params.DestroyOrphanedVolumesParams{
+		Volumes: []params.OrphanedVolume{{Provider: "radiance", ProviderId: "vol-2"}},
+	})
+	s.assertBlocked(c, err, "TestDestroyOrphanedVolumesBlocked")
+	s.volumeSource.CheckNoCalls(c)
+}
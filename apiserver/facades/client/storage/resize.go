@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// ResizeStorage requests that the volumes of the specified storage
+// instances be grown to the given sizes. The storage provisioner
+// resizes the volumes while they remain in use, reporting progress
+// in the volumes' status.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) ResizeStorage(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		result[i].Error = common.ServerError(a.resizeStorage(arg))
+	}
+	return params.ErrorResults{result}, nil
+}
+
+func (a *StorageAPI) resizeStorage(arg params.ResizeStorageInstance) error {
+	tag, err := names.ParseStorageTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if a.modelType == state.ModelTypeCAAS {
+		// Volumes of CAAS models are provisioned by per-application
+		// storage provisioners, which do not resize volumes.
+		return errors.NotSupportedf("resizing storage in a kubernetes model")
+	}
+	if _, err := a.storageAccess.StorageInstance(tag); err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		return errors.NotSupportedf("resizing storage %q without a volume", tag.Id())
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Only volumes managed by the model's storage provisioner
+	// can be resized, and then only if the provider supports it.
+	providerType, err := a.poolProviderType(info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeSource, err := a.environVolumeSource(providerType)
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+		return errors.NotSupportedf("resizing volumes of storage provider %q", providerType)
+	}
+	return a.storageAccess.ResizeStorageInstance(tag, arg.Size)
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+)
+
+type resizeSuite struct {
+	baseStorageSuite
+
+	volumeSource storage.VolumeSource
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+
+	s.volumeSource = &dummy.VolumeSource{
+		ResizeVolumesFunc: func(context.ProviderCallContext, []storage.VolumeResizeParams) ([]error, error) {
+			return nil, errors.New("should not be called")
+		},
+	}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machinescoped"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeMachine,
+		IsDynamic:    true,
+	}
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-1", Pool: "radiance", Size: 1024}
+}
+
+func (s *resizeSuite) resize(c *gc.C, tag string, size uint64) *params.Error {
+	results, err := s.api.ResizeStorage(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{Tag: tag, Size: size}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0].Error
+}
+
+func (s *resizeSuite) TestResizeStorage(c *gc.C) {
+	c.Assert(s.resize(c, "storage-data-0", 2048), gc.IsNil)
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+		resizeStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 3, resizeStorageInstanceCall, s.storageTag, uint64(2048))
+}
+
+func (s *resizeSuite) TestResizeStorageInvalidTag(c *gc.C) {
+	err := s.resize(c, "volume-0", 2048)
+	c.Assert(err, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+}
+
+func (s *resizeSuite) TestResizeStorageNotFound(c *gc.C) {
+	err := s.resize(c, "storage-data-1", 2048)
+	c.Assert(err, gc.ErrorMatches, `storage data/1 not found`)
+	c.Assert(params.IsCodeNotFound(err), jc.IsTrue)
+}
+
+func (s *resizeSuite) TestResizeStorageNotProvisioned(c *gc.C) {
+	s.volume.info = nil
+	err := s.resize(c, "storage-data-0", 2048)
+	c.Assert(err, gc.ErrorMatches, `volume-22 not provisioned`)
+	c.Assert(params.IsCodeNotProvisioned(err), jc.IsTrue)
+}
+
+func (s *resizeSuite) TestResizeStorageProviderUnsupported(c *gc.C) {
+	s.volumeSource = struct{ storage.VolumeSource }{s.volumeSource}
+	err := s.resize(c, "storage-data-0", 2048)
+	c.Assert(err, gc.ErrorMatches, `resizing volumes of storage provider "radiance" not supported`)
+	c.Assert(params.IsCodeNotSupported(err), jc.IsTrue)
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall,
+		storageInstanceCall,
+		storageInstanceVolumeCall,
+	)
+}
+
+func (s *resizeSuite) TestResizeStorageMachineScoped(c *gc.C) {
+	s.volume.info.Pool = "machinescoped"
+	err := s.resize(c, "storage-data-0", 2048)
+	c.Assert(err, gc.ErrorMatches, `resizing volumes of storage provider "machinescoped" not supported`)
+}
+
+func (s *resizeSuite) TestResizeStorageCAAS(c *gc.C) {
+	results, err := s.apiCaas.ResizeStorage(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{Tag: "storage-data-0", Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `resizing storage in a kubernetes model not supported`)
+	c.Assert(params.IsCodeNotSupported(results.Results[0].Error), jc.IsTrue)
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *resizeSuite) TestResizeStorageError(c *gc.C) {
+	s.stub.SetErrors(errors.NotValidf("size"))
+	err := s.resize(c, "storage-data-0", 2048)
+	c.Assert(err, gc.ErrorMatches, `size not valid`)
+}
+
+func (s *resizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{Tag: "storage-data-0", Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
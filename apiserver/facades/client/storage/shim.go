@@ -61,6 +61,10 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool, bool, time.Duration) error
+
+	// ResizeStorageInstance requests that the volume of the storage
+	// instance with the specified tag be grown to the given size.
+	ResizeStorageInstance(names.StorageTag, uint64) error
 }
 
 type storageVolume interface {
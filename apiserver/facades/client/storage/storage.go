@@ -8,6 +8,7 @@ package storage
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -29,7 +30,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v8) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -38,11 +39,22 @@ type StorageAPI struct {
 	authorizer    facade.Authorizer
 	callContext   context.ProviderCallContext
 	modelType     state.ModelType
+	clock         clock.Clock
+}
+
+// StorageAPIv7 implements the storage v7 API.
+type StorageAPIv7 struct {
+	StorageAPI
+}
+
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPIv7
 }
 
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -80,7 +92,7 @@ func NewStorageAPI(context facade.Context) (*StorageAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return newStorageAPI(stateShim{st}, model.Type(), storageAccessor, registry, pm, authorizer, state.CallContext(st)), nil
+	return newStorageAPI(stateShim{st}, model.Type(), storageAccessor, registry, pm, authorizer, state.CallContext(st), clock.WallClock), nil
 }
 
 func newStorageAPI(
@@ -91,6 +103,7 @@ func newStorageAPI(
 	pm poolmanager.PoolManager,
 	authorizer facade.Authorizer,
 	callContext context.ProviderCallContext,
+	clock clock.Clock,
 ) *StorageAPI {
 	return &StorageAPI{
 		backend:       backend,
@@ -100,17 +113,40 @@ func newStorageAPI(
 		poolManager:   pm,
 		authorizer:    authorizer,
 		callContext:   callContext,
+		clock:         clock,
+	}
+}
+
+// NewStorageAPIV7 returns a new storage v7 API facade.
+func NewStorageAPIV7(context facade.Context) (*StorageAPIv7, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
 	}
+	return &StorageAPIv7{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPIV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPIv7: *storageAPI,
+	}, nil
 }
 
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -1212,6 +1248,13 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v8 api version
+func (*StorageAPIv7) ResizeStorage(_, _ struct{}) {}
+
+// Added in v7 api version
+func (*StorageAPIv6) DetectOrphanedStorage(_, _ struct{})  {}
+func (*StorageAPIv6) DestroyOrphanedVolumes(_, _ struct{}) {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
@@ -371,7 +371,11 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +446,11 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +520,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +580,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -838,7 +854,7 @@ func (s *storageSuite) TestListStorageAsAdminOnNotOwnedModel(c *gc.C) {
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("superuserfoo"),
 	}
-	s.api = facadestorage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext)
+	s.api = facadestorage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext, s.clock)
 
 	// Sanity check before running test:
 	// Ensure that the user has NO read access to the model but SuperuserAccess
@@ -860,7 +876,7 @@ func (s *storageSuite) TestListStorageAsNonAdminOnNotOwnedModel(c *gc.C) {
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag: names.NewUserTag("userfoo"),
 	}
-	s.api = facadestorage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext)
+	s.api = facadestorage.NewStorageAPIForTest(s.state, state.ModelTypeIAAS, s.storageAccessor, s.registry, s.poolManager, s.authorizer, s.callContext, s.clock)
 
 	// Sanity check before running test:
 	// Ensure that the user has NO read access to the model and NO SuperuserAccess
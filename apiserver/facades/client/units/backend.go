@@ -0,0 +1,42 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package units
+
+import (
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the state methods needed by the Units facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Unit(name string) (Unit, error)
+}
+
+// Unit provides the state methods needed by the Units facade for
+// a single unit.
+type Unit interface {
+	ApplicationName() string
+	Life() state.Life
+	AssignedMachineId() (string, error)
+	CharmURL() (*charm.URL, bool)
+	WorkloadVersion() (string, error)
+	OperationHistory() ([]state.UnitOperation, error)
+	PendingHooks() (state.UnitHookQueue, error)
+}
+
+type stateShim struct {
+	*state.State
+	model *state.Model
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return s.model.ModelTag()
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	return s.State.Unit(name)
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package units provides the Units facade, which reports details of
+// individual units to clients.
+package units
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// API implements the Units facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new Units facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{State: st, model: m}, ctx.Auth())
+}
+
+// NewAPI returns a new Units facade backed by the given backend.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) unit(tag string) (Unit, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Unit(unitTag.Id())
+}
+
+// UnitsInfo returns information about the given units.
+func (api *API) UnitsInfo(args params.Entities) (params.UnitInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitInfoResults{}, errors.Trace(err)
+	}
+	results := params.UnitInfoResults{
+		Results: make([]params.UnitInfoResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		info, err := api.unitInfo(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
+}
+
+func (api *API) unitInfo(tag string) (*params.UnitInfo, error) {
+	unit, err := api.unit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.UnitInfo{
+		Tag:         tag,
+		Application: unit.ApplicationName(),
+		Life:        unit.Life().String(),
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err == nil {
+		info.Machine = machineId
+	} else if !errors.IsNotAssigned(err) {
+		return nil, errors.Trace(err)
+	}
+	if curl, _ := unit.CharmURL(); curl != nil {
+		info.Charm = curl.String()
+	}
+	if info.WorkloadVersion, err = unit.WorkloadVersion(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	queue, err := unit.PendingHooks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !queue.Updated.IsZero() {
+		info.HookQueue = &params.UnitHookQueue{
+			Pending: queue.Pending,
+			Updated: queue.Updated,
+		}
+	}
+	return info, nil
+}
+
+// OperationHistory returns the recent operations run by each of the
+// given units, most recent first.
+func (api *API) OperationHistory(args params.Entities) (params.UnitOperationsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitOperationsResults{}, errors.Trace(err)
+	}
+	results := params.UnitOperationsResults{
+		Results: make([]params.UnitOperationsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		ops, err := api.operationHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Operations = ops
+	}
+	return results, nil
+}
+
+func (api *API) operationHistory(tag string) ([]params.UnitOperation, error) {
+	unit, err := api.unit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := unit.OperationHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]params.UnitOperation, len(history))
+	for i, op := range history {
+		ops[i] = params.UnitOperation{
+			Kind:        op.Kind,
+			Description: op.Description,
+			Started:     op.Started,
+			Completed:   op.Completed,
+			Error:       op.Error,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package units_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/units"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type unitsSuite struct {
+	jujutesting.JujuConnSuite
+
+	api  *units.API
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&unitsSuite{})
+
+func (s *unitsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	var err error
+	s.api, err = units.NewFacade(facadetest.Context{
+		State_: s.State,
+		Auth_:  apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine := s.Factory.MakeMachine(c, nil)
+	app := s.Factory.MakeApplication(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: app,
+		Machine:     machine,
+		SetCharmURL: true,
+	})
+}
+
+func (s *unitsSuite) TestUnitsInfo(c *gc.C) {
+	err := s.unit.SetWorkloadVersion("1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.unit.CharmURL()
+
+	results, err := s.api.UnitsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.unit.Tag().String()},
+		{Tag: "unit-foo-0"},
+		{Tag: "machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.UnitInfoResult{
+		Result: &params.UnitInfo{
+			Tag:             s.unit.Tag().String(),
+			Application:     s.unit.ApplicationName(),
+			Machine:         "0",
+			Life:            "alive",
+			Charm:           curl.String(),
+			WorkloadVersion: "1.2.3",
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "foo/0" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+}
+
+func (s *unitsSuite) TestUnitsInfoHookQueue(c *gc.C) {
+	err := s.unit.SetPendingHooks([]string{"config-changed", "update-status"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.UnitsInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.unit.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	queue := results.Results[0].Result.HookQueue
+	c.Assert(queue, gc.NotNil)
+	c.Check(queue.Pending, jc.DeepEquals, []string{"config-changed", "update-status"})
+	c.Check(queue.Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *unitsSuite) TestOperationHistory(c *gc.C) {
+	started := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := s.unit.RecordOperations(state.UnitOperation{
+		Kind:        "run-hook",
+		Description: "run install hook",
+		Started:     started,
+		Completed:   started.Add(time.Second),
+	}, state.UnitOperation{
+		Kind:        "run-action",
+		Description: "run action 1234",
+		Started:     started.Add(2 * time.Second),
+		Completed:   started.Add(3 * time.Second),
+		Error:       "action failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.OperationHistory(params.Entities{Entities: []params.Entity{
+		{Tag: s.unit.Tag().String()},
+		{Tag: "unit-foo-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	ops := results.Results[0].Operations
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0].Kind, gc.Equals, "run-action")
+	c.Check(ops[0].Error, gc.Equals, "action failed")
+	c.Check(ops[0].Started.Equal(started.Add(2*time.Second)), jc.IsTrue)
+	c.Check(ops[1].Kind, gc.Equals, "run-hook")
+	c.Check(ops[1].Description, gc.Equals, "run install hook")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "foo/0" not found`)
+}
+
+func (s *unitsSuite) TestReadAccessRequired(c *gc.C) {
+	api, err := units.NewFacade(facadetest.Context{
+		State_: s.State,
+		Auth_:  apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	_, err = api.UnitsInfo(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.OperationHistory(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *unitsSuite) TestAgentNotAllowed(c *gc.C) {
+	_, err := units.NewFacade(facadetest.Context{
+		State_: s.State,
+		Auth_:  apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -93,7 +93,9 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 	for i, arg := range args.Users {
 		var user *state.User
 		var err error
-		if arg.Password != "" {
+		if len(arg.ModelAccess) > 0 || arg.Expires != nil {
+			user, err = api.addUserWithArgs(arg)
+		} else if arg.Password != "" {
 			user, err = api.state.AddUser(arg.Username, arg.DisplayName, arg.Password, api.apiUser.Id())
 		} else {
 			user, err = api.state.AddUserWithSecretKey(arg.Username, arg.DisplayName, api.apiUser.Id())
@@ -113,6 +115,31 @@ func (api *UserManagerAPI) AddUser(args params.AddUsers) (params.AddUserResults,
 	return result, nil
 }
 
+// addUserWithArgs adds a user that is granted access to models,
+// or that expires, as part of its creation.
+func (api *UserManagerAPI) addUserWithArgs(arg params.AddUser) (*state.User, error) {
+	args := state.AddUserArgs{
+		Name:        arg.Username,
+		DisplayName: arg.DisplayName,
+		Password:    arg.Password,
+		Creator:     api.apiUser.Id(),
+	}
+	if arg.Expires != nil {
+		args.Expires = *arg.Expires
+	}
+	for _, access := range arg.ModelAccess {
+		modelTag, err := names.ParseModelTag(access.ModelTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		args.ModelAccess = append(args.ModelAccess, state.UserModelAccess{
+			ModelUUID: modelTag.Id(),
+			Access:    permission.Access(access.Access),
+		})
+	}
+	return api.state.AddUserWithArgs(args)
+}
+
 // RemoveUser permanently removes a user from the current controller for each
 // entity provided. While the user is permanently removed we keep it's
 // information around for auditing purposes.
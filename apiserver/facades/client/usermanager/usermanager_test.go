@@ -123,6 +123,57 @@ func (s *userManagerSuite) TestAddUserWithSecretKey(c *gc.C) {
 	})
 }
 
+func (s *userManagerSuite) TestAddUserWithModelAccessAndExpiry(c *gc.C) {
+	sharedModelState := s.Factory.MakeModel(c, nil)
+	defer sharedModelState.Close()
+
+	expires := time.Now().Add(24 * time.Hour).UTC().Round(time.Second)
+	args := params.AddUsers{
+		Users: []params.AddUser{{
+			Username:    "foobar",
+			DisplayName: "Foo Bar",
+			ModelAccess: []params.UserModelAccess{{
+				ModelTag: names.NewModelTag(sharedModelState.ModelUUID()).String(),
+				Access:   "write",
+			}},
+			Expires: &expires,
+		}}}
+
+	result, err := s.usermanager.AddUser(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	foobarTag := names.NewLocalUserTag("foobar")
+	user, err := s.State.User(foobarTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Expires().Equal(expires), jc.IsTrue)
+	c.Assert(result.Results[0].SecretKey, gc.DeepEquals, user.SecretKey())
+
+	access, err := sharedModelState.UserAccess(foobarTag, names.NewModelTag(sharedModelState.ModelUUID()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *userManagerSuite) TestAddUserWithInvalidModelAccess(c *gc.C) {
+	args := params.AddUsers{
+		Users: []params.AddUser{{
+			Username: "foobar",
+			ModelAccess: []params.UserModelAccess{{
+				ModelTag: s.Model.ModelTag().String(),
+				Access:   "superuser",
+			}},
+		}}}
+
+	result, err := s.usermanager.AddUser(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `failed to create user: "superuser" model access not valid`)
+
+	_, err = s.State.User(names.NewLocalUserTag("foobar"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *userManagerSuite) TestBlockAddUser(c *gc.C) {
 	args := params.AddUsers{
 		Users: []params.AddUser{{
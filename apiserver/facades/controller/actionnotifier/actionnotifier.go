@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionnotifier implements the API used by the action
+// notifier worker, which posts notifications of finished actions.
+package actionnotifier
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state functionality required by the
+// ActionNotifier facade.
+type Backend interface {
+	Action(id string) (state.Action, error)
+	WatchActionResults() state.StringsWatcher
+}
+
+// API implements the ActionNotifier facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade returns a new ActionNotifier facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	m, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(m, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new ActionNotifier facade using the backend.
+// Only controllers may use the facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// WatchFinishedActions returns a watcher that reports the ids of
+// actions in the model as they finish.
+func (api *API) WatchFinishedActions() (params.StringsWatchResult, error) {
+	w := api.backend.WatchActionResults()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(w)),
+	}, nil
+}
+
+// PendingNotifications returns each of the given actions that has
+// finished and has a notification URL to which its completion has not
+// yet been posted. The result for any other action is empty.
+func (api *API) PendingNotifications(args params.Entities) (params.ActionNotifications, error) {
+	results := params.ActionNotifications{
+		Results: make([]params.ActionNotification, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		action, err := api.getAction(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if action.NotifyURL() == "" || action.Notified() || !isFinished(action.Status()) {
+			continue
+		}
+		receiverTag, err := names.ActionReceiverTag(action.Receiver())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		actionResult := common.MakeActionResult(receiverTag, action)
+		results.Results[i].NotifyURL = action.NotifyURL()
+		results.Results[i].Action = &actionResult
+	}
+	return results, nil
+}
+
+// SetNotified records that the completion of each of the
+// given actions has been posted to its notification URL.
+func (api *API) SetNotified(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		action, err := api.getAction(entity.Tag)
+		if err == nil {
+			err = action.SetNotified()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) getAction(tag string) (state.Action, error) {
+	actionTag, err := names.ParseActionTag(tag)
+	if err != nil {
+		return nil, common.ErrBadId
+	}
+	action, err := api.backend.Action(actionTag.Id())
+	return action, errors.Trace(err)
+}
+
+func isFinished(status state.ActionStatus) bool {
+	switch status {
+	case state.ActionCompleted, state.ActionFailed, state.ActionCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionnotifier_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionnotifier"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	finishedID = "11111111-1111-1111-1111-111111111111"
+	pendingID  = "22222222-2222-2222-2222-222222222222"
+	notifiedID = "33333333-3333-3333-3333-333333333333"
+	noURLID    = "44444444-4444-4444-4444-444444444444"
+)
+
+type actionNotifierSuite struct {
+	coretesting.BaseSuite
+
+	backend   *fakeBackend
+	resources *common.Resources
+	api       *actionnotifier.API
+}
+
+var _ = gc.Suite(&actionNotifierSuite{})
+
+func (s *actionNotifierSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	completed := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &fakeBackend{
+		actions: map[string]*fakeAction{
+			finishedID: {
+				id: finishedID, status: state.ActionCompleted, completed: completed,
+				notifyURL: "https://chat.example.com/hooks/juju",
+			},
+			pendingID: {
+				id: pendingID, status: state.ActionRunning,
+				notifyURL: "https://chat.example.com/hooks/juju",
+			},
+			notifiedID: {
+				id: notifiedID, status: state.ActionFailed, notified: true,
+				notifyURL: "https://chat.example.com/hooks/juju",
+			},
+			noURLID: {id: noURLID, status: state.ActionCompleted},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	var err error
+	s.api, err = actionnotifier.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *actionNotifierSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := actionnotifier.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionNotifierSuite) TestWatchFinishedActions(c *gc.C) {
+	ch := make(chan []string, 1)
+	ch <- []string{finishedID}
+	s.backend.watcher = statetesting.NewMockStringsWatcher(ch)
+
+	result, err := s.api.WatchFinishedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{finishedID},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *actionNotifierSuite) TestPendingNotifications(c *gc.C) {
+	result, err := s.api.PendingNotifications(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewActionTag(finishedID).String()},
+		{Tag: names.NewActionTag(pendingID).String()},
+		{Tag: names.NewActionTag(notifiedID).String()},
+		{Tag: names.NewActionTag(noURLID).String()},
+		{Tag: "unit-mysql-0"},
+		{Tag: names.NewActionTag("55555555-5555-5555-5555-555555555555").String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 6)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NotifyURL, gc.Equals, "https://chat.example.com/hooks/juju")
+	c.Assert(result.Results[0].Action, gc.NotNil)
+	c.Assert(result.Results[0].Action.Action.Tag, gc.Equals, names.NewActionTag(finishedID).String())
+	c.Assert(result.Results[0].Action.Action.Receiver, gc.Equals, "unit-mysql-0")
+	c.Assert(result.Results[0].Action.Status, gc.Equals, "completed")
+
+	for i := 1; i < 4; i++ {
+		c.Check(result.Results[i], jc.DeepEquals, params.ActionNotification{})
+	}
+	c.Assert(result.Results[4].Error, gc.ErrorMatches, "id not found")
+	c.Assert(result.Results[5].Error, gc.ErrorMatches, `action "55555555-5555-5555-5555-555555555555" not found`)
+}
+
+func (s *actionNotifierSuite) TestSetNotified(c *gc.C) {
+	result, err := s.api.SetNotified(params.Entities{Entities: []params.Entity{
+		{Tag: names.NewActionTag(finishedID).String()},
+		{Tag: names.NewActionTag("55555555-5555-5555-5555-555555555555").String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `action "55555555-5555-5555-5555-555555555555" not found`)
+	c.Assert(s.backend.actions[finishedID].notified, jc.IsTrue)
+}
+
+type fakeBackend struct {
+	actions map[string]*fakeAction
+	watcher state.StringsWatcher
+}
+
+func (b *fakeBackend) Action(id string) (state.Action, error) {
+	action, ok := b.actions[id]
+	if !ok {
+		return nil, errors.NotFoundf("action %q", id)
+	}
+	return action, nil
+}
+
+func (b *fakeBackend) WatchActionResults() state.StringsWatcher {
+	return b.watcher
+}
+
+type fakeAction struct {
+	state.Action
+	id        string
+	status    state.ActionStatus
+	completed time.Time
+	notifyURL string
+	notified  bool
+}
+
+func (a *fakeAction) ActionTag() names.ActionTag                { return names.NewActionTag(a.id) }
+func (a *fakeAction) Receiver() string                          { return "mysql/0" }
+func (a *fakeAction) Name() string                              { return "backup" }
+func (a *fakeAction) Parameters() map[string]interface{}        { return nil }
+func (a *fakeAction) Status() state.ActionStatus                { return a.status }
+func (a *fakeAction) Results() (map[string]interface{}, string) { return nil, "" }
+func (a *fakeAction) Enqueued() time.Time                       { return time.Time{} }
+func (a *fakeAction) Started() time.Time                        { return time.Time{} }
+func (a *fakeAction) Completed() time.Time                      { return a.completed }
+func (a *fakeAction) NotifyURL() string                         { return a.notifyURL }
+func (a *fakeAction) Notified() bool                            { return a.notified }
+
+func (a *fakeAction) SetNotified() error {
+	a.notified = true
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -49,6 +50,14 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return nil, errors.NotFoundf("entity %v", tag)
 }
 
+func (st *mockState) Application(name string) (caasoperatorprovisioner.Application, error) {
+	st.MethodCall(st, "Application", name)
+	if st.app == nil || st.app.tag != names.NewApplicationTag(name) {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return st.app, nil
+}
+
 func (st *mockState) ControllerConfig() (controller.Config, error) {
 	cfg := coretesting.FakeControllerConfig()
 	cfg[controller.CAASImageRepo] = st.operatorRepo
@@ -112,6 +121,16 @@ type mockApplication struct {
 	state.Authenticator
 	tag      names.Tag
 	password string
+	config   application.ConfigAttributes
+	watcher  *mockNotifyWatcher
+}
+
+func (m *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return m.config, nil
+}
+
+func (m *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	return m.watcher
 }
 
 func (m *mockApplication) Tag() names.Tag {
@@ -164,3 +183,22 @@ func (w *mockStringsWatcher) Changes() <-chan []string {
 	w.MethodCall(w, "Changes")
 	return w.changes
 }
+
+type mockNotifyWatcher struct {
+	mockWatcher
+	changes chan struct{}
+}
+
+func newMockNotifyWatcher() *mockNotifyWatcher {
+	w := &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	w.Tomb.Go(func() error {
+		<-w.Tomb.Dying()
+		return nil
+	})
+	return w
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	w.MethodCall(w, "Changes")
+	return w.changes
+}
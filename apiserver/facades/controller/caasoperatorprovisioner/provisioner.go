@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloudconfig/podcfg"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
@@ -37,6 +38,23 @@ type API struct {
 	registry           storage.ProviderRegistry
 }
 
+// APIv2 provides the CAASOperatorProvisioner API facade for version 2.
+// OperatorProvisioningInfo takes the applications to provision, so that
+// an application's operator image override can be applied.
+type APIv2 struct {
+	*API
+}
+
+// NewStateCAASOperatorProvisionerAPIv2 provides the signature required
+// for facade registration of version 2.
+func NewStateCAASOperatorProvisionerAPIv2(ctx facade.Context) (*APIv2, error) {
+	api, err := NewStateCAASOperatorProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewStateCAASOperatorProvisionerAPI provides the signature required for facade registration.
 func NewStateCAASOperatorProvisionerAPI(ctx facade.Context) (*API, error) {
 
@@ -147,6 +165,92 @@ func (a *API) OperatorProvisioningInfo() (params.OperatorProvisioningInfo, error
 	}, nil
 }
 
+// OperatorProvisioningInfo returns the info needed to provision the
+// operators of the specified applications. An application may pin its
+// operator image with the juju-operator-image-path config setting.
+func (a *APIv2) OperatorProvisioningInfo(args params.Entities) (params.OperatorProvisioningInfoResults, error) {
+	info, err := a.API.OperatorProvisioningInfo()
+	if err != nil {
+		return params.OperatorProvisioningInfoResults{}, errors.Trace(err)
+	}
+	results := params.OperatorProvisioningInfoResults{
+		Results: make([]params.OperatorProvisioningInfoResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		appTag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		imagePath, err := a.operatorImagePath(appTag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		appInfo := info
+		if imagePath != "" {
+			appInfo.ImagePath = imagePath
+		}
+		results.Results[i].Result = &appInfo
+	}
+	return results, nil
+}
+
+// WatchApplicationsConfig starts a NotifyWatcher for each of the
+// specified applications, notifying when its application config
+// changes, so that a changed operator image can be applied.
+func (a *APIv2) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		id, err := a.watchApplicationConfig(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *APIv2) watchApplicationConfig(tagString string) (string, error) {
+	appTag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(appTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// operatorImagePath returns the operator image pinned by the
+// application's config, or "" if there is none.
+func (a *API) operatorImagePath(appName string) (string, error) {
+	app, err := a.state.Application(appName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	imagePath := appConfig.GetString(caas.JujuOperatorImagePath, "")
+	if imagePath == "" {
+		return "", nil
+	}
+	if err := resources.ValidateDockerRegistryPath(imagePath); err != nil {
+		return "", errors.Annotatef(err, "operator image for %q", appName)
+	}
+	return imagePath, nil
+}
+
 // CharmStorageParams returns filesystem parameters needed
 // to provision storage used for a charm operator or workload.
 func CharmStorageParams(
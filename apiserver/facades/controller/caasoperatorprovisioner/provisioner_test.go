@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -196,6 +197,85 @@ func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoNoStoragePool(c *gc.C
 	})
 }
 
+func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoV2(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:    names.NewApplicationTag("gitlab"),
+		config: application.ConfigAttributes{"juju-operator-image-path": "myrepo/jujud-operator:2.6.4"},
+	}
+	api := &caasoperatorprovisioner.APIv2{API: s.api}
+	results, err := api.OperatorProvisioningInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.ImagePath, gc.Equals, "myrepo/jujud-operator:2.6.4")
+	c.Assert(results.Results[0].Result.Version, gc.Equals, version.MustParse("2.6-beta3"))
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: `application "mysql" not found`,
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+}
+
+func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoV2NoOverride(c *gc.C) {
+	s.st.app = &mockApplication{tag: names.NewApplicationTag("gitlab")}
+	api := &caasoperatorprovisioner.APIv2{API: s.api}
+	results, err := api.OperatorProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.ImagePath, gc.Equals, "jujusolutions/jujud-operator:2.6-beta3")
+}
+
+func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoV2InvalidOverride(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:    names.NewApplicationTag("gitlab"),
+		config: application.ConfigAttributes{"juju-operator-image-path": "Not A Path"},
+	}
+	api := &caasoperatorprovisioner.APIv2{API: s.api}
+	results, err := api.OperatorProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `operator image for "gitlab": docker image path "Not A Path" not valid`)
+}
+
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:     names.NewApplicationTag("gitlab"),
+		watcher: newMockNotifyWatcher(),
+	}
+	s.st.app.watcher.changes <- struct{}{}
+	api := &caasoperatorprovisioner.APIv2{API: s.api}
+	results, err := api.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "application-mysql"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: `application "mysql" not found`,
+	})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.NotNil)
+	c.Assert(resource, gc.Implements, new(state.NotifyWatcher))
+}
+
 func (s *CAASProvisionerSuite) TestAddresses(c *gc.C) {
 	_, err := s.api.APIAddresses()
 	c.Assert(err, jc.ErrorIsNil)
@@ -7,6 +7,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -23,6 +24,7 @@ type CAASOperatorProvisionerState interface {
 	Model() (Model, error)
 	APIHostPortsForAgents() ([][]network.HostPort, error)
 	WatchAPIHostPortsForAgents() state.NotifyWatcher
+	Application(string) (Application, error)
 }
 
 type Model interface {
@@ -30,6 +32,13 @@ type Model interface {
 	ModelConfig() (*config.Config, error)
 }
 
+// Application provides the subset of application state
+// required by the CAAS operator provisioner facade.
+type Application interface {
+	ApplicationConfig() (application.ConfigAttributes, error)
+	WatchApplicationConfig() state.NotifyWatcher
+}
+
 type stateShim struct {
 	*state.State
 }
@@ -41,3 +50,7 @@ func (s stateShim) Model() (Model, error) {
 	}
 	return model.CAASModel()
 }
+
+func (s stateShim) Application(name string) (Application, error) {
+	return s.State.Application(name)
+}
@@ -87,6 +87,8 @@ func (st *mockState) ResolveConstraints(cons constraints.Value) (constraints.Val
 type mockModel struct {
 	testing.Stub
 	podSpecWatcher *statetesting.MockNotifyWatcher
+	configWatcher  *statetesting.MockNotifyWatcher
+	configAttrs    map[string]interface{}
 	containers     []state.CloudContainer
 }
 
@@ -94,9 +96,17 @@ func (m *mockModel) ModelConfig() (*config.Config, error) {
 	m.MethodCall(m, "ModelConfig")
 	attrs := coretesting.FakeConfig()
 	attrs["workload-storage"] = "k8s-storage"
+	for k, v := range m.configAttrs {
+		attrs[k] = v
+	}
 	return config.New(config.UseDefaults, attrs)
 }
 
+func (m *mockModel) WatchForModelConfigChanges() state.NotifyWatcher {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	return m.configWatcher
+}
+
 func (m *mockModel) PodSpec(tag names.ApplicationTag) (string, error) {
 	m.MethodCall(m, "PodSpec", tag)
 	if err := m.NextErr(); err != nil {
@@ -127,9 +137,11 @@ type mockApplication struct {
 	scale      int
 	units      []caasunitprovisioner.Unit
 	ops        *state.UpdateUnitsOperation
+	updateErrs []error
 	providerId string
 	addresses  []network.Address
 	charm      *mockCharm
+	placement  string
 }
 
 func (a *mockApplication) Tag() names.Tag {
@@ -158,6 +170,9 @@ func (a *mockApplication) GetScale() int {
 
 func (a *mockApplication) SetScale(scale int, generation int64, force bool) error {
 	a.MethodCall(a, "SetScale", scale)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
 	a.scale = scale
 	return nil
 }
@@ -191,7 +206,7 @@ func (a *mockApplication) Charm() (caasunitprovisioner.Charm, bool, error) {
 
 func (a *mockApplication) GetPlacement() string {
 	a.MethodCall(a, "GetPlacement")
-	return "placement"
+	return a.placement
 }
 
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
@@ -205,7 +220,12 @@ func (m *mockApplication) AllUnits() (units []caasunitprovisioner.Unit, err erro
 
 func (m *mockApplication) UpdateUnits(ops *state.UpdateUnitsOperation) error {
 	m.ops = ops
-	return nil
+	if len(m.updateErrs) == 0 {
+		return nil
+	}
+	err := m.updateErrs[0]
+	m.updateErrs = m.updateErrs[1:]
+	return err
 }
 
 func (m *mockApplication) DeviceConstraints() (map[string]state.DeviceConstraints, error) {
@@ -313,6 +333,7 @@ type mockStorage struct {
 	storageVolumes     map[names.StorageTag]names.VolumeTag
 	storageAttachments map[names.UnitTag]names.StorageTag
 	backingVolume      names.VolumeTag
+	filesystems        []*mockFilesystem
 }
 
 func (m *mockStorage) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
@@ -326,6 +347,9 @@ func (m *mockStorage) StorageInstance(tag names.StorageTag) (state.StorageInstan
 func (m *mockStorage) AllFilesystems() ([]state.Filesystem, error) {
 	m.MethodCall(m, "AllFilesystems")
 	var result []state.Filesystem
+	for _, fs := range m.filesystems {
+		result = append(result, fs)
+	}
 	for _, fsTag := range m.storageFilesystems {
 		result = append(result, &mockFilesystem{Stub: &m.Stub, tag: fsTag, volTag: m.backingVolume})
 	}
@@ -376,6 +400,11 @@ func (m *mockStorage) SetFilesystemAttachmentInfo(host names.Tag, fsTag names.Fi
 	return nil
 }
 
+func (m *mockStorage) SetFilesystemUnit(fsTag names.FilesystemTag, unitTag names.UnitTag) error {
+	m.MethodCall(m, "SetFilesystemUnit", fsTag, unitTag)
+	return nil
+}
+
 type mockDeviceBackend struct {
 	testing.Stub
 	devices            map[names.StorageTag]names.FilesystemTag
@@ -442,8 +471,11 @@ func (a *mockStorageAttachment) StorageInstance() names.StorageTag {
 type mockFilesystem struct {
 	*testing.Stub
 	state.Filesystem
-	tag    names.FilesystemTag
-	volTag names.VolumeTag
+	tag        names.FilesystemTag
+	volTag     names.VolumeTag
+	unitTag    names.UnitTag
+	storageTag names.StorageTag
+	life       state.Life
 }
 
 func (f *mockFilesystem) Tag() names.Tag {
@@ -461,6 +493,21 @@ func (f *mockFilesystem) Volume() (names.VolumeTag, error) {
 	return f.volTag, nil
 }
 
+func (f *mockFilesystem) Unit() (names.UnitTag, bool) {
+	return f.unitTag, f.unitTag.Id() != ""
+}
+
+func (f *mockFilesystem) Storage() (names.StorageTag, error) {
+	if f.storageTag.Id() == "" {
+		return f.storageTag, errors.NotAssignedf("filesystem %q", f.tag.Id())
+	}
+	return f.storageTag, nil
+}
+
+func (f *mockFilesystem) Life() state.Life {
+	return f.life
+}
+
 func (f *mockFilesystem) SetStatus(statusInfo status.StatusInfo) error {
 	f.MethodCall(f, "SetStatus", statusInfo)
 	return nil
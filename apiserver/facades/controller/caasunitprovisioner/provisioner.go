@@ -12,6 +12,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/proxy"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -45,6 +46,55 @@ type Facade struct {
 	clock              clock.Clock
 }
 
+// FacadeV2 provides version 2 of the CAAS unit provisioner facade,
+// which reports the result of updating each unit separately.
+type FacadeV2 struct {
+	*Facade
+}
+
+// FacadeV3 provides version 3 of the CAAS unit provisioner facade,
+// which adds the cleanup of orphaned storage.
+type FacadeV3 struct {
+	*FacadeV2
+}
+
+// FacadeV4 provides version 4 of the CAAS unit provisioner facade,
+// which adds the model's proxy settings to the provisioning info,
+// and a watcher for changes to them.
+type FacadeV4 struct {
+	*FacadeV3
+}
+
+// NewStateFacadeV4 provides the signature required for version 4
+// facade registration.
+func NewStateFacadeV4(ctx facade.Context) (*FacadeV4, error) {
+	f, err := NewStateFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV4{f}, nil
+}
+
+// NewStateFacadeV3 provides the signature required for version 3
+// facade registration.
+func NewStateFacadeV3(ctx facade.Context) (*FacadeV3, error) {
+	f, err := NewStateFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV3{f}, nil
+}
+
+// NewStateFacadeV2 provides the signature required for version 2
+// facade registration.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -190,6 +240,23 @@ func (f *Facade) watchPodSpec(model Model, tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// WatchProxySettings starts a NotifyWatcher that notifies when the
+// model config, and so possibly the proxy settings to be given to
+// application pods, changes.
+func (f *FacadeV4) WatchProxySettings() (params.NotifyWatchResult, error) {
+	model, err := f.state.Model()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	w := model.WatchForModelConfigChanges()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(w)
+}
+
 // ApplicationsScale returns the scaling info for specified applications in this model.
 func (f *Facade) ApplicationsScale(args params.Entities) (params.IntResults, error) {
 	results := params.IntResults{
@@ -292,6 +359,11 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		modelConfig,
 	)
 
+	scheduling, err := provider.ParseSchedulingPlacement(app.GetPlacement())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	ch, _, err := app.Charm()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -304,6 +376,13 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		Constraints: mergedCons,
 		Tags:        resourceTags,
 	}
+	if scheduling.SpreadAcrossNodes || scheduling.SpreadAcrossZones {
+		info.Scheduling = &params.KubernetesSchedulingParams{
+			SpreadAcrossNodes: scheduling.SpreadAcrossNodes,
+			SpreadAcrossZones: scheduling.SpreadAcrossZones,
+		}
+	}
+	info.ProxySettings = podProxySettings(modelConfig)
 	deployInfo := ch.Meta().Deployment
 	if deployInfo != nil {
 		info.DeploymentInfo = &params.KubernetesDeploymentInfo{
@@ -314,6 +393,28 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 	return info, nil
 }
 
+// podProxySettings returns the model's proxy settings to be set in the
+// environment of an application's pods, or nil if there are none. As
+// for machine agents, the juju-* proxy settings take precedence over
+// the legacy ones.
+func podProxySettings(modelConfig *config.Config) *params.ProxyConfig {
+	var settings proxy.Settings
+	switch {
+	case modelConfig.HasJujuProxy():
+		settings = modelConfig.JujuProxySettings()
+	case modelConfig.HasLegacyProxy():
+		settings = modelConfig.LegacyProxySettings()
+	default:
+		return nil
+	}
+	return &params.ProxyConfig{
+		HTTP:    settings.Http,
+		HTTPS:   settings.Https,
+		FTP:     settings.Ftp,
+		NoProxy: settings.NoProxy,
+	}
+}
+
 func filesystemParams(
 	app Application,
 	cons state.StorageConstraints,
@@ -452,40 +553,187 @@ func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs)
 		return result, nil
 	}
 	for i, appUpdate := range args.Args {
-		appTag, err := names.ParseApplicationTag(appUpdate.ApplicationTag)
+		app, err := a.applicationForUpdate(appUpdate)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		app, err := a.state.Application(appTag.Id())
+		err = a.updateUnitsFromCloud(app, appUpdate.Scale, appUpdate.Generation, appUpdate.Units, nil)
+		if err != nil {
+			// Mask any not found errors as the worker (caller) treats them specially
+			// and they are not relevant here.
+			result.Results[i].Error = common.ServerError(errors.Mask(err))
+		}
+	}
+	return result, nil
+}
+
+// UpdateApplicationsUnits updates the Juju data model to reflect the given
+// units of the specified application. Unlike version 1 of the facade, each
+// unit is updated independently of the others, and the result of updating
+// each unit is reported, in the order in which the units were supplied.
+// A scale change that is stale because events from the cloud arrived out
+// of order is ignored rather than reported as an error.
+func (a *FacadeV2) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (params.UpdateApplicationUnitResults, error) {
+	result := params.UpdateApplicationUnitResults{
+		Results: make([]params.UpdateApplicationUnitResult, len(args.Args)),
+	}
+	for i, appUpdate := range args.Args {
+		app, err := a.applicationForUpdate(appUpdate)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		appStatus := appUpdate.Status
-		if appStatus.Status != "" && appStatus.Status != status.Unknown {
-			now := a.clock.Now()
-			err = app.SetStatus(status.StatusInfo{
-				Status:  appStatus.Status,
-				Message: appStatus.Info,
-				Data:    appStatus.Data,
-				Since:   &now,
-			})
-			if err != nil {
-				result.Results[i].Error = common.ServerError(err)
-				continue
+		unitErrors := make(unitUpdateErrors)
+		err = a.updateUnitsFromCloud(app, appUpdate.Scale, appUpdate.Generation, appUpdate.Units, unitErrors)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(errors.Mask(err))
+			continue
+		}
+
+		requested := set.NewStrings()
+		unitResults := make([]params.ErrorResult, len(appUpdate.Units))
+		for j, u := range appUpdate.Units {
+			requested.Add(u.ProviderId)
+			if err := unitErrors[u.ProviderId]; err != nil {
+				unitResults[j].Error = common.ServerError(errors.Mask(err))
 			}
 		}
-		err = a.updateUnitsFromCloud(app, appUpdate.Scale, appUpdate.Generation, appUpdate.Units)
-		if err != nil {
-			// Mask any not found errors as the worker (caller) treats them specially
-			// and they are not relevant here.
+		result.Results[i].Units = unitResults
+
+		// Units which are no longer in the cloud are updated too, but as
+		// they were not supplied, any failure is reported for the application.
+		var removedIds []string
+		for providerId := range unitErrors {
+			if !requested.Contains(providerId) {
+				removedIds = append(removedIds, providerId)
+			}
+		}
+		if len(removedIds) > 0 {
+			sort.Strings(removedIds)
+			err := errors.Annotatef(unitErrors[removedIds[0]], "updating removed unit with provider id %q", removedIds[0])
 			result.Results[i].Error = common.ServerError(errors.Mask(err))
 		}
 	}
 	return result, nil
 }
 
+// CleanupOrphanedStorage destroys the filesystems, and their storage
+// instances, that were last mounted by a pod of one of the specified
+// applications' units, where that unit no longer exists. Recreating a
+// stateful set gives its pods new units, so without this the volume
+// claims of the old pods would be kept forever.
+func (a *FacadeV3) CleanupOrphanedStorage(args params.CleanupOrphanedStorageArgs) (params.CleanupOrphanedStorageResults, error) {
+	result := params.CleanupOrphanedStorageResults{
+		Results: make([]params.CleanupOrphanedStorageResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		var maxWait time.Duration
+		if arg.MaxWait != nil {
+			maxWait = *arg.MaxWait
+		}
+		destroyed, err := a.cleanupOrphanedStorage(appTag, arg.Force, maxWait)
+		for _, fsTag := range destroyed {
+			result.Results[i].Filesystems = append(result.Results[i].Filesystems, fsTag.String())
+		}
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (a *Facade) cleanupOrphanedStorage(appTag names.ApplicationTag, force bool, maxWait time.Duration) ([]names.FilesystemTag, error) {
+	app, err := a.state.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	existingUnits := set.NewStrings()
+	for _, u := range units {
+		if u.Life() != state.Dead {
+			existingUnits.Add(u.Name())
+		}
+	}
+
+	allFilesystems, err := a.storage.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var destroyed []names.FilesystemTag
+	for _, fs := range allFilesystems {
+		unitTag, ok := fs.Unit()
+		if !ok || existingUnits.Contains(unitTag.Id()) {
+			continue
+		}
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err != nil {
+			return destroyed, errors.Trace(err)
+		}
+		if appName != appTag.Id() || fs.Life() != state.Alive {
+			continue
+		}
+
+		logger.Debugf("found filesystem %v orphaned by unit %v", fs.FilesystemTag(), unitTag.Id())
+		storageTag, err := fs.Storage()
+		if err == nil {
+			err = a.storage.DestroyStorageInstance(storageTag, true, force, maxWait)
+			if err != nil && !errors.IsNotFound(err) {
+				return destroyed, errors.Annotatef(err, "destroying storage %v", storageTag.Id())
+			}
+		} else if !errors.IsNotAssigned(err) {
+			return destroyed, errors.Trace(err)
+		}
+		err = a.storage.DestroyFilesystem(fs.FilesystemTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return destroyed, errors.Annotatef(err, "destroying filesystem %v", fs.FilesystemTag().Id())
+		}
+		destroyed = append(destroyed, fs.FilesystemTag())
+	}
+	return destroyed, nil
+}
+
+// applicationForUpdate returns the application to which the input
+// unit updates apply, having first set the application status.
+func (a *Facade) applicationForUpdate(appUpdate params.UpdateApplicationUnits) (Application, error) {
+	appTag, err := names.ParseApplicationTag(appUpdate.ApplicationTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := a.state.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appStatus := appUpdate.Status
+	if appStatus.Status != "" && appStatus.Status != status.Unknown {
+		now := a.clock.Now()
+		err = app.SetStatus(status.StatusInfo{
+			Status:  appStatus.Status,
+			Message: appStatus.Info,
+			Data:    appStatus.Data,
+			Since:   &now,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return app, nil
+}
+
+// unitUpdateErrors records the errors encountered updating individual
+// units, keyed by their provider ids. When supplied to updateUnitsFromCloud,
+// each unit is updated in its own transaction, so that a failure to update
+// one does not prevent the others from being updated.
+type unitUpdateErrors map[string]error
+
 // updateStatus constructs the agent and cloud container status values.
 func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 	agentStatus *status.StatusInfo,
@@ -535,7 +783,9 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 // source (typically a cloud update event) and merges that with the existing unit
 // data model in state. The passed in units are the complete set for the cloud, so
 // any existing units in state with provider ids which aren't in the set will be removed.
-func (a *Facade) updateUnitsFromCloud(app Application, scale *int, generation *int64, unitUpdates []params.ApplicationUnitParams) error {
+func (a *Facade) updateUnitsFromCloud(
+	app Application, scale *int, generation *int64, unitUpdates []params.ApplicationUnitParams, unitErrors unitUpdateErrors,
+) error {
 	logger.Debugf("unit updates: %#v", unitUpdates)
 	if scale != nil {
 		logger.Debugf("application scale: %v", *scale)
@@ -601,7 +851,7 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int, generation *i
 			continue
 		}
 
-		stateUnitsById[providerId] = stateUnit{Unit: u}
+		stateUnitsById[providerId] = stateUnit{Unit: u, providerId: providerId}
 		stateUnitInCloud := stateUnitExistsInCloud(providerId)
 		aliveStateIds.Add(providerId)
 		if stateUnitInCloud {
@@ -673,7 +923,7 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int, generation *i
 		unitInfo.removedUnits = append(unitInfo.removedUnits, u)
 	}
 
-	if err := a.updateStateUnits(app, unitInfo); err != nil {
+	if err := a.updateStateUnits(app, unitInfo, unitErrors); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -688,14 +938,29 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int, generation *i
 		gen = *generation
 	}
 	if currentScale != *scale {
-		return app.SetScale(*scale, gen, false)
+		err := app.SetScale(*scale, gen, false)
+		if unitErrors != nil && errors.IsForbidden(err) {
+			// Events from the cloud may arrive out of order,
+			// so a scale change may already be superseded.
+			logger.Debugf("ignoring stale scale for %q: %v", app.Name(), err)
+			return nil
+		}
+		return err
 	}
 	return nil
 }
 
 type stateUnit struct {
 	Unit
-	delete bool
+	providerId string
+	delete     bool
+}
+
+// unitOperation holds the state operations
+// that update a single unit.
+type unitOperation struct {
+	providerId string
+	ops        state.UpdateUnitsOperation
 }
 
 type updateStateUnitParams struct {
@@ -725,7 +990,7 @@ type volumeInfo struct {
 	volumeId   string
 }
 
-func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitParams) error {
+func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitParams, unitErrors unitUpdateErrors) error {
 
 	if app.Life() != state.Alive {
 		// We ignore any updates for dying applications.
@@ -739,8 +1004,8 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 	logger.Tracef("unassociated units: %+v", unitInfo.unassociatedUnits)
 
 	// Now we have the added, removed, updated units all sorted,
-	// generate the state update operations.
-	var unitUpdate state.UpdateUnitsOperation
+	// generate the state update operations for each unit.
+	var unitOps []unitOperation
 
 	filesystemUpdates := make(map[string]filesystemInfo)
 	filesystemStatus := make(map[string]status.StatusInfo)
@@ -761,8 +1026,9 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 			filesystemStatus[fs.FilesystemTag().String()] = status.StatusInfo{Status: status.Detached}
 		}
 
+		unitOp := unitOperation{providerId: u.providerId}
 		if u.delete {
-			unitUpdate.Deletes = append(unitUpdate.Deletes, u.DestroyOperation())
+			unitOp.ops.Deletes = append(unitOp.ops.Deletes, u.DestroyOperation())
 		}
 		// We'll set the status as Terminated. This will either be transient, as will
 		// occur when a pod is restarted external to Juju, or permanent if the pod has
@@ -779,8 +1045,9 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 			CloudContainerStatus: cloudContainerStatus,
 			AgentStatus:          agentStatus,
 		}
-		unitUpdate.Updates = append(unitUpdate.Updates,
+		unitOp.ops.Updates = append(unitOp.ops.Updates,
 			u.UpdateOperation(updateProps))
+		unitOps = append(unitOps, unitOp)
 	}
 
 	processUnitParams := func(unitParams params.ApplicationUnitParams) *state.UnitUpdateProperties {
@@ -901,8 +1168,10 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 		if len(unitParams.FilesystemInfo) > 0 {
 			unitParamsWithFilesystemInfo = append(unitParamsWithFilesystemInfo, unitParams)
 		}
-		unitUpdate.Updates = append(unitUpdate.Updates,
+		unitOp := unitOperation{providerId: unitParams.ProviderId}
+		unitOp.ops.Updates = append(unitOp.ops.Updates,
 			u.UpdateOperation(*updateProps))
+		unitOps = append(unitOps, unitOp)
 	}
 
 	// For newly added units in the cloud, either update state units which
@@ -913,8 +1182,10 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 		if idx < len(unitInfo.unassociatedUnits) {
 			u := unitInfo.unassociatedUnits[idx]
 			updateProps := processUnitParams(unitParams)
-			unitUpdate.Updates = append(unitUpdate.Updates,
+			unitOp := unitOperation{providerId: unitParams.ProviderId}
+			unitOp.ops.Updates = append(unitOp.ops.Updates,
 				u.UpdateOperation(*updateProps))
+			unitOps = append(unitOps, unitOp)
 			idx++
 			if len(unitParams.FilesystemInfo) > 0 {
 				unitParamsWithFilesystemInfo = append(unitParamsWithFilesystemInfo, unitParams)
@@ -927,12 +1198,12 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 		if len(unitParams.FilesystemInfo) > 0 {
 			unitParamsWithFilesystemInfo = append(unitParamsWithFilesystemInfo, unitParams)
 		}
-		unitUpdate.Adds = append(unitUpdate.Adds,
+		unitOp := unitOperation{providerId: unitParams.ProviderId}
+		unitOp.ops.Adds = append(unitOp.ops.Adds,
 			app.AddOperation(*updateProps))
+		unitOps = append(unitOps, unitOp)
 	}
-	err := app.UpdateUnits(&unitUpdate)
-	// We ignore any updates for dying applications.
-	if state.IsNotAlive(err) {
+	if done := a.applyUnitOperations(app, unitOps, unitErrors); done {
 		return nil
 	}
 
@@ -960,6 +1231,14 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 
 	processedFilesystemIds := set.NewStrings()
 	for _, unitParams := range unitParamsWithFilesystemInfo {
+		if unitErrors[unitParams.ProviderId] != nil {
+			// The unit was not updated, so leave its filesystems
+			// as they are, and ensure they are not seen as orphaned.
+			for _, fsInfo := range unitParams.FilesystemInfo {
+				processedFilesystemIds.Add(fsInfo.FilesystemId)
+			}
+			continue
+		}
 		var (
 			unitTag names.UnitTag
 			ok      bool
@@ -997,6 +1276,38 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 	return errors.Annotatef(err, "updating filesystem information for %v", appName)
 }
 
+// applyUnitOperations applies the input unit operations to state.
+// Without unitErrors, the operations are applied in a single transaction;
+// otherwise each unit is updated separately and any errors are recorded in
+// unitErrors. It returns true if the application is no longer alive, in
+// which case the updates are ignored.
+func (a *Facade) applyUnitOperations(app Application, unitOps []unitOperation, unitErrors unitUpdateErrors) bool {
+	if unitErrors == nil {
+		var unitUpdate state.UpdateUnitsOperation
+		for _, unitOp := range unitOps {
+			unitUpdate.Adds = append(unitUpdate.Adds, unitOp.ops.Adds...)
+			unitUpdate.Updates = append(unitUpdate.Updates, unitOp.ops.Updates...)
+			unitUpdate.Deletes = append(unitUpdate.Deletes, unitOp.ops.Deletes...)
+		}
+		err := app.UpdateUnits(&unitUpdate)
+		// We ignore any updates for dying applications.
+		return state.IsNotAlive(err)
+	}
+
+	for _, unitOp := range unitOps {
+		unitOp := unitOp
+		err := app.UpdateUnits(&unitOp.ops)
+		if state.IsNotAlive(err) {
+			return true
+		}
+		if err != nil {
+			logger.Warningf("cannot update unit with provider id %q: %v", unitOp.providerId, err)
+			unitErrors[unitOp.providerId] = err
+		}
+	}
+	return false
+}
+
 func (a *Facade) cleaupOrphanedFilesystems(processedFilesystemIds set.Strings) error {
 	// TODO(caas) - record unit id on the filesystem so we can query by unit
 	allFilesystems, err := a.storage.AllFilesystems()
@@ -1164,6 +1475,12 @@ func (a *Facade) updateFilesystemInfo(filesystemUpdates map[string]filesystemInf
 		if err != nil {
 			return errors.Trace(err)
 		}
+
+		// Record the unit on the filesystem itself, so that the
+		// filesystem can be found once the unit's pod is gone.
+		if err := a.storage.SetFilesystemUnit(fsTag, fsData.unitTag); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Do it in sorted order so it's deterministic for tests.
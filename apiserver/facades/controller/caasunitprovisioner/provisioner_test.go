@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
@@ -41,6 +42,7 @@ type CAASProvisionerSuite struct {
 	devices             *mockDeviceBackend
 	applicationsChanges chan []string
 	podSpecChanges      chan struct{}
+	configChanges       chan struct{}
 	scaleChanges        chan struct{}
 
 	resources  *common.Resources
@@ -53,6 +55,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
+	s.configChanges = make(chan struct{}, 1)
 	s.scaleChanges = make(chan struct{}, 1)
 	s.st = &mockState{
 		application: mockApplication{
@@ -64,6 +67,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
 			podSpecWatcher: statetesting.NewMockNotifyWatcher(s.podSpecChanges),
+			configWatcher:  statetesting.NewMockNotifyWatcher(s.configChanges),
 		},
 		unit: mockUnit{
 			life: state.Dying,
@@ -80,6 +84,7 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.configWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -182,6 +187,7 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 			},
 		},
 	}
+	s.st.application.placement = "spread=node"
 
 	results, err := s.facade.ProvisioningInfo(params.Entities{
 		Entities: []params.Entity{
@@ -209,6 +215,7 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 		Tags: map[string]string{
 			"juju-model-uuid":      coretesting.ModelTag.Id(),
 			"juju-controller-uuid": coretesting.ControllerTag.Id()},
+		Scheduling: &params.KubernetesSchedulingParams{SpreadAcrossNodes: true},
 	}
 	expectedFileSystems := map[string]params.KubernetesFilesystemParams{
 		"data": {
@@ -253,6 +260,8 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	c.Assert(obtained.Devices, jc.DeepEquals, expectedResult.Devices)
 	c.Assert(obtained.Constraints, jc.DeepEquals, expectedResult.Constraints)
 	c.Assert(obtained.Tags, jc.DeepEquals, expectedResult.Tags)
+	c.Assert(obtained.Scheduling, jc.DeepEquals, expectedResult.Scheduling)
+	c.Assert(obtained.ProxySettings, gc.IsNil)
 	c.Assert(results.Results[1], jc.DeepEquals, params.KubernetesProvisioningInfoResult{
 		Error: &params.Error{
 			Message: `"unit-gitlab-0" is not a valid application tag`,
@@ -263,6 +272,65 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	s.storagePoolManager.CheckCallNames(c, "Get", "Get")
 }
 
+func (s *CAASProvisionerSuite) TestProvisioningInfoProxySettings(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", life: state.Alive},
+	}
+	s.st.application.charm = &mockCharm{}
+	s.st.model.configAttrs = map[string]interface{}{
+		"juju-http-proxy":  "http://proxy.example.com:3128",
+		"juju-https-proxy": "https://proxy.example.com:3129",
+		"juju-no-proxy":    "10.0.0.0/8,.svc",
+	}
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.ProxySettings, jc.DeepEquals, &params.ProxyConfig{
+		HTTP:    "http://proxy.example.com:3128",
+		HTTPS:   "https://proxy.example.com:3129",
+		NoProxy: "10.0.0.0/8,.svc",
+	})
+}
+
+func (s *CAASProvisionerSuite) TestProvisioningInfoLegacyProxySettings(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", life: state.Alive},
+	}
+	s.st.application.charm = &mockCharm{}
+	s.st.model.configAttrs = map[string]interface{}{
+		"http-proxy": "http://legacy.proxy",
+		"no-proxy":   "127.0.0.1",
+	}
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.ProxySettings, jc.DeepEquals, &params.ProxyConfig{
+		HTTP:    "http://legacy.proxy",
+		NoProxy: "127.0.0.1",
+	})
+}
+
+func (s *CAASProvisionerSuite) TestWatchProxySettings(c *gc.C) {
+	s.configChanges <- struct{}{}
+
+	facade := &caasunitprovisioner.FacadeV4{
+		FacadeV3: &caasunitprovisioner.FacadeV3{FacadeV2: &caasunitprovisioner.FacadeV2{Facade: s.facade}},
+	}
+	result, err := facade.WatchProxySettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.model.configWatcher)
+	s.st.model.CheckCallNames(c, "WatchForModelConfigChanges")
+}
+
 func (s *CAASProvisionerSuite) TestApplicationScale(c *gc.C) {
 	results, err := s.facade.ApplicationsScale(params.Entities{
 		Entities: []params.Entity{
@@ -475,6 +543,77 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsScaleChange(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) updateUnitsV2Args() params.UpdateApplicationUnitArgs {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", life: state.Alive},
+		&mockUnit{name: "gitlab/2", containerInfo: &mockContainerInfo{providerId: "uuid2"}, life: state.Alive},
+	}
+	s.st.application.scale = 3
+
+	return params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{{
+			ApplicationTag: "application-gitlab",
+			Units: []params.ApplicationUnitParams{
+				{ProviderId: "uuid", Address: "address", Status: "running"},
+				{ProviderId: "another-uuid", Address: "another-address", Status: "allocating"},
+			},
+			Scale:      intPtr(2),
+			Generation: int64Ptr(1),
+		}},
+	}
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsV2(c *gc.C) {
+	args := s.updateUnitsV2Args()
+	// The removed unit is updated first, then the existing unit,
+	// and finally the unit newly associated with a pod.
+	s.st.application.updateErrs = []error{nil, errors.New("boom"), nil}
+
+	facade := &caasunitprovisioner.FacadeV2{Facade: s.facade}
+	results, err := facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateApplicationUnitResults{
+		Results: []params.UpdateApplicationUnitResult{{
+			Units: []params.ErrorResult{
+				{Error: &params.Error{Message: "boom"}},
+				{},
+			},
+		}},
+	})
+	s.st.application.CheckCallNames(c, "Life", "Name", "GetScale", "SetScale")
+	s.st.application.CheckCall(c, 3, "SetScale", 2)
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsV2RemovedUnitFails(c *gc.C) {
+	args := s.updateUnitsV2Args()
+	s.st.application.updateErrs = []error{errors.New("boom")}
+
+	facade := &caasunitprovisioner.FacadeV2{Facade: s.facade}
+	results, err := facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Units, jc.DeepEquals, []params.ErrorResult{{}, {}})
+	c.Assert(results.Results[0].Error, jc.DeepEquals, &params.Error{
+		Message: `updating removed unit with provider id "uuid2": boom`,
+	})
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsV2StaleScale(c *gc.C) {
+	args := s.updateUnitsV2Args()
+	s.st.application.SetErrors(errors.Forbiddenf("stale scale"))
+
+	facade := &caasunitprovisioner.FacadeV2{Facade: s.facade}
+	results, err := facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateApplicationUnitResults{
+		Results: []params.UpdateApplicationUnitResult{{
+			Units: []params.ErrorResult{{}, {}},
+		}},
+	})
+	c.Assert(s.st.application.scale, gc.Equals, 3)
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnknownScale(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
@@ -647,7 +786,7 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorage(c *gc.C) {
 		"UnitStorageAttachments", "UnitStorageAttachments", "UnitStorageAttachments",
 		"StorageInstance", "UnitStorageAttachments", "StorageInstance", "AllFilesystems",
 		"Volume", "SetVolumeInfo", "SetVolumeAttachmentInfo", "Volume", "SetStatus", "Volume", "SetStatus",
-		"Filesystem", "SetFilesystemInfo", "SetFilesystemAttachmentInfo",
+		"Filesystem", "SetFilesystemInfo", "SetFilesystemAttachmentInfo", "SetFilesystemUnit",
 		"Filesystem", "SetStatus", "Filesystem", "SetStatus", "Filesystem", "SetStatus", "Filesystem", "SetStatus")
 	s.storage.CheckCall(c, 0, "UnitStorageAttachments", names.NewUnitTag("gitlab/2"))
 	s.storage.CheckCall(c, 1, "UnitStorageAttachments", names.NewUnitTag("gitlab/3"))
@@ -694,19 +833,21 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorage(c *gc.C) {
 			MountPoint: "/path/to/there",
 			ReadOnly:   true,
 		})
-	s.storage.CheckCall(c, 20, "SetStatus",
+	s.storage.CheckCall(c, 17, "SetFilesystemUnit",
+		names.NewFilesystemTag("gitlab/1/0"), names.NewUnitTag("gitlab/1"))
+	s.storage.CheckCall(c, 21, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Pending,
 			Message: "not ready",
 			Since:   &now,
 		})
-	s.storage.CheckCall(c, 22, "SetStatus",
+	s.storage.CheckCall(c, 23, "SetStatus",
 		status.StatusInfo{
 			Status:  status.Attached,
 			Message: "ready",
 			Since:   &now,
 		})
-	s.storage.CheckCall(c, 24, "SetStatus",
+	s.storage.CheckCall(c, 25, "SetStatus",
 		status.StatusInfo{
 			Status: status.Detached,
 			Since:  &now,
@@ -755,7 +896,7 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorageNoBackingVo
 
 	s.storage.CheckCallNames(c,
 		"UnitStorageAttachments", "StorageInstance", "AllFilesystems", "Filesystem",
-		"SetFilesystemInfo", "SetFilesystemAttachmentInfo", "Filesystem", "SetStatus")
+		"SetFilesystemInfo", "SetFilesystemAttachmentInfo", "SetFilesystemUnit", "Filesystem", "SetStatus")
 	s.storage.CheckCall(c, 0, "UnitStorageAttachments", names.NewUnitTag("gitlab/0"))
 	s.storage.CheckCall(c, 1, "StorageInstance", names.NewStorageTag("data/0"))
 
@@ -772,13 +913,71 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorageNoBackingVo
 			MountPoint: "/path/to/here",
 			ReadOnly:   true,
 		})
-	s.storage.CheckCall(c, 7, "SetStatus",
+	s.storage.CheckCall(c, 6, "SetFilesystemUnit",
+		names.NewFilesystemTag("gitlab/0/0"), names.NewUnitTag("gitlab/0"))
+	s.storage.CheckCall(c, 8, "SetStatus",
 		status.StatusInfo{
 			Status: status.Attached,
 			Since:  &now,
 		})
 }
 
+func (s *CAASProvisionerSuite) TestCleanupOrphanedStorage(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/1", life: state.Alive},
+		&mockUnit{name: "gitlab/2", life: state.Dead},
+	}
+	s.storage.filesystems = []*mockFilesystem{{
+		// Orphaned: the unit no longer exists.
+		tag:        names.NewFilesystemTag("gitlab/0/0"),
+		unitTag:    names.NewUnitTag("gitlab/0"),
+		storageTag: names.NewStorageTag("data/0"),
+		life:       state.Alive,
+	}, {
+		tag:        names.NewFilesystemTag("gitlab/1/0"),
+		unitTag:    names.NewUnitTag("gitlab/1"),
+		storageTag: names.NewStorageTag("data/1"),
+		life:       state.Alive,
+	}, {
+		// Orphaned: the unit is dead.
+		tag:     names.NewFilesystemTag("gitlab/2/0"),
+		unitTag: names.NewUnitTag("gitlab/2"),
+		life:    state.Alive,
+	}, {
+		tag:     names.NewFilesystemTag("gitlab/3/0"),
+		unitTag: names.NewUnitTag("gitlab/3"),
+		life:    state.Dying,
+	}, {
+		tag:     names.NewFilesystemTag("mysql/0/0"),
+		unitTag: names.NewUnitTag("mysql/0"),
+		life:    state.Alive,
+	}, {
+		tag:  names.NewFilesystemTag("0"),
+		life: state.Alive,
+	}}
+
+	maxWait := time.Minute
+	facade := &caasunitprovisioner.FacadeV3{FacadeV2: &caasunitprovisioner.FacadeV2{Facade: s.facade}}
+	results, err := facade.CleanupOrphanedStorage(params.CleanupOrphanedStorageArgs{
+		Args: []params.CleanupOrphanedStorageArg{
+			{ApplicationTag: "application-gitlab", Force: true, MaxWait: &maxWait},
+			{ApplicationTag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CleanupOrphanedStorageResults{
+		Results: []params.CleanupOrphanedStorageResult{{
+			Filesystems: []string{"filesystem-gitlab-0-0", "filesystem-gitlab-2-0"},
+		}, {
+			Error: &params.Error{Message: `"unit-gitlab-0" is not a valid application tag`},
+		}},
+	})
+	s.storage.CheckCallNames(c, "AllFilesystems", "DestroyStorageInstance", "DestroyFilesystem", "DestroyFilesystem")
+	s.storage.CheckCall(c, 1, "DestroyStorageInstance", names.NewStorageTag("data/0"), true, true)
+	s.storage.CheckCall(c, 2, "DestroyFilesystem", names.NewFilesystemTag("gitlab/0/0"))
+	s.storage.CheckCall(c, 3, "DestroyFilesystem", names.NewFilesystemTag("gitlab/2/0"))
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsService(c *gc.C) {
 	results, err := s.facade.UpdateApplicationsService(params.UpdateApplicationServiceArgs{
 		Args: []params.UpdateApplicationServiceArg{
@@ -39,13 +39,13 @@ type StorageBackend interface {
 	UnitStorageAttachments(unit names.UnitTag) ([]state.StorageAttachment, error)
 	SetFilesystemInfo(names.FilesystemTag, state.FilesystemInfo) error
 	SetFilesystemAttachmentInfo(names.Tag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
+	SetFilesystemUnit(names.FilesystemTag, names.UnitTag) error
 	Volume(tag names.VolumeTag) (state.Volume, error)
 	StorageInstanceVolume(tag names.StorageTag) (state.Volume, error)
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.Tag, names.VolumeTag, state.VolumeAttachmentInfo) error
 
 	// These are for cleanup up orphaned filesystems when pods are recreated.
-	// TODO(caas) - record unit id on the filesystem so we can query by unit
 	AllFilesystems() ([]state.Filesystem, error)
 	DestroyStorageInstance(tag names.StorageTag, destroyAttachments bool, force bool, maxWait time.Duration) (err error)
 	DestroyFilesystem(tag names.FilesystemTag) (err error)
@@ -63,6 +63,7 @@ type Model interface {
 	ModelConfig() (*config.Config, error)
 	PodSpec(tag names.ApplicationTag) (string, error)
 	WatchPodSpec(tag names.ApplicationTag) (state.NotifyWatcher, error)
+	WatchForModelConfigChanges() state.NotifyWatcher
 	Containers(providerIds ...string) ([]state.CloudContainer, error)
 }
 
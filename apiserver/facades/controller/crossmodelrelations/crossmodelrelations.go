@@ -91,22 +91,48 @@ func NewCrossModelRelationsAPI(
 	}, nil
 }
 
-func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.Tag, mac macaroon.Slice) error {
-	api.mu.Lock()
-	defer api.mu.Unlock()
-
+// offerUUIDForRelation returns the UUID of the offer over which
+// the relation was made. api.mu must be held.
+func (api *CrossModelRelationsAPI) offerUUIDForRelation(relationTag names.Tag) (string, error) {
 	offerUUID, ok := api.relationToOffer[relationTag.Id()]
 	if !ok {
 		oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
 		if err != nil {
-			return errors.Trace(err)
+			return "", errors.Trace(err)
 		}
 		offerUUID = oc.OfferUUID()
 	}
+	return offerUUID, nil
+}
+
+func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.Tag, mac macaroon.Slice) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	offerUUID, err := api.offerUUIDForRelation(relationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	auth := api.authCtxt.Authenticator(api.st.ModelUUID(), offerUUID)
 	return auth.CheckRelationMacaroons(relationTag, mac)
 }
 
+// checkIngressNetworksForRelation returns an error if the offer over
+// which the relation was made does not allow ingress from the networks.
+func (api *CrossModelRelationsAPI) checkIngressNetworksForRelation(relationTag names.Tag, networks []string) error {
+	api.mu.Lock()
+	offerUUID, err := api.offerUUIDForRelation(relationTag)
+	api.mu.Unlock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appOffer, err := api.st.ApplicationOfferForUUID(offerUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return appOffer.CheckConsumerNetworks(networks)
+}
+
 // PublishRelationChanges publishes relation changes to the
 // model hosting the remote application involved in the relation.
 func (api *CrossModelRelationsAPI) PublishRelationChanges(
@@ -179,6 +205,26 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if username == "" || !ok {
 		return nil, common.ErrPerm
 	}
+	// The consuming controller is taken from the macaroon, which was
+	// issued to it when the offer was consumed; the UUID supplied in
+	// the request is only used by consumers which predate that.
+	consumerController := attr[commoncrossmodel.ConsumerControllerKey]
+	if consumerController == "" {
+		consumerController = relation.ConsumerControllerUUID
+	} else if relation.ConsumerControllerUUID != "" && relation.ConsumerControllerUUID != consumerController {
+		logger.Warningf("relation to offer %v from model %v claims controller %v, macaroon declares %v",
+			appOffer.OfferName, relation.SourceModelTag, relation.ConsumerControllerUUID, consumerController)
+		return nil, common.ErrPerm
+	}
+	// The offer may only be consumable from specific controllers and networks.
+	if err := appOffer.CheckConsumerController(consumerController); err != nil {
+		logger.Warningf("rejecting relation to offer %v from model %v: %v", appOffer.OfferName, relation.SourceModelTag, err)
+		return nil, errors.Trace(err)
+	}
+	if err := appOffer.CheckConsumerNetworks(relation.ConsumerNetworks); err != nil {
+		logger.Warningf("rejecting relation to offer %v from model %v: %v", appOffer.OfferName, relation.SourceModelTag, err)
+		return nil, errors.Trace(err)
+	}
 	localApplicationName := appOffer.ApplicationName
 	localApp, err := api.st.Application(localApplicationName)
 	if err != nil {
@@ -505,6 +551,14 @@ func (api *CrossModelRelationsAPI) PublishIngressNetworkChanges(
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		// Ingress may be restricted by the offer to specific networks.
+		if change.IngressRequired {
+			if err := api.checkIngressNetworksForRelation(relationTag, change.Networks); err != nil {
+				logger.Warningf("rejecting ingress for %v: %v", relationTag.Id(), err)
+				results.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
 		if err := commoncrossmodel.PublishIngressNetworkChange(api.st, relationTag, change); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
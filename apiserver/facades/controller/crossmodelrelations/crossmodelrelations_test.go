@@ -272,6 +272,136 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) registerRelationForRestrictedOffer(
+	c *gc.C, offer *crossmodel.ApplicationOffer, declaredController string, arg params.RegisterRemoteRelationArg,
+) params.RegisterRemoteRelationResult {
+	app := &mockApplication{}
+	app.eps = []state.Endpoint{{
+		ApplicationName: "offeredapp",
+		Relation:        charm.Relation{Name: "local"},
+	}}
+	s.st.applications["offeredapp"] = app
+	offer.OfferUUID = "offer-uuid"
+	offer.OfferName = "offered"
+	offer.ApplicationName = "offeredapp"
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{"offer-uuid": offer}
+	caveats := []checkers.Caveat{
+		checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+		checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+		checkers.DeclaredCaveat("username", "mary"),
+	}
+	if declaredController != "" {
+		caveats = append(caveats, checkers.DeclaredCaveat("consumer-controller-uuid", declaredController))
+	}
+	mac, err := s.bakery.NewMacaroon(caveats)
+	c.Assert(err, jc.ErrorIsNil)
+	arg.ApplicationToken = "app-token"
+	arg.SourceModelTag = coretesting.ModelTag.String()
+	arg.RelationToken = "rel-token"
+	arg.RemoteEndpoint = params.RemoteEndpoint{Name: "remote"}
+	arg.OfferUUID = "offer-uuid"
+	arg.LocalEndpointName = "local"
+	arg.Macaroons = macaroon.Slice{mac}
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{arg},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *crossmodelRelationsSuite) registerRelationForControllerRestrictedOffer(c *gc.C, controllerUUID string) params.RegisterRemoteRelationResult {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerControllers: []string{coretesting.ControllerTag.Id()},
+	}
+	return s.registerRelationForRestrictedOffer(c, offer, "", params.RegisterRemoteRelationArg{
+		ConsumerControllerUUID: controllerUUID,
+	})
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsAllowedController(c *gc.C) {
+	result := s.registerRelationForControllerRestrictedOffer(c, coretesting.ControllerTag.Id())
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsControllerNotAllowed(c *gc.C) {
+	result := s.registerRelationForControllerRestrictedOffer(c, "deadbeef-0bad-400d-8000-5b1d0d06f00d")
+	c.Assert(result.Error, gc.ErrorMatches, `controller "deadbeef-0bad-400d-8000-5b1d0d06f00d" may not consume offer "offered"`)
+	c.Assert(result.Error.Code, gc.Equals, params.CodeUnauthorized)
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsControllerNotIdentified(c *gc.C) {
+	result := s.registerRelationForControllerRestrictedOffer(c, "")
+	c.Assert(result.Error, gc.ErrorMatches, `offer "offered" requires the consuming controller to be identified`)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsDeclaredControllerAllowed(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerControllers: []string{coretesting.ControllerTag.Id()},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, coretesting.ControllerTag.Id(), params.RegisterRemoteRelationArg{})
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsDeclaredControllerNotAllowed(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerControllers: []string{coretesting.ControllerTag.Id()},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, "deadbeef-0bad-400d-8000-5b1d0d06f00d", params.RegisterRemoteRelationArg{})
+	c.Assert(result.Error, gc.ErrorMatches, `controller "deadbeef-0bad-400d-8000-5b1d0d06f00d" may not consume offer "offered"`)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsControllerDoesNotMatchMacaroon(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerControllers: []string{coretesting.ControllerTag.Id()},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, "deadbeef-0bad-400d-8000-5b1d0d06f00d", params.RegisterRemoteRelationArg{
+		ConsumerControllerUUID: coretesting.ControllerTag.Id(),
+	})
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+	c.Assert(result.Error.Code, gc.Equals, params.CodeUnauthorized)
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsNetworksAllowed(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerCIDRs: []string{"10.0.0.0/16"},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, "", params.RegisterRemoteRelationArg{
+		ConsumerNetworks: []string{"10.0.1.0/24"},
+	})
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsNetworksNotAllowed(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerCIDRs: []string{"10.0.0.0/16"},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, "", params.RegisterRemoteRelationArg{
+		ConsumerNetworks: []string{"10.0.1.0/24", "1.2.3.4/32"},
+	})
+	c.Assert(result.Error, gc.ErrorMatches, `network "1.2.3.4/32" may not consume offer "offered"`)
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsNetworksNotIdentified(c *gc.C) {
+	offer := &crossmodel.ApplicationOffer{
+		AllowedConsumerCIDRs: []string{"10.0.0.0/16"},
+	}
+	result := s.registerRelationForRestrictedOffer(c, offer, "", params.RegisterRemoteRelationArg{})
+	c.Assert(result.Error, gc.ErrorMatches, `offer "offered" requires the consuming networks to be identified`)
+	c.Assert(s.st.offerConnections, gc.HasLen, 0)
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
@@ -388,6 +518,67 @@ func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesRejected(c *g
 	})
 }
 
+func (s *crossmodelRelationsSuite) publishIngressForNetworkRestrictedOffer(c *gc.C, networks ...string) error {
+	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
+	rel := newMockRelation(1)
+	rel.key = "db2:db django:db"
+	s.st.relations["db2:db django:db"] = rel
+	s.st.remoteEntities[names.NewApplicationTag("db2")] = "token-db2"
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"hosted-db2-uuid": {
+			OfferUUID:            "hosted-db2-uuid",
+			OfferName:            "hosted-db2",
+			ApplicationName:      "db2",
+			AllowedConsumerCIDRs: []string{"10.0.0.0/16"},
+		}}
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.PublishIngressNetworkChanges(params.IngressNetworksChanges{
+		Changes: []params.IngressNetworksChangeEvent{
+			{
+				ApplicationToken: "token-db2",
+				RelationToken:    "token-db2:db django:db",
+				Networks:         networks,
+				IngressRequired:  true,
+				Macaroons:        macaroon.Slice{mac},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return results.Combine()
+}
+
+func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesAllowedByOffer(c *gc.C) {
+	err := s.publishIngressForNetworkRestrictedOffer(c, "10.0.1.0/24", "10.0.2.1/32")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.st.ingressNetworks["db2:db django:db"], jc.DeepEquals, []string{"10.0.1.0/24", "10.0.2.1/32"})
+}
+
+func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesNotAllowedByOffer(c *gc.C) {
+	err := s.publishIngressForNetworkRestrictedOffer(c, "10.0.1.0/24", "1.2.3.4/32")
+	c.Assert(err, gc.ErrorMatches, `network "1.2.3.4/32" may not consume offer "hosted-db2"`)
+	c.Assert(s.st.ingressNetworks, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesNoNetworksNotAllowedByOffer(c *gc.C) {
+	err := s.publishIngressForNetworkRestrictedOffer(c)
+	c.Assert(err, gc.ErrorMatches, `offer "hosted-db2" requires the consuming networks to be identified`)
+	c.Assert(s.st.ingressNetworks, gc.HasLen, 0)
+}
+
 func (s *crossmodelRelationsSuite) TestWatchEgressAddressesForRelations(c *gc.C) {
 	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
 	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
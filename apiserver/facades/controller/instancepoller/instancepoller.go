@@ -13,10 +13,23 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
+// InstancePollerAPIV3 provides access to version 3 of the
+// InstancePoller API facade, which lacks Series.
+type InstancePollerAPIV3 struct {
+	*InstancePollerAPIV4
+}
+
+// InstancePollerAPIV4 provides access to version 4 of the
+// InstancePoller API facade, which lacks SetInstanceUtilization.
+type InstancePollerAPIV4 struct {
+	*InstancePollerAPI
+}
+
 // InstancePollerAPI provides access to the InstancePoller API facade.
 type InstancePollerAPI struct {
 	*common.LifeGetter
@@ -45,6 +58,32 @@ func NewFacade(
 	return NewInstancePollerAPI(st, m, resources, authorizer, clock.WallClock)
 }
 
+// NewFacadeV4 wraps NewInstancePollerAPI for version 4 of the facade.
+func NewFacadeV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstancePollerAPIV4, error) {
+	api, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InstancePollerAPIV4{api}, nil
+}
+
+// NewFacadeV3 wraps NewInstancePollerAPI for version 3 of the facade.
+func NewFacadeV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstancePollerAPIV3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InstancePollerAPIV3{api}, nil
+}
+
 // NewInstancePollerAPI creates a new server-side InstancePoller API
 // facade.
 func NewInstancePollerAPI(
@@ -245,3 +284,53 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	}
 	return result, nil
 }
+
+// Series returns the series of each given machine. Only machine tags
+// are accepted.
+func (a *InstancePollerAPI) Series(args params.Entities) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			result.Results[i].Result = machine.Series()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetInstanceUtilization records the resource utilization reported
+// by the provider for each given machine's instance. Only machine
+// tags are accepted.
+func (a *InstancePollerAPI) SetInstanceUtilization(args params.SetMachinesUtilization) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Machines {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			err = machine.SetInstanceUtilization(instance.Utilization{
+				CPUPercent:    arg.Utilization.CPUPercent,
+				MemoryPercent: arg.Utilization.MemoryPercent,
+			})
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// Series isn't on the V3 API.
+func (*InstancePollerAPIV3) Series(_, _ struct{}) {}
+
+// SetInstanceUtilization isn't on the V4 API.
+func (*InstancePollerAPIV4) SetInstanceUtilization(_, _ struct{}) {}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -709,6 +710,53 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckFindEntityCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestSeries(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1", series: "win2016"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", series: "bionic"})
+
+	result, err := s.api.Series(s.mixedEntities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "win2016"},
+			{Result: "bionic"},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ServerError(`"application-unknown" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"invalid-tag" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"unit-missing-1" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"42" is not a valid tag`)},
+		}},
+	)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "Series")
+	s.st.CheckFindEntityCall(c, 2, "2")
+	s.st.CheckCall(c, 3, "Series")
+	s.st.CheckFindEntityCall(c, 4, "42")
+}
+
+func (s *InstancePollerSuite) TestSetInstanceUtilization(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2"})
+
+	cpu, mem := 25.0, 50.0
+	arg := params.SetMachinesUtilization{}
+	for _, entity := range s.mixedEntities.Entities {
+		arg.Machines = append(arg.Machines, params.SetMachineUtilization{Tag: entity.Tag})
+	}
+	arg.Machines[1].Utilization = params.MachineUtilization{CPUPercent: &cpu, MemoryPercent: &mem}
+	result, err := s.api.SetInstanceUtilization(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.mixedErrorResults)
+
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "SetInstanceUtilization", instance.Utilization{})
+	s.st.CheckFindEntityCall(c, 2, "2")
+	s.st.CheckCall(c, 3, "SetInstanceUtilization", instance.Utilization{CPUPercent: &cpu, MemoryPercent: &mem})
+	s.st.CheckFindEntityCall(c, 4, "42")
+}
+
 func statusInfo(st string) status.StatusInfo {
 	return status.StatusInfo{Status: status.Status(st)}
 }
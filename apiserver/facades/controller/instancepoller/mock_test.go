@@ -212,6 +212,8 @@ type machineInfo struct {
 	providerAddresses []network.Address
 	life              state.Life
 	isManual          bool
+	series            string
+	utilization       instance.Utilization
 }
 
 type mockMachine struct {
@@ -308,6 +310,28 @@ func (m *mockMachine) IsManual() (bool, error) {
 	return m.isManual, m.NextErr()
 }
 
+// Series implements StateMachine.
+func (m *mockMachine) Series() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Series")
+	return m.series
+}
+
+// SetInstanceUtilization implements StateMachine.
+func (m *mockMachine) SetInstanceUtilization(utilization instance.Utilization) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "SetInstanceUtilization", utilization)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.utilization = utilization
+	return nil
+}
+
 // Status implements StateMachine.
 func (m *mockMachine) Status() (status.StatusInfo, error) {
 	m.mu.Lock()
@@ -4,6 +4,7 @@
 package migrationmaster
 
 import (
+	"github.com/juju/description"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
@@ -19,8 +20,10 @@ type Backend interface {
 	ModelUUID() string
 	ModelName() (string, error)
 	ModelOwner() (names.UserTag, error)
+	ModelUsers() ([]names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	ExportPartial(state.ExportConfig) (description.Model, error)
 
 	migration.StateExporter
 }
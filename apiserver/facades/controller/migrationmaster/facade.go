@@ -19,6 +19,7 @@ import (
 	coremigration "github.com/juju/juju/core/migration"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
@@ -102,6 +103,7 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				AuthTag:       target.AuthTag.String(),
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
+				UserMap:       target.UserMap,
 			},
 		},
 		MigrationId:      mig.Id(),
@@ -111,7 +113,8 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 }
 
 // ModelInfo returns essential information about the model to be
-// migrated.
+// migrated. The model's owner and users are reported as they will be
+// known on the target controller.
 func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 	empty := params.MigrationModelInfo{}
 
@@ -125,19 +128,50 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 		return empty, errors.Annotate(err, "retrieving model owner")
 	}
 
+	users, err := api.backend.ModelUsers()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving model users")
+	}
+
 	vers, err := api.backend.AgentVersion()
 	if err != nil {
 		return empty, errors.Annotate(err, "retrieving agent version")
 	}
 
+	userMap, err := api.userMap()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	userTags := make([]string, len(users))
+	for i, user := range users {
+		userTags[i] = coremigration.MapUser(userMap, user).String()
+	}
+
 	return params.MigrationModelInfo{
 		UUID:         api.backend.ModelUUID(),
 		Name:         name,
-		OwnerTag:     owner.String(),
+		OwnerTag:     coremigration.MapUser(userMap, owner).String(),
 		AgentVersion: vers,
+		UserTags:     userTags,
 	}, nil
 }
 
+// userMap returns the user map of the latest migration of the model,
+// if any.
+func (api *API) userMap() (map[string]string, error) {
+	mig, err := api.backend.LatestMigration()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "retrieving model migration")
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving target info")
+	}
+	return target.UserMap, nil
+}
+
 // SetPhase sets the phase of the active model migration. The provided
 // phase must be a valid phase value, for example QUIESCE" or
 // "ABORT". See the core/migration package for the complete list.
@@ -194,7 +228,11 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
 
-	model, err := api.backend.Export()
+	userMap, err := api.userMap()
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
+	model, err := api.backend.ExportPartial(state.ExportConfig{UserMap: userMap})
 	if err != nil {
 		return serialized, err
 	}
@@ -125,6 +125,37 @@ func (s *Suite) TestModelInfo(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "model-name")
 	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("owner").String())
 	c.Assert(model.AgentVersion, gc.Equals, version.MustParse("1.2.3"))
+	c.Assert(model.UserTags, jc.DeepEquals, []string{"user-owner", "user-bob@external"})
+}
+
+func (s *Suite) TestModelInfoUserMap(c *gc.C) {
+	s.backend.migration.userMap = map[string]string{
+		"owner":        "alice",
+		"bob@external": "bob",
+	}
+	api := s.mustMakeAPI(c)
+	model, err := api.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("alice").String())
+	c.Assert(model.UserTags, jc.DeepEquals, []string{"user-alice", "user-bob"})
+}
+
+func (s *Suite) TestMigrationStatusUserMap(c *gc.C) {
+	userMap := map[string]string{"bob@external": "bob"}
+	s.backend.migration.userMap = userMap
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Spec.TargetInfo.UserMap, jc.DeepEquals, userMap)
+}
+
+func (s *Suite) TestExportUserMap(c *gc.C) {
+	userMap := map[string]string{"admin": "alice"}
+	s.backend.migration.userMap = userMap
+	api := s.mustMakeAPI(c)
+	_, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 1, "ExportPartial", state.ExportConfig{UserMap: userMap})
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -478,15 +509,25 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
+func (b *stubBackend) ModelUsers() ([]names.UserTag, error) {
+	return []names.UserTag{names.NewUserTag("owner"), names.NewUserTag("bob@external")}, nil
+}
+
 func (b *stubBackend) Export() (description.Model, error) {
 	b.stub.AddCall("Export")
 	return b.model, nil
 }
 
+func (b *stubBackend) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
+	b.stub.AddCall("ExportPartial", cfg)
+	return b.model, nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
 	stub            *testing.Stub
+	userMap         map[string]string
 	setPhaseErr     error
 	phaseSet        coremigration.Phase
 	setMessageErr   error
@@ -523,6 +564,7 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 		AuthTag:       names.NewUserTag("admin"),
 		Password:      "secret",
 		Macaroons:     []macaroon.Slice{{mac}},
+		UserMap:       m.userMap,
 	}, nil
 }
 
@@ -55,6 +55,23 @@ func (s *backendShim) ModelOwner() (names.UserTag, error) {
 	return model.Owner(), nil
 }
 
+// ModelUsers implements Backend.
+func (s *backendShim) ModelUsers() ([]names.UserTag, error) {
+	model, err := s.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	users, err := model.Users()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]names.UserTag, len(users))
+	for i, user := range users {
+		tags[i] = user.UserTag
+	}
+	return tags, nil
+}
+
 // AgentVersion implements Backend.
 func (s *backendShim) AgentVersion() (version.Number, error) {
 	m, err := s.Model()
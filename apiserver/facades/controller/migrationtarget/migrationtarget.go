@@ -89,6 +89,14 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var users []names.UserTag
+	for _, userTag := range model.UserTags {
+		user, err := names.ParseUserTag(userTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		users = append(users, user)
+	}
 	controllerState := api.pool.SystemState()
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -107,6 +115,7 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 			Owner:                  ownerTag,
 			AgentVersion:           model.AgentVersion,
 			ControllerAgentVersion: model.ControllerAgentVersion,
+			Users:                  users,
 		},
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -34,6 +35,7 @@ type mockState struct {
 	applicationRelationsWatchers map[string]*mockStringsWatcher
 	remoteEntities               map[names.Tag]string
 	controllerInfo               map[string]*mockControllerInfo
+	egressSubnets                string
 }
 
 func newMockState() *mockState {
@@ -49,6 +51,16 @@ func newMockState() *mockState {
 	}
 }
 
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"egress-subnets": st.egressSubnets,
+	}))
+}
+
 func (st *mockState) ControllerConfig() (controller.Config, error) {
 	return nil, errors.NotImplementedf("ControllerConfig")
 }
@@ -23,6 +23,12 @@ type RemoteRelationsAPI struct {
 	authorizer facade.Authorizer
 }
 
+// RemoteRelationsAPIV1 provides access to version 1 of the RemoteRelations
+// API facade, which does not provide the model's egress subnets.
+type RemoteRelationsAPIV1 struct {
+	*RemoteRelationsAPI
+}
+
 // NewStateRemoteRelationsAPI creates a new server-side RemoteRelationsAPI facade
 // backed by global state.
 func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error) {
@@ -34,6 +40,16 @@ func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error)
 
 }
 
+// NewStateRemoteRelationsAPIV1 creates a new server-side RemoteRelationsAPIV1
+// facade backed by global state.
+func NewStateRemoteRelationsAPIV1(ctx facade.Context) (*RemoteRelationsAPIV1, error) {
+	api, err := NewStateRemoteRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationsAPIV1{api}, nil
+}
+
 // NewRemoteRelationsAPI returns a new server-side RemoteRelationsAPI facade.
 func NewRemoteRelationsAPI(
 	st RemoteRelationsState,
@@ -425,3 +441,16 @@ func (f *RemoteRelationsAPI) SetRemoteApplicationsStatus(args params.SetStatus)
 	}
 	return result, nil
 }
+
+// ModelEgressSubnets returns the egress subnets configured for the model,
+// which identify the networks from which the model consumes offers.
+func (api *RemoteRelationsAPI) ModelEgressSubnets() (params.StringsResult, error) {
+	cfg, err := api.st.ModelConfig()
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringsResult{Result: cfg.EgressSubnets()}, nil
+}
+
+// ModelEgressSubnets isn't on the V1 API.
+func (*RemoteRelationsAPIV1) ModelEgressSubnets(_, _ struct{}) {}
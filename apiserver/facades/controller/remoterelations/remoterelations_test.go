@@ -444,3 +444,19 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationsStatusTerminated(c *gc.C
 	s.st.CheckCallNames(c, "RemoteApplication", "ApplyOperation")
 	s.st.CheckCall(c, 1, "ApplyOperation", &mockOperation{message: "killer whales"})
 }
+
+func (s *remoteRelationsSuite) TestModelEgressSubnets(c *gc.C) {
+	s.st.egressSubnets = "10.0.0.0/16, 192.168.1.0/24"
+	result, err := s.api.ModelEgressSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, jc.DeepEquals, []string{"10.0.0.0/16", "192.168.1.0/24"})
+	s.st.CheckCallNames(c, "ModelConfig")
+}
+
+func (s *remoteRelationsSuite) TestModelEgressSubnetsNotSet(c *gc.C) {
+	result, err := s.api.ModelEgressSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.HasLen, 0)
+}
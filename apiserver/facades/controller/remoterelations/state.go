@@ -9,6 +9,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	common "github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -35,6 +36,9 @@ type RemoteRelationsState interface {
 
 	// SaveMacaroon saves the given macaroon for the specified entity.
 	SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
+
+	// ModelConfig returns the config of the model.
+	ModelConfig() (*config.Config, error)
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -74,3 +78,11 @@ func (st stateShim) WatchRemoteApplicationRelations(applicationName string) (sta
 	}
 	return a.WatchRelations(), nil
 }
+
+func (st stateShim) ModelConfig() (*config.Config, error) {
+	m, err := st.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.ModelConfig()
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Pressure describes how close the controller's log storage is to
+// being exhausted, and so which log records the logsink handler
+// admits.
+type Pressure int
+
+const (
+	// PressureNone means that all log records are admitted.
+	PressureNone Pressure = iota
+
+	// PressureModerate means that only one in every downsampleRate
+	// DEBUG and TRACE records is admitted.
+	PressureModerate
+
+	// PressureHigh means that no DEBUG or TRACE records are admitted.
+	PressureHigh
+)
+
+// String is part of fmt.Stringer.
+func (p Pressure) String() string {
+	switch p {
+	case PressureNone:
+		return "none"
+	case PressureModerate:
+		return "moderate"
+	case PressureHigh:
+		return "high"
+	}
+	return fmt.Sprintf("Pressure(%d)", int(p))
+}
+
+// HealthProvider reports on the health of the controller's log
+// storage.
+type HealthProvider interface {
+	// LogStoragePressure returns the current pressure on the
+	// controller's log storage, and a description of its cause.
+	// It is called for each low-level record received, so
+	// implementations should cache the results of expensive checks.
+	LogStoragePressure() (Pressure, string)
+}
+
+// AdmissionConfig contains the configuration the logsink handler
+// uses to shed low-level log records while the controller's log
+// storage is under pressure.
+type AdmissionConfig struct {
+	// Health reports the pressure on the controller's log storage.
+	Health HealthProvider
+
+	// Clock is used to time the warning records which report how
+	// many log records have been shed.
+	Clock clock.Clock
+}
+
+const (
+	// downsampleRate is the ratio of DEBUG and TRACE records
+	// received to those admitted under moderate pressure.
+	downsampleRate = 10
+
+	// shedWarningInterval is the least time between the warning
+	// records a connection writes to report shed log records.
+	shedWarningInterval = time.Minute
+
+	// shedWarningModule is the module of the warning records.
+	shedWarningModule = "juju.apiserver.logsink"
+)
+
+// newAdmitter returns an admitter for a single connection, or nil
+// if the handler has no admission control.
+func (config *AdmissionConfig) newAdmitter() *logAdmitter {
+	if config == nil || config.Health == nil {
+		return nil
+	}
+	return &logAdmitter{config: *config}
+}
+
+// logAdmitter decides which of the log records received on a single
+// connection are written. It is not safe for concurrent use.
+type logAdmitter struct {
+	config AdmissionConfig
+
+	// sampled counts the low-level records received under moderate
+	// pressure, so that one in every downsampleRate is admitted.
+	sampled int
+
+	// shed counts the records shed since the last warning record.
+	shed int
+
+	lastWarning time.Time
+}
+
+// admit reports whether the given log record should be written. When
+// the record is shed, admit may also return a warning record reporting
+// the records shed so far, which should be written in its place.
+func (a *logAdmitter) admit(m params.LogRecord) (bool, *params.LogRecord) {
+	if level, ok := loggo.ParseLevel(m.Level); !ok || level > loggo.DEBUG {
+		// Records at INFO and above, or of unknown level, are
+		// always admitted.
+		return true, nil
+	}
+	pressure, reason := a.config.Health.LogStoragePressure()
+	switch pressure {
+	case PressureNone:
+		a.sampled = 0
+		return true, nil
+	case PressureModerate:
+		a.sampled++
+		if a.sampled%downsampleRate == 1 {
+			return true, nil
+		}
+	}
+	a.shed++
+	now := a.config.Clock.Now()
+	if !a.lastWarning.IsZero() && now.Sub(a.lastWarning) < shedWarningInterval {
+		return false, nil
+	}
+	warning := &params.LogRecord{
+		Time:   now.UTC(),
+		Module: shedWarningModule,
+		Level:  loggo.WARNING.String(),
+		Message: fmt.Sprintf(
+			"%s pressure on controller log storage (%s): dropped %d DEBUG and TRACE records",
+			pressure, reason, a.shed,
+		),
+	}
+	a.shed = 0
+	a.lastWarning = now
+	return false, warning
+}
@@ -12,15 +12,23 @@ import (
 func NewHTTPHandlerForTest(
 	newLogWriteCloser NewLogWriteCloserFunc,
 	abort <-chan struct{},
-	ratelimit *RateLimitConfig,
+	ratelimit *RateLimiter,
+	keepalive *KeepaliveConfig,
+	admission *AdmissionConfig,
 	metrics MetricsCollector,
 	modelUUID string,
 	makeChannel func() (chan struct{}, func()),
 ) http.Handler {
+	if keepalive == nil {
+		defaultKeepalive := DefaultKeepaliveConfig()
+		keepalive = &defaultKeepalive
+	}
 	return &logSinkHandler{
 		newLogWriteCloser: newLogWriteCloser,
 		abort:             abort,
 		ratelimit:         ratelimit,
+		keepalive:         *keepalive,
+		admission:         admission,
 		newStopChannel:    makeChannel,
 		metrics:           metrics,
 		modelUUID:         modelUUID,
@@ -13,7 +13,6 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
-	"github.com/juju/ratelimit"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/version"
 	"github.com/prometheus/client_golang/prometheus"
@@ -64,6 +63,48 @@ type RateLimitConfig struct {
 	Clock clock.Clock
 }
 
+// KeepaliveConfig contains the configuration the logsink handler uses
+// to notice connections whose other end has gone away.
+type KeepaliveConfig struct {
+	// PingPeriod is how often ping messages are sent to agents
+	// that respond to them.
+	PingPeriod time.Duration
+
+	// PongDelay is how long to wait for a pong before the
+	// connection is considered dead. It should be longer than
+	// PingPeriod, to give the agent time to respond.
+	PongDelay time.Duration
+
+	// IdleTimeout is how long a connection may go without sending
+	// a log message before it is closed. If it is zero, connections
+	// are never closed for being idle.
+	IdleTimeout time.Duration
+}
+
+// DefaultKeepaliveConfig returns the KeepaliveConfig used when the
+// handler is not given one. Idle connections are not closed by
+// default, since a healthy agent may log nothing for long periods.
+func DefaultKeepaliveConfig() KeepaliveConfig {
+	return KeepaliveConfig{
+		PingPeriod: websocket.PingPeriod,
+		PongDelay:  websocket.PongDelay,
+	}
+}
+
+// Validate validates the keepalive configuration.
+func (config KeepaliveConfig) Validate() error {
+	if config.PingPeriod <= 0 {
+		return errors.NotValidf("PingPeriod %s <= 0", config.PingPeriod)
+	}
+	if config.PongDelay <= config.PingPeriod {
+		return errors.NotValidf("PongDelay %s <= PingPeriod %s", config.PongDelay, config.PingPeriod)
+	}
+	if config.IdleTimeout < 0 {
+		return errors.NotValidf("IdleTimeout %s < 0", config.IdleTimeout)
+	}
+	return nil
+}
+
 // CounterVec is a Collector that bundles a set of Counters that all share the
 // same description.
 type CounterVec interface {
@@ -108,25 +149,45 @@ type MetricsCollector interface {
 	// the log that happened. It's split on the success/error/disconnect, so
 	// the charts will have to take that into account.
 	LogReadCount(modelUUID, state string) prometheus.Counter
+
+	// LogShedCount returns a prometheus metric for the number of log
+	// records dropped, rather than written, because the controller's
+	// log storage was under pressure.
+	LogShedCount(modelUUID string) prometheus.Counter
 }
 
 // NewHTTPHandler returns a new http.Handler for receiving log messages over a
 // websocket, using the given NewLogWriteCloserFunc to obtain a writer to which
 // the log messages will be written.
 //
-// ratelimit defines an optional rate-limit configuration. If nil, no rate-
-// limiting will be applied.
+// ratelimit defines an optional rate limiter, whose configuration may be
+// updated while the handler is running. If nil, no rate-limiting will be
+// applied.
+//
+// keepalive defines how the handler notices connections whose other end has
+// gone away. If nil, DefaultKeepaliveConfig() will be used.
+//
+// admission defines how the handler sheds DEBUG and TRACE records while the
+// controller's log storage is under pressure. If nil, all records are written.
 func NewHTTPHandler(
 	newLogWriteCloser NewLogWriteCloserFunc,
 	abort <-chan struct{},
-	ratelimit *RateLimitConfig,
+	ratelimit *RateLimiter,
+	keepalive *KeepaliveConfig,
+	admission *AdmissionConfig,
 	metrics MetricsCollector,
 	modelUUID string,
 ) http.Handler {
+	if keepalive == nil {
+		defaultKeepalive := DefaultKeepaliveConfig()
+		keepalive = &defaultKeepalive
+	}
 	return &logSinkHandler{
 		newLogWriteCloser: newLogWriteCloser,
 		abort:             abort,
 		ratelimit:         ratelimit,
+		keepalive:         *keepalive,
+		admission:         admission,
 		newStopChannel: func() (chan struct{}, func()) {
 			ch := make(chan struct{})
 			return ch, func() { close(ch) }
@@ -139,7 +200,9 @@ func NewHTTPHandler(
 type logSinkHandler struct {
 	newLogWriteCloser NewLogWriteCloserFunc
 	abort             <-chan struct{}
-	ratelimit         *RateLimitConfig
+	ratelimit         *RateLimiter
+	keepalive         KeepaliveConfig
+	admission         *AdmissionConfig
 	metrics           MetricsCollector
 	modelUUID         string
 	mu                sync.Mutex
@@ -166,6 +229,12 @@ type logSinkHandler struct {
 //
 // Now, in theory, we should be using this ping/pong across all the websockets,
 // but that is a little outside the scope of this piece of work.
+//
+// Pings only tell us that the other end is alive, not that it is doing
+// anything. Connections that send no log messages for the configured idle
+// timeout are closed as well, so that a connection the agent has abandoned
+// without closing doesn't hold on to its goroutines until the controller
+// restarts.
 
 const (
 	// For endpoints that don't support ping/pong (i.e. agents prior to 2.2-beta1)
@@ -210,19 +279,28 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		// respond to ping control messages, so don't try.
 		var tickChannel <-chan time.Time
 		if endpointVersion > 0 {
-			socket.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+			socket.SetReadDeadline(time.Now().Add(h.keepalive.PongDelay))
 			socket.SetPongHandler(func(string) error {
 				logger.Tracef("pong logsink %p", socket)
-				socket.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+				socket.SetReadDeadline(time.Now().Add(h.keepalive.PongDelay))
 				return nil
 			})
-			ticker := time.NewTicker(websocket.PingPeriod)
+			ticker := time.NewTicker(h.keepalive.PingPeriod)
 			defer ticker.Stop()
 			tickChannel = ticker.C
 		} else {
 			socket.SetReadDeadline(time.Now().Add(vZeroDelay))
 		}
 
+		var idleTimer *time.Timer
+		var idleChannel <-chan time.Time
+		if h.keepalive.IdleTimeout > 0 {
+			idleTimer = time.NewTimer(h.keepalive.IdleTimeout)
+			defer idleTimer.Stop()
+			idleChannel = idleTimer.C
+		}
+
+		admitter := h.admission.newAdmitter()
 		stopReceiving, closer := h.newStopChannel()
 		defer closer()
 		logCh := h.receiveLogs(socket, endpointVersion, resolvedModelUUID, stopReceiving)
@@ -230,6 +308,9 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			select {
 			case <-h.abort:
 				return
+			case <-idleChannel:
+				logger.Debugf("closing logsink %p, idle for %s", socket, h.keepalive.IdleTimeout)
+				return
 			case <-tickChannel:
 				deadline := time.Now().Add(websocket.WriteWait)
 				logger.Tracef("ping logsink %p", socket)
@@ -250,6 +331,25 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 					return
 				}
 
+				// The connection isn't idle, even if the record is shed.
+				if idleTimer != nil {
+					if !idleTimer.Stop() {
+						<-idleTimer.C
+					}
+					idleTimer.Reset(h.keepalive.IdleTimeout)
+				}
+
+				if admitter != nil {
+					admit, warning := admitter.admit(m)
+					if !admit {
+						h.metrics.LogShedCount(resolvedModelUUID).Inc()
+						if warning == nil {
+							continue
+						}
+						m = *warning
+					}
+				}
+
 				if err := writer.WriteLog(m); err != nil {
 					h.sendError(socket, req, err)
 					// Increment the number of failure cases per modelUUID, that
@@ -289,13 +389,9 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn,
 ) <-chan params.LogRecord {
 	logCh := make(chan params.LogRecord)
 
-	var tokenBucket *ratelimit.Bucket
+	var tokenBucket *connectionBucket
 	if h.ratelimit != nil {
-		tokenBucket = ratelimit.NewBucketWithClock(
-			h.ratelimit.Refill,
-			h.ratelimit.Burst,
-			ratelimitClock{h.ratelimit.Clock},
-		)
+		tokenBucket = h.ratelimit.newBucket()
 	}
 
 	go func() {
@@ -329,9 +425,9 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn,
 			// each connection individually to prevent one noisy
 			// individual from drowning out the others.
 			if tokenBucket != nil {
-				if d := tokenBucket.Take(1); d > 0 {
+				if wait := tokenBucket.wait(); wait != nil {
 					select {
-					case <-h.ratelimit.Clock.After(d):
+					case <-wait:
 					case <-h.abort:
 						return
 					}
@@ -5,6 +5,7 @@ package logsink_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -205,11 +206,13 @@ func (s *logsinkSuite) TestRateLimit(c *gc.C) {
 			}, s.stub.NextErr()
 		},
 		s.abort,
-		&logsink.RateLimitConfig{
+		logsink.NewRateLimiter(logsink.RateLimitConfig{
 			Burst:  2,
 			Refill: time.Second,
 			Clock:  testClock,
-		},
+		}),
+		nil,
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 	))
@@ -260,6 +263,208 @@ func (s *logsinkSuite) TestRateLimit(c *gc.C) {
 	expectNoRecord()
 }
 
+func (s *logsinkSuite) TestRateLimitUpdated(c *gc.C) {
+	modelUUID, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+
+	metricsCollector, finish := createMockMetrics(c, modelUUID.String())
+	defer finish()
+
+	testClock := testclock.NewClock(time.Time{})
+	limiter := logsink.NewRateLimiter(logsink.RateLimitConfig{
+		Burst:  1,
+		Refill: time.Hour,
+		Clock:  testClock,
+	})
+	srv := httptest.NewServer(logsink.NewHTTPHandler(
+		func(req *http.Request) (logsink.LogWriteCloser, error) {
+			s.stub.AddCall("Open")
+			return &mockLogWriteCloser{
+				s.stub,
+				s.written,
+				nil,
+			}, s.stub.NextErr()
+		},
+		s.abort,
+		limiter,
+		nil,
+		nil,
+		metricsCollector,
+		modelUUID.String(),
+	))
+	defer srv.Close()
+
+	conn := s.dialWebsocket(c, srv)
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO.String(),
+		Message:  "all is well",
+	}
+	for i := 0; i < 5; i++ {
+		err := conn.WriteJSON(&record)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	expectRecords := func(n int) {
+		for i := 0; i < n; i++ {
+			select {
+			case written, ok := <-s.written:
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(written, jc.DeepEquals, record)
+			case <-time.After(coretesting.LongWait):
+				c.Fatal("timed out waiting for log record to be written")
+			}
+		}
+		select {
+		case <-s.written:
+			c.Fatal("unexpected log record")
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+
+	// The second record waits for the hour-long refill.
+	expectRecords(1)
+	c.Assert(limiter.Update(3, time.Second), jc.IsTrue)
+	c.Assert(limiter.Update(3, time.Second), jc.IsFalse)
+	c.Assert(limiter.Config().Burst, gc.Equals, int64(3))
+
+	// Once the record being throttled is let through, the
+	// connection uses the new limits for the records after it.
+	// The bucket was empty, so the new burst is not available
+	// straight away; the records are let through at the new
+	// refill rate.
+	testClock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	expectRecords(1)
+	for i := 0; i < 3; i++ {
+		testClock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		expectRecords(1)
+	}
+}
+
+func (s *logsinkSuite) createAdmissionServer(
+	c *gc.C, pressure logsink.Pressure, shed int,
+) (*httptest.Server, *testclock.Clock, func()) {
+	modelUUID, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+
+	metricsCollector, finish := createMockMetrics(c, modelUUID.String())
+	ctrl := gomock.NewController(c)
+	shedCounter := mocks.NewMockCounter(ctrl)
+	shedCounter.EXPECT().Inc().Times(shed)
+	metricsCollector.EXPECT().LogShedCount(modelUUID.String()).Return(shedCounter).AnyTimes()
+
+	testClock := testclock.NewClock(time.Time{})
+	srv := httptest.NewServer(logsink.NewHTTPHandler(
+		func(req *http.Request) (logsink.LogWriteCloser, error) {
+			s.stub.AddCall("Open")
+			return &mockLogWriteCloser{
+				s.stub,
+				s.written,
+				nil,
+			}, s.stub.NextErr()
+		},
+		s.abort,
+		nil,
+		nil,
+		&logsink.AdmissionConfig{
+			Health: fakeHealthProvider{pressure, "disk full"},
+			Clock:  testClock,
+		},
+		metricsCollector,
+		modelUUID.String(),
+	))
+	return srv, testClock, func() {
+		srv.Close()
+		ctrl.Finish()
+		finish()
+	}
+}
+
+func (s *logsinkSuite) expectRecord(c *gc.C) params.LogRecord {
+	select {
+	case written, ok := <-s.written:
+		c.Assert(ok, jc.IsTrue)
+		return written
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for log record to be written")
+	}
+	panic("unreachable")
+}
+
+func (s *logsinkSuite) TestAdmissionHighPressure(c *gc.C) {
+	srv, testClock, finish := s.createAdmissionServer(c, logsink.PressureHigh, 3)
+	defer finish()
+
+	conn := s.dialWebsocket(c, srv)
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.DEBUG.String(),
+		Message:  "all is well",
+	}
+	info := record
+	info.Level = loggo.INFO.String()
+	for _, r := range []params.LogRecord{record, record, info} {
+		err := conn.WriteJSON(&r)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The first record shed is replaced by a warning, but the
+	// second is dropped silently.
+	c.Assert(s.expectRecord(c), jc.DeepEquals, params.LogRecord{
+		Time:    testClock.Now().UTC(),
+		Module:  "juju.apiserver.logsink",
+		Level:   loggo.WARNING.String(),
+		Message: "high pressure on controller log storage (disk full): dropped 1 DEBUG and TRACE records",
+	})
+	c.Assert(s.expectRecord(c), jc.DeepEquals, info)
+
+	// Once the warning interval has passed, the next record
+	// shed is replaced by a warning counting those dropped.
+	testClock.Advance(time.Minute)
+	err := conn.WriteJSON(&record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.expectRecord(c).Message, gc.Equals,
+		"high pressure on controller log storage (disk full): dropped 2 DEBUG and TRACE records")
+}
+
+func (s *logsinkSuite) TestAdmissionModeratePressure(c *gc.C) {
+	srv, _, finish := s.createAdmissionServer(c, logsink.PressureModerate, 9)
+	defer finish()
+
+	conn := s.dialWebsocket(c, srv)
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.TRACE.String(),
+	}
+	for i := 0; i < 11; i++ {
+		record.Message = fmt.Sprint(i)
+		err := conn.WriteJSON(&record)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// One in every ten records is written.
+	c.Assert(s.expectRecord(c).Message, gc.Equals, "0")
+	c.Assert(s.expectRecord(c).Level, gc.Equals, loggo.WARNING.String())
+	c.Assert(s.expectRecord(c).Message, gc.Equals, "10")
+	select {
+	case <-s.written:
+		c.Fatal("unexpected log record")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *logsinkSuite) TestReceiverStopsWhenAsked(c *gc.C) {
 	myStopCh := make(chan struct{})
 
@@ -276,6 +481,8 @@ func (s *logsinkSuite) TestReceiverStopsWhenAsked(c *gc.C) {
 		},
 		s.abort,
 		nil,
+		nil,
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 		func() (chan struct{}, func()) {
@@ -335,6 +542,8 @@ func (s *logsinkSuite) TestHandlerClosesStopChannel(c *gc.C) {
 		},
 		s.abort,
 		nil,
+		nil,
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 		func() (chan struct{}, func()) {
@@ -379,6 +588,89 @@ func (s *logsinkSuite) TestHandlerClosesStopChannel(c *gc.C) {
 	stub.CheckCallNames(c, "close stop channel")
 }
 
+func (s *logsinkSuite) TestIdleConnectionClosed(c *gc.C) {
+	modelUUID, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+
+	metricsCollector, finish := createMockMetrics(c, modelUUID.String())
+	defer finish()
+
+	var stub testing.Stub
+	keepalive := logsink.DefaultKeepaliveConfig()
+	keepalive.IdleTimeout = 100 * time.Millisecond
+	handler := logsink.NewHTTPHandlerForTest(
+		func(req *http.Request) (logsink.LogWriteCloser, error) {
+			return &mockLogWriteCloser{
+				s.stub,
+				s.written,
+				nil,
+			}, s.stub.NextErr()
+		},
+		s.abort,
+		nil,
+		&keepalive,
+		nil,
+		metricsCollector,
+		modelUUID.String(),
+		func() (chan struct{}, func()) {
+			ch := make(chan struct{})
+			return ch, func() {
+				stub.AddCall("close stop channel")
+				close(ch)
+			}
+		},
+	)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	conn := s.dialWebsocket(c, srv)
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO.String(),
+		Message:  "all is well",
+	}
+	err = conn.WriteJSON(&record)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case written, ok := <-s.written:
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(written, jc.DeepEquals, record)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for log record to be written")
+	}
+
+	// Nothing more is sent, so the handler gives up on the
+	// connection and releases the receiver.
+	websockettest.AssertWebsocketClosed(c, conn)
+	for a := longAttempt.Start(); a.Next(); {
+		if len(stub.Calls()) == 1 {
+			break
+		}
+	}
+	stub.CheckCallNames(c, "close stop channel")
+}
+
+func (s *logsinkSuite) TestKeepaliveConfigValidate(c *gc.C) {
+	config := logsink.DefaultKeepaliveConfig()
+	c.Assert(config.Validate(), jc.ErrorIsNil)
+	c.Assert(config.IdleTimeout, gc.Equals, time.Duration(0))
+
+	config.IdleTimeout = time.Hour
+	c.Assert(config.Validate(), jc.ErrorIsNil)
+
+	config.IdleTimeout = -time.Second
+	c.Assert(config.Validate(), gc.ErrorMatches, "IdleTimeout -1s < 0 not valid")
+
+	config.PongDelay = config.PingPeriod
+	c.Assert(config.Validate(), gc.ErrorMatches, "PongDelay 1m0s <= PingPeriod 1m0s not valid")
+
+	config.PingPeriod = 0
+	c.Assert(config.Validate(), gc.ErrorMatches, "PingPeriod 0s <= 0 not valid")
+}
+
 func (s *logsinkSuite) createServer(c *gc.C) (*httptest.Server, func()) {
 	recordStack := func() {
 		s.stackMu.Lock()
@@ -402,6 +694,8 @@ func (s *logsinkSuite) createServer(c *gc.C) (*httptest.Server, func()) {
 		},
 		s.abort,
 		nil, // no rate-limiting
+		nil, // default keepalive
+		nil, // no admission control
 		metricsCollector,
 		modelUUID.String(),
 	))
@@ -431,6 +725,15 @@ func (m *mockLogWriteCloser) WriteLog(r params.LogRecord) error {
 	return m.NextErr()
 }
 
+type fakeHealthProvider struct {
+	pressure logsink.Pressure
+	reason   string
+}
+
+func (f fakeHealthProvider) LogStoragePressure() (logsink.Pressure, string) {
+	return f.pressure, f.reason
+}
+
 type slowWriteCloser struct{}
 
 func (slowWriteCloser) Close() error {
@@ -57,6 +57,18 @@ func (mr *MockMetricsCollectorMockRecorder) LogReadCount(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogReadCount", reflect.TypeOf((*MockMetricsCollector)(nil).LogReadCount), arg0, arg1)
 }
 
+// LogShedCount mocks base method
+func (m *MockMetricsCollector) LogShedCount(arg0 string) prometheus.Counter {
+	ret := m.ctrl.Call(m, "LogShedCount", arg0)
+	ret0, _ := ret[0].(prometheus.Counter)
+	return ret0
+}
+
+// LogShedCount indicates an expected call of LogShedCount
+func (mr *MockMetricsCollectorMockRecorder) LogShedCount(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogShedCount", reflect.TypeOf((*MockMetricsCollector)(nil).LogShedCount), arg0)
+}
+
 // LogWriteCount mocks base method
 func (m *MockMetricsCollector) LogWriteCount(arg0, arg1 string) prometheus.Counter {
 	ret := m.ctrl.Call(m, "LogWriteCount", arg0, arg1)
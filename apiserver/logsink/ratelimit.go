@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/ratelimit"
+)
+
+// RateLimiter holds the rate-limit configuration shared by the
+// connections of logsink handlers. The burst and refill may be
+// updated while connections are being served; each connection
+// starts using the new values before it next receives a message.
+type RateLimiter struct {
+	mu      sync.Mutex
+	config  RateLimitConfig
+	version int
+}
+
+// NewRateLimiter returns a new RateLimiter with the given
+// initial configuration.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{config: config}
+}
+
+// Config returns the current rate-limit configuration.
+func (r *RateLimiter) Config() RateLimitConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.config
+}
+
+// Update changes the burst and refill used to rate-limit log
+// messages, reporting whether they were different from before.
+func (r *RateLimiter) Update(burst int64, refill time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.config.Burst == burst && r.config.Refill == refill {
+		return false
+	}
+	r.config.Burst = burst
+	r.config.Refill = refill
+	r.version++
+	return true
+}
+
+// newBucket returns a token bucket for a single connection.
+func (r *RateLimiter) newBucket() *connectionBucket {
+	b := &connectionBucket{limiter: r}
+	b.refresh()
+	return b
+}
+
+// connectionBucket rate-limits the messages received on a
+// single connection. It is not safe for concurrent use.
+type connectionBucket struct {
+	limiter *RateLimiter
+	version int
+	config  RateLimitConfig
+	bucket  *ratelimit.Bucket
+}
+
+// refresh replaces the token bucket if the limiter's configuration
+// has changed since it was created. The tokens remaining in the old
+// bucket are carried over to the new one, up to its burst, so that
+// an update to the limits doesn't let a throttled connection burst
+// again.
+func (b *connectionBucket) refresh() {
+	b.limiter.mu.Lock()
+	defer b.limiter.mu.Unlock()
+	if b.bucket != nil && b.version == b.limiter.version {
+		return
+	}
+	config := b.limiter.config
+	bucket := ratelimit.NewBucketWithClock(
+		config.Refill,
+		config.Burst,
+		ratelimitClock{config.Clock},
+	)
+	if b.bucket != nil {
+		remaining := b.bucket.TakeAvailable(b.config.Burst)
+		if remaining < config.Burst {
+			bucket.TakeAvailable(config.Burst - remaining)
+		}
+	}
+	b.version = b.limiter.version
+	b.config = config
+	b.bucket = bucket
+}
+
+// wait returns a channel that is ready when the next message may
+// be received, or nil if it may be received immediately.
+func (b *connectionBucket) wait() <-chan time.Time {
+	b.refresh()
+	if d := b.bucket.Take(1); d > 0 {
+		return b.config.Clock.After(d)
+	}
+	return nil
+}
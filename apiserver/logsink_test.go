@@ -223,6 +223,19 @@ func (s *logsinkSuite) TestNewServerValidatesLogSinkConfig(c *gc.C) {
 	cfg.LogSinkConfig.RateLimitBurst = 1000
 	_, err = apiserver.NewServer(cfg)
 	c.Assert(err, gc.ErrorMatches, "validating logsink configuration: RateLimitRefill 0s <= 0 not valid")
+
+	cfg.LogSinkConfig.RateLimitRefill = time.Millisecond
+	_, err = apiserver.NewServer(cfg)
+	c.Assert(err, gc.ErrorMatches, "validating logsink configuration: PingPeriod 0s <= 0 not valid")
+
+	cfg.LogSinkConfig.PingPeriod = time.Minute
+	_, err = apiserver.NewServer(cfg)
+	c.Assert(err, gc.ErrorMatches, "validating logsink configuration: PongDelay 0s <= PingPeriod 1m0s not valid")
+
+	cfg.LogSinkConfig.PongDelay = 90 * time.Second
+	cfg.LogSinkConfig.IdleTimeout = -time.Second
+	_, err = apiserver.NewServer(cfg)
+	c.Assert(err, gc.ErrorMatches, "validating logsink configuration: IdleTimeout -1s < 0 not valid")
 }
 
 func (s *logsinkSuite) dialWebsocket(c *gc.C) *websocket.Conn {
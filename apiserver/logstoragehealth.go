@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/logsink"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// logStorageCheckInterval is how often the controller's log
+	// storage is checked.
+	logStorageCheckInterval = 30 * time.Second
+
+	// The percentages of the mongo volume in use above which
+	// log storage is under moderate and high pressure.
+	diskModeratePercent = 90
+	diskHighPercent     = 95
+
+	// The percentages of the controller's max-logs-size the logs
+	// database may grow to, between prunings, above which log
+	// storage is under moderate and high pressure.
+	logsModeratePercent = 150
+	logsHighPercent     = 200
+)
+
+// logStorageHealth reports the pressure on the controller's log
+// storage to the logsink handlers, by checking the space left on
+// the volume holding the mongo database and the size of the logs
+// database. The checks are made periodically in the background by
+// loop, so that reporting the pressure never waits for them.
+type logStorageHealth struct {
+	clock  clock.Clock
+	logger loggo.Logger
+
+	// volumeUsage returns the used and total bytes on the mongo
+	// volume.
+	volumeUsage func() (used, total uint64, err error)
+
+	// logsSize returns the size of the logs database, and the
+	// size to which it is pruned, in MB.
+	logsSize func() (size, max int, err error)
+
+	// current holds the logStoragePressure found by the most
+	// recent check.
+	current atomic.Value
+}
+
+// logStoragePressure is the result of a check on log storage.
+type logStoragePressure struct {
+	pressure logsink.Pressure
+	reason   string
+}
+
+func newLogStorageHealth(st *state.State, dataDir string, clock clock.Clock, logger loggo.Logger) *logStorageHealth {
+	dbDir := filepath.Join(dataDir, "db")
+	return &logStorageHealth{
+		clock:  clock,
+		logger: logger,
+		volumeUsage: func() (uint64, uint64, error) {
+			return volumeUsage(dbDir)
+		},
+		logsSize: func() (int, int, error) {
+			controllerConfig, err := st.ControllerConfig()
+			if err != nil {
+				return 0, 0, errors.Trace(err)
+			}
+			size, err := state.LogsSizeMB(st)
+			if err != nil {
+				return 0, 0, errors.Trace(err)
+			}
+			return size, controllerConfig.MaxLogSizeMB(), nil
+		},
+	}
+}
+
+// LogStoragePressure is part of the logsink.HealthProvider interface.
+// There is no pressure until log storage has first been checked.
+func (h *logStorageHealth) LogStoragePressure() (logsink.Pressure, string) {
+	current, _ := h.current.Load().(logStoragePressure)
+	return current.pressure, current.reason
+}
+
+// loop checks the log storage every logStorageCheckInterval until
+// the stop channel is closed.
+func (h *logStorageHealth) loop(stop <-chan struct{}) {
+	for {
+		pressure, reason := h.check()
+		h.current.Store(logStoragePressure{pressure: pressure, reason: reason})
+		select {
+		case <-stop:
+			return
+		case <-h.clock.After(logStorageCheckInterval):
+		}
+	}
+}
+
+// check returns the greater of the pressures on the mongo volume and
+// the logs database. A check which fails is logged and ignored, so
+// that records aren't shed without a known cause.
+func (h *logStorageHealth) check() (logsink.Pressure, string) {
+	pressure, reason := logsink.PressureNone, ""
+	if used, total, err := h.volumeUsage(); err != nil {
+		h.logger.Debugf("cannot get mongo volume usage: %v", err)
+	} else if total > 0 {
+		percent := int(used * 100 / total)
+		p := percentPressure(percent, diskModeratePercent, diskHighPercent)
+		if p > pressure {
+			pressure = p
+			reason = fmt.Sprintf("mongo volume %d%% full", percent)
+		}
+	}
+	if size, max, err := h.logsSize(); err != nil {
+		h.logger.Warningf("cannot get logs database size: %v", err)
+	} else if max > 0 {
+		p := percentPressure(size*100/max, logsModeratePercent, logsHighPercent)
+		if p > pressure {
+			pressure = p
+			reason = fmt.Sprintf("logs database %d MB exceeds max-logs-size %d MB", size, max)
+		}
+	}
+	if previous, _ := h.LogStoragePressure(); pressure != previous {
+		if pressure == logsink.PressureNone {
+			h.logger.Infof("log storage pressure relieved, accepting all log records")
+		} else {
+			h.logger.Warningf("%s log storage pressure: %s", pressure, reason)
+		}
+	}
+	return pressure, reason
+}
+
+func percentPressure(percent, moderate, high int) logsink.Pressure {
+	switch {
+	case percent >= high:
+		return logsink.PressureHigh
+	case percent >= moderate:
+		return logsink.PressureModerate
+	}
+	return logsink.PressureNone
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/logsink"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type logStorageHealthSuite struct {
+	testing.IsolationSuite
+
+	clock     *testclock.Clock
+	used      uint64
+	volumeErr error
+	logsMB    int
+	checks    int
+	health    *logStorageHealth
+}
+
+var _ = gc.Suite(&logStorageHealthSuite{})
+
+func (s *logStorageHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.used = 50
+	s.volumeErr = nil
+	s.logsMB = 100
+	s.checks = 0
+	s.health = &logStorageHealth{
+		clock:  s.clock,
+		logger: loggo.GetLogger("test"),
+		volumeUsage: func() (uint64, uint64, error) {
+			s.checks++
+			return s.used, 100, s.volumeErr
+		},
+		logsSize: func() (int, int, error) {
+			return s.logsMB, 100, nil
+		},
+	}
+}
+
+// assertPressure checks the log storage, and then the pressure
+// reported for it.
+func (s *logStorageHealthSuite) assertPressure(c *gc.C, expect logsink.Pressure, reason string) {
+	checked, checkedReason := s.health.check()
+	s.health.current.Store(logStoragePressure{pressure: checked, reason: checkedReason})
+	pressure, actual := s.health.LogStoragePressure()
+	c.Check(pressure, gc.Equals, expect)
+	c.Check(actual, gc.Equals, reason)
+}
+
+func (s *logStorageHealthSuite) TestNoPressure(c *gc.C) {
+	s.assertPressure(c, logsink.PressureNone, "")
+}
+
+func (s *logStorageHealthSuite) TestVolumePressure(c *gc.C) {
+	s.used = 90
+	s.assertPressure(c, logsink.PressureModerate, "mongo volume 90% full")
+	s.used = 97
+	s.assertPressure(c, logsink.PressureHigh, "mongo volume 97% full")
+}
+
+func (s *logStorageHealthSuite) TestLogsPressure(c *gc.C) {
+	s.logsMB = 150
+	s.assertPressure(c, logsink.PressureModerate, "logs database 150 MB exceeds max-logs-size 100 MB")
+	s.logsMB = 250
+	s.assertPressure(c, logsink.PressureHigh, "logs database 250 MB exceeds max-logs-size 100 MB")
+}
+
+func (s *logStorageHealthSuite) TestGreatestPressure(c *gc.C) {
+	s.used = 91
+	s.logsMB = 200
+	s.assertPressure(c, logsink.PressureHigh, "logs database 200 MB exceeds max-logs-size 100 MB")
+}
+
+func (s *logStorageHealthSuite) TestCheckErrorIgnored(c *gc.C) {
+	s.used = 99
+	s.volumeErr = errors.New("boom")
+	s.assertPressure(c, logsink.PressureNone, "")
+}
+
+func (s *logStorageHealthSuite) TestNoPressureBeforeCheck(c *gc.C) {
+	pressure, reason := s.health.LogStoragePressure()
+	c.Assert(pressure, gc.Equals, logsink.PressureNone)
+	c.Assert(reason, gc.Equals, "")
+	c.Assert(s.checks, gc.Equals, 0)
+}
+
+func (s *logStorageHealthSuite) TestLoop(c *gc.C) {
+	checked := make(chan struct{})
+	s.health.volumeUsage = func() (uint64, uint64, error) {
+		checked <- struct{}{}
+		return 99, 100, nil
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.health.loop(stop)
+	}()
+	defer func() {
+		close(stop)
+		select {
+		case <-done:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("loop did not stop")
+		}
+	}()
+
+	// The storage is checked straight away, and then periodically.
+	s.waitCheck(c, checked)
+	err := s.clock.WaitAdvance(logStorageCheckInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCheck(c, checked)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if pressure, _ := s.health.LogStoragePressure(); pressure == logsink.PressureHigh {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("pressure not reported")
+		}
+	}
+	pressure, reason := s.health.LogStoragePressure()
+	c.Assert(pressure, gc.Equals, logsink.PressureHigh)
+	c.Assert(reason, gc.Equals, "mongo volume 99% full")
+}
+
+func (s *logStorageHealthSuite) waitCheck(c *gc.C, checked <-chan struct{}) {
+	select {
+	case <-checked:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("log storage not checked")
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package apiserver
+
+import (
+	"syscall"
+)
+
+// volumeUsage returns the used and total bytes on the file system
+// at the given path.
+func volumeUsage(path string) (uint64, uint64, error) {
+	// Note: golang.org/x/sys/unix is avoided here for the same
+	// reasons as in container/lxd (lp:1632541).
+	statfs := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return 0, 0, err
+	}
+	total := uint64(statfs.Bsize) * statfs.Blocks
+	free := uint64(statfs.Bsize) * statfs.Bfree
+	return total - free, total, nil
+}
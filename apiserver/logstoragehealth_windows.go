@@ -0,0 +1,14 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+)
+
+// volumeUsage returns the used and total bytes on the file system
+// at the given path.
+func volumeUsage(path string) (uint64, uint64, error) {
+	return 0, 0, errors.NotSupportedf("volume usage on windows")
+}
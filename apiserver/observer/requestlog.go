@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/rpc"
+)
+
+const (
+	// RequestCategoryAudited is the category of requests that are
+	// recorded in the audit log. They are always recorded in the
+	// request log.
+	RequestCategoryAudited = "audited"
+
+	// RequestCategoryAuditExempt is the category of requests that are
+	// excluded from the audit log, such as read-only requests. Only a
+	// sample of them are recorded in the request log.
+	RequestCategoryAuditExempt = "audit-exempt"
+
+	// unknownErrorCode is recorded for requests that failed with an
+	// error that has no code.
+	unknownErrorCode = "error"
+)
+
+// RequestLogConfig holds the configuration for request logging.
+type RequestLogConfig struct {
+	// Enabled determines whether requests are recorded at all.
+	Enabled bool
+
+	// SamplePercent is the percentage of audit-exempt requests
+	// that are recorded.
+	SamplePercent int
+
+	// ExcludeMethods holds the methods excluded from the audit log,
+	// which determine the category of each request.
+	ExcludeMethods set.Strings
+}
+
+// RequestLogEntry holds the details of an API request recorded by a
+// RequestLogObserver.
+type RequestLogEntry struct {
+	Time         time.Time
+	ModelUUID    string
+	ConnectionID uint64
+	Entity       string
+	Facade       string
+	Version      int
+	Method       string
+	Duration     time.Duration
+	ErrorCode    string
+	Category     string
+}
+
+// RequestLogWriter writes the entries recorded by request log
+// observers. Implementations must be safe for concurrent use, and
+// should not block.
+type RequestLogWriter interface {
+	WriteRequestLog(RequestLogEntry)
+}
+
+// RequestLogObserverContext provides the information needed for a
+// RequestLogObserver to operate.
+type RequestLogObserverContext struct {
+	// Clock is the clock used to time requests.
+	Clock clock.Clock
+
+	// Writer is where the recorded requests are written.
+	Writer RequestLogWriter
+
+	// GetConfig returns the current request logging configuration.
+	// It is called for each request, so that configuration changes
+	// apply to existing connections.
+	GetConfig func() RequestLogConfig
+
+	// Sample returns a random integer in [0, 100), used to sample
+	// audit-exempt requests. If nil, math/rand is used.
+	Sample func() int
+}
+
+// RequestLogObserver records the API requests made on a connection
+// in the request log.
+type RequestLogObserver struct {
+	ctx RequestLogObserverContext
+
+	// state holds the information about the connection that is
+	// built up as the Observer methods are called.
+	state struct {
+		id    uint64
+		tag   string
+		model string
+	}
+}
+
+// NewRequestLogObserver returns a new RequestLogObserver.
+func NewRequestLogObserver(ctx RequestLogObserverContext) *RequestLogObserver {
+	if ctx.Sample == nil {
+		ctx.Sample = func() int { return rand.Intn(100) }
+	}
+	return &RequestLogObserver{ctx: ctx}
+}
+
+// Login implements Observer.
+func (o *RequestLogObserver) Login(entity names.Tag, model names.ModelTag, fromController bool, userData string) {
+	o.state.tag = entity.String()
+	o.state.model = model.Id()
+}
+
+// Join implements Observer.
+func (o *RequestLogObserver) Join(req *http.Request, connectionID uint64) {
+	o.state.id = connectionID
+}
+
+// Leave implements Observer.
+func (o *RequestLogObserver) Leave() {}
+
+// RPCObserver implements Observer.
+func (o *RequestLogObserver) RPCObserver() rpc.Observer {
+	return &requestLogRPCObserver{
+		ctx:   o.ctx,
+		id:    o.state.id,
+		tag:   o.state.tag,
+		model: o.state.model,
+	}
+}
+
+// requestLogRPCObserver records a single request in the request log.
+type requestLogRPCObserver struct {
+	ctx          RequestLogObserverContext
+	id           uint64
+	tag          string
+	model        string
+	requestStart time.Time
+}
+
+// ServerRequest implements rpc.Observer.
+func (o *requestLogRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	o.requestStart = o.ctx.Clock.Now()
+}
+
+// ServerReply implements rpc.Observer.
+func (o *requestLogRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	cfg := o.ctx.GetConfig()
+	if !cfg.Enabled {
+		return
+	}
+	category := RequestCategoryAudited
+	interesting := MakeInterestingRequestFilter(cfg.ExcludeMethods)
+	if !interesting(auditlog.Request{Facade: req.Type, Method: req.Action}) {
+		category = RequestCategoryAuditExempt
+		if o.ctx.Sample() >= cfg.SamplePercent {
+			return
+		}
+	}
+	errorCode := hdr.ErrorCode
+	if hdr.Error != "" && errorCode == "" {
+		errorCode = unknownErrorCode
+	}
+	o.ctx.Writer.WriteRequestLog(RequestLogEntry{
+		Time:         o.requestStart,
+		ModelUUID:    o.model,
+		ConnectionID: o.id,
+		Entity:       o.tag,
+		Facade:       req.Type,
+		Version:      req.Version,
+		Method:       req.Action,
+		Duration:     o.ctx.Clock.Now().Sub(o.requestStart),
+		ErrorCode:    errorCode,
+		Category:     category,
+	})
+}
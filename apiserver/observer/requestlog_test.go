@@ -0,0 +1,118 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+)
+
+type RequestLogObserverSuite struct {
+	testing.IsolationSuite
+	clock   *testclock.Clock
+	writer  requestLogWriter
+	config  observer.RequestLogConfig
+	sample  int
+	observe *observer.RequestLogObserver
+}
+
+var _ = gc.Suite(&RequestLogObserverSuite{})
+
+func (s *RequestLogObserverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC))
+	s.writer = nil
+	s.config = observer.RequestLogConfig{
+		Enabled:        true,
+		SamplePercent:  10,
+		ExcludeMethods: set.NewStrings("Client.FullStatus"),
+	}
+	s.sample = 0
+	s.observe = observer.NewRequestLogObserver(observer.RequestLogObserverContext{
+		Clock:     s.clock,
+		Writer:    &s.writer,
+		GetConfig: func() observer.RequestLogConfig { return s.config },
+		Sample:    func() int { return s.sample },
+	})
+	s.observe.Join(nil, 42)
+	s.observe.Login(names.NewUserTag("bob"), names.NewModelTag("model-uuid"), false, "")
+}
+
+func (s *RequestLogObserverSuite) serve(facade, method string, reply rpc.Header) {
+	rpcObserver := s.observe.RPCObserver()
+	rpcObserver.ServerRequest(&rpc.Header{}, nil)
+	s.clock.Advance(250 * time.Millisecond)
+	req := rpc.Request{Type: facade, Version: 3, Action: method}
+	rpcObserver.ServerReply(req, &reply, nil)
+}
+
+func (s *RequestLogObserverSuite) TestAuditedRequestLogged(c *gc.C) {
+	s.sample = 99
+	s.serve("Application", "Deploy", rpc.Header{})
+	c.Assert(s.writer, jc.DeepEquals, requestLogWriter{{
+		Time:         time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC),
+		ModelUUID:    "model-uuid",
+		ConnectionID: 42,
+		Entity:       "user-bob",
+		Facade:       "Application",
+		Version:      3,
+		Method:       "Deploy",
+		Duration:     250 * time.Millisecond,
+		Category:     observer.RequestCategoryAudited,
+	}})
+}
+
+func (s *RequestLogObserverSuite) TestAuditExemptRequestSampled(c *gc.C) {
+	s.sample = 9
+	s.serve("Client", "FullStatus", rpc.Header{})
+	s.sample = 10
+	s.serve("Client", "FullStatus", rpc.Header{})
+	c.Assert(s.writer, gc.HasLen, 1)
+	c.Assert(s.writer[0].Method, gc.Equals, "FullStatus")
+	c.Assert(s.writer[0].Category, gc.Equals, observer.RequestCategoryAuditExempt)
+}
+
+func (s *RequestLogObserverSuite) TestReadOnlyMethodsAuditExempt(c *gc.C) {
+	s.config.ExcludeMethods = set.NewStrings("ReadOnlyMethods")
+	s.serve("Client", "FullStatus", rpc.Header{})
+	c.Assert(s.writer, gc.HasLen, 1)
+	c.Assert(s.writer[0].Category, gc.Equals, observer.RequestCategoryAuditExempt)
+}
+
+func (s *RequestLogObserverSuite) TestSamplePercentZero(c *gc.C) {
+	s.config.SamplePercent = 0
+	s.serve("Client", "FullStatus", rpc.Header{})
+	s.serve("Application", "Deploy", rpc.Header{})
+	c.Assert(s.writer, gc.HasLen, 1)
+	c.Assert(s.writer[0].Method, gc.Equals, "Deploy")
+}
+
+func (s *RequestLogObserverSuite) TestDisabled(c *gc.C) {
+	s.config.Enabled = false
+	s.serve("Application", "Deploy", rpc.Header{})
+	c.Assert(s.writer, gc.HasLen, 0)
+}
+
+func (s *RequestLogObserverSuite) TestErrorCodes(c *gc.C) {
+	s.serve("Application", "Deploy", rpc.Header{Error: "boom", ErrorCode: "not found"})
+	s.serve("Application", "Deploy", rpc.Header{Error: "boom"})
+	c.Assert(s.writer, gc.HasLen, 2)
+	c.Assert(s.writer[0].ErrorCode, gc.Equals, "not found")
+	c.Assert(s.writer[1].ErrorCode, gc.Equals, "error")
+}
+
+type requestLogWriter []observer.RequestLogEntry
+
+func (w *requestLogWriter) WriteRequestLog(entry observer.RequestLogEntry) {
+	*w = append(*w, entry)
+}
@@ -38,6 +38,21 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	NotifyURL  string                 `json:"notify-url,omitempty"`
+}
+
+// ActionNotification holds a finished action whose completion is
+// to be posted to a notification URL.
+type ActionNotification struct {
+	NotifyURL string        `json:"notify-url,omitempty"`
+	Action    *ActionResult `json:"action,omitempty"`
+	Error     *Error        `json:"error,omitempty"`
+}
+
+// ActionNotifications holds the results of a bulk request
+// for action notifications.
+type ActionNotifications struct {
+	Results []ActionNotification `json:"results,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
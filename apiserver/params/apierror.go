@@ -173,6 +173,7 @@ const (
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
 	CodeCloudRegionRequired       = "cloud region required"
+	CodeQuotaExceeded             = "quota exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -346,3 +347,7 @@ func IsCodeForbidden(err error) bool {
 func IsCodeCloudRegionRequired(err error) bool {
 	return ErrCode(err) == CodeCloudRegionRequired
 }
+
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
@@ -126,6 +126,12 @@ type ApplicationSetCharm struct {
 	// facade version 2
 	ConfigSettingsYAML string `json:"config-settings-yaml,omitempty"`
 
+	// ConfigMap maps the names of config options of the current charm
+	// to the names of the new charm's options that their values are
+	// carried over to. This field is only understood by Application
+	// facade version 12 and greater.
+	ConfigMap map[string]string `json:"config-map,omitempty"`
+
 	// Force forces the lxd profile validation overriding even if it's fails.
 	Force bool `json:"force"`
 
@@ -439,3 +445,135 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// CredentialAccess records that a hook run by a trusted
+// application was given its model's cloud credential.
+type CredentialAccess struct {
+	Unit string    `json:"unit,omitempty"`
+	Hook string    `json:"hook,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+// CredentialAccessResult holds the recorded credential
+// accesses of an application, or a retrieval error.
+type CredentialAccessResult struct {
+	Accesses []CredentialAccess `json:"accesses,omitempty"`
+	Error    *Error             `json:"error,omitempty"`
+}
+
+// CredentialAccessResults holds the recorded credential
+// accesses of a number of applications.
+type CredentialAccessResults struct {
+	Results []CredentialAccessResult `json:"results"`
+}
+
+// UnitInfo holds information about a unit.
+type UnitInfo struct {
+	Tag             string `json:"tag"`
+	Application     string `json:"application"`
+	Machine         string `json:"machine,omitempty"`
+	Life            string `json:"life"`
+	Charm           string `json:"charm,omitempty"`
+	WorkloadVersion string `json:"workload-version,omitempty"`
+
+	// HookQueue holds the hooks the unit agent is waiting to run,
+	// or is nil if the agent has not reported them.
+	HookQueue *UnitHookQueue `json:"hook-queue,omitempty"`
+}
+
+// UnitHookQueue holds the hooks that a unit agent is waiting to run.
+type UnitHookQueue struct {
+	Pending []string  `json:"pending"`
+	Updated time.Time `json:"updated"`
+}
+
+// UnitPendingHooksArg holds the hooks that a unit agent
+// is waiting to run, in the order it expects to run them.
+type UnitPendingHooksArg struct {
+	Tag     string   `json:"tag"`
+	Pending []string `json:"pending"`
+}
+
+// UnitPendingHooksArgs holds the pending hooks of a number of units.
+type UnitPendingHooksArgs struct {
+	Args []UnitPendingHooksArg `json:"args"`
+}
+
+// UnitInfoResult holds a unit info or a retrieval error.
+type UnitInfoResult struct {
+	Result *UnitInfo `json:"result,omitempty"`
+	Error  *Error    `json:"error,omitempty"`
+}
+
+// UnitInfoResults holds units associated with entities.
+type UnitInfoResults struct {
+	Results []UnitInfoResult `json:"results"`
+}
+
+// UnitOperation describes the execution of a single operation, such as
+// a hook, action or charm upgrade, by a unit agent.
+type UnitOperation struct {
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Started     time.Time `json:"started"`
+	Completed   time.Time `json:"completed"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// UnitOperationsArg holds operations to record for a unit.
+type UnitOperationsArg struct {
+	Tag        string          `json:"tag"`
+	Operations []UnitOperation `json:"operations"`
+}
+
+// UnitOperationsArgs holds operations to record for a number of units.
+type UnitOperationsArgs struct {
+	Args []UnitOperationsArg `json:"args"`
+}
+
+// UnitOperationsResult holds the operation history of a unit, most
+// recent first, or a retrieval error.
+type UnitOperationsResult struct {
+	Operations []UnitOperation `json:"operations,omitempty"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// UnitOperationsResults holds the operation histories of a number
+// of units.
+type UnitOperationsResults struct {
+	Results []UnitOperationsResult `json:"results"`
+}
+
+// ApplicationMergeBindings holds endpoint bindings to merge with
+// the existing bindings of an application. An empty endpoint name
+// sets the application's default space.
+type ApplicationMergeBindings struct {
+	ApplicationTag string            `json:"application-tag"`
+	Bindings       map[string]string `json:"bindings"`
+
+	// Force, if true, changes the bindings even when some of the
+	// application's machines are not connected to the new spaces.
+	Force bool `json:"force"`
+}
+
+// ApplicationMergeBindingsArgs holds endpoint bindings to merge
+// for a number of applications.
+type ApplicationMergeBindingsArgs struct {
+	Args []ApplicationMergeBindings `json:"args"`
+}
+
+// ApplicationMergeBindingsResult holds the result of merging the
+// endpoint bindings of an application. Problems describes each
+// machine hosting the application's units that is not connected
+// to a space one of its endpoints is now bound to; such machines
+// need their network reconfigured, or replacing.
+type ApplicationMergeBindingsResult struct {
+	Problems []string `json:"problems,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// ApplicationMergeBindingsResults holds the results of merging
+// the endpoint bindings of a number of applications.
+type ApplicationMergeBindingsResults struct {
+	Results []ApplicationMergeBindingsResult `json:"results"`
+}
@@ -205,6 +205,14 @@ type CloudSpecResult struct {
 	Error  *Error     `json:"error,omitempty"`
 }
 
+// HookCloudSpecArgs holds the arguments of a unit agent's
+// request for its model's cloud spec.
+type HookCloudSpecArgs struct {
+	// Hook is the name of the hook or action on whose
+	// behalf the cloud spec is requested.
+	Hook string `json:"hook,omitempty"`
+}
+
 // CloudSpecResults contains a set of CloudSpecResults.
 type CloudSpecResults struct {
 	Results []CloudSpecResult `json:"results,omitempty"`
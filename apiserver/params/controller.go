@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -106,3 +108,125 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// VerifyModelsArgs holds the arguments for Controller.VerifyModels.
+type VerifyModelsArgs struct {
+	// Fix indicates that any issues found should be repaired.
+	Fix bool `json:"fix"`
+
+	// FixKinds restricts the kinds of issue that are repaired when
+	// Fix is true. If empty, all kinds are repaired.
+	FixKinds []string `json:"fix-kinds,omitempty"`
+}
+
+// IntegrityIssue describes a dangling reference found in a model.
+type IntegrityIssue struct {
+	Kind      string `json:"kind"`
+	Entity    string `json:"entity"`
+	Reference string `json:"reference"`
+	Message   string `json:"message"`
+	Repaired  bool   `json:"repaired"`
+}
+
+// VerifyModelResult holds the integrity issues found in a single model.
+type VerifyModelResult struct {
+	ModelTag string           `json:"model-tag"`
+	Issues   []IntegrityIssue `json:"issues,omitempty"`
+	Error    *Error           `json:"error,omitempty"`
+}
+
+// VerifyModelsResults holds the results of Controller.VerifyModels.
+type VerifyModelsResults struct {
+	Results []VerifyModelResult `json:"results"`
+}
+
+// ControllerHealthResult holds the health of the controller's mongo
+// replica set, raft cluster and API servers.
+type ControllerHealthResult struct {
+	APIServers []APIServerHealth        `json:"api-servers"`
+	ReplicaSet []ReplicaSetMemberHealth `json:"replica-set"`
+	Raft       *RaftHealth              `json:"raft,omitempty"`
+	Error      *Error                   `json:"error,omitempty"`
+}
+
+// APIServerHealth holds the health of a single API server.
+type APIServerHealth struct {
+	MachineId string `json:"machine-id"`
+
+	// AgentVersion is the version of the agent reported by the
+	// API server machine.
+	AgentVersion string `json:"agent-version,omitempty"`
+
+	// Reporting is true if a health report has recently been
+	// received from the API server machine.
+	Reporting bool `json:"reporting"`
+
+	// LastReport is the time at which the last health report was
+	// received from the API server machine.
+	LastReport *time.Time `json:"last-report,omitempty"`
+
+	// ClockSkew is the estimated difference between the clock of
+	// the API server machine and that of the machine that served
+	// the request.
+	ClockSkew time.Duration `json:"clock-skew"`
+}
+
+// ReplicaSetMemberHealth holds the state of a mongo replica set member.
+type ReplicaSetMemberHealth struct {
+	MachineId string `json:"machine-id,omitempty"`
+	Address   string `json:"address"`
+	State     string `json:"state"`
+	Healthy   bool   `json:"healthy"`
+
+	// Lag is how far the member's replication is behind the primary.
+	Lag time.Duration `json:"lag"`
+
+	// LastHeartbeat is when the member last responded to a heartbeat
+	// from the machine that reported the replica set status. It is
+	// nil for the member running on that machine.
+	LastHeartbeat *time.Time `json:"last-heartbeat,omitempty"`
+}
+
+// RaftHealth holds the state of the controller's raft cluster.
+type RaftHealth struct {
+	Leader     string             `json:"leader"`
+	Term       uint64             `json:"term"`
+	Servers    []RaftServerHealth `json:"servers"`
+	LastReport time.Time          `json:"last-report"`
+}
+
+// RaftServerHealth holds the configuration of a raft server.
+type RaftServerHealth struct {
+	Id       string `json:"id"`
+	Address  string `json:"address"`
+	Suffrage string `json:"suffrage"`
+}
+
+// ControllerFeaturesResult holds the feature flags enabled on the
+// controller, and the recorded changes to them.
+type ControllerFeaturesResult struct {
+	Features []string                  `json:"features"`
+	Changes  []ControllerFeatureChange `json:"changes,omitempty"`
+}
+
+// ControllerFeatureChange records a controller feature flag being
+// enabled or disabled.
+type ControllerFeatureChange struct {
+	Feature string    `json:"feature"`
+	Enabled bool      `json:"enabled"`
+	User    string    `json:"user"`
+	When    time.Time `json:"when"`
+}
+
+// SetControllerFeatureArgs holds the arguments for
+// ControllerFeatures.SetFeatures.
+type SetControllerFeatureArgs struct {
+	Args []SetControllerFeatureArg `json:"args"`
+}
+
+// SetControllerFeatureArg holds a feature flag to be enabled
+// or disabled on the controller.
+type SetControllerFeatureArg struct {
+	Feature string `json:"feature"`
+	Enabled bool   `json:"enabled"`
+}
@@ -121,6 +121,13 @@ type AddApplicationOffer struct {
 	ApplicationName        string            `json:"application-name"`
 	ApplicationDescription string            `json:"application-description"`
 	Endpoints              map[string]string `json:"endpoints"`
+
+	// AllowedConsumerCIDRs and AllowedConsumerControllers, if set,
+	// restrict consumption of the offer to consuming models whose
+	// egress networks fall within the CIDRs, and which are hosted
+	// by the controllers with the given UUIDs.
+	AllowedConsumerCIDRs       []string `json:"allowed-consumer-cidrs,omitempty"`
+	AllowedConsumerControllers []string `json:"allowed-consumer-controllers,omitempty"`
 }
 
 // DestroyApplicationOffers holds parameters for the DestroyOffers call.
@@ -168,6 +175,18 @@ type OfferURLs struct {
 	OfferURLs []string `json:"offer-urls,omitempty"`
 }
 
+// ConsumeOfferDetailsArg holds the arguments for getting the
+// details needed to consume offers.
+type ConsumeOfferDetailsArg struct {
+	OfferURLs OfferURLs `json:"offer-urls"`
+
+	// ConsumerControllerUUID, if set, is the UUID of the controller
+	// hosting the consuming model. It is declared in the returned
+	// macaroons, so that offers restricted to specific controllers
+	// can check it when the relation is registered.
+	ConsumerControllerUUID string `json:"consumer-controller-uuid,omitempty"`
+}
+
 // ConsumeApplicationArg holds the arguments for consuming a remote application.
 type ConsumeApplicationArg struct {
 	// The offer to be consumed.
@@ -480,6 +499,16 @@ type RegisterRemoteRelationArg struct {
 
 	// Macaroons are used for authentication.
 	Macaroons macaroon.Slice `json:"macaroons,omitempty"`
+
+	// ConsumerControllerUUID is the UUID of the controller hosting
+	// the consuming model. Offers which restrict consumption to
+	// specific controllers check the controller declared by the
+	// macaroons instead; this must match it when both are set.
+	ConsumerControllerUUID string `json:"consumer-controller-uuid,omitempty"`
+
+	// ConsumerNetworks are the egress networks of the consuming
+	// model. Offers may restrict consumption to specific CIDRs.
+	ConsumerNetworks []string `json:"consumer-networks,omitempty"`
 }
 
 // RegisterRemoteRelationArgs holds args used to add remote relations.
@@ -0,0 +1,36 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// HelpTopic holds a help topic served by the controller.
+type HelpTopic struct {
+	Name  string `json:"name"`
+	Short string `json:"short"`
+	Doc   string `json:"doc,omitempty"`
+}
+
+// HelpTopicList holds the summaries of the help topics served by
+// the controller, along with the controller's version.
+type HelpTopicList struct {
+	Version string      `json:"version"`
+	Topics  []HelpTopic `json:"topics"`
+}
+
+// HelpTopicNames holds the names of help topics to fetch.
+type HelpTopicNames struct {
+	Names []string `json:"names"`
+}
+
+// HelpTopicResult holds a help topic or an error.
+type HelpTopicResult struct {
+	Result *HelpTopic `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// HelpTopicResults holds the results of a bulk Topics API call,
+// along with the controller's version.
+type HelpTopicResults struct {
+	Version string            `json:"version"`
+	Results []HelpTopicResult `json:"results"`
+}
@@ -444,6 +444,10 @@ type EntityStatusArgs struct {
 	Status string                 `json:"status"`
 	Info   string                 `json:"info"`
 	Data   map[string]interface{} `json:"data"`
+
+	// Since, if set, is the time at which the status was originally
+	// set by an agent that could not reach the controller at the time.
+	Since *time.Time `json:"since,omitempty"`
 }
 
 // SetStatus holds the parameters for making a SetStatus/UpdateStatus call.
@@ -713,6 +717,7 @@ type ProvisioningInfo struct {
 	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
 	CloudInitUserData map[string]interface{}    `json:"cloudinit-userdata,omitempty"`
 	CharmLXDProfiles  []string                  `json:"charm-lxd-profiles,omitempty"`
+	SpreadPolicy      string                    `json:"spread-policy,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
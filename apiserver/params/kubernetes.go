@@ -24,6 +24,15 @@ type KubernetesProvisioningInfo struct {
 	Filesystems    []KubernetesFilesystemParams `json:"filesystems,omitempty"`
 	Volumes        []KubernetesVolumeParams     `json:"volumes,omitempty"`
 	Devices        []KubernetesDeviceParams     `json:"devices,omitempty"`
+	Scheduling     *KubernetesSchedulingParams  `json:"scheduling,omitempty"`
+	ProxySettings  *ProxyConfig                 `json:"proxy-settings,omitempty"`
+}
+
+// KubernetesSchedulingParams holds the constraints on where
+// an application's pods are scheduled relative to each other.
+type KubernetesSchedulingParams struct {
+	SpreadAcrossNodes bool `json:"spread-across-nodes,omitempty"`
+	SpreadAcrossZones bool `json:"spread-across-zones,omitempty"`
 }
 
 // KubernetesProvisioningInfoResult holds unit provisioning info or an error.
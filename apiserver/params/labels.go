@@ -0,0 +1,40 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// LabelsResult holds the labels of a machine or unit, or an error.
+type LabelsResult struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// LabelsResults holds the results of a Labels call.
+type LabelsResults struct {
+	Results []LabelsResult `json:"results"`
+}
+
+// UpdateLabelsArg holds the labels to set and remove on a
+// machine or unit.
+type UpdateLabelsArg struct {
+	Tag    string            `json:"tag"`
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+// UpdateLabelsArgs holds the arguments of an UpdateLabels call.
+type UpdateLabelsArgs struct {
+	Args []UpdateLabelsArg `json:"args"`
+}
+
+// LabelSelectorArg holds a label selector, such as "env=canary".
+type LabelSelectorArg struct {
+	Selector string `json:"selector"`
+}
+
+// LabelSelectResult holds the ids of the machines, and the names of
+// the units, which match a label selector.
+type LabelSelectResult struct {
+	Machines []string `json:"machines,omitempty"`
+	Units    []string `json:"units,omitempty"`
+}
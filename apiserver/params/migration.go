@@ -30,13 +30,14 @@ type MigrationSpec struct {
 // MigrationTargetInfo holds the details required to connect to and
 // authenticate with a remote controller for model migration.
 type MigrationTargetInfo struct {
-	ControllerTag   string   `json:"controller-tag"`
-	ControllerAlias string   `json:"controller-alias,omitempty"`
-	Addrs           []string `json:"addrs"`
-	CACert          string   `json:"ca-cert"`
-	AuthTag         string   `json:"auth-tag"`
-	Password        string   `json:"password,omitempty"`
-	Macaroons       string   `json:"macaroons,omitempty"`
+	ControllerTag   string            `json:"controller-tag"`
+	ControllerAlias string            `json:"controller-alias,omitempty"`
+	Addrs           []string          `json:"addrs"`
+	CACert          string            `json:"ca-cert"`
+	AuthTag         string            `json:"auth-tag"`
+	Password        string            `json:"password,omitempty"`
+	Macaroons       string            `json:"macaroons,omitempty"`
+	UserMap         map[string]string `json:"user-map,omitempty"`
 }
 
 // InitiateMigrationResults is used to return the result of one or
@@ -133,6 +134,7 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	UserTags               []string       `json:"user-tags,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -210,6 +210,11 @@ type ModelSummary struct {
 	// in the model, for example machines, cores, containers, units, etc.
 	Counts []ModelEntityCount `json:"counts"`
 
+	// Quotas contains the maximum numbers of entities the model
+	// may have, as set in the controller config. Entities which
+	// are not limited are omitted.
+	Quotas []ModelEntityCount `json:"quotas,omitempty"`
+
 	// Migration contains information about the latest failed or
 	// currently-running migration. It'll be nil if there isn't one.
 	Migration *ModelMigrationStatus `json:"migration,omitempty"`
@@ -235,6 +240,7 @@ const (
 	Machines CountedEntity = "machines"
 	Cores    CountedEntity = "cores"
 	Units    CountedEntity = "units"
+	Storage  CountedEntity = "storage"
 )
 
 // ModelSLAInfo describes the SLA info for a model.
@@ -445,3 +451,24 @@ type ChangeModelCredentialParams struct {
 type ChangeModelCredentialsParams struct {
 	Models []ChangeModelCredentialParams `json:"model-credentials"`
 }
+
+// TransferModelOwnershipParam holds the arguments for
+// transferring the ownership of a model to another user.
+type TransferModelOwnershipParam struct {
+	// ModelTag is the tag of the model to transfer.
+	ModelTag string `json:"model-tag"`
+
+	// OwnerTag is the tag of the user to become the model owner.
+	OwnerTag string `json:"owner-tag"`
+
+	// CloudCredentialTag is the tag of the new owner's cloud
+	// credential that the model will use. It is required
+	// for models on clouds that need credentials.
+	CloudCredentialTag string `json:"credential-tag,omitempty"`
+}
+
+// TransferModelOwnershipParams holds the arguments for
+// transferring the ownership of models.
+type TransferModelOwnershipParams struct {
+	Models []TransferModelOwnershipParam `json:"models"`
+}
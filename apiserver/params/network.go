@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
@@ -426,6 +428,56 @@ type SetMachineNetworkConfig struct {
 	Config []NetworkConfig `json:"config"`
 }
 
+// FanBridgeStatus holds the state of a machine's bridge for one FAN.
+type FanBridgeStatus struct {
+	Name         string `json:"name"`
+	Underlay     string `json:"underlay"`
+	Overlay      string `json:"overlay"`
+	LocalOverlay string `json:"local-overlay,omitempty"`
+	Present      bool   `json:"present"`
+	Up           bool   `json:"up"`
+}
+
+// FanConfigError holds an error hit configuring FAN networking.
+type FanConfigError struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// MachineNetworkStatus holds the state of a machine's FAN networking,
+// as reported by its machine agent.
+type MachineNetworkStatus struct {
+	FanBridges []FanBridgeStatus `json:"fan-bridges,omitempty"`
+	FanErrors  []FanConfigError  `json:"fan-errors,omitempty"`
+	Updated    time.Time         `json:"updated"`
+}
+
+// SetMachineNetworkStatus holds the network status to record
+// for a machine.
+type SetMachineNetworkStatus struct {
+	Tag    string               `json:"tag"`
+	Status MachineNetworkStatus `json:"status"`
+}
+
+// SetMachinesNetworkStatus holds the parameters for making an API
+// call to record the network status of machines.
+type SetMachinesNetworkStatus struct {
+	Args []SetMachineNetworkStatus `json:"args"`
+}
+
+// MachineNetworkStatusResult holds the network status of a machine
+// or an error.
+type MachineNetworkStatusResult struct {
+	Result *MachineNetworkStatus `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// MachineNetworkStatusResults holds the network status of
+// multiple machines.
+type MachineNetworkStatusResults struct {
+	Results []MachineNetworkStatusResult `json:"results"`
+}
+
 // MachineAddressesResult holds a list of machine addresses or an
 // error.
 type MachineAddressesResult struct {
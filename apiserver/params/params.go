@@ -315,6 +315,19 @@ type OperatorProvisioningInfo struct {
 	CharmStorage KubernetesFilesystemParams `json:"charm-storage"`
 }
 
+// OperatorProvisioningInfoResult holds the info needed to provision
+// the operator of an application, or an error.
+type OperatorProvisioningInfoResult struct {
+	Result *OperatorProvisioningInfo `json:"result,omitempty"`
+	Error  *Error                    `json:"error,omitempty"`
+}
+
+// OperatorProvisioningInfoResults holds the results of an
+// OperatorProvisioningInfo call.
+type OperatorProvisioningInfoResults struct {
+	Results []OperatorProvisioningInfoResult `json:"results"`
+}
+
 // PublicAddress holds parameters for the PublicAddress call.
 type PublicAddress struct {
 	Target string `json:"target"`
@@ -394,6 +407,48 @@ type UpdateApplicationUnits struct {
 	Units          []ApplicationUnitParams `json:"units"`
 }
 
+// UpdateApplicationUnitResults holds the results of updating
+// the units of a number of applications.
+type UpdateApplicationUnitResults struct {
+	Results []UpdateApplicationUnitResult `json:"results"`
+}
+
+// UpdateApplicationUnitResult holds the result of updating the units
+// of an application. Units holds the result for each unit, in the order
+// the units were supplied; Error is set if the application as a whole
+// could not be updated.
+type UpdateApplicationUnitResult struct {
+	Units []ErrorResult `json:"units,omitempty"`
+	Error *Error        `json:"error,omitempty"`
+}
+
+// CleanupOrphanedStorageArgs holds the arguments for cleaning up
+// the orphaned storage of a number of CAAS applications.
+type CleanupOrphanedStorageArgs struct {
+	Args []CleanupOrphanedStorageArg `json:"args"`
+}
+
+// CleanupOrphanedStorageArg holds the arguments for cleaning up the
+// filesystems of an application whose units' pods no longer exist.
+type CleanupOrphanedStorageArg struct {
+	ApplicationTag string         `json:"application-tag"`
+	Force          bool           `json:"force,omitempty"`
+	MaxWait        *time.Duration `json:"max-wait,omitempty"`
+}
+
+// CleanupOrphanedStorageResults holds the results of cleaning up
+// the orphaned storage of a number of CAAS applications.
+type CleanupOrphanedStorageResults struct {
+	Results []CleanupOrphanedStorageResult `json:"results"`
+}
+
+// CleanupOrphanedStorageResult holds the tags of the orphaned
+// filesystems of an application that were destroyed.
+type CleanupOrphanedStorageResult struct {
+	Filesystems []string `json:"filesystems,omitempty"`
+	Error       *Error   `json:"error,omitempty"`
+}
+
 // ApplicationUnitParams holds unit parameters used to update a unit.
 type ApplicationUnitParams struct {
 	ProviderId     string                     `json:"provider-id"`
@@ -1082,6 +1137,79 @@ type UpgradeSeriesUnitsResult struct {
 	UnitNames []string `json:"unit-names"`
 }
 
+// UpgradeStepArg identifies an upgrade step for an agent upgrading
+// to a version. Step is not used when listing pending steps.
+type UpgradeStepArg struct {
+	Tag     string         `json:"tag"`
+	Version version.Number `json:"version"`
+	Step    string         `json:"step,omitempty"`
+}
+
+// UpgradeStepArgs holds the arguments for upgrade step calls.
+type UpgradeStepArgs struct {
+	Args []UpgradeStepArg `json:"args"`
+}
+
+// PendingUpgradeStepsResult holds the upgrade steps yet to be run for
+// an agent, or an error.
+type PendingUpgradeStepsResult struct {
+	Steps []string `json:"steps,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// PendingUpgradeStepsResults holds the results of a
+// PendingUpgradeSteps call.
+type PendingUpgradeStepsResults struct {
+	Results []PendingUpgradeStepsResult `json:"results"`
+}
+
+// SetUpgradeStepProgressArg holds the status of an upgrade step run
+// by an agent upgrading to a version.
+type SetUpgradeStepProgressArg struct {
+	UpgradeStepArg
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// SetUpgradeStepProgressArgs holds the arguments for a
+// SetUpgradeStepProgress call.
+type SetUpgradeStepProgressArgs struct {
+	Args []SetUpgradeStepProgressArg `json:"args"`
+}
+
+// UpgradeStepProgress describes the progress of an upgrade step run
+// by an agent.
+type UpgradeStepProgress struct {
+	Tag     string    `json:"tag"`
+	Step    string    `json:"step"`
+	Status  string    `json:"status"`
+	Message string    `json:"message,omitempty"`
+	Started time.Time `json:"started"`
+	Updated time.Time `json:"updated"`
+}
+
+// UpgradeStepsProgressResult holds the progress of the upgrade steps
+// run by each agent while upgrading to a version.
+type UpgradeStepsProgressResult struct {
+	Version version.Number        `json:"version"`
+	Steps   []UpgradeStepProgress `json:"steps,omitempty"`
+}
+
+// SetUnitStateArg holds the state that a unit agent keeps between
+// runs, to be stored in the controller.
+type SetUnitStateArg struct {
+	Tag           string         `json:"tag"`
+	UniterState   string         `json:"uniter-state,omitempty"`
+	RelationState map[int]string `json:"relation-state,omitempty"`
+	StorageState  string         `json:"storage-state,omitempty"`
+}
+
+// SetUnitStateArgs holds the arguments for storing the state
+// of a number of unit agents.
+type SetUnitStateArgs struct {
+	Args []SetUnitStateArg `json:"args"`
+}
+
 type ProfileArg struct {
 	Entity   Entity `json:"entity"`
 	UnitName string `json:"unit-name"`
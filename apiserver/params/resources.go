@@ -96,6 +96,35 @@ type Resource struct {
 
 	// Timestamp indicates when the resource was added to the model.
 	Timestamp time.Time `json:"timestamp"`
+
+	// ImageScan holds the result of the most recent vulnerability scan
+	// of a container image resource, if it has been scanned.
+	ImageScan *ImageScanResult `json:"image-scan,omitempty"`
+}
+
+// ImageScanResult holds the outcome of scanning a container image
+// resource for vulnerabilities.
+type ImageScanResult struct {
+	// ScannedAt is when the scan was performed.
+	ScannedAt time.Time `json:"scanned-at"`
+
+	// Critical is the number of critical vulnerabilities found.
+	Critical int `json:"critical"`
+
+	// High is the number of high severity vulnerabilities found.
+	High int `json:"high"`
+
+	// Summary holds a summary of the findings, as reported by the scanner.
+	Summary string `json:"summary,omitempty"`
+
+	// Error holds the reason the image could not be scanned, if any.
+	Error string `json:"error,omitempty"`
+
+	// Pending is true while the scan has yet to complete.
+	Pending bool `json:"pending,omitempty"`
+
+	// Blocked is true if the scan policy prevents the image being used.
+	Blocked bool `json:"blocked,omitempty"`
 }
 
 // CharmResource contains the definition for a resource.
@@ -14,8 +14,16 @@ import (
 )
 
 // StatusParams holds parameters for the Status call.
+// LabelSelectorPatternPrefix prefixes status patterns which hold
+// a label selector, matching machines and units by their labels.
+const LabelSelectorPatternPrefix = "selector:"
+
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// SummaryOnly requests that only the model information and
+	// the model summary are returned.
+	SummaryOnly bool `json:"summary-only,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -29,6 +37,23 @@ type FullStatus struct {
 	Offers              map[string]ApplicationOfferStatus  `json:"offers"`
 	Relations           []RelationStatus                   `json:"relations"`
 	ControllerTimestamp *time.Time                         `json:"controller-timestamp"`
+
+	// Summary holds aggregate counts for the model, if
+	// they are available to the controller.
+	Summary *ModelStatusSummary `json:"summary,omitempty"`
+}
+
+// ModelStatusSummary holds aggregate information about
+// the entities in a model.
+type ModelStatusSummary struct {
+	ApplicationCount    int               `json:"application-count"`
+	MachineCount        int               `json:"machine-count"`
+	ContainerCount      int               `json:"container-count"`
+	UnitCount           int               `json:"unit-count"`
+	UnitWorkloadStatus  map[string]int    `json:"unit-workload-status,omitempty"`
+	UnitAgentStatus     map[string]int    `json:"unit-agent-status,omitempty"`
+	MachineAgentStatus  map[string]int    `json:"machine-agent-status,omitempty"`
+	ApplicationVersions map[string]string `json:"application-versions,omitempty"`
 }
 
 // IsEmpty checks all collections on FullStatus to determine if the status is empty.
@@ -122,6 +147,33 @@ type MachineStatus struct {
 	// LXDProfiles holds all the machines current LXD profiles that have
 	// been applied to the machine
 	LXDProfiles map[string]LXDProfile `json:"lxd-profiles,omitempty"`
+
+	// Labels holds the freeform labels attached to the machine.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Utilization holds the most recent resource utilization of the
+	// machine's instance, if reported by the provider.
+	Utilization *MachineUtilization `json:"utilization,omitempty"`
+}
+
+// MachineUtilization holds the resource utilization of a machine's
+// instance, as reported by the provider.
+type MachineUtilization struct {
+	CPUPercent    *float64 `json:"cpu-percent,omitempty"`
+	MemoryPercent *float64 `json:"memory-percent,omitempty"`
+}
+
+// SetMachineUtilization holds the resource utilization to record
+// for a machine.
+type SetMachineUtilization struct {
+	Tag         string             `json:"tag"`
+	Utilization MachineUtilization `json:"utilization"`
+}
+
+// SetMachinesUtilization holds the parameters for making an API
+// call to record the resource utilization of machines.
+type SetMachinesUtilization struct {
+	Machines []SetMachineUtilization `json:"machines"`
 }
 
 // LXDProfile holds status info about a LXDProfile
@@ -149,12 +201,33 @@ type ApplicationStatus struct {
 	CharmProfile     string                 `json:"charm-profile"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// Health summarises the workload status of the application's units.
+	// RollupHealth also takes into account the status of their
+	// subordinates, and is only set for principal applications with
+	// subordinate units.
+	Health       *ApplicationHealth `json:"health,omitempty"`
+	RollupHealth *ApplicationHealth `json:"rollup-health,omitempty"`
+
 	// The following are for CAAS models.
 	Scale         int    `json:"int,omitempty"`
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
 }
 
+// ApplicationHealth counts an application's units by workload status.
+type ApplicationHealth struct {
+	Active  int `json:"active"`
+	Blocked int `json:"blocked"`
+	Error   int `json:"error"`
+
+	// Other counts the units in any other state, such as
+	// waiting or maintenance.
+	Other int `json:"other"`
+
+	// Score is the percentage of the units that are active.
+	Score int `json:"score"`
+}
+
 // RemoteApplicationStatus holds status info about a remote application.
 type RemoteApplicationStatus struct {
 	Err       *Error              `json:"err,omitempty"`
@@ -199,6 +272,9 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// Labels holds the freeform labels attached to the unit.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
@@ -274,6 +274,19 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for resizing a storage
+// volume.
+type ResizeVolumeParams struct {
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size in MiB that the volume is to be resized to.
+	Size uint64 `json:"size"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -355,6 +368,17 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParamsResult holds parameters for resizing a volume.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for resizing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -401,8 +425,9 @@ type FilesystemAttachment struct {
 
 // FilesystemAttachmentInfo describes a filesystem attachment.
 type FilesystemAttachmentInfo struct {
-	MountPoint string `json:"mount-point,omitempty"`
-	ReadOnly   bool   `json:"read-only,omitempty"`
+	MountPoint   string   `json:"mount-point,omitempty"`
+	ReadOnly     bool     `json:"read-only,omitempty"`
+	MountOptions []string `json:"mount-options,omitempty"`
 }
 
 // FilesystemAttachments describes a set of storage filesystem attachments.
@@ -438,13 +463,14 @@ type RemoveFilesystemParams struct {
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
-	FilesystemTag string `json:"filesystem-tag"`
-	MachineTag    string `json:"machine-tag"`
-	FilesystemId  string `json:"filesystem-id,omitempty"`
-	InstanceId    string `json:"instance-id,omitempty"`
-	Provider      string `json:"provider"`
-	MountPoint    string `json:"mount-point,omitempty"`
-	ReadOnly      bool   `json:"read-only,omitempty"`
+	FilesystemTag string   `json:"filesystem-tag"`
+	MachineTag    string   `json:"machine-tag"`
+	FilesystemId  string   `json:"filesystem-id,omitempty"`
+	InstanceId    string   `json:"instance-id,omitempty"`
+	Provider      string   `json:"provider"`
+	MountPoint    string   `json:"mount-point,omitempty"`
+	ReadOnly      bool     `json:"read-only,omitempty"`
+	MountOptions  []string `json:"mount-options,omitempty"`
 }
 
 // FilesystemAttachmentResult holds the details of a single filesystem attachment,
@@ -891,6 +917,22 @@ type RemoveStorageInstance struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// ResizeStorage holds the parameters for resizing storage instances.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for resizing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the size in MiB that the storage instance's volume
+	// is to be grown to.
+	Size uint64 `json:"size"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
@@ -933,6 +975,40 @@ type ImportStorageDetails struct {
 	StorageTag string `json:"storage-tag"`
 }
 
+// OrphanedVolume identifies a volume that exists in the cloud but is
+// unknown to the model, or a model volume that no longer exists in the
+// cloud.
+type OrphanedVolume struct {
+	// Provider is the type of the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// ProviderId is the storage provider's unique ID for the volume,
+	// e.g. the EBS volume ID.
+	ProviderId string `json:"provider-id"`
+
+	// VolumeTag is the string representation of the tag of the model's
+	// volume, if the model knows about the volume.
+	VolumeTag string `json:"volume-tag,omitempty"`
+}
+
+// OrphanedStorageResult contains the result of comparing the volumes
+// known to the model with those the storage providers report for it.
+type OrphanedStorageResult struct {
+	// Unknown holds the volumes tagged for the model in the cloud
+	// that the model does not know about.
+	Unknown []OrphanedVolume `json:"unknown,omitempty"`
+
+	// Missing holds the provisioned model volumes that no longer
+	// exist in the cloud.
+	Missing []OrphanedVolume `json:"missing,omitempty"`
+}
+
+// DestroyOrphanedVolumesParams contains the parameters for destroying
+// volumes that are unknown to the model.
+type DestroyOrphanedVolumesParams struct {
+	Volumes []OrphanedVolume `json:"volumes"`
+}
+
 // AddStorageResults contains the results of adding storage to units.
 type AddStorageResults struct {
 	Results []AddStorageResult `json:"results"`
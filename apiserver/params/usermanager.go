@@ -52,6 +52,22 @@ type AddUser struct {
 	// be possible to login with a password until
 	// registration with the secret key is completed.
 	Password string `json:"password,omitempty"`
+
+	// ModelAccess holds the access to models that is granted
+	// to the user. The grants are made atomically with the
+	// creation of the user.
+	ModelAccess []UserModelAccess `json:"model-access,omitempty"`
+
+	// Expires, if set, is the time after which
+	// the user is automatically disabled.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// UserModelAccess holds the access to a model
+// to be granted to a user when it is added.
+type UserModelAccess struct {
+	ModelTag string `json:"model-tag"`
+	Access   string `json:"access"`
 }
 
 // AddUserResults holds the results of the bulk AddUser API call.
@@ -0,0 +1,17 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// The Warning constants hold the codes of the warnings that may be
+// attached to API replies.
+const (
+	// WarningDeprecatedFacadeVersion is attached to replies to requests
+	// made with a facade version that will be removed in a future
+	// release.
+	WarningDeprecatedFacadeVersion = "deprecated facade version"
+
+	// WarningDeprecatedConfig is attached to replies to requests that
+	// set deprecated configuration attributes.
+	WarningDeprecatedConfig = "deprecated config"
+)
@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/state"
+)
+
+// requestRecordLogger writes request records to storage.
+type requestRecordLogger interface {
+	Log([]state.RequestLogRecord) error
+}
+
+// requestLogWriter accumulates the requests recorded by the request
+// log observers of all API connections, flushing them to the
+// underlying logger when the buffer fills or the flush interval
+// passes, in the same way as the buffered logsink DB loggers.
+type requestLogWriter struct {
+	l             requestRecordLogger
+	clock         clock.Clock
+	flushInterval time.Duration
+
+	mu         sync.Mutex
+	buf        []state.RequestLogRecord
+	flushTimer clock.Timer
+}
+
+func newRequestLogWriter(
+	l requestRecordLogger,
+	bufferSize int,
+	flushInterval time.Duration,
+	clock clock.Clock,
+) *requestLogWriter {
+	return &requestLogWriter{
+		l:             l,
+		buf:           make([]state.RequestLogRecord, 0, bufferSize),
+		clock:         clock,
+		flushInterval: flushInterval,
+	}
+}
+
+// WriteRequestLog is part of the observer.RequestLogWriter interface.
+func (w *requestLogWriter) WriteRequestLog(entry observer.RequestLogEntry) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, state.RequestLogRecord{
+		Time:         entry.Time,
+		ModelUUID:    entry.ModelUUID,
+		ConnectionID: entry.ConnectionID,
+		Entity:       entry.Entity,
+		Facade:       entry.Facade,
+		Version:      entry.Version,
+		Method:       entry.Method,
+		Duration:     entry.Duration,
+		ErrorCode:    entry.ErrorCode,
+		Category:     entry.Category,
+	})
+	if len(w.buf) >= cap(w.buf) {
+		w.flushAndLog()
+		return
+	}
+	if w.flushTimer == nil {
+		w.flushTimer = w.clock.AfterFunc(w.flushInterval, w.flushOnTimer)
+	}
+}
+
+// Flush flushes any buffered request records to the underlying logger.
+func (w *requestLogWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *requestLogWriter) flushOnTimer() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushAndLog()
+}
+
+// flushAndLog flushes the buffered request records, logging rather
+// than returning any error, as the observers writing the records
+// cannot do anything about it. The caller must be holding w.mu.
+func (w *requestLogWriter) flushAndLog() {
+	if err := w.flush(); err != nil {
+		logger.Warningf("cannot write request log: %v", err)
+		// Drop the records rather than growing the buffer
+		// without bound while the database is unavailable.
+		w.buf = w.buf[:0]
+	}
+}
+
+// flush flushes any buffered request records to the underlying logger,
+// and stops the flush timer if there is one. The caller must be
+// holding w.mu.
+func (w *requestLogWriter) flush() error {
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+		w.flushTimer = nil
+	}
+	if len(w.buf) > 0 {
+		if err := w.l.Log(w.buf); err != nil {
+			return errors.Trace(err)
+		}
+		w.buf = w.buf[:0]
+	}
+	return nil
+}
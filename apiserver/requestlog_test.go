@@ -0,0 +1,90 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type requestLogWriterSuite struct {
+	testing.IsolationSuite
+	clock  *testclock.Clock
+	logger *fakeRequestLogger
+	writer *requestLogWriter
+}
+
+var _ = gc.Suite(&requestLogWriterSuite{})
+
+func (s *requestLogWriterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.logger = &fakeRequestLogger{written: make(chan []state.RequestLogRecord, 10)}
+	s.writer = newRequestLogWriter(s.logger, 2, time.Minute, s.clock)
+}
+
+func (s *requestLogWriterSuite) assertWritten(c *gc.C, methods ...string) {
+	select {
+	case records := <-s.logger.written:
+		var written []string
+		for _, r := range records {
+			written = append(written, r.Method)
+		}
+		c.Assert(written, jc.DeepEquals, methods)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request records")
+	}
+}
+
+func (s *requestLogWriterSuite) TestFlushesWhenFull(c *gc.C) {
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "One"})
+	c.Assert(s.logger.written, gc.HasLen, 0)
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "Two"})
+	s.assertWritten(c, "One", "Two")
+}
+
+func (s *requestLogWriterSuite) TestFlushesOnTimer(c *gc.C) {
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "One"})
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertWritten(c, "One")
+}
+
+func (s *requestLogWriterSuite) TestFlush(c *gc.C) {
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "One"})
+	err := s.writer.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertWritten(c, "One")
+}
+
+func (s *requestLogWriterSuite) TestDropsRecordsOnError(c *gc.C) {
+	s.logger.err = errors.New("boom")
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "One"})
+	s.writer.WriteRequestLog(observer.RequestLogEntry{Method: "Two"})
+	s.assertWritten(c, "One", "Two")
+
+	s.logger.err = nil
+	err := s.writer.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.logger.written, gc.HasLen, 0)
+}
+
+type fakeRequestLogger struct {
+	written chan []state.RequestLogRecord
+	err     error
+}
+
+func (l *fakeRequestLogger) Log(records []state.RequestLogRecord) error {
+	l.written <- append([]state.RequestLogRecord(nil), records...)
+	return l.err
+}
@@ -4,23 +4,37 @@
 package apiserver
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
 	"path"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/state"
 )
 
+// imageScanClient is the HTTP client used to contact the configured
+// container image scanner.
+var imageScanClient = &http.Client{Timeout: 5 * time.Minute}
+
+// imageRegistryClient is the HTTP client used to contact container
+// image registries when pinning image resources to their digests.
+var imageRegistryClient = &http.Client{Timeout: time.Minute}
+
 // ResourcesBackend is the functionality of Juju's state needed for the resources API.
 type ResourcesBackend interface {
 	// OpenResource returns the identified resource and its content.
@@ -37,6 +51,10 @@ type ResourcesBackend interface {
 
 	// UpdatePendingResource adds the resource to blob storage and updates the metadata.
 	UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
+
+	// SetImageScanResult records the result of scanning the identified
+	// container image resource for vulnerabilities.
+	SetImageScanResult(resourceID string, result resources.ImageScanResult) error
 }
 
 // ResourcesHandler is the HTTP handler for client downloads and
@@ -44,6 +62,35 @@ type ResourcesBackend interface {
 type ResourcesHandler struct {
 	StateAuthFunc     func(*http.Request, ...string) (ResourcesBackend, state.PoolHelper, names.Tag, error)
 	ChangeAllowedFunc func(*http.Request) error
+
+	// ImageScannerFunc, if set, returns how uploaded container image
+	// resources are checked for vulnerabilities. A nil ImageScan
+	// disables scanning.
+	ImageScannerFunc func(*http.Request) (*ImageScan, error)
+
+	// ImageDigestResolverFunc, if set, returns the resolver used to
+	// pin uploaded container image resources to their digests when
+	// the client asks for it.
+	ImageDigestResolverFunc func(*http.Request) (resources.ImageDigestResolver, error)
+}
+
+// ImageScan holds the scanner used to check uploaded container image
+// resources for vulnerabilities, the policy to apply to its findings,
+// and how to record them. Scans run in the background, so that
+// uploads are not held up by slow scanners.
+type ImageScan struct {
+	Scanner resources.ImageScanner
+	Policy  resources.ImageScanPolicy
+
+	// Start runs the scan in the background, passing it a channel
+	// that is closed if the scan should be abandoned. It returns an
+	// error if no more scans may be started.
+	Start func(scan func(abort <-chan struct{})) error
+
+	// Record records the result of scanning the identified resource.
+	// It is called once the scan has completed, which may be after
+	// the upload request has been answered.
+	Record func(resourceID string, result resources.ImageScanResult) error
 }
 
 // ServeHTTP implements http.Handler.
@@ -105,6 +152,19 @@ func (h *ResourcesHandler) upload(backend ResourcesBackend, req *http.Request, u
 		return nil, errors.Trace(err)
 	}
 
+	var scan *ImageScan
+	var details resources.DockerImageDetails
+	if uploaded.Resource.Type == charmresource.TypeContainerImage {
+		if uploaded.PinDigest {
+			if err := h.pinImage(req, uploaded); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if scan, details, err = h.imageScan(req, uploaded); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// UpdatePendingResource does the same as SetResource (just calls setResource) except SetResouce just blanks PendingID.
 	var stored resource.Resource
 	if uploaded.PendingID != "" {
@@ -116,6 +176,24 @@ func (h *ResourcesHandler) upload(backend ResourcesBackend, req *http.Request, u
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if scan != nil {
+		// Until the scan completes, the image may only be used if
+		// the policy allows images that fail their scan.
+		pending := resources.ImageScanResult{
+			Pending: true,
+			Blocked: scan.Policy == resources.ImageScanBlock,
+		}
+		if err := backend.SetImageScanResult(stored.ID, pending); err != nil {
+			return nil, errors.Annotate(err, "recording image scan result")
+		}
+		stored.ImageScan = &pending
+		err := scan.Start(func(abort <-chan struct{}) {
+			runImageScan(scan, stored, details, abort)
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "starting image scan")
+		}
+	}
 
 	result := &params.UploadResult{
 		Resource: api.Resource2API(stored),
@@ -123,6 +201,130 @@ func (h *ResourcesHandler) upload(backend ResourcesBackend, req *http.Request, u
 	return result, nil
 }
 
+// pinImage replaces the registry path of the uploaded container image
+// resource with one that refers to the image by its current digest.
+func (h *ResourcesHandler) pinImage(req *http.Request, uploaded *uploadedResource) error {
+	if h.ImageDigestResolverFunc == nil {
+		return errors.NotSupportedf("pinning image digests")
+	}
+	resolver, err := h.ImageDigestResolverFunc(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	data, err := ioutil.ReadAll(uploaded.Data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var details resources.DockerImageDetails
+	if err := json.Unmarshal(data, &details); err != nil {
+		return errors.Annotate(err, "reading image details")
+	}
+	details, err = resources.PinImageDigest(resolver, details)
+	if err != nil {
+		return errors.Annotatef(err, "pinning image for resource %q", uploaded.Resource.Name)
+	}
+	if data, err = json.Marshal(details); err != nil {
+		return errors.Trace(err)
+	}
+	fingerprint, err := charmresource.GenerateFingerprint(bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uploaded.Data = ioutil.NopCloser(bytes.NewReader(data))
+	uploaded.Resource.Size = int64(len(data))
+	uploaded.Resource.Fingerprint = fingerprint
+	logger.Debugf("pinned image for resource %q of application %q to %q", uploaded.Resource.Name, uploaded.Application, details.RegistryPath)
+	return nil
+}
+
+// imageScan returns how the uploaded container image resource is to
+// be scanned, if there is a scanner configured, along with the details
+// of the image to scan.
+func (h *ResourcesHandler) imageScan(req *http.Request, uploaded *uploadedResource) (*ImageScan, resources.DockerImageDetails, error) {
+	var details resources.DockerImageDetails
+	if h.ImageScannerFunc == nil {
+		return nil, details, nil
+	}
+	scan, err := h.ImageScannerFunc(req)
+	if err != nil {
+		return nil, details, errors.Trace(err)
+	}
+	if scan == nil {
+		return nil, details, nil
+	}
+
+	// The image details are small, so we read them here so that they
+	// can be both scanned and stored.
+	data, err := ioutil.ReadAll(uploaded.Data)
+	if err != nil {
+		return nil, details, errors.Trace(err)
+	}
+	uploaded.Data = ioutil.NopCloser(bytes.NewReader(data))
+	if err := json.Unmarshal(data, &details); err != nil {
+		return nil, details, errors.Annotate(err, "reading image details")
+	}
+	return scan, details, nil
+}
+
+// runImageScan scans the stored container image resource, and records
+// the result. Images that fail the scan are blocked from use if the
+// scan policy says so.
+func runImageScan(scan *ImageScan, stored resource.Resource, details resources.DockerImageDetails, abort <-chan struct{}) {
+	result, err := scan.Scanner.ScanImage(details, abort)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if result.Failed() {
+		result.Blocked = scan.Policy == resources.ImageScanBlock
+		var reason string
+		if result.Error != "" {
+			reason = fmt.Sprintf("could not be scanned: %s", result.Error)
+		} else {
+			reason = fmt.Sprintf("has %d critical vulnerabilities", result.Critical)
+		}
+		logger.Warningf("image %q for resource %q of application %q %s", details.RegistryPath, stored.Name, stored.ApplicationID, reason)
+	}
+	if err := scan.Record(stored.ID, result); err != nil {
+		logger.Errorf("cannot record scan of image for resource %q of application %q: %v", stored.Name, stored.ApplicationID, err)
+	}
+}
+
+// imageScans runs container image scans in the background, so that
+// they can be abandoned, and waited for, when the server stops.
+type imageScans struct {
+	abort <-chan struct{}
+
+	mu      sync.Mutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// start runs the scan in a new goroutine, unless the scans have
+// been stopped.
+func (s *imageScans) start(scan func(abort <-chan struct{})) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return errors.New("apiserver shutting down")
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		scan(s.abort)
+	}()
+	return nil
+}
+
+// stop prevents any more scans from starting, and waits for those
+// already running to finish.
+func (s *imageScans) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
 // uploadedResource holds both the information about an uploaded
 // resource and the reader containing its data.
 type uploadedResource struct {
@@ -137,6 +339,10 @@ type uploadedResource struct {
 
 	// Data holds the resource blob.
 	Data io.ReadCloser
+
+	// PinDigest is whether a container image resource should be
+	// pinned to its current digest before it is stored.
+	PinDigest bool
 }
 
 // readResource extracts the relevant info from the request.
@@ -178,6 +384,7 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 		PendingID:   uReq.PendingID,
 		Resource:    chRes,
 		Data:        req.Body,
+		PinDigest:   uReq.PinDigest,
 	}, nil
 }
 
@@ -217,6 +424,7 @@ func extractUploadRequest(req *http.Request) (api.UploadRequest, error) {
 	fingerprint := req.Header.Get(api.HeaderContentSha384) // This parallels "Content-MD5".
 	sizeRaw := req.Header.Get(api.HeaderContentLength)
 	pendingID := req.URL.Query().Get(api.QueryParamPendingID)
+	pinDigest := req.URL.Query().Get(api.QueryParamPinDigest) == "true"
 
 	fp, err := charmresource.ParseFingerprint(fingerprint)
 	if err != nil {
@@ -240,6 +448,7 @@ func extractUploadRequest(req *http.Request) (api.UploadRequest, error) {
 		Size:        size,
 		Fingerprint: fp,
 		PendingID:   pendingID,
+		PinDigest:   pinDigest,
 	}
 	return ur, nil
 }
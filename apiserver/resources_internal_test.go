@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type imageScansSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&imageScansSuite{})
+
+func (s *imageScansSuite) TestStopAbortsAndWaits(c *gc.C) {
+	abort := make(chan struct{})
+	scans := &imageScans{abort: abort}
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	err := scans.start(func(scanAbort <-chan struct{}) {
+		close(started)
+		<-scanAbort
+		<-finish
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scan to start")
+	}
+
+	close(abort)
+	stopped := make(chan struct{})
+	go func() {
+		scans.stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		c.Fatalf("stopped before the scan finished")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	close(finish)
+	select {
+	case <-stopped:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scans to stop")
+	}
+
+	err = scans.start(func(<-chan struct{}) {
+		c.Errorf("scan started after stop")
+	})
+	c.Assert(err, gc.ErrorMatches, "apiserver shutting down")
+}
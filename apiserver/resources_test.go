@@ -17,6 +17,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/opencontainers/go-digest"
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/names.v2"
@@ -25,10 +26,12 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type ResourcesHandlerSuite struct {
@@ -152,6 +155,221 @@ func (s *ResourcesHandlerSuite) TestPutSuccessDockerResource(c *gc.C) {
 	s.checkResp(c, http.StatusOK, "application/json", string(expected))
 }
 
+// setImageScanner configures the handler to scan uploaded images with
+// the scanner, and returns a channel on which the results of the scans
+// are recorded.
+func (s *ResourcesHandlerSuite) setImageScanner(scanner resources.ImageScanner, policy resources.ImageScanPolicy) <-chan resources.ImageScanResult {
+	recorded := make(chan resources.ImageScanResult, 1)
+	s.handler.ImageScannerFunc = func(*http.Request) (*apiserver.ImageScan, error) {
+		return &apiserver.ImageScan{
+			Scanner: scanner,
+			Policy:  policy,
+			Start: func(scan func(<-chan struct{})) error {
+				go scan(nil)
+				return nil
+			},
+			Record: func(resourceID string, result resources.ImageScanResult) error {
+				recorded <- result
+				return nil
+			},
+		}, nil
+	}
+	return recorded
+}
+
+func waitImageScan(c *gc.C, recorded <-chan resources.ImageScanResult) resources.ImageScanResult {
+	select {
+	case result := <-recorded:
+		return result
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for image scan")
+	}
+	panic("unreachable")
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceScanned(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image@sha256:deadbeef","Username":"fred"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	scanner := &fakeImageScanner{result: resources.ImageScanResult{High: 2}}
+	recorded := s.setImageScanner(scanner, resources.ImageScanBlock)
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	// The upload is answered before the scan completes, and the
+	// image is blocked from use until it does.
+	pending := resources.ImageScanResult{Pending: true, Blocked: true}
+	res.ImageScan = &pending
+	expected := mustMarshalJSON(&params.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+	s.checkResp(c, http.StatusOK, "application/json", string(expected))
+	c.Assert(s.backend.StoredData, gc.Equals, uploadContent)
+	c.Assert(s.backend.ScanResults, jc.DeepEquals, map[string]resources.ImageScanResult{
+		res.ID: pending,
+	})
+
+	c.Assert(waitImageScan(c, recorded), jc.DeepEquals, resources.ImageScanResult{High: 2})
+	c.Assert(scanner.details, jc.DeepEquals, resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+		Username:     "fred",
+	})
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceScanWarns(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image@sha256:deadbeef"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	recorded := s.setImageScanner(&fakeImageScanner{err: errors.New("scanner down")}, resources.ImageScanWarn)
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(s.backend.ScanResults, jc.DeepEquals, map[string]resources.ImageScanResult{
+		res.ID: {Pending: true},
+	})
+	c.Assert(waitImageScan(c, recorded), jc.DeepEquals, resources.ImageScanResult{Error: "scanner down"})
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceScanBlocks(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image@sha256:deadbeef"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	recorded := s.setImageScanner(&fakeImageScanner{result: resources.ImageScanResult{Critical: 3}}, resources.ImageScanBlock)
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(s.backend.StoredData, gc.Equals, uploadContent)
+	c.Assert(waitImageScan(c, recorded), jc.DeepEquals, resources.ImageScanResult{
+		Critical: 3,
+		Blocked:  true,
+	})
+}
+
+func (s *ResourcesHandlerSuite) TestPutPendingDockerResourceScanned(c *gc.C) {
+	// Resources given when upgrading a charm are uploaded as pending
+	// resources, and are scanned in the same way.
+	uploadContent := `{"ImageName":"registry.example.com/image@sha256:deadbeef"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	res.PendingID = "some-unique-id"
+	stored := newDockerResource(c, "spam", "", "")
+	stored.PendingID = "some-unique-id"
+	s.backend.ReturnGetPendingResource = stored
+	s.backend.ReturnUpdatePendingResource = res
+	scanner := &fakeImageScanner{result: resources.ImageScanResult{Critical: 1}}
+	recorded := s.setImageScanner(scanner, resources.ImageScanBlock)
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	req.URL.RawQuery += "&pendingid=some-unique-id"
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(s.backend.StoredData, gc.Equals, uploadContent)
+	c.Assert(s.backend.ScanResults, jc.DeepEquals, map[string]resources.ImageScanResult{
+		res.ID: {Pending: true, Blocked: true},
+	})
+	c.Assert(waitImageScan(c, recorded), jc.DeepEquals, resources.ImageScanResult{
+		Critical: 1,
+		Blocked:  true,
+	})
+	c.Assert(scanner.details, jc.DeepEquals, resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+	})
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceScanNotStarted(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image@sha256:deadbeef"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	s.handler.ImageScannerFunc = func(*http.Request) (*apiserver.ImageScan, error) {
+		return &apiserver.ImageScan{
+			Scanner: &fakeImageScanner{},
+			Policy:  resources.ImageScanBlock,
+			Start: func(func(<-chan struct{})) error {
+				return errors.New("apiserver shutting down")
+			},
+		}, nil
+	}
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	_, expected := apiFailure("starting image scan: apiserver shutting down", "")
+	s.checkResp(c, http.StatusInternalServerError, "application/json", expected)
+
+	// The image stays blocked until it is uploaded again.
+	c.Assert(s.backend.ScanResults, jc.DeepEquals, map[string]resources.ImageScanResult{
+		res.ID: {Pending: true, Blocked: true},
+	})
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourcePinned(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image:1.0","Username":"fred"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	resolver := &fakeDigestResolver{digest: testImageDigest}
+	s.handler.ImageDigestResolverFunc = func(*http.Request) (resources.ImageDigestResolver, error) {
+		return resolver, nil
+	}
+	scanner := &fakeImageScanner{}
+	recorded := s.setImageScanner(scanner, resources.ImageScanBlock)
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	req.URL.RawQuery += "&pin-digest=true"
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(resolver.details.RegistryPath, gc.Equals, "registry.example.com/image:1.0")
+	pinned := resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@" + string(testImageDigest),
+		Username:     "fred",
+		PinnedFrom:   "registry.example.com/image:1.0",
+	}
+	var storedDetails resources.DockerImageDetails
+	err := json.Unmarshal([]byte(s.backend.StoredData), &storedDetails)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storedDetails, jc.DeepEquals, pinned)
+	fingerprint, err := charmresource.GenerateFingerprint(strings.NewReader(s.backend.StoredData))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.StoredResource.Fingerprint, jc.DeepEquals, fingerprint)
+	c.Assert(s.backend.StoredResource.Size, gc.Equals, int64(len(s.backend.StoredData)))
+	waitImageScan(c, recorded)
+	c.Assert(scanner.details, jc.DeepEquals, pinned)
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourcePinFails(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/image:1.0"}`
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.handler.ImageDigestResolverFunc = func(*http.Request) (resources.ImageDigestResolver, error) {
+		return &fakeDigestResolver{err: errors.NotFoundf("image")}, nil
+	}
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	req.URL.RawQuery += "&pin-digest=true"
+	s.handler.ServeHTTP(s.recorder, req)
+
+	_, expected := apiFailure(
+		`pinning image for resource "spam": resolving digest of image "registry.example.com/image:1.0": image not found`,
+		params.CodeNotFound)
+	s.checkResp(c, http.StatusNotFound, "application/json", expected)
+	c.Assert(s.backend.StoredData, gc.Equals, "")
+}
+
 func (s *ResourcesHandlerSuite) TestPutExtensionMismatch(c *gc.C) {
 	content := "<some data>"
 
@@ -222,6 +440,9 @@ type fakeBackend struct {
 	ReturnSetResource           resource.Resource
 	SetResourceErr              error
 	ReturnUpdatePendingResource resource.Resource
+	StoredData                  string
+	StoredResource              charmresource.Resource
+	ScanResults                 map[string]resources.ImageScanResult
 }
 
 const resourceBody = "body"
@@ -245,13 +466,57 @@ func (s *fakeBackend) SetResource(applicationID, userID string, res charmresourc
 	if s.SetResourceErr != nil {
 		return resource.Resource{}, s.SetResourceErr
 	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	s.StoredData = string(data)
+	s.StoredResource = res
 	return s.ReturnSetResource, nil
 }
 
 func (s *fakeBackend) UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	s.StoredData = string(data)
+	s.StoredResource = res
 	return s.ReturnUpdatePendingResource, nil
 }
 
+func (s *fakeBackend) SetImageScanResult(resourceID string, result resources.ImageScanResult) error {
+	if s.ScanResults == nil {
+		s.ScanResults = make(map[string]resources.ImageScanResult)
+	}
+	s.ScanResults[resourceID] = result
+	return nil
+}
+
+type fakeImageScanner struct {
+	details resources.DockerImageDetails
+	result  resources.ImageScanResult
+	err     error
+}
+
+func (s *fakeImageScanner) ScanImage(details resources.DockerImageDetails, abort <-chan struct{}) (resources.ImageScanResult, error) {
+	s.details = details
+	return s.result, s.err
+}
+
+const testImageDigest = digest.Digest("sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b")
+
+type fakeDigestResolver struct {
+	details resources.DockerImageDetails
+	digest  digest.Digest
+	err     error
+}
+
+func (r *fakeDigestResolver) ResolveDigest(details resources.DockerImageDetails) (digest.Digest, error) {
+	r.details = details
+	return r.digest, r.err
+}
+
 func newDockerResource(c *gc.C, name, username, data string) resource.Resource {
 	opened := resourcetesting.NewDockerResource(c, nil, name, "a-application", data)
 	res := opened.Resource
@@ -13,6 +13,7 @@ import (
 // commonModelFacadeNames lists facades that are shared between CAAS
 // and IAAS models.
 var commonModelFacadeNames = set.NewStrings(
+	"ActionNotifier",
 	"ActionPruner",
 	"AllWatcher",
 	"Agent",
@@ -28,6 +29,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"CrossController",
 	"CrossModelRelations",
 	"FilesystemAttachmentsWatcher",
+	"HelpTopics",
 	"LeadershipService",
 	"LifeFlag",
 	"Logger",
@@ -57,6 +59,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"StringsWatcher",
 	"Undertaker",
 	"Uniter",
+	"Units",
 	"Upgrader",
 	"VolumeAttachmentsWatcher",
 
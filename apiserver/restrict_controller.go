@@ -18,6 +18,7 @@ var controllerFacadeNames = set.NewStrings(
 	"ApplicationOffers",
 	"Cloud",
 	"Controller",
+	"ControllerFeatures",
 	"CrossController",
 	"MigrationTarget",
 	"ModelManager",
@@ -30,6 +31,10 @@ var commonFacadeNames = set.NewStrings(
 	"Pinger",
 	"Bundle",
 
+	// HelpTopics documents the controller's features, and
+	// does not depend on any model.
+	"HelpTopics",
+
 	// TODO(mjs) - bug 1632172 - Exposed for model logins for
 	// backwards compatibility. Remove once we're sure no non-Juju
 	// clients care about it.
@@ -45,6 +45,9 @@ var allowedMethodsDuringUpgrades = map[string]set.Strings{
 		"PublicKeys",
 		"Proxy",
 	),
+	"Controller": set.NewStrings(
+		"UpgradeStepsProgress", // for "juju upgrade-controller --progress"
+	),
 	"Pinger": set.NewStrings(
 		"Ping",
 	),
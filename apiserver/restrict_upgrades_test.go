@@ -31,6 +31,7 @@ func (r *restrictUpgradesSuite) TestAllowedMethods(c *gc.C) {
 	checkAllowed("SSHClient", "PublicAddress")
 	checkAllowed("SSHClient", "Proxy")
 	checkAllowed("Pinger", "Ping")
+	checkAllowed("Controller", "UpgradeStepsProgress")
 }
 
 func (r *restrictUpgradesSuite) TestFindDisallowedMethod(c *gc.C) {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -143,6 +144,8 @@ func (r *apiHandler) Kill() {
 // available for an RPC call and allow the RPC code to instantiate an object
 // and place a call on its method.
 type srvCaller struct {
+	rootName  string
+	version   int
 	objMethod rpcreflect.ObjMethod
 	goType    reflect.Type
 	creator   func(id string) (reflect.Value, error)
@@ -167,6 +170,7 @@ func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	warnDeprecatedFacadeVersion(ctx, s.rootName, s.version)
 	return s.objMethod.Call(ctx, objVal, arg)
 }
 
@@ -347,6 +351,8 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		return objValue, nil
 	}
 	return &srvCaller{
+		rootName:  rootName,
+		version:   version,
 		creator:   creator,
 		objMethod: objMethod,
 	}, nil
@@ -432,6 +438,16 @@ func (ctx *facadeContext) Hub() facade.Hub {
 	return ctx.r.shared.centralHub
 }
 
+// ControllerHealth implements facade.Context.
+func (ctx *facadeContext) ControllerHealth() facade.ControllerHealth {
+	return ctx.r.shared.health
+}
+
+// ControllerConfig implements facade.Context.
+func (ctx *facadeContext) ControllerConfig() controller.Config {
+	return ctx.r.shared.controllerConfig()
+}
+
 // Controller implements facade.Context.
 func (ctx *facadeContext) Controller() *cache.Controller {
 	return ctx.r.shared.controller
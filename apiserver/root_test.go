@@ -17,6 +17,8 @@ import (
 
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -184,6 +186,37 @@ func (r *rootSuite) TestFindMethodEnsuresTypeMatch(c *gc.C) {
 	c.Check(res.IsValid(), jc.IsFalse)
 }
 
+func (r *rootSuite) TestFindMethodWarnsDeprecatedFacadeVersion(c *gc.C) {
+	myGoodFacade := func(facade.Context) (facade.Facade, error) {
+		return &testingType{}, nil
+	}
+	expectedType := reflect.TypeOf((*testingType)(nil))
+	registry := new(facade.Registry)
+	registry.Register("my-testing-facade", 0, myGoodFacade, expectedType)
+	registry.Register("my-testing-facade", 1, myGoodFacade, expectedType)
+	srvRoot := apiserver.TestingAPIRoot(registry)
+	r.PatchValue(apiserver.DeprecatedFacadeVersions, map[string]map[int]string{
+		"my-testing-facade": {0: "use version 1"},
+	})
+
+	caller, err := srvRoot.FindMethod("my-testing-facade", 0, "Exposed")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, warnings := rpc.WithWarnings(context.Background())
+	_, err = caller.Call(ctx, "", reflect.Value{})
+	c.Check(err, gc.ErrorMatches, "Exposed was bogus")
+	c.Check(warnings.All(), jc.DeepEquals, []rpc.Warning{{
+		Code:    params.WarningDeprecatedFacadeVersion,
+		Message: "my-testing-facade facade version 0 is deprecated and will be removed in a future release: use version 1",
+	}})
+
+	caller, err = srvRoot.FindMethod("my-testing-facade", 1, "Exposed")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, warnings = rpc.WithWarnings(context.Background())
+	_, err = caller.Call(ctx, "", reflect.Value{})
+	c.Check(err, gc.ErrorMatches, "Exposed was bogus")
+	c.Check(warnings.All(), gc.HasLen, 0)
+}
+
 type stringVar struct {
 	Val string
 }
@@ -6,10 +6,14 @@ package apiserver
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/apiserver/logsink"
+	"github.com/juju/juju/apiserver/observer"
+	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
@@ -41,9 +45,20 @@ type sharedServerContext struct {
 	presence     presence.Recorder
 	leaseManager lease.Manager
 	logger       loggo.Logger
+	health       *controllerHealth
 
 	featuresMutex sync.RWMutex
 	features      set.Strings
+	config        corecontroller.Config
+
+	requestLogMutex sync.RWMutex
+	requestLog      observer.RequestLogConfig
+
+	// logSinkRateLimit holds the logsink rate limits from the agent
+	// configuration, which apply unless the controller config
+	// overrides them.
+	logSinkRateLimit   logsink.RateLimitConfig
+	logSinkRateLimiter *logsink.RateLimiter
 
 	unsubscribe func()
 }
@@ -54,7 +69,12 @@ type sharedServerConfig struct {
 	centralHub   SharedHub
 	presence     presence.Recorder
 	leaseManager lease.Manager
+	clock        clock.Clock
 	logger       loggo.Logger
+
+	// logSinkRateLimit holds the logsink rate limits from
+	// the agent configuration.
+	logSinkRateLimit logsink.RateLimitConfig
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -73,6 +93,9 @@ func (c *sharedServerConfig) validate() error {
 	if c.leaseManager == nil {
 		return errors.NotValidf("nil leaseManager")
 	}
+	if c.clock == nil {
+		return errors.NotValidf("nil clock")
+	}
 	return nil
 }
 
@@ -87,12 +110,18 @@ func newSharedServerContex(config sharedServerConfig) (*sharedServerContext, err
 		presence:     config.presence,
 		leaseManager: config.leaseManager,
 		logger:       config.logger,
+
+		logSinkRateLimit:   config.logSinkRateLimit,
+		logSinkRateLimiter: logsink.NewRateLimiter(config.logSinkRateLimit),
 	}
 	controllerConfig, err := ctx.statePool.SystemState().ControllerConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "unable to get controller config")
 	}
 	ctx.features = controllerConfig.Features()
+	ctx.config = controllerConfig
+	ctx.requestLog = newRequestLogConfig(controllerConfig)
+	ctx.updateLogSinkRateLimit(controllerConfig)
 	// We are able to get the current controller config before subscribing to changes
 	// because the changes are only ever published in response to an API call, and
 	// this function is called in the newServer call to create the API server,
@@ -102,11 +131,18 @@ func newSharedServerContex(config sharedServerConfig) (*sharedServerContext, err
 		ctx.logger.Criticalf("programming error in subscribe function: %v", err)
 		return nil, errors.Trace(err)
 	}
+	ctx.health, err = newControllerHealth(ctx.centralHub, config.clock, ctx.logger)
+	if err != nil {
+		ctx.unsubscribe()
+		ctx.logger.Criticalf("programming error in subscribe function: %v", err)
+		return nil, errors.Trace(err)
+	}
 	return ctx, nil
 }
 
 func (c *sharedServerContext) Close() {
 	c.unsubscribe()
+	c.health.Close()
 }
 
 func (c *sharedServerContext) onConfigChanged(topic string, data controller.ConfigChangedMessage, err error) {
@@ -121,12 +157,20 @@ func (c *sharedServerContext) onConfigChanged(topic string, data controller.Conf
 	removed := c.features.Difference(features)
 	added := features.Difference(c.features)
 	c.features = features
+	c.config = data.Config
 	values := features.SortedValues()
 	c.featuresMutex.Unlock()
 
 	if removed.Size() != 0 || added.Size() != 0 {
 		c.logger.Infof("updating features to %v", values)
 	}
+
+	c.requestLogMutex.Lock()
+	c.requestLog = newRequestLogConfig(data.Config)
+	c.requestLogMutex.Unlock()
+
+	c.updateLogSinkRateLimit(data.Config)
+
 	// If the presence implementation changes we need to restart
 	// the apiserver. So if the old presence feature flag is in either
 	// added or removed, we need to publish the restart message.
@@ -145,3 +189,42 @@ func (c *sharedServerContext) featureEnabled(flag string) bool {
 	defer c.featuresMutex.RUnlock()
 	return c.features.Contains(flag)
 }
+
+// controllerConfig returns the current controller configuration.
+func (c *sharedServerContext) controllerConfig() corecontroller.Config {
+	c.featuresMutex.RLock()
+	defer c.featuresMutex.RUnlock()
+	return c.config
+}
+
+// requestLogConfig returns the current request logging configuration.
+func (c *sharedServerContext) requestLogConfig() observer.RequestLogConfig {
+	c.requestLogMutex.RLock()
+	defer c.requestLogMutex.RUnlock()
+	return c.requestLog
+}
+
+func newRequestLogConfig(cfg corecontroller.Config) observer.RequestLogConfig {
+	return observer.RequestLogConfig{
+		Enabled:        cfg.RequestLogging(),
+		SamplePercent:  cfg.RequestLogSamplePercent(),
+		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+	}
+}
+
+// updateLogSinkRateLimit applies the logsink rate limits in the
+// controller config to the handlers' existing connections, falling
+// back to the agent's configuration for limits that are not set.
+func (c *sharedServerContext) updateLogSinkRateLimit(cfg corecontroller.Config) {
+	burst := cfg.AgentLogSinkRateLimitBurst()
+	if burst <= 0 {
+		burst = c.logSinkRateLimit.Burst
+	}
+	refill := cfg.AgentLogSinkRateLimitRefill()
+	if refill <= 0 {
+		refill = c.logSinkRateLimit.Refill
+	}
+	if c.logSinkRateLimiter.Update(burst, refill) {
+		c.logger.Infof("updating logsink rate limit to burst %d, refill %s", burst, refill)
+	}
+}
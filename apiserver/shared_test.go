@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/apiserver/logsink"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/presence"
@@ -45,6 +46,7 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 		},
 		PrometheusRegisterer: noopRegisterer{},
 		Cleanup:              func() {},
+		Clock:                clock.WallClock,
 	})
 	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, modelCache) })
 	c.Assert(err, jc.ErrorIsNil)
@@ -59,6 +61,7 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 		centralHub:   s.hub,
 		presence:     presence.New(clock.WallClock),
 		leaseManager: &lease.Manager{},
+		clock:        clock.WallClock,
 		logger:       loggo.GetLogger("test"),
 	}
 }
@@ -91,6 +94,13 @@ func (s *sharedServerContextSuite) TestConfigNoLeaseManager(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "nil leaseManager not valid")
 }
 
+func (s *sharedServerContextSuite) TestConfigNoClock(c *gc.C) {
+	s.config.clock = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil clock not valid")
+}
+
 func (s *sharedServerContextSuite) TestNewCallsConfigValidate(c *gc.C) {
 	s.config.statePool = nil
 	ctx, err := newSharedServerContex(s.config)
@@ -151,6 +161,73 @@ func (s *sharedServerContextSuite) TestControllerConfigChanged(c *gc.C) {
 	c.Check(stub.published, gc.HasLen, 0)
 }
 
+func (s *sharedServerContextSuite) TestRequestLogConfigChanged(c *gc.C) {
+	ctx := s.newContext(c)
+	c.Check(ctx.requestLogConfig().Enabled, jc.IsFalse)
+
+	msg := controller.ConfigChangedMessage{
+		Config: corecontroller.Config{
+			corecontroller.RequestLogging:          true,
+			corecontroller.RequestLogSamplePercent: 25,
+			corecontroller.AuditLogExcludeMethods:  []interface{}{"Client.FullStatus"},
+		},
+	}
+	done, err := s.hub.Publish(controller.ConfigChanged, msg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("handler didn't")
+	}
+
+	cfg := ctx.requestLogConfig()
+	c.Check(cfg.Enabled, jc.IsTrue)
+	c.Check(cfg.SamplePercent, gc.Equals, 25)
+	c.Check(cfg.ExcludeMethods.SortedValues(), jc.DeepEquals, []string{"Client.FullStatus"})
+}
+
+func (s *sharedServerContextSuite) TestLogSinkRateLimitConfigChanged(c *gc.C) {
+	s.config.logSinkRateLimit = logsink.RateLimitConfig{
+		Burst:  1000,
+		Refill: time.Millisecond,
+		Clock:  clock.WallClock,
+	}
+	ctx := s.newContext(c)
+	cfg := ctx.logSinkRateLimiter.Config()
+	c.Check(cfg.Burst, gc.Equals, int64(1000))
+	c.Check(cfg.Refill, gc.Equals, time.Millisecond)
+
+	publish := func(config corecontroller.Config) {
+		done, err := s.hub.Publish(controller.ConfigChanged, controller.ConfigChangedMessage{
+			Config: config,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-done:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("handler didn't")
+		}
+	}
+
+	publish(corecontroller.Config{
+		corecontroller.AgentLogSinkRateLimitBurst:  50,
+		corecontroller.AgentLogSinkRateLimitRefill: "100ms",
+	})
+	cfg = ctx.logSinkRateLimiter.Config()
+	c.Check(cfg.Burst, gc.Equals, int64(50))
+	c.Check(cfg.Refill, gc.Equals, 100*time.Millisecond)
+
+	// Limits removed from the controller config go back
+	// to the agent's configuration.
+	publish(corecontroller.Config{
+		corecontroller.AgentLogSinkRateLimitBurst: 50,
+	})
+	cfg = ctx.logSinkRateLimiter.Config()
+	c.Check(cfg.Burst, gc.Equals, int64(50))
+	c.Check(cfg.Refill, gc.Equals, time.Millisecond)
+}
+
 func (s *sharedServerContextSuite) TestAddingOldPresenceFeature(c *gc.C) {
 	// Adding the feature.OldPresence to the feature list will cause
 	// a message to be published on the hub to request an apiserver restart.
@@ -214,3 +291,63 @@ func (noopRegisterer) Register(prometheus.Collector) error {
 func (noopRegisterer) Unregister(prometheus.Collector) bool {
 	return true
 }
+
+func (s *sharedServerContextSuite) TestControllerHealth(c *gc.C) {
+	ctx := s.newContext(c)
+	c.Assert(ctx.health.Nodes(), gc.HasLen, 0)
+	c.Assert(ctx.health.Raft(), gc.IsNil)
+
+	now := time.Now()
+	done, err := s.hub.Publish(controller.NodeHealthTopic, controller.NodeHealthMessage{
+		Origin:       "machine-1",
+		AgentVersion: "2.7.0",
+		Time:         now,
+		ReplicaSet: []controller.ReplicaSetMember{{
+			MachineID: "1",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHandled(c, done)
+
+	done, err = s.hub.Publish(controller.RaftHealthTopic, controller.RaftHealthMessage{
+		Leader: "1",
+		Term:   3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHandled(c, done)
+
+	nodes := ctx.health.Nodes()
+	c.Assert(nodes, gc.HasLen, 1)
+	c.Check(nodes["1"].AgentVersion, gc.Equals, "2.7.0")
+	c.Check(nodes["1"].Time.Equal(now), jc.IsTrue)
+	c.Check(nodes["1"].Received.IsZero(), jc.IsFalse)
+	c.Check(nodes["1"].Reporting, jc.IsTrue)
+	c.Check(nodes["1"].ReplicaSet, gc.HasLen, 1)
+
+	raft := ctx.health.Raft()
+	c.Assert(raft, gc.NotNil)
+	c.Check(raft.Leader, gc.Equals, "1")
+	c.Check(raft.Term, gc.Equals, uint64(3))
+}
+
+func (s *sharedServerContextSuite) TestControllerHealthIgnoresBadOrigin(c *gc.C) {
+	ctx := s.newContext(c)
+
+	done, err := s.hub.Publish(controller.NodeHealthTopic, controller.NodeHealthMessage{
+		Origin: "unit-foo-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitHandled(c, done)
+	c.Assert(ctx.health.Nodes(), gc.HasLen, 0)
+}
+
+func (s *sharedServerContextSuite) waitHandled(c *gc.C, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("handler didn't")
+	}
+}
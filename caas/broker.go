@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/proxy"
 	"github.com/juju/version"
 	core "k8s.io/api/core/v1"
 
@@ -115,6 +116,26 @@ type ServiceParams struct {
 
 	// Devices is a set of parameters for Devices that is required.
 	Devices []devices.KubernetesDeviceParams
+
+	// Scheduling holds the constraints on where the
+	// pods are scheduled relative to each other.
+	Scheduling SchedulingParams
+
+	// ProxySettings holds the model's proxy settings,
+	// to be set in the environment of the pods.
+	ProxySettings proxy.Settings
+}
+
+// SchedulingParams holds the constraints on where an
+// application's pods are scheduled relative to each other.
+type SchedulingParams struct {
+	// SpreadAcrossNodes, if true, requires that no two
+	// pods of the application run on the same node.
+	SpreadAcrossNodes bool
+
+	// SpreadAcrossZones, if true, asks that the pods of the
+	// application be spread across availability zones.
+	SpreadAcrossZones bool
 }
 
 // OperatorState is returned by the OperatorExists call.
@@ -7,6 +7,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/resources"
 )
 
 const (
@@ -18,6 +20,10 @@ const (
 
 	// JujuDefaultApplicationPath is the default value for juju-application-path.
 	JujuDefaultApplicationPath = "/"
+
+	// JujuOperatorImagePath specifies the docker image used for a CAAS
+	// application's operator, overriding the controller's operator image.
+	JujuOperatorImagePath = "juju-operator-image-path"
 )
 
 var configFields = environschema.Fields{
@@ -31,6 +37,11 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	JujuOperatorImagePath: {
+		Description: "the docker image path used for the application's operator",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 // ConfigSchema returns the valid fields for a CAAS application config.
@@ -56,6 +67,17 @@ func configSchema(extra environschema.Fields) (environschema.Fields, error) {
 	return fields, nil
 }
 
+// ValidateConfig returns an error if any of the CAAS application config
+// attributes have values that the schema alone cannot reject.
+func ValidateConfig(attrs map[string]interface{}) error {
+	if imagePath, ok := attrs[JujuOperatorImagePath].(string); ok && imagePath != "" {
+		if err := resources.ValidateDockerRegistryPath(imagePath); err != nil {
+			return errors.Annotatef(err, "invalid %s", JujuOperatorImagePath)
+		}
+	}
+	return nil
+}
+
 // ConfigDefaults returns the default values for a CAAS application config.
 func ConfigDefaults(providerDefaults schema.Defaults) schema.Defaults {
 	defaults := schema.Defaults{
@@ -24,6 +24,11 @@ var baseFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	caas.JujuOperatorImagePath: {
+		Description: "the docker image path used for the application's operator",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var baseDefaults = schema.Defaults{
@@ -93,3 +98,21 @@ func (s *ConfigSuite) TestConfigSchemaProviderDefaults(c *gc.C) {
 	}
 	c.Assert(defaults, jc.DeepEquals, expectedDefaults)
 }
+
+func (s *ConfigSuite) TestValidateConfig(c *gc.C) {
+	err := caas.ValidateConfig(map[string]interface{}{
+		caas.JujuOperatorImagePath: "docker.io/jujusolutions/jujud-operator:2.6.0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = caas.ValidateConfig(map[string]interface{}{
+		caas.JujuOperatorImagePath: "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateConfigInvalidOperatorImagePath(c *gc.C) {
+	err := caas.ValidateConfig(map[string]interface{}{
+		caas.JujuOperatorImagePath: "Bad Image!",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid juju-operator-image-path: docker image path "Bad Image!" not valid`)
+}
@@ -18,6 +18,13 @@ var unsupportedConstraints = []string{
 	constraints.RootDisk,
 	constraints.InstanceType,
 	constraints.Spaces,
+	// Pods are limited with the mem constraint; cpu-quota and
+	// mem-limit only apply to units on machines.
+	constraints.CpuQuota,
+	constraints.MemLimit,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
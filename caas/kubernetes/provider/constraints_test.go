@@ -71,6 +71,11 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"root-disk=10M",
 		"spaces=foo",
 		"container=kvm",
+		"cpu-quota=50",
+		"mem-limit=1G",
+		"gpus=1",
+		"gpu-type=nvidia-tesla-k80",
+		"instance-lifecycle=preemptible",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -83,6 +88,11 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"root-disk",
 		"spaces",
 		"container",
+		"cpu-quota",
+		"mem-limit",
+		"gpus",
+		"gpu-type",
+		"instance-lifecycle",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
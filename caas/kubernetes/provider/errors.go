@@ -4,8 +4,16 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/core/status"
 )
 
 // ClusterQueryError represents an issue when querying a cluster.
@@ -65,3 +73,135 @@ func MaskError(err error) bool {
 	_, ok := errors.Cause(err).(*k8serrors.StatusError)
 	return ok
 }
+
+// QuotaExceededError occurs when creating or scaling an application's
+// pods would exceed a namespace ResourceQuota. Each map is keyed on the
+// quota's resource names, eg "limits.memory" or "pods".
+type QuotaExceededError struct {
+	Message   string
+	Quota     string
+	Requested map[string]string
+	Used      map[string]string
+	Limited   map[string]string
+}
+
+func (e QuotaExceededError) Error() string {
+	return e.Message
+}
+
+// Remaining returns the capacity remaining under the quota for each
+// of the resources whose limit would have been exceeded.
+func (e QuotaExceededError) Remaining() map[string]string {
+	remaining := make(map[string]string)
+	for name, limit := range e.Limited {
+		limited, err := resource.ParseQuantity(limit)
+		if err != nil {
+			continue
+		}
+		used, err := resource.ParseQuantity(e.Used[name])
+		if err != nil {
+			continue
+		}
+		limited.Sub(used)
+		if limited.Sign() < 0 {
+			limited.Set(0)
+		}
+		remaining[name] = limited.String()
+	}
+	return remaining
+}
+
+// Summary describes the quota, the resources requested and the
+// capacity remaining, for reporting as the application's status.
+func (e QuotaExceededError) Summary() string {
+	return fmt.Sprintf("exceeded quota %q: requested %s, remaining %s",
+		e.Quota, formatResources(e.Requested), formatResources(e.Remaining()))
+}
+
+// Status returns the status reported for an application whose pods
+// can not be created because the quota would be exceeded.
+func (e QuotaExceededError) Status() status.StatusInfo {
+	return status.StatusInfo{
+		Status:  status.Blocked,
+		Message: e.Summary(),
+		Data:    map[string]interface{}{"quota": e.Quota},
+	}
+}
+
+// IsQuotaExceededStatus returns true if the status reports that an
+// application's pods can not be created because a quota would be
+// exceeded.
+func IsQuotaExceededStatus(info status.StatusInfo) bool {
+	if info.Status != status.Blocked {
+		return false
+	}
+	_, ok := info.Data["quota"]
+	return ok
+}
+
+// IsQuotaExceededError returns true if err is a QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	_, ok := errors.Cause(err).(QuotaExceededError)
+	return ok
+}
+
+// quotaExceededRegexp matches the message of the error returned by
+// the ResourceQuota admission controller.
+var quotaExceededRegexp = regexp.MustCompile(
+	`exceeded quota: ([^,]+), requested: (\S*), used: (\S*), limited: (\S*)`,
+)
+
+// newQuotaExceededError returns a QuotaExceededError describing err,
+// if err is a forbidden error from the ResourceQuota admission
+// controller.
+func newQuotaExceededError(err error) (QuotaExceededError, bool) {
+	statusErr, ok := errors.Cause(err).(*k8serrors.StatusError)
+	if !ok || !k8serrors.IsForbidden(statusErr) {
+		return QuotaExceededError{}, false
+	}
+	return quotaExceededFromMessage(statusErr.Error())
+}
+
+// quotaExceededFromMessage returns a QuotaExceededError describing the
+// message, if it contains the message of an error returned by the
+// ResourceQuota admission controller. Controllers which fail to create
+// pods report such messages in conditions and events.
+func quotaExceededFromMessage(message string) (QuotaExceededError, bool) {
+	match := quotaExceededRegexp.FindStringSubmatch(message)
+	if match == nil {
+		return QuotaExceededError{}, false
+	}
+	return QuotaExceededError{
+		Message:   message,
+		Quota:     match[1],
+		Requested: parseResources(match[2]),
+		Used:      parseResources(match[3]),
+		Limited:   parseResources(match[4]),
+	}, true
+}
+
+// parseResources parses resources formatted as by the ResourceQuota
+// admission controller, eg "limits.memory=2Gi,pods=1".
+func parseResources(in string) map[string]string {
+	out := make(map[string]string)
+	for _, part := range strings.Split(in, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			continue
+		}
+		out[kv[0]] = kv[1]
+	}
+	return out
+}
+
+func formatResources(in map[string]string) string {
+	if len(in) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(in))
+	for name, value := range in {
+		parts = append(parts, name+"="+value)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/status"
+)
+
+type errorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (s *errorsSuite) TestQuotaExceeded(c *gc.C) {
+	err := errors.Trace(k8serrors.NewForbidden(
+		schema.GroupResource{Resource: "pods"}, "gitlab-0",
+		errors.New("exceeded quota: compute-resources, requested: limits.memory=2Gi,pods=1, "+
+			"used: limits.memory=7Gi,pods=4, limited: limits.memory=8Gi,pods=5"),
+	))
+	quotaErr, ok := provider.NewQuotaExceededError(err)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(quotaErr.Quota, gc.Equals, "compute-resources")
+	c.Assert(quotaErr.Requested, jc.DeepEquals, map[string]string{"limits.memory": "2Gi", "pods": "1"})
+	c.Assert(quotaErr.Used, jc.DeepEquals, map[string]string{"limits.memory": "7Gi", "pods": "4"})
+	c.Assert(quotaErr.Limited, jc.DeepEquals, map[string]string{"limits.memory": "8Gi", "pods": "5"})
+	c.Assert(quotaErr.Remaining(), jc.DeepEquals, map[string]string{"limits.memory": "1Gi", "pods": "1"})
+	c.Assert(quotaErr.Summary(), gc.Equals,
+		`exceeded quota "compute-resources": requested limits.memory=2Gi,pods=1, remaining limits.memory=1Gi,pods=1`)
+	c.Assert(provider.IsQuotaExceededError(errors.Wrap(err, quotaErr)), jc.IsTrue)
+	c.Assert(provider.MaskError(err), jc.IsTrue)
+}
+
+func (s *errorsSuite) TestQuotaExceededNoneRemaining(c *gc.C) {
+	quotaErr := provider.QuotaExceededError{
+		Quota:     "object-counts",
+		Requested: map[string]string{"pods": "2"},
+		Used:      map[string]string{"pods": "6"},
+		Limited:   map[string]string{"pods": "5"},
+	}
+	c.Assert(quotaErr.Remaining(), jc.DeepEquals, map[string]string{"pods": "0"})
+}
+
+func (s *errorsSuite) TestNotQuotaExceeded(c *gc.C) {
+	for _, err := range []error{
+		errors.New("exceeded quota: compute-resources, requested: pods=1, used: pods=4, limited: pods=5"),
+		k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "gitlab-0", errors.New("no")),
+		k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gitlab-0"),
+	} {
+		_, ok := provider.NewQuotaExceededError(err)
+		c.Check(ok, jc.IsFalse)
+		c.Check(provider.IsQuotaExceededError(err), jc.IsFalse)
+	}
+}
+
+func (s *errorsSuite) TestQuotaExceededStatus(c *gc.C) {
+	quotaErr := provider.QuotaExceededError{
+		Quota:     "object-counts",
+		Requested: map[string]string{"pods": "1"},
+		Used:      map[string]string{"pods": "5"},
+		Limited:   map[string]string{"pods": "5"},
+	}
+	info := quotaErr.Status()
+	c.Assert(info, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Blocked,
+		Message: `exceeded quota "object-counts": requested pods=1, remaining pods=0`,
+		Data:    map[string]interface{}{"quota": "object-counts"},
+	})
+	c.Assert(provider.IsQuotaExceededStatus(info), jc.IsTrue)
+	c.Assert(provider.IsQuotaExceededStatus(status.StatusInfo{Status: status.Blocked}), jc.IsFalse)
+}
+
+func (s *errorsSuite) TestDeploymentQuotaExceeded(c *gc.C) {
+	deployment := &apps.Deployment{
+		Status: apps.DeploymentStatus{
+			Conditions: []apps.DeploymentCondition{{
+				Type:   apps.DeploymentProgressing,
+				Status: core.ConditionTrue,
+			}, {
+				Type:   apps.DeploymentReplicaFailure,
+				Status: core.ConditionTrue,
+				Reason: "FailedCreate",
+				Message: `pods "gitlab-7c9f-x2k" is forbidden: exceeded quota: object-counts, ` +
+					`requested: pods=1, used: pods=5, limited: pods=5`,
+			}},
+		},
+	}
+	quotaErr := provider.DeploymentQuotaExceeded(deployment)
+	c.Assert(quotaErr, gc.NotNil)
+	c.Assert(quotaErr.Quota, gc.Equals, "object-counts")
+	c.Assert(quotaErr.Requested, jc.DeepEquals, map[string]string{"pods": "1"})
+
+	deployment.Status.Conditions[1].Status = core.ConditionFalse
+	c.Assert(provider.DeploymentQuotaExceeded(deployment), gc.IsNil)
+}
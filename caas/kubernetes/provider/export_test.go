@@ -36,6 +36,9 @@ var (
 	NewK8sBroker             = newK8sBroker
 	ToYaml                   = toYaml
 	Indent                   = indent
+	ConfigureProxySettings   = configureProxySettings
+	NewQuotaExceededError    = newQuotaExceededError
+	DeploymentQuotaExceeded  = deploymentQuotaExceeded
 )
 
 type (
@@ -960,6 +960,13 @@ func (k *kubernetesClient) GetService(appName string, includeClusterIP bool) (*c
 			Status:  ssStatus,
 			Message: message,
 		}
+		quotaErr, err := k.statefulSetQuotaExceeded(ss)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting status for %s", ss.Name)
+		}
+		if quotaErr != nil {
+			result.Status = quotaErr.Status()
+		}
 		return &result, nil
 	}
 	if !k8serrors.IsNotFound(err) {
@@ -986,6 +993,9 @@ func (k *kubernetesClient) GetService(appName string, includeClusterIP bool) (*c
 			Status:  ssStatus,
 			Message: message,
 		}
+		if quotaErr := deploymentQuotaExceeded(deployment); quotaErr != nil {
+			result.Status = quotaErr.Status()
+		}
 	}
 	return &result, nil
 }
@@ -1084,9 +1094,13 @@ func (k *kubernetesClient) EnsureService(
 	config application.ConfigAttributes,
 ) (err error) {
 	defer func() {
-		if err != nil {
-			_ = statusCallback(appName, status.Error, err.Error(), nil)
+		if err == nil {
+			return
+		}
+		if quotaErr, ok := newQuotaExceededError(err); ok {
+			err = errors.Wrap(err, quotaErr)
 		}
+		_ = statusCallback(appName, status.Error, err.Error(), nil)
 	}()
 
 	logger.Debugf("creating/updating application %s", appName)
@@ -1205,11 +1219,13 @@ func (k *kubernetesClient) EnsureService(
 		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
 		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
 			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Key:      zoneTopologyKey,
 				Operator: core.NodeSelectorOpIn,
 				Values:   zones,
 			})
 	}
+	configureScheduling(unitSpec, appName, params.Scheduling)
+	configureProxySettings(&unitSpec.Pod, params.ProxySettings)
 
 	annotations := resourceTagsToAnnotations(params.ResourceTags)
 
@@ -2308,6 +2324,43 @@ func (k *kubernetesClient) getDeploymentStatus(deployment *apps.Deployment) (str
 	return k.getStatusFromEvents(deployment.Name, "Deployment", jujuStatus)
 }
 
+// statefulSetQuotaExceeded returns the error describing why pods can
+// not be created for the stateful set, if the most recent event for it
+// reports that a resource quota would be exceeded.
+func (k *kubernetesClient) statefulSetQuotaExceeded(ss *apps.StatefulSet) (*QuotaExceededError, error) {
+	if ss.Spec.Replicas == nil || ss.Status.Replicas >= *ss.Spec.Replicas {
+		return nil, nil
+	}
+	events, err := k.getEvents(ss.Name, "StatefulSet")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count := len(events); count > 0 {
+		evt := events[count-1]
+		if evt.Type == core.EventTypeWarning && evt.Reason == "FailedCreate" {
+			if quotaErr, ok := quotaExceededFromMessage(evt.Message); ok {
+				return &quotaErr, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// deploymentQuotaExceeded returns the error describing why pods can not
+// be created for the deployment, if its replica failure condition
+// reports that a resource quota would be exceeded.
+func deploymentQuotaExceeded(deployment *apps.Deployment) *QuotaExceededError {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type != apps.DeploymentReplicaFailure || cond.Status != core.ConditionTrue {
+			continue
+		}
+		if quotaErr, ok := quotaExceededFromMessage(cond.Message); ok {
+			return &quotaErr
+		}
+	}
+	return nil
+}
+
 func (k *kubernetesClient) getStatusFromEvents(name, kind string, jujuStatus status.Status) (string, status.Status, error) {
 	events, err := k.getEvents(name, kind)
 	if err != nil {
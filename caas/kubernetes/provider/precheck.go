@@ -41,8 +41,8 @@ func (k *kubernetesClient) PrecheckInstance(ctx context.ProviderCallContext, par
 		return errors.NotValidf("series %q", params.Series)
 	}
 
-	if params.Placement != "" {
-		return errors.NotValidf("placement directive %q", params.Placement)
+	if _, err := ParseSchedulingPlacement(params.Placement); err != nil {
+		return errors.Trace(err)
 	}
 	if params.Constraints.Tags == nil {
 		return nil
@@ -68,6 +68,17 @@ func (s *PrecheckSuite) TestPlacementNotAllowed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `placement directive "a" not valid`)
 }
 
+func (s *PrecheckSuite) TestSpreadPlacement(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.PrecheckInstance(context.NewCloudCallContext(), environs.PrecheckInstanceParams{
+		Series:    "kubernetes",
+		Placement: "spread=node|zone",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *PrecheckSuite) TestInvalidConstraints(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,52 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/juju/proxy"
+	core "k8s.io/api/core/v1"
+)
+
+// configureProxySettings adds the model's proxy settings to the
+// environment of all of the pod's containers, so that workloads
+// reach the outside world the same way as machine agents do. Any
+// variable already set by the charm's pod spec is left alone.
+// Because the settings are part of the pod template, a change to
+// them rolls the application's pods.
+func configureProxySettings(pod *core.PodSpec, settings proxy.Settings) {
+	var proxyEnv []core.EnvVar
+	for _, value := range settings.AsEnvironmentValues() {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		proxyEnv = append(proxyEnv, core.EnvVar{Name: parts[0], Value: parts[1]})
+	}
+	if len(proxyEnv) == 0 {
+		return
+	}
+	for i := range pod.Containers {
+		pod.Containers[i].Env = mergeEnv(pod.Containers[i].Env, proxyEnv)
+	}
+	for i := range pod.InitContainers {
+		pod.InitContainers[i].Env = mergeEnv(pod.InitContainers[i].Env, proxyEnv)
+	}
+}
+
+// mergeEnv returns env with the variables in extra appended,
+// skipping any which env already sets.
+func mergeEnv(env, extra []core.EnvVar) []core.EnvVar {
+	existing := make(map[string]bool)
+	for _, v := range env {
+		existing[v.Name] = true
+	}
+	for _, v := range extra {
+		if !existing[v.Name] {
+			env = append(env, v)
+		}
+	}
+	return env
+}
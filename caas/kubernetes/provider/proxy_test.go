@@ -0,0 +1,68 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/proxy"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+)
+
+type proxySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&proxySuite{})
+
+func (s *proxySuite) TestConfigureProxySettings(c *gc.C) {
+	pod := &core.PodSpec{
+		Containers: []core.Container{{
+			Name: "test",
+			Env: []core.EnvVar{
+				{Name: "foo", Value: "bar"},
+				{Name: "HTTPS_PROXY", Value: "https://charm.proxy"},
+			},
+		}},
+		InitContainers: []core.Container{{
+			Name: "init",
+		}},
+	}
+	provider.ConfigureProxySettings(pod, proxy.Settings{
+		Http:    "http://proxy.example.com:3128",
+		Https:   "https://proxy.example.com:3129",
+		NoProxy: "10.0.0.0/8",
+	})
+	c.Assert(pod.Containers[0].Env, jc.DeepEquals, []core.EnvVar{
+		{Name: "foo", Value: "bar"},
+		{Name: "HTTPS_PROXY", Value: "https://charm.proxy"},
+		{Name: "http_proxy", Value: "http://proxy.example.com:3128"},
+		{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "https_proxy", Value: "https://proxy.example.com:3129"},
+		{Name: "no_proxy", Value: "10.0.0.0/8"},
+		{Name: "NO_PROXY", Value: "10.0.0.0/8"},
+	})
+	c.Assert(pod.InitContainers[0].Env, jc.DeepEquals, []core.EnvVar{
+		{Name: "http_proxy", Value: "http://proxy.example.com:3128"},
+		{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "https_proxy", Value: "https://proxy.example.com:3129"},
+		{Name: "HTTPS_PROXY", Value: "https://proxy.example.com:3129"},
+		{Name: "no_proxy", Value: "10.0.0.0/8"},
+		{Name: "NO_PROXY", Value: "10.0.0.0/8"},
+	})
+}
+
+func (s *proxySuite) TestConfigureProxySettingsNone(c *gc.C) {
+	pod := &core.PodSpec{
+		Containers: []core.Container{{
+			Name: "test",
+			Env:  []core.EnvVar{{Name: "foo", Value: "bar"}},
+		}},
+	}
+	provider.ConfigureProxySettings(pod, proxy.Settings{})
+	c.Assert(pod.Containers[0].Env, jc.DeepEquals, []core.EnvVar{{Name: "foo", Value: "bar"}})
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+const (
+	// spreadPlacementKey is the placement directive key used to
+	// spread an application's pods across nodes or zones, for
+	// example "spread=node" or "spread=node|zone".
+	spreadPlacementKey = "spread"
+
+	nodeTopologyKey = "kubernetes.io/hostname"
+	zoneTopologyKey = "failure-domain.beta.kubernetes.io/zone"
+)
+
+// ParseSchedulingPlacement parses the placement directive of a
+// Kubernetes application into the scheduling constraints for its pods.
+// An empty directive yields no scheduling constraints.
+func ParseSchedulingPlacement(directive string) (caas.SchedulingParams, error) {
+	var result caas.SchedulingParams
+	if directive == "" {
+		return result, nil
+	}
+	parts := strings.SplitN(directive, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != spreadPlacementKey {
+		return result, errors.NotValidf("placement directive %q", directive)
+	}
+	for _, topology := range strings.Split(parts[1], "|") {
+		switch strings.TrimSpace(topology) {
+		case "node":
+			result.SpreadAcrossNodes = true
+		case "zone":
+			result.SpreadAcrossZones = true
+		default:
+			return caas.SchedulingParams{}, errors.NotValidf("placement directive %q", directive)
+		}
+	}
+	return result, nil
+}
+
+// configureScheduling adds pod anti-affinity rules to the unit spec so
+// that the application's pods are spread as requested. Spreading across
+// nodes is required, so no two pods share a node; spreading across zones
+// is preferred, so pods can still be scheduled if a zone is full.
+func configureScheduling(unitSpec *unitSpec, appName string, scheduling caas.SchedulingParams) {
+	if !scheduling.SpreadAcrossNodes && !scheduling.SpreadAcrossZones {
+		return
+	}
+	appSelector := &v1.LabelSelector{
+		MatchLabels: map[string]string{labelApplication: appName},
+	}
+	antiAffinity := &core.PodAntiAffinity{}
+	if scheduling.SpreadAcrossNodes {
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []core.PodAffinityTerm{{
+			LabelSelector: appSelector,
+			TopologyKey:   nodeTopologyKey,
+		}}
+	}
+	if scheduling.SpreadAcrossZones {
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []core.WeightedPodAffinityTerm{{
+			Weight: 100,
+			PodAffinityTerm: core.PodAffinityTerm{
+				LabelSelector: appSelector,
+				TopologyKey:   zoneTopologyKey,
+			},
+		}}
+	}
+	if unitSpec.Pod.Affinity == nil {
+		unitSpec.Pod.Affinity = &core.Affinity{}
+	}
+	unitSpec.Pod.Affinity.PodAntiAffinity = antiAffinity
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+)
+
+type schedulingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&schedulingSuite{})
+
+func (s *schedulingSuite) TestParseSchedulingPlacement(c *gc.C) {
+	for i, test := range []struct {
+		directive string
+		expected  caas.SchedulingParams
+	}{{
+		directive: "",
+	}, {
+		directive: "spread=node",
+		expected:  caas.SchedulingParams{SpreadAcrossNodes: true},
+	}, {
+		directive: "spread=zone",
+		expected:  caas.SchedulingParams{SpreadAcrossZones: true},
+	}, {
+		directive: "spread = zone | node",
+		expected:  caas.SchedulingParams{SpreadAcrossNodes: true, SpreadAcrossZones: true},
+	}} {
+		c.Logf("test %d: %q", i, test.directive)
+		scheduling, err := provider.ParseSchedulingPlacement(test.directive)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(scheduling, gc.Equals, test.expected)
+	}
+}
+
+func (s *schedulingSuite) TestParseSchedulingPlacementInvalid(c *gc.C) {
+	for _, directive := range []string{"a", "spread", "spread=", "spread=rack", "zone=a"} {
+		_, err := provider.ParseSchedulingPlacement(directive)
+		c.Check(err, gc.ErrorMatches, `placement directive ".*" not valid`)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
@@ -187,9 +188,8 @@ func (c FakeClient) ListResources(channel params.Channel, id *charm.URL) ([]para
 	return c.repo.ListResources(id)
 }
 
-// Publish marks id as published against channels within the charm store.
-//
-// In this implementation, the resources parameter is ignored.
+// Publish marks id as published against channels within the charm store,
+// pinning the given resource revisions in those channels.
 func (c FakeClient) Publish(id *charm.URL, channels []params.Channel, resources map[string]int) error {
 	return c.repo.Publish(id, channels, resources)
 }
@@ -284,9 +284,8 @@ func (c ChannelAwareFakeClient) ListResources(id *charm.URL) ([]params.Resource,
 	return c.charmstore.ListResources(c.channel, id)
 }
 
-// Publish marks id as published against channels within the charm store.
-//
-// In this implementation, the resources parameter is ignored.
+// Publish marks id as published against channels within the charm store,
+// pinning the given resource revisions in those channels.
 func (c ChannelAwareFakeClient) Publish(id *charm.URL, channels []params.Channel, resources map[string]int) error {
 	return c.charmstore.Publish(id, channels, resources)
 }
@@ -308,47 +307,123 @@ func (c ChannelAwareFakeClient) WithChannel(channel params.Channel) *ChannelAwar
 // about which charm revisions it knows about is decoupled from the charm data that it currently
 // stores.
 //
+// Simulating the charm store
+//
+// Repository simulates a few charm store behaviours that deploy tests
+// rely upon:
+//
+//  - resource revisions are pinned per channel when an entity is published
+//    with resources, and the latest revisions are used otherwise
+//  - promulgated charms and bundles may be referred to without their owner,
+//    see SetPromulgated
+//  - read permissions set by putting a list of users to an entity's
+//    "meta/perm/read" path are enforced against the user set with SetUser
+//
 // Related Interfaces
 //
 // Repository implements gopkg.in/juju/charmrepo Interface and derivative
 // interfaces, such as github.com/juju/juju/cmd/juju/application DeployAPI.
 type Repository struct {
-	channel       params.Channel
-	charms        map[params.Channel]map[charm.URL]charm.Charm
-	bundles       map[params.Channel]map[charm.URL]charm.Bundle
-	resources     map[params.Channel]map[charm.URL][]params.Resource
-	revisions     map[params.Channel]map[charm.URL]int
-	added         map[string][]charm.URL
-	resourcesData datastore
-	generations   map[string]string
-	published     map[params.Channel]set.Strings
+	channel           params.Channel
+	charms            map[params.Channel]map[charm.URL]charm.Charm
+	bundles           map[params.Channel]map[charm.URL]charm.Bundle
+	resourceRevisions map[charm.URL]map[string][]params.Resource
+	resourcePins      map[params.Channel]map[charm.URL]map[string]int
+	revisions         map[params.Channel]map[charm.URL]int
+	added             map[string][]charm.URL
+	resourcesData     datastore
+	generations       map[string]string
+	published         map[params.Channel]set.Strings
+	promulgated       map[charm.URL]string
+	user              string
 }
 
 // NewRepository returns an empty Repository. To populate it with charms, bundles and resources
 // use UploadCharm, UploadBundle and/or UploadResource.
 func NewRepository() *Repository {
 	repo := Repository{
-		channel:       params.StableChannel,
-		charms:        make(map[params.Channel]map[charm.URL]charm.Charm),
-		bundles:       make(map[params.Channel]map[charm.URL]charm.Bundle),
-		resources:     make(map[params.Channel]map[charm.URL][]params.Resource),
-		revisions:     make(map[params.Channel]map[charm.URL]int),
-		added:         make(map[string][]charm.URL),
-		resourcesData: make(datastore),
-		published:     make(map[params.Channel]set.Strings),
+		channel:           params.StableChannel,
+		charms:            make(map[params.Channel]map[charm.URL]charm.Charm),
+		bundles:           make(map[params.Channel]map[charm.URL]charm.Bundle),
+		resourceRevisions: make(map[charm.URL]map[string][]params.Resource),
+		resourcePins:      make(map[params.Channel]map[charm.URL]map[string]int),
+		revisions:         make(map[params.Channel]map[charm.URL]int),
+		added:             make(map[string][]charm.URL),
+		resourcesData:     make(datastore),
+		published:         make(map[params.Channel]set.Strings),
+		promulgated:       make(map[charm.URL]string),
 	}
 	for _, channel := range params.OrderedChannels {
 		repo.charms[channel] = make(map[charm.URL]charm.Charm)
 		repo.bundles[channel] = make(map[charm.URL]charm.Bundle)
-		repo.resources[channel] = make(map[charm.URL][]params.Resource)
+		repo.resourcePins[channel] = make(map[charm.URL]map[string]int)
 		repo.revisions[channel] = make(map[charm.URL]int)
 		repo.published[channel] = set.NewStrings()
 	}
 	return &repo
 }
 
+// SetUser sets the name of the user that the repository acts on behalf
+// of when checking read permissions. The default is the anonymous user,
+// with an empty name.
+func (r *Repository) SetUser(user string) {
+	r.user = user
+}
+
+// SetPromulgated marks the charm or bundle owned by the user in id as
+// promulgated, or not. Promulgated entities may be referred to without
+// their owner, so if ~charmers/trusty/wordpress is promulgated,
+// trusty/wordpress refers to it.
+func (r Repository) SetPromulgated(id *charm.URL, promulgated bool) {
+	unowned := *id.WithRevision(-1)
+	unowned.User = ""
+	if promulgated {
+		r.promulgated[unowned] = id.User
+	} else {
+		delete(r.promulgated, unowned)
+	}
+}
+
+// owned returns ref with its user set to the owner of the promulgated
+// entity it refers to, if any; otherwise ref is returned unchanged.
+func (r Repository) owned(ref *charm.URL) *charm.URL {
+	if ref.User != "" {
+		return ref
+	}
+	owner, ok := r.promulgated[*ref.WithRevision(-1)]
+	if !ok {
+		return ref
+	}
+	withOwner := *ref
+	withOwner.User = owner
+	return &withOwner
+}
+
+// checkReadAccess returns an error satisfying params.ErrUnauthorized if
+// read permissions have been put for id, or for id without its revision,
+// and they do not include the repository's user or everyone.
+func (r Repository) checkReadAccess(id *charm.URL) error {
+	id = r.owned(id)
+	perms, ok := r.resourcesData["/"+id.Path()+"/meta/perm/read"].([]string)
+	if !ok {
+		perms, ok = r.resourcesData["/"+id.WithRevision(-1).Path()+"/meta/perm/read"].([]string)
+	}
+	if !ok {
+		return nil
+	}
+	for _, perm := range perms {
+		if perm == params.Everyone || perm == r.user {
+			return nil
+		}
+	}
+	return errors.Annotatef(&params.Error{
+		Code:    params.ErrUnauthorized,
+		Message: fmt.Sprintf("access denied for user %q", r.user),
+	}, "cannot get %q", "/"+id.Path()+"/meta/any?include=id&include=supported-series&include=published")
+}
+
 func (r *Repository) addRevision(ref *charm.URL) *charm.URL {
-	revision := r.revisions[r.channel][*ref]
+	revision := r.revisions[r.channel][*r.owned(ref)]
 	return ref.WithRevision(revision)
 }
 
@@ -421,6 +496,9 @@ func (r Repository) CharmInfo(charmURL string) (*charms.CharmInfo, error) {
 //
 // Part of the charmrepo.Interface
 func (r Repository) Resolve(ref *charm.URL) (canonRef *charm.URL, supportedSeries []string, err error) {
+	if err := r.checkReadAccess(ref); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	return r.addRevision(ref), []string{"trusty", "wily", "quantal"}, nil
 }
 
@@ -436,8 +514,11 @@ func (r Repository) ResolveWithChannel(ref *charm.URL) (*charm.URL, params.Chann
 //
 // Part of the charmrepo.Interface
 func (r Repository) Get(id *charm.URL) (charm.Charm, error) {
+	if err := r.checkReadAccess(id); err != nil {
+		return nil, errors.Trace(err)
+	}
 	withRevision := r.addRevision(id)
-	charmData := r.charms[r.channel][*withRevision]
+	charmData := r.charms[r.channel][*r.owned(withRevision)]
 	if charmData == nil {
 		return charmData, errors.NotFoundf("cannot retrieve \"%v\": charm", id.String())
 	}
@@ -448,7 +529,10 @@ func (r Repository) Get(id *charm.URL) (charm.Charm, error) {
 //
 // Part of the charmrepo.Interface
 func (r Repository) GetBundle(id *charm.URL) (charm.Bundle, error) {
-	bundleData := r.bundles[r.channel][*id]
+	if err := r.checkReadAccess(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	bundleData := r.bundles[r.channel][*r.owned(id)]
 	if bundleData == nil {
 		return nil, errors.NotFoundf(id.String())
 	}
@@ -460,12 +544,39 @@ func (r Repository) GetBundle(id *charm.URL) (charm.Bundle, error) {
 // Although id is type *charm.URL, resources are not restricted to charms. That
 // type is also used for other entities in the charmstore, such as bundles.
 //
+// The resource revisions returned are those pinned when id was published
+// in the repository's current channel, or the latest revisions uploaded
+// for resources that have not been pinned.
+//
 // Returns an error that satisfies errors.IsNotFound when no resources
 // are present for id.
 func (r Repository) ListResources(id *charm.URL) ([]params.Resource, error) {
-	resources := r.resources[r.channel][*id]
-	if len(resources) == 0 {
-		return resources, errors.NotFoundf("no resources for %v", id)
+	if err := r.checkReadAccess(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	id = r.owned(id)
+	revisions := r.resourceRevisions[*id]
+	if len(revisions) == 0 {
+		return nil, errors.NotFoundf("no resources for %v", id)
+	}
+	names := make([]string, 0, len(revisions))
+	for name := range revisions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pins := r.resourcePins[r.channel][*id]
+	resources := make([]params.Resource, len(names))
+	for i, name := range names {
+		history := revisions[name]
+		revision := len(history) - 1
+		if pinned, ok := pins[name]; ok {
+			if pinned < 0 || pinned >= len(history) {
+				return nil, errors.NotFoundf("revision %d of resource %q for %v", pinned, name, id)
+			}
+			revision = pinned
+		}
+		resources[i] = history[revision]
 	}
 	return resources, nil
 }
@@ -531,18 +642,17 @@ func (r Repository) UploadBundleWithRevision(id *charm.URL, bundleData charm.Bun
 //
 // In this implementation, the progress parameter is ignored.
 func (r Repository) UploadResource(id *charm.URL, name, path string, file io.ReaderAt, size int64, progress csclient.Progress) (revision int, err error) {
-	if len(r.resources[r.channel]) == 0 {
-		r.resources[r.channel] = make(map[charm.URL][]params.Resource)
-	}
-	resources, err := r.ListResources(id)
-	if err != nil {
-		return -1, errors.Trace(err)
+	id = r.owned(id)
+	revisions := r.resourceRevisions[*id]
+	if revisions == nil {
+		revisions = make(map[string][]params.Resource)
+		r.resourceRevisions[*id] = revisions
 	}
 
-	revision = len(resources)
-	data := []byte{}
+	revision = len(revisions[name])
+	data := make([]byte, size)
 	_, err = file.ReadAt(data, 0)
-	if err != nil {
+	if err != nil && err != io.EOF {
 		return -1, errors.Trace(err)
 	}
 
@@ -550,7 +660,7 @@ func (r Repository) UploadResource(id *charm.URL, name, path string, file io.Rea
 	if err != nil {
 		return -1, errors.Trace(err)
 	}
-	r.resources[r.channel][*id] = append(resources, params.Resource{
+	revisions[name] = append(revisions[name], params.Resource{
 		Name:        name,
 		Path:        path,
 		Revision:    revision,
@@ -565,14 +675,27 @@ func (r Repository) UploadResource(id *charm.URL, name, path string, file io.Rea
 	return revision, nil
 }
 
-// Publish marks a charm or bundle as published within channels.
-//
-// In this implementation, the resources parameter is ignored.
+// Publish marks a charm or bundle as published within channels, pinning
+// the given resource revisions in those channels. Resources that are not
+// pinned are listed at their latest revision.
 func (r Repository) Publish(id *charm.URL, channels []params.Channel, resources map[string]int) error {
+	id = r.owned(id)
 	for _, channel := range channels {
 		published := r.published[channel]
 		published.Add(id.String())
 		r.published[channel] = published
+
+		if len(resources) == 0 {
+			continue
+		}
+		if r.resourcePins[channel] == nil {
+			r.resourcePins[channel] = make(map[charm.URL]map[string]int)
+		}
+		pins := make(map[string]int)
+		for name, revision := range resources {
+			pins[name] = revision
+		}
+		r.resourcePins[channel][*id] = pins
 	}
 	return nil
 }
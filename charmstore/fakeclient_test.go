@@ -0,0 +1,155 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/testcharms"
+)
+
+type FakeClientSuite struct {
+	testing.IsolationSuite
+
+	repo   *charmstore.Repository
+	client *charmstore.FakeClient
+}
+
+var _ = gc.Suite(&FakeClientSuite{})
+
+func (s *FakeClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.repo = charmstore.NewRepository()
+	s.client = charmstore.NewFakeClient(s.repo)
+}
+
+func (s *FakeClientSuite) uploadResource(c *gc.C, id *charm.URL, name, content string) int {
+	revision, err := s.client.UploadResource(id, name, name+".tgz", strings.NewReader(content), int64(len(content)), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return revision
+}
+
+func resourceRevisions(resources []params.Resource) map[string]int {
+	revisions := make(map[string]int)
+	for _, r := range resources {
+		revisions[r.Name] = r.Revision
+	}
+	return revisions
+}
+
+func isUnauthorized(err error) bool {
+	csErr, ok := errors.Cause(err).(*params.Error)
+	return ok && csErr.Code == params.ErrUnauthorized
+}
+
+func (s *FakeClientSuite) TestUploadResourceRevisions(c *gc.C) {
+	id := charm.MustParseURL("cs:~bob/trusty/wordpress-1")
+	_, err := s.client.ListResources(params.StableChannel, id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(s.uploadResource(c, id, "data", "one"), gc.Equals, 0)
+	c.Assert(s.uploadResource(c, id, "data", "two"), gc.Equals, 1)
+	c.Assert(s.uploadResource(c, id, "config", "three"), gc.Equals, 0)
+
+	resources, err := s.client.ListResources(params.StableChannel, id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceRevisions(resources), jc.DeepEquals, map[string]int{"config": 0, "data": 1})
+	c.Assert(resources[1].Size, gc.Equals, int64(3))
+}
+
+func (s *FakeClientSuite) TestPublishPinsResourceRevisionsPerChannel(c *gc.C) {
+	id := charm.MustParseURL("cs:~bob/trusty/wordpress-1")
+	s.uploadResource(c, id, "data", "one")
+	s.uploadResource(c, id, "data", "two")
+
+	err := s.client.Publish(id, []params.Channel{params.StableChannel}, map[string]int{"data": 0})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.Publish(id, []params.Channel{params.EdgeChannel}, map[string]int{"data": 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := s.client.WithChannel(params.StableChannel).ListResources(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceRevisions(resources), jc.DeepEquals, map[string]int{"data": 0})
+
+	resources, err = s.client.WithChannel(params.EdgeChannel).ListResources(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceRevisions(resources), jc.DeepEquals, map[string]int{"data": 1})
+
+	// Resources are listed at their latest revision in
+	// channels they have not been published to.
+	s.uploadResource(c, id, "data", "three")
+	resources, err = s.client.WithChannel(params.BetaChannel).ListResources(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourceRevisions(resources), jc.DeepEquals, map[string]int{"data": 2})
+}
+
+func (s *FakeClientSuite) TestPublishUnknownResourceRevision(c *gc.C) {
+	id := charm.MustParseURL("cs:~bob/trusty/wordpress-1")
+	s.uploadResource(c, id, "data", "one")
+	err := s.client.Publish(id, []params.Channel{params.StableChannel}, map[string]int{"data": 3})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.client.ListResources(params.StableChannel, id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `revision 3 of resource "data" for cs:~bob/trusty/wordpress-1 not found`)
+}
+
+func (s *FakeClientSuite) TestPromulgated(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("wordpress")
+	id := charm.MustParseURL("cs:~charmers/trusty/wordpress")
+	_, err := s.client.UploadCharm(id, ch)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unowned := charm.MustParseURL("cs:trusty/wordpress")
+	_, err = s.repo.Get(unowned)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.repo.SetPromulgated(id, true)
+	got, err := s.repo.Get(unowned)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Meta().Name, gc.Equals, "wordpress")
+
+	s.repo.SetPromulgated(id, false)
+	_, err = s.repo.Get(unowned)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FakeClientSuite) TestReadPermissions(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("wordpress")
+	id := charm.MustParseURL("cs:~bob/trusty/wordpress")
+	_, err := s.client.UploadCharm(id, ch)
+	c.Assert(err, jc.ErrorIsNil)
+	s.repo.SetUser("client-username")
+
+	// Entities without read permissions are public.
+	_, _, err = s.repo.Resolve(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.client.Put("/"+id.Path()+"/meta/perm/read", []string{"bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.repo.Resolve(id)
+	c.Assert(err, jc.Satisfies, isUnauthorized)
+	c.Assert(err, gc.ErrorMatches, `cannot get "/~bob/trusty/wordpress/meta/any\?include=id&include=supported-series&include=published": access denied for user "client-username"`)
+	_, err = s.repo.Get(id)
+	c.Assert(err, jc.Satisfies, isUnauthorized)
+
+	err = s.client.Put("/"+id.Path()+"/meta/perm/read", []string{"bob", "client-username"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.repo.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.client.Put("/"+id.Path()+"/meta/perm/read", []string{params.Everyone})
+	c.Assert(err, jc.ErrorIsNil)
+	s.repo.SetUser("")
+	_, err = s.repo.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
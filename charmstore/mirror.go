@@ -0,0 +1,546 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/yaml.v2"
+)
+
+// MirrorIndexFile is the name of the file at the root of a charm store
+// mirror that describes the entities the mirror holds.
+const MirrorIndexFile = "index.yaml"
+
+// MirrorIndex describes the charms and bundles held in a charm store
+// mirror.
+type MirrorIndex struct {
+	Entities []MirrorEntity `yaml:"entities"`
+}
+
+// MirrorEntity describes a charm or bundle held in a charm store
+// mirror.
+type MirrorEntity struct {
+	// ID holds the canonical charm store URL of the entity,
+	// including its revision.
+	ID string `yaml:"id"`
+
+	// PromulgatedID holds the promulgated URL of the entity,
+	// if it is promulgated.
+	PromulgatedID string `yaml:"promulgated-id,omitempty"`
+
+	// Channel holds the channel the entity was downloaded from.
+	Channel csparams.Channel `yaml:"channel,omitempty"`
+
+	// SupportedSeries holds the series supported by a charm.
+	SupportedSeries []string `yaml:"supported-series,omitempty"`
+
+	// Archive holds the path of the entity's archive, relative
+	// to the root of the mirror.
+	Archive string `yaml:"archive"`
+
+	// SHA384 holds the hex-encoded SHA384 hash of the archive.
+	SHA384 string `yaml:"sha384,omitempty"`
+
+	// Signature holds the armored detached PGP signature of the
+	// archive, if it is signed.
+	Signature string `yaml:"signature,omitempty"`
+
+	// Resources holds the resources of a charm.
+	Resources []MirrorResource `yaml:"resources,omitempty"`
+}
+
+// MirrorResource describes a charm resource revision held in a charm
+// store mirror.
+type MirrorResource struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Path        string `yaml:"path"`
+	Description string `yaml:"description,omitempty"`
+	Revision    int    `yaml:"revision"`
+
+	// Fingerprint holds the hex-encoded SHA384 hash of the
+	// resource data.
+	Fingerprint string `yaml:"fingerprint"`
+	Size        int64  `yaml:"size"`
+
+	// Data holds the path of the resource data, relative to the
+	// root of the mirror.
+	Data string `yaml:"data"`
+}
+
+// Mirror is a read-only charm repository backed by a charm store
+// mirror: a directory, or an http server serving one, holding charm
+// and bundle archives and their resources as described by the
+// mirror's index file.
+type Mirror struct {
+	location string
+	open     func(name string) (io.ReadCloser, error)
+	index    MirrorIndex
+	channel  csparams.Channel
+}
+
+// OpenMirror opens the charm store mirror at the given location, which
+// is either a directory path, or an http, https or file url.
+func OpenMirror(location string) (*Mirror, error) {
+	m := &Mirror{location: location}
+	u, err := url.Parse(location)
+	switch {
+	case err == nil && (u.Scheme == "http" || u.Scheme == "https"):
+		m.open = httpMirrorOpener(u)
+	case err == nil && u.Scheme == "file":
+		m.open = dirMirrorOpener(u.Path)
+	default:
+		m.open = dirMirrorOpener(location)
+	}
+	r, err := m.open(MirrorIndexFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open charm store mirror %q", location)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm store mirror %q", location)
+	}
+	if err := yaml.Unmarshal(data, &m.index); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse charm store mirror %q index", location)
+	}
+	return m, nil
+}
+
+func dirMirrorOpener(dir string) func(string) (io.ReadCloser, error) {
+	return func(name string) (io.ReadCloser, error) {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			return nil, errors.NotFoundf("%q", name)
+		}
+		return f, errors.Trace(err)
+	}
+}
+
+func httpMirrorOpener(base *url.URL) func(string) (io.ReadCloser, error) {
+	return func(name string) (io.ReadCloser, error) {
+		u := *base
+		u.Path = path.Join(u.Path, name)
+		resp, err := utils.GetHTTPClient(utils.VerifySSLHostnames).Get(u.String())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return resp.Body, nil
+		case http.StatusNotFound:
+			resp.Body.Close()
+			return nil, errors.NotFoundf("%q", name)
+		}
+		resp.Body.Close()
+		return nil, errors.Errorf("cannot get %q: %s", u.String(), resp.Status)
+	}
+}
+
+// Location returns the location the mirror was opened from.
+func (m *Mirror) Location() string {
+	return m.location
+}
+
+// WithChannel returns a copy of the mirror that only serves entities
+// downloaded from the given channel, or downloaded without one.
+func (m *Mirror) WithChannel(channel csparams.Channel) *Mirror {
+	m1 := *m
+	m1.channel = channel
+	return &m1
+}
+
+// mirrorMatch is an entity in a mirror that matches a charm URL.
+type mirrorMatch struct {
+	entity MirrorEntity
+
+	// id holds the entity's URL in the same form as the URL
+	// it matched: promulgated if that was not owned.
+	id *charm.URL
+}
+
+// find returns the entity that best matches the given URL: the
+// highest revision of the charm or bundle in the mirror's channel.
+func (m *Mirror) find(ref *charm.URL) (mirrorMatch, error) {
+	var best mirrorMatch
+	for _, e := range m.index.Entities {
+		if m.channel != csparams.NoChannel && e.Channel != csparams.NoChannel && e.Channel != m.channel {
+			continue
+		}
+		idStr := e.ID
+		if ref.User == "" {
+			idStr = e.PromulgatedID
+		}
+		if idStr == "" {
+			continue
+		}
+		id, err := charm.ParseURL(idStr)
+		if err != nil {
+			return mirrorMatch{}, errors.Annotatef(err, "invalid entity in charm store mirror %q", m.location)
+		}
+		if !mirrorURLMatches(ref, id, e.SupportedSeries) {
+			continue
+		}
+		if best.id == nil || id.Revision > best.id.Revision {
+			best = mirrorMatch{entity: e, id: id}
+		}
+	}
+	if best.id == nil {
+		return mirrorMatch{}, errors.NotFoundf("%v in charm store mirror", ref)
+	}
+	return best, nil
+}
+
+func mirrorURLMatches(ref, id *charm.URL, supportedSeries []string) bool {
+	if ref.Name != id.Name || ref.User != id.User {
+		return false
+	}
+	if ref.Revision >= 0 && ref.Revision != id.Revision {
+		return false
+	}
+	if ref.Series == "" || ref.Series == id.Series {
+		return true
+	}
+	if id.Series != "" {
+		return false
+	}
+	for _, series := range supportedSeries {
+		if series == ref.Series {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve is part of the charmrepo.Interface interface.
+func (m *Mirror) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	id, _, supportedSeries, err := m.ResolveWithChannel(ref)
+	return id, supportedSeries, err
+}
+
+// ResolveWithChannel resolves the given reference to the URL of the
+// best matching entity in the mirror, and returns the channel it was
+// downloaded from and its supported series.
+func (m *Mirror) ResolveWithChannel(ref *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	match, err := m.find(ref)
+	if err != nil {
+		return nil, csparams.NoChannel, nil, errors.Trace(err)
+	}
+	return match.id, match.entity.Channel, match.entity.SupportedSeries, nil
+}
+
+// Get is part of the charmrepo.Interface interface. The charm archive
+// is copied to a temporary file, which the caller should remove when
+// done with it. If the index records a SHA384 hash for the archive, the
+// copy is checked against it. On error, no temporary file is left
+// behind.
+func (m *Mirror) Get(id *charm.URL) (_ charm.Charm, err error) {
+	match, err := m.find(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r, err := m.open(match.entity.Archive)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get archive for %v", id)
+	}
+	defer r.Close()
+	f, err := ioutil.TempFile("", "charm-mirror")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	hash := sha512.New384()
+	_, err = io.Copy(io.MultiWriter(f, hash), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get archive for %v", id)
+	}
+	if expect := match.entity.SHA384; expect != "" {
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != expect {
+			return nil, errors.Errorf("archive for %v has SHA384 %s, expected %s", id, sum, expect)
+		}
+	}
+	ch, err := charm.ReadCharmArchive(f.Name())
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read archive for %v", id)
+	}
+	return ch, nil
+}
+
+// GetBundle is part of the charmrepo.Interface interface.
+func (m *Mirror) GetBundle(id *charm.URL) (charm.Bundle, error) {
+	match, err := m.find(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := m.readFile(match.entity.Archive)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get archive for %v", id)
+	}
+	b, err := charm.ReadBundleArchiveBytes(data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read archive for %v", id)
+	}
+	return b, nil
+}
+
+// ArchiveSHA384 implements ArchiveVerifier. It returns "" for
+// entities added to the mirror without a recorded hash.
+func (m *Mirror) ArchiveSHA384(id *charm.URL) (string, error) {
+	match, err := m.find(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return match.entity.SHA384, nil
+}
+
+// ArchiveSignature implements ArchiveVerifier.
+func (m *Mirror) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	match, err := m.find(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if match.entity.Signature == "" {
+		return nil, errors.NotFoundf("signature for %v", id)
+	}
+	return []byte(match.entity.Signature), nil
+}
+
+func (m *Mirror) readFile(name string) ([]byte, error) {
+	r, err := m.open(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Latest returns the latest revision in the mirror of each of the
+// given charms.
+func (m *Mirror) Latest(ids []*charm.URL) ([]csparams.CharmRevision, error) {
+	results := make([]csparams.CharmRevision, len(ids))
+	for i, id := range ids {
+		match, err := m.find(id.WithRevision(-1))
+		if err != nil {
+			results[i].Err = errors.Trace(err)
+			continue
+		}
+		results[i].Revision = match.id.Revision
+	}
+	return results, nil
+}
+
+// ListResources returns the resources of the given charm held in the
+// mirror.
+func (m *Mirror) ListResources(id *charm.URL) ([]csparams.Resource, error) {
+	match, err := m.find(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources := make([]csparams.Resource, len(match.entity.Resources))
+	for i, r := range match.entity.Resources {
+		if resources[i], err = r.apiResource(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return resources, nil
+}
+
+// ResourceMeta returns the metadata of the given revision of a charm
+// resource held in the mirror. A negative revision means the latest
+// revision in the mirror.
+func (m *Mirror) ResourceMeta(id *charm.URL, name string, revision int) (csparams.Resource, error) {
+	r, err := m.resource(id, name, revision)
+	if err != nil {
+		return csparams.Resource{}, errors.Trace(err)
+	}
+	return r.apiResource()
+}
+
+// GetResource returns the data of the given revision of a charm resource
+// held in the mirror. A negative revision means the latest revision in
+// the mirror.
+func (m *Mirror) GetResource(id *charm.URL, name string, revision int) (csclient.ResourceData, error) {
+	r, err := m.resource(id, name, revision)
+	if err != nil {
+		return csclient.ResourceData{}, errors.Trace(err)
+	}
+	data, err := m.open(r.Data)
+	if err != nil {
+		return csclient.ResourceData{}, errors.Annotatef(err, "cannot get resource %q for %v", name, id)
+	}
+	return csclient.ResourceData{
+		ReadCloser: data,
+		Hash:       r.Fingerprint,
+	}, nil
+}
+
+func (m *Mirror) resource(id *charm.URL, name string, revision int) (MirrorResource, error) {
+	match, err := m.find(id)
+	if err != nil {
+		return MirrorResource{}, errors.Trace(err)
+	}
+	found := false
+	var result MirrorResource
+	for _, r := range match.entity.Resources {
+		if r.Name != name {
+			continue
+		}
+		if revision >= 0 && r.Revision != revision {
+			continue
+		}
+		if !found || r.Revision > result.Revision {
+			result, found = r, true
+		}
+	}
+	if !found {
+		if revision >= 0 {
+			return MirrorResource{}, errors.NotFoundf("revision %d of resource %q for %v in charm store mirror", revision, name, id)
+		}
+		return MirrorResource{}, errors.NotFoundf("resource %q for %v in charm store mirror", name, id)
+	}
+	return result, nil
+}
+
+func (r MirrorResource) apiResource() (csparams.Resource, error) {
+	fingerprint, err := hex.DecodeString(r.Fingerprint)
+	if err != nil {
+		return csparams.Resource{}, errors.Annotatef(err, "invalid fingerprint for resource %q", r.Name)
+	}
+	return csparams.Resource{
+		Name:        r.Name,
+		Type:        r.Type,
+		Path:        r.Path,
+		Description: r.Description,
+		Revision:    r.Revision,
+		Fingerprint: fingerprint,
+		Size:        r.Size,
+	}, nil
+}
+
+// MirrorWriter adds entities to a charm store mirror directory.
+type MirrorWriter struct {
+	dir string
+}
+
+// NewMirrorWriter returns a MirrorWriter that adds entities to the
+// charm store mirror in the given directory, creating it if needed.
+func NewMirrorWriter(dir string) (*MirrorWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MirrorWriter{dir: dir}, nil
+}
+
+// AddEntity writes the archive of the given entity, and the data of
+// each of its resources, keyed by resource name, to the mirror, and
+// records the entity in the mirror index, replacing any entity with
+// the same ID. The Archive field of the entity and the Data field of
+// each of its resources are set by AddEntity. If the SHA384 field of
+// the entity is set, the archive must have that hash; otherwise it is
+// set by AddEntity.
+func (w *MirrorWriter) AddEntity(entity MirrorEntity, archive io.Reader, resources map[string]io.Reader) error {
+	id, err := charm.ParseURL(entity.ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entityPath := strings.TrimPrefix(id.Path(), "~")
+	entity.Archive = path.Join("archives", entityPath+".zip")
+	hash := sha512.New384()
+	if err := w.writeFile(entity.Archive, io.TeeReader(archive, hash)); err != nil {
+		return errors.Annotatef(err, "cannot write archive for %v", id)
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if entity.SHA384 != "" && entity.SHA384 != sum {
+		os.Remove(filepath.Join(w.dir, filepath.FromSlash(entity.Archive)))
+		return errors.Errorf("archive for %v has SHA384 %s, expected %s", id, sum, entity.SHA384)
+	}
+	entity.SHA384 = sum
+	for i, r := range entity.Resources {
+		data, ok := resources[r.Name]
+		if !ok {
+			return errors.NotFoundf("data for resource %q", r.Name)
+		}
+		r.Data = path.Join("resources", entityPath, r.Name, strconv.Itoa(r.Revision))
+		if err := w.writeFile(r.Data, data); err != nil {
+			return errors.Annotatef(err, "cannot write resource %q for %v", r.Name, id)
+		}
+		entity.Resources[i] = r
+	}
+
+	index, err := w.readIndex()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entities := []MirrorEntity{entity}
+	for _, e := range index.Entities {
+		if e.ID != entity.ID {
+			entities = append(entities, e)
+		}
+	}
+	index.Entities = entities
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return utils.AtomicWriteFile(filepath.Join(w.dir, MirrorIndexFile), data, 0644)
+}
+
+func (w *MirrorWriter) readIndex() (MirrorIndex, error) {
+	var index MirrorIndex
+	data, err := ioutil.ReadFile(filepath.Join(w.dir, MirrorIndexFile))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return index, errors.Trace(err)
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return index, errors.Annotate(err, "cannot parse charm store mirror index")
+	}
+	return index, nil
+}
+
+func (w *MirrorWriter) writeFile(name string, r io.Reader) error {
+	target := filepath.Join(w.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Trace(err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,303 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/macaroon.v2-unstable"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testcharms"
+)
+
+type MirrorSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&MirrorSuite{})
+
+func (s *MirrorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.addCharm(c, charmstore.MirrorEntity{
+		ID:              "cs:~bob/wordpress-2",
+		PromulgatedID:   "cs:wordpress-5",
+		Channel:         params.StableChannel,
+		SupportedSeries: []string{"xenial", "bionic"},
+	}, "data")
+	s.addCharm(c, charmstore.MirrorEntity{
+		ID:              "cs:~bob/wordpress-3",
+		Channel:         params.EdgeChannel,
+		SupportedSeries: []string{"bionic"},
+	}, "newer-data")
+}
+
+func (s *MirrorSuite) addCharm(c *gc.C, entity charmstore.MirrorEntity, data string) {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	entity.Resources = []charmstore.MirrorResource{{
+		Name:        "data",
+		Type:        "file",
+		Path:        "data.tgz",
+		Revision:    len(data),
+		Fingerprint: fp.String(),
+		Size:        int64(len(data)),
+	}}
+	archive, err := os.Open(testcharms.Repo.CharmArchivePath(c.MkDir(), "wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	w, err := charmstore.NewMirrorWriter(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.AddEntity(entity, archive, map[string]io.Reader{
+		"data": strings.NewReader(data),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MirrorSuite) TestResolve(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		channel params.Channel
+		ref     string
+		expect  string
+		series  []string
+		err     string
+	}{{
+		ref:    "cs:~bob/wordpress",
+		expect: "cs:~bob/wordpress-3",
+		series: []string{"bionic"},
+	}, {
+		ref:    "cs:~bob/xenial/wordpress",
+		expect: "cs:~bob/wordpress-2",
+		series: []string{"xenial", "bionic"},
+	}, {
+		channel: params.StableChannel,
+		ref:     "cs:~bob/wordpress",
+		expect:  "cs:~bob/wordpress-2",
+		series:  []string{"xenial", "bionic"},
+	}, {
+		ref:    "cs:wordpress",
+		expect: "cs:wordpress-5",
+		series: []string{"xenial", "bionic"},
+	}, {
+		ref: "cs:~bob/trusty/wordpress",
+		err: `cs:~bob/trusty/wordpress in charm store mirror not found`,
+	}, {
+		ref: "cs:~alice/wordpress",
+		err: `cs:~alice/wordpress in charm store mirror not found`,
+	}} {
+		c.Logf("test %d: %s", i, test.ref)
+		id, supportedSeries, err := mirror.WithChannel(test.channel).Resolve(charm.MustParseURL(test.ref))
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotFound)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(id.String(), gc.Equals, test.expect)
+		c.Check(supportedSeries, jc.DeepEquals, test.series)
+	}
+}
+
+func (s *MirrorSuite) TestGet(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := mirror.Get(charm.MustParseURL("cs:~bob/xenial/wordpress-2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Meta().Name, gc.Equals, "wordpress")
+	os.Remove(ch.(*charm.CharmArchive).Path)
+}
+
+func (s *MirrorSuite) TestGetHashMismatch(c *gc.C) {
+	tmpDir := c.MkDir()
+	s.PatchEnvironment("TMPDIR", tmpDir)
+	// Replace the archive with a different, valid, charm.
+	other, err := ioutil.ReadFile(testcharms.Repo.CharmArchivePath(c.MkDir(), "mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.dir, "archives", "bob", "wordpress-2.zip"), other, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mirror.Get(charm.MustParseURL("cs:~bob/xenial/wordpress-2"))
+	c.Assert(err, gc.ErrorMatches, `archive for cs:~bob/xenial/wordpress-2 has SHA384 [0-9a-f]+, expected [0-9a-f]+`)
+
+	// The temporary copy of the archive has been removed.
+	files, err := ioutil.ReadDir(tmpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, gc.HasLen, 0)
+}
+
+func (s *MirrorSuite) TestResources(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	id := charm.MustParseURL("cs:~bob/wordpress-2")
+
+	resources, err := mirror.ListResources(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0].Name, gc.Equals, "data")
+	c.Assert(resources[0].Revision, gc.Equals, 4)
+
+	data, err := mirror.GetResource(id, "data", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "data")
+
+	_, err = mirror.GetResource(id, "data", 10)
+	c.Assert(err, gc.ErrorMatches, `revision 10 of resource "data" for cs:~bob/wordpress-2 in charm store mirror not found`)
+}
+
+func (s *MirrorSuite) TestHTTPMirror(c *gc.C) {
+	server := httptest.NewServer(http.FileServer(http.Dir(s.dir)))
+	defer server.Close()
+
+	mirror, err := charmstore.OpenMirror(server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := mirror.Get(charm.MustParseURL("cs:wordpress-5"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Meta().Name, gc.Equals, "wordpress")
+	os.Remove(ch.(*charm.CharmArchive).Path)
+
+	_, err = mirror.Get(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestOpenMirrorMissingIndex(c *gc.C) {
+	_, err := charmstore.OpenMirror(c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `cannot open charm store mirror ".*": "index.yaml" not found`)
+}
+
+func (s *MirrorSuite) TestAddEntityReplaces(c *gc.C) {
+	s.addCharm(c, charmstore.MirrorEntity{
+		ID:              "cs:~bob/wordpress-2",
+		SupportedSeries: []string{"xenial"},
+	}, "replaced")
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, supportedSeries, err := mirror.Resolve(charm.MustParseURL("cs:~bob/wordpress-2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supportedSeries, jc.DeepEquals, []string{"xenial"})
+	_, _, err = mirror.Resolve(charm.MustParseURL("cs:wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestMirroredRepoOffline(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	repo := charmstore.NewMirroredRepo(mirror, nil)
+	_, _, err = repo.Resolve(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestMirroredRepoFallback(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	store := charmstore.NewRepository()
+	_, err = charmstore.NewFakeClient(store).UploadCharm(
+		charm.MustParseURL("cs:~bob/trusty/mysql"),
+		testcharms.Repo.CharmDir("mysql"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	repo := charmstore.NewMirroredRepo(mirror, store)
+
+	id, _, err := repo.Resolve(charm.MustParseURL("cs:~bob/trusty/mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id.String(), gc.Equals, "cs:~bob/trusty/mysql-0")
+
+	id, channel, _, err := repo.ResolveWithChannel(charm.MustParseURL("cs:~bob/wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id.String(), gc.Equals, "cs:~bob/wordpress-3")
+	c.Assert(channel, gc.Equals, params.EdgeChannel)
+}
+
+func (s *MirrorSuite) TestMirroredCachingClientOffline(c *gc.C) {
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	client, err := charmstore.NewMirroredCachingClient(macaroonCache{}, "https://api.jujucharms.com/charmstore", mirror, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := client.GetResource(charmstore.ResourceRequest{
+		Charm:    charm.MustParseURL("cs:~bob/wordpress-3"),
+		Channel:  params.EdgeChannel,
+		Name:     "data",
+		Revision: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "newer-data")
+
+	revisions, err := client.LatestRevisions([]charmstore.CharmID{{
+		URL:     charm.MustParseURL("cs:~bob/wordpress-2"),
+		Channel: params.StableChannel,
+	}, {
+		URL:     charm.MustParseURL("cs:~bob/mysql-1"),
+		Channel: params.StableChannel,
+	}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions[0].Revision, gc.Equals, 2)
+	c.Assert(revisions[1].Err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MirrorSuite) TestOpenModelMirror(c *gc.C) {
+	mirror, offline, err := charmstore.OpenModelMirror(s.dir, config.CharmMirrorDisabled)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirror, gc.IsNil)
+	c.Assert(offline, jc.IsFalse)
+
+	mirror, offline, err = charmstore.OpenModelMirror(s.dir, config.CharmMirrorPrefer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirror, gc.NotNil)
+	c.Assert(offline, jc.IsFalse)
+
+	mirror, offline, err = charmstore.OpenModelMirror(s.dir, config.CharmMirrorOffline)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirror, gc.NotNil)
+	c.Assert(offline, jc.IsTrue)
+
+	// The charm store is used if a preferred mirror is unavailable,
+	// but never in offline mode.
+	missing := c.MkDir()
+	mirror, _, err = charmstore.OpenModelMirror(missing, config.CharmMirrorPrefer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirror, gc.IsNil)
+	_, _, err = charmstore.OpenModelMirror(missing, config.CharmMirrorOffline)
+	c.Assert(err, gc.ErrorMatches, `cannot open charm store mirror .*`)
+	_, _, err = charmstore.OpenModelMirror("", config.CharmMirrorOffline)
+	c.Assert(err, gc.ErrorMatches, `charm-mirror-mode "offline" without a controller `+controller.CharmStoreMirror+` not valid`)
+}
+
+type macaroonCache struct{}
+
+func (macaroonCache) Set(*charm.URL, macaroon.Slice) error {
+	return nil
+}
+
+func (macaroonCache) Get(*charm.URL) (macaroon.Slice, error) {
+	return nil, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+)
+
+// OpenModelMirror opens the charm store mirror at the given location
+// if a model with the given charm mirror mode uses it, returning
+// whether the charm store must not be contacted. It returns a nil
+// mirror if the model does not use a mirror.
+func OpenModelMirror(location, mode string) (*Mirror, bool, error) {
+	if mode == config.CharmMirrorDisabled || mode == "" {
+		return nil, false, nil
+	}
+	offline := mode == config.CharmMirrorOffline
+	if location == "" {
+		if offline {
+			return nil, false, errors.NotValidf("%s %q without a controller %s", config.CharmMirrorModeKey, mode, controller.CharmStoreMirror)
+		}
+		return nil, false, nil
+	}
+	mirror, err := OpenMirror(location)
+	if err != nil {
+		if offline {
+			return nil, false, errors.Trace(err)
+		}
+		// The charm store is still available, so don't
+		// stop the model using it if the mirror is not.
+		logger.Warningf("not using charm store mirror: %v", err)
+		return nil, false, nil
+	}
+	return mirror, offline, nil
+}
+
+// NewModelCachingClient returns a client like NewCachingClient,
+// which also uses the controller's charm store mirror according
+// to the model's charm mirror mode.
+func NewModelCachingClient(cache MacaroonCache, controllerCfg controller.Config, modelCfg *config.Config) (Client, error) {
+	mirror, offline, err := OpenModelMirror(controllerCfg.CharmStoreMirror(), modelCfg.CharmMirrorMode())
+	if err != nil {
+		return Client{}, errors.Trace(err)
+	}
+	if mirror == nil {
+		return NewCachingClient(cache, controllerCfg.CharmStoreURL())
+	}
+	return NewMirroredCachingClient(cache, controllerCfg.CharmStoreURL(), mirror, offline)
+}
+
+// NewMirroredCachingClient returns a client like NewCachingClient,
+// which fetches charm revisions and resources from the given mirror.
+// Unless offline is true, requests for charms not held in the mirror
+// are made to the charm store at server.
+func NewMirroredCachingClient(cache MacaroonCache, server string, mirror *Mirror, offline bool) (Client, error) {
+	return newCachingClient(cache, server, func(bakeryClient *httpbakery.Client, server string) (csWrapper, error) {
+		wrapper := mirrorWrapper{
+			mirror:    mirror,
+			serverURL: server,
+		}
+		if !offline {
+			fallback, err := makeWrapper(bakeryClient, server)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			wrapper.fallback = fallback
+		}
+		return wrapper, nil
+	})
+}
+
+// mirrorWrapper is an implementation of csWrapper that serves requests
+// from a charm store mirror, falling back to the charm store for
+// charms the mirror does not hold when fallback is not nil.
+type mirrorWrapper struct {
+	mirror    *Mirror
+	fallback  csWrapper
+	serverURL string
+}
+
+// Latest implements csWrapper.
+func (w mirrorWrapper) Latest(channel csparams.Channel, ids []*charm.URL, headers map[string][]string) ([]csparams.CharmRevision, error) {
+	results, err := w.mirror.WithChannel(channel).Latest(ids)
+	if err != nil || w.fallback == nil {
+		return results, errors.Trace(err)
+	}
+	var missing []*charm.URL
+	var missingIndexes []int
+	for i, result := range results {
+		if errors.IsNotFound(result.Err) {
+			missing = append(missing, ids[i])
+			missingIndexes = append(missingIndexes, i)
+		}
+	}
+	if len(missing) == 0 {
+		return results, nil
+	}
+	fallbackResults, err := w.fallback.Latest(channel, missing, headers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, result := range fallbackResults {
+		results[missingIndexes[i]] = result
+	}
+	return results, nil
+}
+
+// ListResources implements csWrapper.
+func (w mirrorWrapper) ListResources(channel csparams.Channel, id *charm.URL) ([]csparams.Resource, error) {
+	resources, err := w.mirror.WithChannel(channel).ListResources(id)
+	if errors.IsNotFound(err) && w.fallback != nil {
+		return w.fallback.ListResources(channel, id)
+	}
+	return resources, errors.Trace(err)
+}
+
+// GetResource implements csWrapper.
+func (w mirrorWrapper) GetResource(channel csparams.Channel, id *charm.URL, name string, revision int) (csclient.ResourceData, error) {
+	data, err := w.mirror.WithChannel(channel).GetResource(id, name, revision)
+	if errors.IsNotFound(err) && w.fallback != nil {
+		return w.fallback.GetResource(channel, id, name, revision)
+	}
+	return data, errors.Trace(err)
+}
+
+// ResourceMeta implements csWrapper.
+func (w mirrorWrapper) ResourceMeta(channel csparams.Channel, id *charm.URL, name string, revision int) (csparams.Resource, error) {
+	meta, err := w.mirror.WithChannel(channel).ResourceMeta(id, name, revision)
+	if errors.IsNotFound(err) && w.fallback != nil {
+		return w.fallback.ResourceMeta(channel, id, name, revision)
+	}
+	return meta, errors.Trace(err)
+}
+
+// ServerURL implements csWrapper.
+func (w mirrorWrapper) ServerURL() string {
+	return w.serverURL
+}
+
+// channelResolver is implemented by charm repositories that report
+// the channel a charm reference was resolved in, such as
+// *charmrepo.CharmStore.
+type channelResolver interface {
+	ResolveWithChannel(*charm.URL) (*charm.URL, csparams.Channel, []string, error)
+}
+
+// MirroredRepo is a charm repository that gets charms and bundles from
+// a charm store mirror, and from a fallback repository, usually the
+// charm store, when the mirror does not hold them.
+type MirroredRepo struct {
+	mirror   *Mirror
+	fallback charmrepo.Interface
+}
+
+// NewMirroredRepo returns a MirroredRepo that gets charms and bundles
+// from the given mirror, falling back to the given repository unless
+// it is nil.
+func NewMirroredRepo(mirror *Mirror, fallback charmrepo.Interface) *MirroredRepo {
+	return &MirroredRepo{
+		mirror:   mirror,
+		fallback: fallback,
+	}
+}
+
+// Get is part of the charmrepo.Interface interface.
+func (r *MirroredRepo) Get(id *charm.URL) (charm.Charm, error) {
+	ch, err := r.mirror.Get(id)
+	if errors.IsNotFound(err) && r.fallback != nil {
+		return r.fallback.Get(id)
+	}
+	return ch, errors.Trace(err)
+}
+
+// GetBundle is part of the charmrepo.Interface interface.
+func (r *MirroredRepo) GetBundle(id *charm.URL) (charm.Bundle, error) {
+	b, err := r.mirror.GetBundle(id)
+	if errors.IsNotFound(err) && r.fallback != nil {
+		return r.fallback.GetBundle(id)
+	}
+	return b, errors.Trace(err)
+}
+
+// Resolve is part of the charmrepo.Interface interface.
+func (r *MirroredRepo) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	id, supportedSeries, err := r.mirror.Resolve(ref)
+	if errors.IsNotFound(err) && r.fallback != nil {
+		return r.fallback.Resolve(ref)
+	}
+	return id, supportedSeries, errors.Trace(err)
+}
+
+// ResolveWithChannel resolves the given reference like Resolve,
+// also returning the channel it was resolved in.
+func (r *MirroredRepo) ResolveWithChannel(ref *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	id, channel, supportedSeries, err := r.mirror.ResolveWithChannel(ref)
+	if errors.IsNotFound(err) && r.fallback != nil {
+		if resolver, ok := r.fallback.(channelResolver); ok {
+			return resolver.ResolveWithChannel(ref)
+		}
+		id, supportedSeries, err := r.fallback.Resolve(ref)
+		return id, csparams.NoChannel, supportedSeries, err
+	}
+	return id, channel, supportedSeries, errors.Trace(err)
+}
+
+// ArchiveSHA384 implements ArchiveVerifier, reporting the hash of
+// charms not held in the mirror from the fallback repository.
+func (r *MirroredRepo) ArchiveSHA384(id *charm.URL) (string, error) {
+	sum, err := r.mirror.ArchiveSHA384(id)
+	if errors.IsNotFound(err) {
+		if verifier := r.fallbackVerifier(); verifier != nil {
+			return verifier.ArchiveSHA384(id)
+		}
+	}
+	return sum, errors.Trace(err)
+}
+
+// ArchiveSignature implements ArchiveVerifier, reporting the signature
+// of charms not held in the mirror from the fallback repository.
+func (r *MirroredRepo) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	if _, err := r.mirror.find(id); errors.IsNotFound(err) {
+		if verifier := r.fallbackVerifier(); verifier != nil {
+			return verifier.ArchiveSignature(id)
+		}
+	}
+	signature, err := r.mirror.ArchiveSignature(id)
+	return signature, errors.Trace(err)
+}
+
+func (r *MirroredRepo) fallbackVerifier() ArchiveVerifier {
+	if r.fallback == nil {
+		return nil
+	}
+	return RepoArchiveVerifier(r.fallback)
+}
@@ -0,0 +1,204 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+)
+
+// SignatureExtraInfoKey is the charm store extra-info key holding the
+// armored detached PGP signature of a charm archive.
+const SignatureExtraInfoKey = "signature"
+
+// ArchiveVerifier is implemented by charm repositories that can report
+// what the archives of the charms they hold should contain.
+type ArchiveVerifier interface {
+	// ArchiveSHA384 returns the hex-encoded SHA-384 digest of the
+	// archive of the given charm, or "" if it is not known.
+	ArchiveSHA384(id *charm.URL) (string, error)
+
+	// ArchiveSignature returns the armored detached PGP signature
+	// of the archive of the given charm. It returns a NotFound error
+	// if the charm is not signed.
+	ArchiveSignature(id *charm.URL) ([]byte, error)
+}
+
+// RepoArchiveVerifier returns the ArchiveVerifier for the given charm
+// repository, or nil if archives from the repository cannot be
+// verified.
+func RepoArchiveVerifier(repo charmrepo.Interface) ArchiveVerifier {
+	switch repo := repo.(type) {
+	case ArchiveVerifier:
+		return repo
+	case *charmrepo.CharmStore:
+		return NewCharmStoreVerifier(repo.Client())
+	}
+	return nil
+}
+
+// NewCharmStoreVerifier returns an ArchiveVerifier that reads archive
+// digests and signatures from the charm store's metadata.
+func NewCharmStoreVerifier(client *csclient.Client) ArchiveVerifier {
+	return csVerifier{client}
+}
+
+type csVerifier struct {
+	client *csclient.Client
+}
+
+// ArchiveSHA384 implements ArchiveVerifier.
+func (v csVerifier) ArchiveSHA384(id *charm.URL) (string, error) {
+	var meta struct {
+		Hash csparams.HashResponse
+	}
+	if _, err := v.client.Meta(id, &meta); err != nil {
+		return "", errors.Annotatef(err, "cannot get archive digest for %v", id)
+	}
+	return meta.Hash.Sum, nil
+}
+
+// ArchiveSignature implements ArchiveVerifier.
+func (v csVerifier) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	var signature string
+	err := v.client.Get("/"+id.Path()+"/meta/extra-info/"+SignatureExtraInfoKey, &signature)
+	if errors.Cause(err) == csparams.ErrNotFound || err == nil && signature == "" {
+		return nil, errors.NotFoundf("signature for %v", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get signature for %v", id)
+	}
+	return []byte(signature), nil
+}
+
+// VerifyParams holds the policy used to verify charm archives.
+type VerifyParams struct {
+	// TrustKeyring holds the armored PGP public keys trusted to sign
+	// charm archives. If it is empty, signatures are not checked.
+	TrustKeyring string
+
+	// RequireSignature holds whether archives without a signature
+	// are refused.
+	RequireSignature bool
+}
+
+// VerifyArchive checks that the charm archive read from r has the
+// digest reported by the verifier and, if the params hold a trust
+// keyring, a valid signature by one of its keys. A nil verifier means
+// the expected digest and signature are unknown.
+func VerifyArchive(id *charm.URL, r io.ReadSeeker, verifier ArchiveVerifier, p VerifyParams) error {
+	expectedSHA384 := ""
+	if verifier != nil {
+		var err error
+		if expectedSHA384, err = verifier.ArchiveSHA384(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if expectedSHA384 != "" {
+		hash := sha512.New384()
+		if _, err := io.Copy(hash, r); err != nil {
+			return errors.Annotatef(err, "cannot read archive for %v", id)
+		}
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != expectedSHA384 {
+			return errors.Errorf("archive for %v has SHA384 %s, expected %s", id, sum, expectedSHA384)
+		}
+	} else {
+		logger.Debugf("no archive digest known for %v", id)
+	}
+
+	if p.TrustKeyring == "" {
+		return nil
+	}
+	var signature []byte
+	if verifier != nil {
+		var err error
+		signature, err = verifier.ArchiveSignature(id)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	if signature == nil {
+		if p.RequireSignature {
+			return errors.Errorf("charm %v is not signed", id)
+		}
+		return nil
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(p.TrustKeyring))
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm trust keyring")
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return errors.Annotatef(err, "cannot rewind archive for %v", id)
+	}
+	if _, err := openpgp.CheckArmoredDetachedSignature(keyring, r, bytes.NewReader(signature)); err != nil {
+		return errors.Annotatef(err, "invalid signature for charm %v", id)
+	}
+	return nil
+}
+
+// VerifyingRepo is a charm repository that verifies the archives of the
+// charms it gets from another repository.
+type VerifyingRepo struct {
+	charmrepo.Interface
+	params VerifyParams
+}
+
+// NewVerifyingRepo returns a VerifyingRepo that gets charms from the
+// given repository, verifying their archives with VerifyArchive.
+func NewVerifyingRepo(repo charmrepo.Interface, p VerifyParams) *VerifyingRepo {
+	return &VerifyingRepo{
+		Interface: repo,
+		params:    p,
+	}
+}
+
+// Get is part of the charmrepo.Interface interface. It returns an error
+// if the charm's archive cannot be verified, in which case the
+// downloaded archive is removed.
+func (r *VerifyingRepo) Get(id *charm.URL) (charm.Charm, error) {
+	ch, err := r.Interface.Get(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return nil, errors.Errorf("expected a charm archive, got %T", ch)
+	}
+	if err := r.verify(id, archive.Path); err != nil {
+		os.Remove(archive.Path)
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+func (r *VerifyingRepo) verify(id *charm.URL, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Annotatef(err, "cannot open archive for %v", id)
+	}
+	defer f.Close()
+	return VerifyArchive(id, f, RepoArchiveVerifier(r.Interface), r.params)
+}
+
+// ResolveWithChannel resolves the given reference like Resolve, also
+// returning the channel it was resolved in if the underlying
+// repository reports it.
+func (r *VerifyingRepo) ResolveWithChannel(ref *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	if resolver, ok := r.Interface.(channelResolver); ok {
+		return resolver.ResolveWithChannel(ref)
+	}
+	id, supportedSeries, err := r.Interface.Resolve(ref)
+	return id, csparams.NoChannel, supportedSeries, err
+}
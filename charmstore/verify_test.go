@@ -0,0 +1,185 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/charmstore"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
+	"github.com/juju/juju/testcharms"
+)
+
+type VerifySuite struct {
+	testing.IsolationSuite
+	id          *charm.URL
+	archivePath string
+	archive     []byte
+	sha384      string
+	signature   []byte
+}
+
+var _ = gc.Suite(&VerifySuite{})
+
+func (s *VerifySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.id = charm.MustParseURL("cs:~bob/wordpress-2")
+	s.archivePath = testcharms.Repo.CharmArchivePath(c.MkDir(), "wordpress")
+	var err error
+	s.archive, err = ioutil.ReadFile(s.archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	sum := sha512.Sum384(s.archive)
+	s.sha384 = hex.EncodeToString(sum[:])
+	s.signature = sign(c, s.archive)
+}
+
+// sign returns an armored detached signature of the given data made
+// with the simplestreams test key.
+func sign(c *gc.C, data []byte) []byte {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(sstesting.SignedMetadataPrivateKey))
+	c.Assert(err, jc.ErrorIsNil)
+	signer := keyring[0]
+	err = signer.PrivateKey.Decrypt([]byte(sstesting.PrivateKeyPassphrase))
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	err = openpgp.ArmoredDetachSign(&buf, signer, bytes.NewReader(data), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *VerifySuite) verify(verifier charmstore.ArchiveVerifier, p charmstore.VerifyParams) error {
+	return charmstore.VerifyArchive(s.id, bytes.NewReader(s.archive), verifier, p)
+}
+
+func (s *VerifySuite) TestDigest(c *gc.C) {
+	err := s.verify(fakeVerifier{sha384: s.sha384}, charmstore.VerifyParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.verify(fakeVerifier{sha384: "0123"}, charmstore.VerifyParams{})
+	c.Assert(err, gc.ErrorMatches, `archive for cs:~bob/wordpress-2 has SHA384 `+s.sha384+`, expected 0123`)
+
+	err = s.verify(fakeVerifier{err: errors.New("boom")}, charmstore.VerifyParams{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *VerifySuite) TestUnknownDigest(c *gc.C) {
+	err := s.verify(nil, charmstore.VerifyParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.verify(fakeVerifier{}, charmstore.VerifyParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VerifySuite) TestSignature(c *gc.C) {
+	p := charmstore.VerifyParams{
+		TrustKeyring:     sstesting.SignedMetadataPublicKey,
+		RequireSignature: true,
+	}
+	err := s.verify(fakeVerifier{sha384: s.sha384, signature: s.signature}, p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.verify(fakeVerifier{sha384: s.sha384, signature: sign(c, []byte("other data"))}, p)
+	c.Assert(err, gc.ErrorMatches, "invalid signature for charm cs:~bob/wordpress-2: .*")
+}
+
+func (s *VerifySuite) TestUnsigned(c *gc.C) {
+	p := charmstore.VerifyParams{
+		TrustKeyring: sstesting.SignedMetadataPublicKey,
+	}
+	err := s.verify(fakeVerifier{sha384: s.sha384}, p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	p.RequireSignature = true
+	err = s.verify(fakeVerifier{sha384: s.sha384}, p)
+	c.Assert(err, gc.ErrorMatches, "charm cs:~bob/wordpress-2 is not signed")
+	err = s.verify(nil, p)
+	c.Assert(err, gc.ErrorMatches, "charm cs:~bob/wordpress-2 is not signed")
+}
+
+func (s *VerifySuite) TestSignatureNotCheckedWithoutKeyring(c *gc.C) {
+	err := s.verify(fakeVerifier{sha384: s.sha384, signature: []byte("garbage")}, charmstore.VerifyParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VerifySuite) addToMirror(c *gc.C, dir string, signature []byte) *charmstore.Mirror {
+	archive, err := os.Open(s.archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	w, err := charmstore.NewMirrorWriter(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.AddEntity(charmstore.MirrorEntity{
+		ID:        s.id.String(),
+		Signature: string(signature),
+	}, archive, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	mirror, err := charmstore.OpenMirror(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return mirror
+}
+
+func (s *VerifySuite) TestVerifyingRepo(c *gc.C) {
+	mirror := s.addToMirror(c, c.MkDir(), s.signature)
+	sha384, err := mirror.ArchiveSHA384(s.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sha384, gc.Equals, s.sha384)
+
+	repo := charmstore.NewVerifyingRepo(charmstore.NewMirroredRepo(mirror, nil), charmstore.VerifyParams{
+		TrustKeyring:     sstesting.SignedMetadataPublicKey,
+		RequireSignature: true,
+	})
+	ch, err := repo.Get(s.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Meta().Name, gc.Equals, "wordpress")
+}
+
+func (s *VerifySuite) TestVerifyingRepoRefusesUnsigned(c *gc.C) {
+	mirror := s.addToMirror(c, c.MkDir(), nil)
+	repo := charmstore.NewVerifyingRepo(mirror, charmstore.VerifyParams{
+		TrustKeyring:     sstesting.SignedMetadataPublicKey,
+		RequireSignature: true,
+	})
+	_, err := repo.Get(s.id)
+	c.Assert(err, gc.ErrorMatches, "charm cs:~bob/wordpress-2 is not signed")
+}
+
+func (s *VerifySuite) TestAddEntityChecksDigest(c *gc.C) {
+	archive, err := os.Open(s.archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	w, err := charmstore.NewMirrorWriter(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.AddEntity(charmstore.MirrorEntity{
+		ID:     s.id.String(),
+		SHA384: "0123",
+	}, archive, nil)
+	c.Assert(err, gc.ErrorMatches, `archive for cs:~bob/wordpress-2 has SHA384 `+s.sha384+`, expected 0123`)
+}
+
+type fakeVerifier struct {
+	sha384    string
+	signature []byte
+	err       error
+}
+
+func (v fakeVerifier) ArchiveSHA384(id *charm.URL) (string, error) {
+	return v.sha384, v.err
+}
+
+func (v fakeVerifier) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	if v.signature == nil {
+		return nil, errors.NotFoundf("signature for %v", id)
+	}
+	return v.signature, nil
+}
@@ -356,6 +356,42 @@ type CredentialAttr struct {
 
 	// Options, if set, define the allowed values for this field.
 	Options []interface{}
+
+	// MultiLine controls whether the value may span several lines, as
+	// a PEM-encoded key does, when it is entered interactively rather
+	// than read from a file.
+	MultiLine bool
+}
+
+// ValidateValue checks a single value entered for the attribute, so
+// that interactive clients can report a bad value as soon as it is
+// entered rather than when the whole credential is finalized. File
+// path values must refer to an existing file.
+func (a CredentialAttr) ValidateValue(value string) error {
+	if value == "" {
+		if a.Optional {
+			return nil
+		}
+		return errors.NotValidf("empty value")
+	}
+	if len(a.Options) > 0 {
+		found := false
+		for _, opt := range a.Options {
+			if fmt.Sprint(opt) == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.NotValidf("value %q, expected one of %v", value, a.Options)
+		}
+	}
+	if a.FilePath || a.ExpandFilePath {
+		if _, err := ValidateFileAttrValue(value); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 type cloudCredentialChecker struct{}
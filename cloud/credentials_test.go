@@ -313,6 +313,53 @@ func (s *credentialsSuite) testParseCredentialsError(c *gc.C, input []byte, expe
 	c.Assert(err, gc.ErrorMatches, expect)
 }
 
+func (s *credentialsSuite) TestCredentialAttrValidateValue(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "key")
+	err := ioutil.WriteFile(path, []byte("key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		attr  cloud.CredentialAttr
+		value string
+		err   string
+	}{{
+		attr:  cloud.CredentialAttr{},
+		value: "value",
+	}, {
+		attr: cloud.CredentialAttr{},
+		err:  "empty value not valid",
+	}, {
+		attr: cloud.CredentialAttr{Optional: true},
+	}, {
+		attr:  cloud.CredentialAttr{Options: []interface{}{"a", "b"}},
+		value: "b",
+	}, {
+		attr:  cloud.CredentialAttr{Options: []interface{}{"a", "b"}},
+		value: "c",
+		err:   `value "c", expected one of \[a b\] not valid`,
+	}, {
+		attr:  cloud.CredentialAttr{FilePath: true},
+		value: path,
+	}, {
+		attr:  cloud.CredentialAttr{ExpandFilePath: true},
+		value: dir,
+		err:   "file path must be a file: .*",
+	}, {
+		attr:  cloud.CredentialAttr{FilePath: true},
+		value: filepath.Join(dir, "missing"),
+		err:   "invalid file path: .*",
+	}} {
+		c.Logf("test %d", i)
+		err := test.attr.ValidateValue(test.value)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
 func (s *credentialsSuite) TestFinalizeCredential(c *gc.C) {
 	cred := cloud.NewCredential(
 		cloud.UserPassAuthType,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -73,6 +74,21 @@ func (c *ActionCommandBase) NewActionAPIClient() (APIClient, error) {
 	return newAPIClient(c)
 }
 
+// StatusAPIClient represents the status API functionality
+// used to select units by their labels.
+type StatusAPIClient interface {
+	io.Closer
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+var newStatusAPIClient = func(c *ActionCommandBase) (StatusAPIClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewLabelSelectClient(root), nil
+}
+
 var newAPIClient = func(c *ActionCommandBase) (APIClient, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -14,9 +14,19 @@ import (
 
 var (
 	NewActionAPIClient = &newAPIClient
+	NewStatusAPIClient = &newStatusAPIClient
 	AddValueToMap      = addValueToMap
 )
 
+// ApplyQuery parses the query expression and applies it to doc.
+func ApplyQuery(expr string, doc interface{}) (interface{}, error) {
+	q, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.apply(doc), nil
+}
+
 type ShowOutputCommand struct {
 	*showOutputCommand
 }
@@ -30,6 +30,8 @@ type listCommand struct {
 	applicationTag names.ApplicationTag
 	fullSchema     bool
 	out            cmd.Output
+	queryExpr      string
+	query          *query
 }
 
 const listDoc = `
@@ -37,6 +39,10 @@ List the actions available to run on the target application, with a short
 description.  To show the full schema for the actions, use --schema.
 
 For more information, see also the 'run-action' command, which executes actions.
+` + queryDoc + `
+Examples:
+    juju actions postgresql --query backup.description
+    juju actions postgresql --schema --query "backup.properties.*.type"
 `
 
 // Set up the output.
@@ -60,6 +66,7 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 		"default": c.dummyDefault,
 	})
 	f.BoolVar(&c.fullSchema, "schema", false, "Display the full action schema")
+	f.StringVar(&c.queryExpr, "query", "", "Show only the part of the output selected by a JMESPath-style expression")
 }
 
 func (c *listCommand) Info() *cmd.Info {
@@ -77,6 +84,15 @@ func (c *listCommand) Init(args []string) error {
 	if c.out.Name() == "tabular" && c.fullSchema {
 		return errors.New("full schema not compatible with tabular output")
 	}
+	if c.queryExpr != "" {
+		if c.out.Name() == "tabular" {
+			return errors.New("--query not compatible with tabular output")
+		}
+		var err error
+		if c.query, err = parseQuery(c.queryExpr); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no application name specified")
@@ -112,6 +128,9 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 			verboseSpecs[k] = v.Params
 		}
 
+		if c.query != nil {
+			return c.writeQueried(ctx, verboseSpecs)
+		}
 		if c.out.Name() == "default" {
 			return c.out.WriteFormatter(ctx, cmd.FormatYaml, verboseSpecs)
 		} else {
@@ -130,6 +149,10 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	}
 	naturalsort.Sort(sortedNames)
 
+	if c.query != nil {
+		return c.writeQueried(ctx, shortOutput)
+	}
+
 	var output interface{}
 	switch c.out.Name() {
 	case "yaml", "json":
@@ -154,6 +177,16 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 
 }
 
+// writeQueried writes the part of doc selected by the query, as YAML
+// unless another format was asked for.
+func (c *listCommand) writeQueried(ctx *cmd.Context, doc interface{}) error {
+	result := c.query.apply(doc)
+	if c.out.Name() == "default" {
+		return c.out.WriteFormatter(ctx, cmd.FormatYaml, result)
+	}
+	return c.out.Write(ctx, result)
+}
+
 type listOutput struct {
 	action      string
 	description string
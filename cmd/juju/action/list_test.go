@@ -61,6 +61,14 @@ func (s *ListSuite) TestInit(c *gc.C) {
 		should:      "schema with tabular output",
 		args:        []string{"--format=tabular", "--schema", validApplicationId},
 		expectedErr: "full schema not compatible with tabular output",
+	}, {
+		should:      "query with tabular output",
+		args:        []string{"--format=tabular", "--query", "kill", validApplicationId},
+		expectedErr: "--query not compatible with tabular output",
+	}, {
+		should:      "fail with invalid query",
+		args:        []string{"--query", "kill[", validApplicationId},
+		expectedErr: `invalid query "kill\[": missing \]`,
 	}, {
 		should:               "init properly with valid application name and --schema",
 		args:                 []string{"--format=yaml", "--schema", validApplicationId},
@@ -109,6 +117,7 @@ snapshot        Take a snapshot of the database.
 		withAPIErr       string
 		withCharmActions map[string]params.ActionSpec
 		expectedErr      string
+		expectedOutput   string
 	}{{
 		should:      "pass back API error correctly",
 		withArgs:    []string{validApplicationId},
@@ -137,6 +146,25 @@ snapshot        Take a snapshot of the database.
 		withArgs:         []string{"--format=default", "--schema", validApplicationId},
 		expectFullSchema: true,
 		withCharmActions: someCharmActions,
+	}, {
+		should:           "select a description with --query",
+		withArgs:         []string{"--query", "snapshot", validApplicationId},
+		withCharmActions: someCharmActions,
+		expectedOutput:   "Take a snapshot of the database.\n",
+	}, {
+		should:           "select part of the full schema with --query",
+		withArgs:         []string{"--schema", "--query", "*.foo", validApplicationId},
+		withCharmActions: someCharmActions,
+		expectedOutput: `
+- baz
+- baz
+- bar: baz
+`[1:],
+	}, {
+		should:           "format the query result as asked",
+		withArgs:         []string{"--format=json", "--schema", "--query", "snapshot.foo", validApplicationId},
+		withCharmActions: someCharmActions,
+		expectedOutput:   `{"bar":"baz"}` + "\n",
 	}}
 
 	for i, t := range tests {
@@ -162,6 +190,8 @@ snapshot        Take a snapshot of the database.
 					result := ctx.Stdout.(*bytes.Buffer).Bytes()
 					if t.expectFullSchema {
 						checkFullSchema(c, t.withCharmActions, result)
+					} else if t.expectedOutput != "" {
+						c.Check(cmdtesting.Stdout(ctx), gc.Equals, t.expectedOutput)
 					} else if t.expectNoResults {
 						c.Check(cmdtesting.Stderr(ctx), gc.Matches, t.expectMessage)
 					} else {
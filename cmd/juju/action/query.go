@@ -0,0 +1,226 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const queryDoc = `
+The --query option selects part of the output using a JMESPath-style
+expression, so that a single value can be extracted by a script. Field
+names are separated by dots, and may be quoted when they contain dots
+themselves; unlike JMESPath, field names may also contain hyphens
+without being quoted. [n] selects an element of a list, counting back
+from the end when negative. The wildcards * and [*] apply the rest of
+the expression to every value of a map or list, as in
+"results.backups[*].filename". Parts of the expression which select
+nothing give no output, rather than an error.
+`
+
+// queryStepKind identifies the kind of a queryStep.
+type queryStepKind int
+
+const (
+	// fieldStep selects a map value by key.
+	fieldStep queryStepKind = iota
+
+	// indexStep selects a list element by index.
+	indexStep
+
+	// valuesStep projects the rest of the query over
+	// the values of a map, in order of their keys.
+	valuesStep
+
+	// elementsStep projects the rest of the query over
+	// the elements of a list.
+	elementsStep
+)
+
+// queryStep is a single step of a query.
+type queryStep struct {
+	kind  queryStepKind
+	field string
+	index int
+}
+
+// query selects part of a document made of maps, lists and
+// scalar values, using a subset of the JMESPath language.
+type query struct {
+	steps []queryStep
+}
+
+// parseQuery parses the given query expression.
+func parseQuery(expr string) (*query, error) {
+	s := strings.TrimSpace(expr)
+	// Be forgiving of jq users.
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, errors.NotValidf("empty query")
+	}
+	var q query
+	afterDot := false
+	for first := true; s != ""; first = false {
+		switch {
+		case s[0] == '[' && !afterDot:
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, errors.Errorf("invalid query %q: missing ]", expr)
+			}
+			inner := strings.TrimSpace(s[1:end])
+			if inner == "*" {
+				q.steps = append(q.steps, queryStep{kind: elementsStep})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, errors.Errorf("invalid query %q: invalid index %q", expr, inner)
+				}
+				q.steps = append(q.steps, queryStep{kind: indexStep, index: index})
+			}
+			s = s[end+1:]
+		case s[0] == '.' && !afterDot && !first:
+			afterDot = true
+			s = s[1:]
+			if s == "" {
+				return nil, errors.Errorf("invalid query %q: missing field after .", expr)
+			}
+		case afterDot || first:
+			step, rest, err := parseQueryField(s)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid query %q", expr)
+			}
+			q.steps = append(q.steps, step)
+			s = rest
+			afterDot = false
+		default:
+			return nil, errors.Errorf("invalid query %q: unexpected %q", expr, s)
+		}
+	}
+	return &q, nil
+}
+
+// parseQueryField parses the field or wildcard at the start of s,
+// returning the remainder of s.
+func parseQueryField(s string) (queryStep, string, error) {
+	switch s[0] {
+	case '*':
+		return queryStep{kind: valuesStep}, s[1:], nil
+	case '"':
+		end := closingQuote(s)
+		if end < 0 {
+			return queryStep{}, "", errors.Errorf("unterminated quoted field %s", s)
+		}
+		quoted := s[:end+1]
+		field, err := strconv.Unquote(quoted)
+		if err != nil {
+			return queryStep{}, "", errors.Trace(err)
+		}
+		return queryStep{kind: fieldStep, field: field}, s[len(quoted):], nil
+	}
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || r == '-' ||
+			r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	if end == 0 {
+		return queryStep{}, "", errors.Errorf("unexpected %q", s)
+	}
+	return queryStep{kind: fieldStep, field: s[:end]}, s[end:], nil
+}
+
+// closingQuote returns the index of the unescaped double quote
+// which closes the quoted string at the start of s, or -1 if
+// there is none.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// apply returns the part of doc selected by the query, or nil
+// if the query selects nothing.
+func (q *query) apply(doc interface{}) interface{} {
+	return applyQuerySteps(q.steps, doc)
+}
+
+func applyQuerySteps(steps []queryStep, value interface{}) interface{} {
+	for i, step := range steps {
+		if value == nil {
+			return nil
+		}
+		v := reflect.ValueOf(value)
+		switch step.kind {
+		case fieldStep:
+			if v.Kind() != reflect.Map {
+				return nil
+			}
+			key := reflect.ValueOf(step.field)
+			keyType := v.Type().Key()
+			switch keyType.Kind() {
+			case reflect.String:
+				key = key.Convert(keyType)
+			case reflect.Interface:
+			default:
+				return nil
+			}
+			elem := v.MapIndex(key)
+			if !elem.IsValid() {
+				return nil
+			}
+			value = elem.Interface()
+		case indexStep:
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return nil
+			}
+			index := step.index
+			if index < 0 {
+				index += v.Len()
+			}
+			if index < 0 || index >= v.Len() {
+				return nil
+			}
+			value = v.Index(index).Interface()
+		case valuesStep, elementsStep:
+			var elems []reflect.Value
+			switch {
+			case step.kind == valuesStep && v.Kind() == reflect.Map:
+				keys := v.MapKeys()
+				sort.Slice(keys, func(i, j int) bool {
+					return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+				})
+				for _, key := range keys {
+					elems = append(elems, v.MapIndex(key))
+				}
+			case step.kind == elementsStep && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+				for i := 0; i < v.Len(); i++ {
+					elems = append(elems, v.Index(i))
+				}
+			default:
+				return nil
+			}
+			results := []interface{}{}
+			for _, elem := range elems {
+				if result := applyQuerySteps(steps[i+1:], elem.Interface()); result != nil {
+					results = append(results, result)
+				}
+			}
+			return results
+		}
+	}
+	return value
+}
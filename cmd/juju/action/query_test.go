@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+var queryDoc = map[string]interface{}{
+	"status": "completed",
+	"results": map[string]interface{}{
+		"backup-filename": "juju-backup.tar.gz",
+		"dotted.key":      "dotted",
+		"sizes":           []interface{}{1, 2, 3},
+		"units": map[string]interface{}{
+			"mysql/1": map[string]interface{}{"port": 3306},
+			"mysql/0": map[string]interface{}{"port": 3307},
+			"mysql/2": map[string]interface{}{},
+		},
+		"backups": []interface{}{
+			map[string]interface{}{"filename": "one.tar.gz"},
+			map[string]interface{}{"size": 10},
+			map[string]interface{}{"filename": "three.tar.gz"},
+		},
+	},
+	"timing": map[string]string{
+		"completed": "2015-02-14 08:15:30 +0000 UTC",
+	},
+}
+
+func (s *QuerySuite) TestApply(c *gc.C) {
+	for i, test := range []struct {
+		query  string
+		result interface{}
+	}{{
+		query:  "status",
+		result: "completed",
+	}, {
+		query:  ".status",
+		result: "completed",
+	}, {
+		query:  "results.backup-filename",
+		result: "juju-backup.tar.gz",
+	}, {
+		query:  `results."dotted.key"`,
+		result: "dotted",
+	}, {
+		query:  "timing.completed",
+		result: "2015-02-14 08:15:30 +0000 UTC",
+	}, {
+		query:  "results.sizes[0]",
+		result: 1,
+	}, {
+		query:  "results.sizes[-1]",
+		result: 3,
+	}, {
+		query:  "results.sizes[3]",
+		result: nil,
+	}, {
+		query:  "results.missing.key",
+		result: nil,
+	}, {
+		query:  "status[0]",
+		result: nil,
+	}, {
+		query:  "results.backups[*].filename",
+		result: []interface{}{"one.tar.gz", "three.tar.gz"},
+	}, {
+		query:  "results.units.*.port",
+		result: []interface{}{3307, 3306},
+	}, {
+		query:  "results.sizes[*]",
+		result: []interface{}{1, 2, 3},
+	}, {
+		query:  "status[*]",
+		result: nil,
+	}} {
+		c.Logf("test %d: %s", i, test.query)
+		result, err := action.ApplyQuery(test.query, queryDoc)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, test.result)
+	}
+}
+
+func (s *QuerySuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		query string
+		err   string
+	}{{
+		query: "",
+		err:   "empty query not valid",
+	}, {
+		query: "results.",
+		err:   `invalid query "results.": missing field after .`,
+	}, {
+		query: "results..foo",
+		err:   `invalid query "results..foo": unexpected ".foo"`,
+	}, {
+		query: "results.[0]",
+		err:   `invalid query "results.\[0\]": unexpected "\[0\]"`,
+	}, {
+		query: "results[0",
+		err:   `invalid query "results\[0": missing \]`,
+	}, {
+		query: "results[first]",
+		err:   `invalid query "results\[first\]": invalid index "first"`,
+	}, {
+		query: `results."foo`,
+		err:   `invalid query "results.\\"foo": unterminated quoted field "foo`,
+	}, {
+		query: "results foo",
+		err:   `invalid query "results foo": unexpected " foo"`,
+	}} {
+		c.Logf("test %d: %s", i, test.query)
+		_, err := action.ApplyQuery(test.query, queryDoc)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
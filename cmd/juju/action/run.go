@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6"
@@ -20,6 +21,8 @@ import (
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/labels"
 )
 
 // leaderSnippet is a regular expression for unit ID-like syntax that is used
@@ -41,11 +44,14 @@ type runCommand struct {
 	ActionCommandBase
 	api           APIClient
 	unitReceivers []string
+	selector      string
 	leaders       map[string]string
 	actionName    string
 	paramsYAML    cmd.FileVar
 	parseStrings  bool
 	wait          waitFlag
+	background    bool
+	notifyURL     string
 	out           cmd.Output
 	args          [][]string
 }
@@ -62,6 +68,11 @@ Valid unit identifiers are:
 If the leader syntax is used, the leader unit for the application will be
 resolved before the action is enqueued.
 
+Units may also be selected by their labels with the --selector option, which
+takes a comma-separated list of requirements of the form key=value,
+key!=value, key or !key. The action is enqueued on every unit whose labels
+match, in addition to any units given explicitly.
+
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
 Params may be in a yaml file which is passed with the --params option, or they
@@ -74,11 +85,22 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+The --background option returns as soon as the action is queued, printing
+the action IDs, so that long-running actions such as backups need not hold
+the terminal open. It cannot be combined with --wait.
+
+The --notify option takes an https URL to which the controller posts
+a JSON notification when the action finishes, whether it completed, failed
+or was cancelled. The notification includes a "text" summary, so the URL of
+a chat service's incoming webhook may be used to be notified in a channel.
+
 Examples:
 
     juju run-action mysql/3 backup --wait
     juju run-action mysql/3 backup
+    juju run-action mysql/3 backup --background --notify https://hooks.example.com/juju
     juju run-action mysql/leader backup
+    juju run-action --selector env=canary backup
     juju show-action-output <ID>
     juju run-action mysql/3 backup --params parameters.yml
     juju run-action mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
@@ -94,12 +116,15 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.StringVar(&c.selector, "selector", "", "Queue the action on the units whose labels match the selector")
+	f.BoolVar(&c.background, "background", false, "Return as soon as the action is queued")
+	f.StringVar(&c.notifyURL, "notify", "", "URL to post a notification to when the action finishes")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "[<unit> ...] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	})
@@ -117,12 +142,24 @@ func (c *runCommand) Init(args []string) (err error) {
 			return errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(c.unitReceivers) == 0 {
+	if c.selector != "" {
+		if _, err := labels.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+	} else if len(c.unitReceivers) == 0 {
 		return errors.New("no unit specified")
 	}
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
+	if c.background && (c.wait.forever || c.wait.d > 0) {
+		return errors.New("cannot specify both --background and --wait")
+	}
+	if c.notifyURL != "" {
+		if err := actions.ValidateNotifyURL(c.notifyURL); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
@@ -203,6 +240,17 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.notifyURL != "" && c.api.BestAPIVersion() < 4 {
+		return errors.New("action notifications are not supported by this controller" +
+			"\nupgrade your controller to use --notify")
+	}
+
+	if c.selector != "" {
+		if err := c.addSelectedUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	actions := make([]params.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -218,6 +266,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
+		actions[i].NotifyURL = c.notifyURL
 	}
 	results, err := c.api.Enqueue(params.Actions{Actions: actions})
 	if err != nil {
@@ -243,7 +292,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		// Legacy Juju 1.25 output format for a single unit, no wait.
 		if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 && len(results.Results) == 1 {
 			out := map[string]string{"Action queued with id": tag.Id()}
-			return c.out.Write(ctx, out)
+			return c.writeQueued(ctx, out)
 		}
 	}
 
@@ -268,7 +317,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				"unit": unitTag.Id(),
 			}
 		}
-		return c.out.Write(ctx, out)
+		return c.writeQueued(ctx, out)
 	}
 
 	var wait *time.Timer
@@ -301,6 +350,42 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, out)
 }
 
+// writeQueued writes the IDs of the queued actions, telling the user
+// how to get their results if the command was run in the background.
+func (c *runCommand) writeQueued(ctx *cmd.Context, out interface{}) error {
+	if err := c.out.Write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
+	if c.background {
+		ctx.Infof("Run 'juju show-action-output <ID>' to see the results of the action.")
+	}
+	return nil
+}
+
+// addSelectedUnits adds the units whose labels match
+// the selector to those the action is enqueued on.
+func (c *runCommand) addSelectedUnits() error {
+	client, err := newStatusAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	_, units, err := common.SelectByLabels(client, c.selector)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return errors.Errorf("no units match selector %q", c.selector)
+	}
+	given := set.NewStrings(c.unitReceivers...)
+	for _, unit := range units {
+		if !given.Contains(unit) {
+			c.unitReceivers = append(c.unitReceivers, unit)
+		}
+	}
+	return nil
+}
+
 func (c *runCommand) ensureAPI() (err error) {
 	if c.api != nil {
 		return nil
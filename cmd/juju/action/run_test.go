@@ -197,6 +197,33 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnits:  []string{"mysql/leader"},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:       "work with a selector and no units",
+		args:         []string{"--selector", "env=canary", "valid-action-name"},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{},
+	}, {
+		should:      "fail with an invalid selector",
+		args:        []string{"--selector", "env=", "valid-action-name"},
+		expectError: `parsing selector "env=": .*`,
+	}, {
+		should:       "work with --background and --notify",
+		args:         []string{"--background", "--notify", "https://hooks.example.com/1", validUnitId, "valid-action-name"},
+		expectUnits:  []string{validUnitId},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{},
+	}, {
+		should:      "fail with --background and --wait",
+		args:        []string{"--background", "--wait", validUnitId, "valid-action-name"},
+		expectError: "cannot specify both --background and --wait",
+	}, {
+		should:      "fail with an invalid notification URL",
+		args:        []string{"--notify", "http://example.com", validUnitId, "valid-action-name"},
+		expectError: `notification URL "http://example.com" \(expected an https URL\) not valid`,
+	}, {
+		should:      "fail with a private notification URL",
+		args:        []string{"--notify", "https://10.0.0.1/hook", validUnitId, "valid-action-name"},
+		expectError: `notification URL "https://10.0.0.1/hook" \(local and private addresses are not allowed\) not valid`,
 	}}
 
 	for i, t := range tests {
@@ -219,6 +246,66 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestRunWithSelector(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{
+			{Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-0"}},
+			{Action: &params.Action{Tag: validActionTagString, Receiver: "unit-mysql-1"}},
+		},
+		apiVersion: 3,
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+	statusClient := &fakeStatusAPIClient{status: &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/0": {Labels: map[string]string{"env": "canary"}},
+				"mysql/1": {Labels: map[string]string{"env": "canary"}},
+				"mysql/2": {Labels: map[string]string{"env": "prod"}},
+			}},
+		},
+	}}
+	s.PatchValue(action.NewStatusAPIClient, func(*action.ActionCommandBase) (action.StatusAPIClient, error) {
+		return statusClient, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "--selector", "env=canary", validUnitId, "some-action")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusClient.patterns, jc.DeepEquals, []string{"selector:env=canary"})
+	receivers := []string{}
+	for _, a := range fakeClient.EnqueuedActions().Actions {
+		receivers = append(receivers, a.Receiver)
+	}
+	c.Check(receivers, jc.DeepEquals, []string{"unit-mysql-0", "unit-mysql-1"})
+}
+
+func (s *RunSuite) TestRunWithSelectorNoUnits(c *gc.C) {
+	s.PatchValue(action.NewStatusAPIClient, func(*action.ActionCommandBase) (action.StatusAPIClient, error) {
+		return &fakeStatusAPIClient{status: &params.FullStatus{}}, nil
+	})
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", "--selector", "env=canary", "some-action")
+	c.Assert(err, gc.ErrorMatches, `no units match selector "env=canary"`)
+}
+
+type fakeStatusAPIClient struct {
+	status   *params.FullStatus
+	patterns []string
+}
+
+func (c *fakeStatusAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	c.patterns = patterns
+	return c.status, nil
+}
+
+func (c *fakeStatusAPIClient) Close() error {
+	return nil
+}
+
 func (s *RunSuite) TestRun(c *gc.C) {
 	tests := []struct {
 		should                 string
@@ -410,6 +497,27 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   "mysql/leader",
 		},
+	}, {
+		should:   "fail with --notify on an old API",
+		withArgs: []string{validUnitId, "some-action", "--notify", "https://hooks.example.com/1"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedErr: "action notifications are not supported by this controller" +
+			"\nupgrade your controller to use --notify",
+	}, {
+		should:      "enqueue an action with a notification URL in the background",
+		clientSetup: func(api *fakeAPIClient) { api.apiVersion = 4 },
+		withArgs:    []string{validUnitId, "some-action", "--background", "--notify", "https://hooks.example.com/1"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			NotifyURL:  "https://hooks.example.com/1",
+		},
 	}}
 
 	for i, t := range tests {
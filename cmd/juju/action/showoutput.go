@@ -28,6 +28,8 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	queryExpr   string
+	query       *query
 }
 
 const showOutputDoc = `
@@ -39,6 +41,10 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+` + queryDoc + `
+Examples:
+    juju show-action-output 1234 --query status
+    juju show-action-output 1234 --wait 0 --query results.backup-filename
 `
 
 // Set up the output.
@@ -46,6 +52,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.StringVar(&c.queryExpr, "query", "", "Show only the part of the output selected by a JMESPath-style expression")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -59,6 +66,12 @@ func (c *showOutputCommand) Info() *cmd.Info {
 
 // Init validates the action ID and any other options.
 func (c *showOutputCommand) Init(args []string) error {
+	if c.queryExpr != "" {
+		var err error
+		if c.query, err = parseQuery(c.queryExpr); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no action ID specified")
@@ -110,7 +123,11 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	return c.out.Write(ctx, FormatActionResult(result))
+	formatted := FormatActionResult(result)
+	if c.query != nil {
+		return c.out.Write(ctx, c.query.apply(formatted))
+	}
+	return c.out.Write(ctx, formatted)
 }
 
 // GetActionResult tries to repeatedly fetch an action until it is
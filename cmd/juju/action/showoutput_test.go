@@ -40,6 +40,10 @@ func (s *ShowOutputSuite) TestInit(c *gc.C) {
 		should:      "fail with multiple args",
 		args:        []string{"12345", "54321"},
 		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:      "fail with invalid query",
+		args:        []string{"--query", "results..foo", "12345"},
+		expectError: `invalid query "results..foo": unexpected ".foo"`,
 	}}
 
 	for i, t := range tests {
@@ -294,6 +298,52 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunQuery(c *gc.C) {
+	response := []params.ActionResult{{
+		Status: "completed",
+		Output: map[string]interface{}{
+			"foo": map[string]interface{}{
+				"bar": "baz",
+			},
+			"backups": []interface{}{
+				map[string]interface{}{"filename": "one.tar.gz"},
+				map[string]interface{}{"filename": "two.tar.gz"},
+			},
+		},
+		Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+		Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+	}}
+	for i, t := range []struct {
+		args           []string
+		expectedOutput string
+	}{{
+		args:           []string{"--query", "status"},
+		expectedOutput: "completed\n",
+	}, {
+		args:           []string{"--query", "results.foo.bar"},
+		expectedOutput: "baz\n",
+	}, {
+		args:           []string{"--query", "results.backups[-1].filename"},
+		expectedOutput: "two.tar.gz\n",
+	}, {
+		args:           []string{"--query", "results.backups[*].filename", "--format", "json"},
+		expectedOutput: `["one.tar.gz","two.tar.gz"]` + "\n",
+	}, {
+		args:           []string{"--query", "timing.completed"},
+		expectedOutput: "2015-02-14 08:15:30 +0000 UTC\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		client := makeFakeClient(0, 10*time.Second, tagsForIdPrefix(validActionId, validActionTagString), response, params.ActionsByNames{}, "")
+		unpatch := s.BaseActionSuite.patchAPIClient(client)
+		cmd, _ := action.NewShowOutputCommandForTest(s.store)
+		args := append([]string{"-m", "admin", validActionId}, t.args...)
+		ctx, err := cmdtesting.RunCommand(c, cmd, args...)
+		unpatch()
+		c.Assert(err, gc.IsNil)
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, t.expectedOutput)
+	}
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -118,6 +118,6 @@ func (mockAddAPI) Consume(crossmodel.ConsumeApplicationArgs) (string, error) {
 	return "", errors.New("unexpected method call: Consume")
 }
 
-func (mockAddAPI) GetConsumeDetails(string) (params.ConsumeOfferDetails, error) {
-	return params.ConsumeOfferDetails{}, errors.New("unexpected method call: GetConsumeDetails")
+func (mockAddAPI) GetConsumeDetailsForController(string, string) (params.ConsumeOfferDetails, error) {
+	return params.ConsumeOfferDetails{}, errors.New("unexpected method call: GetConsumeDetailsForController")
 }
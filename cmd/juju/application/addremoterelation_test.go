@@ -47,7 +47,7 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationRemoteApplications(c *gc.C
 
 func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationToOneRemoteApplication(c *gc.C) {
 	s.assertAddedRelation(c, "applicationname", "othermodel.applicationname2")
-	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2")
+	s.mockAPI.CheckCall(c, 1, "GetConsumeDetailsForController", "othermodel.applicationname2", s.ControllerConfig.ControllerUUID())
 	s.mockAPI.CheckCall(c, 2, "Consume",
 		crossmodel.ConsumeApplicationArgs{
 			Offer: params.ApplicationOfferDetails{
@@ -68,7 +68,7 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationToOneRemoteApplication(c *
 
 func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationAnyRemoteApplication(c *gc.C) {
 	s.assertAddedRelation(c, "othermodel.applicationname2", "applicationname")
-	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2")
+	s.mockAPI.CheckCall(c, 1, "GetConsumeDetailsForController", "othermodel.applicationname2", s.ControllerConfig.ControllerUUID())
 	s.mockAPI.CheckCall(c, 2, "Consume",
 		crossmodel.ConsumeApplicationArgs{
 			Offer: params.ApplicationOfferDetails{
@@ -95,13 +95,13 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationFailure(c *gc.C) {
 
 	err := s.runAddRelation(c, "othermodel.applicationname2", "applicationname")
 	c.Assert(err, gc.ErrorMatches, msg)
-	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetails", "Consume", "Close", "AddRelation", "Close")
+	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetailsForController", "Consume", "Close", "AddRelation", "Close")
 }
 
 func (s *AddRemoteRelationSuiteNewAPI) TestAddedRelationVia(c *gc.C) {
 	err := s.runAddRelation(c, "othermodel.applicationname2", "applicationname", "--via", "192.168.1.0/16, 10.0.0.0/16")
 	c.Assert(err, jc.ErrorIsNil)
-	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetails", "Consume", "Close", "AddRelation", "Close")
+	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetailsForController", "Consume", "Close", "AddRelation", "Close")
 	s.mockAPI.CheckCall(c, 4, "AddRelation",
 		[]string{"applicationname2", "applicationname"}, []string{"192.168.1.0/16", "10.0.0.0/16"})
 }
@@ -109,7 +109,7 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddedRelationVia(c *gc.C) {
 func (s *AddRemoteRelationSuiteNewAPI) assertAddedRelation(c *gc.C, args ...string) {
 	err := s.runAddRelation(c, args...)
 	c.Assert(err, jc.ErrorIsNil)
-	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetails", "Consume", "Close", "AddRelation", "Close")
+	s.mockAPI.CheckCallNames(c, "BestAPIVersion", "GetConsumeDetailsForController", "Consume", "Close", "AddRelation", "Close")
 }
 
 // AddRemoteRelationSuiteOldAPI only needs to check that we have fallen through to the old api
@@ -253,8 +253,8 @@ func (m *mockAddRelationAPI) Consume(arg crossmodel.ConsumeApplicationArgs) (str
 	return arg.ApplicationAlias, nil
 }
 
-func (m *mockAddRelationAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetails, error) {
-	m.AddCall("GetConsumeDetails", url)
+func (m *mockAddRelationAPI) GetConsumeDetailsForController(url, controllerUUID string) (params.ConsumeOfferDetails, error) {
+	m.AddCall("GetConsumeDetailsForController", url, controllerUUID)
 	return params.ConsumeOfferDetails{
 		Offer: &params.ApplicationOfferDetails{
 			OfferName: "hosted-mysql",
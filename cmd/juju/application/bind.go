@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces an application's endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Binds the endpoints of a deployed application to spaces, changing the
+bindings made when it was deployed. Bindings take the same form as the
+--bind option of deploy: endpoint-name=space-name binds one endpoint,
+and a lone space name changes the application's default space, which
+applies to every endpoint not bound explicitly.
+
+The controller checks that each machine hosting the application's
+units is connected to the new spaces. If any machine is not, the
+bindings are left unchanged and the machines are listed; such
+machines need their network reconfiguring, or replacing with machines
+that are connected to the spaces. With --force, the bindings are
+changed anyway, and the machines are listed as warnings.
+
+Examples:
+    juju bind mysql db=internal
+    juju bind mysql public server=internal cluster=internal
+    juju bind --force mysql db=internal
+
+See also:
+    deploy
+    spaces`[1:]
+
+// NewBindCommand returns a command which changes the endpoint
+// bindings of an application.
+func NewBindCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+// bindAPI defines a subset of the application facade, as required
+// by the bind command.
+type bindAPI interface {
+	Close() error
+	MergeBindings(application string, bindings map[string]string, force bool) ([]string, error)
+}
+
+// bindCommand changes the endpoint bindings of an application.
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+
+	newAPIFunc func() (bindAPI, error)
+
+	applicationName string
+	bindings        map[string]string
+	force           bool
+}
+
+// Info implements cmd.Command.
+func (c *bindCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "bind",
+		Args:    "<application> [<default-space>] [<endpoint-name>=<space> ...]",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *bindCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Change the bindings even if machines are not connected to the new spaces")
+}
+
+// Init implements cmd.Command.
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	bindings, err := parseBindExpr(strings.Join(args[1:], " "))
+	if err != nil {
+		return errors.Annotate(err, "parsing bindings")
+	}
+	c.bindings = bindings
+	return nil
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *bindCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	problems, err := client.MergeBindings(c.applicationName, c.bindings, c.force)
+	if err != nil {
+		for _, problem := range problems {
+			ctx.Infof("  %s", problem)
+		}
+		if len(problems) > 0 {
+			ctx.Infof("Reconfigure or replace the machines, or use --force to change the bindings anyway.")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	for _, problem := range problems {
+		ctx.Warningf("%s; the machine requires network reconfiguration or replacement", problem)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type BindSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockBindAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockBindAPI{Stub: &testing.Stub{}}
+}
+
+func (s *BindSuite) runBind(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewBindCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *BindSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql", "=internal"},
+		err:  "parsing bindings: Found = without endpoint name. Use a lone space name to set the default.",
+	}, {
+		args: []string{"my sql", "db=internal"},
+		err:  `application name "my sql" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := s.runBind(c, "mysql", "public", "db=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"MergeBindings", []interface{}{"mysql", map[string]string{"": "public", "db": "internal"}, false}},
+		{"Close", nil},
+	})
+}
+
+func (s *BindSuite) TestBindMachinesNotConnected(c *gc.C) {
+	s.mockAPI.problems = []string{`machine 1 (hosting mysql/1) is not connected to space(s) "internal"`}
+	s.mockAPI.SetErrors(errors.New("cannot change endpoint bindings"))
+
+	ctx, err := s.runBind(c, "mysql", "db=internal")
+	c.Assert(err, gc.ErrorMatches, "cannot change endpoint bindings")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+  machine 1 (hosting mysql/1) is not connected to space(s) "internal"
+Reconfigure or replace the machines, or use --force to change the bindings anyway.
+`[1:])
+}
+
+func (s *BindSuite) TestBindForce(c *gc.C) {
+	s.mockAPI.problems = []string{`machine 1 (hosting mysql/1) is not connected to space(s) "internal"`}
+
+	ctx, err := s.runBind(c, "--force", "mysql", "db=internal")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "MergeBindings", "mysql", map[string]string{"db": "internal"}, true)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "machine requires network reconfiguration or replacement")
+}
+
+type mockBindAPI struct {
+	*testing.Stub
+	problems []string
+}
+
+func (m *mockBindAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockBindAPI) MergeBindings(application string, bindings map[string]string, force bool) ([]string, error) {
+	m.MethodCall(m, "MergeBindings", application, bindings, force)
+	return m.problems, m.NextErr()
+}
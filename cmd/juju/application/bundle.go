@@ -6,6 +6,7 @@ package application
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/controllerfeatures"
 	app "github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
@@ -114,6 +116,11 @@ type bundleDeploySpec struct {
 	dryRun bool
 	force  bool
 	trust  bool
+	atomic bool
+
+	// progress, if not nil, reports machine-readable
+	// progress events for the deploy.
+	progress *deployProgress
 
 	bundleData        *charm.BundleData
 	bundleDir         string
@@ -133,17 +140,23 @@ type bundleDeploySpec struct {
 
 // deployBundle deploys the given bundle data using the given API client and
 // charm store client. The deployment is not transactional, and its progress is
-// notified using the given deployment logger.
+// notified using the given deployment logger. If spec.atomic is set, the
+// entities created by the deployment are removed again if it fails.
 func deployBundle(spec bundleDeploySpec) (map[*charm.URL]*macaroon.Macaroon, error) {
 	if err := composeBundle(spec.bundleData, spec.ctx, spec.bundleDir, spec.bundleOverlayFile); err != nil {
 		return nil, errors.Trace(err)
 	}
+	zonePlacements, err := extractZonePlacements(spec.bundleData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := verifyBundle(spec.bundleData, spec.bundleDir); err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	// TODO: move bundle parsing and checking into the handler.
 	h := makeBundleHandler(spec)
+	h.zonePlacements = zonePlacements
 	if err := h.makeModel(spec.useExistingMachines, spec.bundleMachines); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -241,6 +254,25 @@ type bundleHandler struct {
 
 	// The UUID of the model where the bundle is about to be deployed.
 	targetModelUUID string
+
+	// zonePlacements holds the zones requested by the zone placement
+	// directives of each application, keyed by application name.
+	zonePlacements map[string][]zonePlacement
+
+	// machineZones and unitZones hold the zones to create machines in,
+	// keyed by the id of the change that adds the machine, or the unit
+	// on a new machine.
+	machineZones map[string]zonePlacement
+	unitZones    map[string]zonePlacement
+
+	// rollbackRecord records the entities created by the deploy, so
+	// that they can be removed if it fails. It is nil unless the
+	// bundle is deployed atomically.
+	rollbackRecord *bundleRollback
+
+	// progress reports machine-readable progress events for the
+	// deploy. It is nil unless they were requested.
+	progress *deployProgress
 }
 
 func makeBundleHandler(spec bundleDeploySpec) *bundleHandler {
@@ -248,6 +280,10 @@ func makeBundleHandler(spec bundleDeploySpec) *bundleHandler {
 	for name := range spec.bundleData.Applications {
 		applications.Add(name)
 	}
+	var rollbackRecord *bundleRollback
+	if spec.atomic && !spec.dryRun {
+		rollbackRecord = &bundleRollback{}
+	}
 	return &bundleHandler{
 		dryRun:        spec.dryRun,
 		force:         spec.force,
@@ -267,6 +303,8 @@ func makeBundleHandler(spec bundleDeploySpec) *bundleHandler {
 		channels:      make(map[*charm.URL]csparams.Channel),
 
 		targetModelUUID: spec.targetModelUUID,
+		rollbackRecord:  rollbackRecord,
+		progress:        spec.progress,
 	}
 }
 
@@ -379,18 +417,38 @@ func (h *bundleHandler) getChanges() error {
 	// Filter create offer changes if the feature flag is not enabled. We
 	// need to do it here rather in handleChanges() as the bundle handler
 	// will also iterate the list to print out a changelog.
-	if !featureflag.Enabled(feature.CMRAwareBundles) {
-		var filtered []bundlechanges.Change
-		for _, ch := range changes {
-			if ch.Method() != "createOffer" {
-				filtered = append(filtered, ch)
-			}
+	var offers, filtered []bundlechanges.Change
+	for _, ch := range changes {
+		if ch.Method() == "createOffer" {
+			offers = append(offers, ch)
+		} else {
+			filtered = append(filtered, ch)
 		}
+	}
+	if len(offers) > 0 && !h.cmrAwareBundles() {
 		changes = filtered
 	}
 
 	h.changes = changes
-	return nil
+	return errors.Trace(h.assignZonePlacements())
+}
+
+// cmrAwareBundles reports whether offers in bundles are to be created,
+// because the feature flag is enabled either for the client or on the
+// controller.
+func (h *bundleHandler) cmrAwareBundles() bool {
+	if featureflag.Enabled(feature.CMRAwareBundles) {
+		return true
+	}
+	if h.api.BestFacadeVersion("ControllerFeatures") == 0 {
+		return false
+	}
+	result, err := controllerfeatures.NewClient(h.api).Features()
+	if err != nil {
+		logger.Warningf("cannot get controller feature flags: %v", err)
+		return false
+	}
+	return set.NewStrings(result.Features...).Contains(feature.CMRAwareBundles)
 }
 
 func (h *bundleHandler) handleChanges() error {
@@ -408,14 +466,14 @@ func (h *bundleHandler) handleChanges() error {
 	}
 
 	if h.dryRun {
-		fmt.Fprintf(h.ctx.Stdout, "Changes to deploy bundle:\n")
+		fmt.Fprintf(h.stdout(), "Changes to deploy bundle:\n")
 	} else {
-		fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
+		fmt.Fprintf(h.stdout(), "Executing changes:\n")
 	}
 
 	// Deploy the bundle.
 	for i, change := range h.changes {
-		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		fmt.Fprintf(h.stdout(), "- %s\n", change.Description())
 		logger.Tracef("%d: change %s", i, pretty.Sprint(change))
 		switch change := change.(type) {
 		case *bundlechanges.AddCharmChange:
@@ -446,6 +504,12 @@ func (h *bundleHandler) handleChanges() error {
 			return errors.Errorf("unknown change type: %T", change)
 		}
 		if err != nil {
+			if h.rollbackRecord != nil {
+				fmt.Fprintf(h.stdout(), "Rolling back changes:\n")
+				if rollbackErr := h.rollback(); rollbackErr != nil {
+					h.ctx.Warningf("cannot roll back bundle deploy: %v", rollbackErr)
+				}
+			}
 			return errors.Trace(err)
 		}
 	}
@@ -457,6 +521,16 @@ func (h *bundleHandler) handleChanges() error {
 	return nil
 }
 
+// stdout returns the writer for the output of the deploy meant for
+// people. When progress events are reported on stdout, this output
+// goes to stderr instead so that the two are not mixed.
+func (h *bundleHandler) stdout() io.Writer {
+	if h.progress != nil {
+		return h.ctx.Stderr
+	}
+	return h.ctx.Stdout
+}
+
 func (h *bundleHandler) isLocalCharm(name string) bool {
 	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
 }
@@ -492,6 +566,7 @@ func (h *bundleHandler) addCharm(change *bundlechanges.AddCharmChange) error {
 			if curl, err = h.api.AddLocalCharm(curl, ch, h.force); err != nil {
 				return err
 			}
+			h.progress.charmUploaded(curl)
 			logger.Debugf("added charm %s", curl)
 			h.results[id] = curl.String()
 			return nil
@@ -511,11 +586,13 @@ func (h *bundleHandler) addCharm(change *bundlechanges.AddCharmChange) error {
 	if url.Series == "bundle" {
 		return errors.Errorf("expected charm URL, got bundle URL %q", p.Charm)
 	}
+	h.progress.charmResolved(url)
 	var macaroon *macaroon.Macaroon
 	url, macaroon, err = addCharmFromURL(h.api, url, channel, h.force)
 	if err != nil {
 		return errors.Annotatef(err, "cannot add charm %q", p.Charm)
 	}
+	h.progress.charmUploaded(url)
 	logger.Debugf("added charm %s", url)
 	h.results[id] = url.String()
 	h.macaroons[url] = macaroon
@@ -697,6 +774,8 @@ func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChang
 	}); err != nil {
 		return errors.Annotatef(err, "cannot deploy application %q", p.Application)
 	}
+	h.rollbackRecord.addApplication(p.Application)
+	h.progress.applicationDeployed(p.Application, chID.URL)
 	h.writeAddedResources(resNames2IDs)
 
 	return nil
@@ -744,6 +823,10 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 	if p.Constraints != "" {
 		verbose = append(verbose, fmt.Sprintf("with constraints %q", p.Constraints))
 	}
+	zones := h.machineZones[change.Id()]
+	if len(zones) > 0 {
+		verbose = append(verbose, fmt.Sprintf("in zones %s", strings.Join(zones, ", ")))
+	}
 	if output := strings.Join(verbose, ", "); output != "" {
 		h.ctx.Verbosef("  %s", output)
 	}
@@ -774,9 +857,10 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 		return errors.Annotate(err, "invalid constraints for machine")
 	}
 	machineParams := params.AddMachineParams{
-		Constraints: cons,
+		Constraints: zones.constrain(cons),
 		Series:      p.Series,
 		Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Placement:   zones.machinePlacement(h.targetModelUUID),
 	}
 	if ct := p.ContainerType; ct != "" {
 		// TODO(thumper): move the warning and translation into the bundle reading code.
@@ -822,6 +906,7 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 		logger.Debugf("created %s container in machine %s for holding %s", machine, machineParams.ParentId, deployedApps())
 	}
 	h.results[change.Id()] = machine
+	h.rollbackRecord.addMachine(machine)
 	return nil
 }
 
@@ -844,6 +929,8 @@ func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) err
 		return errors.Annotatef(err, "cannot add relation between %q and %q", ep1, ep2)
 
 	}
+	h.rollbackRecord.addRelation(ep1, ep2)
+	h.progress.relationAdded(ep1, ep2)
 	return nil
 }
 
@@ -881,6 +968,8 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 		}
 		logger.Debugf("  resolved: placement %q", directive)
 		placementArg = append(placementArg, placement)
+	} else if zones, ok := h.unitZones[change.Id()]; ok {
+		placementArg = append(placementArg, zones.machinePlacement(h.targetModelUUID))
 	}
 	r, err := h.api.AddUnits(application.AddUnitsParams{
 		ApplicationName: applicationName,
@@ -891,6 +980,8 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 		return errors.Annotatef(err, "cannot add unit for application %q", applicationName)
 	}
 	unit := r[0]
+	h.rollbackRecord.addUnit(unit, applicationName, targetMachine == "")
+	h.progress.unitAdded(unit, applicationName)
 	if targetMachine == "" {
 		logger.Debugf("added %s unit to new machine", unit)
 		// In this case, the unit name is stored in results instead of the
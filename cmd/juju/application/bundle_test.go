@@ -401,6 +401,28 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleTwice(c *gc.C) {
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleProgress(c *gc.C) {
+	testcharms.UploadCharmWithSeries(c, s.client, "xenial/mysql-42", "mysql", "bionic")
+	testcharms.UploadCharmWithSeries(c, s.client, "xenial/wordpress-47", "wordpress", "bionic")
+	testcharms.UploadBundleWithSeries(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple", "bionic")
+	stdOut, stdErr, err := runDeployWithOutput(c, "bundle/wordpress-simple", "--progress", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdOut, gc.Equals, ""+
+		`{"event":"charm-resolved","charm":"cs:xenial/mysql-42"}`+"\n"+
+		`{"event":"charm-uploaded","charm":"cs:xenial/mysql-42"}`+"\n"+
+		`{"event":"application-deployed","application":"mysql","charm":"cs:xenial/mysql-42"}`+"\n"+
+		`{"event":"charm-resolved","charm":"cs:xenial/wordpress-47"}`+"\n"+
+		`{"event":"charm-uploaded","charm":"cs:xenial/wordpress-47"}`+"\n"+
+		`{"event":"application-deployed","application":"wordpress","charm":"cs:xenial/wordpress-47"}`+"\n"+
+		`{"event":"relation-added","endpoints":["wordpress:db","mysql:server"]}`+"\n"+
+		`{"event":"unit-added","application":"mysql","unit":"mysql/0"}`+"\n"+
+		`{"event":"unit-added","application":"wordpress","unit":"wordpress/0"}`,
+	)
+	// The human-oriented output is written to stderr instead.
+	c.Check(stdErr, jc.Contains, "Executing changes:\n")
+	c.Check(stdErr, jc.Contains, "- add unit wordpress/0 to new machine 1")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDryRunTwice(c *gc.C) {
 	testcharms.UploadCharmWithSeries(c, s.client, "xenial/mysql-42", "mysql", "bionic")
 	testcharms.UploadCharmWithSeries(c, s.client, "xenial/wordpress-47", "wordpress", "bionic")
@@ -842,6 +864,38 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidSeries(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot deploy bundle: cannot add unit for application "django": acquiring machine to host unit "django/0": cannot assign unit "django/0" to machine 0: series does not match`)
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleAtomicRollback(c *gc.C) {
+	testcharms.UploadCharmWithSeries(c, s.client, "trusty/django-0", "dummy", "bionic")
+	stdOut, _, err := s.DeployBundleYAMLWithOutput(c, `
+        applications:
+            django:
+                charm: trusty/django
+                num_units: 1
+                to:
+                    - 1
+        machines:
+            1:
+                series: xenial
+    `, "--atomic")
+	c.Assert(err, gc.ErrorMatches, `cannot deploy bundle: cannot add unit for application "django": .*: series does not match`)
+	c.Assert(stdOut, jc.Contains, "Rolling back changes:")
+
+	// The application and machine created by the deploy are removed.
+	app, err := s.State.Application("django")
+	if err == nil {
+		c.Assert(app.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+	machine, err := s.State.Machine("0")
+	if err == nil {
+		c.Assert(machine.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidBinding(c *gc.C) {
 	testcharms.UploadCharmWithSeries(c, s.client, "xenial/wordpress-42", "wordpress", "bionic")
 	err := s.DeployBundleYAML(c, `
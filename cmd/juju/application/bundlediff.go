@@ -126,6 +126,11 @@ func (c *bundleDiffCommand) Run(ctx *cmd.Context) error {
 	if err := composeBundle(bundle, ctx, bundleDir, c.bundleOverlays); err != nil {
 		return errors.Trace(err)
 	}
+	// Zone placements only affect where new machines are created,
+	// which isn't part of the diff.
+	if _, err := extractZonePlacements(bundle); err != nil {
+		return errors.Trace(err)
+	}
 	if err := verifyBundle(bundle, bundleDir); err != nil {
 		return errors.Trace(err)
 	}
@@ -0,0 +1,201 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// BundleRollbackAPI represents the methods of the API the deploy
+// command needs for rolling back a partially applied bundle.
+type BundleRollbackAPI interface {
+	DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error
+	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
+	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	DestroyMachinesWithParams(force, keep bool, machines ...string) error
+}
+
+// bundleRollback records the entities created while applying the
+// changes of a bundle, so that they can be removed again if applying
+// a later change fails. Changes made to entities which existed before
+// the deploy started (such as updated options or constraints) are not
+// recorded, and so are not reverted.
+type bundleRollback struct {
+	// applications holds the names of the applications deployed.
+	applications []string
+
+	// units holds the names of the units added to applications
+	// which existed before the deploy started. Units of the
+	// applications above are removed along with them.
+	units []string
+
+	// unitsOnNewMachines holds the names of all the units added
+	// without a placement, whose machines may have been created
+	// implicitly.
+	unitsOnNewMachines []string
+
+	// machines holds the ids of the machines and containers
+	// created, in the order they were created.
+	machines []string
+
+	// relations holds the endpoints of the relations added.
+	relations [][]string
+}
+
+func (r *bundleRollback) addApplication(name string) {
+	if r != nil {
+		r.applications = append(r.applications, name)
+	}
+}
+
+func (r *bundleRollback) addUnit(unit, application string, newMachine bool) {
+	if r == nil {
+		return
+	}
+	if !set.NewStrings(r.applications...).Contains(application) {
+		r.units = append(r.units, unit)
+	}
+	if newMachine {
+		r.unitsOnNewMachines = append(r.unitsOnNewMachines, unit)
+	}
+}
+
+func (r *bundleRollback) addMachine(id string) {
+	if r != nil {
+		r.machines = append(r.machines, id)
+	}
+}
+
+func (r *bundleRollback) addRelation(endpoints ...string) {
+	if r != nil {
+		r.relations = append(r.relations, endpoints)
+	}
+}
+
+// rollback removes the entities recorded in h.rollbackRecord, in the reverse
+// order of their dependencies, leaving the model as it was before the
+// deploy started. All removals are attempted even when some of them
+// fail; the first error is returned.
+func (h *bundleHandler) rollback() error {
+	r := h.rollbackRecord
+	if r == nil {
+		return nil
+	}
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// Find any machines created implicitly for the new units before
+	// the units are removed. Machines which were present before the
+	// deploy started may have been chosen to host these units, and
+	// must be left alone.
+	machines := set.NewStrings(r.machines...)
+	implicitMachines := set.NewStrings()
+	unitMachines, err := h.unitMachines(r.unitsOnNewMachines)
+	if err != nil {
+		fail(errors.Annotate(err, "cannot get machines of new units"))
+	}
+	for _, unit := range r.unitsOnNewMachines {
+		machine := unitMachines[unit]
+		if machine == "" || machines.Contains(machine) {
+			continue
+		}
+		if _, ok := h.model.Machines[machine]; ok {
+			continue
+		}
+		implicitMachines.Add(machine)
+	}
+
+	for _, endpoints := range r.relations {
+		h.ctx.Infof("- remove relation %s", strings.Join(endpoints, " "))
+		if err := h.api.DestroyRelation(nil, nil, endpoints...); err != nil {
+			fail(errors.Annotatef(err, "cannot remove relation %s", strings.Join(endpoints, " ")))
+		}
+	}
+	if len(r.units) > 0 {
+		h.ctx.Infof("- remove units %s", strings.Join(r.units, ", "))
+		results, err := h.api.DestroyUnits(application.DestroyUnitsParams{
+			Units: r.units,
+		})
+		if err != nil {
+			fail(errors.Annotate(err, "cannot remove units"))
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				fail(errors.Annotatef(result.Error, "cannot remove unit %q", r.units[i]))
+			}
+		}
+	}
+	if len(r.applications) > 0 {
+		h.ctx.Infof("- remove applications %s", strings.Join(r.applications, ", "))
+		results, err := h.api.DestroyApplications(application.DestroyApplicationsParams{
+			Applications: r.applications,
+		})
+		if err != nil {
+			fail(errors.Annotate(err, "cannot remove applications"))
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				fail(errors.Annotatef(result.Error, "cannot remove application %q", r.applications[i]))
+			}
+		}
+	}
+
+	// Containers are created after their hosts, so removing the
+	// machines in reverse order removes containers first.
+	toDestroy := implicitMachines.SortedValues()
+	for i := len(r.machines) - 1; i >= 0; i-- {
+		toDestroy = append(toDestroy, r.machines[i])
+	}
+	if len(toDestroy) > 0 {
+		h.ctx.Infof("- remove machines %s", strings.Join(toDestroy, ", "))
+		if err := h.api.DestroyMachinesWithParams(true, false, toDestroy...); err != nil {
+			fail(errors.Annotate(err, "cannot remove machines"))
+		}
+	}
+	return firstErr
+}
+
+// unitMachines returns the ids of the machines the given units are
+// assigned to, keyed by unit name. Units added without a placement
+// are recorded in h.unitStatus before they are assigned, so the
+// machines of any units not yet known are read from the model status.
+// Units which are still unassigned are left out.
+func (h *bundleHandler) unitMachines(units []string) (map[string]string, error) {
+	result := make(map[string]string)
+	var unknown []string
+	for _, unit := range units {
+		if machine := h.unitStatus[unit]; machine != "" {
+			result[unit] = machine
+		} else {
+			unknown = append(unknown, unit)
+		}
+	}
+	if len(unknown) == 0 {
+		return result, nil
+	}
+	status, err := h.api.Status(unknown)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	unknownSet := set.NewStrings(unknown...)
+	for _, appData := range status.Applications {
+		for unit, unitData := range appData.Units {
+			if unknownSet.Contains(unit) && unitData.Machine != "" {
+				result[unit] = unitData.Machine
+			}
+		}
+	}
+	return result, nil
+}
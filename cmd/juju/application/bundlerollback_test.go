@@ -0,0 +1,184 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleRollbackSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleRollbackSuite{})
+
+func (s *bundleRollbackSuite) TestRecordNil(c *gc.C) {
+	// Recording into a nil bundleRollback, as happens when the
+	// bundle is not deployed atomically, does nothing.
+	var r *bundleRollback
+	r.addApplication("mysql")
+	r.addUnit("mysql/0", "mysql", true)
+	r.addMachine("0")
+	r.addRelation("mysql:db", "wordpress:db")
+	c.Assert(r, gc.IsNil)
+}
+
+func (s *bundleRollbackSuite) TestRollback(c *gc.C) {
+	api := &fakeRollbackAPI{}
+	h := s.makeHandler(c, api)
+	h.rollbackRecord.addMachine("1")
+	h.rollbackRecord.addMachine("1/lxd/0")
+	h.rollbackRecord.addApplication("wordpress")
+	h.rollbackRecord.addUnit("wordpress/0", "wordpress", false)
+	h.rollbackRecord.addUnit("wordpress/1", "wordpress", true)
+	h.rollbackRecord.addUnit("mysql/1", "mysql", true)
+	h.rollbackRecord.addUnit("mysql/2", "mysql", true)
+	h.rollbackRecord.addRelation("mysql:db", "wordpress:db")
+	h.unitStatus = map[string]string{
+		"wordpress/0": "1/lxd/0",
+		"wordpress/1": "2",
+		// The pre-existing machine 0 was chosen for mysql/1,
+		// and must not be removed.
+		"mysql/1": "0",
+		"mysql/2": "3",
+	}
+
+	err := h.rollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.calls, jc.DeepEquals, []string{
+		"DestroyRelation mysql:db wordpress:db",
+		"DestroyUnits mysql/1 mysql/2",
+		"DestroyApplications wordpress",
+		"DestroyMachinesWithParams force=true 2 3 1/lxd/0 1",
+	})
+}
+
+func (s *bundleRollbackSuite) TestRollbackUnplacedUnits(c *gc.C) {
+	// Units added without a placement are recorded with no machine,
+	// so the machines they have since been assigned to are read
+	// from the model status.
+	api := &fakeRollbackAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "0"},
+						"mysql/1": {Machine: "0"},
+						"mysql/2": {Machine: "2"},
+						"mysql/3": {},
+					},
+				},
+				"wordpress": {
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "3"},
+					},
+				},
+			},
+		},
+	}
+	h := s.makeHandler(c, api)
+	h.rollbackRecord.addApplication("wordpress")
+	h.rollbackRecord.addUnit("wordpress/0", "wordpress", true)
+	h.rollbackRecord.addUnit("mysql/1", "mysql", true)
+	h.rollbackRecord.addUnit("mysql/2", "mysql", true)
+	h.rollbackRecord.addUnit("mysql/3", "mysql", true)
+	h.unitStatus = map[string]string{
+		"mysql/0":     "0",
+		"mysql/1":     "",
+		"mysql/2":     "",
+		"mysql/3":     "",
+		"wordpress/0": "",
+	}
+
+	err := h.rollback()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.calls, jc.DeepEquals, []string{
+		"Status wordpress/0 mysql/1 mysql/2 mysql/3",
+		"DestroyUnits mysql/1 mysql/2 mysql/3",
+		"DestroyApplications wordpress",
+		"DestroyMachinesWithParams force=true 2 3",
+	})
+}
+
+func (s *bundleRollbackSuite) TestRollbackContinuesOnError(c *gc.C) {
+	api := &fakeRollbackAPI{
+		errors: map[string]error{
+			"DestroyRelation":     errors.New("boom"),
+			"DestroyApplications": errors.New("splat"),
+		},
+	}
+	h := s.makeHandler(c, api)
+	h.rollbackRecord.addMachine("1")
+	h.rollbackRecord.addApplication("wordpress")
+	h.rollbackRecord.addRelation("mysql:db", "wordpress:db")
+
+	err := h.rollback()
+	c.Assert(err, gc.ErrorMatches, "cannot remove relation mysql:db wordpress:db: boom")
+	c.Assert(api.calls, jc.DeepEquals, []string{
+		"DestroyRelation mysql:db wordpress:db",
+		"DestroyApplications wordpress",
+		"DestroyMachinesWithParams force=true 1",
+	})
+}
+
+func (s *bundleRollbackSuite) makeHandler(c *gc.C, api DeployAPI) *bundleHandler {
+	return &bundleHandler{
+		ctx: cmdtesting.Context(c),
+		api: api,
+		model: &bundlechanges.Model{
+			Machines: map[string]*bundlechanges.Machine{
+				"0": {},
+			},
+		},
+		unitStatus:     make(map[string]string),
+		rollbackRecord: &bundleRollback{},
+	}
+}
+
+type fakeRollbackAPI struct {
+	DeployAPI
+	calls  []string
+	errors map[string]error
+	status *params.FullStatus
+}
+
+func (f *fakeRollbackAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.calls = append(f.calls, "Status "+strings.Join(patterns, " "))
+	if f.status == nil {
+		return &params.FullStatus{}, f.errors["Status"]
+	}
+	return f.status, f.errors["Status"]
+}
+
+func (f *fakeRollbackAPI) DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error {
+	f.calls = append(f.calls, "DestroyRelation "+strings.Join(endpoints, " "))
+	return f.errors["DestroyRelation"]
+}
+
+func (f *fakeRollbackAPI) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	f.calls = append(f.calls, "DestroyUnits "+strings.Join(args.Units, " "))
+	return make([]params.DestroyUnitResult, len(args.Units)), f.errors["DestroyUnits"]
+}
+
+func (f *fakeRollbackAPI) DestroyApplications(args application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	f.calls = append(f.calls, "DestroyApplications "+strings.Join(args.Applications, " "))
+	return make([]params.DestroyApplicationResult, len(args.Applications)), f.errors["DestroyApplications"]
+}
+
+func (f *fakeRollbackAPI) DestroyMachinesWithParams(force, keep bool, machines ...string) error {
+	f.calls = append(f.calls, fmt.Sprintf("DestroyMachinesWithParams force=%v %s", force, strings.Join(machines, " ")))
+	return f.errors["DestroyMachinesWithParams"]
+}
@@ -0,0 +1,206 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+)
+
+const (
+	zonePlacementPrefix  = "zone="
+	zonesPlacementPrefix = "zones="
+)
+
+// zonePlacement holds the availability zones that a unit's new machine
+// may be created in, as given by a "zone=<zone>" or "zones=[<zone>,...]"
+// placement directive in the "to" list of a bundle application.
+type zonePlacement []string
+
+// parseZonePlacement parses a bundle placement directive, reporting
+// whether it is a zone placement directive.
+func parseZonePlacement(directive string) (zonePlacement, bool, error) {
+	switch {
+	case strings.HasPrefix(directive, zonePlacementPrefix):
+		zone := strings.TrimSpace(strings.TrimPrefix(directive, zonePlacementPrefix))
+		if zone == "" {
+			return nil, true, errors.NotValidf("placement %q without zone", directive)
+		}
+		return zonePlacement{zone}, true, nil
+	case strings.HasPrefix(directive, zonesPlacementPrefix):
+		list := strings.TrimPrefix(directive, zonesPlacementPrefix)
+		if !strings.HasPrefix(list, "[") || !strings.HasSuffix(list, "]") {
+			return nil, true, errors.NotValidf("placement %q, expected zones=[<zone>,...]", directive)
+		}
+		var zones zonePlacement
+		for _, zone := range strings.Split(list[1:len(list)-1], ",") {
+			zone = strings.TrimSpace(zone)
+			if zone == "" {
+				return nil, true, errors.NotValidf("placement %q with empty zone", directive)
+			}
+			zones = append(zones, zone)
+		}
+		return zones, true, nil
+	}
+	return nil, false, nil
+}
+
+// machinePlacement returns the placement directive for a new machine,
+// which is only set when the unit must be placed in a single zone.
+func (z zonePlacement) machinePlacement(modelUUID string) *instance.Placement {
+	if len(z) != 1 {
+		return nil
+	}
+	return &instance.Placement{Scope: modelUUID, Directive: zonePlacementPrefix + z[0]}
+}
+
+// constrain restricts the constraints of a new machine to the zones.
+func (z zonePlacement) constrain(cons constraints.Value) constraints.Value {
+	if len(z) > 1 {
+		zones := append([]string(nil), z...)
+		cons.Zones = &zones
+	}
+	return cons
+}
+
+// extractZonePlacements removes the zone placement directives from
+// the "to" lists of the bundle's applications, replacing each with
+// "new", and returns the zones requested for each application's units
+// keyed by application name. Zone placement directives are not known
+// to the bundle verification or change computation, so they are
+// applied to the resulting changes by the bundle handler.
+func extractZonePlacements(data *charm.BundleData) (map[string][]zonePlacement, error) {
+	result := make(map[string][]zonePlacement)
+	for name, spec := range data.Applications {
+		if spec == nil {
+			continue
+		}
+		var placements []zonePlacement
+		found := false
+		for i, directive := range spec.To {
+			zones, ok, err := parseZonePlacement(directive)
+			if err != nil {
+				return nil, errors.Annotatef(err, "application %q", name)
+			}
+			if ok {
+				found = true
+				spec.To[i] = "new"
+			}
+			placements = append(placements, zones)
+		}
+		if !found {
+			continue
+		}
+		if data.Type == "kubernetes" {
+			return nil, errors.NotSupportedf("zone placement of application %q in a kubernetes bundle", name)
+		}
+		result[name] = placements
+	}
+	return result, nil
+}
+
+// assignZonePlacements works out which of the bundle changes create
+// machines, or add units on new machines, for applications with zone
+// placement directives, and checks that the requested zones are
+// available in the model. A unit without a directive of its own uses
+// the last directive of its application.
+func (h *bundleHandler) assignZonePlacements() error {
+	if len(h.zonePlacements) == 0 {
+		return nil
+	}
+	if err := h.checkPlacementZones(); err != nil {
+		return errors.Trace(err)
+	}
+
+	applications := make(map[string]string)
+	machines := make(map[string]*bundlechanges.AddMachineChange)
+	unitCounts := make(map[string]int)
+	h.machineZones = make(map[string]zonePlacement)
+	h.unitZones = make(map[string]zonePlacement)
+	for _, change := range h.changes {
+		switch change := change.(type) {
+		case *bundlechanges.AddApplicationChange:
+			applications[change.Id()] = change.Params.Application
+		case *bundlechanges.AddMachineChange:
+			machines[change.Id()] = change
+		case *bundlechanges.AddUnitChange:
+			name := resolve(change.Params.Application, applications)
+			placements, ok := h.zonePlacements[name]
+			if !ok {
+				continue
+			}
+			index, ok := unitCounts[name]
+			if !ok {
+				// Units of an existing application are placed
+				// after the units that are already deployed.
+				if app := h.model.GetApplication(name); app != nil {
+					index = len(app.Units)
+				}
+			}
+			unitCounts[name] = index + 1
+			zones := placements[len(placements)-1]
+			if index < len(placements) {
+				zones = placements[index]
+			}
+			if zones == nil {
+				continue
+			}
+			to := change.Params.To
+			if to == "" {
+				// The unit is added with a placement directive, so
+				// units are spread over the zones in turn.
+				h.unitZones[change.Id()] = zonePlacement{zones[index%len(zones)]}
+				continue
+			}
+			if !strings.HasPrefix(to, "$") {
+				continue
+			}
+			if machine, ok := machines[to[1:]]; ok && machine.Params.ContainerType == "" {
+				h.machineZones[machine.Id()] = zones
+			}
+		}
+	}
+	return nil
+}
+
+// checkPlacementZones returns an error if any of the zones requested
+// by zone placement directives is not available in the model.
+func (h *bundleHandler) checkPlacementZones() error {
+	results, err := h.api.AllZones()
+	if err != nil {
+		return errors.Annotate(err, "cannot get availability zones for zone placement")
+	}
+	available := set.NewStrings()
+	for _, result := range results {
+		if result.Error == nil && result.Available {
+			available.Add(result.Name)
+		}
+	}
+	names := make([]string, 0, len(h.zonePlacements))
+	for name := range h.zonePlacements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, zones := range h.zonePlacements[name] {
+			for _, zone := range zones {
+				if !available.Contains(zone) {
+					return errors.NotFoundf(
+						"availability zone %q for application %q (available zones: %s)",
+						zone, name, strings.Join(available.SortedValues(), ", "),
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
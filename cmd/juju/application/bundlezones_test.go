@@ -0,0 +1,229 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type zonePlacementSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&zonePlacementSuite{})
+
+func (s *zonePlacementSuite) TestParseZonePlacement(c *gc.C) {
+	for i, test := range []struct {
+		directive string
+		zones     zonePlacement
+		ok        bool
+		err       string
+	}{{
+		directive: "new",
+	}, {
+		directive: "lxd:1",
+	}, {
+		directive: "zone=us-east1-b",
+		zones:     zonePlacement{"us-east1-b"},
+		ok:        true,
+	}, {
+		directive: "zones=[us-east1-b, us-east1-c]",
+		zones:     zonePlacement{"us-east1-b", "us-east1-c"},
+		ok:        true,
+	}, {
+		directive: "zone=",
+		ok:        true,
+		err:       `placement "zone=" without zone not valid`,
+	}, {
+		directive: "zones=us-east1-b",
+		ok:        true,
+		err:       `placement "zones=us-east1-b", expected zones=\[<zone>,...\] not valid`,
+	}, {
+		directive: "zones=[a,,b]",
+		ok:        true,
+		err:       `placement "zones=\[a,,b\]" with empty zone not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.directive)
+		zones, ok, err := parseZonePlacement(test.directive)
+		c.Check(ok, gc.Equals, test.ok)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(zones, jc.DeepEquals, test.zones)
+	}
+}
+
+func (s *zonePlacementSuite) TestZonePlacementMachineParams(c *gc.C) {
+	single := zonePlacement{"a"}
+	c.Check(single.machinePlacement("uuid"), jc.DeepEquals, &instance.Placement{Scope: "uuid", Directive: "zone=a"})
+	c.Check(single.constrain(constraints.MustParse("mem=4G")), jc.DeepEquals, constraints.MustParse("mem=4G"))
+
+	multiple := zonePlacement{"a", "b"}
+	c.Check(multiple.machinePlacement("uuid"), gc.IsNil)
+	c.Check(multiple.constrain(constraints.MustParse("mem=4G")), jc.DeepEquals, constraints.MustParse("mem=4G zones=a,b"))
+
+	var none zonePlacement
+	c.Check(none.machinePlacement("uuid"), gc.IsNil)
+	c.Check(none.constrain(constraints.MustParse("mem=4G")), jc.DeepEquals, constraints.MustParse("mem=4G"))
+}
+
+func (s *zonePlacementSuite) TestExtractZonePlacements(c *gc.C) {
+	data := s.readBundle(c, `
+applications:
+    django:
+        charm: cs:django
+        num_units: 3
+        to: ["zone=a", "new", "zones=[a,b]"]
+    memcached:
+        charm: cs:mem-47
+        num_units: 1
+        to: ["lxd:new"]
+`)
+	placements, err := extractZonePlacements(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placements, jc.DeepEquals, map[string][]zonePlacement{
+		"django": {{"a"}, nil, {"a", "b"}},
+	})
+	c.Assert(data.Applications["django"].To, jc.DeepEquals, []string{"new", "new", "new"})
+	c.Assert(data.Applications["memcached"].To, jc.DeepEquals, []string{"lxd:new"})
+}
+
+func (s *zonePlacementSuite) TestExtractZonePlacementsInvalid(c *gc.C) {
+	data := s.readBundle(c, `
+applications:
+    django:
+        charm: cs:django
+        num_units: 1
+        to: ["zones=a"]
+`)
+	_, err := extractZonePlacements(data)
+	c.Assert(err, gc.ErrorMatches, `application "django": placement "zones=a", expected .* not valid`)
+}
+
+func (s *zonePlacementSuite) TestExtractZonePlacementsKubernetes(c *gc.C) {
+	data := s.readBundle(c, `
+bundle: kubernetes
+applications:
+    gitlab:
+        charm: cs:gitlab
+        num_units: 1
+        to: ["zone=a"]
+`)
+	_, err := extractZonePlacements(data)
+	c.Assert(err, gc.ErrorMatches, `zone placement of application "gitlab" in a kubernetes bundle not supported`)
+}
+
+func (s *zonePlacementSuite) TestAssignZonePlacements(c *gc.C) {
+	api := &fakeZonesAPI{zones: []params.ZoneResult{
+		{Name: "a", Available: true},
+		{Name: "b", Available: true},
+	}}
+	h := s.makeHandler(c, api, `
+applications:
+    django:
+        charm: cs:xenial/django-42
+        num_units: 3
+        to: ["zone=a", "new", "zones=[a,b]"]
+`)
+	err := h.assignZonePlacements()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var assigned []zonePlacement
+	var machineChanges []*bundlechanges.AddMachineChange
+	for _, change := range h.changes {
+		if change, ok := change.(*bundlechanges.AddMachineChange); ok {
+			assigned = append(assigned, h.machineZones[change.Id()])
+			machineChanges = append(machineChanges, change)
+		}
+	}
+	c.Assert(assigned, jc.DeepEquals, []zonePlacement{{"a"}, nil, {"a", "b"}})
+
+	err = h.addMachine(machineChanges[0])
+	c.Assert(err, jc.ErrorIsNil)
+	err = h.addMachine(machineChanges[2])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.machines, gc.HasLen, 2)
+	c.Check(api.machines[0].Placement, jc.DeepEquals, &instance.Placement{Scope: "model-uuid", Directive: "zone=a"})
+	c.Check(api.machines[0].Constraints, jc.DeepEquals, constraints.MustParse(""))
+	c.Check(api.machines[1].Placement, gc.IsNil)
+	c.Check(api.machines[1].Constraints, jc.DeepEquals, constraints.MustParse("zones=a,b"))
+}
+
+func (s *zonePlacementSuite) TestAssignZonePlacementsUnknownZone(c *gc.C) {
+	api := &fakeZonesAPI{zones: []params.ZoneResult{
+		{Name: "a", Available: true},
+		{Name: "b", Available: false},
+	}}
+	h := s.makeHandler(c, api, `
+applications:
+    django:
+        charm: cs:xenial/django-42
+        num_units: 1
+        to: ["zones=[a,b]"]
+`)
+	err := h.assignZonePlacements()
+	c.Assert(err, gc.ErrorMatches, `availability zone "b" for application "django" \(available zones: a\) not found`)
+}
+
+func (s *zonePlacementSuite) readBundle(c *gc.C, content string) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *zonePlacementSuite) makeHandler(c *gc.C, api *fakeZonesAPI, content string) *bundleHandler {
+	data := s.readBundle(c, content)
+	placements, err := extractZonePlacements(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model := &bundlechanges.Model{}
+	changes, err := bundlechanges.FromData(bundlechanges.ChangesConfig{
+		Bundle: data,
+		Model:  model,
+		Logger: logger,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return &bundleHandler{
+		ctx:             cmdtesting.Context(c),
+		api:             api,
+		data:            data,
+		model:           model,
+		changes:         changes,
+		results:         make(map[string]string),
+		zonePlacements:  placements,
+		targetModelUUID: "model-uuid",
+	}
+}
+
+type fakeZonesAPI struct {
+	DeployAPI
+	zones    []params.ZoneResult
+	machines []params.AddMachineParams
+}
+
+func (f *fakeZonesAPI) AllZones() ([]params.ZoneResult, error) {
+	return f.zones, nil
+}
+
+func (f *fakeZonesAPI) AddMachines(machines []params.AddMachineParams) ([]params.AddMachinesResult, error) {
+	f.machines = append(f.machines, machines...)
+	results := make([]params.AddMachinesResult, len(machines))
+	for i := range results {
+		results[i].Machine = "0"
+	}
+	return results, nil
+}
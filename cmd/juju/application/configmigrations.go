@@ -0,0 +1,151 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/yaml.v2"
+)
+
+// configMigrationsFile is the name of the file in which a charm may
+// declare the config options which have been renamed since earlier
+// revisions, so that their values are carried over when upgrading.
+//
+// For example:
+//
+//	renames:
+//	  old-option: new-option
+const configMigrationsFile = "config-migrations.yaml"
+
+// configMigrations holds the contents of a charm's config
+// migrations file.
+type configMigrations struct {
+	Renames map[string]string `yaml:"renames"`
+}
+
+// readConfigMigrations returns the config option renames declared by
+// the given charm, which should be a charm directory or archive. If
+// the charm has no config migrations file, it returns nil.
+func readConfigMigrations(ch charm.Charm) (map[string]string, error) {
+	var data []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, configMigrationsFile))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	case *charm.CharmArchive:
+		data, err = readArchiveFile(ch.Path, configMigrationsFile)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", configMigrationsFile)
+	}
+	return parseConfigMigrations(data)
+}
+
+// readStoreConfigMigrations returns the config option renames declared
+// by the charm store charm with the given URL. Only the config
+// migrations file is fetched, rather than the whole archive. If the
+// charm has no config migrations file, it returns nil.
+// It is defined as a variable so it can be changed for testing purposes.
+var readStoreConfigMigrations = func(client *csclient.Client, curl *charm.URL) (map[string]string, error) {
+	r, err := client.GetFileFromArchive(curl, configMigrationsFile)
+	if errors.Cause(err) == csparams.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", configMigrationsFile)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", configMigrationsFile)
+	}
+	return parseConfigMigrations(data)
+}
+
+// parseConfigMigrations returns the renames declared in the given
+// contents of a config migrations file.
+func parseConfigMigrations(data []byte) (map[string]string, error) {
+	var migrations configMigrations
+	if err := yaml.Unmarshal(data, &migrations); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", configMigrationsFile)
+	}
+	return migrations.Renames, nil
+}
+
+// readArchiveFile returns the contents of the named file in the
+// charm archive at the given path.
+func readArchiveFile(path, name string) ([]byte, error) {
+	zipr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if f.Name != name {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return nil, errors.NotFoundf("%s in charm archive", name)
+}
+
+// applicableConfigRenames returns the renames declared by a charm
+// which apply to an upgrade from a charm with the given config
+// options to one with the given config. A charm's renames may refer
+// to options of any earlier revision, so those renaming options the
+// current charm does not have, or to options the new charm does not
+// have, are dropped. Renames given explicitly take precedence.
+func applicableConfigRenames(
+	charmRenames map[string]string,
+	currentOptions map[string]interface{},
+	newConfig *charm.Config,
+	explicit map[string]string,
+) map[string]string {
+	renames := make(map[string]string)
+	explicitTargets := make(map[string]bool)
+	for oldName, newName := range explicit {
+		renames[oldName] = newName
+		explicitTargets[newName] = true
+	}
+	for oldName, newName := range charmRenames {
+		if _, ok := renames[oldName]; ok || explicitTargets[newName] {
+			continue
+		}
+		if _, ok := currentOptions[oldName]; !ok {
+			continue
+		}
+		if newConfig == nil {
+			continue
+		}
+		if _, ok := newConfig.Options[newName]; !ok {
+			continue
+		}
+		renames[oldName] = newName
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+	return renames
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+)
+
+type ConfigMigrationsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigMigrationsSuite{})
+
+const configMigrationsMeta = `
+name: foo
+summary: a charm
+description: a charm with renamed config
+`
+
+func (s *ConfigMigrationsSuite) makeCharmDir(c *gc.C, migrations string) *charm.CharmDir {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(configMigrationsMeta), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	if migrations != "" {
+		err = ioutil.WriteFile(filepath.Join(dir, configMigrationsFile), []byte(migrations), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *ConfigMigrationsSuite) makeCharmArchive(c *gc.C, migrations string) *charm.CharmArchive {
+	dir := s.makeCharmDir(c, migrations)
+	path := filepath.Join(c.MkDir(), "foo.charm")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = dir.ArchiveTo(f)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmArchive(path)
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *ConfigMigrationsSuite) TestReadConfigMigrationsDir(c *gc.C) {
+	ch := s.makeCharmDir(c, "renames:\n  db-host: database-host\n")
+	renames, err := readConfigMigrations(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(renames, jc.DeepEquals, map[string]string{"db-host": "database-host"})
+}
+
+func (s *ConfigMigrationsSuite) TestReadConfigMigrationsArchive(c *gc.C) {
+	ch := s.makeCharmArchive(c, "renames:\n  db-host: database-host\n")
+	renames, err := readConfigMigrations(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(renames, jc.DeepEquals, map[string]string{"db-host": "database-host"})
+}
+
+func (s *ConfigMigrationsSuite) TestReadConfigMigrationsMissing(c *gc.C) {
+	renames, err := readConfigMigrations(s.makeCharmDir(c, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(renames, gc.IsNil)
+
+	renames, err = readConfigMigrations(s.makeCharmArchive(c, ""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(renames, gc.IsNil)
+}
+
+func (s *ConfigMigrationsSuite) TestReadConfigMigrationsInvalid(c *gc.C) {
+	ch := s.makeCharmDir(c, "renames: [db-host]\n")
+	_, err := readConfigMigrations(ch)
+	c.Assert(err, gc.ErrorMatches, "parsing config-migrations.yaml: .*")
+}
+
+func (s *ConfigMigrationsSuite) TestApplicableConfigRenames(c *gc.C) {
+	newConfig := charm.NewConfig()
+	newConfig.Options = map[string]charm.Option{
+		"database-host": {Type: "string"},
+		"database-port": {Type: "int"},
+		"name":          {Type: "string"},
+	}
+	currentOptions := map[string]interface{}{
+		"db-host": map[string]interface{}{"type": "string"},
+		"db-port": map[string]interface{}{"type": "int"},
+		"title":   map[string]interface{}{"type": "string"},
+	}
+	for i, test := range []struct {
+		about        string
+		charmRenames map[string]string
+		explicit     map[string]string
+		expect       map[string]string
+	}{{
+		about:        "renames of options defined by both charms apply",
+		charmRenames: map[string]string{"db-host": "database-host", "db-port": "database-port"},
+		expect:       map[string]string{"db-host": "database-host", "db-port": "database-port"},
+	}, {
+		about:        "renames from earlier revisions are dropped",
+		charmRenames: map[string]string{"hostname": "database-host", "db-port": "port"},
+	}, {
+		about:        "explicit renames take precedence",
+		charmRenames: map[string]string{"db-host": "database-host", "title": "name"},
+		explicit:     map[string]string{"db-host": "name"},
+		expect:       map[string]string{"db-host": "name"},
+	}, {
+		about:    "explicit renames are not filtered",
+		explicit: map[string]string{"hostname": "database-host"},
+		expect:   map[string]string{"hostname": "database-host"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		renames := applicableConfigRenames(test.charmRenames, currentOptions, newConfig, test.explicit)
+		c.Check(renames, jc.DeepEquals, test.expect)
+	}
+}
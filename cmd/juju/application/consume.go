@@ -133,7 +133,11 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer sourceClient.Close()
 
-	consumeDetails, err := sourceClient.GetConsumeDetails(url.AsLocal().String())
+	controllerUUID, err := consumerControllerUUID(&c.ModelCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	consumeDetails, err := sourceClient.GetConsumeDetailsForController(url.AsLocal().String(), controllerUUID)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -184,5 +188,19 @@ type applicationConsumeAPI interface {
 
 type applicationConsumeDetailsAPI interface {
 	Close() error
-	GetConsumeDetails(string) (params.ConsumeOfferDetails, error)
+	GetConsumeDetailsForController(url, controllerUUID string) (params.ConsumeOfferDetails, error)
+}
+
+// consumerControllerUUID returns the UUID of the controller hosting
+// the current model, which is the model that consumes an offer.
+func consumerControllerUUID(c *modelcmd.ModelCommandBase) (string, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	details, err := c.ClientStore().ControllerByName(controllerName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return details.ControllerUUID, nil
 }
@@ -37,7 +37,9 @@ func (s *ConsumeSuite) SetUpTest(c *gc.C) {
 	controllerName := "test-master"
 	s.store = jujuclient.NewMemStore()
 	s.store.CurrentControllerName = controllerName
-	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+	}
 	s.store.Models[controllerName] = &jujuclient.ControllerModels{
 		CurrentModel: "bob/test",
 		Models: map[string]jujuclient.ModelDetails{
@@ -87,7 +89,7 @@ func (s *ConsumeSuite) TestErrorFromAPI(c *gc.C) {
 func (s *ConsumeSuite) TestConsumeBlocked(c *gc.C) {
 	s.mockAPI.SetErrors(nil, &params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
 	_, err := s.runConsume(c, "model.application")
-	s.mockAPI.CheckCallNames(c, "GetConsumeDetails", "Consume", "Close", "Close")
+	s.mockAPI.CheckCallNames(c, "GetConsumeDetailsForController", "Consume", "Close", "Close")
 	c.Assert(err.Error(), jc.Contains, `could not consume bob/model.application: nope`)
 	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
 }
@@ -107,7 +109,7 @@ func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string) {
 	mac, err := apitesting.NewMacaroon("id")
 	c.Assert(err, jc.ErrorIsNil)
 	s.mockAPI.CheckCalls(c, []testing.StubCall{
-		{"GetConsumeDetails", []interface{}{"bob/booster.uke"}},
+		{"GetConsumeDetailsForController", []interface{}{"bob/booster.uke", coretesting.ControllerTag.Id()}},
 		{"Consume", []interface{}{crossmodel.ConsumeApplicationArgs{
 			Offer:            params.ApplicationOfferDetails{OfferName: "an offer", OfferURL: "ctrl:bob/booster.uke"},
 			ApplicationAlias: alias,
@@ -150,8 +152,8 @@ func (a *mockConsumeAPI) Consume(arg crossmodel.ConsumeApplicationArgs) (string,
 	return a.localName, a.NextErr()
 }
 
-func (a *mockConsumeAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetails, error) {
-	a.MethodCall(a, "GetConsumeDetails", url)
+func (a *mockConsumeAPI) GetConsumeDetailsForController(url, controllerUUID string) (params.ConsumeOfferDetails, error) {
+	a.MethodCall(a, "GetConsumeDetailsForController", url, controllerUUID)
 	mac, err := apitesting.NewMacaroon("id")
 	if err != nil {
 		return params.ConsumeOfferDetails{}, err
@@ -35,6 +35,7 @@ import (
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/api/subnets"
 	app "github.com/juju/juju/apiserver/facades/client/application"
 	apiparams "github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
@@ -42,6 +43,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
@@ -98,6 +100,12 @@ type OfferAPI interface {
 	Offer(modelUUID, application string, endpoints []string, offerName, descr string) ([]apiparams.ErrorResult, error)
 }
 
+// ZonesAPI represents the methods of the API the deploy command needs
+// for checking the availability zones named in bundle placements.
+type ZonesAPI interface {
+	AllZones() ([]apiparams.ZoneResult, error)
+}
+
 var supportedJujuSeries = func() []string {
 	// We support all of the juju series AND all the ESM supported series.
 	// Juju is congruant with the Ubuntu release cycle for it's own series (not
@@ -124,6 +132,8 @@ type DeployAPI interface {
 	ApplicationAPI
 	ModelAPI
 	OfferAPI
+	ZonesAPI
+	BundleRollbackAPI
 
 	// ApplicationClient
 	Deploy(application.DeployArgs) error
@@ -207,6 +217,10 @@ type offerClient struct {
 	*applicationoffers.Client
 }
 
+type subnetsClient struct {
+	*subnets.API
+}
+
 type deployAPIAdapter struct {
 	api.Connection
 	*apiClient
@@ -218,6 +232,7 @@ type deployAPIAdapter struct {
 	*annotationsClient
 	*plansClient
 	*offerClient
+	*subnetsClient
 }
 
 func (a *deployAPIAdapter) Client() *api.Client {
@@ -296,6 +311,10 @@ func NewDeployCommand() modelcmd.ModelCommand {
 			return nil, errors.Trace(err)
 		}
 		cstoreClient := newCharmStoreClient(bakeryClient, csURL).WithChannel(deployCmd.Channel)
+		charmRepo, err := deployCmd.charmRepo(apiRoot, controllerAPIRoot, charmrepo.NewCharmStoreFromClient(cstoreClient))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		return &deployAPIAdapter{
 			Connection:        apiRoot,
@@ -305,9 +324,10 @@ func NewDeployCommand() modelcmd.ModelCommand {
 			modelConfigClient: &modelConfigClient{Client: modelconfig.NewClient(apiRoot)},
 			charmstoreClient:  &charmstoreClient{&charmstoreClientShim{cstoreClient}},
 			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
-			charmRepoClient:   &charmRepoClient{charmrepo.NewCharmStoreFromClient(cstoreClient)},
+			charmRepoClient:   &charmRepoClient{charmRepo},
 			plansClient:       &plansClient{planURL: mURL},
 			offerClient:       &offerClient{Client: applicationoffers.NewClient(controllerAPIRoot)},
+			subnetsClient:     &subnetsClient{API: subnets.NewAPI(apiRoot)},
 		}, nil
 	}
 
@@ -341,6 +361,19 @@ type DeployCommand struct {
 	// deployed but just output the changes.
 	DryRun bool
 
+	// Atomic is used to specify that the entities created while
+	// deploying a bundle should be removed if the deploy fails.
+	Atomic bool
+
+	// Progress is used to specify that machine-readable progress
+	// events should be written to stdout, in the format given by
+	// ProgressFormat.
+	Progress       bool
+	ProgressFormat string
+
+	// progress reports the progress events requested with Progress.
+	progress *deployProgress
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -389,6 +422,11 @@ type DeployCommand struct {
 	// to trusted credentials will be granted access.
 	Trust bool
 
+	// Spread holds how the units of a deployed charm are spread
+	// across machines: "zones", "hosts" or "none". It is empty
+	// if the application's default is to be used.
+	Spread string
+
 	machineMap string
 	flagSet    *gnuflag.FlagSet
 
@@ -491,6 +529,15 @@ use
 
   juju deploy mediawiki --config mycfg.yaml
 
+The file may also be read from an https or s3 URL, optionally followed by the
+SHA-256 checksum of its content, which is then verified. Credentials for s3
+URLs are taken from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+environment variables, and the bucket's region from AWS_REGION. Query values,
+such as the signature of a pre-signed URL, are not shown in output:
+
+  juju deploy mediawiki --config https://example.com/mycfg.yaml#sha256=<hex>
+  juju deploy mediawiki --config s3://my-bucket/mycfg.yaml
+
 Key=value pairs can also be passed directly in the command. For example, to
 declare the 'name' key:
 
@@ -524,6 +571,20 @@ specifying a "placement directive". The ` + "`status`" + ` command should be use
 guidance on how to refer to machines. A few placement directives are
 provider-dependent (e.g.: 'zone').
 
+On Kubernetes models, '--to' instead controls how the application's pods are
+scheduled: 'spread=node' keeps each pod on a different node, and 'spread=zone'
+spreads the pods across availability zones. Both may be combined, as in
+'spread=node|zone'.
+
+On other models, the '--spread' option controls how the application's units
+are spread across machines: 'zones' (the default) spreads them across
+availability zones, 'hosts' keeps each unit on a different host machine, and
+'none' places them without regard to each other. When units cannot be spread
+as requested, a warning is logged; set the application's 'spread-policy'
+config to 'fail' to refuse to place them instead:
+
+  juju config mysql spread-policy=fail
+
 In more complex scenarios, "network spaces" are used to partition the cloud
 networking layer into sets of subnets. Instances hosting units inside the same
 space can communicate with each other without any firewalls. Traffic crossing
@@ -538,6 +599,11 @@ When deploying an application or adding machines, the 'spaces' constraint can
 be used to define a comma-delimited list of required and forbidden spaces (the
 latter prefixed with '^', similar to the 'tags' constraint).
 
+The endpoints of an application are bound to spaces with the '--bind' option.
+If the "default-space" model config is set, endpoints not bound with '--bind'
+are bound to that space, unless '--bind' gives a default space for the
+application.
+
 When deploying bundles, machines specified in the bundle are added to the model
 as new machines. Use the '--map-machines=existing' option to make use of any
 existing machines. To map particular existing machines to machines defined in
@@ -553,6 +619,25 @@ bundle.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+Tools that wrap 'juju deploy' can follow its progress with the
+'--progress --format json' options. A JSON object is written to stdout, one
+per line, as each charm is resolved and uploaded and each application is
+deployed; bundle deploys also report each unit and relation added. For example:
+
+  {"event":"charm-resolved","charm":"cs:mysql-58"}
+  {"event":"charm-uploaded","charm":"cs:mysql-58"}
+  {"event":"application-deployed","application":"mysql","charm":"cs:mysql-58"}
+  {"event":"unit-added","application":"mysql","unit":"mysql/0"}
+  {"event":"relation-added","endpoints":["wordpress:db","mysql:server"]}
+
+The messages otherwise written to stdout are then written to stderr.
+
+A bundle deploy that fails part way through leaves behind whatever it had
+deployed so far. Use the '--atomic' option to have the applications, units,
+relations and machines created by the deploy removed again if it fails.
+Changes made to applications and machines which already existed in the model,
+such as updated configuration or constraints, are not reverted.
+
 When charms that include LXD profiles are deployed the profiles are validated
 for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
@@ -617,6 +702,14 @@ attribute of 'gpu=nvidia-tesla-p100':
     juju deploy mycharm --device \
        twingpu=2,nvidia.com/gpu,gpu=nvidia-tesla-p100
 
+Deploy 3 units, each on a different host machine:
+
+    juju deploy mysql -n 3 --spread hosts
+
+Deploy a Kubernetes charm with no two pods on the same node:
+
+    juju deploy mycharm -n 3 --to spread=node
+
 See also:
     add-relation
     add-unit
@@ -674,7 +767,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "dry-run", "map-machines", "atomic",
 	}
 )
 
@@ -683,7 +776,7 @@ var (
 func charmOnlyFlags() []string {
 	charmOnlyFlags := []string{
 		"bind", "config", "constraints", "n", "num-units",
-		"series", "to", "resource", "attach-storage",
+		"series", "to", "resource", "attach-storage", "spread",
 	}
 
 	return charmOnlyFlags
@@ -697,14 +790,18 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.IntVar(&c.NumUnits, "n", 1, "Number of application units to deploy for principal charms")
 	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to use when getting the charm or bundle from the charm store")
-	f.Var(&c.ConfigOptions, "config", "Either a path to yaml-formatted application config file or a key=value pair ")
+	f.Var(&c.ConfigOptions, "config", "Either a path or https/s3 URL of a yaml-formatted application config file, or a key=value pair ")
 
 	f.BoolVar(&c.Trust, "trust", false, "Allows charm to run hooks that require access credentials")
+	f.StringVar(&c.Spread, "spread", "", "How units are spread across machines: zones, hosts or none")
 
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.Atomic, "atomic", false, "Remove what the bundle deploy created if it fails")
+	f.BoolVar(&c.Progress, "progress", false, "Write progress events to stdout in the format given by --format")
+	f.StringVar(&c.ProgressFormat, "format", "", "Format of the progress events written by --progress: json")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm/bundle to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -750,6 +847,19 @@ func (c *DeployCommand) Init(args []string) error {
 		return err
 	}
 
+	if c.Spread != "" {
+		if _, err := coreapplication.ParseSpread(c.Spread); err != nil {
+			return errors.Annotate(err, "invalid --spread")
+		}
+	}
+
+	if c.Progress && c.ProgressFormat != "json" {
+		return errors.New("--progress requires --format json")
+	}
+	if c.ProgressFormat != "" && !c.Progress {
+		return errors.New("--format is only supported with --progress")
+	}
+
 	useExisting, mapping, err := parseMachineMap(c.machineMap)
 	if err != nil {
 		return errors.Annotate(err, "error in --map-machines")
@@ -797,8 +907,13 @@ func (c *DeployCommand) validatePlacementByModelType() error {
 	if modelType == model.IAAS {
 		return nil
 	}
-	if len(c.Placement) > 0 {
-		return errors.New("--to cannot be used on kubernetes models")
+	if len(c.Placement) > 1 {
+		return errors.New("only one --to placement directive can be used on kubernetes models")
+	}
+	for _, p := range c.Placement {
+		if p.Scope != "model-uuid" {
+			return errors.New("--to cannot target machines or containers on kubernetes models")
+		}
 	}
 	return nil
 }
@@ -967,7 +1082,11 @@ func (c *DeployCommand) deployCharm(
 		return errors.Errorf("only a single config YAML file can be specified, got %d", len(files))
 	}
 	if len(files) == 1 {
-		configYAML, err = ioutil.ReadFile(files[0])
+		if common.IsConfigURL(files[0]) {
+			configYAML, err = common.ReadConfigURL(ctx, files[0])
+		} else {
+			configYAML, err = ioutil.ReadFile(files[0])
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -986,6 +1105,11 @@ func (c *DeployCommand) deployCharm(
 		appConfig[app.TrustConfigOptionName] = strconv.FormatBool(c.Trust)
 	}
 
+	// Expand the spread flag into the appConfig
+	if c.Spread != "" {
+		appConfig[coreapplication.SpreadConfigOptionName] = c.Spread
+	}
+
 	// Application facade V5 expects charm config to either all be in YAML
 	// or config map. If config map is specified, that overrides YAML.
 	// So we need to combine the two here to have only one.
@@ -1083,7 +1207,11 @@ func (c *DeployCommand) deployCharm(
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
 	}
-	return errors.Trace(apiRoot.Deploy(args))
+	if err := apiRoot.Deploy(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.progress.applicationDeployed(applicationName, id.URL)
+	return nil
 }
 
 const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
@@ -1095,12 +1223,23 @@ const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<en
 // * The above in a space separated list to specify multiple bindings,
 //   e.g. "rel1=space1 ext1=space2 space3"
 func (c *DeployCommand) parseBind() error {
-	bindings := make(map[string]string)
 	if c.BindToSpaces == "" {
 		return nil
 	}
+	bindings, err := parseBindExpr(c.BindToSpaces)
+	if err != nil {
+		return errors.New(parseBindErrorPrefix + err.Error())
+	}
+	c.Bindings = bindings
+	return nil
+}
 
-	for _, s := range strings.Split(c.BindToSpaces, " ") {
+// parseBindExpr parses a space separated list of bindings, each either
+// of the form endpoint-name=space-name, or a lone space name setting
+// the default space, which is recorded against the empty endpoint name.
+func parseBindExpr(expr string) (map[string]string, error) {
+	bindings := make(map[string]string)
+	for _, s := range strings.Split(expr, " ") {
 		s = strings.TrimSpace(s)
 		if s == "" {
 			continue
@@ -1114,21 +1253,20 @@ func (c *DeployCommand) parseBind() error {
 			space = v[0]
 		case 2:
 			if v[0] == "" {
-				return errors.New(parseBindErrorPrefix + "Found = without endpoint name. Use a lone space name to set the default.")
+				return nil, errors.New("Found = without endpoint name. Use a lone space name to set the default.")
 			}
 			endpoint = v[0]
 			space = v[1]
 		default:
-			return errors.New(parseBindErrorPrefix + "Found multiple = in binding. Did you forget to space-separate the binding list?")
+			return nil, errors.New("Found multiple = in binding. Did you forget to space-separate the binding list?")
 		}
 
 		if !names.IsValidSpace(space) {
-			return errors.New(parseBindErrorPrefix + "Space name invalid.")
+			return nil, errors.New("Space name invalid.")
 		}
 		bindings[endpoint] = space
 	}
-	c.Bindings = bindings
-	return nil
+	return bindings, nil
 }
 
 func (c *DeployCommand) Run(ctx *cmd.Context) error {
@@ -1155,6 +1293,10 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 		step.SetPlanURL(apiRoot.PlanURL())
 	}
 
+	if c.Progress {
+		c.progress = newDeployProgress(ctx.Stdout)
+	}
+
 	deploy, err := findDeployerFIFO(
 		func() (deployFn, error) { return c.maybeReadLocalBundle(ctx) },
 		func() (deployFn, error) { return c.maybeReadLocalCharm(apiRoot) },
@@ -1352,6 +1494,8 @@ func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error)
 		return errors.Trace(c.deployBundle(bundleDeploySpec{
 			ctx:                 ctx,
 			dryRun:              c.DryRun,
+			atomic:              c.Atomic,
+			progress:            c.progress,
 			force:               c.Force,
 			trust:               c.Trust,
 			bundleDir:           bundleDir,
@@ -1450,6 +1594,7 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 		if curl, err = apiRoot.AddLocalCharm(curl, ch, c.Force); err != nil {
 			return errors.Trace(err)
 		}
+		c.progress.charmUploaded(curl)
 
 		id := charmstore.CharmID{
 			URL: curl,
@@ -1527,6 +1672,8 @@ func (c *DeployCommand) maybeReadCharmstoreBundleFn(apiRoot DeployAPI) func() (d
 			return errors.Trace(c.deployBundle(bundleDeploySpec{
 				ctx:                 ctx,
 				dryRun:              c.DryRun,
+				atomic:              c.Atomic,
+				progress:            c.progress,
 				force:               c.Force,
 				trust:               c.Trust,
 				bundleData:          data,
@@ -1552,6 +1699,24 @@ func (c *DeployCommand) getMeteringAPIURL(controllerAPIRoot api.Connection) (str
 	return controllerCfg.MeteringURL(), nil
 }
 
+// charmRepo returns the repository used to resolve and get charm store
+// charms and bundles, which uses the controller's charm store mirror if
+// the model is configured to.
+func (c *DeployCommand) charmRepo(apiRoot, controllerAPIRoot api.Connection, store *charmrepo.CharmStore) (charmrepoForDeploy, error) {
+	mirror, err := getCharmStoreMirror(controllerAPIRoot)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mirror == "" {
+		return store, nil
+	}
+	modelAttrs, err := modelconfig.NewClient(apiRoot).ModelGet()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return mirroredCharmRepo(store, mirror, modelAttrs, c.Channel)
+}
+
 func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 	userRequestedURL, err := charm.ParseURL(c.CharmOrBundle)
 	if err != nil {
@@ -1577,6 +1742,7 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 		} else if err != nil {
 			return errors.Trace(err)
 		}
+		c.progress.charmResolved(storeCharmOrBundleURL)
 
 		if err := c.validateCharmFlags(); err != nil {
 			return errors.Trace(err)
@@ -1618,6 +1784,7 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			}
 			return errors.Annotatef(err, "storing charm for URL %q", storeCharmOrBundleURL)
 		}
+		c.progress.charmUploaded(curl)
 
 		// If the original series was empty, so we couldn't validate the original
 		// charm series, but the charm url wasn't nil, we can check and validate
@@ -57,6 +57,7 @@ import (
 	jjcharmstore "github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
@@ -221,6 +222,18 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"bundle", "--map-machines", "foo"},
 		err:  `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`,
+	}, {
+		args: []string{"charm", "--spread", "racks"},
+		err:  `invalid --spread: spread "racks" \(expected zones, hosts or none\) not valid`,
+	}, {
+		args: []string{"charm", "--progress"},
+		err:  `--progress requires --format json`,
+	}, {
+		args: []string{"charm", "--progress", "--format", "yaml"},
+		err:  `--progress requires --format json`,
+	}, {
+		args: []string{"charm", "--format", "json"},
+		err:  `--format is only supported with --progress`,
 	},
 }
 
@@ -486,6 +499,17 @@ func (s *DeploySuite) TestConstraints(c *gc.C) {
 	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=2G cores=2"))
 }
 
+func (s *DeploySuite) TestSpread(c *gc.C) {
+	ch := testcharms.RepoWithSeries("bionic").CharmArchivePath(s.CharmsPath, "multi-series")
+	err := s.runDeploy(c, ch, "-n", "2", "--spread", "hosts", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	curl := charm.MustParseURL("local:trusty/multi-series-1")
+	application, _ := s.AssertApplication(c, "multi-series", curl, 2, 0)
+	appConfig, err := application.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appConfig.GetString(coreapplication.SpreadConfigOptionName, ""), gc.Equals, "hosts")
+}
+
 func (s *DeploySuite) TestResources(c *gc.C) {
 	ch := testcharms.RepoWithSeries("bionic").CharmArchivePath(s.CharmsPath, "dummy")
 
@@ -834,8 +858,10 @@ var caasTests = []struct {
 }{
 	{[]string{"-m", "caas-model", "some-application-name", "--attach-storage", "foo/0"},
 		"--attach-storage cannot be used on kubernetes models"},
-	{[]string{"-m", "caas-model", "some-application-name", "--to", "a=b"},
-		regexp.QuoteMeta(`--to cannot be used on kubernetes models`)},
+	{[]string{"-m", "caas-model", "some-application-name", "--to", "0"},
+		regexp.QuoteMeta(`--to cannot target machines or containers on kubernetes models`)},
+	{[]string{"-m", "caas-model", "some-application-name", "--to", "spread=node,spread=zone"},
+		regexp.QuoteMeta(`only one --to placement directive can be used on kubernetes models`)},
 }
 
 func (s *CAASDeploySuite) TestCaasModelValidatedAtRun(c *gc.C) {
@@ -1085,7 +1111,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	c.Assert(command.flagSet, jc.DeepEquals, flagSet)
 	// Add to the slice below if a new flag is introduced which is valid for
 	// both charms and bundles.
-	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust"}
+	charmAndBundleFlags := []string{"channel", "storage", "device", "force", "trust", "progress", "format"}
 	var allFlags []string
 	flagSet.VisitAll(func(flag *gnuflag.Flag) {
 		allFlags = append(allFlags, flag.Name)
@@ -1104,6 +1130,17 @@ func (s *DeploySuite) TestDeployLocalWithSeriesMismatchReturnsError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `terms1 is not available on the following series: quantal not supported`)
 }
 
+func (s *DeploySuite) TestDeployLocalProgress(c *gc.C) {
+	ch := testcharms.RepoWithSeries("quantal").ClonedDirPath(s.CharmsPath, "terms1")
+	stdOut, stdErr, err := s.runDeployWithOutput(c, ch, "--series", "quantal", "--force", "--progress", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdOut, gc.Equals, ""+
+		`{"event":"charm-uploaded","charm":"local:quantal/terms1-1"}`+"\n"+
+		`{"event":"application-deployed","application":"terms1","charm":"local:quantal/terms1-1"}`,
+	)
+	c.Check(stdErr, gc.Equals, `Deploying charm "local:quantal/terms1-1".`)
+}
+
 func (s *DeploySuite) TestDeployLocalWithSeriesAndForce(c *gc.C) {
 	ch := testcharms.RepoWithSeries("quantal").ClonedDirPath(s.CharmsPath, "terms1")
 	_, stdErr, err := s.runDeployWithOutput(c, ch, "--series", "quantal", "--force")
@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/juju/charm.v6"
+)
+
+// The events reported by a deployProgress.
+const (
+	progressCharmResolved       = "charm-resolved"
+	progressCharmUploaded       = "charm-uploaded"
+	progressApplicationDeployed = "application-deployed"
+	progressUnitAdded           = "unit-added"
+	progressRelationAdded       = "relation-added"
+)
+
+// progressEvent is a single machine-readable progress event,
+// written as a line of JSON.
+type progressEvent struct {
+	Event       string   `json:"event"`
+	Application string   `json:"application,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	Charm       string   `json:"charm,omitempty"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+}
+
+// deployProgress writes machine-readable progress events for a
+// deploy, one JSON object per line, so that tools wrapping the
+// deploy command can follow it without parsing the messages meant
+// for people. All methods may be called on a nil *deployProgress,
+// in which case they do nothing.
+type deployProgress struct {
+	out io.Writer
+}
+
+func newDeployProgress(out io.Writer) *deployProgress {
+	return &deployProgress{out: out}
+}
+
+func (p *deployProgress) report(event progressEvent) {
+	if p == nil {
+		return
+	}
+	if err := json.NewEncoder(p.out).Encode(event); err != nil {
+		logger.Warningf("cannot report deploy progress: %v", err)
+	}
+}
+
+func (p *deployProgress) charmResolved(curl *charm.URL) {
+	p.report(progressEvent{Event: progressCharmResolved, Charm: curl.String()})
+}
+
+func (p *deployProgress) charmUploaded(curl *charm.URL) {
+	p.report(progressEvent{Event: progressCharmUploaded, Charm: curl.String()})
+}
+
+func (p *deployProgress) applicationDeployed(application string, curl *charm.URL) {
+	p.report(progressEvent{Event: progressApplicationDeployed, Application: application, Charm: curl.String()})
+}
+
+func (p *deployProgress) unitAdded(unit, application string) {
+	p.report(progressEvent{Event: progressUnitAdded, Unit: unit, Application: application})
+}
+
+func (p *deployProgress) relationAdded(endpoints ...string) {
+	p.report(progressEvent{Event: progressRelationAdded, Endpoints: endpoints})
+}
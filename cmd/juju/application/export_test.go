@@ -229,6 +229,24 @@ func NewSetSeriesCommandForTest(
 	return modelcmd.Wrap(cmd)
 }
 
+// NewBindCommandForTest returns a BindCommand with the api provided as specified.
+func NewBindCommandForTest(api bindAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &bindCommand{newAPIFunc: func() (bindAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewLabelCommandForTest returns a LabelCommand with the api provided as specified.
+func NewLabelCommandForTest(api labelAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &labelCommand{newAPIFunc: func() (labelAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewSuspendRelationCommandForTest returns a SuspendRelationCommand with the api provided as specified.
 func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &suspendRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -274,10 +292,25 @@ func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolv
 	return modelcmd.Wrap(cmd)
 }
 
-func NewShowCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore) cmd.Command {
-	cmd := &showApplicationCommand{newAPIFunc: func() (ApplicationsInfoAPI, error) {
-		return api, nil
-	}}
+func NewShowCommandForTest(api ApplicationsInfoAPI, historyAPI VersionHistoryAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showApplicationCommand{
+		newAPIFunc: func() (ApplicationsInfoAPI, error) {
+			return api, nil
+		},
+		newHistoryAPIFunc: func() (VersionHistoryAPI, error) {
+			return historyAPI, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showUnitCommand{
+		newAPIFunc: func() (UnitsInfoAPI, error) {
+			return api, nil
+		},
+	}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
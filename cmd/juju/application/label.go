@@ -0,0 +1,157 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/labels"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	corelabels "github.com/juju/juju/core/labels"
+)
+
+var usageLabelSummary = `
+Shows or changes the labels of a machine or unit.`[1:]
+
+var usageLabelDetails = `
+Labels are freeform key=value pairs attached to machines and units.
+With no further arguments, the labels of the machine or unit are shown.
+Otherwise, each key=value argument sets a label, and each key- argument
+removes one. Keys and values may contain lowercase letters, digits,
+dashes and underscores, and must begin and end with a letter or digit.
+
+Labels may be used to select machines and units with the --selector
+option of the status, run and run-action commands. A selector is a
+comma-separated list of requirements, each of the form key=value,
+key!=value, key (the label is present) or !key (the label is absent).
+
+Examples:
+    juju label 0
+    juju label mysql/0 env=canary tier=db
+    juju label mysql/0 env-
+    juju status --selector env=canary
+    juju run --selector env=canary,tier=db 'hostname'
+
+See also:
+    status
+    run
+    run-action`[1:]
+
+// NewLabelCommand returns a command which shows or changes the
+// labels of a machine or unit.
+func NewLabelCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&labelCommand{})
+}
+
+// labelAPI defines a subset of the labels facade, as required
+// by the label command.
+type labelAPI interface {
+	Close() error
+	Labels(tag names.Tag) (map[string]string, error)
+	UpdateLabels(tag names.Tag, set map[string]string, remove []string) error
+}
+
+// labelCommand shows or changes the labels of a machine or unit.
+type labelCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+
+	newAPIFunc func() (labelAPI, error)
+
+	tag    names.Tag
+	set    map[string]string
+	remove []string
+}
+
+// Info implements cmd.Command.
+func (c *labelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "label",
+		Args:    "<machine|unit> [<key>=<value> ...] [<key>- ...]",
+		Purpose: usageLabelSummary,
+		Doc:     usageLabelDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *labelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *labelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine or unit specified")
+	}
+	switch {
+	case names.IsValidMachine(args[0]):
+		c.tag = names.NewMachineTag(args[0])
+	case names.IsValidUnit(args[0]):
+		c.tag = names.NewUnitTag(args[0])
+	default:
+		return errors.NotValidf("machine or unit %q", args[0])
+	}
+	for _, arg := range args[1:] {
+		if strings.HasSuffix(arg, "-") && !strings.Contains(arg, "=") {
+			key := strings.TrimSuffix(arg, "-")
+			if err := corelabels.ValidateKey(key); err != nil {
+				return errors.Trace(err)
+			}
+			c.remove = append(c.remove, key)
+			continue
+		}
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected key=value or key-, got %q", arg)
+		}
+		if c.set == nil {
+			c.set = make(map[string]string)
+		}
+		c.set[parts[0]] = parts[1]
+	}
+	return errors.Trace(corelabels.Validate(c.set))
+}
+
+func (c *labelCommand) getAPI() (labelAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return labels.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *labelCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if len(c.set) == 0 && len(c.remove) == 0 {
+		result, err := client.Labels(c.tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(result) == 0 {
+			ctx.Infof("%s has no labels", names.ReadableString(c.tag))
+			return nil
+		}
+		return c.out.Write(ctx, result)
+	}
+	err = client.UpdateLabels(c.tag, c.set, c.remove)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
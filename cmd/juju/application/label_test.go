@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type LabelSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockLabelAPI
+}
+
+var _ = gc.Suite(&LabelSuite{})
+
+func (s *LabelSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockLabelAPI{Stub: &testing.Stub{}}
+}
+
+func (s *LabelSuite) runLabel(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewLabelCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *LabelSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no machine or unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `machine or unit "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "env"},
+		err:  `expected key=value or key-, got "env"`,
+	}, {
+		args: []string{"mysql/0", "Env=canary"},
+		err:  `label key "Env" not valid`,
+	}, {
+		args: []string{"0", "env=can ary"},
+		err:  `label value "can ary" not valid`,
+	}, {
+		args: []string{"0", "-env-"},
+		err:  `label key "-env" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runLabel(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LabelSuite) TestShowLabels(c *gc.C) {
+	s.mockAPI.labels = map[string]string{"env": "canary", "tier": "db"}
+	ctx, err := s.runLabel(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "env: canary\ntier: db\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"Labels", []interface{}{names.NewUnitTag("mysql/0")}},
+		{"Close", nil},
+	})
+}
+
+func (s *LabelSuite) TestShowNoLabels(c *gc.C) {
+	ctx, err := s.runLabel(c, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "machine 0 has no labels\n")
+}
+
+func (s *LabelSuite) TestUpdateLabels(c *gc.C) {
+	_, err := s.runLabel(c, "0", "env=canary", "tier-", "empty=")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdateLabels", []interface{}{
+			names.NewMachineTag("0"),
+			map[string]string{"env": "canary", "empty": ""},
+			[]string{"tier"},
+		}},
+		{"Close", nil},
+	})
+}
+
+type mockLabelAPI struct {
+	*testing.Stub
+	labels map[string]string
+}
+
+func (m *mockLabelAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockLabelAPI) Labels(tag names.Tag) (map[string]string, error) {
+	m.MethodCall(m, "Labels", tag)
+	return m.labels, m.NextErr()
+}
+
+func (m *mockLabelAPI) UpdateLabels(tag names.Tag, set map[string]string, remove []string) error {
+	m.MethodCall(m, "UpdateLabels", tag, set, remove)
+	return m.NextErr()
+}
@@ -4,6 +4,7 @@
 package application
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -11,11 +12,14 @@ import (
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/status"
 )
 
 const showApplicationDoc = `
@@ -30,6 +34,13 @@ Examples:
     $ juju show-application myapplication
         where "myapplication" is the application name alias, see "juju help deploy" for more information
 
+    $ juju show-application mysql --version-history
+
+The --version-history option includes, for each unit of the application,
+the workload versions reported by the unit's charm and when each version
+was first reported. This is useful for tracking the progress of a rollout
+of a new workload version across the units of an application.
+
 `
 
 // NewShowApplicationCommand returns a command that displays applications info.
@@ -38,6 +49,9 @@ func NewShowApplicationCommand() cmd.Command {
 	s.newAPIFunc = func() (ApplicationsInfoAPI, error) {
 		return s.newApplicationAPI()
 	}
+	s.newHistoryAPIFunc = func() (VersionHistoryAPI, error) {
+		return s.NewAPIClient()
+	}
 	return modelcmd.Wrap(s)
 }
 
@@ -45,9 +59,11 @@ func NewShowApplicationCommand() cmd.Command {
 type showApplicationCommand struct {
 	modelcmd.ModelCommandBase
 
-	out        cmd.Output
-	apps       []string
-	newAPIFunc func() (ApplicationsInfoAPI, error)
+	out               cmd.Output
+	apps              []string
+	versionHistory    bool
+	newAPIFunc        func() (ApplicationsInfoAPI, error)
+	newHistoryAPIFunc func() (VersionHistoryAPI, error)
 }
 
 // Info implements Command.Info.
@@ -87,6 +103,7 @@ func (c *showApplicationCommand) Init(args []string) error {
 func (c *showApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+	f.BoolVar(&c.versionHistory, "version-history", false, "Include the workload version history of each unit")
 }
 
 // ApplicationsInfoAPI defines the API methods that show-application command uses.
@@ -96,6 +113,16 @@ type ApplicationsInfoAPI interface {
 	ApplicationsInfo([]names.ApplicationTag) ([]params.ApplicationInfoResult, error)
 }
 
+// VersionHistoryAPI defines the API methods that show-application command
+// uses to report the workload version history of units.
+type VersionHistoryAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+}
+
+var _ VersionHistoryAPI = (*api.Client)(nil)
+
 func (c *showApplicationCommand) newApplicationAPI() (ApplicationsInfoAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -143,9 +170,70 @@ func (c *showApplicationCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.versionHistory {
+		if err := c.addVersionHistory(output); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.out.Write(ctx, output)
 }
 
+// maxVersionHistory is the maximum number of workload version changes
+// reported for each unit.
+const maxVersionHistory = 50
+
+// addVersionHistory adds the workload version history of the units of
+// each application to the application infos.
+func (c *showApplicationCommand) addVersionHistory(infos map[string]ApplicationInfo) error {
+	client, err := c.newHistoryAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	appNames := make([]string, 0, len(infos))
+	for name := range infos {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	fullStatus, err := client.Status(appNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filter := status.StatusHistoryFilter{Size: maxVersionHistory}
+	for _, appName := range appNames {
+		app, ok := fullStatus.Applications[appName]
+		if !ok {
+			continue
+		}
+		info := infos[appName]
+		info.VersionHistory = make(map[string][]WorkloadVersionChange)
+		for unitName := range app.Units {
+			history, err := client.StatusHistory(status.KindWorkloadVersion, names.NewUnitTag(unitName), filter)
+			if err != nil {
+				return errors.Annotatef(err, "getting workload version history for %s", unitName)
+			}
+			var changes []WorkloadVersionChange
+			for _, entry := range history {
+				// Only report changes, not repeated settings of the
+				// same version.
+				if n := len(changes); n > 0 && changes[n-1].Version == entry.Info {
+					continue
+				}
+				change := WorkloadVersionChange{Version: entry.Info}
+				if entry.Since != nil {
+					change.Since = common.FormatTime(entry.Since, true)
+				}
+				changes = append(changes, change)
+			}
+			info.VersionHistory[unitName] = changes
+		}
+		infos[appName] = info
+	}
+	return nil
+}
+
 func (c *showApplicationCommand) getApplicationTags() ([]names.ApplicationTag, error) {
 	tags := make([]names.ApplicationTag, len(c.apps))
 	for i, one := range c.apps {
@@ -184,6 +272,15 @@ type ApplicationInfo struct {
 	Exposed          bool              `yaml:"exposed" json:"exposed"`
 	Remote           bool              `yaml:"remote" json:"remote"`
 	EndpointBindings map[string]string `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+
+	VersionHistory map[string][]WorkloadVersionChange `yaml:"version-history,omitempty" json:"version-history,omitempty"`
+}
+
+// WorkloadVersionChange defines the serialization behaviour of a change
+// of the workload version reported by a unit.
+type WorkloadVersionChange struct {
+	Version string `yaml:"version" json:"version"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
 }
 
 func createApplicationInfo(details params.ApplicationInfo) (names.ApplicationTag, ApplicationInfo, error) {
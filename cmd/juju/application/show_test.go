@@ -5,6 +5,7 @@ package application_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	jujutesting "github.com/juju/juju/testing"
@@ -52,7 +54,7 @@ func (s *ShowSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *ShowSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, application.NewShowCommandForTest(s.mockAPI, s.store), args...)
+	return cmdtesting.RunCommand(c, application.NewShowCommandForTest(s.mockAPI, s.mockAPI, s.store), args...)
 }
 
 type showTest struct {
@@ -246,9 +248,70 @@ wordpress:
 	})
 }
 
+func (s *ShowSuite) TestShowVersionHistory(c *gc.C) {
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{
+			{Result: s.createTestApplicationInfo("wordpress", "")},
+		}, nil
+	}
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+	s.mockAPI.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {
+				Units: map[string]params.UnitStatus{
+					"wordpress/0": {},
+					"wordpress/1": {},
+				},
+			},
+		},
+	}
+	s.mockAPI.history = map[string]status.History{
+		"wordpress/0": {
+			{Info: "4.9", Since: &t0},
+			{Info: "5.0", Since: &t1},
+			{Info: "5.0", Since: &t2},
+		},
+		"wordpress/1": {
+			{Info: "4.9", Since: &t0},
+		},
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"wordpress", "--version-history", "--format", "json"},
+		stdout: "{\"wordpress\":{\"charm\":\"charm-wordpress\",\"series\":\"quantal\",\"channel\":\"development\",\"constraints\":{\"arch\":\"amd64\",\"cores\":1,\"mem\":4096,\"root-disk\":8192},\"principal\":true,\"exposed\":false,\"remote\":false,\"endpoint-bindings\":{\"juju-info\":\"myspace\"}," +
+			"\"version-history\":{\"wordpress/0\":[{\"version\":\"4.9\",\"since\":\"2019-05-01 10:00:00Z\"},{\"version\":\"5.0\",\"since\":\"2019-05-01 11:00:00Z\"}]," +
+			"\"wordpress/1\":[{\"version\":\"4.9\",\"since\":\"2019-05-01 10:00:00Z\"}]}}}\n",
+	})
+	c.Assert(s.mockAPI.statusPatterns, jc.DeepEquals, []string{"wordpress"})
+}
+
+func (s *ShowSuite) TestShowVersionHistoryError(c *gc.C) {
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{
+			{Result: s.createTestApplicationInfo("wordpress", "")},
+		}, nil
+	}
+	s.mockAPI.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"wordpress": {
+				Units: map[string]params.UnitStatus{"wordpress/0": {}},
+			},
+		},
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"wordpress", "--version-history"},
+		err:  `getting workload version history for wordpress/0: no history for wordpress/0`,
+	})
+}
+
 type mockShowAPI struct {
 	version              int
 	applicationsInfoFunc func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error)
+
+	status         *params.FullStatus
+	statusPatterns []string
+	history        map[string]status.History
 }
 
 func (s mockShowAPI) Close() error {
@@ -262,3 +325,19 @@ func (s mockShowAPI) BestAPIVersion() int {
 func (s mockShowAPI) ApplicationsInfo(tags []names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
 	return s.applicationsInfoFunc(tags)
 }
+
+func (s *mockShowAPI) Status(patterns []string) (*params.FullStatus, error) {
+	s.statusPatterns = patterns
+	return s.status, nil
+}
+
+func (s *mockShowAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	if kind != status.KindWorkloadVersion {
+		return nil, fmt.Errorf("unexpected history kind %q", kind)
+	}
+	history, ok := s.history[tag.Id()]
+	if !ok {
+		return nil, fmt.Errorf("no history for %s", tag.Id())
+	}
+	return history, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/units"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showUnitDoc = `
+The command takes deployed unit names as an argument.
+
+Examples:
+    $ juju show-unit mysql/0
+    $ juju show-unit mysql/0 wordpress/1
+
+    $ juju show-unit mysql/0 --operations
+
+The --operations option includes the operations most recently run by
+each unit's agent, such as hooks, actions and charm upgrades, together
+with when each started and completed and why it failed, if it did. The
+most recent operation is listed first.
+
+Once a unit's agent has reported them, the hooks it is waiting to run
+are summarised under hook-queue: the number of hooks pending, the hook
+that will run next, and when the agent last reported its queue.
+Relation hooks are only included once the agent starts to run them.
+
+`
+
+// NewShowUnitCommand returns a command that displays unit info.
+func NewShowUnitCommand() cmd.Command {
+	s := &showUnitCommand{}
+	s.newAPIFunc = func() (UnitsInfoAPI, error) {
+		return s.newUnitsAPI()
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showUnitCommand displays unit information.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+
+	out        cmd.Output
+	units      []string
+	operations bool
+	newAPIFunc func() (UnitsInfoAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showUnitCommand) Info() *cmd.Info {
+	showCmd := &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: "Displays information about a unit.",
+		Doc:     showUnitDoc,
+	}
+	return jujucmd.Info(showCmd)
+}
+
+// Init implements Command.Init.
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.units = args
+	var invalid []string
+	for _, one := range c.units {
+		if !names.IsValidUnit(one) {
+			invalid = append(invalid, one)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	plural := "s"
+	if len(invalid) == 1 {
+		plural = ""
+	}
+	return errors.NotValidf(`unit name%v %v`, plural, strings.Join(invalid, `, `))
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+	f.BoolVar(&c.operations, "operations", false, "Include the recent operations run by each unit")
+}
+
+// UnitsInfoAPI defines the API methods that the show-unit command uses.
+type UnitsInfoAPI interface {
+	Close() error
+	UnitsInfo([]names.UnitTag) ([]params.UnitInfoResult, error)
+	OperationHistory([]names.UnitTag) ([]params.UnitOperationsResult, error)
+}
+
+func (c *showUnitCommand) newUnitsAPI() (UnitsInfoAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("Units") < 1 {
+		root.Close()
+		return nil, errors.NotSupportedf("show-unit on this version of Juju")
+	}
+	return units.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tags := make([]names.UnitTag, len(c.units))
+	for i, one := range c.units {
+		tags[i] = names.NewUnitTag(one)
+	}
+	results, err := client.UnitsInfo(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var errs params.ErrorResults
+	output := make(map[string]UnitInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		output[tags[i].Id()] = createUnitInfo(*result.Result)
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+
+	if c.operations {
+		history, err := client.OperationHistory(tags)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, result := range history {
+			if result.Error != nil {
+				return errors.Annotatef(result.Error, "getting operations for %s", tags[i].Id())
+			}
+			info := output[tags[i].Id()]
+			info.Operations = make([]UnitOperation, len(result.Operations))
+			for j, op := range result.Operations {
+				info.Operations[j] = createUnitOperation(op)
+			}
+			output[tags[i].Id()] = info
+		}
+	}
+	return c.out.Write(ctx, output)
+}
+
+// UnitInfo defines the serialization behaviour of the unit information.
+type UnitInfo struct {
+	Application     string `yaml:"application" json:"application"`
+	Machine         string `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Life            string `yaml:"life" json:"life"`
+	Charm           string `yaml:"charm,omitempty" json:"charm,omitempty"`
+	WorkloadVersion string `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+
+	HookQueue  *UnitHookQueue  `yaml:"hook-queue,omitempty" json:"hook-queue,omitempty"`
+	Operations []UnitOperation `yaml:"operations,omitempty" json:"operations,omitempty"`
+}
+
+// UnitHookQueue defines the serialization behaviour of the hooks
+// a unit is waiting to run.
+type UnitHookQueue struct {
+	Pending int    `yaml:"pending" json:"pending"`
+	Next    string `yaml:"next,omitempty" json:"next,omitempty"`
+	Updated string `yaml:"updated" json:"updated"`
+}
+
+// UnitOperation defines the serialization behaviour of an operation
+// run by a unit.
+type UnitOperation struct {
+	Kind        string `yaml:"kind" json:"kind"`
+	Description string `yaml:"description" json:"description"`
+	Started     string `yaml:"started" json:"started"`
+	Completed   string `yaml:"completed" json:"completed"`
+	Duration    string `yaml:"duration" json:"duration"`
+	Error       string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func createUnitInfo(details params.UnitInfo) UnitInfo {
+	info := UnitInfo{
+		Application:     details.Application,
+		Machine:         details.Machine,
+		Life:            details.Life,
+		Charm:           details.Charm,
+		WorkloadVersion: details.WorkloadVersion,
+	}
+	if queue := details.HookQueue; queue != nil {
+		info.HookQueue = &UnitHookQueue{
+			Pending: len(queue.Pending),
+			Updated: common.FormatTime(&queue.Updated, true),
+		}
+		if len(queue.Pending) > 0 {
+			info.HookQueue.Next = queue.Pending[0]
+		}
+	}
+	return info
+}
+
+func createUnitOperation(op params.UnitOperation) UnitOperation {
+	return UnitOperation{
+		Kind:        op.Kind,
+		Description: op.Description,
+		Started:     common.FormatTime(&op.Started, true),
+		Completed:   common.FormatTime(&op.Completed, true),
+		Duration:    op.Completed.Sub(op.Started).String(),
+		Error:       op.Error,
+	}
+}
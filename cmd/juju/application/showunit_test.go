@@ -0,0 +1,196 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	started := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockShowUnitAPI{
+		info: map[string]params.UnitInfoResult{
+			"unit-mysql-0": {Result: &params.UnitInfo{
+				Tag:             "unit-mysql-0",
+				Application:     "mysql",
+				Machine:         "0",
+				Life:            "alive",
+				Charm:           "cs:mysql-42",
+				WorkloadVersion: "5.7",
+			}},
+		},
+		operations: map[string][]params.UnitOperation{
+			"unit-mysql-0": {{
+				Kind:        "run-hook",
+				Description: "run config-changed hook",
+				Started:     started.Add(time.Minute),
+				Completed:   started.Add(time.Minute + 1500*time.Millisecond),
+				Error:       "hook failed",
+			}, {
+				Kind:        "install",
+				Description: "install cs:mysql-42",
+				Started:     started,
+				Completed:   started.Add(10 * time.Second),
+			}},
+		},
+	}
+}
+
+func (s *ShowUnitSuite) runShowUnit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ShowUnitSuite) TestShowNoArguments(c *gc.C) {
+	_, err := s.runShowUnit(c)
+	c.Assert(err, gc.ErrorMatches, "a unit name must be supplied")
+}
+
+func (s *ShowUnitSuite) TestShowInvalidName(c *gc.C) {
+	_, err := s.runShowUnit(c, "mysql", "wordpress/1", "0/foo")
+	c.Assert(err, gc.ErrorMatches, "unit names mysql, 0/foo not valid")
+}
+
+func (s *ShowUnitSuite) TestShow(c *gc.C) {
+	ctx, err := s.runShowUnit(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  application: mysql
+  machine: "0"
+  life: alive
+  charm: cs:mysql-42
+  workload-version: "5.7"
+`[1:])
+	c.Assert(s.mockAPI.historyCalled, jc.IsFalse)
+}
+
+func (s *ShowUnitSuite) TestShowHookQueue(c *gc.C) {
+	s.mockAPI.info["unit-mysql-0"].Result.HookQueue = &params.UnitHookQueue{
+		Pending: []string{"config-changed", "update-status"},
+		Updated: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	ctx, err := s.runShowUnit(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  application: mysql
+  machine: "0"
+  life: alive
+  charm: cs:mysql-42
+  workload-version: "5.7"
+  hook-queue:
+    pending: 2
+    next: config-changed
+    updated: 2019-06-01 12:00:00Z
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowHookQueueEmpty(c *gc.C) {
+	s.mockAPI.info["unit-mysql-0"].Result.HookQueue = &params.UnitHookQueue{
+		Updated: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	ctx, err := s.runShowUnit(c, "mysql/0", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"mysql/0":{"application":"mysql","machine":"0","life":"alive","charm":"cs:mysql-42","workload-version":"5.7","hook-queue":{"pending":0,"updated":"2019-06-01 12:00:00Z"}}}`+"\n")
+}
+
+func (s *ShowUnitSuite) TestShowOperations(c *gc.C) {
+	ctx, err := s.runShowUnit(c, "mysql/0", "--operations")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql/0:
+  application: mysql
+  machine: "0"
+  life: alive
+  charm: cs:mysql-42
+  workload-version: "5.7"
+  operations:
+  - kind: run-hook
+    description: run config-changed hook
+    started: 2019-06-01 12:01:00Z
+    completed: 2019-06-01 12:01:01Z
+    duration: 1.5s
+    error: hook failed
+  - kind: install
+    description: install cs:mysql-42
+    started: 2019-06-01 12:00:00Z
+    completed: 2019-06-01 12:00:10Z
+    duration: 10s
+`[1:])
+}
+
+func (s *ShowUnitSuite) TestShowUnitNotFound(c *gc.C) {
+	_, err := s.runShowUnit(c, "mysql/0", "wordpress/1")
+	c.Assert(err, gc.ErrorMatches, `unit "wordpress/1" not found`)
+}
+
+type mockShowUnitAPI struct {
+	info          map[string]params.UnitInfoResult
+	operations    map[string][]params.UnitOperation
+	historyCalled bool
+}
+
+func (s *mockShowUnitAPI) Close() error {
+	return nil
+}
+
+func (s *mockShowUnitAPI) UnitsInfo(tags []names.UnitTag) ([]params.UnitInfoResult, error) {
+	results := make([]params.UnitInfoResult, len(tags))
+	for i, tag := range tags {
+		result, ok := s.info[tag.String()]
+		if !ok {
+			result.Error = &params.Error{
+				Message: errors.NotFoundf("unit %q", tag.Id()).Error(),
+				Code:    params.CodeNotFound,
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (s *mockShowUnitAPI) OperationHistory(tags []names.UnitTag) ([]params.UnitOperationsResult, error) {
+	s.historyCalled = true
+	results := make([]params.UnitOperationsResult, len(tags))
+	for i, tag := range tags {
+		results[i].Operations = s.operations[tag.String()]
+	}
+	return results, nil
+}
@@ -8,16 +8,20 @@ package application
 
 import (
 	"net/url"
+	"strings"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
 	"gopkg.in/juju/charmrepo.v3/csclient"
 	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/environs/config"
 )
 
 // SeriesConfig defines the single config method that we need to resolve
@@ -96,3 +100,36 @@ func authorizeCharmStoreEntity(csClient charmstoreForDeploy, curl *charm.URL) (*
 	}
 	return m, nil
 }
+
+// mirroredCharmRepo returns the repository used to resolve and get charms
+// and bundles for a model with the given config. If the model uses the
+// controller's charm store mirror at location, and the client can reach
+// it, charms are taken from the mirror, falling back to store unless the
+// model is offline. Otherwise store is returned.
+func mirroredCharmRepo(
+	store *charmrepo.CharmStore,
+	location string,
+	modelAttrs map[string]interface{},
+	channel csparams.Channel,
+) (charmrepoForDeploy, error) {
+	mode, _ := modelAttrs[config.CharmMirrorModeKey].(string)
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		// Other mirrors are only accessible to the controller.
+		if location != "" && mode == config.CharmMirrorOffline {
+			logger.Debugf("charm store mirror %q is not accessible to clients", location)
+		}
+		return store, nil
+	}
+	mirror, offline, err := charmstore.OpenModelMirror(location, mode)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mirror == nil {
+		return store, nil
+	}
+	var fallback charmrepo.Interface
+	if !offline {
+		fallback = store
+	}
+	return charmstore.NewMirroredRepo(mirror.WithChannel(channel), fallback), nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testcharms"
+)
+
+type mirroredCharmRepoSuite struct {
+	testing.IsolationSuite
+	store  *charmrepo.CharmStore
+	server *httptest.Server
+}
+
+var _ = gc.Suite(&mirroredCharmRepoSuite{})
+
+func (s *mirroredCharmRepoSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	dir := c.MkDir()
+	archive, err := os.Open(testcharms.Repo.CharmArchivePath(c.MkDir(), "wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	w, err := charmstore.NewMirrorWriter(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = w.AddEntity(charmstore.MirrorEntity{
+		ID:              "cs:~bob/wordpress-2",
+		PromulgatedID:   "cs:wordpress-5",
+		Channel:         csparams.StableChannel,
+		SupportedSeries: []string{"bionic"},
+	}, archive, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.server = httptest.NewServer(http.FileServer(http.Dir(dir)))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.store = charmrepo.NewCharmStore(charmrepo.NewCharmStoreParams{})
+}
+
+func (s *mirroredCharmRepoSuite) TestNotMirrored(c *gc.C) {
+	for i, test := range []struct {
+		location string
+		mode     string
+	}{{
+		location: "",
+		mode:     config.CharmMirrorOffline,
+	}, {
+		location: "/srv/charm-mirror",
+		mode:     config.CharmMirrorOffline,
+	}, {
+		location: s.server.URL,
+		mode:     config.CharmMirrorDisabled,
+	}, {
+		location: s.server.URL,
+	}} {
+		c.Logf("test %d: %q %q", i, test.location, test.mode)
+		attrs := map[string]interface{}{}
+		if test.mode != "" {
+			attrs[config.CharmMirrorModeKey] = test.mode
+		}
+		repo, err := mirroredCharmRepo(s.store, test.location, attrs, csparams.StableChannel)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(repo, gc.Equals, s.store)
+	}
+}
+
+func (s *mirroredCharmRepoSuite) TestOffline(c *gc.C) {
+	repo, err := mirroredCharmRepo(s.store, s.server.URL, map[string]interface{}{
+		config.CharmMirrorModeKey: config.CharmMirrorOffline,
+	}, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repo, gc.FitsTypeOf, &charmstore.MirroredRepo{})
+
+	id, channel, series, err := repo.ResolveWithChannel(charm.MustParseURL("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id.String(), gc.Equals, "cs:wordpress-5")
+	c.Check(channel, gc.Equals, csparams.StableChannel)
+	c.Check(series, jc.DeepEquals, []string{"bionic"})
+
+	_, _, _, err = repo.ResolveWithChannel(charm.MustParseURL("mysql"))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *mirroredCharmRepoSuite) TestOfflineUnavailable(c *gc.C) {
+	s.server.Close()
+	_, err := mirroredCharmRepo(s.store, s.server.URL, map[string]interface{}{
+		config.CharmMirrorModeKey: config.CharmMirrorOffline,
+	}, csparams.StableChannel)
+	c.Assert(err, gc.ErrorMatches, `cannot open charm store mirror ".*": .*`)
+}
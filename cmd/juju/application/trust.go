@@ -10,32 +10,68 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
+	apiapplication "github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
 )
 
 const (
 	trustSummary = `Sets the trust status of a deployed application to true.`
 	trustDetails = `Sets the trust configuration value to true.
 
+A trusted application's hooks may fetch the model's cloud credential,
+for example with credential-get. The controller records each hook which
+does so. Removing trust with --remove takes effect immediately, even
+for hooks which are already running, and then lists the hooks which
+most recently accessed the credential while the application was trusted.
+
 Examples:
     juju trust media-wiki
+    juju trust media-wiki --remove
 
 See also:
     config
 `
 )
 
+// credentialAccessAPI defines the API methods used to report which
+// hooks accessed the cloud credential while an application was trusted.
+type credentialAccessAPI interface {
+	Close() error
+	CredentialAccesses(application string) ([]params.CredentialAccess, error)
+}
+
 type trustCommand struct {
 	configCommand
 	removeTrust bool
+
+	accessAPI credentialAccessAPI
 }
 
 func NewTrustCommand() cmd.Command {
 	return modelcmd.Wrap(&trustCommand{})
 }
 
+// NewTrustCommandForTest returns a trust command with the APIs
+// provided as specified.
+func NewTrustCommandForTest(
+	api applicationAPI,
+	accessAPI credentialAccessAPI,
+	store jujuclient.ClientStore,
+) modelcmd.ModelCommand {
+	c := modelcmd.Wrap(&trustCommand{
+		configCommand: configCommand{api: api},
+		accessAPI:     accessAPI,
+	})
+	c.SetClientStore(store)
+	return c
+}
+
 // Info is part of the cmd.Command interface.
 func (c *trustCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
@@ -62,3 +98,62 @@ func (c *trustCommand) Init(args []string) error {
 	trustOptionPair = fmt.Sprintf("%s=%t", application.TrustConfigOptionName, !c.removeTrust)
 	return c.parseSet([]string{trustOptionPair})
 }
+
+// Run is part of the cmd.Command interface.
+func (c *trustCommand) Run(ctx *cmd.Context) error {
+	if err := c.configCommand.Run(ctx); err != nil {
+		return err
+	}
+	if !c.removeTrust {
+		return nil
+	}
+	return c.reportCredentialAccesses(ctx)
+}
+
+func (c *trustCommand) getAccessAPI() (credentialAccessAPI, error) {
+	if c.accessAPI != nil {
+		return c.accessAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiapplication.NewClient(root), nil
+}
+
+// reportCredentialAccesses writes out the hooks which most recently
+// accessed the cloud credential while the application was trusted.
+func (c *trustCommand) reportCredentialAccesses(ctx *cmd.Context) error {
+	client, err := c.getAccessAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	accesses, err := client.CredentialAccesses(c.applicationName)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("cannot report credential accesses: %v", err)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting credential accesses")
+	}
+	if len(accesses) == 0 {
+		ctx.Infof("No hooks of %q accessed the cloud credential while it was trusted.", c.applicationName)
+		return nil
+	}
+	ctx.Infof("Hooks of %q which accessed the cloud credential while it was trusted:", c.applicationName)
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Unit", "Hook", "Time")
+	for _, access := range accesses {
+		unit, hook := access.Unit, access.Hook
+		if unit == "" {
+			unit = "-"
+		}
+		if hook == "" {
+			hook = "-"
+		}
+		w.Println(unit, hook, common.FormatTime(&access.Time, true))
+	}
+	return errors.Trace(tw.Flush())
+}
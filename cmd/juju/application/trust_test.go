@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type trustCommandSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake      *fakeApplicationAPI
+	accessAPI *fakeCredentialAccessAPI
+	store     jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&trustCommandSuite{})
+
+func (s *trustCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeApplicationAPI{
+		name:      "gitlab",
+		charmName: "gitlab",
+		appValues: map[string]interface{}{"trust": true},
+		version:   6,
+	}
+	s.accessAPI = &fakeCredentialAccessAPI{}
+	s.store = jujuclienttesting.MinimalStore()
+}
+
+func (s *trustCommandSuite) runTrust(c *gc.C, args ...string) (string, string, error) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewTrustCommandForTest(s.fake, s.accessAPI, s.store), args...)
+	if err != nil {
+		return "", "", err
+	}
+	return cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), nil
+}
+
+func (s *trustCommandSuite) TestTrust(c *gc.C) {
+	s.fake.appValues["trust"] = false
+	stdout, _, err := s.runTrust(c, "gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, "")
+	c.Assert(s.fake.appValues["trust"], gc.Equals, "true")
+	c.Assert(s.accessAPI.application, gc.Equals, "")
+}
+
+func (s *trustCommandSuite) TestRemoveTrustReportsAccesses(c *gc.C) {
+	when := time.Date(2019, 5, 1, 10, 30, 0, 0, time.UTC)
+	s.accessAPI.accesses = []params.CredentialAccess{
+		{Unit: "gitlab/1", Hook: "config-changed", Time: when.Add(time.Minute)},
+		{Hook: "install", Time: when},
+	}
+	stdout, stderr, err := s.runTrust(c, "gitlab", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues["trust"], gc.Equals, "false")
+	c.Assert(s.accessAPI.application, gc.Equals, "gitlab")
+	c.Assert(stderr, gc.Equals, `Hooks of "gitlab" which accessed the cloud credential while it was trusted:`+"\n")
+	c.Assert(stdout, gc.Equals, ""+
+		"Unit      Hook            Time\n"+
+		"gitlab/1  config-changed  2019-05-01 10:31:00Z\n"+
+		"-         install         2019-05-01 10:30:00Z\n",
+	)
+}
+
+func (s *trustCommandSuite) TestRemoveTrustNoAccesses(c *gc.C) {
+	stdout, stderr, err := s.runTrust(c, "gitlab", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues["trust"], gc.Equals, "false")
+	c.Assert(stdout, gc.Equals, "")
+	c.Assert(stderr, gc.Equals, `No hooks of "gitlab" accessed the cloud credential while it was trusted.`+"\n")
+}
+
+func (s *trustCommandSuite) TestRemoveTrustAccessesNotSupported(c *gc.C) {
+	s.accessAPI.err = errors.NotSupportedf("credential access history on this juju controller")
+	stdout, stderr, err := s.runTrust(c, "gitlab", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.appValues["trust"], gc.Equals, "false")
+	c.Assert(stdout, gc.Equals, "")
+	c.Assert(stderr, gc.Equals, "")
+}
+
+func (s *trustCommandSuite) TestRemoveTrustAccessesError(c *gc.C) {
+	s.accessAPI.err = errors.New("boom")
+	_, _, err := s.runTrust(c, "gitlab", "--remove")
+	c.Assert(err, gc.ErrorMatches, "getting credential accesses: boom")
+	c.Assert(s.fake.appValues["trust"], gc.Equals, "false")
+}
+
+type fakeCredentialAccessAPI struct {
+	application string
+	accesses    []params.CredentialAccess
+	err         error
+}
+
+func (f *fakeCredentialAccessAPI) Close() error {
+	return nil
+}
+
+func (f *fakeCredentialAccessAPI) CredentialAccesses(application string) ([]params.CredentialAccess, error) {
+	f.application = application
+	return f.accesses, f.err
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -137,6 +138,20 @@ type upgradeCharmCommand struct {
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
 
+	// ConfigMap maps the names of config options of the current charm
+	// to the names of the new charm's options that their values are
+	// carried over to during upgrade.
+	ConfigMap map[string]string
+
+	// Preview, if true, adds the charm to the model without upgrading
+	// the application, so that the upgrade can be previewed by running
+	// the juju-upgrade-preview action on its units.
+	Preview bool
+
+	// charmConfigRenames holds the config option renames declared
+	// by the config migrations file of a local charm being upgraded to.
+	charmConfigRenames map[string]string
+
 	catacomb catacomb.Catacomb
 	plan     catacomb.Plan
 }
@@ -180,6 +195,32 @@ Charm settings may be added or updated at upgrade time by specifying the
 
   juju upgrade-charm foo --config config.yaml
 
+Config options which have been renamed in the new charm may have their values
+carried over by specifying the --config-map option, with the old and new
+option names as an old=new pair. This option may be repeated to rename more
+than one option. The settings are renamed as part of the upgrade, so there is
+no window in which the application runs with the new charm and stale settings.
+
+  juju upgrade-charm foo --config-map db-host=database-host
+
+A local charm may also declare the options which have been renamed since its
+earlier revisions in a config-migrations.yaml file, of the form:
+
+  renames:
+    db-host: database-host
+
+These renames apply to whichever of the options the deployed and new charms
+define; --config-map takes precedence over them.
+
+The differences between the deployed charm and the new charm may be previewed
+before upgrading by specifying the --preview option. The new charm is added to
+the model, but the application is not upgraded; running the predefined
+juju-upgrade-preview action on a unit then reports the files and config
+options that the upgrade would add, remove or change.
+
+  juju upgrade-charm foo --preview
+  juju run-action foo/0 juju-upgrade-preview charm-url=cs:foo-2 --wait
+
 If the new version of a charm does not explicitly support the application's series, the
 upgrade is disallowed unless the --force-series option is used. This option should be
 used with caution since using a charm on a machine running an unsupported series may
@@ -239,6 +280,8 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.Var(stringMap{&c.ConfigMap}, "config-map", "Config option to rename during upgrade, as old=new")
+	f.BoolVar(&c.Preview, "preview", false, "Add the charm to the model so that the upgrade may be previewed, without upgrading")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -262,6 +305,11 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	for oldName, newName := range c.ConfigMap {
+		if oldName == "" || newName == "" {
+			return errors.Errorf("invalid --config-map %q: expected old=new", oldName+"="+newName)
+		}
+	}
 	return nil
 }
 
@@ -282,13 +330,14 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			action = "updating storage constraints"
 		}
 		if apiRoot.BestFacadeVersion("Application") < 2 {
-			suffix := "this server"
-			if version, ok := apiRoot.ServerVersion(); ok {
-				suffix = fmt.Sprintf("server version %s", version)
-			}
-			return errors.New(action + " at upgrade-charm time is not supported by " + suffix)
+			return errors.New(action + " at upgrade-charm time is not supported by " + serverDescription(apiRoot))
 		}
 	}
+	// Renaming config requires facade version 12.
+	supportsConfigMap := apiRoot.BestFacadeVersion("Application") >= 12
+	if len(c.ConfigMap) > 0 && !supportsConfigMap {
+		return errors.New("renaming config at upgrade-charm time is not supported by " + serverDescription(apiRoot))
+	}
 
 	generation, err := c.ActiveBranch()
 	if err != nil {
@@ -354,6 +403,11 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 	ctx.Infof("Added charm %q to the model.", chID.URL)
+	if c.Preview {
+		ctx.Infof("To preview the upgrade, run:\n  juju run-action %s/<unit> %s charm-url=%s --wait",
+			c.ApplicationName, actions.JujuUpgradePreviewActionName, chID.URL)
+		return nil
+	}
 
 	// Next, upgrade resources.
 	charmsClient := c.NewCharmClient(apiRoot)
@@ -371,6 +425,23 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 
 	// Finally, upgrade the application.
+	configMap := c.ConfigMap
+	if len(c.charmConfigRenames) > 0 {
+		if supportsConfigMap {
+			charmInfo, err := charmsClient.CharmInfo(chID.URL.String())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			configMap = applicableConfigRenames(
+				c.charmConfigRenames,
+				applicationInfo.CharmConfig,
+				charmInfo.Config,
+				c.ConfigMap,
+			)
+		} else {
+			ctx.Warningf("ignoring config renames declared by charm: not supported by %s", serverDescription(apiRoot))
+		}
+	}
 	var configYAML []byte
 	if c.Config.Path != "" {
 		configYAML, err = c.Config.Read(ctx)
@@ -382,6 +453,7 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ApplicationName:    c.ApplicationName,
 		CharmID:            chID,
 		ConfigSettingsYAML: string(configYAML),
+		ConfigMap:          configMap,
 		Force:              c.Force,
 		ForceSeries:        c.ForceSeries,
 		ForceUnits:         c.ForceUnits,
@@ -391,6 +463,15 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(generation, cfg), block.BlockChange)
 }
 
+// serverDescription describes the server the API connection is to,
+// for use in errors about features it does not support.
+func serverDescription(apiRoot api.Connection) string {
+	if version, ok := apiRoot.ServerVersion(); ok {
+		return fmt.Sprintf("server version %s", version)
+	}
+	return "this server"
+}
+
 // upgradeResources pushes metadata up to the server for each resource defined
 // in the new charm's metadata and returns a map of resource names to pending
 // IDs to include in the upgrage-charm call.
@@ -543,6 +624,17 @@ var getCharmStoreAPIURL = func(conAPIRoot base.APICallCloser) (string, error) {
 	return controllerCfg.CharmStoreURL(), nil
 }
 
+// getCharmStoreMirror consults the controller config for the charm store
+// mirror to use, if any.
+var getCharmStoreMirror = func(conAPIRoot base.APICallCloser) (string, error) {
+	controllerAPI := controller.NewClient(conAPIRoot)
+	controllerCfg, err := controllerAPI.ControllerConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return controllerCfg.CharmStoreMirror(), nil
+}
+
 // addCharm interprets the new charmRef and adds the specified charm if
 // the new charm is different to what's already deployed as specified by
 // oldURL.
@@ -564,6 +656,10 @@ func (c *upgradeCharmCommand) addCharm(
 		if newName != oldURL.Name {
 			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
 		}
+		c.charmConfigRenames, err = readConfigMigrations(ch)
+		if err != nil {
+			return id, nil, errors.Trace(err)
+		}
 		addedURL, err := charmAdder.AddLocalCharm(newURL, ch, force)
 		id.URL = addedURL
 		return id, nil, err
@@ -611,6 +707,10 @@ func (c *upgradeCharmCommand) addCharm(
 		return id, nil, errors.Errorf("already running latest charm %q", newURL)
 	}
 
+	c.charmConfigRenames, err = readStoreConfigMigrations(charmRepo.Client(), newURL)
+	if err != nil {
+		return id, nil, errors.Trace(err)
+	}
 	curl, csMac, err := addCharmFromURL(charmAdder, newURL, channel, force)
 	if err != nil {
 		return id, nil, errors.Trace(err)
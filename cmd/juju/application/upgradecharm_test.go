@@ -22,6 +22,7 @@ import (
 	"gopkg.in/juju/charm.v6"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/charmrepo.v3"
+	"gopkg.in/juju/charmrepo.v3/csclient"
 	csclientparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 	"gopkg.in/juju/charmstore.v5"
 	"gopkg.in/juju/names.v2"
@@ -69,6 +70,9 @@ var _ = gc.Suite(&UpgradeCharmSuite{})
 func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.Stub.ResetCalls()
+	s.PatchValue(&readStoreConfigMigrations, func(*csclient.Client, *charm.URL) (map[string]string, error) {
+		return nil, nil
+	})
 
 	// Create persistent cookies in a temporary location.
 	cookieFile := filepath.Join(c.MkDir(), "cookies")
@@ -189,6 +193,16 @@ func (s *UpgradeCharmSuite) TestStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *UpgradeCharmSuite) TestPreview(c *gc.C) {
+	ctx, err := s.runUpgradeCharm(c, "foo", "--preview")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, fmt.Sprintf(`
+To preview the upgrade, run:
+  juju run-action foo/<unit> juju-upgrade-preview charm-url=%s --wait
+`[1:], s.resolvedCharmURL))
+}
+
 func (s *UpgradeCharmSuite) TestUseConfiguredCharmStoreURL(c *gc.C) {
 	_, err := s.runUpgradeCharm(c, "foo")
 	c.Assert(err, jc.ErrorIsNil)
@@ -249,6 +263,37 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestConfigMap(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 12
+	_, err := s.runUpgradeCharm(c, "foo", "--config-map", "db-host=database-host", "--config-map", "port=db-port")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+
+	s.charmAPIClient.CheckCall(c, 2, "SetCharm", model.GenerationMaster, application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		ConfigMap: map[string]string{
+			"db-host": "database-host",
+			"port":    "db-port",
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestConfigMapMinFacadeVersion(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 11
+	_, err := s.runUpgradeCharm(c, "foo", "--config-map", "db-host=database-host")
+	c.Assert(err, gc.ErrorMatches,
+		"renaming config at upgrade-charm time is not supported by server version 1.2.3")
+}
+
+func (s *UpgradeCharmSuite) TestConfigMapInvalid(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--config-map", "db-host=")
+	c.Assert(err, gc.ErrorMatches, `invalid --config-map "db-host=": expected old=new`)
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -602,6 +647,44 @@ func (s *UpgradeCharmSuccessStateSuite) TestCharmPathNoRevUpgrade(c *gc.C) {
 	c.Assert(curl.String(), gc.Equals, "local:bionic/riak-8")
 }
 
+func (s *UpgradeCharmSuccessStateSuite) TestCharmPathConfigMigrations(c *gc.C) {
+	oldPath := testcharms.RepoWithSeries("bionic").ClonedDirPath(c.MkDir(), "riak")
+	err := ioutil.WriteFile(path.Join(oldPath, "revision"), []byte("42"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(oldPath, "config.yaml"), []byte(`
+options:
+  db-host: {type: string, description: Database host}
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = runUpgradeCharm(c, "riak", "--path", oldPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 42, false)
+	err = s.riak.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"db-host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newPath := testcharms.RepoWithSeries("bionic").ClonedDirPath(c.MkDir(), "riak")
+	err = ioutil.WriteFile(path.Join(newPath, "revision"), []byte("43"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(newPath, "config.yaml"), []byte(`
+options:
+  database-host: {type: string, description: Database host}
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path.Join(newPath, "config-migrations.yaml"), []byte(`
+renames:
+  db-host: database-host
+  hostname: db-host
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = runUpgradeCharm(c, "riak", "--path", newPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, s.riak, 43, false)
+
+	settings, err := s.riak.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"database-host": "db.example.com"})
+}
+
 func (s *UpgradeCharmSuccessStateSuite) TestCharmPathDifferentNameFails(c *gc.C) {
 	myriakPath := testcharms.RepoWithSeries("bionic").RenamedClonedDirPath(s.CharmsPath, "riak", "myriak")
 	metadataPath := filepath.Join(myriakPath, "metadata.yaml")
@@ -813,6 +896,55 @@ func (s *UpgradeCharmCharmStoreStateSuite) TestUpgradeCharmShouldRespectDeployed
 	})
 }
 
+func (s *UpgradeCharmCharmStoreStateSuite) TestUpgradeCharmConfigMigrations(c *gc.C) {
+	uploadRiak := func(url, config, migrations string) {
+		dir := testcharms.RepoWithSeries("bionic").ClonedDirPath(c.MkDir(), "riak")
+		err := ioutil.WriteFile(path.Join(dir, "config.yaml"), []byte(config), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+		if migrations != "" {
+			err = ioutil.WriteFile(path.Join(dir, "config-migrations.yaml"), []byte(migrations), 0644)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		ch, err := charm.ReadCharmDir(dir)
+		c.Assert(err, jc.ErrorIsNil)
+		id := charm.MustParseURL(url)
+		err = s.client.UploadCharmWithRevision(id, ch, -1)
+		c.Assert(err, jc.ErrorIsNil)
+		testcharms.SetPublic(c, s.client, id)
+	}
+	uploadRiak("cs:~other/trusty/riak-0", `
+options:
+  db-host: {type: string, description: Database host}
+`, "")
+	err := runDeploy(c, "cs:~other/trusty/riak-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	errs, err := s.APIState.UnitAssigner().AssignUnits([]names.UnitTag{unit.UnitTag()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.DeepEquals, []error{nil})
+	err = riak.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"db-host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uploadRiak("cs:~other/trusty/riak-1", `
+options:
+  database-host: {type: string, description: Database host}
+`, `
+renames:
+  db-host: database-host
+`)
+	err = runUpgradeCharm(c, "riak")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUpgraded(c, riak, 1, false)
+
+	settings, err := riak.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"database-host": "db.example.com"})
+}
+
 func (s *UpgradeCharmCharmStoreStateSuite) TestUpgradeWithTermsNotSigned(c *gc.C) {
 	id, ch := testcharms.UploadCharmWithSeries(c, s.client, "bionic/terms1-1", "terms1", "bionic")
 	err := runDeploy(c, "bionic/terms1")
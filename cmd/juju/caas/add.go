@@ -159,7 +159,7 @@ type AddCAASCommand struct {
 
 // NewAddCAASCommand returns a command to add caas information.
 func NewAddCAASCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	cmd := &AddCAASCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
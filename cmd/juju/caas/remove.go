@@ -56,7 +56,7 @@ type RemoveCAASCommand struct {
 
 // NewRemoveCAASCommand returns a command to add caas information.
 func NewRemoveCAASCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	cmd := &RemoveCAASCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
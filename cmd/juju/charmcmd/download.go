@@ -0,0 +1,264 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd
+
+import (
+	"encoding/hex"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// CharmStoreAPI holds the charm store methods used by the
+// download-charm command.
+type CharmStoreAPI interface {
+	// Resolve returns the canonical and, if there is one, the
+	// promulgated URL of the latest revision of the given charm
+	// or bundle, and the series a charm supports.
+	Resolve(ref *charm.URL) (id, promulgatedID *charm.URL, supportedSeries []string, err error)
+
+	// GetArchive returns the archive of the given entity and its
+	// hex-encoded SHA384 hash.
+	GetArchive(id *charm.URL) (io.ReadCloser, string, error)
+
+	// ArchiveSignature returns the armored detached signature of the
+	// archive of the given entity, or a NotFound error if it is not
+	// signed.
+	ArchiveSignature(id *charm.URL) ([]byte, error)
+
+	// ListResources returns the resources of the given charm.
+	ListResources(id *charm.URL) ([]csparams.Resource, error)
+
+	// GetResource returns the data of the given resource revision.
+	GetResource(id *charm.URL, name string, revision int) (io.ReadCloser, error)
+}
+
+// NewDownloadCommand returns a command that downloads charms and
+// bundles, and optionally their resources, from the charm store into
+// a charm store mirror directory.
+func NewDownloadCommand() cmd.Command {
+	return &downloadCommand{}
+}
+
+type downloadCommand struct {
+	cmd.CommandBase
+
+	// newAPI is called by Run to get a charm store client.
+	newAPI func(charmStoreURL string, channel csparams.Channel) CharmStoreAPI
+
+	charmStoreURL string
+	channel       string
+	withResources bool
+
+	ref *charm.URL
+	dir string
+}
+
+const downloadDoc = `
+Downloads a charm or bundle from the charm store into a charm store
+mirror directory, which is created if it does not exist. With
+--with-resources the charm's file resources are also downloaded, at the
+revisions published in the channel. The archive's hash and, if the
+charm store holds one, its signature are recorded in the mirror so the
+controller can verify the charm when it is deployed.
+
+A controller can serve charms and resources from the mirror, either
+as a directory on the controller machines or over http, by setting the
+"charmstore-mirror" controller config. Models then use it according to
+their "charm-mirror-mode": with "prefer" charms found in the mirror are
+deployed from it, and with "offline" the charm store is never
+contacted, so charms can be deployed in air-gapped environments. For
+clients to deploy to offline models the mirror must be served over
+http or https.
+
+If no directory is given, the current directory is used.
+
+Examples:
+
+    juju download-charm mysql /srv/charm-mirror
+    juju download-charm --with-resources cs:~user/xenial/myapp-3 /srv/charm-mirror
+    juju download-charm --channel edge cs:bundle/wiki-simple
+
+See also:
+    controller-config
+    model-config
+    deploy
+`
+
+// Info implements Command.Info.
+func (c *downloadCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "download-charm",
+		Args:    "<charm> [<directory>]",
+		Purpose: "Downloads a charm or bundle into a charm store mirror.",
+		Doc:     downloadDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.charmStoreURL, "charmstore-url", csclient.ServerURL, "The charm store to download from")
+	f.StringVar(&c.channel, "channel", string(csparams.StableChannel), "The channel to download from")
+	f.BoolVar(&c.withResources, "with-resources", false, "Also download the charm's resources")
+}
+
+// Init implements Command.Init.
+func (c *downloadCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no charm specified")
+	case 1:
+		c.dir = "."
+	case 2:
+		c.dir = args[1]
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	ref, err := charm.ParseURL(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ref.Schema != "cs" {
+		return errors.Errorf("only charm store charms can be downloaded, got %q", ref)
+	}
+	c.ref = ref
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *downloadCommand) Run(ctx *cmd.Context) error {
+	newAPI := c.newAPI
+	if newAPI == nil {
+		newAPI = newCharmStoreAPI
+	}
+	channel := csparams.Channel(c.channel)
+	api := newAPI(c.charmStoreURL, channel)
+
+	id, promulgatedID, supportedSeries, err := api.Resolve(c.ref)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entity := charmstore.MirrorEntity{
+		ID:              id.String(),
+		Channel:         channel,
+		SupportedSeries: supportedSeries,
+	}
+	if promulgatedID != nil {
+		entity.PromulgatedID = promulgatedID.String()
+	}
+
+	resourceData := make(map[string]io.Reader)
+	if c.withResources && id.Series != "bundle" {
+		resources, err := api.ListResources(id)
+		if err != nil {
+			return errors.Annotatef(err, "cannot list resources for %v", id)
+		}
+		for _, r := range resources {
+			if r.Type != "file" {
+				ctx.Warningf("skipping %s resource %q: only file resources can be mirrored", r.Type, r.Name)
+				continue
+			}
+			data, err := api.GetResource(id, r.Name, r.Revision)
+			if err != nil {
+				return errors.Annotatef(err, "cannot download resource %q", r.Name)
+			}
+			defer data.Close()
+			resourceData[r.Name] = data
+			entity.Resources = append(entity.Resources, charmstore.MirrorResource{
+				Name:        r.Name,
+				Type:        r.Type,
+				Path:        r.Path,
+				Description: r.Description,
+				Revision:    r.Revision,
+				Fingerprint: hex.EncodeToString(r.Fingerprint),
+				Size:        r.Size,
+			})
+		}
+	}
+
+	signature, err := api.ArchiveSignature(id)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	entity.Signature = string(signature)
+
+	archive, sha384, err := api.GetArchive(id)
+	if err != nil {
+		return errors.Annotatef(err, "cannot download %v", id)
+	}
+	defer archive.Close()
+	entity.SHA384 = sha384
+
+	writer, err := charmstore.NewMirrorWriter(c.dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := writer.AddEntity(entity, archive, resourceData); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Downloaded %v to %s", id, c.dir)
+	for _, r := range entity.Resources {
+		ctx.Infof("Downloaded resource %q revision %d", r.Name, r.Revision)
+	}
+	return nil
+}
+
+func newCharmStoreAPI(charmStoreURL string, channel csparams.Channel) CharmStoreAPI {
+	client := csclient.New(csclient.Params{
+		URL: charmStoreURL,
+	})
+	return csClientAPI{client.WithChannel(channel)}
+}
+
+// csClientAPI implements CharmStoreAPI with a csclient.Client.
+type csClientAPI struct {
+	client *csclient.Client
+}
+
+// Resolve implements CharmStoreAPI.
+func (api csClientAPI) Resolve(ref *charm.URL) (*charm.URL, *charm.URL, []string, error) {
+	var meta struct {
+		Id              csparams.IdResponse
+		SupportedSeries csparams.SupportedSeriesResponse
+	}
+	if _, err := api.client.Meta(ref, &meta); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	return meta.Id.Id, meta.Id.PromulgatedId, meta.SupportedSeries.SupportedSeries, nil
+}
+
+// GetArchive implements CharmStoreAPI.
+func (api csClientAPI) GetArchive(id *charm.URL) (io.ReadCloser, string, error) {
+	r, _, sha384, _, err := api.client.GetArchive(id)
+	return r, sha384, errors.Trace(err)
+}
+
+// ArchiveSignature implements CharmStoreAPI.
+func (api csClientAPI) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	return charmstore.NewCharmStoreVerifier(api.client).ArchiveSignature(id)
+}
+
+// ListResources implements CharmStoreAPI.
+func (api csClientAPI) ListResources(id *charm.URL) ([]csparams.Resource, error) {
+	resources, err := api.client.ListResources(id)
+	return resources, errors.Trace(err)
+}
+
+// GetResource implements CharmStoreAPI.
+func (api csClientAPI) GetResource(id *charm.URL, name string, revision int) (io.ReadCloser, error) {
+	data, err := api.client.GetResource(id, name, revision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data.ReadCloser, nil
+}
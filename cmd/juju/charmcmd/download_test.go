@@ -0,0 +1,225 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd_test
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/charmrepo.v3/csclient"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/juju/charmcmd"
+	"github.com/juju/juju/testcharms"
+)
+
+type DownloadSuite struct {
+	testing.IsolationSuite
+	api *fakeCharmStoreAPI
+	dir string
+}
+
+var _ = gc.Suite(&DownloadSuite{})
+
+func (s *DownloadSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("some data"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = &fakeCharmStoreAPI{
+		Stub:          &testing.Stub{},
+		archivePath:   testcharms.Repo.CharmArchivePath(c.MkDir(), "wordpress"),
+		id:            charm.MustParseURL("cs:~bob/wordpress-2"),
+		promulgatedID: charm.MustParseURL("cs:wordpress-5"),
+		resources: []csparams.Resource{{
+			Name:        "data",
+			Type:        "file",
+			Path:        "data.tgz",
+			Revision:    3,
+			Fingerprint: fp.Bytes(),
+			Size:        9,
+		}, {
+			Name:     "image",
+			Type:     "oci-image",
+			Revision: 1,
+		}},
+		data: "some data",
+	}
+}
+
+func (s *DownloadSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := charmcmd.NewDownloadCommandForTest(func(url string, channel csparams.Channel) charmcmd.CharmStoreAPI {
+		s.api.AddCall("NewAPI", url, channel)
+		return s.api
+	})
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *DownloadSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no charm specified",
+	}, {
+		args: []string{"local:wordpress"},
+		err:  `only charm store charms can be downloaded, got "local:wordpress"`,
+	}, {
+		args: []string{"wordpress", "dir", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *DownloadSuite) TestDownload(c *gc.C) {
+	ctx, err := s.run(c, "--channel", "edge", "wordpress", s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Downloaded cs:~bob/wordpress-2 to "+s.dir+"\n")
+	s.api.CheckCallNames(c, "NewAPI", "Resolve", "ArchiveSignature", "GetArchive")
+	s.api.CheckCall(c, 0, "NewAPI", csclient.ServerURL, csparams.EdgeChannel)
+
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	id, channel, series, err := mirror.WithChannel(csparams.EdgeChannel).ResolveWithChannel(charm.MustParseURL("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id.String(), gc.Equals, "cs:wordpress-5")
+	c.Check(channel, gc.Equals, csparams.EdgeChannel)
+	c.Check(series, jc.DeepEquals, []string{"xenial"})
+	ch, err := mirror.Get(s.api.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Meta().Name, gc.Equals, "wordpress")
+	resources, err := mirror.ListResources(s.api.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resources, gc.HasLen, 0)
+}
+
+func (s *DownloadSuite) TestDownloadWithResources(c *gc.C) {
+	ctx, err := s.run(c, "--with-resources", "--charmstore-url", "https://cs.example.com", "cs:~bob/wordpress", s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+WARNING skipping oci-image resource "image": only file resources can be mirrored
+Downloaded cs:~bob/wordpress-2 to `[1:]+s.dir+`
+Downloaded resource "data" revision 3
+`)
+	s.api.CheckCallNames(c, "NewAPI", "Resolve", "ListResources", "GetResource", "ArchiveSignature", "GetArchive")
+	s.api.CheckCall(c, 0, "NewAPI", "https://cs.example.com", csparams.StableChannel)
+	s.api.CheckCall(c, 3, "GetResource", s.api.id, "data", 3)
+
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err := mirror.ListResources(s.api.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Check(resources[0].Name, gc.Equals, "data")
+	c.Check(resources[0].Revision, gc.Equals, 3)
+	data, err := mirror.GetResource(s.api.id, "data", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "some data")
+}
+
+func (s *DownloadSuite) TestDownloadRecordsDigestAndSignature(c *gc.C) {
+	data, err := ioutil.ReadFile(s.api.archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	sum := sha512.Sum384(data)
+	s.api.sha384 = hex.EncodeToString(sum[:])
+	s.api.signature = "signature"
+
+	_, err = s.run(c, "cs:~bob/wordpress", s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mirror, err := charmstore.OpenMirror(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	sha384, err := mirror.ArchiveSHA384(s.api.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sha384, gc.Equals, s.api.sha384)
+	signature, err := mirror.ArchiveSignature(s.api.id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(signature), gc.Equals, "signature")
+}
+
+func (s *DownloadSuite) TestDownloadDigestMismatch(c *gc.C) {
+	s.api.sha384 = "0123"
+	_, err := s.run(c, "cs:~bob/wordpress", s.dir)
+	c.Assert(err, gc.ErrorMatches, `archive for cs:~bob/wordpress-2 has SHA384 [0-9a-f]+, expected 0123`)
+}
+
+func (s *DownloadSuite) TestResolveError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("cs:nope"))
+	_, err := s.run(c, "nope", s.dir)
+	c.Assert(err, gc.ErrorMatches, "cs:nope not found")
+	_, err = os.Stat(filepath.Join(s.dir, charmstore.MirrorIndexFile))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+type fakeCharmStoreAPI struct {
+	*testing.Stub
+	archivePath   string
+	id            *charm.URL
+	promulgatedID *charm.URL
+	resources     []csparams.Resource
+	data          string
+	sha384        string
+	signature     string
+}
+
+func (f *fakeCharmStoreAPI) Resolve(ref *charm.URL) (*charm.URL, *charm.URL, []string, error) {
+	f.MethodCall(f, "Resolve", ref)
+	if err := f.NextErr(); err != nil {
+		return nil, nil, nil, err
+	}
+	return f.id, f.promulgatedID, []string{"xenial"}, nil
+}
+
+func (f *fakeCharmStoreAPI) GetArchive(id *charm.URL) (io.ReadCloser, string, error) {
+	f.MethodCall(f, "GetArchive", id)
+	if err := f.NextErr(); err != nil {
+		return nil, "", err
+	}
+	r, err := os.Open(f.archivePath)
+	return r, f.sha384, err
+}
+
+func (f *fakeCharmStoreAPI) ArchiveSignature(id *charm.URL) ([]byte, error) {
+	f.MethodCall(f, "ArchiveSignature", id)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	if f.signature == "" {
+		return nil, errors.NotFoundf("signature for %v", id)
+	}
+	return []byte(f.signature), nil
+}
+
+func (f *fakeCharmStoreAPI) ListResources(id *charm.URL) ([]csparams.Resource, error) {
+	f.MethodCall(f, "ListResources", id)
+	return f.resources, f.NextErr()
+}
+
+func (f *fakeCharmStoreAPI) GetResource(id *charm.URL, name string, revision int) (io.ReadCloser, error) {
+	f.MethodCall(f, "GetResource", id, name, revision)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader(f.data)), nil
+}
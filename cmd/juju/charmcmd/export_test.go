@@ -0,0 +1,15 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd
+
+import (
+	"github.com/juju/cmd"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+)
+
+// NewDownloadCommandForTest returns a download-charm command that
+// uses the given function to get its charm store client.
+func NewDownloadCommandForTest(newAPI func(string, csparams.Channel) CharmStoreAPI) cmd.Command {
+	return &downloadCommand{newAPI: newAPI}
+}
@@ -168,7 +168,7 @@ type AddCloudCommand struct {
 // NewAddCloudCommand returns a command to add cloud information.
 func NewAddCloudCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
 	cloudCallCtx := context.NewCloudCallContext()
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	c := &AddCloudCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/interact"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -30,7 +31,10 @@ The juju add-credential command operates in two modes.
 
 When called with only the <cloud name> argument, ` + "`juju add-credential`" + ` will 
 take you through an interactive prompt to add a credential specific to 
-the cloud provider.
+the cloud provider. Each value is checked as it is entered. Values such 
+as keys may be read from a file, or pasted over several lines, ending 
+with an empty line. If the cloud supports it, you will be offered to 
+check the credential with the cloud before it is saved.
 
 Providing the ` + "`-f <credentials.yaml>` " + `option switches to the 
 non-interactive mode. <credentials.yaml> must be a path to a correctly 
@@ -127,7 +131,7 @@ type addCredentialCommand struct {
 // NewAddCredentialCommand returns a command to add credential information.
 func NewAddCredentialCommand() cmd.Command {
 	return &addCredentialCommand{
-		store:           jujuclient.NewFileCredentialStore(),
+		store:           jujuclient.NewCredentialStore(),
 		cloudByNameFunc: jujucloud.CloudByName,
 	}
 }
@@ -330,6 +334,15 @@ func (c *addCredentialCommand) interactiveAddCredential(ctxt *cmd.Context, schem
 		return errors.Trace(err)
 	}
 
+	save, err := c.verifyCredential(ctxt, pollster, *newCredential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !save {
+		fmt.Fprintf(ctxt.Stdout, "Credential %q not %v.\n", credentialName, verb)
+		return nil
+	}
+
 	existingCredentials.AuthCredentials[credentialName] = *newCredential
 	err = c.store.UpdateCredential(c.CloudName, *existingCredentials)
 	if err != nil {
@@ -373,6 +386,51 @@ func (c *addCredentialCommand) finalizeProvider(ctxt *cmd.Context, authType juju
 	return newCredential, errors.Annotate(err, "finalizing credential")
 }
 
+// verifyCredential offers to check the credential with the cloud, if the
+// cloud's provider supports it, and reports whether the credential should
+// be saved. The user may choose to save a credential which the cloud
+// rejects, for example when the cloud cannot be reached from this client.
+func (c *addCredentialCommand) verifyCredential(ctxt *cmd.Context, p *interact.Pollster, credential jujucloud.Credential) (bool, error) {
+	provider, err := environs.Provider(c.cloud.Type)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	verifier, ok := provider.(environs.CredentialVerifier)
+	if !ok {
+		return true, nil
+	}
+	verify, err := p.YN("Verify the credential with the cloud", true)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !verify {
+		return true, nil
+	}
+
+	// As when finalizing the credential, any region will do.
+	var region string
+	if len(c.cloud.Regions) > 0 {
+		region = c.cloud.Regions[0].Name
+	}
+	spec, err := environs.MakeCloudSpec(*c.cloud, region, &credential)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	// There is no model whose credential could be invalidated here,
+	// so a rejected credential is just reported to the user.
+	callCtx := context.NewCloudCallContext()
+	callCtx.InvalidateCredentialFunc = func(string) error {
+		return nil
+	}
+	if err := verifier.VerifyCredential(callCtx, spec); err != nil {
+		fmt.Fprintf(ctxt.Stdout, "Credential not accepted by cloud %q: %v\n", c.CloudName, err)
+		return p.YN("Save the credential anyway", false)
+	}
+	fmt.Fprintf(ctxt.Stdout, "Credential accepted by cloud %q.\n", c.CloudName)
+	return true, nil
+}
+
 func (c *addCredentialCommand) promptCredentialName(p *interact.Pollster, out io.Writer) (string, error) {
 	credentialName, err := p.EnterVerify("credential name", func(value string) (ok bool, errmsg string, err error) {
 		if !names.IsValidCloudCredentialName(value) {
@@ -416,11 +474,9 @@ func (c *addCredentialCommand) promptAuthType(p *interact.Pollster, authTypes []
 }
 
 func (c *addCredentialCommand) promptCredentialAttributes(p *interact.Pollster, authType jujucloud.AuthType, schema jujucloud.CredentialSchema) (attributes map[string]string, err error) {
-	// Interactive add does not support adding multi-line values, which
-	// is what we typically get when the attribute can come from a file.
-	// For now we'll skip, and just get the user to enter the file path.
-	// TODO(wallyworld) - add support for multi-line entry
-
+	// Attributes which can come from a file are usually multi-line
+	// values. Unless the schema says the value may be entered over
+	// several lines, we just get the user to enter the file path.
 	attrs := make(map[string]string)
 	for _, attr := range schema {
 		currentAttr := attr
@@ -432,6 +488,21 @@ func (c *addCredentialCommand) promptCredentialAttributes(p *interact.Pollster,
 			if err != nil {
 				return nil, err
 			}
+		} else if currentAttr.MultiLine {
+			var path string
+			path, err = enterFileOrValue(currentAttr, p)
+			if err != nil {
+				return nil, err
+			}
+			if path != "" {
+				currentAttr.Name = currentAttr.FileAttr
+				value = path
+			} else {
+				value, err = enterMultiLine(p, currentAttr)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
 		} else {
 			currentAttr.Name = currentAttr.FileAttr
 			currentAttr.Hidden = false
@@ -465,8 +536,11 @@ func (c *addCredentialCommand) promptFieldValue(p *interact.Pollster, attr jujuc
 	}
 
 	// We assume that Hidden, ExpandFilePath and FilePath are mutually
-	// exclusive here.
+	// exclusive here. Hidden multi-line values are entered without
+	// echoing them.
 	switch {
+	case attr.MultiLine:
+		return enterMultiLine(p, attr)
 	case attr.Hidden:
 		return p.EnterPassword(name)
 	case attr.ExpandFilePath:
@@ -476,8 +550,54 @@ func (c *addCredentialCommand) promptFieldValue(p *interact.Pollster, attr jujuc
 	case attr.Optional:
 		return p.EnterOptional(name)
 	default:
-		return p.Enter(name)
+		return p.EnterVerify(name, verifyAttrValue(attr.CredentialAttr))
+	}
+}
+
+// enterMultiLine asks for the value of a multi-line attribute,
+// without echoing it if the attribute is hidden.
+func enterMultiLine(p *interact.Pollster, attr jujucloud.NamedCredentialAttr) (string, error) {
+	verify := verifyAttrValue(attr.CredentialAttr)
+	if attr.Hidden {
+		return p.EnterMultiLinePassword(attr.Name, verify)
+	}
+	return p.EnterMultiLine(attr.Name, verify)
+}
+
+// verifyAttrValue returns a function verifying values entered for the
+// given attribute. Empty values for mandatory attributes are rejected
+// without a message, so the user is simply asked again.
+func verifyAttrValue(attr jujucloud.CredentialAttr) interact.VerifyFunc {
+	return func(value string) (ok bool, msg string, err error) {
+		if value == "" && !attr.Optional {
+			return false, "", nil
+		}
+		if err := attr.ValidateValue(value); err != nil {
+			return false, err.Error(), nil
+		}
+		return true, "", nil
+	}
+}
+
+// enterFileOrValue asks for the path of the file holding the value of
+// an attribute which may also be entered directly. An empty path means
+// the value will be entered directly.
+func enterFileOrValue(attr jujucloud.NamedCredentialAttr, p *interact.Pollster) (string, error) {
+	question := fmt.Sprintf("%s (leave empty to enter %s directly)", attr.FileAttr, attr.Name)
+	input, err := p.EnterVerify(question, func(s string) (ok bool, msg string, err error) {
+		if s == "" {
+			return true, "", nil
+		}
+		if _, err := jujucloud.ValidateFileAttrValue(s); err != nil {
+			return false, err.Error(), nil
+		}
+		return true, "", nil
+	})
+	if err != nil || input == "" {
+		return "", errors.Trace(err)
 	}
+	abs, err := jujucloud.ValidateFileAttrValue(input)
+	return abs, errors.Trace(err)
 }
 
 func enterFile(name, descr string, p *interact.Pollster, expanded, optional bool) (string, error) {
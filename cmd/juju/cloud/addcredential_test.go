@@ -21,6 +21,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	environsTesting "github.com/juju/juju/environs/testing"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/all"
@@ -34,6 +35,7 @@ type addCredentialSuite struct {
 	schema          map[jujucloud.AuthType]jujucloud.CredentialSchema
 	authTypes       []jujucloud.AuthType
 	cloudByNameFunc func(string) (*jujucloud.Cloud, error)
+	verifier        *mockVerifyingProvider
 }
 
 var _ = gc.Suite(&addCredentialSuite{
@@ -46,12 +48,23 @@ func (s *addCredentialSuite) SetUpSuite(c *gc.C) {
 	s.AddCleanup(func(_ *gc.C) {
 		unreg()
 	})
+	s.verifier = &mockVerifyingProvider{mockProvider: &mockProvider{credSchemas: &s.schema}}
+	unregVerifier := environs.RegisterProvider("mock-addcredential-verifying-provider", s.verifier)
+	s.AddCleanup(func(_ *gc.C) {
+		unregVerifier()
+	})
 	s.cloudByNameFunc = func(cloud string) (*jujucloud.Cloud, error) {
-		if cloud != "somecloud" && cloud != "anothercloud" {
+		providerType := "mock-addcredential-provider"
+		switch cloud {
+		case "somecloud", "anothercloud":
+		case "verifyingcloud":
+			providerType = "mock-addcredential-verifying-provider"
+		default:
 			return nil, errors.NotFoundf("cloud %v", cloud)
 		}
 		return &jujucloud.Cloud{
-			Type:             "mock-addcredential-provider",
+			Name:             cloud,
+			Type:             providerType,
 			AuthTypes:        s.authTypes,
 			Endpoint:         "cloud-endpoint",
 			IdentityEndpoint: "cloud-identity-endpoint",
@@ -62,6 +75,8 @@ func (s *addCredentialSuite) SetUpSuite(c *gc.C) {
 func (s *addCredentialSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.store.Credentials = make(map[string]jujucloud.CloudCredential)
+	s.verifier.verifyErr = nil
+	s.verifier.specs = nil
 }
 
 func (s *addCredentialSuite) run(c *gc.C, stdin io.Reader, args ...string) (*cmd.Context, error) {
@@ -571,6 +586,196 @@ Credential "blah" added locally for cloud "somecloud".
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *addCredentialSuite) TestAddCredentialMultiLine(c *gc.C) {
+	s.authTypes = []jujucloud.AuthType{jujucloud.UserPassAuthType}
+	s.schema = map[jujucloud.AuthType]jujucloud.CredentialSchema{
+		jujucloud.UserPassAuthType: {
+			{
+				"key", jujucloud.CredentialAttr{Hidden: true, MultiLine: true},
+			},
+		},
+	}
+	// An empty value is rejected before the key is entered.
+	stdin := strings.NewReader("fred\n\n-----BEGIN KEY-----\nabc\n-----END KEY-----\n\n")
+	ctx, err := s.run(c, stdin, "somecloud")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter key (end with an empty line):
+
+Enter key (end with an empty line):
+
+Credential "fred" added locally for cloud "somecloud".
+
+`[1:])
+	c.Assert(s.store.Credentials, jc.DeepEquals, map[string]jujucloud.CloudCredential{
+		"somecloud": {
+			AuthCredentials: map[string]jujucloud.Credential{
+				"fred": jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+					"key": "-----BEGIN KEY-----\nabc\n-----END KEY-----\n",
+				}),
+			},
+		},
+	})
+}
+
+func (s *addCredentialSuite) setMultiLineFileAttrSchema() {
+	s.authTypes = []jujucloud.AuthType{jujucloud.UserPassAuthType}
+	s.schema = map[jujucloud.AuthType]jujucloud.CredentialSchema{
+		jujucloud.UserPassAuthType: {
+			{
+				"key",
+				jujucloud.CredentialAttr{
+					FileAttr:  "key-file",
+					MultiLine: true,
+				},
+			},
+		},
+	}
+}
+
+func (s *addCredentialSuite) TestAddCredentialMultiLineWithFileAttrFromFile(c *gc.C) {
+	s.setMultiLineFileAttrSchema()
+	// Input includes invalid file info.
+	s.assertAddFileCredential(c, "fred\nbadfile\n.\n%s\n", "key-file")
+}
+
+func (s *addCredentialSuite) TestAddCredentialMultiLineWithFileAttrEntered(c *gc.C) {
+	s.setMultiLineFileAttrSchema()
+	stdin := strings.NewReader("fred\n\nline one\nline two\n\n")
+	ctx, err := s.run(c, stdin, "somecloud")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter key-file (leave empty to enter key directly): 
+Enter key (end with an empty line):
+
+Credential "fred" added locally for cloud "somecloud".
+
+`[1:])
+	c.Assert(s.store.Credentials, jc.DeepEquals, map[string]jujucloud.CloudCredential{
+		"somecloud": {
+			AuthCredentials: map[string]jujucloud.Credential{
+				"fred": jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+					"key": "line one\nline two\n",
+				}),
+			},
+		},
+	})
+}
+
+func (s *addCredentialSuite) TestAddCredentialRejectsInvalidFilePath(c *gc.C) {
+	s.authTypes = []jujucloud.AuthType{jujucloud.JSONFileAuthType}
+	s.schema = map[jujucloud.AuthType]jujucloud.CredentialSchema{
+		jujucloud.JSONFileAuthType: {
+			{
+				"file", jujucloud.CredentialAttr{Description: "path", FilePath: true},
+			},
+		},
+	}
+	dir := c.MkDir()
+	stdin := strings.NewReader(fmt.Sprintf("fred\n%s\n", dir))
+	ctx, err := s.run(c, stdin, "somecloud")
+	c.Assert(err, gc.ErrorMatches, ".*EOF")
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "file path must be a file: "+dir)
+}
+
+func (s *addCredentialSuite) assertAddVerifiedCredential(c *gc.C, input, expectedOutput string, saved bool) {
+	s.authTypes = []jujucloud.AuthType{jujucloud.UserPassAuthType}
+	s.schema = map[jujucloud.AuthType]jujucloud.CredentialSchema{
+		jujucloud.UserPassAuthType: {
+			{
+				"username", jujucloud.CredentialAttr{},
+			},
+		},
+	}
+	stdin := strings.NewReader(input)
+	ctx, err := s.run(c, stdin, "verifyingcloud")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, expectedOutput)
+
+	cred := jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+		"username": "user",
+	})
+	if saved {
+		c.Assert(s.store.Credentials, jc.DeepEquals, map[string]jujucloud.CloudCredential{
+			"verifyingcloud": {
+				AuthCredentials: map[string]jujucloud.Credential{
+					"fred": cred,
+				},
+			},
+		})
+	} else {
+		c.Assert(s.store.Credentials, gc.HasLen, 0)
+	}
+}
+
+func (s *addCredentialSuite) TestAddCredentialVerified(c *gc.C) {
+	s.assertAddVerifiedCredential(c, "fred\nuser\n\n", `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter username: 
+Verify the credential with the cloud? (Y/n): 
+Credential accepted by cloud "verifyingcloud".
+Credential "fred" added locally for cloud "verifyingcloud".
+
+`[1:], true)
+
+	c.Assert(s.verifier.specs, gc.HasLen, 1)
+	c.Assert(s.verifier.specs[0].Name, gc.Equals, "verifyingcloud")
+	c.Assert(s.verifier.specs[0].Endpoint, gc.Equals, "cloud-endpoint")
+	c.Assert(s.verifier.specs[0].Credential.Attributes(), jc.DeepEquals, map[string]string{
+		"username": "user",
+	})
+}
+
+func (s *addCredentialSuite) TestAddCredentialVerifySkipped(c *gc.C) {
+	s.assertAddVerifiedCredential(c, "fred\nuser\nn\n", `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter username: 
+Verify the credential with the cloud? (Y/n): 
+Credential "fred" added locally for cloud "verifyingcloud".
+
+`[1:], true)
+	c.Assert(s.verifier.specs, gc.HasLen, 0)
+}
+
+func (s *addCredentialSuite) TestAddCredentialVerifyFailedSaveAnyway(c *gc.C) {
+	s.verifier.verifyErr = errors.New("access denied")
+	s.assertAddVerifiedCredential(c, "fred\nuser\ny\ny\n", `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter username: 
+Verify the credential with the cloud? (Y/n): 
+Credential not accepted by cloud "verifyingcloud": access denied
+Save the credential anyway? (y/N): 
+Credential "fred" added locally for cloud "verifyingcloud".
+
+`[1:], true)
+}
+
+func (s *addCredentialSuite) TestAddCredentialVerifyFailedNotSaved(c *gc.C) {
+	s.verifier.verifyErr = errors.New("access denied")
+	s.assertAddVerifiedCredential(c, "fred\nuser\n\n\n", `
+Enter credential name: 
+Using auth-type "userpass".
+
+Enter username: 
+Verify the credential with the cloud? (Y/n): 
+Credential not accepted by cloud "verifyingcloud": access denied
+Save the credential anyway? (y/N): 
+Credential "fred" not added.
+`[1:], false)
+}
+
 func (s *addCredentialSuite) TestShouldFinalizeCredentialWithEnvironProvider(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -618,3 +823,14 @@ func (s *addCredentialSuite) TestShouldFinalizeCredentialFailure(c *gc.C) {
 	got := cloud.ShouldFinalizeCredential(provider, cred)
 	c.Assert(got, jc.IsFalse)
 }
+
+type mockVerifyingProvider struct {
+	*mockProvider
+	verifyErr error
+	specs     []environs.CloudSpec
+}
+
+func (p *mockVerifyingProvider) VerifyCredential(ctx context.ProviderCallContext, spec environs.CloudSpec) error {
+	p.specs = append(p.specs, spec)
+	return p.verifyErr
+}
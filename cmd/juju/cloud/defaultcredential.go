@@ -47,7 +47,7 @@ type setDefaultCredentialCommand struct {
 // NewSetDefaultCredentialCommand returns a command to set the default credential for a cloud.
 func NewSetDefaultCredentialCommand() cmd.Command {
 	return &setDefaultCredentialCommand{
-		store: jujuclient.NewFileCredentialStore(),
+		store: jujuclient.NewCredentialStore(),
 	}
 }
 
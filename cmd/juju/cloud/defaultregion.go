@@ -39,7 +39,7 @@ See also:
 // NewSetDefaultRegionCommand returns a command to set the default region for a cloud.
 func NewSetDefaultRegionCommand() cmd.Command {
 	return &setDefaultRegionCommand{
-		store: jujuclient.NewFileCredentialStore(),
+		store: jujuclient.NewCredentialStore(),
 	}
 }
 
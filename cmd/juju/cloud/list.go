@@ -100,7 +100,7 @@ type ListCloudsAPI interface {
 
 // NewListCloudsCommand returns a command to list cloud information.
 func NewListCloudsCommand() cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	c := &listCloudsCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
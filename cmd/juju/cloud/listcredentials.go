@@ -115,7 +115,7 @@ type credentialsMap struct {
 // NewListCredentialsCommand returns a command to list cloud credentials.
 func NewListCredentialsCommand() cmd.Command {
 	return &listCredentialsCommand{
-		store:           jujuclient.NewFileCredentialStore(),
+		store:           jujuclient.NewCredentialStore(),
 		cloudByNameFunc: jujucloud.CloudByName,
 	}
 }
@@ -53,7 +53,7 @@ type removeCloudAPI interface {
 
 // NewRemoveCloudCommand returns a command to remove cloud information.
 func NewRemoveCloudCommand() cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	c := &removeCloudCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
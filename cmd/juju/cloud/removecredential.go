@@ -39,7 +39,7 @@ See also:
 // NewremoveCredentialCommand returns a command to remove a named credential for a cloud.
 func NewRemoveCredentialCommand() cmd.Command {
 	return &removeCredentialCommand{
-		store: jujuclient.NewFileCredentialStore(),
+		store: jujuclient.NewCredentialStore(),
 	}
 }
 
@@ -67,7 +67,7 @@ type showCloudAPI interface {
 
 // NewShowCloudCommand returns a command to list cloud information.
 func NewShowCloudCommand() cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	c := &showCloudCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
@@ -33,7 +33,7 @@ type showCredentialCommand struct {
 // credentials stored on the controller.
 func NewShowCredentialCommand() cmd.Command {
 	cmd := &showCredentialCommand{
-		store: jujuclient.NewFileClientStore(),
+		store: jujuclient.NewClientStore(),
 	}
 	cmd.newAPIFunc = func() (CredentialContentAPI, error) {
 		return cmd.NewCredentialAPI()
@@ -75,7 +75,7 @@ var NewUpdateCloudCommand = func(cloudMetadataStore CloudMetadataStore) cmd.Comm
 }
 
 func newUpdateCloudCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
-	store := jujuclient.NewFileClientStore()
+	store := jujuclient.NewClientStore()
 	c := &updateCloudCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{
 			Store:       store,
@@ -677,9 +677,12 @@ See `[1:] + "`juju kill-controller`" + `.`)
 				// Set resultErr to cmd.ErrSilent to prevent
 				// logging the error twice.
 				resultErr = cmd.ErrSilent
+				// Clean up even if bootstrap was interrupted.
+				destroyCallCtx := *cloudCallCtx
+				destroyCallCtx.DyingFunc = nil
 				handleBootstrapError(ctx, func() error {
 					return environsDestroy(
-						c.controllerName, environ, cloudCallCtx, store,
+						c.controllerName, environ, &destroyCallCtx, store,
 					)
 				})
 			}
@@ -687,14 +690,23 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	}()
 
 	// Block interruption during bootstrap. Providers may also
-	// register for interrupt notification so they can exit early.
+	// register for interrupt notification so they can exit early,
+	// and stop waiting for cloud operations once the call context
+	// is dying.
 	interrupted := make(chan os.Signal, 1)
 	defer close(interrupted)
 	ctx.InterruptNotify(interrupted)
 	defer ctx.StopInterruptNotify(interrupted)
+	dying := make(chan struct{})
+	cloudCallCtx.DyingFunc = func() <-chan struct{} { return dying }
 	go func() {
 		for range interrupted {
 			ctx.Infof("Interrupt signalled: waiting for bootstrap to exit")
+			select {
+			case <-dying:
+			default:
+				close(dying)
+			}
 		}
 	}()
 
@@ -52,13 +52,30 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by log
+message, using regular expressions that are matched by the controller.
+
+The '--since' and '--until' options select the messages logged within a
+window of time. Each takes a time such as "2019-06-01 12:30" or
+"2019-06-01T12:30:00Z", or a duration such as "2h" meaning that long ago.
+Times without a time zone are in local time, or UTC if --utc is given.
+The '--since' option shows all matching messages from that time onwards,
+as --replay does from the start of the log. The '--until' option stops
+after returning existing messages unless --tail is also given.
+
+The '--limit-bytes' option stops once the messages shown would exceed
+the given number of bytes.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message and --exclude-message selections are logically ANDed
+  to form the complete filter.
 
 Examples:
 
@@ -101,6 +118,16 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show the messages mentioning timeouts that were logged during a half
+hour window, and stop after at most 10MB of messages:
+
+    juju debug-log --since "2019-06-01 12:00" --until "2019-06-01 12:30" \
+        --include-message "timed? out" --limit-bytes 10485760
+
+Show all messages logged in the last 2 hours:
+
+    juju debug-log --since 2h --no-tail
+
 See also:
     status
     ssh`
@@ -127,6 +154,8 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
 	params common.DebugLogParams
 
 	utc      bool
@@ -150,6 +179,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -158,6 +189,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.since, "since", "", "Show log messages logged at or after this time, or this long ago")
+	f.StringVar(&c.until, "until", "", "Show log messages logged before this time, or this long ago")
+	f.Uint64Var(&c.params.LimitBytes, "limit-bytes", 0, "Exit before the log messages shown exceed this many bytes")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -192,6 +226,9 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.ms {
 		c.format = c.format + ".000"
 	}
+	if err := c.initTimeRange(time.Now()); err != nil {
+		return errors.Trace(err)
+	}
 	modelType, err := c.ModelType()
 	if err != nil {
 		return errors.Trace(err)
@@ -202,6 +239,66 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// initTimeRange sets the time window of the log messages
+// to show from the --since and --until options.
+func (c *debugLogCommand) initTimeRange(now time.Time) error {
+	tz := c.tz
+	if tz == nil {
+		tz = time.Local
+	}
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now, tz)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now, tz)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		if !c.params.StartTime.IsZero() && !until.After(c.params.StartTime) {
+			return errors.New("--until must be later than --since")
+		}
+		c.params.EndTime = until
+		if !c.tail {
+			c.notail = true
+		}
+	}
+	return nil
+}
+
+// logTimeLayouts holds the layouts accepted for the
+// --since and --until options, in addition to RFC3339.
+var logTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseLogTime parses a time given as an RFC3339 timestamp, as a local
+// date and time in the given location, or as a duration before now.
+func parseLogTime(value string, now time.Time, tz *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range logTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, tz); err == nil {
+			return t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, errors.Errorf(
+		"%q is not a time (eg %q) or a duration (eg %q)", value, "2019-06-01 12:30", "2h",
+	)
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
@@ -104,6 +104,40 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--include-message", "timed? out", "--exclude-message", "retrying"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"timed? out"},
+				ExcludeMessage: []string{"retrying"},
+				Backlog:        10,
+			},
+		}, {
+			args: []string{"--limit-bytes", "1048576"},
+			expected: common.DebugLogParams{
+				Backlog:    10,
+				LimitBytes: 1048576,
+			},
+		}, {
+			args: []string{"--since", "2019-06-01T12:00:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--utc", "--since", "2019-06-01 12:00", "--until", "2019-06-01 12:30:15"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2019, 6, 1, 12, 30, 15, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "2019-06-01T13:00:00Z", "--until", "2019-06-01T12:00:00Z"},
+			errMatch: `--until must be later than --since`,
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is not a time \(eg "2019-06-01 12:30"\) or a duration \(eg "2h"\)`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -119,6 +153,49 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestParseLogTime(c *gc.C) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	tz := time.FixedZone("test", 2*60*60)
+	for i, test := range []struct {
+		value    string
+		expected time.Time
+	}{{
+		value:    "2019-06-01T09:30:00+01:00",
+		expected: time.Date(2019, 6, 1, 8, 30, 0, 0, time.UTC),
+	}, {
+		value:    "2019-06-01 09:30",
+		expected: time.Date(2019, 6, 1, 7, 30, 0, 0, time.UTC),
+	}, {
+		value:    "2019-05-31",
+		expected: time.Date(2019, 5, 30, 22, 0, 0, 0, time.UTC),
+	}, {
+		value:    "90m",
+		expected: time.Date(2019, 6, 1, 10, 30, 0, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %s", i, test.value)
+		t, err := parseLogTime(test.value, now, tz)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(t.Equal(test.expected), jc.IsTrue, gc.Commentf("got %v", t))
+	}
+
+	_, err := parseLogTime("-1h", now, tz)
+	c.Assert(err, gc.ErrorMatches, `"-1h" is not a time .*`)
+}
+
+func (s *DebugLogSuite) TestUntilImpliesNoTail(c *gc.C) {
+	command := &debugLogCommand{}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--until", "2h"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.notail, jc.IsTrue)
+
+	command = &debugLogCommand{}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err = cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--until", "2h", "--tail"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.notail, jc.IsFalse)
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -0,0 +1,151 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/helptopics"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// remoteHelpFlag is the flag that asks "juju help" to show
+// the help topics served by the controller.
+const remoteHelpFlag = "--remote"
+
+// remoteHelpArgs reports whether args ask for help topics from the
+// controller, as in "juju help <topic> --remote", and if so returns
+// the arguments for the remote help command. The help command is
+// provided by the cmd package, so these requests are handled before
+// it is reached.
+func remoteHelpArgs(args []string) ([]string, bool) {
+	if len(args) == 0 || args[0] != "help" {
+		return nil, false
+	}
+	remote := false
+	rest := []string{}
+	for _, arg := range args[1:] {
+		if arg == remoteHelpFlag {
+			remote = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, remote
+}
+
+// remoteHelpAPI defines the API methods used by the remote help command.
+type remoteHelpAPI interface {
+	Close() error
+	ListTopics() (params.HelpTopicList, error)
+	Topic(name string) (params.HelpTopic, string, error)
+}
+
+func newRemoteHelpCommand() modelcmd.ControllerCommand {
+	return modelcmd.WrapController(&remoteHelpCommand{})
+}
+
+// remoteHelpCommand shows the help topics served by the controller.
+type remoteHelpCommand struct {
+	modelcmd.ControllerCommandBase
+
+	newAPIFunc func() (remoteHelpAPI, error)
+	topic      string
+}
+
+const remoteHelpDoc = `
+Help topics served by the controller document the features of the
+controller's version of Juju, which may be older or newer than this
+client. With no topic, the topics served by the controller are listed.
+
+Examples:
+
+    juju help --remote
+    juju help constraints --remote
+    juju help model-config --remote -c prod
+
+See also:
+    help
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *remoteHelpCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "help",
+		Args:    "[<topic>] " + remoteHelpFlag,
+		Purpose: "Show help topics served by the controller.",
+		Doc:     remoteHelpDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *remoteHelpCommand) Init(args []string) (err error) {
+	c.topic, err = cmd.ZeroOrOneArgs(args)
+	return err
+}
+
+func (c *remoteHelpCommand) getAPI() (remoteHelpAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return helptopics.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *remoteHelpCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if c.topic == "" {
+		return c.listTopics(ctx, api, controllerName)
+	}
+	topic, version, err := api.Topic(c.topic)
+	switch {
+	case errors.IsNotSupported(err):
+		return errors.Errorf("controller %q does not serve help topics", controllerName)
+	case params.IsCodeNotFound(err):
+		return errors.Errorf(
+			"help topic %q not found on controller %q, see \"juju help %s\" for the available topics",
+			c.topic, controllerName, remoteHelpFlag,
+		)
+	case err != nil:
+		return errors.Trace(err)
+	}
+	ctx.Infof("Help for %q from controller %q (version %s):\n", topic.Name, controllerName, version)
+	fmt.Fprint(ctx.Stdout, topic.Doc)
+	return nil
+}
+
+func (c *remoteHelpCommand) listTopics(ctx *cmd.Context, api remoteHelpAPI, controllerName string) error {
+	list, err := api.ListTopics()
+	if errors.IsNotSupported(err) {
+		return errors.Errorf("controller %q does not serve help topics", controllerName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Help topics from controller %q (version %s):\n", controllerName, list.Version)
+	tw := output.TabWriter(ctx.Stdout)
+	for _, topic := range list.Topics {
+		fmt.Fprintf(tw, "%s\t%s\n", topic.Name, topic.Short)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type RemoteHelpSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeRemoteHelpAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RemoteHelpSuite{})
+
+func (s *RemoteHelpSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.api = &fakeRemoteHelpAPI{
+		list: params.HelpTopicList{
+			Version: "2.6.1",
+			Topics: []params.HelpTopic{
+				{Name: "constraints", Short: "Constraints supported by the controller"},
+				{Name: "placement", Short: "Placement directives supported by the controller"},
+			},
+		},
+		topic: params.HelpTopic{
+			Name:  "constraints",
+			Short: "Constraints supported by the controller",
+			Doc:   "arch\n    The machine architecture.\n",
+		},
+	}
+}
+
+func (s *RemoteHelpSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := newRemoteHelpCommand()
+	command.SetClientStore(s.store)
+	inner := modelcmd.InnerCommand(command).(*remoteHelpCommand)
+	inner.newAPIFunc = func() (remoteHelpAPI, error) {
+		return s.api, nil
+	}
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *RemoteHelpSuite) TestListTopics(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Help topics from controller \"fake\" (version 2.6.1):\n")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+constraints  Constraints supported by the controller
+placement    Placement directives supported by the controller
+`[1:])
+	s.api.CheckCallNames(c, "ListTopics", "Close")
+}
+
+func (s *RemoteHelpSuite) TestTopic(c *gc.C) {
+	ctx, err := s.run(c, "constraints")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Help for \"constraints\" from controller \"fake\" (version 2.6.1):\n")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "arch\n    The machine architecture.\n")
+	s.api.CheckCall(c, 0, "Topic", "constraints")
+}
+
+func (s *RemoteHelpSuite) TestTopicNotFound(c *gc.C) {
+	s.api.SetErrors(common.ServerError(errors.NotFoundf("help topic %q", "bundles")))
+	_, err := s.run(c, "bundles")
+	c.Assert(err, gc.ErrorMatches, `help topic "bundles" not found on controller "fake", see "juju help --remote" for the available topics`)
+}
+
+func (s *RemoteHelpSuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("help topics on this controller"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `controller "fake" does not serve help topics`)
+}
+
+func (s *RemoteHelpSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "constraints", "placement")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["placement"\]`)
+}
+
+func (s *RemoteHelpSuite) TestRemoteHelpArgs(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect []string
+		remote bool
+	}{{
+		args: []string{"help", "constraints"},
+	}, {
+		args: []string{"status", "--remote"},
+	}, {
+		args:   []string{"help", "--remote"},
+		expect: []string{},
+		remote: true,
+	}, {
+		args:   []string{"help", "--remote", "constraints", "-c", "prod"},
+		expect: []string{"constraints", "-c", "prod"},
+		remote: true,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		args, remote := remoteHelpArgs(test.args)
+		c.Check(remote, gc.Equals, test.remote)
+		if test.remote {
+			c.Check(args, jc.DeepEquals, test.expect)
+		}
+	}
+}
+
+type fakeRemoteHelpAPI struct {
+	jutesting.Stub
+	list  params.HelpTopicList
+	topic params.HelpTopic
+}
+
+func (f *fakeRemoteHelpAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeRemoteHelpAPI) ListTopics() (params.HelpTopicList, error) {
+	f.MethodCall(f, "ListTopics")
+	return f.list, f.NextErr()
+}
+
+func (f *fakeRemoteHelpAPI) Topic(name string) (params.HelpTopic, string, error) {
+	f.MethodCall(f, "Topic", name)
+	return f.topic, f.list.Version, f.NextErr()
+}
@@ -57,6 +57,8 @@ Currently available charm hook tools are:
     is-leader                print application leadership status
     juju-log                 write a message to the juju log
     juju-reboot              Reboot the host machine
+    label-get                print the labels of the unit
+    label-set                change the labels of the unit
     leader-get               print application leadership settings
     leader-set               write application leadership settings
     network-get              get network config
@@ -97,6 +99,8 @@ var expectedCommands = []string{
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
+	"label-get",
+	"label-set",
 	"leader-get",
 	"leader-set",
 	"network-get",
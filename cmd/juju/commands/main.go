@@ -139,6 +139,10 @@ func (m main) Run(args []string) int {
 		}
 	}
 
+	if helpArgs, ok := remoteHelpArgs(args[1:]); ok {
+		return cmd.Main(newRemoteHelpCommand(), ctx, helpArgs)
+	}
+
 	jcmd := NewJujuCommand(ctx)
 	return cmd.Main(jcmd, ctx, args[1:])
 }
@@ -267,6 +271,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Reporting commands.
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
+	r.Register(newWarningsCommand())
 	r.Register(status.NewStatusHistoryCommand())
 
 	// Error resolution and debugging commands.
@@ -288,6 +293,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Charm tool commands.
 	r.Register(newHelpToolCommand())
+	r.Register(charmcmd.NewDownloadCommand())
 	// TODO (anastasiamac 2017-08-1) This needs to be removed in Juju 3.x
 	// lp#1707836
 	r.Register(charmcmd.NewSuperCommand())
@@ -334,11 +340,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewConfigCommand())
 	r.Register(model.NewDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
+	r.Register(model.NewSetImageCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewTransferCommand())
 	if featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -369,12 +377,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewBindCommand())
+	r.Register(application.NewLabelCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewApplicationGetConstraintsCommand())
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewShowUnitCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -392,6 +403,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommand())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -419,10 +431,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewKillCommand())
 	r.Register(controller.NewListControllersCommand())
 	r.Register(controller.NewRegisterCommand())
-	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
+	r.Register(controller.NewUnregisterCommand(jujuclient.NewClientStore()))
+	r.Register(controller.NewClientCacheCommand())
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewVerifyCommand())
+	r.Register(controller.NewFeaturesCommand())
+	r.Register(controller.NewExportConfigCommand())
+	r.Register(controller.NewImportConfigCommand())
+	r.Register(controller.NewExportClientConfigCommand(jujuclient.NewClientStore()))
+	r.Register(controller.NewImportClientConfigCommand(jujuclient.NewClientStore()))
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -431,6 +431,7 @@ var commandNames = []string{
 	"attach-storage",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budget",
 	"cached-images",
@@ -438,11 +439,14 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"charm-resources",
+	"client-cache",
 	"clouds",
 	"collect-metrics",
 	"config",
 	"consume",
 	"controller-config",
+	"controller-features",
+	"controller-verify",
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
@@ -460,11 +464,14 @@ var commandNames = []string{
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"download-charm",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-client-config",
+	"export-controller-config",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -477,9 +484,12 @@ var commandNames = []string{
 	"help-tool",
 	"hook-tool",
 	"hook-tools",
+	"import-client-config",
+	"import-controller-config",
 	"import-filesystem",
 	"import-ssh-key",
 	"kill-controller",
+	"label",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -539,6 +549,7 @@ var commandNames = []string{
 	"remove-user",
 	"resolved",
 	"resolve",
+	"resize-storage",
 	"resources",
 	"restore-backup",
 	"resume-relation",
@@ -554,6 +565,7 @@ var commandNames = []string{
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-image",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -573,6 +585,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-unit",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -587,6 +600,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"transfer-model",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -606,6 +620,7 @@ var commandNames = []string{
 	"users",
 	"version",
 	"wallets",
+	"warnings",
 	"whoami",
 }
 
@@ -9,9 +9,11 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -33,6 +36,7 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	userMapFile      cmd.FileVar
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -80,6 +84,24 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+Users with access to the model may be replaced by different users on
+the target controller using the --user-map option. The option names a
+YAML file mapping user names on the source controller to user names on
+the target controller, for example:
+
+    bob@external: bob
+    mary: mary@external
+
+The model's owner, and the users granted access to it, are renamed
+accordingly when the model is imported into the target controller.
+Users not in the map keep their names. Each user may be mapped to at
+most one user, and no two users may be mapped to the same user.
+
+Examples:
+
+    juju migrate mymodel target
+    juju migrate mymodel target --user-map users.yaml
+
 See also:
     login
     controllers
@@ -96,6 +118,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(&c.userMapFile, "user-map", "Path to a YAML file mapping source users to target users")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -131,6 +159,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if spec.TargetUserMap, err = c.readUserMap(ctx); err != nil {
+		return errors.Trace(err)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -184,6 +215,26 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	}, nil
 }
 
+// readUserMap reads the user map from the file specified with
+// --user-map, if any.
+func (c *migrateCommand) readUserMap(ctx *cmd.Context) (map[string]string, error) {
+	if c.userMapFile.Path == "" {
+		return nil, nil
+	}
+	data, err := c.userMapFile.Read(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var userMap map[string]string
+	if err := yaml.Unmarshal(data, &userMap); err != nil {
+		return nil, errors.Annotate(err, "parsing user map")
+	}
+	if err := coremigration.ValidateUserMap(userMap); err != nil {
+		return nil, errors.Annotate(err, "validating user map")
+	}
+	return userMap, nil
+}
+
 func (c *migrateCommand) getMigrationAPI(controllerName string) (migrateAPI, error) {
 	if c.migAPI != nil && c.migAPI[controllerName] != nil {
 		return c.migAPI[controllerName], nil
@@ -255,6 +306,9 @@ func (c *migrateCommand) checkMigrationFeasibility(spec *controller.MigrationSpe
 	if srcUsers, err = c.getModelUsers(names.NewModelTag(spec.ModelUUID)); err != nil {
 		return err
 	}
+	// The users will be known by their mapped names in the target
+	// controller, so those are the names that must exist there.
+	srcUsers = mapUsers(srcUsers, spec.TargetUserMap)
 	if dstUsers, err = c.getTargetControllerUsers(); err != nil {
 		return err
 	}
@@ -376,6 +430,14 @@ func (c *migrateCommand) getTargetControllerUsers() (set.Strings, error) {
 	return users, nil
 }
 
+func mapUsers(users set.Strings, userMap map[string]string) set.Strings {
+	out := set.NewStrings()
+	for _, user := range users.Values() {
+		out.Add(coremigration.MapUser(userMap, names.NewUserTag(user)).Id())
+	}
+	return out
+}
+
 func filterSet(s set.Strings, keep func(string) bool) set.Strings {
 	out := set.NewStrings()
 	for _, v := range s.Values() {
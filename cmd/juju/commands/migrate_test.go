@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/cmd"
@@ -403,6 +405,51 @@ the current model:
 	}
 }
 
+func (s *MigrateSuite) writeUserMap(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "users.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *MigrateSuite) TestUserMap(c *gc.C) {
+	s.userAPI.users = append(s.userAPI.users, params.UserInfo{Username: "foo"})
+	path := s.writeUserMap(c, "foo@external: foo\n")
+
+	// The controllers use different identity providers, but the
+	// external user is mapped to a local user in the target.
+	cmd := s.makeCommand()
+	inner := modelcmd.InnerCommand(cmd).(*migrateCommand)
+	inner.migAPI["source"].(*fakeMigrateAPI).identityURL = "https://api.jujucharms.com/identity"
+	inner.migAPI["target"].(*fakeMigrateAPI).identityURL = "https://candid.provider/identity"
+	_, err := cmdtesting.RunCommand(c, cmd, "model-with-extra-external-users", "target", "--user-map", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.TargetUserMap, jc.DeepEquals, map[string]string{
+		"foo@external": "foo",
+	})
+}
+
+func (s *MigrateSuite) TestUserMapTargetUserMissing(c *gc.C) {
+	path := s.writeUserMap(c, "foo: baz\n")
+	_, err := s.makeAndRun(c, "model-with-extra-local-users", "target", "--user-map", path)
+	c.Assert(err, gc.ErrorMatches, `(?s)cannot initiate migration as the users granted access to the model do not exist.*  - baz`)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestUserMapInvalid(c *gc.C) {
+	path := s.writeUserMap(c, "foo: baz\nbar: baz\n")
+	_, err := s.makeAndRun(c, "model", "target", "--user-map", path)
+	c.Assert(err, gc.ErrorMatches, `validating user map: users "(foo|bar)" and "(foo|bar)" both mapped to "baz" in UserMap not valid`)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestUserMapBadYAML(c *gc.C) {
+	path := s.writeUserMap(c, "[foo, bar]\n")
+	_, err := s.makeAndRun(c, "model", "target", "--user-map", path)
+	c.Assert(err, gc.ErrorMatches, `parsing user map: .*`)
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) TestSpecifyOwner(c *gc.C) {
 	ctx, err := s.makeAndRun(c, "alpha/production", "target")
 	c.Assert(err, jc.ErrorIsNil)
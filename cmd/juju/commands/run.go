@@ -22,7 +22,9 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/labels"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -54,6 +56,7 @@ type runCommand struct {
 	machines     []string
 	applications []string
 	units        []string
+	selector     string
 	commands     string
 	timeAfter    func(time.Duration) <-chan time.Time
 }
@@ -93,6 +96,13 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+--selector runs the command on the machines and units whose labels match
+the selector, a comma-separated list of requirements of the form
+key=value, key!=value, key or !key. Labels are set with the label command.
+For example:
+
+    juju run --selector env=canary,tier=db -- hostname -f
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
@@ -128,6 +138,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "u", "One or more unit ids")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "")
+	f.StringVar(&c.selector, "selector", "", "Run the commands on the machines and units whose labels match the selector")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -155,9 +166,17 @@ func (c *runCommand) Init(args []string) error {
 		if len(c.units) != 0 {
 			return errors.Errorf("You cannot specify --all and individual units")
 		}
+		if c.selector != "" {
+			return errors.Errorf("You cannot specify --all and a selector")
+		}
 	} else {
-		if len(c.machines) == 0 && len(c.applications) == 0 && len(c.units) == 0 {
-			return errors.Errorf("You must specify a target, either through --all, --machine, --application or --unit")
+		if len(c.machines) == 0 && len(c.applications) == 0 && len(c.units) == 0 && c.selector == "" {
+			return errors.Errorf("You must specify a target, either through --all, --machine, --application, --unit or --selector")
+		}
+	}
+	if c.selector != "" {
+		if _, err := labels.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
 		}
 	}
 
@@ -258,12 +277,22 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			}
 		}
 
+		machines, units := c.machines, c.units
+		if c.selector != "" {
+			selectedMachines, selectedUnits, err := c.selectByLabels()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			machines = append(machines, selectedMachines...)
+			units = append(units, selectedUnits...)
+		}
+
 		params := params.RunParams{
 			Commands:     c.commands,
 			Timeout:      c.timeout,
-			Machines:     c.machines,
+			Machines:     machines,
 			Applications: c.applications,
-			Units:        c.units,
+			Units:        units,
 		}
 		runResults, err = client.Run(params)
 	}
@@ -422,6 +451,33 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// runStatusClient exposes the status capability used to
+// resolve a label selector.
+type runStatusClient interface {
+	common.StatusAPI
+	Close() error
+}
+
+// getRunStatusAPIClient is patched in tests.
+var getRunStatusAPIClient = func(c *runCommand) (runStatusClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewLabelSelectClient(root), nil
+}
+
+// selectByLabels returns the machines and units whose labels
+// match the command's selector.
+func (c *runCommand) selectByLabels() (machines, units []string, _ error) {
+	client, err := getRunStatusAPIClient(c)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer client.Close()
+	return common.SelectByLabels(client, c.selector)
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
@@ -45,6 +45,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines     []string
 		units        []string
 		applications []string
+		selector     string
 		commands     string
 		errMatch     string
 	}{{
@@ -53,7 +54,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}, {
 		message:  "no target",
 		args:     []string{"sudo reboot"},
-		errMatch: "You must specify a target, either through --all, --machine, --application or --unit",
+		errMatch: "You must specify a target, either through --all, --machine, --application, --unit or --selector",
 	}, {
 		message:  "command to all machines",
 		args:     []string{"--all", "sudo reboot"},
@@ -140,6 +141,19 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines:     []string{"0"},
 		applications: []string{"mysql"},
 		units:        []string{"wordpress/0", "wordpress/1", "consul/leader"},
+	}, {
+		message:  "command to selected machines and units",
+		args:     []string{"--selector", "env=canary", "sudo reboot"},
+		commands: "sudo reboot",
+		selector: "env=canary",
+	}, {
+		message:  "all and selector",
+		args:     []string{"--all", "--selector", "env=canary", "sudo reboot"},
+		errMatch: `You cannot specify --all and a selector`,
+	}, {
+		message:  "invalid selector",
+		args:     []string{"--selector", "Env=canary", "sudo reboot"},
+		errMatch: `parsing selector "Env=canary": label key "Env" not valid`,
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		cmd := &runCommand{}
@@ -147,6 +161,7 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		runCmd := modelcmd.Wrap(cmd)
 		cmdtesting.TestInit(c, runCmd, test.args, test.errMatch)
 		if test.errMatch == "" {
+			c.Check(cmd.selector, gc.Equals, test.selector)
 			c.Check(cmd.all, gc.Equals, test.all)
 			c.Check(cmd.machines, gc.DeepEquals, test.machines)
 			c.Check(cmd.applications, gc.DeepEquals, test.applications)
@@ -298,6 +313,39 @@ func (s *RunSuite) TestRunForMachineAndUnit(c *gc.C) {
 	c.Check(cmdtesting.Stdout(context), gc.Equals, buff.String())
 }
 
+func (s *RunSuite) TestRunForSelector(c *gc.C) {
+	mock := s.setupMockAPI()
+	statusAPI := &mockRunStatusAPI{status: &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", Labels: map[string]string{"env": "canary"}},
+			"1": {Id: "1"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"unit": {Units: map[string]params.UnitStatus{
+				"unit/0": {Labels: map[string]string{"env": "canary"}},
+			}},
+		},
+	}}
+	s.PatchValue(&getRunStatusAPIClient, func(_ *runCommand) (runStatusClient, error) {
+		return statusAPI, nil
+	})
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.setResponse("unit/0", mockResponse{stdout: "bumblebee", unitTag: "unit-unit-0"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--format=json", "--selector", "env=canary", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusAPI.patterns, jc.DeepEquals, []string{"selector:env=canary"})
+	c.Check(mock.runParams.Machines, jc.DeepEquals, []string{"0"})
+	c.Check(mock.runParams.Units, jc.DeepEquals, []string{"unit/0"})
+	c.Check(statusAPI.closed, jc.IsTrue)
+}
+
 func (s *RunSuite) TestBlockRunForMachineAndUnit(c *gc.C) {
 	mock := s.setupMockAPI()
 	// Block operation
@@ -552,10 +600,27 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runParams       params.RunParams
 	//
 	bestAPIVersion int
 }
 
+type mockRunStatusAPI struct {
+	status   *params.FullStatus
+	patterns []string
+	closed   bool
+}
+
+func (m *mockRunStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	m.patterns = patterns
+	return m.status, nil
+}
+
+func (m *mockRunStatusAPI) Close() error {
+	m.closed = true
+	return nil
+}
+
 type mockResponse struct {
 	stdout     interface{}
 	stderr     interface{}
@@ -661,6 +726,7 @@ func (m *mockRunAPI) RunOnAllMachines(commands string, timeout time.Duration) ([
 
 func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, error) {
 	var result []params.ActionResult
+	m.runParams = runParams
 
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
@@ -18,7 +18,7 @@ import (
 
 func newSwitchCommand() cmd.Command {
 	command := &switchCommand{
-		Store: jujuclient.NewFileClientStore(),
+		Store: jujuclient.NewClientStore(),
 	}
 	command.CanClearCurrentModel = true
 	command.RefreshModels = command.CommandBase.RefreshModels
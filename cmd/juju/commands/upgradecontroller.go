@@ -13,9 +13,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/podcfg"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/docker"
@@ -46,9 +48,14 @@ The command will abort if an upgrade is in progress. It will also abort if
 a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 
+While an upgrade is running, '--progress' shows each upgrade step run
+by the controller model's agents, whether it is running, completed or
+failed, and any error from a failed step. No upgrade is started.
+
 Examples:
     juju upgrade-controller --dry-run
     juju upgrade-controller --agent-version 2.0.1
+    juju upgrade-controller --progress
     
 See also: 
     upgrade-model`
@@ -63,8 +70,18 @@ type upgradeControllerCommand struct {
 	modelcmd.ControllerCommandBase
 	baseUpgradeCommand
 
-	upgradeJujuAPI upgradeJujuAPI
-	rawArgs        []string
+	upgradeJujuAPI     upgradeJujuAPI
+	upgradeProgressAPI upgradeProgressAPI
+	rawArgs            []string
+
+	showProgress bool
+}
+
+// upgradeProgressAPI defines the controller API methods used to
+// show the progress of a controller upgrade.
+type upgradeProgressAPI interface {
+	UpgradeStepsProgress() (params.UpgradeStepsProgressResult, error)
+	Close() error
 }
 
 func (c *upgradeControllerCommand) Info() *cmd.Info {
@@ -78,6 +95,14 @@ func (c *upgradeControllerCommand) Info() *cmd.Info {
 func (c *upgradeControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
 	c.baseUpgradeCommand.SetFlags(f)
+	f.BoolVar(&c.showProgress, "progress", false, "Show the progress of the upgrade steps of a running upgrade")
+}
+
+func (c *upgradeControllerCommand) Init(args []string) error {
+	if c.showProgress && (c.vers != "" || c.AgentStream != "" || c.BuildAgent || c.DryRun || c.ResetPrevious) {
+		return errors.New("--progress cannot be used with other upgrade options")
+	}
+	return c.baseUpgradeCommand.Init(args)
 }
 
 func (c *upgradeControllerCommand) getUpgradeJujuAPI() (upgradeJujuAPI, error) {
@@ -112,7 +137,18 @@ func (c *upgradeControllerCommand) getControllerAPI() (controllerAPI, error) {
 	return c.NewControllerAPIClient()
 }
 
+func (c *upgradeControllerCommand) getUpgradeProgressAPI() (upgradeProgressAPI, error) {
+	if c.upgradeProgressAPI != nil {
+		return c.upgradeProgressAPI, nil
+	}
+
+	return c.NewControllerAPIClient()
+}
+
 func (c *upgradeControllerCommand) Run(ctx *cmd.Context) (err error) {
+	if c.showProgress {
+		return c.showUpgradeProgress(ctx)
+	}
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return errors.Trace(err)
@@ -133,6 +169,38 @@ func (c *upgradeControllerCommand) Run(ctx *cmd.Context) (err error) {
 	return c.upgradeIAASController(ctx)
 }
 
+// showUpgradeProgress writes a table of the upgrade steps run by
+// each agent while upgrading to the controller model's agent version.
+func (c *upgradeControllerCommand) showUpgradeProgress(ctx *cmd.Context) error {
+	client, err := c.getUpgradeProgressAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.UpgradeStepsProgress()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Steps) == 0 {
+		fmt.Fprintf(ctx.Stdout, "No upgrade steps have been run for version %s.\n", result.Version)
+		return nil
+	}
+
+	fmt.Fprintf(ctx.Stdout, "Upgrade steps for version %s:\n\n", result.Version)
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Agent", "Step", "Status", "Message")
+	for _, step := range result.Steps {
+		agent := step.Tag
+		if tag, err := names.ParseTag(step.Tag); err == nil {
+			agent = tag.Kind() + " " + tag.Id()
+		}
+		w.Println(agent, step.Step, step.Status, step.Message)
+	}
+	return errors.Trace(tw.Flush())
+}
+
 func (c *upgradeControllerCommand) upgradeCAASController(ctx *cmd.Context) error {
 	if c.BuildAgent {
 		return errors.NotSupportedf("--build-agent for k8s controller upgrades")
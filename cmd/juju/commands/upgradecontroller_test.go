@@ -16,6 +16,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
@@ -119,6 +120,71 @@ func (s *UpgradeIAASControllerSuite) TestUpgradeDryRun(c *gc.C) {
 	s.assertUpgradeDryRun(c, "upgrade-controller", s.upgradeControllerCommand)
 }
 
+type fakeUpgradeProgressAPI struct {
+	result params.UpgradeStepsProgressResult
+	err    error
+}
+
+func (f *fakeUpgradeProgressAPI) UpgradeStepsProgress() (params.UpgradeStepsProgressResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeUpgradeProgressAPI) Close() error {
+	return nil
+}
+
+func (s *UpgradeIAASControllerSuite) upgradeProgressCommand(api upgradeProgressAPI) cmd.Command {
+	cmd := &upgradeControllerCommand{upgradeProgressAPI: api}
+	cmd.SetClientStore(s.ControllerStore)
+	return modelcmd.WrapController(cmd)
+}
+
+func (s *UpgradeIAASControllerSuite) TestUpgradeProgress(c *gc.C) {
+	api := &fakeUpgradeProgressAPI{
+		result: params.UpgradeStepsProgressResult{
+			Version: version.MustParse("2.7.1"),
+			Steps: []params.UpgradeStepProgress{{
+				Tag:    "machine-0",
+				Step:   "add upgrade steps collection",
+				Status: "completed",
+			}, {
+				Tag:     "machine-0",
+				Step:    "reset kvm machine modification status",
+				Status:  "failed",
+				Message: "boom",
+			}, {
+				Tag:    "machine-1",
+				Step:   "add upgrade steps collection",
+				Status: "running",
+			}},
+		},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.upgradeProgressCommand(api), "--progress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Upgrade steps for version 2.7.1:
+
+Agent      Step                                   Status     Message
+machine 0  add upgrade steps collection           completed  
+machine 0  reset kvm machine modification status  failed     boom
+machine 1  add upgrade steps collection           running    
+`[1:])
+}
+
+func (s *UpgradeIAASControllerSuite) TestUpgradeProgressNoSteps(c *gc.C) {
+	api := &fakeUpgradeProgressAPI{
+		result: params.UpgradeStepsProgressResult{Version: version.MustParse("2.7.1")},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.upgradeProgressCommand(api), "--progress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "No upgrade steps have been run for version 2.7.1.\n")
+}
+
+func (s *UpgradeIAASControllerSuite) TestUpgradeProgressWithOtherOptions(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.upgradeProgressCommand(&fakeUpgradeProgressAPI{}), "--progress", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--progress cannot be used with other upgrade options")
+}
+
 type UpgradeCAASControllerSuite struct {
 	UpgradeBaseSuite
 }
@@ -0,0 +1,121 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func newWarningsCommand() cmd.Command {
+	return &warningsCommand{
+		readWarnings: modelcmd.ReadClientWarnings,
+	}
+}
+
+type warningsCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	readWarnings func() ([]modelcmd.ClientWarning, error)
+}
+
+const warningsDoc = `
+Controllers attach warnings to their replies to API requests that rely
+on deprecated behaviour, such as an old API facade version or a
+deprecated model config attribute. Each command prints the warnings it
+receives when it finishes, and records them so they can be reviewed
+later with this command.
+
+The most recently seen warnings are listed first, along with the API
+request each was attached to.
+
+Examples:
+
+    juju warnings
+    juju warnings --format yaml
+`
+
+// Info implements Command.Info.
+func (c *warningsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "warnings",
+		Purpose: "Lists the warnings recently received from controllers.",
+		Doc:     warningsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *warningsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatWarningsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *warningsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// clientWarning is the serialisation format for a warning received
+// by the client.
+type clientWarning struct {
+	Code     string    `yaml:"code" json:"code"`
+	Message  string    `yaml:"message" json:"message"`
+	Facade   string    `yaml:"facade" json:"facade"`
+	Version  int       `yaml:"version" json:"version"`
+	Method   string    `yaml:"method" json:"method"`
+	LastSeen time.Time `yaml:"last-seen" json:"last-seen"`
+}
+
+// Run implements Command.Run.
+func (c *warningsCommand) Run(ctx *cmd.Context) error {
+	warnings, err := c.readWarnings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := []clientWarning{}
+	for _, w := range warnings {
+		result = append(result, clientWarning{
+			Code:     w.Code,
+			Message:  w.Message,
+			Facade:   w.Facade,
+			Version:  w.Version,
+			Method:   w.Method,
+			LastSeen: w.LastSeen,
+		})
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatWarningsTabular(writer io.Writer, value interface{}) error {
+	warnings, ok := value.([]clientWarning)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", warnings, value)
+	}
+	if len(warnings) == 0 {
+		fmt.Fprintln(writer, "No warnings have been received.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Last seen", "Request", "Warning")
+	for _, warning := range warnings {
+		request := fmt.Sprintf("%s(%d).%s", warning.Facade, warning.Version, warning.Method)
+		w.Println(warning.LastSeen.UTC().Format(time.RFC3339), request, warning.Message)
+	}
+	return errors.Trace(tw.Flush())
+}
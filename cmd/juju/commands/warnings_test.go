@@ -0,0 +1,98 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+type WarningsSuite struct {
+	testing.IsolationSuite
+	warnings []modelcmd.ClientWarning
+	err      error
+}
+
+var _ = gc.Suite(&WarningsSuite{})
+
+func (s *WarningsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.err = nil
+	s.warnings = []modelcmd.ClientWarning{{
+		Code:     "deprecated config",
+		Message:  `model config attribute "ignore-machine-addresses" is deprecated`,
+		Facade:   "ModelConfig",
+		Version:  2,
+		Method:   "ModelSet",
+		LastSeen: time.Date(2019, 6, 3, 10, 0, 0, 0, time.UTC),
+	}, {
+		Code:     "deprecated facade version",
+		Message:  "Client facade version 1 is deprecated",
+		Facade:   "Client",
+		Version:  1,
+		Method:   "FullStatus",
+		LastSeen: time.Date(2019, 6, 1, 9, 30, 0, 0, time.UTC),
+	}}
+}
+
+func (s *WarningsSuite) newCommand() *warningsCommand {
+	return &warningsCommand{
+		readWarnings: func() ([]modelcmd.ClientWarning, error) {
+			return s.warnings, s.err
+		},
+	}
+}
+
+func (s *WarningsSuite) TestInitArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *WarningsSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Last seen             Request                  Warning\n"+
+		"2019-06-03T10:00:00Z  ModelConfig(2).ModelSet  model config attribute \"ignore-machine-addresses\" is deprecated\n"+
+		"2019-06-01T09:30:00Z  Client(1).FullStatus     Client facade version 1 is deprecated\n")
+}
+
+func (s *WarningsSuite) TestTabularNoWarnings(c *gc.C) {
+	s.warnings = nil
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "No warnings have been received.\n")
+}
+
+func (s *WarningsSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- code: deprecated config
+  message: model config attribute "ignore-machine-addresses" is deprecated
+  facade: ModelConfig
+  version: 2
+  method: ModelSet
+  last-seen: 2019-06-03T10:00:00Z
+- code: deprecated facade version
+  message: Client facade version 1 is deprecated
+  facade: Client
+  version: 1
+  method: FullStatus
+  last-seen: 2019-06-01T09:30:00Z
+`[1:])
+}
+
+func (s *WarningsSuite) TestReadError(c *gc.C) {
+	s.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
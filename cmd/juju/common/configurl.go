@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+)
+
+const (
+	// configChecksumPrefix prefixes the checksum in the fragment of
+	// a config URL, as in https://example.com/config.yaml#sha256=<hex>.
+	configChecksumPrefix = "sha256="
+
+	// configURLMaxSize is the maximum size of config read from a URL.
+	configURLMaxSize = 1 << 20
+)
+
+// IsConfigURL reports whether the config flag value refers to
+// configuration held remotely, rather than a local file.
+// Only https and s3 URLs are supported.
+func IsConfigURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "s3://")
+}
+
+// RedactConfigURL returns the config URL in a form suitable for
+// logging and error messages: credentials and query values, which
+// may hold the signature of a pre-signed URL, are replaced.
+func RedactConfigURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	if u.User != nil {
+		u.User = url.User("REDACTED")
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query.Set(key, "REDACTED")
+		}
+		u.RawQuery = query.Encode()
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+// ReadConfigURL fetches the configuration held at the https or s3
+// URL. If the URL has a fragment of the form sha256=<hex>, the
+// SHA-256 checksum of the configuration is checked against it.
+func ReadConfigURL(ctx *cmd.Context, rawURL string) ([]byte, error) {
+	redacted := RedactConfigURL(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.NotValidf("config URL %q", redacted)
+	}
+	var checksum string
+	if u.Fragment != "" {
+		if !strings.HasPrefix(u.Fragment, configChecksumPrefix) {
+			return nil, errors.NotValidf("checksum %q in config URL (expected %s<hex>)", u.Fragment, configChecksumPrefix)
+		}
+		checksum = strings.ToLower(strings.TrimPrefix(u.Fragment, configChecksumPrefix))
+		u.Fragment = ""
+	}
+
+	ctx.Verbosef("reading config from %s", redacted)
+	var rc io.ReadCloser
+	switch u.Scheme {
+	case "https":
+		rc, err = fetchHTTPSConfig(u.String())
+	case "s3":
+		rc, err = fetchS3Config(u.Host, strings.TrimPrefix(u.Path, "/"))
+	default:
+		return nil, errors.NotSupportedf("config URL scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(redactURLError(err), "cannot read config from %s", redacted)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(rc, configURLMaxSize+1))
+	if err != nil {
+		return nil, errors.Annotatef(redactURLError(err), "cannot read config from %s", redacted)
+	}
+	if len(data) > configURLMaxSize {
+		return nil, errors.Errorf("config at %s exceeds %d bytes", redacted, configURLMaxSize)
+	}
+
+	if checksum != "" {
+		if actual := fmt.Sprintf("%x", sha256.Sum256(data)); actual != checksum {
+			return nil, errors.Errorf("config at %s has SHA-256 checksum %s, expected %s", redacted, actual, checksum)
+		}
+	}
+	return data, nil
+}
+
+// redactURLError strips the URL, which may hold credentials, from
+// errors returned by the HTTP client.
+func redactURLError(err error) error {
+	if urlErr, ok := errors.Cause(err).(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
+
+// fetchHTTPSConfig returns the body of the response to a GET
+// of the URL. It is a variable so it can be replaced in tests.
+var fetchHTTPSConfig = func(rawURL string) (io.ReadCloser, error) {
+	client := utils.GetHTTPClient(utils.VerifySSLHostnames)
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected response status %q", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// fetchS3Config returns the content of the object with the key in
+// the S3 bucket. Credentials are taken from the standard AWS
+// environment variables, and the region from AWS_REGION, which
+// defaults to us-east-1. It is a variable so it can be replaced
+// in tests.
+var fetchS3Config = func(bucketName, key string) (io.ReadCloser, error) {
+	auth, err := aws.EnvAuth()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading AWS credentials")
+	}
+	region := aws.USEast
+	if name := os.Getenv("AWS_REGION"); name != "" {
+		var ok bool
+		if region, ok = aws.Regions[name]; !ok {
+			return nil, errors.NotValidf("AWS region %q", name)
+		}
+	}
+	bucket, err := s3.New(auth, region).Bucket(bucketName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rc, err := bucket.GetReader(key)
+	return rc, errors.Trace(err)
+}
@@ -38,6 +38,11 @@ func (f *ConfigFlag) Set(s string) error {
 	if s == "" {
 		return errors.NotValidf("empty string")
 	}
+	if IsConfigURL(s) {
+		// URLs may contain "=" in their query or checksum.
+		f.files = append(f.files, s)
+		return nil
+	}
 	fields := strings.SplitN(s, "=", 2)
 	if len(fields) == 1 {
 		f.files = append(f.files, fields[0])
@@ -91,9 +96,14 @@ func (f *ConfigFlag) ReadConfigPairs(ctx *cmd.Context) (map[string]interface{},
 }
 
 // AbsoluteFileNames returns the absolute path of any file names specified.
+// Config URLs are returned unchanged.
 func (f *ConfigFlag) AbsoluteFileNames(ctx *cmd.Context) ([]string, error) {
 	files := make([]string, len(f.files))
 	for i, f := range f.files {
+		if IsConfigURL(f) {
+			files[i] = f
+			continue
+		}
 		path, err := utils.NormalizePath(f)
 		if err != nil {
 			return nil, errors.Trace(err)
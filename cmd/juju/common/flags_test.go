@@ -47,6 +47,17 @@ func (*FlagsSuite) TestConfigFlagSet(c *gc.C) {
 	assertConfigFlag(c, f, []string{"a.yaml", "b.yaml"}, map[string]interface{}{"k1": "=v2", "k2": 3, "k3": "", "k4": "4.0"})
 }
 
+func (*FlagsSuite) TestConfigFlagSetURL(c *gc.C) {
+	var f ConfigFlag
+	c.Assert(f.Set("https://example.com/a.yaml?token=x#sha256=0123"), jc.ErrorIsNil)
+	c.Assert(f.Set("s3://bucket/b.yaml"), jc.ErrorIsNil)
+	assertConfigFlag(c, f, []string{"https://example.com/a.yaml?token=x#sha256=0123", "s3://bucket/b.yaml"}, nil)
+
+	files, err := f.AbsoluteFileNames(cmdtesting.Context(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, jc.DeepEquals, []string{"https://example.com/a.yaml?token=x#sha256=0123", "s3://bucket/b.yaml"})
+}
+
 func (*FlagsSuite) TestConfigFlagSetErrors(c *gc.C) {
 	var f ConfigFlag
 	c.Assert(f.Set(""), gc.ErrorMatches, "empty string not valid")
@@ -0,0 +1,119 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	apilabels "github.com/juju/juju/api/labels"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/labels"
+)
+
+// StatusAPI defines the status method used to select
+// machines and units by their labels.
+type StatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// LabelSelectAPI defines the method used to select machines and units
+// by their labels, where the controller supports it.
+type LabelSelectAPI interface {
+	// Select returns the ids of the machines, and the names of the
+	// units, whose labels match the selector. It returns a
+	// NotSupported error if the controller cannot select by labels.
+	Select(selector string) (machines, units []string, _ error)
+}
+
+// LabelSelectClient selects machines and units by their labels
+// through the Labels facade, and falls back to the model's status
+// on controllers whose Labels facade cannot.
+type LabelSelectClient struct {
+	*api.Client
+	labels *apilabels.Client
+}
+
+// NewLabelSelectClient returns a LabelSelectClient using the
+// API connection, which is closed when the client is.
+func NewLabelSelectClient(root api.Connection) *LabelSelectClient {
+	return &LabelSelectClient{
+		Client: root.Client(),
+		labels: apilabels.NewClient(root),
+	}
+}
+
+// Select is part of the LabelSelectAPI interface.
+func (c *LabelSelectClient) Select(selector string) (machines, units []string, _ error) {
+	return c.labels.Select(selector)
+}
+
+// SelectByLabels returns the ids of the machines, and the names of the
+// units, whose labels match the selector. If the api can select by
+// labels, the controller's model cache is asked for them. Otherwise
+// the controller filters the status by the selector, and the labels of
+// each machine and unit are checked again here, so that nothing is
+// selected by a controller which does not support labels.
+func SelectByLabels(api StatusAPI, selector string) (machines, units []string, _ error) {
+	parsed, err := labels.ParseSelector(selector)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if selectAPI, ok := api.(LabelSelectAPI); ok {
+		machines, units, err := selectAPI.Select(selector)
+		if err == nil {
+			return selected(selector, machines, units)
+		}
+		if !errors.IsNotSupported(err) {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+	status, err := api.Status([]string{params.LabelSelectorPatternPrefix + selector})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var addMachine func(params.MachineStatus)
+	addMachine = func(m params.MachineStatus) {
+		if parsed.Matches(m.Labels) {
+			machines = append(machines, m.Id)
+		}
+		for _, container := range m.Containers {
+			addMachine(container)
+		}
+	}
+	for _, m := range status.Machines {
+		addMachine(m)
+	}
+
+	var addUnit func(string, params.UnitStatus)
+	addUnit = func(name string, u params.UnitStatus) {
+		if parsed.Matches(u.Labels) {
+			units = append(units, name)
+		}
+		for subName, sub := range u.Subordinates {
+			addUnit(subName, sub)
+		}
+	}
+	for _, app := range status.Applications {
+		for name, u := range app.Units {
+			addUnit(name, u)
+		}
+	}
+
+	return selected(selector, machines, units)
+}
+
+// selected returns the sorted machines and units selected by
+// the selector, or a NotFound error if there are none.
+func selected(selector string, machines, units []string) ([]string, []string, error) {
+	sort.Strings(machines)
+	sort.Strings(units)
+	if len(machines) == 0 && len(units) == 0 {
+		return nil, nil, errors.NotFoundf("machines or units matching selector %q", selector)
+	}
+	return machines, units, nil
+}
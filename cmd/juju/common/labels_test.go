@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type SelectByLabelsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SelectByLabelsSuite{})
+
+func (s *SelectByLabelsSuite) TestSelectByLabels(c *gc.C) {
+	api := &mockStatusAPI{Stub: &testing.Stub{}, status: &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0", Labels: map[string]string{"env": "canary"}, Containers: map[string]params.MachineStatus{
+				"0/lxd/0": {Id: "0/lxd/0", Labels: map[string]string{"env": "canary"}},
+			}},
+			"1": {Id: "1", Labels: map[string]string{"env": "prod"}},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: map[string]params.UnitStatus{
+				"mysql/0": {Labels: map[string]string{"env": "canary"}, Subordinates: map[string]params.UnitStatus{
+					"logging/0": {Labels: map[string]string{"env": "canary"}},
+				}},
+				"mysql/1": {},
+			}},
+		},
+	}}
+	machines, units, err := common.SelectByLabels(api, "env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []string{"0", "0/lxd/0"})
+	c.Assert(units, jc.DeepEquals, []string{"logging/0", "mysql/0"})
+	api.CheckCall(c, 0, "Status", []string{"selector:env=canary"})
+}
+
+func (s *SelectByLabelsSuite) TestSelectByLabelsNoMatch(c *gc.C) {
+	// Controllers which do not support labels do not return them,
+	// so nothing is selected.
+	api := &mockStatusAPI{Stub: &testing.Stub{}, status: &params.FullStatus{
+		Machines: map[string]params.MachineStatus{"0": {Id: "0"}},
+	}}
+	_, _, err := common.SelectByLabels(api, "env=canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `machines or units matching selector "env=canary" not found`)
+}
+
+func (s *SelectByLabelsSuite) TestSelectByLabelsInvalidSelector(c *gc.C) {
+	api := &mockStatusAPI{Stub: &testing.Stub{}}
+	_, _, err := common.SelectByLabels(api, "env=canary,")
+	c.Assert(err, gc.ErrorMatches, `empty requirement in selector "env=canary," not valid`)
+	api.CheckNoCalls(c)
+}
+
+func (s *SelectByLabelsSuite) TestSelectByLabelsFromController(c *gc.C) {
+	api := &mockLabelSelectAPI{
+		mockStatusAPI: mockStatusAPI{Stub: &testing.Stub{}},
+		machines:      []string{"1", "0"},
+		units:         []string{"mysql/0"},
+	}
+	machines, units, err := common.SelectByLabels(api, "env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []string{"0", "1"})
+	c.Assert(units, jc.DeepEquals, []string{"mysql/0"})
+	api.CheckCalls(c, []testing.StubCall{{"Select", []interface{}{"env=canary"}}})
+}
+
+func (s *SelectByLabelsSuite) TestSelectByLabelsFallsBackToStatus(c *gc.C) {
+	api := &mockLabelSelectAPI{
+		mockStatusAPI: mockStatusAPI{Stub: &testing.Stub{}, status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {Id: "0", Labels: map[string]string{"env": "canary"}},
+			},
+		}},
+	}
+	api.SetErrors(errors.NotSupportedf("selecting by labels"))
+	machines, units, err := common.SelectByLabels(api, "env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []string{"0"})
+	c.Assert(units, gc.HasLen, 0)
+	api.CheckCallNames(c, "Select", "Status")
+}
+
+type mockLabelSelectAPI struct {
+	mockStatusAPI
+	machines []string
+	units    []string
+}
+
+func (m *mockLabelSelectAPI) Select(selector string) ([]string, []string, error) {
+	m.MethodCall(m, "Select", selector)
+	return m.machines, m.units, m.NextErr()
+}
+
+type mockStatusAPI struct {
+	*testing.Stub
+	status *params.FullStatus
+}
+
+func (m *mockStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	m.MethodCall(m, "Status", patterns)
+	return m.status, m.NextErr()
+}
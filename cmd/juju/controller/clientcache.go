@@ -0,0 +1,257 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charmrepo.v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+const (
+	cookiesCacheName = "cookies"
+	charmsCacheName  = "charms"
+)
+
+// clientCache describes a cache of data held by the Juju client,
+// which can safely be cleared.
+type clientCache struct {
+	Name        string
+	Description string
+	Dir         string
+}
+
+// clientCaches returns the caches held by the Juju client.
+func clientCaches() []clientCache {
+	return []clientCache{{
+		Name:        cookiesCacheName,
+		Description: "cookies and macaroons, per controller",
+		Dir:         jujuclient.JujuCookiesDir(),
+	}, {
+		Name:        charmsCacheName,
+		Description: "charms downloaded from the charm store",
+		Dir:         charmrepo.CacheDir,
+	}}
+}
+
+// cacheUsage returns the number of files in the directory,
+// and their total size in bytes.
+func cacheUsage(dir string) (int, int64, error) {
+	var files int
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() {
+			files++
+			size += info.Size()
+		}
+		return nil
+	})
+	return files, size, errors.Trace(err)
+}
+
+// clearCacheDir removes the contents of the directory, leaving the
+// directory itself in place.
+func clearCacheDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// removeControllerCookies removes the cookie jar of the
+// named controller.
+func removeControllerCookies(controllerName string) error {
+	err := os.Remove(jujuclient.JujuCookiePath(controllerName))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewClientCacheCommand returns a command to inspect and clear
+// the caches held by the Juju client.
+func NewClientCacheCommand() cmd.Command {
+	return modelcmd.WrapBase(&clientCacheCommand{})
+}
+
+// clientCacheCommand inspects and clears client-side caches.
+type clientCacheCommand struct {
+	modelcmd.CommandBase
+	out cmd.Output
+
+	clear          bool
+	controllerName string
+	names          []string
+}
+
+var usageClientCacheDetails = `
+Shows the caches held by the Juju client, and how much they hold.
+With --clear, the named caches (or all caches, if none are named)
+are emptied. Clearing a cache never removes controllers, models,
+accounts or credentials known to the client.
+
+The cookies cache holds the cookies and macaroons obtained when
+authenticating with each controller. When a controller is rebuilt
+under the same name, the stale cookies can cause confusing
+authorization (discharge) failures; clearing them, optionally only
+for one controller with --controller, forces a fresh login.
+
+The charms cache holds charms downloaded from the charm store.
+
+Examples:
+
+    juju client-cache
+    juju client-cache --clear
+    juju client-cache --clear cookies --controller my-controller
+
+See also:
+    unregister
+    login`
+
+// Info implements Command.Info.
+func (c *clientCacheCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "client-cache",
+		Args:    "[<cache name> ...]",
+		Purpose: "Shows or clears the caches held by the Juju client.",
+		Doc:     usageClientCacheDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *clientCacheCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.clear, "clear", false, "Clear the caches")
+	f.StringVar(&c.controllerName, "controller", "", "Only clear the cookies of the named controller")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatClientCachesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *clientCacheCommand) Init(args []string) error {
+	known := make(map[string]bool)
+	for _, cache := range clientCaches() {
+		known[cache.Name] = true
+	}
+	for _, name := range args {
+		if !known[name] {
+			return errors.NotValidf("cache name %q", name)
+		}
+	}
+	c.names = args
+	if c.controllerName != "" && !c.clear {
+		return errors.New("--controller can only be used with --clear")
+	}
+	return nil
+}
+
+// clientCacheInfo describes the usage of a client cache.
+type clientCacheInfo struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Path        string `yaml:"path" json:"path"`
+	Files       int    `yaml:"files" json:"files"`
+	Size        int64  `yaml:"size" json:"size"`
+}
+
+// Run implements Command.Run.
+func (c *clientCacheCommand) Run(ctx *cmd.Context) error {
+	selected := make(map[string]bool)
+	for _, name := range c.names {
+		selected[name] = true
+	}
+	var infos []clientCacheInfo
+	for _, cache := range clientCaches() {
+		if len(selected) > 0 && !selected[cache.Name] {
+			continue
+		}
+		if c.clear {
+			if err := c.clearCache(ctx, cache); err != nil {
+				return errors.Annotatef(err, "clearing %s cache", cache.Name)
+			}
+			continue
+		}
+		files, size, err := cacheUsage(cache.Dir)
+		if err != nil {
+			return errors.Annotatef(err, "reading %s cache", cache.Name)
+		}
+		infos = append(infos, clientCacheInfo{
+			Name:        cache.Name,
+			Description: cache.Description,
+			Path:        cache.Dir,
+			Files:       files,
+			Size:        size,
+		})
+	}
+	if c.clear {
+		return nil
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func (c *clientCacheCommand) clearCache(ctx *cmd.Context, cache clientCache) error {
+	if c.controllerName != "" {
+		if cache.Name != cookiesCacheName {
+			return nil
+		}
+		if err := removeControllerCookies(c.controllerName); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Cleared cookies of controller %q.", c.controllerName)
+		return nil
+	}
+	if err := clearCacheDir(cache.Dir); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Cleared %s cache.", cache.Name)
+	return nil
+}
+
+func formatClientCachesTabular(writer io.Writer, value interface{}) error {
+	infos, ok := value.([]clientCacheInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Cache", "Files", "Size", "Path")
+	tw.SetColumnAlignRight(1)
+	tw.SetColumnAlignRight(2)
+	for _, info := range infos {
+		w.Println(info.Name, info.Files, fmt.Sprintf("%dKiB", (info.Size+1023)/1024), info.Path)
+	}
+	return tw.Flush()
+}
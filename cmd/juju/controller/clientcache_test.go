@@ -0,0 +1,110 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ClientCacheSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	charmsDir string
+}
+
+var _ = gc.Suite(&ClientCacheSuite{})
+
+func (s *ClientCacheSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.charmsDir = c.MkDir()
+	s.PatchValue(&charmrepo.CacheDir, s.charmsDir)
+
+	writeCacheFile(c, jujuclient.JujuCookiePath("ctrl1"), "cookies")
+	writeCacheFile(c, jujuclient.JujuCookiePath("ctrl2"), "more cookies")
+	writeCacheFile(c, filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), "charm")
+}
+
+func writeCacheFile(c *gc.C, path, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ClientCacheSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"biscuits"},
+		err:  `cache name "biscuits" not valid`,
+	}, {
+		args: []string{"--controller", "ctrl1"},
+		err:  `--controller can only be used with --clear`,
+	}, {
+		args: []string{"--clear", "cookies", "charms"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(controller.NewClientCacheCommand(), test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ClientCacheSuite) TestShow(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewClientCacheCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- name: cookies
+  description: cookies and macaroons, per controller
+  path: `[1:]+jujuclient.JujuCookiesDir()+`
+  files: 2
+  size: 19
+- name: charms
+  description: charms downloaded from the charm store
+  path: `+s.charmsDir+`
+  files: 1
+  size: 5
+`)
+}
+
+func (s *ClientCacheSuite) TestClearAll(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewClientCacheCommand(), "--clear")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(jujuclient.JujuCookiePath("ctrl1"), jc.DoesNotExist)
+	c.Assert(jujuclient.JujuCookiePath("ctrl2"), jc.DoesNotExist)
+	c.Assert(filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), jc.DoesNotExist)
+	c.Assert(s.charmsDir, jc.IsDirectory)
+}
+
+func (s *ClientCacheSuite) TestClearNamed(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewClientCacheCommand(), "--clear", "charms")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(jujuclient.JujuCookiePath("ctrl1"), jc.IsNonEmptyFile)
+	c.Assert(filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), jc.DoesNotExist)
+}
+
+func (s *ClientCacheSuite) TestClearControllerCookies(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewClientCacheCommand(), "--clear", "--controller", "ctrl1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(jujuclient.JujuCookiePath("ctrl1"), jc.DoesNotExist)
+	c.Assert(jujuclient.JujuCookiePath("ctrl2"), jc.IsNonEmptyFile)
+	c.Assert(filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), jc.IsNonEmptyFile)
+}
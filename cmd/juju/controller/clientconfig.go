@@ -0,0 +1,71 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// readBundlePassphrase returns the passphrase used to encrypt or
+// decrypt a client config bundle. The passphrase is read from the
+// passphrase file if one is given, and otherwise prompted for; if
+// confirm is true the user is asked to enter it twice.
+func readBundlePassphrase(ctx *cmd.Context, passphraseFile string, confirm bool) (string, error) {
+	if passphraseFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(passphraseFile))
+		if err != nil {
+			return "", errors.Annotate(err, "cannot read passphrase")
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return "", errors.NotValidf("empty passphrase in %q", passphraseFile)
+		}
+		return passphrase, nil
+	}
+
+	reader := bufio.NewReader(byteAtATimeReader{ctx.Stdin})
+	passphrase, err := promptPassphrase(ctx, reader, "Enter passphrase: ")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if passphrase == "" {
+		return "", errors.New("you must enter a passphrase")
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+	verify, err := promptPassphrase(ctx, reader, "Confirm passphrase: ")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if passphrase != verify {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+func promptPassphrase(ctx *cmd.Context, reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Fprint(ctx.Stderr, prompt)
+	defer fmt.Fprintln(ctx.Stderr)
+	if f, ok := ctx.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		passphrase, err := terminal.ReadPassword(int(f.Fd()))
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(passphrase), nil
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ClientConfigSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store          *jujuclient.MemStore
+	dir            string
+	bundle         string
+	passphraseFile string
+}
+
+var _ = gc.Suite(&ClientConfigSuite{})
+
+func (s *ClientConfigSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["prod"] = jujuclient.ControllerDetails{
+		ControllerUUID: "prod-uuid",
+		APIEndpoints:   []string{"10.0.0.1:17070"},
+		CACert:         "prod-ca-cert",
+	}
+	s.store.CurrentControllerName = "prod"
+	s.store.Accounts["prod"] = jujuclient.AccountDetails{
+		User:     "admin",
+		Password: "hunter2",
+	}
+	s.dir = c.MkDir()
+	s.bundle = filepath.Join(s.dir, "client.bundle")
+	s.passphraseFile = filepath.Join(s.dir, "passphrase")
+	err := ioutil.WriteFile(s.passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ClientConfigSuite) export(c *gc.C) {
+	command := controller.NewExportClientConfigCommand(s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, s.bundle)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ClientConfigSuite) TestExportImport(c *gc.C) {
+	s.export(c)
+	data, err := ioutil.ReadFile(s.bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Not(jc.Contains), "hunter2")
+
+	target := jujuclient.NewMemStore()
+	command := controller.NewImportClientConfigCommand(target)
+	ctx, err := cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, s.bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Imported 1 controllers and 0 cloud credentials from "+s.bundle+"\n")
+	c.Assert(target.Controllers, jc.DeepEquals, s.store.Controllers)
+	c.Assert(target.Accounts, jc.DeepEquals, s.store.Accounts)
+	c.Assert(target.CurrentControllerName, gc.Equals, "prod")
+}
+
+func (s *ClientConfigSuite) runExportWithStdin(c *gc.C, stdin string) (*cmd.Context, error) {
+	command := controller.NewExportClientConfigCommand(s.store)
+	err := cmdtesting.InitCommand(command, []string{s.bundle})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	return ctx, command.Run(ctx)
+}
+
+func (s *ClientConfigSuite) TestExportPromptsForPassphrase(c *gc.C) {
+	ctx, err := s.runExportWithStdin(c, "sekrit\nsekrit\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.HasPrefix, "Enter passphrase: \nConfirm passphrase: \n")
+
+	target := jujuclient.NewMemStore()
+	command := controller.NewImportClientConfigCommand(target)
+	_, err = cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, s.bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Controllers, gc.HasLen, 1)
+}
+
+func (s *ClientConfigSuite) TestExportPassphraseMismatch(c *gc.C) {
+	_, err := s.runExportWithStdin(c, "sekrit\nsecret\n")
+	c.Assert(err, gc.ErrorMatches, "passphrases do not match")
+	c.Assert(s.bundle, jc.DoesNotExist)
+}
+
+func (s *ClientConfigSuite) TestImportWrongPassphrase(c *gc.C) {
+	s.export(c)
+	err := ioutil.WriteFile(s.passphraseFile, []byte("wrong\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := controller.NewImportClientConfigCommand(jujuclient.NewMemStore())
+	_, err = cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, s.bundle)
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt client config bundle: wrong passphrase or corrupt bundle")
+}
+
+func (s *ClientConfigSuite) TestImportControllerExists(c *gc.C) {
+	s.export(c)
+	command := controller.NewImportClientConfigCommand(s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, s.bundle)
+	c.Assert(err, gc.ErrorMatches, "use --replace to replace controllers already known: controller prod already exists")
+
+	command = controller.NewImportClientConfigCommand(s.store)
+	_, err = cmdtesting.RunCommand(c, command, "--passphrase-file", s.passphraseFile, "--replace", s.bundle)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ClientConfigSuite) TestInitNoFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewExportClientConfigCommand(s.store))
+	c.Assert(err, gc.ErrorMatches, "no bundle filename specified")
+	_, err = cmdtesting.RunCommand(c, controller.NewImportClientConfigCommand(s.store))
+	c.Assert(err, gc.ErrorMatches, "no bundle filename specified")
+}
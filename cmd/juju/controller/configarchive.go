@@ -0,0 +1,341 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	cloudapi "github.com/juju/juju/api/cloud"
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/api/spaces"
+	"github.com/juju/juju/api/usermanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	// controllerArchiveVersion is the version of the controller
+	// configuration archive format written by this client.
+	controllerArchiveVersion = 1
+
+	archiveContentName   = "controller-config.yaml"
+	archiveSignatureName = "controller-config.yaml.hmac-sha256"
+
+	// minSigningKeySize is the minimum size of the key used to
+	// sign controller configuration archives.
+	minSigningKeySize = 16
+)
+
+// controllerArchive holds the configuration of a controller that is
+// not tied to any of its hosted models, so that it can be replayed
+// onto a freshly bootstrapped controller.
+type controllerArchive struct {
+	Version          int                              `yaml:"version"`
+	ControllerName   string                           `yaml:"controller-name"`
+	ControllerUUID   string                           `yaml:"controller-uuid"`
+	ExportedAt       time.Time                        `yaml:"exported-at"`
+	ControllerConfig map[string]interface{}           `yaml:"controller-config"`
+	ModelDefaults    map[string]archivedModelDefaults `yaml:"model-defaults,omitempty"`
+	Clouds           map[string]archivedCloud         `yaml:"clouds,omitempty"`
+	Users            []archivedUser                   `yaml:"users,omitempty"`
+	Spaces           []archivedSpace                  `yaml:"spaces,omitempty"`
+}
+
+// archivedModelDefaults holds the model defaults set for a cloud.
+type archivedModelDefaults struct {
+	Controller map[string]interface{}            `yaml:"controller,omitempty"`
+	Regions    map[string]map[string]interface{} `yaml:"regions,omitempty"`
+}
+
+// archivedCloud holds the definition of a cloud, and references to
+// the credentials that were uploaded for it. Credential secrets are
+// not exported, so credentials must be added to the new controller
+// again.
+type archivedCloud struct {
+	Definition  string   `yaml:"definition"`
+	Credentials []string `yaml:"credentials,omitempty"`
+}
+
+// archivedUser holds a user and its access to the controller.
+type archivedUser struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display-name,omitempty"`
+	Access      string `yaml:"access"`
+	Disabled    bool   `yaml:"disabled,omitempty"`
+}
+
+// archivedSpace holds a space in the controller model.
+type archivedSpace struct {
+	Name    string   `yaml:"name"`
+	Subnets []string `yaml:"subnets,omitempty"`
+}
+
+// readSigningKey reads the key used to sign and verify controller
+// configuration archives from the named file.
+func readSigningKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("no signing key file specified, see --key-file")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read signing key")
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) < minSigningKeySize {
+		return nil, errors.NotValidf("signing key shorter than %d bytes", minSigningKeySize)
+	}
+	return key, nil
+}
+
+func signArchiveContent(content, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeControllerArchive writes the archive to w as a gzipped tarball
+// holding the archive's content and its signature.
+func writeControllerArchive(w io.Writer, archive *controllerArchive, key []byte) error {
+	content, err := yaml.Marshal(archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	signature := signArchiveContent(content, key)
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{archiveContentName, content},
+		{archiveSignatureName, []byte(signature + "\n")},
+	} {
+		hdr := &tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.data)),
+			ModTime: archive.ExportedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// readControllerArchive reads an archive written by
+// writeControllerArchive, returning an error if its signature
+// does not match the key.
+func readControllerArchive(r io.Reader, key []byte) (*controllerArchive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading controller config archive")
+	}
+	defer gzr.Close()
+
+	var content []byte
+	var signature string
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading controller config archive")
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading controller config archive")
+		}
+		switch hdr.Name {
+		case archiveContentName:
+			content = data
+		case archiveSignatureName:
+			signature = strings.TrimSpace(string(data))
+		}
+	}
+	if content == nil || signature == "" {
+		return nil, errors.NotValidf("controller config archive without content or signature")
+	}
+	if !hmac.Equal([]byte(signature), []byte(signArchiveContent(content, key))) {
+		return nil, errors.New("controller config archive signature does not match the signing key")
+	}
+
+	var archive controllerArchive
+	if err := yaml.Unmarshal(content, &archive); err != nil {
+		return nil, errors.Annotate(err, "parsing controller config archive")
+	}
+	if archive.Version != controllerArchiveVersion {
+		return nil, errors.NotSupportedf("controller config archive version %d", archive.Version)
+	}
+	return &archive, nil
+}
+
+// controllerArchiveAPI provides the methods used to export and
+// import controller configuration archives.
+type controllerArchiveAPI interface {
+	Close() error
+
+	ControllerConfig() (controller.Config, error)
+	ConfigSet(values map[string]interface{}) error
+
+	Clouds() (map[names.CloudTag]jujucloud.Cloud, error)
+	AddCloud(cloud jujucloud.Cloud) error
+	UserCredentials(user names.UserTag, cloud names.CloudTag) ([]names.CloudCredentialTag, error)
+	Credentials(tags ...names.CloudCredentialTag) ([]params.CloudCredentialResult, error)
+
+	ModelDefaults(cloud string) (config.ModelDefaultAttributes, error)
+	SetModelDefaults(cloud, region string, config map[string]interface{}) error
+
+	UserInfo(usernames []string, all usermanager.IncludeDisabled) ([]params.UserInfo, error)
+	AddUser(username, displayName, password string) (names.UserTag, []byte, error)
+	DisableUser(username string) error
+	GrantController(user, access string) error
+
+	ListSpaces() ([]params.Space, error)
+	CreateSpace(name string, subnetIds []string, public bool) error
+}
+
+// controllerArchiveClient implements controllerArchiveAPI using the
+// facades of a controller connection, and of a connection to the
+// controller model for its spaces.
+type controllerArchiveClient struct {
+	controllerClient *apicontroller.Client
+	cloudClient      *cloudapi.Client
+	modelManager     *modelmanager.Client
+	userManager      *usermanager.Client
+	spaces           *spaces.API
+
+	controllerRoot api.Connection
+	modelRoot      api.Connection
+}
+
+// newControllerArchiveClient returns a controllerArchiveAPI for the
+// controller that the command is connected to.
+func newControllerArchiveClient(c *modelcmd.ControllerCommandBase) (controllerArchiveAPI, error) {
+	controllerRoot, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelRoot, err := c.NewModelAPIRoot(bootstrap.ControllerModelName)
+	if err != nil {
+		controllerRoot.Close()
+		return nil, errors.Trace(err)
+	}
+	return &controllerArchiveClient{
+		controllerClient: apicontroller.NewClient(controllerRoot),
+		cloudClient:      cloudapi.NewClient(controllerRoot),
+		modelManager:     modelmanager.NewClient(controllerRoot),
+		userManager:      usermanager.NewClient(controllerRoot),
+		spaces:           spaces.NewAPI(modelRoot),
+		controllerRoot:   controllerRoot,
+		modelRoot:        modelRoot,
+	}, nil
+}
+
+// Close is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) Close() error {
+	err := c.modelRoot.Close()
+	if err2 := c.controllerRoot.Close(); err == nil {
+		err = err2
+	}
+	return errors.Trace(err)
+}
+
+// ControllerConfig is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) ControllerConfig() (controller.Config, error) {
+	return c.controllerClient.ControllerConfig()
+}
+
+// ConfigSet is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) ConfigSet(values map[string]interface{}) error {
+	return c.controllerClient.ConfigSet(values)
+}
+
+// GrantController is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) GrantController(user, access string) error {
+	return c.controllerClient.GrantController(user, access)
+}
+
+// Clouds is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) Clouds() (map[names.CloudTag]jujucloud.Cloud, error) {
+	return c.cloudClient.Clouds()
+}
+
+// AddCloud is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) AddCloud(cloud jujucloud.Cloud) error {
+	return c.cloudClient.AddCloud(cloud)
+}
+
+// UserCredentials is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) UserCredentials(user names.UserTag, cloud names.CloudTag) ([]names.CloudCredentialTag, error) {
+	return c.cloudClient.UserCredentials(user, cloud)
+}
+
+// Credentials is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) Credentials(tags ...names.CloudCredentialTag) ([]params.CloudCredentialResult, error) {
+	return c.cloudClient.Credentials(tags...)
+}
+
+// ModelDefaults is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) ModelDefaults(cloud string) (config.ModelDefaultAttributes, error) {
+	return c.modelManager.ModelDefaults(cloud)
+}
+
+// SetModelDefaults is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) SetModelDefaults(cloud, region string, config map[string]interface{}) error {
+	return c.modelManager.SetModelDefaults(cloud, region, config)
+}
+
+// UserInfo is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) UserInfo(usernames []string, all usermanager.IncludeDisabled) ([]params.UserInfo, error) {
+	return c.userManager.UserInfo(usernames, all)
+}
+
+// AddUser is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) AddUser(username, displayName, password string) (names.UserTag, []byte, error) {
+	return c.userManager.AddUser(username, displayName, password)
+}
+
+// DisableUser is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) DisableUser(username string) error {
+	return c.userManager.DisableUser(username)
+}
+
+// ListSpaces is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) ListSpaces() ([]params.Space, error) {
+	return c.spaces.ListSpaces()
+}
+
+// CreateSpace is part of the controllerArchiveAPI interface.
+func (c *controllerArchiveClient) CreateSpace(name string, subnetIds []string, public bool) error {
+	return c.spaces.CreateSpace(name, subnetIds, public)
+}
@@ -0,0 +1,317 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/usermanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/controller"
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
+)
+
+type controllerConfigArchiveSuite struct {
+	baseControllerSuite
+	store   *jujuclient.MemStore
+	dir     string
+	keyFile string
+	source  *fakeControllerArchiveAPI
+	target  *fakeControllerArchiveAPI
+}
+
+var _ = gc.Suite(&controllerConfigArchiveSuite{})
+
+func (s *controllerConfigArchiveSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"10.0.0.1:17070"},
+	}
+
+	s.dir = c.MkDir()
+	s.keyFile = filepath.Join(s.dir, "dr.key")
+	err := ioutil.WriteFile(s.keyFile, []byte("0123456789abcdef0123456789abcdef\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.source = &fakeControllerArchiveAPI{
+		config: jujucontroller.Config{
+			jujucontroller.ControllerUUIDKey: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			jujucontroller.APIPort:           17070,
+			jujucontroller.MaxLogsAge:        "24h",
+		},
+		clouds: map[names.CloudTag]jujucloud.Cloud{
+			names.NewCloudTag("prod-maas"): {
+				Name:      "prod-maas",
+				Type:      "maas",
+				AuthTypes: jujucloud.AuthTypes{jujucloud.OAuth1AuthType},
+				Endpoint:  "http://maas.example.com/MAAS",
+			},
+		},
+		credentials: map[string][]names.CloudCredentialTag{
+			"admin": {names.NewCloudCredentialTag("prod-maas/admin/default")},
+		},
+		defaults: map[string]config.ModelDefaultAttributes{
+			"prod-maas": {
+				"http-proxy": {Default: "", Controller: "http://proxy:3128"},
+				"ftp-proxy":  {Default: ""},
+				"no-proxy": {Regions: []config.RegionDefaultValue{
+					{Name: "default", Value: "10.0.0.0/8"},
+				}},
+			},
+		},
+		users: []params.UserInfo{
+			{Username: "admin", Access: "superuser"},
+			{Username: "bob", DisplayName: "Bob", Access: "login", Disabled: true},
+			{Username: "mary@external", Access: "add-model"},
+		},
+		spaces: []params.Space{
+			{Name: "db", Subnets: []params.Subnet{{CIDR: "10.1.0.0/24"}, {CIDR: "10.2.0.0/24"}}},
+		},
+	}
+	s.target = &fakeControllerArchiveAPI{
+		config: jujucontroller.Config{
+			jujucontroller.ControllerUUIDKey: "f00dcafe-0bad-400d-8000-4b1d0d06f00d",
+			jujucontroller.APIPort:           17071,
+		},
+		clouds: make(map[names.CloudTag]jujucloud.Cloud),
+		users:  []params.UserInfo{{Username: "admin", Access: "superuser"}},
+	}
+}
+
+func (s *controllerConfigArchiveSuite) export(c *gc.C) string {
+	path := filepath.Join(s.dir, "controller.tar.gz")
+	ctx, err := cmdtesting.RunCommand(c, controller.NewExportConfigCommandForTest(s.source, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, `Exported the configuration of controller "fake", with 1 clouds, 3 users and 1 spaces, to .*\n`)
+	c.Check(s.source.closed, jc.IsTrue)
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+	return path
+}
+
+func (s *controllerConfigArchiveSuite) TestExportRequiresKeyFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewExportConfigCommandForTest(s.source, s.store), "controller.tar.gz")
+	c.Assert(err, gc.ErrorMatches, `no signing key file specified, see --key-file`)
+}
+
+func (s *controllerConfigArchiveSuite) TestExportShortKey(c *gc.C) {
+	err := ioutil.WriteFile(s.keyFile, []byte("short\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, controller.NewExportConfigCommandForTest(s.source, s.store), "--key-file", s.keyFile, "controller.tar.gz")
+	c.Assert(err, gc.ErrorMatches, `signing key shorter than 16 bytes not valid`)
+}
+
+func (s *controllerConfigArchiveSuite) TestExportNoFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewExportConfigCommandForTest(s.source, s.store), "--key-file", s.keyFile)
+	c.Assert(err, gc.ErrorMatches, `no archive filename specified`)
+}
+
+func (s *controllerConfigArchiveSuite) TestImport(c *gc.C) {
+	path := s.export(c)
+
+	ctx, err := cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.target.closed, jc.IsTrue)
+	token, err := jujuclient.RegistrationInfo{
+		User:           "bob",
+		Addrs:          []string{"10.0.0.1:17070"},
+		SecretKey:      []byte("secret-bob"),
+		ControllerName: "fake",
+	}.Encode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+controller config: set max-logs-age
+controller config: not changed, as they can only be set at bootstrap: api-port
+cloud "prod-maas": added
+cloud "prod-maas": credentials to add again: prod-maas/admin/default
+model defaults for cloud "prod-maas": set http-proxy
+model defaults for region "default" of cloud "prod-maas": set no-proxy
+user "bob": added, send this command to bob:
+    juju register `+token+`
+user "mary@external": granted add-model access
+space "db": added
+`[1:])
+
+	c.Check(s.target.config[jujucontroller.MaxLogsAge], gc.Equals, "24h")
+	c.Check(s.target.config[jujucontroller.APIPort], gc.Equals, 17071)
+	c.Check(s.target.clouds[names.NewCloudTag("prod-maas")].Endpoint, gc.Equals, "http://maas.example.com/MAAS")
+	c.Check(s.target.setDefaults, jc.DeepEquals, []string{"prod-maas/", "prod-maas/default"})
+	c.Check(s.target.disabled, jc.DeepEquals, []string{"bob"})
+	c.Check(s.target.grants, jc.DeepEquals, []string{"mary@external:add-model"})
+	c.Check(s.target.spaces, jc.DeepEquals, []params.Space{{Name: "db"}})
+	c.Check(s.target.spaceSubnets, jc.DeepEquals, [][]string{{"10.1.0.0/24", "10.2.0.0/24"}})
+}
+
+func (s *controllerConfigArchiveSuite) TestImportIsRepeatable(c *gc.C) {
+	path := s.export(c)
+	_, err := cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.target.credentials = map[string][]names.CloudCredentialTag{
+		"admin": {names.NewCloudCredentialTag("prod-maas/admin/default")},
+	}
+	ctx, err := cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+controller config: not changed, as they can only be set at bootstrap: api-port
+model defaults for cloud "prod-maas": set http-proxy
+model defaults for region "default" of cloud "prod-maas": set no-proxy
+`[1:])
+}
+
+func (s *controllerConfigArchiveSuite) TestImportReportsFailures(c *gc.C) {
+	path := s.export(c)
+	s.target.addCloudErr = errors.New("boom")
+
+	ctx, err := cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, `ERROR adding cloud "prod-maas": boom`)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `space "db": added`)
+}
+
+func (s *controllerConfigArchiveSuite) TestImportWrongKey(c *gc.C) {
+	path := s.export(c)
+	err := ioutil.WriteFile(s.keyFile, []byte("fedcba9876543210fedcba9876543210\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, gc.ErrorMatches, `controller config archive signature does not match the signing key`)
+	c.Check(s.target.closed, jc.IsFalse)
+}
+
+func (s *controllerConfigArchiveSuite) TestImportNotAnArchive(c *gc.C) {
+	path := filepath.Join(s.dir, "controller.tar.gz")
+	err := ioutil.WriteFile(path, []byte("not an archive"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, controller.NewImportConfigCommandForTest(s.target, s.store), "--key-file", s.keyFile, path)
+	c.Assert(err, gc.ErrorMatches, `reading controller config archive: .*`)
+}
+
+type fakeControllerArchiveAPI struct {
+	config       jujucontroller.Config
+	clouds       map[names.CloudTag]jujucloud.Cloud
+	credentials  map[string][]names.CloudCredentialTag
+	defaults     map[string]config.ModelDefaultAttributes
+	users        []params.UserInfo
+	spaces       []params.Space
+	addCloudErr  error
+	closed       bool
+	setDefaults  []string
+	disabled     []string
+	grants       []string
+	spaceSubnets [][]string
+}
+
+func (f *fakeControllerArchiveAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) ControllerConfig() (jujucontroller.Config, error) {
+	return f.config, nil
+}
+
+func (f *fakeControllerArchiveAPI) ConfigSet(values map[string]interface{}) error {
+	for key, value := range values {
+		f.config[key] = value
+	}
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) Clouds() (map[names.CloudTag]jujucloud.Cloud, error) {
+	return f.clouds, nil
+}
+
+func (f *fakeControllerArchiveAPI) AddCloud(cloud jujucloud.Cloud) error {
+	if f.addCloudErr != nil {
+		return f.addCloudErr
+	}
+	f.clouds[names.NewCloudTag(cloud.Name)] = cloud
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) UserCredentials(user names.UserTag, cloud names.CloudTag) ([]names.CloudCredentialTag, error) {
+	var result []names.CloudCredentialTag
+	for _, tag := range f.credentials[user.Id()] {
+		if tag.Cloud() == cloud {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeControllerArchiveAPI) Credentials(tags ...names.CloudCredentialTag) ([]params.CloudCredentialResult, error) {
+	results := make([]params.CloudCredentialResult, len(tags))
+	for n, tag := range tags {
+		results[n].Error = &params.Error{Code: params.CodeNotFound, Message: "not found"}
+		for _, existing := range f.credentials[tag.Owner().Id()] {
+			if existing == tag {
+				results[n] = params.CloudCredentialResult{Result: &params.CloudCredential{}}
+			}
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeControllerArchiveAPI) ModelDefaults(cloud string) (config.ModelDefaultAttributes, error) {
+	return f.defaults[cloud], nil
+}
+
+func (f *fakeControllerArchiveAPI) SetModelDefaults(cloud, region string, values map[string]interface{}) error {
+	f.setDefaults = append(f.setDefaults, cloud+"/"+region)
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) UserInfo(usernames []string, all usermanager.IncludeDisabled) ([]params.UserInfo, error) {
+	return f.users, nil
+}
+
+func (f *fakeControllerArchiveAPI) AddUser(username, displayName, password string) (names.UserTag, []byte, error) {
+	f.users = append(f.users, params.UserInfo{Username: username, DisplayName: displayName, Access: "login"})
+	return names.NewUserTag(username), []byte("secret-" + username), nil
+}
+
+func (f *fakeControllerArchiveAPI) DisableUser(username string) error {
+	f.disabled = append(f.disabled, username)
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) GrantController(user, access string) error {
+	f.grants = append(f.grants, user+":"+access)
+	for n := range f.users {
+		if f.users[n].Username == user {
+			f.users[n].Access = access
+			return nil
+		}
+	}
+	f.users = append(f.users, params.UserInfo{Username: user, Access: access})
+	return nil
+}
+
+func (f *fakeControllerArchiveAPI) ListSpaces() ([]params.Space, error) {
+	return f.spaces, nil
+}
+
+func (f *fakeControllerArchiveAPI) CreateSpace(name string, subnetIds []string, public bool) error {
+	f.spaces = append(f.spaces, params.Space{Name: name})
+	f.spaceSubnets = append(f.spaceSubnets, subnetIds)
+	return nil
+}
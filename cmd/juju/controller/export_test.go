@@ -93,6 +93,46 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewVerifyCommandForTest returns a verifyCommand with the API
+// mocked out.
+func NewVerifyCommandForTest(api verifyAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &verifyCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewFeaturesCommandForTest returns a featuresCommand with the API
+// mocked out.
+func NewFeaturesCommandForTest(api featuresAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &featuresCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewExportConfigCommandForTest returns an exportConfigCommand with
+// the API mocked out.
+func NewExportConfigCommandForTest(api controllerArchiveAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &exportConfigCommand{
+		newAPIFunc: func() (controllerArchiveAPI, error) { return api, nil },
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewImportConfigCommandForTest returns an importConfigCommand with
+// the API mocked out.
+func NewImportConfigCommandForTest(api controllerArchiveAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &importConfigCommand{
+		newAPIFunc: func() (controllerArchiveAPI, error) { return api, nil },
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewExportClientConfigCommand returns a command that exports the
+// client configuration to an encrypted bundle.
+func NewExportClientConfigCommand(store jujuclient.ClientStore) cmd.Command {
+	if store == nil {
+		panic("valid store must be specified")
+	}
+	return modelcmd.WrapBase(&exportClientConfigCommand{store: store})
+}
+
+type exportClientConfigCommand struct {
+	modelcmd.CommandBase
+	store jujuclient.ClientStore
+
+	filename           string
+	passphraseFile     string
+	includeCredentials bool
+}
+
+const exportClientConfigDoc = `
+Exports the controllers, models and accounts known to this client to a
+single bundle, so that the client setup can be moved to another machine
+with "juju import-client-config". With --include-credentials, the cloud
+credentials known to the client are also exported.
+
+The bundle holds passwords, and possibly credentials, so it is
+encrypted with a passphrase. The passphrase is prompted for, or read
+from the file given by --passphrase-file, and the same passphrase must
+be given when importing the bundle.
+
+Examples:
+
+    juju export-client-config juju-client.bundle
+    juju export-client-config --include-credentials juju-client.bundle
+    juju export-client-config --passphrase-file ~/bundle.pass juju-client.bundle
+
+See also:
+    import-client-config
+    controllers
+    credentials
+`
+
+// Info implements Command.Info.
+func (c *exportClientConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-client-config",
+		Args:    "<filename>",
+		Purpose: "Exports the client configuration to an encrypted bundle.",
+		Doc:     exportClientConfigDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportClientConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.includeCredentials, "include-credentials", false, "Also export the cloud credentials known to the client")
+	f.StringVar(&c.passphraseFile, "passphrase-file", "", "The file holding the passphrase used to encrypt the bundle")
+}
+
+// Init implements Command.Init.
+func (c *exportClientConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle filename specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *exportClientConfigCommand) Run(ctx *cmd.Context) error {
+	cfg, err := jujuclient.ExportClientConfig(c.store, c.includeCredentials)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	passphrase, err := readBundlePassphrase(ctx, c.passphraseFile, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := jujuclient.EncryptClientConfig(cfg, passphrase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.filename), data, 0600); err != nil {
+		return errors.Annotate(err, "writing client config bundle")
+	}
+	ctx.Infof(
+		"Exported %d controllers and %d cloud credentials to %s",
+		len(cfg.Controllers.Controllers), len(cfg.Credentials), c.filename,
+	)
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/usermanager"
+	jujucloud "github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+)
+
+// NewExportConfigCommand returns a command that exports the
+// configuration of a controller to a signed archive.
+func NewExportConfigCommand() cmd.Command {
+	return modelcmd.WrapController(&exportConfigCommand{})
+}
+
+type exportConfigCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (controllerArchiveAPI, error)
+
+	filename string
+	keyFile  string
+}
+
+const exportConfigDoc = `
+Exports the configuration of a controller that is not held by any of
+its models to a signed archive, so that it can be replayed onto a new
+controller with "juju import-controller-config" when rebuilding after
+a disaster. The archive holds:
+
+    - the controller config
+    - the model defaults of each cloud
+    - the clouds, and references to the credentials uploaded for them
+    - the users, and their access to the controller
+    - the spaces of the controller model
+
+Credential secrets and user passwords are not exported. The archive is
+signed with the key in the file given by --key-file, and the same key
+must be given when importing it. Keep the key separately from the
+archive. Only controller administrators may run this command.
+
+Examples:
+
+    juju export-controller-config --key-file ~/dr.key controller.tar.gz
+    juju export-controller-config -c prod --key-file ~/dr.key prod.tar.gz
+
+See also:
+    import-controller-config
+    controller-config
+    create-backup
+`
+
+// Info implements Command.Info.
+func (c *exportConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-controller-config",
+		Args:    "<filename>",
+		Purpose: "Exports the configuration of a controller to a signed archive.",
+		Doc:     exportConfigDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.keyFile, "key-file", "", "The file holding the key used to sign the archive")
+}
+
+// Init implements Command.Init.
+func (c *exportConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive filename specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *exportConfigCommand) getAPI() (controllerArchiveAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return newControllerArchiveClient(&c.ControllerCommandBase)
+}
+
+// Run implements Command.Run.
+func (c *exportConfigCommand) Run(ctx *cmd.Context) error {
+	key, err := readSigningKey(ctx.AbsPath(c.keyFile))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	archive, err := exportControllerArchive(client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	archive.ControllerName = controllerName
+	archive.ExportedAt = time.Now().UTC()
+
+	var buf bytes.Buffer
+	if err := writeControllerArchive(&buf, archive, key); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(ctx.AbsPath(c.filename), buf.Bytes(), 0600); err != nil {
+		return errors.Annotate(err, "writing controller config archive")
+	}
+	ctx.Infof(
+		"Exported the configuration of controller %q, with %d clouds, %d users and %d spaces, to %s",
+		controllerName, len(archive.Clouds), len(archive.Users), len(archive.Spaces), c.filename,
+	)
+	return nil
+}
+
+// exportControllerArchive reads the configuration of the
+// controller into a new archive.
+func exportControllerArchive(client controllerArchiveAPI) (*controllerArchive, error) {
+	cfg, err := client.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading controller config")
+	}
+	archive := &controllerArchive{
+		Version:          controllerArchiveVersion,
+		ControllerUUID:   cfg.ControllerUUID(),
+		ControllerConfig: cfg,
+		ModelDefaults:    make(map[string]archivedModelDefaults),
+		Clouds:           make(map[string]archivedCloud),
+	}
+
+	users, err := client.UserInfo(nil, usermanager.AllUsers)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading users")
+	}
+	for _, user := range users {
+		archive.Users = append(archive.Users, archivedUser{
+			Name:        user.Username,
+			DisplayName: user.DisplayName,
+			Access:      user.Access,
+			Disabled:    user.Disabled,
+		})
+	}
+	sort.Slice(archive.Users, func(i, j int) bool {
+		return archive.Users[i].Name < archive.Users[j].Name
+	})
+
+	clouds, err := client.Clouds()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading clouds")
+	}
+	for tag, cloud := range clouds {
+		definition, err := jujucloud.MarshalCloud(cloud)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cloud %q", tag.Id())
+		}
+		var credentials []string
+		for _, user := range users {
+			tags, err := client.UserCredentials(names.NewUserTag(user.Username), tag)
+			if err != nil {
+				return nil, errors.Annotatef(err, "reading credentials of %q for cloud %q", user.Username, tag.Id())
+			}
+			for _, credTag := range tags {
+				credentials = append(credentials, credTag.Id())
+			}
+		}
+		sort.Strings(credentials)
+		archive.Clouds[tag.Id()] = archivedCloud{
+			Definition:  string(definition),
+			Credentials: credentials,
+		}
+
+		defaults, err := client.ModelDefaults(tag.Id())
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading model defaults for cloud %q", tag.Id())
+		}
+		if cloudDefaults, ok := exportModelDefaults(defaults); ok {
+			archive.ModelDefaults[tag.Id()] = cloudDefaults
+		}
+	}
+
+	spaces, err := client.ListSpaces()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading spaces")
+	}
+	for _, space := range spaces {
+		archived := archivedSpace{Name: space.Name}
+		for _, subnet := range space.Subnets {
+			archived.Subnets = append(archived.Subnets, subnet.CIDR)
+		}
+		archive.Spaces = append(archive.Spaces, archived)
+	}
+	return archive, nil
+}
+
+// exportModelDefaults returns the model defaults set by the
+// controller's operators, ignoring the built in defaults.
+func exportModelDefaults(defaults map[string]config.AttributeDefaultValues) (archivedModelDefaults, bool) {
+	var result archivedModelDefaults
+	for key, value := range defaults {
+		if value.Controller != nil {
+			if result.Controller == nil {
+				result.Controller = make(map[string]interface{})
+			}
+			result.Controller[key] = value.Controller
+		}
+		for _, region := range value.Regions {
+			if result.Regions == nil {
+				result.Regions = make(map[string]map[string]interface{})
+			}
+			if result.Regions[region.Name] == nil {
+				result.Regions[region.Name] = make(map[string]interface{})
+			}
+			result.Regions[region.Name][key] = region.Value
+		}
+	}
+	return result, result.Controller != nil || result.Regions != nil
+}
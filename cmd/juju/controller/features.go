@@ -0,0 +1,203 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/controllerfeatures"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewFeaturesCommand returns a command that lists, enables and
+// disables the feature flags of a running controller.
+func NewFeaturesCommand() cmd.Command {
+	return modelcmd.WrapController(&featuresCommand{})
+}
+
+type featuresCommand struct {
+	modelcmd.ControllerCommandBase
+	api featuresAPI
+	out cmd.Output
+
+	action   string
+	features []string
+}
+
+type featuresAPI interface {
+	Close() error
+	Features() (params.ControllerFeaturesResult, error)
+	SetFeatures(enabled bool, features ...string) error
+}
+
+const featuresDoc = `
+Feature flags enable functionality that is experimental or not yet
+enabled by default. Flags enabled on the controller take effect
+immediately, without restarting the controller or setting
+JUJU_DEV_FEATURE_FLAGS in its environment.
+
+With no arguments, the flags enabled on the controller are listed.
+Controller administrators are also shown the history of changes to
+them, showing which user enabled or disabled each flag and when.
+
+"enable" and "disable" turn the given flags on or off, as setting the
+"features" controller config does, except that each change is recorded
+against the user making it. Only controller administrators may change
+the flags.
+
+Examples:
+
+    juju controller-features
+    juju controller-features enable cmr-aware-bundles
+    juju controller-features disable cmr-aware-bundles legacy-leases
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *featuresCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-features",
+		Args:    "[enable|disable <flag> ...]",
+		Purpose: "Lists, enables or disables feature flags on a controller.",
+		Doc:     featuresDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *featuresCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFeaturesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *featuresCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	c.action, c.features = args[0], args[1:]
+	switch c.action {
+	case "enable", "disable":
+	default:
+		return errors.Errorf("unknown action %q, expected enable or disable", c.action)
+	}
+	if len(c.features) == 0 {
+		return errors.Errorf("no feature flags specified to %s", c.action)
+	}
+	return nil
+}
+
+func (c *featuresCommand) getAPI() (featuresAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controllerfeatures.NewClient(root), nil
+}
+
+// controllerFeatures is the serialisation format for the feature
+// flags enabled on a controller.
+type controllerFeatures struct {
+	Features []string        `yaml:"features" json:"features"`
+	Changes  []featureChange `yaml:"changes,omitempty" json:"changes,omitempty"`
+}
+
+// featureChange is the serialisation format for a change to a
+// controller feature flag.
+type featureChange struct {
+	Feature string    `yaml:"feature" json:"feature"`
+	Enabled bool      `yaml:"enabled" json:"enabled"`
+	User    string    `yaml:"user" json:"user"`
+	When    time.Time `yaml:"when" json:"when"`
+}
+
+// Run implements Command.Run.
+func (c *featuresCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.action != "" {
+		enabled := c.action == "enable"
+		if err := client.SetFeatures(enabled, c.features...); err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	result, err := client.Features()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	features := controllerFeatures{
+		Features: result.Features,
+	}
+	if features.Features == nil {
+		features.Features = []string{}
+	}
+	for _, change := range result.Changes {
+		features.Changes = append(features.Changes, featureChange{
+			Feature: change.Feature,
+			Enabled: change.Enabled,
+			User:    change.User,
+			When:    change.When,
+		})
+	}
+	return c.out.Write(ctx, features)
+}
+
+func formatFeaturesTabular(writer io.Writer, value interface{}) error {
+	features, ok := value.(controllerFeatures)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", features, value)
+	}
+	if len(features.Features) == 0 {
+		fmt.Fprintln(writer, "No feature flags are enabled.")
+	} else {
+		tw := output.TabWriter(writer)
+		w := output.Wrapper{tw}
+		w.Println("Feature")
+		for _, feature := range features.Features {
+			w.Println(feature)
+		}
+		if err := tw.Flush(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(features.Changes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(writer)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Changed", "Feature", "User", "When")
+	for _, change := range features.Changes {
+		action := "disabled"
+		if change.Enabled {
+			action = "enabled"
+		}
+		w.Println(action, change.Feature, change.User, change.When.UTC().Format(time.RFC3339))
+	}
+	return errors.Trace(tw.Flush())
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type featuresSuite struct {
+	baseControllerSuite
+	api   *fakeFeaturesAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&featuresSuite{})
+
+func (s *featuresSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeFeaturesAPI{
+		result: params.ControllerFeaturesResult{
+			Features: []string{"cmr-aware-bundles", "legacy-leases"},
+			Changes: []params.ControllerFeatureChange{{
+				Feature: "legacy-leases",
+				Enabled: true,
+				User:    "admin",
+				When:    time.Date(2019, 5, 2, 9, 30, 0, 0, time.UTC),
+			}, {
+				Feature: "cmr-aware-bundles",
+				Enabled: true,
+				User:    "bob",
+				When:    time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *featuresSuite) newCommand() cmd.Command {
+	return controller.NewFeaturesCommandForTest(s.api, s.store)
+}
+
+func (s *featuresSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   []string{"toggle", "foo"},
+		expect: `unknown action "toggle", expected enable or disable`,
+	}, {
+		args:   []string{"enable"},
+		expect: "no feature flags specified to enable",
+	}} {
+		c.Logf("test %d", i)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *featuresSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Feature\n"+
+		"cmr-aware-bundles\n"+
+		"legacy-leases\n"+
+		"\n"+
+		"Changed  Feature            User   When\n"+
+		"enabled  legacy-leases      admin  2019-05-02T09:30:00Z\n"+
+		"enabled  cmr-aware-bundles  bob    2019-05-01T12:00:00Z\n")
+}
+
+func (s *featuresSuite) TestListNoFeatures(c *gc.C) {
+	s.api.result = params.ControllerFeaturesResult{}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "No feature flags are enabled.\n")
+}
+
+func (s *featuresSuite) TestListYaml(c *gc.C) {
+	s.api.result.Changes = s.api.result.Changes[1:]
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+features:
+- cmr-aware-bundles
+- legacy-leases
+changes:
+- feature: cmr-aware-bundles
+  enabled: true
+  user: bob
+  when: 2019-05-01T12:00:00Z
+`[1:])
+}
+
+func (s *featuresSuite) TestEnable(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "enable", "cmr-aware-bundles", "generations")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.enabled, jc.IsTrue)
+	c.Assert(s.api.features, jc.DeepEquals, []string{"cmr-aware-bundles", "generations"})
+}
+
+func (s *featuresSuite) TestDisable(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "disable", "legacy-leases")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.enabled, jc.IsFalse)
+	c.Assert(s.api.features, jc.DeepEquals, []string{"legacy-leases"})
+}
+
+func (s *featuresSuite) TestAPIError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "enable", "generations")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeFeaturesAPI struct {
+	result   params.ControllerFeaturesResult
+	err      error
+	enabled  bool
+	features []string
+}
+
+func (f *fakeFeaturesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeFeaturesAPI) Features() (params.ControllerFeaturesResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeFeaturesAPI) SetFeatures(enabled bool, features ...string) error {
+	f.enabled = enabled
+	f.features = features
+	return f.err
+}
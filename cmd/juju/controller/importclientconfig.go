@@ -0,0 +1,111 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewImportClientConfigCommand returns a command that imports the
+// client configuration from an encrypted bundle.
+func NewImportClientConfigCommand(store jujuclient.ClientStore) cmd.Command {
+	if store == nil {
+		panic("valid store must be specified")
+	}
+	return modelcmd.WrapBase(&importClientConfigCommand{store: store})
+}
+
+type importClientConfigCommand struct {
+	modelcmd.CommandBase
+	store jujuclient.ClientStore
+
+	filename       string
+	passphraseFile string
+	replace        bool
+}
+
+const importClientConfigDoc = `
+Imports the controllers, models, accounts and any cloud credentials
+in a bundle written by "juju export-client-config" into this client.
+The passphrase the bundle was encrypted with is prompted for, or read
+from the file given by --passphrase-file.
+
+By default, the import fails without changing anything if this client
+already knows any of the controllers in the bundle, and credentials
+already known to this client are kept in preference to those of the
+same name in the bundle. With --replace, the controllers, accounts and
+credentials in the bundle replace those already known. The current
+controller is only changed if this client has none.
+
+Examples:
+
+    juju import-client-config juju-client.bundle
+    juju import-client-config --replace juju-client.bundle
+
+See also:
+    export-client-config
+    controllers
+    credentials
+`
+
+// Info implements Command.Info.
+func (c *importClientConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-client-config",
+		Args:    "<filename>",
+		Purpose: "Imports the client configuration from an encrypted bundle.",
+		Doc:     importClientConfigDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importClientConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.replace, "replace", false, "Replace controllers and credentials already known to the client")
+	f.StringVar(&c.passphraseFile, "passphrase-file", "", "The file holding the passphrase used to decrypt the bundle")
+}
+
+// Init implements Command.Init.
+func (c *importClientConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle filename specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *importClientConfigCommand) Run(ctx *cmd.Context) error {
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "reading client config bundle")
+	}
+	passphrase, err := readBundlePassphrase(ctx, c.passphraseFile, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := jujuclient.DecryptClientConfig(data, passphrase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := jujuclient.ImportClientConfig(c.store, cfg, c.replace); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return errors.Annotate(err, "use --replace to replace controllers already known")
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof(
+		"Imported %d controllers and %d cloud credentials from %s",
+		len(cfg.Controllers.Controllers), len(cfg.Credentials), c.filename,
+	)
+	return nil
+}
@@ -0,0 +1,371 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/usermanager"
+	jujucloud "github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/permission"
+)
+
+// NewImportConfigCommand returns a command that replays a controller
+// configuration archive onto a controller.
+func NewImportConfigCommand() cmd.Command {
+	return modelcmd.WrapController(&importConfigCommand{})
+}
+
+type importConfigCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (controllerArchiveAPI, error)
+
+	filename string
+	keyFile  string
+}
+
+const importConfigDoc = `
+Replays a controller configuration archive, written by
+"juju export-controller-config", onto a controller. This is intended
+for a controller that has been freshly bootstrapped to replace one that
+was lost. The archive's signature is checked with the key in the file
+given by --key-file before anything is changed.
+
+Configuration already present on the controller is left as it is:
+clouds, users and spaces that exist are not changed. Controller config
+keys that can only be set at bootstrap time are reported, so that they
+can be given to "juju bootstrap --config" if needed. New local users
+are created without a password; the "juju register" command each of
+them needs is printed, as it is by "juju add-user". Credential secrets
+are not held in the archive, so credentials that are missing are
+reported, and must be added again with "juju add-credential" or
+"juju update-credential".
+
+Examples:
+
+    juju import-controller-config --key-file ~/dr.key controller.tar.gz
+    juju import-controller-config -c new-prod --key-file ~/dr.key prod.tar.gz
+
+See also:
+    export-controller-config
+    bootstrap
+    restore-backup
+`
+
+// Info implements Command.Info.
+func (c *importConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-controller-config",
+		Args:    "<filename>",
+		Purpose: "Replays a controller configuration archive onto a controller.",
+		Doc:     importConfigDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.keyFile, "key-file", "", "The file holding the key used to sign the archive")
+}
+
+// Init implements Command.Init.
+func (c *importConfigCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive filename specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *importConfigCommand) getAPI() (controllerArchiveAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	return newControllerArchiveClient(&c.ControllerCommandBase)
+}
+
+// Run implements Command.Run.
+func (c *importConfigCommand) Run(ctx *cmd.Context) error {
+	key, err := readSigningKey(ctx.AbsPath(c.keyFile))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "opening controller config archive")
+	}
+	defer f.Close()
+	archive, err := readControllerArchive(f, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerDetails, err := c.ClientStore().ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	importer := &archiveImporter{
+		client: client,
+		ctx:    ctx,
+		registration: jujuclient.RegistrationInfo{
+			Addrs:          controllerDetails.APIEndpoints,
+			ControllerName: controllerName,
+		},
+	}
+	ctx.Infof("Importing the configuration of controller %q, exported at %s",
+		archive.ControllerName, archive.ExportedAt.Format("2006-01-02 15:04:05 MST"))
+	for _, step := range []func(*controllerArchive) error{
+		importer.importControllerConfig,
+		importer.importClouds,
+		importer.importModelDefaults,
+		importer.importUsers,
+		importer.importSpaces,
+	} {
+		if err := step(archive); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if importer.failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// archiveImporter replays the sections of a controller configuration
+// archive. Failures to replay individual items are reported and the
+// import continues, so that as much as possible is restored.
+type archiveImporter struct {
+	client controllerArchiveAPI
+	ctx    *cmd.Context
+	failed bool
+
+	// registration holds the controller details printed for
+	// new users to pass to "juju register".
+	registration jujuclient.RegistrationInfo
+}
+
+func (i *archiveImporter) report(format string, args ...interface{}) {
+	fmt.Fprintf(i.ctx.Stdout, format+"\n", args...)
+}
+
+func (i *archiveImporter) fail(err error, format string, args ...interface{}) {
+	i.failed = true
+	fmt.Fprintf(i.ctx.Stderr, "ERROR %s: %v\n", fmt.Sprintf(format, args...), err)
+}
+
+func (i *archiveImporter) importControllerConfig(archive *controllerArchive) error {
+	current, err := i.client.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "reading controller config")
+	}
+	values := make(map[string]interface{})
+	var bootstrapOnly []string
+	for key, value := range archive.ControllerConfig {
+		// Values read over the API and from the archive may be
+		// decoded as different types, so compare their text.
+		if current, ok := current[key]; ok && fmt.Sprint(current) == fmt.Sprint(value) {
+			continue
+		}
+		if !controller.AllowedUpdateConfigAttributes.Contains(key) {
+			if key != controller.ControllerUUIDKey && key != controller.CACertKey {
+				bootstrapOnly = append(bootstrapOnly, key)
+			}
+			continue
+		}
+		values[key] = value
+	}
+	if len(values) > 0 {
+		if err := i.client.ConfigSet(values); err != nil {
+			i.fail(err, "setting controller config")
+		} else {
+			i.report("controller config: set %s", strings.Join(sortedKeys(values), ", "))
+		}
+	}
+	if len(bootstrapOnly) > 0 {
+		sort.Strings(bootstrapOnly)
+		i.report("controller config: not changed, as they can only be set at bootstrap: %s",
+			strings.Join(bootstrapOnly, ", "))
+	}
+	return nil
+}
+
+func (i *archiveImporter) importClouds(archive *controllerArchive) error {
+	existing, err := i.client.Clouds()
+	if err != nil {
+		return errors.Annotate(err, "reading clouds")
+	}
+	for _, name := range sortedKeys(archive.Clouds) {
+		archived := archive.Clouds[name]
+		if _, ok := existing[names.NewCloudTag(name)]; !ok {
+			cloud, err := jujucloud.UnmarshalCloud([]byte(archived.Definition))
+			if err != nil {
+				i.fail(err, "parsing cloud %q", name)
+				continue
+			}
+			if err := i.client.AddCloud(cloud); err != nil {
+				i.fail(err, "adding cloud %q", name)
+				continue
+			}
+			i.report("cloud %q: added", name)
+		}
+		i.checkCredentials(name, archived.Credentials)
+	}
+	return nil
+}
+
+// checkCredentials reports the archived credentials that
+// are missing from the controller.
+func (i *archiveImporter) checkCredentials(cloud string, credentials []string) {
+	var tags []names.CloudCredentialTag
+	for _, id := range credentials {
+		if !names.IsValidCloudCredential(id) {
+			i.fail(errors.NotValidf("credential %q", id), "checking credentials of cloud %q", cloud)
+			continue
+		}
+		tags = append(tags, names.NewCloudCredentialTag(id))
+	}
+	if len(tags) == 0 {
+		return
+	}
+	results, err := i.client.Credentials(tags...)
+	if err != nil {
+		i.fail(err, "checking credentials of cloud %q", cloud)
+		return
+	}
+	var missing []string
+	for n, result := range results {
+		if result.Error != nil && n < len(tags) {
+			missing = append(missing, tags[n].Id())
+		}
+	}
+	if len(missing) > 0 {
+		i.report("cloud %q: credentials to add again: %s", cloud, strings.Join(missing, ", "))
+	}
+}
+
+func (i *archiveImporter) importModelDefaults(archive *controllerArchive) error {
+	for _, cloud := range sortedKeys(archive.ModelDefaults) {
+		defaults := archive.ModelDefaults[cloud]
+		if len(defaults.Controller) > 0 {
+			if err := i.client.SetModelDefaults(cloud, "", defaults.Controller); err != nil {
+				i.fail(err, "setting model defaults for cloud %q", cloud)
+			} else {
+				i.report("model defaults for cloud %q: set %s", cloud, strings.Join(sortedKeys(defaults.Controller), ", "))
+			}
+		}
+		for _, region := range sortedKeys(defaults.Regions) {
+			values := defaults.Regions[region]
+			if err := i.client.SetModelDefaults(cloud, region, values); err != nil {
+				i.fail(err, "setting model defaults for region %q of cloud %q", region, cloud)
+				continue
+			}
+			i.report("model defaults for region %q of cloud %q: set %s", region, cloud, strings.Join(sortedKeys(values), ", "))
+		}
+	}
+	return nil
+}
+
+func (i *archiveImporter) importUsers(archive *controllerArchive) error {
+	current, err := i.client.UserInfo(nil, usermanager.AllUsers)
+	if err != nil {
+		return errors.Annotate(err, "reading users")
+	}
+	existing := make(map[string]string)
+	for _, user := range current {
+		existing[user.Username] = user.Access
+	}
+	for _, user := range archive.Users {
+		access, ok := existing[user.Name]
+		if !ok && names.NewUserTag(user.Name).IsLocal() {
+			_, secretKey, err := i.client.AddUser(user.Name, user.DisplayName, "")
+			if err != nil {
+				i.fail(err, "adding user %q", user.Name)
+				continue
+			}
+			access = string(permission.LoginAccess)
+			registration := i.registration
+			registration.User = user.Name
+			registration.SecretKey = secretKey
+			if token, err := registration.Encode(); err != nil {
+				i.report("user %q: added", user.Name)
+				i.fail(err, "generating registration string for user %q", user.Name)
+			} else {
+				i.report("user %q: added, send this command to %s:\n    juju register %s", user.Name, user.Name, token)
+			}
+			if user.Disabled {
+				if err := i.client.DisableUser(user.Name); err != nil {
+					i.fail(err, "disabling user %q", user.Name)
+				}
+			}
+		}
+		if user.Access == "" || permission.Access(access).EqualOrGreaterControllerAccessThan(permission.Access(user.Access)) {
+			continue
+		}
+		if err := i.client.GrantController(user.Name, user.Access); err != nil {
+			i.fail(err, "granting %s access to user %q", user.Access, user.Name)
+			continue
+		}
+		i.report("user %q: granted %s access", user.Name, user.Access)
+	}
+	return nil
+}
+
+func (i *archiveImporter) importSpaces(archive *controllerArchive) error {
+	if len(archive.Spaces) == 0 {
+		return nil
+	}
+	current, err := i.client.ListSpaces()
+	if err != nil {
+		return errors.Annotate(err, "reading spaces")
+	}
+	existing := set.NewStrings()
+	for _, space := range current {
+		existing.Add(space.Name)
+	}
+	for _, space := range archive.Spaces {
+		if existing.Contains(space.Name) {
+			continue
+		}
+		if err := i.client.CreateSpace(space.Name, space.Subnets, true); err != nil {
+			i.fail(err, "adding space %q", space.Name)
+			continue
+		}
+		i.report("space %q: added", space.Name)
+	}
+	return nil
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	result := make([]string, len(keys))
+	for n, key := range keys {
+		result[n] = key.String()
+	}
+	sort.Strings(result)
+	return result
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/status"
@@ -29,8 +30,14 @@ var helpControllersDetails = `
 The output format may be selected with the '--format' option. In the
 default tabular output, the current controller is marked with an asterisk.
 
+With --refresh, the HA column shows how many of the controller machines are
+active, out of those that should be voting in the controller's mongo replica
+set. A machine whose replica set member is unhealthy is not counted as active;
+use show-controller to see the state, lag and last heartbeat of each member.
+
 Examples:
     juju controllers
+    juju controllers --refresh
     juju controllers --format json --output ~/tmp/controllers.json
 
 See also:
@@ -40,7 +47,7 @@ See also:
 // NewListControllersCommand returns a command to list registered controllers.
 func NewListControllersCommand() cmd.Command {
 	cmd := &listControllersCommand{
-		store: jujuclient.NewFileClientStore(),
+		store: jujuclient.NewClientStore(),
 	}
 	return modelcmd.WrapBase(cmd)
 }
@@ -151,6 +158,12 @@ func (c *listControllersCommand) refreshControllerDetails(client ControllerAcces
 	if err != nil {
 		return err
 	}
+	// The replica set health is only available to controller
+	// superusers on newer controllers.
+	health, err := client.ControllerHealth()
+	if err != nil && !errors.IsNotSupported(err) && !params.IsCodeUnauthorized(err) {
+		return err
+	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -174,9 +187,29 @@ func (c *listControllersCommand) refreshControllerDetails(client ControllerAcces
 	}
 	details.MachineCount = &machineCount
 	details.ActiveControllerMachineCount, details.ControllerMachineCount = ControllerMachineCounts(controllerModelUUID, modelStatus)
+	if healthy, ok := healthyReplicaSetMembers(health.ReplicaSet); ok && healthy < details.ActiveControllerMachineCount {
+		details.ActiveControllerMachineCount = healthy
+	}
 	return c.store.UpdateController(controllerName, *details)
 }
 
+// healthyReplicaSetMembers returns the number of replica set members
+// that are healthy and either primary or secondary. A controller
+// machine whose member is not healthy is not counted as active, even
+// if its agent is. The result is false if no members were reported.
+func healthyReplicaSetMembers(members []params.ReplicaSetMemberHealth) (int, bool) {
+	if len(members) == 0 {
+		return 0, false
+	}
+	healthy := 0
+	for _, m := range members {
+		if m.Healthy && (m.State == "PRIMARY" || m.State == "SECONDARY") {
+			healthy++
+		}
+	}
+	return healthy, true
+}
+
 func ControllerMachineCounts(controllerModelUUID string, modelStatusResults []base.ModelStatus) (activeCount, totalCount int) {
 	for _, s := range modelStatusResults {
 		if s.Error != nil {
@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
@@ -144,6 +145,39 @@ mark-test-prodstack  -             admin  (unknown)  prodstack                -
 	s.assertListControllers(c, "--refresh")
 }
 
+func (s *ListControllersSuite) TestListControllersUnhealthyReplicaSet(c *gc.C) {
+	s.createTestClientStore(c)
+	s.api = func(controllerName string) controller.ControllerAccessAPI {
+		fakeController := &fakeController{controllerName: controllerName}
+		if controllerName == "aws-test" {
+			fakeController.machines = map[string][]base.Machine{
+				"ghi": {
+					{Id: "1", HasVote: true, WantsVote: true, Status: "active"},
+					{Id: "2", HasVote: true, WantsVote: true, Status: "active"},
+					{Id: "3", HasVote: true, WantsVote: true, Status: "active"},
+				},
+			}
+			fakeController.health = &params.ControllerHealthResult{
+				ReplicaSet: []params.ReplicaSetMemberHealth{
+					{MachineId: "1", State: "PRIMARY", Healthy: true},
+					{MachineId: "2", State: "SECONDARY", Healthy: true},
+					{MachineId: "3", State: "RECOVERING", Healthy: true},
+				},
+			}
+		}
+		return fakeController
+	}
+	s.expectedOutput = `
+Controller           Model         User   Access     Cloud/Region        Models  Nodes   HA  Version
+aws-test             controller    admin  (unknown)  aws/us-east-1            1      2  2/3  2.0.1      
+k8s-controller       my-k8s-model  admin  superuser  microk8s/localhost       2      4    -  6.6.6      
+mallards*            my-model      admin  superuser  mallards/mallards1       2      4    -  (unknown)  
+mark-test-prodstack  -             admin  (unknown)  prodstack                -      -    -  (unknown)  
+
+`[1:]
+	s.assertListControllers(c, "--refresh")
+}
+
 func (s *ListControllersSuite) TestListControllersYaml(c *gc.C) {
 	s.expectedOutput = `
 controllers:
@@ -245,7 +245,11 @@ type ModelSummary struct {
 
 	// Counts is the map of different counts where key is the entity that was counted
 	// and value is the number, for e.g. {"machines":10,"cores":3, "units:4}.
-	Counts       map[string]int64 `json:"-" yaml:"-"`
+	Counts map[string]int64 `json:"-" yaml:"-"`
+
+	// Quotas is the map of the maximum numbers of entities the model may
+	// have, keyed like Counts. Entities without a quota are not included.
+	Quotas       map[string]int64 `json:"-" yaml:"-"`
 	SLA          string           `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner     string           `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion string           `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
@@ -322,10 +326,14 @@ func (c *modelsCommand) modelSummaryFromParams(apiSummary base.UserModelSummary,
 	for _, v := range apiSummary.Counts {
 		summary.Counts[v.Entity] = v.Count
 	}
+	summary.Quotas = map[string]int64{}
+	for _, v := range apiSummary.Quotas {
+		summary.Quotas[v.Entity] = v.Count
+	}
 
 	// If hasMachinesCounts is not yet set, check if we should set it based on this model summary.
 	if !c.runVars.hasMachinesCount {
-		if _, ok := summary.Counts[string(params.Machines)]; ok {
+		if summary.hasCountOrQuota(params.Machines) {
 			c.runVars.hasMachinesCount = true
 		}
 	}
@@ -339,13 +347,42 @@ func (c *modelsCommand) modelSummaryFromParams(apiSummary base.UserModelSummary,
 
 	// If hasUnitsCounts is not yet set, check if we should set it based on this model summary.
 	if !c.runVars.hasUnitsCount {
-		if _, ok := summary.Counts[string(params.Units)]; ok {
+		if summary.hasCountOrQuota(params.Units) {
 			c.runVars.hasUnitsCount = true
 		}
 	}
+
+	// If hasStorageCount is not yet set, check if we should set it based on this model summary.
+	if !c.runVars.hasStorageCount {
+		if summary.hasCountOrQuota(params.Storage) {
+			c.runVars.hasStorageCount = true
+		}
+	}
 	return summary, nil
 }
 
+// hasCountOrQuota reports whether the model summary has a count of,
+// or a quota for, the given entity.
+func (m ModelSummary) hasCountOrQuota(entity params.CountedEntity) bool {
+	_, hasCount := m.Counts[string(entity)]
+	_, hasQuota := m.Quotas[string(entity)]
+	return hasCount || hasQuota
+}
+
+// countColumn returns the value to show in the column for the given
+// entity: its count, followed by its quota if it has one, or the
+// given default if there is neither.
+func (m ModelSummary) countColumn(entity params.CountedEntity, missing interface{}) interface{} {
+	count, hasCount := m.Counts[string(entity)]
+	if quota, ok := m.Quotas[string(entity)]; ok {
+		return fmt.Sprintf("%d/%d", count, quota)
+	}
+	if !hasCount {
+		return missing
+	}
+	return count
+}
+
 // These values are specific to an individual Run() of the model command.
 type modelsRunValues struct {
 	currentUser      names.UserTag
@@ -353,6 +390,7 @@ type modelsRunValues struct {
 	hasMachinesCount bool
 	hasCoresCount    bool
 	hasUnitsCount    bool
+	hasStorageCount  bool
 }
 
 // formatTabular takes an interface{} to adhere to the cmd.Formatter interface
@@ -397,6 +435,20 @@ func (c *modelsCommand) tabularColumns(tw *ansiterm.TabWriter, w output.Wrapper)
 		printColumnHeader("Units", 5)
 	}
 
+	if c.runVars.hasStorageCount {
+		column := 4
+		for _, shown := range []bool{
+			c.runVars.hasMachinesCount,
+			c.runVars.hasCoresCount,
+			c.runVars.hasUnitsCount,
+		} {
+			if shown {
+				column++
+			}
+		}
+		printColumnHeader("Storage", column)
+	}
+
 	w.Println("Access", "Last connection")
 }
 
@@ -429,11 +481,7 @@ func (c *modelsCommand) tabularSummaries(writer io.Writer, modelSet ModelSummary
 		}
 		w.Print(cloudRegion, model.ProviderType, status)
 		if c.runVars.hasMachinesCount {
-			if v, ok := model.Counts[string(params.Machines)]; ok {
-				w.Print(v)
-			} else {
-				w.Print(0)
-			}
+			w.Print(model.countColumn(params.Machines, 0))
 		}
 		if c.runVars.hasCoresCount {
 			if v, ok := model.Counts[string(params.Cores)]; ok {
@@ -443,11 +491,10 @@ func (c *modelsCommand) tabularSummaries(writer io.Writer, modelSet ModelSummary
 			}
 		}
 		if c.runVars.hasUnitsCount {
-			if v, ok := model.Counts[string(params.Units)]; ok {
-				w.Print(v)
-			} else {
-				w.Print("-")
-			}
+			w.Print(model.countColumn(params.Units, "-"))
+		}
+		if c.runVars.hasStorageCount {
+			w.Print(model.countColumn(params.Storage, "-"))
 		}
 		access := model.UserAccess
 		if access == "" {
@@ -465,6 +512,9 @@ models which have been shared with you. Default values for user and
 controller are, respectively, the current user and the current controller.
 The active model is denoted by an asterisk.
 
+Where the controller limits the number of machines, units or storage
+instances each model may have, their counts are shown as "count/limit".
+
 Examples:
 
     juju models
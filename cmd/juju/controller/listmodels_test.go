@@ -46,9 +46,11 @@ var _ = gc.Suite(&ModelsSuiteV4{})
 type fakeModelMgrAPIClient struct {
 	*gitjujutesting.Stub
 
-	err   error
-	infos []params.ModelInfoResult
-	units map[string]int
+	err     error
+	infos   []params.ModelInfoResult
+	units   map[string]int
+	storage map[string]int
+	quotas  []base.EntityCount
 
 	version int
 }
@@ -167,6 +169,10 @@ func (f *fakeModelMgrAPIClient) ListModelSummaries(user string, all bool) ([]bas
 		if count, ok := f.units[info.Result.Name]; ok && count > 0 {
 			results[i].Counts = append(results[i].Counts, base.EntityCount{string(params.Units), int64(count)})
 		}
+		if count, ok := f.storage[info.Result.Name]; ok && count > 0 {
+			results[i].Counts = append(results[i].Counts, base.EntityCount{string(params.Storage), int64(count)})
+		}
+		results[i].Quotas = f.quotas
 	}
 	return results, nil
 }
@@ -693,6 +699,24 @@ func (s *ModelsSuiteV4) TestModelWithUnits(c *gc.C) {
 	s.checkAPICalls(c, "BestAPIVersion", "ListModels", "ModelInfo", "Close")
 }
 
+func (s *ModelsSuiteV4) TestModelWithQuotas(c *gc.C) {
+	s.api.units = map[string]int{"test-model2": 3}
+	s.api.storage = map[string]int{"test-model1": 2}
+	s.api.quotas = []base.EntityCount{{string(params.Units), 10}}
+
+	context, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Controller: fake\n"+
+		"\n"+
+		"Model                        Cloud/Region  Type   Status      Units  Storage  Access  Last connection\n"+
+		"test-model1*                 dummy         local  active      0/10         2  read    2015-03-20\n"+
+		"carlotta/test-model2         dummy         local  active      3/10         -  write   2015-03-01\n"+
+		"daiwik@external/test-model3  dummy         local  destroying  0/10         -  -       never connected\n"+
+		"\n")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
 func (s *ModelsSuiteV4) TestModelsJson(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
@@ -47,7 +47,7 @@ func NewRegisterCommand() cmd.Command {
 	c := &registerCommand{}
 	c.apiOpen = c.APIOpen
 	c.listModelsFunc = c.listModels
-	c.store = jujuclient.NewFileClientStore()
+	c.store = jujuclient.NewClientStore()
 	c.CanClearCurrentModel = true
 	return modelcmd.WrapBase(c)
 }
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -30,7 +31,9 @@ Shows detailed information of a controller.`[1:]
 
 var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
-and user login details.
+and user login details. For users with superuser access, a summary of the
+health of the controller's API servers, mongo replica set and raft cluster
+is also shown.
 
 Examples:
     juju show-controller
@@ -54,7 +57,7 @@ type showControllerCommand struct {
 // NewShowControllerCommand returns a command to show details of the desired controllers.
 func NewShowControllerCommand() cmd.Command {
 	cmd := &showControllerCommand{
-		store: jujuclient.NewFileClientStore(),
+		store: jujuclient.NewClientStore(),
 	}
 	return modelcmd.WrapBase(cmd)
 }
@@ -93,6 +96,7 @@ type ControllerAccessAPI interface {
 	AllModels() ([]base.UserModel, error)
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
+	ControllerHealth() (params.ControllerHealthResult, error)
 	Close() error
 }
 
@@ -146,6 +150,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			details      ShowControllerDetails
 			allModels    []base.UserModel
 			mongoVersion string
+			health       *params.ControllerHealthResult
 		)
 
 		// NOTE: this user may have been granted AddModelAccess which
@@ -177,6 +182,15 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				continue
 			}
+
+			// Fetch the health summary if the apiserver supports it
+			result, err := client.ControllerHealth()
+			if err == nil {
+				health = &result
+			} else if !errors.IsNotSupported(err) {
+				details.Errors = append(details.Errors, err.Error())
+				continue
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -201,6 +215,9 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		}
 
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatusResults, mongoVersion, identityURL)
+		if health != nil {
+			details.Health = convertHealthForShow(*health)
+		}
 		controllers[controllerName] = details
 		machineCount := 0
 		for _, r := range modelStatusResults {
@@ -214,6 +231,11 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		}
 		one.MachineCount = &machineCount
 		one.ActiveControllerMachineCount, one.ControllerMachineCount = ControllerMachineCounts(controllerModelUUID, modelStatusResults)
+		if health != nil {
+			if healthy, ok := healthyReplicaSetMembers(health.ReplicaSet); ok && healthy < one.ActiveControllerMachineCount {
+				one.ActiveControllerMachineCount = healthy
+			}
+		}
 		err = c.store.UpdateController(controllerName, *one)
 		if err != nil {
 			details.Errors = append(details.Errors, err.Error())
@@ -276,6 +298,10 @@ type ShowControllerDetails struct {
 
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
+
+	// Health is a summary of the health of the controller's API servers,
+	// mongo replica set and raft cluster.
+	Health *ControllerHealth `yaml:"health,omitempty" json:"health,omitempty"`
 }
 
 // ControllerDetails holds details of a controller to show.
@@ -348,6 +374,63 @@ type ModelDetails struct {
 	UnitCount *int `yaml:"unit-count,omitempty" json:"unit-count,omitempty"`
 }
 
+// ControllerHealth holds a summary of the health of a controller to show.
+type ControllerHealth struct {
+	// APIServers holds the health of the API server on each controller
+	// machine, keyed by machine id.
+	APIServers map[string]APIServerHealth `yaml:"api-servers,omitempty" json:"api-servers,omitempty"`
+
+	// ReplicaSet holds the state of each mongo replica set member,
+	// keyed by machine id.
+	ReplicaSet map[string]ReplicaSetMemberHealth `yaml:"replica-set,omitempty" json:"replica-set,omitempty"`
+
+	// Raft holds the state of the controller's raft cluster.
+	Raft *RaftHealth `yaml:"raft,omitempty" json:"raft,omitempty"`
+}
+
+// APIServerHealth holds the health of a controller machine's API server.
+type APIServerHealth struct {
+	// AgentVersion is the version of the agent on the machine.
+	AgentVersion string `yaml:"agent-version,omitempty" json:"agent-version,omitempty"`
+
+	// Reporting is true if the machine has recently reported its health.
+	Reporting bool `yaml:"reporting" json:"reporting"`
+
+	// ClockSkew is the difference between the machine's clock and that
+	// of the API server answering the request.
+	ClockSkew string `yaml:"clock-skew,omitempty" json:"clock-skew,omitempty"`
+}
+
+// ReplicaSetMemberHealth holds the state of a mongo replica set member.
+type ReplicaSetMemberHealth struct {
+	// Address is the address of the mongo server.
+	Address string `yaml:"address" json:"address"`
+
+	// State is the replica set state of the member, eg PRIMARY.
+	State string `yaml:"state" json:"state"`
+
+	// Healthy is true if the member is healthy.
+	Healthy bool `yaml:"healthy" json:"healthy"`
+
+	// Lag is how far the member's replication is behind the primary.
+	Lag string `yaml:"lag,omitempty" json:"lag,omitempty"`
+
+	// LastHeartbeat is when the member last responded to a heartbeat.
+	LastHeartbeat *time.Time `yaml:"last-heartbeat,omitempty" json:"last-heartbeat,omitempty"`
+}
+
+// RaftHealth holds the state of a controller's raft cluster.
+type RaftHealth struct {
+	// Leader is the id of the machine that is the raft leader.
+	Leader string `yaml:"leader,omitempty" json:"leader,omitempty"`
+
+	// Term is the current raft term.
+	Term uint64 `yaml:"term" json:"term"`
+
+	// Servers maps the id of each server in the cluster to its suffrage.
+	Servers map[string]string `yaml:"servers,omitempty" json:"servers,omitempty"`
+}
+
 // AccountDetails holds details of an account to show.
 type AccountDetails struct {
 	// User is the username for the account.
@@ -415,6 +498,55 @@ func (c *showControllerCommand) convertControllerForShow(
 	}
 }
 
+func convertHealthForShow(result params.ControllerHealthResult) *ControllerHealth {
+	health := &ControllerHealth{}
+	if len(result.APIServers) > 0 {
+		health.APIServers = make(map[string]APIServerHealth)
+		for _, s := range result.APIServers {
+			server := APIServerHealth{
+				AgentVersion: s.AgentVersion,
+				Reporting:    s.Reporting,
+			}
+			if s.LastReport != nil {
+				server.ClockSkew = s.ClockSkew.String()
+			}
+			health.APIServers[s.MachineId] = server
+		}
+	}
+	if len(result.ReplicaSet) > 0 {
+		health.ReplicaSet = make(map[string]ReplicaSetMemberHealth)
+		for _, m := range result.ReplicaSet {
+			member := ReplicaSetMemberHealth{
+				Address: m.Address,
+				State:   m.State,
+				Healthy: m.Healthy,
+			}
+			// The primary is never behind itself.
+			if m.State != "PRIMARY" {
+				member.Lag = m.Lag.String()
+			}
+			if m.LastHeartbeat != nil {
+				heartbeat := m.LastHeartbeat.UTC()
+				member.LastHeartbeat = &heartbeat
+			}
+			health.ReplicaSet[m.MachineId] = member
+		}
+	}
+	if result.Raft != nil {
+		health.Raft = &RaftHealth{
+			Leader: result.Raft.Leader,
+			Term:   result.Raft.Term,
+		}
+		if len(result.Raft.Servers) > 0 {
+			health.Raft.Servers = make(map[string]string)
+			for _, s := range result.Raft.Servers {
+				health.Raft.Servers[s.Id] = s.Suffrage
+			}
+		}
+	}
+	return health
+}
+
 func (c *showControllerCommand) convertAccountsForShow(controllerName string, controller *ShowControllerDetails, access string) {
 	storeDetails, err := c.store.AccountDetails(controllerName)
 	if err != nil && !errors.IsNotFound(err) {
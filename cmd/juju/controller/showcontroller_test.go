@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -506,6 +508,78 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithHealth(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "health:")
+
+	lastReport := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastHeartbeat := lastReport.Add(-time.Minute)
+	s.fakeController.health = &params.ControllerHealthResult{
+		APIServers: []params.APIServerHealth{{
+			MachineId:    "0",
+			AgentVersion: "999.99.99",
+			Reporting:    true,
+			LastReport:   &lastReport,
+			ClockSkew:    1500 * time.Millisecond,
+		}, {
+			MachineId:    "2",
+			AgentVersion: "999.99.98",
+		}},
+		ReplicaSet: []params.ReplicaSetMemberHealth{{
+			MachineId: "0",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+		}, {
+			MachineId:     "2",
+			Address:       "10.0.0.3:37017",
+			State:         "DOWN",
+			Lag:           90 * time.Second,
+			LastHeartbeat: &lastHeartbeat,
+		}},
+		Raft: &params.RaftHealth{
+			Leader: "0",
+			Term:   3,
+			Servers: []params.RaftServerHealth{
+				{Id: "0", Address: "10.0.0.1:17070", Suffrage: "Voter"},
+				{Id: "2", Address: "10.0.0.3:17070", Suffrage: "Nonvoter"},
+			},
+		},
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `
+  health:
+    api-servers:
+      "0":
+        agent-version: 999.99.99
+        reporting: true
+        clock-skew: 1.5s
+      "2":
+        agent-version: 999.99.98
+        reporting: false
+    replica-set:
+      "0":
+        address: 10.0.0.1:37017
+        state: PRIMARY
+        healthy: true
+      "2":
+        address: 10.0.0.3:37017
+        state: DOWN
+        healthy: false
+        lag: 1m30s
+        last-heartbeat: 2019-06-01T11:59:00Z
+    raft:
+      leader: "0"
+      term: 3
+      servers:
+        "0": Voter
+        "2": Nonvoter
+`[1:])
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -600,6 +674,7 @@ type fakeController struct {
 	access         permission.Access
 	bestAPIVersion int
 	identityURL    string
+	health         *params.ControllerHealthResult
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -656,6 +731,13 @@ func (c *fakeController) IdentityProviderURL() (string, error) {
 	return c.identityURL, nil
 }
 
+func (c *fakeController) ControllerHealth() (params.ControllerHealthResult, error) {
+	if c.health == nil {
+		return params.ControllerHealthResult{}, errors.NotSupportedf("ControllerHealth")
+	}
+	return *c.health, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
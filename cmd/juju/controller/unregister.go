@@ -29,6 +29,7 @@ type unregisterCommand struct {
 	modelcmd.CommandBase
 	controllerName string
 	assumeYes      bool
+	purge          bool
 	store          jujuclient.ClientStore
 }
 
@@ -38,11 +39,19 @@ command does not destroy the controller.  In order to regain access to an
 unregistered controller, it will need to be added again using the juju register
 command.
 
+With --purge-local-data, data cached by the client is also removed: the
+cookies and macaroons obtained from the controller, and the local charm
+cache (which is shared by all controllers). This avoids authorization
+failures caused by stale cookies if a controller with the same name is
+registered again.
+
 Examples:
 
     juju unregister my-controller
+    juju unregister --purge-local-data my-controller
 
 See also:
+    client-cache
     destroy-controller
     kill-controller
     register`
@@ -62,6 +71,7 @@ func (c *unregisterCommand) Info() *cmd.Info {
 func (c *unregisterCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
 	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.BoolVar(&c.purge, "purge-local-data", false, "Also remove data cached by the client for the controller")
 }
 
 // Init implements Command.Init.
@@ -88,6 +98,14 @@ you register it again.
 
 Continue [y/N]?`[1:]
 
+var unregisterPurgeMsg = `
+This command will remove connection information for controller %q,
+its cookies, and the local charm cache.
+Doing so will prevent you from accessing this controller until
+you register it again.
+
+Continue [y/N]?`[1:]
+
 func (c *unregisterCommand) Run(ctx *cmd.Context) error {
 
 	_, err := c.store.ControllerByName(c.controllerName)
@@ -96,12 +114,42 @@ func (c *unregisterCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if !c.assumeYes {
-		fmt.Fprintf(ctx.Stdout, unregisterMsg, c.controllerName)
+		msg := unregisterMsg
+		if c.purge {
+			msg = unregisterPurgeMsg
+		}
+		fmt.Fprintf(ctx.Stdout, msg, c.controllerName)
 
 		if err := jujucmd.UserConfirmYes(ctx); err != nil {
 			return errors.Annotate(err, "unregistering controller")
 		}
 	}
 
-	return (c.store.RemoveController(c.controllerName))
+	if err := c.store.RemoveController(c.controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.purge {
+		return nil
+	}
+	return errors.Annotate(c.purgeLocalData(ctx), "purging local data")
+}
+
+// purgeLocalData removes the data cached by the client for the
+// controller being unregistered.
+func (c *unregisterCommand) purgeLocalData(ctx *cmd.Context) error {
+	// The file store removes a controller's cookies along with
+	// the controller, but other stores may not.
+	if err := removeControllerCookies(c.controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	for _, cache := range clientCaches() {
+		if cache.Name != charmsCacheName {
+			continue
+		}
+		if err := clearCacheDir(cache.Dir); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.Infof("Removed local data for controller %q.", c.controllerName)
+	return nil
 }
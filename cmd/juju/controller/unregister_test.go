@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"bytes"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/cmd"
@@ -13,6 +14,7 @@ import (
 	jt "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charmrepo.v3"
 
 	"github.com/juju/juju/cmd/cmdtest"
 	"github.com/juju/juju/cmd/juju/controller"
@@ -145,3 +147,42 @@ func (s *UnregisterSuite) unregisterCommandConfirms(c *gc.C, answer string) {
 func (s *UnregisterSuite) TestUnregisterCommandConfirmsOnY(c *gc.C) {
 	s.unregisterCommandConfirms(c, "y")
 }
+
+type UnregisterPurgeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store     *fakeStore
+	charmsDir string
+}
+
+var _ = gc.Suite(&UnregisterPurgeSuite{})
+
+func (s *UnregisterPurgeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = &fakeStore{}
+	s.charmsDir = c.MkDir()
+	s.PatchValue(&charmrepo.CacheDir, s.charmsDir)
+
+	writeCacheFile(c, jujuclient.JujuCookiePath("fake1"), "cookies")
+	writeCacheFile(c, jujuclient.JujuCookiePath("fake2"), "cookies")
+	writeCacheFile(c, filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), "charm")
+}
+
+func (s *UnregisterPurgeSuite) TestUnregisterPurgeLocalData(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewUnregisterCommand(s.store), "fake1", "-y", "--purge-local-data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.store.removedName, gc.Equals, "fake1")
+
+	c.Check(jujuclient.JujuCookiePath("fake1"), jc.DoesNotExist)
+	c.Check(jujuclient.JujuCookiePath("fake2"), jc.IsNonEmptyFile)
+	c.Check(filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), jc.DoesNotExist)
+}
+
+func (s *UnregisterPurgeSuite) TestUnregisterKeepsLocalData(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewUnregisterCommand(s.store), "fake1", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.store.removedName, gc.Equals, "fake1")
+
+	// The fake store does not remove cookies itself.
+	c.Check(jujuclient.JujuCookiePath("fake1"), jc.IsNonEmptyFile)
+	c.Check(filepath.Join(s.charmsDir, "cs_wordpress-1.charm"), jc.IsNonEmptyFile)
+}
@@ -0,0 +1,208 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewVerifyCommand returns a command that checks the models in a
+// controller for dangling references, optionally repairing them.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.WrapController(&verifyCommand{})
+}
+
+type verifyCommand struct {
+	modelcmd.ControllerCommandBase
+	api verifyAPI
+	out cmd.Output
+
+	fix      string
+	fixKinds []string
+}
+
+type verifyAPI interface {
+	Close() error
+	VerifyModels(fix bool, fixKinds ...string) ([]params.VerifyModelResult, error)
+}
+
+const verifyDoc = `
+Checks every model in the controller for database documents that refer
+to documents which no longer exist. The following kinds of issue are
+detected:
+
+    unit-missing-machine           a unit assigned to a removed machine
+    relation-missing-application   a relation to a removed application
+    orphaned-storage-attachment    a storage attachment whose unit or
+                                   storage instance has been removed
+
+By default issues are only reported. The --fix option repairs issues of
+the given comma separated kinds, or of every kind if "all" is given.
+Only controller administrators may run this command.
+
+Examples:
+
+    juju controller-verify
+    juju controller-verify --fix all
+    juju controller-verify --fix unit-missing-machine,orphaned-storage-attachment
+
+See also:
+    controllers
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-verify",
+		Purpose: "Checks the models in a controller for dangling references.",
+		Doc:     verifyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.fix, "fix", "", `Repair issues of the given kinds ("all" for every kind)`)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatVerifyTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if c.fix != "" && c.fix != "all" {
+		for _, kind := range strings.Split(c.fix, ",") {
+			kind = strings.TrimSpace(kind)
+			if kind == "" {
+				return errors.Errorf("empty issue kind in --fix %q", c.fix)
+			}
+			c.fixKinds = append(c.fixKinds, kind)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *verifyCommand) getAPI() (verifyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// integrityIssue is the serialisation format for an issue found
+// in a model.
+type integrityIssue struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Entity    string `yaml:"entity" json:"entity"`
+	Reference string `yaml:"reference" json:"reference"`
+	Message   string `yaml:"message" json:"message"`
+	Repaired  bool   `yaml:"repaired" json:"repaired"`
+}
+
+// modelVerification is the serialisation format for the issues
+// found in a single model.
+type modelVerification struct {
+	Issues []integrityIssue `yaml:"issues,omitempty" json:"issues,omitempty"`
+	Error  string           `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.VerifyModels(c.fix != "", c.fixKinds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	models := make(map[string]modelVerification)
+	var failed bool
+	for _, result := range results {
+		tag, err := names.ParseModelTag(result.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var verification modelVerification
+		if result.Error != nil {
+			failed = true
+			verification.Error = result.Error.Error()
+		}
+		for _, issue := range result.Issues {
+			verification.Issues = append(verification.Issues, integrityIssue{
+				Kind:      issue.Kind,
+				Entity:    issue.Entity,
+				Reference: issue.Reference,
+				Message:   issue.Message,
+				Repaired:  issue.Repaired,
+			})
+		}
+		models[tag.Id()] = verification
+	}
+	if err := c.out.Write(ctx, models); err != nil {
+		return errors.Trace(err)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func formatVerifyTabular(writer io.Writer, value interface{}) error {
+	models, ok := value.(map[string]modelVerification)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", models, value)
+	}
+
+	var uuids []string
+	var issueCount int
+	for uuid, model := range models {
+		uuids = append(uuids, uuid)
+		issueCount += len(model.Issues)
+		if model.Error != "" {
+			issueCount++
+		}
+	}
+	if issueCount == 0 {
+		_, err := io.WriteString(writer, "No issues found.\n")
+		return errors.Trace(err)
+	}
+
+	sort.Strings(uuids)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Kind", "Entity", "Reference", "Repaired")
+	for _, uuid := range uuids {
+		model := models[uuid]
+		if model.Error != "" {
+			w.Println(uuid, "error", model.Error, "", "")
+		}
+		for _, issue := range model.Issues {
+			repaired := "no"
+			if issue.Repaired {
+				repaired = "yes"
+			}
+			w.Println(uuid, issue.Kind, issue.Entity, issue.Reference, repaired)
+		}
+	}
+	return errors.Trace(tw.Flush())
+}
@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	baseControllerSuite
+	api   *fakeVerifyAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeVerifyAPI{
+		results: []params.VerifyModelResult{{
+			ModelTag: coretesting.ModelTag.String(),
+			Issues: []params.IntegrityIssue{{
+				Kind:      "unit-missing-machine",
+				Entity:    "mysql/0",
+				Reference: "2",
+				Message:   "unit mysql/0 is assigned to missing machine 2",
+			}},
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *verifySuite) newCommand() cmd.Command {
+	return controller.NewVerifyCommandForTest(s.api, s.store)
+}
+
+func (s *verifySuite) TestVerifyTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.fix, jc.IsFalse)
+	c.Assert(s.api.fixKinds, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Model                                 Kind                  Entity   Reference  Repaired\n"+
+		"deadbeef-0bad-400d-8000-4b1d0d06f00d  unit-missing-machine  mysql/0  2          no\n")
+}
+
+func (s *verifySuite) TestVerifyNoIssues(c *gc.C) {
+	s.api.results[0].Issues = nil
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "No issues found.\n")
+}
+
+func (s *verifySuite) TestVerifyYaml(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+deadbeef-0bad-400d-8000-4b1d0d06f00d:
+  issues:
+  - kind: unit-missing-machine
+    entity: mysql/0
+    reference: "2"
+    message: unit mysql/0 is assigned to missing machine 2
+    repaired: false
+`[1:])
+}
+
+func (s *verifySuite) TestFixAll(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--fix", "all")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.fix, jc.IsTrue)
+	c.Assert(s.api.fixKinds, gc.HasLen, 0)
+}
+
+func (s *verifySuite) TestFixKinds(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--fix", "unit-missing-machine, orphaned-storage-attachment")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.fix, jc.IsTrue)
+	c.Assert(s.api.fixKinds, jc.DeepEquals, []string{"unit-missing-machine", "orphaned-storage-attachment"})
+}
+
+func (s *verifySuite) TestFixEmptyKind(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--fix", "unit-missing-machine,")
+	c.Assert(err, gc.ErrorMatches, `empty issue kind in --fix "unit-missing-machine,"`)
+}
+
+func (s *verifySuite) TestModelError(c *gc.C) {
+	s.api.results[0].Issues = nil
+	s.api.results[0].Error = &params.Error{Message: "boom"}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, `(?s).*deadbeef-0bad-400d-8000-4b1d0d06f00d +error +boom.*`)
+}
+
+func (s *verifySuite) TestAPIError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeVerifyAPI struct {
+	results  []params.VerifyModelResult
+	err      error
+	fix      bool
+	fixKinds []string
+}
+
+func (f *fakeVerifyAPI) Close() error {
+	return nil
+}
+
+func (f *fakeVerifyAPI) VerifyModels(fix bool, fixKinds ...string) ([]params.VerifyModelResult, error) {
+	f.fix = fix
+	f.fixKinds = fixKinds
+	return f.results, f.err
+}
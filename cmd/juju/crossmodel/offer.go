@@ -26,12 +26,19 @@ Deployed application endpoints are offered for use by consumers.
 By default, the offer is named after the application, unless
 an offer name is explicitly specified.
 
+In addition to the users granted access to the offer, consumption may
+be restricted to models whose egress networks fall within specific
+CIDRs with --allow-cidr, and to models hosted by specific controllers,
+identified by UUID, with --allow-controller.
+
 Examples:
 
 $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer mysql:db --allow-cidr 10.0.0.0/16,192.168.1.0/24
+$ juju offer mysql:db --allow-controller 2f7e1c3c-5d2e-4b61-8d5a-3a7e5c0b1d2e
 
 See also:
     consume
@@ -66,6 +73,14 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// AllowedCIDRs restricts consumption to models whose egress
+	// networks fall within these CIDRs.
+	AllowedCIDRs []string
+
+	// AllowedControllers restricts consumption to models hosted
+	// by the controllers with these UUIDs.
+	AllowedControllers []string
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -99,12 +114,17 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if err := jujucrossmodel.ValidateConsumerACLs(c.AllowedCIDRs, c.AllowedControllers); err != nil {
+		return errors.Trace(err)
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.AllowedCIDRs), "allow-cidr", "Comma separated CIDRs from which the offer may be consumed")
+	f.Var(cmd.NewStringsValue(nil, &c.AllowedControllers), "allow-controller", "Comma separated UUIDs of controllers from which the offer may be consumed")
 }
 
 // Run implements Command.Run.
@@ -151,7 +171,15 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 		c.OfferName = c.Application
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	var results []params.ErrorResult
+	if len(c.AllowedCIDRs) > 0 || len(c.AllowedControllers) > 0 {
+		results, err = api.OfferWithConsumerACLs(
+			modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "",
+			c.AllowedCIDRs, c.AllowedControllers,
+		)
+	} else {
+		results, err = api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	}
 	if err != nil {
 		return err
 	}
@@ -173,6 +201,10 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 type OfferAPI interface {
 	Close() error
 	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	OfferWithConsumerACLs(
+		modelUUID, application string, endpoints []string, offerName string, desc string,
+		allowedCIDRs, allowedControllers []string,
+	) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
@@ -107,6 +107,27 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferAllowedConsumers(c *gc.C) {
+	s.args = []string{
+		"tst:db",
+		"--allow-cidr", "10.0.0.0/16,192.168.1.0/24",
+		"--allow-controller", "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.allowedCIDRs["tst"], jc.DeepEquals, []string{"10.0.0.0/16", "192.168.1.0/24"})
+	c.Assert(s.mockAPI.allowedControllers["tst"], jc.DeepEquals, []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"})
+}
+
+func (s *offerSuite) TestOfferInvalidAllowedCIDR(c *gc.C) {
+	s.args = []string{"tst:db", "--allow-cidr", "10.0.0.1"}
+	s.assertOfferErrorOutput(c, `allowed consumer CIDR "10.0.0.1" not valid`)
+}
+
+func (s *offerSuite) TestOfferInvalidAllowedController(c *gc.C) {
+	s.args = []string{"tst:db", "--allow-controller", "foo"}
+	s.assertOfferErrorOutput(c, `allowed consumer controller "foo" not valid`)
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -119,6 +140,9 @@ type mockOfferAPI struct {
 	offers           map[string][]string
 	applications     map[string]string
 	descs            map[string]string
+
+	allowedCIDRs       map[string][]string
+	allowedControllers map[string][]string
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -126,6 +150,8 @@ func newMockOfferAPI() *mockOfferAPI {
 	mock.offers = make(map[string][]string)
 	mock.descs = make(map[string]string)
 	mock.applications = make(map[string]string)
+	mock.allowedCIDRs = make(map[string][]string)
+	mock.allowedControllers = make(map[string][]string)
 	return mock
 }
 
@@ -151,3 +177,19 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.descs[offerName] = desc
 	return result, nil
 }
+
+func (s *mockOfferAPI) OfferWithConsumerACLs(
+	modelUUID, application string, endpoints []string, offerName, desc string,
+	allowedCIDRs, allowedControllers []string,
+) ([]params.ErrorResult, error) {
+	result, err := s.Offer(modelUUID, application, endpoints, offerName, desc)
+	if err != nil || result[0].Error != nil {
+		return result, err
+	}
+	if offerName == "" {
+		offerName = application
+	}
+	s.allowedCIDRs[offerName] = allowedCIDRs
+	s.allowedControllers[offerName] = allowedControllers
+	return result, nil
+}
@@ -194,6 +194,96 @@ func (p *Pollster) EnterOptional(valueName string) (string, error) {
 	return QueryVerify("Enter "+valueName+" (optional): ", p.scanner, p.out, p.errOut, nil)
 }
 
+// EnterMultiLine requests that the user enter a value which may span several
+// lines, such as a PEM-encoded key. Entry ends at the first empty line. Values
+// failing to verify will be rejected with the error message returned by
+// verify, and the user asked to enter the whole value again. A nil verify
+// function will accept any value.
+func (p *Pollster) EnterMultiLine(valueName string, verify VerifyFunc) (string, error) {
+	return p.enterMultiLine(valueName, verify, p.scanLine)
+}
+
+// EnterMultiLinePassword is like EnterMultiLine, but if the Pollster is
+// reading from a terminal the lines entered are not echoed, so that secrets
+// such as private keys are not displayed.
+func (p *Pollster) EnterMultiLinePassword(valueName string, verify VerifyFunc) (string, error) {
+	f, ok := p.in.(*os.File)
+	if !ok || !terminal.IsTerminal(int(f.Fd())) {
+		return p.EnterMultiLine(valueName, verify)
+	}
+	return p.enterMultiLine(valueName, verify, func() (string, bool, error) {
+		line, err := terminal.ReadPassword(int(f.Fd()))
+		if err == io.EOF {
+			return "", false, nil
+		}
+		return string(line), err == nil, errors.Trace(err)
+	})
+}
+
+// scanLine returns the next line read by the Pollster's scanner, and
+// whether there was one.
+func (p *Pollster) scanLine() (string, bool, error) {
+	if p.scanner.Scan() {
+		return p.scanner.Text(), true, nil
+	}
+	return "", false, errors.Trace(p.scanner.Err())
+}
+
+func (p *Pollster) enterMultiLine(valueName string, verify VerifyFunc, readLine func() (string, bool, error)) (string, error) {
+	defer fmt.Fprint(p.out, "\n")
+	for {
+		if _, err := fmt.Fprintf(p.out, "Enter %s (end with an empty line):\n", valueName); err != nil {
+			return "", errors.Trace(err)
+		}
+		var lines []string
+		done := true
+		for {
+			line, ok, err := readLine()
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			if !ok {
+				break
+			}
+			if line == "" {
+				done = false
+				break
+			}
+			lines = append(lines, line)
+		}
+		if done && len(lines) == 0 {
+			// EOF
+			return "", io.EOF
+		}
+		var value string
+		if len(lines) > 0 {
+			value = strings.Join(lines, "\n") + "\n"
+		}
+		if verify == nil {
+			return value, nil
+		}
+		ok, msg, err := verify(value)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if ok {
+			return value, nil
+		}
+		if msg != "" {
+			if _, err := fmt.Fprint(p.errOut, msg+"\n"); err != nil {
+				return "", errors.Trace(err)
+			}
+		}
+		if _, err := p.errOut.Write([]byte{'\n'}); err != nil {
+			return "", errors.Trace(err)
+		}
+		if done {
+			// can't query any more, nothing we can do.
+			return "", io.EOF
+		}
+	}
+}
+
 // EnterVerifyDefault requests that the user enter a value.  Values failing to
 // verify will be rejected with the error message returned by verify.  An empty
 // string will be accepted as the default value even if it would fail
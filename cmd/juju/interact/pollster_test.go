@@ -51,6 +51,74 @@ Select apple:
 `[1:])
 }
 
+func (PollsterSuite) TestEnterMultiLine(c *gc.C) {
+	r := strings.NewReader("-----BEGIN KEY-----\nabc\n-----END KEY-----\n\nnext\n")
+	w := &bytes.Buffer{}
+	p := New(r, w, w)
+	s, err := p.EnterMultiLine("key", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s, gc.Equals, "-----BEGIN KEY-----\nabc\n-----END KEY-----\n")
+	c.Assert(w.String(), gc.Equals, `
+Enter key (end with an empty line):
+
+`[1:])
+
+	// The rest of the input is left for later queries.
+	s, err = p.Enter("name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s, gc.Equals, "next")
+}
+
+func (PollsterSuite) TestEnterMultiLineVerify(c *gc.C) {
+	r := strings.NewReader("\nbad\n\ngood\nkey\n")
+	w := &bytes.Buffer{}
+	p := New(r, w, w)
+	verify := func(s string) (ok bool, msg string, err error) {
+		if s == "" {
+			return false, "", nil
+		}
+		if !strings.HasPrefix(s, "good") {
+			return false, "not good", nil
+		}
+		return true, "", nil
+	}
+	s, err := p.EnterMultiLine("key", verify)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s, gc.Equals, "good\nkey\n")
+	c.Assert(w.String(), gc.Equals, `
+Enter key (end with an empty line):
+
+Enter key (end with an empty line):
+not good
+
+Enter key (end with an empty line):
+
+`[1:])
+}
+
+func (PollsterSuite) TestEnterMultiLineEOF(c *gc.C) {
+	r := strings.NewReader("bad\n")
+	w := &bytes.Buffer{}
+	p := New(r, w, w)
+	_, err := p.EnterMultiLine("key", func(s string) (ok bool, msg string, err error) {
+		return false, "", nil
+	})
+	c.Assert(err, gc.Equals, io.EOF)
+}
+
+func (PollsterSuite) TestEnterMultiLinePasswordNotTerminal(c *gc.C) {
+	r := strings.NewReader("-----BEGIN KEY-----\nabc\n-----END KEY-----\n\n")
+	w := &bytes.Buffer{}
+	p := New(r, w, w)
+	s, err := p.EnterMultiLinePassword("key", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s, gc.Equals, "-----BEGIN KEY-----\nabc\n-----END KEY-----\n")
+	c.Assert(w.String(), gc.Equals, `
+Enter key (end with an empty line):
+
+`[1:])
+}
+
 func (PollsterSuite) TestSelectDefault(c *gc.C) {
 	r := strings.NewReader("\n")
 	w := &bytes.Buffer{}
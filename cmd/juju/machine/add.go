@@ -391,6 +391,13 @@ func (c *addCommand) provisionWinRM(args manual.ProvisionMachineArgs) (string, e
 	base := osenv.JujuXDGDataHomePath("x509")
 	keyPath := filepath.Join(base, "winrmkey.pem")
 	certPath := filepath.Join(base, "winrmcert.crt")
+	rotated, err := winrmprovisioner.RotateClientCert(keyPath, certPath, time.Now(), winrmprovisioner.DefaultClientCertRenewal)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot check x509 client cert for winrm connection")
+	}
+	if rotated {
+		logger.Infof("generating a new x509 client cert for winrm connection")
+	}
 	cert := winrm.NewX509()
 	if err := cert.LoadClientCert(keyPath, certPath); err != nil {
 		return "", errors.Annotatef(err, "connot load/create x509 client certs for winrm connection")
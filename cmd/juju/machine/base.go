@@ -36,6 +36,7 @@ type baselistMachinesCommand struct {
 	machineIds    []string
 	defaultFormat string
 	color         bool
+	utilization   bool
 }
 
 // SetFlags sets utc and format flags based on user specified options.
@@ -83,5 +84,11 @@ func (c *baselistMachinesCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *baselistMachinesCommand) tabular(writer io.Writer, value interface{}) error {
+	if networking, ok := value.(machinesNetworking); ok {
+		return formatMachinesNetworkingTabular(writer, networking)
+	}
+	if c.utilization {
+		return status.FormatMachineUtilizationTabular(writer, c.color, value)
+	}
 	return status.FormatMachineTabular(writer, c.color, value)
 }
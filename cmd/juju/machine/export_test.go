@@ -32,6 +32,15 @@ func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI Machine
 	return modelcmd.Wrap(command), &AddCommand{command}
 }
 
+// NewShowNetworkingCommandForTest returns a showMachineCommand with the
+// specified status and network status apis.
+func NewShowNetworkingCommandForTest(api statusAPI, networkAPI networkStatusAPI) cmd.Command {
+	command := newShowMachineCommand(api)
+	command.networkAPI = networkAPI
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 // NewListCommandForTest returns a listMachineCommand with specified api
 func NewListCommandForTest(api statusAPI) cmd.Command {
 	command := newListMachinesCommand(api)
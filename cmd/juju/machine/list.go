@@ -5,6 +5,7 @@ package machine
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/gnuflag"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -18,8 +19,17 @@ By default, the tabular format is used.
 The following sections are included: ID, STATE, DNS, INS-ID, SERIES, AZ
 Note: AZ above is the cloud region's availability zone.
 
+The --utilization option instead shows the CPU and memory utilization
+of each machine, as last reported by the cloud, for quick capacity
+checks. Utilization is only shown for clouds that report it, such as
+GCE; memory utilization on GCE requires the Cloud Monitoring agent to
+be installed on the machine. Utilization is refreshed each time the
+controller polls the cloud for the machine's status, so may be some
+minutes old.
+
 Examples:
      juju machines
+     juju machines --utilization
 
 See also: 
     status`
@@ -51,6 +61,12 @@ func (c *listMachinesCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.utilization, "utilization", false, "Show the CPU and memory utilization reported by the cloud")
+}
+
 // Init ensures the machines Command does not take arguments.
 func (c *listMachinesCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
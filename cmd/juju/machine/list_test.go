@@ -144,6 +144,37 @@ func (s *MachineListCommandSuite) TestMachine(c *gc.C) {
 		"\n")
 }
 
+type utilizationStatusAPI struct {
+	fakeStatusAPI
+}
+
+func (f *utilizationStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	result, err := f.fakeStatusAPI.Status(c)
+	if err != nil {
+		return nil, err
+	}
+	cpu, memory := 12.5, 40.0
+	m := result.Machines["0"]
+	m.Utilization = &params.MachineUtilization{
+		CPUPercent:    &cpu,
+		MemoryPercent: &memory,
+	}
+	result.Machines["0"] = m
+	return result, nil
+}
+
+func (s *MachineListCommandSuite) TestListMachineUtilization(c *gc.C) {
+	command := machine.NewListCommandForTest(&utilizationStatusAPI{})
+	context, err := cmdtesting.RunCommand(c, command, "--utilization")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  State    Inst id              CPU    Memory\n"+
+		"0        started  juju-badd06-0        12.5%  40.0%\n"+
+		"1        started  juju-badd06-1               \n"+
+		"1/lxd/0  pending  juju-badd06-1-lxd-0         \n"+
+		"\n")
+}
+
 func (s *MachineListCommandSuite) TestListMachineYaml(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, newMachineListCommand(), "--format", "yaml")
 	c.Assert(err, jc.ErrorIsNil)
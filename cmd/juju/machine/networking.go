@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+)
+
+// networkStatusAPI defines the API methods used by show-machine
+// to report the FAN networking status of machines.
+type networkStatusAPI interface {
+	NetworkStatus(machines ...string) ([]params.MachineNetworkStatusResult, error)
+	Close() error
+}
+
+// machinesNetworking holds the networking status of machines,
+// keyed by machine ID, for formatting.
+type machinesNetworking struct {
+	Machines map[string]machineNetworking `yaml:"machines" json:"machines"`
+}
+
+// machineNetworking holds the networking status of a machine
+// for formatting.
+type machineNetworking struct {
+	FanBridges []fanBridge `yaml:"fan-bridges,omitempty" json:"fan-bridges,omitempty"`
+	FanErrors  []fanError  `yaml:"fan-errors,omitempty" json:"fan-errors,omitempty"`
+	Updated    string      `yaml:"updated,omitempty" json:"updated,omitempty"`
+	Err        string      `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+type fanBridge struct {
+	Name         string `yaml:"name" json:"name"`
+	Underlay     string `yaml:"underlay" json:"underlay"`
+	Overlay      string `yaml:"overlay" json:"overlay"`
+	LocalOverlay string `yaml:"local-overlay,omitempty" json:"local-overlay,omitempty"`
+	Present      bool   `yaml:"present" json:"present"`
+	Up           bool   `yaml:"up" json:"up"`
+}
+
+type fanError struct {
+	Time    string `yaml:"time" json:"time"`
+	Message string `yaml:"message" json:"message"`
+}
+
+// formatMachinesNetworking converts the networking status results for
+// the specified machines into a form suitable for output.
+func formatMachinesNetworking(
+	machineIds []string,
+	results []params.MachineNetworkStatusResult,
+	isoTime bool,
+) machinesNetworking {
+	out := machinesNetworking{
+		Machines: make(map[string]machineNetworking),
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			out.Machines[machineIds[i]] = machineNetworking{Err: result.Error.Error()}
+			continue
+		}
+		status := result.Result
+		var networking machineNetworking
+		for _, bridge := range status.FanBridges {
+			networking.FanBridges = append(networking.FanBridges, fanBridge{
+				Name:         bridge.Name,
+				Underlay:     bridge.Underlay,
+				Overlay:      bridge.Overlay,
+				LocalOverlay: bridge.LocalOverlay,
+				Present:      bridge.Present,
+				Up:           bridge.Up,
+			})
+		}
+		for _, fanErr := range status.FanErrors {
+			networking.FanErrors = append(networking.FanErrors, fanError{
+				Time:    common.FormatTime(&fanErr.Time, isoTime),
+				Message: fanErr.Message,
+			})
+		}
+		if !status.Updated.IsZero() {
+			networking.Updated = common.FormatTime(&status.Updated, isoTime)
+		}
+		out.Machines[machineIds[i]] = networking
+	}
+	return out
+}
+
+// formatMachinesNetworkingTabular writes a tabular summary of the
+// networking status of machines.
+func formatMachinesNetworkingTabular(writer io.Writer, networking machinesNetworking) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	ids := make([]string, 0, len(networking.Machines))
+	for id := range networking.Machines {
+		ids = append(ids, id)
+	}
+	ids = naturalsort.Sort(ids)
+
+	w.Println("Machine", "Bridge", "Underlay", "Overlay", "Local overlay", "Present", "Up")
+	for _, id := range ids {
+		m := networking.Machines[id]
+		if len(m.FanBridges) == 0 {
+			w.Println(id, "-")
+		}
+		for _, bridge := range m.FanBridges {
+			w.Println(id, bridge.Name, bridge.Underlay, bridge.Overlay, bridge.LocalOverlay, bridge.Present, bridge.Up)
+		}
+	}
+
+	var haveErrors bool
+	for _, id := range ids {
+		m := networking.Machines[id]
+		if m.Err != "" || len(m.FanErrors) > 0 {
+			haveErrors = true
+			break
+		}
+	}
+	if haveErrors {
+		w.Println()
+		w.Println("Machine", "Time", "Error")
+		for _, id := range ids {
+			m := networking.Machines[id]
+			if m.Err != "" {
+				w.Println(id, "", m.Err)
+			}
+			for _, fanErr := range m.FanErrors {
+				w.Println(id, fanErr.Time, fanErr.Message)
+			}
+		}
+	}
+	return errors.Trace(tw.Flush())
+}
+
+// allMachineIds returns the IDs of all machines and containers
+// in the given status.
+func allMachineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, m := range machines {
+		ids = append(ids, id)
+		ids = append(ids, allMachineIds(m.Containers)...)
+	}
+	return naturalsort.Sort(ids)
+}
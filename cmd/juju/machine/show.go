@@ -5,7 +5,10 @@ package machine
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/api/machinemanager"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -15,9 +18,15 @@ Show a specified machine on a model.  Default format is in yaml,
 other formats can be specified with the "--format" option.
 Available formats are yaml, tabular, and json
 
+With "--networking", the FAN networking status last reported by each
+machine is shown instead: the state of the machine's FAN bridges, the
+slice of each FAN overlay mapped to the machine, and the most recent
+errors hit configuring FAN networking.
+
 Examples:
     juju show-machine 0
     juju show-machine 1 2 3
+    juju show-machine --networking 0
 
 `
 
@@ -36,6 +45,8 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+	networking bool
+	networkAPI networkStatusAPI
 }
 
 // Info implements Command.Info.
@@ -53,3 +64,54 @@ func (c *showMachineCommand) Init(args []string) error {
 	c.machineIds = args
 	return nil
 }
+
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.networking, "networking", false, "Show the FAN networking status of the machines")
+}
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if !c.networking {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+
+	machineIds := c.machineIds
+	if len(machineIds) == 0 {
+		statusClient, err := newAPIClientForMachines(&c.baselistMachinesCommand)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer statusClient.Close()
+		fullStatus, err := statusClient.Status(nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineIds = allMachineIds(fullStatus.Machines)
+	}
+
+	client, err := c.getNetworkStatusAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	results, err := client.NetworkStatus(machineIds...)
+	if errors.IsNotSupported(err) {
+		return errors.New("show-machine --networking is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatMachinesNetworking(machineIds, results, c.isoTime))
+}
+
+func (c *showMachineCommand) getNetworkStatusAPI() (networkStatusAPI, error) {
+	if c.networkAPI != nil {
+		return c.networkAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
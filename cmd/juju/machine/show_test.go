@@ -4,11 +4,14 @@
 package machine_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -243,3 +246,94 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(actualJSON, gc.DeepEquals, expectedJSON)
 }
+
+type fakeNetworkStatusAPI struct {
+	machines []string
+}
+
+func (f *fakeNetworkStatusAPI) NetworkStatus(machines ...string) ([]params.MachineNetworkStatusResult, error) {
+	f.machines = machines
+	results := make([]params.MachineNetworkStatusResult, len(machines))
+	for i, id := range machines {
+		if id != "0" {
+			results[i].Error = &params.Error{Message: "boom"}
+			continue
+		}
+		results[i].Result = &params.MachineNetworkStatus{
+			FanBridges: []params.FanBridgeStatus{{
+				Name:         "fan-252",
+				Underlay:     "10.0.0.0/16",
+				Overlay:      "252.0.0.0/8",
+				LocalOverlay: "252.5.0.0/16",
+				Present:      true,
+				Up:           true,
+			}, {
+				Name:     "fan-253",
+				Underlay: "192.168.0.0/16",
+				Overlay:  "253.0.0.0/8",
+			}},
+			FanErrors: []params.FanConfigError{{
+				Time:    time.Date(2019, 8, 1, 11, 59, 0, 0, time.UTC),
+				Message: "fanatic failed",
+			}},
+		}
+	}
+	return results, nil
+}
+
+func (*fakeNetworkStatusAPI) Close() error {
+	return nil
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineNetworking(c *gc.C) {
+	networkAPI := &fakeNetworkStatusAPI{}
+	command := machine.NewShowNetworkingCommandForTest(&fakeStatusAPI{}, networkAPI)
+	context, err := cmdtesting.RunCommand(c, command, "--networking", "--utc", "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networkAPI.machines, jc.DeepEquals, []string{"0", "1"})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    fan-bridges:\n"+
+		"    - name: fan-252\n"+
+		"      underlay: 10.0.0.0/16\n"+
+		"      overlay: 252.0.0.0/8\n"+
+		"      local-overlay: 252.5.0.0/16\n"+
+		"      present: true\n"+
+		"      up: true\n"+
+		"    - name: fan-253\n"+
+		"      underlay: 192.168.0.0/16\n"+
+		"      overlay: 253.0.0.0/8\n"+
+		"      present: false\n"+
+		"      up: false\n"+
+		"    fan-errors:\n"+
+		"    - time: 2019-08-01 11:59:00Z\n"+
+		"      message: fanatic failed\n"+
+		"  \"1\":\n"+
+		"    error: boom\n",
+	)
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineNetworkingAllMachines(c *gc.C) {
+	networkAPI := &fakeNetworkStatusAPI{}
+	command := machine.NewShowNetworkingCommandForTest(&fakeStatusAPI{}, networkAPI)
+	_, err := cmdtesting.RunCommand(c, command, "--networking")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networkAPI.machines, jc.DeepEquals, []string{"0", "1", "1/lxd/0"})
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineNetworkingTabular(c *gc.C) {
+	command := machine.NewShowNetworkingCommandForTest(&fakeStatusAPI{}, &fakeNetworkStatusAPI{})
+	context, err := cmdtesting.RunCommand(c, command, "--networking", "--utc", "--format", "tabular", "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Bridge   Underlay        Overlay      Local overlay  Present  Up\n"+
+		"0        fan-252  10.0.0.0/16     252.0.0.0/8  252.5.0.0/16   true     true\n"+
+		"0        fan-253  192.168.0.0/16  253.0.0.0/8                 false    false\n"+
+		"1        -\n"+
+		"\n"+
+		"Machine  Time                  Error\n"+
+		"0        2019-08-01 11:59:00Z  fanatic failed\n"+
+		"1                              boom\n",
+	)
+}
@@ -44,6 +44,16 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetImageCommandForTest returns a setImageCommand with the api
+// provided as specified.
+func NewSetImageCommandForTest(api setImageAPI) cmd.Command {
+	cmd := &setImageCommand{
+		api: api,
+	}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -220,3 +230,17 @@ func NewDiffCommandForTest(api DiffCommandAPI, store jujuclient.ClientStore) cmd
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewTransferCommandForTest returns a TransferCommand with the apis provided as specified.
+func NewTransferCommandForTest(api TransferModelAPI, cloudAPI TransferCredentialAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &transferCommand{
+		api:      api,
+		cloudAPI: cloudAPI,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(
+		cmd,
+		modelcmd.WrapSkipDefaultModel,
+		modelcmd.WrapSkipModelFlags,
+	)
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/status"
 )
 
 func NewRetryProvisioningCommand() cmd.Command {
@@ -26,6 +29,7 @@ type retryProvisioningCommand struct {
 	modelcmd.ModelCommandBase
 	modelcmd.IAASOnlyCommand
 	Machines []names.MachineTag
+	all      bool
 	api      RetryProvisioningAPI
 }
 
@@ -34,17 +38,48 @@ type retryProvisioningCommand struct {
 type RetryProvisioningAPI interface {
 	Close() error
 	RetryProvisioning(machines ...names.MachineTag) ([]params.ErrorResult, error)
+	Status(patterns []string) (*params.FullStatus, error)
 }
 
+const retryProvisioningDoc = `
+Machines that failed to provision are left in an error state. The
+provisioner will retry starting them according to the model's
+provisioner-retry-count and provisioner-retry-delay settings; once
+those attempts are exhausted, this command asks the provisioner to
+try again.
+
+The --all option retries every machine in the model that is in an
+error state, and reports the outcome for each machine.
+
+Examples:
+    juju retry-provisioning 0 3
+    juju retry-provisioning --all
+
+See also:
+    model-config
+`
+
 func (c *retryProvisioningCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "retry-provisioning",
 		Args:    "<machine> [...]",
 		Purpose: "Retries provisioning for failed machines.",
+		Doc:     retryProvisioningDoc,
 	})
 }
 
+func (c *retryProvisioningCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.all, "all", false, "Retry provisioning all failed machines")
+}
+
 func (c *retryProvisioningCommand) Init(args []string) error {
+	if c.all {
+		if len(args) > 0 {
+			return errors.Errorf("specify machines or --all, not both")
+		}
+		return nil
+	}
 	if len(args) == 0 {
 		return errors.Errorf("no machine specified")
 	}
@@ -75,14 +110,49 @@ func (c *retryProvisioningCommand) Run(context *cmd.Context) error {
 	}
 	defer client.Close()
 
-	results, err := client.RetryProvisioning(c.Machines...)
+	machines := c.Machines
+	if c.all {
+		machines, err = failedMachines(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(machines) == 0 {
+			context.Infof("No machines in an error state")
+			return nil
+		}
+	}
+
+	results, err := client.RetryProvisioning(machines...)
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
-	for _, result := range results {
+	for i, result := range results {
 		if result.Error != nil {
 			fmt.Fprintf(context.Stderr, "%v\n", result.Error)
+		} else if c.all {
+			context.Infof("retrying provisioning of machine %s", machines[i].Id())
 		}
 	}
 	return nil
 }
+
+// failedMachines returns the tags of the top level machines in the
+// model whose status is error.
+func failedMachines(client RetryProvisioningAPI) ([]names.MachineTag, error) {
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model status")
+	}
+	var ids []string
+	for id, m := range fullStatus.Machines {
+		if m.AgentStatus.Status == status.Error.String() {
+			ids = append(ids, id)
+		}
+	}
+	ids = naturalsort.Sort(ids)
+	machines := make([]names.MachineTag, len(ids))
+	for i, id := range ids {
+		machines[i] = names.NewMachineTag(id)
+	}
+	return machines, nil
+}
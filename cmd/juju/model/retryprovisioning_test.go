@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -33,6 +34,21 @@ type fakeRetryProvisioningClient struct {
 	err error
 }
 
+func (f *fakeRetryProvisioningClient) Status(patterns []string) (*params.FullStatus, error) {
+	machines := make(map[string]params.MachineStatus)
+	for id, m := range f.m {
+		agentStatus := status.Started
+		if m.info == "broken" {
+			agentStatus = status.Error
+		}
+		machines[id] = params.MachineStatus{
+			Id:          id,
+			AgentStatus: params.DetailedStatus{Status: agentStatus.String()},
+		}
+	}
+	return &params.FullStatus{Machines: machines}, nil
+}
+
 type fakeMachine struct {
 	info string
 	data map[string]interface{}
@@ -117,6 +133,9 @@ var resolvedMachineTests = []struct {
 	}, {
 		args: []string{"0/lxd/0"},
 		err:  `invalid machine "0/lxd/0" retry-provisioning does not support containers`,
+	}, {
+		args: []string{"--all", "0"},
+		err:  `specify machines or --all, not both`,
 	},
 }
 
@@ -155,3 +174,26 @@ func (s *retryProvisioningSuite) TestBlockRetryProvisioning(c *gc.C) {
 		testing.AssertOperationWasBlocked(c, err, ".*TestBlockRetryProvisioning.*")
 	}
 }
+
+func (s *retryProvisioningSuite) TestRetryProvisioningAll(c *gc.C) {
+	s.fake.m["2"] = fakeMachine{info: "broken", data: make(map[string]interface{})}
+	command := model.NewRetryProvisioningCommandForTest(s.fake)
+	context, err := cmdtesting.RunCommand(c, command, "--all")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"retrying provisioning of machine 0\n"+
+		"retrying provisioning of machine 2\n")
+	c.Check(s.fake.m["0"].data["transient"], jc.IsTrue)
+	c.Check(s.fake.m["1"].data["transient"], gc.IsNil)
+	c.Check(s.fake.m["2"].data["transient"], jc.IsTrue)
+}
+
+func (s *retryProvisioningSuite) TestRetryProvisioningAllNoneFailed(c *gc.C) {
+	s.fake.m = map[string]fakeMachine{
+		"1": {info: "", data: make(map[string]interface{})},
+	}
+	command := model.NewRetryProvisioningCommandForTest(s.fake)
+	context, err := cmdtesting.RunCommand(c, command, "--all")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "No machines in an error state\n")
+}
@@ -0,0 +1,178 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/os/series"
+	"github.com/juju/utils/arch"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/modelconfig"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/config"
+)
+
+// NewSetImageCommand returns a command that pins the images used
+// to start machines in a model.
+func NewSetImageCommand() cmd.Command {
+	return modelcmd.Wrap(&setImageCommand{})
+}
+
+// setImageCommand sets the "image-ids" model config from a mapping
+// of series and architecture to image id.
+type setImageCommand struct {
+	modelcmd.ModelCommandBase
+	api setImageAPI
+
+	mappingFile string
+	reset       bool
+}
+
+// setImageAPI defines the methods on the model config API
+// that the set-image command calls.
+type setImageAPI interface {
+	Close() error
+	ModelSet(config map[string]interface{}) error
+	ModelUnset(keys ...string) error
+}
+
+const setImageDoc = `
+Pins the image used to start machines of each series and architecture
+in the model. The provisioner boots the pinned image instead of
+searching the image metadata, so that exactly the images prepared by
+the operator are used. Pinned images are honoured by the GCE,
+OpenStack and LXD providers.
+
+The mapping file is YAML, mapping series to architecture to image id:
+
+    bionic:
+      amd64: ami-0123456789abcdef0
+      arm64: ami-0fedcba9876543210
+    xenial:
+      amd64: ami-00112233445566778
+
+For LXD, the image id is the fingerprint or an alias of the image on
+the LXD server or one of the image remotes.
+
+Setting a mapping replaces any images previously pinned. The --reset
+option removes all pinned images, after which images are again found
+using the image metadata. Additional image metadata sources may be
+configured with the "image-metadata-urls" model config.
+
+Examples:
+    juju set-image images.yaml
+    juju set-image --reset
+
+See also:
+    model-config
+`
+
+// Info implements Command.Info.
+func (c *setImageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-image",
+		Args:    "<mapping.yaml>",
+		Purpose: "Pins the images used to start machines in a model.",
+		Doc:     setImageDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setImageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove all pinned images")
+}
+
+// Init implements Command.Init.
+func (c *setImageCommand) Init(args []string) error {
+	if c.reset {
+		if len(args) > 0 {
+			return errors.New("specify a mapping file or --reset, not both")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no image mapping file specified")
+	}
+	c.mappingFile = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *setImageCommand) getAPI() (setImageAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelconfig.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *setImageCommand) Run(ctx *cmd.Context) error {
+	var imageIds string
+	if !c.reset {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.mappingFile))
+		if err != nil {
+			return errors.Annotate(err, "reading image mapping")
+		}
+		imageIds, err = parseImageMapping(data)
+		if err != nil {
+			return errors.Annotatef(err, "invalid image mapping %q", c.mappingFile)
+		}
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.reset {
+		err = client.ModelUnset(config.ImageIdsKey)
+	} else {
+		err = client.ModelSet(map[string]interface{}{config.ImageIdsKey: imageIds})
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// parseImageMapping parses a YAML mapping of series to architecture to
+// image id, returning the equivalent "image-ids" model config value.
+func parseImageMapping(data []byte) (string, error) {
+	var mapping map[string]map[string]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(mapping) == 0 {
+		return "", errors.New("no images specified")
+	}
+	var pairs []string
+	for imageSeries, images := range mapping {
+		if _, err := series.SeriesVersion(imageSeries); err != nil {
+			return "", errors.NotValidf("series %q", imageSeries)
+		}
+		for imageArch, imageId := range images {
+			if !arch.IsSupportedArch(imageArch) {
+				return "", errors.NotValidf("architecture %q for series %q", imageArch, imageSeries)
+			}
+			if imageId == "" || strings.ContainsAny(imageId, " =") {
+				return "", errors.NotValidf("image id %q for %s/%s", imageId, imageSeries, imageArch)
+			}
+			pairs = append(pairs, fmt.Sprintf("%s/%s=%s", imageSeries, imageArch, imageId))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " "), nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type SetImageSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake fakeSetImageClient
+}
+
+var _ = gc.Suite(&SetImageSuite{})
+
+type fakeSetImageClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSetImageClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSetImageClient) ModelSet(config map[string]interface{}) error {
+	f.MethodCall(f, "ModelSet", config)
+	return f.NextErr()
+}
+
+func (f *fakeSetImageClient) ModelUnset(keys ...string) error {
+	f.MethodCall(f, "ModelUnset", keys)
+	return f.NextErr()
+}
+
+func (s *SetImageSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+}
+
+func (s *SetImageSuite) writeMapping(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "images.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *SetImageSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no image mapping file specified",
+	}, {
+		args: []string{"a.yaml", "b.yaml"},
+		err:  `unrecognized args: \["b.yaml"\]`,
+	}, {
+		args: []string{"--reset", "a.yaml"},
+		err:  "specify a mapping file or --reset, not both",
+	}} {
+		c.Logf("test %d", i)
+		_, err := cmdtesting.RunCommand(c, model.NewSetImageCommandForTest(&s.fake), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *SetImageSuite) TestSetImage(c *gc.C) {
+	path := s.writeMapping(c, `
+bionic:
+  amd64: ami-bionic-amd64
+  arm64: ami-bionic-arm64
+xenial:
+  amd64: ami-xenial-amd64
+`)
+	_, err := cmdtesting.RunCommand(c, model.NewSetImageCommandForTest(&s.fake), path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelSet", []interface{}{map[string]interface{}{
+			"image-ids": "bionic/amd64=ami-bionic-amd64 bionic/arm64=ami-bionic-arm64 xenial/amd64=ami-xenial-amd64",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetImageSuite) TestSetImageReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSetImageCommandForTest(&s.fake), "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelUnset", []interface{}{[]string{"image-ids"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetImageSuite) TestSetImageInvalidMapping(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "",
+		err:     `invalid image mapping .*: no images specified`,
+	}, {
+		content: "bionic: ami-123",
+		err:     `(?s)invalid image mapping .*: yaml: unmarshal errors:.*`,
+	}, {
+		content: "notaseries:\n  amd64: ami-123",
+		err:     `invalid image mapping .*: series "notaseries" not valid`,
+	}, {
+		content: "bionic:\n  sparc: ami-123",
+		err:     `invalid image mapping .*: architecture "sparc" for series "bionic" not valid`,
+	}, {
+		content: "bionic:\n  amd64: \"\"",
+		err:     `invalid image mapping .*: image id "" for bionic/amd64 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		path := s.writeMapping(c, test.content)
+		_, err := cmdtesting.RunCommand(c, model.NewSetImageCommandForTest(&s.fake), path)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *SetImageSuite) TestSetImageError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	path := s.writeMapping(c, "bionic:\n  amd64: ami-123\n")
+	_, err := cmdtesting.RunCommand(c, model.NewSetImageCommandForTest(&s.fake), path)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,264 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewTransferCommand returns a command used to transfer
+// the ownership of a model to another user.
+func NewTransferCommand() cmd.Command {
+	return modelcmd.Wrap(
+		&transferCommand{},
+		modelcmd.WrapSkipDefaultModel,
+		modelcmd.WrapSkipModelFlags,
+	)
+}
+
+// TransferModelAPI defines the methods on the modelmanager API that
+// the transfer-model command calls. It is exported for mocking in tests.
+type TransferModelAPI interface {
+	Close() error
+	ModelInfo([]names.ModelTag) ([]params.ModelInfoResult, error)
+	TransferModelOwnership(model names.ModelTag, owner names.UserTag, credential names.CloudCredentialTag) error
+}
+
+// TransferCredentialAPI defines the methods on the cloud API that
+// the transfer-model command calls. It is exported for mocking in tests.
+type TransferCredentialAPI interface {
+	Close() error
+	UserCredentials(names.UserTag, names.CloudTag) ([]names.CloudCredentialTag, error)
+}
+
+// transferCommand transfers the ownership of a model to another user.
+type transferCommand struct {
+	modelcmd.ModelCommandBase
+
+	api      TransferModelAPI
+	cloudAPI TransferCredentialAPI
+
+	assumeYes  bool
+	newOwner   names.UserTag
+	credential string
+}
+
+const transferDoc = `
+Transfers the ownership of the specified model to another user.
+
+The new owner is granted admin access to the model. The current owner
+keeps the access it already has; use 'juju revoke' to remove it once
+the transfer is complete. The new owner must not already own a model
+with the same name.
+
+A controller administrator may transfer any model. Otherwise, a transfer
+needs the consent of both users: the current owner first grants the new
+owner admin access to the model with 'juju grant', and the new owner
+then runs this command to take ownership.
+
+If the model uses a cloud credential, a credential belonging to the new
+owner for the model's cloud must be supplied with --credential, so that
+the model no longer relies on the current owner's credential. The
+credential must already exist on the controller.
+
+The command shows what will change and prompts for confirmation
+(unless overridden with the '-y' option) before taking any action.
+
+Examples:
+
+    juju transfer-model alice/mymodel bob
+    juju transfer-model -y alice/mymodel bob --credential bobs-aws
+
+See also:
+    grant
+    revoke
+    set-credential
+    show-credential
+`
+
+var transferModelMsg = `
+Model %q will be transferred from %q to %q.
+`[1:]
+
+var transferCredentialMsg = `
+The model's cloud credential will change from %q to %q.
+`[1:]
+
+var transferConfirmMsg = `
+Continue [y/N]? `[1:]
+
+// Info implements Command.Info.
+func (c *transferCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "transfer-model",
+		Args:    "[<controller name>:]<model name> <new owner>",
+		Purpose: "Transfers the ownership of a model to another user.",
+		Doc:     transferDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *transferCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.StringVar(&c.credential, "credential", "", "The new owner's cloud credential for the model to use")
+}
+
+// Init implements Command.Init.
+func (c *transferCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no model specified")
+	case 1:
+		return errors.New("no new owner specified")
+	}
+	if !names.IsValidUser(args[1]) {
+		return errors.NotValidf("user name %q", args[1])
+	}
+	if c.credential != "" && !names.IsValidCloudCredentialName(c.credential) {
+		return errors.NotValidf("cloud credential name %q", c.credential)
+	}
+	if err := c.SetModelIdentifier(args[0], false); err != nil {
+		return errors.Trace(err)
+	}
+	c.newOwner = names.NewUserTag(args[1])
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *transferCommand) getAPI() (TransferModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+func (c *transferCommand) getCloudAPI() (TransferCredentialAPI, error) {
+	if c.cloudAPI != nil {
+		return c.cloudAPI, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cloudapi.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *transferCommand) Run(ctx *cmd.Context) error {
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to API")
+	}
+	defer api.Close()
+
+	results, err := api.ModelInfo([]names.ModelTag{modelTag})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return errors.Trace(results[0].Error)
+	}
+	info := results[0].Result
+
+	currentOwner, err := names.ParseUserTag(info.OwnerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if currentOwner == c.newOwner {
+		return errors.Errorf("model %q is already owned by %q", modelName, c.newOwner.Id())
+	}
+	cloudTag, err := names.ParseCloudTag(info.CloudTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var currentCredential, newCredential names.CloudCredentialTag
+	if info.CloudCredentialTag != "" {
+		if currentCredential, err = names.ParseCloudCredentialTag(info.CloudCredentialTag); err != nil {
+			return errors.Trace(err)
+		}
+		if c.credential == "" {
+			return errors.Errorf(
+				"model %q uses cloud credential %q; use --credential to specify a credential belonging to %q",
+				modelName, currentCredential.Id(), c.newOwner.Id(),
+			)
+		}
+	}
+	if c.credential != "" {
+		if newCredential, err = common.ResolveCloudCredentialTag(c.newOwner, cloudTag, c.credential); err != nil {
+			return errors.Annotate(err, "resolving credential")
+		}
+		if err := c.checkCredential(ctx, cloudTag, newCredential); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if !c.assumeYes {
+		fmt.Fprintf(ctx.Stdout, transferModelMsg, modelName, currentOwner.Id(), c.newOwner.Id())
+		if newCredential != (names.CloudCredentialTag{}) {
+			fmt.Fprintf(ctx.Stdout, transferCredentialMsg, currentCredential.Id(), newCredential.Id())
+		}
+		fmt.Fprint(ctx.Stdout, transferConfirmMsg)
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "model transfer")
+		}
+	}
+
+	if err := api.TransferModelOwnership(modelTag, c.newOwner, newCredential); err != nil {
+		return block.ProcessBlockedError(errors.Annotate(err, "cannot transfer model"), block.BlockChange)
+	}
+	ctx.Infof("Transferred model %q to %q.", modelName, c.newOwner.Id())
+	return nil
+}
+
+// checkCredential confirms that the new owner's credential exists on
+// the controller for the model's cloud. Only controller administrators
+// may list another user's credentials; when the check cannot be made,
+// the controller still validates the credential during the transfer.
+func (c *transferCommand) checkCredential(ctx *cmd.Context, cloudTag names.CloudTag, credential names.CloudCredentialTag) error {
+	cloudAPI, err := c.getCloudAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to API")
+	}
+	defer cloudAPI.Close()
+
+	credentials, err := cloudAPI.UserCredentials(c.newOwner, cloudTag)
+	if err != nil {
+		ctx.Infof("Could not verify the credentials of %q: %v", c.newOwner.Id(), err)
+		return nil
+	}
+	for _, tag := range credentials {
+		if tag == credential {
+			return nil
+		}
+	}
+	return errors.NotFoundf("credential %q for user %q on cloud %q", c.credential, c.newOwner.Id(), cloudTag.Id())
+}
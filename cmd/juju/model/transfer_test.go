@@ -0,0 +1,202 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type TransferSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api      *fakeTransferAPI
+	cloudAPI *fakeTransferCloudAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&TransferSuite{})
+
+func (s *TransferSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.api = &fakeTransferAPI{
+		info: params.ModelInfo{
+			Name:               "mymodel",
+			OwnerTag:           "user-admin",
+			CloudTag:           "cloud-aws",
+			CloudCredentialTag: "cloudcred-aws_admin_default",
+		},
+	}
+	s.cloudAPI = &fakeTransferCloudAPI{
+		credentials: []names.CloudCredentialTag{
+			names.NewCloudCredentialTag("aws/bob/bobs"),
+		},
+	}
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TransferSuite) run(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	command := model.NewTransferCommandForTest(s.api, s.cloudAPI, s.store)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return nil, err
+	}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	return ctx, command.Run(ctx)
+}
+
+func (s *TransferSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no model specified",
+	}, {
+		args: []string{"mymodel"},
+		err:  "no new owner specified",
+	}, {
+		args: []string{"mymodel", "b@d@user"},
+		err:  `user name "b@d@user" not valid`,
+	}, {
+		args: []string{"mymodel", "bob", "--credential", "bad/cred"},
+		err:  `cloud credential name "bad/cred" not valid`,
+	}, {
+		args: []string{"mymodel", "bob", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, "", test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *TransferSuite) TestTransfer(c *gc.C) {
+	ctx, err := s.run(c, "y\n", "mymodel", "bob", "--credential", "bobs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model "admin/mymodel" will be transferred from "admin" to "bob".
+The model's cloud credential will change from "aws/admin/default" to "aws/bob/bobs".
+Continue [y/N]? `[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Transferred model \"admin/mymodel\" to \"bob\".\n")
+
+	s.cloudAPI.CheckCall(c, 0, "UserCredentials", names.NewUserTag("bob"), names.NewCloudTag("aws"))
+	s.api.CheckCalls(c, []jutesting.StubCall{
+		{"ModelInfo", []interface{}{[]names.ModelTag{testing.ModelTag}}},
+		{"TransferModelOwnership", []interface{}{
+			testing.ModelTag, names.NewUserTag("bob"), names.NewCloudCredentialTag("aws/bob/bobs"),
+		}},
+	})
+}
+
+func (s *TransferSuite) TestTransferWithoutCredential(c *gc.C) {
+	s.api.info.CloudCredentialTag = ""
+	_, err := s.run(c, "", "mymodel", "bob", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	s.cloudAPI.CheckNoCalls(c)
+	s.api.CheckCall(c, 1, "TransferModelOwnership",
+		testing.ModelTag, names.NewUserTag("bob"), names.CloudCredentialTag{},
+	)
+}
+
+func (s *TransferSuite) TestTransferAborted(c *gc.C) {
+	_, err := s.run(c, "n\n", "mymodel", "bob", "--credential", "bobs")
+	c.Assert(err, gc.ErrorMatches, "model transfer: aborted")
+	s.api.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *TransferSuite) TestTransferCredentialRequired(c *gc.C) {
+	_, err := s.run(c, "", "mymodel", "bob", "-y")
+	c.Assert(err, gc.ErrorMatches,
+		`model "admin/mymodel" uses cloud credential "aws/admin/default"; use --credential to specify a credential belonging to "bob"`)
+	s.api.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *TransferSuite) TestTransferCredentialNotFound(c *gc.C) {
+	_, err := s.run(c, "", "mymodel", "bob", "-y", "--credential", "other")
+	c.Assert(err, gc.ErrorMatches, `credential "other" for user "bob" on cloud "aws" not found`)
+	s.api.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *TransferSuite) TestTransferCredentialsNotListable(c *gc.C) {
+	s.cloudAPI.SetErrors(&params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	ctx, err := s.run(c, "", "mymodel", "bob", "-y", "--credential", "bobs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Could not verify the credentials of "bob": permission denied`)
+	s.api.CheckCallNames(c, "ModelInfo", "TransferModelOwnership")
+}
+
+func (s *TransferSuite) TestTransferSameOwner(c *gc.C) {
+	_, err := s.run(c, "", "mymodel", "admin", "-y")
+	c.Assert(err, gc.ErrorMatches, `model "admin/mymodel" is already owned by "admin"`)
+	s.api.CheckCallNames(c, "ModelInfo")
+}
+
+func (s *TransferSuite) TestTransferFails(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, "", "mymodel", "bob", "-y", "--credential", "bobs")
+	c.Assert(err, gc.ErrorMatches, "cannot transfer model: boom")
+}
+
+type fakeTransferAPI struct {
+	jutesting.Stub
+	info params.ModelInfo
+}
+
+func (f *fakeTransferAPI) Close() error { return nil }
+
+func (f *fakeTransferAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	f.MethodCall(f, "ModelInfo", tags)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	info := f.info
+	return []params.ModelInfoResult{{Result: &info}}, nil
+}
+
+func (f *fakeTransferAPI) TransferModelOwnership(model names.ModelTag, owner names.UserTag, credential names.CloudCredentialTag) error {
+	f.MethodCall(f, "TransferModelOwnership", model, owner, credential)
+	return f.NextErr()
+}
+
+type fakeTransferCloudAPI struct {
+	jutesting.Stub
+	credentials []names.CloudCredentialTag
+}
+
+func (f *fakeTransferCloudAPI) Close() error { return nil }
+
+func (f *fakeTransferCloudAPI) UserCredentials(user names.UserTag, cloud names.CloudTag) ([]names.CloudCredentialTag, error) {
+	f.MethodCall(f, "UserCredentials", user, cloud)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.credentials, nil
+}
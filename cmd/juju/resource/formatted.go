@@ -41,6 +41,7 @@ type FormattedAppResource struct {
 	Used          bool      `json:"used" yaml:"used"`
 	Timestamp     time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
 	Username      string    `json:"username,omitempty" yaml:"username,omitempty"`
+	ImageScan     string    `json:"image-scan,omitempty" yaml:"image-scan,omitempty"`
 
 	CombinedRevision string `json:"-"`
 	UsedYesNo        string `json:"-"`
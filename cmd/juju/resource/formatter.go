@@ -13,6 +13,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
@@ -78,6 +79,7 @@ func FormatAppResource(res resource.Resource) FormattedAppResource {
 		CombinedRevision: combinedRevision(res),
 		CombinedOrigin:   combinedOrigin(used, res),
 		UsedYesNo:        usedYesNo(used),
+		ImageScan:        formatImageScan(res.ImageScan),
 	}
 	// Have to check since revision 0 is still a valid revision.
 	if res.Revision >= 0 {
@@ -187,6 +189,27 @@ func combinedOrigin(used bool, r resource.Resource) string {
 	return r.Origin.String()
 }
 
+// formatImageScan summarises the vulnerability scan of a container
+// image resource.
+func formatImageScan(scan *resources.ImageScanResult) string {
+	if scan == nil {
+		return ""
+	}
+	var result string
+	switch {
+	case scan.Pending:
+		result = "pending"
+	case scan.Error != "":
+		result = "failed: " + scan.Error
+	default:
+		result = fmt.Sprintf("%d critical, %d high", scan.Critical, scan.High)
+	}
+	if scan.Blocked {
+		result += " (blocked)"
+	}
+	return result
+}
+
 func usedYesNo(used bool) string {
 	if used {
 		return "yes"
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	resourcecmd "github.com/juju/juju/cmd/juju/resource"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
@@ -131,6 +132,27 @@ func (s *SvcFormatterSuite) TestInitialOriginUpload(c *gc.C) {
 	c.Assert(f.CombinedOrigin, gc.Equals, "upload")
 }
 
+func (s *SvcFormatterSuite) TestImageScan(c *gc.C) {
+	for _, test := range []struct {
+		scan     *resources.ImageScanResult
+		expected string
+	}{{
+		expected: "",
+	}, {
+		scan:     &resources.ImageScanResult{Pending: true, Blocked: true},
+		expected: "pending (blocked)",
+	}, {
+		scan:     &resources.ImageScanResult{Error: "timed out"},
+		expected: "failed: timed out",
+	}, {
+		scan:     &resources.ImageScanResult{Critical: 1, High: 4, Blocked: true},
+		expected: "1 critical, 4 high (blocked)",
+	}} {
+		f := resourcecmd.FormatAppResource(resource.Resource{ImageScan: test.scan})
+		c.Check(f.ImageScan, gc.Equals, test.expected)
+	}
+}
+
 var _ = gc.Suite(&DetailFormatterSuite{})
 
 type DetailFormatterSuite struct {
@@ -44,6 +44,15 @@ func (s *stubAPIClient) Upload(application, name, filename string, resource io.R
 	return nil
 }
 
+func (s *stubAPIClient) UploadPinnedImage(application, name, filename string, resource io.ReadSeeker) error {
+	s.stub.AddCall("UploadPinnedImage", application, name, filename, resource)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) ListResources(applications []string) ([]resource.ApplicationResources, error) {
 	s.stub.AddCall("ListResources", applications)
 	if err := s.stub.NextErr(); err != nil {
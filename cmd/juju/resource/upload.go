@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/names.v2"
 
@@ -22,6 +23,10 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(application, name, filename string, resource io.ReadSeeker) error
 
+	// UploadPinnedImage sends the container image resource to Juju,
+	// asking for it to be pinned to the image's current digest.
+	UploadPinnedImage(application, name, filename string, resource io.ReadSeeker) error
+
 	// ListResources returns info about resources for applications in the model.
 	ListResources(applications []string) ([]resource.ApplicationResources, error)
 
@@ -51,6 +56,7 @@ type UploadCommand struct {
 	modelcmd.ModelCommandBase
 	application   string
 	resourceValue resourceValue
+	fromRegistry  bool
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -69,6 +75,10 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+With --from-oci-registry the controller looks up the digest the registry currently holds
+for the image's tag and pins the resource to it, so the application is not affected if the
+tag is later pushed again. The original image reference is recorded with the resource.
 `
 )
 
@@ -83,6 +93,12 @@ func (c *UploadCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.fromRegistry, "from-oci-registry", false, "Pin the OCI image resource to the digest its registry holds for the image")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -140,6 +156,9 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 			c.resourceValue.resourceType = r.Type
 		}
 	}
+	if c.fromRegistry && c.resourceValue.resourceType != charmresource.TypeContainerImage {
+		return errors.Errorf("--from-oci-registry requires an OCI image resource, %q is a %s resource", c.resourceValue.name, c.resourceValue.resourceType)
+	}
 
 	if err := c.upload(c.resourceValue, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceValue.name)
@@ -155,7 +174,11 @@ func (c *UploadCommand) upload(rf resourceValue, client UploadClient) error {
 		return errors.Trace(err)
 	}
 	defer f.Close()
-	err = client.Upload(rf.application, rf.name, rf.value, f)
+	if c.fromRegistry {
+		err = client.UploadPinnedImage(rf.application, rf.name, rf.value, f)
+	} else {
+		err = client.Upload(rf.application, rf.name, rf.value, f)
+	}
 	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
 		return errors.Trace(err)
 	}
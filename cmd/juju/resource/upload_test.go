@@ -5,8 +5,12 @@ package resource_test
 
 import (
 	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
 
 	jujucmd "github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/testing"
@@ -107,6 +111,10 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+With --from-oci-registry the controller looks up the digest the registry currently holds
+for the image's tag and pins the resource to it, so the application is not affected if the
+tag is later pushed again. The original image reference is recorded with the resource.
 `,
 		Aliases:        []string{"attach"},
 		FlagKnownAs:    "option",
@@ -223,6 +231,57 @@ password: hunter2
 	s.stub.CheckCall(c, 2, "OpenResource", "bar")
 }
 
+func (s *UploadSuite) TestUploadDockerResourceFromRegistry(c *gc.C) {
+	s.stubDeps.client.(*stubAPIClient).resources = resource.ApplicationResources{
+		Resources: []resource.Resource{{Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name: "foo",
+				Type: charmresource.TypeContainerImage,
+			},
+		}}},
+	}
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	err := cmdtesting.InitCommand(u, []string{"--from-oci-registry", "svc", "foo=mariadb:10.3"})
+	c.Assert(err, jc.ErrorIsNil)
+	// The image reference is not a local file.
+	s.stub.SetErrors(nil, nil, os.ErrNotExist)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"OpenResource",
+		"UploadPinnedImage",
+		"Close",
+	)
+	s.stub.CheckCall(c, 2, "OpenResource", "mariadb:10.3")
+	args := s.stub.Calls()[3].Args
+	c.Assert(args[:3], jc.DeepEquals, []interface{}{"svc", "foo", "mariadb:10.3"})
+	data, err := ioutil.ReadAll(args[3].(io.Reader))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `{"ImageName":"mariadb:10.3","Username":""}`)
+}
+
+func (s *UploadSuite) TestUploadFileResourceFromRegistry(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	},
+	)
+	err := cmdtesting.InitCommand(u, []string{"--from-oci-registry", "svc", "foo=bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, gc.ErrorMatches, `--from-oci-registry requires an OCI image resource, "foo" is a file resource`)
+	s.stub.CheckCallNames(c, "NewClient", "ListResources", "Close")
+}
+
 type stubUploadDeps struct {
 	stub   *testing.Stub
 	file   resourcecmd.ReadSeekCloser
@@ -30,6 +30,17 @@ type formattedMachineStatus struct {
 	Machines map[string]machineStatus `json:"machines"`
 }
 
+type formattedStatusSummary struct {
+	Applications        int               `json:"applications" yaml:"applications"`
+	Machines            int               `json:"machines" yaml:"machines"`
+	Containers          int               `json:"containers" yaml:"containers"`
+	Units               int               `json:"units" yaml:"units"`
+	UnitWorkloadStatus  map[string]int    `json:"unit-workload-status,omitempty" yaml:"unit-workload-status,omitempty"`
+	UnitAgentStatus     map[string]int    `json:"unit-agent-status,omitempty" yaml:"unit-agent-status,omitempty"`
+	MachineAgentStatus  map[string]int    `json:"machine-agent-status,omitempty" yaml:"machine-agent-status,omitempty"`
+	ApplicationVersions map[string]string `json:"application-versions,omitempty" yaml:"application-versions,omitempty"`
+}
+
 type errorStatus struct {
 	StatusError string `json:"status-error" yaml:"status-error"`
 }
@@ -77,6 +88,15 @@ type machineStatus struct {
 	Hardware           string                        `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus           string                        `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
 	LXDProfiles        map[string]lxdProfileContents `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
+	Labels             map[string]string             `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Utilization        *machineUtilization           `json:"utilization,omitempty" yaml:"utilization,omitempty"`
+}
+
+// machineUtilization holds the resource utilization of a
+// machine's instance, as reported by the provider.
+type machineUtilization struct {
+	CPUPercent    *float64 `json:"cpu-percent,omitempty" yaml:"cpu-percent,omitempty"`
+	MemoryPercent *float64 `json:"memory-percent,omitempty" yaml:"memory-percent,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -133,7 +153,19 @@ type applicationStatus struct {
 	SubordinateTo    []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units            map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
 	Version          string                `json:"version,omitempty" yaml:"version,omitempty"`
+	MixedVersions    []string              `json:"mixed-versions,omitempty" yaml:"mixed-versions,omitempty"`
 	EndpointBindings map[string]string     `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
+	Health           *applicationHealth    `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// applicationHealth summarises the workload status of an
+// application's units.
+type applicationHealth struct {
+	Score   int `json:"score" yaml:"score"`
+	Active  int `json:"active" yaml:"active"`
+	Blocked int `json:"blocked" yaml:"blocked"`
+	Error   int `json:"error" yaml:"error"`
+	Other   int `json:"other" yaml:"other"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -227,6 +259,7 @@ type unitStatus struct {
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Address       string                `json:"address,omitempty" yaml:"address,omitempty"`
 	ProviderId    string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
+	Labels        map[string]string     `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/os"
 	"github.com/juju/os/series"
 	"gopkg.in/juju/charm.v6"
@@ -25,6 +26,11 @@ type statusFormatter struct {
 	relations              map[int]params.RelationStatus
 	storage                *storage.CombinedStorage
 	isoTime, showRelations bool
+
+	// showHealth indicates if application health is displayed, and
+	// rollupSubordinates if that health includes the status of the
+	// subordinate units.
+	showHealth, rollupSubordinates bool
 }
 
 // NewStatusFormatter takes stored model information (params.FullStatus) and populates
@@ -43,16 +49,20 @@ type newStatusFormatterParams struct {
 	status                 *params.FullStatus
 	controllerName         string
 	isoTime, showRelations bool
+
+	showHealth, rollupSubordinates bool
 }
 
 func newStatusFormatter(p newStatusFormatterParams) *statusFormatter {
 	sf := statusFormatter{
-		storage:        p.storage,
-		status:         p.status,
-		controllerName: p.controllerName,
-		relations:      make(map[int]params.RelationStatus),
-		isoTime:        p.isoTime,
-		showRelations:  p.showRelations,
+		storage:            p.storage,
+		status:             p.status,
+		controllerName:     p.controllerName,
+		relations:          make(map[int]params.RelationStatus),
+		isoTime:            p.isoTime,
+		showRelations:      p.showRelations,
+		showHealth:         p.showHealth,
+		rollupSubordinates: p.rollupSubordinates,
 	}
 	if p.showRelations {
 		for _, relation := range p.status.Relations {
@@ -163,6 +173,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Constraints:        machine.Constraints,
 		Hardware:           machine.Hardware,
 		LXDProfiles:        make(map[string]lxdProfileContents),
+		Labels:             machine.Labels,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -180,6 +191,13 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		out.Containers[k] = sf.formatMachine(m)
 	}
 
+	if machine.Utilization != nil {
+		out.Utilization = &machineUtilization{
+			CPUPercent:    machine.Utilization.CPUPercent,
+			MemoryPercent: machine.Utilization.MemoryPercent,
+		}
+	}
+
 	for _, job := range machine.Jobs {
 		if job == multiwatcher.JobManageModel {
 			out.HAStatus = makeHAStatus(machine.HasVote, machine.WantsVote)
@@ -250,8 +268,16 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Units:            make(map[string]unitStatus),
 		StatusInfo:       sf.getApplicationStatusInfo(application),
 		Version:          application.WorkloadVersion,
+		MixedVersions:    mixedWorkloadVersions(application.Units),
 		EndpointBindings: application.EndpointBindings,
 	}
+	if sf.showHealth {
+		health := application.Health
+		if sf.rollupSubordinates && application.RollupHealth != nil {
+			health = application.RollupHealth
+		}
+		out.Health = formatApplicationHealth(health)
+	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
@@ -264,6 +290,35 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+func formatApplicationHealth(health *params.ApplicationHealth) *applicationHealth {
+	if health == nil {
+		return nil
+	}
+	return &applicationHealth{
+		Score:   health.Score,
+		Active:  health.Active,
+		Blocked: health.Blocked,
+		Error:   health.Error,
+		Other:   health.Other,
+	}
+}
+
+// mixedWorkloadVersions returns the sorted distinct workload versions
+// reported by the units, if they do not all report the same version.
+// This indicates that a rollout of a new workload version is incomplete.
+func mixedWorkloadVersions(units map[string]params.UnitStatus) []string {
+	versions := set.NewStrings()
+	for _, unit := range units {
+		if unit.WorkloadVersion != "" {
+			versions.Add(unit.WorkloadVersion)
+		}
+	}
+	if versions.Size() < 2 {
+		return nil
+	}
+	return versions.SortedValues()
+}
+
 func (sf *statusFormatter) formatRemoteApplication(name string, application params.RemoteApplicationStatus) remoteApplicationStatus {
 	out := remoteApplicationStatus{
 		Err:        typedNilCheck(application.Err),
@@ -393,6 +448,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
+		Labels:             info.unit.Labels,
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
@@ -509,3 +565,18 @@ func findOtherEndpoint(endpoints []params.EndpointStatus, applicationName string
 	}
 	return params.EndpointStatus{}, false
 }
+
+// formatStatusSummary returns the model status summary
+// in the form used for output.
+func formatStatusSummary(summary *params.ModelStatusSummary) formattedStatusSummary {
+	return formattedStatusSummary{
+		Applications:        summary.ApplicationCount,
+		Machines:            summary.MachineCount,
+		Containers:          summary.ContainerCount,
+		Units:               summary.UnitCount,
+		UnitWorkloadStatus:  summary.UnitWorkloadStatus,
+		UnitAgentStatus:     summary.UnitAgentStatus,
+		MachineAgentStatus:  summary.MachineAgentStatus,
+		ApplicationVersions: summary.ApplicationVersions,
+	}
+}
@@ -184,7 +184,7 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	}
 	var tag names.Tag
 	switch kind {
-	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+	case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindWorkloadVersion:
 		if !names.IsValidUnit(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
@@ -103,6 +103,25 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	return nil
 }
 
+// formatHealthTabular returns the percentage of healthy units,
+// followed by the number of units in error or blocked, if any.
+func formatHealthTabular(health *applicationHealth) string {
+	if health == nil {
+		return ""
+	}
+	var problems []string
+	if health.Error > 0 {
+		problems = append(problems, fmt.Sprintf("%d error", health.Error))
+	}
+	if health.Blocked > 0 {
+		problems = append(problems, fmt.Sprintf("%d blocked", health.Blocked))
+	}
+	if len(problems) == 0 {
+		return fmt.Sprintf("%d%%", health.Score)
+	}
+	return fmt.Sprintf("%d%% (%s)", health.Score, strings.Join(problems, ", "))
+}
+
 func startSection(tw *ansiterm.TabWriter, top bool, headers ...interface{}) output.Wrapper {
 	w := output.Wrapper{tw}
 	if !top {
@@ -124,13 +143,23 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 	truncatedWidth := maxVersionWidth - len(ellipsis)
 
 	metering := fs.Model.MeterStatus != nil
+	showHealth := false
+	for _, app := range fs.Applications {
+		if app.Health != nil {
+			showHealth = true
+			break
+		}
+	}
 	units := make(map[string]unitStatus)
-	var w output.Wrapper
+	header := []interface{}{"App", "Version", "Status", "Scale", "Charm", "Store", "Rev", "OS"}
 	if fs.Model.Type == caasModelType {
-		w = startSection(tw, false, "App", "Version", "Status", "Scale", "Charm", "Store", "Rev", "OS", "Address", "Notes")
-	} else {
-		w = startSection(tw, false, "App", "Version", "Status", "Scale", "Charm", "Store", "Rev", "OS", "Notes")
+		header = append(header, "Address")
 	}
+	if showHealth {
+		header = append(header, "Health")
+	}
+	header = append(header, "Notes")
+	w := startSection(tw, false, header...)
 	tw.SetColumnAlignRight(3)
 	tw.SetColumnAlignRight(6)
 	for _, appName := range naturalsort.Sort(stringKeysFromMap(fs.Applications)) {
@@ -148,10 +177,16 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 			version = version[:truncatedWidth] + ellipsis
 		}
 		// Notes may well contain other things later.
-		notes := ""
+		var noteItems []string
 		if app.Exposed {
-			notes = "exposed"
+			noteItems = append(noteItems, "exposed")
+		}
+		// Flag applications whose units report different workload
+		// versions, such as during a partial rollout.
+		if len(app.MixedVersions) > 0 {
+			noteItems = append(noteItems, "mixed versions")
 		}
+		notes := strings.Join(noteItems, ", ")
 		// Expose any operator messages.
 		if fs.Model.Type == caasModelType {
 			if app.StatusInfo.Message != "" {
@@ -174,6 +209,9 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		if fs.Model.Type == caasModelType {
 			w.Print(app.Address)
 		}
+		if showHealth {
+			w.Print(formatHealthTabular(app.Health))
+		}
 
 		w.Println(notes)
 		for un, u := range app.Units {
@@ -431,6 +469,86 @@ func FormatMachineTabular(writer io.Writer, forceColor bool, value interface{})
 	return nil
 }
 
+// FormatMachineUtilizationTabular writes a tabular summary of machines
+// and the resource utilization of their instances reported by the
+// provider. Utilization the provider does not report is left blank.
+func FormatMachineUtilizationTabular(writer io.Writer, forceColor bool, value interface{}) error {
+	fs, valueConverted := value.(formattedMachineStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	tw := output.TabWriter(writer)
+	if forceColor {
+		tw.SetColorCapable(forceColor)
+	}
+	w := startSection(tw, true, "Machine", "State", "Inst id", "CPU", "Memory")
+	for _, name := range naturalsort.Sort(stringKeysFromMap(fs.Machines)) {
+		printMachineUtilization(w, fs.Machines[name])
+	}
+	endSection(tw)
+	return nil
+}
+
+func printMachineUtilization(w output.Wrapper, m machineStatus) {
+	status, _ := getStatusAndMessageFromMachineStatus(m)
+	var cpu, memory string
+	if m.Utilization != nil {
+		cpu = formatPercent(m.Utilization.CPUPercent)
+		memory = formatPercent(m.Utilization.MemoryPercent)
+	}
+	w.Print(m.Id)
+	w.PrintStatus(status)
+	w.Println(m.machineName(), cpu, memory)
+
+	for _, name := range naturalsort.Sort(stringKeysFromMap(m.Containers)) {
+		printMachineUtilization(w, m.Containers[name])
+	}
+}
+
+// FormatStatusSummaryTabular writes a tabular summary of the counts
+// of the model's entities and their statuses.
+func FormatStatusSummaryTabular(writer io.Writer, forceColor bool, value interface{}) error {
+	fs, valueConverted := value.(formattedStatusSummary)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	tw := output.TabWriter(writer)
+	if forceColor {
+		tw.SetColorCapable(forceColor)
+	}
+	w := startSection(tw, true, "Applications", "Machines", "Containers", "Units")
+	w.Println(fs.Applications, fs.Machines, fs.Containers, fs.Units)
+	printStatusCounts(tw, "Unit workload", fs.UnitWorkloadStatus)
+	printStatusCounts(tw, "Unit agent", fs.UnitAgentStatus)
+	printStatusCounts(tw, "Machine agent", fs.MachineAgentStatus)
+	if len(fs.ApplicationVersions) > 0 {
+		w = startSection(tw, false, "App", "Version")
+		for _, name := range naturalsort.Sort(stringKeysFromMap(fs.ApplicationVersions)) {
+			w.Println(name, fs.ApplicationVersions[name])
+		}
+	}
+	endSection(tw)
+	return nil
+}
+
+func printStatusCounts(tw *ansiterm.TabWriter, heading string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	w := startSection(tw, false, heading, "Count")
+	for _, s := range naturalsort.Sort(stringKeysFromMap(counts)) {
+		w.PrintStatus(status.Status(s))
+		w.Println(counts[s])
+	}
+}
+
+func formatPercent(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", *value)
+}
+
 // agentDoing returns what hook or action, if any,
 // the agent is currently executing.
 // The hook name or action is extracted from the agent message.
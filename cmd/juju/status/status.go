@@ -23,6 +23,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/labels"
 	"github.com/juju/juju/juju/osenv"
 )
 
@@ -30,6 +31,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	StatusSummary() (*params.ModelStatusSummary, error)
 	Close() error
 }
 
@@ -50,6 +52,7 @@ type statusCommand struct {
 	modelcmd.ModelCommandBase
 	out        cmd.Output
 	patterns   []string
+	selector   string
 	isoTime    bool
 	statusAPI  statusAPI
 	storageAPI storage.StorageListAPI
@@ -68,6 +71,15 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// health indicates if application health is displayed, and
+	// rollupSubordinates if it includes the subordinate units.
+	health             bool
+	rollupSubordinates bool
+
+	// summary indicates if only the counts of the model's entities
+	// are displayed.
+	summary bool
 }
 
 var usageSummary = `
@@ -87,6 +99,10 @@ Machine numbers may also be used as output filters. This will only display data
 in each section relevant to the specified machines. For example, application
 section will only contain the applications that have units on these machines, etc.
 
+Machines and units may also be filtered by their labels with the --selector
+option, which takes a comma-separated list of requirements of the form
+key=value, key!=value, key or !key. Labels are set with the label command.
+
 The available output formats are:
 
 - tabular (default): Displays status in a tabular format with a separate table
@@ -110,14 +126,32 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
 formats.
 
+The --summary option shows only the number of applications, machines,
+containers and units in the model, and the number of units and machines in
+each state, without the status of each of them. It is much cheaper for the
+controller to report, and so suits being polled by monitoring. It may only be
+used with the tabular, yaml and json formats, and without filters.
+
+The --health option shows the health of each application in all formats: the
+percentage of its units whose workload is active, along with the number of
+units that are active, blocked, in error or in any other state. With
+--rollup-subordinates, each unit of a principal application counts as the least
+healthy of itself and its subordinate units, so that a failing subordinate
+shows up against the application it is deployed with. --rollup-subordinates
+implies --health.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status --selector env=canary
+    juju show-status --health --rollup-subordinates
+    juju show-status --summary
 
 See also:
+    label
     machines
     show-model
     show-status-log
@@ -141,6 +175,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section")
+	f.StringVar(&c.selector, "selector", "", "Show only the machines and units whose labels match the selector")
+	f.BoolVar(&c.health, "health", false, "Show the health of each application")
+	f.BoolVar(&c.rollupSubordinates, "rollup-subordinates", false, "Include subordinate units in the health of their principal applications")
+	f.BoolVar(&c.summary, "summary", false, "Show only the counts of the model's entities and their statuses")
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
@@ -175,6 +213,15 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.selector != "" {
+		if _, err := labels.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+		c.patterns = append(c.patterns, params.LabelSelectorPatternPrefix+c.selector)
+	}
+	if c.summary && len(c.patterns) > 0 {
+		return errors.New("--summary cannot be used with filters")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -252,6 +299,9 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
+	if c.summary {
+		return c.runSummary(ctx)
+	}
 
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
@@ -302,10 +352,12 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 	formatterParams := newStatusFormatterParams{
-		status:         status,
-		controllerName: controllerName,
-		isoTime:        c.isoTime,
-		showRelations:  showRelations,
+		status:             status,
+		controllerName:     controllerName,
+		isoTime:            c.isoTime,
+		showRelations:      showRelations,
+		showHealth:         c.health || c.rollupSubordinates,
+		rollupSubordinates: c.rollupSubordinates,
 	}
 	if showStorage {
 		storageInfo, err := c.getStorageInfo(ctx)
@@ -351,6 +403,31 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// runSummary writes the summary of the model maintained by the
+// controller, which is much cheaper to get than the model's status.
+func (c *statusCommand) runSummary(ctx *cmd.Context) error {
+	switch c.out.Name() {
+	case "tabular", "yaml", "json":
+	default:
+		return errors.Errorf("--summary cannot be used with the %s format", c.out.Name())
+	}
+	apiclient, err := newAPIClientForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	summary, err := apiclient.StatusSummary()
+	if errors.IsNotSupported(err) {
+		return errors.New("this controller does not support status summaries; use --format summary instead")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	formatted := formatStatusSummary(summary)
+	if c.out.Name() == "tabular" {
+		return FormatStatusSummaryTabular(ctx.Stdout, c.color, formatted)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -3131,7 +3132,8 @@ var statusTests = []testCase{
 				},
 				"applications": M{
 					"mysql": mysqlCharm(M{
-						"version": "not as good",
+						"version":        "not as good",
+						"mixed-versions": L{"not as good", "the best!"},
 						"application-status": M{
 							"current": "waiting",
 							"message": "waiting for machine",
@@ -4324,6 +4326,18 @@ func (oup openUnitPort) step(c *gc.C, ctx *context) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type setUnitLabels struct {
+	unitName string
+	labels   map[string]string
+}
+
+func (sul setUnitLabels) step(c *gc.C, ctx *context) {
+	u, err := ctx.st.Unit(sul.unitName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.UpdateLabels(sul.labels, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type ensureDyingUnit struct {
 	unitName string
 }
@@ -4707,6 +4721,10 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) StatusSummary() (*params.ModelStatusSummary, error) {
+	return nil, errors.NotSupportedf("model status summary")
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -5195,6 +5213,47 @@ foo/0  waiting   idle                            80/TCP
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularStatusNotesMixedVersions(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed:       true,
+				Version:       "2.0",
+				MixedVersions: []string{"1.0", "2.0"},
+				Units: map[string]unitStatus{
+					"foo/0": {},
+					"foo/1": {},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Matches, `(?s).*\nfoo +2\.0 .* exposed, mixed versions\n.*`)
+}
+
+func (s *StatusSuite) TestFormatTabularHealth(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Health: &applicationHealth{Score: 50, Active: 2, Blocked: 1, Error: 1},
+			},
+			"bar": {
+				Health: &applicationHealth{Score: 100, Active: 3},
+			},
+			"baz": {},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Matches, `(?s).*\nApp .* OS +Health +Notes\n`+
+		`bar .* 0 +100% +\n`+
+		`baz .* 0 +\n`+
+		`foo .* 0 +50% \(1 error, 1 blocked\) +\n.*`)
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -5345,6 +5404,49 @@ func (s *StatusSuite) FilteringTestSetup(c *gc.C) *context {
 	return ctx
 }
 
+// Scenario: user asks for application health, with and without subordinates
+func (s *StatusSuite) TestStatusHealth(c *gc.C) {
+	ctx := s.FilteringTestSetup(c)
+	defer s.resetContext(c, ctx)
+
+	// Given the subordinate unit of the "mysql" application is blocked
+	setUnitStatus{"logging/1", status.Blocked, "", nil}.step(c, ctx)
+
+	health := func(args ...string) map[string]*applicationHealth {
+		code, stdout, stderr := runStatus(c, append([]string{"--format", "json"}, args...)...)
+		c.Assert(code, gc.Equals, 0)
+		c.Assert(string(stderr), gc.Equals, "")
+		var out struct {
+			Applications map[string]struct {
+				Health *applicationHealth `json:"health"`
+			} `json:"applications"`
+		}
+		err := json.Unmarshal(stdout, &out)
+		c.Assert(err, jc.ErrorIsNil)
+		result := make(map[string]*applicationHealth)
+		for name, app := range out.Applications {
+			result[name] = app.Health
+		}
+		return result
+	}
+
+	c.Assert(health(), jc.DeepEquals, map[string]*applicationHealth{
+		"logging":   nil,
+		"mysql":     nil,
+		"wordpress": nil,
+	})
+	c.Assert(health("--health"), jc.DeepEquals, map[string]*applicationHealth{
+		"logging":   {Score: 50, Active: 1, Blocked: 1},
+		"mysql":     {Score: 100, Active: 1},
+		"wordpress": {Score: 100, Active: 1},
+	})
+	c.Assert(health("--rollup-subordinates"), jc.DeepEquals, map[string]*applicationHealth{
+		"logging":   {Score: 50, Active: 1, Blocked: 1},
+		"mysql":     {Blocked: 1},
+		"wordpress": {Score: 100, Active: 1},
+	})
+}
+
 // Scenario: One unit is in an errored state and user filters to active
 func (s *StatusSuite) TestFilterToActive(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
@@ -5585,6 +5687,31 @@ func (s *StatusSuite) TestFilterOnPorts(c *gc.C) {
 	c.Assert(string(stdout), gc.Equals, expected[1:])
 }
 
+// Scenario: user filters on unit labels
+func (s *StatusSuite) TestFilterOnLabelSelector(c *gc.C) {
+	ctx := s.FilteringTestSetup(c)
+	defer s.resetContext(c, ctx)
+
+	setUnitLabels{"mysql/0", map[string]string{"env": "canary"}}.step(c, ctx)
+	// When I run juju status --format oneline --selector env=canary
+	_, stdout, stderr := runStatus(c, "--format", "oneline", "--selector", "env=canary")
+	c.Assert(string(stderr), gc.Equals, "")
+	// Then I should receive output prefixed with:
+	const expected = `
+
+- mysql/0: 10.0.2.1 (agent:idle, workload:active)
+  - logging/1: 10.0.2.1 (agent:idle, workload:active)
+`
+	c.Assert(string(stdout), gc.Equals, expected[1:])
+}
+
+// Scenario: user gives an invalid label selector
+func (s *StatusSuite) TestFilterOnInvalidLabelSelector(c *gc.C) {
+	code, _, stderr := runStatus(c, "--selector", "env=Canary")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(string(stderr), jc.Contains, `parsing selector "env=Canary": label value "Canary" not valid`)
+}
+
 // Scenario: User filters out a parent, but not its subordinate
 func (s *StatusSuite) TestFilterParentButNotSubordinate(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
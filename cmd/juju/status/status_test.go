@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -130,12 +131,81 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) TestSummary(c *gc.C) {
+	s.statusapi.summary = &params.ModelStatusSummary{
+		ApplicationCount:    2,
+		MachineCount:        3,
+		ContainerCount:      1,
+		UnitCount:           4,
+		UnitWorkloadStatus:  map[string]int{"active": 3, "blocked": 1},
+		UnitAgentStatus:     map[string]int{"idle": 4},
+		MachineAgentStatus:  map[string]int{"started": 3},
+		ApplicationVersions: map[string]string{"mysql": "5.7"},
+	}
+	context, err := s.runStatus(c, "--summary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.statusapi.statusCalled, jc.IsFalse)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Applications  Machines  Containers  Units
+2             3         1           4
+
+Unit workload  Count
+active         3
+blocked        1
+
+Unit agent  Count
+idle        4
+
+Machine agent  Count
+started        3
+
+App    Version
+mysql  5.7
+`[1:])
+
+	context, err = s.runStatus(c, "--summary", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+applications: 2
+machines: 3
+containers: 1
+units: 4
+unit-workload-status:
+  active: 3
+  blocked: 1
+unit-agent-status:
+  idle: 4
+machine-agent-status:
+  started: 3
+application-versions:
+  mysql: "5.7"
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestSummaryNotSupported(c *gc.C) {
+	_, err := s.runStatus(c, "--summary")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support status summaries; use --format summary instead")
+}
+
+func (s *MinimalStatusSuite) TestSummaryWithFilter(c *gc.C) {
+	_, err := s.runStatus(c, "--summary", "mysql")
+	c.Assert(err, gc.ErrorMatches, "--summary cannot be used with filters")
+}
+
+func (s *MinimalStatusSuite) TestSummaryWithOnelineFormat(c *gc.C) {
+	_, err := s.runStatus(c, "--summary", "--format", "oneline")
+	c.Assert(err, gc.ErrorMatches, "--summary cannot be used with the oneline format")
+}
+
 type fakeStatusAPI struct {
-	result *params.FullStatus
-	errors []error
+	result       *params.FullStatus
+	summary      *params.ModelStatusSummary
+	errors       []error
+	statusCalled bool
 }
 
 func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.statusCalled = true
 	if len(f.errors) > 0 {
 		err, rest := f.errors[0], f.errors[1:]
 		f.errors = rest
@@ -144,6 +214,13 @@ func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
 	return f.result, nil
 }
 
+func (f *fakeStatusAPI) StatusSummary() (*params.ModelStatusSummary, error) {
+	if f.summary == nil {
+		return nil, jujuerrors.NotSupportedf("model status summary")
+	}
+	return f.summary, nil
+}
+
 func (*fakeStatusAPI) Close() error {
 	return nil
 }
@@ -37,8 +37,14 @@ const (
 Attach existing storage to a unit. Specify a unit
 and one or more storage IDs to attach to it.
 
+Storage that is attached to another unit, including a unit of a
+different application, is moved: it is first detached from its
+current unit, and then attached to the specified unit. The unit's
+charm must declare storage with the same name and type.
+
 Examples:
     juju attach-storage postgresql/1 pgdata/0
+    juju attach-storage other-app/0 data/2
 `
 
 	attachStorageCommandArgs = `<unit> <storage> [<storage> ...]`
@@ -63,6 +63,14 @@ func NewListCommandForTest(api StorageListAPI, store jujuclient.ClientStore) cmd
 	return modelcmd.Wrap(cmd)
 }
 
+func NewListOrphansCommandForTest(api StorageOrphansAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listCommand{newOrphansAPIFunc: func() (StorageOrphansAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &addCommand{newAPIFunc: func() (StorageAddAPI, error) {
 		return api, nil
@@ -91,3 +99,11 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewResizeStorageCommandForTest(api StorageResizeAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{newAPIFunc: func() (StorageResizeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
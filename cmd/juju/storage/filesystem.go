@@ -54,9 +54,10 @@ type FilesystemAttachments struct {
 }
 
 type FilesystemAttachment struct {
-	MountPoint string `yaml:"mount-point" json:"mount-point"`
-	ReadOnly   bool   `yaml:"read-only" json:"read-only"`
-	Life       string `yaml:"life,omitempty" json:"life,omitempty"`
+	MountPoint   string   `yaml:"mount-point" json:"mount-point"`
+	ReadOnly     bool     `yaml:"read-only" json:"read-only"`
+	MountOptions []string `yaml:"mount-options,omitempty" json:"mount-options,omitempty"`
+	Life         string   `yaml:"life,omitempty" json:"life,omitempty"`
 }
 
 // generateListFilesystemOutput returns a map filesystem IDs to filesystem info
@@ -134,6 +135,7 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 			out[id] = FilesystemAttachment{
 				attachment.MountPoint,
 				attachment.ReadOnly,
+				attachment.MountOptions,
 				string(attachment.Life),
 			}
 		}
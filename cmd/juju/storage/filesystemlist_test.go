@@ -297,7 +297,8 @@ func (s mockListAPI) ListFilesystems(machines []string) ([]params.FilesystemDeta
 			MachineAttachments: map[string]params.FilesystemAttachmentDetails{
 				"machine-1": {
 					FilesystemAttachmentInfo: params.FilesystemAttachmentInfo{
-						MountPoint: "/mnt/zion",
+						MountPoint:   "/mnt/zion",
+						MountOptions: []string{"noatime", "nodev"},
 					},
 				},
 			},
@@ -6,10 +6,12 @@ package storage
 import (
 	"fmt"
 	"io"
+	"regexp"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
@@ -22,11 +24,36 @@ func NewListCommand() cmd.Command {
 	cmd.newAPIFunc = func() (StorageListAPI, error) {
 		return cmd.NewStorageAPI()
 	}
+	cmd.newOrphansAPIFunc = func() (StorageOrphansAPI, error) {
+		return cmd.NewStorageAPI()
+	}
 	return modelcmd.Wrap(cmd)
 }
 
 const listCommandDoc = `
 List information about storage.
+
+With --detect-orphans, the volumes that the cloud reports as belonging
+to the model are compared with the volumes that the model knows about.
+Volumes that exist in the cloud but are unknown to the model, such as
+those left behind after a forced removal, are listed along with model
+volumes that no longer exist in the cloud. So that volumes which are
+still being provisioned are not listed, volumes unknown to the model
+are only listed if they were created over an hour ago, and before any
+of the model's pending volumes were added; clouds which cannot report
+when volumes were created list none. The volumes that are unknown
+to the model may then be imported into the model as filesystem storage
+with --import-as, or destroyed with --destroy.
+
+Examples:
+
+    juju storage --detect-orphans
+    juju storage --detect-orphans --import-as pgdata
+    juju storage --detect-orphans --destroy
+
+See also:
+    import-filesystem
+    remove-storage
 `
 
 // listCommand returns storage instances.
@@ -37,6 +64,12 @@ type listCommand struct {
 	filesystem bool
 	volume     bool
 	newAPIFunc func() (StorageListAPI, error)
+
+	detectOrphans     bool
+	destroyOrphans    bool
+	importOrphansAs   string
+	assumeYes         bool
+	newOrphansAPIFunc func() (StorageOrphansAPI, error)
 }
 
 // Info implements Command.Info.
@@ -62,6 +95,11 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	// for listing just filesystems or volumes.
 	f.BoolVar(&c.filesystem, "filesystem", false, "List filesystem storage")
 	f.BoolVar(&c.volume, "volume", false, "List volume storage")
+	f.BoolVar(&c.detectOrphans, "detect-orphans", false, "List volumes that are unknown to the model or missing from the cloud")
+	f.BoolVar(&c.destroyOrphans, "destroy", false, "Destroy the volumes that are unknown to the model (requires --detect-orphans)")
+	f.StringVar(&c.importOrphansAs, "import-as", "", "Import the volumes that are unknown to the model with the given storage name (requires --detect-orphans)")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
 }
 
 // Init implements Command.Init.
@@ -72,12 +110,34 @@ func (c *listCommand) Init(args []string) (err error) {
 	if len(args) > 0 && !c.filesystem && !c.volume {
 		return errors.New("specifying IDs only supported with --filesystem and --volume options")
 	}
+	if c.detectOrphans {
+		if c.filesystem || c.volume {
+			return errors.New("--detect-orphans can not be used with --filesystem or --volume")
+		}
+		if c.destroyOrphans && c.importOrphansAs != "" {
+			return errors.New("--destroy and --import-as can not be used together")
+		}
+		if c.importOrphansAs != "" {
+			validStorageName, err := regexp.MatchString("^"+names.StorageNameSnippet+"$", c.importOrphansAs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !validStorageName {
+				return errors.Errorf("%q is not a valid storage name", c.importOrphansAs)
+			}
+		}
+	} else if c.destroyOrphans || c.importOrphansAs != "" {
+		return errors.New("--destroy and --import-as require --detect-orphans")
+	}
 	c.ids = args
 	return nil
 }
 
 // Run implements Command.Run.
 func (c *listCommand) Run(ctx *cmd.Context) (err error) {
+	if c.detectOrphans {
+		return c.runOrphans(ctx)
+	}
 	api, err := c.newAPIFunc()
 	if err != nil {
 		return err
@@ -184,6 +244,9 @@ func formatListTabularOne(writer io.Writer, value interface{}) error {
 }
 
 func formatListTabular(writer io.Writer, value interface{}, all bool) error {
+	if orphans, ok := value.(OrphanedStorage); ok {
+		return formatOrphanedStorageTabular(writer, orphans)
+	}
 	combined := value.(CombinedStorage)
 	var newline bool
 	if len(combined.StorageInstances) > 0 {
@@ -160,6 +160,9 @@ filesystems:
         "1":
           mount-point: /mnt/zion
           read-only: false
+          mount-options:
+          - noatime
+          - nodev
     size: 3
     status:
       current: attached
@@ -0,0 +1,183 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/storage"
+)
+
+// StorageOrphansAPI defines the API methods that the storage command
+// uses to detect, import and destroy orphaned volumes.
+type StorageOrphansAPI interface {
+	Close() error
+	DetectOrphanedStorage() (params.OrphanedStorageResult, error)
+	DestroyOrphanedVolumes([]params.OrphanedVolume) ([]params.ErrorResult, error)
+	Import(kind storage.StorageKind, storagePool, storageProviderId, storageName string) (names.StorageTag, error)
+}
+
+// OrphanedStorage holds the volumes that exist in the cloud but are
+// unknown to the model, and the model's volumes that no longer exist
+// in the cloud.
+type OrphanedStorage struct {
+	Unknown []OrphanedVolume `yaml:"unknown,omitempty" json:"unknown,omitempty"`
+	Missing []OrphanedVolume `yaml:"missing,omitempty" json:"missing,omitempty"`
+}
+
+// OrphanedVolume holds the details of an orphaned volume.
+type OrphanedVolume struct {
+	Provider   string `yaml:"provider" json:"provider"`
+	ProviderId string `yaml:"provider-id" json:"provider-id"`
+	Volume     string `yaml:"volume,omitempty" json:"volume,omitempty"`
+}
+
+// Empty checks if OrphanedStorage is empty.
+func (o *OrphanedStorage) Empty() bool {
+	return len(o.Unknown) == 0 && len(o.Missing) == 0
+}
+
+func convertToOrphanedStorage(result params.OrphanedStorageResult) (OrphanedStorage, error) {
+	var orphans OrphanedStorage
+	for _, v := range result.Unknown {
+		orphans.Unknown = append(orphans.Unknown, OrphanedVolume{
+			Provider:   v.Provider,
+			ProviderId: v.ProviderId,
+		})
+	}
+	for _, v := range result.Missing {
+		volume := OrphanedVolume{
+			Provider:   v.Provider,
+			ProviderId: v.ProviderId,
+		}
+		if v.VolumeTag != "" {
+			tag, err := names.ParseVolumeTag(v.VolumeTag)
+			if err != nil {
+				return OrphanedStorage{}, errors.Trace(err)
+			}
+			volume.Volume = tag.Id()
+		}
+		orphans.Missing = append(orphans.Missing, volume)
+	}
+	return orphans, nil
+}
+
+var destroyOrphansMsg = `
+WARNING! This command will destroy %d volume(s) in the cloud:
+`[1:]
+
+// runOrphans detects orphaned volumes and, if requested, imports
+// or destroys the volumes that are unknown to the model.
+func (c *listCommand) runOrphans(ctx *cmd.Context) error {
+	api, err := c.newOrphansAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	result, err := api.DetectOrphanedStorage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	orphans, err := convertToOrphanedStorage(result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	switch {
+	case c.destroyOrphans:
+		return c.destroyOrphanedVolumes(ctx, api, result.Unknown)
+	case c.importOrphansAs != "":
+		return c.importOrphanedVolumes(ctx, api, result.Unknown)
+	}
+	if orphans.Empty() {
+		if c.out.Name() == "tabular" {
+			ctx.Infof("No orphaned storage to display.")
+		}
+		return nil
+	}
+	return c.out.Write(ctx, orphans)
+}
+
+func (c *listCommand) destroyOrphanedVolumes(ctx *cmd.Context, api StorageOrphansAPI, volumes []params.OrphanedVolume) error {
+	if len(volumes) == 0 {
+		ctx.Infof("No orphaned volumes to destroy.")
+		return nil
+	}
+	if !c.assumeYes {
+		fmt.Fprintf(ctx.Stdout, destroyOrphansMsg, len(volumes))
+		for _, v := range volumes {
+			fmt.Fprintf(ctx.Stdout, " - %s (%s)\n", v.ProviderId, v.Provider)
+		}
+		fmt.Fprint(ctx.Stdout, "\nContinue [y/N]? ")
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "destroying orphaned volumes")
+		}
+	}
+	results, err := api.DestroyOrphanedVolumes(volumes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to destroy volume %q: %s", volumes[i].ProviderId, result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("destroyed volume %q", volumes[i].ProviderId)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *listCommand) importOrphanedVolumes(ctx *cmd.Context, api StorageOrphansAPI, volumes []params.OrphanedVolume) error {
+	if len(volumes) == 0 {
+		ctx.Infof("No orphaned volumes to import.")
+		return nil
+	}
+	var failed bool
+	for _, v := range volumes {
+		storageTag, err := api.Import(storage.StorageKindFilesystem, v.Provider, v.ProviderId, c.importOrphansAs)
+		if err != nil {
+			ctx.Infof("failed to import volume %q: %s", v.ProviderId, err)
+			failed = true
+			continue
+		}
+		ctx.Infof("imported volume %q as storage %s", v.ProviderId, storageTag.Id())
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// formatOrphanedStorageTabular writes a tabular summary of orphaned volumes.
+func formatOrphanedStorageTabular(writer io.Writer, orphans OrphanedStorage) error {
+	tw := output.TabWriter(writer)
+
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Provider", "Provider id", "Volume", "Status")
+	for _, v := range orphans.Unknown {
+		print(v.Provider, v.ProviderId, v.Volume, "unknown to model")
+	}
+	for _, v := range orphans.Missing {
+		print(v.Provider, v.ProviderId, v.Volume, "missing from cloud")
+	}
+	return tw.Flush()
+}
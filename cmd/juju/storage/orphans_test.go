@@ -0,0 +1,201 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	jujustorage "github.com/juju/juju/storage"
+)
+
+type OrphansSuite struct {
+	SubStorageSuite
+	api *mockOrphansAPI
+}
+
+var _ = gc.Suite(&OrphansSuite{})
+
+func (s *OrphansSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockOrphansAPI{
+		result: params.OrphanedStorageResult{
+			Unknown: []params.OrphanedVolume{
+				{Provider: "ebs", ProviderId: "vol-1"},
+				{Provider: "ebs", ProviderId: "vol-2"},
+			},
+			Missing: []params.OrphanedVolume{
+				{Provider: "ebs", ProviderId: "vol-3", VolumeTag: "volume-0"},
+			},
+		},
+	}
+}
+
+func (s *OrphansSuite) run(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	command := storage.NewListOrphansCommandForTest(s.api, s.store)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return nil, err
+	}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	return ctx, command.Run(ctx)
+}
+
+func (s *OrphansSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--destroy"},
+		err:  "--destroy and --import-as require --detect-orphans",
+	}, {
+		args: []string{"--import-as", "pgdata"},
+		err:  "--destroy and --import-as require --detect-orphans",
+	}, {
+		args: []string{"--detect-orphans", "--volume"},
+		err:  "--detect-orphans can not be used with --filesystem or --volume",
+	}, {
+		args: []string{"--detect-orphans", "--destroy", "--import-as", "pgdata"},
+		err:  "--destroy and --import-as can not be used together",
+	}, {
+		args: []string{"--detect-orphans", "--import-as", "#bad"},
+		err:  `"#bad" is not a valid storage name`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, "", test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *OrphansSuite) TestDetectOrphans(c *gc.C) {
+	ctx, err := s.run(c, "", "--detect-orphans")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Provider  Provider id  Volume  Status
+ebs       vol-1                unknown to model
+ebs       vol-2                unknown to model
+ebs       vol-3        0       missing from cloud
+`[1:])
+	s.api.CheckCallNames(c, "DetectOrphanedStorage", "Close")
+}
+
+func (s *OrphansSuite) TestDetectOrphansYAML(c *gc.C) {
+	ctx, err := s.run(c, "", "--detect-orphans", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+unknown:
+- provider: ebs
+  provider-id: vol-1
+- provider: ebs
+  provider-id: vol-2
+missing:
+- provider: ebs
+  provider-id: vol-3
+  volume: "0"
+`[1:])
+}
+
+func (s *OrphansSuite) TestDetectOrphansNone(c *gc.C) {
+	s.api.result = params.OrphanedStorageResult{}
+	ctx, err := s.run(c, "", "--detect-orphans")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No orphaned storage to display.\n")
+}
+
+func (s *OrphansSuite) TestDestroyOrphans(c *gc.C) {
+	ctx, err := s.run(c, "y\n", "--detect-orphans", "--destroy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+WARNING! This command will destroy 2 volume(s) in the cloud:
+ - vol-1 (ebs)
+ - vol-2 (ebs)
+
+Continue [y/N]? `[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+destroyed volume "vol-1"
+destroyed volume "vol-2"
+`[1:])
+	s.api.CheckCall(c, 1, "DestroyOrphanedVolumes", s.api.result.Unknown)
+}
+
+func (s *OrphansSuite) TestDestroyOrphansAborted(c *gc.C) {
+	_, err := s.run(c, "n\n", "--detect-orphans", "--destroy")
+	c.Assert(err, gc.ErrorMatches, "destroying orphaned volumes: aborted")
+	s.api.CheckCallNames(c, "DetectOrphanedStorage", "Close")
+}
+
+func (s *OrphansSuite) TestDestroyOrphansFails(c *gc.C) {
+	s.api.destroyResults = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "in use"}},
+	}
+	ctx, err := s.run(c, "", "--detect-orphans", "--destroy", "-y")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+destroyed volume "vol-1"
+failed to destroy volume "vol-2": in use
+`[1:])
+}
+
+func (s *OrphansSuite) TestImportOrphans(c *gc.C) {
+	s.api.SetErrors(nil, nil, errors.New("boom"))
+	ctx, err := s.run(c, "", "--detect-orphans", "--import-as", "pgdata")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+imported volume "vol-1" as storage pgdata/0
+failed to import volume "vol-2": boom
+`[1:])
+	s.api.CheckCalls(c, []jutesting.StubCall{
+		{"DetectOrphanedStorage", nil},
+		{"Import", []interface{}{jujustorage.StorageKindFilesystem, "ebs", "vol-1", "pgdata"}},
+		{"Import", []interface{}{jujustorage.StorageKindFilesystem, "ebs", "vol-2", "pgdata"}},
+		{"Close", nil},
+	})
+}
+
+type mockOrphansAPI struct {
+	jutesting.Stub
+	result         params.OrphanedStorageResult
+	destroyResults []params.ErrorResult
+}
+
+func (m *mockOrphansAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockOrphansAPI) DetectOrphanedStorage() (params.OrphanedStorageResult, error) {
+	m.MethodCall(m, "DetectOrphanedStorage")
+	return m.result, m.NextErr()
+}
+
+func (m *mockOrphansAPI) DestroyOrphanedVolumes(volumes []params.OrphanedVolume) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "DestroyOrphanedVolumes", volumes)
+	if m.destroyResults != nil {
+		return m.destroyResults, m.NextErr()
+	}
+	return make([]params.ErrorResult, len(volumes)), m.NextErr()
+}
+
+func (m *mockOrphansAPI) Import(
+	kind jujustorage.StorageKind, storagePool, storageProviderId, storageName string,
+) (names.StorageTag, error) {
+	m.MethodCall(m, "Import", kind, storagePool, storageProviderId, storageName)
+	if err := m.NextErr(); err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.NewStorageTag(storageName + "/0"), nil
+}
@@ -45,10 +45,16 @@ configuration as space-separated pairs, e.g. tags, size, path, etc.
 For Kubernetes models, the provider type defaults to "kubernetes"
 unless otherwise specified.
 
+A pool can be made the model's default for block or filesystem storage by
+setting the "storage-default-block-source" or "storage-default-filesystem-source"
+model config. It is then used when storage is deployed or added with a size
+or count but without a pool.
+
 Examples:
 
     juju create-storage-pool ebsrotary ebs volume-type=standard
     juju create-storage-pool gcepd storage-provisioner=kubernetes.io/gce-pd parameters.type=pd-standard
+    juju model-config storage-default-block-source=ebsrotary
 
 See also:
     remove-storage-pool
@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// StorageResizeAPI defines the API methods that the resize-storage
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	Resize([]params.ResizeStorageInstance) ([]params.ErrorResult, error)
+}
+
+const resizeStorageCommandDoc = `
+Grows the volume backing a storage instance to the specified size, while
+the storage remains attached and in use. The storage's volume reports the
+"resizing" status until the storage provider has completed the resize.
+
+Only volumes of storage providers that support online resizing, such as
+Google persistent disks, can be resized; storage in Kubernetes models can
+not be resized. Filesystems on resized volumes are grown once the machine
+sees the larger block device. Storage can not be shrunk.
+
+The size is specified as a number with an optional multiplier suffix
+(M, G, T, P, E, Z, Y); the default multiplier is M.
+
+Examples:
+    juju resize-storage data/3 --size 100G
+
+See also:
+    storage
+    show-storage
+`
+
+// NewResizeStorageCommand returns a command used to resize storage.
+func NewResizeStorageCommand() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// resizeStorageCommand resizes a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageResizeAPI, error)
+	storageId  string
+	size       string
+	sizeMiB    uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("resize-storage requires a storage ID")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	if c.size == "" {
+		return errors.New("--size must be specified")
+	}
+	size, err := utils.ParseSize(c.size)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse --size")
+	}
+	if size == 0 {
+		return errors.New("--size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.sizeMiB = size
+	return cmd.CheckEmpty(args[1:])
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *resizeStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.size, "size", "", "The new size of the storage")
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows storage while it remains in use.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    "<storage> --size <size>",
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.Resize([]params.ResizeStorageInstance{{
+		Tag:  names.NewStorageTag(c.storageId).String(),
+		Size: c.sizeMiB,
+	}})
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return errors.Trace(err)
+	}
+	if err := results[0].Error; err != nil {
+		return errors.Annotatef(err, "resizing %s", c.storageId)
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.sizeMiB)
+	return nil
+}
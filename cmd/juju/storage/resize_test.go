@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type ResizeSuite struct {
+	SubStorageSuite
+	api *mockResizeAPI
+}
+
+var _ = gc.Suite(&ResizeSuite{})
+
+func (s *ResizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockResizeAPI{}
+}
+
+func (s *ResizeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewResizeStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *ResizeSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "resize-storage requires a storage ID",
+	}, {
+		args: []string{"data", "--size", "10G"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"data/3"},
+		err:  "--size must be specified",
+	}, {
+		args: []string{"data/3", "--size", "ten"},
+		err:  `cannot parse --size: expected a non-negative number, got "ten"`,
+	}, {
+		args: []string{"data/3", "--size", "0"},
+		err:  "--size must be greater than zero",
+	}, {
+		args: []string{"data/3", "data/4", "--size", "10G"},
+		err:  `unrecognized args: \["data/4"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ResizeSuite) TestResize(c *gc.C) {
+	ctx, err := s.run(c, "data/3", "--size", "100G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing data/3 to 102400MiB\n")
+	s.api.CheckCalls(c, []jutesting.StubCall{
+		{"Resize", []interface{}{[]params.ResizeStorageInstance{
+			{Tag: "storage-data-3", Size: 102400},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResizeSuite) TestResizeResultError(c *gc.C) {
+	s.api.result = &params.Error{Message: "not supported", Code: params.CodeNotSupported}
+	_, err := s.run(c, "data/3", "--size", "100G")
+	c.Assert(err, gc.ErrorMatches, "resizing data/3: not supported")
+}
+
+func (s *ResizeSuite) TestResizeError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "data/3", "--size", "100G")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockResizeAPI struct {
+	jutesting.Stub
+	result *params.Error
+}
+
+func (m *mockResizeAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockResizeAPI) Resize(storage []params.ResizeStorageInstance) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "Resize", storage)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return []params.ErrorResult{{Error: m.result}}, nil
+}
@@ -5,9 +5,13 @@ package user
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -15,6 +19,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/permission"
 )
 
 var usageSummary = `
@@ -30,9 +35,19 @@ complete the registration process.
 Some machine providers will require the user to be in possession of certain
 credentials in order to create a model.
 
+Access to models may be granted to the user as it is added, with the --grant
+option. The value is a comma-separated list of <model name>=<access level>
+pairs, where the access level is one of read, write or admin. Either the user
+is added with all of the grants, or it is not added at all.
+
+The --expires option causes the user to be disabled automatically once the
+given period has elapsed. The period is given in days, e.g. 90d, or as a
+duration such as 36h.
+
 Examples:
     juju add-user bob
     juju add-user --controller mycontroller bob
+    juju add-user --grant default=read,staging=write --expires 90d bob
 
 See also:
     register
@@ -46,7 +61,11 @@ See also:
 
 // AddUserAPI defines the usermanager API methods that the add command uses.
 type AddUserAPI interface {
-	AddUser(username, displayName, password string) (names.UserTag, []byte, error)
+	AddUserWithGrants(
+		username, displayName, password string,
+		grants map[string]permission.Access,
+		expires time.Time,
+	) (names.UserTag, []byte, error)
 	Close() error
 }
 
@@ -60,6 +79,23 @@ type addCommand struct {
 	api         AddUserAPI
 	User        string
 	DisplayName string
+
+	// Grants holds the model access to grant
+	// the user, in the order supplied.
+	Grants []modelGrant
+
+	// ExpiresAfter, if not zero, is the period after
+	// which the user will be disabled automatically.
+	ExpiresAfter time.Duration
+
+	grantsValue  string
+	expiresValue string
+}
+
+// modelGrant holds access to a named model.
+type modelGrant struct {
+	ModelName string
+	Access    permission.Access
 }
 
 // Info implements Command.Info.
@@ -72,12 +108,27 @@ func (c *addCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.grantsValue, "grant", "", "Grant access to models, as a comma-separated list of <model>=<access>")
+	f.StringVar(&c.expiresValue, "expires", "", "Disable the user after this period, e.g. 90d")
+}
+
 // Init implements Command.Init.
 func (c *addCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no username supplied")
 	}
 
+	var err error
+	if c.Grants, err = parseModelGrants(c.grantsValue); err != nil {
+		return errors.Trace(err)
+	}
+	if c.ExpiresAfter, err = parseExpiry(c.expiresValue); err != nil {
+		return errors.Trace(err)
+	}
+
 	c.User, args = args[0], args[1:]
 	if len(args) > 0 {
 		c.DisplayName, args = args[0], args[1:]
@@ -97,10 +148,30 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		defer api.Close()
 	}
 
+	var grants map[string]permission.Access
+	if len(c.Grants) > 0 {
+		modelNames := make([]string, len(c.Grants))
+		for i, grant := range c.Grants {
+			modelNames[i] = grant.ModelName
+		}
+		modelUUIDs, err := c.ModelUUIDs(modelNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		grants = make(map[string]permission.Access)
+		for i, modelUUID := range modelUUIDs {
+			grants[modelUUID] = c.Grants[i].Access
+		}
+	}
+	var expires time.Time
+	if c.ExpiresAfter > 0 {
+		expires = time.Now().Add(c.ExpiresAfter).UTC()
+	}
+
 	// Add a user without a password. This will generate a temporary
 	// secret key, which we'll print out for the user to supply to
 	// "juju register".
-	_, secretKey, err := api.AddUser(c.User, c.DisplayName, "")
+	_, secretKey, err := api.AddUserWithGrants(c.User, c.DisplayName, "", grants, expires)
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a user")
@@ -125,9 +196,70 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "    juju register %s\n",
 		base64RegistrationData,
 	)
-	fmt.Fprintf(ctx.Stdout, `
+	if len(c.Grants) == 0 {
+		fmt.Fprintf(ctx.Stdout, `
 %q has not been granted access to any models. You can use "juju grant" to grant access.
 `, displayName)
+	} else {
+		fmt.Fprintln(ctx.Stdout)
+		for _, grant := range c.Grants {
+			fmt.Fprintf(ctx.Stdout, "%q has been granted %s access to model %q.\n", displayName, grant.Access, grant.ModelName)
+		}
+	}
+	if !expires.IsZero() {
+		fmt.Fprintf(ctx.Stdout, "%q will be disabled after %s.\n", displayName, expires.Format(time.RFC3339))
+	}
 
 	return nil
 }
+
+// parseModelGrants parses a comma-separated list of <model>=<access>
+// pairs, as supplied to the --grant option.
+func parseModelGrants(value string) ([]modelGrant, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var grants []modelGrant
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid model grant %q, expected <model>=<access>", item)
+		}
+		modelName, access := parts[0], permission.Access(parts[1])
+		if err := permission.ValidateModelAccess(access); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if seen[modelName] {
+			return nil, errors.Errorf("model %q granted more than once", modelName)
+		}
+		seen[modelName] = true
+		grants = append(grants, modelGrant{ModelName: modelName, Access: access})
+	}
+	return grants, nil
+}
+
+// parseExpiry parses the period supplied to the --expires option. As well
+// as the usual duration units, a whole number of days may be given, e.g. 90d.
+func parseExpiry(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	var period time.Duration
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, errors.NotValidf("expiry period %q", value)
+		}
+		period = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		if period, err = time.ParseDuration(value); err != nil {
+			return 0, errors.NotValidf("expiry period %q", value)
+		}
+	}
+	if period <= 0 {
+		return 0, errors.NotValidf("non-positive expiry period %q", value)
+	}
+	return period, nil
+}
@@ -5,6 +5,7 @@ package user_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
 )
 
@@ -125,6 +127,69 @@ func (s *UserAddCommandSuite) TestUserRegistrationString(c *gc.C) {
 	}
 }
 
+func (s *UserAddCommandSuite) TestInitGrantsAndExpiry(c *gc.C) {
+	for i, test := range []struct {
+		args         []string
+		grants       []user.ModelGrant
+		expiresAfter time.Duration
+		errorString  string
+	}{{
+		args: []string{"--grant", "model=read,other=admin", "foobar"},
+		grants: []user.ModelGrant{
+			{ModelName: "model", Access: permission.ReadAccess},
+			{ModelName: "other", Access: permission.AdminAccess},
+		},
+	}, {
+		args:         []string{"--expires", "90d", "foobar"},
+		expiresAfter: 90 * 24 * time.Hour,
+	}, {
+		args:         []string{"--expires", "36h", "foobar"},
+		expiresAfter: 36 * time.Hour,
+	}, {
+		args:        []string{"--grant", "model", "foobar"},
+		errorString: `invalid model grant "model", expected <model>=<access>`,
+	}, {
+		args:        []string{"--grant", "model=superuser", "foobar"},
+		errorString: `"superuser" model access not valid`,
+	}, {
+		args:        []string{"--grant", "model=read,model=write", "foobar"},
+		errorString: `model "model" granted more than once`,
+	}, {
+		args:        []string{"--expires", "soon", "foobar"},
+		errorString: `expiry period "soon" not valid`,
+	}, {
+		args:        []string{"--expires", "0d", "foobar"},
+		errorString: `non-positive expiry period "0d" not valid`,
+	}} {
+		c.Logf("test %d (%q)", i, test.args)
+		wrappedCommand, command := user.NewAddCommandForTest(s.mockAPI, s.store, &mockModelAPI{})
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.Grants, jc.DeepEquals, test.grants)
+			c.Check(command.ExpiresAfter, gc.Equals, test.expiresAfter)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *UserAddCommandSuite) TestAddUserWithGrantsAndExpiry(c *gc.C) {
+	before := time.Now()
+	context, err := s.run(c, "--grant", "model=write", "--expires", "1d", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.username, gc.Equals, "foobar")
+	c.Assert(s.mockAPI.grants, jc.DeepEquals, map[string]permission.Access{
+		"modeluuid": permission.WriteAccess,
+	})
+	c.Assert(s.mockAPI.expires.Before(before.Add(24*time.Hour)), jc.IsFalse)
+	c.Assert(s.mockAPI.expires.After(time.Now().Add(24*time.Hour)), jc.IsFalse)
+
+	lines := strings.Split(cmdtesting.Stdout(context), "\n")
+	c.Assert(lines[4], gc.Equals, `"foobar" has been granted write access to model "model".`)
+	c.Assert(lines[5], gc.Matches, `"foobar" will be disabled after .*\.`)
+}
+
 type mockModelAPI struct{}
 
 func (m *mockModelAPI) ListModels(user string) ([]base.UserModel, error) {
@@ -167,9 +232,17 @@ type mockAddUserAPI struct {
 	username    string
 	displayname string
 	password    string
+	grants      map[string]permission.Access
+	expires     time.Time
 }
 
-func (m *mockAddUserAPI) AddUser(username, displayname, password string) (names.UserTag, []byte, error) {
+func (m *mockAddUserAPI) AddUserWithGrants(
+	username, displayname, password string,
+	grants map[string]permission.Access,
+	expires time.Time,
+) (names.UserTag, []byte, error) {
+	m.grants = grants
+	m.expires = expires
 	if m.blocked {
 		return names.UserTag{}, nil, common.OperationBlockedError("the operation has been blocked")
 	}
@@ -26,6 +26,8 @@ type AddCommand struct {
 	*addCommand
 }
 
+type ModelGrant = modelGrant
+
 type RemoveCommand struct {
 	*removeCommand
 }
@@ -4,9 +4,6 @@
 package user
 
 import (
-	"encoding/asn1"
-	"encoding/base64"
-
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cmd/modelcmd"
@@ -20,8 +17,7 @@ func generateUserControllerAccessToken(command modelcmd.ControllerCommandBase, u
 	}
 
 	// Generate the base64-encoded string for the user to pass to
-	// "juju register". We marshal the information using ASN.1
-	// to keep the size down, since we need to encode binary data.
+	// "juju register".
 	controllerDetails, err := command.ClientStore().ControllerByName(controllerName)
 	if err != nil {
 		return "", errors.Trace(err)
@@ -32,21 +28,6 @@ func generateUserControllerAccessToken(command modelcmd.ControllerCommandBase, u
 		SecretKey:      secretKey,
 		ControllerName: controllerName,
 	}
-	registrationData, err := asn1.Marshal(registrationInfo)
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Use URLEncoding so we don't get + or / in the string,
-	// and pad with zero bytes so we don't get =; this all
-	// makes it easier to copy & paste in a terminal.
-	//
-	// The embedded ASN.1 data is length-encoded, so the
-	// padding will not complicate decoding.
-	remainder := len(registrationData) % 3
-	if remainder != 0 {
-		var pad [3]byte
-		registrationData = append(registrationData, pad[:3-remainder]...)
-	}
-	return base64.URLEncoding.EncodeToString(registrationData), nil
+	token, err := registrationInfo.Encode()
+	return token, errors.Trace(err)
 }
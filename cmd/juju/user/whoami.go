@@ -36,7 +36,7 @@ See also:
 // NewWhoAmICommand returns a command to print login details.
 func NewWhoAmICommand() cmd.Command {
 	cmd := &whoAmICommand{
-		store: jujuclient.NewFileClientStore(),
+		store: jujuclient.NewClientStore(),
 	}
 	return modelcmd.WrapBase(cmd)
 }
@@ -53,6 +53,7 @@ import (
 var (
 	initiateMongoServer  = peergrouper.InitiateMongoServer
 	agentInitializeState = agentbootstrap.InitializeState
+	agentPreflightCheck  = agentbootstrap.PreflightCheck
 	sshGenerateKey       = ssh.GenerateKey
 	minSocketTimeout     = 1 * time.Minute
 )
@@ -288,6 +289,15 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 
 	agentConfig = c.CurrentConfig()
 
+	// Check the machine can run a controller before anything is
+	// started on it. Under CAAS, mongo runs in its own container,
+	// whose resources are set by the pod spec.
+	if !isCAAS {
+		if err := agentPreflightCheck(agentConfig); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Create system-identity file
 	if err := agent.WriteSystemIdentityFile(agentConfig); err != nil {
 		return errors.Trace(err)
@@ -120,6 +120,7 @@ func (s *BootstrapSuite) SetUpTest(c *gc.C) {
 	s.mongoOplogSize = "1234"
 	s.fakeEnsureMongo = agenttest.InstallFakeEnsureMongo(s)
 	s.PatchValue(&initiateMongoServer, s.fakeEnsureMongo.InitiateMongo)
+	s.PatchValue(&agentPreflightCheck, func(agent.Config) error { return nil })
 	s.makeTestModel(c)
 
 	// Create fake tools.tar.gz and downloaded-tools.txt.
@@ -603,6 +604,17 @@ func (s *BootstrapSuite) TestInitializeStateArgs(c *gc.C) {
 	c.Assert(called, gc.Equals, 1)
 }
 
+func (s *BootstrapSuite) TestPreflightCheckFailsBeforeMongo(c *gc.C) {
+	s.PatchValue(&agentPreflightCheck, func(agent.Config) error {
+		return errors.New("bootstrap machine is not suitable for a controller")
+	})
+	_, cmd, err := s.initBootstrapCommand(c, nil, s.bootstrapParamsFile)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(nil)
+	c.Assert(err, gc.ErrorMatches, "bootstrap machine is not suitable for a controller")
+	c.Assert(s.fakeEnsureMongo.EnsureCount, gc.Equals, 0)
+}
+
 func (s *BootstrapSuite) TestInitializeStateMinSocketTimeout(c *gc.C) {
 	var called int
 	initializeState := func(_ names.UserTag, _ agent.ConfigSetter, _ agentbootstrap.InitializeStateParams, dialOpts mongo.DialOpts, _ state.NewPolicyFunc) (_ *state.Controller, _ *state.Machine, resultErr error) {
@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -46,6 +47,10 @@ func (ctx *fakeContext) RecallUnit(unitName string) error {
 	return nil
 }
 
+func (ctx *fakeContext) SetUnitLimits(string, constraints.Value) error {
+	return nil
+}
+
 func (ctx *fakeContext) DeployedUnits() ([]string, error) {
 	ctx.mu.Lock()
 	defer ctx.mu.Unlock()
@@ -32,6 +32,7 @@ var (
 		"valid-credential-flag",
 	}
 	requireValidCredentialModelWorkers = []string{
+		"action-notifier",        // tertiary dependency: will be inactive because migration workers will be inactive
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
@@ -52,8 +53,10 @@ var (
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
 		"undertaker",
 		"unit-assigner", // tertiary dependency: will be inactive because migration workers will be inactive
+		"winrm-health",  // tertiary dependency: will be inactive because migration workers will be inactive
 	}
 	aliveModelWorkers = []string{
+		"action-notifier",
 		"action-pruner",
 		"application-scaler",
 		"charm-revision-updater",
@@ -73,6 +76,7 @@ var (
 		"status-history-pruner",
 		"storage-provisioner",
 		"unit-assigner",
+		"winrm-health",
 	}
 	migratingModelWorkers = []string{
 		"environ-tracker",
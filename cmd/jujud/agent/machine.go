@@ -68,6 +68,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
+	statewatcher "github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
 	jworker "github.com/juju/juju/worker"
@@ -306,6 +307,7 @@ func NewMachineAgent(
 		prometheusRegistry:          prometheusRegistry,
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
+		watcherLapseCollector:       statewatcher.NewLapseCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
 		isCaasMachineAgent:          isCaasMachineAgent,
 	}
@@ -334,6 +336,9 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	if err := a.prometheusRegistry.Register(a.watcherLapseCollector); err != nil {
+		return errors.Annotate(err, "registering state watcher lapse collector")
+	}
 	return nil
 }
 
@@ -366,6 +371,7 @@ type MachineAgent struct {
 	prometheusRegistry         *prometheus.Registry
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
+	watcherLapseCollector      *statewatcher.LapseCollector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
 	// Only API servers have hubs. This is temporary until the apiserver and
@@ -969,6 +975,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.StatePool, er
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		a.watcherLapseCollector.WatchLapsed,
 	)
 	if err != nil {
 		return nil, err
@@ -1075,6 +1082,7 @@ func openStatePool(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	watcherLapseObserver func(modelUUID string),
 ) (_ *state.StatePool, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1093,6 +1101,7 @@ func openStatePool(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		WatcherLapseObserver:   watcherLapseObserver,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"net/http"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -109,6 +110,10 @@ const (
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
 
+	// modelCacheSnapshotInterval is the interval between
+	// snapshots of the model cache.
+	modelCacheSnapshotInterval = 5 * time.Minute
+
 	// leaseRequestTopic is the pubsub topic that lease FSM updates
 	// will be published on.
 	leaseRequestTopic = "lease.request"
@@ -398,6 +403,9 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			StateName:            stateName,
 			Logger:               loggo.GetLogger("juju.worker.modelcache"),
 			PrometheusRegisterer: config.PrometheusRegisterer,
+			SnapshotPath:         filepath.Join(agentConfig.DataDir(), "model-cache.snapshot"),
+			SnapshotInterval:     modelCacheSnapshotInterval,
+			Clock:                config.Clock,
 			NewWorker:            modelcache.NewWorker,
 		}),
 
@@ -771,6 +779,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		raftClustererName: ifRaftLeader(raftclusterer.Manifold(raftclusterer.ManifoldConfig{
 			RaftName:       raftName,
 			CentralHubName: centralHubName,
+			ClockName:      clockName,
 			NewWorker:      raftclusterer.NewWorker,
 		})),
 
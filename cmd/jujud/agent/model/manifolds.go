@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/actionnotifier"
 	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
@@ -60,6 +61,7 @@ import (
 	"github.com/juju/juju/worker/storageprovisioner"
 	"github.com/juju/juju/worker/undertaker"
 	"github.com/juju/juju/worker/unitassigner"
+	"github.com/juju/juju/worker/winrmhealth"
 )
 
 // ManifoldsConfig holds the dependencies and configuration options for a
@@ -265,6 +267,15 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		actionNotifierName: ifNotMigrating(actionnotifier.Manifold(actionnotifier.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			PostTimeout:   30 * time.Second,
+			RetryDelay:    actionnotifier.DefaultRetryDelay,
+			MaxAttempts:   actionnotifier.DefaultMaxAttempts,
+			NewFacade:     actionnotifier.NewFacade,
+			NewWorker:     actionnotifier.NewWorker,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
@@ -297,6 +308,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 		environTrackerName: ifCredentialValid(ifResponsible(environ.Manifold(environ.ManifoldConfig{
 			APICallerName:  apiCallerName,
 			NewEnvironFunc: config.NewEnvironFunc,
+			Clock:          config.Clock,
 		}))),
 
 		// Everything else should be wrapped in ifResponsible,
@@ -404,6 +416,15 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewClient:     instancemutater.NewClient,
 			NewWorker:     instancemutater.NewEnvironWorker,
 		})),
+		winrmHealthName: ifNotMigrating(winrmhealth.Manifold(winrmhealth.ManifoldConfig{
+			APICallerName:            apiCallerName,
+			ClockName:                clockName,
+			Period:                   winrmhealth.DefaultPeriod,
+			CertificateExpiryWarning: winrmhealth.DefaultCertificateExpiryWarning,
+			ProbeTimeout:             10 * time.Second,
+			NewFacade:                winrmhealth.NewFacade,
+			NewWorker:                winrmhealth.NewWorker,
+		})),
 	}
 
 	result := commonManifolds(config)
@@ -459,6 +480,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			caasunitprovisioner.ManifoldConfig{
 				APICallerName: apiCallerName,
 				BrokerName:    caasBrokerTrackerName,
+				ClockName:     clockName,
 				NewClient: func(caller base.APICaller) caasunitprovisioner.Client {
 					return caasunitprovisionerapi.NewClient(caller)
 				},
@@ -596,10 +618,12 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionNotifierName       = "action-notifier"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 	instanceMutaterName      = "instance-mutater"
+	winrmHealthName          = "winrm-health"
 
 	caasFirewallerName          = "caas-firewaller"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
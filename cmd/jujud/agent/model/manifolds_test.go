@@ -32,6 +32,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 	// NOTE: if this test failed, the cmd/jujud/agent tests will
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
+		"action-notifier",
 		"action-pruner",
 		"agent",
 		"api-caller",
@@ -63,6 +64,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"undertaker",
 		"unit-assigner",
 		"valid-credential-flag",
+		"winrm-health",
 	})
 }
 
@@ -77,6 +79,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 	// NOTE: if this test failed, the cmd/jujud/agent tests will
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
+		"action-notifier",
 		"action-pruner",
 		"agent",
 		"api-caller",
@@ -189,6 +192,17 @@ func (s *ManifoldsSuite) TestCAASManifold(c *gc.C) {
 }
 
 var expectedCAASModelManifoldsWithDependencies = map[string][]string{
+	"action-notifier": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"action-pruner": {
 		"agent",
 		"api-caller",
@@ -248,6 +262,7 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"agent",
 		"api-caller",
 		"caas-broker-tracker",
+		"clock",
 		"is-responsible-flag",
 		"migration-fortress",
 		"migration-inactive-flag",
@@ -357,6 +372,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
+	"action-notifier": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"action-pruner": {
 		"agent",
 		"api-caller",
@@ -600,4 +627,15 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"not-dead-flag"},
 
 	"valid-credential-flag": {"agent", "api-caller"},
+
+	"winrm-health": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
 }
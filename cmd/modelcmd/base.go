@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -98,6 +99,10 @@ type Command interface {
 	closeAPIContexts()
 	initContexts(*cmd.Context)
 	setRunStarted()
+
+	// reportWarnings reports any warnings attached to the replies
+	// to the command's API requests.
+	reportWarnings()
 }
 
 // ModelAPI provides access to the model client facade methods.
@@ -118,6 +123,12 @@ type CommandBase struct {
 	runStarted    bool
 	refreshModels func(jujuclient.ClientStore, string) error
 
+	// warningsMu guards warnings.
+	warningsMu sync.Mutex
+	// warnings holds the warnings attached to the replies
+	// to the command's API requests.
+	warnings []ClientWarning
+
 	// CanClearCurrentModel indicates that this command can reset current model in local cache, aka client store.
 	CanClearCurrentModel bool
 }
@@ -318,6 +329,9 @@ func (c *CommandBase) APIOpen(info *api.Info, opts api.DialOpts) (api.Connection
 // apiOpen establishes a connection to the API server using the
 // the give api.Info and api.DialOpts.
 func (c *CommandBase) apiOpen(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+	if opts.WarningHandler == nil {
+		opts.WarningHandler = c.addWarnings
+	}
 	if c.apiOpenFunc != nil {
 		return c.apiOpenFunc(info, opts)
 	}
@@ -466,6 +480,7 @@ func (w *baseCommandWrapper) inner() cmd.Command {
 // Run implements Command.Run.
 func (w *baseCommandWrapper) Run(ctx *cmd.Context) error {
 	defer w.closeAPIContexts()
+	defer w.reportWarnings()
 	w.initContexts(ctx)
 	w.setRunStarted()
 	return w.Command.Run(ctx)
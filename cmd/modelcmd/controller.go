@@ -361,7 +361,7 @@ func (w *controllerCommandWrapper) Run(ctx *cmd.Context) error {
 	w.setRunStarted()
 	store := w.ClientStore()
 	if store == nil {
-		store = jujuclient.NewFileClientStore()
+		store = jujuclient.NewClientStore()
 	}
 	store = QualifyingClientStore{store}
 	w.SetClientStore(store)
@@ -28,3 +28,9 @@ func SetModelRefresh(refresh func(jujuclient.ClientStore, string) error, b inter
 }) {
 	b.SetModelRefresh(refresh)
 }
+
+func ReportWarnings(b interface {
+	reportWarnings()
+}) {
+	b.reportWarnings()
+}
@@ -150,7 +150,7 @@ func (c *ModelCommandBase) maybeInitModel() error {
 	if !c.doneInitModel {
 		store := c.store
 		if store == nil {
-			store = jujuclient.NewFileClientStore()
+			store = jujuclient.NewClientStore()
 		}
 		store = QualifyingClientStore{store}
 		c.SetClientStore(store)
@@ -594,7 +594,7 @@ func (w *modelCommandWrapper) Run(ctx *cmd.Context) error {
 	w.setRunStarted()
 	store := w.ClientStore()
 	if store == nil {
-		store = jujuclient.NewFileClientStore()
+		store = jujuclient.NewClientStore()
 	}
 	store = QualifyingClientStore{store}
 	w.SetClientStore(store)
@@ -0,0 +1,153 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/rpc"
+)
+
+// maxClientWarnings holds the maximum number of warnings kept in the
+// client warnings file.
+const maxClientWarnings = 50
+
+// ClientWarning records a warning attached by a controller to the
+// reply to an API request made by the client.
+type ClientWarning struct {
+	// Code identifies the kind of warning.
+	Code string `yaml:"code"`
+
+	// Message holds a human-readable description of the warning.
+	Message string `yaml:"message"`
+
+	// Facade, Version and Method identify the API request
+	// the warning was attached to.
+	Facade  string `yaml:"facade"`
+	Version int    `yaml:"version"`
+	Method  string `yaml:"method"`
+
+	// LastSeen holds when the warning was last received.
+	LastSeen time.Time `yaml:"last-seen"`
+}
+
+type clientWarningsCollection struct {
+	Warnings []ClientWarning `yaml:"warnings"`
+}
+
+// ClientWarningsFile returns the path of the file holding the warnings
+// most recently received by the client.
+func ClientWarningsFile() string {
+	return osenv.JujuXDGDataHomePath("warnings.yaml")
+}
+
+// ReadClientWarnings returns the warnings recorded in the client
+// warnings file, most recently seen first. It returns no warnings
+// if the file does not exist.
+func ReadClientWarnings() ([]ClientWarning, error) {
+	data, err := ioutil.ReadFile(ClientWarningsFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result clientWarningsCollection
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal client warnings")
+	}
+	return result.Warnings, nil
+}
+
+// recordClientWarnings merges the given warnings into the client
+// warnings file. A warning with the same code, message and request
+// as a recorded one replaces it.
+func recordClientWarnings(warnings []ClientWarning) error {
+	all, err := ReadClientWarnings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The given warnings are the most recently seen,
+	// so they go first, latest first.
+	for _, w := range warnings {
+		all = append([]ClientWarning{w}, removeClientWarning(all, w)...)
+	}
+	if len(all) > maxClientWarnings {
+		all = all[:maxClientWarnings]
+	}
+	data, err := yaml.Marshal(clientWarningsCollection{Warnings: all})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal client warnings")
+	}
+	return utils.AtomicWriteFile(ClientWarningsFile(), data, os.FileMode(0600))
+}
+
+func removeClientWarning(warnings []ClientWarning, w ClientWarning) []ClientWarning {
+	result := warnings[:0]
+	for _, existing := range warnings {
+		if !existing.sameAs(w) {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+func (w ClientWarning) sameAs(other ClientWarning) bool {
+	return w.Code == other.Code &&
+		w.Message == other.Message &&
+		w.Facade == other.Facade &&
+		w.Version == other.Version &&
+		w.Method == other.Method
+}
+
+// addWarnings is an rpc.WarningHandler that collects the warnings
+// attached to the replies to the command's API requests, to be
+// reported when the command finishes.
+func (c *CommandBase) addWarnings(req rpc.Request, warnings []rpc.Warning) {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+	now := time.Now()
+	for _, w := range warnings {
+		cw := ClientWarning{
+			Code:     w.Code,
+			Message:  w.Message,
+			Facade:   req.Type,
+			Version:  req.Version,
+			Method:   req.Action,
+			LastSeen: now,
+		}
+		c.warnings = append(removeClientWarning(c.warnings, cw), cw)
+	}
+}
+
+// reportWarnings writes each distinct warning collected while the
+// command ran to stderr, and records them in the client warnings file
+// so they can be reviewed later with "juju warnings".
+func (c *CommandBase) reportWarnings() {
+	c.warningsMu.Lock()
+	warnings := c.warnings
+	c.warnings = nil
+	c.warningsMu.Unlock()
+	if len(warnings) == 0 || c.cmdContext == nil {
+		return
+	}
+	reported := make(map[string]bool)
+	for _, w := range warnings {
+		if reported[w.Message] {
+			continue
+		}
+		reported[w.Message] = true
+		c.cmdContext.Warningf("%s", w.Message)
+	}
+	if err := recordClientWarnings(warnings); err != nil {
+		logger.Debugf("cannot record client warnings: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/testing"
+)
+
+type WarningsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&WarningsSuite{})
+
+func (s *WarningsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "foo"
+	s.store.Controllers["foo"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"testing.invalid:1234"},
+	}
+	s.store.Accounts["foo"] = jujuclient.AccountDetails{
+		User: "bar", Password: "hunter2",
+	}
+}
+
+func (s *WarningsSuite) runWithWarnings(c *gc.C, req rpc.Request, warnings ...rpc.Warning) string {
+	apiOpen := func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Assert(opts.WarningHandler, gc.NotNil)
+		opts.WarningHandler(req, warnings)
+		opts.WarningHandler(req, warnings)
+		return nil, errors.New("boom")
+	}
+	baseCmd := new(modelcmd.ControllerCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(apiOpen)
+	ctx := cmdtesting.Context(c)
+	modelcmd.InitContexts(ctx, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	_, err := baseCmd.NewAPIRoot()
+	c.Assert(err, gc.ErrorMatches, ".*boom")
+	modelcmd.ReportWarnings(baseCmd)
+	return cmdtesting.Stderr(ctx)
+}
+
+func (s *WarningsSuite) TestWarningsReported(c *gc.C) {
+	stderr := s.runWithWarnings(c,
+		rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"},
+		rpc.Warning{Code: "deprecated facade version", Message: "Client facade version 1 is deprecated"},
+	)
+	c.Assert(stderr, gc.Matches, "(?s).*Client facade version 1 is deprecated\n")
+	c.Assert(stderr, gc.Not(gc.Matches), "(?s).*deprecated.*deprecated.*")
+
+	warnings, err := modelcmd.ReadClientWarnings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(warnings, gc.HasLen, 1)
+	c.Assert(warnings[0].LastSeen.IsZero(), jc.IsFalse)
+	c.Assert(warnings[0].Code, gc.Equals, "deprecated facade version")
+	c.Assert(warnings[0].Message, gc.Equals, "Client facade version 1 is deprecated")
+	c.Assert(warnings[0].Facade, gc.Equals, "Client")
+	c.Assert(warnings[0].Version, gc.Equals, 1)
+	c.Assert(warnings[0].Method, gc.Equals, "FullStatus")
+}
+
+func (s *WarningsSuite) TestWarningsRecordedMostRecentFirst(c *gc.C) {
+	facadeWarning := rpc.Warning{Code: "deprecated facade version", Message: "Client facade version 1 is deprecated"}
+	configWarning := rpc.Warning{Code: "deprecated config", Message: `model config attribute "foo" is deprecated`}
+	s.runWithWarnings(c, rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"}, facadeWarning)
+	s.runWithWarnings(c, rpc.Request{Type: "ModelConfig", Version: 2, Action: "ModelSet"}, configWarning)
+	s.runWithWarnings(c, rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"}, facadeWarning)
+
+	warnings, err := modelcmd.ReadClientWarnings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(warnings, gc.HasLen, 2)
+	c.Assert(warnings[0].Message, gc.Equals, facadeWarning.Message)
+	c.Assert(warnings[1].Message, gc.Equals, configWarning.Message)
+}
+
+func (s *WarningsSuite) TestNoWarnings(c *gc.C) {
+	stderr := s.runWithWarnings(c, rpc.Request{Type: "Client", Version: 2, Action: "FullStatus"})
+	c.Assert(stderr, gc.Equals, "")
+	warnings, err := modelcmd.ReadClientWarnings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(warnings, gc.HasLen, 0)
+}
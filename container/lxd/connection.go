@@ -36,10 +36,11 @@ type ServerSpec struct {
 type ProxyFunc func(*http.Request) (*url.URL, error)
 
 // NewServerSpec creates a ServerSpec with default values where needed.
-// It also ensures the HTTPS for the host implicitly
+// It also ensures the HTTPS for the host implicitly, unless the host is an
+// ssh:// address.
 func NewServerSpec(host, serverCert string, clientCert *Certificate) ServerSpec {
 	return ServerSpec{
-		Host: EnsureHTTPS(host),
+		Host: ensureRemoteScheme(host),
 		connectionArgs: &lxd.ConnectionArgs{
 			TLSServerCert: serverCert,
 			TLSClientCert: string(clientCert.CertPEM),
@@ -73,10 +74,11 @@ func (s ServerSpec) WithSkipGetServer(b bool) ServerSpec {
 
 // NewInsecureServerSpec creates a ServerSpec without certificate requirements,
 // which also bypasses the TLS verification.
-// It also ensures the HTTPS for the host implicitly
+// It also ensures the HTTPS for the host implicitly, unless the host is an
+// ssh:// address.
 func NewInsecureServerSpec(host string) ServerSpec {
 	return ServerSpec{
-		Host: EnsureHTTPS(host),
+		Host: ensureRemoteScheme(host),
 		connectionArgs: &lxd.ConnectionArgs{
 			InsecureSkipVerify: true,
 		},
@@ -136,7 +138,15 @@ func connectLocal() (lxd.ContainerServer, error) {
 }
 
 // ConnectRemote connects to LXD on a remote socket.
+// If the spec host is an ssh:// address, the LXD unix socket on that host is
+// forwarded over SSH, so the HTTPS listener need not be enabled. The returned
+// server then implements io.Closer, which closes the tunnel.
 func ConnectRemote(spec ServerSpec) (lxd.ContainerServer, error) {
+	if IsSSHRemote(spec.Host) {
+		client, err := connectSSHRemote(spec)
+		return client, errors.Trace(err)
+	}
+
 	// Ensure the Port on the Host, if we get an error it is reasonable to
 	// assume that the address in the spec is invalid.
 	uri, err := EnsureHostPort(spec.Host)
@@ -190,6 +200,15 @@ func EnsureHTTPS(address string) string {
 	return "https://" + address
 }
 
+// ensureRemoteScheme returns ssh:// addresses unchanged, and otherwise
+// ensures that the address is a HTTPS URL.
+func ensureRemoteScheme(address string) string {
+	if IsSSHRemote(address) {
+		return address
+	}
+	return EnsureHTTPS(address)
+}
+
 const defaultPort = 8443
 
 // EnsureHostPort takes a URI and ensures that it has a port set, if it doesn't
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/container/lxd"
@@ -50,6 +51,55 @@ func (s *connectionSuite) TestConnectRemoteBadProtocol(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "bad protocol supplied for connection: FOOBAR")
 }
 
+func (s *connectionSuite) TestNewServerSpecSSHRemote(c *gc.C) {
+	spec := lxd.NewInsecureServerSpec("ssh://ubuntu@somewhere")
+	c.Check(spec.Host, gc.Equals, "ssh://ubuntu@somewhere")
+	c.Check(lxd.IsSSHRemote(spec.Host), jc.IsTrue)
+
+	spec = lxd.NewInsecureServerSpec("somewhere")
+	c.Check(spec.Host, gc.Equals, "https://somewhere")
+	c.Check(lxd.IsSSHRemote(spec.Host), jc.IsFalse)
+}
+
+func (s *connectionSuite) TestParseSSHRemote(c *gc.C) {
+	for _, t := range []struct {
+		Input   string
+		User    string
+		Address string
+		Socket  string
+	}{
+		{
+			Input:   "ssh://ubuntu@somewhere",
+			User:    "ubuntu",
+			Address: "somewhere:22",
+			Socket:  "/var/snap/lxd/common/lxd/unix.socket",
+		},
+		{
+			Input:   "ssh://ubuntu@somewhere:2222",
+			User:    "ubuntu",
+			Address: "somewhere:2222",
+			Socket:  "/var/snap/lxd/common/lxd/unix.socket",
+		},
+		{
+			Input:   "ssh://ubuntu@10.0.0.1/var/lib/lxd/unix.socket",
+			User:    "ubuntu",
+			Address: "10.0.0.1:22",
+			Socket:  "/var/lib/lxd/unix.socket",
+		},
+	} {
+		user, address, socket, err := lxd.ParseSSHRemote(t.Input)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(user, gc.Equals, t.User)
+		c.Check(address, gc.Equals, t.Address)
+		c.Check(socket, gc.Equals, t.Socket)
+	}
+}
+
+func (s *connectionSuite) TestParseSSHRemoteInvalid(c *gc.C) {
+	_, _, _, err := lxd.ParseSSHRemote("ssh:///var/lib/lxd/unix.socket")
+	c.Check(err, gc.ErrorMatches, `ssh remote "ssh:///var/lib/lxd/unix.socket" not valid`)
+}
+
 func (s *connectionSuite) TestEnsureHTTPSUnchangedWhenCorrect(c *gc.C) {
 	addr := "https://somewhere"
 	c.Check(lxd.EnsureHTTPS(addr), gc.Equals, addr)
@@ -6,6 +6,7 @@ package lxd
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -43,6 +44,25 @@ type ContainerSpec struct {
 // MiB suffix for memory constraints. By default we use "MB".
 var minMiBVersion = &version.DottedVersion{Major: 3, Minor: 10}
 
+// gpuVendorIDs maps the values accepted for the gpu-type constraint
+// to the PCI vendor IDs that LXD uses to select host GPUs.
+var gpuVendorIDs = map[string]string{
+	"amd":    "1002",
+	"intel":  "8086",
+	"nvidia": "10de",
+}
+
+// GPUTypes returns the values accepted for the gpu-type constraint
+// by LXD containers.
+func GPUTypes() []string {
+	types := make([]string, 0, len(gpuVendorIDs))
+	for gpuType := range gpuVendorIDs {
+		types = append(types, gpuType)
+	}
+	sort.Strings(types)
+	return types
+}
+
 // ApplyConstraints applies the input constraints as valid LXD container
 // configuration to the container spec.
 // Note that we pass these through as supplied. If an instance type constraint
@@ -65,6 +85,25 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 		}
 		c.Config["limits.memory"] = fmt.Sprintf(template, *cons.Mem)
 	}
+	if cons.HasGPUs() {
+		// Pass through the requested number of host GPUs,
+		// restricted to the requested vendor if there is one.
+		if c.Devices == nil {
+			c.Devices = map[string]device{}
+		}
+		for i := uint64(0); i < *cons.GPUs; i++ {
+			gpu := device{
+				"type": "gpu",
+				"id":   fmt.Sprintf("%d", i),
+			}
+			if cons.HasGPUType() {
+				if vendorID, ok := gpuVendorIDs[*cons.GPUType]; ok {
+					gpu["vendorid"] = vendorID
+				}
+			}
+			c.Devices[fmt.Sprintf("gpu%d", i)] = gpu
+		}
+	}
 }
 
 // Container extends the upstream LXD container type.
@@ -119,6 +158,17 @@ func (c *Container) Mem() uint {
 	return uint(mib)
 }
 
+// GPUs returns the number of host GPUs passed through to the container.
+func (c *Container) GPUs() uint {
+	var gpus uint
+	for _, dev := range c.Devices {
+		if dev["type"] == "gpu" {
+			gpus++
+		}
+	}
+	return gpus
+}
+
 // AddDisk modifies updates the container's devices map to represent a disk
 // device described by the input arguments.
 // If the device already exists, an error is returned.
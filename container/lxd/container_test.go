@@ -57,6 +57,22 @@ func (s *containerSuite) TestContainerMem(c *gc.C) {
 	c.Check(int(container.Mem()), gc.Equals, 2048)
 }
 
+func (s *containerSuite) TestContainerGPUs(c *gc.C) {
+	container := lxd.Container{}
+	c.Check(container.GPUs(), gc.Equals, uint(0))
+
+	container.Devices = map[string]map[string]string{
+		"eth0": {"type": "nic"},
+		"gpu0": {"type": "gpu", "id": "0"},
+		"gpu1": {"type": "gpu", "id": "1"},
+	}
+	c.Check(container.GPUs(), gc.Equals, uint(2))
+}
+
+func (s *containerSuite) TestGPUTypes(c *gc.C) {
+	c.Check(lxd.GPUTypes(), jc.DeepEquals, []string{"amd", "intel", "nvidia"})
+}
+
 func (s *containerSuite) TestContainerAddDiskNoDevices(c *gc.C) {
 	container := lxd.Container{}
 	err := container.AddDisk("root", "/", "source", "default", true)
@@ -559,4 +575,30 @@ func (s *managerSuite) TestSpecApplyConstraints(c *gc.C) {
 	spec.ApplyConstraints("2.0.11", cons)
 	c.Check(spec.Config, gc.DeepEquals, exp)
 	c.Check(spec.InstanceType, gc.Equals, instType)
+	c.Check(spec.Devices, gc.HasLen, 0)
+}
+
+func (s *managerSuite) TestSpecApplyConstraintsGPUs(c *gc.C) {
+	spec := lxd.ContainerSpec{
+		Config: map[string]string{},
+		Devices: map[string]map[string]string{
+			"eth0": {"type": "nic"},
+		},
+	}
+	spec.ApplyConstraints("3.10.0", constraints.MustParse("gpus=2 gpu-type=nvidia"))
+
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"eth0": {"type": "nic"},
+		"gpu0": {"type": "gpu", "id": "0", "vendorid": "10de"},
+		"gpu1": {"type": "gpu", "id": "1", "vendorid": "10de"},
+	})
+}
+
+func (s *managerSuite) TestSpecApplyConstraintsGPUsAnyType(c *gc.C) {
+	spec := lxd.ContainerSpec{}
+	spec.ApplyConstraints("3.10.0", constraints.MustParse("gpus=1"))
+
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"gpu0": {"type": "gpu", "id": "0"},
+	})
 }
@@ -20,6 +20,13 @@ var (
 	ErrIPV6NotSupported   = errIPV6NotSupported
 )
 
+// ParseSSHRemote exposes parseSSHRemote, returning the user, address and
+// remote socket path.
+func ParseSSHRemote(address string) (string, string, string, error) {
+	remote, err := parseSSHRemote(address)
+	return remote.user, remote.address, remote.socketPath, err
+}
+
 type patcher interface {
 	PatchValue(interface{}, interface{})
 }
@@ -115,6 +115,77 @@ func (s *Server) FindImage(
 	return sourced, nil
 }
 
+// FindImageByID looks for the image identified by the input fingerprint
+// or alias, first on the local server and then in each of the input
+// sources in supplied order. It is used when the image for a series and
+// architecture has been pinned in model config.
+// Supplying true for copyLocal will copy a remote image to the local
+// cache. No juju alias is added to copied images, so that the pinned
+// image never replaces the default image for its series.
+func (s *Server) FindImageByID(
+	imageID string,
+	sources []ServerSpec,
+	copyLocal bool,
+	callback environs.StatusCallbackFunc,
+) (SourcedImage, error) {
+	if callback != nil {
+		callback(status.Provisioning, "acquiring pinned LXD image", nil)
+	}
+
+	if image := findImageByID(s.ContainerServer, imageID); image != nil {
+		logger.Debugf("Found pinned image locally - %q %q", image.Filename, image.Fingerprint)
+		return SourcedImage{
+			Image:     image,
+			LXDServer: s.ContainerServer,
+		}, nil
+	}
+
+	sourced := SourcedImage{}
+	lastErr := errors.NotFoundf("image %q", imageID)
+	for _, remote := range sources {
+		source, err := ConnectImageRemote(remote)
+		if err != nil {
+			logger.Infof("failed to connect to %q: %s", remote.Host, err)
+			lastErr = errors.Trace(err)
+			continue
+		}
+		if image := findImageByID(source, imageID); image != nil {
+			logger.Debugf("Found pinned image remotely - %q %q %q", remote.Name, image.Filename, image.Fingerprint)
+			sourced.Image = image
+			sourced.LXDServer = source
+			break
+		}
+	}
+
+	if sourced.Image == nil {
+		return sourced, lastErr
+	}
+
+	if copyLocal {
+		if err := s.CopyRemoteImage(sourced, nil, callback); err != nil {
+			return sourced, errors.Trace(err)
+		}
+		sourced.LXDServer = s.ContainerServer
+	}
+
+	return sourced, nil
+}
+
+// findImageByID returns the image on the server with the input
+// fingerprint, or the target of the alias with the input name.
+// Nil is returned if there is no such image.
+func findImageByID(server lxd.ImageServer, imageID string) *api.Image {
+	if image, _, err := server.GetImage(imageID); err == nil && image != nil {
+		return image
+	}
+	if entry, _, err := server.GetImageAlias(imageID); err == nil && entry != nil && entry.Target != "" {
+		if image, _, err := server.GetImage(entry.Target); err == nil {
+			return image
+		}
+	}
+	return nil
+}
+
 // CopyRemoteImage accepts an image sourced from a remote server and copies it
 // to the local cache
 func (s *Server) CopyRemoteImage(
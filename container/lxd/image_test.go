@@ -184,6 +184,77 @@ func (s *imageSuite) TestFindImageRemoteServersNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*failed to retrieve image.*")
 }
 
+func (s *imageSuite) TestFindImageByIDLocalServer(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	alias := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "golden-fingerprint"}}
+	image := lxdapi.Image{Filename: "this-is-our-golden-image"}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImage("golden").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().GetImageAlias("golden").Return(alias, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImage("golden-fingerprint").Return(&image, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := jujuSvr.FindImageByID("golden", []lxd.ServerSpec{{}}, false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindImageByIDRemoteServerCopyLocal(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"server-that-has-image": rSvr,
+	})
+
+	copyOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	copyOp.EXPECT().Wait().Return(nil).AnyTimes()
+	copyOp.EXPECT().GetTarget().Return(&lxdapi.Operation{StatusCode: lxdapi.Success}, nil)
+
+	image := lxdapi.Image{Filename: "this-is-our-golden-image", Fingerprint: "golden-fingerprint"}
+	copyReq := &lxdclient.ImageCopyArgs{Aliases: []lxdapi.ImageAlias{}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImage("golden-fingerprint").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().GetImageAlias("golden-fingerprint").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		rSvr.EXPECT().GetImage("golden-fingerprint").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().CopyImage(rSvr, image, copyReq).Return(copyOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{
+		{Name: "server-that-has-image", Protocol: lxd.SimpleStreamsProtocol},
+	}
+	found, err := jujuSvr.FindImageByID("golden-fingerprint", remotes, true, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindImageByIDNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+	iSvr.EXPECT().GetImage("golden").Return(nil, lxdtesting.ETag, errors.New("not found"))
+	iSvr.EXPECT().GetImageAlias("golden").Return(nil, lxdtesting.ETag, errors.New("not found"))
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.FindImageByID("golden", nil, false, nil)
+	c.Assert(err, gc.ErrorMatches, `image "golden" not found`)
+}
+
 func (s *imageSuite) TestSeriesRemoteAliasesNotSupported(c *gc.C) {
 	_, err := lxd.SeriesRemoteAliases("centos7", "arm64")
 	c.Assert(err, gc.ErrorMatches, `series "centos7" not supported`)
@@ -4,6 +4,8 @@
 package lxd
 
 import (
+	"io"
+
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/utils/arch"
@@ -85,7 +87,22 @@ func NewRemoteServer(spec ServerSpec) (*Server, error) {
 		return nil, errors.Trace(err)
 	}
 	svr, err := NewServer(cSvr)
-	return svr, err
+	if err != nil {
+		if closer, ok := cSvr.(io.Closer); ok {
+			_ = closer.Close()
+		}
+		return nil, err
+	}
+	return svr, nil
+}
+
+// Close releases any resources held by the connection to the server,
+// such as the SSH tunnel to an ssh:// remote.
+func (s *Server) Close() error {
+	if closer, ok := s.ContainerServer.(io.Closer); ok {
+		return errors.Trace(closer.Close())
+	}
+	return nil
 }
 
 // NewServer builds and returns a Server for high-level interaction with the
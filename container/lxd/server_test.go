@@ -37,6 +37,29 @@ func (s *serverSuite) TestUpdateServerConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type closingContainerServer struct {
+	*lxdtesting.MockContainerServer
+	closed bool
+}
+
+func (s *closingContainerServer) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *serverSuite) TestClose(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := &closingContainerServer{MockContainerServer: lxdtesting.NewMockContainerServer(ctrl)}
+	cSvr.EXPECT().GetServer().Return(&api.Server{}, lxdtesting.ETag, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cSvr.closed, jc.IsTrue)
+}
+
 func (s *serverSuite) TestUpdateContainerConfig(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,270 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	jujussh "github.com/juju/utils/ssh"
+	lxd "github.com/lxc/lxd/client"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	sshScheme = "ssh://"
+
+	// defaultRemoteSocketPath is the LXD socket used on the remote host when
+	// the ssh:// address does not specify one. LXD installed via Snap is
+	// given preference, as for local connections.
+	defaultRemoteSocketPath = "/var/snap/lxd/common/lxd/unix.socket"
+)
+
+// IsSSHRemote returns true if the input address is of the form
+// ssh://[user@]host[:port][/path/to/unix.socket], indicating that the LXD
+// unix socket on that host should be reached through an SSH tunnel.
+func IsSSHRemote(address string) bool {
+	return strings.HasPrefix(address, sshScheme)
+}
+
+// sshRemote holds the connection details parsed from an ssh:// address.
+type sshRemote struct {
+	user       string
+	address    string
+	socketPath string
+}
+
+// parseSSHRemote parses an ssh:// remote address, filling in the default
+// user, port and socket path where they are not supplied.
+func parseSSHRemote(address string) (sshRemote, error) {
+	uri, err := url.Parse(address)
+	if err != nil {
+		return sshRemote{}, errors.Trace(err)
+	}
+	if uri.Scheme != "ssh" || uri.Hostname() == "" {
+		return sshRemote{}, errors.NotValidf("ssh remote %q", address)
+	}
+
+	remote := sshRemote{
+		address:    uri.Host,
+		socketPath: uri.Path,
+	}
+	if uri.Port() == "" {
+		remote.address = net.JoinHostPort(uri.Hostname(), "22")
+	}
+	if remote.socketPath == "" || remote.socketPath == "/" {
+		remote.socketPath = defaultRemoteSocketPath
+	}
+	if uri.User != nil {
+		remote.user = uri.User.Username()
+	}
+	if remote.user == "" {
+		current, err := user.Current()
+		if err != nil {
+			return sshRemote{}, errors.Annotate(err, "determining ssh user")
+		}
+		remote.user = current.Username
+	}
+	return remote, nil
+}
+
+// sshClientConfig returns the configuration used to establish SSH
+// tunnels. It authenticates with whichever of the Juju client keys can
+// be read, and verifies the remote host against the user's known_hosts
+// file.
+var sshClientConfig = func(userName string) (*ssh.ClientConfig, error) {
+	var signers []ssh.Signer
+	for _, file := range jujussh.PrivateKeyFiles() {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			logger.Debugf("ignoring private key %q: %v", file, err)
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			logger.Debugf("ignoring private key %q: %v", file, err)
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) == 0 {
+		return nil, errors.NotFoundf("ssh private keys")
+	}
+
+	hostKeyCallback, err := knownhosts.New(filepath.Join(utils.Home(), ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading ssh known hosts")
+	}
+	return &ssh.ClientConfig{
+		User:            userName,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// sshTunnel forwards connections made to a local unix socket over an SSH
+// connection, to a unix socket on the remote host.
+type sshTunnel struct {
+	client       *ssh.Client
+	listener     net.Listener
+	dir          string
+	remoteSocket string
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// newSSHTunnel connects to the input ssh:// address and begins forwarding
+// connections from a local socket to the remote LXD socket.
+func newSSHTunnel(address string) (*sshTunnel, error) {
+	remote, err := parseSSHRemote(address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := sshClientConfig(remote.user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := ssh.Dial("tcp", remote.address, cfg)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to %s@%s", remote.user, remote.address)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-lxd-ssh")
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Trace(err)
+	}
+	listener, err := net.Listen("unix", filepath.Join(dir, "unix.socket"))
+	if err != nil {
+		_ = client.Close()
+		_ = os.RemoveAll(dir)
+		return nil, errors.Trace(err)
+	}
+
+	t := &sshTunnel{
+		client:       client,
+		listener:     listener,
+		dir:          dir,
+		remoteSocket: remote.socketPath,
+	}
+	logger.Debugf("forwarding %s to %s on %s", t.SocketPath(), remote.socketPath, remote.address)
+	go t.serve()
+	return t, nil
+}
+
+// SocketPath returns the path of the local end of the tunnel.
+func (t *sshTunnel) SocketPath() string {
+	return t.listener.Addr().String()
+}
+
+func (t *sshTunnel) serve() {
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			if !t.isClosed() {
+				logger.Errorf("accepting connection on ssh tunnel: %v", err)
+			}
+			return
+		}
+		go t.forward(local)
+	}
+}
+
+func (t *sshTunnel) forward(local net.Conn) {
+	defer local.Close()
+
+	remote, err := t.client.Dial("unix", t.remoteSocket)
+	if err != nil {
+		logger.Errorf("dialling %s over ssh tunnel: %v", t.remoteSocket, err)
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func (t *sshTunnel) isClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+// Close stops forwarding and tears down the SSH connection.
+func (t *sshTunnel) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+
+	err := t.listener.Close()
+	if err2 := t.client.Close(); err == nil {
+		err = err2
+	}
+	if err2 := os.RemoveAll(t.dir); err == nil {
+		err = err2
+	}
+	return errors.Trace(err)
+}
+
+// sshContainerServer is a ContainerServer connected through an SSH tunnel.
+// Closing it closes the tunnel.
+type sshContainerServer struct {
+	lxd.ContainerServer
+	tunnel *sshTunnel
+}
+
+// Close implements io.Closer.
+func (s *sshContainerServer) Close() error {
+	return s.tunnel.Close()
+}
+
+// connectSSHRemote establishes an SSH tunnel to the host in the input spec,
+// and connects to LXD over the forwarded unix socket.
+func connectSSHRemote(spec ServerSpec) (lxd.ContainerServer, error) {
+	tunnel, err := newSSHTunnel(spec.Host)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The connection is made over a unix socket, so none of the TLS
+	// arguments apply.
+	var args *lxd.ConnectionArgs
+	if spec.connectionArgs != nil {
+		args = &lxd.ConnectionArgs{
+			SkipGetServer: spec.connectionArgs.SkipGetServer,
+		}
+	}
+	client, err := lxd.ConnectLXDUnix(tunnel.SocketPath(), args)
+	if err != nil {
+		_ = tunnel.Close()
+		return nil, errors.Trace(err)
+	}
+	return &sshContainerServer{
+		ContainerServer: client,
+		tunnel:          tunnel,
+	}, nil
+}
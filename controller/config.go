@@ -6,7 +6,9 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/collections/set"
@@ -15,11 +17,13 @@ import (
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"golang.org/x/crypto/openpgp"
 	"gopkg.in/juju/charmrepo.v3/csclient"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v2-unstable/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/admission"
 	"github.com/juju/juju/core/resources"
 )
 
@@ -79,6 +83,49 @@ const (
 	// new versions of Juju will be honoured.
 	ReadOnlyMethodsWildcard = "ReadOnlyMethods"
 
+	// RequestLogging determines whether the controller records the
+	// API requests it serves, with the user, facade, method, duration
+	// and result of each, in the request log.
+	RequestLogging = "request-logging"
+
+	// RequestLogSamplePercent is the percentage of the requests exempt
+	// from auditing (see AuditLogExcludeMethods) that are recorded in
+	// the request log. All other requests are always recorded when
+	// request logging is enabled.
+	RequestLogSamplePercent = "request-log-sample-percent"
+
+	// AgentLogSinkRateLimitBurst is the number of log messages an agent
+	// may send to the controller before its messages are rate limited.
+	// When not set, the value in the controller agent's configuration
+	// is used. Changes take effect immediately.
+	AgentLogSinkRateLimitBurst = "agent-logsink-ratelimit-burst"
+
+	// AgentLogSinkRateLimitRefill is the interval at which an agent
+	// which has used its burst may send another log message, eg "1ms".
+	// When not set, the value in the controller agent's configuration
+	// is used. Changes take effect immediately.
+	AgentLogSinkRateLimitRefill = "agent-logsink-ratelimit-refill"
+
+	// WatcherMaxPendingEvents is the number of undelivered events the
+	// controller queues for a watcher before it lapses the watcher, if
+	// the watcher can recover from the events it missed. Changes take
+	// effect when the controller's state workers next restart.
+	WatcherMaxPendingEvents = "watcher-max-pending-events"
+
+	// MaxModelMachines is the maximum number of machines each model
+	// hosted by the controller may have. Zero or unset means there is
+	// no limit.
+	MaxModelMachines = "max-model-machines"
+
+	// MaxModelUnits is the maximum number of units each model hosted
+	// by the controller may have. Zero or unset means there is no limit.
+	MaxModelUnits = "max-model-units"
+
+	// MaxModelStorage is the maximum number of storage instances each
+	// model hosted by the controller may have. Zero or unset means
+	// there is no limit.
+	MaxModelStorage = "max-model-storage"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -88,6 +135,23 @@ const (
 	// CharmStoreURL is the key for the url to use for charmstore API calls
 	CharmStoreURL = "charmstore-url"
 
+	// CharmStoreMirror is the key for the location of a local charm
+	// store mirror, either a directory on the controller or an http
+	// or https url, populated with "juju download-charm". Models use
+	// the mirror according to their charm-mirror-mode.
+	CharmStoreMirror = "charmstore-mirror"
+
+	// CharmTrustKeyring is the key for the armored PGP public keys
+	// trusted to sign charm archives. When it is set, the detached
+	// signatures of charms added from the charm store or its mirror
+	// are validated before the charms are accepted.
+	CharmTrustKeyring = "charm-trust-keyring"
+
+	// RequireSignedCharms is the key for whether charms added from
+	// the charm store or its mirror must be signed by a key in the
+	// charm trust keyring.
+	RequireSignedCharms = "require-signed-charms"
+
 	// ControllerUUIDKey is the key for the controller UUID attribute.
 	ControllerUUIDKey = "controller-uuid"
 
@@ -174,6 +238,15 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultRequestLogging is the default for the RequestLogging
+	// setting (which is not to record requests).
+	DefaultRequestLogging = false
+
+	// DefaultRequestLogSamplePercent is the default percentage of
+	// requests exempt from auditing that are recorded in the request
+	// log.
+	DefaultRequestLogSamplePercent = 10
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -221,6 +294,26 @@ const (
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
 
+	// JujuDBSpace is the network space within which the MongoDB replica-set
+	// should communicate, when database traffic is isolated from the space
+	// used for controller HA. If unset, JujuHASpace is used.
+	JujuDBSpace = "juju-db-space"
+
+	// JujuHAMemberOptions changes how the mongo replica set members of
+	// particular controller nodes take part in the replica set, as a
+	// comma separated list of <node-id>=<option> pairs. The options are
+	// low-priority, hidden and delayed:<duration>; hidden and delayed
+	// members never become primary, which suits nodes such as a
+	// disaster recovery node in another region.
+	JujuHAMemberOptions = "juju-ha-member-options"
+
+	// JujuHARemovalTimeout is how long the mongo replica set member of a
+	// controller node may be unreachable before the member is removed from
+	// the replica set, eg "30m". Members are only removed while the
+	// reachable voting members form a majority. If unset or zero,
+	// unreachable members are never removed.
+	JujuHARemovalTimeout = "juju-ha-removal-timeout"
+
 	// JujuManagementSpace is the network space that agents should use to
 	// communicate with controllers.
 	JujuManagementSpace = "juju-mgmt-space"
@@ -239,6 +332,45 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// ResourceScannerURL is the url of a webhook that scans container
+	// image resources for vulnerabilities when they are attached or
+	// refreshed. Images are not scanned if it is not set.
+	ResourceScannerURL = "resource-scanner-url"
+
+	// ResourceScanPolicy determines whether container image resources
+	// with critical vulnerabilities are only reported ("warn"), or are
+	// rejected ("block").
+	ResourceScanPolicy = "resource-scan-policy"
+
+	// AdmissionPolicyURL is the url of a policy endpoint, such as an
+	// Open Policy Agent rule, that is consulted before applications are
+	// deployed, upgraded or exposed. Requests are not checked if it is
+	// not set.
+	AdmissionPolicyURL = "admission-policy-url"
+
+	// AdmissionFailurePolicy determines whether requests are rejected
+	// ("fail") or allowed ("ignore") when the admission policy endpoint
+	// cannot be consulted.
+	AdmissionFailurePolicy = "admission-failure-policy"
+
+	// MongoWriteConcern overrides the write concern used for writes to
+	// the controller's mongo collections, as a comma separated list of
+	// <collection>=<majority|n>[:journal] pairs, where the collection
+	// "*" applies to all collections not listed. Transactions are run
+	// with the strongest write concern given for any collection they
+	// may write to. Changes take effect when the controller agents are
+	// restarted.
+	MongoWriteConcern = "mongo-write-concern"
+
+	// MongoReadPreference overrides the replica set members that reads
+	// from the controller's mongo collections are sent to, as a comma
+	// separated list of <collection>=<mode> pairs, where the collection
+	// "*" applies to all collections not listed. Reads may only be sent
+	// to secondaries for collections that are not written to by
+	// transactions. Changes take effect when the controller agents are
+	// restarted.
+	MongoReadPreference = "mongo-read-preference"
 )
 
 var (
@@ -252,6 +384,9 @@ var (
 		AutocertURLKey,
 		CACertKey,
 		CharmStoreURL,
+		CharmStoreMirror,
+		CharmTrustKeyring,
+		RequireSignedCharms,
 		ControllerAPIPort,
 		ControllerUUIDKey,
 		IdentityPublicKey,
@@ -267,16 +402,33 @@ var (
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
 		JujuHASpace,
+		JujuDBSpace,
+		JujuHAMemberOptions,
+		JujuHARemovalTimeout,
 		JujuManagementSpace,
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		RequestLogging,
+		RequestLogSamplePercent,
+		AgentLogSinkRateLimitBurst,
+		AgentLogSinkRateLimitRefill,
+		WatcherMaxPendingEvents,
+		MaxModelMachines,
+		MaxModelUnits,
+		MaxModelStorage,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
 		MeteringURL,
+		ResourceScannerURL,
+		ResourceScanPolicy,
+		AdmissionPolicyURL,
+		AdmissionFailurePolicy,
+		MongoWriteConcern,
+		MongoReadPreference,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -287,6 +439,14 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		RequestLogging,
+		RequestLogSamplePercent,
+		AgentLogSinkRateLimitBurst,
+		AgentLogSinkRateLimitRefill,
+		WatcherMaxPendingEvents,
+		MaxModelMachines,
+		MaxModelUnits,
+		MaxModelStorage,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -298,10 +458,22 @@ var (
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
 		JujuHASpace,
+		JujuDBSpace,
+		JujuHAMemberOptions,
+		JujuHARemovalTimeout,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
+		ResourceScannerURL,
+		ResourceScanPolicy,
+		AdmissionPolicyURL,
+		AdmissionFailurePolicy,
+		MongoWriteConcern,
+		MongoReadPreference,
+		CharmStoreMirror,
+		CharmTrustKeyring,
+		RequireSignedCharms,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -473,6 +645,89 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// RequestLogging returns whether the controller records the API
+// requests it serves in the request log. The default is false.
+func (c Config) RequestLogging() bool {
+	if v, ok := c[RequestLogging]; ok {
+		return v.(bool)
+	}
+	return DefaultRequestLogging
+}
+
+// RequestLogSamplePercent returns the percentage of requests exempt
+// from auditing that are recorded in the request log.
+func (c Config) RequestLogSamplePercent() int {
+	if _, ok := c[RequestLogSamplePercent]; !ok {
+		return DefaultRequestLogSamplePercent
+	}
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[RequestLogSamplePercent].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[RequestLogSamplePercent].(int)
+	return value
+}
+
+// AgentLogSinkRateLimitBurst returns the number of log messages an
+// agent may send before they are rate limited, or zero if it is not
+// set.
+func (c Config) AgentLogSinkRateLimitBurst() int64 {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[AgentLogSinkRateLimitBurst].(float64); ok {
+		return int64(value)
+	}
+	value, _ := c[AgentLogSinkRateLimitBurst].(int)
+	return int64(value)
+}
+
+// AgentLogSinkRateLimitRefill returns the interval at which a rate
+// limited agent may send another log message, or zero if it is not
+// set.
+func (c Config) AgentLogSinkRateLimitRefill() time.Duration {
+	// We know that the value must be a parseable time.Duration
+	// for the config to be valid.
+	d, _ := time.ParseDuration(c.asString(AgentLogSinkRateLimitRefill))
+	return d
+}
+
+// WatcherMaxPendingEvents returns the number of undelivered events
+// queued for a watcher before it is lapsed, or zero if it is not set.
+func (c Config) WatcherMaxPendingEvents() int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[WatcherMaxPendingEvents].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[WatcherMaxPendingEvents].(int)
+	return value
+}
+
+// MaxModelMachines returns the maximum number of machines each model
+// may have, or zero if there is no limit.
+func (c Config) MaxModelMachines() int {
+	return c.modelQuota(MaxModelMachines)
+}
+
+// MaxModelUnits returns the maximum number of units each model may
+// have, or zero if there is no limit.
+func (c Config) MaxModelUnits() int {
+	return c.modelQuota(MaxModelUnits)
+}
+
+// MaxModelStorage returns the maximum number of storage instances each
+// model may have, or zero if there is no limit.
+func (c Config) MaxModelStorage() int {
+	return c.modelQuota(MaxModelStorage)
+}
+
+func (c Config) modelQuota(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -494,6 +749,27 @@ func (c Config) CharmStoreURL() string {
 	return url
 }
 
+// CharmStoreMirror returns the location of the charm store mirror,
+// or "" if there is none.
+func (c Config) CharmStoreMirror() string {
+	return c.asString(CharmStoreMirror)
+}
+
+// CharmTrustKeyring returns the armored PGP public keys trusted to
+// sign charm archives, or "" if charm signatures are not validated.
+func (c Config) CharmTrustKeyring() string {
+	return c.asString(CharmTrustKeyring)
+}
+
+// RequireSignedCharms returns whether charms must be signed by a key
+// in the charm trust keyring to be added to the controller's models.
+func (c Config) RequireSignedCharms() bool {
+	if v, ok := c[RequireSignedCharms]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
 // ControllerUUID returns the uuid for the controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -626,6 +902,12 @@ func (c Config) JujuHASpace() string {
 	return c.asString(JujuHASpace)
 }
 
+// JujuDBSpace is the network space within which the MongoDB replica-set
+// should communicate, if distinct from JujuHASpace.
+func (c Config) JujuDBSpace() string {
+	return c.asString(JujuDBSpace)
+}
+
 // JujuManagementSpace is the network space that agents should use to
 // communicate with controllers.
 func (c Config) JujuManagementSpace() string {
@@ -653,6 +935,69 @@ func (c Config) MeteringURL() string {
 	return url
 }
 
+// ResourceScannerURL returns the url of the webhook used to scan
+// container image resources, or "" if they are not scanned.
+func (c Config) ResourceScannerURL() string {
+	return c.asString(ResourceScannerURL)
+}
+
+// ResourceScanPolicy returns how container image resources with
+// critical vulnerabilities are treated.
+func (c Config) ResourceScanPolicy() resources.ImageScanPolicy {
+	if policy := c.asString(ResourceScanPolicy); policy != "" {
+		return resources.ImageScanPolicy(policy)
+	}
+	return resources.ImageScanWarn
+}
+
+// AdmissionPolicyURL returns the url of the admission policy
+// endpoint, or "" if requests are not checked against policy.
+func (c Config) AdmissionPolicyURL() string {
+	return c.asString(AdmissionPolicyURL)
+}
+
+// AdmissionFailurePolicy returns how requests are treated when
+// the admission policy endpoint cannot be consulted.
+func (c Config) AdmissionFailurePolicy() admission.FailurePolicy {
+	if policy := c.asString(AdmissionFailurePolicy); policy != "" {
+		return admission.FailurePolicy(policy)
+	}
+	return admission.FailurePolicyFail
+}
+
+// JujuHAMemberOptions returns the options for the mongo replica set
+// members of controller nodes, keyed by node ID.
+func (c Config) JujuHAMemberOptions() map[string]HAMemberOptions {
+	// Value has already been validated.
+	options, _ := ParseHAMemberOptions(c.asString(JujuHAMemberOptions))
+	return options
+}
+
+// JujuHARemovalTimeout returns how long a controller node's mongo
+// replica set member may be unreachable before it is removed from the
+// replica set. Zero means that unreachable members are never removed.
+func (c Config) JujuHARemovalTimeout() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(JujuHARemovalTimeout))
+	return val
+}
+
+// MongoWriteConcerns returns the mongo write concerns configured
+// for the controller's collections, keyed by collection name.
+func (c Config) MongoWriteConcerns() map[string]MongoWriteConcern {
+	// Value has already been validated.
+	concerns, _ := ParseMongoWriteConcerns(c.asString(MongoWriteConcern))
+	return concerns
+}
+
+// MongoReadPreferences returns the mongo read preferences configured
+// for the controller's collections, keyed by collection name.
+func (c Config) MongoReadPreferences() map[string]MongoReadPreference {
+	// Value has already been validated.
+	preferences, _ := ParseMongoReadPreferences(c.asString(MongoReadPreference))
+	return preferences
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -722,6 +1067,10 @@ func Validate(c Config) error {
 		return errors.Trace(err)
 	}
 
+	if err := c.validateSpaceConfig(JujuDBSpace, "juju DB"); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.validateSpaceConfig(JujuManagementSpace, "juju mgmt"); err != nil {
 		return errors.Trace(err)
 	}
@@ -738,6 +1087,81 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[CharmStoreMirror].(string); ok && v != "" {
+		if err := validateCharmStoreMirror(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[CharmTrustKeyring].(string); ok && v != "" {
+		if _, err := openpgp.ReadArmoredKeyRing(strings.NewReader(v)); err != nil {
+			return errors.Annotate(err, "invalid charm trust keyring in configuration")
+		}
+	}
+	if c.RequireSignedCharms() && c.CharmTrustKeyring() == "" {
+		return errors.Errorf("%s requires %s to be set", RequireSignedCharms, CharmTrustKeyring)
+	}
+
+	if v, ok := c[ResourceScannerURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid resource scanner url in configuration")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("resource scanner url %q", v)
+		}
+	}
+
+	if v, ok := c[ResourceScanPolicy].(string); ok && v != "" {
+		if err := resources.ImageScanPolicy(v).Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[AdmissionPolicyURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid admission policy url in configuration")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("admission policy url %q", v)
+		}
+	}
+
+	if v, ok := c[AdmissionFailurePolicy].(string); ok && v != "" {
+		if err := admission.FailurePolicy(v).Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := c[JujuHAMemberOptions].(string); ok {
+		if _, err := ParseHAMemberOptions(v); err != nil {
+			return errors.Annotate(err, "invalid juju HA member options in configuration")
+		}
+	}
+
+	if v, ok := c[JujuHARemovalTimeout].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "30m")`, JujuHARemovalTimeout)
+		}
+		if d < 0 {
+			return errors.NotValidf("negative %s %q", JujuHARemovalTimeout, v)
+		}
+	}
+
+	if v, ok := c[MongoWriteConcern].(string); ok {
+		if _, err := ParseMongoWriteConcerns(v); err != nil {
+			return errors.Annotate(err, "invalid mongo write concern in configuration")
+		}
+	}
+
+	if v, ok := c[MongoReadPreference].(string); ok {
+		if _, err := ParseMongoReadPreferences(v); err != nil {
+			return errors.Annotate(err, "invalid mongo read preference in configuration")
+		}
+	}
+
 	var auditLogMaxSize int
 	if v, ok := c[AuditLogMaxSize].(string); ok {
 		if size, err := utils.ParseSize(v); err != nil {
@@ -772,6 +1196,34 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[RequestLogSamplePercent].(int); ok {
+		if v < 0 || v > 100 {
+			return errors.Errorf("invalid request log sample percent: should be between 0 and 100, got %d", v)
+		}
+	}
+
+	if v, ok := c[AgentLogSinkRateLimitBurst].(int); ok && v <= 0 {
+		return errors.NotValidf("non-positive integer for %s", AgentLogSinkRateLimitBurst)
+	}
+	if v, ok := c[WatcherMaxPendingEvents].(int); ok && v <= 0 {
+		return errors.NotValidf("non-positive integer for %s", WatcherMaxPendingEvents)
+	}
+	if v, ok := c[AgentLogSinkRateLimitRefill].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Errorf("%s value %q must be a valid duration", AgentLogSinkRateLimitRefill, v)
+		}
+		if d <= 0 {
+			return errors.NotValidf("non-positive duration for %s", AgentLogSinkRateLimitRefill)
+		}
+	}
+
+	for _, key := range []string{MaxModelMachines, MaxModelUnits, MaxModelStorage} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.NotValidf("negative integer for %s", key)
+		}
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalide and --reset is used.
 		// However that doesn't exist yet.
@@ -795,6 +1247,23 @@ func Validate(c Config) error {
 	return nil
 }
 
+// validateCharmStoreMirror checks that the charm store mirror location
+// is an absolute directory path, or an http, https or file url.
+func validateCharmStoreMirror(location string) error {
+	if filepath.IsAbs(location) {
+		return nil
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return errors.Annotate(err, "invalid charm store mirror in configuration")
+	}
+	switch u.Scheme {
+	case "http", "https", "file":
+		return nil
+	}
+	return errors.NotValidf("charm store mirror %q", location)
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -812,7 +1281,7 @@ func (c Config) validateSpaceConfig(key, topic string) error {
 }
 
 // AsSpaceConstraints checks to see whether config has spaces names populated
-// for management and/or HA (Mongo).
+// for management, HA and/or DB (Mongo).
 // Non-empty values are merged with any input spaces and returned as a new
 // slice reference.
 // A slice pointer is used for congruence with the Spaces member in
@@ -825,7 +1294,7 @@ func (c Config) AsSpaceConstraints(spaces *[]string) *[]string {
 		}
 	}
 
-	for _, c := range []string{c.JujuManagementSpace(), c.JujuHASpace()} {
+	for _, c := range []string{c.JujuManagementSpace(), c.JujuHASpace(), c.JujuDBSpace()} {
 		if c != "" {
 			newSpaces.Add(c)
 		}
@@ -847,65 +1316,105 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	APIPortOpenDelay:        schema.String(),
-	ControllerAPIPort:       schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
-	MaxPruneTxnBatchSize:    schema.ForceInt(),
-	MaxPruneTxnPasses:       schema.ForceInt(),
-	PruneTxnQueryCount:      schema.ForceInt(),
-	PruneTxnSleepTime:       schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	CAASImageRepo:           schema.String(),
-	Features:                schema.List(schema.String()),
-	CharmStoreURL:           schema.String(),
-	MeteringURL:             schema.String(),
+	AuditingEnabled:             schema.Bool(),
+	AuditLogCaptureArgs:         schema.Bool(),
+	AuditLogMaxSize:             schema.String(),
+	AuditLogMaxBackups:          schema.ForceInt(),
+	AuditLogExcludeMethods:      schema.List(schema.String()),
+	RequestLogging:              schema.Bool(),
+	RequestLogSamplePercent:     schema.ForceInt(),
+	AgentLogSinkRateLimitBurst:  schema.ForceInt(),
+	WatcherMaxPendingEvents:     schema.ForceInt(),
+	AgentLogSinkRateLimitRefill: schema.String(),
+	MaxModelMachines:            schema.ForceInt(),
+	MaxModelUnits:               schema.ForceInt(),
+	MaxModelStorage:             schema.ForceInt(),
+	APIPort:                     schema.ForceInt(),
+	APIPortOpenDelay:            schema.String(),
+	ControllerAPIPort:           schema.ForceInt(),
+	StatePort:                   schema.ForceInt(),
+	IdentityURL:                 schema.String(),
+	IdentityPublicKey:           schema.String(),
+	SetNUMAControlPolicyKey:     schema.Bool(),
+	AutocertURLKey:              schema.String(),
+	AutocertDNSNameKey:          schema.String(),
+	AllowModelAccessKey:         schema.Bool(),
+	MongoMemoryProfile:          schema.String(),
+	MaxLogsAge:                  schema.String(),
+	MaxLogsSize:                 schema.String(),
+	MaxTxnLogSize:               schema.String(),
+	MaxPruneTxnBatchSize:        schema.ForceInt(),
+	MaxPruneTxnPasses:           schema.ForceInt(),
+	PruneTxnQueryCount:          schema.ForceInt(),
+	PruneTxnSleepTime:           schema.String(),
+	JujuHASpace:                 schema.String(),
+	JujuDBSpace:                 schema.String(),
+	JujuHAMemberOptions:         schema.String(),
+	JujuHARemovalTimeout:        schema.String(),
+	JujuManagementSpace:         schema.String(),
+	CAASOperatorImagePath:       schema.String(),
+	CAASImageRepo:               schema.String(),
+	Features:                    schema.List(schema.String()),
+	CharmStoreURL:               schema.String(),
+	CharmStoreMirror:            schema.String(),
+	CharmTrustKeyring:           schema.String(),
+	RequireSignedCharms:         schema.Bool(),
+	MeteringURL:                 schema.String(),
+	ResourceScannerURL:          schema.String(),
+	ResourceScanPolicy:          schema.String(),
+	AdmissionPolicyURL:          schema.String(),
+	AdmissionFailurePolicy:      schema.String(),
+	MongoWriteConcern:           schema.String(),
+	MongoReadPreference:         schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
-	ControllerAPIPort:       schema.Omit,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      DefaultMongoMemoryProfile,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:    DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
-	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	CAASImageRepo:           schema.Omit,
-	Features:                schema.Omit,
-	CharmStoreURL:           csclient.ServerURL,
-	MeteringURL:             romulus.DefaultAPIRoot,
+	APIPort:                     DefaultAPIPort,
+	APIPortOpenDelay:            DefaultAPIPortOpenDelay,
+	ControllerAPIPort:           schema.Omit,
+	AuditingEnabled:             DefaultAuditingEnabled,
+	AuditLogCaptureArgs:         DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:             fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:          DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:      DefaultAuditLogExcludeMethods,
+	RequestLogging:              DefaultRequestLogging,
+	RequestLogSamplePercent:     DefaultRequestLogSamplePercent,
+	AgentLogSinkRateLimitBurst:  schema.Omit,
+	AgentLogSinkRateLimitRefill: schema.Omit,
+	WatcherMaxPendingEvents:     schema.Omit,
+	MaxModelMachines:            schema.Omit,
+	MaxModelUnits:               schema.Omit,
+	MaxModelStorage:             schema.Omit,
+	StatePort:                   DefaultStatePort,
+	IdentityURL:                 schema.Omit,
+	IdentityPublicKey:           schema.Omit,
+	SetNUMAControlPolicyKey:     DefaultNUMAControlPolicy,
+	AutocertURLKey:              schema.Omit,
+	AutocertDNSNameKey:          schema.Omit,
+	AllowModelAccessKey:         schema.Omit,
+	MongoMemoryProfile:          DefaultMongoMemoryProfile,
+	MaxLogsAge:                  fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:                 fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:               fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:        DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:           DefaultMaxPruneTxnPasses,
+	PruneTxnQueryCount:          DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:           DefaultPruneTxnSleepTime,
+	JujuHASpace:                 schema.Omit,
+	JujuDBSpace:                 schema.Omit,
+	JujuHAMemberOptions:         schema.Omit,
+	JujuHARemovalTimeout:        schema.Omit,
+	JujuManagementSpace:         schema.Omit,
+	CAASOperatorImagePath:       schema.Omit,
+	CAASImageRepo:               schema.Omit,
+	Features:                    schema.Omit,
+	CharmStoreURL:               csclient.ServerURL,
+	CharmStoreMirror:            schema.Omit,
+	CharmTrustKeyring:           schema.Omit,
+	RequireSignedCharms:         schema.Omit,
+	MeteringURL:                 romulus.DefaultAPIRoot,
+	ResourceScannerURL:          schema.Omit,
+	ResourceScanPolicy:          schema.Omit,
+	AdmissionPolicyURL:          schema.Omit,
+	AdmissionFailurePolicy:      schema.Omit,
+	MongoWriteConcern:           schema.Omit,
+	MongoReadPreference:         schema.Omit,
 })
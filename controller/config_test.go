@@ -18,6 +18,9 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/admission"
+	"github.com/juju/juju/core/resources"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/testing"
 )
 
@@ -145,6 +148,13 @@ var validateTests = []struct {
 		controller.JujuManagementSpace: "\n",
 	},
 	expectError: `juju mgmt space name "\\n" not valid`,
+}, {
+	about: "invalid DB space name - caps",
+	config: controller.Config{
+		controller.CACertKey:   testing.CACert,
+		controller.JujuDBSpace: "CAPS",
+	},
+	expectError: `juju DB space name "CAPS" not valid`,
 }, {
 	about: "invalid HA space name - number",
 	config: controller.Config{
@@ -268,6 +278,118 @@ var validateTests = []struct {
 		controller.PruneTxnSleepTime: "15",
 	},
 	expectError: `prune-txn-sleep-time must be a valid duration \(eg "10ms"\): time: missing unit in duration 15`,
+}, {
+	about: "charmstore-mirror relative path",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.CharmStoreMirror: "mirror/charms",
+	},
+	expectError: `charm store mirror "mirror/charms" not valid`,
+}, {
+	about: "charm-trust-keyring not a keyring",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.CharmTrustKeyring: "not a keyring",
+	},
+	expectError: `invalid charm trust keyring in configuration: .*`,
+}, {
+	about: "require-signed-charms without keyring",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.RequireSignedCharms: true,
+	},
+	expectError: `require-signed-charms requires charm-trust-keyring to be set`,
+}, {
+	about: "resource-scanner-url not http",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.ResourceScannerURL: "ftp://scanner.example.com",
+	},
+	expectError: `resource scanner url "ftp://scanner.example.com" not valid`,
+}, {
+	about: "resource-scan-policy not valid",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.ResourceScanPolicy: "ignore",
+	},
+	expectError: `image scan policy "ignore" not valid`,
+}, {
+	about: "admission-policy-url not http",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.AdmissionPolicyURL: "ftp://opa.example.com",
+	},
+	expectError: `admission policy url "ftp://opa.example.com" not valid`,
+}, {
+	about: "admission-failure-policy not valid",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.AdmissionFailurePolicy: "warn",
+	},
+	expectError: `admission failure policy "warn" not valid`,
+}, {
+	about: "mongo-write-concern not valid",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.MongoWriteConcern: "txns=0",
+	},
+	expectError: `invalid mongo write concern in configuration: collection "txns": mongo write concern "0", expected majority or a number of members not valid`,
+}, {
+	about: "mongo-write-concern missing collection",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.MongoWriteConcern: "majority",
+	},
+	expectError: `invalid mongo write concern in configuration: mongo collection setting "majority", expected <collection>=<value> not valid`,
+}, {
+	about: "juju-ha-member-options not valid",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.JujuHAMemberOptions: "2=low-priority,3=arbiter",
+	},
+	expectError: `invalid juju HA member options in configuration: controller node "3": member option "arbiter", expected low-priority, hidden or delayed:<duration> not valid`,
+}, {
+	about: "juju-ha-member-options delay not valid",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.JujuHAMemberOptions: "3=delayed:-1h",
+	},
+	expectError: `invalid juju HA member options in configuration: controller node "3": member delay "-1h" not valid`,
+}, {
+	about: "juju-ha-removal-timeout not a duration",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.JujuHARemovalTimeout: "soon",
+	},
+	expectError: `juju-ha-removal-timeout must be a valid duration \(eg "30m"\): time: invalid duration "?soon"?`,
+}, {
+	about: "juju-ha-removal-timeout negative",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.JujuHARemovalTimeout: "-5m",
+	},
+	expectError: `negative juju-ha-removal-timeout "-5m" not valid`,
+}, {
+	about: "mongo-read-preference not valid",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.MongoReadPreference: "statuseshistory=anywhere",
+	},
+	expectError: `invalid mongo read preference in configuration: collection "statuseshistory": mongo read preference "anywhere" not valid`,
+}, {
+	about: "mongo-read-preference repeated",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.MongoReadPreference: "*=primary-preferred,*=primary",
+	},
+	expectError: `invalid mongo read preference in configuration: repeated mongo collection setting for "\*" not valid`,
+}, {
+	about: "mongo-read-preference secondary for all collections",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.MongoReadPreference: "*=secondary",
+	},
+	expectError: `invalid mongo read preference in configuration: mongo read preference "secondary" for all collections not valid`,
 }, {
 	about: "mongo-memory-profile not valid",
 	config: controller.Config{
@@ -375,17 +497,20 @@ func (s *ConfigSuite) TestPruneTxnQueryCount(c *gc.C) {
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
+	dbSpace := "space3"
 
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
 		testing.CACert,
 		map[string]interface{}{
 			controller.JujuHASpace:         haSpace,
+			controller.JujuDBSpace:         dbSpace,
 			controller.JujuManagementSpace: managementSpace,
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.JujuHASpace(), gc.Equals, haSpace)
+	c.Assert(cfg.JujuDBSpace(), gc.Equals, dbSpace)
 	c.Assert(cfg.JujuManagementSpace(), gc.Equals, managementSpace)
 }
 
@@ -397,6 +522,7 @@ func (s *ConfigSuite) TestNetworkSpaceConfigDefaults(c *gc.C) {
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.JujuHASpace(), gc.Equals, "")
+	c.Assert(cfg.JujuDBSpace(), gc.Equals, "")
 	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "")
 }
 
@@ -454,6 +580,136 @@ func (s *ConfigSuite) TestAuditLogFloatBackupsLoadedDirectly(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 }
 
+func (s *ConfigSuite) TestRequestLogDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.RequestLogging(), gc.Equals, false)
+	c.Assert(cfg.RequestLogSamplePercent(), gc.Equals, 10)
+}
+
+func (s *ConfigSuite) TestRequestLogValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"request-logging":            true,
+			"request-log-sample-percent": 25.0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.RequestLogging(), gc.Equals, true)
+	c.Assert(cfg.RequestLogSamplePercent(), gc.Equals, 25)
+}
+
+func (s *ConfigSuite) TestRequestLogSamplePercentNotValid(c *gc.C) {
+	_, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"request-log-sample-percent": 101,
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `invalid request log sample percent: should be between 0 and 100, got 101`)
+}
+
+func (s *ConfigSuite) TestAgentLogSinkRateLimitDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLogSinkRateLimitBurst(), gc.Equals, int64(0))
+	c.Assert(cfg.AgentLogSinkRateLimitRefill(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestAgentLogSinkRateLimitValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"agent-logsink-ratelimit-burst":  500.0,
+			"agent-logsink-ratelimit-refill": "10ms",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentLogSinkRateLimitBurst(), gc.Equals, int64(500))
+	c.Assert(cfg.AgentLogSinkRateLimitRefill(), gc.Equals, 10*time.Millisecond)
+}
+
+func (s *ConfigSuite) TestWatcherMaxPendingEvents(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.WatcherMaxPendingEvents(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{"watcher-max-pending-events": 500.0},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.WatcherMaxPendingEvents(), gc.Equals, 500)
+
+	_, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{"watcher-max-pending-events": 0},
+	)
+	c.Assert(err, gc.ErrorMatches, `non-positive integer for watcher-max-pending-events not valid`)
+}
+
+func (s *ConfigSuite) TestAgentLogSinkRateLimitNotValid(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"agent-logsink-ratelimit-burst": 0},
+		err:   `non-positive integer for agent-logsink-ratelimit-burst not valid`,
+	}, {
+		attrs: map[string]interface{}{"agent-logsink-ratelimit-refill": "soon"},
+		err:   `agent-logsink-ratelimit-refill value "soon" must be a valid duration`,
+	}, {
+		attrs: map[string]interface{}{"agent-logsink-ratelimit-refill": "-1s"},
+		err:   `non-positive duration for agent-logsink-ratelimit-refill not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestModelQuotasDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxModelMachines(), gc.Equals, 0)
+	c.Assert(cfg.MaxModelUnits(), gc.Equals, 0)
+	c.Assert(cfg.MaxModelStorage(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestModelQuotasValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-model-machines": 10.0,
+			"max-model-units":    "20",
+			"max-model-storage":  30,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxModelMachines(), gc.Equals, 10)
+	c.Assert(cfg.MaxModelUnits(), gc.Equals, 20)
+	c.Assert(cfg.MaxModelStorage(), gc.Equals, 30)
+}
+
+func (s *ConfigSuite) TestModelQuotasNotValid(c *gc.C) {
+	for i, key := range []string{"max-model-machines", "max-model-units", "max-model-storage"} {
+		c.Logf("test %d", i)
+		_, err := controller.NewConfig(
+			testing.ControllerTag.Id(),
+			testing.CACert,
+			map[string]interface{}{key: -1},
+		)
+		c.Check(err, gc.ErrorMatches, "negative integer for "+key+" not valid")
+	}
+}
+
 func (s *ConfigSuite) TestConfigManagementSpaceAsConstraint(c *gc.C) {
 	managementSpace := "management-space"
 	cfg, err := controller.NewConfig(
@@ -476,6 +732,21 @@ func (s *ConfigSuite) TestConfigHASpaceAsConstraint(c *gc.C) {
 	c.Check(*cfg.AsSpaceConstraints(nil), gc.DeepEquals, []string{haSpace})
 }
 
+func (s *ConfigSuite) TestConfigDBSpaceAsConstraint(c *gc.C) {
+	haSpace := "ha-space"
+	dbSpace := "db-space"
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.JujuHASpace: haSpace,
+			controller.JujuDBSpace: dbSpace,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*cfg.AsSpaceConstraints(nil), gc.DeepEquals, []string{dbSpace, haSpace})
+}
+
 func (s *ConfigSuite) TestConfigAllSpacesAsMergedConstraints(c *gc.C) {
 	haSpace := "ha-space"
 	managementSpace := "management-space"
@@ -569,3 +840,165 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
+
+func (s *ConfigSuite) TestCharmStoreMirror(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.CharmStoreMirror(), gc.Equals, "")
+
+	for _, location := range []string{
+		"/srv/charm-mirror",
+		"file:///srv/charm-mirror",
+		"https://mirror.example.com/charms",
+	} {
+		cfg, err := controller.NewConfig(
+			testing.ControllerTag.Id(),
+			testing.CACert,
+			map[string]interface{}{
+				controller.CharmStoreMirror: location,
+			},
+		)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cfg.CharmStoreMirror(), gc.Equals, location)
+	}
+}
+
+func (s *ConfigSuite) TestCharmSigning(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.CharmTrustKeyring(), gc.Equals, "")
+	c.Check(cfg.RequireSignedCharms(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.CharmTrustKeyring:   sstesting.SignedMetadataPublicKey,
+			controller.RequireSignedCharms: true,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.CharmTrustKeyring(), gc.Equals, sstesting.SignedMetadataPublicKey)
+	c.Check(cfg.RequireSignedCharms(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestResourceScanPolicyDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ResourceScannerURL(), gc.Equals, "")
+	c.Check(cfg.ResourceScanPolicy(), gc.Equals, resources.ImageScanWarn)
+}
+
+func (s *ConfigSuite) TestResourceScanSettingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.ResourceScannerURL: "https://scanner.example.com/scan",
+			controller.ResourceScanPolicy: "block",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ResourceScannerURL(), gc.Equals, "https://scanner.example.com/scan")
+	c.Check(cfg.ResourceScanPolicy(), gc.Equals, resources.ImageScanBlock)
+}
+
+func (s *ConfigSuite) TestAdmissionPolicyDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AdmissionPolicyURL(), gc.Equals, "")
+	c.Check(cfg.AdmissionFailurePolicy(), gc.Equals, admission.FailurePolicyFail)
+}
+
+func (s *ConfigSuite) TestAdmissionPolicySettingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.AdmissionPolicyURL:     "https://opa.example.com/v1/data/juju/admission",
+			controller.AdmissionFailurePolicy: "ignore",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AdmissionPolicyURL(), gc.Equals, "https://opa.example.com/v1/data/juju/admission")
+	c.Check(cfg.AdmissionFailurePolicy(), gc.Equals, admission.FailurePolicyIgnore)
+}
+
+func (s *ConfigSuite) TestMongoSessionSettingsDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MongoWriteConcerns(), gc.HasLen, 0)
+	c.Check(cfg.MongoReadPreferences(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestMongoSessionSettingsValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.MongoWriteConcern:   "txns=majority:journal, statuseshistory=1, *=2",
+			controller.MongoReadPreference: "statuseshistory=secondary-preferred",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MongoWriteConcerns(), jc.DeepEquals, map[string]controller.MongoWriteConcern{
+		"txns":            {Majority: true, Journal: true},
+		"statuseshistory": {W: 1},
+		"*":               {W: 2},
+	})
+	c.Check(cfg.MongoReadPreferences(), jc.DeepEquals, map[string]controller.MongoReadPreference{
+		"statuseshistory": controller.MongoReadSecondaryPreferred,
+	})
+}
+
+func (s *ConfigSuite) TestJujuHAMemberOptions(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.JujuHAMemberOptions: "1=low-priority, 2=hidden, 3=delayed:1h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.JujuHAMemberOptions(), jc.DeepEquals, map[string]controller.HAMemberOptions{
+		"1": {LowPriority: true},
+		"2": {Hidden: true},
+		"3": {Hidden: true, Delay: time.Hour},
+	})
+}
+
+func (s *ConfigSuite) TestJujuHARemovalTimeout(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.JujuHARemovalTimeout(), gc.Equals, time.Duration(0))
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.JujuHARemovalTimeout: "30m",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.JujuHARemovalTimeout(), gc.Equals, 30*time.Minute)
+}
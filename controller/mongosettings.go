@@ -0,0 +1,244 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// MongoAllCollections is the collection name used in the mongo write
+// concern and read preference settings to give the setting for all
+// collections that are not named explicitly.
+const MongoAllCollections = "*"
+
+// MongoWriteConcern describes the acknowledgement required of writes
+// to a mongo collection.
+type MongoWriteConcern struct {
+	// Majority requires writes to be acknowledged by a majority
+	// of the replica set members.
+	Majority bool
+
+	// W is the number of replica set members that must acknowledge
+	// writes, if Majority is false.
+	W int
+
+	// Journal requires writes to be committed to the journal
+	// before they are acknowledged.
+	Journal bool
+}
+
+// String returns the write concern in the form used in
+// controller config.
+func (w MongoWriteConcern) String() string {
+	s := strconv.Itoa(w.W)
+	if w.Majority {
+		s = "majority"
+	}
+	if w.Journal {
+		s += ":journal"
+	}
+	return s
+}
+
+// parseMongoWriteConcern parses a write concern of the form
+// <majority|n>[:journal].
+func parseMongoWriteConcern(value string) (MongoWriteConcern, error) {
+	var result MongoWriteConcern
+	parts := strings.Split(value, ":")
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] != "journal") {
+		return result, errors.NotValidf("mongo write concern %q", value)
+	}
+	result.Journal = len(parts) == 2
+	if parts[0] == "majority" {
+		result.Majority = true
+		return result, nil
+	}
+	w, err := strconv.Atoi(parts[0])
+	if err != nil || w < 1 {
+		return result, errors.NotValidf("mongo write concern %q, expected majority or a number of members", value)
+	}
+	result.W = w
+	return result, nil
+}
+
+// MongoReadPreference describes which replica set members
+// reads from a mongo collection are sent to.
+type MongoReadPreference string
+
+const (
+	MongoReadPrimary            MongoReadPreference = "primary"
+	MongoReadPrimaryPreferred   MongoReadPreference = "primary-preferred"
+	MongoReadSecondary          MongoReadPreference = "secondary"
+	MongoReadSecondaryPreferred MongoReadPreference = "secondary-preferred"
+	MongoReadNearest            MongoReadPreference = "nearest"
+)
+
+// Validate returns an error if the read preference is not recognised.
+func (p MongoReadPreference) Validate() error {
+	switch p {
+	case MongoReadPrimary, MongoReadPrimaryPreferred, MongoReadSecondary,
+		MongoReadSecondaryPreferred, MongoReadNearest:
+		return nil
+	}
+	return errors.NotValidf("mongo read preference %q", string(p))
+}
+
+// ReadsFromSecondaries reports whether reads with the preference
+// may be served by secondary members of the replica set, and so
+// may not reflect the most recent writes.
+func (p MongoReadPreference) ReadsFromSecondaries() bool {
+	switch p {
+	case MongoReadSecondary, MongoReadSecondaryPreferred, MongoReadNearest:
+		return true
+	}
+	return false
+}
+
+// ParseMongoWriteConcerns parses the value of the mongo-write-concern
+// setting, a comma separated list of <collection>=<write concern>
+// pairs such as "txns=majority:journal,statuseshistory=1", and returns
+// the write concerns keyed by collection.
+func ParseMongoWriteConcerns(value string) (map[string]MongoWriteConcern, error) {
+	result := make(map[string]MongoWriteConcern)
+	err := parseMongoSettings(value, "collection", func(collection, setting string) error {
+		concern, err := parseMongoWriteConcern(setting)
+		if err != nil {
+			return errors.Annotatef(err, "collection %q", collection)
+		}
+		result[collection] = concern
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ParseMongoReadPreferences parses the value of the mongo-read-preference
+// setting, a comma separated list of <collection>=<read preference>
+// pairs such as "statuseshistory=secondary-preferred", and returns the
+// read preferences keyed by collection. Reads from all collections can
+// not be sent to secondaries, since most are written by transactions.
+func ParseMongoReadPreferences(value string) (map[string]MongoReadPreference, error) {
+	result := make(map[string]MongoReadPreference)
+	err := parseMongoSettings(value, "collection", func(collection, setting string) error {
+		preference := MongoReadPreference(setting)
+		if err := preference.Validate(); err != nil {
+			return errors.Annotatef(err, "collection %q", collection)
+		}
+		if collection == MongoAllCollections && preference.ReadsFromSecondaries() {
+			return errors.NotValidf("mongo read preference %q for all collections", setting)
+		}
+		result[collection] = preference
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// HAMemberOptions describes how a controller node's member of the
+// mongo replica set differs from the default, which is a member that
+// votes and is as likely as any other to be elected primary.
+type HAMemberOptions struct {
+	// LowPriority makes the member less likely to be elected primary,
+	// while still allowing it to become primary if no other member
+	// can.
+	LowPriority bool
+
+	// Hidden makes the member invisible to clients, and prevents it
+	// from becoming primary. Hidden members still replicate data, and
+	// vote if the peergrouper has given them a vote.
+	Hidden bool
+
+	// Delay is how far the member's copy of the data is kept behind
+	// that of the primary. Delayed members are always hidden.
+	Delay time.Duration
+}
+
+const (
+	haMemberLowPriority = "low-priority"
+	haMemberHidden      = "hidden"
+	haMemberDelayed     = "delayed"
+)
+
+// parseHAMemberOptions parses member options of the form
+// low-priority, hidden or delayed:<duration>.
+func parseHAMemberOptions(value string) (HAMemberOptions, error) {
+	var result HAMemberOptions
+	parts := strings.SplitN(value, ":", 2)
+	switch {
+	case value == haMemberLowPriority:
+		result.LowPriority = true
+	case value == haMemberHidden:
+		result.Hidden = true
+	case parts[0] == haMemberDelayed && len(parts) == 2:
+		delay, err := time.ParseDuration(parts[1])
+		if err != nil || delay <= 0 {
+			return result, errors.NotValidf("member delay %q", parts[1])
+		}
+		result.Hidden = true
+		result.Delay = delay
+	default:
+		return result, errors.NotValidf(
+			"member option %q, expected %s, %s or %s:<duration>",
+			value, haMemberLowPriority, haMemberHidden, haMemberDelayed,
+		)
+	}
+	return result, nil
+}
+
+// ParseHAMemberOptions parses the value of the juju-ha-member-options
+// setting, a comma separated list of <node-id>=<option> pairs such as
+// "2=low-priority,3=delayed:1h", and returns the options keyed by
+// controller node ID.
+func ParseHAMemberOptions(value string) (map[string]HAMemberOptions, error) {
+	result := make(map[string]HAMemberOptions)
+	err := parseMongoSettings(value, "member", func(nodeId, setting string) error {
+		options, err := parseHAMemberOptions(setting)
+		if err != nil {
+			return errors.Annotatef(err, "controller node %q", nodeId)
+		}
+		result[nodeId] = options
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// parseMongoSettings parses a comma separated list of <key>=<value>
+// pairs, calling add for each of them. The kind of key, such as
+// "collection", is used in error messages.
+func parseMongoSettings(value, kind string, add func(key, setting string) error) error {
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return errors.NotValidf("mongo %s setting %q, expected <%s>=<value>", kind, item, kind)
+		}
+		key, setting := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if key == "" || setting == "" {
+			return errors.NotValidf("mongo %s setting %q, expected <%s>=<value>", kind, item, kind)
+		}
+		if seen[key] {
+			return errors.NotValidf("repeated mongo %s setting for %q", kind, key)
+		}
+		seen[key] = true
+		if err := add(key, setting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -11,6 +11,10 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuUpgradePreviewActionName defines the action name used to preview
+// the upgrade of a unit's charm.
+const JujuUpgradePreviewActionName = "juju-upgrade-preview"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: {
@@ -32,4 +36,19 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuUpgradePreviewActionName: {
+		Description: "predefined action that reports how a charm differs from the deployed charm",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuUpgradePreviewActionName,
+			"description": "predefined juju-upgrade-preview action params",
+			"required":    []interface{}{"charm-url"},
+			"properties": map[string]interface{}{
+				"charm-url": map[string]interface{}{
+					"type":        "string",
+					"description": "URL of a charm in the model to compare with the deployed charm",
+				},
+			},
+		},
+	},
 }
@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// restrictedNetworks holds the private and shared address ranges to
+// which action notifications may not be posted. Loopback, link-local
+// and unspecified addresses are checked separately.
+var restrictedNetworks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = ipNet
+	}
+	return nets
+}
+
+// IsRestrictedNotifyAddress reports whether action notifications may
+// not be posted to the given address, because it is a loopback,
+// link-local, private or unspecified address. Notifications are posted
+// by the controller, so such addresses would give users who can run
+// actions a way to reach services on the controller's own networks.
+func IsRestrictedNotifyAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, ipNet := range restrictedNetworks {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateNotifyURL returns an error if the URL may not be used to
+// notify the finishing of an action: it must be an absolute https URL
+// whose host is not a restricted address. Host names are checked
+// again, once resolved, when the notification is posted.
+func ValidateNotifyURL(notifyURL string) error {
+	u, err := url.Parse(notifyURL)
+	if err != nil {
+		return errors.NotValidf("notification URL %q", notifyURL)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" {
+		return errors.NotValidf("notification URL %q (expected an https URL)", notifyURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.NotValidf("notification URL %q (local addresses are not allowed)", notifyURL)
+	}
+	if ip := net.ParseIP(host); ip != nil && IsRestrictedNotifyAddress(ip) {
+		return errors.NotValidf("notification URL %q (local and private addresses are not allowed)", notifyURL)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type notifySuite struct{}
+
+var _ = gc.Suite(&notifySuite{})
+
+func (*notifySuite) TestValidateNotifyURL(c *gc.C) {
+	for _, test := range []struct {
+		url string
+		err string
+	}{{
+		url: "https://chat.example.com/hooks/juju",
+	}, {
+		url: "https://8.8.8.8:8443/hooks",
+	}, {
+		url: "http://chat.example.com/hooks/juju",
+		err: `notification URL "http://chat.example.com/hooks/juju" \(expected an https URL\) not valid`,
+	}, {
+		url: "ftp://chat.example.com/hooks/juju",
+		err: `notification URL "ftp://chat.example.com/hooks/juju" \(expected an https URL\) not valid`,
+	}, {
+		url: "/hooks/juju",
+		err: `notification URL "/hooks/juju" \(expected an https URL\) not valid`,
+	}, {
+		url: "https://localhost:17070/",
+		err: `notification URL "https://localhost:17070/" \(local addresses are not allowed\) not valid`,
+	}, {
+		url: "https://127.0.0.1:17070/",
+		err: `notification URL "https://127.0.0.1:17070/" \(local and private addresses are not allowed\) not valid`,
+	}, {
+		url: "https://169.254.169.254/latest/meta-data",
+		err: `notification URL "https://169.254.169.254/latest/meta-data" \(local and private addresses are not allowed\) not valid`,
+	}, {
+		url: "https://10.1.2.3/hooks",
+		err: `notification URL "https://10.1.2.3/hooks" \(local and private addresses are not allowed\) not valid`,
+	}, {
+		url: "https://[::1]/hooks",
+		err: `notification URL "https://\[::1\]/hooks" \(local and private addresses are not allowed\) not valid`,
+	}} {
+		c.Logf("%s", test.url)
+		err := actions.ValidateNotifyURL(test.url)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
+func (*notifySuite) TestIsRestrictedNotifyAddress(c *gc.C) {
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "0.0.0.0", "::", "169.254.1.1", "fe80::1",
+		"10.0.0.1", "172.16.5.4", "192.168.1.1", "100.64.0.1", "fd00::1",
+	} {
+		c.Check(actions.IsRestrictedNotifyAddress(net.ParseIP(addr)), jc.IsTrue, gc.Commentf("%s", addr))
+	}
+	for _, addr := range []string{"8.8.8.8", "172.32.0.1", "2001:4860:4860::8888"} {
+		c.Check(actions.IsRestrictedNotifyAddress(net.ParseIP(addr)), jc.IsFalse, gc.Commentf("%s", addr))
+	}
+}
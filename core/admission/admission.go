@@ -0,0 +1,248 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package admission provides deploy-time policy checks, allowing an
+// external policy engine such as Open Policy Agent to allow, deny or
+// mutate requests to change a model's applications, in the style of
+// Kubernetes admission webhooks.
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.core.admission")
+
+// Operation identifies the kind of request being admitted.
+type Operation string
+
+const (
+	// Deploy is the operation of deploying a new application.
+	Deploy Operation = "deploy"
+
+	// SetCharm is the operation of changing the charm
+	// of an existing application.
+	SetCharm Operation = "set-charm"
+
+	// Expose is the operation of exposing an application.
+	Expose Operation = "expose"
+
+	// Unexpose is the operation of unexposing an application.
+	Unexpose Operation = "unexpose"
+
+	// Update is the operation of updating an application's charm,
+	// config, constraints or minimum number of units together.
+	Update Operation = "update"
+
+	// SetConfig is the operation of changing an application's config.
+	SetConfig Operation = "set-config"
+
+	// UnsetConfig is the operation of resetting an application's
+	// config to its defaults.
+	UnsetConfig Operation = "unset-config"
+
+	// SetConstraints is the operation of changing an
+	// application's constraints.
+	SetConstraints Operation = "set-constraints"
+
+	// SetSeries is the operation of changing an application's series.
+	SetSeries Operation = "set-series"
+
+	// SetBindings is the operation of changing the spaces
+	// to which an application's endpoints are bound.
+	SetBindings Operation = "set-bindings"
+
+	// SetMetricCredentials is the operation of changing
+	// an application's metric credentials.
+	SetMetricCredentials Operation = "set-metric-credentials"
+
+	// AddUnits is the operation of adding units to an application.
+	AddUnits Operation = "add-units"
+
+	// Scale is the operation of scaling a CAAS application.
+	Scale Operation = "scale"
+
+	// RemoveUnit is the operation of removing a unit.
+	RemoveUnit Operation = "remove-unit"
+
+	// Resolve is the operation of marking a unit's error resolved.
+	Resolve Operation = "resolve"
+
+	// RemoveApplication is the operation of removing an application,
+	// local or consumed.
+	RemoveApplication Operation = "remove-application"
+
+	// AddRelation is the operation of relating applications.
+	AddRelation Operation = "add-relation"
+
+	// RemoveRelation is the operation of removing a relation.
+	RemoveRelation Operation = "remove-relation"
+
+	// SuspendRelation is the operation of suspending or
+	// resuming a cross model relation.
+	SuspendRelation Operation = "suspend-relation"
+
+	// Consume is the operation of adding an offered
+	// application to the model.
+	Consume Operation = "consume"
+)
+
+// FailurePolicy determines how a request is treated when the
+// policy endpoint cannot be consulted.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail rejects requests that could not be checked.
+	FailurePolicyFail FailurePolicy = "fail"
+
+	// FailurePolicyIgnore logs the failure and allows requests
+	// that could not be checked.
+	FailurePolicyIgnore FailurePolicy = "ignore"
+)
+
+// Validate returns an error if the policy is not recognised.
+func (p FailurePolicy) Validate() error {
+	switch p {
+	case FailurePolicyFail, FailurePolicyIgnore:
+		return nil
+	}
+	return errors.NotValidf("admission failure policy %q", p)
+}
+
+// Request holds the details of a request submitted for admission.
+type Request struct {
+	Operation   Operation         `json:"operation"`
+	ModelUUID   string            `json:"model-uuid"`
+	ModelName   string            `json:"model-name"`
+	User        string            `json:"user"`
+	Application string            `json:"application"`
+	CharmURL    string            `json:"charm-url,omitempty"`
+	Channel     string            `json:"channel,omitempty"`
+	Series      string            `json:"series,omitempty"`
+	NumUnits    int               `json:"num-units,omitempty"`
+	ScaleChange int               `json:"scale-change,omitempty"`
+	Config      map[string]string `json:"config,omitempty"`
+	ConfigYAML  string            `json:"config-yaml,omitempty"`
+	Constraints string            `json:"constraints,omitempty"`
+	Placement   []string          `json:"placement,omitempty"`
+	Units       []string          `json:"units,omitempty"`
+	Endpoints   []string          `json:"endpoints,omitempty"`
+	Keys        []string          `json:"keys,omitempty"`
+	Force       bool              `json:"force,omitempty"`
+}
+
+// Decision holds the outcome of checking a request against policy.
+type Decision struct {
+	// Allowed reports whether the request may proceed.
+	Allowed bool `json:"allowed"`
+
+	// Reasons holds the policy's explanation of the decision,
+	// and is reported to the user when a request is denied.
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Config holds charm config values that the policy requires,
+	// overriding those in the request.
+	Config map[string]string `json:"config,omitempty"`
+
+	// Constraints, if set, replaces the constraints in the request.
+	// It is only applied to operations which set constraints.
+	Constraints *string `json:"constraints,omitempty"`
+}
+
+// Mutated reports whether the policy changed the request.
+func (d Decision) Mutated() bool {
+	return len(d.Config) > 0 || d.Constraints != nil
+}
+
+// Err returns a Forbidden error describing why the request was
+// denied, or nil if it was allowed.
+func (d Decision) Err(op Operation) error {
+	if d.Allowed {
+		return nil
+	}
+	reason := "no reason given"
+	if len(d.Reasons) > 0 {
+		reason = strings.Join(d.Reasons, "; ")
+	}
+	return errors.Forbiddenf("%s denied by admission policy: %s", op, reason)
+}
+
+// Checker checks requests against policy.
+type Checker interface {
+	// Check returns the policy's decision for the request.
+	Check(Request) (Decision, error)
+}
+
+// webhookRequest is the body posted to a policy endpoint. It follows
+// the Open Policy Agent data API, so that a rule such as
+// data.juju.admission can be queried directly.
+type webhookRequest struct {
+	Input Request `json:"input"`
+}
+
+// webhookResponse is the body returned by a policy endpoint.
+type webhookResponse struct {
+	Result *Decision `json:"result"`
+}
+
+type webhookChecker struct {
+	url           string
+	failurePolicy FailurePolicy
+	client        *http.Client
+}
+
+// NewWebhookChecker returns a Checker that posts each request to the
+// given URL as {"input": request}, and expects a JSON response of the
+// form {"result": decision}. If the endpoint cannot be consulted, the
+// request is denied or allowed according to the failure policy.
+func NewWebhookChecker(url string, failurePolicy FailurePolicy, client *http.Client) Checker {
+	return &webhookChecker{
+		url:           url,
+		failurePolicy: failurePolicy,
+		client:        client,
+	}
+}
+
+// Check is part of the Checker interface.
+func (w *webhookChecker) Check(req Request) (Decision, error) {
+	decision, err := w.check(req)
+	if err == nil {
+		return decision, nil
+	}
+	if w.failurePolicy == FailurePolicyIgnore {
+		logger.Warningf("allowing %s of %q: %v", req.Operation, req.Application, err)
+		return Decision{Allowed: true}, nil
+	}
+	return Decision{}, errors.Trace(err)
+}
+
+func (w *webhookChecker) check(req Request) (Decision, error) {
+	body, err := json.Marshal(webhookRequest{Input: req})
+	if err != nil {
+		return Decision{}, errors.Trace(err)
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, errors.Annotate(err, "contacting admission policy endpoint")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Decision{}, errors.Errorf("admission policy endpoint returned %s", resp.Status)
+	}
+	var result webhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Decision{}, errors.Annotate(err, "decoding admission policy response")
+	}
+	if result.Result == nil {
+		// OPA omits the result when the queried rule is undefined,
+		// which usually means the policy has not been loaded.
+		return Decision{}, errors.New("admission policy returned no result")
+	}
+	return *result.Result, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package admission_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/admission"
+)
+
+type AdmissionSuite struct{}
+
+var _ = gc.Suite(&AdmissionSuite{})
+
+func (s *AdmissionSuite) TestFailurePolicyValidate(c *gc.C) {
+	c.Assert(admission.FailurePolicyFail.Validate(), jc.ErrorIsNil)
+	c.Assert(admission.FailurePolicyIgnore.Validate(), jc.ErrorIsNil)
+	c.Assert(admission.FailurePolicy("warn").Validate(), gc.ErrorMatches, `admission failure policy "warn" not valid`)
+}
+
+func (s *AdmissionSuite) TestDecisionErr(c *gc.C) {
+	c.Assert(admission.Decision{Allowed: true}.Err(admission.Deploy), jc.ErrorIsNil)
+
+	err := admission.Decision{Reasons: []string{"no trust", "no latest"}}.Err(admission.Deploy)
+	c.Assert(err, gc.ErrorMatches, "deploy denied by admission policy: no trust; no latest")
+	c.Assert(errors.IsForbidden(err), jc.IsTrue)
+
+	err = admission.Decision{}.Err(admission.Expose)
+	c.Assert(err, gc.ErrorMatches, "expose denied by admission policy: no reason given")
+}
+
+func (s *AdmissionSuite) TestDecisionMutated(c *gc.C) {
+	cons := "mem=4G"
+	c.Assert(admission.Decision{Allowed: true}.Mutated(), jc.IsFalse)
+	c.Assert(admission.Decision{Config: map[string]string{"a": "b"}}.Mutated(), jc.IsTrue)
+	c.Assert(admission.Decision{Constraints: &cons}.Mutated(), jc.IsTrue)
+}
+
+func (s *AdmissionSuite) TestWebhookChecker(c *gc.C) {
+	var received map[string]admission.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(json.NewDecoder(req.Body).Decode(&received), jc.ErrorIsNil)
+		w.Write([]byte(`{"result": {"allowed": true, "config": {"debug": "false"}, "constraints": "mem=4G"}}`))
+	}))
+	defer server.Close()
+
+	checker := admission.NewWebhookChecker(server.URL, admission.FailurePolicyFail, http.DefaultClient)
+	req := admission.Request{
+		Operation:   admission.Deploy,
+		ModelUUID:   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:   "prod",
+		User:        "user-fred",
+		Application: "mysql",
+		CharmURL:    "cs:mysql-1",
+		Config:      map[string]string{"debug": "true"},
+	}
+	decision, err := checker.Check(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, map[string]admission.Request{"input": req})
+	cons := "mem=4G"
+	c.Assert(decision, jc.DeepEquals, admission.Decision{
+		Allowed:     true,
+		Config:      map[string]string{"debug": "false"},
+		Constraints: &cons,
+	})
+}
+
+func (s *AdmissionSuite) TestWebhookCheckerDenied(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"result": {"allowed": false, "reasons": ["charm store charms only"]}}`))
+	}))
+	defer server.Close()
+
+	checker := admission.NewWebhookChecker(server.URL, admission.FailurePolicyFail, http.DefaultClient)
+	decision, err := checker.Check(admission.Request{Operation: admission.Deploy, Application: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, jc.DeepEquals, admission.Decision{
+		Reasons: []string{"charm store charms only"},
+	})
+}
+
+func (s *AdmissionSuite) TestWebhookCheckerNoResult(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	checker := admission.NewWebhookChecker(server.URL, admission.FailurePolicyFail, http.DefaultClient)
+	_, err := checker.Check(admission.Request{Operation: admission.Deploy, Application: "mysql"})
+	c.Assert(err, gc.ErrorMatches, "admission policy returned no result")
+}
+
+func (s *AdmissionSuite) TestWebhookCheckerError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	checker := admission.NewWebhookChecker(server.URL, admission.FailurePolicyFail, http.DefaultClient)
+	_, err := checker.Check(admission.Request{Operation: admission.Deploy, Application: "mysql"})
+	c.Assert(err, gc.ErrorMatches, "admission policy endpoint returned 503 Service Unavailable")
+}
+
+func (s *AdmissionSuite) TestWebhookCheckerErrorIgnored(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	checker := admission.NewWebhookChecker(server.URL, admission.FailurePolicyIgnore, http.DefaultClient)
+	decision, err := checker.Check(admission.Request{Operation: admission.Deploy, Application: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision, jc.DeepEquals, admission.Decision{Allowed: true})
+}
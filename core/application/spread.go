@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+)
+
+const (
+	// SpreadConfigOptionName is the option name used to set how an
+	// application's units are spread in application configuration.
+	SpreadConfigOptionName = "spread"
+
+	// SpreadPolicyConfigOptionName is the option name used to set what
+	// happens when an application's units cannot be spread as requested.
+	SpreadPolicyConfigOptionName = "spread-policy"
+)
+
+// Spread describes how the units of an application are placed
+// relative to each other.
+type Spread string
+
+const (
+	// SpreadZones places the machines hosting an application's units
+	// in distinct availability zones.
+	SpreadZones Spread = "zones"
+
+	// SpreadHosts places an application's units on distinct hosts,
+	// so that no two units share a machine or containers on the
+	// same machine.
+	SpreadHosts Spread = "hosts"
+
+	// SpreadNone places an application's units without regard to
+	// where its other units are.
+	SpreadNone Spread = "none"
+)
+
+// DefaultSpread is the spread used when none is configured, which
+// matches how units have always been distributed.
+const DefaultSpread = SpreadZones
+
+// ParseSpread returns the spread with the given name.
+func ParseSpread(s string) (Spread, error) {
+	switch spread := Spread(s); spread {
+	case SpreadZones, SpreadHosts, SpreadNone:
+		return spread, nil
+	}
+	return "", errors.NotValidf("spread %q (expected zones, hosts or none)", s)
+}
+
+// SpreadPolicy describes what happens when an application's units
+// cannot be spread as requested.
+type SpreadPolicy string
+
+const (
+	// SpreadPolicyWarn places units anyway, logging a warning.
+	SpreadPolicyWarn SpreadPolicy = "warn"
+
+	// SpreadPolicyFail refuses to place units where they would not
+	// be spread as requested.
+	SpreadPolicyFail SpreadPolicy = "fail"
+)
+
+// DefaultSpreadPolicy is the spread policy used when none is configured.
+const DefaultSpreadPolicy = SpreadPolicyWarn
+
+// ParseSpreadPolicy returns the spread policy with the given name.
+func ParseSpreadPolicy(s string) (SpreadPolicy, error) {
+	switch policy := SpreadPolicy(s); policy {
+	case SpreadPolicyWarn, SpreadPolicyFail:
+		return policy, nil
+	}
+	return "", errors.NotValidf("spread policy %q (expected warn or fail)", s)
+}
+
+// SpreadFromConfig returns the spread and spread policy set in the given
+// application config, or the defaults where they are not set.
+func SpreadFromConfig(attrs ConfigAttributes) (Spread, SpreadPolicy, error) {
+	spread, err := ParseSpread(attrs.GetString(SpreadConfigOptionName, string(DefaultSpread)))
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	policy, err := ParseSpreadPolicy(attrs.GetString(SpreadPolicyConfigOptionName, string(DefaultSpreadPolicy)))
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return spread, policy, nil
+}
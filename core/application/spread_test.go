@@ -0,0 +1,55 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SpreadSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SpreadSuite{})
+
+func (s *SpreadSuite) TestParseSpread(c *gc.C) {
+	for _, name := range []string{"zones", "hosts", "none"} {
+		spread, err := application.ParseSpread(name)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(spread, gc.Equals, application.Spread(name))
+	}
+	_, err := application.ParseSpread("racks")
+	c.Assert(err, gc.ErrorMatches, `spread "racks" \(expected zones, hosts or none\) not valid`)
+}
+
+func (s *SpreadSuite) TestParseSpreadPolicy(c *gc.C) {
+	for _, name := range []string{"warn", "fail"} {
+		policy, err := application.ParseSpreadPolicy(name)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(policy, gc.Equals, application.SpreadPolicy(name))
+	}
+	_, err := application.ParseSpreadPolicy("ignore")
+	c.Assert(err, gc.ErrorMatches, `spread policy "ignore" \(expected warn or fail\) not valid`)
+}
+
+func (s *SpreadSuite) TestSpreadFromConfigDefaults(c *gc.C) {
+	spread, policy, err := application.SpreadFromConfig(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spread, gc.Equals, application.SpreadZones)
+	c.Assert(policy, gc.Equals, application.SpreadPolicyWarn)
+}
+
+func (s *SpreadSuite) TestSpreadFromConfig(c *gc.C) {
+	spread, policy, err := application.SpreadFromConfig(application.ConfigAttributes{
+		"spread":        "hosts",
+		"spread-policy": "fail",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spread, gc.Equals, application.SpreadHosts)
+	c.Assert(policy, gc.Equals, application.SpreadPolicyFail)
+}
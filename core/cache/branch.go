@@ -4,6 +4,7 @@
 package cache
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/pubsub"
 
 	"github.com/juju/juju/core/settings"
@@ -58,6 +59,16 @@ func (b *Branch) AppConfig(appName string) settings.ItemChanges {
 	return b.details.Config[appName]
 }
 
+// IsTracking returns true if the unit with the input
+// name, of the input application, is tracking the branch.
+func (b *Branch) IsTracking(appName, unitName string) bool {
+	units := b.details.AssignedUnits[appName]
+	if len(units) == 0 {
+		return false
+	}
+	return set.NewStrings(units...).Contains(unitName)
+}
+
 // Created returns a Unix timestamp indicating when this generation
 // was created.
 func (b *Branch) Created() int64 {
@@ -101,3 +112,21 @@ func (b *Branch) copy() Branch {
 	cb.details = cb.details.copy()
 	return cb
 }
+
+// applyBranchDeltas returns a copy of the input master config,
+// with the input branch-based deltas applied.
+func applyBranchDeltas(master map[string]interface{}, deltas settings.ItemChanges) map[string]interface{} {
+	cfg := copyDataMap(master)
+	if cfg == nil {
+		cfg = make(map[string]interface{})
+	}
+	for _, delta := range deltas {
+		switch {
+		case delta.IsAddition(), delta.IsModification():
+			cfg[delta.Key] = delta.NewValue
+		case delta.IsDeletion():
+			delete(cfg, delta.Key)
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/pubsub"
+
+	"github.com/juju/juju/core/model"
+)
+
+// branchTrackingWatcherConfig contains data required for a
+// BranchTrackingWatcher to operate.
+type branchTrackingWatcherConfig struct {
+	model charmConfigModel
+
+	unitName string
+	appName  string
+
+	// hub is the pub/sub hub on which the watcher will receive
+	// branch change and removal messages.
+	hub *pubsub.SimpleHub
+	// res is the cache resident responsible for creating this watcher.
+	res *Resident
+}
+
+// BranchTrackingWatcher notifies when the model branch tracked by a unit
+// changes. This occurs when the unit is assigned to a branch,
+// or when the branch that it is tracking is committed or aborted,
+// returning the unit to the master generation.
+type BranchTrackingWatcher struct {
+	*notifyWatcherBase
+
+	// initComplete is a channel that will be closed when the
+	// watcher is fully constructed and ready to handle events.
+	initComplete chan struct{}
+
+	unitName   string
+	appName    string
+	branchName string
+}
+
+func newBranchTrackingWatcher(cfg branchTrackingWatcherConfig) *BranchTrackingWatcher {
+	w := &BranchTrackingWatcher{
+		notifyWatcherBase: newNotifyWatcherBase(),
+		initComplete:      make(chan struct{}),
+		unitName:          cfg.unitName,
+		appName:           cfg.appName,
+		branchName:        model.GenerationMaster,
+	}
+
+	deregister := cfg.res.registerWorker(w)
+
+	multi := cfg.hub.NewMultiplexer()
+	multi.Add(branchChange, w.branchChanged)
+	multi.Add(modelBranchRemove, w.branchRemoved)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		multi.Unsubscribe()
+		deregister()
+		return nil
+	})
+
+	for _, b := range cfg.model.Branches() {
+		if b.IsTracking(w.appName, w.unitName) {
+			w.branchName = b.Name()
+			break
+		}
+	}
+	close(w.initComplete)
+	return w
+}
+
+// branchChanged is called when we receive a message to say that a branch
+// has been updated in the cache. If the watcher's unit is newly assigned
+// to the branch, a notification is sent.
+func (w *BranchTrackingWatcher) branchChanged(_ string, msg interface{}) {
+	if !w.waitInitOrDying() {
+		return
+	}
+
+	b, ok := msg.(Branch)
+	if !ok {
+		logger.Errorf("programming error; branch change message was not of expected type, Branch")
+		return
+	}
+
+	if w.branchName == b.Name() || !b.IsTracking(w.appName, w.unitName) {
+		return
+	}
+	w.branchName = b.Name()
+	w.notify()
+}
+
+// branchRemoved is called when we receive a message to say that a branch
+// has been removed from the cache. If the watcher's unit was tracking it,
+// the unit reverts to the master generation and a notification is sent.
+func (w *BranchTrackingWatcher) branchRemoved(_ string, msg interface{}) {
+	if !w.waitInitOrDying() {
+		return
+	}
+
+	name, ok := msg.(string)
+	if !ok {
+		logger.Errorf("programming error; branch deleted message was not of expected type, string")
+		return
+	}
+
+	if w.branchName != name {
+		return
+	}
+	w.branchName = model.GenerationMaster
+	w.notify()
+}
+
+// waitInitOrDying returns true when the watcher is fully initialised,
+// or false if it is dying.
+func (w *BranchTrackingWatcher) waitInitOrDying() bool {
+	select {
+	case <-w.initComplete:
+		return true
+	case <-w.tomb.Dying():
+		return false
+	}
+}
@@ -95,6 +95,16 @@ func (tc *TestController) UpdateMachine(c *gc.C, modelUUID string, machine *stat
 	tc.SendChange(MachineChange(c, modelUUID, machine))
 }
 
+// UpdateRelation updates the input state relation in the cache.
+func (tc *TestController) UpdateRelation(modelUUID string, relation *state.Relation) {
+	tc.SendChange(RelationChange(modelUUID, relation))
+}
+
+// UpdateOffer updates the named state application offer in the cache.
+func (tc *TestController) UpdateOffer(c *gc.C, st *state.State, offerName string) {
+	tc.SendChange(OfferChange(c, st, offerName))
+}
+
 func (tc *TestController) SendChange(change interface{}) {
 	tc.changes <- change
 }
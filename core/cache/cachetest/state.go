@@ -166,3 +166,51 @@ func UnitChange(c *gc.C, modelUUID string, unit *state.Unit) cache.UnitChange {
 		// TODO: Subordinate
 	}
 }
+
+// RelationChange returns a RelationChange representing the input state relation.
+func RelationChange(modelUUID string, relation *state.Relation) cache.RelationChange {
+	eps := relation.Endpoints()
+	endpoints := make([]cache.RelationEndpoint, len(eps))
+	for i, ep := range eps {
+		endpoints[i] = cache.RelationEndpoint{
+			Application: ep.ApplicationName,
+			Name:        ep.Name,
+			Role:        string(ep.Role),
+			Interface:   ep.Interface,
+			Optional:    ep.Optional,
+			Limit:       ep.Limit,
+			Scope:       string(ep.Scope),
+		}
+	}
+
+	return cache.RelationChange{
+		ModelUUID: modelUUID,
+		Key:       relation.String(),
+		Id:        relation.Id(),
+		Endpoints: endpoints,
+	}
+}
+
+// OfferChange returns an OfferChange representing
+// the state application offer with the input name.
+func OfferChange(c *gc.C, st *state.State, offerName string) cache.OfferChange {
+	offer, err := state.NewApplicationOffers(st).ApplicationOffer(offerName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := st.Application(offer.ApplicationName)
+	c.Assert(err, jc.ErrorIsNil)
+	cURL, _ := app.CharmURL()
+
+	conn, err := st.RemoteConnectionStatus(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return cache.OfferChange{
+		ModelUUID:            st.ModelUUID(),
+		OfferUUID:            offer.OfferUUID,
+		OfferName:            offer.OfferName,
+		ApplicationName:      offer.ApplicationName,
+		CharmName:            cURL.Name,
+		TotalConnectedCount:  conn.TotalConnectionCount(),
+		ActiveConnectedCount: conn.ActiveConnectionCount(),
+	}
+}
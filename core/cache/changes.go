@@ -26,6 +26,14 @@ type ModelChange struct {
 	Status    status.StatusInfo
 }
 
+// copy returns a deep copy of the ModelChange.
+func (m ModelChange) copy() ModelChange {
+	m.Config = copyDataMap(m.Config)
+	m.Status = copyStatusInfo(m.Status)
+
+	return m
+}
+
 // RemoveModel represents the situation when a model is removed
 // from the database.
 type RemoveModel struct {
@@ -134,6 +142,7 @@ type UnitChange struct {
 	Subordinate    bool
 	WorkloadStatus status.StatusInfo
 	AgentStatus    status.StatusInfo
+	Labels         map[string]string
 }
 
 // copy returns a deep copy of the UnitChange.
@@ -160,6 +169,7 @@ func (u UnitChange) copy() UnitChange {
 
 	u.WorkloadStatus = copyStatusInfo(u.WorkloadStatus)
 	u.AgentStatus = copyStatusInfo(u.AgentStatus)
+	u.Labels = copyStringMap(u.Labels)
 
 	return u
 }
@@ -190,6 +200,7 @@ type MachineChange struct {
 	Addresses                []network.Address
 	HasVote                  bool
 	WantsVote                bool
+	Labels                   map[string]string
 }
 
 // copy returns a deep copy of the MachineChange.
@@ -231,6 +242,8 @@ func (m MachineChange) copy() MachineChange {
 	}
 	m.Addresses = cAddresses
 
+	m.Labels = copyStringMap(m.Labels)
+
 	return m
 }
 
@@ -305,6 +318,63 @@ type RemoveBranch struct {
 	Id        string
 }
 
+// RelationChange represents either a new relation, or a change
+// to an existing relation in a model.
+type RelationChange struct {
+	ModelUUID string
+	Key       string
+	Id        int
+	Endpoints []RelationEndpoint
+}
+
+// RelationEndpoint describes one end of a relation.
+type RelationEndpoint struct {
+	Application string
+	Name        string
+	Role        string
+	Interface   string
+	Optional    bool
+	Limit       int
+	Scope       string
+}
+
+func (r RelationChange) copy() RelationChange {
+	var cEndpoints []RelationEndpoint
+	if r.Endpoints != nil {
+		cEndpoints = make([]RelationEndpoint, len(r.Endpoints))
+		copy(cEndpoints, r.Endpoints)
+	}
+	r.Endpoints = cEndpoints
+
+	return r
+}
+
+// RemoveRelation represents the situation when a relation
+// is removed from a model in the database.
+type RemoveRelation struct {
+	ModelUUID string
+	Key       string
+}
+
+// OfferChange represents either a new application offer,
+// or a change to an existing offer in a model.
+type OfferChange struct {
+	ModelUUID            string
+	OfferUUID            string
+	OfferName            string
+	ApplicationName      string
+	CharmName            string
+	TotalConnectedCount  int
+	ActiveConnectedCount int
+}
+
+// RemoveOffer represents the situation when an application
+// offer is removed from a model in the database.
+type RemoveOffer struct {
+	ModelUUID string
+	OfferName string
+}
+
 func copyStatusInfo(info status.StatusInfo) status.StatusInfo {
 	var cSince *time.Time
 	if info.Since != nil {
@@ -320,6 +390,17 @@ func copyStatusInfo(info status.StatusInfo) status.StatusInfo {
 	}
 }
 
+func copyStringMap(data map[string]string) map[string]string {
+	var cData map[string]string
+	if data != nil {
+		cData = make(map[string]string, len(data))
+		for k, v := range data {
+			cData[k] = v
+		}
+	}
+	return cData
+}
+
 func copyDataMap(data map[string]interface{}) map[string]interface{} {
 	var cData map[string]interface{}
 	if data != nil {
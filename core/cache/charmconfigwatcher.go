@@ -4,7 +4,6 @@
 package cache
 
 import (
-	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 
@@ -213,11 +212,7 @@ func (w *CharmConfigWatcher) branchRemoved(topic string, msg interface{}) {
 
 // isTracking returns true if this watcher's unit is tracking the input branch.
 func (w *CharmConfigWatcher) isTracking(b Branch) bool {
-	units := b.AssignedUnits()[w.appName]
-	if len(units) == 0 {
-		return false
-	}
-	return set.NewStrings(units...).Contains(w.unitName)
+	return b.IsTracking(w.appName, w.unitName)
 }
 
 // checkConfig generates a new hash based on current effective configuration.
@@ -237,16 +232,7 @@ func (w *CharmConfigWatcher) checkConfig() {
 // Then compares a hash of the result with the last known config hash.
 // The boolean return indicates whether the has has changed.
 func (w *CharmConfigWatcher) setConfigHash() (bool, error) {
-	cfg := copyDataMap(w.masterSettings)
-	for _, delta := range w.branchDeltas {
-		switch {
-		case delta.IsAddition(), delta.IsModification():
-			cfg[delta.Key] = delta.NewValue
-		case delta.IsDeletion():
-			delete(cfg, delta.Key)
-		}
-	}
-
+	cfg := applyBranchDeltas(w.masterSettings, w.branchDeltas)
 	newHash, err := hash(cfg)
 	if err != nil {
 		return false, errors.Trace(err)
@@ -5,6 +5,7 @@ package cache
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -26,6 +27,13 @@ type ControllerConfig struct {
 	// called by the controller main processing loop after processing a change.
 	// The change processed is passed in as the arg to notify.
 	Notify func(interface{})
+
+	// Snapshot, if supplied, is used to populate the cache before
+	// any changes are processed. The residents restored from the
+	// snapshot are marked as stale until a change for them is
+	// received, so the first full set of changes brings them up to
+	// date, and the next sweep evicts any that no longer exist.
+	Snapshot *Snapshot
 }
 
 // Validate ensures the controller has the right values to be created.
@@ -73,6 +81,15 @@ func newController(config ControllerConfig, manager *residentManager) (*Controll
 		metrics: createControllerGauges(),
 	}
 
+	if config.Snapshot != nil {
+		for _, change := range config.Snapshot.Changes() {
+			if err := c.processChange(change); err != nil {
+				return nil, errors.Annotate(err, "restoring cache snapshot")
+			}
+		}
+		manager.mark()
+	}
+
 	manager.dying = c.tomb.Dying()
 	c.tomb.Go(c.loop)
 	return c, nil
@@ -84,34 +101,7 @@ func (c *Controller) loop() error {
 		case <-c.tomb.Dying():
 			return nil
 		case change := <-c.changes:
-			var err error
-
-			switch ch := change.(type) {
-			case ModelChange:
-				c.updateModel(ch)
-			case RemoveModel:
-				err = c.removeModel(ch)
-			case ApplicationChange:
-				c.updateApplication(ch)
-			case RemoveApplication:
-				err = c.removeApplication(ch)
-			case CharmChange:
-				c.updateCharm(ch)
-			case RemoveCharm:
-				err = c.removeCharm(ch)
-			case MachineChange:
-				c.updateMachine(ch)
-			case RemoveMachine:
-				err = c.removeMachine(ch)
-			case UnitChange:
-				c.updateUnit(ch)
-			case RemoveUnit:
-				err = c.removeUnit(ch)
-			case BranchChange:
-				c.updateBranch(ch)
-			case RemoveBranch:
-				err = c.removeBranch(ch)
-			}
+			err := c.processChange(change)
 			if c.notify != nil {
 				c.notify(change)
 			}
@@ -123,6 +113,47 @@ func (c *Controller) loop() error {
 	}
 }
 
+// processChange applies the input change to the cache.
+func (c *Controller) processChange(change interface{}) error {
+	var err error
+
+	switch ch := change.(type) {
+	case ModelChange:
+		c.updateModel(ch)
+	case RemoveModel:
+		err = c.removeModel(ch)
+	case ApplicationChange:
+		c.updateApplication(ch)
+	case RemoveApplication:
+		err = c.removeApplication(ch)
+	case CharmChange:
+		c.updateCharm(ch)
+	case RemoveCharm:
+		err = c.removeCharm(ch)
+	case MachineChange:
+		c.updateMachine(ch)
+	case RemoveMachine:
+		err = c.removeMachine(ch)
+	case UnitChange:
+		c.updateUnit(ch)
+	case RemoveUnit:
+		err = c.removeUnit(ch)
+	case BranchChange:
+		c.updateBranch(ch)
+	case RemoveBranch:
+		err = c.removeBranch(ch)
+	case RelationChange:
+		c.updateRelation(ch)
+	case RemoveRelation:
+		err = c.removeRelation(ch)
+	case OfferChange:
+		c.updateOffer(ch)
+	case RemoveOffer:
+		err = c.removeOffer(ch)
+	}
+	return err
+}
+
 // Mark updates all cached entities to indicate they are stale.
 func (c *Controller) Mark() {
 	c.manager.mark()
@@ -150,6 +181,23 @@ func (c *Controller) Report() map[string]interface{} {
 	return result
 }
 
+// Snapshot returns a copy of the current contents of the cache,
+// suitable for supplying to a new controller via its config.
+func (c *Controller) Snapshot() Snapshot {
+	c.mu.Lock()
+	models := make([]*Model, 0, len(c.models))
+	for _, model := range c.models {
+		models = append(models, model)
+	}
+	c.mu.Unlock()
+
+	snapshot := Snapshot{Version: snapshotVersion}
+	for _, model := range models {
+		snapshot.Models = append(snapshot.Models, model.snapshot())
+	}
+	return snapshot
+}
+
 // ModelUUIDs returns the UUIDs of the models in the cache.
 func (c *Controller) ModelUUIDs() []string {
 	c.mu.Lock()
@@ -163,6 +211,27 @@ func (c *Controller) ModelUUIDs() []string {
 	return result
 }
 
+// allModels returns the models in the cache.
+func (c *Controller) allModels() []*Model {
+	c.mu.Lock()
+
+	result := make([]*Model, 0, len(c.models))
+	for _, model := range c.models {
+		result = append(result, model)
+	}
+
+	c.mu.Unlock()
+	return result
+}
+
+// ObserveChangeLag records the time between a batch of changes being
+// received from the database and the cache having applied them.
+// It is called by the supplier of the changes, as only it knows
+// when they were received.
+func (c *Controller) ObserveChangeLag(lag time.Duration) {
+	c.metrics.observeChangeLag(lag)
+}
+
 // Kill is part of the worker.Worker interface.
 func (c *Controller) Kill() {
 	c.tomb.Kill(nil)
@@ -177,9 +246,10 @@ func (c *Controller) Wait() error {
 // If the model isn't found, a NotFoundError is returned.
 func (c *Controller) Model(uuid string) (*Model, error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	model, found := c.models[uuid]
+	c.mu.Unlock()
+
+	c.metrics.lookup(modelKind, found)
 	if !found {
 		return nil, errors.NotFoundf("model %q", uuid)
 	}
@@ -255,6 +325,26 @@ func (c *Controller) removeBranch(ch RemoveBranch) error {
 	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeBranch(ch) }))
 }
 
+// updateRelation adds or updates the relation in the specified model.
+func (c *Controller) updateRelation(ch RelationChange) {
+	c.ensureModel(ch.ModelUUID).updateRelation(ch, c.manager)
+}
+
+// removeRelation removes the relation from the cached model.
+func (c *Controller) removeRelation(ch RemoveRelation) error {
+	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeRelation(ch) }))
+}
+
+// updateOffer adds or updates the application offer in the specified model.
+func (c *Controller) updateOffer(ch OfferChange) {
+	c.ensureModel(ch.ModelUUID).updateOffer(ch, c.manager)
+}
+
+// removeOffer removes the application offer from the cached model.
+func (c *Controller) removeOffer(ch RemoveOffer) error {
+	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeOffer(ch) }))
+}
+
 // removeResident uses the input removal function to remove a cache resident,
 // including cleaning up resources it was responsible for creating.
 // If the cache does not have the model loaded for the resident yet,
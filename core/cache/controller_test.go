@@ -3,6 +3,8 @@
 package cache_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 )
 
 type ControllerSuite struct {
@@ -57,6 +60,8 @@ func (s *ControllerSuite) TestAddModel(c *gc.C) {
 			"machine-count":     0,
 			"unit-count":        0,
 			"branch-count":      0,
+			"relation-count":    0,
+			"offer-count":       0,
 		}})
 
 	// The model has the first ID and is registered.
@@ -245,6 +250,131 @@ func (s *ControllerSuite) TestRemoveBranch(c *gc.C) {
 	s.AssertResident(c, branch.CacheId(), false)
 }
 
+func (s *ControllerSuite) TestAddRelation(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, relationChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["relation-count"], gc.Equals, 1)
+
+	relation, err := mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertResident(c, relation.CacheId(), true)
+}
+
+func (s *ControllerSuite) TestRemoveRelation(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, relationChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remove := cache.RemoveRelation{
+		ModelUUID: modelChange.ModelUUID,
+		Key:       relationChange.Key,
+	}
+	s.processChange(c, remove, events)
+
+	c.Check(mod.Report()["relation-count"], gc.Equals, 0)
+	s.AssertResident(c, relation.CacheId(), false)
+}
+
+func (s *ControllerSuite) TestAddOffer(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, offerChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["offer-count"], gc.Equals, 1)
+
+	offer, err := mod.Offer(offerChange.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertResident(c, offer.CacheId(), true)
+}
+
+func (s *ControllerSuite) TestRemoveOffer(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, offerChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := mod.Offer(offerChange.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remove := cache.RemoveOffer{
+		ModelUUID: modelChange.ModelUUID,
+		OfferName: offerChange.OfferName,
+	}
+	s.processChange(c, remove, events)
+
+	c.Check(mod.Report()["offer-count"], gc.Equals, 0)
+	s.AssertResident(c, offer.CacheId(), false)
+}
+
+func (s *ControllerSuite) TestModelSummary(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, appChange, events)
+	s.processChange(c, machineChange, events)
+	container := machineChange
+	container.Id = "0/lxd/0"
+	container.ContainerType = "lxd"
+	container.AgentStatus = status.StatusInfo{Status: status.Pending}
+	s.processChange(c, container, events)
+	s.processChange(c, unitChange, events)
+	blocked := unitChange
+	blocked.Name = "application-name/1"
+	blocked.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	s.processChange(c, blocked, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Summary(), jc.DeepEquals, cache.ModelSummary{
+		ApplicationCount: 1,
+		MachineCount:     1,
+		ContainerCount:   1,
+		UnitCount:        2,
+		UnitWorkloadStatus: map[status.Status]int{
+			status.Active:  1,
+			status.Blocked: 1,
+		},
+		UnitAgentStatus: map[status.Status]int{
+			status.Active: 2,
+		},
+		MachineAgentStatus: map[status.Status]int{
+			status.Active:  1,
+			status.Pending: 1,
+		},
+		ApplicationVersions: map[string]string{
+			"application-name": "666",
+		},
+	})
+
+	// Updates replace the previous contribution of the entity.
+	blocked.WorkloadStatus = status.StatusInfo{Status: status.Active}
+	s.processChange(c, blocked, events)
+	s.processChange(c, cache.RemoveMachine{
+		ModelUUID: container.ModelUUID,
+		Id:        container.Id,
+	}, events)
+	noVersion := appChange
+	noVersion.WorkloadVersion = ""
+	s.processChange(c, noVersion, events)
+
+	summary := mod.Summary()
+	c.Check(summary.ApplicationCount, gc.Equals, 1)
+	c.Check(summary.ContainerCount, gc.Equals, 0)
+	c.Check(summary.UnitWorkloadStatus, jc.DeepEquals, map[status.Status]int{
+		status.Active: 2,
+	})
+	c.Check(summary.MachineAgentStatus, jc.DeepEquals, map[status.Status]int{
+		status.Active: 1,
+	})
+	c.Check(summary.ApplicationVersions, gc.HasLen, 0)
+}
+
 func (s *ControllerSuite) TestMarkAndSweep(c *gc.C) {
 	controller, events := s.new(c)
 
@@ -280,6 +410,102 @@ func (s *ControllerSuite) TestMarkAndSweep(c *gc.C) {
 	s.AssertNoResidents(c)
 }
 
+func (s *ControllerSuite) TestSnapshotRoundTrip(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, charmChange, events)
+	s.processChange(c, appChange, events)
+	s.processChange(c, machineChange, events)
+	s.processChange(c, unitChange, events)
+	s.processChange(c, relationChange, events)
+	s.processChange(c, offerChange, events)
+	s.processChange(c, branchChange, events)
+
+	var buf bytes.Buffer
+	err := cache.WriteSnapshot(&buf, controller.Snapshot())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := cache.ReadSnapshot(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored, err := cache.NewController(cache.ControllerConfig{
+		Changes:  make(chan interface{}),
+		Snapshot: &snapshot,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, restored)
+
+	c.Check(restored.Report(), jc.DeepEquals, controller.Report())
+
+	mod, err := restored.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.IsStale(), jc.IsTrue)
+	c.Check(mod.Name(), gc.Equals, modelChange.Name)
+	c.Check(mod.Config(), jc.DeepEquals, modelChange.Config)
+
+	app, err := mod.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.Config(), jc.DeepEquals, appChange.Config)
+
+	_, err = mod.Unit(unitChange.Name)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Machine(machineChange.Id)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Charm(charmChange.CharmURL)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Relation(relationChange.Key)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Offer(offerChange.OfferName)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(mod.Branches(), gc.HasLen, 1)
+}
+
+func (s *ControllerSuite) TestSnapshotRestoredResidentsSwept(c *gc.C) {
+	s.Config.Snapshot = &cache.Snapshot{
+		Models: []cache.ModelSnapshot{{
+			Model:        modelChange,
+			Applications: []cache.ApplicationChange{appChange},
+		}},
+	}
+	controller, events := s.new(c)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The restored residents are stale until confirmed. Only the
+	// model is confirmed, so the restored application is evicted.
+	c.Check(mod.IsStale(), jc.IsTrue)
+	s.processChange(c, modelChange, events)
+	c.Check(mod.IsStale(), jc.IsFalse)
+
+	done := make(chan struct{})
+	go func() {
+		c.Check(s.nextChange(c, events), gc.FitsTypeOf, cache.RemoveApplication{})
+		close(done)
+	}()
+
+	controller.Sweep()
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timeout waiting for sweep removal messages")
+	}
+
+	_, err = mod.Application(appChange.Name)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerSuite) TestReadSnapshotVersionMismatch(c *gc.C) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(cache.Snapshot{Version: 0})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cache.ReadSnapshot(&buf)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, "cache snapshot version 0 not supported")
+}
+
 func (s *ControllerSuite) new(c *gc.C) (*cache.Controller, <-chan interface{}) {
 	events := s.captureEvents(c)
 	controller, err := s.NewController()
@@ -317,6 +543,14 @@ func (s *ControllerSuite) captureEvents(c *gc.C) <-chan interface{} {
 			send = true
 		case cache.RemoveBranch:
 			send = true
+		case cache.RelationChange:
+			send = true
+		case cache.RemoveRelation:
+			send = true
+		case cache.OfferChange:
+			send = true
+		case cache.RemoveOffer:
+			send = true
 		default:
 			// no-op
 		}
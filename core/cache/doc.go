@@ -9,4 +9,9 @@
 // Instances in the cache package also provide watchers. These watchers are
 // checking for changes in the in-memory representation and can be used to avoid
 // excess database reads.
+//
+// Models also accept subscriptions to their applications, machines and
+// units, selected by kind, application, status and labels. Subscribers
+// receive coalesced events for the entities that start, stop or continue
+// to match, rather than each running a watcher of their own.
 package cache
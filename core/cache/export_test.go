@@ -31,6 +31,14 @@ func (m *Model) RemoveBranch(details RemoveBranch) error {
 	return m.removeBranch(details)
 }
 
+func (m *Model) RemoveRelation(details RemoveRelation) error {
+	return m.removeRelation(details)
+}
+
+func (m *Model) RemoveOffer(details RemoveOffer) error {
+	return m.removeOffer(details)
+}
+
 // Expose Update* for testing.
 
 func (m *Model) UpdateMachine(details MachineChange, manager *residentManager) {
@@ -52,3 +60,11 @@ func (m *Model) UpdateCharm(details CharmChange, manager *residentManager) {
 func (m *Model) UpdateBranch(details BranchChange, manager *residentManager) {
 	m.updateBranch(details, manager)
 }
+
+func (m *Model) UpdateRelation(details RelationChange, manager *residentManager) {
+	m.updateRelation(details, manager)
+}
+
+func (m *Model) UpdateOffer(details OfferChange, manager *residentManager) {
+	m.updateOffer(details, manager)
+}
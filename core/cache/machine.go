@@ -70,6 +70,11 @@ func (m *Machine) Config() map[string]interface{} {
 	return m.details.Config
 }
 
+// Labels returns the freeform labels attached to the machine.
+func (m *Machine) Labels() map[string]string {
+	return m.details.Labels
+}
+
 // Units returns all the units that have been assigned to the machine
 // including subordinates.
 func (m *Machine) Units() ([]Unit, error) {
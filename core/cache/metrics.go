@@ -4,6 +4,8 @@
 package cache
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -20,6 +22,20 @@ const (
 	agentStatusLabel      = "agent_status"
 	instanceStatusLabel   = "instance_status"
 	workloadStatusLabel   = "workload_status"
+	kindLabel             = "kind"
+	modelUUIDLabel        = "model_uuid"
+)
+
+// Kinds of cached entity, used to label the lookup and entity metrics.
+const (
+	modelKind       = "model"
+	applicationKind = "application"
+	charmKind       = "charm"
+	machineKind     = "machine"
+	unitKind        = "unit"
+	relationKind    = "relation"
+	offerKind       = "offer"
+	branchKind      = "branch"
 )
 
 var (
@@ -44,6 +60,15 @@ var (
 		statusLabel,
 	}
 
+	lookupLabelNames = []string{
+		kindLabel,
+	}
+
+	entityLabelNames = []string{
+		modelUUIDLabel,
+		kindLabel,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -68,6 +93,11 @@ type ControllerGauges struct {
 	LXDProfileChangeError        prometheus.Gauge
 	LXDProfileChangeNotification prometheus.Gauge
 	LXDProfileNoChange           prometheus.Gauge
+
+	LookupHit  *prometheus.CounterVec
+	LookupMiss *prometheus.CounterVec
+
+	ChangeLag prometheus.Histogram
 }
 
 func createControllerGauges() *ControllerGauges {
@@ -135,9 +165,47 @@ func createControllerGauges() *ControllerGauges {
 				Help:      "The number of times an LXD Profile related change did not trigger a notification.",
 			},
 		),
+		LookupHit: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "lookup_hit_total",
+				Help:      "The number of times a requested entity was found in the cache.",
+			},
+			lookupLabelNames,
+		),
+		LookupMiss: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "lookup_miss_total",
+				Help:      "The number of times a requested entity was not found in the cache.",
+			},
+			lookupLabelNames,
+		),
+		ChangeLag: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "change_lag_seconds",
+				Help:      "The time between changes being received from the database watcher and being applied to the cache.",
+				Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+			},
+		),
 	}
 }
 
+// lookup records the result of a request for a cached entity.
+func (c *ControllerGauges) lookup(kind string, found bool) {
+	if found {
+		c.LookupHit.WithLabelValues(kind).Inc()
+	} else {
+		c.LookupMiss.WithLabelValues(kind).Inc()
+	}
+}
+
+// observeChangeLag records the time taken for a change to be applied.
+func (c *ControllerGauges) observeChangeLag(lag time.Duration) {
+	c.ChangeLag.Observe(lag.Seconds())
+}
+
 // Describe is part of the prometheus.Collector interface.
 func (c *ControllerGauges) Describe(ch chan<- *prometheus.Desc) {
 	c.ModelConfigReads.Describe(ch)
@@ -151,6 +219,11 @@ func (c *ControllerGauges) Describe(ch chan<- *prometheus.Desc) {
 	c.LXDProfileChangeError.Describe(ch)
 	c.LXDProfileChangeNotification.Describe(ch)
 	c.LXDProfileNoChange.Describe(ch)
+
+	c.LookupHit.Describe(ch)
+	c.LookupMiss.Describe(ch)
+
+	c.ChangeLag.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -166,6 +239,11 @@ func (c *ControllerGauges) Collect(ch chan<- prometheus.Metric) {
 	c.LXDProfileChangeError.Collect(ch)
 	c.LXDProfileChangeNotification.Collect(ch)
 	c.LXDProfileNoChange.Collect(ch)
+
+	c.LookupHit.Collect(ch)
+	c.LookupMiss.Collect(ch)
+
+	c.ChangeLag.Collect(ch)
 }
 
 // Collector is a prometheus.Collector that collects metrics about
@@ -181,6 +259,7 @@ type Collector struct {
 	applications *prometheus.GaugeVec
 	units        *prometheus.GaugeVec
 	users        *prometheus.GaugeVec
+	entities     *prometheus.GaugeVec
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -242,6 +321,14 @@ func NewMetricsCollector(controller *Controller) *Collector {
 			},
 			userLabelNames,
 		),
+		entities: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_entities",
+				Help:      "Number of entities resident in the cache for each model.",
+			},
+			entityLabelNames,
+		),
 	}
 }
 
@@ -254,6 +341,7 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.applications.Describe(ch)
 	c.units.Describe(ch)
 	c.users.Describe(ch)
+	c.entities.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
 	c.scrapeDuration.Describe(ch)
@@ -272,6 +360,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.applications.Reset()
 	c.units.Reset()
 	c.users.Reset()
+	c.entities.Reset()
 
 	c.updateMetrics()
 
@@ -281,29 +370,26 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.applications.Collect(ch)
 	c.units.Collect(ch)
 	c.users.Collect(ch)
+	c.entities.Collect(ch)
 }
 
 func (c *Collector) updateMetrics() {
 	logger.Tracef("updating cache metrics")
 	defer logger.Tracef("updated cache metrics")
 
-	modelUUIDs := c.controller.ModelUUIDs()
-	for _, m := range modelUUIDs {
-		c.updateModelMetrics(m)
+	// The models are retrieved directly rather than with Model,
+	// so that scraping does not count as cache lookups.
+	for _, model := range c.controller.allModels() {
+		c.updateModelMetrics(model)
 	}
 
 	// TODO: add user metrics.
 }
 
-func (c *Collector) updateModelMetrics(modelUUID string) {
-	logger.Tracef("updating cache metrics for %s", modelUUID)
-	model, err := c.controller.Model(modelUUID)
-	if err != nil {
-		logger.Debugf("error getting model: %v", err)
-		return
-	}
+func (c *Collector) updateModelMetrics(model *Model) {
 	model.mu.Lock()
 	defer model.mu.Unlock()
+	logger.Tracef("updating cache metrics for %s", model.details.ModelUUID)
 
 	for _, machine := range model.machines {
 		c.machines.With(prometheus.Labels{
@@ -329,4 +415,19 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 		lifeLabel:   string(model.details.Life),
 		statusLabel: string(model.details.Status.Status),
 	}).Inc()
+
+	for kind, count := range map[string]int{
+		applicationKind: len(model.applications),
+		charmKind:       len(model.charms),
+		machineKind:     len(model.machines),
+		unitKind:        len(model.units),
+		relationKind:    len(model.relations),
+		offerKind:       len(model.offers),
+		branchKind:      len(model.branches),
+	} {
+		c.entities.With(prometheus.Labels{
+			modelUUIDLabel: model.details.ModelUUID,
+			kindLabel:      kind,
+		}).Set(float64(count))
+	}
 }
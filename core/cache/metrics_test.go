@@ -4,7 +4,9 @@ package cache_test
 
 import (
 	"bytes"
+	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -57,3 +59,108 @@ juju_cache_units{agent_status="active",life="alive",workload_status="active"} 1
 
 	workertest.CleanKill(c, controller)
 }
+
+func (s *ControllerSuite) TestCollectEntities(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, charmChange, events)
+	s.processChange(c, appChange, events)
+	s.processChange(c, machineChange, events)
+	s.processChange(c, unitChange, events)
+
+	collector := cache.NewMetricsCollector(controller)
+
+	expected := bytes.NewBuffer([]byte(`
+# HELP juju_cache_model_entities Number of entities resident in the cache for each model.
+# TYPE juju_cache_model_entities gauge
+juju_cache_model_entities{kind="application",model_uuid="model-uuid"} 1
+juju_cache_model_entities{kind="branch",model_uuid="model-uuid"} 0
+juju_cache_model_entities{kind="charm",model_uuid="model-uuid"} 1
+juju_cache_model_entities{kind="machine",model_uuid="model-uuid"} 1
+juju_cache_model_entities{kind="offer",model_uuid="model-uuid"} 0
+juju_cache_model_entities{kind="relation",model_uuid="model-uuid"} 0
+juju_cache_model_entities{kind="unit",model_uuid="model-uuid"} 1
+		`[1:]))
+
+	err := testutil.CollectAndCompare(collector, expected, "juju_cache_model_entities")
+	if !c.Check(err, jc.ErrorIsNil) {
+		c.Logf("\nerror:\n%v", err)
+	}
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestCollectLookups(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+	s.processChange(c, appChange, events)
+
+	// Scraping the metrics does not count as a lookup.
+	collector := cache.NewMetricsCollector(controller)
+	err := testutil.CollectAndCompare(collector, bytes.NewBuffer([]byte(`
+# HELP juju_cache_models Number of models in the controller.
+# TYPE juju_cache_models gauge
+juju_cache_models{life="alive",status="active"} 1
+		`[1:])), "juju_cache_models")
+	c.Assert(err, jc.ErrorIsNil)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = controller.Model("nope")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = mod.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Unit("nope/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	expected := bytes.NewBuffer([]byte(`
+# HELP juju_cache_lookup_hit_total The number of times a requested entity was found in the cache.
+# TYPE juju_cache_lookup_hit_total counter
+juju_cache_lookup_hit_total{kind="application"} 1
+juju_cache_lookup_hit_total{kind="model"} 1
+# HELP juju_cache_lookup_miss_total The number of times a requested entity was not found in the cache.
+# TYPE juju_cache_lookup_miss_total counter
+juju_cache_lookup_miss_total{kind="model"} 1
+juju_cache_lookup_miss_total{kind="unit"} 1
+		`[1:]))
+
+	err = testutil.CollectAndCompare(collector, expected, "juju_cache_lookup_hit_total", "juju_cache_lookup_miss_total")
+	if !c.Check(err, jc.ErrorIsNil) {
+		c.Logf("\nerror:\n%v", err)
+	}
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestCollectChangeLag(c *gc.C) {
+	controller, _ := s.new(c)
+	controller.ObserveChangeLag(30 * time.Millisecond)
+	controller.ObserveChangeLag(2 * time.Second)
+
+	collector := cache.NewMetricsCollector(controller)
+
+	expected := bytes.NewBuffer([]byte(`
+# HELP juju_cache_change_lag_seconds The time between changes being received from the database watcher and being applied to the cache.
+# TYPE juju_cache_change_lag_seconds histogram
+juju_cache_change_lag_seconds_bucket{le="0.001"} 0
+juju_cache_change_lag_seconds_bucket{le="0.01"} 0
+juju_cache_change_lag_seconds_bucket{le="0.05"} 1
+juju_cache_change_lag_seconds_bucket{le="0.1"} 1
+juju_cache_change_lag_seconds_bucket{le="0.25"} 1
+juju_cache_change_lag_seconds_bucket{le="0.5"} 1
+juju_cache_change_lag_seconds_bucket{le="1"} 1
+juju_cache_change_lag_seconds_bucket{le="2.5"} 2
+juju_cache_change_lag_seconds_bucket{le="5"} 2
+juju_cache_change_lag_seconds_bucket{le="10"} 2
+juju_cache_change_lag_seconds_bucket{le="+Inf"} 2
+juju_cache_change_lag_seconds_sum 2.03
+juju_cache_change_lag_seconds_count 2
+		`[1:]))
+
+	err := testutil.CollectAndCompare(collector, expected, "juju_cache_change_lag_seconds")
+	if !c.Check(err, jc.ErrorIsNil) {
+		c.Logf("\nerror:\n%v", err)
+	}
+
+	workertest.CleanKill(c, controller)
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/core/model"
 )
 
 const (
@@ -25,6 +28,10 @@ const (
 	modelUnitRemove = "model-unit-remove"
 	// A branch has been removed from the model.
 	modelBranchRemove = "model-branch-remove"
+	// A relation has been added to, or removed from the model.
+	modelAddRemoveRelation = "model-add-remove-relation"
+	// An offer has been added to, changed in, or removed from the model.
+	modelOfferChange = "model-offer-change"
 )
 
 func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *Model {
@@ -37,6 +44,9 @@ func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *
 		machines:     make(map[string]*Machine),
 		units:        make(map[string]*Unit),
 		branches:     make(map[string]*Branch),
+		relations:    make(map[string]*Relation),
+		offers:       make(map[string]*Offer),
+		summary:      newModelSummary(),
 	}
 	return m
 }
@@ -60,6 +70,9 @@ type Model struct {
 	machines     map[string]*Machine
 	units        map[string]*Unit
 	branches     map[string]*Branch
+	relations    map[string]*Relation
+	offers       map[string]*Offer
+	summary      *modelSummary
 }
 
 // Config returns the current model config.
@@ -79,6 +92,13 @@ func (m *Model) Name() string {
 	return m.details.Name
 }
 
+// IsStale returns true if the model's details have not been confirmed
+// by the database since they were restored from a snapshot, or since
+// the cache's watcher was last restarted.
+func (m *Model) IsStale() bool {
+	return m.isStale()
+}
+
 // WatchConfig creates a watcher for the model config.
 func (m *Model) WatchConfig(keys ...string) *ConfigWatcher {
 	return newConfigWatcher(keys, m.hashCache, m.hub, modelConfigChange, m.Resident)
@@ -96,9 +116,47 @@ func (m *Model) Report() map[string]interface{} {
 		"machine-count":     len(m.machines),
 		"unit-count":        len(m.units),
 		"branch-count":      len(m.branches),
+		"relation-count":    len(m.relations),
+		"offer-count":       len(m.offers),
 	}
 }
 
+// snapshot returns a copy of the details of the model and its entities.
+func (m *Model) snapshot() ModelSnapshot {
+	defer m.doLocked()()
+
+	s := ModelSnapshot{Model: m.details.copy()}
+	for _, ch := range m.charms {
+		s.Charms = append(s.Charms, ch.details.copy())
+	}
+	for _, app := range m.applications {
+		s.Applications = append(s.Applications, app.details.copy())
+	}
+	for _, machine := range m.machines {
+		s.Machines = append(s.Machines, machine.details.copy())
+	}
+	for _, unit := range m.units {
+		s.Units = append(s.Units, unit.details.copy())
+	}
+	for _, relation := range m.relations {
+		s.Relations = append(s.Relations, relation.details.copy())
+	}
+	for _, offer := range m.offers {
+		s.Offers = append(s.Offers, offer.details)
+	}
+	for _, branch := range m.branches {
+		s.Branches = append(s.Branches, branch.details.copy())
+	}
+	return s
+}
+
+// Summary returns aggregate counts and versions for the entities
+// in the model, without visiting each of them.
+func (m *Model) Summary() ModelSummary {
+	defer m.doLocked()()
+	return m.summary.copy()
+}
+
 // Branches returns all active branches in the model.
 func (m *Model) Branches() map[string]Branch {
 	m.mu.Lock()
@@ -123,18 +181,43 @@ func (m *Model) Branch(name string) (Branch, error) {
 
 	for _, b := range m.branches {
 		if b.details.Name == name {
+			m.metrics.lookup(branchKind, true)
 			return b.copy(), nil
 		}
 	}
+	m.metrics.lookup(branchKind, false)
 	return Branch{}, errors.NotFoundf("branch %q", name)
 }
 
+// ApplicationConfig returns the charm config for the input application,
+// as it applies to units tracking the branch with the input name.
+// An empty name or "master" indicates the master config,
+// which applies to units not tracking any branch.
+// If either the application or the branch is not found,
+// a NotFoundError is returned.
+func (m *Model) ApplicationConfig(appName, branchName string) (map[string]interface{}, error) {
+	app, err := m.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branchName == "" || branchName == model.GenerationMaster {
+		return applyBranchDeltas(app.Config(), nil), nil
+	}
+
+	branch, err := m.Branch(branchName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applyBranchDeltas(app.Config(), branch.AppConfig(appName)), nil
+}
+
 // Application returns the application for the input name.
 // If the application is not found, a NotFoundError is returned.
 func (m *Model) Application(appName string) (Application, error) {
 	defer m.doLocked()()
 
 	app, found := m.applications[appName]
+	m.metrics.lookup(applicationKind, found)
 	if !found {
 		return Application{}, errors.NotFoundf("application %q", appName)
 	}
@@ -154,12 +237,27 @@ func (m *Model) Units() map[string]Unit {
 	return units
 }
 
+// UnitsMatching returns copies of the model's units
+// whose labels match the selector.
+func (m *Model) UnitsMatching(selector labels.Selector) map[string]Unit {
+	defer m.doLocked()()
+
+	units := make(map[string]Unit)
+	for name, u := range m.units {
+		if selector.Matches(u.details.Labels) {
+			units[name] = u.copy()
+		}
+	}
+	return units
+}
+
 // Unit returns the unit with the input name.
 // If the unit is not found, a NotFoundError is returned.
 func (m *Model) Unit(unitName string) (Unit, error) {
 	defer m.doLocked()()
 
 	unit, found := m.units[unitName]
+	m.metrics.lookup(unitKind, found)
 	if !found {
 		return Unit{}, errors.NotFoundf("unit %q", unitName)
 	}
@@ -179,12 +277,27 @@ func (m *Model) Machines() map[string]Machine {
 	return machines
 }
 
+// MachinesMatching returns copies of the model's machines
+// whose labels match the selector.
+func (m *Model) MachinesMatching(selector labels.Selector) map[string]Machine {
+	defer m.doLocked()()
+
+	machines := make(map[string]Machine)
+	for id, machine := range m.machines {
+		if selector.Matches(machine.details.Labels) {
+			machines[id] = machine.copy()
+		}
+	}
+	return machines
+}
+
 // Machine returns the machine with the input id.
 // If the machine is not found, a NotFoundError is returned.
 func (m *Model) Machine(machineId string) (Machine, error) {
 	defer m.doLocked()()
 
 	machine, found := m.machines[machineId]
+	m.metrics.lookup(machineKind, found)
 	if !found {
 		return Machine{}, errors.NotFoundf("machine %q", machineId)
 	}
@@ -197,12 +310,105 @@ func (m *Model) Charm(charmURL string) (Charm, error) {
 	defer m.doLocked()()
 
 	charm, found := m.charms[charmURL]
+	m.metrics.lookup(charmKind, found)
 	if !found {
 		return Charm{}, errors.NotFoundf("charm %q", charmURL)
 	}
 	return charm.copy(), nil
 }
 
+// Relation returns the relation with the input key.
+// If the relation is not found, a NotFoundError is returned.
+func (m *Model) Relation(key string) (Relation, error) {
+	defer m.doLocked()()
+
+	relation, found := m.relations[key]
+	m.metrics.lookup(relationKind, found)
+	if !found {
+		return Relation{}, errors.NotFoundf("relation %q", key)
+	}
+	return relation.copy(), nil
+}
+
+// Relations makes a copy of the model's relation collection and returns it.
+func (m *Model) Relations() map[string]Relation {
+	defer m.doLocked()()
+
+	relations := make(map[string]Relation, len(m.relations))
+	for key, r := range m.relations {
+		relations[key] = r.copy()
+	}
+	return relations
+}
+
+// Offer returns the application offer with the input name.
+// If the offer is not found, a NotFoundError is returned.
+func (m *Model) Offer(offerName string) (Offer, error) {
+	defer m.doLocked()()
+
+	offer, found := m.offers[offerName]
+	m.metrics.lookup(offerKind, found)
+	if !found {
+		return Offer{}, errors.NotFoundf("offer %q", offerName)
+	}
+	return offer.copy(), nil
+}
+
+// Offers makes a copy of the model's application offer
+// collection and returns it.
+func (m *Model) Offers() map[string]Offer {
+	defer m.doLocked()()
+
+	offers := make(map[string]Offer, len(m.offers))
+	for name, o := range m.offers {
+		offers[name] = o.copy()
+	}
+	return offers
+}
+
+// WatchRelations returns a PredicateStringsWatcher to notify about
+// added and removed relations in the model. The initial event contains
+// a slice of the current relation keys.
+func (m *Model) WatchRelations() *PredicateStringsWatcher {
+	defer m.doLocked()()
+
+	keys := make([]string, 0, len(m.relations))
+	for key := range m.relations {
+		keys = append(keys, key)
+	}
+	return m.watchStrings(modelAddRemoveRelation, keys)
+}
+
+// WatchOffers returns a PredicateStringsWatcher to notify about added,
+// changed and removed application offers in the model. The initial
+// event contains a slice of the current offer names.
+func (m *Model) WatchOffers() *PredicateStringsWatcher {
+	defer m.doLocked()()
+
+	names := make([]string, 0, len(m.offers))
+	for name := range m.offers {
+		names = append(names, name)
+	}
+	return m.watchStrings(modelOfferChange, names)
+}
+
+// watchStrings returns a watcher that notifies with the values
+// published on the input topic, starting with the input values.
+// The model lock must be held by the caller.
+func (m *Model) watchStrings(topic string, values []string) *PredicateStringsWatcher {
+	w := newChangeWatcher(values...)
+	deregister := m.registerWorker(w)
+	unsub := m.hub.Subscribe(topic, w.changed)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
 // WatchMachines returns a PredicateStringsWatcher to notify about
 // added and removed machines in the model.  The initial event contains
 // a slice of the current machine ids.  Containers are excluded.
@@ -242,12 +448,19 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
 
+	var previous *EntityEvent
 	app, found := m.applications[ch.Name]
 	if !found {
 		app = newApplication(m.metrics, m.hub, rm.new())
 		m.applications[ch.Name] = app
+		m.summary.updateApplication(nil, ch)
+	} else {
+		m.summary.updateApplication(&app.details, ch)
+		e := applicationEvent(app.details)
+		previous = &e
 	}
 	app.setDetails(ch)
+	publishEntityEvent(m.hub, previous, applicationEvent(ch))
 
 	m.mu.Unlock()
 }
@@ -261,7 +474,9 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 		if err := app.evict(); err != nil {
 			return errors.Trace(err)
 		}
+		m.summary.removeApplication(app.details)
 		delete(m.applications, ch.Name)
+		m.publishRemoved(applicationEvent(app.details))
 	}
 	return nil
 }
@@ -298,12 +513,19 @@ func (m *Model) removeCharm(ch RemoveCharm) error {
 func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	m.mu.Lock()
 
+	var previous *EntityEvent
 	unit, found := m.units[ch.Name]
 	if !found {
 		unit = newUnit(m, rm.new())
 		m.units[ch.Name] = unit
+		m.summary.updateUnit(nil, ch)
+	} else {
+		m.summary.updateUnit(&unit.details, ch)
+		e := unitEvent(unit.details)
+		previous = &e
 	}
 	unit.setDetails(ch)
+	publishEntityEvent(m.hub, previous, unitEvent(ch))
 
 	m.mu.Unlock()
 }
@@ -318,7 +540,9 @@ func (m *Model) removeUnit(ch RemoveUnit) error {
 		if err := unit.evict(); err != nil {
 			return errors.Trace(err)
 		}
+		m.summary.removeUnit(unit.details)
 		delete(m.units, ch.Name)
+		m.publishRemoved(unitEvent(unit.details))
 	}
 	return nil
 }
@@ -327,13 +551,20 @@ func (m *Model) removeUnit(ch RemoveUnit) error {
 func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 	m.mu.Lock()
 
+	var previous *EntityEvent
 	machine, found := m.machines[ch.Id]
 	if !found {
 		machine = newMachine(m, rm.new())
 		m.machines[ch.Id] = machine
 		m.hub.Publish(modelAddRemoveMachine, []string{ch.Id})
+		m.summary.updateMachine(nil, ch)
+	} else {
+		m.summary.updateMachine(&machine.details, ch)
+		e := machineEvent(machine.details)
+		previous = &e
 	}
 	machine.setDetails(ch)
+	publishEntityEvent(m.hub, previous, machineEvent(ch))
 
 	m.mu.Unlock()
 }
@@ -348,7 +579,69 @@ func (m *Model) removeMachine(ch RemoveMachine) error {
 		if err := machine.evict(); err != nil {
 			return errors.Trace(err)
 		}
+		m.summary.removeMachine(machine.details)
 		delete(m.machines, ch.Id)
+		m.publishRemoved(machineEvent(machine.details))
+	}
+	return nil
+}
+
+// updateRelation adds or updates the relation in the model.
+func (m *Model) updateRelation(ch RelationChange, rm *residentManager) {
+	m.mu.Lock()
+
+	relation, found := m.relations[ch.Key]
+	if !found {
+		relation = newRelation(m.metrics, m.hub, rm.new())
+		m.relations[ch.Key] = relation
+		m.hub.Publish(modelAddRemoveRelation, []string{ch.Key})
+	}
+	relation.setDetails(ch)
+
+	m.mu.Unlock()
+}
+
+// removeRelation removes the relation from the model.
+func (m *Model) removeRelation(ch RemoveRelation) error {
+	defer m.doLocked()()
+
+	relation, ok := m.relations[ch.Key]
+	if ok {
+		m.hub.Publish(modelAddRemoveRelation, []string{ch.Key})
+		if err := relation.evict(); err != nil {
+			return errors.Trace(err)
+		}
+		delete(m.relations, ch.Key)
+	}
+	return nil
+}
+
+// updateOffer adds or updates the application offer in the model.
+func (m *Model) updateOffer(ch OfferChange, rm *residentManager) {
+	m.mu.Lock()
+
+	offer, found := m.offers[ch.OfferName]
+	if !found {
+		offer = newOffer(m.metrics, m.hub, rm.new())
+		m.offers[ch.OfferName] = offer
+	}
+	offer.setDetails(ch)
+	m.hub.Publish(modelOfferChange, []string{ch.OfferName})
+
+	m.mu.Unlock()
+}
+
+// removeOffer removes the application offer from the model.
+func (m *Model) removeOffer(ch RemoveOffer) error {
+	defer m.doLocked()()
+
+	offer, ok := m.offers[ch.OfferName]
+	if ok {
+		m.hub.Publish(modelOfferChange, []string{ch.OfferName})
+		if err := offer.evict(); err != nil {
+			return errors.Trace(err)
+		}
+		delete(m.offers, ch.OfferName)
 	}
 	return nil
 }
@@ -13,8 +13,10 @@ import (
 	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/labels"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 )
@@ -35,6 +37,8 @@ func (s *ModelSuite) TestReport(c *gc.C) {
 		"machine-count":     0,
 		"unit-count":        0,
 		"branch-count":      0,
+		"relation-count":    0,
+		"offer-count":       0,
 	})
 }
 
@@ -252,6 +256,38 @@ func (s *ModelSuite) TestUnitReturnsCopy(c *gc.C) {
 	c.Assert(u2.Ports(), gc.DeepEquals, unitChange.Ports)
 }
 
+func (s *ModelSuite) TestMachinesAndUnitsMatching(c *gc.C) {
+	m := s.NewModel(modelChange)
+	canary := machineChange
+	canary.Labels = map[string]string{"env": "canary"}
+	m.UpdateMachine(canary, s.Manager)
+	prod := machineChange
+	prod.Id = "1"
+	prod.Labels = map[string]string{"env": "prod"}
+	m.UpdateMachine(prod, s.Manager)
+
+	unit := unitChange
+	unit.Labels = map[string]string{"env": "canary", "tier": "web"}
+	m.UpdateUnit(unit, s.Manager)
+
+	selector, err := labels.ParseSelector("env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	machines := m.MachinesMatching(selector)
+	c.Assert(machines, gc.HasLen, 1)
+	machine := machines["0"]
+	c.Check(machine.Labels(), jc.DeepEquals, map[string]string{"env": "canary"})
+
+	units := m.UnitsMatching(selector)
+	c.Assert(units, gc.HasLen, 1)
+	u := units[unitChange.Name]
+	c.Check(u.Labels(), jc.DeepEquals, map[string]string{"env": "canary", "tier": "web"})
+
+	selector, err = labels.ParseSelector("tier=db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.UnitsMatching(selector), gc.HasLen, 0)
+}
+
 func (s *ModelSuite) TestBranchNotFoundError(c *gc.C) {
 	m := s.NewModel(modelChange)
 	_, err := m.Branch("nope")
@@ -275,6 +311,145 @@ func (s *ModelSuite) TestBranchReturnsCopy(c *gc.C) {
 	c.Assert(b2.AssignedUnits(), gc.DeepEquals, branchChange.AssignedUnits)
 }
 
+func (s *ModelSuite) TestApplicationConfigMaster(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+
+	for _, name := range []string{"", "master"} {
+		cfg, err := m.ApplicationConfig(appChange.Name, name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cfg, jc.DeepEquals, appChange.Config)
+	}
+}
+
+func (s *ModelSuite) TestApplicationConfigBranch(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+
+	br := branchChange
+	br.Config = map[string]settings.ItemChanges{
+		appChange.Name: {
+			settings.MakeAddition("new-key", "new-value"),
+			settings.MakeDeletion("key", "value"),
+		},
+	}
+	m.UpdateBranch(br, s.Manager)
+
+	cfg, err := m.ApplicationConfig(appChange.Name, br.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, jc.DeepEquals, map[string]interface{}{
+		"another": "foo",
+		"new-key": "new-value",
+	})
+
+	// The master config is unaffected.
+	app, err := m.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(app.Config(), jc.DeepEquals, appChange.Config)
+}
+
+func (s *ModelSuite) TestApplicationConfigBranchNotFound(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+
+	_, err := m.ApplicationConfig(appChange.Name, "nope")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelSuite) TestRelationNotFoundError(c *gc.C) {
+	m := s.NewModel(modelChange)
+	_, err := m.Relation("nope")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *ModelSuite) TestRelationReturnsCopy(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+
+	r1, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r1.Applications(), jc.DeepEquals, []string{"wordpress", "mysql"})
+
+	// Make a change to the slice returned in the copy.
+	eps := r1.Endpoints()
+	eps[0].Name = "banana"
+
+	// Get another copy from the model and ensure it is unchanged.
+	r2, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r2.Endpoints(), jc.DeepEquals, relationChange.Endpoints)
+}
+
+func (s *ModelSuite) TestOfferNotFoundError(c *gc.C) {
+	m := s.NewModel(modelChange)
+	_, err := m.Offer("nope")
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *ModelSuite) TestOffers(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateOffer(offerChange, s.Manager)
+
+	offers := m.Offers()
+	c.Assert(offers, gc.HasLen, 1)
+	offer := offers[offerChange.OfferName]
+	c.Check(offer.UUID(), gc.Equals, offerChange.OfferUUID)
+	c.Check(offer.ApplicationName(), gc.Equals, "mysql")
+	c.Check(offer.ActiveConnectedCount(), gc.Equals, 1)
+}
+
+func (s *ModelSuite) TestWatchRelations(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+
+	w := m.WatchRelations()
+	defer workertest.CleanKill(c, w)
+	wc := NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{relationChange.Key})
+
+	other := relationChange
+	other.Key = "wordpress:cache memcached:cache"
+	other.Id = 2
+	m.UpdateRelation(other, s.Manager)
+	wc.AssertOneChange([]string{other.Key})
+
+	// Updating an existing relation does not notify.
+	m.UpdateRelation(other, s.Manager)
+	wc.AssertNoChange()
+
+	c.Assert(m.RemoveRelation(cache.RemoveRelation{
+		ModelUUID: relationChange.ModelUUID,
+		Key:       relationChange.Key,
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange([]string{relationChange.Key})
+}
+
+func (s *ModelSuite) TestWatchOffers(c *gc.C) {
+	m := s.NewModel(modelChange)
+
+	w := m.WatchOffers()
+	defer workertest.CleanKill(c, w)
+	wc := NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{})
+
+	m.UpdateOffer(offerChange, s.Manager)
+	wc.AssertOneChange([]string{offerChange.OfferName})
+
+	// Changes to an existing offer notify.
+	changed := offerChange
+	changed.ActiveConnectedCount = 2
+	m.UpdateOffer(changed, s.Manager)
+	wc.AssertOneChange([]string{offerChange.OfferName})
+
+	c.Assert(m.RemoveOffer(cache.RemoveOffer{
+		ModelUUID: offerChange.ModelUUID,
+		OfferName: offerChange.OfferName,
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange([]string{offerChange.OfferName})
+}
+
 func (s *ModelSuite) TestRemoveBranchPublishesName(c *gc.C) {
 	m := s.NewModel(modelChange)
 	m.UpdateBranch(branchChange, s.Manager)
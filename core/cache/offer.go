@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/pubsub"
+)
+
+func newOffer(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *Offer {
+	return &Offer{
+		Resident: res,
+		metrics:  metrics,
+		hub:      hub,
+	}
+}
+
+// Offer represents an application offer in a cached model.
+type Offer struct {
+	// Resident identifies the offer as a type-agnostic cached entity
+	// and tracks resources that it is responsible for cleaning up.
+	*Resident
+
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+
+	details OfferChange
+}
+
+// Note that these property accessors are not lock-protected.
+// They are intended for calling from external packages that have retrieved a
+// copy from the cache.
+
+// Name returns the name of the offer, which uniquely
+// identifies it in the model.
+func (o *Offer) Name() string {
+	return o.details.OfferName
+}
+
+// UUID returns the UUID of the offer.
+func (o *Offer) UUID() string {
+	return o.details.OfferUUID
+}
+
+// ApplicationName returns the name of the offered application.
+func (o *Offer) ApplicationName() string {
+	return o.details.ApplicationName
+}
+
+// CharmName returns the name of the offered application's charm.
+func (o *Offer) CharmName() string {
+	return o.details.CharmName
+}
+
+// TotalConnectedCount returns the number of relations
+// that have been made to the offer.
+func (o *Offer) TotalConnectedCount() int {
+	return o.details.TotalConnectedCount
+}
+
+// ActiveConnectedCount returns the number of relations
+// to the offer that are active.
+func (o *Offer) ActiveConnectedCount() int {
+	return o.details.ActiveConnectedCount
+}
+
+func (o *Offer) setDetails(details OfferChange) {
+	// If this is the first receipt of details, set the removal message.
+	if o.removalMessage == nil {
+		o.removalMessage = RemoveOffer{
+			ModelUUID: details.ModelUUID,
+			OfferName: details.OfferName,
+		}
+	}
+
+	o.setStale(false)
+	o.details = details
+}
+
+// copy returns a copy of the offer.
+func (o *Offer) copy() Offer {
+	return *o
+}
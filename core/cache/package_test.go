@@ -110,8 +110,10 @@ func (*ImportSuite) TestImports(c *gc.C) {
 	c.Assert(found, jc.SameContents, []string{
 		"core/constraints",
 		"core/instance",
+		"core/labels",
 		"core/life",
 		"core/lxdprofile",
+		"core/model",
 		"core/network",
 		"core/settings",
 		"core/status",
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/pubsub"
+)
+
+func newRelation(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *Relation {
+	return &Relation{
+		Resident: res,
+		metrics:  metrics,
+		hub:      hub,
+	}
+}
+
+// Relation represents a relation in a cached model.
+type Relation struct {
+	// Resident identifies the relation as a type-agnostic cached entity
+	// and tracks resources that it is responsible for cleaning up.
+	*Resident
+
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+
+	details RelationChange
+}
+
+// Note that these property accessors are not lock-protected.
+// They are intended for calling from external packages that have retrieved a
+// deep copy from the cache.
+
+// Key returns the key of the relation, which uniquely
+// identifies it in the model.
+func (r *Relation) Key() string {
+	return r.details.Key
+}
+
+// Id returns the integer id of the relation.
+func (r *Relation) Id() int {
+	return r.details.Id
+}
+
+// Endpoints returns the endpoints of the relation.
+func (r *Relation) Endpoints() []RelationEndpoint {
+	return r.details.Endpoints
+}
+
+// Applications returns the names of the applications
+// participating in the relation.
+func (r *Relation) Applications() []string {
+	apps := make([]string, len(r.details.Endpoints))
+	for i, ep := range r.details.Endpoints {
+		apps[i] = ep.Application
+	}
+	return apps
+}
+
+func (r *Relation) setDetails(details RelationChange) {
+	// If this is the first receipt of details, set the removal message.
+	if r.removalMessage == nil {
+		r.removalMessage = RemoveRelation{
+			ModelUUID: details.ModelUUID,
+			Key:       details.Key,
+		}
+	}
+
+	r.setStale(false)
+	r.details = details
+}
+
+// copy returns a copy of the relation, ensuring appropriate deep copying.
+func (r *Relation) copy() Relation {
+	cr := *r
+	cr.details = cr.details.copy()
+	return cr
+}
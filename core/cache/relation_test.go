@@ -0,0 +1,61 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+)
+
+type RelationSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&RelationSuite{})
+
+func (s *RelationSuite) TestUpdateKeepsRelation(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+	r1, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m.UpdateRelation(relationChange, s.Manager)
+	r2, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r2.CacheId(), gc.Equals, r1.CacheId())
+	c.Check(r2.Id(), gc.Equals, relationChange.Id)
+	c.Check(r2.Key(), gc.Equals, relationChange.Key)
+}
+
+var relationChange = cache.RelationChange{
+	ModelUUID: "model-uuid",
+	Key:       "wordpress:db mysql:server",
+	Id:        1,
+	Endpoints: []cache.RelationEndpoint{{
+		Application: "wordpress",
+		Name:        "db",
+		Role:        "requirer",
+		Interface:   "mysql",
+		Limit:       1,
+		Scope:       "global",
+	}, {
+		Application: "mysql",
+		Name:        "server",
+		Role:        "provider",
+		Interface:   "mysql",
+		Scope:       "global",
+	}},
+}
+
+var offerChange = cache.OfferChange{
+	ModelUUID:            "model-uuid",
+	OfferUUID:            "offer-uuid",
+	OfferName:            "hosted-mysql",
+	ApplicationName:      "mysql",
+	CharmName:            "mysql",
+	TotalConnectedCount:  2,
+	ActiveConnectedCount: 1,
+}
@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"encoding/gob"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// snapshotVersion identifies the encoding of snapshots written by this
+// version of the cache. Snapshots written with any other version are
+// rejected on read, and the cache is warmed from the database instead.
+const snapshotVersion = 1
+
+func init() {
+	// Config and status data are free-form maps. Register the types
+	// that can be found in their values so that gob can encode them.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register([]string{})
+	gob.Register(time.Time{})
+}
+
+// Snapshot is a point-in-time copy of the contents of the cache.
+// A new controller supplied with a snapshot is populated from it
+// before it processes any changes, so that it can serve requests
+// without waiting for the all-model watcher to load the database.
+type Snapshot struct {
+	Version int
+	Models  []ModelSnapshot
+}
+
+// ModelSnapshot holds the details of a cached model and its entities.
+type ModelSnapshot struct {
+	Model        ModelChange
+	Charms       []CharmChange
+	Applications []ApplicationChange
+	Machines     []MachineChange
+	Units        []UnitChange
+	Relations    []RelationChange
+	Offers       []OfferChange
+	Branches     []BranchChange
+}
+
+// Changes returns the changes that, when applied to an empty cache,
+// reproduce the contents of the snapshot.
+func (s Snapshot) Changes() []interface{} {
+	var changes []interface{}
+	for _, m := range s.Models {
+		if m.Model.ModelUUID != "" {
+			changes = append(changes, m.Model)
+		}
+		for _, ch := range m.Charms {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Applications {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Machines {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Units {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Relations {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Offers {
+			changes = append(changes, ch)
+		}
+		for _, ch := range m.Branches {
+			changes = append(changes, ch)
+		}
+	}
+	return changes
+}
+
+// WriteSnapshot encodes the input snapshot to the writer.
+func WriteSnapshot(w io.Writer, s Snapshot) error {
+	s.Version = snapshotVersion
+	return errors.Trace(gob.NewEncoder(w).Encode(s))
+}
+
+// ReadSnapshot decodes a snapshot from the reader.
+// A snapshot written by an incompatible version
+// of the cache results in a NotSupported error.
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	if err := gob.NewDecoder(r).Decode(&s); err != nil {
+		return Snapshot{}, errors.Annotate(err, "decoding cache snapshot")
+	}
+	if s.Version != snapshotVersion {
+		return Snapshot{}, errors.NotSupportedf("cache snapshot version %d", s.Version)
+	}
+	return s, nil
+}
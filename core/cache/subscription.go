@@ -0,0 +1,384 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/core/status"
+)
+
+// An application, machine or unit has been added, changed or removed.
+const modelEntityChange = "model-entity-change"
+
+// EntityKind identifies a kind of cached entity that may be subscribed to.
+type EntityKind string
+
+const (
+	// ApplicationEntity identifies applications.
+	ApplicationEntity EntityKind = applicationKind
+
+	// MachineEntity identifies machines.
+	MachineEntity EntityKind = machineKind
+
+	// UnitEntity identifies units.
+	UnitEntity EntityKind = unitKind
+)
+
+// EntityEvent describes the state of a cached entity, as far as it is
+// relevant to subscriptions.
+type EntityEvent struct {
+	// Kind is the kind of the entity.
+	Kind EntityKind
+
+	// Id is the application name, machine id or unit name.
+	Id string
+
+	// Application is the application of a unit,
+	// or the name of an application.
+	Application string
+
+	// Labels holds the labels of a machine or unit.
+	Labels map[string]string
+
+	// Status is the workload status of a unit, the agent status of
+	// a machine, or the status of an application.
+	Status status.StatusInfo
+
+	// Removed is true if the entity has been removed from the model,
+	// or no longer matches the subscription's filter.
+	Removed bool
+}
+
+func applicationEvent(details ApplicationChange) EntityEvent {
+	return EntityEvent{
+		Kind:        ApplicationEntity,
+		Id:          details.Name,
+		Application: details.Name,
+		Status:      copyStatusInfo(details.Status),
+	}
+}
+
+func machineEvent(details MachineChange) EntityEvent {
+	return EntityEvent{
+		Kind:   MachineEntity,
+		Id:     details.Id,
+		Labels: copyStringMap(details.Labels),
+		Status: copyStatusInfo(details.AgentStatus),
+	}
+}
+
+func unitEvent(details UnitChange) EntityEvent {
+	return EntityEvent{
+		Kind:        UnitEntity,
+		Id:          details.Name,
+		Application: details.Application,
+		Labels:      copyStringMap(details.Labels),
+		Status:      copyStatusInfo(details.WorkloadStatus),
+	}
+}
+
+// EntityFilter selects the entities whose events are delivered to a
+// subscription. Fields other than Kind which are left empty match all
+// entities.
+type EntityFilter struct {
+	// Kind is the kind of entity to subscribe to. It is required.
+	Kind EntityKind
+
+	// Application matches units of, or the application with, the name.
+	Application string
+
+	// Selector matches machines and units with the labels.
+	Selector labels.Selector
+
+	// Status matches entities with the status.
+	Status status.Status
+
+	// Predicate, if set, must also return true for an entity to match.
+	Predicate func(EntityEvent) bool
+}
+
+// Validate returns an error if the filter is not valid.
+func (f EntityFilter) Validate() error {
+	switch f.Kind {
+	case ApplicationEntity:
+		if len(f.Selector) > 0 {
+			return errors.NotValidf("label selector for applications")
+		}
+	case MachineEntity:
+		if f.Application != "" {
+			return errors.NotValidf("application for machines")
+		}
+	case UnitEntity:
+	default:
+		return errors.NotValidf("entity kind %q", f.Kind)
+	}
+	return nil
+}
+
+// Matches reports whether the event is for an entity selected by the filter.
+func (f EntityFilter) Matches(e EntityEvent) bool {
+	if e.Kind != f.Kind {
+		return false
+	}
+	if f.Application != "" && e.Application != f.Application {
+		return false
+	}
+	if f.Status != "" && e.Status.Status != f.Status {
+		return false
+	}
+	if !f.Selector.Matches(e.Labels) {
+		return false
+	}
+	return f.Predicate == nil || f.Predicate(e)
+}
+
+// Subscription notifies with events for the entities selected by an
+// EntityFilter. Events for entities which change again before the
+// previous events have been read are coalesced, so that each entity
+// appears at most once in a change, with its latest state.
+type Subscription struct {
+	tomb    tomb.Tomb
+	filter  EntityFilter
+	changes chan []EntityEvent
+
+	// We can't send down a closed channel, so protect the sending
+	// with a mutex and bool.
+	closed bool
+	mu     sync.Mutex
+
+	// matching holds the ids of the entities last notified
+	// as matching the filter.
+	matching set.Strings
+}
+
+func newSubscription(filter EntityFilter, initial []EntityEvent) *Subscription {
+	s := &Subscription{
+		filter:   filter,
+		changes:  make(chan []EntityEvent, 1),
+		matching: set.NewStrings(),
+	}
+	for _, e := range initial {
+		s.matching.Add(e.Id)
+	}
+
+	// Send initial event down the channel. We know that this will
+	// execute immediately because it is a buffered channel.
+	s.changes <- initial
+	return s
+}
+
+// Changes returns the channel on which events are sent.
+// The initial event holds all of the matching entities.
+// The channel is closed when the subscription is stopped.
+func (s *Subscription) Changes() <-chan []EntityEvent {
+	return s.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *Subscription) Kill() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	close(s.changes)
+	s.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *Subscription) Wait() error {
+	return s.tomb.Wait()
+}
+
+// Stop is currently required by the Resources wrapper in the apiserver.
+func (s *Subscription) Stop() error {
+	s.Kill()
+	return s.Wait()
+}
+
+func (s *Subscription) changed(topic string, value interface{}) {
+	e, ok := value.(EntityEvent)
+	if !ok {
+		logger.Errorf("programming error, value not of type EntityEvent")
+		return
+	}
+	if e.Kind != s.filter.Kind {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	if !e.Removed && s.filter.Matches(e) {
+		s.matching.Add(e.Id)
+	} else if s.matching.Contains(e.Id) {
+		// Tell the subscriber that the entity has gone.
+		s.matching.Remove(e.Id)
+		e.Removed = true
+	} else {
+		return
+	}
+
+	select {
+	case s.changes <- []EntityEvent{e}:
+	default:
+		// Already a pending change, so coalesce the new event
+		// into it.
+		select {
+		case pending := <-s.changes:
+			s.changes <- coalesceEvent(pending, e)
+		default:
+			// Someone read the channel in the meantime.
+			s.changes <- []EntityEvent{e}
+		}
+	}
+}
+
+// coalesceEvent replaces the event for the same entity in the
+// pending events, or appends the event if there is none.
+func coalesceEvent(pending []EntityEvent, e EntityEvent) []EntityEvent {
+	for i, p := range pending {
+		if p.Id == e.Id {
+			pending[i] = e
+			return pending
+		}
+	}
+	return append(pending, e)
+}
+
+// EntityIdsWatcher is a StringsWatcher which notifies with the ids of
+// the entities that start or stop matching a subscription's filter.
+// Changes to entities which go on matching it are not notified.
+type EntityIdsWatcher struct {
+	*stringsWatcherBase
+	sub *Subscription
+}
+
+// NewEntityIdsWatcher returns an EntityIdsWatcher for the subscription,
+// whose initial event must not have been read. The subscription is
+// stopped when the watcher is.
+func NewEntityIdsWatcher(sub *Subscription) *EntityIdsWatcher {
+	initial := <-sub.Changes()
+	ids := make([]string, len(initial))
+	for i, e := range initial {
+		ids[i] = e.Id
+	}
+	w := &EntityIdsWatcher{
+		stringsWatcherBase: newStringsWatcherBase(ids...),
+		sub:                sub,
+	}
+	w.tomb.Go(func() error {
+		return w.loop(set.NewStrings(ids...))
+	})
+	return w
+}
+
+func (w *EntityIdsWatcher) loop(known set.Strings) error {
+	defer w.sub.Kill()
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return nil
+		case events, ok := <-w.sub.Changes():
+			if !ok {
+				// The subscription was stopped with the model.
+				w.Kill()
+				return nil
+			}
+			var ids []string
+			for _, e := range events {
+				if e.Removed == known.Contains(e.Id) {
+					ids = append(ids, e.Id)
+				}
+				if e.Removed {
+					known.Remove(e.Id)
+				} else {
+					known.Add(e.Id)
+				}
+			}
+			if len(ids) > 0 {
+				w.notify(ids)
+			}
+		}
+	}
+}
+
+// publishEntityEvent publishes the entity's current state to
+// subscriptions, if it differs from its previous state. A nil
+// previous state indicates that the entity is new.
+func publishEntityEvent(hub *pubsub.SimpleHub, previous *EntityEvent, current EntityEvent) {
+	if previous != nil && reflect.DeepEqual(*previous, current) {
+		// Nothing that subscriptions care about has changed.
+		return
+	}
+	hub.Publish(modelEntityChange, current)
+}
+
+// publishRemoved tells subscriptions that the entity has been removed.
+func (m *Model) publishRemoved(e EntityEvent) {
+	e.Removed = true
+	m.hub.Publish(modelEntityChange, e)
+}
+
+// Subscribe returns a subscription to events for the model's
+// applications, machines or units which match the filter. The initial
+// event holds the entities which match; later events hold those which
+// have changed, or have started or stopped matching, since the last
+// event was read.
+func (m *Model) Subscribe(filter EntityFilter) (*Subscription, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	defer m.doLocked()()
+
+	var events []EntityEvent
+	switch filter.Kind {
+	case ApplicationEntity:
+		for _, app := range m.applications {
+			events = append(events, applicationEvent(app.details))
+		}
+	case MachineEntity:
+		for _, machine := range m.machines {
+			events = append(events, machineEvent(machine.details))
+		}
+	case UnitEntity:
+		for _, unit := range m.units {
+			events = append(events, unitEvent(unit.details))
+		}
+	}
+	initial := make([]EntityEvent, 0, len(events))
+	for _, e := range events {
+		if filter.Matches(e) {
+			initial = append(initial, e)
+		}
+	}
+
+	s := newSubscription(filter, initial)
+	deregister := m.registerWorker(s)
+	unsub := m.hub.Subscribe(modelEntityChange, s.changed)
+
+	s.tomb.Go(func() error {
+		<-s.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return s, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+type SubscriptionSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&SubscriptionSuite{})
+
+func (s *SubscriptionSuite) TestInvalidFilter(c *gc.C) {
+	m := s.NewModel(modelChange)
+
+	_, err := m.Subscribe(cache.EntityFilter{Kind: "charm"})
+	c.Assert(err, gc.ErrorMatches, `entity kind "charm" not valid`)
+	_, err = m.Subscribe(cache.EntityFilter{Kind: cache.MachineEntity, Application: "foo"})
+	c.Assert(err, gc.ErrorMatches, `application for machines not valid`)
+}
+
+func (s *SubscriptionSuite) TestUnitsInError(c *gc.C) {
+	m := s.NewModel(modelChange)
+	inError := unitChange
+	inError.WorkloadStatus = status.StatusInfo{Status: status.Error, Message: "hook failed"}
+	m.UpdateUnit(inError, s.Manager)
+	other := unitChange
+	other.Name = "application-name/1"
+	m.UpdateUnit(other, s.Manager)
+
+	sub, err := m.Subscribe(cache.EntityFilter{
+		Kind:        cache.UnitEntity,
+		Application: "application-name",
+		Status:      status.Error,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, sub)
+
+	// The initial event holds the matching units.
+	assertEvents(c, sub, []string{"application-name/0"})
+
+	// Changes which don't affect the entity's event are not sent.
+	changed := inError
+	changed.PublicAddress = "10.0.0.1"
+	m.UpdateUnit(changed, s.Manager)
+	assertNoEvents(c, sub)
+
+	// A unit which starts matching is sent.
+	other.WorkloadStatus = status.StatusInfo{Status: status.Error}
+	m.UpdateUnit(other, s.Manager)
+	assertEvents(c, sub, []string{"application-name/1"})
+
+	// A unit which stops matching is sent as removed.
+	m.UpdateUnit(unitChange, s.Manager)
+	events := assertEvents(c, sub, []string{"application-name/0"})
+	c.Assert(events[0].Removed, jc.IsTrue)
+
+	// As is a matching unit which is removed.
+	err = m.RemoveUnit(cache.RemoveUnit{ModelUUID: other.ModelUUID, Name: other.Name})
+	c.Assert(err, jc.ErrorIsNil)
+	events = assertEvents(c, sub, []string{"application-name/1"})
+	c.Assert(events[0].Removed, jc.IsTrue)
+
+	// Units which didn't match aren't sent when removed.
+	err = m.RemoveUnit(cache.RemoveUnit{ModelUUID: unitChange.ModelUUID, Name: unitChange.Name})
+	c.Assert(err, jc.ErrorIsNil)
+	assertNoEvents(c, sub)
+}
+
+func (s *SubscriptionSuite) TestMachinesMatchingSelector(c *gc.C) {
+	m := s.NewModel(modelChange)
+	selector, err := labels.ParseSelector("env=canary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	sub, err := m.Subscribe(cache.EntityFilter{
+		Kind:     cache.MachineEntity,
+		Selector: selector,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, sub)
+	assertEvents(c, sub, []string{})
+
+	canary := machineChange
+	canary.Labels = map[string]string{"env": "canary"}
+	m.UpdateMachine(canary, s.Manager)
+	events := assertEvents(c, sub, []string{"0"})
+	c.Assert(events[0], jc.DeepEquals, cache.EntityEvent{
+		Kind:   cache.MachineEntity,
+		Id:     "0",
+		Labels: map[string]string{"env": "canary"},
+		Status: status.StatusInfo{Status: status.Active},
+	})
+
+	prod := machineChange
+	prod.Id = "1"
+	prod.Labels = map[string]string{"env": "prod"}
+	m.UpdateMachine(prod, s.Manager)
+	assertNoEvents(c, sub)
+}
+
+func (s *SubscriptionSuite) TestEventsCoalesced(c *gc.C) {
+	m := s.NewModel(modelChange)
+	sub, err := m.Subscribe(cache.EntityFilter{
+		Kind: cache.ApplicationEntity,
+		Predicate: func(e cache.EntityEvent) bool {
+			return e.Status.Status != status.Unknown
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, sub)
+	assertEvents(c, sub, []string{})
+
+	app := appChange
+	m.UpdateApplication(app, s.Manager)
+	app.Status = status.StatusInfo{Status: status.Blocked}
+	m.UpdateApplication(app, s.Manager)
+	other := appChange
+	other.Name = "other"
+	other.Status = status.StatusInfo{Status: status.Unknown}
+	m.UpdateApplication(other, s.Manager)
+	app.Status = status.StatusInfo{Status: status.Waiting}
+	m.UpdateApplication(app, s.Manager)
+
+	// The unread changes to the application are sent as a single
+	// event, with its latest status.
+	var events []cache.EntityEvent
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		select {
+		case changes := <-sub.Changes():
+			events = append(events, changes...)
+		case <-time.After(testing.ShortWait):
+		}
+		if len(events) > 0 && events[len(events)-1].Status.Status == status.Waiting {
+			break
+		}
+	}
+	c.Assert(events, gc.Not(gc.HasLen), 0)
+	last := events[len(events)-1]
+	c.Assert(last.Id, gc.Equals, appChange.Name)
+	c.Assert(last.Status.Status, gc.Equals, status.Waiting)
+	for _, e := range events {
+		c.Assert(e.Id, gc.Equals, appChange.Name)
+	}
+}
+
+func (s *SubscriptionSuite) TestEntityIdsWatcher(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateMachine(machineChange, s.Manager)
+
+	sub, err := m.Subscribe(cache.EntityFilter{Kind: cache.MachineEntity})
+	c.Assert(err, jc.ErrorIsNil)
+	w := cache.NewEntityIdsWatcher(sub)
+	defer workertest.CleanKill(c, w)
+	wc := NewStringsWatcherC(c, w)
+	wc.AssertOneChange([]string{"0"})
+
+	// Changes to machines which go on matching are not notified.
+	changed := machineChange
+	changed.AgentStatus = status.StatusInfo{Status: status.Down}
+	m.UpdateMachine(changed, s.Manager)
+	wc.AssertNoChange()
+
+	added := machineChange
+	added.Id = "1"
+	m.UpdateMachine(added, s.Manager)
+	wc.AssertOneChange([]string{"1"})
+
+	err = m.RemoveMachine(cache.RemoveMachine{ModelUUID: modelChange.ModelUUID, Id: "0"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange([]string{"0"})
+
+	// Stopping the watcher stops the subscription.
+	wc.AssertStops()
+	workertest.CheckKilled(c, sub)
+}
+
+func assertEvents(c *gc.C, sub *cache.Subscription, ids []string) []cache.EntityEvent {
+	select {
+	case events, ok := <-sub.Changes():
+		c.Assert(ok, jc.IsTrue)
+		obtained := make([]string, len(events))
+		for i, e := range events {
+			obtained[i] = e.Id
+		}
+		c.Assert(obtained, jc.SameContents, ids)
+		assertNoEvents(c, sub)
+		return events
+	case <-time.After(testing.LongWait):
+		c.Fatalf("subscription did not send events")
+	}
+	return nil
+}
+
+func assertNoEvents(c *gc.C, sub *cache.Subscription) {
+	select {
+	case events, ok := <-sub.Changes():
+		c.Fatalf("subscription sent unexpected events: %#v, %v", events, ok)
+	case <-time.After(testing.ShortWait):
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/juju/core/status"
+)
+
+// ModelSummary holds aggregate information about the entities in a
+// cached model. It is maintained incrementally as changes flow into
+// the cache, so that it can be read without visiting every entity.
+type ModelSummary struct {
+	ApplicationCount int
+	MachineCount     int
+	ContainerCount   int
+	UnitCount        int
+
+	// UnitWorkloadStatus and UnitAgentStatus hold the number
+	// of units with each workload and agent status.
+	UnitWorkloadStatus map[status.Status]int
+	UnitAgentStatus    map[status.Status]int
+
+	// MachineAgentStatus holds the number of machines, including
+	// containers, with each agent status.
+	MachineAgentStatus map[status.Status]int
+
+	// ApplicationVersions maps the names of applications that
+	// report a workload version to that version.
+	ApplicationVersions map[string]string
+}
+
+// modelSummary is the incrementally maintained summary of a model.
+// It is not goroutine safe; it is protected by the model's lock.
+type modelSummary struct {
+	summary ModelSummary
+}
+
+func newModelSummary() *modelSummary {
+	return &modelSummary{summary: ModelSummary{
+		UnitWorkloadStatus:  make(map[status.Status]int),
+		UnitAgentStatus:     make(map[status.Status]int),
+		MachineAgentStatus:  make(map[status.Status]int),
+		ApplicationVersions: make(map[string]string),
+	}}
+}
+
+// updateApplication accounts for an application being added, if old
+// is nil, or its details changing from old to new.
+func (s *modelSummary) updateApplication(old *ApplicationChange, new ApplicationChange) {
+	if old == nil {
+		s.summary.ApplicationCount++
+	}
+	if new.WorkloadVersion != "" {
+		s.summary.ApplicationVersions[new.Name] = new.WorkloadVersion
+	} else {
+		delete(s.summary.ApplicationVersions, new.Name)
+	}
+}
+
+// removeApplication accounts for an application being removed.
+func (s *modelSummary) removeApplication(old ApplicationChange) {
+	s.summary.ApplicationCount--
+	delete(s.summary.ApplicationVersions, old.Name)
+}
+
+// updateUnit accounts for a unit being added, if old is nil,
+// or its details changing from old to new.
+func (s *modelSummary) updateUnit(old *UnitChange, new UnitChange) {
+	if old != nil {
+		s.removeUnit(*old)
+	}
+	s.summary.UnitCount++
+	s.summary.UnitWorkloadStatus[new.WorkloadStatus.Status]++
+	s.summary.UnitAgentStatus[new.AgentStatus.Status]++
+}
+
+// removeUnit accounts for a unit being removed.
+func (s *modelSummary) removeUnit(old UnitChange) {
+	s.summary.UnitCount--
+	decrement(s.summary.UnitWorkloadStatus, old.WorkloadStatus.Status)
+	decrement(s.summary.UnitAgentStatus, old.AgentStatus.Status)
+}
+
+// updateMachine accounts for a machine being added, if old is nil,
+// or its details changing from old to new.
+func (s *modelSummary) updateMachine(old *MachineChange, new MachineChange) {
+	if old != nil {
+		s.removeMachine(*old)
+	}
+	if new.ContainerType != "" {
+		s.summary.ContainerCount++
+	} else {
+		s.summary.MachineCount++
+	}
+	s.summary.MachineAgentStatus[new.AgentStatus.Status]++
+}
+
+// removeMachine accounts for a machine being removed.
+func (s *modelSummary) removeMachine(old MachineChange) {
+	if old.ContainerType != "" {
+		s.summary.ContainerCount--
+	} else {
+		s.summary.MachineCount--
+	}
+	decrement(s.summary.MachineAgentStatus, old.AgentStatus.Status)
+}
+
+// copy returns a copy of the summary that is safe to
+// use without holding the model's lock.
+func (s *modelSummary) copy() ModelSummary {
+	result := s.summary
+	result.UnitWorkloadStatus = copyStatusCounts(s.summary.UnitWorkloadStatus)
+	result.UnitAgentStatus = copyStatusCounts(s.summary.UnitAgentStatus)
+	result.MachineAgentStatus = copyStatusCounts(s.summary.MachineAgentStatus)
+	result.ApplicationVersions = make(map[string]string, len(s.summary.ApplicationVersions))
+	for name, version := range s.summary.ApplicationVersions {
+		result.ApplicationVersions[name] = version
+	}
+	return result
+}
+
+func decrement(counts map[status.Status]int, key status.Status) {
+	if counts[key] <= 1 {
+		delete(counts, key)
+		return
+	}
+	counts[key]--
+}
+
+func copyStatusCounts(counts map[status.Status]int) map[status.Status]int {
+	result := make(map[status.Status]int, len(counts))
+	for k, v := range counts {
+		result[k] = v
+	}
+	return result
+}
@@ -6,10 +6,10 @@ package cache
 import (
 	"fmt"
 
-	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
 )
@@ -70,6 +70,31 @@ func (u *Unit) Ports() []network.Port {
 	return u.details.Ports
 }
 
+// Labels returns the freeform labels attached to the unit.
+func (u *Unit) Labels() map[string]string {
+	return u.details.Labels
+}
+
+// Branch returns the name of the model branch that this unit is tracking.
+// If the unit is not tracking a branch, "master" is returned.
+func (u *Unit) Branch() string {
+	if b, tracking := u.trackedBranch(); tracking {
+		return b.Name()
+	}
+	return model.GenerationMaster
+}
+
+// trackedBranch returns the model branch that this unit is tracking,
+// and true, or false if it is not tracking any branch.
+func (u *Unit) trackedBranch() (Branch, bool) {
+	for _, b := range u.model.Branches() {
+		if b.IsTracking(u.details.Application, u.details.Name) {
+			return b, true
+		}
+	}
+	return Branch{}, false
+}
+
 // Config settings returns the effective charm configuration for this unit
 // taking into account whether it is tracking a model branch.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
@@ -78,30 +103,13 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	cfg := app.Config()
-	if cfg == nil {
-		cfg = make(map[string]interface{})
-	}
 
 	// Apply any branch-based deltas to the master settings.
 	var deltas settings.ItemChanges
-	for _, b := range u.model.Branches() {
-		if units := b.AssignedUnits()[appName]; len(units) > 0 {
-			if set.NewStrings(units...).Contains(u.details.Name) {
-				deltas = b.AppConfig(appName)
-				break
-			}
-		}
-	}
-
-	for _, delta := range deltas {
-		switch {
-		case delta.IsAddition(), delta.IsModification():
-			cfg[delta.Key] = delta.NewValue
-		case delta.IsDeletion():
-			delete(cfg, delta.Key)
-		}
+	if b, tracking := u.trackedBranch(); tracking {
+		deltas = b.AppConfig(appName)
 	}
+	cfg := applyBranchDeltas(app.Config(), deltas)
 
 	// Fill in any empty values with charm defaults.
 	ch, err := u.model.Charm(u.details.CharmURL)
@@ -137,6 +145,19 @@ func (u *Unit) WatchConfigSettings() (*CharmConfigWatcher, error) {
 	return w, errors.Trace(err)
 }
 
+// WatchBranch returns a new watcher that will notify when this unit is
+// assigned to a model branch, or when the branch that it is tracking is
+// committed or aborted, so that its effective config must be re-read.
+func (u *Unit) WatchBranch() *BranchTrackingWatcher {
+	return newBranchTrackingWatcher(branchTrackingWatcherConfig{
+		model:    u.model,
+		unitName: u.details.Name,
+		appName:  u.details.Application,
+		hub:      u.model.hub,
+		res:      u.Resident,
+	})
+}
+
 func (u *Unit) setDetails(details UnitChange) {
 	// If this is the first receipt of details, set the removal message.
 	if u.removalMessage == nil {
@@ -110,6 +110,56 @@ func (s *UnitSuite) TestConfigSettingsDefaultsOnly(c *gc.C) {
 	c.Assert(cfg, gc.DeepEquals, expected)
 }
 
+func (s *UnitSuite) TestBranch(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(u.Branch(), gc.Equals, "master")
+
+	br := branchChange
+	br.AssignedUnits = map[string][]string{appChange.Name: {unitChange.Name}}
+	m.UpdateBranch(br, s.Manager)
+
+	c.Check(u.Branch(), gc.Equals, br.Name)
+}
+
+func (s *UnitSuite) TestWatchBranch(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := u.WatchBranch()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// A branch not tracked by the unit does not notify.
+	m.UpdateBranch(branchChange, s.Manager)
+	wc.AssertNoChange()
+
+	// Assigning the unit to the branch notifies, but only once.
+	br := branchChange
+	br.AssignedUnits = map[string][]string{appChange.Name: {unitChange.Name}}
+	m.UpdateBranch(br, s.Manager)
+	wc.AssertOneChange()
+	m.UpdateBranch(br, s.Manager)
+	wc.AssertNoChange()
+
+	// Removing the branch returns the unit to master.
+	c.Assert(m.RemoveBranch(cache.RemoveBranch{
+		ModelUUID: br.ModelUUID,
+		Id:        br.Id,
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 var unitChange = cache.UnitChange{
 	ModelUUID:      "model-uuid",
 	Name:           "application-name/0",
@@ -26,6 +26,8 @@ const (
 	cpuCores       = "cpu-cores"
 	Cores          = "cores"
 	CpuPower       = "cpu-power"
+	GPUs           = "gpus"
+	GPUType        = "gpu-type"
 	Mem            = "mem"
 	RootDisk       = "root-disk"
 	RootDiskSource = "root-disk-source"
@@ -34,6 +36,15 @@ const (
 	Spaces         = "spaces"
 	VirtType       = "virt-type"
 	Zones          = "zones"
+
+	// InstanceLifecycle selects how an instance is priced and how
+	// long it may run for, such as "preemptible" or "spot".
+	InstanceLifecycle = "instance-lifecycle"
+
+	// CpuQuota and MemLimit cap the resources used by the processes
+	// of each unit on a machine, rather than select a machine.
+	CpuQuota = "cpu-quota"
+	MemLimit = "mem-limit"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -58,6 +69,14 @@ type Value struct {
 	// equivalent to 1 Amazon ECU (or, roughly, a single 2007-era Xeon).
 	CpuPower *uint64 `json:"cpu-power,omitempty" yaml:"cpu-power,omitempty"`
 
+	// GPUs, if not nil, indicates that a machine must have at least that
+	// number of GPUs attached.
+	GPUs *uint64 `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+
+	// GPUType, if not nil or empty, indicates the kind of GPU that must
+	// be attached to a machine. The values accepted are provider specific.
+	GPUType *string `json:"gpu-type,omitempty" yaml:"gpu-type,omitempty"`
+
 	// Mem, if not nil, indicates that a machine must have at least that many
 	// megabytes of RAM.
 	Mem *uint64 `json:"mem,omitempty" yaml:"mem,omitempty"`
@@ -84,6 +103,11 @@ type Value struct {
 	// be used. Only valid for clouds which support instance types.
 	InstanceType *string `json:"instance-type,omitempty" yaml:"instance-type,omitempty"`
 
+	// InstanceLifecycle, if not nil or empty, indicates the lifecycle of the
+	// instance to be used, such as a preemptible or spot instance that the
+	// cloud may stop at any time. The values accepted are provider specific.
+	InstanceLifecycle *string `json:"instance-lifecycle,omitempty" yaml:"instance-lifecycle,omitempty"`
+
 	// Spaces, if not nil, holds a list of juju network spaces that
 	// should be available (or not) on the machine. Positive and
 	// negative values are accepted, and the difference is the latter
@@ -97,6 +121,17 @@ type Value struct {
 	// Zones, if not nil, holds a list of availability zones limiting where
 	// the machine can be located.
 	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// CpuQuota, if not nil, limits the CPU time the processes of a unit
+	// may use, as a percentage of a single CPU; 200 allows a unit the
+	// equivalent of two CPUs. It is enforced by the machine agent, and
+	// does not affect which machine a unit is deployed to.
+	CpuQuota *uint64 `json:"cpu-quota,omitempty" yaml:"cpu-quota,omitempty"`
+
+	// MemLimit, if not nil, limits the memory the processes of a unit
+	// may use, in megabytes. It is enforced by the machine agent, and
+	// does not affect which machine a unit is deployed to.
+	MemLimit *uint64 `json:"mem-limit,omitempty" yaml:"mem-limit,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -134,12 +169,35 @@ func (v *Value) HasCpuPower() bool {
 	return v.CpuPower != nil && *v.CpuPower > 0
 }
 
+// HasCpuQuota returns true if the constraints.Value specifies a limit
+// on the CPU time used by a unit.
+func (v *Value) HasCpuQuota() bool {
+	return v.CpuQuota != nil && *v.CpuQuota > 0
+}
+
+// HasMemLimit returns true if the constraints.Value specifies a limit
+// on the memory used by a unit.
+func (v *Value) HasMemLimit() bool {
+	return v.MemLimit != nil && *v.MemLimit > 0
+}
+
 // HasCpuCores returns true if the constraints.Value specifies a minimum number
 // of CPU cores.
 func (v *Value) HasCpuCores() bool {
 	return v.CpuCores != nil && *v.CpuCores > 0
 }
 
+// HasGPUs returns true if the constraints.Value specifies a minimum number
+// of GPUs.
+func (v *Value) HasGPUs() bool {
+	return v.GPUs != nil && *v.GPUs > 0
+}
+
+// HasGPUType returns true if the constraints.Value specifies a GPU type.
+func (v *Value) HasGPUType() bool {
+	return v.GPUType != nil && *v.GPUType != ""
+}
+
 // HasRootDisk returns true if the contraints.Value specifies a RootDisk size.
 func (v *Value) HasRootDisk() bool {
 	return v.RootDisk != nil && *v.RootDisk > 0
@@ -156,6 +214,12 @@ func (v *Value) HasInstanceType() bool {
 	return v.InstanceType != nil && *v.InstanceType != ""
 }
 
+// HasInstanceLifecycle returns true if the constraints.Value specifies
+// an instance lifecycle.
+func (v *Value) HasInstanceLifecycle() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle != ""
+}
+
 // extractItems returns the list of entries in the given field which
 // are either positive (included) or negative (!included; with prefix
 // "^").
@@ -223,9 +287,21 @@ func (v Value) String() string {
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.CpuQuota != nil {
+		strs = append(strs, "cpu-quota="+uintStr(*v.CpuQuota))
+	}
+	if v.GPUs != nil {
+		strs = append(strs, "gpus="+uintStr(*v.GPUs))
+	}
+	if v.GPUType != nil {
+		strs = append(strs, "gpu-type="+(*v.GPUType))
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		strs = append(strs, "instance-lifecycle="+(*v.InstanceLifecycle))
+	}
 	if v.Mem != nil {
 		s := uintStr(*v.Mem)
 		if s != "" {
@@ -233,6 +309,13 @@ func (v Value) String() string {
 		}
 		strs = append(strs, "mem="+s)
 	}
+	if v.MemLimit != nil {
+		s := uintStr(*v.MemLimit)
+		if s != "" {
+			s += "M"
+		}
+		strs = append(strs, "mem-limit="+s)
+	}
 	if v.RootDisk != nil {
 		s := uintStr(*v.RootDisk)
 		if s != "" {
@@ -275,15 +358,30 @@ func (v Value) GoString() string {
 	if v.CpuPower != nil {
 		values = append(values, fmt.Sprintf("CpuPower: %v", *v.CpuPower))
 	}
+	if v.CpuQuota != nil {
+		values = append(values, fmt.Sprintf("CpuQuota: %v", *v.CpuQuota))
+	}
+	if v.GPUs != nil {
+		values = append(values, fmt.Sprintf("GPUs: %v", *v.GPUs))
+	}
+	if v.GPUType != nil {
+		values = append(values, fmt.Sprintf("GPUType: %q", *v.GPUType))
+	}
 	if v.Mem != nil {
 		values = append(values, fmt.Sprintf("Mem: %v", *v.Mem))
 	}
+	if v.MemLimit != nil {
+		values = append(values, fmt.Sprintf("MemLimit: %v", *v.MemLimit))
+	}
 	if v.RootDisk != nil {
 		values = append(values, fmt.Sprintf("RootDisk: %v", *v.RootDisk))
 	}
 	if v.InstanceType != nil {
 		values = append(values, fmt.Sprintf("InstanceType: %q", *v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		values = append(values, fmt.Sprintf("InstanceLifecycle: %q", *v.InstanceLifecycle))
+	}
 	if v.Container != nil {
 		values = append(values, fmt.Sprintf("Container: %q", *v.Container))
 	}
@@ -449,8 +547,16 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setCpuCores(str)
 	case CpuPower:
 		err = v.setCpuPower(str)
+	case CpuQuota:
+		err = v.setCpuQuota(str)
+	case GPUs:
+		err = v.setGPUs(str)
+	case GPUType:
+		err = v.setGPUType(str)
 	case Mem:
 		err = v.setMem(str)
+	case MemLimit:
+		err = v.setMemLimit(str)
 	case RootDisk:
 		err = v.setRootDisk(str)
 	case RootDiskSource:
@@ -459,6 +565,8 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setTags(str)
 	case InstanceType:
 		err = v.setInstanceType(str)
+	case InstanceLifecycle:
+		err = v.setInstanceLifecycle(str)
 	case Spaces:
 		err = v.setSpaces(str)
 	case VirtType:
@@ -506,12 +614,22 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.Container = &ctype
 		case InstanceType:
 			v.InstanceType = &vstr
+		case InstanceLifecycle:
+			v.InstanceLifecycle = &vstr
 		case Cores:
 			v.CpuCores, err = parseUint64(vstr)
 		case CpuPower:
 			v.CpuPower, err = parseUint64(vstr)
+		case CpuQuota:
+			v.CpuQuota, err = parseUint64(vstr)
+		case GPUs:
+			v.GPUs, err = parseUint64(vstr)
+		case GPUType:
+			v.GPUType = &vstr
 		case Mem:
 			v.Mem, err = parseUint64(vstr)
+		case MemLimit:
+			v.MemLimit, err = parseUint64(vstr)
 		case RootDisk:
 			v.RootDisk, err = parseUint64(vstr)
 		case RootDiskSource:
@@ -591,6 +709,30 @@ func (v *Value) setCpuPower(str string) (err error) {
 	return
 }
 
+func (v *Value) setCpuQuota(str string) (err error) {
+	if v.CpuQuota != nil {
+		return errors.Errorf("already set")
+	}
+	v.CpuQuota, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setGPUs(str string) (err error) {
+	if v.GPUs != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUs, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setGPUType(str string) error {
+	if v.GPUType != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUType = &str
+	return nil
+}
+
 func (v *Value) setInstanceType(str string) error {
 	if v.InstanceType != nil {
 		return errors.Errorf("already set")
@@ -599,6 +741,14 @@ func (v *Value) setInstanceType(str string) error {
 	return nil
 }
 
+func (v *Value) setInstanceLifecycle(str string) error {
+	if v.InstanceLifecycle != nil {
+		return errors.Errorf("already set")
+	}
+	v.InstanceLifecycle = &str
+	return nil
+}
+
 func (v *Value) setMem(str string) (err error) {
 	if v.Mem != nil {
 		return errors.Errorf("already set")
@@ -607,6 +757,14 @@ func (v *Value) setMem(str string) (err error) {
 	return
 }
 
+func (v *Value) setMemLimit(str string) (err error) {
+	if v.MemLimit != nil {
+		return errors.Errorf("already set")
+	}
+	v.MemLimit, err = parseSize(str)
+	return
+}
+
 func (v *Value) setRootDisk(str string) (err error) {
 	if v.RootDisk != nil {
 		return errors.Errorf("already set")
@@ -166,6 +166,47 @@ var parseConstraintsTests = []struct {
 		err:     `bad "cpu-power" constraint: already set`,
 	},
 
+	// "cpu-quota" in detail.
+	{
+		summary: "set cpu-quota empty",
+		args:    []string{"cpu-quota="},
+	}, {
+		summary: "set cpu-quota",
+		args:    []string{"cpu-quota=150"},
+	}, {
+		summary: "set nonsense cpu-quota",
+		args:    []string{"cpu-quota=half"},
+		err:     `bad "cpu-quota" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set cpu-quota",
+		args:    []string{"cpu-quota=50", "cpu-quota=100"},
+		err:     `bad "cpu-quota" constraint: already set`,
+	},
+
+	// "gpus" and "gpu-type" in detail.
+	{
+		summary: "set gpus empty",
+		args:    []string{"gpus="},
+	}, {
+		summary: "set gpus",
+		args:    []string{"gpus=2"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=lots"},
+		err:     `bad "gpus" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set gpus",
+		args:    []string{"gpus=1 gpus=2"},
+		err:     `bad "gpus" constraint: already set`,
+	}, {
+		summary: "set gpu-type",
+		args:    []string{"gpus=1 gpu-type=nvidia-tesla-k80"},
+	}, {
+		summary: "double set gpu-type",
+		args:    []string{"gpu-type=nvidia", "gpu-type=amd"},
+		err:     `bad "gpu-type" constraint: already set`,
+	},
+
 	// "mem" in detail.
 	{
 		summary: "set mem empty",
@@ -210,6 +251,26 @@ var parseConstraintsTests = []struct {
 		err:     `bad "mem" constraint: already set`,
 	},
 
+	// "mem-limit" in detail.
+	{
+		summary: "set mem-limit empty",
+		args:    []string{"mem-limit="},
+	}, {
+		summary: "set mem-limit without suffix",
+		args:    []string{"mem-limit=512"},
+	}, {
+		summary: "set mem-limit with G suffix",
+		args:    []string{"mem-limit=1.5G"},
+	}, {
+		summary: "set nonsense mem-limit",
+		args:    []string{"mem-limit=lots"},
+		err:     `bad "mem-limit" constraint: must be a non-negative float with optional M/G/T/P suffix`,
+	}, {
+		summary: "double set mem-limit",
+		args:    []string{"mem-limit=1G", "mem-limit=2G"},
+		err:     `bad "mem-limit" constraint: already set`,
+	},
+
 	// "root-disk" in detail.
 	{
 		summary: "set root-disk empty",
@@ -310,6 +371,19 @@ var parseConstraintsTests = []struct {
 		args:    []string{"instance-type="},
 	},
 
+	// "instance-lifecycle" in detail.
+	{
+		summary: "set instance-lifecycle empty",
+		args:    []string{"instance-lifecycle="},
+	}, {
+		summary: "set instance-lifecycle",
+		args:    []string{"instance-lifecycle=preemptible"},
+	}, {
+		summary: "double set instance-lifecycle",
+		args:    []string{"instance-lifecycle=spot", "instance-lifecycle=preemptible"},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	},
+
 	// "virt-type" in detail.
 	{
 		summary: "set virt-type empty",
@@ -489,6 +563,18 @@ func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	c.Check(con.HasRootDiskSource(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestHasGPUs(c *gc.C) {
+	con := constraints.MustParse("gpus=2 gpu-type=nvidia")
+	c.Check(con.HasGPUs(), jc.IsTrue)
+	c.Check(con.HasGPUType(), jc.IsTrue)
+	con = constraints.MustParse("gpus=0 gpu-type=")
+	c.Check(con.HasGPUs(), jc.IsFalse)
+	c.Check(con.HasGPUType(), jc.IsFalse)
+	con = constraints.MustParse("cores=2")
+	c.Check(con.HasGPUs(), jc.IsFalse)
+	c.Check(con.HasGPUType(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDisk(c *gc.C) {
 	con := constraints.MustParse("root-disk=32G")
 	c.Check(con.HasRootDisk(), jc.IsTrue)
@@ -558,9 +644,18 @@ var constraintsRoundtripTests = []roundTrip{
 	{"CpuPower1", constraints.Value{CpuPower: nil}},
 	{"CpuPower2", constraints.Value{CpuPower: uint64p(0)}},
 	{"CpuPower3", constraints.Value{CpuPower: uint64p(250)}},
+	{"GPUs1", constraints.Value{GPUs: nil}},
+	{"GPUs2", constraints.Value{GPUs: uint64p(0)}},
+	{"GPUs3", constraints.Value{GPUs: uint64p(4)}},
+	{"GPUType1", constraints.Value{GPUType: strp("")}},
+	{"GPUType2", constraints.Value{GPUType: strp("nvidia-tesla-v100")}},
 	{"Mem1", constraints.Value{Mem: nil}},
 	{"Mem2", constraints.Value{Mem: uint64p(0)}},
 	{"Mem3", constraints.Value{Mem: uint64p(98765)}},
+	{"CpuQuota1", constraints.Value{CpuQuota: uint64p(0)}},
+	{"CpuQuota2", constraints.Value{CpuQuota: uint64p(150)}},
+	{"MemLimit1", constraints.Value{MemLimit: uint64p(0)}},
+	{"MemLimit2", constraints.Value{MemLimit: uint64p(2048)}},
 	{"RootDisk1", constraints.Value{RootDisk: nil}},
 	{"RootDisk2", constraints.Value{RootDisk: uint64p(0)}},
 	{"RootDisk2", constraints.Value{RootDisk: uint64p(109876)}},
@@ -574,21 +669,28 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"InstanceLifecycle1", constraints.Value{InstanceLifecycle: strp("")}},
+	{"InstanceLifecycle2", constraints.Value{InstanceLifecycle: strp("spot")}},
 	{"Zones1", constraints.Value{Zones: nil}},
 	{"Zones2", constraints.Value{Zones: &[]string{}}},
 	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"All", constraints.Value{
-		Arch:           strp("i386"),
-		Container:      ctypep("lxd"),
-		CpuCores:       uint64p(4096),
-		CpuPower:       uint64p(9001),
-		Mem:            uint64p(18000000000),
-		RootDisk:       uint64p(24000000000),
-		RootDiskSource: strp("cave"),
-		Tags:           &[]string{"foo", "bar"},
-		Spaces:         &[]string{"space1", "^space2"},
-		InstanceType:   strp("foo"),
-		Zones:          &[]string{"az1", "az2"},
+		Arch:              strp("i386"),
+		Container:         ctypep("lxd"),
+		CpuCores:          uint64p(4096),
+		CpuPower:          uint64p(9001),
+		GPUs:              uint64p(2),
+		GPUType:           strp("nvidia"),
+		Mem:               uint64p(18000000000),
+		RootDisk:          uint64p(24000000000),
+		RootDiskSource:    strp("cave"),
+		Tags:              &[]string{"foo", "bar"},
+		Spaces:            &[]string{"space1", "^space2"},
+		InstanceType:      strp("foo"),
+		InstanceLifecycle: strp("preemptible"),
+		Zones:             &[]string{"az1", "az2"},
+		CpuQuota:          uint64p(50),
+		MemLimit:          uint64p(1024),
 	}},
 }
 
@@ -666,6 +768,27 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasInstanceLifecycle(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 instance-lifecycle=")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsFalse)
+	cons = constraints.MustParse("arch=amd64 instance-lifecycle=spot")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasCpuQuota(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 cpu-quota=0")
+	c.Check(cons.HasCpuQuota(), jc.IsFalse)
+	cons = constraints.MustParse("arch=amd64 cpu-quota=50")
+	c.Check(cons.HasCpuQuota(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasMemLimit(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 mem-limit=0")
+	c.Check(cons.HasMemLimit(), jc.IsFalse)
+	cons = constraints.MustParse("arch=amd64 mem-limit=1G")
+	c.Check(cons.HasMemLimit(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo " +
 	"container=lxd instance-type=bar zones=az1,az2"
 
@@ -0,0 +1,93 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// ValidateConsumerACLs returns an error if any of the CIDRs or
+// controller UUIDs used to restrict consumption of an offer are
+// not valid.
+func ValidateConsumerACLs(cidrs, controllers []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("allowed consumer CIDR %q", cidr)
+		}
+	}
+	for _, uuid := range controllers {
+		if !utils.IsValidUUIDString(uuid) {
+			return errors.NotValidf("allowed consumer controller %q", uuid)
+		}
+	}
+	return nil
+}
+
+// CheckConsumerController returns an Unauthorized error if the offer
+// restricts consumption to specific controllers, and the controller
+// with the given UUID is not one of them.
+func (s *ApplicationOffer) CheckConsumerController(controllerUUID string) error {
+	if len(s.AllowedConsumerControllers) == 0 {
+		return nil
+	}
+	for _, allowed := range s.AllowedConsumerControllers {
+		if controllerUUID != "" && allowed == controllerUUID {
+			return nil
+		}
+	}
+	if controllerUUID == "" {
+		return errors.Unauthorizedf("offer %q requires the consuming controller to be identified", s.OfferName)
+	}
+	return errors.Unauthorizedf("controller %q may not consume offer %q", controllerUUID, s.OfferName)
+}
+
+// CheckConsumerNetworks returns an Unauthorized error if the offer
+// restricts consumption to specific CIDRs, and any of the given
+// networks does not fall entirely within one of them. A consumer that
+// provides no networks at all is not authorised by a restricted offer.
+func (s *ApplicationOffer) CheckConsumerNetworks(cidrs []string) error {
+	if len(s.AllowedConsumerCIDRs) == 0 {
+		return nil
+	}
+	if len(cidrs) == 0 {
+		return errors.Unauthorizedf("offer %q requires the consuming networks to be identified", s.OfferName)
+	}
+	allowed := make([]*net.IPNet, 0, len(s.AllowedConsumerCIDRs))
+	for _, cidr := range s.AllowedConsumerCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		allowed = append(allowed, ipNet)
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return errors.NotValidf("consumer network %q", cidr)
+		}
+		if !networkWithin(ipNet, allowed) {
+			return errors.Unauthorizedf("network %q may not consume offer %q", cidr, s.OfferName)
+		}
+	}
+	return nil
+}
+
+// networkWithin reports whether the network lies entirely within
+// any one of the candidate networks.
+func networkWithin(network *net.IPNet, candidates []*net.IPNet) bool {
+	ones, bits := network.Mask.Size()
+	for _, candidate := range candidates {
+		candidateOnes, candidateBits := candidate.Mask.Size()
+		if bits != candidateBits || candidateOnes > ones {
+			continue
+		}
+		if candidate.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
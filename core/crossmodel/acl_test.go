@@ -0,0 +1,84 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type aclSuite struct{}
+
+var _ = gc.Suite(&aclSuite{})
+
+const (
+	controllerA = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	controllerB = "deadbeef-0bad-400d-8000-5b1d0d06f00d"
+)
+
+func (s *aclSuite) TestValidateConsumerACLs(c *gc.C) {
+	err := crossmodel.ValidateConsumerACLs([]string{"10.0.0.0/8", "2001:db8::/32"}, []string{controllerA})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = crossmodel.ValidateConsumerACLs([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `allowed consumer CIDR "10.0.0.0" not valid`)
+
+	err = crossmodel.ValidateConsumerACLs(nil, []string{"controller"})
+	c.Assert(err, gc.ErrorMatches, `allowed consumer controller "controller" not valid`)
+}
+
+func (s *aclSuite) TestCheckConsumerControllerUnrestricted(c *gc.C) {
+	offer := crossmodel.ApplicationOffer{OfferName: "db"}
+	c.Assert(offer.CheckConsumerController(""), jc.ErrorIsNil)
+	c.Assert(offer.CheckConsumerController(controllerA), jc.ErrorIsNil)
+}
+
+func (s *aclSuite) TestCheckConsumerController(c *gc.C) {
+	offer := crossmodel.ApplicationOffer{
+		OfferName:                  "db",
+		AllowedConsumerControllers: []string{controllerA},
+	}
+	c.Assert(offer.CheckConsumerController(controllerA), jc.ErrorIsNil)
+
+	err := offer.CheckConsumerController(controllerB)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, `controller "deadbeef-0bad-400d-8000-5b1d0d06f00d" may not consume offer "db"`)
+
+	err = offer.CheckConsumerController("")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, `offer "db" requires the consuming controller to be identified`)
+}
+
+func (s *aclSuite) TestCheckConsumerNetworksUnrestricted(c *gc.C) {
+	offer := crossmodel.ApplicationOffer{OfferName: "db"}
+	c.Assert(offer.CheckConsumerNetworks(nil), jc.ErrorIsNil)
+	c.Assert(offer.CheckConsumerNetworks([]string{"0.0.0.0/0"}), jc.ErrorIsNil)
+}
+
+func (s *aclSuite) TestCheckConsumerNetworks(c *gc.C) {
+	offer := crossmodel.ApplicationOffer{
+		OfferName:            "db",
+		AllowedConsumerCIDRs: []string{"10.0.0.0/16", "192.168.1.0/24", "2001:db8::/32"},
+	}
+	err := offer.CheckConsumerNetworks([]string{"10.0.1.0/24", "192.168.1.5/32", "2001:db8:1::/48"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A network wider than the allowed range isn't permitted,
+	// even though it overlaps.
+	err = offer.CheckConsumerNetworks([]string{"10.0.1.0/24", "10.0.0.0/8"})
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, `network "10.0.0.0/8" may not consume offer "db"`)
+
+	err = offer.CheckConsumerNetworks([]string{"172.16.0.1/32"})
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	err = offer.CheckConsumerNetworks(nil)
+	c.Assert(err, gc.ErrorMatches, `offer "db" requires the consuming networks to be identified`)
+
+	err = offer.CheckConsumerNetworks([]string{"bad"})
+	c.Assert(err, gc.ErrorMatches, `consumer network "bad" not valid`)
+}
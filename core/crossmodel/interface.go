@@ -29,6 +29,16 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// AllowedConsumerCIDRs, if not empty, restricts consumption of the
+	// offer to consuming models whose egress networks fall within
+	// these CIDRs.
+	AllowedConsumerCIDRs []string
+
+	// AllowedConsumerControllers, if not empty, restricts consumption
+	// of the offer to consuming models hosted by the controllers with
+	// these UUIDs.
+	AllowedConsumerControllers []string
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -56,6 +66,16 @@ type AddApplicationOfferArgs struct {
 	// Icon is an icon to display when browsing the ApplicationOffers, which by default
 	// comes from the charm.
 	Icon []byte
+
+	// AllowedConsumerCIDRs, if not empty, restricts consumption of the
+	// offer to consuming models whose egress networks fall within
+	// these CIDRs.
+	AllowedConsumerCIDRs []string
+
+	// AllowedConsumerControllers, if not empty, restricts consumption
+	// of the offer to consuming models hosted by the controllers with
+	// these UUIDs.
+	AllowedConsumerControllers []string
 }
 
 // ConsumeApplicationArgs contains parameters used to consume an offer.
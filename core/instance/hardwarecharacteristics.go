@@ -33,6 +33,12 @@ type HardwareCharacteristics struct {
 	// CpuPower is a relative representation of the speed of the processor.
 	CpuPower *uint64 `json:"cpu-power,omitempty" yaml:"cpupower,omitempty"`
 
+	// GPUs is the number of GPUs attached to the machine.
+	GPUs *uint64 `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+
+	// GPUType is the kind of GPU attached to the machine.
+	GPUType *string `json:"gpu-type,omitempty" yaml:"gputype,omitempty"`
+
 	// Tags is a list of strings that identify the machine.
 	Tags *[]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 
@@ -51,6 +57,12 @@ func (hc HardwareCharacteristics) String() string {
 	if hc.CpuPower != nil {
 		strs = append(strs, fmt.Sprintf("cpu-power=%d", *hc.CpuPower))
 	}
+	if hc.GPUs != nil {
+		strs = append(strs, fmt.Sprintf("gpus=%d", *hc.GPUs))
+	}
+	if hc.GPUType != nil {
+		strs = append(strs, fmt.Sprintf("gpu-type=%s", *hc.GPUType))
+	}
 	if hc.Mem != nil {
 		strs = append(strs, fmt.Sprintf("mem=%dM", *hc.Mem))
 	}
@@ -113,6 +125,10 @@ func (hc *HardwareCharacteristics) setRaw(raw string) error {
 		err = hc.setCpuCores(str)
 	case "cpu-power":
 		err = hc.setCpuPower(str)
+	case "gpus":
+		err = hc.setGPUs(str)
+	case "gpu-type":
+		err = hc.setGPUType(str)
 	case "mem":
 		err = hc.setMem(str)
 	case "root-disk":
@@ -159,6 +175,24 @@ func (hc *HardwareCharacteristics) setCpuPower(str string) (err error) {
 	return
 }
 
+func (hc *HardwareCharacteristics) setGPUs(str string) (err error) {
+	if hc.GPUs != nil {
+		return fmt.Errorf("already set")
+	}
+	hc.GPUs, err = parseUint64(str)
+	return
+}
+
+func (hc *HardwareCharacteristics) setGPUType(str string) error {
+	if hc.GPUType != nil {
+		return fmt.Errorf("already set")
+	}
+	if str != "" {
+		hc.GPUType = &str
+	}
+	return nil
+}
+
 func (hc *HardwareCharacteristics) setMem(str string) (err error) {
 	if hc.Mem != nil {
 		return fmt.Errorf("already set")
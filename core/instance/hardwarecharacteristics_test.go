@@ -156,6 +156,23 @@ var parseHardwareTests = []parseHardwareTestSpec{
 		err:     `bad "cpu-power" characteristic: already set`,
 	},
 
+	// "gpus" and "gpu-type" in detail.
+	{
+		summary: "set gpus",
+		args:    []string{"gpus=2"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=cheese"},
+		err:     `bad "gpus" characteristic: must be a non-negative integer`,
+	}, {
+		summary: "set gpus and gpu-type",
+		args:    []string{"gpus=1 gpu-type=nvidia-tesla-k80"},
+	}, {
+		summary: "double set gpu-type",
+		args:    []string{"gpu-type=nvidia", "gpu-type=amd"},
+		err:     `bad "gpu-type" characteristic: already set`,
+	},
+
 	// "mem" in detail.
 	{
 		summary: "set mem empty",
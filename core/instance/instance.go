@@ -19,3 +19,34 @@ type Status struct {
 
 // UnknownId can be used to explicitly specify the instance Id when it does not matter.
 const UnknownId Id = ""
+
+// Utilization holds the resource utilization of an instance, as
+// reported by the provider. Values the provider does not report
+// are nil.
+type Utilization struct {
+	// CPUPercent is the percentage of the instance's allocated
+	// CPU in use.
+	CPUPercent *float64
+
+	// MemoryPercent is the percentage of the instance's memory
+	// in use.
+	MemoryPercent *float64
+}
+
+// IsEmpty returns true if the provider reported no utilization.
+func (u Utilization) IsEmpty() bool {
+	return u.CPUPercent == nil && u.MemoryPercent == nil
+}
+
+// Equals returns true if the utilizations report the same values.
+func (u Utilization) Equals(other Utilization) bool {
+	return percentEquals(u.CPUPercent, other.CPUPercent) &&
+		percentEquals(u.MemoryPercent, other.MemoryPercent)
+}
+
+func percentEquals(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
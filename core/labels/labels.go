@@ -0,0 +1,193 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package labels provides the freeform labels that may be attached to
+// machines and units, and the selectors used to match them.
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// MaxLength is the maximum length of label keys and values.
+const MaxLength = 63
+
+// validLabel matches label keys, and non-empty label values. They must
+// begin and end with a lowercase letter or digit, and may contain
+// dashes and underscores. Dots are not allowed, as labels are stored
+// as fields of Mongo documents.
+var validLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`)
+
+// ValidateKey returns an error if the label key is not valid.
+func ValidateKey(key string) error {
+	if len(key) > MaxLength || !validLabel.MatchString(key) {
+		return errors.NotValidf("label key %q", key)
+	}
+	return nil
+}
+
+// ValidateValue returns an error if the label value is not valid.
+// Label values may be empty.
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > MaxLength || !validLabel.MatchString(value) {
+		return errors.NotValidf("label value %q", value)
+	}
+	return nil
+}
+
+// Validate returns an error if any of the labels are not valid.
+func Validate(labels map[string]string) error {
+	for key, value := range labels {
+		if err := ValidateKey(key); err != nil {
+			return errors.Trace(err)
+		}
+		if err := ValidateValue(value); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Operator describes how a requirement of a selector is evaluated.
+type Operator string
+
+const (
+	// Equals requires the label to have the value.
+	Equals Operator = "="
+
+	// NotEquals requires the label to be absent, or to
+	// have a different value.
+	NotEquals Operator = "!="
+
+	// Exists requires the label to be present.
+	Exists Operator = ""
+
+	// DoesNotExist requires the label to be absent.
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single term of a selector.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+// Matches reports whether the labels satisfy the requirement.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case Equals:
+		return ok && value == r.Value
+	case NotEquals:
+		return !ok || value != r.Value
+	case DoesNotExist:
+		return !ok
+	}
+	return ok
+}
+
+// String returns the requirement in the form accepted by ParseSelector.
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Equals, NotEquals:
+		return r.Key + string(r.Operator) + r.Value
+	case DoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key
+}
+
+// Selector matches sets of labels which satisfy all of its
+// requirements. An empty selector matches everything.
+type Selector []Requirement
+
+// ParseSelector parses a selector of comma-separated requirements,
+// each of which takes one of the following forms:
+//
+//    key=value   the label has the value (key==value is also accepted)
+//    key!=value  the label is absent, or has a different value
+//    key         the label is present
+//    !key        the label is absent
+func ParseSelector(s string) (Selector, error) {
+	var selector Selector
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return nil, errors.NotValidf("empty requirement in selector %q", s)
+		}
+		r, err := parseRequirement(term)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing selector %q", s)
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+func parseRequirement(term string) (Requirement, error) {
+	var r Requirement
+	switch {
+	case strings.Contains(term, "!="):
+		parts := strings.SplitN(term, "!=", 2)
+		r = Requirement{Key: parts[0], Operator: NotEquals, Value: parts[1]}
+	case strings.Contains(term, "=="):
+		parts := strings.SplitN(term, "==", 2)
+		r = Requirement{Key: parts[0], Operator: Equals, Value: parts[1]}
+	case strings.Contains(term, "="):
+		parts := strings.SplitN(term, "=", 2)
+		r = Requirement{Key: parts[0], Operator: Equals, Value: parts[1]}
+	case strings.HasPrefix(term, "!"):
+		r = Requirement{Key: term[1:], Operator: DoesNotExist}
+	default:
+		r = Requirement{Key: term, Operator: Exists}
+	}
+	r.Key = strings.TrimSpace(r.Key)
+	r.Value = strings.TrimSpace(r.Value)
+	if err := ValidateKey(r.Key); err != nil {
+		return Requirement{}, errors.Trace(err)
+	}
+	if err := ValidateValue(r.Value); err != nil {
+		return Requirement{}, errors.Trace(err)
+	}
+	return r, nil
+}
+
+// Matches reports whether the labels satisfy all of the
+// selector's requirements.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form accepted by ParseSelector.
+func (s Selector) String() string {
+	terms := make([]string, len(s))
+	for i, r := range s {
+		terms[i] = r.String()
+	}
+	return strings.Join(terms, ",")
+}
+
+// Format returns the labels as sorted, comma-separated
+// key=value pairs, for display.
+func Format(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
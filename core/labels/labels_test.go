@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package labels_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/labels"
+	"github.com/juju/juju/testing"
+)
+
+type labelsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (s *labelsSuite) TestValidate(c *gc.C) {
+	err := labels.Validate(map[string]string{"env": "canary", "tier": "", "rack_2": "a-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, key := range []string{"", "Env", "-env", "env-", "env.prod", "env$", strings.Repeat("a", 64)} {
+		err := labels.Validate(map[string]string{key: "value"})
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("key %q", key))
+	}
+	for _, value := range []string{"Canary", "canary.1", "a b", strings.Repeat("a", 64)} {
+		err := labels.Validate(map[string]string{"env": value})
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("value %q", value))
+	}
+}
+
+func (s *labelsSuite) TestParseSelector(c *gc.C) {
+	selector, err := labels.ParseSelector("env=canary, tier!=web,zone==a,rack,!decommissioned")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selector, jc.DeepEquals, labels.Selector{
+		{Key: "env", Operator: labels.Equals, Value: "canary"},
+		{Key: "tier", Operator: labels.NotEquals, Value: "web"},
+		{Key: "zone", Operator: labels.Equals, Value: "a"},
+		{Key: "rack", Operator: labels.Exists},
+		{Key: "decommissioned", Operator: labels.DoesNotExist},
+	})
+	c.Assert(selector.String(), gc.Equals, "env=canary,tier!=web,zone=a,rack,!decommissioned")
+}
+
+func (s *labelsSuite) TestParseSelectorErrors(c *gc.C) {
+	for i, test := range []struct {
+		selector string
+		err      string
+	}{{
+		selector: "",
+		err:      `empty requirement in selector "" not valid`,
+	}, {
+		selector: "env=canary,",
+		err:      `empty requirement in selector "env=canary," not valid`,
+	}, {
+		selector: "=canary",
+		err:      `parsing selector "=canary": label key "" not valid`,
+	}, {
+		selector: "env=Canary",
+		err:      `parsing selector "env=Canary": label value "Canary" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.selector)
+		_, err := labels.ParseSelector(test.selector)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *labelsSuite) TestSelectorMatches(c *gc.C) {
+	entity := map[string]string{"env": "canary", "tier": "db"}
+	for i, test := range []struct {
+		selector string
+		matches  bool
+	}{
+		{"env=canary", true},
+		{"env=prod", false},
+		{"env=canary,tier=db", true},
+		{"env=canary,tier=web", false},
+		{"tier!=web", true},
+		{"rack!=a", true},
+		{"env", true},
+		{"rack", false},
+		{"!rack", true},
+		{"!env", false},
+	} {
+		c.Logf("test %d: %q", i, test.selector)
+		selector, err := labels.ParseSelector(test.selector)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(selector.Matches(entity), gc.Equals, test.matches)
+	}
+}
+
+func (s *labelsSuite) TestFormat(c *gc.C) {
+	c.Assert(labels.Format(nil), gc.Equals, "")
+	c.Assert(labels.Format(map[string]string{"tier": "db", "env": "canary"}), gc.Equals, "env=canary,tier=db")
+}
@@ -68,6 +68,10 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// Users holds the users with access to the model, as they will be
+	// known on the target controller.
+	Users []names.UserTag
 }
 
 func (i *ModelInfo) Validate() error {
@@ -43,6 +43,11 @@ type TargetInfo struct {
 	// Macaroons holds macaroons to use with AuthTag. At least one of
 	// Password or Macaroons must be set.
 	Macaroons []macaroon.Slice
+
+	// UserMap optionally maps the names of users on the source
+	// controller to the names of the users that replace them on the
+	// target controller. Users not in the map keep their names.
+	UserMap map[string]string
 }
 
 // Validate returns an error if the TargetInfo contains bad data. Nil
@@ -70,5 +75,45 @@ func (info *TargetInfo) Validate() error {
 		return errors.NotValidf("missing Password & Macaroons")
 	}
 
+	if err := ValidateUserMap(info.UserMap); err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
+
+// ValidateUserMap returns an error if the user map refers to invalid
+// user names, or maps more than one source user to the same target
+// user.
+func ValidateUserMap(userMap map[string]string) error {
+	targets := make(map[string]string)
+	for source, target := range userMap {
+		if !names.IsValidUser(source) {
+			return errors.NotValidf("source user %q in UserMap", source)
+		}
+		if !names.IsValidUser(target) {
+			return errors.NotValidf("target user %q in UserMap", target)
+		}
+		target = names.NewUserTag(target).Id()
+		if other, ok := targets[target]; ok {
+			return errors.NotValidf("users %q and %q both mapped to %q in UserMap", other, source, target)
+		}
+		targets[target] = source
+	}
+	return nil
+}
+
+// MapUser returns the tag of the user that replaces the input user on
+// the target controller.
+func (info *TargetInfo) MapUser(user names.UserTag) names.UserTag {
+	return MapUser(info.UserMap, user)
+}
+
+// MapUser returns the tag of the user that the input user is mapped to
+// by the user map. Users not in the map are returned unchanged.
+func MapUser(userMap map[string]string, user names.UserTag) names.UserTag {
+	if target, ok := userMap[user.Id()]; ok {
+		return names.NewUserTag(target)
+	}
+	return user
+}
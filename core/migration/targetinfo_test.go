@@ -81,6 +81,30 @@ func (s *TargetInfoSuite) TestValidation(c *gc.C) {
 			info.Macaroons = nil
 		},
 		"",
+	}, {
+		"invalid UserMap source",
+		func(info *migration.TargetInfo) {
+			info.UserMap = map[string]string{"not valid": "bob"}
+		},
+		`source user "not valid" in UserMap not valid`,
+	}, {
+		"invalid UserMap target",
+		func(info *migration.TargetInfo) {
+			info.UserMap = map[string]string{"bob": "not valid"}
+		},
+		`target user "not valid" in UserMap not valid`,
+	}, {
+		"UserMap duplicate target",
+		func(info *migration.TargetInfo) {
+			info.UserMap = map[string]string{"bob": "mary", "fred": "mary"}
+		},
+		`users "(bob|fred)" and "(bob|fred)" both mapped to "mary" in UserMap not valid`,
+	}, {
+		"Success - UserMap",
+		func(info *migration.TargetInfo) {
+			info.UserMap = map[string]string{"bob@external": "bob"}
+		},
+		"",
 	}, {
 		"Success - all set",
 		func(*migration.TargetInfo) {},
@@ -101,6 +125,13 @@ func (s *TargetInfoSuite) TestValidation(c *gc.C) {
 	}
 }
 
+func (s *TargetInfoSuite) TestMapUser(c *gc.C) {
+	info := makeValidTargetInfo(c)
+	info.UserMap = map[string]string{"bob@external": "bob"}
+	c.Check(info.MapUser(names.NewUserTag("bob@external")), gc.Equals, names.NewUserTag("bob"))
+	c.Check(info.MapUser(names.NewUserTag("mary")), gc.Equals, names.NewUserTag("mary"))
+}
+
 func makeValidTargetInfo(c *gc.C) migration.TargetInfo {
 	mac, err := macaroon.New([]byte("secret"), []byte("id"), "location")
 	c.Assert(err, jc.ErrorIsNil)
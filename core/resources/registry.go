@@ -0,0 +1,217 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/juju/errors"
+	"github.com/opencontainers/go-digest"
+)
+
+// ImageDigestResolver resolves container image references to the
+// digest of the image they refer to.
+type ImageDigestResolver interface {
+	// ResolveDigest returns the digest of the image with the given
+	// details. If the registry path already holds a digest, the
+	// registry must still hold an image with that digest.
+	ResolveDigest(details DockerImageDetails) (digest.Digest, error)
+}
+
+// PinImageDigest returns a copy of the given details that refers to
+// the image by the digest the resolver reports, so that the image is
+// not affected by its tag being pushed again. The original registry
+// path is recorded in PinnedFrom.
+func PinImageDigest(resolver ImageDigestResolver, details DockerImageDetails) (DockerImageDetails, error) {
+	named, err := reference.ParseNormalizedNamed(details.RegistryPath)
+	if err != nil {
+		return details, errors.NotValidf("docker image path %q", details.RegistryPath)
+	}
+	dgst, err := resolver.ResolveDigest(details)
+	if err != nil {
+		return details, errors.Annotatef(err, "resolving digest of image %q", details.RegistryPath)
+	}
+	pinned, err := reference.WithDigest(reference.TrimNamed(named), dgst)
+	if err != nil {
+		return details, errors.Trace(err)
+	}
+	details.PinnedFrom = details.RegistryPath
+	details.RegistryPath = reference.FamiliarString(pinned)
+	return details, nil
+}
+
+// manifestMediaTypes holds the manifest types accepted when resolving
+// an image digest, including manifest lists for multi-arch images.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// dockerHubRegistry is the host of the registry API for images on
+// Docker Hub, whose references use the domain "docker.io".
+const dockerHubRegistry = "registry-1.docker.io"
+
+// registryTokenHosts holds, for registries whose token service is on a
+// different host, the host of that service.
+var registryTokenHosts = map[string]string{
+	dockerHubRegistry: "auth.docker.io",
+}
+
+type registryDigestResolver struct {
+	client *http.Client
+}
+
+// NewRegistryDigestResolver returns an ImageDigestResolver that asks
+// the image's registry for the digest of its manifest, using the
+// Docker registry HTTP API.
+func NewRegistryDigestResolver(client *http.Client) ImageDigestResolver {
+	return &registryDigestResolver{client: client}
+}
+
+// ResolveDigest is part of the ImageDigestResolver interface.
+func (r *registryDigestResolver) ResolveDigest(details DockerImageDetails) (digest.Digest, error) {
+	named, err := reference.ParseNormalizedNamed(details.RegistryPath)
+	if err != nil {
+		return "", errors.NotValidf("docker image path %q", details.RegistryPath)
+	}
+	named = reference.TagNameOnly(named)
+	var manifest string
+	switch ref := named.(type) {
+	case reference.Canonical:
+		manifest = ref.Digest().String()
+	case reference.Tagged:
+		manifest = ref.Tag()
+	}
+	host := reference.Domain(named)
+	if host == "docker.io" {
+		host = dockerHubRegistry
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, reference.Path(named), manifest)
+
+	resp, err := r.headManifest(manifestURL, "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := r.authorize(host, resp.Header.Get("WWW-Authenticate"), details)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if resp, err = r.headManifest(manifestURL, authorization); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", errors.NotFoundf("image %q", details.RegistryPath)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", errors.Unauthorizedf("access to image %q denied", details.RegistryPath)
+	default:
+		return "", errors.Errorf("registry returned %s", resp.Status)
+	}
+	dgst, err := digest.Parse(resp.Header.Get("Docker-Content-Digest"))
+	if err != nil {
+		return "", errors.Annotate(err, "invalid digest from registry")
+	}
+	if ref, ok := named.(reference.Canonical); ok && ref.Digest() != dgst {
+		return "", errors.Errorf("registry returned digest %s, expected %s", dgst, ref.Digest())
+	}
+	return dgst, nil
+}
+
+func (r *registryDigestResolver) headManifest(manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "contacting registry")
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authorize returns the Authorization header value that satisfies the
+// given WWW-Authenticate challenge from the registry on host, getting a
+// bearer token from the registry's token service if needed. The user's
+// credentials are only sent to a token service on the registry's own
+// host, or on the host known to serve its tokens; any other token
+// service is asked for an anonymous token.
+func (r *registryDigestResolver) authorize(host, challenge string, details DockerImageDetails) (string, error) {
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	switch scheme {
+	case "basic":
+		if details.Username == "" {
+			return "", errors.Unauthorizedf("registry requires credentials")
+		}
+		req, _ := http.NewRequest(http.MethodGet, "", nil)
+		req.SetBasicAuth(details.Username, details.Password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+	default:
+		return "", errors.NotSupportedf("registry authentication %q", challenge)
+	}
+
+	params := make(map[string]string)
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" || realm.Host == "" {
+		return "", errors.NotValidf("registry token realm %q", params["realm"])
+	}
+	if realm.Scheme != "https" {
+		return "", errors.NotValidf("registry token realm %q (expected an https URL)", params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	realmHost := strings.ToLower(realm.Host)
+	if details.Username != "" && (realmHost == host || realmHost == registryTokenHosts[host]) {
+		req.SetBasicAuth(details.Username, details.Password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", errors.Annotate(err, "contacting registry token service")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Unauthorizedf("registry token service returned %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.Annotate(err, "decoding registry token")
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return "Bearer " + token.Token, nil
+}
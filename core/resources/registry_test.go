@@ -0,0 +1,184 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/opencontainers/go-digest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/resources"
+)
+
+const imageDigest = digest.Digest("sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b")
+
+type RegistrySuite struct {
+	server   *httptest.Server
+	host     string
+	requests []*http.Request
+	handler  func(w http.ResponseWriter, req *http.Request)
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) SetUpTest(c *gc.C) {
+	s.requests = nil
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Docker-Content-Digest", string(imageDigest))
+	}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.requests = append(s.requests, req)
+		s.handler(w, req)
+	}))
+	s.host = strings.TrimPrefix(s.server.URL, "https://")
+}
+
+func (s *RegistrySuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+func (s *RegistrySuite) resolve(path string) (digest.Digest, error) {
+	resolver := resources.NewRegistryDigestResolver(s.server.Client())
+	return resolver.ResolveDigest(resources.DockerImageDetails{
+		RegistryPath: path,
+		Username:     "fred",
+		Password:     "secret",
+	})
+}
+
+func (s *RegistrySuite) TestResolveTag(c *gc.C) {
+	dgst, err := s.resolve(s.host + "/team/mariadb:10.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dgst, gc.Equals, imageDigest)
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "HEAD")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/v2/team/mariadb/manifests/10.3")
+	c.Assert(s.requests[0].Header.Get("Accept"), jc.Contains, "application/vnd.docker.distribution.manifest.v2+json")
+}
+
+func (s *RegistrySuite) TestResolveDefaultTag(c *gc.C) {
+	_, err := s.resolve(s.host + "/mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/v2/mariadb/manifests/latest")
+}
+
+func (s *RegistrySuite) TestResolveDigest(c *gc.C) {
+	dgst, err := s.resolve(s.host + "/mariadb@" + string(imageDigest))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dgst, gc.Equals, imageDigest)
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/v2/mariadb/manifests/"+string(imageDigest))
+}
+
+func (s *RegistrySuite) TestResolveDigestMismatch(c *gc.C) {
+	other := digest.FromString("other")
+	_, err := s.resolve(s.host + "/mariadb@" + string(other))
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("registry returned digest %s, expected %s", imageDigest, other))
+}
+
+func (s *RegistrySuite) TestResolveNotFound(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	_, err := s.resolve(s.host + "/mariadb:10.3")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistrySuite) TestResolveBearerToken(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/token":
+			user, password, _ := req.BasicAuth()
+			c.Check(user, gc.Equals, "fred")
+			c.Check(password, gc.Equals, "secret")
+			c.Check(req.URL.Query().Get("service"), gc.Equals, "registry")
+			c.Check(req.URL.Query().Get("scope"), gc.Equals, "repository:mariadb:pull")
+			w.Write([]byte(`{"token": "sekrit"}`))
+		case req.Header.Get("Authorization") == "Bearer sekrit":
+			w.Header().Set("Docker-Content-Digest", string(imageDigest))
+		default:
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="%s/token",service="registry",scope="repository:mariadb:pull"`, s.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}
+	dgst, err := s.resolve(s.host + "/mariadb:10.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dgst, gc.Equals, imageDigest)
+	c.Assert(s.requests, gc.HasLen, 3)
+}
+
+func (s *RegistrySuite) TestResolveBearerTokenOtherHost(c *gc.C) {
+	tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _, ok := req.BasicAuth()
+		c.Check(ok, jc.IsFalse)
+		w.Write([]byte(`{"token": "anonymous"}`))
+	}))
+	defer tokenServer.Close()
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") == "Bearer anonymous" {
+			w.Header().Set("Docker-Content-Digest", string(imageDigest))
+			return
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token"`, tokenServer.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	dgst, err := s.resolve(s.host + "/mariadb:10.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dgst, gc.Equals, imageDigest)
+}
+
+func (s *RegistrySuite) TestResolveBearerTokenInsecureRealm(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+s.host+`/token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	_, err := s.resolve(s.host + "/mariadb:10.3")
+	c.Assert(err, gc.ErrorMatches, `registry token realm "http://.*/token" \(expected an https URL\) not valid`)
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *RegistrySuite) TestResolveUnauthorized(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	_, err := s.resolve(s.host + "/mariadb:10.3")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(s.requests, gc.HasLen, 2)
+	user, _, _ := s.requests[1].BasicAuth()
+	c.Assert(user, gc.Equals, "fred")
+}
+
+func (s *RegistrySuite) TestPinImageDigest(c *gc.C) {
+	pinned, err := resources.PinImageDigest(fakeDigestResolver{digest: imageDigest}, resources.DockerImageDetails{
+		RegistryPath: "mariadb:10.3",
+		Username:     "fred",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned, jc.DeepEquals, resources.DockerImageDetails{
+		RegistryPath: "mariadb@" + string(imageDigest),
+		Username:     "fred",
+		PinnedFrom:   "mariadb:10.3",
+	})
+
+	_, err = resources.PinImageDigest(fakeDigestResolver{err: errors.New("boom")}, resources.DockerImageDetails{
+		RegistryPath: "mariadb:10.3",
+	})
+	c.Assert(err, gc.ErrorMatches, `resolving digest of image "mariadb:10.3": boom`)
+}
+
+type fakeDigestResolver struct {
+	digest digest.Digest
+	err    error
+}
+
+func (r fakeDigestResolver) ResolveDigest(resources.DockerImageDetails) (digest.Digest, error) {
+	return r.digest, r.err
+}
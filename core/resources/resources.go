@@ -22,6 +22,10 @@ type DockerImageDetails struct {
 
 	// Password holds the password used to gain access to a non-public image.
 	Password string `json:"Password,omitempty" yaml:"password"`
+
+	// PinnedFrom holds the registry path the image was attached with,
+	// if RegistryPath was pinned to the digest it referred to then.
+	PinnedFrom string `json:"PinnedFrom,omitempty" yaml:"pinned-from,omitempty"`
 }
 
 // ValidateDockerRegistryPath ensures the registry path is valid (i.e. api.jujucharms.com@sha256:deadbeef)
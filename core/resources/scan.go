@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// ImageScanPolicy determines how critical vulnerabilities found in a
+// container image resource are treated.
+type ImageScanPolicy string
+
+const (
+	// ImageScanWarn records the findings of a scan, but allows the
+	// resource to be used regardless.
+	ImageScanWarn ImageScanPolicy = "warn"
+
+	// ImageScanBlock prevents the use of resources whose scan reports
+	// critical vulnerabilities, or which could not be scanned, or whose
+	// scan has not yet completed.
+	ImageScanBlock ImageScanPolicy = "block"
+)
+
+// Validate returns an error if the policy is not recognised.
+func (p ImageScanPolicy) Validate() error {
+	switch p {
+	case ImageScanWarn, ImageScanBlock:
+		return nil
+	}
+	return errors.NotValidf("image scan policy %q", p)
+}
+
+// ImageScanResult holds the outcome of scanning a container image
+// resource for vulnerabilities.
+type ImageScanResult struct {
+	// ScannedAt is when the scan was performed.
+	ScannedAt time.Time
+
+	// Critical is the number of critical vulnerabilities found.
+	Critical int
+
+	// High is the number of high severity vulnerabilities found.
+	High int
+
+	// Summary holds a human readable summary of the findings, as
+	// reported by the scanner.
+	Summary string
+
+	// Error holds the reason the image could not be scanned, if any.
+	Error string
+
+	// Pending is true while the scan has yet to complete.
+	Pending bool
+
+	// Blocked is true if the image may not be used, because the scan
+	// policy blocks images which fail, or have yet to complete, a scan.
+	Blocked bool
+}
+
+// Failed reports whether the scan found critical vulnerabilities, or
+// could not be completed.
+func (r ImageScanResult) Failed() bool {
+	return r.Critical > 0 || r.Error != ""
+}
+
+// ImageScanner scans container images for vulnerabilities.
+type ImageScanner interface {
+	// ScanImage scans the image with the given details. The time of
+	// the scan is recorded in the result even if an error is returned.
+	// The scan is abandoned if the abort channel is closed.
+	ScanImage(details DockerImageDetails, abort <-chan struct{}) (ImageScanResult, error)
+}
+
+// webhookScanRequest is the body posted to an image scanning webhook.
+type webhookScanRequest struct {
+	Image    string `json:"image"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// webhookScanResponse is the body returned by an image scanning webhook.
+type webhookScanResponse struct {
+	Critical int    `json:"critical"`
+	High     int    `json:"high"`
+	Summary  string `json:"summary"`
+}
+
+type webhookScanner struct {
+	url    string
+	client *http.Client
+	clock  clock.Clock
+}
+
+// NewWebhookImageScanner returns an ImageScanner that posts the details
+// of each image to the given URL, and expects a JSON response holding
+// the number of critical and high severity vulnerabilities found, in
+// the style of a Trivy or Clair adapter. Registry credentials are only
+// sent to https URLs; private images can not be scanned otherwise.
+func NewWebhookImageScanner(url string, client *http.Client, clock clock.Clock) ImageScanner {
+	return &webhookScanner{
+		url:    url,
+		client: client,
+		clock:  clock,
+	}
+}
+
+// ScanImage is part of the ImageScanner interface.
+func (s *webhookScanner) ScanImage(details DockerImageDetails, abort <-chan struct{}) (ImageScanResult, error) {
+	result := ImageScanResult{ScannedAt: s.clock.Now().UTC()}
+	scanReq := webhookScanRequest{Image: details.RegistryPath}
+	if details.Username != "" || details.Password != "" {
+		u, err := url.Parse(s.url)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if u.Scheme != "https" {
+			return result, errors.Errorf("not sending registry credentials to image scanner over %s", u.Scheme)
+		}
+		scanReq.Username = details.Username
+		scanReq.Password = details.Password
+	}
+	body, err := json.Marshal(scanReq)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return result, errors.Annotate(err, "contacting image scanner")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return result, errors.Errorf("image scanner returned %s", resp.Status)
+	}
+	var findings webhookScanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&findings); err != nil {
+		return result, errors.Annotate(err, "decoding image scanner response")
+	}
+	result.Critical = findings.Critical
+	result.High = findings.High
+	result.Summary = findings.Summary
+	return result, nil
+}
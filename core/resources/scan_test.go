@@ -0,0 +1,124 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/resources"
+)
+
+type ScanSuite struct{}
+
+var _ = gc.Suite(&ScanSuite{})
+
+func (s *ScanSuite) TestPolicyValidate(c *gc.C) {
+	c.Assert(resources.ImageScanWarn.Validate(), jc.ErrorIsNil)
+	c.Assert(resources.ImageScanBlock.Validate(), jc.ErrorIsNil)
+	c.Assert(resources.ImageScanPolicy("ignore").Validate(), gc.ErrorMatches, `image scan policy "ignore" not valid`)
+}
+
+func (s *ScanSuite) TestResultFailed(c *gc.C) {
+	c.Assert(resources.ImageScanResult{High: 3}.Failed(), jc.IsFalse)
+	c.Assert(resources.ImageScanResult{Critical: 1}.Failed(), jc.IsTrue)
+	c.Assert(resources.ImageScanResult{Error: "boom"}.Failed(), jc.IsTrue)
+}
+
+func (s *ScanSuite) TestWebhookScanner(c *gc.C) {
+	var received map[string]string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(json.NewDecoder(req.Body).Decode(&received), jc.ErrorIsNil)
+		w.Write([]byte(`{"critical": 1, "high": 4, "summary": "CVE-2019-0001"}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	scanner := resources.NewWebhookImageScanner(server.URL, server.Client(), testclock.NewClock(now))
+	result, err := scanner.ScanImage(resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+		Username:     "fred",
+		Password:     "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, map[string]string{
+		"image":    "registry.example.com/image@sha256:deadbeef",
+		"username": "fred",
+		"password": "secret",
+	})
+	c.Assert(result, jc.DeepEquals, resources.ImageScanResult{
+		ScannedAt: now,
+		Critical:  1,
+		High:      4,
+		Summary:   "CVE-2019-0001",
+	})
+}
+
+func (s *ScanSuite) TestWebhookScannerError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	scanner := resources.NewWebhookImageScanner(server.URL, http.DefaultClient, testclock.NewClock(now))
+	result, err := scanner.ScanImage(resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "image scanner returned 503 Service Unavailable")
+	c.Assert(result.ScannedAt, gc.Equals, now)
+}
+
+func (s *ScanSuite) TestWebhookScannerCredentialsRequireHTTPS(c *gc.C) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(json.NewDecoder(req.Body).Decode(&received), jc.ErrorIsNil)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	scanner := resources.NewWebhookImageScanner(server.URL, http.DefaultClient, testclock.NewClock(now))
+	_, err := scanner.ScanImage(resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+		Username:     "fred",
+		Password:     "secret",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, "not sending registry credentials to image scanner over http")
+	c.Assert(received, gc.IsNil)
+
+	// Public images may be scanned over http.
+	_, err = scanner.ScanImage(resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, map[string]string{
+		"image": "registry.example.com/image@sha256:deadbeef",
+	})
+}
+
+func (s *ScanSuite) TestWebhookScannerAbort(c *gc.C) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	scanner := resources.NewWebhookImageScanner(server.URL, http.DefaultClient, testclock.NewClock(now))
+	abort := make(chan struct{})
+	close(abort)
+	_, err := scanner.ScanImage(resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/image@sha256:deadbeef",
+	}, abort)
+	c.Assert(err, gc.ErrorMatches, "contacting image scanner: .*context canceled")
+}
@@ -173,6 +173,10 @@ const (
 	// Detached indicates that the storage is not attached to
 	// any machine.
 	Detached Status = "detached"
+
+	// Resizing indicates that the storage is being grown to
+	// a new size.
+	Resizing Status = "resizing"
 )
 
 const (
@@ -225,6 +229,10 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Preempted indicates that the cloud has stopped a preemptible
+	// or spot instance to reclaim its capacity.
+	Preempted Status = "preempted"
 )
 
 // ModificationStatus
@@ -261,6 +269,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Preempted,
 		Error,
 		Unknown:
 		return true
@@ -85,6 +85,8 @@ const (
 	KindUnitAgent HistoryKind = "juju-unit"
 	// KindWorkload represents a charm workload status history entry.
 	KindWorkload HistoryKind = "workload"
+	// KindWorkloadVersion represents a charm workload version history entry.
+	KindWorkloadVersion HistoryKind = "workload-version"
 	// KindMachineInstance represents an entry for a machine instance.
 	KindMachineInstance HistoryKind = "machine"
 	// KindMachine represents an entry for a machine agent.
@@ -103,7 +105,7 @@ func (k HistoryKind) String() string {
 // Valid will return true if the current kind is a valid one.
 func (k HistoryKind) Valid() bool {
 	switch k {
-	case KindUnit, KindUnitAgent, KindWorkload,
+	case KindUnit, KindUnitAgent, KindWorkload, KindWorkloadVersion,
 		KindMachineInstance, KindMachine,
 		KindContainerInstance, KindContainer:
 		return true
@@ -117,6 +119,7 @@ func AllHistoryKind() map[HistoryKind]string {
 		KindUnit:              "statuses for specified unit and its workload",
 		KindUnitAgent:         "statuses from the agent that is managing a unit",
 		KindWorkload:          "statuses for unit's workload",
+		KindWorkloadVersion:   "workload versions reported by a unit's charm",
 		KindMachineInstance:   "statuses that occur due to provisioning of a machine",
 		KindMachine:           "status of the agent that is managing a machine",
 		KindContainerInstance: "statuses from the agent that is managing containers",
@@ -87,6 +87,7 @@ func (s *StatusSuite) TestInvalidModelStatus(c *gc.C) {
 		status.Provisioning,
 		status.ProvisioningError,
 		status.Rebooting,
+		status.Resizing,
 		status.Running,
 		status.Suspending,
 		status.Started,
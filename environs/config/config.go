@@ -6,7 +6,9 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/proxy"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	"gopkg.in/juju/charmrepo.v3"
 	"gopkg.in/juju/environschema.v1"
@@ -28,6 +31,7 @@ import (
 	jujuversion "github.com/juju/juju/juju/version"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/storage"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -74,6 +78,15 @@ const (
 	// ProvisionerHarvestModeKey stores the key for this setting.
 	ProvisionerHarvestModeKey = "provisioner-harvest-mode"
 
+	// ProvisionerRetryCountKey is the key for the number of times the
+	// provisioner will retry starting an instance before the machine
+	// is left in an error state.
+	ProvisionerRetryCountKey = "provisioner-retry-count"
+
+	// ProvisionerRetryDelayKey is the key for how long the provisioner
+	// waits between attempts to start an instance, eg "10s".
+	ProvisionerRetryDelayKey = "provisioner-retry-delay"
+
 	// AgentStreamKey stores the key for this setting.
 	AgentStreamKey = "agent-stream"
 
@@ -88,6 +101,16 @@ const (
 	// of OS image metadata for containers.
 	ContainerImageMetadataURLKey = "container-image-metadata-url"
 
+	// ImageMetadataURLsKey is an optional comma-separated list of
+	// additional URLs at which image metadata is located. They are
+	// searched in order, after image-metadata-url.
+	ImageMetadataURLsKey = "image-metadata-urls"
+
+	// ImageIdsKey is an optional list or space-separated string of
+	// series/arch=image-id pairs, pinning the image used to start
+	// machines of each series and architecture.
+	ImageIdsKey = "image-ids"
+
 	// Proxy behaviour has become something of an annoying thing to define
 	// well. These following four proxy variables are being kept to continue
 	// with the existing behaviour for those deployments that specify them.
@@ -219,6 +242,14 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// HookTimeout is how long a charm hook may run before it is killed.
+	// A zero or unset value means hooks may run indefinitely.
+	HookTimeout = "hook-timeout"
+
+	// HookOutputLimit is the maximum amount of output from each charm
+	// hook or action that is logged or recorded in action results.
+	HookOutputLimit = "hook-output-limit"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -226,6 +257,11 @@ const (
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// DefaultSpaceKey is the name of the space to which all endpoints
+	// of newly deployed applications are bound, unless bound to
+	// another space when deploying.
+	DefaultSpaceKey = "default-space"
+
 	// CloudInitUserDataKey is the key to specify cloud-init yaml the user
 	// wants to add into the cloud-config data produced by Juju when
 	// provisioning machines.
@@ -239,6 +275,11 @@ const (
 	// list will be comma separated.
 	ContainerInheritPropertiesKey = "container-inherit-properties"
 
+	// CharmMirrorModeKey determines whether charms and their
+	// resources are fetched from the controller's charm store
+	// mirror rather than the charm store.
+	CharmMirrorModeKey = "charm-mirror-mode"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -399,11 +440,28 @@ const (
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
+	// DefaultHookOutputLimit is the default value for HookOutputLimit.
+	DefaultHookOutputLimit = "10M"
+
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
 )
 
+const (
+	// CharmMirrorDisabled means charms are always fetched from the
+	// charm store. It is the default CharmMirrorModeKey.
+	CharmMirrorDisabled = "disabled"
+
+	// CharmMirrorPrefer means charms are fetched from the charm store
+	// mirror when it holds them, and from the charm store otherwise.
+	CharmMirrorPrefer = "prefer"
+
+	// CharmMirrorOffline means charms are only ever fetched from the
+	// charm store mirror, so the charm store is never contacted.
+	CharmMirrorOffline = "offline"
+)
+
 var defaultConfigValues = map[string]interface{}{
 	// Network.
 	"firewall-mode":              FwInstance,
@@ -518,6 +576,24 @@ func (c *Config) ensureUnitLogging() error {
 	return nil
 }
 
+// deprecatedAttributes holds the names of the attributes that are
+// deprecated and will be removed in a future release. Attributes are
+// only listed once nothing depends on them being set.
+var deprecatedAttributes []string
+
+// DeprecatedAttributes returns the names of any deprecated attributes
+// in attrs, in sorted order.
+func DeprecatedAttributes(attrs map[string]interface{}) []string {
+	var deprecated []string
+	for _, name := range deprecatedAttributes {
+		if _, ok := attrs[name]; ok {
+			deprecated = append(deprecated, name)
+		}
+	}
+	sort.Strings(deprecated)
+	return deprecated
+}
+
 // ProcessDeprecatedAttributes gathers any deprecated attributes in attrs and adds or replaces
 // them with new name value pairs for the replacement attrs.
 // Ths ensures that older versions of Juju which require that deprecated
@@ -535,9 +611,10 @@ func ProcessDeprecatedAttributes(attrs map[string]interface{}) map[string]interf
 func CoerceForStorage(attrs map[string]interface{}) map[string]interface{} {
 	coercedAttrs := make(map[string]interface{}, len(attrs))
 	for attrName, attrValue := range attrs {
-		if attrName == ResourceTagsKey {
-			// Resource Tags are specified by the user as a string but transformed
-			// to a map when config is parsed. We want to store as a string.
+		if attrName == ResourceTagsKey || attrName == ImageIdsKey {
+			// Resource Tags and image ids are specified by the user as a string
+			// but transformed to a map when config is parsed. We want to store
+			// as a string.
 			var tagsSlice []string
 			if tags, ok := attrValue.(map[string]string); ok {
 				for resKey, resValue := range tags {
@@ -604,6 +681,16 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	for _, u := range cfg.ImageMetadataURLs() {
+		if parsed, err := url.Parse(u); err != nil || parsed.Scheme == "" {
+			return errors.Errorf("invalid image metadata URL %q in model configuration", u)
+		}
+	}
+
+	if err := validateImageIds(cfg.ImageIds()); err != nil {
+		return errors.Annotate(err, "validating image ids")
+	}
+
 	if v, ok := cfg.defined[MaxStatusHistoryAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max status history age in model configuration")
@@ -628,6 +715,20 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[ProvisionerRetryCountKey].(int); ok {
+		if v < 0 {
+			return errors.NotValidf("negative provisioner retry count %d", v)
+		}
+	}
+
+	if v, ok := cfg.defined[ProvisionerRetryDelayKey].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid provisioner retry delay in model configuration")
+		} else if d < 0 {
+			return errors.NotValidf("negative provisioner retry delay %v", d)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -641,6 +742,20 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		} else if d < 0 {
+			return errors.NotValidf("negative hook timeout %v", d)
+		}
+	}
+
+	if v, ok := cfg.defined[HookOutputLimit].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid hook output limit in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -660,6 +775,10 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v := cfg.DefaultSpace(); v != "" && !names.IsValidSpace(v) {
+		return errors.NotValidf("default space %q", v)
+	}
+
 	if v, ok := cfg.defined[ContainerNetworkingMethod].(string); ok {
 		switch v {
 		case "fan":
@@ -706,6 +825,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	for _, key := range []string{StorageDefaultBlockSourceKey, StorageDefaultFilesystemSourceKey} {
+		if pool, ok := cfg.defined[key].(string); ok && pool != "" && !storage.IsValidPoolName(pool) {
+			return errors.NotValidf("%s %q", key, pool)
+		}
+	}
+
 	if raw, ok := cfg.defined[ContainerInheritPropertiesKey].(string); ok && raw != "" {
 		rawProperties := strings.Split(raw, ",")
 		propertySet := set.NewStrings()
@@ -1116,6 +1241,48 @@ func (c *Config) ContainerImageMetadataURL() (string, bool) {
 	return "", false
 }
 
+// ImageMetadataURLs returns the additional URLs at which the metadata
+// used to locate image ids is located, in the order they are searched.
+func (c *Config) ImageMetadataURLs() []string {
+	var urls []string
+	for _, u := range strings.Split(c.asString(ImageMetadataURLsKey), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// ImageIds returns the image ids pinned for each series and
+// architecture, keyed by "series/arch".
+func (c *Config) ImageIds() map[string]string {
+	ids, _ := c.defined[ImageIdsKey].(map[string]string)
+	return ids
+}
+
+// ImageId returns the image id pinned for the given series and
+// architecture, and whether one has been pinned.
+func (c *Config) ImageId(series, arch string) (string, bool) {
+	id, ok := c.ImageIds()[series+"/"+arch]
+	return id, ok && id != ""
+}
+
+func validateImageIds(ids map[string]string) error {
+	for key, id := range ids {
+		parts := strings.Split(key, "/")
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected series/arch, got %q", key)
+		}
+		if !arch.IsSupportedArch(parts[1]) {
+			return errors.NotValidf("architecture %q for %q", parts[1], key)
+		}
+		if id == "" {
+			return errors.Errorf("empty image id for %q", key)
+		}
+	}
+	return nil
+}
+
 // Development returns whether the environment is in development mode.
 func (c *Config) Development() bool {
 	value, _ := c.defined["development"].(bool)
@@ -1195,6 +1362,27 @@ func (c *Config) ProvisionerHarvestMode() HarvestMode {
 	}
 }
 
+// ProvisionerRetryCount returns the number of times the provisioner
+// should retry starting an instance before giving up, and whether
+// the value was set in the config.
+func (c *Config) ProvisionerRetryCount() (int, bool) {
+	v, ok := c.defined[ProvisionerRetryCountKey].(int)
+	return v, ok
+}
+
+// ProvisionerRetryDelay returns how long the provisioner should wait
+// between attempts to start an instance, and whether the value was
+// set in the config.
+func (c *Config) ProvisionerRetryDelay() (time.Duration, bool) {
+	raw := c.asString(ProvisionerRetryDelayKey)
+	if raw == "" {
+		return 0, false
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val, true
+}
+
 // ImageStream returns the simplestreams stream
 // used to identify which image ids to search
 // when starting an instance.
@@ -1332,6 +1520,37 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// HookTimeout returns how long a charm hook may run before it is
+// killed. A zero duration means hooks may run indefinitely.
+func (c *Config) HookTimeout() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(HookTimeout))
+	return val
+}
+
+// HookOutputLimit returns the maximum number of bytes of output from
+// each charm hook or action that is logged or recorded in action
+// results. Zero means the output is not limited.
+func (c *Config) HookOutputLimit() int64 {
+	raw := c.asString(HookOutputLimit)
+	if raw == "" {
+		raw = DefaultHookOutputLimit
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(raw)
+	return int64(val) * 1024 * 1024
+}
+
+// CharmMirrorMode returns whether charms are fetched from the
+// controller's charm store mirror: one of CharmMirrorDisabled,
+// CharmMirrorPrefer or CharmMirrorOffline.
+func (c *Config) CharmMirrorMode() string {
+	if mode := c.asString(CharmMirrorModeKey); mode != "" {
+		return mode
+	}
+	return CharmMirrorDisabled
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1354,6 +1573,13 @@ func (c *Config) FanConfig() (network.FanConfig, error) {
 	return network.ParseFanConfig(c.asString(FanConfig))
 }
 
+// DefaultSpace returns the name of the space to which all endpoints of
+// newly deployed applications are bound by default, or "" if endpoints
+// are bound to the model's default space.
+func (c *Config) DefaultSpace() string {
+	return c.asString(DefaultSpaceKey)
+}
+
 // CloudInitUserData returns a copy of the raw user data attributes
 // that were specified by the user.
 func (c *Config) CloudInitUserData() map[string]interface{} {
@@ -1451,6 +1677,8 @@ var alwaysOptional = schema.Defaults{
 	"firewall-mode":               schema.Omit,
 	"logging-config":              schema.Omit,
 	ProvisionerHarvestModeKey:     schema.Omit,
+	ProvisionerRetryCountKey:      schema.Omit,
+	ProvisionerRetryDelayKey:      schema.Omit,
 	HTTPProxyKey:                  schema.Omit,
 	HTTPSProxyKey:                 schema.Omit,
 	FTPProxyKey:                   schema.Omit,
@@ -1478,6 +1706,8 @@ var alwaysOptional = schema.Defaults{
 	AgentMetadataURLKey:           schema.Omit,
 	ContainerImageStreamKey:       schema.Omit,
 	ContainerImageMetadataURLKey:  schema.Omit,
+	ImageMetadataURLsKey:          schema.Omit,
+	ImageIdsKey:                   schema.Omit,
 	"default-series":              schema.Omit,
 	"development":                 schema.Omit,
 	"ssl-hostname-verification":   schema.Omit,
@@ -1494,8 +1724,12 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
+	HookTimeout:                   schema.Omit,
+	HookOutputLimit:               schema.Omit,
+	CharmMirrorModeKey:            schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
+	DefaultSpaceKey:               schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
 	ContainerInheritPropertiesKey: schema.Omit,
 	BackupDirKey:                  schema.Omit,
@@ -1843,6 +2077,16 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ImageMetadataURLsKey: {
+		Description: "A comma-separated list of additional URLs at which the metadata used to locate OS image ids is located, searched in order after image-metadata-url",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ImageIdsKey: {
+		Description: "The image ids used to start machines, as series/arch=image-id pairs, overriding any found in image metadata",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
 	"logging-config": {
 		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
 		Type:        environschema.Tstring,
@@ -1862,6 +2106,16 @@ global or per instance security groups.`,
 		Values:      []interface{}{"all", "none", "unknown", "destroyed"},
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerRetryCountKey: {
+		Description: "The number of times the provisioner retries starting an instance before the machine is put into an error state (default 10)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryDelayKey: {
+		Description: "How long the provisioner waits between attempts to start an instance, in human-readable time format (default 10s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"proxy-ssh": {
 		// default: true
 		Description: `Whether SSH commands should be proxied through the API server`,
@@ -1978,6 +2232,22 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "How long a charm hook may run before it is killed, in human-readable time format (eg 30m); unset for no limit",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookOutputLimit: {
+		Description: "The maximum amount of output from each charm hook or action that is logged or recorded in action results, in human-readable size format (eg 10M); 0 for no limit",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CharmMirrorModeKey: {
+		Description: `Whether charms and resources are fetched from the controller's charmstore-mirror: "disabled" (the default), "prefer" (the mirror, then the charm store) or "offline" (only the mirror)`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{CharmMirrorDisabled, CharmMirrorPrefer, CharmMirrorOffline},
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -1988,6 +2258,11 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	DefaultSpaceKey: {
+		Description: "The space to which all endpoints of newly deployed applications are bound, unless bound to another space with --bind",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	CloudInitUserDataKey: {
 		Description: "Cloud-init user-data (in yaml format) to be added to userdata for new machines created in this model",
 		Type:        environschema.Tstring,
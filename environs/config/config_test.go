@@ -565,6 +565,20 @@ var configTests = []configTest{
 			"container-inherit-properties": "apt-security, write_files,users,apt-sources",
 		}),
 		err: `container-inherit-properties: users, write_files not allowed`,
+	}, {
+		about:       "Invalid provisioner-retry-count",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-count": -1,
+		}),
+		err: `negative provisioner retry count -1 not valid`,
+	}, {
+		about:       "Invalid provisioner-retry-delay",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-delay": "soon",
+		}),
+		err: `invalid provisioner retry delay in model configuration: time: invalid duration "?soon"?`,
 	}, {
 		about:       "String as valid value",
 		useDefaults: config.UseDefaults,
@@ -1318,6 +1332,182 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "45m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, 45*time.Minute)
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":         testing.ModelTag.Id(),
+		"hook-timeout": "-5m",
+	})
+	c.Assert(err, gc.ErrorMatches, "negative hook timeout -5m0s not valid")
+}
+
+func (s *ConfigSuite) TestHookOutputLimitConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookOutputLimit(), gc.Equals, int64(10*1024*1024))
+}
+
+func (s *ConfigSuite) TestHookOutputLimitConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-output-limit": "1G",
+	})
+	c.Assert(cfg.HookOutputLimit(), gc.Equals, int64(1024*1024*1024))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"hook-output-limit": "0",
+	})
+	c.Assert(cfg.HookOutputLimit(), gc.Equals, int64(0))
+}
+
+func (s *ConfigSuite) TestHookOutputLimitConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":              testing.ModelTag.Id(),
+		"hook-output-limit": "lots",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid hook output limit in model configuration: .*`)
+}
+
+func (s *ConfigSuite) TestStorageDefaultSourceInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":                         testing.ModelTag.Id(),
+		"storage-default-block-source": "fast ebs",
+	})
+	c.Assert(err, gc.ErrorMatches, `storage-default-block-source "fast ebs" not valid`)
+}
+
+func (s *ConfigSuite) TestCharmMirrorModeDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.CharmMirrorMode(), gc.Equals, config.CharmMirrorDisabled)
+}
+
+func (s *ConfigSuite) TestCharmMirrorModeValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"charm-mirror-mode": "offline",
+	})
+	c.Assert(cfg.CharmMirrorMode(), gc.Equals, config.CharmMirrorOffline)
+}
+
+func (s *ConfigSuite) TestCharmMirrorModeInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":              testing.ModelTag.Id(),
+		"charm-mirror-mode": "sometimes",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm-mirror-mode: expected one of \[disabled prefer offline\], got "sometimes"`)
+}
+
+func (s *ConfigSuite) TestDefaultSpace(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DefaultSpace(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{"default-space": "dmz"})
+	c.Assert(cfg.DefaultSpace(), gc.Equals, "dmz")
+}
+
+func (s *ConfigSuite) TestDefaultSpaceInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":          testing.ModelTag.Id(),
+		"default-space": "Not A Space",
+	})
+	c.Assert(err, gc.ErrorMatches, `default space "Not A Space" not valid`)
+}
+
+func (s *ConfigSuite) TestImageMetadataURLs(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ImageMetadataURLs(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"image-metadata-urls": "https://images.example.com/a, file:///srv/images,",
+	})
+	c.Assert(cfg.ImageMetadataURLs(), jc.DeepEquals, []string{
+		"https://images.example.com/a",
+		"file:///srv/images",
+	})
+}
+
+func (s *ConfigSuite) TestImageMetadataURLsInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.Attrs{
+		"type": "my-type", "name": "my-name",
+		"uuid":                testing.ModelTag.Id(),
+		"image-metadata-urls": "https://images.example.com,images.example.com",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid image metadata URL "images.example.com" in model configuration`)
+}
+
+func (s *ConfigSuite) TestImageIds(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.ImageId("bionic", "amd64")
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"image-ids": "bionic/amd64=ami-0123 bionic/arm64=ami-4567",
+	})
+	c.Assert(cfg.ImageIds(), jc.DeepEquals, map[string]string{
+		"bionic/amd64": "ami-0123",
+		"bionic/arm64": "ami-4567",
+	})
+	id, ok := cfg.ImageId("bionic", "arm64")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(id, gc.Equals, "ami-4567")
+	_, ok = cfg.ImageId("xenial", "amd64")
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestImageIdsInvalid(c *gc.C) {
+	for _, test := range []struct {
+		ids string
+		err string
+	}{{
+		ids: "bionic=ami-0123",
+		err: `validating image ids: expected series/arch, got "bionic"`,
+	}, {
+		ids: "bionic/sparc=ami-0123",
+		err: `validating image ids: architecture "sparc" for "bionic/sparc" not valid`,
+	}} {
+		_, err := config.New(config.UseDefaults, testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid":      testing.ModelTag.Id(),
+			"image-ids": test.ids,
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestProvisionerRetryConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestProvisionerRetryConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"provisioner-retry-count": 3,
+		"provisioner-retry-delay": "1m",
+	})
+	count, ok := cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(count, gc.Equals, 3)
+	delay, ok := cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(delay, gc.Equals, time.Minute)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -1404,6 +1594,23 @@ func (s *ConfigSuite) TestCoerceForStorage(c *gc.C) {
 	c.Assert(tagsMap, gc.DeepEquals, expectedTags)
 }
 
+func (s *ConfigSuite) TestDeprecatedAttributes(c *gc.C) {
+	// The machiner still honours ignore-machine-addresses.
+	c.Assert(config.DeprecatedAttributes(map[string]interface{}{
+		"ignore-machine-addresses": true,
+	}), gc.HasLen, 0)
+
+	s.PatchValue(config.DeprecatedAttributesList, []string{"test-mode", "logging-config"})
+	c.Assert(config.DeprecatedAttributes(map[string]interface{}{
+		"default-series": "bionic",
+	}), gc.HasLen, 0)
+	c.Assert(config.DeprecatedAttributes(map[string]interface{}{
+		"default-series": "bionic",
+		"logging-config": "<root>=DEBUG",
+		"test-mode":      true,
+	}), jc.DeepEquals, []string{"logging-config", "test-mode"})
+}
+
 var specializeCharmRepoTests = []struct {
 	about    string
 	testMode bool
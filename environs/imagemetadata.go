@@ -4,6 +4,7 @@
 package environs
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/juju/errors"
@@ -85,14 +86,18 @@ func ImageMetadataSources(env BootstrapEnviron) ([]simplestreams.DataSource, err
 
 	// Add configured and environment-specific datasources.
 	var sources []simplestreams.DataSource
+	verify := utils.VerifySSLHostnames
+	if !config.SSLHostnameVerification() {
+		verify = utils.NoVerifySSLHostnames
+	}
+	publicKey, _ := simplestreams.UserPublicSigningKey()
 	if userURL, ok := config.ImageMetadataURL(); ok {
-		verify := utils.VerifySSLHostnames
-		if !config.SSLHostnameVerification() {
-			verify = utils.NoVerifySSLHostnames
-		}
-		publicKey, _ := simplestreams.UserPublicSigningKey()
 		sources = append(sources, simplestreams.NewURLSignedDataSource("image-metadata-url", userURL, publicKey, verify, simplestreams.SPECIFIC_CLOUD_DATA, false))
 	}
+	for i, userURL := range config.ImageMetadataURLs() {
+		description := fmt.Sprintf("image-metadata-urls[%d]", i)
+		sources = append(sources, simplestreams.NewURLSignedDataSource(description, userURL, publicKey, verify, simplestreams.SPECIFIC_CLOUD_DATA, false))
+	}
 
 	envDataSources, err := environmentDataSources(env)
 	if err != nil {
@@ -34,7 +34,11 @@ func (s *ImageMetadataSuite) TearDownTest(c *gc.C) {
 }
 
 func (s *ImageMetadataSuite) env(c *gc.C, imageMetadataURL, stream string) environs.Environ {
-	attrs := dummy.SampleConfig()
+	return s.envWithAttrs(c, imageMetadataURL, stream, nil)
+}
+
+func (s *ImageMetadataSuite) envWithAttrs(c *gc.C, imageMetadataURL, stream string, extra testing.Attrs) environs.Environ {
+	attrs := dummy.SampleConfig().Merge(extra)
 	if stream != "" {
 		attrs = attrs.Merge(testing.Attrs{
 			"image-stream": stream,
@@ -82,6 +86,22 @@ func (s *ImageMetadataSuite) TestImageMetadataURLs(c *gc.C) {
 	})
 }
 
+func (s *ImageMetadataSuite) TestImageMetadataURLsAdditionalURLs(c *gc.C) {
+	env := s.envWithAttrs(c, "config-image-metadata-url", "", testing.Attrs{
+		"image-metadata-urls": "https://mirror-a.example.com,https://mirror-b.example.com",
+	})
+	sources, err := environs.ImageMetadataSources(env)
+	c.Assert(err, jc.ErrorIsNil)
+	sstesting.AssertExpectedSources(c, sources, []sstesting.SourceDetails{
+		{"config-image-metadata-url/", ""},
+		{"https://mirror-a.example.com/", ""},
+		{"https://mirror-b.example.com/", ""},
+		{"https://streams.canonical.com/juju/images/releases/", keys.JujuPublicKey},
+		{"http://cloud-images.ubuntu.com/releases/", imagemetadata.SimplestreamsImagesPublicKey},
+	})
+	c.Assert(sources[1].Description(), gc.Equals, "image-metadata-urls[0]")
+}
+
 func (s *ImageMetadataSuite) TestImageMetadataURLsRegisteredFuncs(c *gc.C) {
 	environs.RegisterImageDataSourceFunc("id0", func(environs.Environ) (simplestreams.DataSource, error) {
 		return simplestreams.NewURLDataSource("id0", "betwixt/releases", utils.NoVerifySSLHostnames, simplestreams.DEFAULT_CLOUD_DATA, false), nil
@@ -40,3 +40,11 @@ type InstanceFirewaller interface {
 	// address rules for that port range.
 	IngressRules(ctx context.ProviderCallContext, machineId string) ([]network.IngressRule, error)
 }
+
+// InstanceUtilizer is implemented by instances whose provider
+// reports their resource utilization.
+type InstanceUtilizer interface {
+	// Utilization returns the most recent resource utilization
+	// of the instance reported by the provider.
+	Utilization(context.ProviderCallContext) (instance.Utilization, error)
+}
@@ -154,6 +154,18 @@ type RequestFinalizeCredential interface {
 	ShouldFinalizeCredential(cloud.Credential) bool
 }
 
+// CredentialVerifier is an interface that an EnvironProvider implements
+// in order to check with the cloud that a credential is accepted, before
+// the credential is saved on the client.
+type CredentialVerifier interface {
+
+	// VerifyCredential makes a cheap, non-modifying request to the
+	// cloud described by the given spec using the spec's credential.
+	// An error satisfying common.IsCredentialNotValid is returned if
+	// the cloud rejects the credential.
+	VerifyCredential(context.ProviderCallContext, CloudSpec) error
+}
+
 // FinalizeCredentialContext is an interface passed into FinalizeCredential
 // to provide a means of interacting with the user when finalizing credentials.
 type FinalizeCredentialContext interface {
@@ -395,6 +407,17 @@ type ControllerDestroyer interface {
 	DestroyController(ctx context.ProviderCallContext, controllerUUID string) error
 }
 
+// HealthChecker is an interface that an Environ may implement if the
+// cloud it connects to may become unhealthy in a way that is worth
+// detecting before the environ is next used, such as a remote server
+// whose address or certificate changes.
+type HealthChecker interface {
+	// CheckHealth checks the health of the cloud, and returns an
+	// error if it is unhealthy. The environ uses the result until
+	// the health is next checked.
+	CheckHealth() error
+}
+
 type ResourceAdopter interface {
 	// AdoptResources is called when the model is moved from one
 	// controller to another using model migration. Some providers tag
@@ -489,6 +512,26 @@ type Firewaller interface {
 	IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error)
 }
 
+// ApplicationFirewaller is implemented by environs that can scope
+// ingress rules to the instances hosting an application's units,
+// rather than opening the ports for every instance in the model.
+type ApplicationFirewaller interface {
+	// OpenApplicationPorts opens the given port ranges for the
+	// application, on the instances of the given machines. The
+	// machines are added to any already hosting the application, so
+	// it must be called again when a unit is placed on a new machine.
+	OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, machineIds []string, rules []network.IngressRule) error
+
+	// CloseApplicationPorts closes the given port ranges for the
+	// application.
+	CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error
+
+	// ApplicationIngressRules returns the ingress rules applied to the
+	// application. As with Firewaller.IngressRules, there is only one
+	// rule for a given port range.
+	ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
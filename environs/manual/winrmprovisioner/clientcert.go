@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmprovisioner
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DefaultClientCertRenewal is how long before the WinRM client
+// certificate expires that it is replaced.
+const DefaultClientCertRenewal = 30 * 24 * time.Hour
+
+// RotateClientCert removes the WinRM client key and certificate at
+// the given paths if the certificate expires within the renewal
+// period from now, so that a new key and certificate are generated
+// when they are next loaded. Provisioning installs the client
+// certificate on the machine, replacing any installed before, so
+// machines provisioned afterwards accept the new certificate.
+// It reports whether the key and certificate were removed.
+func RotateClientCert(keyPath, certPath string, now time.Time, renewal time.Duration) (bool, error) {
+	data, err := ioutil.ReadFile(certPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return false, errors.NotValidf("WinRM client certificate %q", certPath)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, errors.Annotatef(err, "parsing WinRM client certificate %q", certPath)
+	}
+	if cert.NotAfter.After(now.Add(renewal)) {
+		return false, nil
+	}
+	logger.Infof("replacing WinRM client certificate %q, which expires on %s",
+		certPath, cert.NotAfter.UTC().Format(time.RFC3339))
+	for _, path := range []string{keyPath, certPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, errors.Trace(err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmprovisioner_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual/winrmprovisioner"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientCertSuite struct {
+	keyPath  string
+	certPath string
+}
+
+var _ = gc.Suite(&clientCertSuite{})
+
+func (s *clientCertSuite) SetUpTest(c *gc.C) {
+	dir := c.MkDir()
+	s.keyPath = filepath.Join(dir, "winrmkey.pem")
+	s.certPath = filepath.Join(dir, "winrmcert.crt")
+}
+
+func (s *clientCertSuite) writeCert(c *gc.C, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "juju winrm client cert"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	c.Assert(err, jc.ErrorIsNil)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	err = ioutil.WriteFile(s.certPath, certPEM, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(s.keyPath, []byte("key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientCertSuite) TestRotateExpiringCert(c *gc.C) {
+	now := coretesting.ZeroTime()
+	s.writeCert(c, now.Add(7*24*time.Hour))
+	rotated, err := winrmprovisioner.RotateClientCert(s.keyPath, s.certPath, now, 30*24*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated, jc.IsTrue)
+	c.Assert(s.keyPath, jc.DoesNotExist)
+	c.Assert(s.certPath, jc.DoesNotExist)
+}
+
+func (s *clientCertSuite) TestRotateValidCert(c *gc.C) {
+	now := coretesting.ZeroTime()
+	s.writeCert(c, now.Add(365*24*time.Hour))
+	rotated, err := winrmprovisioner.RotateClientCert(s.keyPath, s.certPath, now, 30*24*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated, jc.IsFalse)
+	c.Assert(s.keyPath, jc.IsNonEmptyFile)
+	c.Assert(s.certPath, jc.IsNonEmptyFile)
+}
+
+func (s *clientCertSuite) TestRotateMissingCert(c *gc.C) {
+	rotated, err := winrmprovisioner.RotateClientCert(s.keyPath, s.certPath, coretesting.ZeroTime(), time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated, jc.IsFalse)
+}
+
+func (s *clientCertSuite) TestRotateInvalidCert(c *gc.C) {
+	err := ioutil.WriteFile(s.certPath, []byte("not a cert"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = winrmprovisioner.RotateClientCert(s.keyPath, s.certPath, coretesting.ZeroTime(), time.Hour)
+	c.Assert(err, gc.ErrorMatches, `WinRM client certificate ".*" not valid`)
+	_, err = os.Stat(s.certPath)
+	c.Assert(err, jc.ErrorIsNil)
+}
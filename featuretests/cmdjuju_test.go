@@ -166,6 +166,10 @@ application-config:
     source: user
     type: string
     value: ext-host
+  juju-operator-image-path:
+    description: the docker image path used for the application's operator
+    source: unset
+    type: string
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
@@ -44,6 +44,10 @@ const (
 	// timestamps to be written in RFC3339 format.
 	JujuStatusIsoTimeEnvKey = "JUJU_STATUS_ISO_TIME"
 
+	// JujuClientStoreEnvKey is the env var naming the backend used
+	// to store client controller details, accounts and credentials.
+	JujuClientStoreEnvKey = "JUJU_CLIENT_STORE"
+
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
@@ -4,12 +4,10 @@
 package jujuclient
 
 import (
-	"io/ioutil"
 	"os"
 	"strings"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
 
@@ -25,7 +23,7 @@ func JujuAccountsPath() string {
 // ReadAccountsFile loads all accounts defined in a given file.
 // If the file is not found, it is not an error.
 func ReadAccountsFile(file string) (map[string]AccountDetails, error) {
-	data, err := ioutil.ReadFile(file)
+	data, err := readStoreFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -67,7 +65,7 @@ func WriteAccountsFile(controllerAccounts map[string]AccountDetails) error {
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal accounts")
 	}
-	return utils.AtomicWriteFile(JujuAccountsPath(), data, os.FileMode(0600))
+	return writeStoreFile(JujuAccountsPath(), data)
 }
 
 // ParseAccounts parses the given YAML bytes into accounts metadata.
@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// FileBackend is the name of the default client store backend,
+// which keeps everything in files in $XDG_DATA_HOME/juju.
+const FileBackend = "file"
+
+// NewClientStoreFunc returns a new client store.
+type NewClientStoreFunc func() (ClientStore, error)
+
+var (
+	backendsMu sync.Mutex
+	backends   = map[string]NewClientStoreFunc{
+		FileBackend:    func() (ClientStore, error) { return NewFileClientStore(), nil },
+		KeyringBackend: newKeyringClientStore,
+		VaultBackend:   newVaultClientStore,
+	}
+)
+
+// RegisterClientStore registers a client store backend with the
+// given name, which may then be selected by setting JUJU_CLIENT_STORE
+// to that name.
+func RegisterClientStore(name string, newStore NewClientStoreFunc) error {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if _, ok := backends[name]; ok {
+		return errors.AlreadyExistsf("client store backend %q", name)
+	}
+	backends[name] = newStore
+	return nil
+}
+
+// ClientStoreBackends returns the names of the registered client store
+// backends, in alphabetical order.
+func ClientStoreBackends() []string {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewClientStore returns a new client store using the backend named by
+// JUJU_CLIENT_STORE, or the file store if it is not set.
+//
+// If the backend cannot be created, the store returned fails to get or
+// update accounts, credentials and cookies, so that secrets are never
+// written to disk when another backend was asked for.
+func NewClientStore() ClientStore {
+	store, err := newClientStore(os.Getenv(osenv.JujuClientStoreEnvKey))
+	if err != nil {
+		logger.Errorf("%v", err)
+		// Drop the cause, so that the error is never
+		// mistaken for a secret not being found.
+		return NewSecretClientStore(failingSecretStore{errors.New(err.Error())})
+	}
+	return store
+}
+
+// NewCredentialStore returns a new credential store using the backend
+// named by JUJU_CLIENT_STORE, or the file store if it is not set.
+func NewCredentialStore() CredentialStore {
+	return NewClientStore()
+}
+
+func newClientStore(name string) (ClientStore, error) {
+	if name == "" {
+		name = FileBackend
+	}
+	backendsMu.Lock()
+	newStore, ok := backends[name]
+	backendsMu.Unlock()
+	if !ok {
+		return nil, errors.NotValidf("client store backend %q", name)
+	}
+	store, err := newStore()
+	return store, errors.Annotatef(err, "creating %q client store", name)
+}
+
+// failingSecretStore is a SecretStore that fails every operation with
+// the error that prevented the requested secret store being created.
+type failingSecretStore struct {
+	err error
+}
+
+// Get is part of the SecretStore interface.
+func (s failingSecretStore) Get(string) ([]byte, error) {
+	return nil, s.err
+}
+
+// Set is part of the SecretStore interface.
+func (s failingSecretStore) Set(string, []byte) error {
+	return s.err
+}
+
+// Delete is part of the SecretStore interface.
+func (s failingSecretStore) Delete(string) error {
+	return s.err
+}
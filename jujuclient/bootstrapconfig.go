@@ -4,11 +4,9 @@
 package jujuclient
 
 import (
-	"io/ioutil"
 	"os"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/juju/osenv"
@@ -23,7 +21,7 @@ func JujuBootstrapConfigPath() string {
 // ReadBootstrapConfigFile loads all bootstrap configurations defined in a
 // given file. If the file is not found, it is not an error.
 func ReadBootstrapConfigFile(file string) (map[string]BootstrapConfig, error) {
-	data, err := ioutil.ReadFile(file)
+	data, err := readStoreFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -44,7 +42,7 @@ func WriteBootstrapConfigFile(configs map[string]BootstrapConfig) error {
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal bootstrap configurations")
 	}
-	return utils.AtomicWriteFile(JujuBootstrapConfigPath(), data, os.FileMode(0600))
+	return writeStoreFile(JujuBootstrapConfigPath(), data)
 }
 
 // ParseBootstrapConfig parses the given YAML bytes into bootstrap config
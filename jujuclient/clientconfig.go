@@ -0,0 +1,296 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sort"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/pbkdf2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+)
+
+const (
+	// clientConfigBundleVersion is the version of the format of
+	// encrypted client config bundles written by this client.
+	clientConfigBundleVersion = 1
+
+	// clientConfigKeyIterations is the number of PBKDF2 iterations
+	// used to derive the bundle key from the passphrase.
+	clientConfigKeyIterations = 100000
+)
+
+// ClientConfig holds the client configuration that is moved between
+// machines in a client config bundle.
+type ClientConfig struct {
+	// Controllers holds the controllers known to the client, and
+	// the name of the current controller.
+	Controllers Controllers `yaml:"controllers"`
+
+	// Models holds the models known to the client, keyed on
+	// controller name.
+	Models map[string]*ControllerModels `yaml:"models,omitempty"`
+
+	// Accounts holds the accounts used to log in to the
+	// controllers, keyed on controller name.
+	Accounts map[string]AccountDetails `yaml:"accounts,omitempty"`
+
+	// Credentials holds the cloud credentials known to the client,
+	// keyed on cloud name. It is empty unless credentials were
+	// included when the configuration was exported.
+	Credentials map[string]cloud.CloudCredential `yaml:"credentials,omitempty"`
+}
+
+// ExportClientConfig reads the controllers, models and accounts
+// from the store, along with the cloud credentials if
+// includeCredentials is true.
+func ExportClientConfig(store ClientStore, includeCredentials bool) (*ClientConfig, error) {
+	controllers, err := store.AllControllers()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading controllers")
+	}
+	current, err := store.CurrentController()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading current controller")
+	}
+	cfg := &ClientConfig{
+		Controllers: Controllers{
+			Controllers:       controllers,
+			CurrentController: current,
+		},
+		Models:   make(map[string]*ControllerModels),
+		Accounts: make(map[string]AccountDetails),
+	}
+	for controllerName := range controllers {
+		models, err := store.AllModels(controllerName)
+		if errors.IsNotFound(err) {
+			models = nil
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading models for controller %s", controllerName)
+		}
+		currentModel, err := store.CurrentModel(controllerName)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "reading current model for controller %s", controllerName)
+		}
+		if len(models) > 0 {
+			cfg.Models[controllerName] = &ControllerModels{
+				Models:       models,
+				CurrentModel: currentModel,
+			}
+		}
+
+		account, err := store.AccountDetails(controllerName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading account for controller %s", controllerName)
+		}
+		cfg.Accounts[controllerName] = *account
+	}
+	if includeCredentials {
+		cfg.Credentials, err = store.AllCredentials()
+		if err != nil {
+			return nil, errors.Annotate(err, "reading credentials")
+		}
+	}
+	return cfg, nil
+}
+
+// ImportClientConfig writes the controllers, models, accounts and
+// credentials in the client configuration to the store. If replace is
+// false, an error satisfying errors.IsAlreadyExists is returned if any
+// of the controllers is already known to the store, and existing
+// credentials are kept in preference to imported ones of the same
+// name. The current controller of the store is only changed if it has
+// none.
+func ImportClientConfig(store ClientStore, cfg *ClientConfig, replace bool) error {
+	controllerNames := make([]string, 0, len(cfg.Controllers.Controllers))
+	for controllerName := range cfg.Controllers.Controllers {
+		controllerNames = append(controllerNames, controllerName)
+	}
+	sort.Strings(controllerNames)
+
+	// Check for clashes before changing anything, so that a
+	// failed import does not leave a partial configuration.
+	if !replace {
+		for _, controllerName := range controllerNames {
+			_, err := store.ControllerByName(controllerName)
+			if err == nil {
+				return errors.AlreadyExistsf("controller %s", controllerName)
+			} else if !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	for _, controllerName := range controllerNames {
+		details := cfg.Controllers.Controllers[controllerName]
+		err := store.AddController(controllerName, details)
+		if errors.IsAlreadyExists(err) && replace {
+			err = store.UpdateController(controllerName, details)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "importing controller %s", controllerName)
+		}
+		if account, ok := cfg.Accounts[controllerName]; ok {
+			if err := store.UpdateAccount(controllerName, account); err != nil {
+				return errors.Annotatef(err, "importing account for controller %s", controllerName)
+			}
+		}
+		models, ok := cfg.Models[controllerName]
+		if !ok || models == nil {
+			continue
+		}
+		if err := store.SetModels(controllerName, models.Models); err != nil {
+			return errors.Annotatef(err, "importing models for controller %s", controllerName)
+		}
+		if models.CurrentModel == "" {
+			continue
+		}
+		if err := store.SetCurrentModel(controllerName, models.CurrentModel); err != nil {
+			return errors.Annotatef(err, "setting current model for controller %s", controllerName)
+		}
+	}
+
+	for cloudName, imported := range cfg.Credentials {
+		if err := importCloudCredential(store, cloudName, imported, replace); err != nil {
+			return errors.Annotatef(err, "importing credentials for cloud %s", cloudName)
+		}
+	}
+
+	current := cfg.Controllers.CurrentController
+	if current == "" {
+		return nil
+	}
+	if _, err := store.CurrentController(); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(store.SetCurrentController(current), "setting current controller")
+}
+
+// importCloudCredential merges the imported credentials for a cloud
+// with those already in the store.
+func importCloudCredential(store ClientStore, cloudName string, imported cloud.CloudCredential, replace bool) error {
+	existing, err := store.CredentialForCloud(cloudName)
+	if errors.IsNotFound(err) {
+		return errors.Trace(store.UpdateCredential(cloudName, imported))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	merged := *existing
+	merged.AuthCredentials = make(map[string]cloud.Credential)
+	for name, credential := range existing.AuthCredentials {
+		merged.AuthCredentials[name] = credential
+	}
+	for name, credential := range imported.AuthCredentials {
+		if _, ok := merged.AuthCredentials[name]; ok && !replace {
+			continue
+		}
+		merged.AuthCredentials[name] = credential
+	}
+	if merged.DefaultCredential == "" || replace && imported.DefaultCredential != "" {
+		merged.DefaultCredential = imported.DefaultCredential
+	}
+	if merged.DefaultRegion == "" || replace && imported.DefaultRegion != "" {
+		merged.DefaultRegion = imported.DefaultRegion
+	}
+	return errors.Trace(store.UpdateCredential(cloudName, merged))
+}
+
+// clientConfigBundle is the serialisation format of an encrypted
+// client config bundle.
+type clientConfigBundle struct {
+	Version    int    `yaml:"version"`
+	Iterations int    `yaml:"iterations"`
+	Salt       string `yaml:"salt"`
+	Nonce      string `yaml:"nonce"`
+	Data       string `yaml:"data"`
+}
+
+// EncryptClientConfig serialises the client configuration into a
+// bundle encrypted with a key derived from the passphrase.
+func EncryptClientConfig(cfg *ClientConfig, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.NotValidf("empty passphrase")
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot marshal client config")
+	}
+	var salt [32]byte
+	var nonce [24]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := clientConfigKey(passphrase, salt[:], clientConfigKeyIterations)
+	sealed := secretbox.Seal(nil, data, &nonce, key)
+	bundle, err := yaml.Marshal(clientConfigBundle{
+		Version:    clientConfigBundleVersion,
+		Iterations: clientConfigKeyIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt[:]),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce[:]),
+		Data:       base64.StdEncoding.EncodeToString(sealed),
+	})
+	return bundle, errors.Annotate(err, "cannot marshal client config bundle")
+}
+
+// DecryptClientConfig decrypts a bundle written by EncryptClientConfig
+// using the passphrase it was encrypted with.
+func DecryptClientConfig(data []byte, passphrase string) (*ClientConfig, error) {
+	var bundle clientConfigBundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal client config bundle")
+	}
+	if bundle.Version != clientConfigBundleVersion {
+		return nil, errors.NotSupportedf("client config bundle version %d", bundle.Version)
+	}
+	if bundle.Iterations <= 0 {
+		return nil, errors.NotValidf("key iterations %d", bundle.Iterations)
+	}
+	salt, err := base64.StdEncoding.DecodeString(bundle.Salt)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode salt")
+	}
+	nonceBytes, err := base64.StdEncoding.DecodeString(bundle.Nonce)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode nonce")
+	}
+	var nonce [24]byte
+	if len(nonceBytes) != len(nonce) {
+		return nil, errors.NotValidf("nonce length %d", len(nonceBytes))
+	}
+	copy(nonce[:], nonceBytes)
+	sealed, err := base64.StdEncoding.DecodeString(bundle.Data)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode client config")
+	}
+
+	key := clientConfigKey(passphrase, salt, bundle.Iterations)
+	opened, ok := secretbox.Open(nil, sealed, &nonce, key)
+	if !ok {
+		return nil, errors.New("cannot decrypt client config bundle: wrong passphrase or corrupt bundle")
+	}
+	var cfg ClientConfig
+	if err := yaml.Unmarshal(opened, &cfg); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal client config")
+	}
+	return &cfg, nil
+}
+
+func clientConfigKey(passphrase string, salt []byte, iterations int) *[32]byte {
+	var key [32]byte
+	copy(key[:], pbkdf2.Key([]byte(passphrase), salt, iterations, len(key), sha256.New))
+	return &key
+}
@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ClientConfigSuite struct {
+	testing.BaseSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ClientConfigSuite{})
+
+func (s *ClientConfigSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["prod"] = jujuclient.ControllerDetails{
+		ControllerUUID: "prod-uuid",
+		APIEndpoints:   []string{"10.0.0.1:17070"},
+		CACert:         "prod-ca-cert",
+		Cloud:          "aws",
+	}
+	s.store.CurrentControllerName = "prod"
+	s.store.Models["prod"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/default": {ModelUUID: "default-uuid", ModelType: model.IAAS},
+		},
+		CurrentModel: "admin/default",
+	}
+	s.store.Accounts["prod"] = jujuclient.AccountDetails{
+		User:     "admin",
+		Password: "hunter2",
+	}
+	s.store.Credentials["aws"] = cloud.CloudCredential{
+		DefaultCredential: "bob",
+		AuthCredentials: map[string]cloud.Credential{
+			"bob": cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			}),
+		},
+	}
+}
+
+func (s *ClientConfigSuite) TestExportClientConfig(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Controllers, jc.DeepEquals, jujuclient.Controllers{
+		Controllers:       s.store.Controllers,
+		CurrentController: "prod",
+	})
+	c.Assert(cfg.Models, jc.DeepEquals, s.store.Models)
+	c.Assert(cfg.Accounts, jc.DeepEquals, s.store.Accounts)
+	c.Assert(cfg.Credentials, gc.HasLen, 0)
+}
+
+func (s *ClientConfigSuite) TestExportClientConfigWithCredentials(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Credentials, jc.DeepEquals, s.store.Credentials)
+}
+
+func (s *ClientConfigSuite) TestEncryptDecryptRoundTrip(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, true)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := jujuclient.EncryptClientConfig(cfg, "passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Not(jc.Contains), "hunter2")
+
+	decrypted, err := jujuclient.DecryptClientConfig(data, "passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, jc.DeepEquals, cfg)
+}
+
+func (s *ClientConfigSuite) TestDecryptWrongPassphrase(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := jujuclient.EncryptClientConfig(cfg, "passphrase")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuclient.DecryptClientConfig(data, "wrong")
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt client config bundle: wrong passphrase or corrupt bundle")
+}
+
+func (s *ClientConfigSuite) TestEncryptEmptyPassphrase(c *gc.C) {
+	_, err := jujuclient.EncryptClientConfig(&jujuclient.ClientConfig{}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ClientConfigSuite) TestImportClientConfig(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := jujuclient.NewMemStore()
+	err = jujuclient.ImportClientConfig(target, cfg, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Controllers, jc.DeepEquals, s.store.Controllers)
+	c.Assert(target.CurrentControllerName, gc.Equals, "prod")
+	c.Assert(target.Models, jc.DeepEquals, s.store.Models)
+	c.Assert(target.Accounts, jc.DeepEquals, s.store.Accounts)
+	c.Assert(target.Credentials, jc.DeepEquals, s.store.Credentials)
+}
+
+func (s *ClientConfigSuite) TestImportClientConfigKeepsCurrentController(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := jujuclient.NewMemStore()
+	target.Controllers["dev"] = jujuclient.ControllerDetails{
+		ControllerUUID: "dev-uuid",
+		CACert:         "dev-ca-cert",
+	}
+	target.CurrentControllerName = "dev"
+	err = jujuclient.ImportClientConfig(target, cfg, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Controllers, gc.HasLen, 2)
+	c.Assert(target.CurrentControllerName, gc.Equals, "dev")
+}
+
+func (s *ClientConfigSuite) TestImportClientConfigControllerExists(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.Accounts["prod"] = jujuclient.AccountDetails{User: "bob"}
+
+	err = jujuclient.ImportClientConfig(s.store, cfg, false)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(s.store.Accounts["prod"].User, gc.Equals, "admin")
+}
+
+func (s *ClientConfigSuite) TestImportClientConfigReplace(c *gc.C) {
+	cfg, err := jujuclient.ExportClientConfig(s.store, false)
+	c.Assert(err, jc.ErrorIsNil)
+	details := cfg.Controllers.Controllers["prod"]
+	details.APIEndpoints = []string{"10.0.0.2:17070"}
+	cfg.Controllers.Controllers["prod"] = details
+	cfg.Accounts["prod"] = jujuclient.AccountDetails{User: "bob"}
+
+	err = jujuclient.ImportClientConfig(s.store, cfg, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.Controllers["prod"].APIEndpoints, jc.DeepEquals, []string{"10.0.0.2:17070"})
+	c.Assert(s.store.Accounts["prod"].User, gc.Equals, "bob")
+}
+
+func (s *ClientConfigSuite) TestImportClientConfigMergesCredentials(c *gc.C) {
+	target := jujuclient.NewMemStore()
+	existing := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "other-key",
+		"secret-key": "other-secret",
+	})
+	target.Credentials["aws"] = cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			"bob":   existing,
+			"alice": existing,
+		},
+	}
+	cfg := &jujuclient.ClientConfig{
+		Credentials: map[string]cloud.CloudCredential{
+			"aws": s.store.Credentials["aws"],
+		},
+	}
+	err := jujuclient.ImportClientConfig(target, cfg, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.Credentials["aws"], jc.DeepEquals, cloud.CloudCredential{
+		DefaultCredential: "bob",
+		AuthCredentials: map[string]cloud.Credential{
+			"bob":   existing,
+			"alice": existing,
+		},
+	})
+}
@@ -4,7 +4,6 @@
 package jujuclient
 
 import (
-	"io/ioutil"
 	"os"
 
 	"github.com/juju/errors"
@@ -23,7 +22,7 @@ func JujuControllersPath() string {
 // ReadControllersFile loads all controllers defined in a given file.
 // If the file is not found, it is not an error.
 func ReadControllersFile(file string) (*Controllers, error) {
-	data, err := ioutil.ReadFile(file)
+	data, err := readStoreFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &Controllers{}, nil
@@ -53,7 +52,7 @@ func WriteControllersFile(controllers *Controllers) error {
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal yaml controllers")
 	}
-	return utils.AtomicWriteFile(JujuControllersPath(), data, os.FileMode(0600))
+	return writeStoreFile(JujuControllersPath(), data)
 }
 
 // ParseControllers parses the given YAML bytes into controllers metadata.
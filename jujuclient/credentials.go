@@ -4,11 +4,9 @@
 package jujuclient
 
 import (
-	"io/ioutil"
 	"os"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cloud"
@@ -24,7 +22,7 @@ func JujuCredentialsPath() string {
 // ReadCredentialsFile loads all credentials defined in a given file.
 // If the file is not found, it is not an error.
 func ReadCredentialsFile(file string) (*cloud.CredentialCollection, error) {
-	data, err := ioutil.ReadFile(file)
+	data, err := readStoreFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &cloud.CredentialCollection{}, nil
@@ -45,5 +43,5 @@ func WriteCredentialsFile(credentials *cloud.CredentialCollection) error {
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal yaml credentials")
 	}
-	return utils.AtomicWriteFile(JujuCredentialsPath(), data, os.FileMode(0600))
+	return writeStoreFile(JujuCredentialsPath(), data)
 }
@@ -0,0 +1,26 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import "github.com/juju/mutex"
+
+var RunKeyringCommand = &runKeyringCommand
+
+// NewKeyringSecretStore returns the keyring secret store used on the
+// given OS, without checking that the keyring tool is installed.
+func NewKeyringSecretStore(goos string) (SecretStore, error) {
+	return newKeyringSecretStore(goos)
+}
+
+// NewKeyringError returns the error returned when the
+// keyring tool exits with the given status.
+func NewKeyringError(tool string, exitCode int) error {
+	return &keyringError{tool: tool, exitCode: exitCode}
+}
+
+// LockFiles acquires the store's locks guarding updates to the
+// files with the given paths.
+func LockFiles(s ClientStore, paths ...string) (mutex.Releaser, error) {
+	return s.(*store).lock(paths...)
+}
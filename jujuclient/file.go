@@ -19,6 +19,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/mutex"
 	cookiejar "github.com/juju/persistent-cookiejar"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/model"
@@ -39,57 +40,77 @@ var (
 // NewFileClientStore returns a new filesystem-based client store
 // that manages files in $XDG_DATA_HOME/juju.
 func NewFileClientStore() ClientStore {
-	return &store{
-		lockName: generateStoreLockName(),
-	}
+	return &store{}
 }
 
 // NewFileCredentialStore returns a new filesystem-based credentials store
 // that manages credentials in $XDG_DATA_HOME/juju.
 func NewFileCredentialStore() CredentialStore {
-	return &store{
-		lockName: generateStoreLockName(),
-	}
+	return &store{}
 }
 
+// store is a client store backed by the files in $XDG_DATA_HOME/juju.
+//
+// Reads take no locks: files are only ever replaced by renaming a
+// complete new file over the old one, so a reader always sees either
+// the old or the new content. Writes lock only the files they modify,
+// so that updating one file does not block commands using another.
 type store struct {
-	lockName string
+	// secrets, if set, holds the accounts, credentials and cookies
+	// in place of the files in $XDG_DATA_HOME/juju.
+	secrets SecretStore
 }
 
-// generateStoreLockName uses part of the hash of the controller path as the
-// name of the lock. This is to avoid contention between multiple users on a
-// single machine with different controller files, but also helps with
-// contention in tests.
-func generateStoreLockName() string {
+// fileLockName uses part of the hash of the file path as the name of
+// the lock guarding updates to the file. This is to avoid contention
+// between multiple users on a single machine with different files, but
+// also helps with contention in tests.
+func fileLockName(path string) string {
 	h := sha256.New()
-	h.Write([]byte(JujuControllersPath()))
+	h.Write([]byte(path))
 	fullHash := fmt.Sprintf("%x", h.Sum(nil))
 	return fmt.Sprintf("store-lock-%x", fullHash[:8])
 }
 
-func (s *store) acquireLock() (mutex.Releaser, error) {
-	spec := mutex.Spec{
-		Name:    s.lockName,
-		Clock:   clock.WallClock,
-		Delay:   20 * time.Millisecond,
-		Timeout: lockTimeout,
+// lock acquires the locks guarding updates to the files with the
+// given paths. The locks are always acquired in the same order, so
+// that writers locking overlapping sets of files cannot deadlock.
+func (s *store) lock(paths ...string) (mutex.Releaser, error) {
+	names := set.NewStrings()
+	for _, path := range paths {
+		names.Add(fileLockName(path))
+	}
+	var releasers releasers
+	for _, name := range names.SortedValues() {
+		spec := mutex.Spec{
+			Name:    name,
+			Clock:   clock.WallClock,
+			Delay:   20 * time.Millisecond,
+			Timeout: lockTimeout,
+		}
+		releaser, err := mutex.Acquire(spec)
+		if err != nil {
+			releasers.Release()
+			return nil, errors.Trace(err)
+		}
+		releasers = append(releasers, releaser)
 	}
-	releaser, err := mutex.Acquire(spec)
-	if err != nil {
-		return nil, errors.Trace(err)
+	return releasers, nil
+}
+
+// releasers releases a set of locks in the reverse of the order
+// they were acquired.
+type releasers []mutex.Releaser
+
+// Release implements mutex.Releaser.
+func (r releasers) Release() {
+	for i := len(r) - 1; i >= 0; i-- {
+		r[i].Release()
 	}
-	return releaser, nil
 }
 
 // AllControllers implements ControllersGetter.
 func (s *store) AllControllers() (map[string]ControllerDetails, error) {
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, errors.Annotate(err,
-			"cannot acquire lock file to read all the controllers",
-		)
-	}
-	defer releaser.Release()
 	controllers, err := ReadControllersFile(JujuControllersPath())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -99,13 +120,6 @@ func (s *store) AllControllers() (map[string]ControllerDetails, error) {
 
 // CurrentController implements ControllersGetter.
 func (s *store) CurrentController() (string, error) {
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return "", errors.Annotate(err,
-			"cannot acquire lock file to get the current controller name",
-		)
-	}
-	defer releaser.Release()
 	controllers, err := ReadControllersFile(JujuControllersPath())
 	if err != nil {
 		return "", errors.Trace(err)
@@ -122,14 +136,6 @@ func (s *store) ControllerByName(name string) (*ControllerDetails, error) {
 		return nil, errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, errors.Annotatef(err,
-			"cannot acquire lock file to read controller %s", name,
-		)
-	}
-	defer releaser.Release()
-
 	controllers, err := ReadControllersFile(JujuControllersPath())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -142,12 +148,6 @@ func (s *store) ControllerByName(name string) (*ControllerDetails, error) {
 
 // ControllerByEndpoints implements ControllersGetter.
 func (s *store) ControllerByAPIEndpoints(endpoints ...string) (*ControllerDetails, string, error) {
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, "", errors.Annotatef(err, "cannot acquire lock file to read controllers")
-	}
-	defer releaser.Release()
-
 	controllers, err := ReadControllersFile(JujuControllersPath())
 	if err != nil {
 		return nil, "", errors.Trace(err)
@@ -173,7 +173,7 @@ func (s *store) AddController(name string, details ControllerDetails) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuControllersPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file to add controller %s", name,
@@ -214,7 +214,7 @@ func (s *store) UpdateController(name string, details ControllerDetails) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuControllersPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file to update controller %s", name,
@@ -252,7 +252,7 @@ func (s *store) SetCurrentController(name string) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuControllersPath())
 	if err != nil {
 		return errors.Annotate(err,
 			"cannot acquire lock file to set the current controller name",
@@ -280,7 +280,7 @@ func (s *store) RemoveController(name string) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuControllersPath(), JujuModelsPath(), JujuAccountsPath(), JujuBootstrapConfigPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file to remove controller %s", name,
@@ -324,14 +324,14 @@ func (s *store) RemoveController(name string) error {
 	}
 
 	// Remove accounts for the controller.
-	controllerAccounts, err := ReadAccountsFile(JujuAccountsPath())
+	controllerAccounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	for _, name := range names {
 		if _, ok := controllerAccounts[name]; ok {
 			delete(controllerAccounts, name)
-			if err := WriteAccountsFile(controllerAccounts); err != nil {
+			if err := s.writeAccounts(controllerAccounts); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -353,8 +353,7 @@ func (s *store) RemoveController(name string) error {
 
 	// Remove the controller cookie jars.
 	for _, name := range names {
-		err := os.Remove(JujuCookiePath(name))
-		if err != nil && !os.IsNotExist(err) {
+		if err := s.removeCookies(name); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -373,7 +372,7 @@ func (s *store) UpdateModel(controllerName, modelName string, details ModelDetai
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuModelsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for updating model %s on controller %s", modelName, controllerName,
@@ -409,7 +408,7 @@ func (s *store) SetCurrentModel(controllerName, modelName string) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuModelsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for setting current model %s on controller %s", modelName, controllerName,
@@ -449,14 +448,6 @@ func (s *store) AllModels(controllerName string) (map[string]ModelDetails, error
 		return nil, errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, errors.Annotatef(err,
-			"cannot acquire lock file for getting all models for controller %s", controllerName,
-		)
-	}
-	defer releaser.Release()
-
 	all, err := ReadModelsFile(JujuModelsPath())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -477,14 +468,6 @@ func (s *store) CurrentModel(controllerName string) (string, error) {
 		return "", errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return "", errors.Annotatef(err,
-			"cannot acquire lock file for getting current model for controller %s", controllerName,
-		)
-	}
-	defer releaser.Release()
-
 	all, err := ReadModelsFile(JujuModelsPath())
 	if err != nil {
 		return "", errors.Trace(err)
@@ -514,14 +497,6 @@ func (s *store) ModelByName(controllerName, modelName string) (*ModelDetails, er
 		return nil, errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, errors.Annotatef(err,
-			"cannot acquire lock file for getting model %s for controller %s", modelName, controllerName,
-		)
-	}
-	defer releaser.Release()
-
 	all, err := ReadModelsFile(JujuModelsPath())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -554,7 +529,7 @@ func (s *store) RemoveModel(controllerName, modelName string) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuModelsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for removing model %s on controller %s", modelName, controllerName,
@@ -618,7 +593,7 @@ func (s *store) SetModels(controllerName string, models map[string]ModelDetails)
 		}
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuModelsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for setting models on controller %s", controllerName,
@@ -667,7 +642,7 @@ func (s *store) UpdateAccount(controllerName string, details AccountDetails) err
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuAccountsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for updating an account on controller %s", controllerName,
@@ -675,7 +650,7 @@ func (s *store) UpdateAccount(controllerName string, details AccountDetails) err
 	}
 	defer releaser.Release()
 
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -692,7 +667,7 @@ func (s *store) UpdateAccount(controllerName string, details AccountDetails) err
 	}
 
 	accounts[controllerName] = details
-	return errors.Trace(WriteAccountsFile(accounts))
+	return errors.Trace(s.writeAccounts(accounts))
 }
 
 // AccountByName implements AccountGetter.
@@ -701,15 +676,7 @@ func (s *store) AccountDetails(controllerName string) (*AccountDetails, error) {
 		return nil, errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
-	if err != nil {
-		return nil, errors.Annotatef(err,
-			"cannot acquire lock file for getting an account details on controller %s", controllerName,
-		)
-	}
-	defer releaser.Release()
-
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -726,7 +693,7 @@ func (s *store) RemoveAccount(controllerName string) error {
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuAccountsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for removing an account on controller %s", controllerName,
@@ -734,7 +701,7 @@ func (s *store) RemoveAccount(controllerName string) error {
 	}
 	defer releaser.Release()
 
-	accounts, err := ReadAccountsFile(JujuAccountsPath())
+	accounts, err := s.readAccounts()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -743,12 +710,12 @@ func (s *store) RemoveAccount(controllerName string) error {
 	}
 
 	delete(accounts, controllerName)
-	return errors.Trace(WriteAccountsFile(accounts))
+	return errors.Trace(s.writeAccounts(accounts))
 }
 
 // UpdateCredential implements CredentialUpdater.
 func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential) error {
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuCredentialsPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for updating credentials for %s", cloudName,
@@ -756,18 +723,18 @@ func (s *store) UpdateCredential(cloudName string, details cloud.CloudCredential
 	}
 	defer releaser.Release()
 
-	credentials, err := ReadCredentialsFile(JujuCredentialsPath())
+	credentials, err := s.readCredentials()
 	if err != nil {
 		return errors.Annotate(err, "cannot get credentials")
 	}
 
 	credentials.UpdateCloudCredential(cloudName, details)
-	return WriteCredentialsFile(credentials)
+	return s.writeCredentials(credentials)
 }
 
 // CredentialForCloud implements CredentialGetter.
 func (s *store) CredentialForCloud(cloudName string) (*cloud.CloudCredential, error) {
-	credentialCollection, err := ReadCredentialsFile(JujuCredentialsPath())
+	credentialCollection, err := s.readCredentials()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -780,7 +747,7 @@ func (s *store) CredentialForCloud(cloudName string) (*cloud.CloudCredential, er
 
 // AllCredentials implements CredentialGetter.
 func (s *store) AllCredentials() (map[string]cloud.CloudCredential, error) {
-	credentialCollection, err := ReadCredentialsFile(JujuCredentialsPath())
+	credentialCollection, err := s.readCredentials()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -805,7 +772,7 @@ func (s *store) UpdateBootstrapConfig(controllerName string, cfg BootstrapConfig
 		return errors.Trace(err)
 	}
 
-	releaser, err := s.acquireLock()
+	releaser, err := s.lock(JujuBootstrapConfigPath())
 	if err != nil {
 		return errors.Annotatef(err,
 			"cannot acquire lock file for updating the bootstrap config for controller %s", controllerName,
@@ -838,11 +805,86 @@ func (s *store) BootstrapConfigForController(controllerName string) (*BootstrapC
 	return &cfg, nil
 }
 
+// readAccounts returns the accounts held in the secret store if
+// there is one, or else in the accounts file.
+func (s *store) readAccounts() (map[string]AccountDetails, error) {
+	if s.secrets == nil {
+		return ReadAccountsFile(JujuAccountsPath())
+	}
+	data, err := s.secrets.Get(accountsSecretKey)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get accounts")
+	}
+	return ParseAccounts(data)
+}
+
+// writeAccounts writes the accounts to the secret store if there is
+// one, or else to the accounts file.
+func (s *store) writeAccounts(accounts map[string]AccountDetails) error {
+	if s.secrets == nil {
+		return WriteAccountsFile(accounts)
+	}
+	data, err := yaml.Marshal(accountsCollection{accounts})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal accounts")
+	}
+	return errors.Annotate(s.secrets.Set(accountsSecretKey, data), "cannot store accounts")
+}
+
+// readCredentials returns the credentials held in the secret store if
+// there is one, or else in the credentials file.
+func (s *store) readCredentials() (*cloud.CredentialCollection, error) {
+	if s.secrets == nil {
+		return ReadCredentialsFile(JujuCredentialsPath())
+	}
+	data, err := s.secrets.Get(credentialsSecretKey)
+	if errors.IsNotFound(err) {
+		return &cloud.CredentialCollection{}, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get credentials")
+	}
+	return cloud.ParseCredentialCollection(data)
+}
+
+// writeCredentials writes the credentials to the secret store if there
+// is one, or else to the credentials file.
+func (s *store) writeCredentials(credentials *cloud.CredentialCollection) error {
+	if s.secrets == nil {
+		return WriteCredentialsFile(credentials)
+	}
+	data, err := yaml.Marshal(credentials)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal yaml credentials")
+	}
+	return errors.Annotate(s.secrets.Set(credentialsSecretKey, data), "cannot store credentials")
+}
+
+// removeCookies removes the cookies associated with the given controller.
+func (s *store) removeCookies(controllerName string) error {
+	if s.secrets == nil {
+		err := os.Remove(JujuCookiePath(controllerName))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	err := s.secrets.Delete(cookiesSecretKey(controllerName))
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // CookieJar returns the cookie jar associated with the given controller.
 func (s *store) CookieJar(controllerName string) (CookieJar, error) {
 	if err := ValidateControllerName(controllerName); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if s.secrets != nil {
+		return newSecretCookieJar(s.secrets, controllerName)
+	}
 	path := JujuCookiePath(controllerName)
 	jar, err := cookiejar.New(&cookiejar.Options{
 		Filename: path,
@@ -872,5 +914,11 @@ func (jar *cookieJar) Save() error {
 // JujuCookiePath is the location where cookies associated
 // with the given controller are expected to be found.
 func JujuCookiePath(controllerName string) string {
-	return osenv.JujuXDGDataHomePath("cookies", controllerName+".json")
+	return filepath.Join(JujuCookiesDir(), controllerName+".json")
+}
+
+// JujuCookiesDir is the directory holding the cookies
+// associated with all controllers.
+func JujuCookiesDir() string {
+	return osenv.JujuXDGDataHomePath("cookies")
 }
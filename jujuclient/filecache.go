@@ -0,0 +1,132 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/utils"
+)
+
+// fileWatcher reports changes to the files in watched directories.
+type fileWatcher interface {
+	// Watch starts watching the files in the given directory.
+	// Watching a directory more than once has no effect.
+	Watch(dir string) error
+
+	// Changes returns the paths of the files that have changed
+	// since Changes was last called. If all is true, the set of
+	// changes is incomplete and any watched file may have changed.
+	Changes() (paths []string, all bool, err error)
+
+	// Close stops watching all directories.
+	Close() error
+}
+
+// fileCache caches the contents of the client store files, so that
+// commands reading the store many times do not reread and reparse
+// files that have not changed. A cached file is discarded as soon
+// as a change to it is reported by the watcher; without a watcher,
+// nothing is cached.
+type fileCache struct {
+	mu      sync.Mutex
+	watcher fileWatcher
+	entries map[string][]byte
+}
+
+// storeFiles caches the files read by the client store.
+var storeFiles = newFileCache()
+
+func newFileCache() *fileCache {
+	watcher, err := newFileWatcher()
+	if err != nil {
+		logger.Debugf("not caching client store files: %v", err)
+		watcher = nil
+	}
+	return &fileCache{
+		watcher: watcher,
+		entries: make(map[string][]byte),
+	}
+}
+
+// read returns the contents of the file with the given path. The
+// returned data may be shared, and must not be modified.
+func (c *fileCache) read(path string) ([]byte, error) {
+	path = filepath.Clean(path)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watcher == nil {
+		return ioutil.ReadFile(path)
+	}
+	// Apply all changes made before the read started, so that
+	// the caller sees every write that has already completed.
+	c.applyChanges()
+	if c.watcher == nil {
+		return ioutil.ReadFile(path)
+	}
+	if data, ok := c.entries[path]; ok {
+		return data, nil
+	}
+
+	// The directory must be watched before the file is read, so
+	// that any change made after reading it is reported.
+	if err := c.watcher.Watch(filepath.Dir(path)); err != nil {
+		logger.Debugf("not caching %q: %v", path, err)
+		return ioutil.ReadFile(path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[path] = data
+	return data, nil
+}
+
+// applyChanges discards the cached contents of all changed files.
+// If the watcher fails, it is closed and caching is disabled.
+func (c *fileCache) applyChanges() {
+	paths, all, err := c.watcher.Changes()
+	if err != nil {
+		logger.Debugf("no longer caching client store files: %v", err)
+		if err := c.watcher.Close(); err != nil {
+			logger.Debugf("closing file watcher: %v", err)
+		}
+		c.watcher = nil
+		all = true
+	}
+	if all {
+		c.entries = make(map[string][]byte)
+		return
+	}
+	for _, path := range paths {
+		delete(c.entries, path)
+	}
+}
+
+// invalidate discards the cached contents of the file with the given
+// path. It is called after writing a file, so that the new content is
+// read even if the change has not yet been reported by the watcher.
+func (c *fileCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, filepath.Clean(path))
+}
+
+// readStoreFile returns the contents of the client store file with
+// the given path. The returned data must not be modified.
+func readStoreFile(path string) ([]byte, error) {
+	return storeFiles.read(path)
+}
+
+// writeStoreFile writes the client store file with the given path.
+// The new content is written to a temporary file which is then
+// renamed over the old file, so that concurrent readers see either
+// the old content or the new content, and never a partial write.
+func writeStoreFile(path string, data []byte) error {
+	defer storeFiles.invalidate(path)
+	return utils.AtomicWriteFile(path, data, os.FileMode(0600))
+}
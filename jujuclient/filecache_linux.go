@@ -0,0 +1,122 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"github.com/juju/errors"
+)
+
+// inotifyMask holds the events that indicate a file in a watched
+// directory, or the directory itself, has changed.
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY |
+	syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+	syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+
+// inotifyWatcher is a fileWatcher using inotify. Events are read
+// without blocking, so no goroutine is needed to service them.
+type inotifyWatcher struct {
+	fd   int
+	dirs map[string]int
+	wds  map[int]string
+	buf  []byte
+}
+
+func newFileWatcher() (fileWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, errors.Annotate(os.NewSyscallError("inotify_init1", err), "creating file watcher")
+	}
+	return &inotifyWatcher{
+		fd:   fd,
+		dirs: make(map[string]int),
+		wds:  make(map[int]string),
+		buf:  make([]byte, 64*1024),
+	}, nil
+}
+
+// Watch is part of the fileWatcher interface.
+func (w *inotifyWatcher) Watch(dir string) error {
+	if _, ok := w.dirs[dir]; ok {
+		return nil
+	}
+	wd, err := syscall.InotifyAddWatch(w.fd, dir, inotifyMask)
+	if err != nil {
+		return errors.Annotatef(os.NewSyscallError("inotify_add_watch", err), "watching %q", dir)
+	}
+	w.dirs[dir] = wd
+	w.wds[wd] = dir
+	return nil
+}
+
+// Changes is part of the fileWatcher interface.
+func (w *inotifyWatcher) Changes() ([]string, bool, error) {
+	var paths []string
+	all := false
+	for {
+		n, err := syscall.Read(w.fd, w.buf)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err == syscall.EAGAIN {
+			return paths, all, nil
+		}
+		if err != nil {
+			return nil, true, errors.Annotate(os.NewSyscallError("read", err), "reading file changes")
+		}
+		if n < syscall.SizeofInotifyEvent {
+			return nil, true, errors.New("short read of file changes")
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&w.buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			offset = nameStart + int(event.Len)
+			if event.Mask&syscall.IN_Q_OVERFLOW != 0 {
+				all = true
+				continue
+			}
+			dir, ok := w.wds[int(event.Wd)]
+			if !ok {
+				continue
+			}
+			if event.Mask&(syscall.IN_DELETE_SELF|syscall.IN_MOVE_SELF|syscall.IN_IGNORED) != 0 {
+				// The directory itself has gone, so every file
+				// in it may have changed. Stop watching it; it
+				// will be watched again if it is recreated and
+				// the files in it are read.
+				w.forget(dir)
+				all = true
+				continue
+			}
+			if event.Len == 0 {
+				continue
+			}
+			name := w.buf[nameStart:offset]
+			if i := bytes.IndexByte(name, 0); i >= 0 {
+				name = name[:i]
+			}
+			paths = append(paths, filepath.Join(dir, string(name)))
+		}
+	}
+}
+
+func (w *inotifyWatcher) forget(dir string) {
+	wd := w.dirs[dir]
+	delete(w.dirs, dir)
+	delete(w.wds, wd)
+	// The watch is removed automatically if the directory has
+	// been deleted, in which case this fails harmlessly.
+	syscall.InotifyRmWatch(w.fd, uint32(wd))
+}
+
+// Close is part of the fileWatcher interface.
+func (w *inotifyWatcher) Close() error {
+	return errors.Trace(syscall.Close(w.fd))
+}
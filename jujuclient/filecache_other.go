@@ -0,0 +1,12 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package jujuclient
+
+import "github.com/juju/errors"
+
+func newFileWatcher() (fileWatcher, error) {
+	return nil, errors.NotSupportedf("watching files")
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type FileCacheSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&FileCacheSuite{})
+
+func (s *FileCacheSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewFileClientStore()
+}
+
+func (s *FileCacheSuite) controllerNames(c *gc.C) []string {
+	controllers, err := s.store.AllControllers()
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for name := range controllers {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *FileCacheSuite) TestReadSeesWriteInPlace(c *gc.C) {
+	writeTestControllersFile(c)
+	c.Assert(s.controllerNames(c), gc.HasLen, 3)
+
+	err := ioutil.WriteFile(jujuclient.JujuControllersPath(), []byte(`
+controllers:
+  kontroll:
+    uuid: this-is-the-test-uuid
+    api-endpoints: [this-is-aws-test-of-many-api-endpoints]
+    ca-cert: this-is-aws-test-ca-cert
+    cloud: aws
+`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.controllerNames(c), jc.SameContents, []string{"kontroll"})
+}
+
+func (s *FileCacheSuite) TestReadSeesRename(c *gc.C) {
+	writeTestControllersFile(c)
+	c.Assert(s.controllerNames(c), gc.HasLen, 3)
+
+	path := jujuclient.JujuControllersPath()
+	tempPath := filepath.Join(filepath.Dir(path), "controllers.yaml.new")
+	err := ioutil.WriteFile(tempPath, []byte("controllers: {}\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Rename(tempPath, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.controllerNames(c), gc.HasLen, 0)
+}
+
+func (s *FileCacheSuite) TestReadSeesRemove(c *gc.C) {
+	writeTestControllersFile(c)
+	c.Assert(s.controllerNames(c), gc.HasLen, 3)
+
+	err := os.Remove(jujuclient.JujuControllersPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.controllerNames(c), gc.HasLen, 0)
+}
+
+func (s *FileCacheSuite) TestReadsDoNotWaitForWriters(c *gc.C) {
+	writeTestControllersFile(c)
+	releaser, err := jujuclient.LockFiles(s.store, jujuclient.JujuControllersPath())
+	c.Assert(err, jc.ErrorIsNil)
+	defer releaser.Release()
+
+	// Reading the locked file, and updating another file,
+	// both proceed while the controllers file is locked.
+	c.Assert(s.controllerNames(c), gc.HasLen, 3)
+	err = s.store.UpdateModel("kontroll", "admin/new-model", jujuclient.ModelDetails{
+		ModelUUID:    "test.uuid",
+		ModelType:    model.IAAS,
+		ActiveBranch: model.GenerationMaster,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FileCacheSuite) TestConcurrentUpdates(c *gc.C) {
+	const count = 10
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.store.UpdateModel("kontroll", fmt.Sprintf("admin/model-%d", i), jujuclient.ModelDetails{
+				ModelUUID:    fmt.Sprintf("uuid-%d", i),
+				ModelType:    model.IAAS,
+				ActiveBranch: model.GenerationMaster,
+			})
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	models, err := s.store.AllModels("kontroll")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, count)
+	for i := 0; i < count; i++ {
+		c.Check(models[fmt.Sprintf("admin/model-%d", i)].ModelUUID, gc.Equals, fmt.Sprintf("uuid-%d", i))
+	}
+}
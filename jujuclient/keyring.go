@@ -0,0 +1,171 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// KeyringBackend is the name of the client store backend that keeps
+// accounts, credentials and cookies in the OS keyring: the Secret
+// Service (eg GNOME Keyring or KWallet) on Linux, and the login
+// keychain on macOS.
+const KeyringBackend = "keyring"
+
+// keyringService is the service under which secrets are
+// stored in the keyring.
+const keyringService = "juju"
+
+// keyringError is returned when a keyring tool exits unsuccessfully.
+type keyringError struct {
+	tool     string
+	exitCode int
+	stderr   string
+}
+
+// Error is part of the error interface.
+func (e *keyringError) Error() string {
+	if e.stderr == "" {
+		return fmt.Sprintf("%s exited with status %d", e.tool, e.exitCode)
+	}
+	return fmt.Sprintf("%s exited with status %d: %s", e.tool, e.exitCode, e.stderr)
+}
+
+// runKeyringCommand runs the named keyring tool, passing it stdin and
+// returning its standard output. If the tool exits unsuccessfully the
+// error returned is a *keyringError.
+var runKeyringCommand = func(stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, &keyringError{
+			tool:     name,
+			exitCode: exitErr.ProcessState.Sys().(syscall.WaitStatus).ExitStatus(),
+			stderr:   strings.TrimSpace(stderr.String()),
+		}
+	}
+	return out, errors.Trace(err)
+}
+
+func newKeyringClientStore() (ClientStore, error) {
+	secrets, err := newKeyringSecretStore(runtime.GOOS)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := exec.LookPath(secrets.tool); err != nil {
+		return nil, errors.Annotatef(err, "keyring tool %q not found", secrets.tool)
+	}
+	return NewSecretClientStore(secrets), nil
+}
+
+// keyringSecretStore is a SecretStore backed by the OS keyring, which
+// it drives using the keyring's command line tool. Secrets are base64
+// encoded, as keyrings store text.
+type keyringSecretStore struct {
+	tool string
+
+	// notFoundCode is the status the tool exits with
+	// when there is no secret with the key.
+	notFoundCode int
+
+	get func(key string) []string
+	set func(key, data string) ([]string, []byte)
+	del func(key string) []string
+}
+
+func newKeyringSecretStore(goos string) (*keyringSecretStore, error) {
+	var s keyringSecretStore
+	switch goos {
+	case "linux":
+		s = keyringSecretStore{
+			tool:         "secret-tool",
+			notFoundCode: 1,
+			get: func(key string) []string {
+				return []string{"lookup", "service", keyringService, "key", key}
+			},
+			set: func(key, data string) ([]string, []byte) {
+				return []string{"store", "--label", keyringService + ": " + key, "service", keyringService, "key", key}, []byte(data)
+			},
+			del: func(key string) []string {
+				return []string{"clear", "service", keyringService, "key", key}
+			},
+		}
+	case "darwin":
+		s = keyringSecretStore{
+			tool:         "security",
+			notFoundCode: 44,
+			get: func(key string) []string {
+				return []string{"find-generic-password", "-s", keyringService, "-a", key, "-w"}
+			},
+			set: func(key, data string) ([]string, []byte) {
+				// With no value, -w makes security prompt for the
+				// password, and then again to confirm it, so that
+				// the secret is not visible in the process list.
+				return []string{"add-generic-password", "-U", "-s", keyringService, "-a", key, "-w"}, []byte(data + "\n" + data + "\n")
+			},
+			del: func(key string) []string {
+				return []string{"delete-generic-password", "-s", keyringService, "-a", key}
+			},
+		}
+	default:
+		return nil, errors.NotSupportedf("keyring on %s", goos)
+	}
+	return &s, nil
+}
+
+// Get is part of the SecretStore interface.
+func (s *keyringSecretStore) Get(key string) ([]byte, error) {
+	out, err := runKeyringCommand(nil, s.tool, s.get(key)...)
+	if s.isNotFound(err) {
+		return nil, errors.NotFoundf("keyring secret %q", key)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get keyring secret %q", key)
+	}
+	data, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(out)))
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decode keyring secret %q", key)
+	}
+	return data, nil
+}
+
+// Set is part of the SecretStore interface.
+func (s *keyringSecretStore) Set(key string, data []byte) error {
+	args, stdin := s.set(key, base64.StdEncoding.EncodeToString(data))
+	if _, err := runKeyringCommand(stdin, s.tool, args...); err != nil {
+		return errors.Annotatef(err, "cannot set keyring secret %q", key)
+	}
+	return nil
+}
+
+// Delete is part of the SecretStore interface.
+func (s *keyringSecretStore) Delete(key string) error {
+	// secret-tool succeeds when clearing a missing secret,
+	// so look the secret up first.
+	if _, err := s.Get(key); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := runKeyringCommand(nil, s.tool, s.del(key)...)
+	if s.isNotFound(err) {
+		return errors.NotFoundf("keyring secret %q", key)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot delete keyring secret %q", key)
+	}
+	return nil
+}
+
+func (s *keyringSecretStore) isNotFound(err error) bool {
+	kerr, ok := err.(*keyringError)
+	return ok && kerr.exitCode == s.notFoundCode
+}
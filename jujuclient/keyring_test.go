@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type KeyringSecretStoreSuite struct {
+	testing.BaseSuite
+	secrets map[string]string
+	calls   []string
+}
+
+var _ = gc.Suite(&KeyringSecretStoreSuite{})
+
+func (s *KeyringSecretStoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.secrets = make(map[string]string)
+	s.calls = nil
+	s.PatchValue(jujuclient.RunKeyringCommand, s.runSecretTool)
+}
+
+// runSecretTool fakes the secret-tool command.
+func (s *KeyringSecretStoreSuite) runSecretTool(stdin []byte, name string, args ...string) ([]byte, error) {
+	s.calls = append(s.calls, name+" "+strings.Join(args, " "))
+	key := args[len(args)-1]
+	switch args[0] {
+	case "lookup":
+		value, ok := s.secrets[key]
+		if !ok {
+			return nil, jujuclient.NewKeyringError(name, 1)
+		}
+		return []byte(value + "\n"), nil
+	case "store":
+		s.secrets[key] = string(stdin)
+	case "clear":
+		delete(s.secrets, key)
+	}
+	return nil, nil
+}
+
+func (s *KeyringSecretStoreSuite) TestSetGetDelete(c *gc.C) {
+	store, err := jujuclient.NewKeyringSecretStore("linux")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = store.Get("accounts")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = store.Set("accounts", []byte("controllers: {}"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.secrets["accounts"], gc.Equals, base64.StdEncoding.EncodeToString([]byte("controllers: {}")))
+
+	data, err := store.Get("accounts")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "controllers: {}")
+
+	err = store.Delete("accounts")
+	c.Assert(err, jc.ErrorIsNil)
+	err = store.Delete("accounts")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(s.calls, jc.DeepEquals, []string{
+		"secret-tool lookup service juju key accounts",
+		"secret-tool store --label juju: accounts service juju key accounts",
+		"secret-tool lookup service juju key accounts",
+		"secret-tool lookup service juju key accounts",
+		"secret-tool clear service juju key accounts",
+		"secret-tool lookup service juju key accounts",
+	})
+}
+
+func (s *KeyringSecretStoreSuite) TestSetDarwinPassesSecretOnStdin(c *gc.C) {
+	var stdin []byte
+	s.PatchValue(jujuclient.RunKeyringCommand, func(in []byte, name string, args ...string) ([]byte, error) {
+		s.calls = append(s.calls, name+" "+strings.Join(args, " "))
+		stdin = in
+		return nil, nil
+	})
+	store, err := jujuclient.NewKeyringSecretStore("darwin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = store.Set("accounts", []byte("controllers: {}"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{
+		"security add-generic-password -U -s juju -a accounts -w",
+	})
+	encoded := base64.StdEncoding.EncodeToString([]byte("controllers: {}"))
+	c.Assert(string(stdin), gc.Equals, encoded+"\n"+encoded+"\n")
+}
+
+func (s *KeyringSecretStoreSuite) TestToolError(c *gc.C) {
+	s.PatchValue(jujuclient.RunKeyringCommand, func([]byte, string, ...string) ([]byte, error) {
+		return nil, errors.New("cannot connect to secret service")
+	})
+	store, err := jujuclient.NewKeyringSecretStore("linux")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = store.Get("accounts")
+	c.Assert(err, gc.ErrorMatches, `cannot get keyring secret "accounts": cannot connect to secret service`)
+}
+
+func (s *KeyringSecretStoreSuite) TestUnsupportedOS(c *gc.C) {
+	_, err := jujuclient.NewKeyringSecretStore("windows")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -5,12 +5,10 @@ package jujuclient
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strings"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/yaml.v2"
@@ -30,7 +28,7 @@ func JujuModelsPath() string {
 // ReadModelsFile loads all models defined in a given file.
 // If the file is not found, it is not an error.
 func ReadModelsFile(file string) (map[string]*ControllerModels, error) {
-	data, err := ioutil.ReadFile(file)
+	data, err := readStoreFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -146,7 +144,7 @@ func WriteModelsFile(models map[string]*ControllerModels) error {
 	if err != nil {
 		return errors.Annotate(err, "cannot marshal models")
 	}
-	return utils.AtomicWriteFile(JujuModelsPath(), data, os.FileMode(0600))
+	return writeStoreFile(JujuModelsPath(), data)
 }
 
 // ParseModels parses the given YAML bytes into models metadata.
@@ -3,6 +3,13 @@
 
 package jujuclient
 
+import (
+	"encoding/asn1"
+	"encoding/base64"
+
+	"github.com/juju/errors"
+)
+
 // RegistrationInfo contains the user/controller registration information
 // printed by "juju add-user", and consumed by "juju register".
 type RegistrationInfo struct {
@@ -22,3 +29,27 @@ type RegistrationInfo struct {
 	// the caller of "juju register".
 	ControllerName string
 }
+
+// Encode returns the registration information encoded as the string
+// the user passes to "juju register".
+func (info RegistrationInfo) Encode() (string, error) {
+	// We marshal the information using ASN.1 to keep the size down,
+	// since we need to encode binary data.
+	registrationData, err := asn1.Marshal(info)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Use URLEncoding so we don't get + or / in the string,
+	// and pad with zero bytes so we don't get =; this all
+	// makes it easier to copy & paste in a terminal.
+	//
+	// The embedded ASN.1 data is length-encoded, so the
+	// padding will not complicate decoding.
+	remainder := len(registrationData) % 3
+	if remainder != 0 {
+		var pad [3]byte
+		registrationData = append(registrationData, pad[:3-remainder]...)
+	}
+	return base64.URLEncoding.EncodeToString(registrationData), nil
+}
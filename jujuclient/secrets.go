@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	cookiejar "github.com/juju/persistent-cookiejar"
+)
+
+const (
+	accountsSecretKey    = "accounts"
+	credentialsSecretKey = "credentials"
+)
+
+func cookiesSecretKey(controllerName string) string {
+	return "cookies/" + controllerName
+}
+
+// SecretStore stores the secrets held by a client store, namely the
+// accounts, credentials and cookies, by key.
+type SecretStore interface {
+	// Get returns the data stored with the key, or an error
+	// satisfying errors.IsNotFound if there is none.
+	Get(key string) ([]byte, error)
+
+	// Set stores the data with the key, replacing any
+	// already stored.
+	Set(key string, data []byte) error
+
+	// Delete removes the data stored with the key, returning an
+	// error satisfying errors.IsNotFound if there is none.
+	Delete(key string) error
+}
+
+// NewSecretClientStore returns a new client store that manages
+// controllers, models and bootstrap config in $XDG_DATA_HOME/juju,
+// but keeps accounts, credentials and cookies in the secret store,
+// so that passwords, macaroons and cloud credentials are not
+// written to disk.
+func NewSecretClientStore(secrets SecretStore) ClientStore {
+	return &store{secrets: secrets}
+}
+
+// secretCookieJar is a cookie jar whose cookies are saved in
+// a secret store.
+type secretCookieJar struct {
+	*cookiejar.Jar
+	secrets SecretStore
+	key     string
+}
+
+func newSecretCookieJar(secrets SecretStore, controllerName string) (CookieJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{
+		NoPersist: true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := cookiesSecretKey(controllerName)
+	data, err := secrets.Get(key)
+	if errors.IsNotFound(err) {
+		data = nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get cookies for controller %s", controllerName)
+	}
+	if len(data) > 0 {
+		var cookies []*http.Cookie
+		if err := json.Unmarshal(data, &cookies); err != nil {
+			return nil, errors.Annotatef(err, "cannot unmarshal cookies for controller %s", controllerName)
+		}
+		for _, cookie := range cookies {
+			jar.SetCookies(cookieURL(cookie), []*http.Cookie{cookie})
+		}
+	}
+	return &secretCookieJar{
+		Jar:     jar,
+		secrets: secrets,
+		key:     key,
+	}, nil
+}
+
+// cookieURL returns a URL from which the cookie could have been set.
+func cookieURL(cookie *http.Cookie) *url.URL {
+	scheme := "http"
+	if cookie.Secure {
+		scheme = "https"
+	}
+	path := cookie.Path
+	if path == "" {
+		path = "/"
+	}
+	return &url.URL{
+		Scheme: scheme,
+		Host:   strings.TrimPrefix(cookie.Domain, "."),
+		Path:   path,
+	}
+}
+
+// Save is part of the CookieJar interface.
+func (jar *secretCookieJar) Save() error {
+	data, err := json.Marshal(jar.AllCookies())
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal cookies")
+	}
+	return errors.Annotate(jar.secrets.Set(jar.key, data), "cannot store cookies")
+}
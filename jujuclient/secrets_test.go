@@ -0,0 +1,205 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type SecretClientStoreSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	secrets *memSecretStore
+	store   jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&SecretClientStoreSuite{})
+
+func (s *SecretClientStoreSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.secrets = &memSecretStore{secrets: make(map[string][]byte)}
+	s.store = jujuclient.NewSecretClientStore(s.secrets)
+	err := s.store.AddController("ctrl", jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		CACert:         "ca-cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretClientStoreSuite) assertNoFile(c *gc.C, path string) {
+	_, err := os.Stat(path)
+	c.Assert(os.IsNotExist(err), jc.IsTrue, gc.Commentf("%s exists", path))
+}
+
+func (s *SecretClientStoreSuite) TestAccounts(c *gc.C) {
+	details := jujuclient.AccountDetails{User: "admin", Password: "hunter2"}
+	err := s.store.UpdateAccount("ctrl", details)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoFile(c, jujuclient.JujuAccountsPath())
+	c.Assert(string(s.secrets.get("accounts")), jc.Contains, "hunter2")
+
+	read, err := s.store.AccountDetails("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*read, jc.DeepEquals, details)
+
+	err = s.store.RemoveAccount("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.AccountDetails("ctrl")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretClientStoreSuite) TestCredentials(c *gc.C) {
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	err := s.store.UpdateCredential("aws", cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{"bob": cred},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoFile(c, jujuclient.JujuCredentialsPath())
+
+	read, err := s.store.CredentialForCloud("aws")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read.AuthCredentials["bob"].Attributes(), jc.DeepEquals, cred.Attributes())
+
+	all, err := s.store.AllCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *SecretClientStoreSuite) TestCookies(c *gc.C) {
+	u, err := url.Parse("https://10.0.0.1:17070/api")
+	c.Assert(err, jc.ErrorIsNil)
+	jar, err := s.store.CookieJar("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "macaroon", Value: "m1", Path: "/", Secure: true}})
+	err = jar.Save()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoFile(c, jujuclient.JujuCookiePath("ctrl"))
+
+	jar, err = s.store.CookieJar("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	cookies := jar.Cookies(u)
+	c.Assert(cookies, gc.HasLen, 1)
+	c.Assert(cookies[0].Name, gc.Equals, "macaroon")
+	c.Assert(cookies[0].Value, gc.Equals, "m1")
+
+	err = s.store.RemoveController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.secrets.get("cookies/ctrl"), gc.IsNil)
+}
+
+func (s *SecretClientStoreSuite) TestSecretStoreError(c *gc.C) {
+	s.secrets.err = errors.New("sealed")
+	_, err := s.store.AccountDetails("ctrl")
+	c.Assert(err, gc.ErrorMatches, "cannot get accounts: sealed")
+	err = s.store.UpdateAccount("ctrl", jujuclient.AccountDetails{User: "admin"})
+	c.Assert(err, gc.ErrorMatches, "cannot get accounts: sealed")
+	_, err = s.store.CookieJar("ctrl")
+	c.Assert(err, gc.ErrorMatches, "cannot get cookies for controller ctrl: sealed")
+}
+
+type BackendsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&BackendsSuite{})
+
+func (s *BackendsSuite) TestDefaultBackends(c *gc.C) {
+	c.Assert(jujuclient.ClientStoreBackends(), jc.DeepEquals, []string{"file", "keyring", "vault"})
+}
+
+func (s *BackendsSuite) TestRegisterClientStore(c *gc.C) {
+	secrets := &memSecretStore{secrets: make(map[string][]byte)}
+	err := jujuclient.RegisterClientStore("test-mem", func() (jujuclient.ClientStore, error) {
+		return jujuclient.NewSecretClientStore(secrets), nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuclient.RegisterClientStore("test-mem", nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	s.PatchEnvironment(osenv.JujuClientStoreEnvKey, "test-mem")
+	store := jujuclient.NewClientStore()
+	err = store.UpdateAccount("ctrl", jujuclient.AccountDetails{User: "admin", Password: "hunter2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets.get("accounts"), gc.NotNil)
+}
+
+func (s *BackendsSuite) TestUnknownBackend(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuClientStoreEnvKey, "bogus")
+	store := jujuclient.NewClientStore()
+	err := store.UpdateAccount("ctrl", jujuclient.AccountDetails{User: "admin", Password: "hunter2"})
+	c.Assert(err, gc.ErrorMatches, `cannot get accounts: client store backend "bogus" not valid`)
+	_, err = os.Stat(jujuclient.JujuAccountsPath())
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *BackendsSuite) TestVaultBackendNotConfigured(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuClientStoreEnvKey, "vault")
+	s.PatchEnvironment(jujuclient.VaultAddrEnvKey, "")
+	store := jujuclient.NewClientStore()
+	_, err := store.AllCredentials()
+	c.Assert(err, gc.ErrorMatches, `cannot get credentials: creating "vault" client store: empty Vault address \(set VAULT_ADDR\) not valid`)
+}
+
+// memSecretStore is a SecretStore holding secrets in memory.
+type memSecretStore struct {
+	mu      sync.Mutex
+	secrets map[string][]byte
+	err     error
+}
+
+func (s *memSecretStore) get(key string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.secrets[key]
+}
+
+func (s *memSecretStore) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, ok := s.secrets[key]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", key)
+	}
+	return data, nil
+}
+
+func (s *memSecretStore) Set(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.secrets[key] = data
+	return nil
+}
+
+func (s *memSecretStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if _, ok := s.secrets[key]; !ok {
+		return errors.NotFoundf("secret %q", key)
+	}
+	delete(s.secrets, key)
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// VaultBackend is the name of the client store backend that keeps
+// accounts, credentials and cookies in a HashiCorp Vault key/value
+// (version 2) secrets engine.
+const VaultBackend = "vault"
+
+const (
+	// VaultAddrEnvKey is the env var holding the address of the
+	// Vault server, as used by the vault command.
+	VaultAddrEnvKey = "VAULT_ADDR"
+
+	// VaultTokenEnvKey is the env var holding the Vault token, as
+	// used by the vault command. If it is not set, the token saved
+	// by "vault login" in ~/.vault-token is used.
+	VaultTokenEnvKey = "VAULT_TOKEN"
+
+	// VaultNamespaceEnvKey is the env var holding the Vault
+	// Enterprise namespace, as used by the vault command.
+	VaultNamespaceEnvKey = "VAULT_NAMESPACE"
+
+	// VaultPathEnvKey is the env var holding the path below which
+	// secrets are stored, starting with the mount point of the
+	// key/value secrets engine.
+	VaultPathEnvKey = "JUJU_CLIENT_STORE_VAULT_PATH"
+
+	// DefaultVaultPath is the path below which secrets are stored
+	// if JUJU_CLIENT_STORE_VAULT_PATH is not set.
+	DefaultVaultPath = "secret/juju"
+)
+
+func newVaultClientStore() (ClientStore, error) {
+	token := os.Getenv(VaultTokenEnvKey)
+	if token == "" {
+		data, err := ioutil.ReadFile(filepath.Join(utils.Home(), ".vault-token"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Trace(err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	path := os.Getenv(VaultPathEnvKey)
+	if path == "" {
+		path = DefaultVaultPath
+	}
+	secrets, err := NewVaultSecretStore(VaultConfig{
+		Address:   os.Getenv(VaultAddrEnvKey),
+		Token:     token,
+		Namespace: os.Getenv(VaultNamespaceEnvKey),
+		Path:      path,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSecretClientStore(secrets), nil
+}
+
+// VaultConfig holds the configuration of a Vault secret store.
+type VaultConfig struct {
+	// Address is the address of the Vault server,
+	// eg https://vault.example.com:8200.
+	Address string
+
+	// Token is the token used to authenticate to Vault.
+	Token string
+
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+
+	// Path is the path below which secrets are stored, starting with
+	// the mount point of the key/value secrets engine, eg "secret/juju".
+	Path string
+
+	// HTTPClient is the client used to make requests. If it is
+	// nil, a client with a 30 second timeout is used.
+	HTTPClient *http.Client
+}
+
+// Validate returns an error if the configuration is not valid.
+func (cfg VaultConfig) Validate() error {
+	if cfg.Address == "" {
+		return errors.NotValidf("empty Vault address (set %s)", VaultAddrEnvKey)
+	}
+	if cfg.Token == "" {
+		return errors.NotValidf("empty Vault token (set %s or run \"vault login\")", VaultTokenEnvKey)
+	}
+	if strings.Trim(cfg.Path, "/") == "" {
+		return errors.NotValidf("empty Vault path")
+	}
+	return nil
+}
+
+// vaultSecretStore is a SecretStore backed by a Vault key/value
+// (version 2) secrets engine. Each secret is stored base64 encoded
+// in the "value" field of the Vault secret.
+type vaultSecretStore struct {
+	config VaultConfig
+	client *http.Client
+	mount  string
+	prefix string
+}
+
+// NewVaultSecretStore returns a SecretStore that keeps secrets in Vault.
+func NewVaultSecretStore(config VaultConfig) (SecretStore, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	parts := strings.SplitN(strings.Trim(config.Path, "/"), "/", 2)
+	s := &vaultSecretStore{
+		config: config,
+		client: client,
+		mount:  parts[0],
+	}
+	if len(parts) == 2 {
+		s.prefix = parts[1] + "/"
+	}
+	return s, nil
+}
+
+type vaultSecret struct {
+	Data vaultSecretData `json:"data"`
+}
+
+type vaultSecretData struct {
+	Value string `json:"value"`
+}
+
+type vaultReadResponse struct {
+	Data vaultSecret `json:"data"`
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// Get is part of the SecretStore interface.
+func (s *vaultSecretStore) Get(key string) ([]byte, error) {
+	var resp vaultReadResponse
+	if err := s.do("GET", s.url("data", key), nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot get Vault secret %q", key)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Data.Data.Value)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot decode Vault secret %q", key)
+	}
+	return data, nil
+}
+
+// Set is part of the SecretStore interface.
+func (s *vaultSecretStore) Set(key string, data []byte) error {
+	secret := vaultSecret{vaultSecretData{
+		Value: base64.StdEncoding.EncodeToString(data),
+	}}
+	if err := s.do("POST", s.url("data", key), secret, nil); err != nil {
+		return errors.Annotatef(err, "cannot set Vault secret %q", key)
+	}
+	return nil
+}
+
+// Delete is part of the SecretStore interface.
+func (s *vaultSecretStore) Delete(key string) error {
+	// Vault succeeds in deleting a missing secret,
+	// so look the secret up first.
+	if _, err := s.Get(key); err != nil {
+		return errors.Trace(err)
+	}
+	// Deleting the metadata removes all versions of the secret.
+	if err := s.do("DELETE", s.url("metadata", key), nil, nil); err != nil {
+		return errors.Annotatef(err, "cannot delete Vault secret %q", key)
+	}
+	return nil
+}
+
+func (s *vaultSecretStore) url(kind, key string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s%s", strings.TrimRight(s.config.Address, "/"), s.mount, kind, s.prefix, key)
+}
+
+func (s *vaultSecretStore) do(method, url string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("X-Vault-Token", s.config.Token)
+	if s.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.config.Namespace)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.NotFoundf("secret")
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		var errResp vaultErrorResponse
+		if err := json.Unmarshal(data, &errResp); err == nil && len(errResp.Errors) > 0 {
+			return errors.Errorf("%s: %s", resp.Status, strings.Join(errResp.Errors, "; "))
+		}
+		return errors.New(resp.Status)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return errors.Trace(json.Unmarshal(data, out))
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type VaultSecretStoreSuite struct {
+	testing.BaseSuite
+	vault  *fakeVault
+	server *httptest.Server
+	store  jujuclient.SecretStore
+}
+
+var _ = gc.Suite(&VaultSecretStoreSuite{})
+
+func (s *VaultSecretStoreSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.vault = &fakeVault{secrets: make(map[string]string)}
+	s.server = httptest.NewServer(s.vault)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	var err error
+	s.store, err = jujuclient.NewVaultSecretStore(jujuclient.VaultConfig{
+		Address: s.server.URL,
+		Token:   "s.token",
+		Path:    "kv/ci/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VaultSecretStoreSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config jujuclient.VaultConfig
+		err    string
+	}{{
+		config: jujuclient.VaultConfig{Token: "t", Path: "secret"},
+		err:    `empty Vault address \(set VAULT_ADDR\) not valid`,
+	}, {
+		config: jujuclient.VaultConfig{Address: "http://vault", Path: "secret"},
+		err:    `empty Vault token \(set VAULT_TOKEN or run "vault login"\) not valid`,
+	}, {
+		config: jujuclient.VaultConfig{Address: "http://vault", Token: "t", Path: "/"},
+		err:    "empty Vault path not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := jujuclient.NewVaultSecretStore(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *VaultSecretStoreSuite) TestSetGetDelete(c *gc.C) {
+	_, err := s.store.Get("accounts")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.store.Set("cookies/ctrl", []byte("macaroons"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.vault.paths(), jc.DeepEquals, []string{"/v1/kv/data/ci/juju/cookies/ctrl"})
+
+	data, err := s.store.Get("cookies/ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "macaroons")
+
+	err = s.store.Delete("cookies/ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.Get("cookies/ctrl")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.store.Delete("cookies/ctrl")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VaultSecretStoreSuite) TestPermissionDenied(c *gc.C) {
+	s.store, _ = jujuclient.NewVaultSecretStore(jujuclient.VaultConfig{
+		Address: s.server.URL,
+		Token:   "s.bad",
+		Path:    "kv/ci/juju",
+	})
+	err := s.store.Set("accounts", []byte("x"))
+	c.Assert(err, gc.ErrorMatches, `cannot set Vault secret "accounts": 403 Forbidden: permission denied`)
+}
+
+// fakeVault implements the parts of the Vault key/value
+// version 2 API used by the secret store.
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func (v *fakeVault) paths() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var paths []string
+	for path := range v.secrets {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if req.Header.Get("X-Vault-Token") != "s.token" {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+	switch req.Method {
+	case "GET":
+		value, ok := v.secrets[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]string{"value": value},
+			},
+		})
+	case "POST":
+		body, _ := ioutil.ReadAll(req.Body)
+		var secret struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal(body, &secret); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v.secrets[req.URL.Path] = secret.Data["value"]
+		w.Write([]byte(`{"data":{"version":1}}`))
+	case "DELETE":
+		delete(v.secrets, strings.Replace(req.URL.Path, "/metadata/", "/data/", 1))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -5,7 +5,9 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
@@ -35,6 +37,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	UserExists(names.UserTag) (bool, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -65,6 +68,7 @@ type PrecheckMachine interface {
 	AgentPresence() (bool, error)
 	InstanceStatus() (status.StatusInfo, error)
 	ShouldRebootOrShutdown() (state.RebootAction, error)
+	Labels() map[string]string
 }
 
 // PrecheckApplication describes the state interface for an
@@ -88,6 +92,7 @@ type PrecheckUnit interface {
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	ShouldBeAssigned() bool
+	Labels() map[string]string
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -129,6 +134,10 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	if err := ctx.checkMachineLabels(); err != nil {
+		return errors.Trace(err)
+	}
+
 	appUnits, err := ctx.checkApplications()
 	if err != nil {
 		return errors.Trace(err)
@@ -225,6 +234,10 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		return errors.Trace(err)
 	}
 
+	if err := checkTargetUsers(backend, modelInfo); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Check for conflicts with existing models
 	modelUUIDs, err := backend.AllModelUUIDs()
 	if err != nil {
@@ -251,6 +264,33 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	return nil
 }
 
+// checkTargetUsers ensures that the local users that will own and
+// have access to the model exist in the target controller. External
+// users are resolved by the target's identity provider.
+func checkTargetUsers(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	users := set.NewStrings()
+	for _, user := range append([]names.UserTag{modelInfo.Owner}, modelInfo.Users...) {
+		if !user.IsLocal() || users.Contains(user.Id()) {
+			continue
+		}
+		users.Add(user.Id())
+	}
+	var missing []string
+	for _, name := range users.SortedValues() {
+		exists, err := backend.UserExists(names.NewUserTag(name))
+		if err != nil {
+			return errors.Annotatef(err, "checking user %q", name)
+		}
+		if !exists {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("model users do not exist in target controller: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -328,6 +368,22 @@ func (ctx *precheckContext) checkMachines() error {
 	return nil
 }
 
+// checkMachineLabels returns an error if any of the model's machines
+// have labels. The model description has nowhere to record labels,
+// so they would be lost by the migration.
+func (ctx *precheckContext) checkMachineLabels() error {
+	machines, err := ctx.backend.AllMachines()
+	if err != nil {
+		return errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		if len(machine.Labels()) > 0 {
+			return errors.Errorf("machine %s has labels, which cannot be migrated", machine.Id())
+		}
+	}
+	return nil
+}
+
 func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
@@ -386,6 +442,11 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		if appCharmURL.String() != unitCharmURL.String() {
 			return errors.Errorf("unit %s is upgrading", unit.Name())
 		}
+
+		// As for machines, unit labels would be lost by the migration.
+		if len(unit.Labels()) > 0 {
+			return errors.Errorf("unit %s has labels, which cannot be migrated", unit.Name())
+		}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -102,6 +103,19 @@ func (s *precheckShim) AllRelations() ([]PrecheckRelation, error) {
 	return out, nil
 }
 
+// UserExists implements PrecheckBackend.
+func (s *precheckShim) UserExists(tag names.UserTag) (bool, error) {
+	_, err := s.controllerState.User(tag)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if _, ok := errors.Cause(err).(state.DeletedUserError); ok {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
 // ListPendingResources implements PrecheckBackend.
 func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, error) {
 	resources, err := s.resourcesSt.ListPendingResources(app)
@@ -163,6 +163,17 @@ func (s *SourcePrecheckSuite) TestDownMachineAgent(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "machine 1 agent not functioning at this time (down)")
 }
 
+func (s *SourcePrecheckSuite) TestLabelledMachine(c *gc.C) {
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0"},
+			&fakeMachine{id: "1", labels: map[string]string{"env": "canary"}},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 1 has labels, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -225,6 +236,21 @@ func (s *SourcePrecheckSuite) TestCAASModelNoUnitVersionCheck(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestLabelledUnit(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", labels: map[string]string{"env": "canary"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "unit foo/0 has labels, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestDeadUnit(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -588,6 +614,36 @@ func (s *TargetPrecheckSuite) TestDownMachineAgent(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "machine 1 agent not functioning at this time (down)")
 }
 
+func (s *TargetPrecheckSuite) TestModelUsersExist(c *gc.C) {
+	backend := newHappyBackend()
+	backend.missingUsers = []string{"bob@external"}
+	s.modelInfo.Users = []names.UserTag{
+		names.NewUserTag("alice"),
+		names.NewUserTag("bob@external"),
+	}
+	err := s.runPrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestModelUsersMissing(c *gc.C) {
+	backend := newHappyBackend()
+	backend.missingUsers = []string{"alice", modelOwner.Id()}
+	s.modelInfo.Users = []names.UserTag{
+		modelOwner,
+		names.NewUserTag("alice"),
+		names.NewUserTag("bob"),
+	}
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model users do not exist in target controller: alice, "+modelOwner.Id())
+}
+
+func (s *TargetPrecheckSuite) TestModelUsersError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.userErr = errors.New("boom")
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `checking user ".*": boom`)
+}
+
 func (s *TargetPrecheckSuite) TestModelNameAlreadyInUse(c *gc.C) {
 	pool := &fakePool{
 		models: []migration.PrecheckModel{
@@ -785,6 +841,9 @@ type fakeBackend struct {
 	pendingResourcesErr error
 
 	controllerBackend *fakeBackend
+
+	missingUsers []string
+	userErr      error
 }
 
 func (b *fakeBackend) Model() (migration.PrecheckModel, error) {
@@ -831,6 +890,15 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) UserExists(tag names.UserTag) (bool, error) {
+	for _, name := range b.missingUsers {
+		if tag.Id() == name {
+			return false, b.userErr
+		}
+	}
+	return true, b.userErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -908,6 +976,7 @@ type fakeMachine struct {
 	instanceStatus status.Status
 	lost           bool
 	rebootAction   state.RebootAction
+	labels         map[string]string
 }
 
 func (m *fakeMachine) Id() string {
@@ -999,6 +1068,7 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	labels      map[string]string
 }
 
 func (u *fakeUnit) Name() string {
@@ -1115,3 +1185,11 @@ func (f *fakePresence) AgentStatus(agent string) (presence.Status, error) {
 	}
 	return presence.Alive, nil
 }
+
+func (m *fakeMachine) Labels() map[string]string {
+	return m.labels
+}
+
+func (u *fakeUnit) Labels() map[string]string {
+	return u.labels
+}
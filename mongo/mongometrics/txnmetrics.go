@@ -5,9 +5,14 @@ package mongometrics
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 )
 
+// settingsCollection is the name of the collection holding charm,
+// application, relation and model settings, whose sizes are observed.
+const settingsCollection = "settings"
+
 const (
 	databaseLabel   = "database"
 	collectionLabel = "collection"
@@ -22,12 +27,17 @@ var (
 		optypeLabel,
 		failedLabel,
 	}
+	jujuMgoTxnSettingsLabelNames = []string{
+		databaseLabel,
+		optypeLabel,
+	}
 )
 
 // TxnCollector is a prometheus.Collector that collects metrics about
 // mgo/txn operations.
 type TxnCollector struct {
-	txnOpsTotalCounter *prometheus.CounterVec
+	txnOpsTotalCounter     *prometheus.CounterVec
+	settingsBytesHistogram *prometheus.HistogramVec
 }
 
 // NewTxnCollector returns a new TxnCollector.
@@ -41,6 +51,16 @@ func NewTxnCollector() *TxnCollector {
 			},
 			jujuMgoTxnLabelNames,
 		),
+		prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "juju",
+				Name:      "mgo_txn_settings_bytes",
+				Help:      "Size in bytes of the settings values written by mgo/txn ops.",
+				// 256 bytes to 16MiB, the largest document mongo accepts.
+				Buckets: prometheus.ExponentialBuckets(256, 4, 9),
+			},
+			jujuMgoTxnSettingsLabelNames,
+		),
 	}
 }
 
@@ -57,11 +77,14 @@ func (c *TxnCollector) updateMetrics(dbName string, op txn.Op, err error) {
 		failed = "failed"
 	}
 	var optype string
+	var written interface{}
 	switch {
 	case op.Insert != nil:
 		optype = "insert"
+		written = op.Insert
 	case op.Update != nil:
 		optype = "update"
+		written = op.Update
 	case op.Remove:
 		optype = "remove"
 	default:
@@ -73,14 +96,73 @@ func (c *TxnCollector) updateMetrics(dbName string, op txn.Op, err error) {
 		optypeLabel:     optype,
 		failedLabel:     failed,
 	}).Inc()
+	if op.C == settingsCollection && written != nil && err == nil {
+		c.observeSettingsSize(dbName, optype, written)
+	}
+}
+
+// StoredSizer is implemented by values written by txn ops that record
+// their stored size as they are encoded, so that the size of the data
+// written can be observed without encoding it again.
+type StoredSizer interface {
+	// StoredSize returns the number of bytes stored for the
+	// value, and whether the value has been encoded.
+	StoredSize() (int, bool)
+}
+
+// observeSettingsSize records the stored size of the settings values
+// in the document or update written by a txn op, as recorded when they
+// were written. Nothing is recorded if no sizes were recorded.
+func (c *TxnCollector) observeSettingsSize(dbName, optype string, written interface{}) {
+	size, ok := storedSize(written)
+	if !ok {
+		return
+	}
+	c.settingsBytesHistogram.With(prometheus.Labels{
+		databaseLabel: dbName,
+		optypeLabel:   optype,
+	}).Observe(float64(size))
+}
+
+// storedSize returns the total of the sizes recorded by the
+// StoredSizers in the given document or update, and whether
+// there were any.
+func storedSize(written interface{}) (int, bool) {
+	var total int
+	var found bool
+	add := func(value interface{}) {
+		if size, ok := storedSize(value); ok {
+			total += size
+			found = true
+		}
+	}
+	switch written := written.(type) {
+	case StoredSizer:
+		return written.StoredSize()
+	case bson.D:
+		for _, elem := range written {
+			add(elem.Value)
+		}
+	case bson.M:
+		for _, value := range written {
+			add(value)
+		}
+	case map[string]interface{}:
+		for _, value := range written {
+			add(value)
+		}
+	}
+	return total, found
 }
 
 // Describe is part of the prometheus.Collector interface.
 func (c *TxnCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.txnOpsTotalCounter.Describe(ch)
+	c.settingsBytesHistogram.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
 func (c *TxnCollector) Collect(ch chan<- prometheus.Metric) {
 	c.txnOpsTotalCounter.Collect(ch)
+	c.settingsBytesHistogram.Collect(ch)
 }
@@ -40,8 +40,9 @@ func (s *TxnCollectorSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 1)
+	c.Assert(descs, gc.HasLen, 2)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_mgo_txn_ops_total".*`)
+	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_mgo_txn_settings_bytes".*`)
 }
 
 func (s *TxnCollectorSuite) TestCollect(c *gc.C) {
@@ -149,3 +150,54 @@ func (s *TxnCollectorSuite) TestCollect(c *gc.C) {
 		}
 	}
 }
+
+// storedSizer is a settings value that has recorded its stored size.
+type storedSizer int
+
+func (s storedSizer) StoredSize() (int, bool) {
+	return int(s), true
+}
+
+func (s *TxnCollectorSuite) TestCollectSettingsBytes(c *gc.C) {
+	update := bson.D{
+		{"$set", bson.M{"settings.key": storedSizer(100), "settings.other": storedSizer(23)}},
+		{"$inc", bson.D{{"version", 1}}},
+	}
+
+	s.collector.AfterRunTransaction("dbname", "modeluuid", []txn.Op{{
+		C:      "settings",
+		Update: update,
+	}, {
+		C:      "settings",
+		Assert: txn.DocExists,
+	}, {
+		C:      "settings",
+		Update: bson.D{{"$set", bson.D{{"settings.key", "unsized"}}}},
+	}, {
+		C:      "other-coll",
+		Update: update,
+	}}, nil)
+	s.collector.AfterRunTransaction("dbname", "modeluuid", []txn.Op{{
+		C:      "settings",
+		Update: update,
+	}}, errors.New("bewm"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		s.collector.Collect(ch)
+	}()
+
+	var histograms []dto.Metric
+	for metric := range ch {
+		var dm dto.Metric
+		err := metric.Write(&dm)
+		c.Assert(err, jc.ErrorIsNil)
+		if dm.Histogram != nil {
+			histograms = append(histograms, dm)
+		}
+	}
+	c.Assert(histograms, gc.HasLen, 1)
+	c.Assert(histograms[0].Histogram.GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(histograms[0].Histogram.GetSampleSum(), gc.Equals, float64(123))
+}
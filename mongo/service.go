@@ -55,6 +55,10 @@ const (
 
 	// FileNameDBSSLKey is the file name of db ssl key file name.
 	FileNameDBSSLKey = "server.pem"
+
+	// OpenFilesLimit is the limit on open files set for the
+	// juju-db service.
+	OpenFilesLimit = 64000
 )
 
 var (
@@ -359,12 +363,12 @@ func (mongoArgs *ConfigArgs) asService() (mongoService, error) {
 func (mongoArgs *ConfigArgs) asServiceConf() common.Conf {
 	// See https://docs.mongodb.com/manual/reference/ulimit/.
 	limits := map[string]string{
-		"fsize":   "unlimited", // file size
-		"cpu":     "unlimited", // cpu time
-		"as":      "unlimited", // virtual memory size
-		"memlock": "unlimited", // locked-in-memory size
-		"nofile":  "64000",     // open files
-		"nproc":   "64000",     // processes/threads
+		"fsize":   "unlimited",                  // file size
+		"cpu":     "unlimited",                  // cpu time
+		"as":      "unlimited",                  // virtual memory size
+		"memlock": "unlimited",                  // locked-in-memory size
+		"nofile":  strconv.Itoa(OpenFilesLimit), // open files
+		"nproc":   "64000",                      // processes/threads
 	}
 	conf := common.Conf{
 		Desc:        "juju state database",
@@ -381,6 +381,9 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.GPUs,
+		constraints.GPUType,
+		constraints.InstanceLifecycle,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
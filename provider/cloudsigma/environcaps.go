@@ -13,6 +13,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -932,6 +932,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 				},
 				PrometheusRegisterer: noopRegisterer{},
 				Cleanup:              func() {},
+				Clock:                clock.WallClock,
 			})
 			if err != nil {
 				return errors.Trace(err)
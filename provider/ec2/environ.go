@@ -166,6 +166,9 @@ var unsupportedConstraints = []string{
 	// TODO(anastasiamac 2016-03-16) LP#1557874
 	// use virt-type in StartInstances
 	constraints.VirtType,
+	constraints.GPUs,
+	constraints.GPUType,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -194,6 +194,21 @@ var verifyCredentials = func(e *environ, ctx context.ProviderCallContext) error
 	return maybeConvertCredentialError(err, ctx)
 }
 
+// VerifyCredential is part of the environs.CredentialVerifier interface.
+func (p environProvider) VerifyCredential(ctx context.ProviderCallContext, spec environs.CloudSpec) error {
+	if isBrokenCloud(spec) {
+		if region, ok := aws.Regions[spec.Region]; ok {
+			spec.Endpoint = region.EC2Endpoint
+		}
+	}
+	client, err := awsClient(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = client.AccountAttributes()
+	return maybeConvertCredentialError(err, ctx)
+}
+
 // maybeConvertCredentialError examines the error received from the provider.
 // Authentication related errors are wrapped in common.CredentialNotValid.
 // Authorisation related errors are annotated with an additional
@@ -144,6 +144,25 @@ func (s *ProviderSuite) TestVerifyCredentialsErrs(c *gc.C) {
 	c.Assert(err, gc.Not(jc.Satisfies), common.IsCredentialNotValid)
 }
 
+func (s *ProviderSuite) TestVerifyCredentialErrs(c *gc.C) {
+	verifier, ok := s.provider.(environs.CredentialVerifier)
+	c.Assert(ok, jc.IsTrue)
+
+	err := verifier.VerifyCredential(context.NewCloudCallContext(), s.spec)
+	c.Assert(err, gc.Not(jc.ErrorIsNil))
+	c.Assert(err, gc.Not(jc.Satisfies), common.IsCredentialNotValid)
+}
+
+func (s *ProviderSuite) TestVerifyCredentialUnsupportedCredential(c *gc.C) {
+	verifier, ok := s.provider.(environs.CredentialVerifier)
+	c.Assert(ok, jc.IsTrue)
+
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	s.spec.Credential = &credential
+	err := verifier.VerifyCredential(context.NewCloudCallContext(), s.spec)
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
 func (s *ProviderSuite) TestMaybeConvertCredentialErrorIgnoresNil(c *gc.C) {
 	err := ec2.MaybeConvertCredentialError(nil, context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,15 +4,27 @@
 package gce
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/gce/google"
 )
 
 const (
-	cfgBaseImagePath = "base-image-path"
+	cfgBaseImagePath               = "base-image-path"
+	cfgShieldedSecureBoot          = "shielded-vm-secure-boot"
+	cfgShieldedVTPM                = "shielded-vm-vtpm"
+	cfgShieldedIntegrityMonitoring = "shielded-vm-integrity-monitoring"
+	cfgConfidentialVM              = "confidential-vm"
+	cfgNetwork                     = "network"
+	cfgSubnetwork                  = "subnetwork"
+	cfgNetworkHostProject          = "network-host-project"
+	cfgOperationTimeout            = "operation-timeout"
+	cfgInstanceUtilization         = "instance-utilization"
 )
 
 var configSchema = environschema.Fields{
@@ -20,6 +32,45 @@ var configSchema = environschema.Fields{
 		Description: "Base path to look for machine disk images.",
 		Type:        environschema.Tstring,
 	},
+	cfgShieldedSecureBoot: {
+		Description: "Whether new instances are Shielded VMs that only boot software signed by a recognised authority.",
+		Type:        environschema.Tbool,
+	},
+	cfgShieldedVTPM: {
+		Description: "Whether new instances are Shielded VMs with a virtual Trusted Platform Module.",
+		Type:        environschema.Tbool,
+	},
+	cfgShieldedIntegrityMonitoring: {
+		Description: "Whether the boot integrity of new Shielded VM instances is monitored. Requires shielded-vm-vtpm.",
+		Type:        environschema.Tbool,
+	},
+	cfgConfidentialVM: {
+		Description: "Whether new instances are Confidential VMs, with memory encrypted while in use. Only N2D machine types are supported.",
+		Type:        environschema.Tbool,
+	},
+	cfgNetwork: {
+		Description: "The name of an existing VPC network to create instances and firewall rules in, instead of the project's default network.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	cfgSubnetwork: {
+		Description: "The name of the subnetwork of the network, in the model's region, to connect instances to. Required for custom mode networks.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	cfgNetworkHostProject: {
+		Description: "The ID of the host project of a shared VPC network. Firewall rules are created in the host project, so the model's credential needs permission to manage them.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	cfgOperationTimeout: {
+		Description: "The longest time to wait for a GCE operation, such as starting an instance, to complete (e.g. 10m). Defaults to 5m.",
+		Type:        environschema.Tstring,
+	},
+	cfgInstanceUtilization: {
+		Description: "Whether the CPU and memory utilization of instances is read from Cloud Monitoring each time they are polled, to be shown by juju machines.",
+		Type:        environschema.Tbool,
+	},
 }
 
 // configFields is the spec for each GCE config value's type.
@@ -31,10 +82,23 @@ var configFields = func() schema.Fields {
 	return fs
 }()
 
-var configImmutableFields = []string{}
+var configImmutableFields = []string{
+	cfgNetwork,
+	cfgSubnetwork,
+	cfgNetworkHostProject,
+}
 
 var configDefaults = schema.Defaults{
-	cfgBaseImagePath: schema.Omit,
+	cfgBaseImagePath:               schema.Omit,
+	cfgShieldedSecureBoot:          false,
+	cfgShieldedVTPM:                false,
+	cfgShieldedIntegrityMonitoring: false,
+	cfgConfidentialVM:              false,
+	cfgNetwork:                     schema.Omit,
+	cfgSubnetwork:                  schema.Omit,
+	cfgNetworkHostProject:          schema.Omit,
+	cfgOperationTimeout:            schema.Omit,
+	cfgInstanceUtilization:         false,
 }
 
 type environConfig struct {
@@ -78,10 +142,86 @@ func newConfig(cfg, old *config.Config) (*environConfig, error) {
 		config: cfg,
 		attrs:  attrs,
 	}
+	if err := ecfg.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return ecfg, nil
 }
 
+func (c *environConfig) validate() error {
+	if c.shieldedVM().IntegrityMonitoring && !c.shieldedVM().VTPM {
+		return errors.NotValidf("%s without %s", cfgShieldedIntegrityMonitoring, cfgShieldedVTPM)
+	}
+	if c.network() == "" {
+		for _, attr := range []string{cfgSubnetwork, cfgNetworkHostProject} {
+			if value, _ := c.attrs[attr].(string); value != "" {
+				return errors.NotValidf("%s without %s", attr, cfgNetwork)
+			}
+		}
+	}
+	if value, ok := c.attrs[cfgOperationTimeout].(string); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.Annotatef(err, "%s value %q must be a valid duration", cfgOperationTimeout, value)
+		}
+		if timeout <= 0 {
+			return errors.NotValidf("non-positive %s %q", cfgOperationTimeout, value)
+		}
+	}
+	return nil
+}
+
 func (c *environConfig) baseImagePath() (string, bool) {
 	path, ok := c.attrs[cfgBaseImagePath].(string)
 	return path, ok
 }
+
+// shieldedVM returns the Shielded VM options for new instances.
+func (c *environConfig) shieldedVM() google.ShieldedVMSpec {
+	return google.ShieldedVMSpec{
+		SecureBoot:          c.attrs[cfgShieldedSecureBoot].(bool),
+		VTPM:                c.attrs[cfgShieldedVTPM].(bool),
+		IntegrityMonitoring: c.attrs[cfgShieldedIntegrityMonitoring].(bool),
+	}
+}
+
+// network returns the name of the network that instances
+// are created in, or "" for the project's default network.
+func (c *environConfig) network() string {
+	network, _ := c.attrs[cfgNetwork].(string)
+	return network
+}
+
+// networkSpec returns the network that instances are connected to,
+// and firewall rules created in, for the given region.
+func (c *environConfig) networkSpec(region string) google.NetworkSpec {
+	spec := google.NetworkSpec{
+		Name: c.network(),
+	}
+	spec.Subnetwork, _ = c.attrs[cfgSubnetwork].(string)
+	spec.Project, _ = c.attrs[cfgNetworkHostProject].(string)
+	if spec.Subnetwork != "" {
+		spec.Region = region
+	}
+	return spec
+}
+
+// operationTimeout returns the longest time to wait for a GCE
+// operation to complete, or zero to use the connection's default.
+func (c *environConfig) operationTimeout() time.Duration {
+	value, _ := c.attrs[cfgOperationTimeout].(string)
+	// The value has already been validated.
+	timeout, _ := time.ParseDuration(value)
+	return timeout
+}
+
+// confidentialVM reports whether new instances should be Confidential VMs.
+func (c *environConfig) confidentialVM() bool {
+	return c.attrs[cfgConfidentialVM].(bool)
+}
+
+// instanceUtilization reports whether the utilization of instances
+// should be read from Cloud Monitoring.
+func (c *environConfig) instanceUtilization() bool {
+	return c.attrs[cfgInstanceUtilization].(bool)
+}
@@ -92,6 +92,60 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info: "shielded VM options can be set",
+	insert: testing.Attrs{
+		"shielded-vm-secure-boot":          true,
+		"shielded-vm-vtpm":                 true,
+		"shielded-vm-integrity-monitoring": true,
+		"confidential-vm":                  true,
+	},
+	expect: testing.Attrs{
+		"shielded-vm-secure-boot":          true,
+		"shielded-vm-vtpm":                 true,
+		"shielded-vm-integrity-monitoring": true,
+		"confidential-vm":                  true,
+	},
+}, {
+	info:   "shielded VM integrity monitoring requires vTPM",
+	insert: testing.Attrs{"shielded-vm-integrity-monitoring": true},
+	err:    "shielded-vm-integrity-monitoring without shielded-vm-vtpm not valid",
+}, {
+	info: "network options can be set",
+	insert: testing.Attrs{
+		"network":              "shared",
+		"subnetwork":           "juju",
+		"network-host-project": "host",
+	},
+	expect: testing.Attrs{
+		"network":              "shared",
+		"subnetwork":           "juju",
+		"network-host-project": "host",
+	},
+}, {
+	info:   "subnetwork requires network",
+	insert: testing.Attrs{"subnetwork": "juju"},
+	err:    "subnetwork without network not valid",
+}, {
+	info:   "network host project requires network",
+	insert: testing.Attrs{"network-host-project": "host"},
+	err:    "network-host-project without network not valid",
+}, {
+	info:   "operation timeout can be set",
+	insert: testing.Attrs{"operation-timeout": "10m"},
+	expect: testing.Attrs{"operation-timeout": "10m"},
+}, {
+	info:   "operation timeout must be a duration",
+	insert: testing.Attrs{"operation-timeout": "ten"},
+	err:    `operation-timeout value "ten" must be a valid duration: .*`,
+}, {
+	info:   "operation timeout must be positive",
+	insert: testing.Attrs{"operation-timeout": "0s"},
+	err:    `non-positive operation-timeout "0s" not valid`,
+}, {
+	info:   "instance utilization can be enabled",
+	insert: testing.Attrs{"instance-utilization": true},
+	expect: testing.Attrs{"instance-utilization": true},
 }}
 
 func (s *ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -154,6 +208,10 @@ var changeConfigTests = []configTestSpec{{
 	info:   "can insert unknown field",
 	insert: testing.Attrs{"unknown": "ignoti"},
 	expect: testing.Attrs{"unknown": "ignoti"},
+}, {
+	info:   "cannot change network",
+	insert: testing.Attrs{"network": "shared"},
+	err:    "network: cannot change from <nil> to shared",
 }}
 
 // TODO(wwitzel3) refactor this to the provider_test file.
@@ -44,6 +44,7 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 			CredentialAttr: cloud.CredentialAttr{
 				Description: "client secret",
 				Hidden:      true,
+				MultiLine:   true,
 			},
 		}, {
 			Name:           credAttrProjectID,
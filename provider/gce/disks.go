@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/utils"
 
 	"github.com/juju/juju/environs/context"
@@ -20,8 +23,55 @@ import (
 
 const (
 	storageProviderType = storage.ProviderType("gce")
+
+	// Config attributes
+
+	// The persistent disk type (default pd-standard):
+	//   "pd-standard" for standard (HDD) disks,
+	//   "pd-balanced" for balanced (SSD) disks,
+	//   "pd-ssd" for performance (SSD) disks.
+	gceDiskType = "disk-type"
+
+	// Specifies whether the disk should be a regional disk,
+	// synchronously replicated across two zones of the region
+	// of the instance it is created for.
+	gceRegional = "regional"
 )
 
+var storageConfigFields = schema.Fields{
+	gceDiskType: schema.OneOf(
+		schema.Const(string(google.DiskPersistentStandard)),
+		schema.Const(string(google.DiskPersistentBalanced)),
+		schema.Const(string(google.DiskPersistentSSD)),
+	),
+	gceRegional: schema.Bool(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		gceDiskType: string(google.DiskPersistentStandard),
+		gceRegional: false,
+	},
+)
+
+type storageConfig struct {
+	diskType google.DiskType
+	regional bool
+}
+
+func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
+	out, err := storageConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating GCE storage config")
+	}
+	coerced := out.(map[string]interface{})
+	return &storageConfig{
+		diskType: google.DiskType(coerced[gceDiskType].(string)),
+		regional: coerced[gceRegional].(bool),
+	}, nil
+}
+
 // StorageProviderTypes implements storage.ProviderRegistry.
 func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
 	return []storage.ProviderType{storageProviderType}, nil
@@ -42,7 +92,8 @@ type storageProvider struct {
 var _ storage.Provider = (*storageProvider)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
-	return nil
+	_, err := newStorageConfig(cfg.Attrs())
+	return errors.Trace(err)
 }
 
 func (g *storageProvider) Supports(k storage.StorageKind) bool {
@@ -74,6 +125,7 @@ type volumeSource struct {
 	gce       gceConnection
 	envName   string // non-unique, informational only
 	modelUUID string
+	region    string
 }
 
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
@@ -82,6 +134,7 @@ func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSourc
 		gce:       g.env.gce,
 		envName:   environConfig.Name(),
 		modelUUID: environConfig.UUID(),
+		region:    g.env.cloud.Region,
 	}
 	return source, nil
 }
@@ -173,27 +226,54 @@ func mibToGib(m uint64) uint64 {
 	return (m + 1023) / 1024
 }
 
-func nameVolume(zone string) (string, error) {
+// nameVolume returns a new volume name in the given location,
+// which is the zone of a zonal disk or the region of a regional
+// disk.
+func nameVolume(location string) (string, error) {
 	volumeUUID, err := utils.NewUUID()
 	if err != nil {
 		return "", errors.Annotate(err, "cannot generate uuid to name the volume")
 	}
-	// type-zone-uuid
-	volumeName := fmt.Sprintf("%s--%s", zone, volumeUUID.String())
+	// location--uuid
+	volumeName := fmt.Sprintf("%s--%s", location, volumeUUID.String())
 	return volumeName, nil
 }
 
+// isRegion reports whether the location part of a volume id names
+// a region rather than a zone. Zone names are formed by appending a
+// letter to the name of their region (e.g. "us-east1-b" is a zone in
+// "us-east1"), so only region names end with a digit.
+func isRegion(location string) bool {
+	if location == "" {
+		return false
+	}
+	return unicode.IsDigit(rune(location[len(location)-1]))
+}
+
+// zoneRegion returns the name of the region containing the zone.
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
 func (v *volumeSource) createOneVolume(ctx context.ProviderCallContext, p storage.VolumeParams, instances instanceCache) (volume *storage.Volume, volumeAttachment *storage.VolumeAttachment, err error) {
-	var volumeName, zone string
+	var volumeName string
 	defer func() {
 		if err == nil || volumeName == "" {
 			return
 		}
-		if err := v.gce.RemoveDisk(zone, volumeName); err != nil {
+		if err := v.removeDisk(ctx, volumeName); err != nil {
 			logger.Errorf("error cleaning up volume %v: %v", volumeName, google.HandleCredentialError(err, ctx))
 		}
 	}()
 
+	cfg, err := newStorageConfig(p.Attributes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	instId := string(p.Attachment.InstanceId)
 	if err := instances.update(v.gce, ctx, instId); err != nil {
 		return nil, nil, errors.Annotatef(err, "cannot add %q to instance cache", instId)
@@ -204,13 +284,20 @@ func (v *volumeSource) createOneVolume(ctx context.ProviderCallContext, p storag
 		// because we need to know what its AZ is.
 		return nil, nil, errors.Annotatef(err, "cannot obtain %q from instance cache", instId)
 	}
-	persistentType, ok := p.Attributes["type"].(google.DiskType)
-	if !ok {
-		persistentType = google.DiskPersistentStandard
+
+	// A regional disk is replicated in the instance's zone,
+	// and in another zone of the same region.
+	location := inst.ZoneName
+	var replicaZones []string
+	if cfg.regional {
+		replicaZones, err = v.replicaZones(ctx, inst.ZoneName)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		location = zoneRegion(inst.ZoneName)
 	}
 
-	zone = inst.ZoneName
-	volumeName, err = nameVolume(zone)
+	volumeName, err = nameVolume(location)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "cannot create a new volume name")
 	}
@@ -219,11 +306,17 @@ func (v *volumeSource) createOneVolume(ctx context.ProviderCallContext, p storag
 	disk := google.DiskSpec{
 		SizeHintGB:         mibToGib(p.Size),
 		Name:               volumeName,
-		PersistentDiskType: persistentType,
-		Labels:             resourceTagsToDiskLabels(p.ResourceTags),
+		PersistentDiskType: cfg.diskType,
+		Labels:             resourceTagsToLabels(p.ResourceTags),
+		ReplicaZones:       replicaZones,
 	}
 
-	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
+	var gceDisks []*google.Disk
+	if cfg.regional {
+		gceDisks, err = connectionWithContext(v.gce, ctx).CreateRegionDisks(location, []google.DiskSpec{disk})
+	} else {
+		gceDisks, err = connectionWithContext(v.gce, ctx).CreateDisks(location, []google.DiskSpec{disk})
+	}
 	if err != nil {
 		return nil, nil, google.HandleCredentialError(errors.Annotate(err, "cannot create disk"), ctx)
 	}
@@ -232,7 +325,7 @@ func (v *volumeSource) createOneVolume(ctx context.ProviderCallContext, p storag
 	}
 	gceDisk := gceDisks[0]
 
-	attachedDisk, err := v.attachOneVolume(ctx, gceDisk.Name, google.ModeRW, inst.ID)
+	attachedDisk, err := v.attachOneVolume(ctx, gceDisk.Name, google.ModeRW, inst)
 	if err != nil {
 		return nil, nil, errors.Annotatef(err, "attaching %q to %q", gceDisk.Name, instId)
 	}
@@ -268,6 +361,21 @@ func (v *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volNames
 	return v.foreachVolume(ctx, volNames, v.releaseOneVolume), nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// Persistent disks are grown while attached; the new size is rounded
+// up to the nearest GiB.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]error, error) {
+	volNames := make([]string, len(params))
+	sizes := make(map[string]uint64)
+	for i, p := range params {
+		volNames[i] = p.VolumeId
+		sizes[p.VolumeId] = p.Size
+	}
+	return v.foreachVolume(ctx, volNames, func(ctx context.ProviderCallContext, volName string) error {
+		return v.resizeOneVolume(ctx, volName, sizes[volName])
+	}), nil
+}
+
 func (v *volumeSource) foreachVolume(ctx context.ProviderCallContext, volNames []string, f func(context.ProviderCallContext, string) error) []error {
 	var wg sync.WaitGroup
 	wg.Add(len(volNames))
@@ -297,23 +405,97 @@ func isValidVolume(volumeName string) bool {
 	return err == nil
 }
 
-func (v *volumeSource) destroyOneVolume(ctx context.ProviderCallContext, volName string) error {
-	zone, _, err := parseVolumeId(volName)
+// disk returns the zonal or regional disk with the given name,
+// according to the location in the name.
+func (v *volumeSource) disk(volName string) (*google.Disk, error) {
+	location, _, err := parseVolumeId(volName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if isRegion(location) {
+		return v.gce.RegionDisk(location, volName)
+	}
+	return v.gce.Disk(location, volName)
+}
+
+// removeDisk removes the zonal or regional disk with the given
+// name, according to the location in the name.
+func (v *volumeSource) removeDisk(ctx context.ProviderCallContext, volName string) error {
+	location, _, err := parseVolumeId(volName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isRegion(location) {
+		return connectionWithContext(v.gce, ctx).RemoveRegionDisk(location, volName)
+	}
+	return connectionWithContext(v.gce, ctx).RemoveDisk(location, volName)
+}
+
+// setDiskLabels sets the labels of the zonal or regional disk with
+// the given name, according to the location in the name.
+func (v *volumeSource) setDiskLabels(ctx context.ProviderCallContext, volName, labelFingerprint string, labels map[string]string) error {
+	location, _, err := parseVolumeId(volName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isRegion(location) {
+		return connectionWithContext(v.gce, ctx).SetRegionDiskLabels(location, volName, labelFingerprint, labels)
+	}
+	return connectionWithContext(v.gce, ctx).SetDiskLabels(location, volName, labelFingerprint, labels)
+}
+
+// replicaZones returns the zones in which to replicate a regional
+// disk created for an instance in the given zone: that zone, and
+// another available zone in the same region.
+func (v *volumeSource) replicaZones(ctx context.ProviderCallContext, zone string) ([]string, error) {
+	region := zoneRegion(zone)
+	zones, err := v.gce.AvailabilityZones(region)
 	if err != nil {
+		return nil, google.HandleCredentialError(errors.Annotatef(err, "cannot get availability zones in region %q", region), ctx)
+	}
+	for _, z := range zones {
+		if z.Name() != zone && z.Available() && zoneRegion(z.Name()) == region {
+			return []string{zone, z.Name()}, nil
+		}
+	}
+	return nil, errors.Errorf(
+		"cannot create regional volume: no available zone other than %q in region %q",
+		zone, region,
+	)
+}
+
+func (v *volumeSource) destroyOneVolume(ctx context.ProviderCallContext, volName string) error {
+	if _, _, err := parseVolumeId(volName); err != nil {
 		return errors.Annotatef(err, "invalid volume id %q", volName)
 	}
-	if err := v.gce.RemoveDisk(zone, volName); err != nil {
+	if err := v.removeDisk(ctx, volName); err != nil {
 		return google.HandleCredentialError(errors.Annotatef(err, "cannot destroy volume %q", volName), ctx)
 	}
 	return nil
 }
 
-func (v *volumeSource) releaseOneVolume(ctx context.ProviderCallContext, volName string) error {
-	zone, _, err := parseVolumeId(volName)
+func (v *volumeSource) resizeOneVolume(ctx context.ProviderCallContext, volName string, size uint64) error {
+	location, _, err := parseVolumeId(volName)
 	if err != nil {
 		return errors.Annotatef(err, "invalid volume id %q", volName)
 	}
-	disk, err := v.gce.Disk(zone, volName)
+	sizeGB := mibToGib(size)
+	if isRegion(location) {
+		err = connectionWithContext(v.gce, ctx).ResizeRegionDisk(location, volName, sizeGB)
+	} else {
+		err = connectionWithContext(v.gce, ctx).ResizeDisk(location, volName, sizeGB)
+	}
+	if err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot resize volume %q", volName), ctx)
+	}
+	return nil
+}
+
+func (v *volumeSource) releaseOneVolume(ctx context.ProviderCallContext, volName string) error {
+	if _, _, err := parseVolumeId(volName); err != nil {
+		return errors.Annotatef(err, "invalid volume id %q", volName)
+	}
+	disk, err := v.disk(volName)
 	if err != nil {
 		return google.HandleCredentialError(errors.Trace(err), ctx)
 	}
@@ -333,18 +515,55 @@ func (v *volumeSource) releaseOneVolume(ctx context.ProviderCallContext, volName
 	}
 	delete(disk.Labels, tags.JujuController)
 	delete(disk.Labels, tags.JujuModel)
-	if err := v.gce.SetDiskLabels(zone, volName, disk.LabelFingerprint, disk.Labels); err != nil {
+	if err := v.setDiskLabels(ctx, volName, disk.LabelFingerprint, disk.Labels); err != nil {
 		return google.HandleCredentialError(errors.Annotatef(err, "cannot remove labels from volume %q", volName), ctx)
 	}
 	return nil
 }
 
 func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	disks, err := v.modelDisks(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	var volumes []string
+	for _, disk := range disks {
+		volumes = append(volumes, disk.Name)
+	}
+	return volumes, nil
+}
+
+// VolumeCreationTimes is specified on the storage.VolumeCreationTimer interface.
+func (v *volumeSource) VolumeCreationTimes(ctx context.ProviderCallContext, volIds []string) (map[string]time.Time, error) {
+	disks, err := v.modelDisks(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := set.NewStrings(volIds...)
+	created := make(map[string]time.Time)
+	for _, disk := range disks {
+		if wanted.Contains(disk.Name) && !disk.Created.IsZero() {
+			created[disk.Name] = disk.Created
+		}
+	}
+	return created, nil
+}
+
+// modelDisks returns the zonal and regional disks
+// labelled as volumes of the model.
+func (v *volumeSource) modelDisks(ctx context.ProviderCallContext) ([]*google.Disk, error) {
 	disks, err := v.gce.Disks()
 	if err != nil {
 		return nil, google.HandleCredentialError(errors.Trace(err), ctx)
 	}
+	if v.region != "" {
+		regionDisks, err := v.gce.RegionDisks(v.region)
+		if err != nil {
+			return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+		}
+		disks = append(disks, regionDisks...)
+	}
+	var modelDisks []*google.Disk
 	for _, disk := range disks {
 		if !isValidVolume(disk.Name) {
 			continue
@@ -352,18 +571,17 @@ func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, e
 		if disk.Labels[tags.JujuModel] != v.modelUUID {
 			continue
 		}
-		volumes = append(volumes, disk.Name)
+		modelDisks = append(modelDisks, disk)
 	}
-	return volumes, nil
+	return modelDisks, nil
 }
 
 // ImportVolume is specified on the storage.VolumeImporter interface.
 func (v *volumeSource) ImportVolume(ctx context.ProviderCallContext, volName string, tags map[string]string) (storage.VolumeInfo, error) {
-	zone, _, err := parseVolumeId(volName)
-	if err != nil {
+	if _, _, err := parseVolumeId(volName); err != nil {
 		return storage.VolumeInfo{}, errors.Annotatef(err, "cannot get volume %q", volName)
 	}
-	disk, err := v.gce.Disk(zone, volName)
+	disk, err := v.disk(volName)
 	if err != nil {
 		return storage.VolumeInfo{}, google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", volName), ctx)
 	}
@@ -376,10 +594,10 @@ func (v *volumeSource) ImportVolume(ctx context.ProviderCallContext, volName str
 	if disk.Labels == nil {
 		disk.Labels = make(map[string]string)
 	}
-	for k, v := range resourceTagsToDiskLabels(tags) {
+	for k, v := range resourceTagsToLabels(tags) {
 		disk.Labels[k] = v
 	}
-	if err := v.gce.SetDiskLabels(zone, volName, disk.LabelFingerprint, disk.Labels); err != nil {
+	if err := v.setDiskLabels(ctx, volName, disk.LabelFingerprint, disk.Labels); err != nil {
 		return storage.VolumeInfo{}, google.HandleCredentialError(errors.Annotatef(err, "cannot update labels on volume %q", volName), ctx)
 	}
 	return storage.VolumeInfo{
@@ -402,11 +620,10 @@ func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volNames
 }
 
 func (v *volumeSource) describeOneVolume(ctx context.ProviderCallContext, volName string) (storage.DescribeVolumesResult, error) {
-	zone, _, err := parseVolumeId(volName)
-	if err != nil {
+	if _, _, err := parseVolumeId(volName); err != nil {
 		return storage.DescribeVolumesResult{}, errors.Annotatef(err, "cannot describe %q", volName)
 	}
-	disk, err := v.gce.Disk(zone, volName)
+	disk, err := v.disk(volName)
 	if err != nil {
 		return storage.DescribeVolumesResult{}, google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", volName), ctx)
 	}
@@ -420,13 +637,27 @@ func (v *volumeSource) describeOneVolume(ctx context.ProviderCallContext, volNam
 	return desc, nil
 }
 
-// TODO(perrito666) These rules are yet to be defined.
+// ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
-	return nil
+	_, err := newStorageConfig(params.Attributes)
+	return errors.Trace(err)
 }
 
 func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	results := make([]storage.AttachVolumesResult, len(attachParams))
+	instanceIds := set.NewStrings()
+	for _, attachment := range attachParams {
+		instanceIds.Add(string(attachment.InstanceId))
+	}
+	// The instances are needed to check that they are in
+	// the zones in which the volumes can be attached.
+	instances := make(instanceCache)
+	if err := instances.update(v.gce, ctx, instanceIds.Values()...); err != nil {
+		logger.Debugf("querying running instances: %v", err)
+		if google.HasDenialStatusCode(err) {
+			return results, err
+		}
+	}
 	for i, attachment := range attachParams {
 		volumeName := attachment.VolumeId
 		mode := google.ModeRW
@@ -434,7 +665,11 @@ func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, attachPara
 			mode = google.ModeRW
 		}
 		instanceId := attachment.InstanceId
-		attached, err := v.attachOneVolume(ctx, volumeName, mode, string(instanceId))
+		var attached *google.AttachedDisk
+		inst, err := instances.get(string(instanceId))
+		if err == nil {
+			attached, err = v.attachOneVolume(ctx, volumeName, mode, inst)
+		}
 		if err != nil {
 			logger.Errorf("could not attach %q to %q: %v", volumeName, instanceId, err)
 			results[i].Error = err
@@ -459,12 +694,33 @@ func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, attachPara
 	return results, nil
 }
 
-func (v *volumeSource) attachOneVolume(ctx context.ProviderCallContext, volumeName string, mode google.DiskMode, instanceId string) (*google.AttachedDisk, error) {
-	zone, _, err := parseVolumeId(volumeName)
+func (v *volumeSource) attachOneVolume(ctx context.ProviderCallContext, volumeName string, mode google.DiskMode, inst google.Instance) (*google.AttachedDisk, error) {
+	location, _, err := parseVolumeId(volumeName)
 	if err != nil {
 		return nil, errors.Annotate(err, "invalid volume name")
 	}
-	instanceDisks, err := v.gce.InstanceDisks(zone, instanceId)
+	// A zonal disk can only be attached to instances in its
+	// zone, and a regional disk to instances in its replica zones.
+	regional := isRegion(location)
+	if regional {
+		disk, err := v.gce.RegionDisk(location, volumeName)
+		if err != nil {
+			return nil, google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", volumeName), ctx)
+		}
+		if !set.NewStrings(disk.ReplicaZones...).Contains(inst.ZoneName) {
+			return nil, errors.Errorf(
+				"cannot attach volume %q replicated in zones %q to instance %q in zone %q",
+				volumeName, disk.ReplicaZones, inst.ID, inst.ZoneName,
+			)
+		}
+	} else if location != inst.ZoneName {
+		return nil, errors.Errorf(
+			"cannot attach volume %q in zone %q to instance %q in zone %q",
+			volumeName, location, inst.ID, inst.ZoneName,
+		)
+	}
+
+	instanceDisks, err := v.gce.InstanceDisks(inst.ZoneName, inst.ID)
 	if err != nil {
 		return nil, google.HandleCredentialError(errors.Annotate(err, "cannot verify if the disk is already in the instance"), ctx)
 	}
@@ -475,7 +731,12 @@ func (v *volumeSource) attachOneVolume(ctx context.ProviderCallContext, volumeNa
 		}
 	}
 
-	attachment, err := v.gce.AttachDisk(zone, volumeName, instanceId, mode)
+	var attachment *google.AttachedDisk
+	if regional {
+		attachment, err = connectionWithContext(v.gce, ctx).AttachRegionDisk(inst.ZoneName, location, volumeName, inst.ID, mode)
+	} else {
+		attachment, err = connectionWithContext(v.gce, ctx).AttachDisk(inst.ZoneName, volumeName, inst.ID, mode)
+	}
 	if err != nil {
 		return nil, google.HandleCredentialError(errors.Annotate(err, "cannot attach volume"), ctx)
 	}
@@ -498,25 +759,32 @@ func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, attachPara
 func (v *volumeSource) detachOneVolume(ctx context.ProviderCallContext, attachParam storage.VolumeAttachmentParams) error {
 	instId := attachParam.InstanceId
 	volumeName := attachParam.VolumeId
-	zone, _, err := parseVolumeId(volumeName)
+	location, _, err := parseVolumeId(volumeName)
 	if err != nil {
 		return errors.Annotatef(err, "%q is not a valid volume id", volumeName)
 	}
-	return google.HandleCredentialError(v.gce.DetachDisk(zone, string(instId), volumeName), ctx)
+	if !isRegion(location) {
+		return google.HandleCredentialError(connectionWithContext(v.gce, ctx).DetachDisk(location, string(instId), volumeName), ctx)
+	}
+	// A regional disk is detached from the instance in
+	// whichever of its replica zones the instance is.
+	zone, err := v.instanceZone(string(instId))
+	if err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return google.HandleCredentialError(connectionWithContext(v.gce, ctx).DetachRegionDisk(zone, location, string(instId), volumeName), ctx)
 }
 
-// resourceTagsToDiskLabels translates a set of
-// resource tags, provided by Juju, to disk labels.
-func resourceTagsToDiskLabels(in map[string]string) map[string]string {
-	out := make(map[string]string)
-	for k, v := range in {
-		// Only the controller and model UUID tags are carried
-		// over, as they're known not to conflict with GCE's
-		// rules regarding label values.
-		switch k {
-		case tags.JujuController, tags.JujuModel:
-			out[k] = v
+// instanceZone returns the zone of the instance with the given id.
+func (v *volumeSource) instanceZone(instId string) (string, error) {
+	instances, err := v.gce.Instances("")
+	if err != nil {
+		return "", errors.Annotate(err, "querying instance details")
+	}
+	for _, inst := range instances {
+		if inst.ID == instId {
+			return inst.ZoneName, nil
 		}
 	}
-	return out
+	return "", errors.NotFoundf("instance %q", instId)
 }
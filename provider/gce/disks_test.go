@@ -4,6 +4,8 @@
 package gce_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -38,6 +40,27 @@ func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *storageProviderSuite) TestValidateConfigDiskTypes(c *gc.C) {
+	for _, diskType := range []string{"pd-standard", "pd-balanced", "pd-ssd"} {
+		cfg, err := storage.NewConfig("foo", "gce", map[string]interface{}{
+			"disk-type": diskType,
+			"regional":  true,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.provider.ValidateConfig(cfg)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *storageProviderSuite) TestValidateConfigInvalid(c *gc.C) {
+	cfg, err := storage.NewConfig("foo", "gce", map[string]interface{}{
+		"disk-type": "local-ssd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, gc.ErrorMatches, `validating GCE storage config: disk-type: unexpected value "local-ssd"`)
+}
+
 func (s *storageProviderSuite) TestBlockStorageSupport(c *gc.C) {
 	supports := s.provider.Supports(storage.StorageKindBlock)
 	c.Check(supports, jc.IsTrue)
@@ -100,6 +123,34 @@ func (s *volumeSourceSuite) SetUpTest(c *gc.C) {
 
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesLabels(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].ResourceTags = map[string]string{
+		"juju-model-uuid": "foo",
+		"Team":            "Web Front/End",
+	}
+
+	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	createCalled, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].Disks[0].Labels, jc.DeepEquals, map[string]string{
+		"juju-model-uuid": "foo",
+		"team":            "web_front_end",
+	})
+}
+
 func (s *volumeSourceSuite) TestCreateVolumesNoInstance(c *gc.C) {
 	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
 	c.Check(err, jc.ErrorIsNil)
@@ -174,6 +225,101 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesDiskType(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].Attributes = map[string]interface{}{"disk-type": "pd-balanced"}
+	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	createCalled, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].Disks[0].PersistentDiskType, gc.Equals, google.DiskPersistentBalanced)
+	c.Assert(call[0].Disks[0].ReplicaZones, gc.HasLen, 0)
+}
+
+func (s *volumeSourceSuite) TestCreateVolumesRegional(c *gc.C) {
+	inst := *s.BaseInstance
+	inst.ZoneName = "us-east1-b"
+	s.FakeConn.Insts = []google.Instance{inst}
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("us-east1-b", google.StatusUp, "", ""),
+		google.NewZone("us-east1-c", google.StatusDown, "", ""),
+		google.NewZone("us-east1-d", google.StatusUp, "", ""),
+	}
+	regionDisk := &google.Disk{
+		Id:           1234567,
+		Name:         "us-east1--c930380d-8337-4bf5-b07a-9dbb5ae771e4",
+		Region:       "us-east1",
+		ReplicaZones: []string{"us-east1-b", "us-east1-d"},
+		Status:       google.StatusReady,
+		Size:         1024,
+	}
+	s.FakeConn.GoogleDisks = []*google.Disk{regionDisk}
+	s.FakeConn.GoogleDisk = regionDisk
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: regionDisk.Name,
+		DeviceName: "us-east1-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].Attributes = map[string]interface{}{
+		"disk-type": "pd-ssd",
+		"regional":  true,
+	}
+	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].Volume.VolumeId, gc.Equals, regionDisk.Name)
+	c.Assert(res[0].VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/google-us-east1-1234567")
+
+	zonesCalled, call := s.FakeConn.WasCalled("AvailabilityZones")
+	c.Assert(zonesCalled, jc.IsTrue)
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+
+	createCalled, call := s.FakeConn.WasCalled("CreateRegionDisks")
+	c.Assert(createCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+	c.Assert(call[0].Disks[0].Name, jc.HasPrefix, "us-east1--")
+	c.Assert(call[0].Disks[0].PersistentDiskType, gc.Equals, google.DiskPersistentSSD)
+	c.Assert(call[0].Disks[0].ReplicaZones, jc.DeepEquals, []string{"us-east1-b", "us-east1-d"})
+
+	attachCalled, call := s.FakeConn.WasCalled("AttachRegionDisk")
+	c.Assert(attachCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "us-east1-b")
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
+}
+
+func (s *volumeSourceSuite) TestCreateVolumesRegionalNoOtherZone(c *gc.C) {
+	inst := *s.BaseInstance
+	inst.ZoneName = "us-east1-b"
+	s.FakeConn.Insts = []google.Instance{inst}
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("us-east1-b", google.StatusUp, "", ""),
+		google.NewZone("us-east1-c", google.StatusDown, "", ""),
+	}
+	s.params[0].Attributes = map[string]interface{}{"regional": true}
+	res, err := s.source.CreateVolumes(s.CallCtx, s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, gc.ErrorMatches,
+		`cannot create regional volume: no available zone other than "us-east1-b" in region "us-east1"`)
+
+	createCalled, _ := s.FakeConn.WasCalled("CreateRegionDisks")
+	c.Assert(createCalled, jc.IsFalse)
+}
+
 func (s *volumeSourceSuite) TestDestroyVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -195,6 +341,48 @@ func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, "a--volume-name")
 }
 
+func (s *volumeSourceSuite) TestDestroyRegionalVolumes(c *gc.C) {
+	errs, err := s.source.DestroyVolumes(s.CallCtx, []string{"us-east1--volume-name"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+
+	destroyCalled, call := s.FakeConn.WasCalled("RemoveRegionDisk")
+	c.Check(call, gc.HasLen, 1)
+	c.Assert(destroyCalled, jc.IsTrue)
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+	c.Assert(call[0].ID, gc.Equals, "us-east1--volume-name")
+}
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	resizer, ok := s.source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	errs, err := resizer.ResizeVolumes(s.CallCtx, []storage.VolumeResizeParams{
+		{VolumeId: "a--volume-name", Size: 2049},
+		{VolumeId: "us-east1--volume-name", Size: 4096},
+		{VolumeId: "volume-name", Size: 4096},
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `invalid volume id "volume-name": malformed volume id "volume-name"`)
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "a")
+	c.Assert(call[0].ID, gc.Equals, "a--volume-name")
+	c.Assert(call[0].SizeGB, gc.Equals, uint64(3))
+
+	resizeCalled, call = s.FakeConn.WasCalled("ResizeRegionDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+	c.Assert(call[0].ID, gc.Equals, "us-east1--volume-name")
+	c.Assert(call[0].SizeGB, gc.Equals, uint64(4))
+}
+
 func (s *volumeSourceSuite) TestReleaseVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -299,6 +487,28 @@ func (s *volumeSourceSuite) TestListVolumes(c *gc.C) {
 	c.Assert(disksCalled, jc.IsTrue)
 }
 
+func (s *volumeSourceSuite) TestListVolumesIncludesRegionalDisks(c *gc.C) {
+	regionDisk := &google.Disk{
+		Id:     1234568,
+		Name:   "us-east1--566fe7b2-c026-4a86-a2cc-84cb7f9a4868",
+		Region: "us-east1",
+		Status: google.StatusReady,
+		Size:   1024,
+		Labels: map[string]string{
+			"juju-model-uuid": s.Env.Config().UUID(),
+		},
+	}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.RegionGoogleDisks = []*google.Disk{regionDisk}
+	vols, err := s.source.ListVolumes(s.CallCtx)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(vols, jc.DeepEquals, []string{s.BaseDisk.Name, regionDisk.Name})
+
+	regionDisksCalled, call := s.FakeConn.WasCalled("RegionDisks")
+	c.Assert(regionDisksCalled, jc.IsTrue)
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+}
+
 func (s *volumeSourceSuite) TestListVolumesOnlyListsCurrentModelUUID(c *gc.C) {
 	otherDisk := &google.Disk{
 		Id:          1234568,
@@ -332,6 +542,28 @@ func (s *volumeSourceSuite) TestListVolumesIgnoresNamesFormatteDifferently(c *gc
 	c.Assert(vols, gc.HasLen, 1)
 }
 
+func (s *volumeSourceSuite) TestVolumeCreationTimes(c *gc.C) {
+	created := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	disk := *s.BaseDisk
+	disk.Created = created
+	unknownAge := &google.Disk{
+		Id:     1234568,
+		Name:   "home-zone--566fe7b2-c026-4a86-a2cc-84cb7f9a4868",
+		Zone:   "home-zone",
+		Status: google.StatusReady,
+		Size:   1024,
+		Labels: map[string]string{
+			"juju-model-uuid": s.Env.Config().UUID(),
+		},
+	}
+	s.FakeConn.GoogleDisks = []*google.Disk{&disk, unknownAge}
+	timer, ok := s.source.(storage.VolumeCreationTimer)
+	c.Assert(ok, jc.IsTrue)
+	times, err := timer.VolumeCreationTimes(s.CallCtx, []string{disk.Name, unknownAge.Name, "other"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(times, jc.DeepEquals, map[string]time.Time{disk.Name: created})
+}
+
 func (s *volumeSourceSuite) TestDescribeVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -365,6 +597,7 @@ func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 		DeviceName: "home-zone-1234567",
 		Mode:       "READ_WRITE",
 	}
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
 	res, err := s.source.AttachVolumes(s.CallCtx, attachments)
 	c.Check(err, jc.ErrorIsNil)
 	c.Assert(res, gc.HasLen, 1)
@@ -383,6 +616,40 @@ func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 
 }
 
+func (s *volumeSourceSuite) TestAttachVolumesWrongZone(c *gc.C) {
+	inst := *s.BaseInstance
+	inst.ZoneName = "away-zone"
+	s.FakeConn.Insts = []google.Instance{inst}
+	res, err := s.source.AttachVolumes(s.CallCtx, []storage.VolumeAttachmentParams{*s.attachmentParams})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, gc.ErrorMatches,
+		`cannot attach volume "home-zone--.*" in zone "home-zone" to instance "spam" in zone "away-zone"`)
+
+	attachCalled, _ := s.FakeConn.WasCalled("AttachDisk")
+	c.Assert(attachCalled, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestAttachRegionalVolumeOutsideReplicaZones(c *gc.C) {
+	inst := *s.BaseInstance
+	inst.ZoneName = "us-east1-c"
+	s.FakeConn.Insts = []google.Instance{inst}
+	s.FakeConn.GoogleDisk = &google.Disk{
+		Name:         "us-east1--c930380d-8337-4bf5-b07a-9dbb5ae771e4",
+		Region:       "us-east1",
+		ReplicaZones: []string{"us-east1-b", "us-east1-d"},
+	}
+	s.attachmentParams.VolumeId = s.FakeConn.GoogleDisk.Name
+	res, err := s.source.AttachVolumes(s.CallCtx, []storage.VolumeAttachmentParams{*s.attachmentParams})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, gc.ErrorMatches,
+		`cannot attach volume "us-east1--.*" replicated in zones \["us-east1-b" "us-east1-d"\] to instance "spam" in zone "us-east1-c"`)
+
+	attachCalled, _ := s.FakeConn.WasCalled("AttachRegionDisk")
+	c.Assert(attachCalled, jc.IsFalse)
+}
+
 func (s *volumeSourceSuite) TestAttachVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -408,6 +675,25 @@ func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(call[0].VolumeName, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestDetachRegionalVolumes(c *gc.C) {
+	inst := *s.BaseInstance
+	inst.ZoneName = "us-east1-b"
+	s.FakeConn.Insts = []google.Instance{inst}
+	s.attachmentParams.VolumeId = "us-east1--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	errs, err := s.source.DetachVolumes(s.CallCtx, []storage.VolumeAttachmentParams{*s.attachmentParams})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+
+	detachCalled, call := s.FakeConn.WasCalled("DetachRegionDisk")
+	c.Assert(detachCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "us-east1-b")
+	c.Assert(call[0].Region, gc.Equals, "us-east1")
+	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
+	c.Assert(call[0].VolumeName, gc.Equals, s.attachmentParams.VolumeId)
+}
+
 func (s *volumeSourceSuite) TestDetachVolumesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
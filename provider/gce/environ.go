@@ -30,11 +30,23 @@ type gceConnection interface {
 	Instances(prefix string, statuses ...string) ([]google.Instance, error)
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
+	// RestartInstance starts the stopped instance with the given
+	// ID in the given zone.
+	RestartInstance(id, zone string) error
 	UpdateMetadata(key, value string, ids ...string) error
+	// UpdateLabels sets the labels on the instances with the
+	// given IDs, leaving any other labels in place.
+	UpdateLabels(labels map[string]string, ids ...string) error
+	// AddInstanceTags adds the network tag to the instances
+	// with the given IDs.
+	AddInstanceTags(tag string, ids ...string) error
 
 	IngressRules(fwname string) ([]network.IngressRule, error)
 	OpenPorts(fwname string, rules ...network.IngressRule) error
 	ClosePorts(fwname string, rules ...network.IngressRule) error
+	// RemoveFirewalls removes the firewalls whose
+	// names start with the prefix.
+	RemoveFirewalls(prefix string) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 	// Subnetworks returns the subnetworks that machines can be
@@ -43,6 +55,9 @@ type gceConnection interface {
 	// Networks returns the available networks that exist across
 	// regions.
 	Networks() ([]*compute.Network, error)
+	// VerifyNetwork returns an error if the network described
+	// by the spec, or its subnetwork, does not exist.
+	VerifyNetwork(spec google.NetworkSpec) error
 
 	// Storage related methods.
 
@@ -59,16 +74,48 @@ type gceConnection interface {
 	// SetDiskLabels sets the labels on a disk, ensuring that the disk's
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(zone, id, labelFingerprint string, labels map[string]string) error
+	// ResizeDisk grows the disk identified by <name> in <zone> to
+	// <sizeGB> gibibytes.
+	ResizeDisk(zone, id string, sizeGB uint64) error
+	// CreateRegionDisks will attempt to create the regional disks described by
+	// <disks> spec in <region>, and return a slice of Disk representing the
+	// created disks or error if one of them failed.
+	CreateRegionDisks(region string, disks []google.DiskSpec) ([]*google.Disk, error)
+	// RegionDisks will return a list of the regional disks in <region>.
+	RegionDisks(region string) ([]*google.Disk, error)
+	// RegionDisk will return a Disk representing the regional disk
+	// identified by the passed <name> or error.
+	RegionDisk(region, id string) (*google.Disk, error)
+	// RemoveRegionDisk will destroy the regional disk identified by
+	// <name> in <region>.
+	RemoveRegionDisk(region, id string) error
+	// SetRegionDiskLabels sets the labels on a regional disk, ensuring
+	// that the disk's label fingerprint matches the one supplied.
+	SetRegionDiskLabels(region, id, labelFingerprint string, labels map[string]string) error
+	// ResizeRegionDisk grows the regional disk identified by <name>
+	// in <region> to <sizeGB> gibibytes.
+	ResizeRegionDisk(region, id string, sizeGB uint64) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
 	// DetachDisk will detach <volumeName> disk from <instanceId> if possible
 	// and return error.
 	DetachDisk(zone, instanceId, volumeName string) error
+	// AttachRegionDisk will attach the regional volume identified by
+	// <volumeName> into the instance <instanceId> in <zone>, and return
+	// an AttachedDisk representing it or error.
+	AttachRegionDisk(zone, region, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
+	// DetachRegionDisk will detach the regional <volumeName> disk from
+	// <instanceId> in <zone> if possible and return error.
+	DetachRegionDisk(zone, region, instanceId, volumeName string) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(zone string) ([]google.MachineType, error)
+
+	// InstanceUtilization returns the most recent resource
+	// utilization of the instance with the given numeric ID.
+	InstanceUtilization(numericID uint64) (google.InstanceUtilization, error)
 }
 
 type environ struct {
@@ -86,6 +133,8 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ common.ZonedEnviron = (*environ)(nil)
+var _ context.Distributor = (*environ)(nil)
 
 // Function entry points defined as variables so they can be overridden
 // for testing purposes.
@@ -122,6 +171,9 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 	connectionConfig := google.ConnectionConfig{
 		Region:    cloud.Region,
 		ProjectID: credential.ProjectID,
+		Network:   ecfg.networkSpec(cloud.Region),
+
+		OperationTimeout: ecfg.operationTimeout(),
 	}
 
 	// Connect and authenticate.
@@ -144,6 +196,16 @@ func newEnviron(cloud environs.CloudSpec, cfg *config.Config) (*environ, error)
 	}, nil
 }
 
+// connectionWithContext returns a connection whose waits for GCE
+// operations are abandoned when the given context is dying. It
+// returns conn itself if it does not support cancellation.
+func connectionWithContext(conn gceConnection, ctx context.ProviderCallContext) gceConnection {
+	if gconn, ok := conn.(*google.Connection); ok && ctx != nil {
+		return gconn.WithContext(ctx)
+	}
+	return conn
+}
+
 // Name returns the name of the environment.
 func (env *environ) Name() string {
 	return env.name
@@ -171,10 +233,22 @@ func (env *environ) SetConfig(cfg *config.Config) error {
 	if err != nil {
 		return errors.Annotate(err, "invalid config change")
 	}
+	if err := env.verifyNetwork(ecfg); err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
 	env.ecfg = ecfg
 	return nil
 }
 
+// verifyNetwork returns an error if the network configured for
+// the model does not exist.
+func (env *environ) verifyNetwork(ecfg *environConfig) error {
+	if ecfg.network() == "" {
+		return nil
+	}
+	return errors.Trace(env.gce.VerifyNetwork(ecfg.networkSpec(env.cloud.Region)))
+}
+
 // Config returns the configuration data with which the env was created.
 func (env *environ) Config() *config.Config {
 	env.lock.Lock()
@@ -182,6 +256,13 @@ func (env *environ) Config() *config.Config {
 	return env.ecfg.config
 }
 
+// environConfig returns the GCE specific configuration of the env.
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
 // PrepareForBootstrap implements environs.Environ.
 func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
 	if ctx.ShouldVerifyCredentials() {
@@ -189,7 +270,7 @@ func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controlle
 			return errors.Trace(err)
 		}
 	}
-	return nil
+	return errors.Trace(env.verifyNetwork(env.environConfig()))
 }
 
 // Create implements environs.Environ.
@@ -197,6 +278,9 @@ func (env *environ) Create(ctx context.ProviderCallContext, p environs.CreatePar
 	if err := env.gce.VerifyCredentials(); err != nil {
 		return google.HandleCredentialError(errors.Trace(err), ctx)
 	}
+	if err := env.verifyNetwork(env.environConfig()); err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
 	return nil
 }
 
@@ -213,13 +297,13 @@ func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Pro
 		params.ControllerConfig.APIPort(),
 		params.ControllerConfig.APIPort(),
 	)
-	if err := env.gce.OpenPorts(env.globalFirewallName(), rule); err != nil {
+	if err := connectionWithContext(env.gce, callCtx).OpenPorts(env.globalFirewallName(), rule); err != nil {
 		return nil, google.HandleCredentialError(errors.Trace(err), callCtx)
 	}
 	if params.ControllerConfig.AutocertDNSName() != "" {
 		// Open port 80 as well as it handles Let's Encrypt HTTP challenge.
 		rule = network.NewOpenIngressRule("tcp", 80, 80)
-		if err := env.gce.OpenPorts(env.globalFirewallName(), rule); err != nil {
+		if err := connectionWithContext(env.gce, callCtx).OpenPorts(env.globalFirewallName(), rule); err != nil {
 			return nil, google.HandleCredentialError(errors.Trace(err), callCtx)
 		}
 	}
@@ -239,6 +323,9 @@ func (env *environ) Destroy(ctx context.ProviderCallContext) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := connectionWithContext(env.gce, ctx).RemoveFirewalls(env.applicationFirewallPrefix()); err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
 
 	return destroyEnv(env, ctx)
 }
@@ -90,6 +90,29 @@ func (env *environ) availZoneUp(ctx context.ProviderCallContext, name string) (*
 
 var availabilityZoneAllocations = common.AvailabilityZoneAllocations
 
+// DistributeInstances implements the state.InstanceDistributor policy,
+// spreading the units of an application across availability zones.
+func (env *environ) DistributeInstances(
+	ctx context.ProviderCallContext, candidates, distributionGroup []instance.Id, limitZones []string,
+) ([]instance.Id, error) {
+	return common.DistributeInstances(env, ctx, candidates, distributionGroup, limitZones)
+}
+
+// checkZoneInstanceType returns an error if the named machine type
+// is not offered in the availability zone.
+func (env *environ) checkZoneInstanceType(ctx context.ProviderCallContext, zone, instanceType string) error {
+	machineTypes, err := env.gce.ListMachineTypes(zone)
+	if err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	for _, machineType := range machineTypes {
+		if machineType.Name == instanceType {
+			return nil
+		}
+	}
+	return errors.NotFoundf("instance type %q in availability zone %q", instanceType, zone)
+}
+
 // volumeAttachmentsZone determines the availability zone for each volume
 // identified in the volume attachment parameters, checking that they are
 // all the same, and returns the availability zone name.
@@ -83,6 +83,35 @@ func (s *environAZSuite) TestInstanceAvailabilityZoneNamesAPIs(c *gc.C) {
 	}})
 }
 
+func (s *environAZSuite) TestDistributeInstances(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("home-zone", google.StatusUp, "", ""),
+		google.NewZone("away-zone", google.StatusUp, "", ""),
+	}
+	newInstance := func(id, zone string) instances.Instance {
+		return s.NewInstanceFromBase(google.NewInstance(google.InstanceSummary{
+			ID:       id,
+			ZoneName: zone,
+			Status:   google.StatusRunning,
+		}, nil))
+	}
+	s.FakeEnviron.Insts = []instances.Instance{
+		s.Instance,
+		newInstance("eggs", "away-zone"),
+		newInstance("ham", "away-zone"),
+	}
+
+	group := []instance.Id{"spam", "eggs", "ham"}
+	candidates := []instance.Id{"spam", "eggs"}
+	eligible, err := s.Env.DistributeInstances(s.CallCtx, candidates, group, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(eligible, jc.DeepEquals, []instance.Id{"spam"})
+
+	eligible, err = s.Env.DistributeInstances(s.CallCtx, candidates, group, []string{"away-zone"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(eligible, jc.SameContents, []instance.Id{"eggs"})
+}
+
 func (s *environAZSuite) TestDeriveAvailabilityZonesInvalidCredentialError(c *gc.C) {
 	s.StartInstArgs.Placement = "zone=test-available"
 	s.FakeConn.Err = gce.InvalidCredentialError
@@ -5,11 +5,14 @@ package gce
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jujuos "github.com/juju/os"
 	"github.com/juju/os/series"
 	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
@@ -19,13 +22,37 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
+	jujutags "github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/tools"
 )
 
-// MaintainInstance is specified in the InstanceBroker interface.
-func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+// MaintainInstance is specified in the InstanceBroker interface. If the
+// machine's instance is preemptible and has been stopped by GCE, it is
+// started again.
+func (env *environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	if args.InstanceConfig == nil {
+		return nil
+	}
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	insts, err := env.gce.Instances(hostname, google.StatusTerminated)
+	if err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	for _, inst := range insts {
+		// The instances are matched by prefix, so machine 1
+		// would also find the instance of machine 10.
+		if inst.ID != hostname || !inst.Preempted() {
+			continue
+		}
+		logger.Infof("restarting preempted instance %q", inst.ID)
+		err := connectionWithContext(env.gce, ctx).RestartInstance(inst.ID, inst.ZoneName)
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
 	return nil
 }
 
@@ -127,7 +154,19 @@ func (env *environ) findInstanceSpec(
 	imageMetadata []*imagemetadata.ImageMetadata,
 ) (*instances.InstanceSpec, error) {
 	images := instances.ImageMetadataToImages(imageMetadata)
-	spec, err := instances.FindInstanceSpec(images, ic, allInstanceTypes)
+	instanceTypes := allInstanceTypes
+	if env.environConfig().confidentialVM() {
+		// Only some machine types support Confidential VMs.
+		instanceTypes = confidentialInstanceTypes
+	} else if ic.Constraints.HasInstanceType() {
+		instanceTypes = knownInstanceTypes()
+	} else if custom, ok := customInstanceType(ic.Constraints); ok {
+		// Offer a custom machine type that fits the constraints, so
+		// that the next larger predefined machine type is only chosen
+		// if it is no bigger.
+		instanceTypes = append([]instances.InstanceType{custom}, allInstanceTypes...)
+	}
+	spec, err := instances.FindInstanceSpec(images, ic, instanceTypes)
 	return spec, errors.Trace(err)
 }
 
@@ -176,6 +215,7 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		env.globalFirewallName(),
 		hostname,
 	}
+	tags = append(tags, env.applicationTags(args.InstanceConfig.Tags)...)
 
 	imageURLBase, err := env.imageURLBase(os)
 	if err != nil {
@@ -192,19 +232,34 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		return nil, common.ZoneIndependentError(err)
 	}
 
+	// The instance tags, which include the user's resource-tags,
+	// are also set as labels on the instance and its root disk so
+	// that billing exports can be broken down by model.
+	labels := resourceTagsToLabels(args.InstanceConfig.Tags)
+	for i := range disks {
+		disks[i].Labels = labels
+	}
+
+	ecfg := env.environConfig()
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
-	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
 	// TODO(ericsnow) Use a different net interface name? Configurable?
-	inst, err := env.gce.AddInstance(google.InstanceSpec{
+	inst, err := connectionWithContext(env.gce, ctx).AddInstance(google.InstanceSpec{
 		ID:                hostname,
 		Type:              spec.InstanceType.Name,
 		Disks:             disks,
 		NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata:          metadata,
 		Tags:              tags,
+		Labels:            labels,
 		AvailabilityZone:  args.AvailabilityZone,
-		// Network is omitted (left empty).
+		Accelerators:      getAccelerators(args.Constraints),
+		Preemptible:       hasInstanceLifecycle(args.Constraints, lifecyclePreemptible),
+		Spot:              hasInstanceLifecycle(args.Constraints, lifecycleSpot),
+		ShieldedVM:        ecfg.shieldedVM(),
+		ConfidentialVM:    ecfg.confidentialVM(),
+		Network:           ecfg.networkSpec(env.cloud.Region),
 	})
 	if err != nil {
 		// We currently treat all AddInstance failures
@@ -215,6 +270,49 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 	return inst, nil
 }
 
+// applicationTags returns the network tags targeted by the firewalls
+// of the applications whose units are to be deployed to a new instance.
+func (env *environ) applicationTags(instanceTags map[string]string) []string {
+	applications := set.NewStrings()
+	for _, unitName := range strings.Fields(instanceTags[jujutags.JujuUnitsDeployed]) {
+		if !names.IsValidUnit(unitName) {
+			continue
+		}
+		applicationName, err := names.UnitApplication(unitName)
+		if err != nil {
+			continue
+		}
+		applications.Add(applicationName)
+	}
+	var result []string
+	for _, applicationName := range applications.SortedValues() {
+		result = append(result, env.applicationFirewallName(applicationName))
+	}
+	return result
+}
+
+// getAccelerators returns the GPUs to attach to a new instance
+// with the given constraints.
+func getAccelerators(cons constraints.Value) []google.AcceleratorSpec {
+	if !cons.HasGPUs() {
+		return nil
+	}
+	acceleratorType := defaultAcceleratorType
+	if cons.HasGPUType() {
+		acceleratorType = *cons.GPUType
+	}
+	return []google.AcceleratorSpec{{
+		Type:  acceleratorType,
+		Count: int64(*cons.GPUs),
+	}}
+}
+
+// hasInstanceLifecycle reports whether the constraints ask for
+// an instance with the given lifecycle.
+func hasInstanceLifecycle(cons constraints.Value, lifecycle string) bool {
+	return cons.HasInstanceLifecycle() && *cons.InstanceLifecycle == lifecycle
+}
+
 // getMetadata builds the raw "user-defined" metadata for the new
 // instance (relative to the provided args) and returns it.
 func getMetadata(args environs.StartInstanceParams, os jujuos.OSType) (map[string]string, error) {
@@ -266,7 +364,13 @@ func getDisks(spec *instances.InstanceSpec, cons constraints.Value, ser, eUUID s
 	if imageURLBase == "" {
 		return nil, errors.NotValidf("imageURLBase must be set")
 	}
-	imageURL := imageURLBase + spec.Image.Id
+	// The ids of images from simplestreams are names relative to the
+	// image base path, but an image id pinned for the model may be
+	// the path or URL of an image in any project.
+	imageURL := spec.Image.Id
+	if !strings.Contains(imageURL, "/") {
+		imageURL = imageURLBase + imageURL
+	}
 	logger.Infof("fetching disk image from %v", imageURL)
 	dSpec := google.DiskSpec{
 		Series:     ser,
@@ -295,6 +399,16 @@ func (env *environ) getHardwareCharacteristics(spec *instances.InstanceSpec, ins
 		AvailabilityZone: &inst.base.ZoneName,
 		// Tags: not supported in GCE.
 	}
+	if len(inst.base.Accelerators) > 0 {
+		var gpus uint64
+		for _, accelerator := range inst.base.Accelerators {
+			gpus += uint64(accelerator.Count)
+		}
+		// Juju only ever attaches a single type of GPU.
+		gpuType := inst.base.Accelerators[0].Type
+		hwc.GPUs = &gpus
+		hwc.GPUType = &gpuType
+	}
 	return &hwc
 }
 
@@ -332,6 +446,6 @@ func (env *environ) StopInstances(ctx context.ProviderCallContext, instances ...
 	}
 
 	prefix := env.namespace.Prefix()
-	err := env.gce.RemoveInstances(prefix, ids...)
+	err := connectionWithContext(env.gce, ctx).RemoveInstances(prefix, ids...)
 	return google.HandleCredentialError(errors.Trace(err), ctx)
 }
@@ -13,13 +13,16 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/storage"
 )
 
@@ -145,6 +148,36 @@ func (s *environBrokerSuite) TestFindInstanceSpec(c *gc.C) {
 	c.Check(spec, jc.DeepEquals, s.spec)
 }
 
+func (s *environBrokerSuite) TestFindInstanceSpecConfidentialVM(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"confidential-vm": true})
+	s.ic.Constraints = constraints.Value{}
+
+	spec, err := gce.FindInstanceSpec(s.Env, s.ic, s.imageMetadata)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "n2d-standard-2")
+}
+
+func (s *environBrokerSuite) TestFindInstanceSpecCustomMachineType(c *gc.C) {
+	s.ic.Constraints = constraints.MustParse("cores=6 mem=20G")
+
+	spec, err := gce.FindInstanceSpec(s.Env, s.ic, s.imageMetadata)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "custom-6-20480")
+	c.Check(spec.InstanceType.CpuCores, gc.Equals, uint64(6))
+	c.Check(spec.InstanceType.Mem, gc.Equals, uint64(20480))
+}
+
+func (s *environBrokerSuite) TestFindInstanceSpecPredefinedMachineTypeFits(c *gc.C) {
+	s.ic.Constraints = constraints.MustParse("cores=2 mem=7500M")
+
+	spec, err := gce.FindInstanceSpec(s.Env, s.ic, s.imageMetadata)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "n1-standard-2")
+}
+
 func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	s.FakeConn.Inst = s.BaseInstance
 	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
@@ -158,6 +191,150 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, jc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) TestNewRawInstanceGPUs(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("gpus=2")
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Accelerators, jc.DeepEquals, []google.AcceleratorSpec{{
+		Type:  "nvidia-tesla-k80",
+		Count: 2,
+	}})
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceGPUType(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("gpus=1 gpu-type=nvidia-tesla-v100")
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Accelerators, jc.DeepEquals, []google.AcceleratorSpec{{
+		Type:  "nvidia-tesla-v100",
+		Count: 1,
+	}})
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceNetwork(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.UpdateConfig(c, map[string]interface{}{
+		"network":              "shared",
+		"subnetwork":           "juju",
+		"network-host-project": "host",
+	})
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Network, jc.DeepEquals, google.NetworkSpec{
+		Name:       "shared",
+		Subnetwork: "juju",
+		Region:     "us-east1",
+		Project:    "host",
+	})
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceApplicationTags(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.InstanceConfig.Tags = map[string]string{
+		tags.JujuUnitsDeployed: "wordpress/1 mysql/0 wordpress/2",
+	}
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Tags, jc.DeepEquals, []string{
+		gce.GlobalFirewallName(s.Env),
+		"juju-f75cba-0",
+		gce.ApplicationFirewallName(s.Env, "mysql"),
+		gce.ApplicationFirewallName(s.Env, "wordpress"),
+	})
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceLabels(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.InstanceConfig.Tags = map[string]string{
+		tags.JujuController: s.ControllerUUID,
+		tags.JujuModel:      "some-uuid",
+		"Owner":             "Mary Jane",
+	}
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]string{
+		tags.JujuController: s.ControllerUUID,
+		tags.JujuModel:      "some-uuid",
+		"owner":             "mary_jane",
+	}
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	instanceSpec := s.FakeConn.Calls[0].InstanceSpec
+	c.Check(instanceSpec.Labels, jc.DeepEquals, expected)
+	c.Assert(instanceSpec.Disks, gc.HasLen, 1)
+	c.Check(instanceSpec.Disks[0].Labels, jc.DeepEquals, expected)
+}
+
+func (s *environBrokerSuite) TestNewRawInstancePreemptible(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("instance-lifecycle=preemptible")
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Preemptible, jc.IsTrue)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Spot, jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceSpot(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("instance-lifecycle=spot")
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Spot, jc.IsTrue)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Preemptible, jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceNotPreemptible(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.Preemptible, jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceShieldedVM(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.UpdateConfig(c, map[string]interface{}{
+		"shielded-vm-secure-boot":          true,
+		"shielded-vm-vtpm":                 true,
+		"shielded-vm-integrity-monitoring": true,
+		"confidential-vm":                  true,
+	})
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.ShieldedVM, jc.DeepEquals, google.ShieldedVMSpec{
+		SecureBoot:          true,
+		VTPM:                true,
+		IntegrityMonitoring: true,
+	})
+	c.Check(s.FakeConn.Calls[0].InstanceSpec.ConfidentialVM, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestNewRawInstanceZoneInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
@@ -241,6 +418,20 @@ func (s *environBrokerSuite) TestGetDisks(c *gc.C) {
 	c.Assert(spec.ImageURL, gc.Equals, gce.UbuntuDailyImageBasePath+s.spec.Image.Id)
 }
 
+func (s *environBrokerSuite) TestGetDisksPinnedImage(c *gc.C) {
+	for _, imageId := range []string{
+		"projects/my-project/global/images/my-image",
+		"https://www.googleapis.com/compute/v1/projects/my-project/global/images/my-image",
+	} {
+		spec := *s.spec
+		spec.Image.Id = imageId
+		diskSpecs, err := gce.GetDisks(&spec, s.StartInstArgs.Constraints, "trusty", "32f7d570-5bac-4b72-b169-250c24a94b2b", gce.UbuntuImageBasePath)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(diskSpecs, gc.HasLen, 1)
+		c.Check(diskSpecs[0].ImageURL, gc.Equals, imageId)
+	}
+}
+
 func (s *environBrokerSuite) TestSettingImageStreamsViaConfig(c *gc.C) {
 	s.FakeConn.Inst = s.BaseInstance
 	s.UpdateConfig(c, map[string]interface{}{"image-stream": "released"})
@@ -281,6 +472,76 @@ func (s *environBrokerSuite) TestGetHardwareCharacteristics(c *gc.C) {
 	c.Check(*hwc.CpuPower, gc.Equals, uint64(275))
 	c.Check(*hwc.Mem, gc.Equals, uint64(3750))
 	c.Check(*hwc.RootDisk, gc.Equals, uint64(15360))
+	c.Check(hwc.GPUs, gc.IsNil)
+	c.Check(hwc.GPUType, gc.IsNil)
+}
+
+func (s *environBrokerSuite) TestGetHardwareCharacteristicsGPUs(c *gc.C) {
+	summary := google.InstanceSummary{
+		ID:       "spam",
+		ZoneName: "home-zone",
+		Accelerators: []google.AcceleratorSpec{{
+			Type:  "nvidia-tesla-p100",
+			Count: 4,
+		}},
+	}
+	inst := gce.NewInstance(google.NewInstance(summary, nil), s.Env)
+
+	hwc := gce.GetHardwareCharacteristics(s.Env, s.spec, inst)
+	c.Assert(hwc, gc.NotNil)
+	c.Check(*hwc.GPUs, gc.Equals, uint64(4))
+	c.Check(*hwc.GPUType, gc.Equals, "nvidia-tesla-p100")
+}
+
+func (s *environBrokerSuite) preemptedInstances(preemptible bool) []google.Instance {
+	summary := google.InstanceSummary{
+		ID:          s.InstName,
+		ZoneName:    "home-zone",
+		Status:      google.StatusTerminated,
+		Preemptible: preemptible,
+	}
+	// Instances are listed by prefix, so other machines'
+	// instances may be returned too.
+	other := summary
+	other.ID = s.InstName + "0"
+	return []google.Instance{
+		*google.NewInstance(other, nil),
+		*google.NewInstance(summary, nil),
+	}
+}
+
+func (s *environBrokerSuite) TestMaintainInstancePreempted(c *gc.C) {
+	s.FakeConn.Insts = s.preemptedInstances(true)
+
+	err := s.Env.MaintainInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusTerminated})
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RestartInstance")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-zone")
+}
+
+func (s *environBrokerSuite) TestMaintainInstanceNotPreemptible(c *gc.C) {
+	s.FakeConn.Insts = s.preemptedInstances(false)
+
+	err := s.Env.MaintainInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+}
+
+func (s *environBrokerSuite) TestMaintainInstanceRestartFailed(c *gc.C) {
+	s.FakeConn.Insts = s.preemptedInstances(true)
+	s.FakeConn.Err = errors.New("no capacity")
+	s.FakeConn.FailOnCall = 1
+
+	err := s.Env.MaintainInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, "no capacity")
 }
 
 func (s *environBrokerSuite) TestAllRunningInstances(c *gc.C) {
@@ -4,8 +4,12 @@
 package gce
 
 import (
+	"crypto/sha256"
+	"fmt"
+
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
@@ -21,7 +25,7 @@ func (env *environ) globalFirewallName() string {
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
 func (env *environ) OpenPorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
-	err := env.gce.OpenPorts(env.globalFirewallName(), rules...)
+	err := connectionWithContext(env.gce, ctx).OpenPorts(env.globalFirewallName(), rules...)
 	return google.HandleCredentialError(errors.Trace(err), ctx)
 }
 
@@ -29,7 +33,7 @@ func (env *environ) OpenPorts(ctx context.ProviderCallContext, rules []network.I
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
 func (env *environ) ClosePorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
-	err := env.gce.ClosePorts(env.globalFirewallName(), rules...)
+	err := connectionWithContext(env.gce, ctx).ClosePorts(env.globalFirewallName(), rules...)
 	return google.HandleCredentialError(errors.Trace(err), ctx)
 }
 
@@ -40,3 +44,64 @@ func (env *environ) IngressRules(ctx context.ProviderCallContext) ([]network.Ing
 	rules, err := env.gce.IngressRules(env.globalFirewallName())
 	return rules, google.HandleCredentialError(errors.Trace(err), ctx)
 }
+
+// maxApplicationFirewallLength is the length that application names
+// are truncated to in firewall names, leaving room for the namespace
+// prefix, a hash, and the suffix that OpenPorts may add within the 63
+// characters allowed in GCE names.
+const maxApplicationFirewallLength = 29
+
+var _ environs.ApplicationFirewaller = (*environ)(nil)
+
+// applicationFirewallPrefix returns the prefix of the names of all of
+// the model's application firewalls.
+func (env *environ) applicationFirewallPrefix() string {
+	return env.namespace.Value("app")
+}
+
+// applicationFirewallName returns the name to use for the firewall of
+// the named application, which is also the network tag given to the
+// instances hosting its units. The hash of the full application name
+// keeps the names unique when they are truncated, and stops the
+// firewalls of an application matching those of another application
+// whose name it prefixes.
+func (env *environ) applicationFirewallName(applicationName string) string {
+	hash := sha256.Sum256([]byte(applicationName))
+	name := applicationName
+	if len(name) > maxApplicationFirewallLength {
+		name = name[:maxApplicationFirewallLength]
+	}
+	return fmt.Sprintf("%s-%s-%x", env.applicationFirewallPrefix(), name, hash[:4])
+}
+
+// OpenApplicationPorts implements environs.ApplicationFirewaller.
+// The instances of the machines are given the application's network
+// tag, which the application's firewall rules target.
+func (env *environ) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, machineIds []string, rules []network.IngressRule) error {
+	fwname := env.applicationFirewallName(applicationName)
+	hostnames := make([]string, len(machineIds))
+	for i, id := range machineIds {
+		hostname, err := env.namespace.Hostname(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		hostnames[i] = hostname
+	}
+	if err := connectionWithContext(env.gce, ctx).AddInstanceTags(fwname, hostnames...); err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	err := connectionWithContext(env.gce, ctx).OpenPorts(fwname, rules...)
+	return google.HandleCredentialError(errors.Trace(err), ctx)
+}
+
+// CloseApplicationPorts implements environs.ApplicationFirewaller.
+func (env *environ) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	err := connectionWithContext(env.gce, ctx).ClosePorts(env.applicationFirewallName(applicationName), rules...)
+	return google.HandleCredentialError(errors.Trace(err), ctx)
+}
+
+// ApplicationIngressRules implements environs.ApplicationFirewaller.
+func (env *environ) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	rules, err := env.gce.IngressRules(env.applicationFirewallName(applicationName))
+	return rules, google.HandleCredentialError(errors.Trace(err), ctx)
+}
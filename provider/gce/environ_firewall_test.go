@@ -99,3 +99,58 @@ func (s *environFirewallSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 }
+
+func (s *environFirewallSuite) TestApplicationFirewallName(c *gc.C) {
+	c.Check(gce.ApplicationFirewallName(s.Env, "mysql"), gc.Matches, "juju-f75cba-app-mysql-[0-9a-f]{8}")
+	c.Check(gce.ApplicationFirewallName(s.Env, "mysql"), gc.Not(gc.Equals), gce.ApplicationFirewallName(s.Env, "mysql-slave"))
+}
+
+func (s *environFirewallSuite) TestApplicationFirewallNameLong(c *gc.C) {
+	long := "a-very-long-application-name-for-a-gce-firewall"
+	fwname := gce.ApplicationFirewallName(s.Env, long)
+	c.Check(fwname, gc.Matches, "juju-f75cba-app-a-very-long-application-name--[0-9a-f]{8}")
+	c.Check(fwname, gc.Not(gc.Equals), gce.ApplicationFirewallName(s.Env, long+"-2"))
+	// The name may be given a 9 character suffix by OpenPorts.
+	c.Check(len(fwname)+9 <= 63, jc.IsTrue)
+}
+
+func (s *environFirewallSuite) TestOpenApplicationPortsAPI(c *gc.C) {
+	fwname := gce.ApplicationFirewallName(s.Env, "mysql")
+	err := s.Env.OpenApplicationPorts(s.CallCtx, "mysql", []string{"0", "1/lxd/2"}, s.Rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "AddInstanceTags")
+	c.Check(s.FakeConn.Calls[0].Value, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].IDs, jc.DeepEquals, []string{"juju-f75cba-0", "juju-f75cba-1-lxd-2"})
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "OpenPorts")
+	c.Check(s.FakeConn.Calls[1].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[1].Rules, jc.DeepEquals, s.Rules)
+}
+
+func (s *environFirewallSuite) TestOpenApplicationPortsInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	err := s.Env.OpenApplicationPorts(s.CallCtx, "mysql", []string{"0"}, s.Rules)
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
+
+func (s *environFirewallSuite) TestCloseApplicationPortsAPI(c *gc.C) {
+	err := s.Env.CloseApplicationPorts(s.CallCtx, "mysql", s.Rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ClosePorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, gce.ApplicationFirewallName(s.Env, "mysql"))
+	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, s.Rules)
+}
+
+func (s *environFirewallSuite) TestApplicationIngressRules(c *gc.C) {
+	s.FakeConn.Rules = s.Rules
+
+	rules, err := s.Env.ApplicationIngressRules(s.CallCtx, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, s.Rules)
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, gce.ApplicationFirewallName(s.Env, "mysql"))
+}
@@ -36,7 +36,9 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 		return nil, environs.ErrNoInstances
 	}
 
-	all, err := getInstances(env, ctx)
+	// Preemptible instances stopped by GCE are terminated, but they
+	// are still reported so that their machines show the preemption.
+	all, err := getInstances(env, ctx, append(instStatuses, google.StatusTerminated)...)
 	if err != nil {
 		// We don't return the error since we need to pack one instance
 		// for each ID into the result. If there is a problem then we
@@ -46,6 +48,8 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 		err = errors.Trace(err)
 	}
 
+	all = excludeTerminated(all)
+
 	// Build the result, matching the provided instance IDs.
 	numFound := 0 // This will never be greater than len(ids).
 	results := make([]instances.Instance, len(ids))
@@ -67,6 +71,21 @@ func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id
 	return results, err
 }
 
+// excludeTerminated returns the instances that are not terminated,
+// along with any preemptible instances that have been preempted.
+func excludeTerminated(all []instances.Instance) []instances.Instance {
+	var result []instances.Instance
+	for _, inst := range all {
+		if inst, ok := inst.(*environInstance); ok {
+			if inst.base.Status() == google.StatusTerminated && !inst.base.Preempted() {
+				continue
+			}
+		}
+		result = append(result, inst)
+	}
+	return result
+}
+
 var getInstances = func(env *environ, ctx context.ProviderCallContext, statusFilters ...string) ([]instances.Instance, error) {
 	return env.instances(ctx, statusFilters...)
 }
@@ -139,10 +158,86 @@ func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUU
 	for _, id := range instances {
 		stringIds = append(stringIds, string(id.Id()))
 	}
-	err = env.gce.UpdateMetadata(tags.JujuController, controllerUUID, stringIds...)
+	err = connectionWithContext(env.gce, ctx).UpdateMetadata(tags.JujuController, controllerUUID, stringIds...)
 	if err != nil {
 		return google.HandleCredentialError(errors.Trace(err), ctx)
 	}
+	labels := resourceTagsToLabels(map[string]string{tags.JujuController: controllerUUID})
+	err = connectionWithContext(env.gce, ctx).UpdateLabels(labels, stringIds...)
+	if err != nil {
+		return google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return nil
+}
+
+var _ environs.InstanceTagger = (*environ)(nil)
+
+// TagInstance is part of the environs.InstanceTagger interface.
+// The tags are set as instance metadata, and as labels on both the
+// instance and the disks attached to it.
+func (env *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, instanceTags map[string]string) error {
+	for key, value := range instanceTags {
+		if err := connectionWithContext(env.gce, ctx).UpdateMetadata(key, value, string(id)); err != nil {
+			return google.HandleCredentialError(errors.Annotatef(err, "tagging instance %q", id), ctx)
+		}
+	}
+	labels := resourceTagsToLabels(instanceTags)
+	err := connectionWithContext(env.gce, ctx).UpdateLabels(labels, string(id))
+	if err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "labelling instance %q", id), ctx)
+	}
+	if err := env.labelInstanceDisks(ctx, id, labels); err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "labelling disks of instance %q", id), ctx)
+	}
+	return nil
+}
+
+// labelInstanceDisks sets the labels on each of the disks attached
+// to the instance, leaving any other labels in place.
+func (env *environ) labelInstanceDisks(ctx context.ProviderCallContext, id instance.Id, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	insts, err := env.Instances(ctx, []instance.Id{id})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	zone := insts[0].(*environInstance).base.ZoneName
+	conn := connectionWithContext(env.gce, ctx)
+	attached, err := conn.InstanceDisks(zone, string(id))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, att := range attached {
+		// The root disk is named after the instance, and is in the
+		// instance's zone. Volumes are named after their location,
+		// which may be a region.
+		location := zone
+		if volLocation, _, err := parseVolumeId(att.VolumeName); err == nil {
+			location = volLocation
+		}
+		var disk *google.Disk
+		if isRegion(location) {
+			disk, err = conn.RegionDisk(location, att.VolumeName)
+		} else {
+			disk, err = conn.Disk(location, att.VolumeName)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		merged, changed := mergeLabels(disk.Labels, labels)
+		if !changed {
+			continue
+		}
+		if isRegion(location) {
+			err = conn.SetRegionDiskLabels(location, disk.Name, disk.LabelFingerprint, merged)
+		} else {
+			err = conn.SetDiskLabels(location, disk.Name, disk.LabelFingerprint, merged)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "labelling disk %q", disk.Name)
+		}
+	}
 	return nil
 }
 
@@ -179,7 +274,7 @@ func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement st
 // specify a recognized instance type.
 func checkInstanceType(cons constraints.Value) bool {
 	// Constraint has an instance-type constraint so let's see if it is valid.
-	for _, itype := range allInstanceTypes {
+	for _, itype := range knownInstanceTypes() {
 		if itype.Name == *cons.InstanceType {
 			return true
 		}
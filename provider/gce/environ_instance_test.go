@@ -37,6 +37,25 @@ func (s *environInstSuite) TestInstances(c *gc.C) {
 	c.Check(insts, jc.DeepEquals, []instances.Instance{spam, eggs, ham})
 }
 
+func (s *environInstSuite) TestInstancesPreempted(c *gc.C) {
+	preempted := s.NewInstanceFromBase(google.NewInstance(google.InstanceSummary{
+		ID:          "spam",
+		Status:      google.StatusTerminated,
+		Preemptible: true,
+	}, nil))
+	terminated := s.NewInstanceFromBase(google.NewInstance(google.InstanceSummary{
+		ID:     "ham",
+		Status: google.StatusTerminated,
+	}, nil))
+	s.FakeEnviron.Insts = []instances.Instance{preempted, terminated}
+
+	ids := []instance.Id{"spam", "ham"}
+	insts, err := s.Env.Instances(s.CallCtx, ids)
+
+	c.Check(insts, jc.DeepEquals, []instances.Instance{preempted, nil})
+	c.Check(errors.Cause(err), gc.Equals, environs.ErrPartialInstances)
+}
+
 func (s *environInstSuite) TestInstancesEmptyArg(c *gc.C) {
 	_, err := s.Env.Instances(s.CallCtx, nil)
 
@@ -223,12 +242,94 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 
 	err := s.Env.AdoptResources(s.CallCtx, "other-uuid", version.MustParse("1.2.3"))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
 	call := s.FakeConn.Calls[0]
 	c.Check(call.FuncName, gc.Equals, "UpdateMetadata")
 	c.Check(call.IDs, gc.DeepEquals, []string{"john", "misty"})
 	c.Check(call.Key, gc.Equals, tags.JujuController)
 	c.Check(call.Value, gc.Equals, "other-uuid")
+	call = s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "UpdateLabels")
+	c.Check(call.IDs, gc.DeepEquals, []string{"john", "misty"})
+	c.Check(call.Labels, jc.DeepEquals, map[string]string{tags.JujuController: "other-uuid"})
+}
+
+func (s *environInstSuite) TestTagInstance(c *gc.C) {
+	s.FakeEnviron.Insts = []instances.Instance{s.Instance}
+	s.FakeConn.AttachedDisks = []*google.AttachedDisk{{VolumeName: "spam"}}
+	s.FakeConn.GoogleDisk = &google.Disk{
+		Name:             "spam",
+		Zone:             "home-zone",
+		Labels:           map[string]string{"other": "label"},
+		LabelFingerprint: "fingerprint",
+	}
+	err := s.Env.TagInstance(s.CallCtx, "spam", map[string]string{
+		tags.JujuModel:         "some-uuid",
+		"CostCentre":           "Dept. 42",
+		"9lives":               "cat",
+		tags.JujuUnitsDeployed: "wordpress/1 mysql/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels := map[string]string{
+		tags.JujuModel:         "some-uuid",
+		"costcentre":           "dept__42",
+		tags.JujuUnitsDeployed: "wordpress_1_mysql_0",
+	}
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 8)
+	for _, call := range s.FakeConn.Calls[:4] {
+		c.Check(call.FuncName, gc.Equals, "UpdateMetadata")
+		c.Check(call.IDs, gc.DeepEquals, []string{"spam"})
+	}
+	call := s.FakeConn.Calls[4]
+	c.Check(call.FuncName, gc.Equals, "UpdateLabels")
+	c.Check(call.IDs, gc.DeepEquals, []string{"spam"})
+	c.Check(call.Labels, jc.DeepEquals, labels)
+
+	call = s.FakeConn.Calls[5]
+	c.Check(call.FuncName, gc.Equals, "InstanceDisks")
+	c.Check(call.ZoneName, gc.Equals, "home-zone")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+	call = s.FakeConn.Calls[6]
+	c.Check(call.FuncName, gc.Equals, "Disk")
+	c.Check(call.ZoneName, gc.Equals, "home-zone")
+	c.Check(call.ID, gc.Equals, "spam")
+	call = s.FakeConn.Calls[7]
+	c.Check(call.FuncName, gc.Equals, "SetDiskLabels")
+	c.Check(call.ZoneName, gc.Equals, "home-zone")
+	c.Check(call.ID, gc.Equals, "spam")
+	c.Check(call.LabelFingerprint, gc.Equals, "fingerprint")
+	labels["other"] = "label"
+	c.Check(call.Labels, jc.DeepEquals, labels)
+}
+
+func (s *environInstSuite) TestTagInstanceRegionalVolume(c *gc.C) {
+	s.FakeEnviron.Insts = []instances.Instance{s.Instance}
+	s.FakeConn.AttachedDisks = []*google.AttachedDisk{{VolumeName: "home-region1--c930380d-8337-4bf5-b07a-9dbb5ae771e4"}}
+	s.FakeConn.GoogleDisk = &google.Disk{
+		Name:   "home-region1--c930380d-8337-4bf5-b07a-9dbb5ae771e4",
+		Region: "home-region1",
+		Labels: map[string]string{tags.JujuModel: "some-uuid"},
+	}
+	err := s.Env.TagInstance(s.CallCtx, "spam", map[string]string{tags.JujuModel: "some-uuid"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The disk already has the labels, so they are not set again.
+	var names []string
+	for _, call := range s.FakeConn.Calls {
+		names = append(names, call.FuncName)
+	}
+	c.Assert(names, jc.DeepEquals, []string{"UpdateMetadata", "UpdateLabels", "InstanceDisks", "RegionDisk"})
+	c.Check(s.FakeConn.Calls[3].Region, gc.Equals, "home-region1")
+}
+
+func (s *environInstSuite) TestTagInstanceInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+
+	err := s.Env.TagInstance(s.CallCtx, "spam", map[string]string{tags.JujuModel: "some-uuid"})
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
 }
 
 func (s *environInstSuite) TestAdoptResourcesInvalidCredentialError(c *gc.C) {
@@ -18,7 +18,8 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, err := env.instancePlacementZone(ctx, args.Placement, volumeAttachmentsZone); err != nil {
+	zone, err := env.instancePlacementZone(ctx, args.Placement, volumeAttachmentsZone)
+	if err != nil {
 		return errors.Trace(err)
 	}
 
@@ -26,11 +27,36 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 		if !checkInstanceType(args.Constraints) {
 			return errors.Errorf("invalid GCE instance type %q", *args.Constraints.InstanceType)
 		}
+		// Not every machine type is offered in every zone, so
+		// check the type when placing in a specific zone.
+		if args.Placement != "" {
+			if err := env.checkZoneInstanceType(ctx, zone, *args.Constraints.InstanceType); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	if env.environConfig().confidentialVM() {
+		if err := checkConfidentialVM(args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return nil
 }
 
+// checkConfidentialVM returns an error if an instance with the
+// provided constraints cannot be created as a Confidential VM.
+func checkConfidentialVM(cons constraints.Value) error {
+	if cons.HasInstanceType() && !supportsConfidentialVM(*cons.InstanceType) {
+		return errors.NotSupportedf("confidential VM with instance type %q", *cons.InstanceType)
+	}
+	if cons.HasGPUs() {
+		return errors.NotSupportedf("confidential VM with GPUs")
+	}
+	return nil
+}
+
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
@@ -65,14 +91,19 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 
 	// vocab
 
-	instTypeNames := make([]string, len(allInstanceTypes))
-	for i, itype := range allInstanceTypes {
+	instanceTypes := knownInstanceTypes()
+	instTypeNames := make([]string, len(instanceTypes))
+	for i, itype := range instanceTypes {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	validator.RegisterVocabulary(constraints.GPUType, allAcceleratorTypes)
+
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, allInstanceLifecycles)
+
 	return validator, nil
 }
 
@@ -33,15 +33,31 @@ func (s *environPolSuite) TestPrecheckInstanceFullAPI(c *gc.C) {
 	s.FakeConn.Zones = []google.AvailabilityZone{
 		google.NewZone("home-zone", google.StatusUp, "", ""),
 	}
+	s.FakeConn.MachineTypes = []google.MachineType{{Name: "n1-standard-1"}}
 
 	cons := constraints.MustParse("instance-type=n1-standard-1 arch=amd64 root-disk=1G")
 	placement := "zone=home-zone"
 	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: version.SupportedLTS(), Constraints: cons, Placement: placement})
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "AvailabilityZones")
 	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "us-east1")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListMachineTypes")
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-zone")
+}
+
+func (s *environPolSuite) TestPrecheckInstanceInstanceTypeNotInZone(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("home-zone", google.StatusUp, "", ""),
+	}
+	s.FakeConn.MachineTypes = []google.MachineType{{Name: "n1-standard-2"}}
+
+	cons := constraints.MustParse("instance-type=n1-standard-1")
+	placement := "zone=home-zone"
+	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: version.SupportedLTS(), Constraints: cons, Placement: placement})
+	c.Assert(err, gc.ErrorMatches, `instance type "n1-standard-1" in availability zone "home-zone" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
 func (s *environPolSuite) TestPrecheckInstanceValidInstanceType(c *gc.C) {
@@ -58,6 +74,22 @@ func (s *environPolSuite) TestPrecheckInstanceInvalidInstanceType(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.*invalid GCE instance type.*`)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceConfidentialVM(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"confidential-vm": true})
+
+	cons := constraints.MustParse("instance-type=n2d-standard-4")
+	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: version.SupportedLTS(), Constraints: cons})
+	c.Check(err, jc.ErrorIsNil)
+
+	cons = constraints.MustParse("instance-type=n1-standard-1")
+	err = s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: version.SupportedLTS(), Constraints: cons})
+	c.Check(err, gc.ErrorMatches, `confidential VM with instance type "n1-standard-1" not supported`)
+
+	cons = constraints.MustParse("gpus=1")
+	err = s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: version.SupportedLTS(), Constraints: cons})
+	c.Check(err, gc.ErrorMatches, `confidential VM with GPUs not supported`)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceDiskSize(c *gc.C) {
 	cons := constraints.MustParse("instance-type=n1-standard-1 root-disk=1G")
 	placement := ""
@@ -196,6 +228,36 @@ func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: container=lxd\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabGPUType(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("gpus=1 gpu-type=nvidia-tesla-t4")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, gc.HasLen, 0)
+
+	cons = constraints.MustParse("gpu-type=voodoo")
+	_, err = validator.Validate(cons)
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: gpu-type=voodoo\nvalid values are:.*")
+}
+
+func (s *environPolSuite) TestConstraintsValidatorVocabInstanceLifecycle(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, lifecycle := range []string{"preemptible", "spot"} {
+		cons := constraints.MustParse("instance-lifecycle=" + lifecycle)
+		unsupported, err := validator.Validate(cons)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(unsupported, gc.HasLen, 0)
+	}
+
+	cons := constraints.MustParse("instance-lifecycle=forever")
+	_, err = validator.Validate(cons)
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=forever\nvalid values are: \\[preemptible spot\\]")
+}
+
 func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package gce_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/testing"
 )
 
@@ -58,6 +60,33 @@ func (s *environSuite) TestSetConfigFake(c *gc.C) {
 	c.Check(s.FakeConn.Calls, gc.HasLen, 0)
 }
 
+func (s *environSuite) TestSetConfigVerifiesNetwork(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{
+		"network":    "shared",
+		"subnetwork": "juju",
+	})
+	err := s.Env.SetConfig(s.Config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "VerifyNetwork")
+	c.Check(s.FakeConn.Calls[0].Network, jc.DeepEquals, google.NetworkSpec{
+		Name:       "shared",
+		Subnetwork: "juju",
+		Region:     "us-east1",
+	})
+}
+
+func (s *environSuite) TestSetConfigNetworkNotFound(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{
+		"network": "shared",
+	})
+	s.FakeConn.Err = errors.NotFoundf(`network "shared" in project "spam"`)
+
+	err := s.Env.SetConfig(s.Config)
+	c.Assert(err, gc.ErrorMatches, `invalid config change: network "shared" in project "spam" not found`)
+}
+
 func (s *environSuite) TestConfig(c *gc.C) {
 	cfg := s.Env.Config()
 
@@ -177,10 +206,12 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 	err := s.Env.Destroy(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	fwname := common.EnvFullName(s.Env.Config().UUID())
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewalls")
+	c.Check(s.FakeConn.Calls[1].Prefix, gc.Equals, "juju-"+s.Env.Config().UUID()[30:]+"-app")
 	s.FakeCommon.CheckCalls(c, []gce.FakeCall{{
 		FuncName: "Destroy",
 		Args: gce.FakeCallArgs{
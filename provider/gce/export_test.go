@@ -16,6 +16,7 @@ var (
 	Provider                 environs.EnvironProvider = providerInstance
 	NewInstance                                       = newInstance
 	CheckInstanceType                                 = checkInstanceType
+	CustomInstanceType                                = customInstanceType
 	GetMetadata                                       = getMetadata
 	GetDisks                                          = getDisks
 	UbuntuImageBasePath                               = ubuntuImageBasePath
@@ -43,6 +44,10 @@ func GlobalFirewallName(env *environ) string {
 	return env.globalFirewallName()
 }
 
+func ApplicationFirewallName(env *environ, applicationName string) string {
+	return env.applicationFirewallName(applicationName)
+}
+
 func ParsePlacement(env *environ, ctx context.ProviderCallContext, placement string) (*instPlacement, error) {
 	return env.parsePlacement(ctx, placement)
 }
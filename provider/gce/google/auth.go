@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
@@ -14,6 +16,7 @@ var (
 	driverScopes = []string{
 		"https://www.googleapis.com/auth/compute",
 		"https://www.googleapis.com/auth/devstorage.full_control",
+		"https://www.googleapis.com/auth/monitoring.read",
 	}
 )
 
@@ -21,6 +24,17 @@ var (
 // the Auth's data and returns it. This includes building the
 // OAuth-wrapping network transport.
 func newConnection(creds *Credentials) (*compute.Service, error) {
+	client, err := newClient(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, err := compute.New(client)
+	return service, errors.Trace(err)
+}
+
+// newClient returns an HTTP client that authenticates its requests
+// to the Google APIs using the Auth's data.
+func newClient(creds *Credentials) (*http.Client, error) {
 	jsonKey := creds.JSONKey
 	if jsonKey == nil {
 		built, err := creds.buildJSONKey()
@@ -33,7 +47,5 @@ func newConnection(creds *Credentials) (*compute.Service, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client := cfg.Client(oauth2.NoContext)
-	service, err := compute.New(client)
-	return service, errors.Trace(err)
+	return cfg.Client(oauth2.NoContext), nil
 }
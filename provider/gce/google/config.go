@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/mail"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -189,6 +190,17 @@ type ConnectionConfig struct {
 	// ProjectID is the project ID to use in all GCE API requests for
 	// the connection.
 	ProjectID string
+
+	// Network identifies the network that firewall rules are created
+	// in. Firewall rules for a shared VPC network are created in the
+	// network's host project. If it is not set, the project's default
+	// network is used.
+	Network NetworkSpec
+
+	// OperationTimeout is the longest time to wait for a GCE
+	// operation, such as adding an instance, to complete. If it
+	// is zero, a default of five minutes is used.
+	OperationTimeout time.Duration
 }
 
 // Validate checks the connection's fields for invalid values.
@@ -205,5 +217,8 @@ func (gc ConnectionConfig) Validate() error {
 	if gc.ProjectID == "" {
 		return NewMissingConfigValue(OSEnvProjectID, "ProjectID")
 	}
+	if gc.OperationTimeout < 0 {
+		return errors.NotValidf("negative OperationTimeout %v", gc.OperationTimeout)
+	}
 	return nil
 }
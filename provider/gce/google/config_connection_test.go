@@ -4,6 +4,9 @@
 package google_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -45,3 +48,15 @@ func (*connConfigSuite) TestValidateMissingProjectID(c *gc.C) {
 	c.Assert(err, gc.FitsTypeOf, &google.InvalidConfigValueError{})
 	c.Check(err.(*google.InvalidConfigValueError).Key, gc.Equals, "GCE_PROJECT_ID")
 }
+
+func (*connConfigSuite) TestValidateNegativeOperationTimeout(c *gc.C) {
+	cfg := google.ConnectionConfig{
+		Region:           "spam",
+		ProjectID:        "eggs",
+		OperationTimeout: -time.Minute,
+	}
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "negative OperationTimeout -1m0s not valid")
+}
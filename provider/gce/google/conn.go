@@ -4,8 +4,13 @@
 package google
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
+
+	"github.com/juju/juju/environs/context"
 )
 
 // rawConnectionWrapper facilitates mocking out the GCE API during tests.
@@ -36,11 +41,27 @@ type rawConnectionWrapper interface {
 	// the instance is removed (or the request fails).
 	RemoveInstance(projectID, id, zone string) error
 
+	// StartInstance sends a request to the GCE API to start the stopped
+	// instance with the provided ID (in the specified zone). The call
+	// blocks until the instance is started (or the request fails).
+	StartInstance(projectID, zone, id string) error
+
 	// SetMetadata sends a request to the GCE API to update one
 	// instance's metadata. The call blocks until the request is
 	// completed or fails.
 	SetMetadata(projectID, zone, instanceID string, metadata *compute.Metadata) error
 
+	// SetTags sends a request to the GCE API to replace one
+	// instance's network tags. The call blocks until the request
+	// is completed or fails.
+	SetTags(projectID, zone, instanceID string, tags *compute.Tags) error
+
+	// SetInstanceLabels sends a request to the GCE API to replace
+	// one instance's labels, provided the instance's label
+	// fingerprint matches the one supplied. The call blocks until
+	// the request is completed or fails.
+	SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error
+
 	// GetFirewalls sends an API request to GCE for the information about
 	// the firewalls with the namePrefix and returns them.
 	// If no firewalls are not found, errors.NotFound is returned.
@@ -84,6 +105,32 @@ type rawConnectionWrapper interface {
 	// label fingerprint matches the one supplied.
 	SetDiskLabels(project, zone, id, labelFingerprint string, labels map[string]string) error
 
+	// ResizeDisk grows the disk identified by id to the given size.
+	ResizeDisk(project, zone, id string, sizeGB int64) error
+
+	// CreateRegionDisk will create a gce regional Persistent Block
+	// device that matches the specified in spec.
+	CreateRegionDisk(project, region string, spec *compute.Disk) error
+
+	// ListRegionDisks returns a list of the regional disks available
+	// for a given project and region.
+	ListRegionDisks(project, region string) ([]*compute.Disk, error)
+
+	// RemoveRegionDisk will delete the regional disk identified by id.
+	RemoveRegionDisk(project, region, id string) error
+
+	// GetRegionDisk will return the regional disk correspondent to
+	// the passed id.
+	GetRegionDisk(project, region, id string) (*compute.Disk, error)
+
+	// SetRegionDiskLabels sets the labels on a regional disk, ensuring
+	// that the disk's label fingerprint matches the one supplied.
+	SetRegionDiskLabels(project, region, id, labelFingerprint string, labels map[string]string) error
+
+	// ResizeRegionDisk grows the regional disk identified by id to
+	// the given size.
+	ResizeRegionDisk(project, region, id string, sizeGB int64) error
+
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
@@ -104,6 +151,12 @@ type rawConnectionWrapper interface {
 
 	// ListNetworks returns a list of Networks available in the given project.
 	ListNetworks(projectID string) ([]*compute.Network, error)
+
+	// LatestTimeSeriesValue sends a request to the Cloud Monitoring
+	// API for the time series matching the filter over the given
+	// interval, and returns the most recent value. If there are no
+	// values in the interval then false is returned.
+	LatestTimeSeriesValue(projectID, filter string, start, end time.Time) (float64, bool, error)
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -120,6 +173,7 @@ type Connection struct {
 	raw       rawConnectionWrapper
 	region    string
 	projectID string
+	network   NetworkSpec
 }
 
 // Connect authenticates using the provided credentials and opens a
@@ -132,11 +186,20 @@ func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	monitoring, err := newMonitoringClient(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw: &rawConn{
+			Service:          raw,
+			monitoring:       monitoring,
+			operationTimeout: connCfg.OperationTimeout,
+		},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
+		network:   connCfg.Network,
 	}
 	return conn, nil
 }
@@ -145,6 +208,23 @@ var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
 	return newConnection(creds)
 }
 
+var newMonitoringClient = func(creds *Credentials) (*http.Client, error) {
+	return newClient(creds)
+}
+
+// WithContext returns a copy of the connection which stops waiting
+// for GCE operations to complete when the given context is dying, so
+// that callers with a deadline are not held up by hung operations.
+func (gc *Connection) WithContext(ctx context.ProviderCallContext) *Connection {
+	conn := *gc
+	if raw, ok := gc.raw.(*rawConn); ok {
+		rawCopy := *raw
+		rawCopy.dying = ctx.Dying()
+		conn.raw = &rawCopy
+	}
+	return &conn
+}
+
 // TODO(ericsnow) Verify in each method that Connection.raw is set?
 
 // VerifyCredentials ensures that the authentication credentials used
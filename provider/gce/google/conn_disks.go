@@ -31,6 +31,30 @@ func (gce *Connection) createDisk(zone string, disk *compute.Disk) error {
 	return gce.raw.CreateDisk(gce.projectID, zone, disk)
 }
 
+// CreateRegionDisks implements storage section of gceConnection.
+func (gce *Connection) CreateRegionDisks(region string, disks []DiskSpec) ([]*Disk, error) {
+	results := make([]*Disk, len(disks))
+	for i, disk := range disks {
+		if len(disk.ReplicaZones) != 2 {
+			return []*Disk{}, errors.Errorf(
+				"cannot create regional disk %q: expected 2 replica zones, got %d",
+				disk.Name, len(disk.ReplicaZones),
+			)
+		}
+		d, err := disk.newDetached()
+		if err != nil {
+			return []*Disk{}, errors.Annotate(err, "cannot create disk spec")
+		}
+		if err := gce.raw.CreateRegionDisk(gce.projectID, region, d); err != nil {
+			return []*Disk{}, errors.Annotatef(err, "cannot create regional disk %q", disk.Name)
+		}
+		results[i] = NewDisk(d)
+		results[i].Region = region
+		results[i].ReplicaZones = disk.ReplicaZones
+	}
+	return results, nil
+}
+
 // Disks implements storage section of gceConnection.
 func (gce *Connection) Disks() ([]*Disk, error) {
 	computeDisks, err := gce.raw.ListDisks(gce.projectID)
@@ -44,6 +68,19 @@ func (gce *Connection) Disks() ([]*Disk, error) {
 	return disks, nil
 }
 
+// RegionDisks implements storage section of gceConnection.
+func (gce *Connection) RegionDisks(region string) ([]*Disk, error) {
+	computeDisks, err := gce.raw.ListRegionDisks(gce.projectID, region)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot list regional disks in region %q", region)
+	}
+	disks := make([]*Disk, len(computeDisks))
+	for i, disk := range computeDisks {
+		disks[i] = NewDisk(disk)
+	}
+	return disks, nil
+}
+
 // RemoveDisk implements storage section of gceConnection.
 // TODO(perrito666) handle non existing disk, perhaps catch 404.
 func (gce *Connection) RemoveDisk(zone, name string) error {
@@ -59,12 +96,44 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// RemoveRegionDisk implements storage section of gceConnection.
+func (gce *Connection) RemoveRegionDisk(region, name string) error {
+	return gce.raw.RemoveRegionDisk(gce.projectID, region, name)
+}
+
+// RegionDisk implements storage section of gceConnection.
+func (gce *Connection) RegionDisk(region, name string) (*Disk, error) {
+	d, err := gce.raw.GetRegionDisk(gce.projectID, region, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get disk %q in region %q", name, region)
+	}
+	return NewDisk(d), nil
+}
+
+// SetRegionDiskLabels implements storage section of gceConnection.
+func (gce *Connection) SetRegionDiskLabels(region, name, labelFingerprint string, labels map[string]string) error {
+	err := gce.raw.SetRegionDiskLabels(gce.projectID, region, name, labelFingerprint, labels)
+	return errors.Annotatef(err, "cannot update labels for disk %q in region %q", name, region)
+}
+
 // SetDiskLabels implements storage section of gceConnection.
 func (gce *Connection) SetDiskLabels(zone, name, labelFingerprint string, labels map[string]string) error {
 	err := gce.raw.SetDiskLabels(gce.projectID, zone, name, labelFingerprint, labels)
 	return errors.Annotatef(err, "cannot update labels for disk %q in zone %q", name, zone)
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB uint64) error {
+	err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGB))
+	return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+}
+
+// ResizeRegionDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeRegionDisk(region, name string, sizeGB uint64) error {
+	err := gce.raw.ResizeRegionDisk(gce.projectID, region, name, int64(sizeGB))
+	return errors.Annotatef(err, "cannot resize disk %q in region %q", name, region)
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
@@ -79,14 +148,28 @@ func (gce *Connection) AttachDisk(zone, volumeName, instanceId string, mode Disk
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot obtain disk %q to attach it", volumeName)
 	}
+	return gce.attachDisk(zone, deviceName(zone, disk.Id), volumeName, disk.SelfLink, instanceId, mode)
+}
+
+// AttachRegionDisk implements storage section of gceConnection.
+// The instance must be in <zone>, one of the disk's replica zones.
+func (gce *Connection) AttachRegionDisk(zone, region, volumeName, instanceId string, mode DiskMode) (*AttachedDisk, error) {
+	disk, err := gce.raw.GetRegionDisk(gce.projectID, region, volumeName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot obtain disk %q to attach it", volumeName)
+	}
+	return gce.attachDisk(zone, deviceName(region, disk.Id), volumeName, disk.SelfLink, instanceId, mode)
+}
+
+func (gce *Connection) attachDisk(zone, device, volumeName, source, instanceId string, mode DiskMode) (*AttachedDisk, error) {
 	attachedDisk := &compute.AttachedDisk{
 		// Specifies a unique device name of your choice that
 		// is reflected into the /dev/disk/by-id/google-*
-		DeviceName: deviceName(zone, disk.Id),
-		Source:     disk.SelfLink,
+		DeviceName: device,
+		Source:     source,
 		Mode:       string(mode),
 	}
-	err = gce.raw.AttachDisk(gce.projectID, zone, instanceId, attachedDisk)
+	err := gce.raw.AttachDisk(gce.projectID, zone, instanceId, attachedDisk)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot attach disk")
 	}
@@ -112,6 +195,21 @@ func (gce *Connection) DetachDisk(zone, instanceId, volumeName string) error {
 	return nil
 }
 
+// DetachRegionDisk implements storage section of gceConnection.
+// The instance must be in <zone>, one of the disk's replica zones.
+func (gce *Connection) DetachRegionDisk(zone, region, instanceId, volumeName string) error {
+	disk, err := gce.raw.GetRegionDisk(gce.projectID, region, volumeName)
+	if err != nil {
+		return errors.Annotatef(err, "cannot obtain disk %q to detach it", volumeName)
+	}
+	dn := deviceName(region, disk.Id)
+	err = gce.raw.DetachDisk(gce.projectID, zone, instanceId, dn)
+	if err != nil {
+		return errors.Annotatef(err, "cannot detach %q from %q", dn, instanceId)
+	}
+	return nil
+}
+
 // sourceToVolumeName will return the disk Name part of a
 // source URL for a compute disk, compute is a bit inconsistent
 // on its handling of disk resources, when used in requests it will
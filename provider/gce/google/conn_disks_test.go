@@ -94,6 +94,18 @@ func (s *connSuite) TestConnectionSetDiskLabels(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Labels, jc.DeepEquals, labels)
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGB, gc.Equals, int64(20))
+}
+
 func (s *connSuite) TestConnectionAttachDisk(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
@@ -134,6 +146,93 @@ func (s *connSuite) TestConnectionDetachDisk(c *gc.C) {
 	c.Check(s.FakeConn.Calls[1].DeviceName, gc.Equals, "home-zone-0")
 }
 
+func (s *connSuite) TestConnectionCreateRegionDisks(c *gc.C) {
+	spec, _, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	spec.Name = "home-region1--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	spec.ReplicaZones = []string{"home-region1-a", "home-region1-b"}
+
+	disks, err := s.Conn.CreateRegionDisks("home-region1", []google.DiskSpec{spec})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(disks, gc.HasLen, 1)
+	c.Assert(disks[0].Name, gc.Equals, spec.Name)
+	c.Assert(disks[0].Region, gc.Equals, "home-region1")
+	c.Assert(disks[0].ReplicaZones, jc.DeepEquals, []string{"home-region1-a", "home-region1-b"})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateRegionDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "home-region1")
+	c.Check(s.FakeConn.Calls[0].ComputeDisk.ReplicaZones, jc.DeepEquals, []string{"home-region1-a", "home-region1-b"})
+}
+
+func (s *connSuite) TestConnectionCreateRegionDisksRequiresTwoZones(c *gc.C) {
+	spec, _, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	spec.ReplicaZones = []string{"home-region1-a"}
+
+	_, err = s.Conn.CreateRegionDisks("home-region1", []google.DiskSpec{spec})
+	c.Assert(err, gc.ErrorMatches, `cannot create regional disk ".*": expected 2 replica zones, got 1`)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 0)
+}
+
+func (s *connSuite) TestConnectionRegionDisk(c *gc.C) {
+	_, fakeDisk, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	s.FakeConn.Disk = fakeDisk
+	s.FakeConn.Disk.Region = "https://www.googleapis.com/compute/v1/projects/my-project/regions/home-region1"
+	s.FakeConn.Disk.ReplicaZones = []string{
+		"https://www.googleapis.com/compute/v1/projects/my-project/zones/home-region1-a",
+		"https://www.googleapis.com/compute/v1/projects/my-project/zones/home-region1-b",
+	}
+
+	disk, err := s.Conn.RegionDisk("home-region1", fakeVolName)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(disk.Zone, gc.Equals, "")
+	c.Assert(disk.Region, gc.Equals, "home-region1")
+	c.Assert(disk.ReplicaZones, jc.DeepEquals, []string{"home-region1-a", "home-region1-b"})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetRegionDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "home-region1")
+}
+
+func (s *connSuite) TestConnectionAttachRegionDisk(c *gc.C) {
+	_, fakeDisk, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	s.FakeConn.Disk = fakeDisk
+	att, err := s.Conn.AttachRegionDisk("home-region1-a", "home-region1", fakeVolName, "a-fake-instance", google.ModeRW)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(att.DeviceName, gc.Equals, "home-region1-0")
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetRegionDisk")
+	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "home-region1")
+
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AttachDisk")
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-region1-a")
+	c.Check(s.FakeConn.Calls[1].InstanceId, gc.Equals, "a-fake-instance")
+	c.Check(s.FakeConn.Calls[1].AttachedDisk.DeviceName, gc.Equals, "home-region1-0")
+}
+
+func (s *connSuite) TestConnectionDetachRegionDisk(c *gc.C) {
+	_, fakeDisk, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	s.FakeConn.Disk = fakeDisk
+	err = s.Conn.DetachRegionDisk("home-region1-a", "home-region1", "a-fake-instance", fakeVolName)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetRegionDisk")
+	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "home-region1")
+
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "DetachDisk")
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-region1-a")
+	c.Check(s.FakeConn.Calls[1].InstanceId, gc.Equals, "a-fake-instance")
+	c.Check(s.FakeConn.Calls[1].DeviceName, gc.Equals, "home-region1-0")
+}
+
 func (s *connSuite) TestConnectionRemoveDisks(c *gc.C) {
 	err := s.Conn.RemoveDisk("home-zone", fakeVolName)
 	c.Check(err, jc.ErrorIsNil)
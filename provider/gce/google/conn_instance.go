@@ -20,6 +20,12 @@ func (gce *Connection) addInstance(requestedInst *compute.Instance, machineType
 	var waitErr error
 	inst := *requestedInst
 	inst.MachineType = formatMachineType(zone, machineType)
+	inst.GuestAccelerators = nil
+	for _, config := range requestedInst.GuestAccelerators {
+		config := *config
+		config.AcceleratorType = formatAcceleratorType(zone, config.AcceleratorType)
+		inst.GuestAccelerators = append(inst.GuestAccelerators, &config)
+	}
 	err := gce.raw.AddInstance(gce.projectID, zone, &inst)
 	if isWaitError(err) {
 		waitErr = err
@@ -84,6 +90,15 @@ func (gce *Connection) Instances(prefix string, statuses ...string) ([]Instance,
 	return insts, nil
 }
 
+// RestartInstance sends a request to the GCE API to start the stopped
+// instance with the provided ID (in the specified zone), such as a
+// preemptible instance that GCE has stopped. The call blocks until
+// the instance is running again (or the request fails).
+func (gce *Connection) RestartInstance(id, zone string) error {
+	err := gce.raw.StartInstance(gce.projectID, zone, id)
+	return errors.Annotatef(err, "starting instance %q", id)
+}
+
 // removeInstance sends a request to the GCE API to remove the instance
 // with the provided ID (in the specified zone). The call blocks until
 // the instance is removed (or the request fails).
@@ -191,6 +206,105 @@ func (gce *Connection) updateInstanceMetadata(instance *compute.Instance, key, v
 	return errors.Trace(gce.raw.SetMetadata(gce.projectID, zoneName, instance.Name, metadata))
 }
 
+// AddInstanceTags adds the network tag to all of the instance ids
+// given that do not already have it. The call blocks until all of
+// the instances are updated or the request fails.
+func (gce *Connection) AddInstanceTags(tag string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	instances, err := gce.raw.ListInstances(gce.projectID, "")
+	if err != nil {
+		return errors.Annotatef(err, "adding tag %q to instances %v", tag, ids)
+	}
+	var failed []string
+	for _, instID := range ids {
+		for _, inst := range instances {
+			if inst.Name == instID {
+				if err := gce.addInstanceTag(inst, tag); err != nil {
+					failed = append(failed, instID)
+					logger.Errorf("while adding tag %q to instance %q: %v", tag, instID, err)
+				}
+				break
+			}
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("some tag updates failed: %v", failed)
+	}
+	return nil
+}
+
+func (gce *Connection) addInstanceTag(instance *compute.Instance, tag string) error {
+	tags := instance.Tags
+	if tags == nil {
+		tags = &compute.Tags{}
+	}
+	for _, item := range tags.Items {
+		if item == tag {
+			// The instance already has the tag.
+			return nil
+		}
+	}
+	tags.Items = append(tags.Items, tag)
+	// The GCE API won't accept a full URL for the zone (lp:1667172).
+	zoneName := path.Base(instance.Zone)
+	return errors.Trace(gce.raw.SetTags(gce.projectID, zoneName, instance.Name, tags))
+}
+
+// UpdateLabels sets the given labels on all of the instance ids
+// given, leaving any other labels in place. The call blocks until
+// all of the instances are updated or the request fails.
+func (gce *Connection) UpdateLabels(labels map[string]string, ids ...string) error {
+	if len(ids) == 0 || len(labels) == 0 {
+		return nil
+	}
+
+	instances, err := gce.raw.ListInstances(gce.projectID, "")
+	if err != nil {
+		return errors.Annotatef(err, "updating labels for instances %v", ids)
+	}
+	var failed []string
+	for _, instID := range ids {
+		for _, inst := range instances {
+			if inst.Name == instID {
+				if err := gce.updateInstanceLabels(inst, labels); err != nil {
+					failed = append(failed, instID)
+					logger.Errorf("while updating labels for instance %q: %v", instID, err)
+				}
+				break
+			}
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("some label updates failed: %v", failed)
+	}
+	return nil
+}
+
+func (gce *Connection) updateInstanceLabels(instance *compute.Instance, labels map[string]string) error {
+	merged := make(map[string]string)
+	for k, v := range instance.Labels {
+		merged[k] = v
+	}
+	changed := false
+	for k, v := range labels {
+		if existing, ok := merged[k]; ok && existing == v {
+			continue
+		}
+		merged[k] = v
+		changed = true
+	}
+	if !changed {
+		// The labels are already right.
+		return nil
+	}
+	// The GCE API won't accept a full URL for the zone (lp:1667172).
+	zoneName := path.Base(instance.Zone)
+	return errors.Trace(gce.raw.SetInstanceLabels(gce.projectID, zoneName, instance.Name, instance.LabelFingerprint, merged))
+}
+
 func findMetadataItem(items []*compute.MetadataItems, key string) *compute.MetadataItems {
 	for _, item := range items {
 		if item == nil {
@@ -104,6 +104,138 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstanceAccelerators(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Accelerators = []google.AcceleratorSpec{{
+		Type:  "nvidia-tesla-k80",
+		Count: 2,
+	}}
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	inst := s.FakeConn.Calls[0].InstValue
+	c.Check(inst.GuestAccelerators, jc.DeepEquals, []*compute.AcceleratorConfig{{
+		AcceleratorType:  "zones/a-zone/acceleratorTypes/nvidia-tesla-k80",
+		AcceleratorCount: 2,
+	}})
+	c.Check(inst.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
+func (s *instanceSuite) TestConnectionAddInstancePreemptible(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Preemptible = true
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	automaticRestart := false
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
+func (s *instanceSuite) TestConnectionAddInstanceSpot(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Spot = true
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	automaticRestart := false
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		ProvisioningModel:         "SPOT",
+		InstanceTerminationAction: "STOP",
+		AutomaticRestart:          &automaticRestart,
+		OnHostMaintenance:         "TERMINATE",
+	})
+}
+
+func (s *instanceSuite) TestConnectionAddInstanceShieldedVM(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.ShieldedVM = google.ShieldedVMSpec{
+		SecureBoot: true,
+		VTPM:       true,
+	}
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	inst := s.FakeConn.Calls[0].InstValue
+	c.Check(inst.ShieldedInstanceConfig, jc.DeepEquals, &compute.ShieldedInstanceConfig{
+		EnableSecureBoot: true,
+		EnableVtpm:       true,
+	})
+	c.Check(inst.Scheduling, gc.IsNil)
+}
+
+func (s *instanceSuite) TestConnectionAddInstanceConfidentialVM(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.ConfidentialVM = true
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	inst := s.FakeConn.Calls[0].InstValue
+	c.Check(inst.ConfidentialInstanceConfig, jc.DeepEquals, &compute.ConfidentialInstanceConfig{
+		EnableConfidentialCompute: true,
+	})
+	c.Check(inst.ShieldedInstanceConfig, gc.IsNil)
+	c.Check(inst.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
+func (s *instanceSuite) TestConnectionAddInstanceConfidentialVMPreemptible(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.ConfidentialVM = true
+	s.InstanceSpec.Preemptible = true
+	s.InstanceSpec.Accelerators = []google.AcceleratorSpec{{
+		Type:  "nvidia-tesla-k80",
+		Count: 1,
+	}}
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	inst := s.FakeConn.Calls[0].InstValue
+	c.Check(inst.ConfidentialInstanceConfig, jc.DeepEquals, &compute.ConfidentialInstanceConfig{
+		EnableConfidentialCompute: true,
+	})
+	automaticRestart := false
+	c.Check(inst.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
+func (s *instanceSuite) TestConnectionAddInstanceLabels(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	labels := map[string]string{"juju-model-uuid": "some-uuid"}
+	s.InstanceSpec.Labels = labels
+	s.InstanceSpec.Disks[0].Labels = labels
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	inst := s.FakeConn.Calls[0].InstValue
+	c.Check(inst.Labels, jc.DeepEquals, labels)
+	c.Assert(inst.Disks, gc.HasLen, 1)
+	c.Check(inst.Disks[0].InitializeParams.Labels, jc.DeepEquals, labels)
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
@@ -195,6 +327,27 @@ func (s *connSuite) TestConnectionInstancesFailure(c *gc.C) {
 	c.Check(errors.Cause(err), gc.Equals, failure)
 }
 
+func (s *connSuite) TestConnectionRestartInstanceAPI(c *gc.C) {
+	err := s.Conn.RestartInstance("spam", "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "StartInstance")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionRestartInstanceFailed(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+
+	err := s.Conn.RestartInstance("spam", "a-zone")
+
+	c.Check(err, gc.ErrorMatches, `starting instance "spam": <unknown>`)
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
+
 func (s *connSuite) TestConnectionRemoveInstance(c *gc.C) {
 	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
 
@@ -321,6 +474,35 @@ func (s *connSuite) TestConnectionRemoveInstancesRemoveFailed(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, ".*some instance removals failed: .*")
 }
 
+func (s *connSuite) TestAddInstanceTags(c *gc.C) {
+	// Ensure we extract the name from the URL we get on the raw instance.
+	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.AddInstanceTags("eggs", s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+
+	call := s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "SetTags")
+	c.Check(call.ProjectID, gc.Equals, "spam")
+	c.Check(call.ZoneName, gc.Equals, "a-zone")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+	c.Check(call.Tags.Items, jc.DeepEquals, []string{"spam", "eggs"})
+}
+
+func (s *connSuite) TestAddInstanceTagsExisting(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.AddInstanceTags("spam", s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+}
+
 func (s *connSuite) TestUpdateMetadataNewAttribute(c *gc.C) {
 	// Ensure we extract the name from the URL we get on the raw instance.
 	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
@@ -464,6 +646,52 @@ func (s *connSuite) TestUpdateMetadataChecksCurrentValue(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 }
 
+func (s *connSuite) TestUpdateLabels(c *gc.C) {
+	// Ensure we extract the name from the URL we get on the raw instance.
+	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
+	s.RawInstanceFull.LabelFingerprint = "heymumwatchthis"
+	s.RawInstanceFull.Labels = map[string]string{"eggs": "steak"}
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateLabels(map[string]string{"business": "time"}, s.RawInstanceFull.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+
+	call := s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "SetInstanceLabels")
+	c.Check(call.ProjectID, gc.Equals, "spam")
+	c.Check(call.ZoneName, gc.Equals, "a-zone")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+	c.Check(call.LabelFingerprint, gc.Equals, "heymumwatchthis")
+	c.Check(call.Labels, jc.DeepEquals, map[string]string{
+		"eggs":     "steak",
+		"business": "time",
+	})
+}
+
+func (s *connSuite) TestUpdateLabelsChecksCurrentValue(c *gc.C) {
+	s.RawInstanceFull.Labels = map[string]string{"eggs": "steak"}
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	err := s.Conn.UpdateLabels(map[string]string{"eggs": "steak"}, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Since the instance already has the right labels we don't
+	// issue the update.
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+}
+
+func (s *connSuite) TestUpdateLabelsError(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	s.FakeConn.Err = errors.New("kablooey")
+	s.FakeConn.FailOnCall = 1
+
+	err := s.Conn.UpdateLabels(map[string]string{"rick": "morty"}, "spam")
+	c.Assert(err, gc.ErrorMatches, `some label updates failed: \[spam\]`)
+}
+
 func makeMetadataItems(key, value string) *compute.MetadataItems {
 	return &compute.MetadataItems{Key: key, Value: google.StringPtr(value)}
 }
@@ -20,7 +20,7 @@ import (
 // no rules match the name the RuleSet will be empty and no error is
 // returned.
 func (gce Connection) firewallRules(fwname string) (ruleSet, error) {
-	firewalls, err := gce.raw.GetFirewalls(gce.projectID, fwname)
+	firewalls, err := gce.raw.GetFirewalls(gce.firewallProject(), fwname)
 	if IsNotFound(err) {
 		return make(ruleSet), nil
 	}
@@ -101,8 +101,8 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 				return errors.Trace(err)
 			}
 			allNames.Add(name)
-			spec := firewallSpec(name, target, inputFirewall.SourceCIDRs, inputFirewall.AllowedPorts)
-			if err := gce.raw.AddFirewall(gce.projectID, spec); err != nil {
+			spec := gce.firewallSpec(name, target, inputFirewall.SourceCIDRs, inputFirewall.AllowedPorts)
+			if err := gce.raw.AddFirewall(gce.firewallProject(), spec); err != nil {
 				return errors.Annotatef(err, "opening port(s) %+v", rules)
 			}
 			continue
@@ -120,14 +120,29 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 		combinedCIDRs := cidrs.Union(set.NewStrings(inputFirewall.SourceCIDRs...)).SortedValues()
 
 		// Copy new firewall details into required firewall spec.
-		spec := firewallSpec(existingFirewall.Name, target, combinedCIDRs, allowedPorts)
-		if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
+		spec := gce.firewallSpec(existingFirewall.Name, target, combinedCIDRs, allowedPorts)
+		if err := gce.raw.UpdateFirewall(gce.firewallProject(), existingFirewall.Name, spec); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", rules)
 		}
 	}
 	return nil
 }
 
+// firewallProject returns the ID of the project that firewall
+// rules are created in, which is the host project of a shared VPC
+// network.
+func (gce Connection) firewallProject() string {
+	return gce.network.project(gce.projectID)
+}
+
+// firewallSpec returns a compute.Firewall for the provided name,
+// in the connection's network.
+func (gce Connection) firewallSpec(name, target string, sourceCIDRs []string, ports protocolPorts) *compute.Firewall {
+	spec := firewallSpec(name, target, sourceCIDRs, ports)
+	spec.Network = gce.network.firewallNetwork()
+	return spec
+}
+
 // RandomSuffixNamer tries to find a unique name for the firewall by
 // appending a random suffix.
 func RandomSuffixNamer(fw *firewall, prefix string, existingNames set.Strings) (string, error) {
@@ -179,15 +194,15 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 			if len(remainingCidrs) == 0 {
 				// Delete a firewall.
 				// TODO(ericsnow) Handle case where firewall does not exist.
-				if err := gce.raw.RemoveFirewall(gce.projectID, existingFirewall.Name); err != nil {
+				if err := gce.raw.RemoveFirewall(gce.firewallProject(), existingFirewall.Name); err != nil {
 					return errors.Annotatef(err, "closing port(s) %+v", rules)
 				}
 				continue
 			}
 
 			// Update the existing firewall with the remaining CIDRs.
-			spec := firewallSpec(existingFirewall.Name, target, remainingCidrs, existingFirewall.AllowedPorts)
-			if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
+			spec := gce.firewallSpec(existingFirewall.Name, target, remainingCidrs, existingFirewall.AllowedPorts)
+			if err := gce.raw.UpdateFirewall(gce.firewallProject(), existingFirewall.Name, spec); err != nil {
 				return errors.Annotatef(err, "closing port(s) %+v", rules)
 			}
 			continue
@@ -208,28 +223,89 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 		remainingPorts := existingFirewall.AllowedPorts.remove(inputFirewall.AllowedPorts)
 
 		// Copy new firewall details into required firewall spec.
-		spec := firewallSpec(existingFirewall.Name, target, existingFirewall.SourceCIDRs, remainingPorts)
-		if err := gce.raw.UpdateFirewall(gce.projectID, existingFirewall.Name, spec); err != nil {
+		spec := gce.firewallSpec(existingFirewall.Name, target, existingFirewall.SourceCIDRs, remainingPorts)
+		if err := gce.raw.UpdateFirewall(gce.firewallProject(), existingFirewall.Name, spec); err != nil {
 			return errors.Annotatef(err, "closing port(s) %+v", rules)
 		}
 	}
 	return nil
 }
 
-// Subnetworks returns the subnets available in this region.
+// RemoveFirewalls removes all of the firewall rules whose names
+// start with the given prefix. It is not an error if there are none.
+func (gce Connection) RemoveFirewalls(prefix string) error {
+	firewalls, err := gce.raw.GetFirewalls(gce.firewallProject(), prefix)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Annotate(err, "while getting firewall rules from GCE")
+	}
+	for _, firewall := range firewalls {
+		if err := gce.raw.RemoveFirewall(gce.firewallProject(), firewall.Name); err != nil {
+			return errors.Annotatef(err, "removing firewall %q", firewall.Name)
+		}
+	}
+	return nil
+}
+
+// Subnetworks returns the subnets available in this region, in the
+// project that owns the connection's network.
 func (gce Connection) Subnetworks(region string) ([]*compute.Subnetwork, error) {
-	results, err := gce.raw.ListSubnetworks(gce.projectID, region)
+	results, err := gce.raw.ListSubnetworks(gce.network.project(gce.projectID), region)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return results, nil
 }
 
-// Networks returns the networks available.
+// Networks returns the networks available in the project that owns
+// the connection's network.
 func (gce Connection) Networks() ([]*compute.Network, error) {
-	results, err := gce.raw.ListNetworks(gce.projectID)
+	results, err := gce.raw.ListNetworks(gce.network.project(gce.projectID))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return results, nil
 }
+
+// VerifyNetwork returns an error if the network described by the
+// spec does not exist, or if its subnetwork does not exist in the
+// spec's region or belongs to another network.
+func (gce Connection) VerifyNetwork(spec NetworkSpec) error {
+	project := spec.project(gce.projectID)
+	networks, err := gce.raw.ListNetworks(project)
+	if err != nil {
+		return errors.Annotatef(err, "listing networks in project %q", project)
+	}
+	var found *compute.Network
+	for _, network := range networks {
+		if network.Name == spec.Name {
+			found = network
+			break
+		}
+	}
+	if found == nil {
+		return errors.NotFoundf("network %q in project %q", spec.Name, project)
+	}
+	if spec.Subnetwork == "" {
+		if !found.AutoCreateSubnetworks && found.IPv4Range == "" {
+			return errors.NotValidf("custom mode network %q without a subnetwork", spec.Name)
+		}
+		return nil
+	}
+	subnetworks, err := gce.raw.ListSubnetworks(project, spec.Region)
+	if err != nil {
+		return errors.Annotatef(err, "listing subnetworks in project %q", project)
+	}
+	for _, subnetwork := range subnetworks {
+		if subnetwork.Name != spec.Subnetwork {
+			continue
+		}
+		if subnetwork.Network != found.SelfLink {
+			return errors.Errorf("subnetwork %q does not belong to network %q", spec.Subnetwork, spec.Name)
+		}
+		return nil
+	}
+	return errors.NotFoundf("subnetwork %q in region %q of project %q", spec.Subnetwork, spec.Region, project)
+}
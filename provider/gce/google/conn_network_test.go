@@ -459,6 +459,113 @@ func (s *connSuite) TestSubnetworks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "us-central1")
 }
 
+func (s *connSuite) TestConnectionOpenPortsSharedVPC(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+	google.SetConnNetwork(s.Conn, google.NetworkSpec{Name: "shared", Project: "host"})
+
+	rule := network.MustNewIngressRule("tcp", 80, 81)
+	err := s.Conn.OpenPortsWithNamer("spam", google.HashSuffixNamer, rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "host")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].ProjectID, gc.Equals, "host")
+	c.Check(s.FakeConn.Calls[1].Firewall.Network, gc.Equals, "projects/host/global/networks/shared")
+}
+
+func (s *connSuite) TestConnectionOpenPortsDefaultNetwork(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	rule := network.MustNewIngressRule("tcp", 80, 81)
+	err := s.Conn.OpenPortsWithNamer("spam", google.HashSuffixNamer, rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall.Network, gc.Equals, "")
+}
+
+func (s *connSuite) TestRemoveFirewalls(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:       "spam-app",
+		TargetTags: []string{"spam-app"},
+	}, {
+		Name:       "spam-app-d01a21e",
+		TargetTags: []string{"spam-app"},
+	}}
+
+	err := s.Conn.RemoveFirewalls("spam-app")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam-app")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam-app")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam-app-d01a21e")
+}
+
+func (s *connSuite) TestRemoveFirewallsNotFound(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam-app")
+
+	err := s.Conn.RemoveFirewalls("spam-app")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+}
+
+func (s *connSuite) TestVerifyNetwork(c *gc.C) {
+	s.FakeConn.Networks = []*compute.Network{{
+		Name:     "shared",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/host/global/networks/shared",
+	}, {
+		Name:     "other",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/host/global/networks/other",
+	}}
+	s.FakeConn.Subnetworks = []*compute.Subnetwork{{
+		Name:    "ham",
+		Network: "https://www.googleapis.com/compute/v1/projects/host/global/networks/shared",
+	}, {
+		Name:    "eggs",
+		Network: "https://www.googleapis.com/compute/v1/projects/host/global/networks/other",
+	}}
+
+	for i, test := range []struct {
+		spec google.NetworkSpec
+		err  string
+	}{{
+		spec: google.NetworkSpec{Name: "shared", Subnetwork: "ham", Region: "us-east1", Project: "host"},
+	}, {
+		spec: google.NetworkSpec{Name: "missing", Project: "host"},
+		err:  `network "missing" in project "host" not found`,
+	}, {
+		spec: google.NetworkSpec{Name: "shared", Project: "host"},
+		err:  `custom mode network "shared" without a subnetwork not valid`,
+	}, {
+		spec: google.NetworkSpec{Name: "shared", Subnetwork: "missing", Region: "us-east1", Project: "host"},
+		err:  `subnetwork "missing" in region "us-east1" of project "host" not found`,
+	}, {
+		spec: google.NetworkSpec{Name: "shared", Subnetwork: "eggs", Region: "us-east1", Project: "host"},
+		err:  `subnetwork "eggs" does not belong to network "shared"`,
+	}} {
+		c.Logf("test %d: %+v", i, test.spec)
+		s.FakeConn.Calls = nil
+		err := s.Conn.VerifyNetwork(test.spec)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+		c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListNetworks")
+		c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "host")
+		c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListSubnetworks")
+		c.Check(s.FakeConn.Calls[1].Region, gc.Equals, "us-east1")
+	}
+}
+
 func (s *connSuite) TestRandomSuffixNamer(c *gc.C) {
 	ruleset := google.NewRuleSetFromRules(
 		network.MustNewIngressRule("tcp", 80, 80),
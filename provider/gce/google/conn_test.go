@@ -4,11 +4,14 @@
 package google_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/provider/gce/google"
 )
 
@@ -33,6 +36,37 @@ func (s *connSuite) TestConnect(c *gc.C) {
 	c.Check(google.ExposeRawService(conn), gc.Equals, service)
 }
 
+func (s *connSuite) TestConnectOperationTimeout(c *gc.C) {
+	s.PatchValue(google.NewRawConnection, func(auth *google.Credentials) (*compute.Service, error) {
+		return &compute.Service{}, nil
+	})
+	s.ConnCfg.OperationTimeout = 10 * time.Minute
+
+	conn, err := google.Connect(s.ConnCfg, s.Credentials)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(google.ExposeRawOperationTimeout(conn), gc.Equals, 10*time.Minute)
+}
+
+func (s *connSuite) TestConnectionWithContext(c *gc.C) {
+	s.PatchValue(google.NewRawConnection, func(auth *google.Credentials) (*compute.Service, error) {
+		return &compute.Service{}, nil
+	})
+	conn, err := google.Connect(s.ConnCfg, s.Credentials)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dying := make(chan struct{})
+	ctx := &context.CloudCallContext{
+		DyingFunc: func() <-chan struct{} { return dying },
+	}
+	ctxConn := conn.WithContext(ctx)
+
+	c.Check(google.ExposeRawDying(ctxConn), gc.Equals, (<-chan struct{})(dying))
+	c.Check(google.ExposeRawService(ctxConn), gc.Equals, google.ExposeRawService(conn))
+	// The original connection is unaffected.
+	c.Check(google.ExposeRawDying(conn), gc.IsNil)
+}
+
 func (s *connSuite) TestConnectionVerifyCredentials(c *gc.C) {
 	s.FakeConn.Project = &compute.Project{}
 	err := s.Conn.VerifyCredentials()
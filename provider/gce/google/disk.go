@@ -5,6 +5,7 @@ package google
 
 import (
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	jujuos "github.com/juju/os"
@@ -25,6 +26,7 @@ const (
 	// persistent
 	DiskPersistentStandard DiskType = "pd-standard"
 	DiskPersistentSSD      DiskType = "pd-ssd"
+	DiskPersistentBalanced DiskType = "pd-balanced"
 	// scratch
 	DiskLocalSSD DiskType = "local-ssd"
 )
@@ -111,6 +113,10 @@ type DiskSpec struct {
 	// Labels holds labels/metadata for the disk. Labels are used for
 	// storing volume resource tags.
 	Labels map[string]string
+	// ReplicaZones holds the names of the zones in which a regional
+	// disk is replicated. It is exclusive to regional disks, and
+	// must hold two zones in the disk's region.
+	ReplicaZones []string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -155,6 +161,7 @@ func (ds *DiskSpec) newAttached() *compute.AttachedDisk {
 			DiskSizeGb: int64(ds.SizeGB()),
 			// DiskType (defaults to pd-standard, pd-ssd, local-ssd)
 			SourceImage: ds.ImageURL,
+			Labels:      ds.Labels,
 		},
 		// Interface (defaults to SCSI)
 		// DeviceName (GCE sets this, persistent disk only)
@@ -173,11 +180,12 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
 	return &compute.Disk{
-		Name:        ds.Name,
-		SizeGb:      int64(ds.SizeGB()),
-		SourceImage: ds.ImageURL,
-		Type:        string(ds.PersistentDiskType),
-		Labels:      ds.Labels,
+		Name:         ds.Name,
+		SizeGb:       int64(ds.SizeGB()),
+		SourceImage:  ds.ImageURL,
+		Type:         string(ds.PersistentDiskType),
+		Labels:       ds.Labels,
+		ReplicaZones: ds.ReplicaZones,
 	}, nil
 }
 
@@ -213,9 +221,18 @@ type Disk struct {
 	// gce (persistent or ephemeral).
 	Type DiskType
 
-	// Zone holds the name of the zone in which the disk lives.
+	// Zone holds the name of the zone in which the disk lives. It is
+	// empty for regional disks.
 	Zone string
 
+	// Region holds the name of the region in which a regional disk
+	// lives. It is empty for zonal disks.
+	Region string
+
+	// ReplicaZones holds the names of the zones in which a regional
+	// disk is replicated.
+	ReplicaZones []string
+
 	// DiskStatus holds the status of he aforementioned disk.
 	Status DiskStatus
 
@@ -230,28 +247,51 @@ type Disk struct {
 	// LabelFingerprint holds a hash of the labels, to be used to prevent
 	// conflicting changes to labels.
 	LabelFingerprint string
+
+	// Created holds the time at which the disk was created. It is
+	// zero if the creation time is unknown.
+	Created time.Time
 }
 
 func NewDisk(cd *compute.Disk) *Disk {
 	// cd.Users contains the users of the disk (attached instances),
 	// in form: project/zones/zone/instances/instance. Disks and
-	// instances must be in the same zone, so we just take the
-	// final part of the path.
+	// instances must be in the same zone (or, for regional disks,
+	// in one of the replica zones), so we just take the final part
+	// of the path.
 	attachedInstances := make([]string, len(cd.Users))
 	for i, u := range cd.Users {
 		attachedInstances[i] = path.Base(u)
 	}
+	var replicaZones []string
+	for _, z := range cd.ReplicaZones {
+		replicaZones = append(replicaZones, path.Base(z))
+	}
 	d := &Disk{
 		Id:                cd.Id,
 		Name:              cd.Name,
 		Description:       cd.Description,
 		Size:              gibToMib(cd.SizeGb),
 		Type:              DiskType(cd.Type),
-		Zone:              path.Base(cd.Zone),
+		Zone:              resourceName(cd.Zone),
+		Region:            resourceName(cd.Region),
+		ReplicaZones:      replicaZones,
 		Status:            DiskStatus(cd.Status),
 		Labels:            cd.Labels,
 		LabelFingerprint:  cd.LabelFingerprint,
 		AttachedInstances: attachedInstances,
 	}
+	if created, err := time.Parse(time.RFC3339, cd.CreationTimestamp); err == nil {
+		d.Created = created
+	}
 	return d
 }
+
+// resourceName returns the final part of a resource URL,
+// or the empty string if there is none.
+func resourceName(url string) string {
+	if url == "" {
+		return ""
+	}
+	return path.Base(url)
+}
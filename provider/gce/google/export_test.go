@@ -4,6 +4,8 @@
 package google
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	"google.golang.org/api/compute/v1"
 )
@@ -19,16 +21,30 @@ var (
 	ExtractAddresses    = extractAddresses
 	NewRuleSetFromRules = newRuleSetFromRules
 	MatchesPrefix       = matchesPrefix
+
+	FormatAcceleratorType = formatAcceleratorType
 )
 
 func SetRawConn(conn *Connection, raw rawConnectionWrapper) {
 	conn.raw = raw
 }
 
+func SetConnNetwork(conn *Connection, spec NetworkSpec) {
+	conn.network = spec
+}
+
 func ExposeRawService(conn *Connection) *compute.Service {
 	return conn.raw.(*rawConn).Service
 }
 
+func ExposeRawOperationTimeout(conn *Connection) time.Duration {
+	return conn.raw.(*rawConn).operationTimeout
+}
+
+func ExposeRawDying(conn *Connection) <-chan struct{} {
+	return conn.raw.(*rawConn).dying
+}
+
 func NewAttached(spec DiskSpec) *compute.AttachedDisk {
 	return spec.newAttached()
 }
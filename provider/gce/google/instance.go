@@ -49,20 +49,143 @@ type InstanceSpec struct {
 	// (e.g. related to firewalls access rules).
 	Tags []string
 
+	// Labels are the GCE labels to set on the new instance. Unlike
+	// metadata, labels are visible in billing exports.
+	Labels map[string]string
+
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Accelerators holds the GPUs to attach to the instance. As with
+	// Type, accelerator types are resolved relative to the availability
+	// zone when the API request is sent.
+	Accelerators []AcceleratorSpec
+
+	// Preemptible indicates whether the instance may be stopped by
+	// GCE at any time to reclaim its capacity, in exchange for a
+	// lower price. Preemptible instances are never restarted by GCE.
+	Preemptible bool
+
+	// Spot indicates whether the instance is a Spot VM. Like
+	// preemptible instances, GCE may stop Spot VMs at any time and
+	// never restarts them, but they have no maximum running time.
+	Spot bool
+
+	// ShieldedVM holds the Shielded VM options for the instance.
+	ShieldedVM ShieldedVMSpec
+
+	// ConfidentialVM indicates whether the instance's memory is
+	// encrypted while in use. Only N2D machine types support
+	// Confidential VMs, which cannot be live migrated.
+	ConfidentialVM bool
+}
+
+// ShieldedVMSpec holds the Shielded VM options for an instance.
+type ShieldedVMSpec struct {
+	// SecureBoot indicates whether the instance only boots
+	// software signed by a recognised authority.
+	SecureBoot bool
+
+	// VTPM indicates whether the instance has a virtual
+	// Trusted Platform Module.
+	VTPM bool
+
+	// IntegrityMonitoring indicates whether the boot integrity
+	// of the instance is monitored. It requires VTPM.
+	IntegrityMonitoring bool
+}
+
+// Enabled reports whether any Shielded VM option is set.
+func (s ShieldedVMSpec) Enabled() bool {
+	return s.SecureBoot || s.VTPM || s.IntegrityMonitoring
+}
+
+// AcceleratorSpec describes a number of GPUs of a single type.
+type AcceleratorSpec struct {
+	// Type is the name of the GCE accelerator type,
+	// eg "nvidia-tesla-k80".
+	Type string
+
+	// Count is the number of accelerators of the type.
+	Count int64
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
-	return &compute.Instance{
+	inst := &compute.Instance{
 		Name:              is.ID,
 		Disks:             is.disks(),
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Labels:            is.Labels,
 		// MachineType is set in the addInstance call.
 	}
+	for _, spec := range is.Accelerators {
+		inst.GuestAccelerators = append(inst.GuestAccelerators, &compute.AcceleratorConfig{
+			AcceleratorType:  spec.Type,
+			AcceleratorCount: spec.Count,
+		})
+	}
+	if is.ShieldedVM.Enabled() {
+		inst.ShieldedInstanceConfig = &compute.ShieldedInstanceConfig{
+			EnableSecureBoot:          is.ShieldedVM.SecureBoot,
+			EnableVtpm:                is.ShieldedVM.VTPM,
+			EnableIntegrityMonitoring: is.ShieldedVM.IntegrityMonitoring,
+		}
+	}
+	if is.ConfidentialVM {
+		inst.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
+			EnableConfidentialCompute: true,
+		}
+	}
+	inst.Scheduling = is.scheduling()
+	return inst
+}
+
+// provisioningModelSpot is the provisioning model of Spot VMs.
+const provisioningModelSpot = "SPOT"
+
+// scheduling returns the scheduling options for the instance, or nil
+// if the defaults apply. Each of the instance's options only sets the
+// fields it needs, so that they combine.
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	var scheduling *compute.Scheduling
+	set := func(update func(*compute.Scheduling)) {
+		if scheduling == nil {
+			scheduling = &compute.Scheduling{}
+		}
+		update(scheduling)
+	}
+	// Instances with GPUs attached, and confidential VMs, cannot be
+	// live migrated.
+	if len(is.Accelerators) > 0 || is.ConfidentialVM {
+		set(func(s *compute.Scheduling) {
+			s.OnHostMaintenance = "TERMINATE"
+		})
+	}
+	if is.Preemptible {
+		// Preemptible instances can neither be live migrated nor
+		// restarted automatically.
+		automaticRestart := false
+		set(func(s *compute.Scheduling) {
+			s.Preemptible = true
+			s.AutomaticRestart = &automaticRestart
+			s.OnHostMaintenance = "TERMINATE"
+		})
+	}
+	if is.Spot {
+		// Spot VMs are stopped, rather than deleted, when GCE
+		// reclaims them, so that the preemption can be reported.
+		automaticRestart := false
+		set(func(s *compute.Scheduling) {
+			s.ProvisioningModel = provisioningModelSpot
+			s.InstanceTerminationAction = "STOP"
+			s.AutomaticRestart = &automaticRestart
+			s.OnHostMaintenance = "TERMINATE"
+		})
+	}
+	return scheduling
 }
 
 // Summary builds an InstanceSummary based on the spec and returns it.
@@ -99,6 +222,9 @@ func (is InstanceSpec) RootDisk() *compute.AttachedDisk {
 type InstanceSummary struct {
 	// ID is the "name" of the instance.
 	ID string
+	// NumericID is the unique number GCE assigns to the instance.
+	// It identifies the instance in Cloud Monitoring.
+	NumericID uint64
 	// ZoneName is the unqualified name of the zone in which the
 	// instance was provisioned.
 	ZoneName string
@@ -111,17 +237,66 @@ type InstanceSummary struct {
 	// NetworkInterfaces are the network connections associated with
 	// the instance.
 	NetworkInterfaces []*compute.NetworkInterface
+	// Accelerators are the GPUs attached to the instance.
+	Accelerators []AcceleratorSpec
+	// Preemptible indicates whether GCE may stop the instance
+	// at any time to reclaim its capacity. This is true for both
+	// preemptible instances and Spot VMs.
+	Preemptible bool
+	// ShieldedVM holds the Shielded VM options of the instance.
+	ShieldedVM ShieldedVMSpec
+	// ConfidentialVM indicates whether the instance's memory is
+	// encrypted while in use.
+	ConfidentialVM bool
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
+	var accelerators []AcceleratorSpec
+	for _, config := range raw.GuestAccelerators {
+		accelerators = append(accelerators, AcceleratorSpec{
+			Type:  path.Base(config.AcceleratorType),
+			Count: config.AcceleratorCount,
+		})
+	}
 	return InstanceSummary{
 		ID:                raw.Name,
+		NumericID:         raw.Id,
 		ZoneName:          path.Base(raw.Zone),
 		Status:            raw.Status,
 		Metadata:          unpackMetadata(raw.Metadata),
 		Addresses:         extractAddresses(raw.NetworkInterfaces...),
 		NetworkInterfaces: raw.NetworkInterfaces,
+		Accelerators:      accelerators,
+		Preemptible:       isPreemptible(raw.Scheduling),
+		ShieldedVM:        shieldedVMSpec(raw.ShieldedInstanceConfig),
+		ConfidentialVM:    raw.ConfidentialInstanceConfig != nil && raw.ConfidentialInstanceConfig.EnableConfidentialCompute,
+	}
+}
+
+// isPreemptible reports whether the scheduling options allow GCE
+// to stop the instance at any time.
+func isPreemptible(scheduling *compute.Scheduling) bool {
+	if scheduling == nil {
+		return false
+	}
+	return scheduling.Preemptible || scheduling.ProvisioningModel == provisioningModelSpot
+}
+
+func shieldedVMSpec(config *compute.ShieldedInstanceConfig) ShieldedVMSpec {
+	if config == nil {
+		return ShieldedVMSpec{}
 	}
+	return ShieldedVMSpec{
+		SecureBoot:          config.EnableSecureBoot,
+		VTPM:                config.EnableVtpm,
+		IntegrityMonitoring: config.EnableIntegrityMonitoring,
+	}
+}
+
+// Preempted reports whether the instance is preemptible and has
+// been stopped.
+func (s InstanceSummary) Preempted() bool {
+	return s.Preemptible && s.Status == StatusTerminated
 }
 
 // Instance represents a single realized GCE compute instance.
@@ -240,3 +415,7 @@ func unpackMetadata(data *compute.Metadata) map[string]string {
 func formatMachineType(zone, name string) string {
 	return fmt.Sprintf("zones/%s/machineTypes/%s", zone, name)
 }
+
+func formatAcceleratorType(zone, name string) string {
+	return fmt.Sprintf("zones/%s/acceleratorTypes/%s", zone, name)
+}
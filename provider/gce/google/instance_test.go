@@ -62,6 +62,24 @@ func (s *instanceSuite) TestInstanceStatusDown(c *gc.C) {
 	c.Check(status, gc.Equals, google.StatusDown)
 }
 
+func (s *instanceSuite) TestInstancePreempted(c *gc.C) {
+	c.Check(s.Instance.Preempted(), jc.IsFalse)
+
+	s.Instance.InstanceSummary.Status = google.StatusTerminated
+	c.Check(s.Instance.Preempted(), jc.IsFalse)
+
+	s.Instance.InstanceSummary.Preemptible = true
+	c.Check(s.Instance.Preempted(), jc.IsTrue)
+}
+
+func (s *instanceSuite) TestNewInstanceSpotIsPreemptible(c *gc.C) {
+	raw := s.RawInstanceFull
+	raw.Scheduling = &compute.Scheduling{ProvisioningModel: "SPOT"}
+	inst := google.NewInstanceRaw(&raw, nil)
+
+	c.Check(inst.InstanceSummary.Preemptible, jc.IsTrue)
+}
+
 func (s *instanceSuite) TestInstanceAddresses(c *gc.C) {
 	addresses := s.Instance.Addresses()
 
@@ -99,3 +117,9 @@ func (s *instanceSuite) TestFormatMachineType(c *gc.C) {
 
 	c.Check(resolved, gc.Equals, "zones/a-zone/machineTypes/spam")
 }
+
+func (s *instanceSuite) TestFormatAcceleratorType(c *gc.C) {
+	resolved := google.FormatAcceleratorType("a-zone", "nvidia-tesla-k80")
+
+	c.Check(resolved, gc.Equals, "zones/a-zone/acceleratorTypes/nvidia-tesla-k80")
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// cpuUtilizationMetric is the fraction of an instance's allocated
+	// CPU in use. It is reported for every instance.
+	cpuUtilizationMetric = "compute.googleapis.com/instance/cpu/utilization"
+
+	// memoryUtilizationMetric is the percentage of an instance's
+	// memory in use. It is only reported for instances running the
+	// Cloud Monitoring agent.
+	memoryUtilizationMetric = "agent.googleapis.com/memory/percent_used"
+
+	// utilizationWindow is how far back to look for the most
+	// recent utilization of an instance. Metrics are sampled
+	// every minute, and take a few minutes to become visible.
+	utilizationWindow = 10 * time.Minute
+)
+
+// InstanceUtilization holds the resource utilization of an instance
+// reported by Cloud Monitoring. Values that are not reported are nil.
+type InstanceUtilization struct {
+	CPUPercent    *float64
+	MemoryPercent *float64
+}
+
+// InstanceUtilization returns the most recent resource utilization of
+// the instance with the given numeric ID reported by Cloud Monitoring.
+func (gc *Connection) InstanceUtilization(numericID uint64) (InstanceUtilization, error) {
+	end := time.Now()
+	start := end.Add(-utilizationWindow)
+	var result InstanceUtilization
+
+	filter := utilizationFilter(cpuUtilizationMetric, numericID)
+	cpu, ok, err := gc.raw.LatestTimeSeriesValue(gc.projectID, filter, start, end)
+	if err != nil {
+		return InstanceUtilization{}, errors.Annotate(err, "getting CPU utilization")
+	}
+	if ok {
+		cpu *= 100
+		result.CPUPercent = &cpu
+	}
+
+	filter = utilizationFilter(memoryUtilizationMetric, numericID) + ` AND metric.labels.state="used"`
+	memory, ok, err := gc.raw.LatestTimeSeriesValue(gc.projectID, filter, start, end)
+	if err != nil {
+		return InstanceUtilization{}, errors.Annotate(err, "getting memory utilization")
+	}
+	if ok {
+		result.MemoryPercent = &memory
+	}
+	return result, nil
+}
+
+// utilizationFilter returns the Cloud Monitoring filter selecting
+// the time series of the metric for the instance with the numeric ID.
+func utilizationFilter(metricType string, numericID uint64) string {
+	return fmt.Sprintf(`metric.type=%q AND resource.labels.instance_id="%d"`, metricType, numericID)
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+const (
+	cpuFilter    = `metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.labels.instance_id="1234"`
+	memoryFilter = `metric.type="agent.googleapis.com/memory/percent_used" AND resource.labels.instance_id="1234" AND metric.labels.state="used"`
+)
+
+func (s *connSuite) TestConnectionInstanceUtilization(c *gc.C) {
+	s.FakeConn.TimeSeries = map[string]float64{
+		cpuFilter:    0.25,
+		memoryFilter: 60,
+	}
+	utilization, err := s.Conn.InstanceUtilization(1234)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilization.CPUPercent, gc.NotNil)
+	c.Check(*utilization.CPUPercent, gc.Equals, 25.0)
+	c.Assert(utilization.MemoryPercent, gc.NotNil)
+	c.Check(*utilization.MemoryPercent, gc.Equals, 60.0)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "LatestTimeSeriesValue")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Filter, gc.Equals, cpuFilter)
+	c.Check(s.FakeConn.Calls[1].Filter, gc.Equals, memoryFilter)
+}
+
+func (s *connSuite) TestConnectionInstanceUtilizationNoAgent(c *gc.C) {
+	s.FakeConn.TimeSeries = map[string]float64{
+		cpuFilter: 0.5,
+	}
+	utilization, err := s.Conn.InstanceUtilization(1234)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilization.CPUPercent, gc.NotNil)
+	c.Check(*utilization.CPUPercent, gc.Equals, 50.0)
+	c.Check(utilization.MemoryPercent, gc.IsNil)
+}
+
+func (s *connSuite) TestConnectionInstanceUtilizationError(c *gc.C) {
+	s.FakeConn.Err = errors.New("forbidden")
+	_, err := s.Conn.InstanceUtilization(1234)
+	c.Assert(err, gc.ErrorMatches, "getting CPU utilization: forbidden")
+}
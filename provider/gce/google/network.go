@@ -4,6 +4,7 @@
 package google
 
 import (
+	"fmt"
 	"sort"
 
 	"google.golang.org/api/compute/v1"
@@ -14,6 +15,7 @@ import (
 const (
 	networkDefaultName = "default"
 	networkPathRoot    = "global/networks/"
+	projectPathRoot    = "projects/"
 )
 
 // The different kinds of network access.
@@ -26,6 +28,20 @@ const (
 type NetworkSpec struct {
 	// Name is the unqualified name of the network.
 	Name string
+
+	// Subnetwork is the unqualified name of the subnetwork of the
+	// network that interfaces are connected to. If it is not set,
+	// the network's subnetwork in the region is chosen by GCE,
+	// which requires the network to be an auto mode network.
+	Subnetwork string
+
+	// Region is the region of the subnetwork.
+	Region string
+
+	// Project is the ID of the project that owns the network. It is
+	// set when the network is a shared VPC network in a host project,
+	// and otherwise the network is in the connection's project.
+	Project string
 	// TODO(ericsnow) support a CIDR for internal IP addr range?
 }
 
@@ -35,7 +51,43 @@ func (ns *NetworkSpec) Path() string {
 	if name == "" {
 		name = networkDefaultName
 	}
-	return networkPathRoot + name
+	return ns.projectPath() + networkPathRoot + name
+}
+
+// SubnetworkPath returns the qualified name of the subnetwork,
+// or "" if no subnetwork is specified.
+func (ns *NetworkSpec) SubnetworkPath() string {
+	if ns.Subnetwork == "" {
+		return ""
+	}
+	return fmt.Sprintf("%sregions/%s/subnetworks/%s", ns.projectPath(), ns.Region, ns.Subnetwork)
+}
+
+func (ns *NetworkSpec) projectPath() string {
+	if ns.Project == "" {
+		return ""
+	}
+	return projectPathRoot + ns.Project + "/"
+}
+
+// project returns the ID of the project that owns the network,
+// given the ID of the connection's project.
+func (ns *NetworkSpec) project(defaultProject string) string {
+	if ns.Project == "" {
+		return defaultProject
+	}
+	return ns.Project
+}
+
+// firewallNetwork returns the qualified name of the network to set
+// on firewalls. It is empty for the default network, so that
+// firewalls are created in the default network as they always have
+// been.
+func (ns *NetworkSpec) firewallNetwork() string {
+	if ns.Name == "" && ns.Project == "" {
+		return ""
+	}
+	return ns.Path()
 }
 
 // newInterface builds up all the data needed by the GCE API to create
@@ -53,6 +105,7 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 	}
 	return &compute.NetworkInterface{
 		Network:       ns.Path(),
+		Subnetwork:    ns.SubnetworkPath(),
 		AccessConfigs: access,
 	}
 }
@@ -45,6 +45,35 @@ func (s *networkSuite) TestNetworkSpecNewInterface(c *gc.C) {
 	})
 }
 
+func (s *networkSuite) TestNetworkSpecPathSharedVPC(c *gc.C) {
+	spec := google.NetworkSpec{
+		Name:       "spam",
+		Subnetwork: "ham",
+		Region:     "us-east1",
+		Project:    "host",
+	}
+	c.Check(spec.Path(), gc.Equals, "projects/host/global/networks/spam")
+	c.Check(spec.SubnetworkPath(), gc.Equals, "projects/host/regions/us-east1/subnetworks/ham")
+}
+
+func (s *networkSuite) TestNetworkSpecNewInterfaceSubnetwork(c *gc.C) {
+	spec := google.NetworkSpec{
+		Name:       "spam",
+		Subnetwork: "ham",
+		Region:     "us-east1",
+	}
+	netIF := google.NewNetInterface(spec, "eggs")
+
+	c.Check(netIF, gc.DeepEquals, &compute.NetworkInterface{
+		Network:    "global/networks/spam",
+		Subnetwork: "regions/us-east1/subnetworks/ham",
+		AccessConfigs: []*compute.AccessConfig{{
+			Name: "eggs",
+			Type: google.NetworkAccessOneToOneNAT,
+		}},
+	})
+}
+
 type ByIPProtocol []*compute.FirewallAllowed
 
 func (s ByIPProtocol) Len() int {
@@ -4,8 +4,10 @@
 package google
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 	"time"
@@ -17,7 +19,12 @@ import (
 	"google.golang.org/api/googleapi"
 )
 
-const diskTypesBase = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s/diskTypes/%s"
+const (
+	diskTypesBase       = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s/diskTypes/%s"
+	regionDiskTypesBase = "https://www.googleapis.com/compute/v1/projects/%s/regions/%s/diskTypes/%s"
+	zoneBase            = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s"
+	timeSeriesBase      = "https://monitoring.googleapis.com/v3/projects/%s/timeSeries"
+)
 
 // These are attempt strategies used in waitOperation.
 var (
@@ -40,6 +47,29 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// monitoring is used to send requests to the Cloud
+	// Monitoring API, which the compute service does not cover.
+	monitoring *http.Client
+
+	// operationTimeout is the longest time to wait for a GCE
+	// operation to complete. If it is zero, attemptsLong.Total
+	// is used.
+	operationTimeout time.Duration
+
+	// dying, if not nil, is closed when the caller is no longer
+	// interested in the result of the operations being waited for.
+	dying <-chan struct{}
+}
+
+// operationAttempts returns the attempt strategy used to wait for
+// GCE operations to complete, limited by the operation timeout.
+func (rc *rawConn) operationAttempts() utils.AttemptStrategy {
+	attempts := attemptsLong
+	if rc.operationTimeout > 0 {
+		attempts.Total = rc.operationTimeout
+	}
+	return attempts
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -100,7 +130,7 @@ func (rc *rawConn) AddInstance(projectID, zoneName string, spec *compute.Instanc
 		// We are guaranteed the insert failed at the point.
 		return errors.Annotate(err, "sending new instance request")
 	}
-	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), logOperationErrors)
 	return errors.Trace(err)
 }
 
@@ -110,7 +140,17 @@ func (rc *rawConn) RemoveInstance(projectID, zone, id string) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = rc.waitOperation(projectID, operation, attemptsLong, returnNotFoundOperationErrors)
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), returnNotFoundOperationErrors)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) StartInstance(projectID, zone, id string) error {
+	call := rc.Instances.Start(projectID, zone, id)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), logOperationErrors)
 	return errors.Trace(err)
 }
 
@@ -143,7 +183,7 @@ func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) err
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), logOperationErrors)
 	return errors.Trace(err)
 }
 
@@ -153,7 +193,7 @@ func (rc *rawConn) UpdateFirewall(projectID, name string, firewall *compute.Fire
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = rc.waitOperation(projectID, operation, attemptsLong, logOperationErrors)
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), logOperationErrors)
 	return errors.Trace(err)
 }
 
@@ -191,7 +231,7 @@ func (rc *rawConn) RemoveFirewall(projectID, name string) error {
 		return errors.Trace(convertRawAPIError(err))
 	}
 
-	err = rc.waitOperation(projectID, operation, attemptsLong, returnNotFoundOperationErrors)
+	err = rc.waitOperation(projectID, operation, rc.operationAttempts(), returnNotFoundOperationErrors)
 	return errors.Trace(convertRawAPIError(err))
 }
 
@@ -239,7 +279,7 @@ func (rc *rawConn) CreateDisk(project, zone string, spec *compute.Disk) error {
 	if err != nil {
 		return errors.Annotate(err, "could not create a new disk")
 	}
-	return errors.Trace(rc.waitOperation(project, op, attemptsLong, logOperationErrors))
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), logOperationErrors))
 }
 
 func (rc *rawConn) ListDisks(project string) ([]*compute.Disk, error) {
@@ -269,7 +309,7 @@ func (rc *rawConn) RemoveDisk(project, zone, id string) error {
 	if err != nil {
 		return errors.Annotatef(err, "could not delete disk %q", id)
 	}
-	return errors.Trace(rc.waitOperation(project, op, attemptsLong, returnNotFoundOperationErrors))
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), returnNotFoundOperationErrors))
 }
 
 func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
@@ -292,6 +332,102 @@ func (rc *rawConn) SetDiskLabels(project, zone, id, labelFingerprint string, lab
 	return errors.Trace(err)
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	ds := rc.Service.Disks
+	call := ds.Resize(project, zone, id, &compute.DisksResizeRequest{SizeGb: sizeGB})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), logOperationErrors))
+}
+
+func formatRegionDisk(project, region string, spec *compute.Disk) {
+	// Replica zones must be specified as URLs.
+	for i, zone := range spec.ReplicaZones {
+		if !strings.Contains(zone, "/") {
+			spec.ReplicaZones[i] = fmt.Sprintf(zoneBase, project, zone)
+		}
+	}
+	// empty will default in pd-standard
+	if spec.Type == "" {
+		return
+	}
+	if strings.HasPrefix(spec.Type, "http") || strings.HasPrefix(spec.Type, "projects") || strings.HasPrefix(spec.Type, "regions") {
+		return
+	}
+	spec.Type = fmt.Sprintf(regionDiskTypesBase, project, region, spec.Type)
+}
+
+func (rc *rawConn) CreateRegionDisk(project, region string, spec *compute.Disk) error {
+	ds := rc.Service.RegionDisks
+	formatRegionDisk(project, region, spec)
+	call := ds.Insert(project, region, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotate(err, "could not create a new regional disk")
+	}
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), logOperationErrors))
+}
+
+func (rc *rawConn) ListRegionDisks(project, region string) ([]*compute.Disk, error) {
+	ds := rc.Service.RegionDisks
+	call := ds.List(project, region)
+	var results []*compute.Disk
+	for {
+		diskList, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, diskList.Items...)
+		if diskList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(diskList.NextPageToken)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) RemoveRegionDisk(project, region, id string) error {
+	ds := rc.RegionDisks
+	call := ds.Delete(project, region, id)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not delete regional disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), returnNotFoundOperationErrors))
+}
+
+func (rc *rawConn) GetRegionDisk(project, region, id string) (*compute.Disk, error) {
+	ds := rc.RegionDisks
+	call := ds.Get(project, region, id)
+	disk, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get disk %q at region %q in project %q", id, region, project)
+	}
+	return disk, nil
+}
+
+func (rc *rawConn) SetRegionDiskLabels(project, region, id, labelFingerprint string, labels map[string]string) error {
+	ds := rc.Service.RegionDisks
+	call := ds.SetLabels(project, region, id, &compute.RegionSetLabelsRequest{
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	})
+	_, err := call.Do()
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) ResizeRegionDisk(project, region, id string, sizeGB int64) error {
+	ds := rc.Service.RegionDisks
+	call := ds.Resize(project, region, id, &compute.RegionDisksResizeRequest{SizeGb: sizeGB})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, rc.operationAttempts(), logOperationErrors))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
@@ -374,7 +510,8 @@ var doOpCall = func(call opDoer) (*compute.Operation, error) {
 
 // waitOperation waits for the provided operation to reach the "done"
 // status. It follows the given attempt strategy (e.g. wait time between
-// attempts) and may time out.
+// attempts) and may time out. Waiting is abandoned if the connection's
+// dying channel is closed between attempts.
 //
 // TODO(katco): 2016-08-09: lp:1611427
 func (rc *rawConn) waitOperation(projectID string, op *compute.Operation, attempts utils.AttemptStrategy, f handleOperationErrors) error {
@@ -391,6 +528,17 @@ func (rc *rawConn) waitOperation(projectID string, op *compute.Operation, attemp
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if op.Status == StatusDone || !a.HasNext() {
+			continue
+		}
+		// Wait out the delay here rather than in a.Next, so
+		// that a cancelled caller does not have to wait for it.
+		select {
+		case <-rc.dying:
+			err := errors.Errorf("cancelled after %d seconds", time.Now().Sub(started)/time.Second)
+			return waitError{op, err}
+		case <-time.After(attempts.Delay):
+		}
 	}
 	if op.Status != StatusDone {
 		// lp:1558657
@@ -421,7 +569,30 @@ func (rc *rawConn) SetMetadata(projectID, zone, instanceID string, metadata *com
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = rc.waitOperation(projectID, op, attemptsLong, logOperationErrors)
+	err = rc.waitOperation(projectID, op, rc.operationAttempts(), logOperationErrors)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) SetTags(projectID, zone, instanceID string, tags *compute.Tags) error {
+	call := rc.Instances.SetTags(projectID, zone, instanceID, tags)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, op, rc.operationAttempts(), logOperationErrors)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error {
+	call := rc.Instances.SetLabels(projectID, zone, instanceID, &compute.InstancesSetLabelsRequest{
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, op, rc.operationAttempts(), logOperationErrors)
 	return errors.Trace(err)
 }
 
@@ -452,3 +623,42 @@ func (rc *rawConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+// timeSeriesList is the part of the Cloud Monitoring API's
+// timeSeries.list response used by LatestTimeSeriesValue.
+type timeSeriesList struct {
+	TimeSeries []struct {
+		Points []struct {
+			Value struct {
+				DoubleValue *float64 `json:"doubleValue"`
+			} `json:"value"`
+		} `json:"points"`
+	} `json:"timeSeries"`
+}
+
+func (rc *rawConn) LatestTimeSeriesValue(projectID, filter string, start, end time.Time) (float64, bool, error) {
+	query := url.Values{
+		"filter":             {filter},
+		"interval.startTime": {start.UTC().Format(time.RFC3339)},
+		"interval.endTime":   {end.UTC().Format(time.RFC3339)},
+	}
+	resp, err := rc.monitoring.Get(fmt.Sprintf(timeSeriesBase, projectID) + "?" + query.Encode())
+	if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return 0, false, errors.Trace(convertRawAPIError(err))
+	}
+	var list timeSeriesList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return 0, false, errors.Annotate(err, "decoding time series")
+	}
+	// The points in each time series are returned most recent first.
+	for _, series := range list.TimeSeries {
+		if len(series.Points) > 0 && series.Points[0].Value.DoubleValue != nil {
+			return *series.Points[0].Value.DoubleValue, true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -4,6 +4,8 @@
 package google
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -37,7 +39,7 @@ func (s *rawConnSuite) SetUpTest(c *gc.C) {
 	service.ZoneOperations = compute.NewZoneOperationsService(service)
 	service.RegionOperations = compute.NewRegionOperationsService(service)
 	service.GlobalOperations = compute.NewGlobalOperationsService(service)
-	s.rawConn = &rawConn{service}
+	s.rawConn = &rawConn{Service: service}
 	s.strategy.Min = 4
 
 	s.callCount = 0
@@ -152,6 +154,28 @@ func (s *rawConnSuite) TestConnectionWaitOperationError(c *gc.C) {
 	c.Check(s.callCount, gc.Equals, 1)
 }
 
+func (s *rawConnSuite) TestConnectionWaitOperationCancelled(c *gc.C) {
+	s.op.Status = StatusRunning
+	s.strategy.Delay = time.Minute
+	dying := make(chan struct{})
+	close(dying)
+	s.rawConn.dying = dying
+
+	err := s.rawConn.waitOperation("proj", s.op, s.strategy, s.handleOperationErrorsF)
+
+	c.Check(err, gc.ErrorMatches, `GCE operation "some_op" failed: cancelled after 0 seconds`)
+	c.Check(s.callCount, gc.Equals, 1)
+}
+
+func (s *rawConnSuite) TestOperationAttempts(c *gc.C) {
+	c.Check(s.rawConn.operationAttempts(), jc.DeepEquals, attemptsLong)
+
+	s.rawConn.operationTimeout = 20 * time.Minute
+	attempts := s.rawConn.operationAttempts()
+	c.Check(attempts.Total, gc.Equals, 20*time.Minute)
+	c.Check(attempts.Delay, gc.Equals, attemptsLong.Delay)
+}
+
 type firewallNameSuite struct{}
 
 var _ = gc.Suite(&firewallNameSuite{})
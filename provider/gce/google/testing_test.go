@@ -4,6 +4,8 @@
 package google
 
 import (
+	"time"
+
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
@@ -168,8 +170,11 @@ type fakeCall struct {
 	DeviceName       string
 	ComputeDisk      *compute.Disk
 	Metadata         *compute.Metadata
+	Tags             *compute.Tags
 	LabelFingerprint string
 	Labels           map[string]string
+	Filter           string
+	SizeGB           int64
 }
 
 type fakeConn struct {
@@ -187,6 +192,9 @@ type fakeConn struct {
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
+	// TimeSeries holds the latest value of the time
+	// series matching each filter.
+	TimeSeries map[string]float64
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -252,6 +260,22 @@ func (rc *fakeConn) AddInstance(projectID, zoneName string, spec *compute.Instan
 	return err
 }
 
+func (rc *fakeConn) StartInstance(projectID, zone, id string) error {
+	call := fakeCall{
+		FuncName:  "StartInstance",
+		ProjectID: projectID,
+		ID:        id,
+		ZoneName:  zone,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) RemoveInstance(projectID, zone, id string) error {
 	call := fakeCall{
 		FuncName:  "RemoveInstance",
@@ -424,6 +448,87 @@ func (rc *fakeConn) SetDiskLabels(project, zone, id, labelFingerprint string, la
 	return err
 }
 
+func (rc *fakeConn) CreateRegionDisk(project, region string, spec *compute.Disk) error {
+	call := fakeCall{
+		FuncName:    "CreateRegionDisk",
+		ProjectID:   project,
+		Region:      region,
+		ComputeDisk: spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListRegionDisks(project, region string) ([]*compute.Disk, error) {
+	call := fakeCall{
+		FuncName:  "ListRegionDisks",
+		ProjectID: project,
+		Region:    region,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Disks, err
+}
+
+func (rc *fakeConn) RemoveRegionDisk(project, region, id string) error {
+	call := fakeCall{
+		FuncName:  "RemoveRegionDisk",
+		ProjectID: project,
+		Region:    region,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) GetRegionDisk(project, region, id string) (*compute.Disk, error) {
+	call := fakeCall{
+		FuncName:  "GetRegionDisk",
+		ProjectID: project,
+		Region:    region,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Disk, err
+}
+
+func (rc *fakeConn) SetRegionDiskLabels(project, region, id, labelFingerprint string, labels map[string]string) error {
+	call := fakeCall{
+		FuncName:         "SetRegionDiskLabels",
+		ProjectID:        project,
+		Region:           region,
+		ID:               id,
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error {
 	call := fakeCall{
 		FuncName:     "AttachDisk",
@@ -491,6 +596,41 @@ func (rc *fakeConn) ListMachineTypes(projectID, zone string) (*compute.MachineTy
 
 }
 
+func (rc *fakeConn) SetTags(projectID, zone, instanceID string, tags *compute.Tags) error {
+	call := fakeCall{
+		FuncName:   "SetTags",
+		ProjectID:  projectID,
+		ZoneName:   zone,
+		InstanceId: instanceID,
+		Tags:       tags,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error {
+	call := fakeCall{
+		FuncName:         "SetInstanceLabels",
+		ProjectID:        projectID,
+		ZoneName:         zone,
+		InstanceId:       instanceID,
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) SetMetadata(projectID, zone, instanceID string, metadata *compute.Metadata) error {
 	call := fakeCall{
 		FuncName:   "SetMetadata",
@@ -542,3 +682,56 @@ func (rc *fakeConn) ListSubnetworks(projectID, region string) ([]*compute.Subnet
 	}
 	return rc.Subnetworks, nil
 }
+
+func (rc *fakeConn) LatestTimeSeriesValue(projectID, filter string, start, end time.Time) (float64, bool, error) {
+	call := fakeCall{
+		FuncName:  "LatestTimeSeriesValue",
+		ProjectID: projectID,
+		Filter:    filter,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	value, ok := rc.TimeSeries[filter]
+	return value, ok, nil
+}
+
+func (rc *fakeConn) ResizeDisk(project, zone, id string, sizeGB int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeDisk",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        id,
+		SizeGB:    sizeGB,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ResizeRegionDisk(project, region, id string, sizeGB int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeRegionDisk",
+		ProjectID: project,
+		Region:    region,
+		ID:        id,
+		SizeGB:    sizeGB,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
@@ -4,6 +4,8 @@
 package gce
 
 import (
+	"math"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/core/instance"
@@ -19,7 +21,10 @@ type environInstance struct {
 	env  *environ
 }
 
-var _ instances.Instance = (*environInstance)(nil)
+var (
+	_ instances.Instance         = (*environInstance)(nil)
+	_ instances.InstanceUtilizer = (*environInstance)(nil)
+)
 
 func newInstance(base *google.Instance, env *environ) *environInstance {
 	return &environInstance{
@@ -47,6 +52,9 @@ func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.St
 	default:
 		jujuStatus = status.Empty
 	}
+	if inst.base.Preempted() {
+		jujuStatus = status.Preempted
+	}
 	return instance.Status{
 		Status:  jujuStatus,
 		Message: instStatus,
@@ -58,6 +66,37 @@ func (inst *environInstance) Addresses(ctx context.ProviderCallContext) ([]netwo
 	return inst.base.Addresses(), nil
 }
 
+// Utilization implements instances.InstanceUtilizer. Utilization is
+// only read from Cloud Monitoring if instance-utilization is set, as
+// it takes two requests for each instance every time it is polled.
+func (inst *environInstance) Utilization(ctx context.ProviderCallContext) (instance.Utilization, error) {
+	inst.env.lock.Lock()
+	enabled := inst.env.ecfg.instanceUtilization()
+	inst.env.lock.Unlock()
+	if !enabled {
+		return instance.Utilization{}, errors.NotSupportedf("instance utilization without %s", cfgInstanceUtilization)
+	}
+	utilization, err := inst.env.gce.InstanceUtilization(inst.base.NumericID)
+	if err != nil {
+		return instance.Utilization{}, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return instance.Utilization{
+		CPUPercent:    roundPercent(utilization.CPUPercent),
+		MemoryPercent: roundPercent(utilization.MemoryPercent),
+	}, nil
+}
+
+// roundPercent rounds the percentage to the tenth of a percent shown
+// by juju machines, so that the utilization recorded for a machine
+// only changes when the change can be seen.
+func roundPercent(percent *float64) *float64 {
+	if percent == nil {
+		return nil
+	}
+	rounded := math.Round(*percent*10) / 10
+	return &rounded
+}
+
 func findInst(id instance.Id, instances []instances.Instance) instances.Instance {
 	for _, inst := range instances {
 		if id == inst.Id() {
@@ -77,7 +116,7 @@ func (inst *environInstance) OpenPorts(ctx context.ProviderCallContext, machineI
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = inst.env.gce.OpenPorts(name, rules...)
+	err = connectionWithContext(inst.env.gce, ctx).OpenPorts(name, rules...)
 	return google.HandleCredentialError(errors.Trace(err), ctx)
 }
 
@@ -88,7 +127,7 @@ func (inst *environInstance) ClosePorts(ctx context.ProviderCallContext, machine
 	if err != nil {
 		return errors.Trace(err)
 	}
-	err = inst.env.gce.ClosePorts(name, rules...)
+	err = connectionWithContext(inst.env.gce, ctx).ClosePorts(name, rules...)
 	return google.HandleCredentialError(errors.Trace(err), ctx)
 }
 
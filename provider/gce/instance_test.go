@@ -4,10 +4,12 @@
 package gce_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
@@ -40,6 +42,29 @@ func (s *instanceSuite) TestStatus(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestStatusPreempted(c *gc.C) {
+	summary := google.InstanceSummary{
+		ID:          "spam",
+		Status:      google.StatusTerminated,
+		Preemptible: true,
+	}
+	inst := gce.NewInstance(google.NewInstance(summary, nil), s.Env)
+
+	instStatus := inst.Status(s.CallCtx)
+	c.Check(instStatus.Status, gc.Equals, status.Preempted)
+	c.Check(instStatus.Message, gc.Equals, google.StatusTerminated)
+}
+
+func (s *instanceSuite) TestStatusTerminated(c *gc.C) {
+	summary := google.InstanceSummary{
+		ID:     "spam",
+		Status: google.StatusTerminated,
+	}
+	inst := gce.NewInstance(google.NewInstance(summary, nil), s.Env)
+
+	c.Check(inst.Status(s.CallCtx).Status, gc.Equals, status.Empty)
+}
+
 func (s *instanceSuite) TestAddresses(c *gc.C) {
 	addresses, err := s.Instance.Addresses(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -48,6 +73,27 @@ func (s *instanceSuite) TestAddresses(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestUtilization(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"instance-utilization": true})
+	cpu := 12.5432
+	s.FakeConn.Utilization = google.InstanceUtilization{CPUPercent: &cpu}
+	utilization, err := s.Instance.Utilization(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rounded := 12.5
+	c.Check(utilization, jc.DeepEquals, instance.Utilization{CPUPercent: &rounded})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "InstanceUtilization")
+	c.Check(s.FakeConn.Calls[0].NumericID, gc.Equals, s.BaseInstance.NumericID)
+}
+
+func (s *instanceSuite) TestUtilizationNotEnabled(c *gc.C) {
+	_, err := s.Instance.Utilization(s.CallCtx)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	s.CheckNoAPI(c)
+}
+
 func (s *instanceSuite) TestOpenPortsAPI(c *gc.C) {
 	err := s.Instance.OpenPorts(s.CallCtx, "42", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
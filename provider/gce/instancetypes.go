@@ -4,8 +4,11 @@
 package gce
 
 import (
+	"fmt"
+
 	"github.com/juju/utils/arch"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/instances"
 )
 
@@ -14,6 +17,119 @@ var (
 	arches = []string{arch.AMD64}
 )
 
+// defaultAcceleratorType is the type of GPU attached to instances
+// when the gpus constraint is specified without gpu-type.
+const defaultAcceleratorType = "nvidia-tesla-k80"
+
+// allAcceleratorTypes holds the names of the GPU types that may be
+// attached to GCE instances. Not every type is available in every zone.
+var allAcceleratorTypes = []string{
+	"nvidia-tesla-k80",
+	"nvidia-tesla-p4",
+	"nvidia-tesla-p100",
+	"nvidia-tesla-t4",
+	"nvidia-tesla-v100",
+}
+
+const (
+	// lifecyclePreemptible is the instance-lifecycle constraint
+	// value requesting a preemptible instance. GCE may stop
+	// preemptible instances at any time, and always stops them
+	// after 24 hours.
+	lifecyclePreemptible = "preemptible"
+
+	// lifecycleSpot is the instance-lifecycle constraint value
+	// requesting a Spot VM. GCE may stop Spot VMs at any time, but
+	// they have no maximum running time.
+	lifecycleSpot = "spot"
+)
+
+// allInstanceLifecycles holds the values accepted for the
+// instance-lifecycle constraint.
+var allInstanceLifecycles = []string{
+	lifecyclePreemptible,
+	lifecycleSpot,
+}
+
+// knownInstanceTypes returns all of the instance types that
+// may be requested with the instance-type constraint.
+func knownInstanceTypes() []instances.InstanceType {
+	var result []instances.InstanceType
+	result = append(result, allInstanceTypes...)
+	return append(result, confidentialInstanceTypes...)
+}
+
+// supportsConfidentialVM reports whether the named
+// instance type supports Confidential VMs.
+func supportsConfidentialVM(instanceType string) bool {
+	for _, itype := range confidentialInstanceTypes {
+		if itype.Name == instanceType {
+			return true
+		}
+	}
+	return false
+}
+
+// The limits of GCE custom machine types, with memory in MiB.
+const (
+	customMaxCores      = 96
+	customMinMemPerCore = 922  // 0.9 GB
+	customMaxMemPerCore = 6656 // 6.5 GB
+	customMemStep       = 256
+
+	// customDefaultMinMem is the least memory given to a custom
+	// machine type when there is no mem constraint, matching the
+	// default used when choosing predefined machine types.
+	customDefaultMinMem = 1024
+)
+
+// customInstanceType returns the smallest GCE custom machine type
+// meeting the cores and mem constraints. It returns false if neither
+// is specified, or if no custom machine type can meet them.
+func customInstanceType(cons constraints.Value) (instances.InstanceType, bool) {
+	if !cons.HasCpuCores() && !cons.HasMem() {
+		return instances.InstanceType{}, false
+	}
+	if cons.HasInstanceType() || cons.HasCpuPower() {
+		return instances.InstanceType{}, false
+	}
+
+	cores := uint64(1)
+	if cons.HasCpuCores() {
+		cores = *cons.CpuCores
+	}
+	mem := uint64(customDefaultMinMem)
+	if cons.HasMem() {
+		mem = *cons.Mem
+		// Without extended memory, each core
+		// supports a limited amount of memory.
+		if minCores := (mem + customMaxMemPerCore - 1) / customMaxMemPerCore; minCores > cores {
+			cores = minCores
+		}
+	}
+	// Custom machine types have a single core,
+	// or an even number of cores.
+	if cores > 1 && cores%2 == 1 {
+		cores++
+	}
+	if cores > customMaxCores {
+		return instances.InstanceType{}, false
+	}
+	if minMem := cores * customMinMemPerCore; mem < minMem {
+		mem = minMem
+	}
+	mem = (mem + customMemStep - 1) / customMemStep * customMemStep
+
+	return instances.InstanceType{
+		Name:     fmt.Sprintf("custom-%d-%d", cores, mem),
+		Arches:   arches,
+		CpuCores: cores,
+		CpuPower: instances.CpuPower(275 * cores),
+		Mem:      mem,
+		VirtType: &vtype,
+	}, true
+}
+
 // Instance types are not associated with disks in GCE, so we do not
 // set RootDisk.
 
@@ -155,3 +271,46 @@ var allInstanceTypes = []instances.InstanceType{
 		VirtType: &vtype,
 	},
 }
+
+// confidentialInstanceTypes holds the instance types that support
+// Confidential VMs. They are only chosen for new instances when
+// Confidential VMs are requested, or when named by the instance-type
+// constraint.
+var confidentialInstanceTypes = []instances.InstanceType{
+	{ // N2D standard machine types
+		Name:     "n2d-standard-2",
+		Arches:   arches,
+		CpuCores: 2,
+		CpuPower: instances.CpuPower(550),
+		Mem:      8192,
+		VirtType: &vtype,
+	}, {
+		Name:     "n2d-standard-4",
+		Arches:   arches,
+		CpuCores: 4,
+		CpuPower: instances.CpuPower(1100),
+		Mem:      16384,
+		VirtType: &vtype,
+	}, {
+		Name:     "n2d-standard-8",
+		Arches:   arches,
+		CpuCores: 8,
+		CpuPower: instances.CpuPower(2200),
+		Mem:      32768,
+		VirtType: &vtype,
+	}, {
+		Name:     "n2d-standard-16",
+		Arches:   arches,
+		CpuCores: 16,
+		CpuPower: instances.CpuPower(4400),
+		Mem:      65536,
+		VirtType: &vtype,
+	}, {
+		Name:     "n2d-standard-32",
+		Arches:   arches,
+		CpuCores: 32,
+		CpuPower: instances.CpuPower(8800),
+		Mem:      131072,
+		VirtType: &vtype,
+	},
+}
@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/provider/gce"
+)
+
+type instanceTypesSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&instanceTypesSuite{})
+
+func (s *instanceTypesSuite) TestCustomInstanceType(c *gc.C) {
+	for i, test := range []struct {
+		cons  string
+		name  string
+		cores uint64
+		mem   uint64
+	}{{
+		cons:  "cores=6 mem=20G",
+		name:  "custom-6-20480",
+		cores: 6,
+		mem:   20480,
+	}, {
+		// Odd numbers of cores are rounded up.
+		cons:  "cores=5 mem=10000M",
+		name:  "custom-6-10240",
+		cores: 6,
+		mem:   10240,
+	}, {
+		// Memory is rounded up to a multiple of 256MiB,
+		// and to the minimum for the number of cores.
+		cons:  "cores=4 mem=1G",
+		name:  "custom-4-3840",
+		cores: 4,
+		mem:   3840,
+	}, {
+		// There is a limit to the memory for each core.
+		cons:  "mem=20G",
+		name:  "custom-4-20480",
+		cores: 4,
+		mem:   20480,
+	}, {
+		cons:  "cores=6",
+		name:  "custom-6-5632",
+		cores: 6,
+		mem:   5632,
+	}, {
+		cons:  "cores=1",
+		name:  "custom-1-1024",
+		cores: 1,
+		mem:   1024,
+	}} {
+		c.Logf("test %d: %s", i, test.cons)
+		itype, ok := gce.CustomInstanceType(constraints.MustParse(test.cons))
+		c.Assert(ok, jc.IsTrue)
+		c.Check(itype.Name, gc.Equals, test.name)
+		c.Check(itype.CpuCores, gc.Equals, test.cores)
+		c.Check(itype.Mem, gc.Equals, test.mem)
+	}
+}
+
+func (s *instanceTypesSuite) TestCustomInstanceTypeNotUsed(c *gc.C) {
+	for i, cons := range []string{
+		"",
+		"root-disk=10G",
+		"instance-type=n1-standard-1 mem=4G",
+		"cores=2 cpu-power=1000",
+		"cores=128",
+	} {
+		c.Logf("test %d: %s", i, cons)
+		_, ok := gce.CustomInstanceType(constraints.MustParse(cons))
+		c.Check(ok, jc.IsFalse)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"strings"
+)
+
+// labelMaxLength is the maximum length of the keys and
+// values of GCE labels.
+const labelMaxLength = 63
+
+// resourceTagsToLabels translates a set of resource tags, provided
+// by Juju, to GCE labels, which are used for instances and disks.
+// Label keys and values may only contain lowercase letters, digits,
+// underscores and dashes, so any other characters are replaced with
+// underscores. Label keys must also start with a letter; tags whose
+// keys do not are dropped.
+func resourceTagsToLabels(in map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range in {
+		key := toLabelValue(k)
+		if key == "" || key[0] < 'a' || key[0] > 'z' {
+			logger.Debugf("not labelling resource with tag %q", k)
+			continue
+		}
+		out[key] = toLabelValue(v)
+	}
+	return out
+}
+
+// toLabelValue converts the string to one that is
+// valid as a GCE label key or value.
+func toLabelValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, s)
+	if len(s) > labelMaxLength {
+		s = s[:labelMaxLength]
+	}
+	return s
+}
+
+// mergeLabels returns the existing labels updated with those given,
+// and whether that changed any of them.
+func mergeLabels(existing, labels map[string]string) (map[string]string, bool) {
+	merged := make(map[string]string)
+	for k, v := range existing {
+		merged[k] = v
+	}
+	changed := false
+	for k, v := range labels {
+		if current, ok := merged[k]; ok && current == v {
+			continue
+		}
+		merged[k] = v
+		changed = true
+	}
+	return merged, changed
+}
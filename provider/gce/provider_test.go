@@ -4,6 +4,8 @@
 package gce_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -46,6 +48,18 @@ func (s *providerSuite) TestOpen(c *gc.C) {
 	c.Assert(envConfig.Name(), gc.Equals, "testmodel")
 }
 
+func (s *providerSuite) TestOpenOperationTimeout(c *gc.C) {
+	cfg, err := s.Config.Apply(map[string]interface{}{"operation-timeout": "10m"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = environs.Open(s.provider, environs.OpenParams{
+		Cloud:  s.spec,
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.ConnectionConfig.OperationTimeout, gc.Equals, 10*time.Minute)
+}
+
 func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
 	s.spec.Name = ""
 	s.testOpenError(c, s.spec, `validating cloud spec: cloud name "" not valid`)
@@ -311,6 +311,10 @@ type BaseSuite struct {
 	FakeCommon  *fakeCommon
 	FakeEnviron *fakeEnviron
 
+	// ConnectionConfig holds the config of the last
+	// connection made by a new environ.
+	ConnectionConfig google.ConnectionConfig
+
 	CallCtx                *context.CloudCallContext
 	InvalidatedCredentials bool
 }
@@ -324,7 +328,8 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 
 	// Patch out all expensive external deps.
 	s.Env.gce = s.FakeConn
-	s.PatchValue(&newConnection, func(google.ConnectionConfig, *google.Credentials) (gceConnection, error) {
+	s.PatchValue(&newConnection, func(connCfg google.ConnectionConfig, _ *google.Credentials) (gceConnection, error) {
+		s.ConnectionConfig = connCfg
 		return s.FakeConn, nil
 	})
 	s.PatchValue(&bootstrap, s.FakeCommon.Bootstrap)
@@ -503,6 +508,9 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	Network          google.NetworkSpec
+	NumericID        uint64
+	SizeGB           uint64
 }
 
 type fakeConn struct {
@@ -515,10 +523,13 @@ type fakeConn struct {
 	Subnets   []*compute.Subnetwork
 	Networks_ []*compute.Network
 
-	GoogleDisks   []*google.Disk
-	GoogleDisk    *google.Disk
-	AttachedDisk  *google.AttachedDisk
-	AttachedDisks []*google.AttachedDisk
+	GoogleDisks       []*google.Disk
+	RegionGoogleDisks []*google.Disk
+	GoogleDisk        *google.Disk
+	AttachedDisk      *google.AttachedDisk
+	AttachedDisks     []*google.AttachedDisk
+	MachineTypes      []google.MachineType
+	Utilization       google.InstanceUtilization
 
 	Err        error
 	FailOnCall int
@@ -564,6 +575,23 @@ func (fc *fakeConn) AddInstance(spec google.InstanceSpec) (*google.Instance, err
 	return fc.Inst, fc.err()
 }
 
+func (fc *fakeConn) InstanceUtilization(numericID uint64) (google.InstanceUtilization, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:  "InstanceUtilization",
+		NumericID: numericID,
+	})
+	return fc.Utilization, fc.err()
+}
+
+func (fc *fakeConn) RestartInstance(id, zone string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RestartInstance",
+		ID:       id,
+		ZoneName: zone,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) RemoveInstances(prefix string, ids ...string) error {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "RemoveInstances",
@@ -583,6 +611,32 @@ func (fc *fakeConn) UpdateMetadata(key, value string, ids ...string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) UpdateLabels(labels map[string]string, ids ...string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "UpdateLabels",
+		Labels:   labels,
+		IDs:      ids,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) AddInstanceTags(tag string, ids ...string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "AddInstanceTags",
+		Value:    tag,
+		IDs:      ids,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RemoveFirewalls(prefix string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveFirewalls",
+		Prefix:   prefix,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "Ports",
@@ -632,6 +686,14 @@ func (fc *fakeConn) Networks() ([]*compute.Network, error) {
 	return fc.Networks_, fc.err()
 }
 
+func (fc *fakeConn) VerifyNetwork(spec google.NetworkSpec) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "VerifyNetwork",
+		Network:  spec,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) CreateDisks(zone string, disks []google.DiskSpec) ([]*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "CreateDisks",
@@ -677,6 +739,95 @@ func (fc *fakeConn) SetDiskLabels(zone, id, labelFingerprint string, labels map[
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, id string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ResizeDisk",
+		ZoneName: zone,
+		ID:       id,
+		SizeGB:   sizeGB,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CreateRegionDisks(region string, disks []google.DiskSpec) ([]*google.Disk, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "CreateRegionDisks",
+		Region:   region,
+		Disks:    disks,
+	})
+	return fc.GoogleDisks, fc.err()
+}
+
+func (fc *fakeConn) RegionDisks(region string) ([]*google.Disk, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RegionDisks",
+		Region:   region,
+	})
+	return fc.RegionGoogleDisks, fc.err()
+}
+
+func (fc *fakeConn) RemoveRegionDisk(region, id string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveRegionDisk",
+		Region:   region,
+		ID:       id,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RegionDisk(region, id string) (*google.Disk, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RegionDisk",
+		Region:   region,
+		ID:       id,
+	})
+	return fc.GoogleDisk, fc.err()
+}
+
+func (fc *fakeConn) SetRegionDiskLabels(region, id, labelFingerprint string, labels map[string]string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:         "SetRegionDiskLabels",
+		Region:           region,
+		ID:               id,
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) ResizeRegionDisk(region, id string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ResizeRegionDisk",
+		Region:   region,
+		ID:       id,
+		SizeGB:   sizeGB,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) AttachRegionDisk(zone, region, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "AttachRegionDisk",
+		ZoneName:   zone,
+		Region:     region,
+		VolumeName: volumeName,
+		InstanceId: instanceId,
+		Mode:       string(mode),
+	})
+	return fc.AttachedDisk, fc.err()
+}
+
+func (fc *fakeConn) DetachRegionDisk(zone, region, instanceId, volumeName string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "DetachRegionDisk",
+		ZoneName:   zone,
+		Region:     region,
+		InstanceId: instanceId,
+		VolumeName: volumeName,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:   "AttachDisk",
@@ -726,6 +877,9 @@ func (fc *fakeConn) ListMachineTypes(zone string) ([]google.MachineType, error)
 	}
 	fc.Calls = append(fc.Calls, call)
 
+	if fc.MachineTypes != nil {
+		return fc.MachineTypes, fc.err()
+	}
 	return []google.MachineType{
 		{Name: "type-1", MemoryMb: 1024},
 		{Name: "type-2", MemoryMb: 2048},
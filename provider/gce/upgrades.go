@@ -56,7 +56,7 @@ func (step diskLabelsUpgradeStep) Run(ctx context.ProviderCallContext) error {
 		}
 		disk.Labels[tags.JujuModel] = env.uuid
 		disk.Labels[tags.JujuController] = step.controllerUUID
-		if err := env.gce.SetDiskLabels(disk.Zone, disk.Name, disk.LabelFingerprint, disk.Labels); err != nil {
+		if err := connectionWithContext(env.gce, ctx).SetDiskLabels(disk.Zone, disk.Name, disk.LabelFingerprint, disk.Labels); err != nil {
 			return google.HandleCredentialError(errors.Annotatef(err, "cannot set labels on volume %q", disk.Name), ctx)
 		}
 	}
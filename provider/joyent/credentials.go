@@ -35,6 +35,7 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 				Description: "Private key used to sign requests",
 				Hidden:      true,
 				FileAttr:    "private-key-path",
+				MultiLine:   true,
 			},
 		}, {
 			credAttrAlgorithm, cloud.CredentialAttr{
@@ -4,6 +4,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -11,8 +13,29 @@ import (
 	"github.com/juju/juju/environs/config"
 )
 
+const (
+	// StoragePoolKey is the model config key for the name of the LXD
+	// storage pool on which the root disks of new containers are
+	// created. If empty, the root disk of the default profile is used.
+	StoragePoolKey = "lxd-storage-pool"
+
+	// NetworkKey is the model config key for the name of the LXD
+	// network or host bridge to which new containers are connected.
+	// If empty, the NICs of the default profile are used.
+	NetworkKey = "lxd-network"
+)
+
 var (
-	configSchema                 = environschema.Fields{}
+	configSchema = environschema.Fields{
+		StoragePoolKey: {
+			Description: "The LXD storage pool on which to create the root disks of new containers. Defaults to the root disk of the default profile.",
+			Type:        environschema.Tstring,
+		},
+		NetworkKey: {
+			Description: "The LXD network or host bridge to which new containers are connected. Defaults to the NICs of the default profile.",
+			Type:        environschema.Tstring,
+		},
+	}
 	configFields, configDefaults = func() (schema.Fields, schema.Defaults) {
 		fields, defaults, err := configSchema.ValidationSchema()
 		if err != nil {
@@ -62,6 +85,25 @@ func (c *environConfig) validate() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// There are currently no known extra fields for LXD
+	for _, key := range []string{StoragePoolKey, NetworkKey} {
+		value, _ := c.attrs[key].(string)
+		if strings.ContainsAny(value, "/ \t\n") {
+			return errors.NotValidf("%s %q", key, value)
+		}
+	}
 	return nil
 }
+
+// storagePool returns the name of the LXD storage pool for the root
+// disks of new containers, or "" if the default profile's is used.
+func (c *environConfig) storagePool() string {
+	value, _ := c.attrs[StoragePoolKey].(string)
+	return value
+}
+
+// network returns the name of the LXD network to which new containers
+// are connected, or "" if the default profile's NICs are used.
+func (c *environConfig) network() string {
+	value, _ := c.attrs[NetworkKey].(string)
+	return value
+}
@@ -130,6 +130,10 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "storage pool and network can be set",
+	insert: testing.Attrs{"lxd-storage-pool": "zfs", "lxd-network": "lxdbr1"},
+	expect: testing.Attrs{"lxd-storage-pool": "zfs", "lxd-network": "lxdbr1"},
 }}
 
 func (s *configSuite) TestNewModelConfig(c *gc.C) {
@@ -261,6 +265,15 @@ func (s *configSuite) TestSetConfig(c *gc.C) {
 	}
 }
 
+func (s *configSuite) TestValidateInvalidNames(c *gc.C) {
+	for _, key := range []string{"lxd-storage-pool", "lxd-network"} {
+		cfg, err := s.config.Apply(testing.Attrs{key: "not/valid"})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.provider.Validate(cfg, nil)
+		c.Check(err, gc.ErrorMatches, `invalid base config: `+key+` "not/valid" not valid`)
+	}
+}
+
 func (s *configSuite) TestSchema(c *gc.C) {
 	fields := s.provider.(interface {
 		Schema() environschema.Fields
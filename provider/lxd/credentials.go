@@ -388,6 +388,7 @@ func (p environProviderCredentials) finalizeRemoteCredential(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	defer closeServer(server)
 
 	clientX509Cert, err := clientCert.X509()
 	if err != nil {
@@ -435,19 +436,20 @@ func (p environProviderCredentials) finalizeRemoteCredential(
 	attributes[credAttrServerCert] = lxdServerCert
 
 	secureCreds := cloud.NewCredential(cloud.CertificateAuthType, attributes)
-	server, err = p.serverFactory.RemoteServer(environs.CloudSpec{
+	secureServer, err := p.serverFactory.RemoteServer(environs.CloudSpec{
 		Endpoint:   endpoint,
 		Credential: &secureCreds,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	defer closeServer(secureServer)
 
 	// Store the server's certificate in the credential.
 	out := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
 		credAttrClientCert: string(clientCert.CertPEM),
 		credAttrClientKey:  string(clientCert.KeyPEM),
-		credAttrServerCert: server.ServerCertificate(),
+		credAttrServerCert: secureServer.ServerCertificate(),
 	})
 	out.Label = credentials.Label
 	return &out, nil
@@ -591,11 +593,11 @@ func endpointURL(endpoint string) (*url.URL, error) {
 		}
 	} else {
 		// If the user specifies an endpoint, it must be either
-		// host:port, or https://host:port. We do not support
-		// unix:// endpoints at present.
-		if remoteURL.Scheme != "https" {
+		// host:port, https://host:port or ssh://[user@]host[:port].
+		// We do not support unix:// endpoints at present.
+		if remoteURL.Scheme != "https" && remoteURL.Scheme != "ssh" {
 			return nil, errors.Errorf(
-				"invalid URL %q: only HTTPS is supported",
+				"invalid URL %q: only HTTPS and SSH are supported",
 				endpoint,
 			)
 		}
@@ -31,7 +31,6 @@ type baseProvider interface {
 }
 
 type environ struct {
-	cloud    environs.CloudSpec
 	provider *environProvider
 
 	name string
@@ -43,11 +42,15 @@ type environ struct {
 
 	// lock protects the *Unlocked fields below.
 	lock           sync.Mutex
+	cloudUnlocked  environs.CloudSpec
 	ecfgUnlocked   *environConfig
 	serverUnlocked Server
 
 	// profileMutex is used when writing profiles via the server.
 	profileMutex sync.Mutex
+
+	// health records the health of the remote LXD server.
+	health remoteHealth
 }
 
 func newEnviron(
@@ -67,7 +70,6 @@ func newEnviron(
 
 	env := &environ{
 		provider:     p,
-		cloud:        spec,
 		name:         ecfg.Name(),
 		uuid:         ecfg.UUID(),
 		namespace:    namespace,
@@ -137,10 +139,21 @@ func (env *environ) SetCloudSpec(spec environs.CloudSpec) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if env.serverUnlocked != nil && env.serverUnlocked != server {
+		closeServer(env.serverUnlocked)
+	}
 	env.serverUnlocked = server
+	env.cloudUnlocked = spec
 	return env.initProfile()
 }
 
+func (env *environ) cloud() environs.CloudSpec {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	return env.cloudUnlocked
+}
+
 func (env *environ) server() Server {
 	env.lock.Lock()
 	defer env.lock.Unlock()
@@ -157,13 +170,41 @@ func (env *environ) Config() *config.Config {
 	return cfg
 }
 
+func (env *environ) ecfg() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	return env.ecfgUnlocked
+}
+
 // PrepareForBootstrap implements environs.Environ.
 func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
-	return nil
+	return errors.Trace(env.verifyModelResources())
 }
 
 // Create implements environs.Environ.
 func (env *environ) Create(context.ProviderCallContext, environs.CreateParams) error {
+	return errors.Trace(env.verifyModelResources())
+}
+
+// verifyModelResources ensures that the storage pool and network
+// named in the model config, if any, exist on the LXD server.
+func (env *environ) verifyModelResources() error {
+	ecfg := env.ecfg()
+	svr := env.server()
+	if pool := ecfg.storagePool(); pool != "" {
+		if !svr.StorageSupported() {
+			return errors.NotSupportedf("%s %q: LXD storage API", StoragePoolKey, pool)
+		}
+		if _, _, err := svr.GetStoragePool(pool); err != nil {
+			return errors.Annotatef(err, "getting %s %q", StoragePoolKey, pool)
+		}
+	}
+	if netName := ecfg.network(); netName != "" {
+		if _, _, err := svr.GetNetwork(netName); err != nil {
+			return errors.Annotatef(err, "getting %s %q", NetworkKey, netName)
+		}
+	}
 	return nil
 }
 
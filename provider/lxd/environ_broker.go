@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/tools"
 )
@@ -36,6 +37,16 @@ func (env *environ) StartInstance(
 	series := args.Tools.OneSeries()
 	logger.Debugf("StartInstance: %q, %s", args.InstanceConfig.MachineId, series)
 
+	// Fail early, with a provisioning status that says why, rather
+	// than when creating the container against an unhealthy remote.
+	if err := env.checkRemoteHealth(remoteHealthMaxAge); err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.ProvisioningError, fmt.Sprintf("LXD remote unhealthy: %v", err), nil)
+		}
+		return nil, errors.Annotate(err, "LXD remote unhealthy")
+	}
+
 	arch, err := env.finishInstanceConfig(args)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -120,7 +131,12 @@ func (env *environ) newContainer(
 		return nil, errors.Trace(err)
 	}
 
-	image, err := target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	var image lxd.SourcedImage
+	if imageID, ok := env.Config().ImageId(args.InstanceConfig.Series, arch); ok {
+		image, err = target.FindImageByID(imageID, imageSources, true, statusCallback)
+	} else {
+		image, err = target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -185,7 +201,6 @@ func (env *environ) getContainerSpec(
 		Image:    image,
 		Config:   make(map[string]string),
 	}
-	cSpec.ApplyConstraints(serverVersion, args.Constraints)
 
 	cloudCfg, err := cloudinit.New(args.InstanceConfig.Series)
 	if err != nil {
@@ -199,7 +214,13 @@ func (env *environ) getContainerSpec(
 	if err != nil {
 		return cSpec, errors.Trace(err)
 	}
-	if !(len(nics) == 1 && nics["eth0"] != nil) {
+
+	ecfg := env.ecfg()
+	if netName := ecfg.network(); netName != "" {
+		// Connect the container to the model's network via a single
+		// eth0 device, masking any NICs inherited from the profile.
+		cSpec.Devices = modelNetworkDevices(nics, netName)
+	} else if !(len(nics) == 1 && nics["eth0"] != nil) {
 		logger.Debugf("generating custom cloud-init networking")
 
 		cSpec.Config[lxd.NetworkConfigKey] = cloudinit.CloudInitNetworkConfigDisabled
@@ -215,6 +236,21 @@ func (env *environ) getContainerSpec(
 		cSpec.Devices = nics
 	}
 
+	if pool := ecfg.storagePool(); pool != "" {
+		if cSpec.Devices == nil {
+			cSpec.Devices = make(map[string]map[string]string)
+		}
+		cSpec.Devices[rootDiskDevice] = map[string]string{
+			"type": "disk",
+			"path": "/",
+			"pool": pool,
+		}
+	}
+
+	// Constraints are applied once the network and storage devices are
+	// in place, so that GPU devices are added alongside them.
+	cSpec.ApplyConstraints(serverVersion, args.Constraints)
+
 	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudCfg, lxdRenderer{})
 	if err != nil {
 		return cSpec, errors.Annotate(err, "composing user data")
@@ -242,6 +278,28 @@ func (env *environ) getContainerSpec(
 	return cSpec, nil
 }
 
+// rootDiskDevice is the name of the device holding a container's
+// root file system.
+const rootDiskDevice = "root"
+
+// modelNetworkDevices returns container devices that connect eth0 to
+// the input network, and disable every other NIC in the input profile
+// devices so that they are not inherited by the container.
+func modelNetworkDevices(profileNICs map[string]map[string]string, netName string) map[string]map[string]string {
+	devices := make(map[string]map[string]string)
+	for name := range profileNICs {
+		devices[name] = map[string]string{"type": "none"}
+	}
+	devices["eth0"] = map[string]string{
+		"type":    "nic",
+		"nictype": "bridged",
+		"parent":  netName,
+		"name":    "eth0",
+		"hwaddr":  network.GenerateVirtualMACAddress(),
+	}
+	return devices
+}
+
 // getTargetServer checks to see if a valid zone was passed as a placement
 // directive in the start-up start-up arguments. If so, a server for the
 // specific node is returned.
@@ -304,11 +362,19 @@ func (env *environ) getHardwareCharacteristics(
 	}
 	cores := uint64(container.CPUs())
 	mem := uint64(container.Mem())
-	return &instance.HardwareCharacteristics{
+	hwc := &instance.HardwareCharacteristics{
 		Arch:     &archStr,
 		CpuCores: &cores,
 		Mem:      &mem,
 	}
+	if gpus := uint64(container.GPUs()); gpus > 0 {
+		hwc.GPUs = &gpus
+		if args.Constraints.HasGPUType() {
+			gpuType := *args.Constraints.GPUType
+			hwc.GPUType = &gpuType
+		}
+	}
+	return hwc
 }
 
 // AllInstances implements environs.InstanceBroker.
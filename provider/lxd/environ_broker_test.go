@@ -102,6 +102,28 @@ func (s *environBrokerSuite) TestStartInstanceDefaultNIC(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstancePinnedImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindImageByID("golden-bionic", gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
+		exp.CreateContainerFromSpec(gomock.Any()).Return(&containerlxd.Container{}, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	env := s.NewEnviron(c, svr, map[string]interface{}{
+		"image-ids": "bionic/amd64=golden-bionic",
+	})
+	_, err := env.StartInstance(s.callCtx, s.GetStartInstanceArgs(c, "bionic"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *environBrokerSuite) TestStartInstanceNonDefaultNIC(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -141,6 +163,54 @@ func (s *environBrokerSuite) TestStartInstanceNonDefaultNIC(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstanceModelStoragePoolAndNetwork(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	nics := map[string]map[string]string{
+		"eth0": {"nictype": "bridged", "parent": "lxdbr0"},
+		"eth1": {"nictype": "bridged", "parent": "lxdbr0"},
+	}
+
+	// Check that eth0 is on the model's network, that the other profile
+	// NIC is masked, and that the root disk is on the model's pool.
+	check := func(spec containerlxd.ContainerSpec) bool {
+		if spec.Config[containerlxd.NetworkConfigKey] != "" {
+			return false
+		}
+		eth0 := spec.Devices["eth0"]
+		if eth0["parent"] != "lxdbr1" || eth0["nictype"] != "bridged" || eth0["hwaddr"] == "" {
+			return false
+		}
+		if spec.Devices["eth1"]["type"] != "none" {
+			return false
+		}
+		return reflect.DeepEqual(spec.Devices["root"], map[string]string{
+			"type": "disk",
+			"path": "/",
+			"pool": "zfs",
+		})
+	}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.GetNICsFromProfile("default").Return(nics, nil),
+		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	env := s.NewEnviron(c, svr, map[string]interface{}{
+		"lxd-storage-pool": "zfs",
+		"lxd-network":      "lxdbr1",
+	})
+	_, err := env.StartInstance(s.callCtx, s.GetStartInstanceArgs(c, "bionic"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithPlacementAvailable(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -313,6 +383,46 @@ func (s *environBrokerSuite) TestStartInstanceWithConstraints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstanceWithGPUConstraints(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	gpus := map[string]map[string]string{
+		"gpu0": {"type": "gpu", "id": "0", "vendorid": "10de"},
+		"gpu1": {"type": "gpu", "id": "1", "vendorid": "10de"},
+	}
+
+	// Check that a GPU device was added for each requested GPU.
+	check := func(spec containerlxd.ContainerSpec) bool {
+		return reflect.DeepEqual(spec.Devices, gpus)
+	}
+
+	container := &containerlxd.Container{}
+	container.Devices = gpus
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindImage("bionic", arch.AMD64, gomock.Any(), true, gomock.Any()).Return(containerlxd.SourcedImage{}, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.GetNICsFromProfile("default").Return(s.defaultProfile.Devices, nil),
+		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(container, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.Constraints = constraints.MustParse("gpus=2 gpu-type=nvidia")
+
+	env := s.NewEnviron(c, svr, nil)
+	result, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Hardware.GPUs, gc.NotNil)
+	c.Check(*result.Hardware.GPUs, gc.Equals, uint64(2))
+	c.Assert(result.Hardware.GPUType, gc.NotNil)
+	c.Check(*result.Hardware.GPUType, gc.Equals, "nvidia")
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithCharmLXDProfile(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
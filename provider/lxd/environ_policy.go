@@ -23,6 +23,7 @@ var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.Container,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -32,6 +33,7 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 
 	validator.RegisterUnsupported(unsupportedConstraints)
 	validator.RegisterVocabulary(constraints.Arch, []string{env.server().HostArch()})
+	validator.RegisterVocabulary(constraints.GPUType, lxd.GPUTypes())
 
 	return validator, nil
 }
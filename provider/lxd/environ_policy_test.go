@@ -171,6 +171,7 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cores=2",
 		"cpu-power=250",
 		"virt-type=kvm",
+		"instance-lifecycle=spot",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -179,6 +180,7 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"tags",
 		"cpu-power",
 		"virt-type",
+		"instance-lifecycle",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
@@ -221,6 +223,25 @@ func (s *environPolicySuite) TestConstraintsValidatorVocabArchUnknown(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are: \\[amd64\\]")
 }
 
+func (s *environPolicySuite) TestConstraintsValidatorVocabGPUTypeUnknown(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+
+	exp := svr.EXPECT()
+	exp.HostArch().Return(arch.AMD64)
+
+	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("gpus=1 gpu-type=voodoo")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: gpu-type=voodoo\nvalid values are: \\[amd intel nvidia\\]")
+}
+
 func (s *environPolicySuite) TestConstraintsValidatorVocabContainerUnknown(c *gc.C) {
 	c.Skip("this will fail until we add a container vocabulary")
 	ctrl := gomock.NewController(c)
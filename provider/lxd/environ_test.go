@@ -519,3 +519,67 @@ func (s *environProfileSuite) expectAssignLXDProfiles(instId, old, new string, o
 	}
 	exp.GetContainerProfiles(instId).Return(newProfiles, nil)
 }
+
+type environModelResourcesSuite struct {
+	lxd.EnvironSuite
+}
+
+var _ = gc.Suite(&environModelResourcesSuite{})
+
+func (s *environModelResourcesSuite) TestCreateNoResources(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+	err := env.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environModelResourcesSuite) TestCreateVerifiesResources(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.StorageSupported().Return(true),
+		exp.GetStoragePool("zfs").Return(&api.StoragePool{}, "", nil),
+		exp.GetNetwork("lxdbr1").Return(&api.Network{}, "", nil),
+	)
+
+	env := s.NewEnviron(c, svr, map[string]interface{}{
+		"lxd-storage-pool": "zfs",
+		"lxd-network":      "lxdbr1",
+	})
+	err := env.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environModelResourcesSuite) TestCreateStorageNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	svr.EXPECT().StorageSupported().Return(false)
+
+	env := s.NewEnviron(c, svr, map[string]interface{}{
+		"lxd-storage-pool": "zfs",
+	})
+	err := env.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `lxd-storage-pool "zfs": LXD storage API not supported`)
+}
+
+func (s *environModelResourcesSuite) TestCreateNetworkNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	svr.EXPECT().GetNetwork("lxdbr1").Return(nil, "", errors.New("not found"))
+
+	env := s.NewEnviron(c, svr, map[string]interface{}{
+		"lxd-network": "lxdbr1",
+	})
+	err := env.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `getting lxd-network "lxdbr1": not found`)
+}
@@ -16,6 +16,7 @@ var (
 	NewInstance           = newInstance
 	GetCertificates       = getCertificates
 	IsSupportedAPIVersion = isSupportedAPIVersion
+	LookupHost            = &lookupHost
 )
 
 func NewProviderWithMocks(
@@ -84,3 +85,7 @@ func GetImageSources(env environs.Environ) ([]lxd.ServerSpec, error) {
 	}
 	return lxdEnv.getImageSources()
 }
+
+func CheckRemoteHealth(env environs.Environ) error {
+	return env.(*environ).checkRemoteHealth(remoteHealthMaxAge)
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+)
+
+// remoteHealthMaxAge is the period for which the result of a health
+// check of a remote LXD server is trusted when starting an instance.
+// The environ tracker checks the health of its environ more often
+// than this, so the server is only probed when an instance is started
+// if its health has not been checked periodically.
+const remoteHealthMaxAge = 5 * time.Minute
+
+// lookupHost is used to resolve the host name of a remote LXD server.
+// It is a variable so that it may be replaced in tests.
+var lookupHost = net.LookupHost
+
+// remoteHealth records the result of the most recent health check of
+// the remote LXD server that an environ is connected to.
+type remoteHealth struct {
+	mu sync.Mutex
+
+	// checked is the time of the last health check.
+	checked time.Time
+
+	// addrs holds the sorted addresses that the server's host
+	// name resolved to at the last health check.
+	addrs []string
+
+	// err holds the reason the server was found to be unhealthy,
+	// or nil if it was healthy.
+	err error
+}
+
+func (env *environ) clock() clock.Clock {
+	if env.provider != nil && env.provider.Clock != nil {
+		return env.provider.Clock
+	}
+	return clock.WallClock
+}
+
+// CheckHealth is part of the environs.HealthChecker interface. It
+// probes the remote LXD server that the environ is connected to, and
+// records the result for use when instances are started.
+func (env *environ) CheckHealth() error {
+	return env.checkRemoteHealth(0)
+}
+
+// checkRemoteHealth returns an error if the remote LXD server that the
+// environ is connected to is unhealthy. The server's host name is
+// re-resolved, and if its addresses have changed the environ reconnects
+// to the server. The server certificate from the credential is checked
+// for expiry and compared with the certificate that the server presents.
+// The result of the last check is returned instead if it is younger
+// than maxAge. Local servers are always considered healthy.
+func (env *environ) checkRemoteHealth(maxAge time.Duration) error {
+	spec := env.cloud()
+	endpoint := spec.Endpoint
+	if endpoint == "" {
+		return nil
+	}
+
+	health := &env.health
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	now := env.clock().Now()
+	if !health.checked.IsZero() && now.Sub(health.checked) < maxAge {
+		return health.err
+	}
+	health.checked = now
+	health.err = env.probeRemote(health, spec, now)
+	if health.err != nil {
+		logger.Warningf("LXD remote %q is unhealthy: %v", endpoint, health.err)
+	}
+	return health.err
+}
+
+func (env *environ) probeRemote(health *remoteHealth, spec environs.CloudSpec, now time.Time) error {
+	host := endpointHost(spec.Endpoint)
+	if net.ParseIP(host) == nil {
+		addrs, err := lookupHost(host)
+		if err != nil {
+			return errors.Annotatef(err, "resolving LXD remote %q", host)
+		}
+		sort.Strings(addrs)
+		changed := health.addrs != nil && strings.Join(addrs, ",") != strings.Join(health.addrs, ",")
+		health.addrs = addrs
+		if changed {
+			logger.Infof("LXD remote %q now resolves to %v, reconnecting", host, addrs)
+			if err := env.reconnect(spec); err != nil {
+				return errors.Annotatef(err, "reconnecting to LXD remote %q", host)
+			}
+		}
+	}
+
+	var serverCert string
+	if spec.Credential != nil {
+		if _, cert, ok := getCertificates(*spec.Credential); ok {
+			serverCert = cert
+		}
+	}
+	if serverCert != "" {
+		if err := checkCertificateValidity(serverCert, now); err != nil {
+			return errors.Annotatef(err, "server certificate for LXD remote %q", host)
+		}
+	}
+
+	info, _, err := env.server().GetServer()
+	if err != nil {
+		return errors.Annotatef(err, "contacting LXD remote %q", host)
+	}
+	presented := strings.TrimSpace(info.Environment.Certificate)
+	if serverCert != "" && presented != "" && presented != strings.TrimSpace(serverCert) {
+		return errors.Errorf("LXD remote %q presented an unexpected server certificate", host)
+	}
+	return nil
+}
+
+// reconnect replaces the environ's connection to the remote LXD server
+// with a new one, so that the server's host name is resolved afresh.
+// The connection is left alone if the cloud spec has been replaced
+// since it was probed, as SetCloudSpec has already reconnected.
+func (env *environ) reconnect(spec environs.CloudSpec) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.cloudUnlocked.Endpoint != spec.Endpoint || env.cloudUnlocked.Credential != spec.Credential {
+		return nil
+	}
+	server, err := env.provider.serverFactory.RemoteServer(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if env.serverUnlocked != nil && env.serverUnlocked != server {
+		closeServer(env.serverUnlocked)
+	}
+	env.serverUnlocked = server
+	return nil
+}
+
+// endpointHost returns the host name or address from a LXD endpoint,
+// which may be a URL or a bare host with an optional port.
+func endpointHost(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return strings.Trim(endpoint, "[]")
+}
+
+// checkCertificateValidity returns an error if the PEM encoded
+// certificate cannot be parsed, or is not valid at the given time.
+func checkCertificateValidity(certPEM string, now time.Time) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return errors.NotValidf("certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Annotate(err, "parsing certificate")
+	}
+	if now.After(cert.NotAfter) {
+		return errors.Errorf("expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Before(cert.NotBefore) {
+		return errors.Errorf("not valid until %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
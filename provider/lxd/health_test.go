@@ -0,0 +1,215 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/lxd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type remoteHealthSuite struct {
+	lxd.EnvironSuite
+
+	clock   *testclock.Clock
+	addrs   []string
+	lookups int
+}
+
+var _ = gc.Suite(&remoteHealthSuite{})
+
+func (s *remoteHealthSuite) SetUpTest(c *gc.C) {
+	s.EnvironSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.addrs = []string{"10.0.0.1"}
+	s.lookups = 0
+	s.PatchValue(lxd.LookupHost, func(host string) ([]string, error) {
+		c.Check(host, gc.Equals, "lxd.example.com")
+		s.lookups++
+		if s.addrs == nil {
+			return nil, errors.New("no such host")
+		}
+		return s.addrs, nil
+	})
+}
+
+func serverWithCert(cert string) *api.Server {
+	return &api.Server{
+		Environment: api.ServerEnvironment{Certificate: cert},
+	}
+}
+
+func (s *remoteHealthSuite) TestLocalServerAlwaysHealthy(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+	c.Assert(lxd.CheckRemoteHealth(env), jc.ErrorIsNil)
+	c.Assert(s.lookups, gc.Equals, 0)
+}
+
+func (s *remoteHealthSuite) TestHealthyRemoteCheckedPeriodically(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+
+	svr.EXPECT().GetServer().Return(serverWithCert(coretesting.ServerCert), "", nil).Times(3)
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	c.Assert(lxd.CheckRemoteHealth(env), jc.ErrorIsNil)
+
+	// The result is reused when starting instances...
+	c.Assert(lxd.CheckRemoteHealth(env), jc.ErrorIsNil)
+	c.Assert(s.lookups, gc.Equals, 1)
+
+	// ...but the periodic check always probes the server.
+	c.Assert(env.(environs.HealthChecker).CheckHealth(), jc.ErrorIsNil)
+	c.Assert(s.lookups, gc.Equals, 2)
+
+	// A result that has not been refreshed is not trusted for long.
+	s.clock.Advance(5 * time.Minute)
+	c.Assert(lxd.CheckRemoteHealth(env), jc.ErrorIsNil)
+	c.Assert(s.lookups, gc.Equals, 3)
+}
+
+func (s *remoteHealthSuite) TestResolveFailure(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+	s.addrs = nil
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "lxd.example.com:8443")
+	err := lxd.CheckRemoteHealth(env)
+	c.Assert(err, gc.ErrorMatches, `resolving LXD remote "lxd.example.com": no such host`)
+
+	// The failure is remembered until the next check.
+	err = lxd.CheckRemoteHealth(env)
+	c.Assert(err, gc.ErrorMatches, `resolving LXD remote "lxd.example.com": no such host`)
+	c.Assert(s.lookups, gc.Equals, 1)
+}
+
+func (s *remoteHealthSuite) TestReconnectWhenAddressesChange(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	newSvr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+
+	svr.EXPECT().GetServer().Return(serverWithCert(coretesting.ServerCert), "", nil)
+	factory.EXPECT().RemoteServer(gomock.Any()).DoAndReturn(func(spec environs.CloudSpec) (lxd.Server, error) {
+		c.Check(spec.Endpoint, gc.Equals, "https://lxd.example.com:8443")
+		return newSvr, nil
+	})
+	newSvr.EXPECT().GetServer().Return(serverWithCert(coretesting.ServerCert), "", nil)
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	c.Assert(lxd.CheckRemoteHealth(env), jc.ErrorIsNil)
+
+	// The new connection is used to contact the server.
+	s.addrs = []string{"10.0.0.2"}
+	c.Assert(env.(environs.HealthChecker).CheckHealth(), jc.ErrorIsNil)
+}
+
+func (s *remoteHealthSuite) TestExpiredCertificate(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+
+	s.clock.Advance(11 * 365 * 24 * time.Hour)
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	err := lxd.CheckRemoteHealth(env)
+	c.Assert(err, gc.ErrorMatches, `server certificate for LXD remote "lxd.example.com": expired at .*`)
+}
+
+func (s *remoteHealthSuite) TestUnexpectedCertificate(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+
+	svr.EXPECT().GetServer().Return(serverWithCert(coretesting.CACert), "", nil)
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	err := lxd.CheckRemoteHealth(env)
+	c.Assert(err, gc.ErrorMatches, `LXD remote "lxd.example.com" presented an unexpected server certificate`)
+}
+
+func (s *remoteHealthSuite) TestRotatedCredentialUsed(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	newSvr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+
+	// The server's certificate is replaced along with the credential.
+	svr.EXPECT().GetServer().Return(serverWithCert(coretesting.OtherCACert), "", nil)
+	factory.EXPECT().RemoteServer(gomock.Any()).DoAndReturn(func(spec environs.CloudSpec) (lxd.Server, error) {
+		c.Check(spec.Credential.Attributes()["server-cert"], gc.Equals, coretesting.OtherCACert)
+		return newSvr, nil
+	})
+	newSvr.EXPECT().HasProfile(gomock.Any()).Return(true, nil)
+	newSvr.EXPECT().GetServer().Return(serverWithCert(coretesting.OtherCACert), "", nil)
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	err := env.(environs.HealthChecker).CheckHealth()
+	c.Assert(err, gc.ErrorMatches, `LXD remote "lxd.example.com" presented an unexpected server certificate`)
+
+	cred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": coretesting.CACert,
+		"client-key":  coretesting.CAKey,
+		"server-cert": coretesting.OtherCACert,
+	})
+	err = env.(environs.CloudSpecSetter).SetCloudSpec(environs.CloudSpec{
+		Name:       "remote",
+		Type:       "lxd",
+		Endpoint:   "https://lxd.example.com:8443",
+		Credential: &cred,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The new credential is used by the next check, which contacts
+	// the server with the new connection.
+	c.Assert(env.(environs.HealthChecker).CheckHealth(), jc.ErrorIsNil)
+}
+
+func (s *remoteHealthSuite) TestStartInstanceReportsUnhealthyRemote(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+	factory := lxd.NewMockServerFactory(ctrl)
+	s.addrs = nil
+
+	var reported []status.Status
+	var messages []string
+	args := s.GetStartInstanceArgs(c, "bionic")
+	args.StatusCallback = func(st status.Status, info string, data map[string]interface{}) error {
+		reported = append(reported, st)
+		messages = append(messages, info)
+		return nil
+	}
+
+	env := s.NewRemoteEnviron(c, svr, factory, s.clock, "https://lxd.example.com:8443")
+	_, err := env.(environs.InstanceBroker).StartInstance(context.NewCloudCallContext(), args)
+	c.Assert(err, gc.ErrorMatches, `LXD remote unhealthy: resolving LXD remote "lxd.example.com": no such host`)
+	c.Assert(reported, jc.DeepEquals, []status.Status{status.ProvisioningError})
+	c.Assert(messages, jc.DeepEquals, []string{
+		`LXD remote unhealthy: resolving LXD remote "lxd.example.com": no such host`,
+	})
+}
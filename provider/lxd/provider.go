@@ -4,6 +4,7 @@
 package lxd
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -133,7 +134,7 @@ func NewProvider() environs.CloudEnvironProvider {
 
 // Version is part of the EnvironProvider interface.
 func (*environProvider) Version() int {
-	return 0
+	return providerVersion1
 }
 
 // Open implements environs.EnvironProvider.
@@ -162,25 +163,32 @@ func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string)
 		return nil
 	}
 
-	// Ensure the Port on the Host, if we get an error it is reasonable to
-	// assume that the address in the spec is invalid.
-	lxdEndpoint, err := lxd.EnsureHostPort(endpoint)
-	if err != nil {
-		return errors.Trace(err)
-	}
+	lxdEndpoint := endpoint
+	if !lxd.IsSSHRemote(endpoint) {
+		// Ensure the Port on the Host, if we get an error it is reasonable to
+		// assume that the address in the spec is invalid.
+		var err error
+		lxdEndpoint, err = lxd.EnsureHostPort(endpoint)
+		if err != nil {
+			return errors.Trace(err)
+		}
 
-	// Make sure we have an https url
-	if lxdEndpoint != endpoint {
-		return errors.Errorf("invalid URL %q: only HTTPS is supported", endpoint)
+		// Make sure we have an https url
+		if lxdEndpoint != endpoint {
+			return errors.Errorf("invalid URL %q: only HTTPS and SSH are supported", endpoint)
+		}
 	}
 
 	// Connect to the remote server anonymously so we can just verify it exists
 	// as we're not sure that the certificates are loaded in time for when the
 	// ping occurs i.e. interactive add-cloud
-	_, err = lxd.ConnectRemote(lxd.NewInsecureServerSpec(lxdEndpoint))
+	svr, err := lxd.ConnectRemote(lxd.NewInsecureServerSpec(lxdEndpoint))
 	if err != nil {
 		return errors.Errorf("no lxd server running at %s", lxdEndpoint)
 	}
+	if closer, ok := svr.(io.Closer); ok {
+		_ = closer.Close()
+	}
 	return nil
 }
 
@@ -494,7 +494,7 @@ func (s *providerSuite) TestPingFailWithHTTP(c *gc.C) {
 	p, err := environs.Provider("lxd")
 	c.Assert(err, jc.ErrorIsNil)
 	err = p.Ping(context.NewCloudCallContext(), server.URL)
-	c.Assert(err, gc.ErrorMatches, "invalid URL \""+server.URL+"\": only HTTPS is supported")
+	c.Assert(err, gc.ErrorMatches, "invalid URL \""+server.URL+"\": only HTTPS and SSH are supported")
 }
 
 type ProviderFunctionalSuite struct {
@@ -543,7 +543,18 @@ func (s *ProviderFunctionalSuite) TestPrepareConfigUnsupportedEndpointScheme(c *
 		Cloud:  cloudSpec,
 		Config: s.Config,
 	})
-	c.Assert(err, gc.ErrorMatches, `validating cloud spec: invalid URL "unix://foo": only HTTPS is supported`)
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: invalid URL "unix://foo": only HTTPS and SSH are supported`)
+}
+
+func (s *ProviderFunctionalSuite) TestPrepareConfigSSHEndpoint(c *gc.C) {
+	cloudSpec := lxdCloudSpec()
+	cloudSpec.Endpoint = "ssh://ubuntu@somewhere"
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Cloud:  cloudSpec,
+		Config: s.Config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
 }
 
 func (s *ProviderFunctionalSuite) TestPrepareConfigUnsupportedAuthType(c *gc.C) {
@@ -5,6 +5,7 @@ package lxd
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"os"
@@ -33,6 +34,7 @@ import (
 //go:generate mockgen -package lxd -destination server_mock_test.go github.com/juju/juju/provider/lxd Server,ServerFactory,InterfaceAddress
 type Server interface {
 	FindImage(string, string, []lxd.ServerSpec, bool, environs.StatusCallbackFunc) (lxd.SourcedImage, error)
+	FindImageByID(string, []lxd.ServerSpec, bool, environs.StatusCallbackFunc) (lxd.SourcedImage, error)
 	GetServer() (server *lxdapi.Server, ETag string, err error)
 	ServerVersion() string
 	GetConnectionInfo() (info *lxdclient.ConnectionInfo, err error)
@@ -73,12 +75,25 @@ type Server interface {
 	HostArch() string
 	EnableHTTPSListener() error
 	GetNICsFromProfile(profName string) (map[string]map[string]string, error)
+	GetNetwork(name string) (network *lxdapi.Network, ETag string, err error)
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
 	Name() string
 }
 
+// closeServer releases any resources held by the connection to the
+// server, such as the SSH tunnel to an ssh:// remote.
+func closeServer(svr Server) {
+	closer, ok := svr.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		logger.Warningf("closing connection to LXD server: %v", err)
+	}
+}
+
 // ServerFactory creates a new factory for creating servers that are required
 // by the server.
 type ServerFactory interface {
@@ -198,10 +213,14 @@ func (s *serverFactory) RemoteServer(spec environs.CloudSpec) (Server, error) {
 	)
 	serverSpec.WithProxy(proxy.DefaultConfig.GetProxy)
 	svr, err := s.newRemoteServerFunc(serverSpec)
-	if err == nil {
-		err = s.bootstrapRemoteServer(svr)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
-	return svr, errors.Trace(err)
+	if err := s.bootstrapRemoteServer(svr); err != nil {
+		closeServer(svr)
+		return nil, errors.Trace(err)
+	}
+	return svr, nil
 }
 
 func (s *serverFactory) InsecureRemoteServer(spec environs.CloudSpec) (Server, error) {
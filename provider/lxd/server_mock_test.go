@@ -239,6 +239,19 @@ func (mr *MockServerMockRecorder) FindImage(arg0, arg1, arg2, arg3, arg4 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImage", reflect.TypeOf((*MockServer)(nil).FindImage), arg0, arg1, arg2, arg3, arg4)
 }
 
+// FindImageByID mocks base method
+func (m *MockServer) FindImageByID(arg0 string, arg1 []lxd.ServerSpec, arg2 bool, arg3 environs.StatusCallbackFunc) (lxd.SourcedImage, error) {
+	ret := m.ctrl.Call(m, "FindImageByID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(lxd.SourcedImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindImageByID indicates an expected call of FindImageByID
+func (mr *MockServerMockRecorder) FindImageByID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImageByID", reflect.TypeOf((*MockServer)(nil).FindImageByID), arg0, arg1, arg2, arg3)
+}
+
 // GetCertificate mocks base method
 func (m *MockServer) GetCertificate(arg0 string) (*api.Certificate, string, error) {
 	ret := m.ctrl.Call(m, "GetCertificate", arg0)
@@ -305,6 +318,20 @@ func (mr *MockServerMockRecorder) GetNICsFromProfile(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNICsFromProfile", reflect.TypeOf((*MockServer)(nil).GetNICsFromProfile), arg0)
 }
 
+// GetNetwork mocks base method
+func (m *MockServer) GetNetwork(arg0 string) (*api.Network, string, error) {
+	ret := m.ctrl.Call(m, "GetNetwork", arg0)
+	ret0, _ := ret[0].(*api.Network)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNetwork indicates an expected call of GetNetwork
+func (mr *MockServerMockRecorder) GetNetwork(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetwork", reflect.TypeOf((*MockServer)(nil).GetNetwork), arg0)
+}
+
 // GetProfile mocks base method
 func (m *MockServer) GetProfile(arg0 string) (*api.Profile, string, error) {
 	ret := m.ctrl.Call(m, "GetProfile", arg0)
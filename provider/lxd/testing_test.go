@@ -468,6 +468,17 @@ func (conn *StubClient) FindImage(
 	return lxd.SourcedImage{}, nil
 }
 
+func (conn *StubClient) FindImageByID(
+	imageID string, sources []lxd.ServerSpec, copyLocal bool, callback environs.StatusCallbackFunc,
+) (lxd.SourcedImage, error) {
+	conn.AddCall("FindImageByID", imageID)
+	if err := conn.NextErr(); err != nil {
+		return lxd.SourcedImage{}, errors.Trace(err)
+	}
+
+	return lxd.SourcedImage{}, nil
+}
+
 func (conn *StubClient) CreateCertificate(cert api.CertificatesPost) error {
 	conn.AddCall("CreateCertificate", cert)
 	return conn.NextErr()
@@ -705,6 +716,15 @@ func (conn *StubClient) GetNICsFromProfile(profName string) (map[string]map[stri
 	return conn.Profile.Devices, conn.NextErr()
 }
 
+func (conn *StubClient) GetNetwork(name string) (*api.Network, string, error) {
+	conn.AddCall("GetNetwork", name)
+	return &api.Network{
+		Name:    name,
+		Type:    "bridge",
+		Managed: true,
+	}, "", conn.NextErr()
+}
+
 func (conn *StubClient) IsClustered() bool {
 	conn.AddCall("IsClustered")
 	return true
@@ -772,6 +792,30 @@ func (s *EnvironSuite) NewEnviron(c *gc.C, svr Server, cfgEdit map[string]interf
 	}
 }
 
+// NewRemoteEnviron returns an environ connected to a remote LXD server
+// at the input endpoint, using the input server factory to reconnect.
+func (s *EnvironSuite) NewRemoteEnviron(
+	c *gc.C, svr Server, factory ServerFactory, clock clock.Clock, endpoint string,
+) environs.Environ {
+	env := s.NewEnviron(c, svr, nil).(*environ)
+	certCred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": testing.CACert,
+		"client-key":  testing.CAKey,
+		"server-cert": testing.ServerCert,
+	})
+	env.cloudUnlocked = environs.CloudSpec{
+		Name:       "remote",
+		Type:       "lxd",
+		Endpoint:   endpoint,
+		Credential: &certCred,
+	}
+	env.provider = &environProvider{
+		serverFactory: factory,
+		Clock:         clock,
+	}
+	return env
+}
+
 func (s *EnvironSuite) GetStartInstanceArgs(c *gc.C, series string) environs.StartInstanceParams {
 	tools := []*coretools.Tools{
 		{
@@ -10,8 +10,12 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	jujupaths "github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/juju/version"
+	"github.com/juju/juju/provider/common"
 )
 
 // ReadLegacyCloudCredentials reads cloud credentials off disk for an old
@@ -55,3 +59,84 @@ func ReadLegacyCloudCredentials(readFile func(string) ([]byte, error)) (cloud.Cr
 		credAttrClientKey:  clientKey,
 	}), nil
 }
+
+// providerVersion1 is the version of the provider from which the
+// root disk and NICs of containers are pinned to the container,
+// rather than being inherited from the default profile.
+const providerVersion1 = 1
+
+// UpgradeOperations is part of the upgrades.OperationSource interface.
+func (env *environ) UpgradeOperations(context.ProviderCallContext, environs.UpgradeOperationsParams) []environs.UpgradeOperation {
+	return []environs.UpgradeOperation{{
+		providerVersion1,
+		[]environs.UpgradeStep{
+			pinContainerDevicesUpgradeStep{env},
+		},
+	}}
+}
+
+// pinContainerDevicesUpgradeStep copies the root disk and NIC devices
+// that existing containers inherit from their profiles onto the
+// containers themselves. This keeps existing containers on their
+// current storage pool and network, regardless of later changes to the
+// default profile or to the lxd-storage-pool and lxd-network model
+// config.
+type pinContainerDevicesUpgradeStep struct {
+	env *environ
+}
+
+// Description is part of the environs.UpgradeStep interface.
+func (pinContainerDevicesUpgradeStep) Description() string {
+	return "Pin root disk and network devices of existing containers"
+}
+
+// Run is part of the environs.UpgradeStep interface.
+func (step pinContainerDevicesUpgradeStep) Run(ctx context.ProviderCallContext) error {
+	svr := step.env.server()
+	containers, err := svr.AliveContainers(step.env.namespace.Prefix())
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return errors.Trace(err)
+	}
+	for i := range containers {
+		container := &containers[i]
+		if !pinContainerDevices(container) {
+			continue
+		}
+		if err := svr.WriteContainer(container); err != nil {
+			common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+			return errors.Annotatef(err, "pinning devices of container %q", container.Name)
+		}
+	}
+	return nil
+}
+
+// pinContainerDevices adds any root disk or NIC devices inherited by
+// the container from its profiles to its local devices, returning true
+// if the container was changed.
+func pinContainerDevices(container *lxd.Container) bool {
+	var changed bool
+	for name, device := range container.ExpandedDevices {
+		if _, ok := container.Devices[name]; ok {
+			continue
+		}
+		isRoot := device["type"] == "disk" && device["path"] == "/"
+		if !isRoot && device["type"] != "nic" {
+			continue
+		}
+		pinned := make(map[string]string, len(device)+1)
+		for k, v := range device {
+			pinned[k] = v
+		}
+		if hwaddr := container.Config["volatile."+name+".hwaddr"]; device["type"] == "nic" && pinned["hwaddr"] == "" && hwaddr != "" {
+			// Keep the MAC address that LXD generated for the NIC.
+			pinned["hwaddr"] = hwaddr
+		}
+		if container.Devices == nil {
+			container.Devices = make(map[string]map[string]string)
+		}
+		container.Devices[name] = pinned
+		changed = true
+	}
+	return changed
+}
@@ -6,12 +6,16 @@ package lxd_test
 import (
 	"os"
 
+	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cloud"
+	containerlxd "github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/provider/lxd"
 )
 
@@ -48,3 +52,54 @@ func (s *upgradesSuite) TestReadLegacyCloudCredentialsFileNotExist(c *gc.C) {
 	_, err := lxd.ReadLegacyCloudCredentials(readFile)
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+type upgradeOperationsSuite struct {
+	lxd.EnvironSuite
+}
+
+var _ = gc.Suite(&upgradeOperationsSuite{})
+
+func (s *upgradeOperationsSuite) TestPinContainerDevices(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+	ops := env.(environs.Upgrader).UpgradeOperations(context.NewCloudCallContext(), environs.UpgradeOperationsParams{})
+	c.Assert(ops, gc.HasLen, 1)
+	c.Assert(ops[0].TargetVersion, gc.Equals, 1)
+	c.Assert(ops[0].Steps, gc.HasLen, 1)
+	step := ops[0].Steps[0]
+	c.Assert(step.Description(), gc.Equals, "Pin root disk and network devices of existing containers")
+
+	inherited := containerlxd.Container{}
+	inherited.Name = "juju-f75cba-0"
+	inherited.Config = map[string]string{"volatile.eth0.hwaddr": "00:16:3e:00:00:01"}
+	inherited.ExpandedDevices = map[string]map[string]string{
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+		"eth0": {"type": "nic", "nictype": "bridged", "parent": "lxdbr0"},
+		"data": {"type": "disk", "path": "/srv", "source": "/srv"},
+	}
+	pinned := containerlxd.Container{}
+	pinned.Name = "juju-f75cba-1"
+	pinned.Devices = map[string]map[string]string{
+		"root": {"type": "disk", "path": "/", "pool": "zfs"},
+	}
+	pinned.ExpandedDevices = pinned.Devices
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.AliveContainers("juju-f75cba-").Return([]containerlxd.Container{inherited, pinned}, nil),
+		exp.WriteContainer(gomock.Any()).DoAndReturn(func(container *containerlxd.Container) error {
+			c.Check(container.Name, gc.Equals, "juju-f75cba-0")
+			c.Check(container.Devices, jc.DeepEquals, map[string]map[string]string{
+				"root": {"type": "disk", "path": "/", "pool": "default"},
+				"eth0": {"type": "nic", "nictype": "bridged", "parent": "lxdbr0", "hwaddr": "00:16:3e:00:00:01"},
+			})
+			return nil
+		}),
+	)
+
+	err := step.Run(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
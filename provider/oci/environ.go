@@ -331,6 +331,9 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 		constraints.Container,
 		constraints.VirtType,
 		constraints.Tags,
+		constraints.GPUs,
+		constraints.GPUType,
+		constraints.InstanceLifecycle,
 	}
 
 	validator := constraints.NewValidator()
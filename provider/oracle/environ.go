@@ -659,6 +659,9 @@ func (o *OracleEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (c
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.GPUs,
+		constraints.GPUType,
+		constraints.InstanceLifecycle,
 	}
 
 	// we choose to use the default validator implementation
@@ -3,7 +3,11 @@
 
 package controller
 
-import "github.com/juju/juju/controller"
+import (
+	"time"
+
+	"github.com/juju/juju/controller"
+)
 
 // ConfigChanged messages are published by the apiserver client controller
 // facade whenever the controller config is updated.
@@ -22,3 +26,63 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// NodeHealthTopic is published periodically by the peergrouper worker
+// running on each controller machine, reporting the state of the mongo
+// replica set as seen from that machine.
+// data: `NodeHealthMessage`
+const NodeHealthTopic = "controller.node-health"
+
+// NodeHealthMessage holds the health of a single controller machine.
+type NodeHealthMessage struct {
+	// Origin is the tag of the machine that published the message.
+	// It is filled in by the central hub.
+	Origin string `yaml:"origin,omitempty"`
+
+	// AgentVersion is the version of the agent running on the machine.
+	AgentVersion string `yaml:"agent-version"`
+
+	// Time is the time on the machine when the message was published.
+	// It is compared with the time the message is received to estimate
+	// the clock skew between controller machines.
+	Time time.Time `yaml:"time"`
+
+	// ReplicaSet holds the status of the replica set members.
+	ReplicaSet []ReplicaSetMember `yaml:"replica-set,omitempty"`
+}
+
+// ReplicaSetMember holds the status of a mongo replica set member.
+type ReplicaSetMember struct {
+	MachineID string `yaml:"machine-id,omitempty"`
+	Address   string `yaml:"address"`
+	State     string `yaml:"state"`
+	Healthy   bool   `yaml:"healthy"`
+
+	// Lag is how far the member's replication is behind the primary.
+	Lag time.Duration `yaml:"lag,omitempty"`
+
+	// LastHeartbeat is when the member last responded to a heartbeat
+	// from the machine publishing the message. It is zero for the
+	// member running on that machine.
+	LastHeartbeat time.Time `yaml:"last-heartbeat,omitempty"`
+}
+
+// RaftHealthTopic is published periodically by the raft clusterer
+// worker, which only runs on the raft leader.
+// data: `RaftHealthMessage`
+const RaftHealthTopic = "controller.raft-health"
+
+// RaftHealthMessage holds the state of the raft cluster as seen
+// by the leader.
+type RaftHealthMessage struct {
+	Leader  string       `yaml:"leader"`
+	Term    uint64       `yaml:"term"`
+	Servers []RaftServer `yaml:"servers,omitempty"`
+}
+
+// RaftServer holds the configuration of a single raft server.
+type RaftServer struct {
+	ID       string `yaml:"id"`
+	Address  string `yaml:"address"`
+	Suffrage string `yaml:"suffrage"`
+}
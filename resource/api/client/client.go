@@ -107,10 +107,22 @@ func newListResourcesArgs(applications []string) (params.ListResourcesArgs, erro
 
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(application, name, filename string, reader io.ReadSeeker) error {
+	return c.upload(application, name, filename, reader, false)
+}
+
+// UploadPinnedImage sends the provided container image details up to
+// Juju, asking the controller to pin the image to the digest its
+// registry currently holds for the image's tag.
+func (c Client) UploadPinnedImage(application, name, filename string, reader io.ReadSeeker) error {
+	return c.upload(application, name, filename, reader, true)
+}
+
+func (c Client) upload(application, name, filename string, reader io.ReadSeeker, pinDigest bool) error {
 	uReq, err := api.NewUploadRequest(application, name, filename, reader)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	uReq.PinDigest = pinDigest
 	req, err := uReq.HTTPRequest()
 	if err != nil {
 		return errors.Trace(err)
@@ -52,6 +52,30 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
 }
 
+func (s *UploadSuite) TestPinnedImage(c *gc.C) {
+	data := `{"ImageName":"mariadb:10.3"}`
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader(data)
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadPinnedImage("a-application", "spam", "mariadb:10.3", reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	req, err := http.NewRequest("PUT", "/applications/a-application/resources/spam", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-SHA384", fp.String())
+	req.Header.Set("Content-Length", fmt.Sprint(len(data)))
+	req.Header.Set("Content-Disposition", `form-data; filename="mariadb:10.3"`)
+	req.ContentLength = int64(len(data))
+	req.URL.RawQuery = "pin-digest=true"
+
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
+	s.stub.CheckCall(c, 3, "Do", req, reader, s.response)
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(s.facade, s, s.facade)
 
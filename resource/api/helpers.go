@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
@@ -23,6 +24,37 @@ func Resource2API(res resource.Resource) params.Resource {
 		ApplicationID: res.ApplicationID,
 		Username:      res.Username,
 		Timestamp:     res.Timestamp,
+		ImageScan:     imageScan2API(res.ImageScan),
+	}
+}
+
+func imageScan2API(scan *resources.ImageScanResult) *params.ImageScanResult {
+	if scan == nil {
+		return nil
+	}
+	return &params.ImageScanResult{
+		ScannedAt: scan.ScannedAt,
+		Critical:  scan.Critical,
+		High:      scan.High,
+		Summary:   scan.Summary,
+		Error:     scan.Error,
+		Pending:   scan.Pending,
+		Blocked:   scan.Blocked,
+	}
+}
+
+func api2ImageScan(scan *params.ImageScanResult) *resources.ImageScanResult {
+	if scan == nil {
+		return nil
+	}
+	return &resources.ImageScanResult{
+		ScannedAt: scan.ScannedAt,
+		Critical:  scan.Critical,
+		High:      scan.High,
+		Summary:   scan.Summary,
+		Error:     scan.Error,
+		Pending:   scan.Pending,
+		Blocked:   scan.Blocked,
 	}
 }
 
@@ -133,6 +165,7 @@ func API2Resource(apiRes params.Resource) (resource.Resource, error) {
 		ApplicationID: apiRes.ApplicationID,
 		Username:      apiRes.Username,
 		Timestamp:     apiRes.Timestamp,
+		ImageScan:     api2ImageScan(apiRes.ImageScan),
 	}
 
 	if err := res.Validate(); err != nil {
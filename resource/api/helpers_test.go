@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/resource/resourcetesting"
@@ -442,6 +443,41 @@ func (HelpersSuite) TestAPI2Resource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, expected)
 }
 
+func (HelpersSuite) TestImageScanRoundTrip(c *gc.C) {
+	res := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name: "image",
+				Type: charmresource.TypeContainerImage,
+			},
+			Origin: charmresource.OriginUpload,
+		},
+		ID:            "a-application/image",
+		ApplicationID: "a-application",
+		Username:      "a-user",
+		Timestamp:     time.Now(),
+		ImageScan: &resources.ImageScanResult{
+			ScannedAt: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+			Critical:  1,
+			High:      4,
+			Summary:   "CVE-2019-0001",
+			Blocked:   true,
+		},
+	}
+	apiRes := api.Resource2API(res)
+	c.Check(apiRes.ImageScan, jc.DeepEquals, &params.ImageScanResult{
+		ScannedAt: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+		Critical:  1,
+		High:      4,
+		Summary:   "CVE-2019-0001",
+		Blocked:   true,
+	})
+
+	converted, err := api.API2Resource(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(converted.ImageScan, jc.DeepEquals, res.ImageScan)
+}
+
 func (HelpersSuite) TestCharmResource2API(c *gc.C) {
 	fp, err := charmresource.NewFingerprint([]byte(fingerprint))
 	c.Assert(err, jc.ErrorIsNil)
@@ -53,6 +53,9 @@ const (
 	MediaTypeFormData = "form-data"
 	// QueryParamPendingID is the query parameter we use to send up the pending id.
 	QueryParamPendingID = "pendingid"
+	// QueryParamPinDigest is the query parameter we use to ask for an
+	// uploaded container image to be pinned to its current digest.
+	QueryParamPinDigest = "pin-digest"
 )
 
 // NewEndpointPath returns the API URL path for the identified resource.
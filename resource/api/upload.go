@@ -35,6 +35,10 @@ type UploadRequest struct {
 
 	// PendingID is the pending ID to associate with this upload, if any.
 	PendingID string
+
+	// PinDigest is whether an uploaded container image should be
+	// pinned to the digest its registry path refers to.
+	PinDigest bool
 }
 
 // NewUploadRequest generates a new upload request for the given resource.
@@ -96,11 +100,14 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	req.ContentLength = ur.Size
 
+	query := req.URL.Query()
 	if ur.PendingID != "" {
-		query := req.URL.Query()
 		query.Set(QueryParamPendingID, ur.PendingID)
-		req.URL.RawQuery = query.Encode()
 	}
+	if ur.PinDigest {
+		query.Set(QueryParamPinDigest, "true")
+	}
+	req.URL.RawQuery = query.Encode()
 
 	return req, nil
 }
@@ -11,6 +11,8 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6/resource"
+
+	"github.com/juju/juju/core/resources"
 )
 
 // Resource defines a single resource within a Juju model.
@@ -65,6 +67,10 @@ type Resource struct {
 
 	// Timestamp indicates when the resource was added to the model.
 	Timestamp time.Time
+
+	// ImageScan holds the result of the most recent vulnerability scan
+	// of a container image resource, if it has been scanned.
+	ImageScan *resources.ImageScanResult
 }
 
 // Validate ensures that the spec is valid.
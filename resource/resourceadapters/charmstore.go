@@ -57,7 +57,15 @@ func newCharmStoreClient(st *state.State) (charmstore.Client, error) {
 	if err != nil {
 		return charmstore.Client{}, errors.Trace(err)
 	}
-	return charmstore.NewCachingClient(state.MacaroonCache{st}, controllerCfg.CharmStoreURL())
+	model, err := st.Model()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	modelCfg, err := model.ModelConfig()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	return charmstore.NewModelCachingClient(state.MacaroonCache{st}, controllerCfg, modelCfg)
 }
 
 // NewClient opens a new charm store client.
@@ -23,6 +23,7 @@ type Call struct {
 	Params   interface{}
 	Response interface{}
 	Error    error
+	Warnings []Warning
 	Done     chan *Call
 }
 
@@ -118,6 +119,9 @@ func (conn *Conn) handleResponse(hdr *Header) error {
 	call := conn.clientPending[reqId]
 	delete(conn.clientPending, reqId)
 	conn.mutex.Unlock()
+	if call != nil {
+		call.Warnings = hdr.Warnings
+	}
 
 	var err error
 	switch {
@@ -175,5 +179,21 @@ func (conn *Conn) Call(req Request, params, response interface{}) error {
 	}
 	conn.send(call)
 	result := <-call.Done
+	if len(result.Warnings) > 0 {
+		conn.mutex.Lock()
+		handleWarnings := conn.warningHandler
+		conn.mutex.Unlock()
+		if handleWarnings != nil {
+			handleWarnings(result.Request, result.Warnings)
+		}
+	}
 	return errors.Trace(result.Error)
 }
+
+// SetWarningHandler sets the function that is called with any
+// warnings attached to the replies to requests made with Call.
+func (conn *Conn) SetWarningHandler(handler WarningHandler) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.warningHandler = handler
+}
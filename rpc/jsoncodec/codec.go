@@ -72,6 +72,7 @@ type inMsgV1 struct {
 	Error     string                 `json:"error"`
 	ErrorCode string                 `json:"error-code"`
 	ErrorInfo map[string]interface{} `json:"error-info"`
+	Warnings  []rpc.Warning          `json:"warnings"`
 	Response  json.RawMessage        `json:"response"`
 }
 
@@ -98,6 +99,7 @@ type outMsgV1 struct {
 	Error     string                 `json:"error,omitempty"`
 	ErrorCode string                 `json:"error-code,omitempty"`
 	ErrorInfo map[string]interface{} `json:"error-info,omitempty"`
+	Warnings  []rpc.Warning          `json:"warnings,omitempty"`
 	Response  interface{}            `json:"response,omitempty"`
 }
 
@@ -142,6 +144,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.ErrorInfo = c.msg.ErrorInfo
+	hdr.Warnings = c.msg.Warnings
 	hdr.Version = version
 	return nil
 }
@@ -276,6 +279,7 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
 		ErrorInfo: hdr.ErrorInfo,
+		Warnings:  hdr.Warnings,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -115,6 +115,14 @@ func (*suite) TestRead(c *gc.C) {
 			Version:   1,
 		},
 		expectBody: &value{X: "result"},
+	}, {
+		msg: `{"request-id": 3, "response": {"X": "result"}, "warnings": [{"code": "deprecated", "message": "old"}]}`,
+		expectHdr: rpc.Header{
+			RequestId: 3,
+			Warnings:  []rpc.Warning{{Code: "deprecated", Message: "old"}},
+			Version:   1,
+		},
+		expectBody: &value{X: "result"},
 	}, {
 		msg: `{"request-id": 4, "type": "foo", "version": 2, "id": "id", "request": "frob", "params": {"X": "param"}}`,
 		expectHdr: rpc.Header{
@@ -258,6 +266,14 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "result"},
 		expect: `{"request-id": 3, "response": {"X": "result"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 3,
+			Warnings:  []rpc.Warning{{Code: "deprecated", Message: "old"}},
+			Version:   1,
+		},
+		body:   &value{X: "result"},
+		expect: `{"request-id": 3, "response": {"X": "result"}, "warnings": [{"code": "deprecated", "message": "old"}]}`,
 	}, {
 		hdr: &rpc.Header{
 			RequestId: 4,
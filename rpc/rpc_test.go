@@ -217,6 +217,16 @@ func (c *ContextMethods) Call1(ctx context.Context, s stringVal) error {
 	return c.checkContext(ctx)
 }
 
+func (c *ContextMethods) Warn(ctx context.Context, s stringVal) error {
+	c.root.called(c, "Warn", s)
+	rpc.Warn(ctx, rpc.Warning{Code: "deprecated", Message: s.Val})
+	rpc.Warn(ctx, rpc.Warning{Code: "deprecated", Message: s.Val})
+	if s.Val == "fail" {
+		return errors.New("failed")
+	}
+	return nil
+}
+
 func (c *ContextMethods) Wait(ctx context.Context) error {
 	c.root.called(c, "Wait", nil)
 	close(c.waiting)
@@ -1173,6 +1183,39 @@ func (*rpcSuite) TestRequestContext(c *gc.C) {
 	c.Assert(arg, gc.Equals, stringVal{"foo"})
 }
 
+func (*rpcSuite) TestRequestWarnings(c *gc.C) {
+	root := &Root{}
+	root.contextInst = &ContextMethods{root: root}
+
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	type handled struct {
+		req      rpc.Request
+		warnings []rpc.Warning
+	}
+	var warned []handled
+	client.SetWarningHandler(func(req rpc.Request, warnings []rpc.Warning) {
+		warned = append(warned, handled{req, warnings})
+	})
+
+	req := rpc.Request{"ContextMethods", 0, "", "Warn"}
+	err := client.Call(req, stringVal{"old"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Call(req, stringVal{"fail"}, nil)
+	c.Assert(err, gc.ErrorMatches, "failed")
+	err = client.Call(rpc.Request{"ContextMethods", 0, "", "Call0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(warned, jc.DeepEquals, []handled{{
+		req:      req,
+		warnings: []rpc.Warning{{Code: "deprecated", Message: "old"}},
+	}, {
+		req:      req,
+		warnings: []rpc.Warning{{Code: "deprecated", Message: "fail"}},
+	}})
+}
+
 func (*rpcSuite) TestConnectionContextCloseClient(c *gc.C) {
 	root := &Root{}
 	root.contextInst = &ContextMethods{
@@ -69,6 +69,9 @@ type Header struct {
 	// error, if any.
 	ErrorInfo map[string]interface{}
 
+	// Warnings holds any warnings attached to a reply.
+	Warnings []Warning
+
 	// Version defines the wire format of the request and response structure.
 	Version int
 }
@@ -164,6 +167,10 @@ type Conn struct {
 	inputLoopError error
 
 	recorderFactory RecorderFactory
+
+	// warningHandler is called with any warnings attached to the
+	// replies to client requests.
+	warningHandler WarningHandler
 }
 
 // NewConn creates a new connection that uses the given codec for
@@ -432,7 +439,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 		}
 		// We don't transform the error here. bindRequest will have
 		// already transformed it and returned a zero req.
-		return conn.writeErrorResponse(hdr, err, recorder)
+		return conn.writeErrorResponse(hdr, err, nil, recorder)
 	}
 	var argp interface{}
 	var arg reflect.Value
@@ -459,7 +466,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 		// the error is actually a framing or syntax
 		// problem, then the next ReadHeader should pick
 		// up the problem and abort.
-		return conn.writeErrorResponse(hdr, req.transformErrors(err), recorder)
+		return conn.writeErrorResponse(hdr, req.transformErrors(err), nil, recorder)
 	}
 	var body interface{} = struct{}{}
 	if req.ParamsType() != nil {
@@ -467,7 +474,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	}
 	if err := recorder.HandleRequest(hdr, body); err != nil {
 		logger.Errorf("error recording request %+v with arg %+v: %T %+v", req, arg, err, err)
-		return conn.writeErrorResponse(hdr, req.transformErrors(err), recorder)
+		return conn.writeErrorResponse(hdr, req.transformErrors(err), nil, recorder)
 	}
 	conn.mutex.Lock()
 	closing := conn.closing
@@ -478,17 +485,18 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	conn.mutex.Unlock()
 	if closing {
 		// We're closing down - no new requests may be initiated.
-		return conn.writeErrorResponse(hdr, req.transformErrors(ErrShutdown), recorder)
+		return conn.writeErrorResponse(hdr, req.transformErrors(ErrShutdown), nil, recorder)
 	}
 	return nil
 }
 
-func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, recorder Recorder) error {
+func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, warnings []Warning, recorder Recorder) error {
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
 	hdr := &Header{
 		RequestId: reqHdr.RequestId,
 		Version:   reqHdr.Version,
+		Warnings:  warnings,
 	}
 	if err, ok := err.(ErrorCoder); ok {
 		hdr.ErrorCode = err.ErrorCode()
@@ -557,7 +565,7 @@ func (conn *Conn) runRequest(
 		if panicResult := recover(); panicResult != nil {
 			logger.Criticalf(
 				"panic running request %+v with arg %+v: %v\n%v", req, arg, panicResult, string(debug.Stack()))
-			conn.writeErrorResponse(&req.hdr, errors.Errorf("%v", panicResult), recorder)
+			conn.writeErrorResponse(&req.hdr, errors.Errorf("%v", panicResult), nil, recorder)
 		}
 	}()
 	defer conn.srvPending.Done()
@@ -568,14 +576,16 @@ func (conn *Conn) runRequest(
 	// TODO(axw) provide a means for clients to cancel a request.
 	ctx, cancel := context.WithCancel(conn.context)
 	defer cancel()
+	ctx, warnings := WithWarnings(ctx)
 
 	rv, err := req.Call(ctx, req.hdr.Request.Id, arg)
 	if err != nil {
-		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), recorder)
+		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), warnings.All(), recorder)
 	} else {
 		hdr := &Header{
 			RequestId: req.hdr.RequestId,
 			Version:   version,
+			Warnings:  warnings.All(),
 		}
 		var rvi interface{}
 		if rv.IsValid() {
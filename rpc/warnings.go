@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc
+
+import (
+	"context"
+	"sync"
+)
+
+// Warning holds a machine-readable warning attached to the reply to a
+// request, such as the deprecation of something the request used.
+type Warning struct {
+	// Code identifies the kind of warning.
+	Code string `json:"code"`
+
+	// Message holds a human-readable description of the warning.
+	Message string `json:"message"`
+}
+
+// WarningHandler is called with the warnings attached to the reply to
+// a request made by a client.
+type WarningHandler func(req Request, warnings []Warning)
+
+// Warnings collects the warnings attached to the reply to a request.
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// All returns the warnings collected so far.
+func (w *Warnings) All() []Warning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.warnings) == 0 {
+		return nil
+	}
+	return append([]Warning(nil), w.warnings...)
+}
+
+func (w *Warnings) add(warning Warning) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, existing := range w.warnings {
+		if existing == warning {
+			return
+		}
+	}
+	w.warnings = append(w.warnings, warning)
+}
+
+type warningsKey struct{}
+
+// WithWarnings returns a context that collects the warnings passed to
+// Warn with it, or any context derived from it. The server side of a
+// connection calls each method with such a context, and attaches the
+// collected warnings to the reply.
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	warnings := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, warnings), warnings
+}
+
+// Warn attaches the warning to the reply to the request being served
+// with the given context. The same warning is only attached once. It
+// does nothing if the context does not collect warnings.
+func Warn(ctx context.Context, warning Warning) {
+	if warnings, ok := ctx.Value(warningsKey{}).(*Warnings); ok {
+		warnings.add(warning)
+	}
+}
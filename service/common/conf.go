@@ -38,6 +38,12 @@ type Conf struct {
 	// Valid values are integers or "infinity"
 	Limit map[string]string
 
+	// Slice, if set, is the systemd slice in which the service's
+	// processes are run, so that resource limits set on the slice
+	// apply to them.
+	// Currently only used with systemd.
+	Slice string
+
 	// Timeout is how many seconds may pass before an exec call (e.g.
 	// ExecStart) times out. Values less than or equal to 0 (the
 	// default) are treated as though there is no timeout.
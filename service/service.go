@@ -309,3 +309,40 @@ func FindUnitServiceNames(svcNames []string) map[string]string {
 	}
 	return unitServices
 }
+
+// UnitAgentServiceName returns the name of the init service that runs
+// the agent for the named unit.
+func UnitAgentServiceName(unitName string) (string, error) {
+	// Service name can be at most 64 characters long, we limit it to 56 just to be safe.
+	tag, err := names.NewUnitTag(unitName).ShortenedString(56)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return "jujud-" + tag, nil
+}
+
+// UnitAgentSliceName returns the name of the systemd slice in which
+// the agent for the named unit runs. Charms may run their workloads in
+// the slice too, so that they share the unit's resource limits.
+func UnitAgentSliceName(unitName string) (string, error) {
+	svcName, err := UnitAgentServiceName(unitName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return svcName + ".slice", nil
+}
+
+// SetSliceLimits limits the CPU time, as a percentage of a single CPU,
+// and the memory, in megabytes, used by the processes in the named
+// slice. A limit of 0 removes the limit. Slices are only supported by
+// systemd; a NotSupported error is returned for other init systems.
+func SetSliceLimits(slice string, cpuQuota, memoryLimit uint64) error {
+	initName, err := discoverInitSystem(series.MustHostSeries())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if initName != InitSystemSystemd {
+		return errors.NotSupportedf("resource limits with init system %q", initName)
+	}
+	return errors.Trace(systemd.SetSliceLimits(slice, cpuQuota, memoryLimit))
+}
@@ -146,6 +146,22 @@ func (s *serviceSuite) TestFindUnitServiceNames(c *gc.C) {
 	c.Check(services, gc.DeepEquals, expected)
 }
 
+func (s *serviceSuite) TestUnitAgentServiceName(c *gc.C) {
+	name, err := service.UnitAgentServiceName("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "jujud-unit-wordpress-0")
+
+	name, err = service.UnitAgentServiceName("a-very-long-application-name-for-testing-shortened-tags/123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(len(name) <= len("jujud-")+56, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestUnitAgentSliceName(c *gc.C) {
+	name, err := service.UnitAgentSliceName("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "jujud-unit-wordpress-0.slice")
+}
+
 type restartSuite struct {
 	service.BaseSuite
 }
@@ -67,6 +67,11 @@ func (c commands) reload() string {
 	return c.resolve(args)
 }
 
+func (c commands) setSliceLimits(slice, cpuQuota, memoryLimit string) string {
+	args := fmt.Sprintf("set-property %s CPUQuota=%s MemoryLimit=%s", c.Quote(slice), cpuQuota, memoryLimit)
+	return c.resolve(args)
+}
+
 func (c commands) conf(name, dirname string) string {
 	serviceFile := c.unitFilename(name, dirname)
 	args := fmt.Sprintf("cat %s", serviceFile)
@@ -143,6 +148,24 @@ func (cl Cmdline) reload() error {
 	return err
 }
 
+// SetSliceLimits limits the CPU time, as a percentage of a single CPU,
+// and the memory, in megabytes, used by the processes in the slice. A
+// limit of 0 removes the limit.
+func (cl Cmdline) SetSliceLimits(slice string, cpuQuota, memoryLimit uint64) error {
+	quota := ""
+	if cpuQuota > 0 {
+		quota = fmt.Sprintf("%d%%", cpuQuota)
+	}
+	limit := "infinity"
+	if memoryLimit > 0 {
+		limit = fmt.Sprintf("%dM", memoryLimit)
+	}
+	cmd := cl.commands.setSliceLimits(slice, quota, limit)
+
+	_, err := cl.runCommand(cmd, "Set slice limits")
+	return errors.Trace(err)
+}
+
 const runCommandMsg = "%s failed (%s)"
 
 func (Cmdline) runCommand(cmd, label string) (string, error) {
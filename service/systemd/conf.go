@@ -210,6 +210,14 @@ func serializeService(conf common.Conf) []*unit.UnitOption {
 		})
 	}
 
+	if conf.Slice != "" {
+		unitOptions = append(unitOptions, &unit.UnitOption{
+			Section: "Service",
+			Name:    "Slice",
+			Value:   conf.Slice,
+		})
+	}
+
 	if conf.ExecStart != "" {
 		unitOptions = append(unitOptions, &unit.UnitOption{
 			Section: "Service",
@@ -309,6 +317,8 @@ func deserializeOptions(opts []*unit.UnitOption, renderer shell.Renderer) (commo
 						break
 					}
 				}
+			case uo.Name == "Slice":
+				conf.Slice = uo.Value
 			case uo.Name == "TimeoutSec":
 				timeout, err := strconv.Atoi(uo.Value)
 				if err != nil {
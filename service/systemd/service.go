@@ -615,3 +615,10 @@ func SysdReload() error {
 	}
 	return nil
 }
+
+// SetSliceLimits limits the CPU time, as a percentage of a single CPU,
+// and the memory, in megabytes, used by the processes in the slice,
+// which need not be running yet. A limit of 0 removes the limit.
+func SetSliceLimits(slice string, cpuQuota, memoryLimit uint64) error {
+	return errors.Trace(Cmdline{}.SetSliceLimits(slice, cpuQuota, memoryLimit))
+}
@@ -324,6 +324,42 @@ func (s *initSystemSuite) TestInstalledError(c *gc.C) {
 	c.Check(installed, jc.IsFalse)
 }
 
+func (s *initSystemSuite) TestSetSliceLimits(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	s.exec.EXPECT().RunCommands(exec.RunParams{
+		Commands: "/bin/systemctl set-property jujud-unit-mysql-0.slice CPUQuota=50% MemoryLimit=512M",
+	}).Return(&exec.ExecResponse{}, nil)
+
+	err := systemd.SetSliceLimits("jujud-unit-mysql-0.slice", 50, 512)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *initSystemSuite) TestSetSliceLimitsUnlimited(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	s.exec.EXPECT().RunCommands(exec.RunParams{
+		Commands: "/bin/systemctl set-property jujud-unit-mysql-0.slice CPUQuota= MemoryLimit=infinity",
+	}).Return(&exec.ExecResponse{}, nil)
+
+	err := systemd.SetSliceLimits("jujud-unit-mysql-0.slice", 0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *initSystemSuite) TestSetSliceLimitsError(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	s.exec.EXPECT().RunCommands(exec.RunParams{
+		Commands: "/bin/systemctl set-property jujud-unit-mysql-0.slice CPUQuota= MemoryLimit=1024M",
+	}).Return(nil, errFailure)
+
+	err := systemd.SetSliceLimits("jujud-unit-mysql-0.slice", 0, 1024)
+	c.Assert(errors.Cause(err), gc.Equals, errFailure)
+}
+
 func (s *initSystemSuite) TestExistsTrue(c *gc.C) {
 	ctrl := s.patch(c)
 	defer ctrl.Finish()
@@ -334,6 +370,17 @@ func (s *initSystemSuite) TestExistsTrue(c *gc.C) {
 	c.Check(exists, jc.IsTrue)
 }
 
+func (s *initSystemSuite) TestExistsSlice(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+	s.conf.Slice = "jujud-unit-wordpress-0.slice"
+	s.expectConf(c, s.conf)
+
+	exists, err := s.newService(c).Exists()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsTrue)
+}
+
 func (s *initSystemSuite) TestExistsFalse(c *gc.C) {
 	ctrl := s.patch(c)
 	defer ctrl.Finish()
@@ -730,6 +777,24 @@ exec 2>&1
 	test.CheckCommands(c, commands)
 }
 
+func (s *initSystemSuite) TestInstallCommandsSlice(c *gc.C) {
+	name := "jujud-machine-0"
+	s.conf.Slice = "jujud-machine-0.slice"
+	commands, err := s.newService(c).InstallCommands()
+	c.Assert(err, jc.ErrorIsNil)
+
+	test := systemdtesting.WriteConfTest{
+		Service: name,
+		DataDir: "/lib/systemd/system",
+		Expected: strings.Replace(
+			s.newConfStr(name),
+			"[Service]\n",
+			"[Service]\nSlice=jujud-machine-0.slice\n",
+			1),
+	}
+	test.CheckCommands(c, commands)
+}
+
 func (s *initSystemSuite) TestInstallCommandsShutdown(c *gc.C) {
 	name := "juju-shutdown-job"
 	conf, err := service.ShutdownAfterConf("cloud-final")
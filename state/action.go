@@ -104,6 +104,13 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// NotifyURL is the URL to which a notification is posted
+	// when the action finishes, if any.
+	NotifyURL string `bson:"notify-url,omitempty"`
+
+	// Notified records whether the notification has been posted.
+	Notified bool `bson:"notified,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +169,18 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// NotifyURL returns the URL to which a notification is posted when
+// the action finishes, or the empty string if there is none.
+func (a *action) NotifyURL() string {
+	return a.doc.NotifyURL
+}
+
+// Notified returns whether the notification of the action
+// finishing has been posted.
+func (a *action) Notified() bool {
+	return a.doc.Notified
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +229,22 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// SetNotified records that the notification of the action
+// finishing has been posted.
+func (a *action) SetNotified() error {
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"notified", true}}}},
+	}})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record notification of action %q", a.Id())
+	}
+	a.doc.Notified = true
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -371,6 +406,12 @@ func (m *Model) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return m.enqueueAction(receiver, actionName, payload, "")
+}
+
+// enqueueAction enqueues the action, recording the URL to which
+// a notification is posted when it finishes, if any.
+func (m *Model) enqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}, notifyURL string) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -384,6 +425,7 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	doc.NotifyURL = notifyURL
 
 	ops := []txn.Op{{
 		C:      receiverCollectionName,
@@ -484,6 +484,38 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestNotifyURL(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.NotifyURL(), gc.Equals, "")
+	c.Assert(a.Notified(), jc.IsFalse)
+
+	a, err = unit.AddActionWithNotifyURL("snapshot", nil, "https://chat.example.com/hooks/juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.NotifyURL(), gc.Equals, "https://chat.example.com/hooks/juju")
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.NotifyURL(), gc.Equals, "https://chat.example.com/hooks/juju")
+
+	action, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.NotifyURL(), gc.Equals, "https://chat.example.com/hooks/juju")
+	c.Assert(action.Notified(), jc.IsFalse)
+
+	err = action.SetNotified()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Notified(), jc.IsTrue)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
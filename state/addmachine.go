@@ -111,7 +111,7 @@ func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemp
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, ops, ModelQuotaUsage{Machines: 2})
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
@@ -121,7 +121,7 @@ func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId stri
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, ops, ModelQuotaUsage{Machines: 1})
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -169,7 +169,7 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 	}
 	ops = append(ops, ssOps...)
 	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
-	if err := st.db().RunTransaction(ops); err != nil {
+	if err := st.runTransactionWithQuotas(ops, machineQuotaUsage(templates)); err != nil {
 		if errors.Cause(err) == txn.ErrAborted {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
@@ -180,9 +180,26 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
+// machineQuotaUsage returns the number of the machines described by
+// the given templates which count towards the model's machine quota.
+// Controller machines are not counted, so that the quota never
+// prevents a controller from being made highly available.
+func machineQuotaUsage(templates []MachineTemplate) ModelQuotaUsage {
+	var usage ModelQuotaUsage
+	for _, template := range templates {
+		if !hasJob(template.Jobs, JobManageModel) {
+			usage.Machines++
+		}
+	}
+	return usage
+}
+
+func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op, adding ModelQuotaUsage) (*Machine, error) {
 	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
-	if err := st.db().RunTransaction(ops); err != nil {
+	if err := st.runTransactionWithQuotas(ops, adding); err != nil {
+		if IsQuotaExceededError(err) {
+			return nil, errors.Annotate(err, "cannot add a new machine")
+		}
 		if errors.Cause(err) == txn.ErrAborted {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
@@ -301,6 +318,9 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 				CpuPower:       template.HardwareCharacteristics.CpuPower,
 				Tags:           template.HardwareCharacteristics.Tags,
 				AvailZone:      template.HardwareCharacteristics.AvailabilityZone,
+
+				GPUs:    template.HardwareCharacteristics.GPUs,
+				GPUType: template.HardwareCharacteristics.GPUType,
 			},
 		})
 	}
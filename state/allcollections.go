@@ -89,6 +89,15 @@ func allCollections() CollectionSchema {
 		// This collection holds the details of the HA-ness of controllers.
 		controllerNodesC: {},
 
+		// This collection records each change to the controller's
+		// feature flags, along with who made it and when.
+		controllerFeatureChangesC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"-when"},
+			}},
+		},
+
 		// This collection is used to track progress when restoring a
 		// controller from backup.
 		restoreInfoC: {global: true},
@@ -97,6 +106,14 @@ func allCollections() CollectionSchema {
 		// upgrades and schema migrations.
 		upgradeInfoC: {global: true},
 
+		// This collection records the progress of the individual
+		// upgrade steps run by each agent in a model during an upgrade.
+		upgradeStepsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "version"},
+			}},
+		},
+
 		// This collection holds a convenient representation of the content of
 		// the simplestreams data source pointing to binaries required by juju.
 		//
@@ -331,6 +348,10 @@ func allCollections() CollectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the state of each machine's FAN
+		// networking, as reported by its machine agent.
+		machineNetworkStatusC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -472,6 +493,37 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the recent operation history of each
+		// unit, as reported by the unit agents.
+		unitOperationsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-started"},
+			}},
+		},
+
+		// This collection holds the hooks that each unit agent
+		// has reported it is waiting to run.
+		unitHookQueuesC: {
+			rawAccess: true,
+		},
+
+		// This collection holds the state that each unit agent
+		// keeps between runs, uploaded by the agents.
+		unitStatesC: {
+			rawAccess: true,
+		},
+
+		// This collection holds the recent history of hooks that have
+		// accessed the model's cloud credential through a trusted
+		// application.
+		credentialAccessC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "-time"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global:  true,
@@ -556,7 +608,9 @@ const (
 	constraintsC               = "constraints"
 	containerRefsC             = "containerRefs"
 	controllersC               = "controllers"
+	controllerFeatureChangesC  = "controllerFeatureChanges"
 	controllerNodesC           = "controllerNodes"
+	credentialAccessC          = "credentialaccess"
 	controllerUsersC           = "controllerusers"
 	dockerResourcesC           = "dockerResources"
 	filesystemAttachmentsC     = "filesystemAttachments"
@@ -570,6 +624,7 @@ const (
 	leasesC                    = "leases"
 	leaseHoldersC              = "leaseholders"
 	machinesC                  = "machines"
+	machineNetworkStatusC      = "machineNetworkStatus"
 	machineRemovalsC           = "machineremovals"
 	machineUpgradeSeriesLocksC = "machineUpgradeSeriesLocks"
 	meterStatusC               = "meterStatus"
@@ -615,7 +670,11 @@ const (
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
 	unitsC                     = "units"
+	unitOperationsC            = "unitoperations"
+	unitHookQueuesC            = "unithookqueues"
+	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
+	upgradeStepsC              = "upgradeSteps"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
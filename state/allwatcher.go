@@ -254,6 +254,7 @@ func (m *backingMachine) updated(st *State, store *multiwatcherStore, id string)
 		SupportedContainersKnown: m.SupportedContainersKnown,
 		HasVote:                  m.HasVote,
 		WantsVote:                wantsVote,
+		Labels:                   copyLabels(m.Labels),
 	}
 	addresses := network.MergedAddresses(networkAddresses(m.MachineAddresses), networkAddresses(m.Addresses))
 	for _, addr := range addresses {
@@ -439,6 +440,7 @@ func (u *backingUnit) updated(st *State, store *multiwatcherStore, id string) er
 		MachineId:   u.MachineId,
 		Principal:   u.Principal,
 		Subordinate: u.Principal != "",
+		Labels:      copyLabels(u.Labels),
 	}
 	if u.CharmURL != nil {
 		info.CharmURL = u.CharmURL.String()
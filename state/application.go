@@ -285,7 +285,13 @@ func (op *DestroyApplicationOperation) Build(attempt int) ([]txn.Op, error) {
 // Done is part of the ModelOperation interface.
 func (op *DestroyApplicationOperation) Done(err error) error {
 	if err == nil {
-		return err
+		if err := eraseCredentialAccesses(op.app.st, op.app.Name()); err != nil {
+			if !op.Force {
+				logger.Errorf("cannot delete credential access history for application %q: %v", op.app.Name(), err)
+			}
+			op.AddError(errors.Errorf("force erase application's %q credential access history proceeded despite encountering ERROR %v", op.app.Name(), err))
+		}
+		return nil
 	}
 	connected, err2 := applicationHasConnectedOffers(op.app.st, op.app.Name())
 	if err2 != nil {
@@ -827,12 +833,66 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 	return ops, nil
 }
 
+// validateConfigMap checks that the config map renames options of
+// the current charm to options of the same type in the new charm,
+// and that no two options are renamed to the same one.
+func validateConfigMap(currentConfig, newConfig *charm.Config, configMap map[string]string) error {
+	from := make([]string, 0, len(configMap))
+	for name := range configMap {
+		from = append(from, name)
+	}
+	sort.Strings(from)
+	renamedFrom := make(map[string]string)
+	for _, oldName := range from {
+		newName := configMap[oldName]
+		oldOption, ok := currentConfig.Options[oldName]
+		if !ok {
+			return errors.Errorf("option %q not defined by current charm", oldName)
+		}
+		newOption, ok := newConfig.Options[newName]
+		if !ok {
+			return errors.Errorf("option %q not defined by new charm", newName)
+		}
+		if oldOption.Type != newOption.Type {
+			return errors.Errorf("cannot rename %s option %q to %s option %q",
+				oldOption.Type, oldName, newOption.Type, newName)
+		}
+		if other, ok := renamedFrom[newName]; ok {
+			return errors.Errorf("cannot rename both %q and %q to %q", other, oldName, newName)
+		}
+		renamedFrom[newName] = oldName
+	}
+	return nil
+}
+
+// renameSettings returns a copy of the settings in which the values
+// of the options named by the keys of the config map are moved to
+// the options named by its values.
+func renameSettings(settings map[string]interface{}, configMap map[string]string) map[string]interface{} {
+	if len(configMap) == 0 {
+		return settings
+	}
+	renamed := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		if _, ok := configMap[name]; !ok {
+			renamed[name] = value
+		}
+	}
+	for oldName, newName := range configMap {
+		if value, ok := settings[oldName]; ok {
+			renamed[newName] = value
+		}
+	}
+	return renamed
+}
+
 // changeCharmOps returns the operations necessary to set a application's
 // charm URL to a new value.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
 	updatedSettings charm.Settings,
+	configMap map[string]string,
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
@@ -841,8 +901,10 @@ func (a *Application) changeCharmOps(
 	var newSettings charm.Settings
 	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
 	if err == nil {
-		// Filter the old settings through to get the new settings.
-		newSettings = ch.Config().FilterSettings(oldKey.Map())
+		// Filter the old settings through to get the new settings,
+		// carrying the values of renamed options over to their new
+		// names.
+		newSettings = ch.Config().FilterSettings(renameSettings(oldKey.Map(), configMap))
 		for k, v := range updatedSettings {
 			newSettings[k] = v
 		}
@@ -1183,6 +1245,12 @@ type SetCharmConfig struct {
 	// the charm.
 	ConfigSettings charm.Settings
 
+	// ConfigMap maps the names of config options of the current charm
+	// to the names of the new charm's options that their values are
+	// carried over to, for options which have been renamed. Any values
+	// in ConfigSettings take precedence.
+	ConfigMap map[string]string
+
 	// ForceUnits forces the upgrade on units in an error state.
 	ForceUnits bool
 
@@ -1278,6 +1346,9 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if err := validateConfigMap(currentCharm.Config(), cfg.Charm.Config(), cfg.ConfigMap); err != nil {
+		return errors.Annotate(err, "validating config map")
+	}
 
 	// we don't need to check that this is a charm.LXDProfiler, as we can
 	// state that the function exists.
@@ -1338,6 +1409,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				cfg.Charm,
 				channel,
 				updatedSettings,
+				cfg.ConfigMap,
 				cfg.ForceUnits,
 				cfg.ResourceIDs,
 				cfg.StorageConstraints,
@@ -1519,6 +1591,10 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 				return nil, errors.NotValidf("cannot remove more units than currently exist")
 			}
 		}
+		quotaOps, err := a.scaleQuotaOps(newScale)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		ops := []txn.Op{{
 			C:  applicationsC,
 			Id: a.doc.DocID,
@@ -1530,6 +1606,7 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 			},
 			Update: bson.D{{"$set", bson.D{{"scale", newScale}}}},
 		}}
+		ops = append(ops, quotaOps...)
 
 		cloudSvcDoc := cloudServiceDoc{
 			DocID:                 a.globalKey(),
@@ -1543,6 +1620,9 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 		return ops, nil
 	}
 	if err := a.st.db().Run(buildTxn); err != nil {
+		if IsQuotaExceededError(err) {
+			return a.doc.DesiredScale, errors.Annotatef(err, "cannot set scale for application %q to %v", a, newScale)
+		}
 		return a.doc.DesiredScale, errors.Errorf("cannot set scale for application %q to %v: %v", a, newScale, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.DesiredScale = newScale
@@ -1586,6 +1666,16 @@ func (a *Application) SetScale(scale int, generation int64, force bool) error {
 				return nil, applicationNotAliveErr
 			}
 		}
+		var quotaOps []txn.Op
+		if force {
+			// Only scaling requested by the user is limited
+			// by the model's quotas; the cluster's scale
+			// reflects the units which already exist.
+			var err error
+			if quotaOps, err = a.scaleQuotaOps(scale); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		ops := []txn.Op{{
 			C:  applicationsC,
 			Id: a.doc.DocID,
@@ -1596,6 +1686,7 @@ func (a *Application) SetScale(scale int, generation int64, force bool) error {
 			},
 			Update: bson.D{{"$set", bson.D{{"scale", scale}}}},
 		}}
+		ops = append(ops, quotaOps...)
 		cloudSvcDoc := cloudServiceDoc{
 			DocID: a.globalKey(),
 		}
@@ -1614,12 +1705,34 @@ func (a *Application) SetScale(scale int, generation int64, force bool) error {
 		return ops, nil
 	}
 	if err := a.st.db().Run(buildTxn); err != nil {
+		if IsQuotaExceededError(err) {
+			return errors.Annotatef(err, "cannot set scale for application %q to %v", a, scale)
+		}
 		return errors.Errorf("cannot set scale for application %q to %v: %v", a, scale, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.DesiredScale = scale
 	return nil
 }
 
+// scaleQuotaOps returns ops enforcing the model's quotas on the units,
+// and their storage, which are to be added to bring the application
+// up to the given scale.
+func (a *Application) scaleQuotaOps(scale int) ([]txn.Op, error) {
+	adding := scale - a.doc.UnitCount
+	if adding <= 0 {
+		return nil, nil
+	}
+	storageCons, err := a.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops, _, err := a.st.modelQuotaOps(ModelQuotaUsage{
+		Units:   adding,
+		Storage: adding * storageQuotaUsage(storageCons),
+	})
+	return ops, errors.Trace(err)
+}
+
 // newUnitName returns the next unit name.
 func (a *Application) newUnitName() (string, error) {
 	unitSeq, err := sequence(a.st, a.Tag().String())
@@ -1984,12 +2097,20 @@ type AddUnitParams struct {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
+	storageCons, err := a.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	name, ops, err := a.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+	err = a.st.runTransactionWithQuotas(ops, ModelQuotaUsage{
+		Units:   1,
+		Storage: storageQuotaUsage(storageCons),
+	})
+	if err == txn.ErrAborted {
 		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
 			return nil, err
 		} else if !alive {
@@ -2488,6 +2609,43 @@ func (a *Application) defaultEndpointBindings() (map[string]string, error) {
 	return DefaultEndpointBindingsForCharm(appCharm.Meta()), nil
 }
 
+// MergeBindings merges the given endpoint bindings, which map endpoint
+// names to space names, with the application's existing bindings. An
+// empty endpoint name sets the application's default space, which
+// applies to all endpoints not bound explicitly. The merged bindings
+// are validated against the application's current charm.
+func (a *Application) MergeBindings(bindings map[string]string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), bindings, ch.Meta())
+		if err == jujutxn.ErrNoOperations {
+			return nil, err
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"charmurl", a.doc.CharmURL}),
+		}, bindingsOp}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot merge endpoint bindings for application %q", a.doc.Name)
+	}
+	return nil
+}
+
 // MetricCredentials returns any metric credentials associated with this application.
 func (a *Application) MetricCredentials() []byte {
 	return a.doc.MetricCredentials
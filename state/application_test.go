@@ -22,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -339,6 +340,91 @@ func (s *ApplicationSuite) TestSetCharmCharmSettingsInvalid(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-2": validating config settings: option "key" expected string, got 123.45`)
 }
 
+var renamedStringConfig = `
+options:
+  new-key: {default: My Key, description: Desc, type: string}
+  other: {default: None, description: My Other, type: string}
+  count: {default: 1, description: Count, type: int}
+`
+
+func (s *ApplicationSuite) TestSetCharmConfigMap(c *gc.C) {
+	newCh := s.AddConfigCharm(c, "mysql", newStringConfig, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          newCh,
+		ConfigSettings: charm.Settings{"key": "value", "other": "one"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCh = s.AddConfigCharm(c, "mysql", renamedStringConfig, 3)
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:     newCh,
+		ConfigMap: map[string]string{"key": "new-key"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.mysql.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, s.combinedSettings(newCh, charm.Settings{
+		"new-key": "value",
+		"other":   "one",
+	}))
+}
+
+func (s *ApplicationSuite) TestSetCharmConfigMapSettingsTakePrecedence(c *gc.C) {
+	newCh := s.AddConfigCharm(c, "mysql", newStringConfig, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          newCh,
+		ConfigSettings: charm.Settings{"key": "value", "other": "one"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCh = s.AddConfigCharm(c, "mysql", renamedStringConfig, 3)
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          newCh,
+		ConfigSettings: charm.Settings{"new-key": "explicit"},
+		ConfigMap:      map[string]string{"key": "new-key", "other": "other"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.mysql.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, s.combinedSettings(newCh, charm.Settings{
+		"new-key": "explicit",
+		"other":   "one",
+	}))
+}
+
+func (s *ApplicationSuite) TestSetCharmConfigMapInvalid(c *gc.C) {
+	newCh := s.AddConfigCharm(c, "mysql", newStringConfig, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: newCh})
+	c.Assert(err, jc.ErrorIsNil)
+	newCh = s.AddConfigCharm(c, "mysql", renamedStringConfig, 3)
+
+	for i, test := range []struct {
+		configMap map[string]string
+		err       string
+	}{{
+		configMap: map[string]string{"missing": "new-key"},
+		err:       `option "missing" not defined by current charm`,
+	}, {
+		configMap: map[string]string{"key": "missing"},
+		err:       `option "missing" not defined by new charm`,
+	}, {
+		configMap: map[string]string{"key": "count"},
+		err:       `cannot rename string option "key" to int option "count"`,
+	}, {
+		configMap: map[string]string{"key": "new-key", "other": "new-key"},
+		err:       `cannot rename both "key" and "other" to "new-key"`,
+	}} {
+		c.Logf("test %d: %v", i, test.configMap)
+		err := s.mysql.SetCharm(state.SetCharmConfig{
+			Charm:     newCh,
+			ConfigMap: test.configMap,
+		})
+		c.Check(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-3": validating config map: `+test.err)
+	}
+}
+
 func (s *ApplicationSuite) TestSetCharmLegacy(c *gc.C) {
 	chDifferentSeries := state.AddTestingCharmForSeries(c, s.State, "precise", "mysql")
 
@@ -2654,6 +2740,28 @@ func (s *ApplicationSuite) TestSetUnsupportedConstraintsWarning(c *gc.C) {
 	c.Assert(scons, gc.DeepEquals, cons)
 }
 
+func (s *ApplicationSuite) TestWatchConstraints(c *gc.C) {
+	w := s.mysql.WatchConstraints()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.SetConstraints(constraints.MustParse("cpu-quota=50"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Other applications' constraints are not reported.
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err = wordpress.SetConstraints(constraints.MustParse("mem-limit=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *ApplicationSuite) TestConstraintsLifecycle(c *gc.C) {
 	// Dying.
 	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -3350,6 +3458,65 @@ func (s *ApplicationSuite) TestEndpointBindingsWithExplictOverrides(c *gc.C) {
 	s.assertApplicationRemovedWithItsBindings(c, application)
 }
 
+func (s *ApplicationSuite) TestMergeBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("ha", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, map[string]string{
+		"server": "db",
+	})
+
+	err = application.MergeBindings(map[string]string{
+		"server":  "ha",
+		"cluster": "db",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	setBindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(setBindings, jc.DeepEquals, map[string]string{
+		"server":  "ha",
+		"client":  "",
+		"cluster": "db",
+	})
+
+	// Merging the same bindings again is a no-op.
+	err = application.MergeBindings(map[string]string{"server": "ha"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestMergeBindingsUnknownSpace(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err := application.MergeBindings(map[string]string{"server": "nowhere"})
+	c.Assert(err, gc.ErrorMatches, `cannot merge endpoint bindings for application "yoursql": unknown space "nowhere" not valid`)
+}
+
+func (s *ApplicationSuite) TestMergeBindingsUnknownEndpoint(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+
+	err := application.MergeBindings(map[string]string{"nonsense": ""})
+	c.Assert(err, gc.ErrorMatches, `cannot merge endpoint bindings for application "yoursql": unknown endpoint "nonsense" not valid`)
+}
+
+func (s *ApplicationSuite) TestMergeBindingsApplicationNotAlive(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	application := s.AddTestingApplicationWithBindings(c, "yoursql", ch, nil)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit, gc.NotNil)
+	err = application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = application.MergeBindings(map[string]string{"server": ""})
+	c.Assert(err, gc.ErrorMatches, `cannot merge endpoint bindings for application "yoursql": application is not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestSetCharmExtraBindingsUseDefaults(c *gc.C) {
 	_, err := s.State.AddSpace("db", "", nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3509,6 +3676,24 @@ func (s *ApplicationSuite) TestWatchCharmConfig(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "sir"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "sir"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 var updateApplicationConfigTests = []struct {
 	about   string
 	initial application.ConfigAttributes
@@ -3925,6 +4110,33 @@ func (s *CAASApplicationSuite) TestSetScale(c *gc.C) {
 	c.Assert(svcInfo.Generation(), jc.DeepEquals, int64(1))
 }
 
+func (s *CAASApplicationSuite) TestSetScaleQuota(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{controller.MaxModelUnits: 2}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.SetScale(3, 0, true)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "gitlab" to 3: units quota exceeded: model has 0 of the 2 allowed by "max-model-units", cannot add 3 more`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+	err = s.app.SetScale(2, 0, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The scale reported by the cluster is not limited.
+	err = s.app.SetScale(3, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CAASApplicationSuite) TestChangeScaleQuota(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{controller.MaxModelUnits: 2}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newScale, err := s.app.ChangeScale(3)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "gitlab" to 3: units quota exceeded: model has 1 of the 2 allowed by "max-model-units", cannot add 2 more`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+	c.Assert(newScale, gc.Equals, 0)
+}
+
 func (s *CAASApplicationSuite) TestInvalidChangeScale(c *gc.C) {
 	newScale, err := s.app.ChangeScale(-1)
 	c.Assert(err, gc.ErrorMatches, "cannot remove more units than currently exist not valid")
@@ -4147,3 +4359,70 @@ func (s *ApplicationSuite) TestDeployedMachinesNotAssignedUnit(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(machines, gc.HasLen, 0)
 }
+
+func (s *ApplicationSuite) TestRecordCredentialAccess(c *gc.C) {
+	accesses, err := s.mysql.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 0)
+
+	err = s.mysql.RecordCredentialAccess("mysql/0", "install")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.RecordCredentialAccess("mysql/1", "config-changed")
+	c.Assert(err, jc.ErrorIsNil)
+
+	accesses, err = s.mysql.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 2)
+	c.Check(accesses[0].Unit, gc.Equals, "mysql/1")
+	c.Check(accesses[0].Hook, gc.Equals, "config-changed")
+	c.Check(accesses[0].Time.IsZero(), jc.IsFalse)
+	c.Check(accesses[1].Unit, gc.Equals, "mysql/0")
+	c.Check(accesses[1].Hook, gc.Equals, "install")
+
+	other := s.AddTestingApplication(c, "other", s.charm)
+	accesses, err = other.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestDestroyErasesCredentialAccesses(c *gc.C) {
+	err := s.mysql.RecordCredentialAccess("mysql/0", "install")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application is removed, so a new one takes its name.
+	mysql := s.AddTestingApplication(c, "mysql", s.charm)
+	accesses, err := mysql.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestCredentialAccessNotRecordedWhenDying(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Life(), gc.Equals, state.Dying)
+
+	err = s.mysql.RecordCredentialAccess("mysql/0", "stop")
+	c.Assert(err, jc.ErrorIsNil)
+	accesses, err := s.mysql.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestCredentialAccessesAreBounded(c *gc.C) {
+	for i := 0; i < state.MaxCredentialAccesses+5; i++ {
+		err := s.mysql.RecordCredentialAccess("mysql/0", fmt.Sprintf("hook-%d", i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	accesses, err := s.mysql.CredentialAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, state.MaxCredentialAccesses)
+	c.Assert(accesses[0].Hook, gc.Equals, fmt.Sprintf("hook-%d", state.MaxCredentialAccesses+4))
+	c.Assert(accesses[len(accesses)-1].Hook, gc.Equals, "hook-5")
+}
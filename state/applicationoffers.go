@@ -53,6 +53,14 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// AllowedConsumerCIDRs restricts consumption of the offer to
+	// consuming models whose egress networks fall within these CIDRs.
+	AllowedConsumerCIDRs []string `bson:"allowed-consumer-cidrs"`
+
+	// AllowedConsumerControllers restricts consumption of the offer to
+	// consuming models hosted by the controllers with these UUIDs.
+	AllowedConsumerControllers []string `bson:"allowed-consumer-controllers"`
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -395,7 +403,7 @@ func (s *applicationOffers) validateOfferArgs(offer crossmodel.AddApplicationOff
 			return errors.NotValidf("offer reader %q", readUser)
 		}
 	}
-	return nil
+	return crossmodel.ValidateConsumerACLs(offer.AllowedConsumerCIDRs, offer.AllowedConsumerControllers)
 }
 
 // AddOffer adds a new application offering to the directory.
@@ -545,6 +553,9 @@ func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string
 		ApplicationName:        offer.ApplicationName,
 		ApplicationDescription: offer.ApplicationDescription,
 		Endpoints:              offer.Endpoints,
+
+		AllowedConsumerCIDRs:       offer.AllowedConsumerCIDRs,
+		AllowedConsumerControllers: offer.AllowedConsumerControllers,
 	}
 	return doc
 }
@@ -729,6 +740,9 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		OfferUUID:              doc.OfferUUID,
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
+
+		AllowedConsumerCIDRs:       doc.AllowedConsumerCIDRs,
+		AllowedConsumerControllers: doc.AllowedConsumerControllers,
 	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
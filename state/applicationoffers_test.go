@@ -141,6 +141,50 @@ func (s *applicationOffersSuite) TestAddApplicationOfferBadEndpoints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *applicationOffersSuite) TestAddApplicationOfferConsumerACLs(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:                  "hosted-mysql",
+		ApplicationName:            "mysql",
+		Endpoints:                  map[string]string{"db": "server"},
+		Owner:                      owner.Name(),
+		AllowedConsumerCIDRs:       []string{"10.0.0.0/8"},
+		AllowedConsumerControllers: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	}
+	offer, err := sd.AddOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.AllowedConsumerCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	offer, err = sd.ApplicationOfferForUUID(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.AllowedConsumerCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(offer.AllowedConsumerControllers, jc.DeepEquals, []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"})
+
+	// Updating the offer may lift the restrictions.
+	args.AllowedConsumerCIDRs = nil
+	args.AllowedConsumerControllers = nil
+	_, err = sd.UpdateOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err = sd.ApplicationOfferForUUID(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.AllowedConsumerCIDRs, gc.HasLen, 0)
+	c.Assert(offer.AllowedConsumerControllers, gc.HasLen, 0)
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOfferInvalidConsumerCIDR(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	_, err := sd.AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:            "hosted-mysql",
+		ApplicationName:      "mysql",
+		Endpoints:            map[string]string{"db": "server"},
+		Owner:                owner.Name(),
+		AllowedConsumerCIDRs: []string{"10.0.0.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application offer "hosted-mysql": allowed consumer CIDR "10.0.0.1" not valid`)
+}
+
 func (s *applicationOffersSuite) TestListOffersNone(c *gc.C) {
 	sd := state.NewApplicationOffers(s.State)
 	offers, err := sd.ListOffers()
@@ -913,6 +913,22 @@ var assignUsingConstraintsTests = []struct {
 		unitConstraints:         "cpu-power=50",
 		hardwareCharacteristics: "mem=4G",
 		assignOk:                false,
+	}, {
+		unitConstraints:         "gpus=2",
+		hardwareCharacteristics: "gpus=4",
+		assignOk:                true,
+	}, {
+		unitConstraints:         "gpus=2",
+		hardwareCharacteristics: "gpus=1",
+		assignOk:                false,
+	}, {
+		unitConstraints:         "gpus=1 gpu-type=nvidia",
+		hardwareCharacteristics: "gpus=1 gpu-type=amd",
+		assignOk:                false,
+	}, {
+		unitConstraints:         "gpus=1 gpu-type=nvidia",
+		hardwareCharacteristics: "gpus=1 gpu-type=nvidia",
+		assignOk:                true,
 	}, {
 		unitConstraints:         "root-disk=8192",
 		hardwareCharacteristics: "cpu-power=50",
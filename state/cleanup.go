@@ -45,6 +45,7 @@ const (
 	cleanupAttachmentsForDyingStorage    cleanupKind = "storageAttachments"
 	cleanupAttachmentsForDyingVolume     cleanupKind = "volumeAttachments"
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
+	cleanupAttachMovedStorage            cleanupKind = "attachMovedStorage"
 	cleanupModelsForDyingController      cleanupKind = "models"
 
 	// IAAS models require machines to be cleaned up.
@@ -182,6 +183,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupAttachmentsForDyingStorage(doc.Prefix, args)
 		case cleanupAttachmentsForDyingVolume:
 			err = st.cleanupAttachmentsForDyingVolume(doc.Prefix)
+		case cleanupAttachMovedStorage:
+			err = st.cleanupAttachMovedStorage(doc.Prefix)
 		case cleanupAttachmentsForDyingFilesystem:
 			err = st.cleanupAttachmentsForDyingFilesystem(doc.Prefix)
 		case cleanupModelsForDyingController:
@@ -1451,6 +1454,17 @@ func (st *State) cleanupAttachmentsForDyingStorage(storageId string, cleanupArgs
 	return nil
 }
 
+// cleanupAttachMovedStorage attaches the specified storage instance,
+// which has been detached from its previous owner, to the unit that
+// it is being moved to.
+func (st *State) cleanupAttachMovedStorage(storageId string) error {
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return sb.attachMovedStorage(names.NewStorageTag(storageId))
+}
+
 // cleanupAttachmentsForDyingVolume sets all volume attachments related
 // to the specified volume to Dying, if they are not already Dying or
 // Dead. It's expected to be used when a volume is destroyed.
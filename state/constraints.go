@@ -17,53 +17,68 @@ import (
 
 // constraintsDoc is the mongodb representation of a constraints.Value.
 type constraintsDoc struct {
-	ModelUUID      string `bson:"model-uuid"`
-	Arch           *string
-	CpuCores       *uint64
-	CpuPower       *uint64
-	Mem            *uint64
-	RootDisk       *uint64
-	RootDiskSource *string
-	InstanceType   *string
-	Container      *instance.ContainerType
-	Tags           *[]string
-	Spaces         *[]string
-	VirtType       *string
-	Zones          *[]string
+	ModelUUID         string `bson:"model-uuid"`
+	Arch              *string
+	CpuCores          *uint64
+	CpuPower          *uint64
+	Mem               *uint64
+	RootDisk          *uint64
+	RootDiskSource    *string
+	InstanceType      *string
+	Container         *instance.ContainerType
+	Tags              *[]string
+	Spaces            *[]string
+	VirtType          *string
+	Zones             *[]string
+	GPUs              *uint64
+	GPUType           *string
+	InstanceLifecycle *string
+	CpuQuota          *uint64
+	MemLimit          *uint64
 }
 
 func (doc constraintsDoc) value() constraints.Value {
 	result := constraints.Value{
-		Arch:           doc.Arch,
-		CpuCores:       doc.CpuCores,
-		CpuPower:       doc.CpuPower,
-		Mem:            doc.Mem,
-		RootDisk:       doc.RootDisk,
-		RootDiskSource: doc.RootDiskSource,
-		InstanceType:   doc.InstanceType,
-		Container:      doc.Container,
-		Tags:           doc.Tags,
-		Spaces:         doc.Spaces,
-		VirtType:       doc.VirtType,
-		Zones:          doc.Zones,
+		Arch:              doc.Arch,
+		CpuCores:          doc.CpuCores,
+		CpuPower:          doc.CpuPower,
+		Mem:               doc.Mem,
+		RootDisk:          doc.RootDisk,
+		RootDiskSource:    doc.RootDiskSource,
+		InstanceType:      doc.InstanceType,
+		Container:         doc.Container,
+		Tags:              doc.Tags,
+		Spaces:            doc.Spaces,
+		VirtType:          doc.VirtType,
+		Zones:             doc.Zones,
+		GPUs:              doc.GPUs,
+		GPUType:           doc.GPUType,
+		InstanceLifecycle: doc.InstanceLifecycle,
+		CpuQuota:          doc.CpuQuota,
+		MemLimit:          doc.MemLimit,
 	}
 	return result
 }
 
 func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 	result := constraintsDoc{
-		Arch:           cons.Arch,
-		CpuCores:       cons.CpuCores,
-		CpuPower:       cons.CpuPower,
-		Mem:            cons.Mem,
-		RootDisk:       cons.RootDisk,
-		RootDiskSource: cons.RootDiskSource,
-		InstanceType:   cons.InstanceType,
-		Container:      cons.Container,
-		Tags:           cons.Tags,
-		Spaces:         cons.Spaces,
-		VirtType:       cons.VirtType,
-		Zones:          cons.Zones,
+		Arch:              cons.Arch,
+		CpuCores:          cons.CpuCores,
+		CpuPower:          cons.CpuPower,
+		Mem:               cons.Mem,
+		RootDisk:          cons.RootDisk,
+		RootDiskSource:    cons.RootDiskSource,
+		InstanceType:      cons.InstanceType,
+		Container:         cons.Container,
+		Tags:              cons.Tags,
+		Spaces:            cons.Spaces,
+		VirtType:          cons.VirtType,
+		Zones:             cons.Zones,
+		GPUs:              cons.GPUs,
+		GPUType:           cons.GPUType,
+		InstanceLifecycle: cons.InstanceLifecycle,
+		CpuQuota:          cons.CpuQuota,
+		MemLimit:          cons.MemLimit,
 	}
 	return result
 }
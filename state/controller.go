@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -99,15 +100,27 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	if err := st.checkValidControllerConfig(updateAttrs, removeAttrs); err != nil {
 		return errors.Trace(err)
 	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		return st.updateControllerConfigOps(func(settings *Settings) {
+			for _, r := range removeAttrs {
+				settings.Delete(r)
+			}
+			settings.Update(updateAttrs)
+		})
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
 
+// updateControllerConfigOps returns the operations needed to apply the
+// changes made by update to the current controller config. The
+// operations assert that the config has not changed since it was
+// read, and jujutxn.ErrNoOperations is returned if nothing changes.
+func (st *State) updateControllerConfigOps(update func(*Settings)) ([]txn.Op, error) {
 	settings, err := readSettings(st.db(), controllersC, controllerSettingsGlobalKey)
 	if err != nil {
-		return errors.Annotatef(err, "controller %q", st.ControllerUUID())
-	}
-	for _, r := range removeAttrs {
-		settings.Delete(r)
+		return nil, errors.Annotatef(err, "controller %q", st.ControllerUUID())
 	}
-	settings.Update(updateAttrs)
+	update(settings)
 
 	// Ensure the resulting config is still valid.
 	newValues := settings.Map()
@@ -117,11 +130,15 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 		newValues,
 	)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	_, ops := settings.settingsUpdateOps()
-	return errors.Trace(settings.write(ops))
+	if len(ops) == 0 {
+		return nil, jujutxn.ErrNoOperations
+	}
+	ops[0].Assert = bson.D{{"version", settings.version}}
+	return ops, nil
 }
 
 func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
@@ -130,12 +147,17 @@ func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{},
 			return errors.Trace(err)
 		}
 
-		if k == jujucontroller.JujuHASpace || k == jujucontroller.JujuManagementSpace {
+		if k == jujucontroller.JujuHASpace || k == jujucontroller.JujuDBSpace || k == jujucontroller.JujuManagementSpace {
 			cVal := updateAttrs[k].(string)
 			if err := st.checkSpaceIsAvailableToAllControllers(cVal); err != nil {
 				return errors.Annotatef(err, "invalid config %q=%q", k, cVal)
 			}
 		}
+		if k == jujucontroller.MongoReadPreference {
+			if err := checkReadPreferences(updateAttrs[k]); err != nil {
+				return errors.Annotatef(err, "invalid config %q", k)
+			}
+		}
 	}
 	for _, r := range removeAttrs {
 		if err := checkUpdateControllerConfig(r); err != nil {
@@ -145,6 +167,28 @@ func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{},
 	return nil
 }
 
+// checkReadPreferences returns an error if the mongo read preferences
+// would send reads of collections written by transactions to
+// secondaries.
+func checkReadPreferences(value interface{}) error {
+	v, ok := value.(string)
+	if !ok {
+		// The value's type is checked with the rest of the config.
+		return nil
+	}
+	preferences, err := jujucontroller.ParseMongoReadPreferences(v)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema := allCollections()
+	for collection, preference := range preferences {
+		if err := checkReadPreference(schema, collection, preference); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func checkUpdateControllerConfig(name string) error {
 	if !jujucontroller.ControllerOnlyAttribute(name) {
 		return errors.Errorf("unknown controller config setting %q", name)
@@ -4,12 +4,15 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
@@ -36,6 +39,9 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AllowModelAccessKey,
 		controller.MongoMemoryProfile,
 		controller.JujuHASpace,
+		controller.JujuDBSpace,
+		controller.JujuHAMemberOptions,
+		controller.JujuHARemovalTimeout,
 		controller.JujuManagementSpace,
 		controller.AuditLogExcludeMethods,
 		controller.MaxPruneTxnBatchSize,
@@ -47,8 +53,17 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.CAASOperatorImagePath,
 		controller.CAASImageRepo,
 		controller.CharmStoreURL,
+		controller.CharmStoreMirror,
+		controller.CharmTrustKeyring,
+		controller.RequireSignedCharms,
 		controller.Features,
 		controller.MeteringURL,
+		controller.ResourceScannerURL,
+		controller.ResourceScanPolicy,
+		controller.AdmissionPolicyURL,
+		controller.AdmissionFailurePolicy,
+		controller.MongoWriteConcern,
+		controller.MongoReadPreference,
 		controller.APIPortOpenDelay,
 		controller.ControllerAPIPort,
 	)
@@ -102,6 +117,83 @@ func (s *ControllerSuite) TestUpdateControllerConfig(c *gc.C) {
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestSetControllerFeature(c *gc.C) {
+	admin := names.NewUserTag("admin")
+	bob := names.NewUserTag("bob")
+	err := s.State.SetControllerFeature("foo", true, admin)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetControllerFeature("bar", true, bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
+
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetControllerFeature("foo", false, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	// Enabling an enabled flag is not recorded.
+	err = s.State.SetControllerFeature("bar", true, admin)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().SortedValues(), jc.DeepEquals, []string{"bar"})
+
+	changes, err := s.State.ControllerFeatureChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.Clock.Now()
+	c.Assert(changes, gc.HasLen, 3)
+	c.Check(changes[0].Feature, gc.Equals, "foo")
+	c.Check(changes[0].Enabled, jc.IsFalse)
+	c.Check(changes[0].User, gc.Equals, "bob")
+	c.Check(changes[0].When.Equal(now), jc.IsTrue)
+	c.Check(changes[1].Feature, gc.Equals, "bar")
+	c.Check(changes[1].Enabled, jc.IsTrue)
+	c.Check(changes[1].User, gc.Equals, "bob")
+	c.Check(changes[1].When.Equal(now.Add(-time.Minute)), jc.IsTrue)
+	c.Check(changes[2].Feature, gc.Equals, "foo")
+	c.Check(changes[2].Enabled, jc.IsTrue)
+	c.Check(changes[2].User, gc.Equals, "admin")
+}
+
+func (s *ControllerSuite) TestSetControllerFeatureDisableLast(c *gc.C) {
+	err := s.State.SetControllerFeature("foo", true, names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerFeature("foo", false, names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().IsEmpty(), jc.IsTrue)
+}
+
+func (s *ControllerSuite) TestSetControllerFeatureConcurrentChange(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.UpdateControllerConfig(map[string]interface{}{
+			controller.Features: []interface{}{"bar"},
+		}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.State.SetControllerFeature("foo", true, names.NewUserTag("admin"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
+	changes, err := s.State.ControllerFeatureChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 1)
+}
+
+func (s *ControllerSuite) TestSetControllerFeatureEmptyName(c *gc.C) {
+	err := s.State.SetControllerFeature("", true, names.NewUserTag("admin"))
+	c.Assert(err, gc.ErrorMatches, "empty feature name not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		controller.AuditingEnabled:     true,
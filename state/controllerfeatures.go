@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	jujucontroller "github.com/juju/juju/controller"
+)
+
+// ControllerFeatureChange records a controller feature flag being
+// enabled or disabled.
+type ControllerFeatureChange struct {
+	// Feature is the name of the feature flag.
+	Feature string
+
+	// Enabled is true if the flag was enabled, and false
+	// if it was disabled.
+	Enabled bool
+
+	// User is the name of the user that made the change.
+	User string
+
+	// When is when the change was made.
+	When time.Time
+}
+
+type controllerFeatureChangeDoc struct {
+	DocID   string `bson:"_id"`
+	Feature string `bson:"feature"`
+	Enabled bool   `bson:"enabled"`
+	User    string `bson:"user"`
+	When    int64  `bson:"when"`
+}
+
+// SetControllerFeature enables or disables the named feature flag in
+// the controller config, as setting the "features" controller config
+// would, and records the change along with the user that made it.
+// Nothing is changed or recorded if the flag is already in the
+// requested state.
+func (st *State) SetControllerFeature(feature string, enabled bool, user names.UserTag) error {
+	if feature == "" {
+		return errors.NotValidf("empty feature name")
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		ops, err := st.updateControllerConfigOps(func(settings *Settings) {
+			features := jujucontroller.Config(settings.Map()).Features()
+			if features.Contains(feature) == enabled {
+				return
+			}
+			if enabled {
+				features.Add(feature)
+			} else {
+				features.Remove(feature)
+			}
+			if features.IsEmpty() {
+				settings.Delete(jujucontroller.Features)
+				return
+			}
+			values := make([]interface{}, 0, features.Size())
+			for _, f := range features.SortedValues() {
+				values = append(values, f)
+			}
+			settings.Set(jujucontroller.Features, values)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return append(ops, txn.Op{
+			C:      controllerFeatureChangesC,
+			Id:     bson.NewObjectId().Hex(),
+			Assert: txn.DocMissing,
+			Insert: &controllerFeatureChangeDoc{
+				Feature: feature,
+				Enabled: enabled,
+				User:    user.Id(),
+				When:    st.clock().Now().UnixNano(),
+			},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update controller feature %q", feature)
+	}
+	return nil
+}
+
+// ControllerFeatureChanges returns the recorded changes to the
+// controller's feature flags, most recent first.
+func (st *State) ControllerFeatureChanges() ([]ControllerFeatureChange, error) {
+	coll, closer := st.db().GetCollection(controllerFeatureChangesC)
+	defer closer()
+
+	var docs []controllerFeatureChangeDoc
+	if err := coll.Find(nil).Sort("-when").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get controller feature changes")
+	}
+	changes := make([]ControllerFeatureChange, len(docs))
+	for i, doc := range docs {
+		changes[i] = ControllerFeatureChange{
+			Feature: doc.Feature,
+			Enabled: doc.Enabled,
+			User:    doc.User,
+			When:    unixNanoToTime0(doc.When),
+		}
+	}
+	return changes, nil
+}
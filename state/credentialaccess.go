@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxCredentialAccesses is the number of credential accesses retained
+// for each application. Older accesses are discarded as new ones are
+// recorded.
+const maxCredentialAccesses = 100
+
+// CredentialAccess records that a hook run by one of a trusted
+// application's units was given the model's cloud credential.
+type CredentialAccess struct {
+	// Unit is the name of the unit whose hook accessed the
+	// credential, or empty if it was accessed by the
+	// application's operator.
+	Unit string
+
+	// Hook is the name of the hook, or the action, which accessed
+	// the credential. It is empty if the agent did not report it.
+	Hook string
+
+	// Time is when the credential was accessed.
+	Time time.Time
+}
+
+type credentialAccessDoc struct {
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	Unit        string `bson:"unit,omitempty"`
+	Hook        string `bson:"hook,omitempty"`
+	Time        int64  `bson:"time"`
+}
+
+// RecordCredentialAccess records that the named hook, run by the named
+// unit of the application, was given the model's cloud credential. Only
+// the most recent accesses are retained. Accesses are not recorded once
+// the application is being destroyed, as its history has been erased.
+func (a *Application) RecordCredentialAccess(unitName, hookName string) error {
+	if a.Life() != Alive {
+		return nil
+	}
+	coll, closer := a.st.db().GetCollection(credentialAccessC)
+	defer closer()
+
+	doc := &credentialAccessDoc{
+		Application: a.Name(),
+		Unit:        unitName,
+		Hook:        hookName,
+		Time:        a.st.clock().Now().UnixNano(),
+	}
+	if err := coll.Writeable().Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record credential access for application %q", a.Name())
+	}
+	return errors.Trace(pruneCredentialAccesses(a.st, a.Name()))
+}
+
+// CredentialAccesses returns the recorded accesses to the model's cloud
+// credential by the application's hooks, most recent first.
+func (a *Application) CredentialAccesses() ([]CredentialAccess, error) {
+	coll, closer := a.st.db().GetCollection(credentialAccessC)
+	defer closer()
+
+	var docs []credentialAccessDoc
+	err := coll.Find(bson.D{{"application", a.Name()}}).Sort("-time", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get credential accesses for application %q", a.Name())
+	}
+	accesses := make([]CredentialAccess, len(docs))
+	for i, doc := range docs {
+		accesses[i] = CredentialAccess{
+			Unit: doc.Unit,
+			Hook: doc.Hook,
+			Time: unixNanoToTime0(doc.Time),
+		}
+	}
+	return accesses, nil
+}
+
+// pruneCredentialAccesses removes all but the most recent
+// maxCredentialAccesses accesses recorded for the named application.
+func pruneCredentialAccesses(mb modelBackend, appName string) error {
+	coll, closer := mb.db().GetCollection(credentialAccessC)
+	defer closer()
+
+	var stale []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"application", appName}}).
+		Sort("-time", "-_id").
+		Skip(maxCredentialAccesses).
+		Select(bson.M{"_id": 1}).
+		All(&stale)
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune credential accesses for application %q", appName)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	ids := make([]bson.ObjectId, len(stale))
+	for i, doc := range stale {
+		ids[i] = doc.ID
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{{"_id", bson.D{{"$in", ids}}}})
+	return errors.Annotatef(err, "cannot prune credential accesses for application %q", appName)
+}
+
+// eraseCredentialAccesses removes all the credential accesses recorded
+// for the named application.
+func eraseCredentialAccesses(mb modelBackend, appName string) error {
+	coll, closer := mb.db().GetCollection(credentialAccessC)
+	defer closer()
+
+	iter := coll.Find(bson.D{{"application", appName}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d credential access documents for " + fmt.Sprintf("%q", appName)
+	deleted, err := deleteInBatches(
+		coll.Writeable().Underlying(), iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
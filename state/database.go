@@ -259,6 +259,15 @@ type database struct {
 
 	// clock is used to time how long transactions take to run
 	clock clock.Clock
+
+	// sessionSettings, if non-nil, holds the write concerns and read
+	// preferences applied to the sessions used for collections.
+	sessionSettings *sessionSettings
+
+	// settingsSessions holds the copies of the database's own session
+	// that have session settings applied. It is only set if ownSession
+	// is true.
+	settingsSessions *settingsSessions
 }
 
 // RunTransactionObserverFunc is the type of a function to be called
@@ -267,7 +276,7 @@ type RunTransactionObserverFunc func(dbName, modelUUID string, ops []txn.Op, err
 
 func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 	session := db.raw.Session.Copy()
-	return &database{
+	newDB := &database{
 		raw:                    db.raw.With(session),
 		schema:                 db.schema,
 		modelUUID:              modelUUID,
@@ -275,7 +284,27 @@ func (db *database) copySession(modelUUID string) (*database, SessionCloser) {
 		ownSession:             true,
 		serverSideTransactions: db.serverSideTransactions,
 		clock:                  db.clock,
-	}, session.Close
+		sessionSettings:        db.sessionSettings,
+		settingsSessions:       newSettingsSessions(session),
+	}
+	return newDB, func() {
+		newDB.settingsSessions.close()
+		session.Close()
+	}
+}
+
+// settingsSession returns a session with the settings identified by
+// the key applied, and a closer for it. Databases that own their
+// session apply the settings once, to a copy of the session that is
+// closed with it; otherwise the session is copied as usual, and the
+// settings applied to the copy.
+func (db *database) settingsSession(key sessionKey) (*mgo.Session, SessionCloser) {
+	if db.ownSession {
+		return db.settingsSessions.get(key), dontCloseAnything
+	}
+	session := db.raw.Session.Copy()
+	applySessionKey(session, key)
+	return session, session.Close
 }
 
 // Copy is part of the Database interface.
@@ -298,19 +327,24 @@ func (db *database) GetCollection(name string) (collection mongo.Collection, clo
 		}
 	}
 
-	// Copy session if necessary.
-	if db.ownSession {
-		collection = mongo.WrapCollection(db.raw.C(name))
-		closer = dontCloseAnything
-	} else {
-		collection, closer = mongo.CollectionFromName(db.raw, name)
-	}
-
-	// Apply model filtering.
-	if !info.global {
-		collection = &modelStateCollection{
-			WriteCollection: collection.Writeable(),
-			modelUUID:       db.modelUUID,
+	// Copy session if necessary. Collections with configured session
+	// settings use a session with the settings applied, so that they
+	// do not affect the other collections sharing the database's
+	// session. Reads that may be sent to secondaries use a separate
+	// session from writes.
+	readKey, writeKey := db.sessionSettings.collectionKeys(name)
+	session, closer := db.settingsSession(writeKey)
+	collection = db.filterCollection(info, mongo.WrapCollection(db.raw.With(session).C(name)))
+	if readKey != writeKey {
+		readSession, readCloser := db.settingsSession(readKey)
+		collection = &secondaryReadCollection{
+			Collection: db.filterCollection(info, mongo.WrapCollection(db.raw.With(readSession).C(name))),
+			writeable:  collection.Writeable(),
+		}
+		writeCloser := closer
+		closer = func() {
+			readCloser()
+			writeCloser()
 		}
 	}
 
@@ -323,6 +357,18 @@ func (db *database) GetCollection(name string) (collection mongo.Collection, clo
 	return collection, closer
 }
 
+// filterCollection applies model filtering to the collection,
+// if it is not global.
+func (db *database) filterCollection(info CollectionInfo, collection mongo.Collection) mongo.Collection {
+	if info.global {
+		return collection
+	}
+	return &modelStateCollection{
+		WriteCollection: collection.Writeable(),
+		modelUUID:       db.modelUUID,
+	}
+}
+
 // GetCollectionFor is part of the Database interface.
 func (db *database) GetCollectionFor(modelUUID, name string) (mongo.Collection, SessionCloser) {
 	newDb, dbcloser := db.CopyForModel(modelUUID)
@@ -344,12 +390,9 @@ func (db *database) TransactionRunner() (runner jujutxn.Runner, closer SessionCl
 	runner = db.runner
 	closer = dontCloseAnything
 	if runner == nil {
-		raw := db.raw
-		if !db.ownSession {
-			session := raw.Session.Copy()
-			raw = raw.With(session)
-			closer = session.Close
-		}
+		var session *mgo.Session
+		session, closer = db.settingsSession(db.sessionSettings.txnKey())
+		raw := db.raw.With(session)
 		observer := func(t jujutxn.Transaction) {
 			txnLogger.Tracef("ran transaction in %.3fs (retries: %d) %# v\nerr: %v",
 				t.Duration.Seconds(), t.Attempt, pretty.Formatter(t.Ops), t.Error)
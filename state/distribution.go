@@ -9,6 +9,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 )
 
@@ -95,3 +96,66 @@ func ApplicationMachines(st *State, application string) ([]string, error) {
 	}
 	return machineIds, nil
 }
+
+// ApplicationSpread returns how the units of the specified application
+// are to be spread, and what to do when they cannot be.
+func ApplicationSpread(st *State, application string) (coreapplication.Spread, coreapplication.SpreadPolicy, error) {
+	app, err := st.Application(application)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	attrs, err := app.ApplicationConfig()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return coreapplication.SpreadFromConfig(attrs)
+}
+
+// checkHostSpread checks whether assigning the unit to the machine with
+// the specified id would place it on the same host as another unit of
+// its application, when that application is spread across hosts. If so,
+// it returns an error or logs a warning, depending on the application's
+// spread policy.
+func checkHostSpread(u *Unit, machineId string) error {
+	if !u.IsPrincipal() {
+		return nil
+	}
+	spread, policy, err := ApplicationSpread(u.st, u.doc.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spread != coreapplication.SpreadHosts {
+		return nil
+	}
+	host := TopParentId(machineId)
+	units, err := allUnits(u.st, u.doc.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, other := range units {
+		if other.Name() == u.Name() {
+			continue
+		}
+		otherMachineId, err := other.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if TopParentId(otherMachineId) != host {
+			continue
+		}
+		if policy == coreapplication.SpreadPolicyFail {
+			return errors.Errorf(
+				"cannot place unit %q on machine %s: unit %q is already on host machine %s",
+				u.Name(), machineId, other.Name(), host,
+			)
+		}
+		logger.Warningf(
+			"placing unit %q on machine %s although unit %q is already on host machine %s",
+			u.Name(), machineId, other.Name(), host,
+		)
+		return nil
+	}
+	return nil
+}
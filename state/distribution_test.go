@@ -9,7 +9,9 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs/context"
@@ -281,3 +283,78 @@ func (s *ApplicationMachinesSuite) TestApplicationMachines(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(len(machines), gc.Equals, 0)
 }
+
+type HostSpreadSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	machine   *state.Machine
+}
+
+var _ = gc.Suite(&HostSpreadSuite{})
+
+func (s *HostSpreadSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.machine, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HostSpreadSuite) setSpread(c *gc.C, spread, policy string) {
+	schema := environschema.Fields{
+		"spread":        environschema.Attr{Type: environschema.Tstring},
+		"spread-policy": environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"spread":        spread,
+		"spread-policy": policy,
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HostSpreadSuite) assignToContainer(c *gc.C) error {
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return s.State.AssignUnitWithPlacement(unit, &instance.Placement{
+		Scope:     string(instance.LXD),
+		Directive: s.machine.Id(),
+	})
+}
+
+func (s *HostSpreadSuite) TestApplicationSpreadDefaults(c *gc.C) {
+	spread, policy, err := state.ApplicationSpread(s.State, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spread, gc.Equals, coreapplication.SpreadZones)
+	c.Assert(policy, gc.Equals, coreapplication.SpreadPolicyWarn)
+}
+
+func (s *HostSpreadSuite) TestHostSpreadFail(c *gc.C) {
+	s.setSpread(c, "hosts", "fail")
+	err := s.assignToContainer(c)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot place unit "wordpress/1" on machine 0: unit "wordpress/0" is already on host machine 0`)
+	children, err := s.machine.Containers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(children, gc.HasLen, 0)
+}
+
+func (s *HostSpreadSuite) TestHostSpreadWarn(c *gc.C) {
+	s.setSpread(c, "hosts", "warn")
+	err := s.assignToContainer(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains,
+		`placing unit "wordpress/1" on machine 0 although unit "wordpress/0" is already on host machine 0`)
+}
+
+func (s *HostSpreadSuite) TestZoneSpreadIgnoresHosts(c *gc.C) {
+	s.setSpread(c, "zones", "fail")
+	err := s.assignToContainer(c)
+	c.Assert(err, jc.ErrorIsNil)
+}
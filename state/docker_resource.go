@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/mgo.v2"
@@ -33,6 +34,26 @@ type dockerMetadataDoc struct {
 
 	// Password holds the password string for a non-private image.
 	Password string `bson:"password"`
+
+	// PinnedFrom holds the registry path the image was attached with,
+	// if RegistryPath was pinned to the image's digest.
+	PinnedFrom string `bson:"pinned-from,omitempty"`
+
+	// Scan holds the result of the most recent vulnerability scan
+	// of the image, if it has been scanned.
+	Scan *imageScanDoc `bson:"scan,omitempty"`
+}
+
+// imageScanDoc records the result of scanning a Docker image for
+// vulnerabilities.
+type imageScanDoc struct {
+	ScannedAt time.Time `bson:"scanned-at"`
+	Critical  int       `bson:"critical"`
+	High      int       `bson:"high"`
+	Summary   string    `bson:"summary,omitempty"`
+	Error     string    `bson:"error,omitempty"`
+	Pending   bool      `bson:"pending,omitempty"`
+	Blocked   bool      `bson:"blocked,omitempty"`
 }
 
 // DockerMetadataStorage provides the interface for storing Docker resource-type data
@@ -40,6 +61,8 @@ type DockerMetadataStorage interface {
 	Save(resourceID string, drInfo resources.DockerImageDetails) error
 	Remove(resourceID string) error
 	Get(resourceID string) (io.ReadCloser, int64, error)
+	SetScanResult(resourceID string, result resources.ImageScanResult) error
+	ScanResult(resourceID string) (resources.ImageScanResult, error)
 }
 
 // NewDockerMetadataStorage returns a dockerMetadataStorage for persisting Docker resources.
@@ -56,6 +79,7 @@ func (dr *dockerMetadataStorage) Save(resourceID string, drInfo resources.Docker
 		RegistryPath: drInfo.RegistryPath,
 		Username:     drInfo.Username,
 		Password:     drInfo.Password,
+		PinnedFrom:   drInfo.PinnedFrom,
 	}
 
 	buildTxn := func(int) ([]txn.Op, error) {
@@ -75,8 +99,11 @@ func (dr *dockerMetadataStorage) Save(resourceID string, drInfo resources.Docker
 							{"registry-path", doc.RegistryPath},
 							{"username", doc.Username},
 							{"password", doc.Password},
+							{"pinned-from", doc.PinnedFrom},
 						},
 					},
+					// Any previous scan result was for the old image.
+					{"$unset", bson.D{{"scan", nil}}},
 				},
 			}}, nil
 		}
@@ -115,6 +142,7 @@ func (dr *dockerMetadataStorage) Get(resourceID string) (io.ReadCloser, int64, e
 			RegistryPath: doc.RegistryPath,
 			Username:     doc.Username,
 			Password:     doc.Password,
+			PinnedFrom:   doc.PinnedFrom,
 		})
 	if err != nil {
 		return nil, -1, errors.Trace(err)
@@ -124,6 +152,52 @@ func (dr *dockerMetadataStorage) Get(resourceID string) (io.ReadCloser, int64, e
 	return &dockerResourceReadCloser{infoReader}, int64(length), nil
 }
 
+// SetScanResult records the result of scanning the stored Docker
+// resource with the provided ID for vulnerabilities.
+func (dr *dockerMetadataStorage) SetScanResult(resourceID string, result resources.ImageScanResult) error {
+	ops := []txn.Op{{
+		C:      dockerResourcesC,
+		Id:     resourceID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"scan", imageScanDoc{
+			ScannedAt: result.ScannedAt,
+			Critical:  result.Critical,
+			High:      result.High,
+			Summary:   result.Summary,
+			Error:     result.Error,
+			Pending:   result.Pending,
+			Blocked:   result.Blocked,
+		}}}}},
+	}}
+	err := dr.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("Docker resource with ID: %s", resourceID)
+	}
+	return errors.Annotate(err, "failed to store Docker resource scan result")
+}
+
+// ScanResult returns the result of the most recent vulnerability scan
+// of the stored Docker resource with the provided ID. A NotFound error
+// is returned if the resource has not been scanned.
+func (dr *dockerMetadataStorage) ScanResult(resourceID string) (resources.ImageScanResult, error) {
+	doc, err := dr.get(resourceID)
+	if err != nil {
+		return resources.ImageScanResult{}, errors.Trace(err)
+	}
+	if doc.Scan == nil {
+		return resources.ImageScanResult{}, errors.NotFoundf("scan result for Docker resource with ID: %s", resourceID)
+	}
+	return resources.ImageScanResult{
+		ScannedAt: doc.Scan.ScannedAt.UTC(),
+		Critical:  doc.Scan.Critical,
+		High:      doc.Scan.High,
+		Summary:   doc.Scan.Summary,
+		Error:     doc.Scan.Error,
+		Pending:   doc.Scan.Pending,
+		Blocked:   doc.Scan.Blocked,
+	}, nil
+}
+
 func (dr *dockerMetadataStorage) get(resourceID string) (*dockerMetadataDoc, error) {
 	coll, closer := dr.st.db().GetCollection(dockerResourcesC)
 	defer closer()
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -106,6 +107,28 @@ func (s *dockerMetadataStorageSuite) TestGet(c *gc.C) {
 
 }
 
+func (s *dockerMetadataStorageSuite) TestGetPinned(c *gc.C) {
+	id := "test-123"
+	resource := resources.DockerImageDetails{
+		RegistryPath: "url@sha256:abc123",
+		PinnedFrom:   "url:latest",
+	}
+	err := s.metadataStorage.Save(id, resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrieved, _, err := s.metadataStorage.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*readerToDockerDetails(c, retrieved), jc.DeepEquals, resource)
+
+	// Attaching an image again without pinning clears the record.
+	resource.PinnedFrom = ""
+	err = s.metadataStorage.Save(id, resource)
+	c.Assert(err, jc.ErrorIsNil)
+	retrieved, _, err = s.metadataStorage.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readerToDockerDetails(c, retrieved).PinnedFrom, gc.Equals, "")
+}
+
 func (s *dockerMetadataStorageSuite) TestRemove(c *gc.C) {
 	id := "test-123"
 	resource := resources.DockerImageDetails{
@@ -120,6 +143,49 @@ func (s *dockerMetadataStorageSuite) TestRemove(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *dockerMetadataStorageSuite) TestScanResult(c *gc.C) {
+	id := "test-123"
+	err := s.metadataStorage.Save(id, resources.DockerImageDetails{
+		RegistryPath: "url@sha256:abc123",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.metadataStorage.ScanResult(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	pending := resources.ImageScanResult{Pending: true, Blocked: true}
+	err = s.metadataStorage.SetScanResult(id, pending)
+	c.Assert(err, jc.ErrorIsNil)
+	retrieved, err := s.metadataStorage.ScanResult(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrieved, jc.DeepEquals, pending)
+
+	result := resources.ImageScanResult{
+		ScannedAt: time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+		Critical:  2,
+		High:      5,
+		Summary:   "2 critical, 5 high",
+	}
+	err = s.metadataStorage.SetScanResult(id, result)
+	c.Assert(err, jc.ErrorIsNil)
+	retrieved, err = s.metadataStorage.ScanResult(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrieved, jc.DeepEquals, result)
+
+	// Saving a new image discards the result for the old one.
+	err = s.metadataStorage.Save(id, resources.DockerImageDetails{
+		RegistryPath: "url@sha256:deadbeef",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.metadataStorage.ScanResult(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *dockerMetadataStorageSuite) TestSetScanResultMissingResource(c *gc.C) {
+	err := s.metadataStorage.SetScanResult("test-123", resources.ImageScanResult{Critical: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func readerToDockerDetails(c *gc.C, r io.ReadCloser) *resources.DockerImageDetails {
 	var info resources.DockerImageDetails
 	respBuf := new(bytes.Buffer)
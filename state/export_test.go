@@ -50,6 +50,10 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxUnitOperations = maxUnitOperations
+
+	MaxCredentialAccesses = maxCredentialAccesses
 )
 
 var (
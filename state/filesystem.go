@@ -67,6 +67,10 @@ type Filesystem interface {
 	// Releasing reports whether or not the filesystem is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// Unit returns the tag of the CAAS unit whose pod most recently
+	// mounted the filesystem, and whether such a unit was recorded.
+	Unit() (names.UnitTag, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -121,6 +125,11 @@ type filesystemDoc struct {
 	// the filesystem as being non-detachable, and to determine
 	// which filesystems must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// UnitId is the ID of the CAAS unit whose pod most recently
+	// mounted the filesystem. It outlives the unit, so that
+	// filesystems left behind by deleted pods can be found.
+	UnitId string `bson:"unitid,omitempty"`
 }
 
 // filesystemAttachmentDoc records information about a filesystem attachment.
@@ -175,6 +184,9 @@ type FilesystemAttachmentInfo struct {
 	// not mounted yet.
 	MountPoint string `bson:"mountpoint"`
 	ReadOnly   bool   `bson:"read-only"`
+	// MountOptions holds the filesystem-specific options
+	// that the filesystem was mounted with, if any.
+	MountOptions []string `bson:"mount-options,omitempty"`
 }
 
 // FilesystemAttachmentParams records parameters for attaching a filesystem to a
@@ -187,6 +199,9 @@ type FilesystemAttachmentParams struct {
 	locationAutoGenerated bool
 	Location              string `bson:"location"`
 	ReadOnly              bool   `bson:"read-only"`
+	// MountOptions holds the filesystem-specific options
+	// with which the filesystem is to be mounted, if any.
+	MountOptions []string `bson:"mount-options,omitempty"`
 }
 
 // validate validates the contents of the filesystem document.
@@ -252,6 +267,14 @@ func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
 }
 
+// Unit is required to implement Filesystem.
+func (f *filesystem) Unit() (names.UnitTag, bool) {
+	if f.doc.UnitId == "" {
+		return names.UnitTag{}, false
+	}
+	return names.NewUnitTag(f.doc.UnitId), true
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return getStatus(f.mb.db(), filesystemGlobalKey(f.FilesystemTag().Id()), "filesystem")
@@ -260,7 +283,7 @@ func (f *filesystem) Status() (status.StatusInfo, error) {
 // SetStatus is required to implement StatusSetter.
 func (f *filesystem) SetStatus(fsStatus status.StatusInfo) error {
 	switch fsStatus.Status {
-	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Destroying:
+	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Resizing, status.Destroying:
 	case status.Error:
 		if fsStatus.Message == "" {
 			return errors.Errorf("cannot set status %q without info", fsStatus.Status)
@@ -1271,6 +1294,31 @@ func (sb *storageBackend) SetFilesystemInfo(tag names.FilesystemTag, info Filesy
 	return sb.mb.db().Run(buildTxn)
 }
 
+// SetFilesystemUnit records the CAAS unit whose pod has mounted
+// the specified filesystem.
+func (sb *storageBackend) SetFilesystemUnit(tag names.FilesystemTag, unitTag names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set unit for filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fs, err := getFilesystemByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fs.doc.UnitId == unitTag.Id() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if fs.doc.Life == Dead {
+			return nil, errors.Errorf("filesystem is dead")
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: notDeadDoc,
+			Update: bson.D{{"$set", bson.D{{"unitid", unitTag.Id()}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -652,6 +652,24 @@ func (s *FilesystemCAASModelSuite) TestWatchUnitFilesystems(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *FilesystemCAASModelSuite) TestSetFilesystemUnit(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+
+	_, ok := s.filesystem(c, filesystemTag).Unit()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.storageBackend.SetFilesystemUnit(filesystemTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	unitTag, ok := s.filesystem(c, filesystemTag).Unit()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(unitTag, gc.Equals, u.UnitTag())
+
+	// Setting the same unit again is a no-op.
+	err = s.storageBackend.SetFilesystemUnit(filesystemTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *FilesystemCAASModelSuite) TestWatchUnitFilesystemAttachments(c *gc.C) {
 	ch := s.AddTestingCharm(c, "storage-filesystem")
 	storage := map[string]state.StorageConstraints{
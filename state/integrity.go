@@ -0,0 +1,279 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// IntegrityIssueKind identifies a class of dangling reference that
+// may be found, and repaired, by the integrity checker.
+type IntegrityIssueKind string
+
+const (
+	// UnitMissingMachine indicates a unit that is assigned to a
+	// machine that no longer exists.
+	UnitMissingMachine IntegrityIssueKind = "unit-missing-machine"
+
+	// RelationMissingApplication indicates a relation with an
+	// endpoint on an application that no longer exists.
+	RelationMissingApplication IntegrityIssueKind = "relation-missing-application"
+
+	// OrphanedStorageAttachment indicates a storage attachment whose
+	// unit or storage instance no longer exists.
+	OrphanedStorageAttachment IntegrityIssueKind = "orphaned-storage-attachment"
+)
+
+// AllIntegrityIssueKinds returns every kind of issue the integrity
+// checker knows how to detect and repair.
+func AllIntegrityIssueKinds() []IntegrityIssueKind {
+	return []IntegrityIssueKind{
+		UnitMissingMachine,
+		RelationMissingApplication,
+		OrphanedStorageAttachment,
+	}
+}
+
+// IntegrityIssue describes a single dangling reference in the model.
+type IntegrityIssue struct {
+	// Kind is the class of the issue.
+	Kind IntegrityIssueKind
+
+	// Entity identifies the document holding the dangling reference,
+	// eg the unit name, relation key or storage attachment id.
+	Entity string
+
+	// Reference identifies the missing document that is referred to.
+	Reference string
+
+	// Message is a human readable description of the issue.
+	Message string
+
+	ops []txn.Op
+}
+
+// CheckIntegrity scans the model for documents that refer to other
+// documents which no longer exist.
+func (st *State) CheckIntegrity() ([]IntegrityIssue, error) {
+	var issues []IntegrityIssue
+	for _, check := range []func() ([]IntegrityIssue, error){
+		st.unitsMissingMachines,
+		st.relationsMissingApplications,
+		st.orphanedStorageAttachments,
+	} {
+		found, err := check()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// RepairIntegrity repairs the issues of the specified kinds, returning
+// the issues that were repaired. If no kinds are specified, all issues
+// found are repaired.
+func (st *State) RepairIntegrity(kinds ...IntegrityIssueKind) ([]IntegrityIssue, error) {
+	wanted := set.NewStrings()
+	for _, kind := range kinds {
+		wanted.Add(string(kind))
+	}
+	issues, err := st.CheckIntegrity()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var repaired []IntegrityIssue
+	for _, issue := range issues {
+		if !wanted.IsEmpty() && !wanted.Contains(string(issue.Kind)) {
+			continue
+		}
+		// Several issues may be repaired by the same transaction, in
+		// which case only the first carries the ops.
+		if len(issue.ops) > 0 {
+			if err := st.db().RunTransaction(issue.ops); err != nil {
+				return repaired, errors.Annotatef(err, "repairing %s %q", issue.Kind, issue.Entity)
+			}
+		}
+		logger.Infof("repaired %s %q: %s", issue.Kind, issue.Entity, issue.Message)
+		repaired = append(repaired, issue)
+	}
+	return repaired, nil
+}
+
+func (st *State) existingIds(collection, field string) (set.Strings, error) {
+	coll, closer := st.db().GetCollection(collection)
+	defer closer()
+
+	var docs []bson.M
+	if err := coll.Find(nil).Select(bson.D{{field, 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading %s", collection)
+	}
+	ids := set.NewStrings()
+	for _, doc := range docs {
+		if id, ok := doc[field].(string); ok {
+			ids.Add(id)
+		}
+	}
+	return ids, nil
+}
+
+func (st *State) unitsMissingMachines() ([]IntegrityIssue, error) {
+	machines, err := st.existingIds(machinesC, "machineid")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+
+	var docs []unitDoc
+	if err := units.Find(bson.D{{"machineid", bson.D{{"$ne", ""}}}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading units")
+	}
+	var issues []IntegrityIssue
+	for _, doc := range docs {
+		if machines.Contains(doc.MachineId) {
+			continue
+		}
+		issues = append(issues, IntegrityIssue{
+			Kind:      UnitMissingMachine,
+			Entity:    doc.Name,
+			Reference: doc.MachineId,
+			Message:   fmt.Sprintf("unit %s is assigned to missing machine %s", doc.Name, doc.MachineId),
+			ops: []txn.Op{{
+				C:      unitsC,
+				Id:     doc.DocID,
+				Assert: bson.D{{"machineid", doc.MachineId}},
+				Update: bson.D{{"$set", bson.D{{"machineid", ""}}}},
+			}},
+		})
+	}
+	sortIntegrityIssues(issues)
+	return issues, nil
+}
+
+func (st *State) relationsMissingApplications() ([]IntegrityIssue, error) {
+	applications, err := st.existingIds(applicationsC, "name")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, closer := st.db().GetCollection(relationsC)
+	defer closer()
+
+	var docs []relationDoc
+	if err := relations.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading relations")
+	}
+	var issues []IntegrityIssue
+	for _, doc := range docs {
+		var missing []string
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		for _, ep := range doc.Endpoints {
+			if applications.Contains(ep.ApplicationName) {
+				ops = append(ops, txn.Op{
+					C:      applicationsC,
+					Id:     st.docID(ep.ApplicationName),
+					Assert: bson.D{{"relationcount", bson.D{{"$gt", 0}}}},
+					Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+				})
+			} else {
+				missing = append(missing, ep.ApplicationName)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		for _, name := range missing {
+			issues = append(issues, IntegrityIssue{
+				Kind:      RelationMissingApplication,
+				Entity:    doc.Key,
+				Reference: name,
+				Message:   fmt.Sprintf("relation %q refers to missing application %s", doc.Key, name),
+				ops:       ops,
+			})
+			ops = nil
+		}
+	}
+	sortIntegrityIssues(issues)
+	return issues, nil
+}
+
+func (st *State) orphanedStorageAttachments() ([]IntegrityIssue, error) {
+	units, err := st.existingIds(unitsC, "name")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instances, err := st.existingIds(storageInstancesC, "id")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments, closer := st.db().GetCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := attachments.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading storage attachments")
+	}
+	var issues []IntegrityIssue
+	for _, doc := range docs {
+		unitExists := units.Contains(doc.Unit)
+		instanceExists := instances.Contains(doc.StorageInstance)
+		if unitExists && instanceExists {
+			continue
+		}
+		ops := []txn.Op{{
+			C:      storageAttachmentsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		reference := doc.Unit
+		if unitExists {
+			reference = doc.StorageInstance
+			ops = append(ops, txn.Op{
+				C:      unitsC,
+				Id:     st.docID(doc.Unit),
+				Assert: bson.D{{"storageattachmentcount", bson.D{{"$gt", 0}}}},
+				Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", -1}}}},
+			})
+		}
+		if instanceExists {
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     st.docID(doc.StorageInstance),
+				Assert: bson.D{{"attachmentcount", bson.D{{"$gt", 0}}}},
+				Update: bson.D{{"$inc", bson.D{{"attachmentcount", -1}}}},
+			})
+		}
+		issues = append(issues, IntegrityIssue{
+			Kind:      OrphanedStorageAttachment,
+			Entity:    st.localID(doc.DocID),
+			Reference: reference,
+			Message: fmt.Sprintf("storage attachment of %s to %s refers to missing %s",
+				doc.StorageInstance, doc.Unit, reference),
+			ops: ops,
+		})
+	}
+	sortIntegrityIssues(issues)
+	return issues, nil
+}
+
+func sortIntegrityIssues(issues []IntegrityIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Entity == issues[j].Entity {
+			return issues[i].Reference < issues[j].Reference
+		}
+		return issues[i].Entity < issues[j].Entity
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type IntegritySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntegritySuite{})
+
+func (s *IntegritySuite) removeRawDoc(c *gc.C, collection, id string) {
+	coll, closer := state.GetRawCollection(s.State, collection)
+	defer closer()
+	err := coll.RemoveId(state.DocID(s.State, id))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntegritySuite) TestCheckIntegrityNoIssues(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	issues, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestUnitMissingMachine(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	s.removeRawDoc(c, "machines", machine.Id())
+
+	issues, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Check(issues[0].Kind, gc.Equals, state.UnitMissingMachine)
+	c.Check(issues[0].Entity, gc.Equals, unit.Name())
+	c.Check(issues[0].Reference, gc.Equals, machine.Id())
+
+	repaired, err := s.State.RepairIntegrity(state.UnitMissingMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.HasLen, 1)
+
+	c.Assert(unit.Refresh(), jc.ErrorIsNil)
+	_, err = unit.AssignedMachineId()
+	c.Assert(err, jc.Satisfies, errors.IsNotAssigned)
+
+	issues, err = s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (s *IntegritySuite) TestRelationMissingApplication(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	s.removeRawDoc(c, "applications", "mysql")
+
+	issues, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Check(issues[0].Kind, gc.Equals, state.RelationMissingApplication)
+	c.Check(issues[0].Entity, gc.Equals, rel.String())
+	c.Check(issues[0].Reference, gc.Equals, "mysql")
+
+	repaired, err := s.State.RepairIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.HasLen, 1)
+
+	c.Assert(rel.Refresh(), jc.Satisfies, errors.IsNotFound)
+	c.Assert(wordpress.Refresh(), jc.ErrorIsNil)
+	c.Assert(state.RelationCount(wordpress), gc.Equals, 0)
+}
+
+func (s *IntegritySuite) TestOrphanedStorageAttachment(c *gc.C) {
+	coll, closer := state.GetRawCollection(s.State, "storageattachments")
+	defer closer()
+	err := coll.Insert(bson.M{
+		"_id":        state.DocID(s.State, "u#ghost/0#data/0"),
+		"model-uuid": s.State.ModelUUID(),
+		"unitid":     "ghost/0",
+		"storageid":  "data/0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	issues, err := s.State.CheckIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Check(issues[0].Kind, gc.Equals, state.OrphanedStorageAttachment)
+	c.Check(issues[0].Entity, gc.Equals, "u#ghost/0#data/0")
+	c.Check(issues[0].Reference, gc.Equals, "ghost/0")
+
+	// Repairing other kinds leaves the attachment in place.
+	repaired, err := s.State.RepairIntegrity(state.UnitMissingMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.HasLen, 0)
+
+	repaired, err = s.State.RepairIntegrity(state.OrphanedStorageAttachment)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(repaired, gc.HasLen, 1)
+
+	count, err := coll.Find(bson.D{{"unitid", "ghost/0"}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
@@ -143,6 +143,11 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithNotifyURL queues an action with the given name and
+	// payload for this ActionReceiver, recording the URL to which a
+	// notification is posted when the action finishes.
+	AddActionWithNotifyURL(name string, payload map[string]interface{}, notifyURL string) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -221,6 +226,18 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// NotifyURL returns the URL to which a notification is posted when
+	// the action finishes, or the empty string if there is none.
+	NotifyURL() string
+
+	// Notified returns whether the notification of the action
+	// finishing has been posted.
+	Notified() bool
+
+	// SetNotified records that the notification of the action
+	// finishing has been posted.
+	SetNotified() error
 }
 
 // ApplicationEntity represents a local or remote application.
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/labels"
+)
+
+// updateLabelsOps returns the operations which set and remove the labels
+// of the machine or unit document in the collection. Each label is
+// updated individually, so concurrent updates of different labels do
+// not conflict.
+func updateLabelsOps(collection, docID string, set map[string]string, remove []string) ([]txn.Op, error) {
+	if err := labels.Validate(set); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var setFields, unsetFields bson.D
+	for key, value := range set {
+		setFields = append(setFields, bson.DocElem{Name: "labels." + key, Value: value})
+	}
+	for _, key := range remove {
+		if err := labels.ValidateKey(key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := set[key]; ok {
+			return nil, errors.Errorf("cannot both set and remove label %q", key)
+		}
+		unsetFields = append(unsetFields, bson.DocElem{Name: "labels." + key, Value: nil})
+	}
+	var update bson.D
+	if len(setFields) > 0 {
+		update = append(update, bson.DocElem{Name: "$set", Value: setFields})
+	}
+	if len(unsetFields) > 0 {
+		update = append(update, bson.DocElem{Name: "$unset", Value: unsetFields})
+	}
+	if len(update) == 0 {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      collection,
+		Id:     docID,
+		Assert: notDeadDoc,
+		Update: update,
+	}}, nil
+}
+
+// updatedLabels returns a copy of the labels, with
+// the updates made by updateLabelsOps applied.
+func updatedLabels(current, set map[string]string, remove []string) map[string]string {
+	result := copyLabels(current)
+	for key, value := range set {
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[key] = value
+	}
+	for _, key := range remove {
+		delete(result, key)
+	}
+	return result
+}
+
+func copyLabels(in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
@@ -316,6 +316,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time // Only logs before this time are returned.
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -323,7 +324,13 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// that are matched against log messages by the database.
+	IncludeMessage []string
+	ExcludeMessage []string
+
+	Oplog *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -620,8 +627,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeSel := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeSel["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeSel["$lt"] = params.EndTime.UnixNano()
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -642,6 +656,14 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.RegEx{Pattern: makeMessagePattern(params.IncludeMessage)}})
+	}
+	if len(params.ExcludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: makeMessagePattern(params.ExcludeMessage)}}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -668,6 +690,10 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+func makeMessagePattern(patterns []string) string {
+	return `(` + strings.Join(patterns, ")|(") + `)`
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -752,6 +778,23 @@ type DebugLogger interface {
 	Debugf(string, ...interface{})
 }
 
+// LogsSizeMB returns the total size, in MB, of the log collections
+// of all the models on the controller.
+func LogsSizeMB(st ControllerSessioner) (int, error) {
+	if !st.IsController() {
+		return 0, errors.Errorf("getting logs size requires a controller state")
+	}
+	session, logsDB := initLogsSessionDB(st)
+	defer session.Close()
+
+	logColls, err := getLogCollections(logsDB)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to get log collections")
+	}
+	size, err := getCollectionTotalMB(logColls)
+	return size, errors.Annotate(err, "failed to get logs size")
+}
+
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
 // removed. Further removal is also performed if the logs collection
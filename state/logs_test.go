@@ -276,6 +276,17 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestLogsSizeMB(c *gc.C) {
+	size, err := state.LogsSizeMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 0)
+
+	s.generateLogs(c, s.State, coretesting.NonZeroTime(), 20000)
+	size, err = state.LogsSizeMB(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, jc.GreaterThan, 0)
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st)
 	defer dbLogger.Close()
@@ -390,6 +401,34 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	startT := coretesting.NonZeroTime()
+	endT := startT.Add(5 * time.Second)
+	dontWant := logTemplate{Message: "dont want"}
+	s.writeLogsT(c, s.otherUUID, startT.Add(-5*time.Second), startT.Add(-time.Millisecond), 5, dontWant)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, startT, endT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, s.otherUUID, endT, endT.Add(5*time.Second), 5, dontWant)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: startT,
+		EndTime:   endT,
+		NoTail:    true,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -743,6 +782,28 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	timeout := logTemplate{Message: "connection timed out after 30s"}
+	refused := logTemplate{Message: "connection refused"}
+	retry := logTemplate{Message: "connection timed out, retrying"}
+	other := logTemplate{Message: "all is well"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, timeout)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, refused)
+		s.writeLogs(c, s.otherUUID, 1, retry)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"timed out after [0-9]+s", "^connection refused$", "retrying"},
+		ExcludeMessage: []string{"retry"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, timeout)
+		s.assertTailer(c, tailer, 1, refused)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -140,6 +140,9 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// Labels holds the freeform labels attached to the machine.
+	Labels map[string]string `bson:"labels,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -227,6 +230,9 @@ type instanceData struct {
 	Tags           *[]string   `bson:"tags,omitempty"`
 	AvailZone      *string     `bson:"availzone,omitempty"`
 
+	GPUs    *uint64 `bson:"gpus,omitempty"`
+	GPUType *string `bson:"gputype,omitempty"`
+
 	// KeepInstance is set to true if, on machine removal from Juju,
 	// the cloud instance should be retained.
 	KeepInstance bool `bson:"keep-instance,omitempty"`
@@ -234,6 +240,11 @@ type instanceData struct {
 	// CharmProfiles contains the names of LXD profiles used by this machine.
 	// Profiles would have been defined in the charm deployed to this machine.
 	CharmProfiles []string `bson:"charm-profiles,omitempty"`
+
+	// CPUUtilization and MemoryUtilization hold the most recent
+	// resource utilization of the instance reported by the provider.
+	CPUUtilization    *float64 `bson:"cpu-utilization,omitempty"`
+	MemoryUtilization *float64 `bson:"memory-utilization,omitempty"`
 }
 
 func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteristics {
@@ -246,6 +257,8 @@ func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteri
 		CpuPower:         instData.CpuPower,
 		Tags:             instData.Tags,
 		AvailabilityZone: instData.AvailZone,
+		GPUs:             instData.GPUs,
+		GPUType:          instData.GPUType,
 	}
 }
 
@@ -315,6 +328,28 @@ func (m *Machine) SetKeepInstance(keepInstance bool) error {
 	return nil
 }
 
+// Labels returns the freeform labels attached to the machine.
+func (m *Machine) Labels() map[string]string {
+	return copyLabels(m.doc.Labels)
+}
+
+// UpdateLabels sets and removes labels of the machine,
+// leaving any other labels unchanged.
+func (m *Machine) UpdateLabels(set map[string]string, remove []string) error {
+	ops, err := updateLabelsOps(machinesC, m.doc.DocID, set, remove)
+	if err != nil {
+		return errors.Annotatef(err, "cannot update labels of machine %v", m)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot update labels of machine %v", m)
+	}
+	m.doc.Labels = updatedLabels(m.doc.Labels, set, remove)
+	return nil
+}
+
 // KeepInstance reports whether a machine, when removed from
 // Juju, will cause the corresponding cloud instance to be stopped.
 func (m *Machine) KeepInstance() (bool, error) {
@@ -338,6 +373,77 @@ func (m *Machine) CharmProfiles() ([]string, error) {
 	return instData.CharmProfiles, nil
 }
 
+// InstanceUtilization returns the most recent resource utilization
+// of the machine's instance reported by the provider.
+func (m *Machine) InstanceUtilization() (instance.Utilization, error) {
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		err = errors.NotProvisionedf("machine %v", m.Id())
+	}
+	if err != nil {
+		return instance.Utilization{}, err
+	}
+	return instanceUtilization(instData), nil
+}
+
+func instanceUtilization(instData instanceData) instance.Utilization {
+	return instance.Utilization{
+		CPUPercent:    instData.CPUUtilization,
+		MemoryPercent: instData.MemoryUtilization,
+	}
+}
+
+// SetInstanceUtilization records the resource utilization of the
+// machine's instance reported by the provider, replacing any
+// previously recorded utilization. Nothing is written if the
+// utilization has not changed.
+func (m *Machine) SetInstanceUtilization(utilization instance.Utilization) error {
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		return errors.NotProvisionedf("machine %v", m.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set instance utilization of machine %v", m)
+	}
+	if instanceUtilization(instData).Equals(utilization) {
+		return nil
+	}
+	set := bson.D{}
+	unset := bson.D{}
+	for _, field := range []struct {
+		key   string
+		value *float64
+	}{
+		{"cpu-utilization", utilization.CPUPercent},
+		{"memory-utilization", utilization.MemoryPercent},
+	} {
+		if field.value != nil {
+			set = append(set, bson.DocElem{Name: field.key, Value: *field.value})
+		} else {
+			unset = append(unset, bson.DocElem{Name: field.key, Value: nil})
+		}
+	}
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.DocElem{Name: "$set", Value: set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{Name: "$unset", Value: unset})
+	}
+	ops := []txn.Op{{
+		C:      instanceDataC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotProvisionedf("machine %v", m.Id())
+		}
+		return errors.Annotatef(err, "cannot set instance utilization of machine %v", m)
+	}
+	return nil
+}
+
 // SetCharmProfiles sets the names of the charm profiles used on a machine
 // in its instanceData.
 func (m *Machine) SetCharmProfiles(profiles []string) error {
@@ -1025,6 +1131,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeMachineNetworkStatusOp(m.globalKey()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -1429,6 +1536,9 @@ func (m *Machine) SetProvisioned(
 		CpuPower:       characteristics.CpuPower,
 		Tags:           characteristics.Tags,
 		AvailZone:      characteristics.AvailabilityZone,
+
+		GPUs:    characteristics.GPUs,
+		GPUType: characteristics.GPUType,
 	}
 
 	ops := []txn.Op{
@@ -2081,10 +2191,18 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return m.AddActionWithNotifyURL(name, payload, "")
+}
+
+// AddActionWithNotifyURL is part of the ActionReceiver interface.
+func (m *Machine) AddActionWithNotifyURL(name string, payload map[string]interface{}, notifyURL string) (Action, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
 	}
+	if name == actions.JujuUpgradePreviewActionName {
+		return nil, errors.Errorf("cannot add action %q to a machine; it only applies to units", name)
+	}
 
 	// Reject bad payloads before attempting to insert defaults.
 	err := spec.ValidateParams(payload)
@@ -2101,7 +2219,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 		return nil, errors.Trace(err)
 	}
 
-	return model.EnqueueAction(m.Tag(), name, payloadWithDefaults)
+	return model.enqueueAction(m.Tag(), name, payloadWithDefaults, notifyURL)
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -138,6 +138,73 @@ func (s *MachineSuite) TestSetKeepInstance(c *gc.C) {
 	c.Assert(keep, jc.IsTrue)
 }
 
+func (s *MachineSuite) TestSetInstanceUtilization(c *gc.C) {
+	err := s.machine.SetProvisioned("1234", "", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	utilization, err := s.machine.InstanceUtilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilization.IsEmpty(), jc.IsTrue)
+
+	cpu, mem := 12.5, 40.0
+	err = s.machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &cpu, MemoryPercent: &mem})
+	c.Assert(err, jc.ErrorIsNil)
+	utilization, err = s.machine.InstanceUtilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilization, jc.DeepEquals, instance.Utilization{CPUPercent: &cpu, MemoryPercent: &mem})
+
+	// Values no longer reported are removed.
+	err = s.machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &mem})
+	c.Assert(err, jc.ErrorIsNil)
+	utilization, err = s.machine.InstanceUtilization()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilization, jc.DeepEquals, instance.Utilization{CPUPercent: &mem})
+
+	// Unchanged utilization is not written again.
+	defer state.SetFailIfTransaction(c, s.State).Check()
+	same := mem
+	err = s.machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &same})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineSuite) TestSetInstanceUtilizationNotProvisioned(c *gc.C) {
+	cpu := 12.5
+	err := s.machine.SetInstanceUtilization(instance.Utilization{CPUPercent: &cpu})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	_, err = s.machine.InstanceUtilization()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineSuite) TestUpdateLabels(c *gc.C) {
+	c.Assert(s.machine.Labels(), gc.HasLen, 0)
+
+	err := s.machine.UpdateLabels(map[string]string{"env": "canary", "tier": "db"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Labels(), jc.DeepEquals, map[string]string{"env": "canary", "tier": "db"})
+
+	err = s.machine.UpdateLabels(map[string]string{"env": "prod"}, []string{"tier"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Labels(), jc.DeepEquals, map[string]string{"env": "prod"})
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Labels(), jc.DeepEquals, map[string]string{"env": "prod"})
+}
+
+func (s *MachineSuite) TestUpdateLabelsInvalid(c *gc.C) {
+	err := s.machine.UpdateLabels(map[string]string{"Env": "canary"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update labels of machine 1: label key "Env" not valid`)
+
+	err = s.machine.UpdateLabels(map[string]string{"env": "canary"}, []string{"env"})
+	c.Assert(err, gc.ErrorMatches, `cannot update labels of machine 1: cannot both set and remove label "env"`)
+}
+
+func (s *MachineSuite) TestUpdateLabelsDead(c *gc.C) {
+	c.Assert(s.machine.EnsureDead(), jc.ErrorIsNil)
+
+	err := s.machine.UpdateLabels(map[string]string{"env": "canary"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update labels of machine 1: not found or dead`)
+}
+
 func (s *MachineSuite) TestAddMachineInsideMachineModelDying(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2578,6 +2645,11 @@ func (s *MachineSuite) TestMachineValidActions(c *gc.C) {
 			actionName: "baiku",
 			errString:  `cannot add action "baiku" to a machine; only predefined actions allowed`,
 		},
+		{
+			actionName:   "juju-upgrade-preview",
+			givenPayload: map[string]interface{}{"charm-url": "cs:quantal/wordpress-3"},
+			errString:    `cannot add action "juju-upgrade-preview" to a machine; it only applies to units`,
+		},
 	}
 
 	for i, t := range tests {
@@ -0,0 +1,195 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MachineNetworkStatus holds the state of a machine's FAN networking,
+// as last reported by its machine agent.
+type MachineNetworkStatus struct {
+	// FanBridges holds the state of the machine's bridge
+	// for each FAN configured in the model.
+	FanBridges []FanBridgeStatus
+
+	// FanErrors holds the most recent errors hit
+	// configuring FAN networking on the machine,
+	// oldest first.
+	FanErrors []FanConfigError
+
+	// Updated is when the status was last reported. It is
+	// ignored by SetNetworkStatus, which records the time
+	// of the update itself.
+	Updated time.Time
+}
+
+// FanBridgeStatus holds the state of a machine's bridge for one FAN.
+type FanBridgeStatus struct {
+	// Name is the name of the bridge device, e.g. "fan-252".
+	Name string
+
+	// Underlay and Overlay are the CIDRs of the FAN's
+	// underlay and overlay networks.
+	Underlay string
+	Overlay  string
+
+	// LocalOverlay is the CIDR of the slice of the overlay
+	// mapped to the machine's address on the underlay. It is
+	// empty if the bridge has no address.
+	LocalOverlay string
+
+	// Present reports whether the bridge device exists.
+	Present bool
+
+	// Up reports whether the bridge device is up.
+	Up bool
+}
+
+// FanConfigError records an error hit configuring FAN networking.
+type FanConfigError struct {
+	Time    time.Time
+	Message string
+}
+
+// machineNetworkStatusDoc represents the MongoDB document that stores
+// the network status of a machine, keyed by the machine's global key.
+type machineNetworkStatusDoc struct {
+	FanBridges []fanBridgeStatusDoc `bson:"fan-bridges,omitempty"`
+	FanErrors  []fanConfigErrorDoc  `bson:"fan-errors,omitempty"`
+	Updated    int64                `bson:"updated"`
+}
+
+type fanBridgeStatusDoc struct {
+	Name         string `bson:"name"`
+	Underlay     string `bson:"underlay"`
+	Overlay      string `bson:"overlay"`
+	LocalOverlay string `bson:"local-overlay,omitempty"`
+	Present      bool   `bson:"present"`
+	Up           bool   `bson:"up"`
+}
+
+type fanConfigErrorDoc struct {
+	Time    int64  `bson:"time"`
+	Message string `bson:"message"`
+}
+
+// NetworkStatus returns the network status last reported for the
+// machine. It returns a NotFound error if none has been reported.
+func (m *Machine) NetworkStatus() (MachineNetworkStatus, error) {
+	coll, closer := m.st.db().GetCollection(machineNetworkStatusC)
+	defer closer()
+
+	var doc machineNetworkStatusDoc
+	err := coll.FindId(m.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return MachineNetworkStatus{}, errors.NotFoundf("network status for machine %q", m.Id())
+	} else if err != nil {
+		return MachineNetworkStatus{}, errors.Annotatef(err, "cannot get network status for machine %q", m.Id())
+	}
+	result := MachineNetworkStatus{
+		Updated: time.Unix(0, doc.Updated).UTC(),
+	}
+	for _, bridge := range doc.FanBridges {
+		result.FanBridges = append(result.FanBridges, FanBridgeStatus{
+			Name:         bridge.Name,
+			Underlay:     bridge.Underlay,
+			Overlay:      bridge.Overlay,
+			LocalOverlay: bridge.LocalOverlay,
+			Present:      bridge.Present,
+			Up:           bridge.Up,
+		})
+	}
+	for _, fanErr := range doc.FanErrors {
+		result.FanErrors = append(result.FanErrors, FanConfigError{
+			Time:    time.Unix(0, fanErr.Time).UTC(),
+			Message: fanErr.Message,
+		})
+	}
+	return result, nil
+}
+
+// SetNetworkStatus replaces the network status recorded for the
+// machine. The machine must not be dead.
+func (m *Machine) SetNetworkStatus(status MachineNetworkStatus) error {
+	doc := machineNetworkStatusDoc{
+		Updated: m.st.clock().Now().UnixNano(),
+	}
+	for _, bridge := range status.FanBridges {
+		doc.FanBridges = append(doc.FanBridges, fanBridgeStatusDoc{
+			Name:         bridge.Name,
+			Underlay:     bridge.Underlay,
+			Overlay:      bridge.Overlay,
+			LocalOverlay: bridge.LocalOverlay,
+			Present:      bridge.Present,
+			Up:           bridge.Up,
+		})
+	}
+	for _, fanErr := range status.FanErrors {
+		doc.FanErrors = append(doc.FanErrors, fanConfigErrorDoc{
+			Time:    fanErr.Time.UnixNano(),
+			Message: fanErr.Message,
+		})
+	}
+
+	coll, closer := m.st.db().GetCollection(machineNetworkStatusC)
+	defer closer()
+	id := m.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, errors.Errorf("machine is dead")
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		n, err := coll.FindId(id).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      machineNetworkStatusC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      machineNetworkStatusC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.D{
+				{"fan-bridges", doc.FanBridges},
+				{"fan-errors", doc.FanErrors},
+				{"updated", doc.Updated},
+			}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set network status for machine %q", m.Id())
+	}
+	return nil
+}
+
+// removeMachineNetworkStatusOp returns the operation needed to remove
+// the network status document associated with the given globalKey.
+func removeMachineNetworkStatusOp(globalKey string) txn.Op {
+	return txn.Op{
+		C:      machineNetworkStatusC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type MachineNetworkStatusSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&MachineNetworkStatusSuite{})
+
+func (s *MachineNetworkStatusSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *MachineNetworkStatusSuite) TestNetworkStatusNotFound(c *gc.C) {
+	_, err := s.machine.NetworkStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `network status for machine "0" not found`)
+}
+
+func (s *MachineNetworkStatusSuite) TestSetNetworkStatus(c *gc.C) {
+	errTime := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	status := state.MachineNetworkStatus{
+		FanBridges: []state.FanBridgeStatus{{
+			Name:         "fan-252",
+			Underlay:     "10.0.0.0/16",
+			Overlay:      "252.0.0.0/8",
+			LocalOverlay: "252.5.0.0/16",
+			Present:      true,
+			Up:           true,
+		}},
+		FanErrors: []state.FanConfigError{{
+			Time:    errTime,
+			Message: "fanatic: exit status 1",
+		}},
+	}
+	err := s.machine.SetNetworkStatus(status)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status.Updated = s.Clock.Now().UTC()
+	obtained, err := s.machine.NetworkStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, status)
+
+	// A later report replaces the earlier one entirely.
+	s.Clock.Advance(time.Minute)
+	status = state.MachineNetworkStatus{
+		FanBridges: []state.FanBridgeStatus{{
+			Name:     "fan-252",
+			Underlay: "10.0.0.0/16",
+			Overlay:  "252.0.0.0/8",
+		}},
+	}
+	err = s.machine.SetNetworkStatus(status)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status.Updated = s.Clock.Now().UTC()
+	obtained, err = s.machine.NetworkStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, status)
+}
+
+func (s *MachineNetworkStatusSuite) TestSetNetworkStatusDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetNetworkStatus(state.MachineNetworkStatus{})
+	c.Assert(err, gc.ErrorMatches, `cannot set network status for machine "0": machine is dead`)
+}
+
+func (s *MachineNetworkStatusSuite) TestRemoveMachineRemovesNetworkStatus(c *gc.C) {
+	err := s.machine.SetNetworkStatus(state.MachineNetworkStatus{
+		FanBridges: []state.FanBridgeStatus{{Name: "fan-252"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.machine.NetworkStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
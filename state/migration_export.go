@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/resource"
@@ -27,7 +28,9 @@ const maxStatusHistoryEntries = 20
 
 // ExportConfig allows certain aspects of the model to be skipped
 // during the export. The intent of this is to be able to get a partial
-// export to support other API calls, like status.
+// export to support other API calls, like status. It also allows the
+// users of the model to be renamed for the benefit of a migration
+// target controller.
 type ExportConfig struct {
 	SkipActions              bool
 	SkipAnnotations          bool
@@ -43,6 +46,11 @@ type ExportConfig struct {
 	SkipRelationData         bool
 	SkipInstanceData         bool
 	SkipApplicationOffers    bool
+
+	// UserMap maps the names of the model's owner and users to the
+	// names they are exported with. Users not in the map are exported
+	// unchanged.
+	UserMap map[string]string
 }
 
 // ExportPartial the current model for the State optionally skipping
@@ -101,7 +109,7 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		Type:               string(dbModel.Type()),
 		Cloud:              dbModel.Cloud(),
 		CloudRegion:        dbModel.CloudRegion(),
-		Owner:              dbModel.Owner(),
+		Owner:              migration.MapUser(cfg.UserMap, dbModel.Owner()),
 		Config:             modelConfig.Settings,
 		LatestToolsVersion: dbModel.LatestToolsVersion(),
 		EnvironVersion:     dbModel.EnvironVersion(),
@@ -114,7 +122,7 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 			return nil, errors.Trace(err)
 		}
 		export.model.SetCloudCredential(description.CloudCredentialArgs{
-			Owner:      credsTag.Owner(),
+			Owner:      migration.MapUser(cfg.UserMap, credsTag.Owner()),
 			Cloud:      credsTag.Cloud(),
 			Name:       credsTag.Name(),
 			AuthType:   creds.AuthType,
@@ -273,9 +281,9 @@ func (e *exporter) modelUsers() error {
 	for _, user := range users {
 		lastConn := lastConnections[strings.ToLower(user.UserName)]
 		arg := description.UserArgs{
-			Name:           user.UserTag,
+			Name:           migration.MapUser(e.cfg.UserMap, user.UserTag),
 			DisplayName:    user.DisplayName,
-			CreatedBy:      user.CreatedBy,
+			CreatedBy:      migration.MapUser(e.cfg.UserMap, user.CreatedBy),
 			DateCreated:    user.DateCreated,
 			LastConnection: lastConn,
 			Access:         string(user.Access),
@@ -554,6 +562,12 @@ func (e *exporter) newCloudInstanceArgs(data instanceData) description.CloudInst
 	if data.AvailZone != nil {
 		inst.AvailabilityZone = *data.AvailZone
 	}
+	if data.GPUs != nil {
+		inst.GPUs = *data.GPUs
+	}
+	if data.GPUType != nil {
+		inst.GPUType = *data.GPUType
+	}
 	if len(data.CharmProfiles) > 0 {
 		inst.CharmProfiles = data.CharmProfiles
 	}
@@ -1685,18 +1699,21 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		return nil
 	}
 	result := description.ConstraintsArgs{
-		Architecture:   optionalString("arch"),
-		Container:      optionalString("container"),
-		CpuCores:       optionalInt("cpucores"),
-		CpuPower:       optionalInt("cpupower"),
-		InstanceType:   optionalString("instancetype"),
-		Memory:         optionalInt("mem"),
-		RootDisk:       optionalInt("rootdisk"),
-		RootDiskSource: optionalString("rootdisksource"),
-		Spaces:         optionalStringSlice("spaces"),
-		Tags:           optionalStringSlice("tags"),
-		VirtType:       optionalString("virttype"),
-		Zones:          optionalStringSlice("zones"),
+		Architecture:      optionalString("arch"),
+		Container:         optionalString("container"),
+		CpuCores:          optionalInt("cpucores"),
+		CpuPower:          optionalInt("cpupower"),
+		InstanceType:      optionalString("instancetype"),
+		Memory:            optionalInt("mem"),
+		RootDisk:          optionalInt("rootdisk"),
+		RootDiskSource:    optionalString("rootdisksource"),
+		Spaces:            optionalStringSlice("spaces"),
+		Tags:              optionalStringSlice("tags"),
+		VirtType:          optionalString("virttype"),
+		Zones:             optionalStringSlice("zones"),
+		GPUs:              optionalInt("gpus"),
+		GPUType:           optionalString("gputype"),
+		InstanceLifecycle: optionalString("instancelifecycle"),
 	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
@@ -261,6 +261,36 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedBob.Access(), gc.Equals, "read")
 }
 
+func (s *MigrationExportSuite) TestModelUsersUserMap(c *gc.C) {
+	bobTag := names.NewUserTag("bob@external")
+	_, err := s.Model.AddUser(state.UserAccessSpec{
+		User:      bobTag,
+		CreatedBy: s.Owner,
+		Access:    permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		UserMap: map[string]string{
+			"bob@external": "bob",
+			s.Owner.Id():   "mary@external",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Owner(), gc.Equals, names.NewUserTag("mary@external"))
+	users := model.Users()
+	c.Assert(users, gc.HasLen, 2)
+	createdBy := make(map[string]string)
+	for _, user := range users {
+		createdBy[user.Name().Id()] = user.CreatedBy().Id()
+	}
+	c.Assert(createdBy, jc.DeepEquals, map[string]string{
+		"bob":           "mary@external",
+		"mary@external": "mary@external",
+	})
+}
+
 func (s *MigrationExportSuite) TestSLAs(c *gc.C) {
 	err := s.State.SetSLA("essential", "bob", []byte("creds"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -299,13 +329,25 @@ func (s *MigrationExportSuite) TestMachinesWithRootDiskSourceConstraint(c *gc.C)
 	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G root-disk-source=aldous"))
 }
 
+func (s *MigrationExportSuite) TestMachinesWithGPUConstraints(c *gc.C) {
+	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G gpus=2 gpu-type=nvidia-tesla-k80"))
+}
+
+func (s *MigrationExportSuite) TestMachinesWithInstanceLifecycleConstraint(c *gc.C) {
+	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G instance-lifecycle=preemptible"))
+}
+
 func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.Value) {
 	// Add a machine with an LXC container.
 	source := "vashti"
+	gpus := uint64(1)
+	gpuType := "nvidia-tesla-p4"
 	machine1 := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: cons,
 		Characteristics: &instance.HardwareCharacteristics{
 			RootDiskSource: &source,
+			GPUs:           &gpus,
+			GPUType:        &gpuType,
 		},
 	})
 	nested := s.Factory.MakeMachineNested(c, machine1.Id(), nil)
@@ -337,6 +379,15 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	if cons.HasRootDisk() {
 		c.Assert(constraints.RootDisk(), gc.Equals, *cons.RootDisk)
 	}
+	if cons.HasGPUs() {
+		c.Assert(constraints.GPUs(), gc.Equals, *cons.GPUs)
+	}
+	if cons.HasGPUType() {
+		c.Assert(constraints.GPUType(), gc.Equals, *cons.GPUType)
+	}
+	if cons.HasInstanceLifecycle() {
+		c.Assert(constraints.InstanceLifecycle(), gc.Equals, *cons.InstanceLifecycle)
+	}
 
 	tools, err := machine1.AgentTools()
 	c.Assert(err, jc.ErrorIsNil)
@@ -357,6 +408,8 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	instance := exported.Instance()
 	c.Assert(instance.ModificationStatus().Value(), gc.Equals, "idle")
 	c.Assert(instance.RootDiskSource(), gc.Equals, "vashti")
+	c.Assert(instance.GPUs(), gc.Equals, uint64(1))
+	c.Assert(instance.GPUType(), gc.Equals, "nvidia-tesla-p4")
 }
 
 func (s *MigrationExportSuite) TestMachineDevices(c *gc.C) {
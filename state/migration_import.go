@@ -538,6 +538,12 @@ func (i *importer) machineInstanceOp(mdoc *machineDoc, inst description.CloudIns
 	if az := inst.AvailabilityZone(); az != "" {
 		doc.AvailZone = &az
 	}
+	if gpus := inst.GPUs(); gpus != 0 {
+		doc.GPUs = &gpus
+	}
+	if gpuType := inst.GPUType(); gpuType != "" {
+		doc.GPUType = &gpuType
+	}
 	if profiles := inst.CharmProfiles(); len(profiles) > 0 {
 		doc.CharmProfiles = profiles
 	}
@@ -1606,6 +1612,15 @@ func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	if zones := cons.Zones(); len(zones) > 0 {
 		result.Zones = &zones
 	}
+	if gpus := cons.GPUs(); gpus != 0 {
+		result.GPUs = &gpus
+	}
+	if gpuType := cons.GPUType(); gpuType != "" {
+		result.GPUType = &gpuType
+	}
+	if lifecycle := cons.InstanceLifecycle(); lifecycle != "" {
+		result.InstanceLifecycle = &lifecycle
+	}
 	return result
 }
 
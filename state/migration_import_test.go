@@ -336,12 +336,16 @@ func (s *MigrationImportSuite) AssertMachineEqual(c *gc.C, newMachine, oldMachin
 
 func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	// Let's add a machine with an LXC container.
-	cons := constraints.MustParse("arch=amd64 mem=8G root-disk-source=bunyan")
+	cons := constraints.MustParse("arch=amd64 mem=8G root-disk-source=bunyan gpus=2 gpu-type=nvidia-tesla-k80")
 	source := "bunyan"
+	gpus := uint64(2)
+	gpuType := "nvidia-tesla-k80"
 	machine1 := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Constraints: cons,
 		Characteristics: &instance.HardwareCharacteristics{
 			RootDiskSource: &source,
+			GPUs:           &gpus,
+			GPUType:        &gpuType,
 		},
 	})
 	err := s.Model.SetAnnotations(machine1, testAnnotations)
@@ -396,6 +400,8 @@ func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	characteristics, err := parent.HardwareCharacteristics()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(*characteristics.RootDiskSource, gc.Equals, "bunyan")
+	c.Assert(*characteristics.GPUs, gc.Equals, uint64(2))
+	c.Assert(*characteristics.GPUType, gc.Equals, "nvidia-tesla-k80")
 }
 
 func (s *MigrationImportSuite) TestMachineDevices(c *gc.C) {
@@ -92,6 +92,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// We don't export the controller model at this stage.
 		controllersC,
 		controllerNodesC,
+		controllerFeatureChangesC,
 		// Clouds aren't migrated. They must exist in the
 		// target controller already.
 		cloudsC,
@@ -122,6 +123,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// upgradeInfoC is used to coordinate upgrades and schema migrations,
 		// and aren't needed for model migrations.
 		upgradeInfoC,
+		// upgradeStepsC records the progress of controller and
+		// agent upgrades, not migrated.
+		upgradeStepsC,
 		// Not exported, but the tools will possibly need to be either bundled
 		// with the representation or sent separately.
 		toolsmetadataC,
@@ -199,6 +203,18 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Resources are transferred separately
 		"storedResources",
+
+		// Operation history is a debugging aid that is rebuilt
+		// as units run operations in the target controller.
+		unitOperationsC,
+
+		// Pending hooks are reported afresh by the unit
+		// agents once they connect to the target controller.
+		unitHookQueuesC,
+
+		// Machine network status is reported afresh by the
+		// machine agents once they connect to the target controller.
+		machineNetworkStatusC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -218,6 +234,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// sure the leader units' leases are claimed in the target
 		// controller when leases are managed in raft.
 		leaseHoldersC,
+		// TODO(unitstate)
+		// Unit agent state needs to be exported once unit agents
+		// read their state from the controller rather than disk.
+		unitStatesC,
+		// TODO(trust)
+		// The history of hooks accessing the cloud credential
+		// should be carried over with the applications.
+		credentialAccessC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -323,6 +347,10 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Labels are not yet supported by the description package, so
+		// the migration precheck refuses models whose machines or units
+		// have them.
+		"Labels",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -351,6 +379,9 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// Utilization is refreshed by the instance poller.
+		"CPUUtilization",
+		"MemoryUtilization",
 	)
 	migrated := set.NewStrings(
 		// DocID is the model + machine id
@@ -371,6 +402,8 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		"Tags",
 		"AvailZone",
 		"CharmProfiles",
+		"GPUs",
+		"GPUType",
 	)
 	s.AssertExportedFields(c, instanceData{}, migrated.Union(ignored))
 }
@@ -424,6 +457,10 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// Labels are not yet supported by the description package, so
+		// the migration precheck refuses models whose machines or units
+		// have them.
+		"Labels",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -575,6 +612,13 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Spaces",
 		"VirtType",
 		"Zones",
+		"GPUs",
+		"GPUType",
+		"InstanceLifecycle",
+		// CpuQuota and MemLimit are not yet part of the model
+		// description, so they are not migrated.
+		"CpuQuota",
+		"MemLimit",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -776,6 +820,7 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"Life",
 		"HostId",    // recreated from pool properties
 		"Releasing", // only when dying; can't migrate dying storage
+		"UnitId",    // recorded again when the unit's pod is next reported
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
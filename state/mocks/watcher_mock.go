@@ -67,6 +67,18 @@ func (mr *MockBaseWatcherMockRecorder) Kill() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockBaseWatcher)(nil).Kill))
 }
 
+// Lapsed mocks base method
+func (m *MockBaseWatcher) Lapsed(arg0 chan<- watcher.Change) <-chan struct{} {
+	ret := m.ctrl.Call(m, "Lapsed", arg0)
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Lapsed indicates an expected call of Lapsed
+func (mr *MockBaseWatcherMockRecorder) Lapsed(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lapsed", reflect.TypeOf((*MockBaseWatcher)(nil).Lapsed), arg0)
+}
+
 // Unwatch mocks base method
 func (m *MockBaseWatcher) Unwatch(arg0 string, arg1 interface{}, arg2 chan<- watcher.Change) {
 	m.ctrl.Call(m, "Unwatch", arg0, arg1, arg2)
@@ -355,6 +355,9 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := st.checkStorageDefaultSources(updateAttrs); err != nil {
+		return errors.Trace(err)
+	}
 
 	validAttrs := validCfg.AllAttrs()
 	for k := range oldConfig.AllAttrs() {
@@ -370,6 +373,40 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 	return modelSettings.write(ops)
 }
 
+// checkStorageDefaultSources returns an error if a default storage
+// source being set names neither a storage pool nor a storage provider
+// type.
+func (st *State) checkStorageDefaultSources(updateAttrs map[string]interface{}) error {
+	if st.policy == nil {
+		// Without a policy there are no storage providers to check
+		// the sources against.
+		return nil
+	}
+	var sb *storageBackend
+	for _, key := range []string{
+		config.StorageDefaultBlockSourceKey,
+		config.StorageDefaultFilesystemSourceKey,
+	} {
+		source, _ := updateAttrs[key].(string)
+		if source == "" {
+			continue
+		}
+		if sb == nil {
+			var err error
+			if sb, err = NewStorageBackend(st); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		_, _, _, err := poolStorageProvider(sb, source)
+		if errors.IsNotFound(err) {
+			return errors.NotValidf("%s %q (no such storage pool or provider)", key, source)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 type modelConfigSourceFunc func() (attrValues, error)
 
 type modelConfigSource struct {
@@ -6,6 +6,7 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -160,10 +161,20 @@ type modelMigDoc struct {
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
 
+	// TargetUserMap holds the optional mapping of source controller
+	// users to the target controller users that replace them. It is
+	// stored as a list as user names may not be valid mongo keys.
+	TargetUserMap []modelMigUserMapDoc `bson:"target-user-map,omitempty"`
+
 	// The list of users and their access-level to the model being migrated.
 	ModelUsers []modelMigUserDoc `bson:"model-users,omitempty"`
 }
 
+type modelMigUserMapDoc struct {
+	Source string `bson:"source"`
+	Target string `bson:"target"`
+}
+
 type modelMigUserDoc struct {
 	UserID string            `bson:"user_id"`
 	Access permission.Access `bson:"access"`
@@ -288,9 +299,32 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 		AuthTag:         authTag,
 		Password:        mig.doc.TargetPassword,
 		Macaroons:       macs,
+		UserMap:         userMapFromDocs(mig.doc.TargetUserMap),
 	}, nil
 }
 
+func userMapFromDocs(docs []modelMigUserMapDoc) map[string]string {
+	if len(docs) == 0 {
+		return nil
+	}
+	userMap := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		userMap[doc.Source] = doc.Target
+	}
+	return userMap
+}
+
+func userMapToDocs(userMap map[string]string) []modelMigUserMapDoc {
+	var docs []modelMigUserMapDoc
+	for source, target := range userMap {
+		docs = append(docs, modelMigUserMapDoc{Source: source, Target: target})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Source < docs[j].Source
+	})
+	return docs
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock().Now().UnixNano()
@@ -717,6 +751,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:         spec.TargetInfo.AuthTag.String(),
 			TargetPassword:        spec.TargetInfo.Password,
 			TargetMacaroons:       macsJSON,
+			TargetUserMap:         userMapToDocs(spec.TargetInfo.UserMap),
 			ModelUsers:            userDocs,
 		}
 
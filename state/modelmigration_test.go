@@ -96,6 +96,25 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *MigrationSuite) TestCreateWithUserMap(c *gc.C) {
+	s.stdSpec.TargetInfo.UserMap = map[string]string{
+		"bob@external": "bob",
+		"mary":         "mary@external",
+	}
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := mig.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.UserMap, jc.DeepEquals, s.stdSpec.TargetInfo.UserMap)
+}
+
+func (s *MigrationSuite) TestCreateWithInvalidUserMap(c *gc.C) {
+	s.stdSpec.TargetInfo.UserMap = map[string]string{"bob": "mary", "fred": "mary"}
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(err, gc.ErrorMatches, `users "(bob|fred)" and "(bob|fred)" both mapped to "mary" in UserMap not valid`)
+}
+
 func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check := func(expected bool) {
 		isActive, err := s.State2.IsMigrationActive()
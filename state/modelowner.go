@@ -0,0 +1,176 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// TransferOwnership makes newOwner the owner of the model.
+//
+// The new owner must not already own a model with the same name.
+// If the model uses a cloud credential, credential must identify a
+// valid credential belonging to the new owner for the model's cloud,
+// and the model is switched to use it; the model would otherwise keep
+// depending on a credential that its previous owner can revoke.
+// The new owner is granted admin access to the model.
+// The previous owner retains whatever access it already had.
+// Callers are responsible for checking that both the current and the
+// new owner have consented to the transfer.
+func (m *Model) TransferOwnership(newOwner names.UserTag, credential names.CloudCredentialTag) error {
+	oldOwner := m.Owner()
+	if newOwner == oldOwner {
+		return errors.Errorf("model %q is already owned by %q", m.Name(), newOwner.Id())
+	}
+	if newOwner.IsLocal() {
+		user, err := m.st.User(newOwner)
+		if err != nil {
+			return errors.Annotatef(err, "cannot transfer model to %q", newOwner.Id())
+		}
+		if user.IsDisabled() {
+			return errors.Errorf("cannot transfer model to disabled user %q", newOwner.Id())
+		}
+	}
+	if err := m.validateTransferCredential(newOwner, credential); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if m.Owner() != oldOwner {
+				return nil, errors.Errorf("model %q owner changed during transfer", m.Name())
+			}
+			if err := m.checkTransferTargetName(newOwner); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, errors.Errorf("model %q is no longer alive", m.Name())
+		}
+		if m.MigrationMode() != MigrationModeNone {
+			return nil, errors.Errorf("model %q is being migrated", m.Name())
+		}
+
+		set := bson.D{{"owner", newOwner.Id()}}
+		if credential != (names.CloudCredentialTag{}) {
+			set = append(set, bson.DocElem{"cloud-credential", credential.Id()})
+		}
+		ops := []txn.Op{{
+			C:  modelsC,
+			Id: m.UUID(),
+			Assert: append(isAliveDoc,
+				bson.DocElem{"migration-mode", MigrationModeNone},
+				bson.DocElem{"owner", oldOwner.Id()},
+			),
+			Update: bson.D{{"$set", set}},
+		}, {
+			C:      usermodelnameC,
+			Id:     m.uniqueIndexID(),
+			Assert: txn.DocExists,
+			Remove: true,
+		},
+			createUniqueOwnerModelNameOp(newOwner, m.Name()),
+		}
+		if credential != (names.CloudCredentialTag{}) {
+			ops = append(ops, txn.Op{
+				C:      cloudCredentialsC,
+				Id:     cloudCredentialDocID(credential),
+				Assert: txn.DocExists,
+			})
+		}
+
+		accessOps, err := m.ownerAccessOps(newOwner, oldOwner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, accessOps...), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot transfer model %q to %q", m.Name(), newOwner.Id())
+	}
+	return m.Refresh()
+}
+
+// validateTransferCredential checks that the input credential may be
+// used by the model when it is owned by newOwner.
+func (m *Model) validateTransferCredential(newOwner names.UserTag, credential names.CloudCredentialTag) error {
+	_, hasCredential := m.CloudCredential()
+	if credential == (names.CloudCredentialTag{}) {
+		if hasCredential {
+			return errors.NotValidf("transferring a model that uses a cloud credential without a credential for the new owner")
+		}
+		return nil
+	}
+
+	if credential.Owner() != newOwner {
+		return errors.NotValidf("credential %q not owned by %q", credential.Id(), newOwner.Id())
+	}
+	if credential.Cloud().Id() != m.Cloud() {
+		return errors.NotValidf("credential %q for model on cloud %q", credential.Id(), m.Cloud())
+	}
+	cred, err := m.st.CloudCredential(credential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cred.IsValid() {
+		return errors.NotValidf("credential %q", credential.Id())
+	}
+	aCloud, err := m.st.Cloud(m.Cloud())
+	if err != nil {
+		return errors.Annotatef(err, "getting cloud %q", m.Cloud())
+	}
+	return errors.Trace(validateCredentialForCloud(aCloud, credential, cred))
+}
+
+// checkTransferTargetName returns an error if newOwner
+// already owns a model with the same name as this one.
+func (m *Model) checkTransferTargetName(newOwner names.UserTag) error {
+	coll, closer := m.st.db().GetCollection(usermodelnameC)
+	defer closer()
+
+	count, err := coll.FindId(userModelNameIndex(newOwner.Id(), m.Name())).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count > 0 {
+		return errors.AlreadyExistsf("model %q for %s", m.Name(), newOwner.Id())
+	}
+	return nil
+}
+
+// ownerAccessOps returns the operations required to
+// ensure that newOwner has admin access to the model.
+func (m *Model) ownerAccessOps(newOwner, grantedBy names.UserTag) ([]txn.Op, error) {
+	existing, err := m.st.UserAccess(newOwner, m.ModelTag())
+	if errors.IsNotFound(err) {
+		displayName := ""
+		if newOwner.IsLocal() {
+			user, err := m.st.User(newOwner)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			displayName = user.DisplayName()
+		}
+		return createModelUserOps(
+			m.UUID(), newOwner, grantedBy, displayName, m.st.nowToTheSecond(), permission.AdminAccess,
+		), nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if existing.Access == permission.AdminAccess {
+		return nil, nil
+	}
+	return []txn.Op{updatePermissionOp(
+		modelKey(m.UUID()), userGlobalKey(userAccessID(newOwner)), permission.AdminAccess,
+	)}, nil
+}
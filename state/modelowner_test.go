@@ -0,0 +1,149 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelOwnerSuite struct {
+	ConnSuite
+
+	bob names.UserTag
+}
+
+var _ = gc.Suite(&ModelOwnerSuite{})
+
+func (s *ModelOwnerSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.bob = s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnership(c *gc.C) {
+	oldOwner := s.Model.Owner()
+	err := s.Model.TransferOwnership(s.bob, names.CloudCredentialTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.Owner(), gc.Equals, s.bob)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Owner(), gc.Equals, s.bob)
+
+	access, err := s.State.UserAccess(s.bob, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+
+	// The previous owner keeps its access.
+	access, err = s.State.UserAccess(oldOwner, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+
+	// The model is now listed under the new owner's name.
+	uuids, err := s.State.ModelUUIDsForUser(s.bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, jc.SameContents, []string{s.Model.UUID()})
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipUpgradesExistingAccess(c *gc.C) {
+	_, err := s.Model.AddUser(state.UserAccessSpec{
+		User:      s.bob,
+		CreatedBy: s.Owner,
+		Access:    permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.TransferOwnership(s.bob, names.CloudCredentialTag{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserAccess(s.bob, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipSameOwner(c *gc.C) {
+	err := s.Model.TransferOwnership(s.Model.Owner(), names.CloudCredentialTag{})
+	c.Assert(err, gc.ErrorMatches, `model "testmodel" is already owned by "test-admin"`)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipUnknownUser(c *gc.C) {
+	err := s.Model.TransferOwnership(names.NewUserTag("mary"), names.CloudCredentialTag{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipNameInUse(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name:  s.Model.Name(),
+		Owner: s.bob,
+	})
+	defer st.Close()
+
+	err := s.Model.TransferOwnership(s.bob, names.CloudCredentialTag{})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(s.Model.Owner(), gc.Not(gc.Equals), s.bob)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipWithCredential(c *gc.C) {
+	m := s.modelWithCredential(c)
+	bobCredential := s.createCredential(c, s.bob, "bobs")
+
+	err := m.TransferOwnership(s.bob, bobCredential)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Owner(), gc.Equals, s.bob)
+	credential, ok := m.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(credential, gc.Equals, bobCredential)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipRequiresCredential(c *gc.C) {
+	m := s.modelWithCredential(c)
+
+	err := m.TransferOwnership(s.bob, names.CloudCredentialTag{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(m.Owner(), gc.Equals, s.Owner)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipCredentialNotOwned(c *gc.C) {
+	m := s.modelWithCredential(c)
+	credential, _ := m.CloudCredential()
+
+	err := m.TransferOwnership(s.bob, credential)
+	c.Assert(err, gc.ErrorMatches, `credential ".*" not owned by "bob" not valid`)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipInvalidCredential(c *gc.C) {
+	m := s.modelWithCredential(c)
+	bobCredential := s.createCredential(c, s.bob, "bobs")
+	err := s.State.InvalidateCloudCredential(bobCredential, "testing")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.TransferOwnership(s.bob, bobCredential)
+	c.Assert(err, gc.ErrorMatches, `credential "dummy/bob/bobs" not valid`)
+}
+
+func (s *ModelOwnerSuite) createCredential(c *gc.C, owner names.UserTag, name string) names.CloudCredentialTag {
+	tag := names.NewCloudCredentialTag("dummy/" + owner.Id() + "/" + name)
+	err := s.State.UpdateCloudCredential(tag, cloud.NewEmptyCredential())
+	c.Assert(err, jc.ErrorIsNil)
+	return tag
+}
+
+func (s *ModelOwnerSuite) modelWithCredential(c *gc.C) *state.Model {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name:            "credmodel",
+		Owner:           s.Owner,
+		CloudCredential: s.createCredential(c, s.Owner, "owners"),
+	})
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
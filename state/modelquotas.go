@@ -0,0 +1,233 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
+)
+
+// ModelQuotaUsage holds the numbers of the entities limited by the
+// controller's per-model quotas.
+type ModelQuotaUsage struct {
+	Machines int
+	Units    int
+	Storage  int
+}
+
+// ErrQuotaExceeded is returned when an operation would take a model
+// over one of the limits set in the controller config.
+type ErrQuotaExceeded struct {
+	// Entity is the kind of entity which is limited, eg "machines".
+	Entity string
+
+	// Key is the controller config key which sets the limit.
+	Key string
+
+	// Limit is the maximum number of entities the model may have.
+	Limit int
+
+	// Current is the number of entities the model has.
+	Current int
+
+	// Adding is the number of entities the operation would add.
+	Adding int
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf(
+		"%s quota exceeded: model has %d of the %d allowed by %q, cannot add %d more",
+		e.Entity, e.Current, e.Limit, e.Key, e.Adding,
+	)
+}
+
+// IsQuotaExceededError returns if the given error or its cause is
+// ErrQuotaExceeded.
+func IsQuotaExceededError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrQuotaExceeded)
+	return ok
+}
+
+// ModelQuotaUsage returns the numbers of alive machines, units and
+// storage instances in the model.
+func (st *State) ModelQuotaUsage() (ModelQuotaUsage, error) {
+	var usage ModelQuotaUsage
+	var err error
+	if usage.Machines, err = st.countAlive(machinesC); err != nil {
+		return ModelQuotaUsage{}, errors.Annotate(err, "cannot count machines")
+	}
+	if usage.Units, err = st.countAlive(unitsC); err != nil {
+		return ModelQuotaUsage{}, errors.Annotate(err, "cannot count units")
+	}
+	if usage.Storage, err = st.countAlive(storageInstancesC); err != nil {
+		return ModelQuotaUsage{}, errors.Annotate(err, "cannot count storage instances")
+	}
+	return usage, nil
+}
+
+func (st *State) countAlive(collName string) (int, error) {
+	coll, closer := st.db().GetCollection(collName)
+	defer closer()
+	return coll.Find(bson.D{{"life", Alive}}).Count()
+}
+
+// CheckModelQuotas returns an ErrQuotaExceeded error if adding the
+// given numbers of entities would take the model over any of the
+// per-model limits set in the controller config.
+//
+// The quotas are enforced by the transactions which add the entities;
+// CheckModelQuotas allows an operation which adds many entities to be
+// rejected before any of them are added.
+func (st *State) CheckModelQuotas(adding ModelQuotaUsage) error {
+	if adding == (ModelQuotaUsage{}) {
+		return nil
+	}
+	limits, err := st.modelQuotaLimits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.checkModelQuotas(limits, adding))
+}
+
+// modelQuotaLimits returns the per-model limits set in the
+// controller config. A limit of zero means there is no limit.
+func (st *State) modelQuotaLimits() (ModelQuotaUsage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return ModelQuotaUsage{}, errors.Trace(err)
+	}
+	return ModelQuotaUsage{
+		Machines: cfg.MaxModelMachines(),
+		Units:    cfg.MaxModelUnits(),
+		Storage:  cfg.MaxModelStorage(),
+	}, nil
+}
+
+func (st *State) checkModelQuotas(limits, adding ModelQuotaUsage) error {
+	if limits == (ModelQuotaUsage{}) {
+		return nil
+	}
+	usage, err := st.ModelQuotaUsage()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, q := range []struct {
+		entity  string
+		key     string
+		limit   int
+		current int
+		adding  int
+	}{
+		{"machines", controller.MaxModelMachines, limits.Machines, usage.Machines, adding.Machines},
+		{"units", controller.MaxModelUnits, limits.Units, usage.Units, adding.Units},
+		{"storage instances", controller.MaxModelStorage, limits.Storage, usage.Storage, adding.Storage},
+	} {
+		if q.limit > 0 && q.adding > 0 && q.current+q.adding > q.limit {
+			return &ErrQuotaExceeded{
+				Entity:  q.entity,
+				Key:     q.key,
+				Limit:   q.limit,
+				Current: q.current,
+				Adding:  q.adding,
+			}
+		}
+	}
+	return nil
+}
+
+// modelQuotaKey identifies the refcount document whose count is
+// incremented by every transaction which adds entities limited by
+// the model's quotas. Asserting the count serialises those
+// transactions, so that concurrent operations can not together take
+// the model over a quota.
+const modelQuotaKey = "modelquotas"
+
+// modelQuotaOps checks that adding the given numbers of entities would
+// not take the model over its quotas, and returns ops which assert that
+// no other limited entities have been added since they were checked.
+// The ops must be run in the transaction which adds the entities. The
+// count asserted by the ops is also returned; it is -1 if there are no
+// ops, because there are no quotas to enforce.
+func (st *State) modelQuotaOps(adding ModelQuotaUsage) ([]txn.Op, int, error) {
+	if adding == (ModelQuotaUsage{}) {
+		return nil, -1, nil
+	}
+	limits, err := st.modelQuotaLimits()
+	if err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	if limits == (ModelQuotaUsage{}) {
+		return nil, -1, nil
+	}
+
+	// The count is read before the entities are counted, so that
+	// the assertion fails if any are added after they are counted.
+	refcounts, closer := st.db().GetCollection(refcountsC)
+	defer closer()
+	var op txn.Op
+	count, err := nsRefcounts.read(refcounts, modelQuotaKey)
+	if errors.IsNotFound(err) {
+		count = 0
+		op = nsRefcounts.JustCreateOp(refcountsC, modelQuotaKey, 1)
+	} else if err != nil {
+		return nil, -1, errors.Trace(err)
+	} else {
+		op = txn.Op{
+			C:      refcountsC,
+			Id:     modelQuotaKey,
+			Assert: bson.D{{"refcount", count}},
+			Update: bson.D{{"$inc", bson.D{{"refcount", 1}}}},
+		}
+	}
+	if err := st.checkModelQuotas(limits, adding); err != nil {
+		return nil, -1, errors.Trace(err)
+	}
+	return []txn.Op{op}, count, nil
+}
+
+// runTransactionWithQuotas runs the ops, which add the given numbers
+// of entities limited by the model's quotas, along with ops enforcing
+// the quotas. If the transaction aborts because other limited entities
+// were added concurrently, the quotas are checked again and the
+// transaction retried; if it aborts for any other reason, txn.ErrAborted
+// is returned, as it is by RunTransaction.
+func (st *State) runTransactionWithQuotas(ops []txn.Op, adding ModelQuotaUsage) error {
+	lastCount := -1
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		quotaOps, count, err := st.modelQuotaOps(adding)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 && count == lastCount {
+			return nil, txn.ErrAborted
+		}
+		lastCount = count
+		return append(ops[:len(ops):len(ops)], quotaOps...), nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// storageQuotaUsage returns the number of storage instances created
+// for each unit with the given storage constraints.
+func storageQuotaUsage(cons map[string]StorageConstraints) int {
+	var n int
+	for _, c := range cons {
+		n += int(c.Count)
+	}
+	return n
+}
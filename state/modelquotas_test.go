@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+type ModelQuotasSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&ModelQuotasSuite{})
+
+func (s *ModelQuotasSuite) setQuotas(c *gc.C, attrs map[string]interface{}) {
+	err := s.State.UpdateControllerConfig(attrs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestModelQuotaUsage(c *gc.C) {
+	charm := s.AddTestingCharm(c, "storage-block2")
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:    "storage-block2",
+		Charm:   charm,
+		Storage: map[string]state.StorageConstraints{"multi1to10": makeStorageCons("loop-pool", 0, 2)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.State.ModelQuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, state.ModelQuotaUsage{
+		Machines: 1,
+		Units:    1,
+		// The charm's multi2up store requires two instances.
+		Storage: 4,
+	})
+}
+
+func (s *ModelQuotasSuite) TestNoQuotas(c *gc.C) {
+	err := s.State.CheckModelQuotas(state.ModelQuotaUsage{Machines: 1000, Units: 1000, Storage: 1000})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestCheckModelQuotas(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelMachines: 2})
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuotas(state.ModelQuotaUsage{Machines: 1, Units: 1000})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckModelQuotas(state.ModelQuotaUsage{Machines: 2})
+	c.Assert(err, gc.ErrorMatches, `machines quota exceeded: model has 1 of the 2 allowed by "max-model-machines", cannot add 2 more`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *ModelQuotasSuite) TestAddMachinesQuota(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelMachines: 1})
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: machines quota exceeded: .*`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *ModelQuotasSuite) TestAddMachinesQuotaIgnoresDeadMachines(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelMachines: 1})
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotasSuite) TestAddUnitQuota(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelUnits: 2})
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for i := 0; i < 2; i++ {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": units quota exceeded: model has 2 of the 2 allowed by "max-model-units", cannot add 1 more`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *ModelQuotasSuite) TestAddApplicationUnitsQuota(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelUnits: 2})
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:     "wordpress",
+		Charm:    s.AddTestingCharm(c, "wordpress"),
+		NumUnits: 3,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress": units quota exceeded: .*`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *ModelQuotasSuite) TestAddUnitStorageQuota(c *gc.C) {
+	// Each unit has three multi1to10 and two multi2up instances.
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelStorage: 6})
+	charm := s.AddTestingCharm(c, "storage-block2")
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:    "storage-block2",
+		Charm:   charm,
+		Storage: map[string]state.StorageConstraints{"multi1to10": makeStorageCons("loop-pool", 0, 3)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block2": storage instances quota exceeded: model has 5 of the 6 allowed by "max-model-storage", cannot add 5 more`)
+
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "multi1to10", state.StorageConstraints{Count: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "multi1to10", state.StorageConstraints{Count: 1})
+	c.Assert(err, gc.ErrorMatches, `adding "multi1to10" storage to storage-block2/0: storage instances quota exceeded: .*`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *ModelQuotasSuite) TestAddMachineQuotaConcurrentAdd(c *gc.C) {
+	s.setQuotas(c, map[string]interface{}{controller.MaxModelMachines: 1})
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: machines quota exceeded: model has 1 of the 1 allowed by "max-model-machines", cannot add 1 more`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
@@ -70,6 +70,7 @@ type ModelSummary struct {
 	MachineCount int64
 	CoreCount    int64
 	UnitCount    int64
+	StorageCount int64
 
 	// Needs Migration collection
 	// Do we need all the Migration fields?
@@ -319,6 +320,34 @@ func (p *modelSummaryProcessor) fillInApplicationSummary() error {
 	return nil
 }
 
+func (p *modelSummaryProcessor) fillInStorageSummary() error {
+	storageInstances, closer := p.st.db().GetRawCollection(storageInstancesC)
+	defer closer()
+	query := storageInstances.Find(bson.M{
+		"model-uuid": bson.M{"$in": p.modelUUIDs},
+		"life":       Alive,
+	})
+	query.Select(bson.M{"life": 1, "model-uuid": 1})
+	iter := query.Iter()
+	defer iter.Close()
+	var doc storageInstanceDoc
+	for iter.Next(&doc) {
+		if doc.Life != Alive {
+			continue
+		}
+		idx, ok := p.indexByUUID[doc.ModelUUID]
+		if !ok {
+			continue
+		}
+		details := &p.summaries[idx]
+		details.StorageCount++
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (p *modelSummaryProcessor) fillInMigration() error {
 	// For now, we just potato the Migration information. Its a little unfortunate, but the expectation is that most
 	// models won't have been migrated, and thus the table is mostly empty anyway.
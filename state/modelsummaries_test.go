@@ -462,6 +462,31 @@ func (s *ModelSummariesSuite) TestContainsMachineInformation(c *gc.C) {
 	c.Check(userSummary.CoreCount, gc.Equals, int64(0))
 }
 
+func (s *ModelSummariesSuite) TestContainsStorageInformation(c *gc.C) {
+	modelNameToUUID := s.Setup4Models(c)
+	shared, err := s.StatePool.Get(modelNameToUUID["shared"])
+	c.Assert(err, jc.ErrorIsNil)
+	defer shared.Release()
+	f := factory.NewFactory(shared.State, s.StatePool)
+	app := f.MakeApplication(c, &factory.ApplicationParams{
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"}),
+		Storage: map[string]state.StorageConstraints{
+			"data":    {Pool: "loop", Size: 1024, Count: 1},
+			"allecto": {Pool: "loop", Size: 1024, Count: 2},
+		},
+	})
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	summaryMap := s.namedSummariesForUser(c, "user1write")
+	sharedSummary := summaryMap["shared"]
+	c.Assert(sharedSummary, gc.NotNil)
+	c.Check(sharedSummary.UnitCount, gc.Equals, int64(1))
+	c.Check(sharedSummary.StorageCount, gc.Equals, int64(3))
+	userSummary := summaryMap["user1model"]
+	c.Assert(userSummary, gc.NotNil)
+	c.Check(userSummary.StorageCount, gc.Equals, int64(0))
+}
+
 func (s *ModelSummariesSuite) TestContainsMigrationInformation(c *gc.C) {
 	//modelNameToUUID := s.Setup4Models(c)
 	// TODO: Figure out how to create a multiple-attempt migration information, and assert that we expose the right info
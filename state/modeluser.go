@@ -226,6 +226,9 @@ func (st *State) ModelSummariesForUser(user names.UserTag, all bool) ([]ModelSum
 	if err := p.fillInApplicationSummary(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := p.fillInStorageSummary(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := p.fillInMigration(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -149,6 +149,7 @@ type MachineInfo struct {
 	Addresses                []Address                         `json:"addresses"`
 	HasVote                  bool                              `json:"has-vote"`
 	WantsVote                bool                              `json:"wants-vote"`
+	Labels                   map[string]string                 `json:"labels,omitempty"`
 }
 
 // EntityId returns a unique identifier for a machine across
@@ -313,6 +314,8 @@ type UnitInfo struct {
 	// Workload and agent state are modelled separately.
 	WorkloadStatus StatusInfo `json:"workload-status"`
 	AgentStatus    StatusInfo `json:"agent-status"`
+
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // EntityId returns a unique identifier for a unit across
@@ -50,6 +50,11 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// WatcherLapseObserver, if non-nil, is a function that will be
+	// called with the model UUID when the watches of a slow watcher
+	// of the model are lapsed. It is only used by a StatePool.
+	WatcherLapseObserver func(modelUUID string)
 }
 
 // Validate validates the OpenParams.
@@ -158,6 +158,7 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 			return nil, errors.Trace(mongo.MaybeUnauthorizedf(err, "cannot read model %s", args.ControllerModelTag.Id()))
 		}
 	}
+	st.watcherLapseObserver = args.WatcherLapseObserver
 	if err = st.start(args.ControllerTag, pool.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -253,6 +254,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.watcherLapseObserver = p.systemState.watcherLapseObserver
 	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,159 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// requestLogsC is the collection in the logs database holding the
+// API requests recorded by the controller's API servers.
+const requestLogsC = "requests"
+
+// RequestLogRecord holds the details of an API request served by
+// the controller.
+type RequestLogRecord struct {
+	// Time is when the request was received.
+	Time time.Time
+
+	// ModelUUID identifies the model the connection was made to. It
+	// is empty for connections to the controller.
+	ModelUUID string
+
+	// ConnectionID identifies the API connection the request was
+	// made on.
+	ConnectionID uint64
+
+	// Entity is the tag of the user or agent that made the request.
+	// It is empty for requests made before logging in.
+	Entity string
+
+	// Facade, Version and Method identify the API method called.
+	Facade  string
+	Version int
+	Method  string
+
+	// Duration is how long the request took to serve.
+	Duration time.Duration
+
+	// ErrorCode holds the code of the error returned by the request,
+	// if any. Errors without a code are recorded as "error".
+	ErrorCode string
+
+	// Category is the category of the request, as used for
+	// sampling.
+	Category string
+}
+
+type requestLogDoc struct {
+	Id           bson.ObjectId `bson:"_id"`
+	Time         int64         `bson:"t"` // unix nano UTC
+	ModelUUID    string        `bson:"model-uuid,omitempty"`
+	ConnectionID int64         `bson:"connection-id"`
+	Entity       string        `bson:"entity,omitempty"`
+	Facade       string        `bson:"facade"`
+	Version      int           `bson:"version"`
+	Method       string        `bson:"method"`
+	Duration     int64         `bson:"duration"` // nanoseconds
+	ErrorCode    string        `bson:"error-code,omitempty"`
+	Category     string        `bson:"category"`
+}
+
+// RequestLogger writes API request records to the request log
+// collection in the logs database.
+type RequestLogger struct {
+	coll *mgo.Collection
+}
+
+// NewRequestLogger returns a new RequestLogger, ensuring that the
+// request log collection is indexed by time.
+func NewRequestLogger(st MongoSessioner) (*RequestLogger, error) {
+	session, db := initLogsSessionDB(st)
+	coll := db.C(requestLogsC)
+	if err := coll.EnsureIndex(mgo.Index{Key: []string{"t"}}); err != nil {
+		session.Close()
+		return nil, errors.Annotate(err, "cannot create index for request log collection")
+	}
+	return &RequestLogger{coll: coll}, nil
+}
+
+// Log writes the request records to the database in bulk; callers
+// should buffer records and call Log with a batch to minimise
+// database writes.
+func (logger *RequestLogger) Log(records []RequestLogRecord) error {
+	bulk := logger.coll.Bulk()
+	for _, r := range records {
+		bulk.Insert(&requestLogDoc{
+			Id:           bson.NewObjectId(),
+			Time:         r.Time.UnixNano(),
+			ModelUUID:    r.ModelUUID,
+			ConnectionID: int64(r.ConnectionID),
+			Entity:       r.Entity,
+			Facade:       r.Facade,
+			Version:      r.Version,
+			Method:       r.Method,
+			Duration:     int64(r.Duration),
+			ErrorCode:    r.ErrorCode,
+			Category:     r.Category,
+		})
+	}
+	_, err := bulk.Run()
+	return errors.Annotatef(err, "inserting %d request log record(s)", len(records))
+}
+
+// Close cleans up resources used by the RequestLogger.
+func (logger *RequestLogger) Close() {
+	logger.coll.Database.Session.Close()
+}
+
+// RequestLogs returns the request records logged since the given
+// time, oldest first.
+func RequestLogs(st MongoSessioner, since time.Time) ([]RequestLogRecord, error) {
+	session, db := initLogsSessionDB(st)
+	defer session.Close()
+
+	var docs []requestLogDoc
+	query := db.C(requestLogsC).Find(bson.M{"t": bson.M{"$gte": since.UnixNano()}})
+	if err := query.Sort("t", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read request log")
+	}
+	records := make([]RequestLogRecord, len(docs))
+	for i, doc := range docs {
+		records[i] = RequestLogRecord{
+			Time:         time.Unix(0, doc.Time).UTC(),
+			ModelUUID:    doc.ModelUUID,
+			ConnectionID: uint64(doc.ConnectionID),
+			Entity:       doc.Entity,
+			Facade:       doc.Facade,
+			Version:      doc.Version,
+			Method:       doc.Method,
+			Duration:     time.Duration(doc.Duration),
+			ErrorCode:    doc.ErrorCode,
+			Category:     doc.Category,
+		}
+	}
+	return records, nil
+}
+
+// PruneRequestLogs removes the request records logged before
+// minLogTime, returning the number removed.
+func PruneRequestLogs(st ControllerSessioner, minLogTime time.Time) (int, error) {
+	if !st.IsController() {
+		return 0, errors.Errorf("pruning request logs requires a controller state")
+	}
+	session, db := initLogsSessionDB(st)
+	defer session.Close()
+
+	info, err := db.C(requestLogsC).RemoveAll(bson.M{
+		"t": bson.M{"$lt": minLogTime.UnixNano()},
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to prune request logs")
+	}
+	return info.Removed, nil
+}
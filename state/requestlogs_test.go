@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RequestLogsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RequestLogsSuite{})
+
+func (s *RequestLogsSuite) logRequests(c *gc.C, records ...state.RequestLogRecord) {
+	logger, err := state.NewRequestLogger(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	defer logger.Close()
+	err = logger.Log(records)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RequestLogsSuite) TestLogAndRead(c *gc.C) {
+	now := coretesting.NonZeroTime().Truncate(time.Millisecond).UTC()
+	records := []state.RequestLogRecord{{
+		Time:         now,
+		ModelUUID:    s.State.ModelUUID(),
+		ConnectionID: 42,
+		Entity:       "user-admin",
+		Facade:       "Client",
+		Version:      2,
+		Method:       "FullStatus",
+		Duration:     150 * time.Millisecond,
+		Category:     "audit-exempt",
+	}, {
+		Time:         now.Add(time.Second),
+		ConnectionID: 43,
+		Entity:       "user-bob",
+		Facade:       "Application",
+		Version:      9,
+		Method:       "Deploy",
+		Duration:     2 * time.Second,
+		ErrorCode:    "unauthorized access",
+		Category:     "audited",
+	}}
+	s.logRequests(c, records...)
+
+	read, err := state.RequestLogs(s.State, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, records)
+
+	read, err = state.RequestLogs(s.State, now.Add(time.Millisecond))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, records[1:])
+}
+
+func (s *RequestLogsSuite) TestPruneRequestLogs(c *gc.C) {
+	now := coretesting.NonZeroTime().UTC()
+	minLogTime := now.Add(-time.Minute)
+	s.logRequests(c,
+		state.RequestLogRecord{Time: now, Facade: "Client", Method: "keep"},
+		state.RequestLogRecord{Time: minLogTime, Facade: "Client", Method: "keep"},
+		state.RequestLogRecord{Time: minLogTime.Add(-time.Second), Facade: "Client", Method: "prune"},
+	)
+
+	removed, err := state.PruneRequestLogs(s.State, minLogTime)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.Equals, 1)
+
+	read, err := state.RequestLogs(s.State, minLogTime.Add(-time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, gc.HasLen, 2)
+	for _, record := range read {
+		c.Check(record.Method, gc.Equals, "keep")
+	}
+}
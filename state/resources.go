@@ -10,6 +10,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
@@ -58,6 +59,14 @@ type Resources interface {
 	// resources for a failed application deployment.
 	RemovePendingAppResources(applicationID string, pendingIDs map[string]string) error
 
+	// SetImageScanResult records the result of scanning the identified
+	// container image resource for vulnerabilities.
+	SetImageScanResult(resourceID string, result resources.ImageScanResult) error
+
+	// ImageScanResult returns the result of the most recent vulnerability
+	// scan of the identified container image resource.
+	ImageScanResult(resourceID string) (resources.ImageScanResult, error)
+
 	// TODO(ericsnow) Move this down to ResourcesPersistence.
 
 	// NewResolvePendingResourcesOps generates mongo transaction operations
@@ -95,6 +95,19 @@ func (st resourceState) ListResources(applicationID string) (resource.Applicatio
 		return resource.ApplicationResources{}, errors.Trace(err)
 	}
 
+	for i, res := range resources.Resources {
+		if res.Type != charmresource.TypeContainerImage || res.IsPlaceholder() {
+			continue
+		}
+		scan, err := st.dockerMetadataStorage.ScanResult(res.ID)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return resource.ApplicationResources{}, errors.Trace(err)
+		}
+		resources.Resources[i].ImageScan = &scan
+	}
+
 	unitIDs, err := st.raw.Units(applicationID)
 	if err != nil {
 		return resource.ApplicationResources{}, errors.Trace(err)
@@ -317,6 +330,38 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	return nil
 }
 
+// SetImageScanResult records the result of scanning the identified
+// container image resource for vulnerabilities.
+func (st resourceState) SetImageScanResult(resourceID string, result resources.ImageScanResult) error {
+	return errors.Trace(st.dockerMetadataStorage.SetScanResult(resourceID, result))
+}
+
+// ImageScanResult returns the result of the most recent vulnerability
+// scan of the identified container image resource.
+func (st resourceState) ImageScanResult(resourceID string) (resources.ImageScanResult, error) {
+	result, err := st.dockerMetadataStorage.ScanResult(resourceID)
+	return result, errors.Trace(err)
+}
+
+// checkImageScan returns a Forbidden error if the scan policy blocks
+// the use of the container image resource, because its scan failed or
+// has yet to complete. Images that have not been scanned may be used.
+func (st resourceState) checkImageScan(res resource.Resource) error {
+	scan, err := st.dockerMetadataStorage.ScanResult(res.ID)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !scan.Blocked {
+		return nil
+	}
+	if scan.Pending {
+		return errors.Forbiddenf("image for resource %q is waiting to be scanned", res.Name)
+	}
+	return errors.Forbiddenf("image for resource %q failed its vulnerability scan", res.Name)
+}
+
 // OpenResource returns metadata about the resource, and a reader for
 // the resource.
 func (st resourceState) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
@@ -337,6 +382,9 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 	var resSize int64
 	switch resourceInfo.Type {
 	case charmresource.TypeContainerImage:
+		if err := st.checkImageScan(resourceInfo); err != nil {
+			return resource.Resource{}, nil, errors.Trace(err)
+		}
 		resourceReader, resSize, err = st.dockerMetadataStorage.Get(resourceInfo.ID)
 	case charmresource.TypeFile:
 		resourceReader, resSize, err = st.storage.Get(storagePath)
@@ -0,0 +1,281 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+)
+
+// sessionSettings holds the mongo write concerns and read preferences
+// configured for the controller's collections. The settings for
+// controller.MongoAllCollections apply to collections that are not
+// named explicitly.
+type sessionSettings struct {
+	writeConcerns   map[string]controller.MongoWriteConcern
+	readPreferences map[string]controller.MongoReadPreference
+
+	// txnWriteConcern is the write concern used by the transaction
+	// runner, and txnWriteConcernSet records whether there is one.
+	txnWriteConcern    controller.MongoWriteConcern
+	txnWriteConcernSet bool
+}
+
+// newSessionSettings returns the session settings in the controller
+// config, or nil if there are none. Read preferences that would send
+// reads of collections written by transactions to secondaries are
+// ignored; they are rejected when the controller config is updated.
+func newSessionSettings(cfg controller.Config, schema CollectionSchema) *sessionSettings {
+	settings := &sessionSettings{
+		writeConcerns:   cfg.MongoWriteConcerns(),
+		readPreferences: cfg.MongoReadPreferences(),
+	}
+	for collection, preference := range settings.readPreferences {
+		if err := checkReadPreference(schema, collection, preference); err != nil {
+			logger.Warningf("ignoring mongo read preference: %v", err)
+			delete(settings.readPreferences, collection)
+		}
+	}
+	if len(settings.writeConcerns) == 0 && len(settings.readPreferences) == 0 {
+		return nil
+	}
+
+	// Transactions may write to any collection that is not accessed
+	// raw, along with the transaction collections themselves, so they
+	// are run with the strongest write concern given for any of them.
+	for collection, concern := range settings.writeConcerns {
+		if info, ok := schema[collection]; ok && info.rawAccess && !isTxnCollection(collection) {
+			continue
+		}
+		if settings.txnWriteConcernSet {
+			concern = strongerWriteConcern(settings.txnWriteConcern, concern)
+		}
+		settings.txnWriteConcern = concern
+		settings.txnWriteConcernSet = true
+	}
+	return settings
+}
+
+// isTxnCollection reports whether the collection is one that
+// mgo/txn uses to record transactions.
+func isTxnCollection(collection string) bool {
+	return collection == txnsC || collection == txnLogC
+}
+
+// checkReadPreference returns an error if reads of the collection may
+// not use the read preference. Reads may only be sent to secondaries
+// for collections that are not written by transactions, since
+// transactions assert on the documents that were read beforehand.
+func checkReadPreference(schema CollectionSchema, collection string, preference controller.MongoReadPreference) error {
+	if !preference.ReadsFromSecondaries() {
+		return nil
+	}
+	info, ok := schema[collection]
+	if !ok || !info.rawAccess || isTxnCollection(collection) || collection == globalClockC {
+		return errors.NotValidf(
+			"mongo read preference %q for collection %q, which is written by transactions",
+			string(preference), collection,
+		)
+	}
+	return nil
+}
+
+// strongerWriteConcern returns a write concern that is at least as
+// strong as both of those given.
+func strongerWriteConcern(a, b controller.MongoWriteConcern) controller.MongoWriteConcern {
+	result := a
+	if b.Majority || (!a.Majority && b.W > a.W) {
+		result.Majority = b.Majority
+		result.W = b.W
+	}
+	result.Journal = a.Journal || b.Journal
+	return result
+}
+
+// sessionKey identifies the settings applied to a session.
+type sessionKey struct {
+	writeConcern    controller.MongoWriteConcern
+	writeConcernSet bool
+	readPreference  controller.MongoReadPreference
+}
+
+// isZero reports whether the key applies no settings.
+func (k sessionKey) isZero() bool {
+	return k == sessionKey{}
+}
+
+// collectionKeys returns the keys of the settings applied to the
+// sessions used to read from and write to the collection. Reads
+// that may be sent to secondaries use a separate session from
+// writes, and the reads that precede them.
+func (s *sessionSettings) collectionKeys(collection string) (read, write sessionKey) {
+	if s == nil {
+		return sessionKey{}, sessionKey{}
+	}
+	if concern, ok := s.writeConcern(collection); ok {
+		write.writeConcern = concern
+		write.writeConcernSet = true
+	}
+	read = write
+	if preference, ok := s.readPreference(collection); ok {
+		read.readPreference = preference
+		if !preference.ReadsFromSecondaries() {
+			write = read
+		}
+	}
+	return read, write
+}
+
+// txnKey returns the key of the settings applied to the session
+// used by the transaction runner. Transactions always read from
+// the primary.
+func (s *sessionSettings) txnKey() sessionKey {
+	if s == nil || !s.txnWriteConcernSet {
+		return sessionKey{}
+	}
+	return sessionKey{
+		writeConcern:    s.txnWriteConcern,
+		writeConcernSet: true,
+	}
+}
+
+func (s *sessionSettings) writeConcern(collection string) (controller.MongoWriteConcern, bool) {
+	if concern, ok := s.writeConcerns[collection]; ok {
+		return concern, true
+	}
+	concern, ok := s.writeConcerns[controller.MongoAllCollections]
+	return concern, ok
+}
+
+func (s *sessionSettings) readPreference(collection string) (controller.MongoReadPreference, bool) {
+	if preference, ok := s.readPreferences[collection]; ok {
+		return preference, true
+	}
+	preference, ok := s.readPreferences[controller.MongoAllCollections]
+	return preference, ok
+}
+
+// applySessionKey sets the write concern and read preference of the
+// session, which must not be shared, to those identified by the key.
+func applySessionKey(session *mgo.Session, key sessionKey) {
+	if key.writeConcernSet {
+		safe := &mgo.Safe{
+			W: key.writeConcern.W,
+			J: key.writeConcern.Journal,
+		}
+		if key.writeConcern.Majority {
+			safe.WMode = "majority"
+		}
+		session.SetSafe(safe)
+	}
+	if key.readPreference != "" {
+		mode, err := sessionMode(key.readPreference)
+		if err != nil {
+			// The controller config has been validated,
+			// so this can't happen.
+			logger.Errorf("%v", err)
+			return
+		}
+		session.SetMode(mode, true)
+	}
+}
+
+// sessionMode returns the mgo session mode for the read preference.
+func sessionMode(preference controller.MongoReadPreference) (mgo.Mode, error) {
+	switch preference {
+	case controller.MongoReadPrimary:
+		return mgo.Primary, nil
+	case controller.MongoReadPrimaryPreferred:
+		return mgo.PrimaryPreferred, nil
+	case controller.MongoReadSecondary:
+		return mgo.Secondary, nil
+	case controller.MongoReadSecondaryPreferred:
+		return mgo.SecondaryPreferred, nil
+	case controller.MongoReadNearest:
+		return mgo.Nearest, nil
+	}
+	return 0, errors.NotValidf("mongo read preference %q", string(preference))
+}
+
+// settingsSessions holds copies of a database's own session with
+// session settings applied, so that the settings are applied once
+// for the session rather than for every collection taken from it.
+type settingsSessions struct {
+	mu       sync.Mutex
+	session  *mgo.Session
+	sessions map[sessionKey]*mgo.Session
+}
+
+func newSettingsSessions(session *mgo.Session) *settingsSessions {
+	return &settingsSessions{
+		session:  session,
+		sessions: make(map[sessionKey]*mgo.Session),
+	}
+}
+
+// get returns the copy of the session with the settings identified
+// by the key applied, or the session itself if the key is zero.
+func (s *settingsSessions) get(key sessionKey) *mgo.Session {
+	if key.isZero() {
+		return s.session
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[key]
+	if !ok {
+		session = s.session.Copy()
+		applySessionKey(session, key)
+		s.sessions[key] = session
+	}
+	return session
+}
+
+// close closes the copies of the session.
+func (s *settingsSessions) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, session := range s.sessions {
+		session.Close()
+		delete(s.sessions, key)
+	}
+}
+
+// secondaryReadCollection is a collection whose reads may be sent to
+// secondaries. It is read-only: the collection it returns from
+// Writeable uses a session whose reads are sent to the primary, so
+// that writes are never based on stale reads.
+type secondaryReadCollection struct {
+	mongo.Collection
+	writeable mongo.WriteCollection
+}
+
+// Writeable is part of the Collection interface.
+func (c *secondaryReadCollection) Writeable() mongo.WriteCollection {
+	return c.writeable
+}
+
+// applySessionSettings reads the mongo write concerns and read
+// preferences from the controller config, and applies them to the
+// sessions used for the state's collections. The controller config
+// does not exist while the database is being initialised, in which
+// case the default session settings are used.
+func (st *State) applySessionSettings() error {
+	db, ok := st.database.(*database)
+	if !ok {
+		return nil
+	}
+	cfg, err := st.ControllerConfig()
+	if errors.IsNotFound(errors.Cause(err)) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	db.sessionSettings = newSessionSettings(cfg, db.schema)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/controller"
+)
+
+type sessionSettingsSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&sessionSettingsSuite{})
+
+func (s *sessionSettingsSuite) TestNoSettings(c *gc.C) {
+	c.Assert(newSessionSettings(controller.Config{}, allCollections()), gc.IsNil)
+}
+
+func (s *sessionSettingsSuite) TestApplySessionSettings(c *gc.C) {
+	err := s.state.UpdateControllerConfig(map[string]interface{}{
+		controller.MongoWriteConcern:   "statuseshistory=1,*=majority:journal",
+		controller.MongoReadPreference: "statuseshistory=secondary-preferred",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.applySessionSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Reads of statuseshistory may be sent to secondaries,
+	// but it is written through a session that reads from
+	// the primary.
+	coll, closer := s.state.db().GetCollection(statusesHistoryC)
+	defer closer()
+	readColl := coll.(*secondaryReadCollection).Collection.Writeable().Underlying()
+	c.Check(readColl.Database.Session.Safe(), jc.DeepEquals, &mgo.Safe{W: 1})
+	c.Check(readColl.Database.Session.Mode(), gc.Equals, mgo.SecondaryPreferred)
+	writeColl := coll.Writeable().Underlying()
+	c.Check(writeColl.Database.Session.Safe(), jc.DeepEquals, &mgo.Safe{W: 1})
+	c.Check(writeColl.Database.Session.Mode(), gc.Equals, s.state.session.Mode())
+
+	rawColl, closer := s.state.db().GetRawCollection(machinesC)
+	defer closer()
+	c.Check(rawColl.Database.Session.Safe(), jc.DeepEquals, &mgo.Safe{WMode: "majority", J: true})
+	c.Check(rawColl.Database.Session.Mode(), gc.Equals, s.state.session.Mode())
+}
+
+func (s *sessionSettingsSuite) TestSettingsDoNotLeak(c *gc.C) {
+	s.state.database.(*database).sessionSettings = newSessionSettings(controller.Config{
+		controller.MongoReadPreference: "statuseshistory=nearest",
+	}, allCollections())
+
+	coll, closer := s.state.db().GetCollection(statusesHistoryC)
+	defer closer()
+	readColl := coll.(*secondaryReadCollection).Collection.Writeable().Underlying()
+	c.Check(readColl.Database.Session.Mode(), gc.Equals, mgo.Nearest)
+
+	rawColl, closer := s.state.db().GetRawCollection(machinesC)
+	defer closer()
+	c.Check(rawColl.Database.Session.Mode(), gc.Equals, s.state.session.Mode())
+	c.Check(s.state.session.Mode(), gc.Not(gc.Equals), mgo.Nearest)
+}
+
+func (s *sessionSettingsSuite) TestSettingsAppliedOncePerSession(c *gc.C) {
+	s.state.database.(*database).sessionSettings = newSessionSettings(controller.Config{
+		controller.MongoWriteConcern: "statuseshistory=majority",
+	}, allCollections())
+
+	db, dbCloser := s.state.db().Copy()
+	defer dbCloser()
+	coll1, closer := db.GetRawCollection(statusesHistoryC)
+	defer closer()
+	coll2, closer := db.GetRawCollection(statusesHistoryC)
+	defer closer()
+	c.Check(coll1.Database.Session, gc.Equals, coll2.Database.Session)
+	c.Check(coll1.Database.Session.Safe(), jc.DeepEquals, &mgo.Safe{WMode: "majority"})
+}
+
+func (s *sessionSettingsSuite) TestTxnWriteConcern(c *gc.C) {
+	settings := newSessionSettings(controller.Config{
+		controller.MongoWriteConcern: "statuseshistory=majority,machines=2:journal,*=1",
+	}, allCollections())
+	// The statuseshistory collection is not written by transactions,
+	// so its write concern does not apply to them.
+	c.Check(settings.txnKey(), jc.DeepEquals, sessionKey{
+		writeConcern:    controller.MongoWriteConcern{W: 2, Journal: true},
+		writeConcernSet: true,
+	})
+
+	settings = newSessionSettings(controller.Config{
+		controller.MongoWriteConcern: "statuseshistory=majority",
+	}, allCollections())
+	c.Check(settings.txnKey(), gc.Equals, sessionKey{})
+}
+
+func (s *sessionSettingsSuite) TestSecondaryReadsOfTxnCollectionsRejected(c *gc.C) {
+	err := s.state.UpdateControllerConfig(map[string]interface{}{
+		controller.MongoReadPreference: "machines=secondary",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config "mongo-read-preference": mongo read preference "secondary" for collection "machines", which is written by transactions not valid`)
+
+	settings := newSessionSettings(controller.Config{
+		controller.MongoReadPreference: "machines=nearest,txns=secondary",
+	}, allCollections())
+	c.Check(settings, gc.IsNil)
+}
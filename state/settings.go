@@ -32,6 +32,25 @@ type settingsDoc struct {
 	Version int64 `bson:"version"`
 }
 
+// StoredSize is part of the mongometrics.StoredSizer interface. It
+// returns the total size of the settings values as stored, if they
+// were written by createSettingsOp and have been encoded.
+func (d *settingsDoc) StoredSize() (int, bool) {
+	var total int
+	for _, v := range d.Settings {
+		value, ok := v.(*settingValue)
+		if !ok {
+			return 0, false
+		}
+		size, ok := value.StoredSize()
+		if !ok {
+			return 0, false
+		}
+		total += size
+	}
+	return total, true
+}
+
 type settingsMap map[string]interface{}
 
 func (m *settingsMap) SetBSON(raw bson.Raw) error {
@@ -39,13 +58,20 @@ func (m *settingsMap) SetBSON(raw bson.Raw) error {
 	if err := raw.Unmarshal(rawMap); err != nil {
 		return err
 	}
+	for k, v := range rawMap {
+		value, err := decompressSettingValue(v)
+		if err != nil {
+			return errors.Annotatef(err, "settings key %q", k)
+		}
+		rawMap[k] = value
+	}
 	*m = settingsMap(utils.UnescapeKeys(rawMap))
 	return nil
 }
 
 func (m settingsMap) GetBSON() (interface{}, error) {
 	escapedMap := utils.EscapeKeys(m)
-	return escapedMap, nil
+	return compressSettingValues(escapedMap), nil
 }
 
 // A Settings manages changes to settings as a delta in memory and merges
@@ -133,7 +159,7 @@ func (s *Settings) settingsUpdateOps() (settings.ItemChanges, []txn.Op) {
 		k := utils.EscapeKey(ch.Key)
 		switch {
 		case ch.IsAddition(), ch.IsModification():
-			updates[k] = ch.NewValue
+			updates[k] = newSettingValue(ch.NewValue)
 		case ch.IsDeletion():
 			deletions[k] = 1
 		}
@@ -289,7 +315,7 @@ func createSettingsOp(collection, key string, values map[string]interface{}) txn
 		Id:     key,
 		Assert: txn.DocMissing,
 		Insert: &settingsDoc{
-			Settings: newValues,
+			Settings: compressSettingValues(newValues),
 		},
 	}
 }
@@ -379,7 +405,7 @@ func replaceSettingsOp(db Database, collection, key string, values map[string]in
 	}
 	newValues := utils.EscapeKeys(values)
 	op := s.assertUnchangedOp()
-	op.Update = setUnsetUpdateSettings(bson.M(newValues), deletes)
+	op.Update = setUnsetUpdateSettings(bson.M(compressSettingValues(newValues)), deletes)
 	assertFailed := func() (bool, error) {
 		latest, err := readSettings(db, collection, key)
 		if err != nil {
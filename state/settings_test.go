@@ -4,7 +4,9 @@
 package state
 
 import (
+	"math/rand"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -296,6 +298,77 @@ func (s *SettingsSuite) TestCreateSettingsEscape(c *gc.C) {
 	c.Assert(mgoData.Settings, gc.DeepEquals, mgoOptions)
 }
 
+func (s *SettingsSuite) TestLargeValuesCompressed(c *gc.C) {
+	large := strings.Repeat("certificate data ", 2000)
+	options := map[string]interface{}{"small": "value", "large": large}
+	node, err := s.createSettings(s.key, options)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, closer := s.state.db().GetCollection(settingsC)
+	defer closer()
+	assertStored := func(key string) {
+		var mgoData struct {
+			Settings map[string]interface{}
+		}
+		err := settings.FindId(s.key).One(&mgoData)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(mgoData.Settings["small"], gc.Equals, "value")
+		stored, ok := mgoData.Settings[key].(bson.Binary)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("%s stored as %T", key, mgoData.Settings[key]))
+		c.Assert(stored.Kind, gc.Equals, byte(compressedSettingKind))
+		c.Assert(len(stored.Data) < len(large), jc.IsTrue)
+	}
+	assertStored("large")
+
+	nodeTwo, err := s.readSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nodeTwo.Map(), gc.DeepEquals, options)
+
+	node.Set("other", large+"more")
+	_, err = node.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	assertStored("other")
+
+	options["other"] = large + "more"
+	err = nodeTwo.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nodeTwo.Map(), gc.DeepEquals, options)
+
+	options = map[string]interface{}{"small": "value", "replaced": large}
+	err = replaceSettings(s.state.db(), s.collection, s.key, options)
+	c.Assert(err, jc.ErrorIsNil)
+	assertStored("replaced")
+	err = nodeTwo.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nodeTwo.Map(), gc.DeepEquals, options)
+}
+
+func (s *SettingsSuite) TestCompressSettingValue(c *gc.C) {
+	for i, value := range []interface{}{
+		1, true, "short", strings.Repeat("x", settingCompressionThreshold),
+	} {
+		c.Logf("test %d", i)
+		stored, err := compressSettingValue(value)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(stored, gc.Equals, value)
+	}
+
+	// Values which do not compress are stored as they are.
+	data := make([]byte, settingCompressionThreshold+1)
+	rand.New(rand.NewSource(0)).Read(data)
+	stored, err := compressSettingValue(string(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, gc.Equals, string(data))
+
+	data = make([]byte, maxSettingSize+1)
+	rand.New(rand.NewSource(0)).Read(data)
+	_, err = compressSettingValue(string(data))
+	c.Assert(err, gc.ErrorMatches, `settings value too large \(4194305 bytes, maximum 4194304\)`)
+
+	_, err = decompressSettingValue(bson.Binary{Kind: compressedSettingKind, Data: []byte("junk")})
+	c.Assert(err, gc.ErrorMatches, "cannot decompress settings value: .*")
+}
+
 func (s *SettingsSuite) TestMultipleReads(c *gc.C) {
 	// Check that reads without writes always resets the data.
 	nodeOne, err := s.createSettings(s.key, nil)
@@ -0,0 +1,130 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// compressedSettingKind is the BSON binary subtype used to store
+	// compressed settings values. Subtypes from 0x80 are reserved for
+	// user-defined data, so no other writer will produce them.
+	compressedSettingKind = 0x80
+
+	// settingCompressionThreshold is the length above which string
+	// settings values are compressed when written to the database.
+	settingCompressionThreshold = 16 * 1024
+
+	// maxSettingSize is the largest a single settings value may be
+	// once compressed. Mongo limits documents to 16MiB, and a settings
+	// document also has to fit in the transaction that writes it.
+	maxSettingSize = 4 * 1024 * 1024
+)
+
+// settingValue wraps a settings value as it is written to the
+// database, compressing large string values. mgo/txn encodes an op
+// more than once, so the stored value is computed only once, and its
+// size recorded for the txn metrics.
+type settingValue struct {
+	value interface{}
+
+	once    sync.Once
+	encoded bool
+	stored  interface{}
+	size    int
+	err     error
+}
+
+func newSettingValue(value interface{}) *settingValue {
+	return &settingValue{value: value}
+}
+
+// GetBSON is part of the bson.Getter interface.
+func (v *settingValue) GetBSON() (interface{}, error) {
+	v.once.Do(func() {
+		v.stored, v.err = compressSettingValue(v.value)
+		v.encoded = v.err == nil
+		switch stored := v.stored.(type) {
+		case string:
+			v.size = len(stored)
+		case bson.Binary:
+			v.size = len(stored.Data)
+		}
+	})
+	return v.stored, v.err
+}
+
+// StoredSize is part of the mongometrics.StoredSizer interface. It
+// returns the size of a string value as stored, once it has been
+// encoded; other values are counted as empty.
+func (v *settingValue) StoredSize() (int, bool) {
+	return v.size, v.encoded
+}
+
+// compressSettingValue returns the value to store for the given
+// settings value. Strings longer than settingCompressionThreshold
+// are gzipped, as long as that makes them smaller; all other values
+// are returned unchanged.
+func compressSettingValue(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok || len(s) <= settingCompressionThreshold {
+		return value, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if buf.Len() >= len(s) {
+		if len(s) > maxSettingSize {
+			return nil, errors.Errorf("settings value too large (%d bytes, maximum %d)", len(s), maxSettingSize)
+		}
+		return value, nil
+	}
+	if buf.Len() > maxSettingSize {
+		return nil, errors.Errorf("settings value too large (%d bytes compressed, maximum %d)", buf.Len(), maxSettingSize)
+	}
+	return bson.Binary{Kind: compressedSettingKind, Data: buf.Bytes()}, nil
+}
+
+// decompressSettingValue returns the settings value represented by
+// the stored value, reversing compressSettingValue.
+func decompressSettingValue(value interface{}) (interface{}, error) {
+	b, ok := value.(bson.Binary)
+	if !ok || b.Kind != compressedSettingKind {
+		return value, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b.Data))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decompress settings value")
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decompress settings value")
+	}
+	return string(data), nil
+}
+
+// compressSettingValues returns a copy of the given settings map
+// with every value wrapped to be compressed when written.
+func compressSettingValues(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if _, ok := v.(*settingValue); !ok {
+			v = newSettingValue(v)
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -93,6 +93,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// watcherLapseObserver, if non-nil, is called when the watches
+	// of a channel on the State's hub watcher lapse.
+	watcherLapseObserver func(modelUUID string)
+
 	// leaseStoreId is used by the lease infrastructure to
 	// differentiate between machines whose clocks may be
 	// relatively-skewed.
@@ -427,6 +431,10 @@ func (st *State) start(controllerTag names.ControllerTag, hub *pubsub.SimpleHub)
 
 	st.controllerTag = controllerTag
 
+	if err := st.applySessionSettings(); err != nil {
+		return errors.Annotate(err, "applying mongo session settings")
+	}
+
 	// Run the "connectionStatus" Mongo command to obtain the authenticated
 	// user name, if any. This is used below for the lease store ID.
 	// See: https://docs.mongodb.com/manual/reference/command/connectionStatus/
@@ -1402,7 +1410,16 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			ops = append(ops, resOps...)
 		}
 
-		// Collect unit-adding operations.
+		// Collect unit-adding operations, which
+		// are limited by the model's quotas.
+		quotaOps, _, err := st.modelQuotaOps(ModelQuotaUsage{
+			Units:   args.NumUnits,
+			Storage: args.NumUnits * storageQuotaUsage(args.Storage),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, quotaOps...)
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
@@ -1597,13 +1614,22 @@ func (st *State) processCAASModelApplicationArgs(args *AddApplicationArgs) error
 	if err := st.processCommonModelApplicationArgs(args); err != nil {
 		return errors.Trace(err)
 	}
-	if len(args.Placement) > 0 {
-		return errors.NotValidf("placement directives on k8s models")
+	// A single placement directive may be used to control how the
+	// application's pods are scheduled; machines cannot be targeted.
+	var placement string
+	if len(args.Placement) > 1 {
+		return errors.NotValidf("multiple placement directives on k8s models")
+	}
+	if len(args.Placement) == 1 {
+		if args.Placement[0].Scope == instance.MachineScope {
+			return errors.NotValidf("placement directives on k8s models")
+		}
+		placement = args.Placement[0].Directive
 	}
 	return st.precheckInstance(
 		args.Series,
 		args.Constraints,
-		"",
+		placement,
 		nil,
 	)
 }
@@ -1709,6 +1735,11 @@ func (st *State) AssignUnitWithPlacement(unit *Unit, placement *instance.Placeme
 	if data.placementType() == directivePlacement {
 		return unit.assignToNewMachine(data.directive)
 	}
+	if data.machineId != "" {
+		if err := checkHostSpread(unit, data.machineId); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	m, err := st.addMachineWithPlacement(unit, data)
 	if err != nil {
@@ -1686,6 +1686,34 @@ func (s *StateSuite) TestAddCAASApplicationPlacementNotAllowed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*"+regexp.QuoteMeta(`cannot add application "gitlab": placement directives on k8s models not valid`))
 }
 
+func (s *StateSuite) TestAddCAASApplicationSchedulingPlacement(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+
+	placement := []*instance.Placement{{Scope: st.ModelUUID(), Directive: "spread=node"}}
+	gitlab, err := st.AddApplication(
+		state.AddApplicationArgs{Name: "gitlab", Charm: ch, Placement: placement})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gitlab.GetPlacement(), gc.Equals, "spread=node")
+}
+
+func (s *StateSuite) TestAddCAASApplicationMultiplePlacementNotAllowed(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+
+	placement := []*instance.Placement{
+		{Scope: st.ModelUUID(), Directive: "spread=node"},
+		{Scope: st.ModelUUID(), Directive: "spread=zone"},
+	}
+	_, err := st.AddApplication(
+		state.AddApplicationArgs{Name: "gitlab", Charm: ch, Placement: placement})
+	c.Assert(err, gc.ErrorMatches, ".*"+regexp.QuoteMeta(`cannot add application "gitlab": multiple placement directives on k8s models not valid`))
+}
+
 func (s *StateSuite) TestAddApplicationWithNilCharmConfigValues(c *gc.C) {
 	ch := s.AddTestingCharm(c, "dummy")
 	insettings := charm.Settings{"tuning": nil}
@@ -2209,6 +2237,22 @@ func (s *StateSuite) TestModelConstraints(c *gc.C) {
 	c.Assert(cons5, gc.DeepEquals, cons4)
 }
 
+func (s *StateSuite) TestWatchModelConstraints(c *gc.C) {
+	w := s.State.WatchModelConstraints()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetModelConstraints(constraints.MustParse("mem-limit=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *StateSuite) TestSetInvalidConstraints(c *gc.C) {
 	cons := constraints.MustParse("mem=4G instance-type=foo")
 	err := s.State.SetModelConstraints(cons)
@@ -213,6 +213,12 @@ type storageInstanceDoc struct {
 	StorageName     string                     `bson:"storagename"`
 	AttachmentCount int                        `bson:"attachmentcount"`
 	Constraints     storageInstanceConstraints `bson:"constraints"`
+
+	// AttachTo, if set, is the tag of the unit that the storage
+	// instance is being moved to. The storage instance will be
+	// attached to the unit once it has been detached from its
+	// current owner.
+	AttachTo string `bson:"attach-to,omitempty"`
 }
 
 // storageInstanceConstraints contains a subset of StorageConstraints,
@@ -1042,6 +1048,15 @@ func (sb *storageBackend) AttachStorage(storage names.StorageTag, unit names.Uni
 		if err != nil {
 			return nil, errors.Annotate(err, "getting charm")
 		}
+		if owner, ok := si.Owner(); ok && owner.Kind() == names.UnitTagKind && owner != unit {
+			// The storage is attached to another unit, possibly
+			// of another application: move it to this unit.
+			ops, err := sb.moveStorageOps(si, owner.(names.UnitTag), u, ch)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, u.assertCharmOps(ch)...), nil
+		}
 		ops, err := sb.attachStorageOps(si, u.UnitTag(), u.Series(), ch, u)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -1122,14 +1137,11 @@ func (sb *storageBackend) attachStorageOps(
 		// application?
 	}
 
-	// Check that the unit's charm declares storage with the storage
-	// instance's storage name.
+	// Check that the unit's charm declares storage compatible
+	// with the storage instance.
 	charmMeta := ch.Meta()
-	if _, ok := charmMeta.Storage[si.StorageName()]; !ok {
-		return nil, errors.Errorf(
-			"charm %s has no storage called %s",
-			charmMeta.Name, si.StorageName(),
-		)
+	if err := validateCharmStorageCompatible(si, charmMeta); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Create a storage attachment doc, ensuring that the storage instance
@@ -1146,6 +1158,13 @@ func (sb *storageBackend) attachStorageOps(
 			"$set", bson.D{{"owner", unitTag.String()}},
 		})
 	}
+	if si.doc.AttachTo != "" {
+		// Attaching the storage completes or supersedes
+		// any pending move.
+		siUpdate = append(siUpdate, bson.DocElem{
+			"$unset", bson.D{{"attach-to", nil}},
+		})
+	}
 	ops := []txn.Op{{
 		C:      storageInstancesC,
 		Id:     si.doc.Id,
@@ -1168,6 +1187,177 @@ func (sb *storageBackend) attachStorageOps(
 	return ops, nil
 }
 
+// validateCharmStorageCompatible checks that the charm declares
+// storage that the storage instance can be attached as: storage
+// with the same name and kind.
+func validateCharmStorageCompatible(si *storageInstance, charmMeta *charm.Meta) error {
+	charmStorage, ok := charmMeta.Storage[si.StorageName()]
+	if !ok {
+		return errors.Errorf(
+			"charm %s has no storage called %s",
+			charmMeta.Name, si.StorageName(),
+		)
+	}
+	var kind StorageKind
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if kind != si.Kind() {
+		return errors.Errorf(
+			"charm %s storage %s is of type %s, not %s",
+			charmMeta.Name, si.StorageName(), charmStorage.Type, si.Kind(),
+		)
+	}
+	return nil
+}
+
+// moveStorageOps returns txn.Ops to move a storage instance from the
+// unit that owns it to another unit, which may belong to a different
+// application. The storage is detached from its current owner, and
+// attached to the new unit by a cleanup once it has been disowned.
+func (sb *storageBackend) moveStorageOps(si *storageInstance, from names.UnitTag, to *Unit, ch *Charm) ([]txn.Op, error) {
+	if si.Life() != Alive {
+		return nil, errors.New("storage not alive")
+	}
+	if si.doc.AttachTo == to.Tag().String() {
+		return nil, jujutxn.ErrNoOperations
+	} else if si.doc.AttachTo != "" {
+		attachTo, err := names.ParseUnitTag(si.doc.AttachTo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("storage is already being moved to %s", names.ReadableString(attachTo))
+	}
+
+	charmMeta := ch.Meta()
+	if err := validateCharmStorageCompatible(si, charmMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, _, err := validateStorageCountChange(sb, to.UnitTag(), si.StorageName(), 1, charmMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := sb.validateStorageMovable(si, from, to); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Detaching the storage must not violate the charm storage
+	// requirements of the current owner.
+	ops, err := validateRemoveOwnerStorageInstanceOps(si)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, txn.Op{
+		C:  storageInstancesC,
+		Id: si.doc.Id,
+		Assert: append(isAliveDoc,
+			bson.DocElem{"owner", si.doc.Owner},
+			bson.DocElem{"attach-to", bson.D{{"$exists", false}}},
+		),
+		Update: bson.D{{"$set", bson.D{{"attach-to", to.Tag().String()}}}},
+	})
+	att, err := sb.storageAttachment(si.StorageTag(), from)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if att.Life() == Alive {
+		ops = append(ops, detachStorageOps(si.StorageTag(), from)...)
+	}
+	return ops, nil
+}
+
+// validateStorageMovable checks that the storage instance can be moved
+// between the specified units. Storage that cannot be detached from its
+// machine can only be moved to a unit on the same machine.
+func (sb *storageBackend) validateStorageMovable(si *storageInstance, from names.UnitTag, to *Unit) error {
+	if sb.modelType != ModelTypeIAAS {
+		return errors.NotSupportedf("moving storage between units of a %s model", sb.modelType)
+	}
+	fromUnit, err := sb.unit(from.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fromMachineId, err := fromUnit.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return errors.Trace(err)
+	}
+	toMachineId, err := to.AssignedMachineId()
+	if err != nil && !errors.IsNotAssigned(err) {
+		return errors.Trace(err)
+	}
+	if fromMachineId != "" && fromMachineId == toMachineId {
+		return nil
+	}
+
+	// Storage that has not yet been assigned a volume or
+	// filesystem is not tied to any machine.
+	detachable := true
+	switch si.Kind() {
+	case StorageKindBlock:
+		volume, err := sb.storageInstanceVolume(si.StorageTag())
+		if err == nil {
+			detachable = volume.Detachable()
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	case StorageKindFilesystem:
+		filesystem, err := sb.storageInstanceFilesystem(si.StorageTag())
+		if err == nil {
+			detachable = filesystem.Detachable()
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	if !detachable {
+		return errors.Errorf(
+			"storage is not detachable, and %s is not on the same machine as %s",
+			names.ReadableString(to.Tag()), names.ReadableString(from),
+		)
+	}
+	return nil
+}
+
+// attachMovedStorage attaches a storage instance that is being moved
+// to the unit recorded as its destination. The move is abandoned,
+// leaving the storage detached, if the unit can no longer accept it.
+func (sb *storageBackend) attachMovedStorage(tag names.StorageTag) error {
+	si, err := sb.storageInstance(tag)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if si.doc.AttachTo == "" || si.doc.Owner != "" || si.Life() != Alive {
+		return nil
+	}
+	unitTag, err := names.ParseUnitTag(si.doc.AttachTo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u, err := sb.unit(unitTag.Id())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err == nil && u.Life() == Alive {
+		// An error here may be transient, e.g. while the storage's
+		// volume is still detaching from its previous machine, so
+		// the cleanup is retried.
+		return errors.Trace(sb.AttachStorage(tag, unitTag))
+	}
+	logger.Warningf(
+		"cannot move %s to %s: unit is no longer alive",
+		names.ReadableString(tag), names.ReadableString(unitTag),
+	)
+	return errors.Trace(sb.mb.db().RunTransaction([]txn.Op{{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: bson.D{{"attach-to", si.doc.AttachTo}},
+		Update: bson.D{{"$unset", bson.D{{"attach-to", nil}}}},
+	}}))
+}
+
 // DetachStorage ensures that the existing storage attachments of
 // the specified unit are removed at some point.
 func (sb *storageBackend) DestroyUnitStorageAttachments(unit names.UnitTag) (err error) {
@@ -1445,6 +1635,11 @@ func removeStorageAttachmentOps(
 			siUpdate = append(siUpdate, bson.DocElem{
 				"$unset", bson.D{{"owner", nil}},
 			})
+			if si.doc.AttachTo != "" {
+				// The storage is being moved to another unit,
+				// which it can be attached to now it is disowned.
+				ops = append(ops, newCleanupOp(cleanupAttachMovedStorage, si.doc.Id))
+			}
 			decrefOp, err := decrefEntityStorageOp(im.mb, s.Unit(), si.StorageName())
 			if err != nil {
 				if !force {
@@ -1762,6 +1957,9 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
 		}
+		if err := storage.ValidateMountOptions(charmStorage.MountOptions); err != nil {
+			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+		}
 		if charmStorage.MinimumSize > 0 && cons.Size < charmStorage.MinimumSize {
 			return errors.Errorf(
 				"charm %q store %q: minimum storage size is %s, %s specified",
@@ -2050,10 +2248,13 @@ func (sb *storageBackend) AddStorageForUnit(
 				return nil, errors.Trace(err)
 			}
 		}
+		quotaOps, _, err := u.st.modelQuotaOps(ModelQuotaUsage{Storage: int(cons.Count)})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		var ops []txn.Op
-		var err error
 		tags, ops, err = sb.addStorageForUnitOps(u, name, cons)
-		return ops, err
+		return append(ops, quotaOps...), err
 	}
 	if err := sb.mb.db().Run(buildTxn); err != nil {
 		return nil, errors.Annotatef(err, "adding %q storage to %s", name, u)
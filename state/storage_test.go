@@ -569,6 +569,26 @@ func (s *StorageStateSuite) TestAddApplicationStorageConstraintsNoUserDefaultPoo
 	s.assertAddApplicationStorageConstraintsDefaults(c, "", storageCons, expectedCons)
 }
 
+func (s *StorageStateSuite) TestUpdateModelConfigStorageDefaultSourceNotFound(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"storage-default-block-source": "no-such-pool",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `storage-default-block-source "no-such-pool" \(no such storage pool or provider\) not valid`)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"storage-default-filesystem-source": "no-such-pool",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `storage-default-filesystem-source "no-such-pool" \(no such storage pool or provider\) not valid`)
+}
+
+func (s *StorageStateSuite) TestUpdateModelConfigStorageDefaultSourceProviderType(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"storage-default-block-source":      "loop",
+		"storage-default-filesystem-source": "rootfs",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *StorageStateSuite) TestAddApplicationStorageConstraintsDefaultSizeFallback(c *gc.C) {
 	storageCons := map[string]state.StorageConstraints{
 		"data": makeStorageCons("loop-pool", 0, 1),
@@ -838,6 +858,113 @@ func (s *StorageStateSuite) TestAttachStorageTakesOwnership(c *gc.C) {
 	c.Assert(owner, gc.Equals, u2.Tag())
 }
 
+func (s *StorageStateSuite) addSecondApplication(c *gc.C, app *state.Application) *state.Unit {
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	app2, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name:   "secondwind",
+		Series: app.Series(),
+		Charm:  ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("modelscoped", 1024, 1),
+		},
+		NumUnits: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app2.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	return units[0]
+}
+
+func (s *StorageStateSuite) TestAttachStorageMovesToOtherApplication(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2 := s.addSecondApplication(c, app)
+
+	// Attaching the storage to a unit of another application
+	// detaches it from its current owner.
+	err := s.storageBackend.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	att, err := s.storageBackend.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(att.Life(), gc.Equals, state.Dying)
+
+	// Repeating the request is a no-op.
+	err = s.storageBackend.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the storage is detached, a cleanup attaches it
+	// to the new unit.
+	err = s.storageBackend.RemoveStorageAttachment(storageTag, u.UnitTag(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	_, err = s.storageBackend.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestAttachStorageMoveAlreadyMoving(c *gc.C) {
+	app, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2 := s.addSecondApplication(c, app)
+	u3, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.AttachStorage(storageTag, u3.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit quantal-storage-block/1: storage is already being moved to unit secondwind/0`,
+	)
+}
+
+func (s *StorageStateSuite) TestAttachStorageMoveIncompatibleKind(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	ch := s.createStorageCharm(c, "storage-filesystem", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		CountMin: 0,
+		CountMax: 2,
+	})
+	app2 := s.AddTestingApplicationWithStorage(c, ch.URL().Name, ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("modelscoped-block", 1024, 1),
+	})
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit quantal-storage-filesystem/0: charm storage-filesystem storage data is of type filesystem, not block`,
+	)
+}
+
+func (s *StorageStateSuite) TestAttachStorageMoveAbandoned(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2 := s.addSecondApplication(c, app)
+
+	err := s.storageBackend.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// If the destination unit goes away before the storage is
+	// detached, the storage is left detached.
+	err = u2.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.RemoveStorageAttachment(storageTag, u.UnitTag(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, hasOwner := si.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+}
+
 func (s *StorageStateSuite) TestAttachStorageAssignedMachine(c *gc.C) {
 	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
 	u2, err := app.AddUnit(state.AddUnitParams{})
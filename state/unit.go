@@ -96,6 +96,9 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+
+	// Labels holds the freeform labels attached to the unit.
+	Labels map[string]string `bson:"labels,omitempty"`
 }
 
 // Unit represents the state of an application unit.
@@ -249,7 +252,7 @@ func (u *Unit) SetWorkloadVersion(version string) error {
 
 // WorkloadVersionHistory returns a HistoryGetter which enables the
 // caller to request past workload version changes.
-func (u *Unit) WorkloadVersionHistory() *HistoryGetter {
+func (u *Unit) WorkloadVersionHistory() status.StatusHistoryGetter {
 	return &HistoryGetter{st: u.st, globalKey: u.globalWorkloadVersionKey()}
 }
 
@@ -285,6 +288,28 @@ func (u *Unit) SetAgentVersion(v version.Binary) (err error) {
 	return nil
 }
 
+// Labels returns the freeform labels attached to the unit.
+func (u *Unit) Labels() map[string]string {
+	return copyLabels(u.doc.Labels)
+}
+
+// UpdateLabels sets and removes labels of the unit,
+// leaving any other labels unchanged.
+func (u *Unit) UpdateLabels(set map[string]string, remove []string) error {
+	ops, err := updateLabelsOps(unitsC, u.doc.DocID, set, remove)
+	if err != nil {
+		return errors.Annotatef(err, "cannot update labels of unit %q", u)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot update labels of unit %q", u)
+	}
+	u.doc.Labels = updatedLabels(u.doc.Labels, set, remove)
+	return nil
+}
+
 // SetPassword sets the password for the machine's agent.
 func (u *Unit) SetPassword(password string) error {
 	if len(password) < utils.MinAgentPasswordLength {
@@ -577,6 +602,27 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 		}
 		op.AddError(one)
 	}
+	if err := eraseUnitOperations(op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "operations")
+		if !op.Force {
+			return one
+		}
+		op.AddError(one)
+	}
+	if err := eraseUnitHookQueue(op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "pending hooks")
+		if !op.Force {
+			return one
+		}
+		op.AddError(one)
+	}
+	if err := eraseUnitState(op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "agent state")
+		if !op.Force {
+			return one
+		}
+		op.AddError(one)
+	}
 	return nil
 }
 
@@ -2502,6 +2548,7 @@ func storageParamsForStorageInstance(
 			locationAutoGenerated: charmStorage.Location == "", // auto-generated location
 			Location:              location,
 			ReadOnly:              charmStorage.ReadOnly,
+			MountOptions:          charmStorage.MountOptions,
 		}
 		var volumeBacked bool
 		if filesystem, err := sb.StorageInstanceFilesystem(storage.StorageTag()); err == nil {
@@ -2723,6 +2770,12 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	if cons.HasCpuPower() {
 		suitableTerms = append(suitableTerms, bson.DocElem{"cpupower", bson.D{{"$gte", *cons.CpuPower}}})
 	}
+	if cons.HasGPUs() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"gpus", bson.D{{"$gte", *cons.GPUs}}})
+	}
+	if cons.HasGPUType() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"gputype", *cons.GPUType})
+	}
 	if cons.Tags != nil && len(*cons.Tags) > 0 {
 		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *cons.Tags}}})
 	}
@@ -2945,6 +2998,11 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithNotifyURL(name, payload, "")
+}
+
+// AddActionWithNotifyURL is part of the ActionReceiver interface.
+func (u *Unit) AddActionWithNotifyURL(name string, payload map[string]interface{}, notifyURL string) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2975,7 +3033,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return m.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return m.enqueueAction(u.Tag(), name, payloadWithDefaults, notifyURL)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
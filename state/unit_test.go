@@ -1206,6 +1206,150 @@ func (s *UnitSuite) TestDestroyRemovesStatusHistory(c *gc.C) {
 	c.Assert(versionInfo, gc.HasLen, 0)
 }
 
+func (s *UnitSuite) TestRecordOperations(c *gc.C) {
+	now := coretesting.NonZeroTime().Round(time.Second)
+	err := s.unit.RecordOperations(state.UnitOperation{
+		Kind:        "install",
+		Description: "install cs:quantal/wordpress-3",
+		Started:     now,
+		Completed:   now.Add(time.Second),
+	}, state.UnitOperation{
+		Kind:        "run-hook",
+		Description: "run config-changed hook",
+		Started:     now.Add(2 * time.Second),
+		Completed:   now.Add(3 * time.Second),
+		Error:       "hook failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops, err := s.unit.OperationHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0].Kind, gc.Equals, "run-hook")
+	c.Check(ops[0].Description, gc.Equals, "run config-changed hook")
+	c.Check(ops[0].Started.Equal(now.Add(2*time.Second)), jc.IsTrue)
+	c.Check(ops[0].Completed.Equal(now.Add(3*time.Second)), jc.IsTrue)
+	c.Check(ops[0].Error, gc.Equals, "hook failed")
+	c.Check(ops[1].Kind, gc.Equals, "install")
+	c.Check(ops[1].Error, gc.Equals, "")
+}
+
+func (s *UnitSuite) TestOperationHistoryIsBounded(c *gc.C) {
+	now := coretesting.NonZeroTime()
+	var ops []state.UnitOperation
+	for i := 0; i < state.MaxUnitOperations+5; i++ {
+		started := now.Add(time.Duration(i) * time.Second)
+		ops = append(ops, state.UnitOperation{
+			Kind:        "run-hook",
+			Description: fmt.Sprintf("operation %d", i),
+			Started:     started,
+			Completed:   started,
+		})
+	}
+	err := s.unit.RecordOperations(ops...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.OperationHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxUnitOperations)
+	c.Assert(history[0].Description, gc.Equals, fmt.Sprintf("operation %d", state.MaxUnitOperations+4))
+	c.Assert(history[len(history)-1].Description, gc.Equals, "operation 5")
+}
+
+func (s *UnitSuite) TestDestroyRemovesOperationHistory(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = s.unit.RecordOperations(state.UnitOperation{
+		Kind:        "run-hook",
+		Description: "run install hook",
+		Started:     now,
+		Completed:   now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops, err := s.unit.OperationHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 0)
+}
+
+func (s *UnitSuite) TestPendingHooks(c *gc.C) {
+	queue, err := s.unit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue, jc.DeepEquals, state.UnitHookQueue{})
+	c.Assert(queue.NextHook(), gc.Equals, "")
+
+	err = s.unit.SetPendingHooks([]string{"config-changed", "update-status"})
+	c.Assert(err, jc.ErrorIsNil)
+	queue, err = s.unit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue.Pending, jc.DeepEquals, []string{"config-changed", "update-status"})
+	c.Assert(queue.Updated.IsZero(), jc.IsFalse)
+	c.Assert(queue.NextHook(), gc.Equals, "config-changed")
+
+	err = s.unit.SetPendingHooks(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	queue, err = s.unit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue.Pending, gc.HasLen, 0)
+	c.Assert(queue.Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *UnitSuite) TestDestroyRemovesPendingHooks(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetPendingHooks([]string{"install"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	queue, err := s.unit.PendingHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queue.Pending, gc.HasLen, 0)
+}
+
+func (s *UnitSuite) TestState(c *gc.C) {
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, state.UnitState{})
+
+	expected := state.UnitState{
+		UniterState:   "started: true\n",
+		RelationState: map[int]string{0: "change-version: 1\n", 12: "change-version: 3\n"},
+		StorageState:  "attached: true\n",
+	}
+	err = s.unit.SetState(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, expected)
+
+	// Setting the state replaces all of it.
+	err = s.unit.SetState(state.UnitState{UniterState: "started: false\n"})
+	c.Assert(err, jc.ErrorIsNil)
+	unitState, err = s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, state.UnitState{UniterState: "started: false\n"})
+}
+
+func (s *UnitSuite) TestDestroyRemovesState(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetState(state.UnitState{UniterState: "started: true\n"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitState, err := s.unit.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitState, jc.DeepEquals, state.UnitState{})
+}
+
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
 	c.Assert(entity.Refresh(), gc.IsNil)
 	c.Assert(entity.Life(), gc.Equals, life)
@@ -1269,6 +1413,27 @@ func (s *UnitSuite) TestSetPassword(c *gc.C) {
 	})
 }
 
+func (s *UnitSuite) TestUpdateLabels(c *gc.C) {
+	c.Assert(s.unit.Labels(), gc.HasLen, 0)
+
+	err := s.unit.UpdateLabels(map[string]string{"env": "canary", "tier": "web"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateLabels(nil, []string{"tier", "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Labels(), jc.DeepEquals, map[string]string{"env": "canary"})
+
+	u, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.Labels(), jc.DeepEquals, map[string]string{"env": "canary"})
+}
+
+func (s *UnitSuite) TestUpdateLabelsDead(c *gc.C) {
+	c.Assert(s.unit.EnsureDead(), jc.ErrorIsNil)
+
+	err := s.unit.UpdateLabels(map[string]string{"env": "canary"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update labels of unit "wordpress/0": not found or dead`)
+}
+
 func (s *UnitSuite) TestUnitSetAgentPresence(c *gc.C) {
 	alive, err := s.unit.AgentPresence()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// UnitHookQueue describes the hooks that a unit agent is waiting to run.
+type UnitHookQueue struct {
+	// Pending holds the hooks waiting to run, in the
+	// order in which the unit agent expects to run them.
+	Pending []string
+
+	// Updated is when the unit agent last reported its queue,
+	// or the zero time if it has never done so.
+	Updated time.Time
+}
+
+// NextHook returns the next hook that the unit agent expects
+// to run, or the empty string if there are none pending.
+func (q UnitHookQueue) NextHook() string {
+	if len(q.Pending) == 0 {
+		return ""
+	}
+	return q.Pending[0]
+}
+
+type unitHookQueueDoc struct {
+	DocID     string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Unit      string   `bson:"unit"`
+	Pending   []string `bson:"pending"`
+	Updated   int64    `bson:"updated"`
+}
+
+// SetPendingHooks records the hooks that the unit's agent is waiting
+// to run, replacing any previously recorded.
+func (u *Unit) SetPendingHooks(pending []string) error {
+	coll, closer := u.st.db().GetCollection(unitHookQueuesC)
+	defer closer()
+
+	doc := unitHookQueueDoc{
+		DocID:     u.st.docID(u.Name()),
+		ModelUUID: u.st.ModelUUID(),
+		Unit:      u.Name(),
+		Pending:   pending,
+		Updated:   u.st.clock().Now().UnixNano(),
+	}
+	if _, err := coll.Writeable().UpsertId(doc.DocID, doc); err != nil {
+		return errors.Annotatef(err, "cannot set pending hooks for unit %q", u.Name())
+	}
+	return nil
+}
+
+// PendingHooks returns the hooks that the unit's agent most recently
+// reported it was waiting to run.
+func (u *Unit) PendingHooks() (UnitHookQueue, error) {
+	coll, closer := u.st.db().GetCollection(unitHookQueuesC)
+	defer closer()
+
+	var doc unitHookQueueDoc
+	err := coll.FindId(u.Name()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return UnitHookQueue{}, nil
+	} else if err != nil {
+		return UnitHookQueue{}, errors.Annotatef(err, "cannot get pending hooks for unit %q", u.Name())
+	}
+	return UnitHookQueue{
+		Pending: doc.Pending,
+		Updated: unixNanoToTime0(doc.Updated),
+	}, nil
+}
+
+// eraseUnitHookQueue removes the pending hooks recorded for the named unit.
+func eraseUnitHookQueue(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(unitHookQueuesC)
+	defer closer()
+
+	err := coll.Writeable().RemoveId(unitName)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove pending hooks for unit %q", unitName)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxUnitOperations is the number of operations retained in the
+// operation history of each unit. Older operations are discarded
+// as new ones are recorded.
+const maxUnitOperations = 100
+
+// UnitOperation describes the execution of a single operation, such as
+// a hook, action or charm upgrade, by a unit agent.
+type UnitOperation struct {
+	// Kind is the kind of operation, eg "run-hook".
+	Kind string
+
+	// Description is a short description of the operation,
+	// eg "run config-changed hook".
+	Description string
+
+	// Started is when the operation started.
+	Started time.Time
+
+	// Completed is when the operation completed.
+	Completed time.Time
+
+	// Error holds the reason that the operation failed,
+	// or is empty if it succeeded.
+	Error string
+}
+
+type unitOperationDoc struct {
+	ModelUUID   string `bson:"model-uuid"`
+	Unit        string `bson:"unit"`
+	Kind        string `bson:"kind"`
+	Description string `bson:"description"`
+	Started     int64  `bson:"started"`
+	Completed   int64  `bson:"completed"`
+	Error       string `bson:"error,omitempty"`
+}
+
+// RecordOperations adds the given operations to the unit's operation
+// history. Only the most recent operations are retained.
+func (u *Unit) RecordOperations(ops ...UnitOperation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	coll, closer := u.st.db().GetCollection(unitOperationsC)
+	defer closer()
+
+	docs := make([]interface{}, len(ops))
+	for i, op := range ops {
+		docs[i] = &unitOperationDoc{
+			Unit:        u.Name(),
+			Kind:        op.Kind,
+			Description: op.Description,
+			Started:     op.Started.UnixNano(),
+			Completed:   op.Completed.UnixNano(),
+			Error:       op.Error,
+		}
+	}
+	if err := coll.Writeable().Insert(docs...); err != nil {
+		return errors.Annotatef(err, "cannot record operations for unit %q", u.Name())
+	}
+	return errors.Trace(pruneUnitOperations(u.st, u.Name()))
+}
+
+// OperationHistory returns the operations recorded for the unit,
+// most recent first.
+func (u *Unit) OperationHistory() ([]UnitOperation, error) {
+	coll, closer := u.st.db().GetCollection(unitOperationsC)
+	defer closer()
+
+	var docs []unitOperationDoc
+	err := coll.Find(bson.D{{"unit", u.Name()}}).Sort("-started", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get operation history for unit %q", u.Name())
+	}
+	ops := make([]UnitOperation, len(docs))
+	for i, doc := range docs {
+		ops[i] = UnitOperation{
+			Kind:        doc.Kind,
+			Description: doc.Description,
+			Started:     unixNanoToTime0(doc.Started),
+			Completed:   unixNanoToTime0(doc.Completed),
+			Error:       doc.Error,
+		}
+	}
+	return ops, nil
+}
+
+// pruneUnitOperations removes all but the most recent maxUnitOperations
+// operations from the named unit's operation history.
+func pruneUnitOperations(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(unitOperationsC)
+	defer closer()
+
+	var stale []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"unit", unitName}}).
+		Sort("-started", "-_id").
+		Skip(maxUnitOperations).
+		Select(bson.M{"_id": 1}).
+		All(&stale)
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune operation history for unit %q", unitName)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	ids := make([]bson.ObjectId, len(stale))
+	for i, doc := range stale {
+		ids[i] = doc.ID
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{{"_id", bson.D{{"$in", ids}}}})
+	return errors.Annotatef(err, "cannot prune operation history for unit %q", unitName)
+}
+
+// eraseUnitOperations removes the operation history of the named unit.
+func eraseUnitOperations(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(unitOperationsC)
+	defer closer()
+
+	iter := coll.Find(bson.D{{"unit", unitName}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d operation history documents for " + fmt.Sprintf("%q", unitName)
+	deleted, err := deleteInBatches(
+		coll.Writeable().Underlying(), iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
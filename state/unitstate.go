@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// UnitState holds the state that a unit agent keeps between runs,
+// which was previously stored only on the disk of the unit's machine.
+type UnitState struct {
+	// UniterState is the serialised state of the uniter's
+	// operation executor.
+	UniterState string
+
+	// RelationState maps the id of each relation the unit is in
+	// to the serialised state of the unit's view of that relation.
+	RelationState map[int]string
+
+	// StorageState is the serialised state of the unit's
+	// attached storage.
+	StorageState string
+}
+
+type unitStateDoc struct {
+	DocID         string            `bson:"_id"`
+	ModelUUID     string            `bson:"model-uuid"`
+	Unit          string            `bson:"unit"`
+	UniterState   string            `bson:"uniter-state,omitempty"`
+	RelationState map[string]string `bson:"relation-state,omitempty"`
+	StorageState  string            `bson:"storage-state,omitempty"`
+}
+
+// SetState records the state of the unit's agent, replacing
+// any previously recorded.
+func (u *Unit) SetState(unitState UnitState) error {
+	coll, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	doc := unitStateDoc{
+		DocID:        u.st.docID(u.Name()),
+		ModelUUID:    u.st.ModelUUID(),
+		Unit:         u.Name(),
+		UniterState:  unitState.UniterState,
+		StorageState: unitState.StorageState,
+	}
+	if len(unitState.RelationState) > 0 {
+		// Mongo document keys must be strings.
+		doc.RelationState = make(map[string]string, len(unitState.RelationState))
+		for id, relState := range unitState.RelationState {
+			doc.RelationState[strconv.Itoa(id)] = relState
+		}
+	}
+	if _, err := coll.Writeable().UpsertId(doc.DocID, doc); err != nil {
+		return errors.Annotatef(err, "cannot set state of unit %q", u.Name())
+	}
+	return nil
+}
+
+// State returns the most recently recorded state of the unit's agent.
+// An empty state is returned if none has been recorded.
+func (u *Unit) State() (UnitState, error) {
+	coll, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := coll.FindId(u.Name()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return UnitState{}, nil
+	} else if err != nil {
+		return UnitState{}, errors.Annotatef(err, "cannot get state of unit %q", u.Name())
+	}
+	unitState := UnitState{
+		UniterState:  doc.UniterState,
+		StorageState: doc.StorageState,
+	}
+	if len(doc.RelationState) > 0 {
+		unitState.RelationState = make(map[int]string, len(doc.RelationState))
+		for key, relState := range doc.RelationState {
+			id, err := strconv.Atoi(key)
+			if err != nil {
+				return UnitState{}, errors.Annotatef(err, "invalid relation id %q in state of unit %q", key, u.Name())
+			}
+			unitState.RelationState[id] = relState
+		}
+	}
+	return unitState, nil
+}
+
+// eraseUnitState removes the agent state recorded for the named unit.
+func eraseUnitState(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(unitStatesC)
+	defer closer()
+
+	err := coll.Writeable().RemoveId(unitName)
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove state of unit %q", unitName)
+	}
+	return nil
+}
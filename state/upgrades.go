@@ -2220,3 +2220,76 @@ func AddControllerNodeDocs(pool *StatePool) error {
 	}
 	return nil
 }
+
+// compressSettingsBatchSize is the largest number of settings documents
+// that CompressLargeSettings updates in one transaction.
+const compressSettingsBatchSize = 100
+
+// CompressLargeSettings rewrites settings values which were stored
+// uncompressed, but are large enough to be compressed by the current
+// settings code. The values read back are unchanged, so the settings
+// versions are not increased. The documents are updated in batches,
+// each small enough to fit in a transaction, so that any number of
+// large settings can be compressed.
+func CompressLargeSettings(pool *StatePool) error {
+	st := pool.SystemState()
+
+	coll, closer := st.db().GetRawCollection(settingsC)
+	defer closer()
+
+	iter := coll.Find(nil).Select(bson.M{"settings": 1}).Iter()
+	defer iter.Close()
+
+	var ops []txn.Op
+	var batchBytes int
+	flush := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		err := st.runRawTransaction(ops)
+		ops, batchBytes = nil, 0
+		return errors.Trace(err)
+	}
+	var doc struct {
+		DocID    string                 `bson:"_id"`
+		Settings map[string]interface{} `bson:"settings"`
+	}
+	for iter.Next(&doc) {
+		set := bson.M{}
+		var docBytes int
+		for key, value := range doc.Settings {
+			s, ok := value.(string)
+			if !ok || len(s) <= settingCompressionThreshold {
+				continue
+			}
+			compressed := newSettingValue(s)
+			if _, err := compressed.GetBSON(); err != nil {
+				return errors.Annotatef(err, "compressing settings %q key %q", doc.DocID, key)
+			}
+			size, _ := compressed.StoredSize()
+			docBytes += size
+			set["settings."+key] = compressed
+		}
+		// Unmarshalling into a non-nil map adds to it.
+		doc.Settings = nil
+		if len(set) == 0 {
+			continue
+		}
+		if len(ops) >= compressSettingsBatchSize || batchBytes+docBytes > maxSettingSize {
+			if err := flush(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      settingsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": set},
+		})
+		batchBytes += docBytes
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(flush())
+}
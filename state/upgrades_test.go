@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -3283,6 +3284,74 @@ func (s *upgradesSuite) TestAddControllerNodeDocs(c *gc.C) {
 	)
 }
 
+func (s *upgradesSuite) TestCompressLargeSettings(c *gc.C) {
+	settingsColl, closer := s.state.db().GetRawCollection(settingsC)
+	defer closer()
+
+	large := strings.Repeat("certificate data ", 2000)
+	compressed, err := compressSettingValue(large)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(compressed, gc.FitsTypeOf, bson.Binary{})
+
+	err = settingsColl.Insert(bson.M{
+		"_id":      "uuid1:a#large",
+		"settings": bson.M{"small": "value", "large": large},
+	}, bson.M{
+		"_id":      "uuid1:a#small",
+		"settings": bson.M{"small": "value", "number": 1},
+	}, bson.M{
+		"_id":      "uuid2:a#compressed",
+		"settings": bson.M{"large": compressed},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := bsonMById{{
+		"_id":      "uuid1:a#large",
+		"settings": bson.M{"small": "value", "large": compressed},
+	}, {
+		"_id":      "uuid1:a#small",
+		"settings": bson.M{"small": "value", "number": 1},
+	}, {
+		"_id":      "uuid2:a#compressed",
+		"settings": bson.M{"large": compressed},
+	}}
+	sort.Sort(expected)
+	filter := bson.D{{"_id", bson.D{{"$in", []string{
+		"uuid1:a#large", "uuid1:a#small", "uuid2:a#compressed",
+	}}}}}
+	s.assertUpgradedDataWithFilter(c, CompressLargeSettings, filter,
+		expectUpgradedData{settingsColl, expected},
+	)
+}
+
+func (s *upgradesSuite) TestCompressLargeSettingsInBatches(c *gc.C) {
+	settingsColl, closer := s.state.db().GetRawCollection(settingsC)
+	defer closer()
+
+	large := strings.Repeat("certificate data ", 2000)
+	compressed, err := compressSettingValue(large)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// More documents than are updated in a single transaction.
+	var ids []string
+	var docs []interface{}
+	var expected bsonMById
+	for i := 0; i < compressSettingsBatchSize*2+1; i++ {
+		id := fmt.Sprintf("uuid1:a#large-%d", i)
+		ids = append(ids, id)
+		docs = append(docs, bson.M{"_id": id, "settings": bson.M{"large": large}})
+		expected = append(expected, bson.M{"_id": id, "settings": bson.M{"large": compressed}})
+	}
+	err = settingsColl.Insert(docs...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sort.Sort(expected)
+	filter := bson.D{{"_id", bson.D{{"$in", ids}}}}
+	s.assertUpgradedDataWithFilter(c, CompressLargeSettings, filter,
+		expectUpgradedData{settingsColl, expected},
+	)
+}
+
 type docById []bson.M
 
 func (d docById) Len() int           { return len(d) }
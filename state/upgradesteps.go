@@ -0,0 +1,173 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UpgradeStepStatus describes the states an individual upgrade step
+// run by an agent may be in.
+type UpgradeStepStatus string
+
+const (
+	// UpgradeStepPending indicates that the step has not yet been run.
+	UpgradeStepPending UpgradeStepStatus = "pending"
+
+	// UpgradeStepRunning indicates that the step is being run.
+	UpgradeStepRunning UpgradeStepStatus = "running"
+
+	// UpgradeStepCompleted indicates that the step ran successfully.
+	UpgradeStepCompleted UpgradeStepStatus = "completed"
+
+	// UpgradeStepFailed indicates that the step failed. Failed steps
+	// are retried, so a failed step may later be running again.
+	UpgradeStepFailed UpgradeStepStatus = "failed"
+)
+
+// Validate returns an error if the status is not one of the known
+// upgrade step statuses.
+func (s UpgradeStepStatus) Validate() error {
+	switch s {
+	case UpgradeStepPending, UpgradeStepRunning, UpgradeStepCompleted, UpgradeStepFailed:
+		return nil
+	}
+	return errors.NotValidf("upgrade step status %q", s)
+}
+
+// UpgradeStepProgress records the progress of an upgrade step run by
+// an agent while upgrading to a target version.
+type UpgradeStepProgress struct {
+	// Tag is the tag of the agent running the step.
+	Tag string
+
+	// Version is the version the agent is upgrading to.
+	Version version.Number
+
+	// Step is the description of the step.
+	Step string
+
+	// Status is the current status of the step.
+	Status UpgradeStepStatus
+
+	// Message holds any error from running the step.
+	Message string
+
+	// Started is when progress was first recorded for the step.
+	Started time.Time
+
+	// Updated is when progress was last recorded for the step.
+	Updated time.Time
+}
+
+type upgradeStepDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Tag       string `bson:"tag"`
+	Version   string `bson:"version"`
+	Step      string `bson:"step"`
+	Status    string `bson:"status"`
+	Message   string `bson:"message,omitempty"`
+	Started   int64  `bson:"started"`
+	Updated   int64  `bson:"updated"`
+}
+
+func upgradeStepDocID(tag names.Tag, vers version.Number, step string) string {
+	return fmt.Sprintf("%s#%s#%s", tag.String(), vers.String(), step)
+}
+
+// SetUpgradeStepProgress records the status of an upgrade step run by
+// the agent with the given tag while upgrading to the given version.
+func (st *State) SetUpgradeStepProgress(
+	tag names.Tag, vers version.Number, step string, status UpgradeStepStatus, message string,
+) error {
+	if step == "" {
+		return errors.NotValidf("empty upgrade step")
+	}
+	if err := status.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	docID := st.docID(upgradeStepDocID(tag, vers, step))
+	now := st.clock().Now().UnixNano()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		coll, closer := st.db().GetCollection(upgradeStepsC)
+		defer closer()
+		count, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      upgradeStepsC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &upgradeStepDoc{
+					ModelUUID: st.ModelUUID(),
+					Tag:       tag.String(),
+					Version:   vers.String(),
+					Step:      step,
+					Status:    string(status),
+					Message:   message,
+					Started:   now,
+					Updated:   now,
+				},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      upgradeStepsC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"status", string(status)},
+				{"message", message},
+				{"updated", now},
+			}}},
+		}}, nil
+	}
+	err := st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set progress of upgrade step %q", step)
+}
+
+// UpgradeStepsProgress returns the recorded progress of the upgrade
+// steps run by all agents in the model while upgrading to the given version, ordered
+// by agent and then by when each step was started.
+func (st *State) UpgradeStepsProgress(vers version.Number) ([]UpgradeStepProgress, error) {
+	coll, closer := st.db().GetCollection(upgradeStepsC)
+	defer closer()
+
+	var docs []upgradeStepDoc
+	if err := coll.Find(bson.D{{"version", vers.String()}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get upgrade steps progress")
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Tag != docs[j].Tag {
+			return docs[i].Tag < docs[j].Tag
+		}
+		if docs[i].Started != docs[j].Started {
+			return docs[i].Started < docs[j].Started
+		}
+		return docs[i].Step < docs[j].Step
+	})
+	progress := make([]UpgradeStepProgress, len(docs))
+	for i, doc := range docs {
+		progress[i] = UpgradeStepProgress{
+			Tag:     doc.Tag,
+			Version: vers,
+			Step:    doc.Step,
+			Status:  UpgradeStepStatus(doc.Status),
+			Message: doc.Message,
+			Started: unixNanoToTime0(doc.Started),
+			Updated: unixNanoToTime0(doc.Updated),
+		}
+	}
+	return progress, nil
+}
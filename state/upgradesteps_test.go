@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type UpgradeStepsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UpgradeStepsSuite{})
+
+func (s *UpgradeStepsSuite) TestSetUpgradeStepProgress(c *gc.C) {
+	machine0 := names.NewMachineTag("0")
+	machine1 := names.NewMachineTag("1")
+	started := s.Clock.Now()
+
+	err := s.State.SetUpgradeStepProgress(machine1, vers("2.7.0"), "step b", state.UpgradeStepRunning, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetUpgradeStepProgress(machine0, vers("2.7.0"), "step a", state.UpgradeStepRunning, "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetUpgradeStepProgress(machine1, vers("2.7.0"), "step b", state.UpgradeStepFailed, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	// Progress for other versions is not included.
+	err = s.State.SetUpgradeStepProgress(machine0, vers("2.6.0"), "step z", state.UpgradeStepCompleted, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	progress, err := s.State.UpgradeStepsProgress(vers("2.7.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, gc.HasLen, 2)
+	c.Check(progress[0].Tag, gc.Equals, "machine-0")
+	c.Check(progress[0].Step, gc.Equals, "step a")
+	c.Check(progress[0].Status, gc.Equals, state.UpgradeStepRunning)
+	c.Check(progress[1].Tag, gc.Equals, "machine-1")
+	c.Check(progress[1].Version, gc.Equals, vers("2.7.0"))
+	c.Check(progress[1].Step, gc.Equals, "step b")
+	c.Check(progress[1].Status, gc.Equals, state.UpgradeStepFailed)
+	c.Check(progress[1].Message, gc.Equals, "boom")
+	c.Check(progress[1].Started.Equal(started), jc.IsTrue)
+	c.Check(progress[1].Updated.Equal(started.Add(2*time.Minute)), jc.IsTrue)
+}
+
+func (s *UpgradeStepsSuite) TestUpgradeStepsProgressOrderedByStart(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	for _, step := range []string{"second", "first"} {
+		err := s.State.SetUpgradeStepProgress(tag, vers("2.7.0"), step, state.UpgradeStepCompleted, "")
+		c.Assert(err, jc.ErrorIsNil)
+		s.Clock.Advance(time.Second)
+	}
+	progress, err := s.State.UpgradeStepsProgress(vers("2.7.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(progress, gc.HasLen, 2)
+	c.Check(progress[0].Step, gc.Equals, "second")
+	c.Check(progress[1].Step, gc.Equals, "first")
+}
+
+func (s *UpgradeStepsSuite) TestSetUpgradeStepProgressInvalid(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	err := s.State.SetUpgradeStepProgress(tag, vers("2.7.0"), "", state.UpgradeStepRunning, "")
+	c.Assert(err, gc.ErrorMatches, "empty upgrade step not valid")
+	err = s.State.SetUpgradeStepProgress(tag, vers("2.7.0"), "step", state.UpgradeStepStatus("bogus"), "")
+	c.Assert(err, gc.ErrorMatches, `upgrade step status "bogus" not valid`)
+}
@@ -50,7 +50,60 @@ func (st *State) checkUserExists(name string) (bool, error) {
 
 // AddUser adds a user to the database.
 func (st *State) AddUser(name, displayName, password, creator string) (*User, error) {
-	return st.addUser(name, displayName, password, creator, nil)
+	return st.addUser(AddUserArgs{
+		Name:        name,
+		DisplayName: displayName,
+		Password:    password,
+		Creator:     creator,
+	}, nil)
+}
+
+// UserModelAccess describes access to a model that is
+// granted to a user at the time that the user is added.
+type UserModelAccess struct {
+	ModelUUID string
+	Access    permission.Access
+}
+
+// AddUserArgs contains the arguments for AddUserWithArgs.
+type AddUserArgs struct {
+	Name        string
+	DisplayName string
+	Creator     string
+
+	// Password is optional. If it is empty, the user is assigned
+	// a randomly generated secret key, as for AddUserWithSecretKey.
+	Password string
+
+	// ModelAccess holds the access to models to grant the user.
+	// The grants are applied in the same transaction that adds
+	// the user, so either all of them are made or none are.
+	ModelAccess []UserModelAccess
+
+	// Expires, if not zero, is the time after which the
+	// user is disabled and may no longer log in.
+	Expires time.Time
+}
+
+// AddUserWithArgs adds a user to the database, along with
+// the model access and expiry time described by args.
+func (st *State) AddUserWithArgs(args AddUserArgs) (*User, error) {
+	if !args.Expires.IsZero() && !args.Expires.After(st.clock().Now()) {
+		return nil, errors.NotValidf("expiry time %s in the past", args.Expires.UTC().Format(time.RFC3339))
+	}
+	for _, access := range args.ModelAccess {
+		if err := permission.ValidateModelAccess(access.Access); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	var secretKey []byte
+	if args.Password == "" {
+		var err error
+		if secretKey, err = generateSecretKey(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return st.addUser(args, secretKey)
 }
 
 // AddUserWithSecretKey adds the user with the specified name, and assigns it
@@ -65,10 +118,15 @@ func (st *State) AddUserWithSecretKey(name, displayName, creator string) (*User,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return st.addUser(name, displayName, "", creator, secretKey)
+	return st.addUser(AddUserArgs{
+		Name:        name,
+		DisplayName: displayName,
+		Creator:     creator,
+	}, secretKey)
 }
 
-func (st *State) addUser(name, displayName, password, creator string, secretKey []byte) (*User, error) {
+func (st *State) addUser(args AddUserArgs, secretKey []byte) (*User, error) {
+	name, displayName, creator := args.Name, args.DisplayName, args.Creator
 	if !names.IsValidUserName(name) {
 		return nil, errors.Errorf("invalid user name %q", name)
 	}
@@ -84,15 +142,16 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 			SecretKey:   secretKey,
 			CreatedBy:   creator,
 			DateCreated: dateCreated,
+			Expires:     args.Expires.UTC(),
 		},
 	}
 
-	if password != "" {
+	if args.Password != "" {
 		salt, err := utils.RandomSalt()
 		if err != nil {
 			return nil, err
 		}
-		user.doc.PasswordHash = utils.UserPasswordHash(password, salt)
+		user.doc.PasswordHash = utils.UserPasswordHash(args.Password, salt)
 		user.doc.PasswordSalt = salt
 	}
 
@@ -110,7 +169,32 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		defaultControllerPermission)
 	ops = append(ops, controllerUserOps...)
 
-	err := st.db().RunTransaction(ops)
+	var err error
+	if len(args.ModelAccess) == 0 {
+		err = st.db().RunTransaction(ops)
+	} else {
+		// The model access spans several models, which the
+		// model-scoped transaction runner does not allow.
+		// The ops are rewritten for each model explicitly.
+		for _, access := range args.ModelAccess {
+			modelOps, err := initialModelUserOps(
+				access.ModelUUID,
+				names.NewUserTag(name),
+				names.NewUserTag(creator),
+				displayName,
+				dateCreated,
+				access.Access)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, modelOps...)
+		}
+		if err = st.db().RunRawTransaction(ops); err == txn.ErrAborted {
+			if err = st.checkModelsAlive(args.ModelAccess); err == nil {
+				err = txn.ErrAborted
+			}
+		}
+	}
 	if err == txn.ErrAborted {
 		err = errors.Errorf("username unavailable")
 	}
@@ -123,6 +207,55 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 	return user, nil
 }
 
+// initialModelUserOps returns the operations required to grant a new user
+// access to the model with the input UUID, suitable for running in a raw
+// transaction alongside operations for other models.
+func initialModelUserOps(
+	modelUUID string, user, createdBy names.UserTag, displayName string, dateCreated time.Time, access permission.Access,
+) ([]txn.Op, error) {
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     modelUUID,
+		Assert: isAliveDoc,
+	}}
+	for _, op := range createModelUserOps(modelUUID, user, createdBy, displayName, dateCreated, access) {
+		if op.C == modelUsersC {
+			doc, err := mungeDocForMultiModel(op.Insert, modelUUID, modelUUIDRequired)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			op.Id = ensureModelUUID(modelUUID, op.Id.(string))
+			op.Insert = doc
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// checkModelsAlive returns an error if any of the models
+// to which access is being granted is missing or not alive.
+func (st *State) checkModelsAlive(access []UserModelAccess) error {
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+
+	for _, a := range access {
+		var doc struct {
+			Life Life `bson:"life"`
+		}
+		err := models.FindId(a.ModelUUID).One(&doc)
+		if err == mgo.ErrNotFound {
+			return errors.NotFoundf("model %q", a.ModelUUID)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if doc.Life != Alive {
+			return errors.Errorf("model %q is no longer alive", a.ModelUUID)
+		}
+	}
+	return nil
+}
+
 // RemoveUser marks the user as deleted. This obviates the ability of a user
 // to function, but keeps the userDoc retaining provenance, i.e. auditing.
 func (st *State) RemoveUser(tag names.UserTag) error {
@@ -282,6 +415,10 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+
+	// Expires, if not zero, is the time after
+	// which the user is considered disabled.
+	Expires time.Time `bson:"expires,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -494,11 +631,17 @@ func (u *User) Enable() error {
 }
 
 func (u *User) setDeactivated(value bool) error {
+	update := bson.D{{"$set", bson.D{{"deactivated", value}}}}
+	if !value {
+		// Explicitly enabling a user clears any expiry time,
+		// which would otherwise leave the user disabled.
+		update = append(update, bson.DocElem{"$unset", bson.D{{"expires", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      usersC,
 		Id:     u.Name(),
 		Assert: txn.DocExists,
-		Update: bson.D{{"$set", bson.D{{"deactivated", value}}}},
+		Update: update,
 	}}
 	if err := u.st.db().RunTransaction(ops); err != nil {
 		if err == txn.ErrAborted {
@@ -507,14 +650,32 @@ func (u *User) setDeactivated(value bool) error {
 		return err
 	}
 	u.doc.Deactivated = value
+	if !value {
+		u.doc.Expires = time.Time{}
+	}
 	return nil
 }
 
-// IsDisabled returns whether the user is currently enabled.
+// IsDisabled returns whether the user is currently disabled,
+// either explicitly or because its expiry time has passed.
 func (u *User) IsDisabled() bool {
 	// Yes, this is a cached value, but in practice the user object is
 	// never held around for a long time.
-	return u.doc.Deactivated
+	return u.doc.Deactivated || u.IsExpired()
+}
+
+// Expires returns the time after which the user is disabled,
+// or the zero time if the user does not expire.
+func (u *User) Expires() time.Time {
+	return u.doc.Expires
+}
+
+// IsExpired returns whether the user's expiry time has passed.
+func (u *User) IsExpired() bool {
+	if u.doc.Expires.IsZero() {
+		return false
+	}
+	return !u.st.clock().Now().Before(u.doc.Expires)
 }
 
 // IsDeleted returns whether the user is currently deleted.
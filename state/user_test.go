@@ -78,6 +78,97 @@ func (s *UserSuite) TestAddUser(c *gc.C) {
 	c.Assert(lastLogin, gc.DeepEquals, time.Time{})
 }
 
+func (s *UserSuite) TestAddUserWithArgsModelAccess(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	user, err := s.State.AddUserWithArgs(state.AddUserArgs{
+		Name:    "bob",
+		Creator: "admin",
+		ModelAccess: []state.UserModelAccess{
+			{ModelUUID: s.State.ModelUUID(), Access: permission.ReadAccess},
+			{ModelUUID: st.ModelUUID(), Access: permission.WriteAccess},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SecretKey(), gc.HasLen, 32)
+
+	access, err := s.State.UserAccess(user.UserTag(), s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access.Access, gc.Equals, permission.ReadAccess)
+
+	access, err = st.UserAccess(user.UserTag(), names.NewModelTag(st.ModelUUID()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access.Access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *UserSuite) TestAddUserWithArgsModelNotAlive(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+
+	_, err = s.State.AddUserWithArgs(state.AddUserArgs{
+		Name:    "bob",
+		Creator: "admin",
+		ModelAccess: []state.UserModelAccess{
+			{ModelUUID: s.State.ModelUUID(), Access: permission.ReadAccess},
+			{ModelUUID: st.ModelUUID(), Access: permission.WriteAccess},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model ".*" is no longer alive`)
+
+	// Neither the user nor any of its access was added.
+	_, err = s.State.User(names.NewUserTag("bob"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UserSuite) TestAddUserWithArgsInvalidAccess(c *gc.C) {
+	_, err := s.State.AddUserWithArgs(state.AddUserArgs{
+		Name:    "bob",
+		Creator: "admin",
+		ModelAccess: []state.UserModelAccess{
+			{ModelUUID: s.State.ModelUUID(), Access: permission.SuperuserAccess},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `"superuser" model access not valid`)
+}
+
+func (s *UserSuite) TestAddUserWithArgsExpiresInPast(c *gc.C) {
+	_, err := s.State.AddUserWithArgs(state.AddUserArgs{
+		Name:    "bob",
+		Creator: "admin",
+		Expires: s.Clock.Now().Add(-time.Minute),
+	})
+	c.Assert(err, gc.ErrorMatches, `expiry time .* in the past not valid`)
+}
+
+func (s *UserSuite) TestUserExpires(c *gc.C) {
+	expires := s.Clock.Now().Add(time.Hour)
+	user, err := s.State.AddUserWithArgs(state.AddUserArgs{
+		Name:     "bob",
+		Creator:  "admin",
+		Password: "a-password",
+		Expires:  expires,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.Expires().Equal(expires), jc.IsTrue)
+	c.Assert(user.IsDisabled(), jc.IsFalse)
+	c.Assert(user.PasswordValid("a-password"), jc.IsTrue)
+
+	s.Clock.Advance(time.Hour)
+	c.Assert(user.IsExpired(), jc.IsTrue)
+	c.Assert(user.IsDisabled(), jc.IsTrue)
+	c.Assert(user.PasswordValid("a-password"), jc.IsFalse)
+
+	// Enabling the user clears its expiry.
+	c.Assert(user.Enable(), jc.ErrorIsNil)
+	c.Assert(user.Refresh(), jc.ErrorIsNil)
+	c.Assert(user.Expires().IsZero(), jc.IsTrue)
+	c.Assert(user.IsDisabled(), jc.IsFalse)
+}
+
 func (s *UserSuite) TestCheckUserExists(c *gc.C) {
 	user := s.Factory.MakeUser(c, nil)
 	exists, err := state.CheckUserExists(s.State, user.Name())
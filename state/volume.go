@@ -52,6 +52,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// ResizeSize returns the size in MiB that the volume has been
+	// requested to grow to. ResizeSize returns true if a resize is
+	// pending, otherwise false.
+	ResizeSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -132,6 +137,11 @@ type volumeDoc struct {
 	// the volume as being non-detachable, and to determine
 	// which volumes must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// ResizeSize is the size in MiB that a provisioned volume
+	// has been requested to grow to, or zero if no resize is
+	// pending.
+	ResizeSize uint64 `bson:"resize-size,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -278,6 +288,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// ResizeSize is required to implement Volume.
+func (v *volume) ResizeSize() (uint64, bool) {
+	return v.doc.ResizeSize, v.doc.ResizeSize != 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -286,7 +301,7 @@ func (v *volume) Status() (status.StatusInfo, error) {
 // SetStatus is required to implement StatusSetter.
 func (v *volume) SetStatus(volumeStatus status.StatusInfo) error {
 	switch volumeStatus.Status {
-	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Destroying:
+	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Resizing, status.Destroying:
 	case status.Error:
 		if volumeStatus.Message == "" {
 			return errors.Errorf("cannot set status %q without info", volumeStatus.Status)
@@ -1440,11 +1455,97 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		// If the volume has grown to the size it was requested
+		// to be resized to, the resize is complete.
+		if size, ok := v.ResizeSize(); ok && info.Size >= size {
+			resizedOps, err := sb.volumeResizedOps(v)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resizedOps...)
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
+// volumeResizedOps returns the operations required to clear a
+// completed resize request from the volume, and to restore its
+// status to reflect whether or not it is attached.
+func (sb *storageBackend) volumeResizedOps(v Volume) ([]txn.Op, error) {
+	vol := v.(*volume)
+	statusDoc := statusDoc{
+		Status:  status.Detached,
+		Updated: sb.mb.clock().Now().UnixNano(),
+	}
+	if vol.doc.AttachmentCount > 0 {
+		statusDoc.Status = status.Attached
+	}
+	statusOps, err := statusSetOps(sb.mb.db(), statusDoc, vol.globalKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append([]txn.Op{{
+		C:      volumesC,
+		Id:     vol.doc.Name,
+		Assert: bson.D{{"resize-size", vol.doc.ResizeSize}},
+		Update: bson.D{{"$unset", bson.D{{"resize-size", nil}}}},
+	}}, statusOps...), nil
+}
+
+// ResizeStorageInstance requests that the volume assigned to the
+// specified storage instance be grown to the given size in MiB. The
+// storage provisioner resizes the volume while it remains in use, and
+// the request is cleared once the volume's new size is recorded.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := sb.storageInstanceVolume(tag)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotSupportedf("resizing storage without a volume")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.Errorf("volume %q is not alive", v.doc.Name)
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.NewNotValid(nil, fmt.Sprintf(
+				"volume %q is already %dMiB, cannot resize to %dMiB",
+				v.doc.Name, info.Size, size,
+			))
+		}
+		if v.doc.ResizeSize == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		statusOps, err := statusSetOps(sb.mb.db(), statusDoc{
+			Status:     status.Resizing,
+			StatusInfo: fmt.Sprintf("resizing to %dMiB", size),
+			Updated:    sb.mb.clock().Now().UnixNano(),
+		}, v.globalKey())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assert := append(isAliveDoc, bson.DocElem{"info.size", info.Size})
+		if v.doc.ResizeSize == 0 {
+			assert = append(assert, bson.DocElem{"resize-size", bson.D{{"$exists", false}}})
+		} else {
+			assert = append(assert, bson.DocElem{"resize-size", v.doc.ResizeSize})
+		}
+		return append([]txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: assert,
+			Update: bson.D{{"$set", bson.D{{"resize-size", size}}}},
+		}}, statusOps...), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -233,6 +233,23 @@ func (sb *storageBackend) WatchModelFilesystems() StringsWatcher {
 	return sb.watchModelHostStorage(filesystemsC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, so that requests to resize them can
+// be observed. The initial event contains all model-scoped volumes.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	mb := sb.mb
+	return newCollectionWatcher(mb, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := mb.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
 var machineOrUnitSnippet = "(" + names.NumberSnippet + "|" + names.UnitSnippet + ")"
 
 func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatcher {
@@ -1664,6 +1681,18 @@ func (a *Application) Watch() NotifyWatcher {
 	return newEntityWatcher(a.st, applicationsC, a.doc.DocID)
 }
 
+// WatchConstraints returns a watcher for observing changes to an
+// application's constraints.
+func (a *Application) WatchConstraints() NotifyWatcher {
+	return newEntityWatcher(a.st, constraintsC, a.st.docID(a.globalKey()))
+}
+
+// WatchModelConstraints returns a watcher for observing changes to the
+// model's constraints.
+func (st *State) WatchModelConstraints() NotifyWatcher {
+	return newEntityWatcher(st, constraintsC, st.docID(modelGlobalKey))
+}
+
 // WatchLeaderSettings returns a watcher for observing changed to an application's
 // leader settings.
 func (a *Application) WatchLeaderSettings() NotifyWatcher {
@@ -1763,6 +1792,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to
+// the application's configuration, as opposed to its charm config.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	configKey := applicationConfigKey(a.doc.Name)
+	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2685,6 +2721,7 @@ func (w *collectionWatcher) loop() error {
 
 	w.watcher.WatchCollectionWithFilter(w.col, w.source, w.filter)
 	defer w.watcher.UnwatchCollection(w.col, w.source)
+	lapsed := w.watcher.Lapsed(w.source)
 
 	changes, err := w.initial()
 	if err != nil {
@@ -2697,6 +2734,8 @@ func (w *collectionWatcher) loop() error {
 			return tomb.ErrDying
 		case <-w.watcher.Dead():
 			return stateWatcherDeadError(w.watcher.Err())
+		case <-lapsed:
+			return errors.Errorf("watcher for %q collection lapsed", w.col)
 		case ch := <-in:
 			updates, ok := collect(ch, in, w.tomb.Dying())
 			if !ok {
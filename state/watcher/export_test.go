@@ -21,5 +21,12 @@ func NewTestWatcher(changelog *mgo.Collection, iteratorFunc func() mongo.Iterato
 }
 
 func NewTestHubWatcher(hub HubSource, clock Clock, modelUUID string, logger Logger) (*HubWatcher, <-chan struct{}) {
-	return newHubWatcher(hub, clock, modelUUID, logger)
+	return newHubWatcher(hub, clock, modelUUID, logger, DefaultMaxPendingEvents, nil)
+}
+
+func NewTestHubWatcherWithMaxPendingEvents(
+	hub HubSource, clock Clock, modelUUID string, logger Logger,
+	maxPendingEvents int, lapseObserver func(string),
+) (*HubWatcher, <-chan struct{}) {
+	return newHubWatcher(hub, clock, modelUUID, logger, maxPendingEvents, lapseObserver)
 }
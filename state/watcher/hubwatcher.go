@@ -23,6 +23,13 @@ var (
 	HubWatcherIdleTime = 50 * time.Millisecond
 )
 
+// DefaultMaxPendingEvents is the number of undelivered events a hub
+// watcher queues for a single channel, when not configured otherwise,
+// before it lapses the watches using that channel. Only the watches
+// of channels whose consumers have asked to be told when they lapse,
+// by calling Lapsed, are lapsed.
+const DefaultMaxPendingEvents = 10000
+
 // HubSource represents the listening aspects of the pubsub hub.
 type HubSource interface {
 	SubscribeMatch(matcher func(string) bool, handler func(string, interface{})) func()
@@ -47,6 +54,18 @@ type HubWatcher struct {
 	// watches holds the observers managed by Watch/Unwatch.
 	watches map[watchKey][]watchInfo
 
+	// channels holds the state of each channel that watches
+	// in watches send events on.
+	channels map[chan<- Change]*channelInfo
+
+	// maxPendingEvents is the number of undelivered events that may
+	// be queued for a channel before its watches are lapsed.
+	maxPendingEvents int
+
+	// lapseObserver, if non-nil, is called each time the watches
+	// of a channel are lapsed.
+	lapseObserver func(modelUUID string)
+
 	// syncEvents contain the events to be
 	// dispatched to the watcher channels. They're queued during
 	// processing and flushed at the end to simplify the algorithm.
@@ -83,6 +102,38 @@ type HubWatcher struct {
 
 	// revnoMapBytes tracks how big our revnomap is in approximate bytes
 	revnoMapBytes uintptr
+
+	// lapseCount is the number of channels whose watches we've lapsed
+	lapseCount uint64
+}
+
+// channelInfo holds the state of a channel that events are sent on.
+type channelInfo struct {
+	// watches is the number of watches sending events on the channel.
+	watches int
+
+	// pending is the number of events queued for the channel
+	// in syncEvents.
+	pending int
+
+	// lapsed is closed when the watches sending events on the
+	// channel lapse.
+	lapsed chan struct{}
+
+	// lapsable records whether the channel's consumer has asked,
+	// by calling Lapsed, to be told when its watches lapse. The
+	// watches of other channels never lapse, as their consumers
+	// would wait forever for events that were dropped.
+	lapsable bool
+}
+
+func (info *channelInfo) isLapsed() bool {
+	select {
+	case <-info.lapsed:
+		return true
+	default:
+		return false
+	}
 }
 
 // HubWatcherConfig contains the configuration parameters required
@@ -97,6 +148,13 @@ type HubWatcherConfig struct {
 	ModelUUID string
 	// Logger is used to control where the log messages for this watcher go.
 	Logger Logger
+	// MaxPendingEvents is the number of undelivered events that may be
+	// queued for a single channel before the watches using the channel
+	// are lapsed. If it is zero, DefaultMaxPendingEvents is used.
+	MaxPendingEvents int
+	// LapseObserver, if non-nil, is called with the model UUID each
+	// time the watches of a channel are lapsed.
+	LapseObserver func(modelUUID string)
 }
 
 // Validate ensures that all the values that have to be set are set.
@@ -110,6 +168,9 @@ func (config HubWatcherConfig) Validate() error {
 	if config.ModelUUID == "" {
 		return errors.NotValidf("missing Model UUID")
 	}
+	if config.MaxPendingEvents < 0 {
+		return errors.NotValidf("negative MaxPendingEvents")
+	}
 	return nil
 }
 
@@ -119,24 +180,34 @@ func NewHubWatcher(config HubWatcherConfig) (*HubWatcher, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Annotate(err, "new HubWatcher invalid config")
 	}
-	watcher, _ := newHubWatcher(config.Hub, config.Clock, config.ModelUUID, config.Logger)
+	maxPendingEvents := config.MaxPendingEvents
+	if maxPendingEvents == 0 {
+		maxPendingEvents = DefaultMaxPendingEvents
+	}
+	watcher, _ := newHubWatcher(config.Hub, config.Clock, config.ModelUUID, config.Logger, maxPendingEvents, config.LapseObserver)
 	return watcher, nil
 }
 
-func newHubWatcher(hub HubSource, clock Clock, modelUUID string, logger Logger) (*HubWatcher, <-chan struct{}) {
+func newHubWatcher(
+	hub HubSource, clock Clock, modelUUID string, logger Logger,
+	maxPendingEvents int, lapseObserver func(string),
+) (*HubWatcher, <-chan struct{}) {
 	if logger == nil {
 		logger = noOpLogger{}
 	}
 	started := make(chan struct{})
 	w := &HubWatcher{
-		hub:       hub,
-		clock:     clock,
-		modelUUID: modelUUID,
-		idleFunc:  HubWatcherIdleFunc,
-		logger:    logger,
-		watches:   make(map[watchKey][]watchInfo),
-		request:   make(chan interface{}),
-		changes:   make(chan Change),
+		hub:              hub,
+		clock:            clock,
+		modelUUID:        modelUUID,
+		idleFunc:         HubWatcherIdleFunc,
+		logger:           logger,
+		watches:          make(map[watchKey][]watchInfo),
+		channels:         make(map[chan<- Change]*channelInfo),
+		maxPendingEvents: maxPendingEvents,
+		lapseObserver:    lapseObserver,
+		request:          make(chan interface{}),
+		changes:          make(chan Change),
 	}
 	w.tomb.Go(func() error {
 		unsub := hub.SubscribeMatch(
@@ -296,6 +367,31 @@ func (w *HubWatcher) UnwatchCollection(collection string, ch chan<- Change) {
 	w.sendReq(reqUnwatch{watchKey{collection, nil}, ch})
 }
 
+type reqLapsed struct {
+	ch    chan<- Change
+	reply chan (<-chan struct{})
+}
+
+// Lapsed returns a channel that is closed if the watches sending events
+// on ch lapse. Watches lapse when more than the configured maximum
+// number of events are queued for a channel that isn't being read,
+// so that one slow consumer cannot hold up the events for all the
+// others. Once lapsed, no more events are sent on ch, and any that
+// were queued are dropped, but ch must still be unwatched as usual.
+// Only the watches of channels for which Lapsed has been called may
+// lapse. Lapsed must be called after ch is watched; if it isn't being
+// watched, a nil channel is returned.
+func (w *HubWatcher) Lapsed(ch chan<- Change) <-chan struct{} {
+	reply := make(chan (<-chan struct{}))
+	w.sendReq(reqLapsed{ch: ch, reply: reply})
+	select {
+	case <-w.tomb.Dying():
+		return nil
+	case lapsed := <-reply:
+		return lapsed
+	}
+}
+
 // HubWatcherStats defines a few metrics that the hub watcher tracks
 type HubWatcherStats struct {
 	// WatchKeyCount is the number of keys being watched
@@ -320,6 +416,9 @@ type HubWatcherStats struct {
 	RequestCount uint64
 	// ChangeCount is the number of changes we've processed
 	ChangeCount uint64
+	// LapseCount is the number of channels whose watches have lapsed
+	// because their consumers fell too far behind
+	LapseCount uint64
 }
 
 type reqStats struct {
@@ -352,6 +451,7 @@ func (w *HubWatcher) Report() map[string]interface{} {
 		"sync-event-coll-count": stats.SyncEventCollCount,
 		"request-count":         stats.RequestCount,
 		"change-count":          stats.ChangeCount,
+		"lapse-count":           stats.LapseCount,
 	}
 }
 
@@ -403,31 +503,61 @@ func (w *HubWatcher) flush() bool {
 	// syncEvents are stored first in first out.
 	// syncEvents may grow during the looping here if new
 	// watch events come in while we are notifying other watchers.
+	//
+	// Each channel is sent its events in order, but a channel whose
+	// consumer is slow doesn't hold up the events for the others;
+	// they are sent to whichever channel is ready first, and the
+	// events for the slow channel wait in syncEvents until it is.
 	w.logger.Tracef("%p flushing syncEvents: len(%d) cap(%d)", w, len(w.syncEvents), cap(w.syncEvents))
-	for i := 0; i < len(w.syncEvents); i++ {
-		// We need to reget the address value each time through the loop
-		// as the slice may be reallocated.
-		for e := &w.syncEvents[i]; e.ch != nil; e = &w.syncEvents[i] {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.tomb.Dying())},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.request)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.changes)},
+	}
+	const fixedCases = 3
+	heads := w.channelHeads()
+	channels := make([]chan<- Change, 0, len(heads))
+	for len(heads) > 0 {
+		cases = cases[:fixedCases]
+		channels = channels[:0]
+		for ch, i := range heads {
+			e := &w.syncEvents[i]
 			outChange := Change{
 				C:     e.key.c,
 				Id:    e.key.id,
 				Revno: e.revno,
 			}
-			w.logger.Tracef("%p sending syncEvent(%d): e.ch=%v %v", w, i, e.ch, outChange)
-			select {
-			case <-w.tomb.Dying():
-				return watchersNotified
-			case req := <-w.request:
-				w.handle(req)
-				continue
-			case inChange := <-w.changes:
-				w.queueChange(inChange)
-				continue
-			case e.ch <- outChange:
-				w.logger.Tracef("%p e.ch=%v has been notified %v", w, e.ch, outChange)
-				watchersNotified = true
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(ch),
+				Send: reflect.ValueOf(outChange),
+			})
+			channels = append(channels, ch)
+		}
+		chosen, received, _ := reflect.Select(cases)
+		switch chosen {
+		case 0:
+			return watchersNotified
+		case 1:
+			w.handle(received.Interface())
+			heads = w.channelHeads()
+		case 2:
+			w.queueChange(received.Interface().(Change))
+			heads = w.channelHeads()
+		default:
+			ch := channels[chosen-fixedCases]
+			i := heads[ch]
+			w.logger.Tracef("%p e.ch=%v has been notified %v", w, ch, cases[chosen].Send.Interface())
+			w.syncEvents[i].ch = nil
+			if info := w.channels[ch]; info != nil {
+				info.pending--
+			}
+			watchersNotified = true
+			if next, ok := w.nextEvent(ch, i+1); ok {
+				heads[ch] = next
+			} else {
+				delete(heads, ch)
 			}
-			break
 		}
 	}
 	w.lastSyncLen = len(w.syncEvents)
@@ -451,6 +581,37 @@ func (w *HubWatcher) flush() bool {
 	return watchersNotified
 }
 
+// channelHeads drops the events that have been sent, or that are no
+// longer to be sent, from syncEvents, so that they don't accumulate
+// while a slow channel holds up the flush. It returns the index in
+// syncEvents of the first event still to be sent on each channel.
+func (w *HubWatcher) channelHeads() map[chan<- Change]int {
+	heads := make(map[chan<- Change]int)
+	pending := w.syncEvents[:0]
+	for _, e := range w.syncEvents {
+		if e.ch == nil {
+			continue
+		}
+		if _, ok := heads[e.ch]; !ok {
+			heads[e.ch] = len(pending)
+		}
+		pending = append(pending, e)
+	}
+	w.syncEvents = pending
+	return heads
+}
+
+// nextEvent returns the index in syncEvents of the next event to be
+// sent on ch, starting at from, and whether there is one.
+func (w *HubWatcher) nextEvent(ch chan<- Change, from int) (int, bool) {
+	for i := from; i < len(w.syncEvents); i++ {
+		if w.syncEvents[i].ch == ch {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 // handle deals with requests delivered by the public API
 // onto the background watcher goroutine.
 func (w *HubWatcher) handle(req interface{}) {
@@ -466,6 +627,7 @@ func (w *HubWatcher) handle(req interface{}) {
 			}
 		}
 		w.watches[r.key] = append(w.watches[r.key], r.info)
+		w.addChannelWatch(r.info.ch)
 		if r.registeredCh != nil {
 			select {
 			case r.registeredCh <- nil:
@@ -494,6 +656,7 @@ func (w *HubWatcher) handle(req interface{}) {
 				filter: nil,
 			}
 			w.watches[key] = append(w.watches[key], info)
+			w.addChannelWatch(r.watchCh)
 		}
 		select {
 		case r.completedCh <- nil:
@@ -513,12 +676,28 @@ func (w *HubWatcher) handle(req interface{}) {
 		if !removed {
 			panic(fmt.Errorf("tried to remove missing channel %v for %s", r.ch, r.key))
 		}
+		info := w.channels[r.ch]
 		for i := range w.syncEvents {
 			e := &w.syncEvents[i]
 			if r.key.match(e.key) && e.ch == r.ch {
 				e.ch = nil
+				info.pending--
 			}
 		}
+		info.watches--
+		if info.watches == 0 {
+			delete(w.channels, r.ch)
+		}
+	case reqLapsed:
+		var lapsed <-chan struct{}
+		if info := w.channels[r.ch]; info != nil {
+			info.lapsable = true
+			lapsed = info.lapsed
+		}
+		select {
+		case <-w.tomb.Dying():
+		case r.reply <- lapsed:
+		}
 	case reqStats:
 		var watchCount uint64
 		for _, watches := range w.watches {
@@ -536,6 +715,7 @@ func (w *HubWatcher) handle(req interface{}) {
 			SyncEventCollCount: w.syncEventCollectionCount,
 			SyncEventDocCount:  w.syncEventDocCount,
 			RequestCount:       w.requestCount,
+			LapseCount:         w.lapseCount,
 		}
 		select {
 		case <-w.tomb.Dying():
@@ -572,6 +752,50 @@ func sizeInMap(key watchKey) uintptr {
 	return size
 }
 
+// addChannelWatch records that another watch sends events on ch.
+func (w *HubWatcher) addChannelWatch(ch chan<- Change) {
+	info := w.channels[ch]
+	if info == nil {
+		info = &channelInfo{lapsed: make(chan struct{})}
+		w.channels[ch] = info
+	}
+	info.watches++
+}
+
+// queueEvent adds evt to syncEvents, unless the watches sending on
+// its channel have lapsed. If that leaves too many events queued for
+// a channel whose consumer handles lapsing, its watches are lapsed.
+func (w *HubWatcher) queueEvent(evt event) bool {
+	info := w.channels[evt.ch]
+	if info.isLapsed() {
+		return false
+	}
+	w.syncEvents = append(w.syncEvents, evt)
+	info.pending++
+	if info.lapsable && info.pending > w.maxPendingEvents {
+		w.lapse(evt.ch, info)
+	}
+	return true
+}
+
+// lapse drops the events queued for ch, and stops any more from being
+// queued, so that flush is no longer held up by ch's consumer. The
+// consumer is notified by closing the channel returned by Lapsed.
+func (w *HubWatcher) lapse(ch chan<- Change, info *channelInfo) {
+	w.logger.Warningf("watcher %v has %d undelivered events, lapsing its watches", ch, info.pending)
+	for i := range w.syncEvents {
+		if e := &w.syncEvents[i]; e.ch == ch {
+			e.ch = nil
+		}
+	}
+	info.pending = 0
+	close(info.lapsed)
+	w.lapseCount++
+	if w.lapseObserver != nil {
+		w.lapseObserver(w.modelUUID)
+	}
+}
+
 // queueChange queues up the change for the registered watchers.
 func (w *HubWatcher) queueChange(change Change) {
 	w.changeCount++
@@ -589,7 +813,9 @@ func (w *HubWatcher) queueChange(change Change) {
 			key:   key,
 			revno: revno,
 		}
-		w.syncEvents = append(w.syncEvents, evt)
+		if !w.queueEvent(evt) {
+			continue
+		}
 		w.syncEventCollectionCount++
 		w.logger.Tracef("%p adding event for collection %q watch %v, syncEvents: len(%d), cap(%d)", w, change.C, info.ch, len(w.syncEvents), cap(w.syncEvents))
 	}
@@ -604,7 +830,9 @@ func (w *HubWatcher) queueChange(change Change) {
 				key:   key,
 				revno: revno,
 			}
-			w.syncEvents = append(w.syncEvents, evt)
+			if !w.queueEvent(evt) {
+				continue
+			}
 			w.syncEventDocCount++
 			w.logger.Tracef("%p adding event for %v watch %v, syncEvents: len(%d), cap(%d)", w, key, info.ch, len(w.syncEvents), cap(w.syncEvents))
 		}
@@ -350,3 +350,116 @@ func (s *HubWatcherSuite) TestWatchStoppedWhileFlushing(c *gc.C) {
 	// unwatch, all the pending events should be cleared.
 	assertNoChange(c, s.ch)
 }
+
+func (s *HubWatcherSuite) TestSlowConsumerLapses(c *gc.C) {
+	var observed []string
+	w, started := watcher.NewTestHubWatcherWithMaxPendingEvents(s.hub, clock.WallClock, "model-uuid", nil, 2, func(modelUUID string) {
+		observed = append(observed, modelUUID)
+	})
+	defer worker.Stop(w)
+	select {
+	case <-started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("hub watcher worker didn't start")
+	}
+
+	slow := make(chan watcher.Change)
+	w.WatchCollection("test", slow)
+	w.WatchCollection("test", s.ch)
+	lapsed := w.Lapsed(slow)
+	c.Assert(lapsed, gc.NotNil)
+	c.Assert(w.Lapsed(make(chan watcher.Change)), gc.IsNil)
+
+	// Nothing reads from slow, so the events for it queue up until
+	// there are too many, and its watch lapses.
+	changes := []watcher.Change{
+		{"test", "a", 1},
+		{"test", "b", 1},
+		{"test", "c", 1},
+	}
+	s.publish(c, changes...)
+	select {
+	case <-lapsed:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("slow watch did not lapse")
+	}
+
+	// The events for the other channel are no longer held up.
+	for _, change := range changes {
+		assertChange(c, s.ch, change)
+	}
+	assertNoChange(c, s.ch)
+	assertNoChange(c, slow)
+
+	// No more events are sent on the lapsed channel.
+	s.publish(c, watcher.Change{"test", "d", 1})
+	assertChange(c, s.ch, watcher.Change{"test", "d", 1})
+	assertNoChange(c, slow)
+	c.Assert(w.Stats().LapseCount, gc.Equals, uint64(1))
+	c.Assert(observed, jc.DeepEquals, []string{"model-uuid"})
+
+	// A lapsed channel is unwatched as usual.
+	w.UnwatchCollection("test", slow)
+	c.Assert(w.Lapsed(slow), gc.IsNil)
+}
+
+func (s *HubWatcherSuite) TestSlowConsumerNotHandlingLapseWaits(c *gc.C) {
+	w, started := watcher.NewTestHubWatcherWithMaxPendingEvents(s.hub, clock.WallClock, "model-uuid", nil, 2, nil)
+	defer worker.Stop(w)
+	select {
+	case <-started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("hub watcher worker didn't start")
+	}
+
+	// Lapsed is never called for the channel, so its
+	// watch doesn't lapse however far behind it falls.
+	slow := make(chan watcher.Change)
+	w.WatchCollection("test", slow)
+	changes := []watcher.Change{
+		{"test", "a", 1},
+		{"test", "b", 1},
+		{"test", "c", 1},
+	}
+	s.publish(c, changes...)
+	for _, change := range changes {
+		assertChange(c, slow, change)
+	}
+	assertNoChange(c, slow)
+	c.Assert(w.Stats().LapseCount, gc.Equals, uint64(0))
+}
+
+func (s *HubWatcherSuite) TestSlowConsumerDoesNotHoldUpOthers(c *gc.C) {
+	// Nothing reads from slow until the end, and it never lapses,
+	// but the events for the other channel are still sent.
+	slow := make(chan watcher.Change)
+	s.w.WatchCollection("test", slow)
+	s.w.WatchCollection("test", s.ch)
+
+	changes := []watcher.Change{
+		{"test", "a", 1},
+		{"test", "b", 1},
+		{"test", "c", 1},
+	}
+	for _, change := range changes {
+		s.publish(c, change)
+		assertChange(c, s.ch, change)
+	}
+	assertNoChange(c, s.ch)
+
+	// The slow channel is sent its events in order once it's read.
+	for _, change := range changes {
+		assertChange(c, slow, change)
+	}
+	assertNoChange(c, slow)
+}
+
+func (s *HubWatcherSuite) TestNewHubWatcherInvalidMaxPendingEvents(c *gc.C) {
+	_, err := watcher.NewHubWatcher(watcher.HubWatcherConfig{
+		Hub:              s.hub,
+		Clock:            clock.WallClock,
+		ModelUUID:        "model-uuid",
+		MaxPendingEvents: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "new HubWatcher invalid config: negative MaxPendingEvents not valid")
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const modelLabel = "model"
+
+// LapseCollector is a prometheus.Collector that counts the channels
+// whose hub watcher watches have lapsed because their consumers fell
+// too far behind.
+type LapseCollector struct {
+	lapsesTotalCounter *prometheus.CounterVec
+}
+
+// NewLapseCollector returns a new LapseCollector.
+func NewLapseCollector() *LapseCollector {
+	return &LapseCollector{
+		prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "juju",
+				Subsystem: "state_watcher",
+				Name:      "lapses_total",
+				Help:      "Total number of channels whose watches have lapsed.",
+			},
+			[]string{modelLabel},
+		),
+	}
+}
+
+// WatchLapsed is called when the watches of a channel lapse. It may
+// be used as a HubWatcherConfig's LapseObserver.
+func (c *LapseCollector) WatchLapsed(modelUUID string) {
+	c.lapsesTotalCounter.WithLabelValues(modelUUID).Inc()
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *LapseCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.lapsesTotalCounter.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *LapseCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lapsesTotalCounter.Collect(ch)
+}
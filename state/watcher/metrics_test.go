@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+type LapseCollectorSuite struct {
+	testing.IsolationSuite
+	collector *watcher.LapseCollector
+}
+
+var _ = gc.Suite(&LapseCollectorSuite{})
+
+func (s *LapseCollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.collector = watcher.NewLapseCollector()
+}
+
+func (s *LapseCollectorSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		s.collector.Describe(ch)
+	}()
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	c.Assert(descs, gc.HasLen, 1)
+	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_state_watcher_lapses_total".*`)
+}
+
+func (s *LapseCollectorSuite) TestCollect(c *gc.C) {
+	s.collector.WatchLapsed("model-uuid")
+	s.collector.WatchLapsed("model-uuid")
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		s.collector.Collect(ch)
+	}()
+	var metrics []prometheus.Metric
+	for metric := range ch {
+		metrics = append(metrics, metric)
+	}
+	c.Assert(metrics, gc.HasLen, 1)
+
+	var metric dto.Metric
+	c.Assert(metrics[0].Write(&metric), jc.ErrorIsNil)
+	c.Assert(metric.Counter.GetValue(), gc.Equals, float64(2))
+	c.Assert(metric.Label, gc.HasLen, 1)
+	c.Assert(metric.Label[0].GetName(), gc.Equals, "model")
+	c.Assert(metric.Label[0].GetValue(), gc.Equals, "model-uuid")
+}
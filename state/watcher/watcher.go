@@ -73,6 +73,15 @@ type BaseWatcher interface {
 	// the same Change channel. Unwatching a collection that isn't being watched
 	// is an error that will panic().
 	UnwatchCollection(collection string, ch chan<- Change)
+
+	// Lapsed returns a channel that is closed if the watcher gives up
+	// sending events on ch because its consumer has fallen too far
+	// behind. No more events are sent on a lapsed channel, so the
+	// consumer can no longer trust its view of the watched documents.
+	// The channel must still be unwatched as usual. Lapsed must be
+	// called after ch is watched, and only the watches of channels
+	// for which it has been called may lapse.
+	Lapsed(ch chan<- Change) <-chan struct{}
 }
 
 var logger = loggo.GetLogger("juju.state.watcher")
@@ -354,6 +363,12 @@ func (w *Watcher) UnwatchCollection(collection string, ch chan<- Change) {
 	w.sendReq(reqUnwatch{watchKey{collection, nil}, ch})
 }
 
+// Lapsed is part of the BaseWatcher interface. A Watcher never lapses
+// the watches using a channel, so Lapsed returns a nil channel.
+func (w *Watcher) Lapsed(ch chan<- Change) <-chan struct{} {
+	return nil
+}
+
 // StartSync forces the watcher to load new events from the database.
 func (w *Watcher) StartSync() {
 	w.sendReq(reqSync{})
@@ -59,11 +59,23 @@ func newWorkers(st *State, hub *pubsub.SimpleHub) (*workers, error) {
 		})
 	} else {
 		ws.StartWorker(txnLogWorker, func() (worker.Worker, error) {
+			// The controller config doesn't exist while the
+			// database is being initialised, in which case the
+			// default limit is used.
+			var maxPendingEvents int
+			cfg, err := st.ControllerConfig()
+			if err == nil {
+				maxPendingEvents = cfg.WatcherMaxPendingEvents()
+			} else if !errors.IsNotFound(errors.Cause(err)) {
+				return nil, errors.Trace(err)
+			}
 			return watcher.NewHubWatcher(watcher.HubWatcherConfig{
-				Hub:       hub,
-				Clock:     st.clock(),
-				ModelUUID: st.modelUUID(),
-				Logger:    loggo.GetLogger("juju.state.watcher"),
+				Hub:              hub,
+				Clock:            st.clock(),
+				ModelUUID:        st.modelUUID(),
+				Logger:           loggo.GetLogger("juju.state.watcher"),
+				MaxPendingEvents: maxPendingEvents,
+				LapseObserver:    st.watcherLapseObserver,
 			})
 		})
 	}
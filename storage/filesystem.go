@@ -3,7 +3,12 @@
 
 package storage
 
-import "gopkg.in/juju/names.v2"
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
 
 // Filesystem identifies and describes a filesystem, either local or remote
 // (NFS, Ceph etc).
@@ -51,4 +56,24 @@ type FilesystemAttachmentInfo struct {
 
 	// ReadOnly indicates that the filesystem is mounted read-only.
 	ReadOnly bool
+
+	// MountOptions holds any additional options
+	// with which the filesystem is mounted.
+	MountOptions []string
+}
+
+// validMountOption matches a single mount option, which
+// is either a flag or a key=value pair.
+var validMountOption = regexp.MustCompile(`^[a-zA-Z0-9_.-]+(=[a-zA-Z0-9_.:/-]+)?$`)
+
+// ValidateMountOptions returns an error if any of the filesystem mount
+// options, such as those requested by a charm's storage metadata, are
+// not valid options to pass to mount(8).
+func ValidateMountOptions(options []string) error {
+	for _, option := range options {
+		if !validMountOption.MatchString(option) {
+			return errors.NotValidf("mount option %q", option)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,29 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type FilesystemSuite struct{}
+
+var _ = gc.Suite(&FilesystemSuite{})
+
+func (s *FilesystemSuite) TestValidateMountOptions(c *gc.C) {
+	err := storage.ValidateMountOptions([]string{"noatime", "nodev", "commit=60", "context=system_u:object_r:tmp_t"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = storage.ValidateMountOptions(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FilesystemSuite) TestValidateMountOptionsInvalid(c *gc.C) {
+	err := storage.ValidateMountOptions([]string{"noatime", "bad option"})
+	c.Assert(err, gc.ErrorMatches, `mount option "bad option" not valid`)
+	err = storage.ValidateMountOptions([]string{"ro,nodev"})
+	c.Assert(err, gc.ErrorMatches, `mount option "ro,nodev" not valid`)
+}
@@ -4,6 +4,8 @@
 package storage
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/instance"
@@ -216,6 +218,54 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes that
+// have already been created, while they remain in use. Storage
+// providers whose volume sources implement VolumeResizer support
+// online resizing of storage.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested size. ResizeVolumes must be
+	// idempotent; it may be called again for a volume that has
+	// already been resized.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]error, error)
+}
+
+// FilesystemResizer provides an interface for growing attached
+// filesystems to fill their backing storage, once it has been
+// resized.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the attached filesystems with the
+	// specified parameters to fill their backing storage, and
+	// returns the filesystems' new sizes. ResizeFilesystems must
+	// be idempotent; it may be called again for a filesystem that
+	// has already been grown.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemAttachmentParams) ([]ResizeFilesystemsResult, error)
+}
+
+// VolumeCreationTimer provides an interface for reporting when
+// volumes were created. Volumes that a model does not know about
+// are only reported as orphaned by volume sources that implement
+// VolumeCreationTimer, so that volumes which are still being
+// provisioned are never mistaken for orphans.
+type VolumeCreationTimer interface {
+	// VolumeCreationTimes returns the times at which the volumes
+	// with the specified provider volume IDs were created. Volumes
+	// whose creation time is unknown are omitted from the result.
+	VolumeCreationTimes(ctx context.ProviderCallContext, volIds []string) (map[string]time.Time, error)
+}
+
+// VolumeResizeParams is a set of parameters for resizing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider ID of the volume to resize.
+	VolumeId string
+
+	// Size is the new minimum size of the volume in MiB.
+	Size uint64
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -337,6 +387,10 @@ type FilesystemAttachmentParams struct {
 	// Path is the path at which the filesystem is to be mounted on the machine that
 	// this attachment corresponds to.
 	Path string
+
+	// MountOptions holds additional options with
+	// which the filesystem is to be mounted.
+	MountOptions []string
 }
 
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
@@ -375,3 +429,10 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeFilesystemsResult contains the result of a FilesystemResizer.ResizeFilesystems
+// call for one filesystem. Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
@@ -4,6 +4,8 @@
 package dummy
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 
@@ -25,6 +27,8 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+	ResizeVolumesFunc        func(context.ProviderCallContext, []storage.VolumeResizeParams) ([]error, error)
+	VolumeCreationTimesFunc  func(context.ProviderCallContext, []string) (map[string]time.Time, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -72,6 +76,24 @@ func (s *VolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []
 	return nil, errors.NotImplementedf("ReleaseVolumes")
 }
 
+// VolumeCreationTimes is defined on storage.VolumeCreationTimer.
+func (s *VolumeSource) VolumeCreationTimes(ctx context.ProviderCallContext, volIds []string) (map[string]time.Time, error) {
+	s.MethodCall(s, "VolumeCreationTimes", ctx, volIds)
+	if s.VolumeCreationTimesFunc != nil {
+		return s.VolumeCreationTimesFunc(ctx, volIds)
+	}
+	return nil, errors.NotImplementedf("VolumeCreationTimes")
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]error, error) {
+	s.MethodCall(s, "ResizeVolumes", ctx, params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
 // ValidateVolumeParams is defined on storage.VolumeSource.
 func (s *VolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	s.MethodCall(s, "ValidateVolumeParams", params)
@@ -154,7 +154,7 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if isDiskDevice(devicePath) {
 		devicePath = partitionDevicePath(devicePath)
 	}
-	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
+	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly, arg.MountOptions); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &storage.FilesystemAttachment{
@@ -163,6 +163,7 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 		storage.FilesystemAttachmentInfo{
 			arg.Path,
 			arg.ReadOnly,
+			arg.MountOptions,
 		},
 	}, nil
 }
@@ -178,6 +179,43 @@ func (s *managedFilesystemSource) DetachFilesystems(ctx context.ProviderCallCont
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemAttachmentParams) (*storage.Filesystem, error) {
+	filesystem, ok := s.filesystems[arg.Filesystem]
+	if !ok {
+		return nil, errors.Errorf("filesystem %v is not yet provisioned", arg.Filesystem.Id())
+	}
+	blockDevice, err := s.backingVolumeBlockDevice(filesystem.Volume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return nil, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystem.Size = blockDevice.Size
+	return &filesystem, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -207,7 +245,32 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
-func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if output, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart fails when the partition already fills the disk.
+		if strings.HasPrefix(output, "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the specified device path to
+// fill the device. The filesystem may be mounted while it is grown.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
+func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool, mountOptions []string) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
 		return errors.Annotate(err, "creating mount point")
@@ -220,9 +283,14 @@ func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoi
 		logger.Debugf("filesystem on %q already mounted at %q", mountSource, mountPoint)
 		return nil
 	}
-	var args []string
+	var options []string
 	if readOnly {
-		args = append(args, "-o", "ro")
+		options = append(options, "ro")
+	}
+	options = append(options, mountOptions...)
+	var args []string
+	if len(options) > 0 {
+		args = append(args, "-o", strings.Join(options, ","))
 	}
 	args = append(args, devicePath, mountPoint)
 	if _, err := run("mount", args...); err != nil {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -159,6 +160,99 @@ func (s *managedfsSuite) TestAttachFilesystemsReattach(c *gc.C) {
 	s.testAttachFilesystems(c, true, true, mtabEntry, "")
 }
 
+func (s *managedfsSuite) TestAttachFilesystemsMountOptions(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	s.commands.expect("mount", "-o", "ro,noatime,commit=60", "/dev/sda1", testMountPoint)
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+			ReadOnly:   true,
+		},
+		Path:         testMountPoint,
+		MountOptions: []string{"noatime", "commit=60"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0/0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path:         testMountPoint,
+				ReadOnly:     true,
+				MountOptions: []string{"noatime", "commit=60"},
+			},
+		},
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// sda's partition is grown before the filesystem on it;
+	// growpart fails if the partition already fills the disk.
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 could only be grown by 0", errors.New("exit status 1"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	for i := 0; i < 2; i++ {
+		tag := names.NewFilesystemTag(fmt.Sprintf("0/%d", i))
+		s.filesystems[tag] = storage.Filesystem{
+			Tag:    tag,
+			Volume: names.NewVolumeTag(fmt.Sprint(i)),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: tag.String(),
+				Size:         uint64(i + 2),
+			},
+		}
+	}
+
+	resizer, ok := source.(storage.FilesystemResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0/0"),
+		Path:       testMountPoint,
+	}, {
+		Filesystem: names.NewFilesystemTag("0/1"),
+		Path:       testMountPoint,
+	}, {
+		Filesystem: names.NewFilesystemTag("0/2"),
+		Path:       testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem.Size, gc.Equals, uint64(4))
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Filesystem.Size, gc.Equals, uint64(6))
+	c.Assert(results[2].Error, gc.ErrorMatches, "filesystem 0/2 is not yet provisioned")
+}
+
 func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool, mtab, fstab string) {
 	source := s.initSource(c)
 	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
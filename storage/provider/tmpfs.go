@@ -207,6 +207,9 @@ func (s *tmpfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmen
 		if arg.ReadOnly {
 			options += ",ro"
 		}
+		for _, option := range arg.MountOptions {
+			options += "," + option
+		}
 		if _, err := s.run(
 			"mount", "-t", "tmpfs", arg.Filesystem.String(), path, "-o", options,
 		); err != nil {
@@ -219,8 +222,9 @@ func (s *tmpfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmen
 		arg.Filesystem,
 		arg.Machine,
 		storage.FilesystemAttachmentInfo{
-			Path:     path,
-			ReadOnly: arg.ReadOnly,
+			Path:         path,
+			ReadOnly:     arg.ReadOnly,
+			MountOptions: arg.MountOptions,
 		},
 	}, nil
 }
@@ -232,6 +232,30 @@ func (s *tmpfsSuite) TestAttachFilesystemsMountReadOnly(c *gc.C) {
 	}})
 }
 
+func (s *tmpfsSuite) TestAttachFilesystemsMountOptions(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	_, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmd := s.commands.expect("df", "--output=source", "/var/lib/juju/storage/fs/foo")
+	cmd.respond("header\nvalue", nil)
+	s.commands.expect("mount", "-t", "tmpfs", "filesystem-1", "/var/lib/juju/storage/fs/foo", "-o", "size=1024m,noexec,mode=0700")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "/var/lib/juju/storage/fs/foo",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("2"),
+		},
+		MountOptions: []string{"noexec", "mode=0700"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment.MountOptions, jc.DeepEquals, []string{"noexec", "mode=0700"})
+}
+
 func (s *tmpfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 	_, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
@@ -65,6 +65,7 @@ type StateBackend interface {
 	RemoveInstanceCharmProfileDataCollection() error
 	UpdateK8sModelNameIndex() error
 	AddControllerNodeDocs() error
+	CompressLargeSettings() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -254,3 +255,7 @@ func (s stateBackend) UpdateK8sModelNameIndex() error {
 func (s stateBackend) AddControllerNodeDocs() error {
 	return state.AddControllerNodeDocs(s.pool)
 }
+
+func (s stateBackend) CompressLargeSettings() error {
+	return state.CompressLargeSettings(s.pool)
+}
@@ -92,3 +92,43 @@ func (c *upgradeContext) APIContext() Context {
 		api:         c.api,
 	}
 }
+
+// WithStepProgress returns a copy of the context that reports the
+// progress of each upgrade step run with it to the reporter.
+func WithStepProgress(context Context, reporter StepProgressReporter) Context {
+	return &progressContext{
+		Context:  context,
+		reporter: reporter,
+	}
+}
+
+// progressContext is a Context that reports upgrade step progress.
+type progressContext struct {
+	Context
+	reporter StepProgressReporter
+}
+
+// StateContext is defined on the Context interface.
+func (c *progressContext) StateContext() Context {
+	return WithStepProgress(c.Context.StateContext(), c.reporter)
+}
+
+// APIContext is defined on the Context interface.
+func (c *progressContext) APIContext() Context {
+	return WithStepProgress(c.Context.APIContext(), c.reporter)
+}
+
+// stepProgressReporter returns the reporter for the context, which
+// does nothing if the context was not created by WithStepProgress.
+func stepProgressReporter(context Context) StepProgressReporter {
+	if c, ok := context.(*progressContext); ok {
+		return c.reporter
+	}
+	return noProgressReporter{}
+}
+
+type noProgressReporter struct{}
+
+func (noProgressReporter) StepStarted(string) {}
+
+func (noProgressReporter) StepFinished(string, error) {}
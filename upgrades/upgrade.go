@@ -43,6 +43,17 @@ type OperationSource interface {
 	UpgradeOperations() []Operation
 }
 
+// StepProgressReporter is told as each upgrade step is started, and
+// when it finishes.
+type StepProgressReporter interface {
+	// StepStarted is called before the step is run.
+	StepStarted(step string)
+
+	// StepFinished is called after the step is run, with
+	// the error from running the step, if any.
+	StepFinished(step string, err error)
+}
+
 // Target defines the type of machine for which a particular upgrade
 // step can be run.
 type Target string
@@ -132,11 +143,15 @@ func hasDatabaseMasterTarget(targets []Target) bool {
 // ones. The steps must be idempotent so that the entire upgrade
 // operation can be retried.
 func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error {
+	reporter := stepProgressReporter(context)
 	for ops.Next() {
 		for _, step := range ops.Get().Steps() {
 			if targetsMatch(targets, step.Targets()) {
 				logger.Infof("running upgrade step: %v", step.Description())
-				if err := step.Run(context); err != nil {
+				reporter.StepStarted(step.Description())
+				err := step.Run(context)
+				reporter.StepFinished(step.Description(), err)
+				if err != nil {
 					logger.Errorf("upgrade step %q failed: %v", step.Description(), err)
 					return &upgradeError{
 						description: step.Description(),
@@ -587,6 +587,53 @@ func (s *upgradeSuite) checkContextRestriction(c *gc.C, expectedPanic string) {
 	)
 }
 
+type recordingReporter struct {
+	events []string
+}
+
+func (r *recordingReporter) StepStarted(step string) {
+	r.events = append(r.events, "started "+step)
+}
+
+func (r *recordingReporter) StepFinished(step string, err error) {
+	if err != nil {
+		r.events = append(r.events, fmt.Sprintf("failed %s: %v", step, err))
+		return
+	}
+	r.events = append(r.events, "finished "+step)
+}
+
+func (s *upgradeSuite) TestPerformUpgradeReportsStepProgress(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps:         []upgrades.Step{&contextStep{useAPI: false}},
+			},
+		}
+	})
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps:         []upgrades.Step{newUpgradeStep("api step error", upgrades.Controller)},
+			},
+		}
+	})
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	reporter := &recordingReporter{}
+	ctx := upgrades.WithStepProgress(&mockContext{state: &mockStateBackend{}}, reporter)
+	err := upgrades.PerformUpgrade(version.MustParse("1.20.0"), targets(upgrades.Controller), ctx)
+	c.Assert(err, gc.ErrorMatches, "api step error: upgrade error occurred")
+	c.Assert(reporter.events, jc.DeepEquals, []string{
+		"started something",
+		"finished something",
+		"started api step error",
+		"failed api step error: upgrade error occurred",
+	})
+}
+
 func (s *upgradeSuite) TestStateStepsNotAttemptedWhenNoStateTarget(c *gc.C) {
 	stateCount := 0
 	stateUpgradeOperations := func() []upgrades.Operation {
@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionnotifier
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// NewHTTPPost returns a PostFunc that posts notifications over HTTPS,
+// giving up on any request that takes longer than the timeout.
+// Notification URLs are validated when actions are enqueued, but host
+// names may since resolve differently, so connections to restricted
+// addresses are refused here too, as are redirects to invalid URLs.
+// Notifications are not sent through any configured proxy, so that
+// the address checked is the one connected to.
+func NewHTTPPost(timeout time.Duration) PostFunc {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: checkDialAddress,
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return actions.ValidateNotifyURL(req.URL.String())
+		},
+	}
+	return func(url string, body []byte) error {
+		if err := actions.ValidateNotifyURL(url); err != nil {
+			return errors.Trace(err)
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return errors.Errorf("notification rejected: %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// checkDialAddress refuses connections to restricted addresses,
+// once the host name has been resolved.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || actions.IsRestrictedNotifyAddress(ip) {
+		return errors.Errorf("cannot post notification to restricted address %s", host)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionnotifier provides a worker that posts a notification
+// to an action's notification URL when the action finishes, so that
+// users who started long-running actions in the background learn of
+// their completion without polling the controller.
+package actionnotifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/actionnotifier"
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionnotifier")
+
+const (
+	// DefaultRetryDelay is the default time to wait before
+	// retrying notifications that could not be posted.
+	DefaultRetryDelay = time.Minute
+
+	// DefaultMaxAttempts is the default number of times the
+	// notification of an action is attempted before giving up.
+	DefaultMaxAttempts = 5
+
+	// batchSize is the maximum number of actions whose
+	// notifications are requested in a single API call.
+	batchSize = 100
+)
+
+// Facade exposes the controller capabilities needed by the worker.
+type Facade interface {
+	WatchFinishedActions() (watcher.StringsWatcher, error)
+	PendingNotifications(ids []string) ([]actionnotifier.Notification, error)
+	SetNotified(id string) error
+}
+
+// PostFunc posts the JSON encoded body to the URL.
+type PostFunc func(url string, body []byte) error
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// ModelUUID is the UUID of the model whose actions are notified.
+	ModelUUID string
+
+	// Post posts notifications.
+	Post PostFunc
+
+	// RetryDelay is the time to wait before retrying
+	// notifications that could not be posted.
+	RetryDelay time.Duration
+
+	// MaxAttempts is the number of times the notification of an
+	// action is attempted before giving up.
+	MaxAttempts int
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.ModelUUID == "" {
+		return errors.NotValidf("empty ModelUUID")
+	}
+	if config.Post == nil {
+		return errors.NotValidf("nil Post")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.MaxAttempts <= 0 {
+		return errors.NotValidf("non-positive MaxAttempts")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that posts a notification of each
+// finished action that has a notification URL.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &notifierWorker{
+		config:   config,
+		attempts: make(map[string]int),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type notifierWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// attempts holds the number of failed attempts to post the
+	// notification of each action awaiting a retry, by action id.
+	attempts map[string]int
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *notifierWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *notifierWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *notifierWorker) loop() error {
+	actionsWatcher, err := w.config.Facade.WatchFinishedActions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(actionsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case ids, ok := <-actionsWatcher.Changes():
+			if !ok {
+				return errors.New("finished actions watcher closed")
+			}
+			if err := w.notify(ids); err != nil {
+				return errors.Trace(err)
+			}
+		case <-retry:
+			retry = nil
+			ids := make([]string, 0, len(w.attempts))
+			for id := range w.attempts {
+				ids = append(ids, id)
+			}
+			if err := w.notify(ids); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if retry == nil && len(w.attempts) > 0 {
+			retry = w.config.Clock.After(w.config.RetryDelay)
+		}
+	}
+}
+
+// notify posts the pending notifications of the actions with the
+// given ids.
+func (w *notifierWorker) notify(ids []string) error {
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > batchSize {
+			batch = ids[:batchSize]
+		}
+		ids = ids[len(batch):]
+
+		notifications, err := w.config.Facade.PendingNotifications(batch)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pending := make(map[string]bool)
+		for _, n := range notifications {
+			pending[n.ActionID] = true
+			if err := w.post(n); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		// Forget retries of actions that no longer need notifying.
+		for _, id := range batch {
+			if !pending[id] {
+				delete(w.attempts, id)
+			}
+		}
+	}
+	return nil
+}
+
+// post posts the notification, recording it as notified once posted
+// or once MaxAttempts attempts have failed.
+func (w *notifierWorker) post(n actionnotifier.Notification) error {
+	body, err := json.Marshal(newPayload(w.config.ModelUUID, n))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.config.Post(n.URL, body); err != nil {
+		w.attempts[n.ActionID]++
+		if w.attempts[n.ActionID] < w.config.MaxAttempts {
+			logger.Debugf("cannot post notification of action %s (will retry): %v", n.ActionID, err)
+			return nil
+		}
+		logger.Warningf("giving up posting notification of action %s after %d attempts: %v",
+			n.ActionID, w.attempts[n.ActionID], err)
+	} else {
+		logger.Debugf("posted notification of action %s", n.ActionID)
+	}
+	delete(w.attempts, n.ActionID)
+	return errors.Trace(w.config.Facade.SetNotified(n.ActionID))
+}
+
+// payload is the body of a notification. The "text" field holds a
+// summary of the notification, so that the URL of a chat service's
+// incoming webhook may be used as the notification URL.
+type payload struct {
+	Text      string                 `json:"text"`
+	ModelUUID string                 `json:"model-uuid"`
+	ActionID  string                 `json:"action-id"`
+	Receiver  string                 `json:"receiver"`
+	Action    string                 `json:"action"`
+	Status    string                 `json:"status"`
+	Message   string                 `json:"message,omitempty"`
+	Results   map[string]interface{} `json:"results,omitempty"`
+	Enqueued  time.Time              `json:"enqueued"`
+	Started   time.Time              `json:"started"`
+	Completed time.Time              `json:"completed"`
+}
+
+func newPayload(modelUUID string, n actionnotifier.Notification) payload {
+	text := fmt.Sprintf("Action %q (id %s) on %s %s", n.Name, n.ActionID, n.Receiver, n.Status)
+	if n.Message != "" {
+		text += ": " + n.Message
+	}
+	return payload{
+		Text:      text,
+		ModelUUID: modelUUID,
+		ActionID:  n.ActionID,
+		Receiver:  n.Receiver,
+		Action:    n.Name,
+		Status:    n.Status,
+		Message:   n.Message,
+		Results:   n.Output,
+		Enqueued:  n.Enqueued,
+		Started:   n.Started,
+		Completed: n.Completed,
+	}
+}
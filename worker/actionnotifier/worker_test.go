@@ -0,0 +1,241 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionnotifier_test
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apiactionnotifier "github.com/juju/juju/api/actionnotifier"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionnotifier"
+)
+
+type WorkerSuite struct {
+	clock   *testclock.Clock
+	changes chan []string
+	facade  *fakeFacade
+	posts   chan post
+
+	mu      sync.Mutex
+	postErr error
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+type post struct {
+	url  string
+	body map[string]interface{}
+}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(coretesting.ZeroTime())
+	s.changes = make(chan []string, 1)
+	s.posts = make(chan post, 10)
+	s.postErr = nil
+	s.facade = &fakeFacade{
+		changes:  s.changes,
+		notified: make(chan string, 10),
+		pending: map[string]apiactionnotifier.Notification{
+			"1": {
+				URL:       "https://hooks.example.com/1",
+				ActionID:  "1",
+				Receiver:  "mysql/0",
+				Name:      "backup",
+				Status:    "completed",
+				Output:    map[string]interface{}{"path": "/tmp/backup.tgz"},
+				Enqueued:  coretesting.ZeroTime(),
+				Started:   coretesting.ZeroTime(),
+				Completed: coretesting.ZeroTime(),
+			},
+		},
+	}
+}
+
+func (s *WorkerSuite) post(url string, body []byte) error {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return errors.Trace(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.posts <- post{url, payload}
+	return s.postErr
+}
+
+func (s *WorkerSuite) config() actionnotifier.Config {
+	return actionnotifier.Config{
+		Facade:      s.facade,
+		Clock:       s.clock,
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Post:        s.post,
+		RetryDelay:  time.Minute,
+		MaxAttempts: 2,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := actionnotifier.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.changes <- []string{"1", "2"}
+	return w
+}
+
+func (s *WorkerSuite) assertPost(c *gc.C) post {
+	select {
+	case p := <-s.posts:
+		return p
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for notification")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNotified(c *gc.C, expect string) {
+	select {
+	case id := <-s.facade.notified:
+		c.Assert(id, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action to be marked notified")
+	}
+}
+
+func (s *WorkerSuite) assertNotNotified(c *gc.C) {
+	select {
+	case id := <-s.facade.notified:
+		c.Fatalf("unexpected notification of action %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*actionnotifier.Config)
+		err    string
+	}{
+		{func(cfg *actionnotifier.Config) { cfg.Facade = nil }, "nil Facade not valid"},
+		{func(cfg *actionnotifier.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *actionnotifier.Config) { cfg.ModelUUID = "" }, "empty ModelUUID not valid"},
+		{func(cfg *actionnotifier.Config) { cfg.Post = nil }, "nil Post not valid"},
+		{func(cfg *actionnotifier.Config) { cfg.RetryDelay = 0 }, "non-positive RetryDelay not valid"},
+		{func(cfg *actionnotifier.Config) { cfg.MaxAttempts = 0 }, "non-positive MaxAttempts not valid"},
+	} {
+		c.Logf("test %d", i)
+		config := s.config()
+		test.change(&config)
+		_, err := actionnotifier.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestPostsNotification(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	p := s.assertPost(c)
+	c.Assert(p.url, gc.Equals, "https://hooks.example.com/1")
+	c.Assert(p.body["text"], gc.Equals, `Action "backup" (id 1) on mysql/0 completed`)
+	c.Assert(p.body["model-uuid"], gc.Equals, coretesting.ModelTag.Id())
+	c.Assert(p.body["action-id"], gc.Equals, "1")
+	c.Assert(p.body["receiver"], gc.Equals, "mysql/0")
+	c.Assert(p.body["action"], gc.Equals, "backup")
+	c.Assert(p.body["status"], gc.Equals, "completed")
+	c.Assert(p.body["results"], jc.DeepEquals, map[string]interface{}{"path": "/tmp/backup.tgz"})
+	s.assertNotified(c, "1")
+	c.Assert(s.facade.requested(), jc.DeepEquals, [][]string{{"1", "2"}})
+}
+
+func (s *WorkerSuite) TestRetriesFailedPost(c *gc.C) {
+	s.postErr = errors.New("connection refused")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertPost(c)
+	s.assertNotNotified(c)
+
+	s.mu.Lock()
+	s.postErr = nil
+	s.mu.Unlock()
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertPost(c)
+	s.assertNotified(c, "1")
+	c.Assert(s.facade.requested(), jc.DeepEquals, [][]string{{"1", "2"}, {"1"}})
+}
+
+func (s *WorkerSuite) TestGivesUpAfterMaxAttempts(c *gc.C) {
+	s.postErr = errors.New("connection refused")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertPost(c)
+	s.assertNotNotified(c)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertPost(c)
+	s.assertNotified(c, "1")
+}
+
+func (s *WorkerSuite) TestWatcherError(c *gc.C) {
+	w := s.startWorker(c)
+	close(s.changes)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "finished actions watcher closed")
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	changes  chan []string
+	pending  map[string]apiactionnotifier.Notification
+	notified chan string
+	requests [][]string
+}
+
+func (f *fakeFacade) WatchFinishedActions() (watcher.StringsWatcher, error) {
+	return watchertest.NewMockStringsWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) PendingNotifications(ids []string) ([]apiactionnotifier.Notification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, ids)
+	var result []apiactionnotifier.Notification
+	for _, id := range ids {
+		if n, ok := f.pending[id]; ok {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeFacade) SetNotified(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.pending, id)
+	f.notified <- id
+	return nil
+}
+
+func (f *fakeFacade) requested() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func (s *WorkerSuite) TestHTTPPostRejectsRestrictedURL(c *gc.C) {
+	post := actionnotifier.NewHTTPPost(time.Second)
+	err := post("https://169.254.169.254/latest/meta-data", []byte("{}"))
+	c.Assert(err, gc.ErrorMatches, `notification URL .* \(local and private addresses are not allowed\) not valid`)
+	err = post("http://hooks.example.com/1", []byte("{}"))
+	c.Assert(err, gc.ErrorMatches, `notification URL .* \(expected an https URL\) not valid`)
+}
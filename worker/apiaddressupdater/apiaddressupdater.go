@@ -5,11 +5,15 @@ package apiaddressupdater
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/network"
@@ -22,11 +26,18 @@ var logger = loggo.GetLogger("juju.worker.apiaddressupdater")
 // In practice, APIAddressUpdater is used by a machine agent to watch
 // API addresses in state and write the changes to the agent's config file.
 type APIAddressUpdater struct {
-	addresser APIAddresser
-	setter    APIAddressSetter
+	addresser   APIAddresser
+	setter      APIAddressSetter
+	prioritizer *Prioritizer
 
-	mu      sync.Mutex
-	current [][]network.HostPort
+	// updateMu serialises updates triggered by address changes
+	// with those triggered by remeasuring endpoint latencies.
+	updateMu sync.Mutex
+	fetched  [][]network.HostPort
+
+	mu        sync.Mutex
+	current   [][]network.HostPort
+	latencies map[string]time.Duration
 }
 
 // APIAddresser is an interface that is provided to NewAPIAddressUpdater
@@ -42,13 +53,62 @@ type APIAddressSetter interface {
 	SetAPIHostPorts(servers [][]network.HostPort) error
 }
 
+// Config holds the configuration for an API address updater worker.
+type Config struct {
+	Addresser APIAddresser
+	Setter    APIAddressSetter
+
+	// Prioritizer, if not nil, is used to order the API addresses
+	// so that the agent prefers the controllers closest to it.
+	Prioritizer *Prioritizer
+
+	// ProbeInterval is how often the latencies of the API endpoints
+	// are remeasured, and the addresses reordered if necessary. If it
+	// is zero, addresses are only reordered when they change.
+	ProbeInterval time.Duration
+
+	// Clock is used to schedule remeasuring endpoint latencies.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start
+// an API address updater.
+func (config Config) Validate() error {
+	if config.Addresser == nil {
+		return errors.NotValidf("nil Addresser")
+	}
+	if config.Setter == nil {
+		return errors.NotValidf("nil Setter")
+	}
+	if config.ProbeInterval < 0 {
+		return errors.NotValidf("negative ProbeInterval")
+	}
+	if config.Prioritizer != nil && config.ProbeInterval > 0 && config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
 // NewAPIAddressUpdater returns a worker.Worker that watches for changes to
 // API addresses and then sets them on the APIAddressSetter.
-// TODO(fwereade): this should have a config struct, and some validation.
 func NewAPIAddressUpdater(addresser APIAddresser, setter APIAddressSetter) (worker.Worker, error) {
+	return NewWorker(Config{
+		Addresser: addresser,
+		Setter:    setter,
+	})
+}
+
+// NewWorker returns a worker.Worker that watches for changes to API
+// addresses and then sets them, in order of preference if the config
+// has a Prioritizer, on the config's APIAddressSetter.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	handler := &APIAddressUpdater{
-		addresser: addresser,
-		setter:    setter,
+		addresser:   config.Addresser,
+		setter:      config.Setter,
+		prioritizer: config.Prioritizer,
 	}
 	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
 		Handler: handler,
@@ -56,7 +116,60 @@ func NewAPIAddressUpdater(addresser APIAddresser, setter APIAddressSetter) (work
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return w, nil
+	if config.Prioritizer == nil || config.ProbeInterval == 0 {
+		return w, nil
+	}
+
+	pw := &probingWorker{
+		updater:  handler,
+		interval: config.ProbeInterval,
+		clock:    config.Clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &pw.catacomb,
+		Work: pw.loop,
+		Init: []worker.Worker{w},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return pw, nil
+}
+
+// probingWorker runs an API address updater, and periodically
+// has it remeasure the latencies of the API endpoints.
+type probingWorker struct {
+	catacomb catacomb.Catacomb
+	updater  *APIAddressUpdater
+	interval time.Duration
+	clock    clock.Clock
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *probingWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *probingWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Report shows up in the dependency engine report.
+func (w *probingWorker) Report() map[string]interface{} {
+	return w.updater.Report()
+}
+
+func (w *probingWorker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.clock.After(w.interval):
+			if err := w.updater.reprioritize(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 }
 
 // SetUp is part of the watcher.NotifyHandler interface.
@@ -70,10 +183,46 @@ func (c *APIAddressUpdater) Handle(_ <-chan struct{}) error {
 	if err != nil {
 		return err
 	}
-	logger.Debugf("updating API hostPorts to %+v", hpsToSet)
+	c.updateMu.Lock()
+	defer c.updateMu.Unlock()
+	c.fetched = hpsToSet
+	return c.update(true)
+}
+
+// reprioritize remeasures the latencies of the most recently fetched
+// API addresses, and sets them again if their order has changed.
+func (c *APIAddressUpdater) reprioritize() error {
+	c.updateMu.Lock()
+	defer c.updateMu.Unlock()
+	if c.fetched == nil {
+		return nil
+	}
+	return c.update(false)
+}
+
+// update orders the most recently fetched API addresses and sets them,
+// unless force is false and they are already set in that order.
+// It must be called with updateMu held.
+func (c *APIAddressUpdater) update(force bool) error {
+	hpsToSet := c.fetched
+	var latencies map[string]time.Duration
+	if c.prioritizer != nil {
+		var err error
+		hpsToSet, latencies, err = c.prioritizer.Prioritize(hpsToSet)
+		if err != nil {
+			return errors.Annotate(err, "prioritizing addresses")
+		}
+	}
 	c.mu.Lock()
+	changed := !reflect.DeepEqual(c.current, hpsToSet)
 	c.current = hpsToSet
+	c.latencies = latencies
 	c.mu.Unlock()
+	if !force && !changed {
+		return nil
+	}
+
+	logger.Debugf("updating API hostPorts to %+v", hpsToSet)
 	if err := c.setter.SetAPIHostPorts(hpsToSet); err != nil {
 		return fmt.Errorf("error setting addresses: %v", err)
 	}
@@ -126,5 +275,12 @@ func (c *APIAddressUpdater) Report() map[string]interface{} {
 		servers = append(servers, addresses)
 	}
 	report["servers"] = servers
+	if c.latencies != nil {
+		latencies := make(map[string]interface{})
+		for addr, latency := range c.latencies {
+			latencies[addr] = latency.String()
+		}
+		report["latencies"] = latencies
+	}
 	return report
 }
@@ -4,6 +4,9 @@
 package apiaddressupdater
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -17,6 +20,10 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 )
 
+// defaultProbeInterval is how often agents remeasure the latencies
+// of the controller API endpoints.
+const defaultProbeInterval = 5 * time.Minute
+
 // ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
 type ManifoldConfig engine.AgentAPIManifoldConfig
 
@@ -47,7 +54,13 @@ var newWorker = func(a agent.Agent, apiCaller base.APICaller) (worker.Worker, er
 	}
 
 	setter := agent.APIHostPortsSetter{a}
-	w, err := NewAPIAddressUpdater(facade, setter)
+	w, err := NewWorker(Config{
+		Addresser:     facade,
+		Setter:        setter,
+		Prioritizer:   NewPrioritizer(),
+		ProbeInterval: defaultProbeInterval,
+		Clock:         clock.WallClock,
+	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
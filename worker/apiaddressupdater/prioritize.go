@@ -0,0 +1,233 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiaddressupdater
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+)
+
+// probeTimeout is how long probeEndpoint waits for a connection
+// to an API endpoint before considering it unreachable.
+const probeTimeout = 5 * time.Second
+
+// unreachable is the latency recorded for endpoints that could
+// not be connected to, so that they sort after all others.
+const unreachable = time.Duration(1<<63 - 1)
+
+// preferredMargin is the fraction of its latency by which a preferred
+// endpoint must be beaten before another endpoint replaces it, so that
+// the agent does not flap between endpoints with similar latencies.
+const preferredMargin = 5 // i.e. 1/5, or 20%
+
+// Prioritizer orders controller API addresses so that an agent prefers
+// the controllers closest to it. Addresses on networks that the agent's
+// host is directly attached to are preferred, followed by those with the
+// lowest connection latency; typically those in the same availability
+// zone. The agent dials addresses in the order that they are written to
+// its configuration, so the preferred controllers are tried first.
+//
+// The addresses preferred by the previous prioritization keep their
+// place unless another is at least 20% faster.
+type Prioritizer struct {
+	// LocalNetworks returns the networks that the agent's host
+	// is directly attached to.
+	LocalNetworks func() ([]*net.IPNet, error)
+
+	// Probe measures the time taken to connect to the given
+	// "host:port" address.
+	Probe func(addr string) (time.Duration, error)
+
+	mu sync.Mutex
+	// preferred holds the address that was preferred overall, and
+	// preferredInServer the address that was preferred for each
+	// server, by the previous prioritization.
+	preferred         string
+	preferredInServer map[string]bool
+}
+
+// NewPrioritizer returns a Prioritizer that inspects the network
+// interfaces of the host, and measures the time taken to establish
+// a TCP connection to each endpoint.
+func NewPrioritizer() *Prioritizer {
+	return &Prioritizer{
+		LocalNetworks: localNetworks,
+		Probe:         probeEndpoint,
+	}
+}
+
+// Prioritize returns the supplied API servers, and the addresses of each
+// server, in order of preference. It also returns the latency measured
+// for each address; unreachable addresses are omitted.
+func (p *Prioritizer) Prioritize(servers [][]network.HostPort) ([][]network.HostPort, map[string]time.Duration, error) {
+	local, err := p.LocalNetworks()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "getting local networks")
+	}
+	latencies := p.probeAll(servers)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	type scored struct {
+		hostPort network.HostPort
+		local    bool
+		latency  time.Duration
+	}
+	// less compares addresses by locality, then by latency, with
+	// the preferred address given the benefit of the margin.
+	less := func(a, b scored, preferred func(string) bool) bool {
+		if a.local != b.local {
+			return a.local
+		}
+		aLatency, bLatency := a.latency, b.latency
+		if preferred(a.hostPort.NetAddr()) {
+			aLatency -= aLatency / preferredMargin
+		}
+		if preferred(b.hostPort.NetAddr()) {
+			bLatency -= bLatency / preferredMargin
+		}
+		return aLatency < bLatency
+	}
+	inServer := func(addr string) bool {
+		return p.preferredInServer[addr]
+	}
+	overall := func(addr string) bool {
+		return addr == p.preferred
+	}
+
+	scoredServers := make([][]scored, len(servers))
+	for i, server := range servers {
+		scoredServer := make([]scored, len(server))
+		for j, hp := range server {
+			latency, ok := latencies[hp.NetAddr()]
+			if !ok {
+				latency = unreachable
+			}
+			scoredServer[j] = scored{
+				hostPort: hp,
+				local:    isLocal(hp.Address, local),
+				latency:  latency,
+			}
+		}
+		sort.SliceStable(scoredServer, func(a, b int) bool {
+			return less(scoredServer[a], scoredServer[b], inServer)
+		})
+		scoredServers[i] = scoredServer
+	}
+	// Each server's best address is first, so servers
+	// are ordered by comparing their first addresses.
+	sort.SliceStable(scoredServers, func(a, b int) bool {
+		if len(scoredServers[b]) == 0 {
+			return len(scoredServers[a]) > 0
+		}
+		if len(scoredServers[a]) == 0 {
+			return false
+		}
+		return less(scoredServers[a][0], scoredServers[b][0], overall)
+	})
+
+	p.preferred = ""
+	p.preferredInServer = make(map[string]bool)
+	result := make([][]network.HostPort, len(scoredServers))
+	for i, scoredServer := range scoredServers {
+		result[i] = make([]network.HostPort, len(scoredServer))
+		for j, s := range scoredServer {
+			result[i][j] = s.hostPort
+		}
+		if len(scoredServer) > 0 && scoredServer[0].latency != unreachable {
+			addr := scoredServer[0].hostPort.NetAddr()
+			p.preferredInServer[addr] = true
+			if p.preferred == "" {
+				p.preferred = addr
+			}
+		}
+	}
+	return result, latencies, nil
+}
+
+// probeAll measures the latencies of all of the servers' addresses
+// concurrently, so that unreachable addresses don't hold up the
+// others. Unreachable addresses are omitted from the result.
+func (p *Prioritizer) probeAll(servers [][]network.HostPort) map[string]time.Duration {
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make(map[string]time.Duration)
+		probed    = make(map[string]bool)
+	)
+	for _, server := range servers {
+		for _, hp := range server {
+			addr := hp.NetAddr()
+			if probed[addr] {
+				continue
+			}
+			probed[addr] = true
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				latency, err := p.Probe(addr)
+				if err != nil {
+					logger.Debugf("cannot connect to API endpoint %s: %v", addr, err)
+					return
+				}
+				mu.Lock()
+				latencies[addr] = latency
+				mu.Unlock()
+			}()
+		}
+	}
+	wg.Wait()
+	return latencies
+}
+
+func isLocal(addr network.Address, local []*net.IPNet) bool {
+	ip := net.ParseIP(addr.Value)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range local {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// localNetworks returns the networks of the host's non-loopback
+// interface addresses.
+func localNetworks() ([]*net.IPNet, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []*net.IPNet
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		result = append(result, ipNet)
+	}
+	return result, nil
+}
+
+// probeEndpoint returns the time taken to establish
+// a TCP connection to the given address.
+func probeEndpoint(addr string) (time.Duration, error) {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, probeTimeout)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	latency := time.Since(start)
+	_ = conn.Close()
+	return latency, nil
+}
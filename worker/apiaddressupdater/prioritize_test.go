@@ -0,0 +1,259 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiaddressupdater_test
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/apiaddressupdater"
+)
+
+type PrioritizerSuite struct {
+	testing.IsolationSuite
+
+	mu        sync.Mutex
+	latencies map[string]time.Duration
+}
+
+var _ = gc.Suite(&PrioritizerSuite{})
+
+func (s *PrioritizerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// Isolate the bridge address filtering from the real machine.
+	s.PatchValue(&network.InterfaceByNameAddrs, func(string) ([]net.Addr, error) {
+		return nil, nil
+	})
+	s.PatchValue(&network.LXCNetDefaultConfig, "")
+	s.latencies = map[string]time.Duration{
+		"10.0.0.1:17070":    20 * time.Millisecond,
+		"10.0.0.2:17070":    5 * time.Millisecond,
+		"192.168.1.1:17070": 30 * time.Millisecond,
+		"10.0.0.3:17070":    time.Millisecond,
+	}
+}
+
+func (s *PrioritizerSuite) setLatency(addr string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[addr] = latency
+}
+
+func (s *PrioritizerSuite) prioritizer() *apiaddressupdater.Prioritizer {
+	return &apiaddressupdater.Prioritizer{
+		LocalNetworks: func() ([]*net.IPNet, error) {
+			_, ipNet, err := net.ParseCIDR("192.168.1.0/24")
+			return []*net.IPNet{ipNet}, err
+		},
+		Probe: func(addr string) (time.Duration, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			latency, ok := s.latencies[addr]
+			if !ok {
+				return 0, errors.New("connection refused")
+			}
+			return latency, nil
+		},
+	}
+}
+
+func (s *PrioritizerSuite) TestPrioritize(c *gc.C) {
+	servers := [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.9", "10.0.0.2"),
+		network.NewHostPorts(17070, "10.0.0.4", "192.168.1.1"),
+	}
+	result, latencies, err := s.prioritizer().Prioritize(servers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, [][]network.HostPort{
+		// The server with an address on a local network is first,
+		// regardless of latency.
+		network.NewHostPorts(17070, "192.168.1.1", "10.0.0.4"),
+		// Unreachable addresses are last.
+		network.NewHostPorts(17070, "10.0.0.2", "10.0.0.9"),
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+	c.Check(latencies, jc.DeepEquals, map[string]time.Duration{
+		"10.0.0.1:17070":    20 * time.Millisecond,
+		"10.0.0.2:17070":    5 * time.Millisecond,
+		"192.168.1.1:17070": 30 * time.Millisecond,
+	})
+}
+
+func (s *PrioritizerSuite) TestPrioritizeProbesConcurrently(c *gc.C) {
+	servers := [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+		network.NewHostPorts(17070, "10.0.0.3"),
+	}
+	// Each probe waits for all of the others to start,
+	// which they only can if they are run concurrently.
+	var started sync.WaitGroup
+	started.Add(3)
+	p := s.prioritizer()
+	probe := p.Probe
+	p.Probe = func(addr string) (time.Duration, error) {
+		started.Done()
+		started.Wait()
+		return probe(addr)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		result, _, err := p.Prioritize(servers)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, [][]network.HostPort{
+			network.NewHostPorts(17070, "10.0.0.3"),
+			network.NewHostPorts(17070, "10.0.0.2"),
+			network.NewHostPorts(17070, "10.0.0.1"),
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for concurrent probes")
+	}
+}
+
+func (s *PrioritizerSuite) TestPrioritizeHysteresis(c *gc.C) {
+	servers := [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}
+	p := s.prioritizer()
+	result, _, err := p.Prioritize(servers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.2"),
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+
+	// The preferred endpoint keeps its place while it is
+	// only slightly slower than another.
+	s.setLatency("10.0.0.1:17070", 5*time.Millisecond)
+	s.setLatency("10.0.0.2:17070", 6*time.Millisecond)
+	result, _, err = p.Prioritize(servers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.2"),
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+
+	// It is replaced once another is clearly faster.
+	s.setLatency("10.0.0.2:17070", 10*time.Millisecond)
+	result, _, err = p.Prioritize(servers)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	})
+}
+
+func (s *PrioritizerSuite) TestPrioritizeLocalNetworksError(c *gc.C) {
+	p := s.prioritizer()
+	p.LocalNetworks = func() ([]*net.IPNet, error) {
+		return nil, errors.New("boom")
+	}
+	_, _, err := p.Prioritize(nil)
+	c.Assert(err, gc.ErrorMatches, "getting local networks: boom")
+}
+
+func (s *PrioritizerSuite) TestValidateConfig(c *gc.C) {
+	config := apiaddressupdater.Config{
+		Addresser:     &fakeAddresser{},
+		Setter:        &apiAddressSetter{},
+		Prioritizer:   s.prioritizer(),
+		ProbeInterval: time.Minute,
+	}
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+	config.ProbeInterval = -time.Minute
+	c.Check(config.Validate(), gc.ErrorMatches, "negative ProbeInterval not valid")
+	config.ProbeInterval = 0
+	c.Check(config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *PrioritizerSuite) TestWorkerReprioritizes(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	addresser := &fakeAddresser{
+		servers: [][]network.HostPort{
+			network.NewHostPorts(17070, "10.0.0.1"),
+			network.NewHostPorts(17070, "10.0.0.3"),
+		},
+		changes: make(chan struct{}, 1),
+	}
+	addresser.changes <- struct{}{}
+	setter := &apiAddressSetter{servers: make(chan [][]network.HostPort, 1)}
+
+	w, err := apiaddressupdater.NewWorker(apiaddressupdater.Config{
+		Addresser:     addresser,
+		Setter:        setter,
+		Prioritizer:   s.prioritizer(),
+		ProbeInterval: time.Minute,
+		Clock:         clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	assertServers := func(expected [][]network.HostPort) {
+		select {
+		case servers := <-setter.servers:
+			c.Assert(servers, jc.DeepEquals, expected)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for SetAPIHostPorts to be called")
+		}
+	}
+	assertServers([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.3"),
+		network.NewHostPorts(17070, "10.0.0.1"),
+	})
+
+	// If the latencies are unchanged, the addresses aren't set again.
+	c.Assert(clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case servers := <-setter.servers:
+		c.Fatalf("unexpected SetAPIHostPorts call with %v", servers)
+	default:
+	}
+
+	s.setLatency("10.0.0.3:17070", time.Second)
+	c.Assert(clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	assertServers([][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.3"),
+	})
+
+	report := w.(interface {
+		Report() map[string]interface{}
+	}).Report()
+	c.Check(report["latencies"], jc.DeepEquals, map[string]interface{}{
+		"10.0.0.1:17070": "20ms",
+		"10.0.0.3:17070": "1s",
+	})
+}
+
+type fakeAddresser struct {
+	servers [][]network.HostPort
+	changes chan struct{}
+}
+
+func (a *fakeAddresser) APIHostPorts() ([][]network.HostPort, error) {
+	return a.servers, nil
+}
+
+func (a *fakeAddresser) WatchAPIHostPorts() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(a.changes), nil
+}
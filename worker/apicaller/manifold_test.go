@@ -132,6 +132,18 @@ func (s *ManifoldSuite) setupWorkerTest(c *gc.C) worker.Worker {
 	return w
 }
 
+func (s *ManifoldSuite) TestReportEndpoint(c *gc.C) {
+	w := s.setupWorkerTest(c)
+	reporter, ok := w.(interface {
+		Report() map[string]interface{}
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"endpoint":   "testing.invalid",
+		"ip-address": "0.1.2.3:17070",
+	})
+}
+
 func (s *ManifoldSuite) TestKillWorkerClosesConnection(c *gc.C) {
 	worker := s.setupWorkerTest(c)
 	assertStop(c, worker)
@@ -111,6 +111,10 @@ func (mock *mockConn) Addr() string {
 	return "testing.invalid"
 }
 
+func (mock *mockConn) IPAddr() string {
+	return "0.1.2.3:17070"
+}
+
 func (mock *mockConn) Close() error {
 	mock.stub.AddCall("Close")
 	return mock.stub.NextErr()
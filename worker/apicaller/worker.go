@@ -43,6 +43,15 @@ func (w *apiConnWorker) Wait() error {
 	return w.tomb.Wait()
 }
 
+// Report shows up in the dependency engine report, and identifies the
+// controller API endpoint that the agent is connected to.
+func (w *apiConnWorker) Report() map[string]interface{} {
+	return map[string]interface{}{
+		"endpoint":   w.conn.Addr(),
+		"ip-address": w.conn.IPAddr(),
+	}
+}
+
 // loop is somewhat out of the ordinary, because an api.Connection
 // *does* maintain an internal workeresque heartbeat goroutine, but it
 // doesn't implement Worker.
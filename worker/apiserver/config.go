@@ -133,5 +133,29 @@ func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 			)
 		}
 	}
+	if v := cfg.Value(agent.LogSinkPingPeriod); v != "" {
+		result.PingPeriod, err = time.ParseDuration(v)
+		if err != nil {
+			return result, errors.Annotatef(
+				err, "parsing %s", agent.LogSinkPingPeriod,
+			)
+		}
+	}
+	if v := cfg.Value(agent.LogSinkPongDelay); v != "" {
+		result.PongDelay, err = time.ParseDuration(v)
+		if err != nil {
+			return result, errors.Annotatef(
+				err, "parsing %s", agent.LogSinkPongDelay,
+			)
+		}
+	}
+	if v := cfg.Value(agent.LogSinkIdleTimeout); v != "" {
+		result.IdleTimeout, err = time.ParseDuration(v)
+		if err != nil {
+			return result, errors.Annotatef(
+				err, "parsing %s", agent.LogSinkIdleTimeout,
+			)
+		}
+	}
 	return result, nil
 }
@@ -181,6 +181,9 @@ func (s *WorkerValidationSuite) TestValidateLogSinkConfig(c *gc.C) {
 	s.testValidateLogSinkConfig(c, agent.LogSinkDBLoggerFlushInterval, "foo", "parsing LOGSINK_DBLOGGER_FLUSH_INTERVAL: .*")
 	s.testValidateLogSinkConfig(c, agent.LogSinkRateLimitBurst, "foo", "parsing LOGSINK_RATELIMIT_BURST: .*")
 	s.testValidateLogSinkConfig(c, agent.LogSinkRateLimitRefill, "foo", "parsing LOGSINK_RATELIMIT_REFILL: .*")
+	s.testValidateLogSinkConfig(c, agent.LogSinkPingPeriod, "foo", "parsing LOGSINK_PING_PERIOD: .*")
+	s.testValidateLogSinkConfig(c, agent.LogSinkPongDelay, "foo", "parsing LOGSINK_PONG_DELAY: .*")
+	s.testValidateLogSinkConfig(c, agent.LogSinkIdleTimeout, "foo", "parsing LOGSINK_IDLE_TIMEOUT: .*")
 }
 
 func (s *WorkerValidationSuite) testValidateLogSinkConfig(c *gc.C, key, value, expect string) {
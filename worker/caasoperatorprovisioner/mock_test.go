@@ -28,7 +28,9 @@ type mockProvisionerFacade struct {
 	caasoperatorprovisioner.CAASProvisionerFacade
 	applicationsWatcher *mockStringsWatcher
 	apiWatcher          *mockNotifyWatcher
+	configWatcher       *mockNotifyWatcher
 	life                life.Value
+	imagePath           string
 }
 
 func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
@@ -36,9 +38,27 @@ func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
 		stub:                stub,
 		applicationsWatcher: newMockStringsWatcher(),
 		apiWatcher:          newMockNotifyWatcher(),
+		configWatcher:       newMockNotifyWatcher(),
+		imagePath:           "juju-operator-image",
 	}
 }
 
+func (m *mockProvisionerFacade) setImagePath(imagePath string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.imagePath = imagePath
+}
+
+func (m *mockProvisionerFacade) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stub.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
 func (m *mockProvisionerFacade) WatchApplications() (watcher.StringsWatcher, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -49,15 +69,15 @@ func (m *mockProvisionerFacade) WatchApplications() (watcher.StringsWatcher, err
 	return m.applicationsWatcher, nil
 }
 
-func (m *mockProvisionerFacade) OperatorProvisioningInfo() (apicaasprovisioner.OperatorProvisioningInfo, error) {
+func (m *mockProvisionerFacade) OperatorProvisioningInfo(appName string) (apicaasprovisioner.OperatorProvisioningInfo, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.stub.MethodCall(m, "OperatorProvisioningInfo")
+	m.stub.MethodCall(m, "OperatorProvisioningInfo", appName)
 	if err := m.stub.NextErr(); err != nil {
 		return apicaasprovisioner.OperatorProvisioningInfo{}, err
 	}
 	return apicaasprovisioner.OperatorProvisioningInfo{
-		ImagePath:    "juju-operator-image",
+		ImagePath:    m.imagePath,
 		Version:      version.MustParse("2.99.0"),
 		APIAddresses: []string{"10.0.0.1:17070", "192.18.1.1:17070"},
 		Tags:         map[string]string{"fred": "mary"},
@@ -30,8 +30,9 @@ var logger = loggo.GetLogger("juju.workers.caasprovisioner")
 
 // CAASProvisionerFacade exposes CAAS provisioning functionality to a worker.
 type CAASProvisionerFacade interface {
-	OperatorProvisioningInfo() (apicaasprovisioner.OperatorProvisioningInfo, error)
+	OperatorProvisioningInfo(string) (apicaasprovisioner.OperatorProvisioningInfo, error)
 	WatchApplications() (watcher.StringsWatcher, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
 	SetPasswords([]apicaasprovisioner.ApplicationPassword) (params.ErrorResults, error)
 	Life(string) (life.Value, error)
 }
@@ -53,6 +54,9 @@ func NewProvisionerWorker(config Config) (worker.Worker, error) {
 		modelTag:          config.ModelTag,
 		agentConfig:       config.AgentConfig,
 		clock:             config.Clock,
+		configWatchers:    make(map[string]worker.Worker),
+		configChanges:     make(chan string),
+		imagePaths:        make(map[string]string),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &p.catacomb,
@@ -69,6 +73,15 @@ type provisioner struct {
 
 	modelTag    names.ModelTag
 	agentConfig agent.Config
+
+	// configWatchers holds the watchers of the application config
+	// of each application with an operator, which send the name of
+	// the application on configChanges when its config changes.
+	configWatchers map[string]worker.Worker
+	configChanges  chan string
+
+	// imagePaths holds the image each operator was last ensured with.
+	imagePaths map[string]string
 }
 
 // Kill is part of the worker.Worker interface.
@@ -110,6 +123,11 @@ func (p *provisioner) loop() error {
 				appLife, err := p.provisionerFacade.Life(app)
 				if errors.IsNotFound(err) || appLife == life.Dead {
 					logger.Debugf("deleting operator for %q", app)
+					if w, ok := p.configWatchers[app]; ok {
+						w.Kill()
+						delete(p.configWatchers, app)
+					}
+					delete(p.imagePaths, app)
 					if err := p.broker.DeleteOperator(app); err != nil {
 						return errors.Annotatef(err, "failed to stop operator for %q", app)
 					}
@@ -126,8 +144,71 @@ func (p *provisioner) loop() error {
 			if err := p.ensureOperators(newApps); err != nil {
 				return errors.Trace(err)
 			}
+
+		// An application's config changed, so its operator is updated
+		// if the operator image it should use has changed.
+		case app := <-p.configChanges:
+			if _, ok := p.configWatchers[app]; !ok {
+				continue
+			}
+			if err := p.ensureOperatorImage(app); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// watchApplicationConfig starts watching the application config of
+// the specified application, if it is not being watched already.
+func (p *provisioner) watchApplicationConfig(app string) error {
+	if _, ok := p.configWatchers[app]; ok {
+		return nil
+	}
+	w, err := p.provisionerFacade.WatchApplicationConfig(app)
+	if errors.IsNotSupported(err) || errors.IsNotFound(err) {
+		logger.Debugf("not watching application config of %q: %v", app, err)
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to watch application config of %q", app)
+	}
+	if err := p.catacomb.Add(w); err != nil {
+		return errors.Trace(err)
+	}
+	p.configWatchers[app] = w
+	go func() {
+		for {
+			select {
+			case <-p.catacomb.Dying():
+				return
+			case _, ok := <-w.Changes():
+				if !ok {
+					return
+				}
+			}
+			select {
+			case <-p.catacomb.Dying():
+				return
+			case p.configChanges <- app:
+			}
 		}
+	}()
+	return nil
+}
+
+// ensureOperatorImage updates the operator of the specified
+// application if the operator image it should use has changed.
+func (p *provisioner) ensureOperatorImage(app string) error {
+	info, err := p.provisionerFacade.OperatorProvisioningInfo(app)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
 	}
+	if info.ImagePath == p.imagePaths[app] {
+		return nil
+	}
+	logger.Infof("operator image for application %q changed to %q", app, info.ImagePath)
+	return errors.Trace(p.ensureOperators([]string{app}))
 }
 
 func (p *provisioner) waitForOperatorTerminated(app string) error {
@@ -212,6 +293,10 @@ func (p *provisioner) ensureOperators(apps []string) error {
 			errorStrings = append(errorStrings, err.Error())
 			continue
 		}
+		p.imagePaths[app] = operatorConfig[i].OperatorImagePath
+		if err := p.watchApplicationConfig(app); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if errorStrings != nil {
 		err := errors.New(strings.Join(errorStrings, "\n"))
@@ -230,7 +315,7 @@ func (p *provisioner) ensureOperator(app string, config *caas.OperatorConfig) er
 
 func (p *provisioner) makeOperatorConfig(appName, password string) (*caas.OperatorConfig, error) {
 	appTag := names.NewApplicationTag(appName)
-	info, err := p.provisionerFacade.OperatorProvisioningInfo()
+	info, err := p.provisionerFacade.OperatorProvisioningInfo(appName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -152,20 +152,25 @@ func (s *CAASProvisionerSuite) assertOperatorCreated(c *gc.C, exists, terminatin
 		c.Assert(config.AgentConf, gc.IsNil)
 	}
 
+	facadeCallNames := []string{"Life", "OperatorProvisioningInfo", "WatchApplicationConfig"}
+	if !exists || terminating {
+		facadeCallNames = []string{"Life", "OperatorProvisioningInfo", "SetPasswords", "WatchApplicationConfig"}
+	}
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.provisionerFacade.stub.Calls()) > 0 {
+		if len(s.provisionerFacade.stub.Calls()) >= len(facadeCallNames) {
 			break
 		}
 	}
+	s.provisionerFacade.stub.CheckCallNames(c, facadeCallNames...)
+	c.Assert(s.provisionerFacade.stub.Calls()[len(facadeCallNames)-1].Args[0], gc.Equals, "myapp")
 
 	if exists && !terminating {
-		s.provisionerFacade.stub.CheckCallNames(c, "Life", "OperatorProvisioningInfo")
 		c.Assert(s.provisionerFacade.stub.Calls()[0].Args[0], gc.Equals, "myapp")
 		return
 	}
 
-	s.provisionerFacade.stub.CheckCallNames(c, "Life", "OperatorProvisioningInfo", "SetPasswords")
 	c.Assert(s.provisionerFacade.stub.Calls()[0].Args[0], gc.Equals, "myapp")
+	c.Assert(s.provisionerFacade.stub.Calls()[1].Args[0], gc.Equals, "myapp")
 	passwords := s.provisionerFacade.stub.Calls()[2].Args[0].([]apicaasprovisioner.ApplicationPassword)
 
 	c.Assert(passwords, gc.HasLen, 1)
@@ -214,3 +219,43 @@ func (s *CAASProvisionerSuite) TestApplicationDeletedRemovesOperator(c *gc.C) {
 	s.caasClient.CheckCallNames(c, "DeleteOperator")
 	c.Assert(s.caasClient.Calls()[0].Args[0], gc.Equals, "myapp")
 }
+
+func (s *CAASProvisionerSuite) TestOperatorImageChangeUpdatesOperator(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c, false, false)
+	s.caasClient.ResetCalls()
+	s.caasClient.setOperatorExists(true)
+	s.provisionerFacade.setImagePath("myrepo/jujud-operator:2.99.0")
+	s.provisionerFacade.configWatcher.changes <- struct{}{}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.caasClient.Calls()) >= 2 {
+			break
+		}
+	}
+	s.caasClient.CheckCallNames(c, "OperatorExists", "EnsureOperator")
+	config := s.caasClient.Calls()[1].Args[2].(*caas.OperatorConfig)
+	c.Assert(config.OperatorImagePath, gc.Equals, "myrepo/jujud-operator:2.99.0")
+	c.Assert(config.AgentConf, gc.IsNil)
+}
+
+func (s *CAASProvisionerSuite) TestApplicationConfigChangeKeepsOperator(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c, false, false)
+	s.caasClient.ResetCalls()
+	s.provisionerFacade.stub.ResetCalls()
+	s.provisionerFacade.configWatcher.changes <- struct{}{}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.provisionerFacade.stub.Calls()) > 0 {
+			break
+		}
+	}
+	s.provisionerFacade.stub.CheckCallNames(c, "OperatorProvisioningInfo")
+	workertest.CleanKill(c, w)
+	s.caasClient.CheckNoCalls(c)
+}
@@ -5,8 +5,8 @@ package caasunitprovisioner
 
 import (
 	"reflect"
-	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/caas"
 	"gopkg.in/juju/names.v2"
@@ -15,11 +15,11 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/status"
-	"github.com/juju/juju/core/watcher"
 )
 
 type applicationWorker struct {
 	catacomb        catacomb.Catacomb
+	clock           clock.Clock
 	application     string
 	serviceBroker   ServiceBroker
 	containerBroker ContainerBroker
@@ -32,6 +32,7 @@ type applicationWorker struct {
 }
 
 func newApplicationWorker(
+	clock clock.Clock,
 	application string,
 	serviceBroker ServiceBroker,
 	containerBroker ContainerBroker,
@@ -42,6 +43,7 @@ func newApplicationWorker(
 	unitUpdater UnitUpdater,
 ) (*applicationWorker, error) {
 	w := &applicationWorker{
+		clock:                    clock,
 		application:              application,
 		serviceBroker:            serviceBroker,
 		containerBroker:          containerBroker,
@@ -72,9 +74,11 @@ func (aw *applicationWorker) Wait() error {
 
 func (aw *applicationWorker) loop() error {
 	deploymentWorker, err := newDeploymentWorker(
+		aw.clock,
 		aw.application,
 		aw.provisioningStatusSetter,
 		aw.serviceBroker,
+		aw.containerBroker,
 		aw.provisioningInfoGetter,
 		aw.applicationGetter,
 		aw.applicationUpdater,
@@ -84,25 +88,35 @@ func (aw *applicationWorker) loop() error {
 	}
 	aw.catacomb.Add(deploymentWorker)
 
-	var (
-		brokerUnitsWatcher   watcher.NotifyWatcher
-		appOperatorWatcher   watcher.NotifyWatcher
-		appDeploymentWatcher watcher.NotifyWatcher
-	)
-	// The caas watcher can just die from underneath hence it needs to be
-	// restarted all the time. So we don't abuse the catacomb by adding new
-	// workers unbounded, use a defer to stop the running worker.
-	defer func() {
-		if brokerUnitsWatcher != nil {
-			worker.Stop(brokerUnitsWatcher)
-		}
-		if appOperatorWatcher != nil {
-			worker.Stop(appOperatorWatcher)
-		}
-		if appDeploymentWatcher != nil {
-			worker.Stop(appDeploymentWatcher)
-		}
-	}()
+	// The caas watchers can just die from underneath, so they are
+	// run by a runner which restarts them as needed. Their changes
+	// are forwarded to these channels, which outlive the watchers.
+	runner := worker.NewRunner(worker.RunnerParams{
+		Clock:        aw.clock,
+		IsFatal:      isPersistentWatcherError,
+		RestartDelay: watcherRestartDelay,
+	})
+	if err := aw.catacomb.Add(runner); err != nil {
+		return errors.Trace(err)
+	}
+	unitsChanges := make(chan struct{})
+	operatorChanges := make(chan struct{})
+	deploymentChanges := make(chan struct{})
+	if err := runner.StartWorker("unit", aw.startWatcherFunc(
+		"unit", aw.containerBroker.WatchUnits, unitsChanges,
+	)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := runner.StartWorker("operator", aw.startWatcherFunc(
+		"operator", aw.containerBroker.WatchOperator, operatorChanges,
+	)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := runner.StartWorker("deployment", aw.startWatcherFunc(
+		"deployment", aw.serviceBroker.WatchService, deploymentChanges,
+	)); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Cache the last reported status information
 	// so we only report true changes.
@@ -110,51 +124,13 @@ func (aw *applicationWorker) loop() error {
 	lastReportedScale := -1
 
 	for {
-		// The caas watcher can just die from underneath so recreate if needed.
-		if brokerUnitsWatcher == nil {
-			brokerUnitsWatcher, err = aw.containerBroker.WatchUnits(aw.application)
-			if err != nil {
-				if strings.Contains(err.Error(), "unexpected EOF") {
-					logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
-					return nil
-				}
-				return errors.Annotatef(err, "failed to start unit watcher for %q", aw.application)
-			}
-		}
-		if appOperatorWatcher == nil {
-			appOperatorWatcher, err = aw.containerBroker.WatchOperator(aw.application)
-			if err != nil {
-				if strings.Contains(err.Error(), "unexpected EOF") {
-					logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
-					return nil
-				}
-				return errors.Annotatef(err, "failed to start operator watcher for %q", aw.application)
-			}
-		}
-		if appDeploymentWatcher == nil {
-			appDeploymentWatcher, err = aw.serviceBroker.WatchService(aw.application)
-			if err != nil {
-				if strings.Contains(err.Error(), "unexpected EOF") {
-					logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
-					return nil
-				}
-				return errors.Annotatef(err, "failed to start deployment watcher for %q", aw.application)
-			}
-		}
-
 		select {
 		// We must handle any processing due to application being removed prior
 		// to shutdown so that we don't leave stuff running in the cloud.
 		case <-aw.catacomb.Dying():
 			return aw.catacomb.ErrDying()
-		case _, ok := <-brokerUnitsWatcher.Changes():
-			logger.Debugf("units changed: %#v", ok)
-			if !ok {
-				logger.Debugf("%v", brokerUnitsWatcher.Wait())
-				worker.Stop(brokerUnitsWatcher)
-				brokerUnitsWatcher = nil
-				continue
-			}
+		case <-unitsChanges:
+			logger.Debugf("units changed")
 			service, err := aw.serviceBroker.GetService(aw.application, false)
 			if err != nil && !errors.IsNotFound(err) {
 				return errors.Trace(err)
@@ -164,14 +140,8 @@ func (aw *applicationWorker) loop() error {
 				// TODO(caas): change the shouldSetScale to false here once appDeploymentWatcher can get all events from k8s.
 				return errors.Trace(err)
 			}
-		case _, ok := <-appDeploymentWatcher.Changes():
-			logger.Debugf("deployment changed: %#v", ok)
-			if !ok {
-				logger.Debugf("%v", appDeploymentWatcher.Wait())
-				worker.Stop(appDeploymentWatcher)
-				appDeploymentWatcher = nil
-				continue
-			}
+		case <-deploymentChanges:
+			logger.Debugf("deployment changed")
 			service, err := aw.serviceBroker.GetService(aw.application, false)
 			if err != nil && !errors.IsNotFound(err) {
 				return errors.Trace(err)
@@ -210,13 +180,7 @@ func (aw *applicationWorker) loop() error {
 			if err := aw.clusterChanged(service, lastReportedStatus, true); err != nil {
 				return errors.Trace(err)
 			}
-		case _, ok := <-appOperatorWatcher.Changes():
-			if !ok {
-				logger.Debugf("%v", appOperatorWatcher.Wait())
-				worker.Stop(appOperatorWatcher)
-				appOperatorWatcher = nil
-				continue
-			}
+		case <-operatorChanges:
 			logger.Debugf("operator update for %v", aw.application)
 			operator, err := aw.containerBroker.Operator(aw.application)
 			if errors.IsNotFound(err) {
@@ -314,17 +278,25 @@ func (aw *applicationWorker) clusterChanged(
 		}
 		args.Units = append(args.Units, unitParams)
 	}
-	if err := aw.unitUpdater.UpdateUnits(args); err != nil {
-		if errors.IsForbidden(err) {
-			// ignore errors raised from SetScale because disordered events could happen often.
-			logger.Warningf("%v", err)
-			return nil
-		}
+	unitErrors, err := aw.unitUpdater.UpdateUnits(args)
+	if err != nil {
 		// We can ignore not found errors as the worker will get stopped anyway.
 		if !errors.IsNotFound(err) {
 			return errors.Trace(err)
 		}
 		logger.Warningf("update units %v", err)
+		return nil
+	}
+	for i, err := range unitErrors {
+		if err == nil {
+			continue
+		}
+		// Forget the status last reported for a unit that failed to
+		// update, so that its full status is sent again with the next
+		// update rather than being suppressed as unchanged.
+		providerId := args.Units[i].ProviderId
+		logger.Warningf("cannot update unit with provider id %q: %v", providerId, err)
+		delete(lastReportedStatus, providerId)
 	}
 	return nil
 }
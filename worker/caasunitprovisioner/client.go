@@ -47,6 +47,7 @@ type ApplicationUpdater interface {
 type ProvisioningInfoGetter interface {
 	ProvisioningInfo(appName string) (*apicaasunitprovisioner.ProvisioningInfo, error)
 	WatchPodSpec(appName string) (watcher.NotifyWatcher, error)
+	WatchProxySettings() (watcher.NotifyWatcher, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -58,7 +59,7 @@ type LifeGetter interface {
 // UnitUpdater provides an interface for updating
 // Juju units from changes in the cloud.
 type UnitUpdater interface {
-	UpdateUnits(arg params.UpdateApplicationUnits) error
+	UpdateUnits(arg params.UpdateApplicationUnits) ([]error, error)
 }
 
 // ProvisioningStatusSetter provides an interface for
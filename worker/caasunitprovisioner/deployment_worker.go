@@ -4,7 +4,11 @@
 package caasunitprovisioner
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/proxy"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
@@ -12,33 +16,50 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
+// quotaRetryDelay is how long the deployment worker waits before
+// trying again to apply a change which would have exceeded a
+// namespace resource quota.
+const quotaRetryDelay = time.Minute
+
+// quotaCheckDelay is how long the deployment worker waits after a
+// change has been applied before checking whether the cluster could
+// create the application's pods without exceeding a resource quota.
+const quotaCheckDelay = 10 * time.Second
+
 // deploymentWorker informs the CAAS broker of how many pods to run and their spec, and
 // lets the broker figure out how to make that all happen.
 type deploymentWorker struct {
 	catacomb                 catacomb.Catacomb
+	clock                    clock.Clock
 	application              string
 	provisioningStatusSetter ProvisioningStatusSetter
 	broker                   ServiceBroker
+	containerBroker          ContainerBroker
 	applicationGetter        ApplicationGetter
 	applicationUpdater       ApplicationUpdater
 	provisioningInfoGetter   ProvisioningInfoGetter
 }
 
 func newDeploymentWorker(
+	clock clock.Clock,
 	application string,
 	provisioningStatusSetter ProvisioningStatusSetter,
 	broker ServiceBroker,
+	containerBroker ContainerBroker,
 	provisioningInfoGetter ProvisioningInfoGetter,
 	applicationGetter ApplicationGetter,
 	applicationUpdater ApplicationUpdater,
 ) (worker.Worker, error) {
 	w := &deploymentWorker{
+		clock:                    clock,
 		application:              application,
 		provisioningStatusSetter: provisioningStatusSetter,
 		broker:                   broker,
+		containerBroker:          containerBroker,
 		provisioningInfoGetter:   provisioningInfoGetter,
 		applicationGetter:        applicationGetter,
 		applicationUpdater:       applicationUpdater,
@@ -70,11 +91,23 @@ func (w *deploymentWorker) loop() error {
 	w.catacomb.Add(appScaleWatcher)
 
 	var (
-		cw       watcher.NotifyWatcher
-		specChan watcher.NotifyChannel
+		cw        watcher.NotifyWatcher
+		specChan  watcher.NotifyChannel
+		pw        watcher.NotifyWatcher
+		proxyChan watcher.NotifyChannel
 
 		currentScale int
 		currentSpec  string
+		currentProxy proxy.Settings
+
+		// quotaBlocked is true while the application's status
+		// reports that a change would exceed a resource quota,
+		// and quotaRetry is set while waiting to retry the change.
+		// quotaCheck is set while waiting to check that the pods
+		// for an applied change could be created.
+		quotaBlocked bool
+		quotaRetry   <-chan time.Time
+		quotaCheck   <-chan time.Time
 	)
 
 	gotSpecNotify := false
@@ -102,12 +135,56 @@ func (w *deploymentWorker) loop() error {
 				}
 				w.catacomb.Add(cw)
 				specChan = cw.Changes()
+
+				pw, err = w.provisioningInfoGetter.WatchProxySettings()
+				if errors.IsNotImplemented(err) {
+					// The controller is too old to give
+					// pods the model's proxy settings.
+					logger.Debugf("not watching proxy settings: %v", err)
+				} else if err != nil {
+					return errors.Trace(err)
+				} else {
+					w.catacomb.Add(pw)
+					proxyChan = pw.Changes()
+				}
 			}
 		case _, ok := <-specChan:
 			if !ok {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-proxyChan:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+		case <-quotaRetry:
+			logger.Debugf("retrying deployment of %v after quota was exceeded", w.application)
+			quotaRetry = nil
+		case <-quotaCheck:
+			quotaCheck = nil
+			// The cluster creates pods after the change is
+			// applied, so a quota which prevents that is only
+			// reported by the deployment's conditions and events.
+			service, err := w.broker.GetService(w.application, false)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if provider.IsQuotaExceededStatus(service.Status) {
+				currentScale = 0
+				quotaRetry = w.clock.After(quotaRetryDelay)
+				if err := w.setQuotaExceededStatus(service.Status); err != nil {
+					return errors.Trace(err)
+				}
+				quotaBlocked = true
+			} else if quotaBlocked {
+				if err := w.clearQuotaExceededStatus(); err != nil {
+					return errors.Trace(err)
+				}
+				quotaBlocked = false
+			}
+			continue
 		}
 		if desiredScale > 0 && !gotSpecNotify {
 			continue
@@ -125,22 +202,35 @@ func (w *deploymentWorker) loop() error {
 				worker.Stop(cw)
 				specChan = nil
 			}
+			if pw != nil {
+				worker.Stop(pw)
+				proxyChan = nil
+			}
 			logger.Debugf("no units for %v", w.application)
 			err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, &caas.ServiceParams{}, 0, nil)
 			if err != nil {
 				return errors.Trace(err)
 			}
 			currentScale = 0
+			quotaRetry = nil
+			quotaCheck = nil
+			if quotaBlocked {
+				if err := w.clearQuotaExceededStatus(); err != nil {
+					return errors.Trace(err)
+				}
+				quotaBlocked = false
+			}
 			continue
 		}
 
 		specStr := info.PodSpec
-		if desiredScale == currentScale && specStr == currentSpec {
+		if desiredScale == currentScale && specStr == currentSpec && info.ProxySettings == currentProxy {
 			continue
 		}
 
 		currentScale = desiredScale
 		currentSpec = specStr
+		currentProxy = info.ProxySettings
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -167,8 +257,27 @@ func (w *deploymentWorker) loop() error {
 				DeploymentType: caas.DeploymentType(info.DeploymentInfo.DeploymentType),
 				ServiceType:    caas.ServiceType(info.DeploymentInfo.ServiceType),
 			},
+			Scheduling: caas.SchedulingParams{
+				SpreadAcrossNodes: info.Scheduling.SpreadAcrossNodes,
+				SpreadAcrossZones: info.Scheduling.SpreadAcrossZones,
+			},
+			ProxySettings: info.ProxySettings,
 		}
 		err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, desiredScale, appConfig)
+		if provider.IsQuotaExceededError(err) {
+			// The change hasn't been applied, so forget it to
+			// try again later, and tell the user why.
+			quotaErr := errors.Cause(err).(provider.QuotaExceededError)
+			logger.Warningf("cannot deploy %v: %v", w.application, quotaErr)
+			currentScale = 0
+			quotaRetry = w.clock.After(quotaRetryDelay)
+			quotaCheck = nil
+			if err := w.setQuotaExceededStatus(quotaErr.Status()); err != nil {
+				return errors.Trace(err)
+			}
+			quotaBlocked = true
+			continue
+		}
 		if err != nil {
 			// Some errors we don't want to exit the worker.
 			if provider.MaskError(err) {
@@ -177,6 +286,10 @@ func (w *deploymentWorker) loop() error {
 			}
 			return errors.Trace(err)
 		}
+		// Any quota status is cleared once the pods
+		// are known to have been created.
+		quotaRetry = nil
+		quotaCheck = w.clock.After(quotaCheckDelay)
 		logger.Debugf("ensured deployment for %s for %v units", w.application, desiredScale)
 		if !serviceUpdated && !spec.OmitServiceFrontend {
 			service, err := w.broker.GetService(w.application, false)
@@ -193,6 +306,28 @@ func (w *deploymentWorker) loop() error {
 	}
 }
 
+// setQuotaExceededStatus reports that the application's pods could not
+// be created or scaled because a namespace resource quota would have
+// been exceeded, naming the quota and the capacity it has remaining.
+func (w *deploymentWorker) setQuotaExceededStatus(info status.StatusInfo) error {
+	return w.provisioningStatusSetter.SetOperatorStatus(w.application, info.Status, info.Message, info.Data)
+}
+
+// clearQuotaExceededStatus reports that a resource quota no longer
+// prevents the application's pods being deployed, by restoring the
+// status of the application's operator which the quota status hid.
+func (w *deploymentWorker) clearQuotaExceededStatus() error {
+	operator, err := w.containerBroker.Operator(w.application)
+	if errors.IsNotFound(err) {
+		return w.provisioningStatusSetter.SetOperatorStatus(w.application, status.Terminated, "", nil)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return w.provisioningStatusSetter.SetOperatorStatus(
+		w.application, operator.Status.Status, operator.Status.Message, operator.Status.Data,
+	)
+}
+
 func updateApplicationService(appTag names.ApplicationTag, svc *caas.Service, updater ApplicationUpdater) error {
 	if svc == nil || svc.Id == "" {
 		return nil
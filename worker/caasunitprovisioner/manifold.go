@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -16,6 +17,7 @@ import (
 type ManifoldConfig struct {
 	APICallerName string
 	BrokerName    string
+	ClockName     string
 
 	NewClient func(base.APICaller) Client
 	NewWorker func(Config) (worker.Worker, error)
@@ -29,6 +31,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.BrokerName == "" {
 		return errors.NotValidf("empty BrokerName")
 	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
 	if config.NewClient == nil {
 		return errors.NotValidf("nil NewClient")
 	}
@@ -53,6 +58,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	client := config.NewClient(apiCaller)
 	w, err := config.NewWorker(Config{
 		ApplicationGetter:  client,
@@ -65,6 +75,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		ProvisioningStatusSetter: client,
 		LifeGetter:               client,
 		UnitUpdater:              client,
+
+		Clock: clock,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -79,6 +91,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		Inputs: []string{
 			config.APICallerName,
 			config.BrokerName,
+			config.ClockName,
 		},
 		Start: config.start,
 	}
@@ -4,6 +4,9 @@
 package caasunitprovisioner_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -26,6 +29,7 @@ type ManifoldSuite struct {
 	apiCaller fakeAPICaller
 	broker    fakeBroker
 	client    fakeClient
+	clock     *testclock.Clock
 }
 
 var _ = gc.Suite(&ManifoldSuite{})
@@ -34,6 +38,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.ResetCalls()
 
+	s.clock = testclock.NewClock(time.Time{})
 	s.context = s.newContext(nil)
 	s.manifold = caasunitprovisioner.Manifold(s.validConfig())
 }
@@ -42,6 +47,7 @@ func (s *ManifoldSuite) validConfig() caasunitprovisioner.ManifoldConfig {
 	return caasunitprovisioner.ManifoldConfig{
 		APICallerName: "api-caller",
 		BrokerName:    "broker",
+		ClockName:     "clock",
 		NewClient:     s.newClient,
 		NewWorker:     s.newWorker,
 	}
@@ -66,6 +72,7 @@ func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Co
 	resources := map[string]interface{}{
 		"api-caller": &s.apiCaller,
 		"broker":     &s.broker,
+		"clock":      s.clock,
 	}
 	for k, v := range overlay {
 		resources[k] = v
@@ -85,6 +92,12 @@ func (s *ManifoldSuite) TestMissingBrokerName(c *gc.C) {
 	s.checkConfigInvalid(c, config, "empty BrokerName not valid")
 }
 
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	config := s.validConfig()
+	config.ClockName = ""
+	s.checkConfigInvalid(c, config, "empty ClockName not valid")
+}
+
 func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
 	config := s.validConfig()
 	config.NewWorker = nil
@@ -97,7 +110,7 @@ func (s *ManifoldSuite) checkConfigInvalid(c *gc.C, config caasunitprovisioner.M
 	c.Check(err, jc.Satisfies, errors.IsNotValid)
 }
 
-var expectedInputs = []string{"api-caller", "broker"}
+var expectedInputs = []string{"api-caller", "broker", "clock"}
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
 	c.Assert(s.manifold.Inputs, jc.SameContents, expectedInputs)
@@ -135,5 +148,6 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		ProvisioningStatusSetter: &s.client,
 		LifeGetter:               &s.client,
 		UnitUpdater:              &s.client,
+		Clock:                    s.clock,
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
 
@@ -44,6 +45,10 @@ type mockServiceBroker struct {
 	podSpec        *caas.PodSpec
 	serviceStatus  status.StatusInfo
 	serviceWatcher *watchertest.MockNotifyWatcher
+
+	// ensureErrors, if set, are returned by successive
+	// calls to EnsureService.
+	ensureErrors []error
 }
 
 func (m *mockServiceBroker) Provider() caas.ContainerEnvironProvider {
@@ -58,6 +63,11 @@ func (m *mockServiceBroker) EnsureService(appName string, statusCallback caas.St
 	m.MethodCall(m, "EnsureService", appName, params, numUnits, config)
 	statusCallback(appName, status.Waiting, "ensuring", map[string]interface{}{"foo": "bar"})
 	m.ensured <- struct{}{}
+	if len(m.ensureErrors) > 0 {
+		err := m.ensureErrors[0]
+		m.ensureErrors = m.ensureErrors[1:]
+		return err
+	}
 	return m.NextErr()
 }
 
@@ -205,6 +215,7 @@ type mockProvisioningInfoGetterGetter struct {
 	testing.Stub
 	provisioningInfo apicaasunitprovisioner.ProvisioningInfo
 	watcher          *watchertest.MockNotifyWatcher
+	proxyWatcher     *watchertest.MockNotifyWatcher
 	specRetrieved    chan struct{}
 }
 
@@ -242,6 +253,14 @@ func (m *mockProvisioningInfoGetterGetter) WatchPodSpec(appName string) (watcher
 	return m.watcher, nil
 }
 
+func (m *mockProvisioningInfoGetterGetter) WatchProxySettings() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchProxySettings")
+	if m.proxyWatcher == nil {
+		return nil, errors.NotImplementedf("WatchProxySettings")
+	}
+	return m.proxyWatcher, nil
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	mu            sync.Mutex
@@ -281,14 +300,15 @@ func (m *mockLifeGetter) Life(entityName string) (life.Value, error) {
 
 type mockUnitUpdater struct {
 	testing.Stub
+	unitErrors []error
 }
 
-func (m *mockUnitUpdater) UpdateUnits(arg params.UpdateApplicationUnits) error {
+func (m *mockUnitUpdater) UpdateUnits(arg params.UpdateApplicationUnits) ([]error, error) {
 	m.MethodCall(m, "UpdateUnits", arg)
 	if err := m.NextErr(); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return m.unitErrors, nil
 }
 
 type mockProvisioningStatusSetter struct {
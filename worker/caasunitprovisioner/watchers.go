@@ -0,0 +1,157 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasunitprovisioner
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+const (
+	// watcherRestartDelay is how long the runner waits before
+	// restarting a broker watcher which has stopped. It is also
+	// the first of the increasing delays between attempts to
+	// start a watcher which keeps failing to start.
+	watcherRestartDelay = time.Second
+
+	// maxWatcherRestartDelay is the longest delay between
+	// attempts to start a watcher.
+	maxWatcherRestartDelay = time.Minute
+
+	// maxWatcherStartAttempts is the number of consecutive
+	// times a broker watcher may fail to start before the
+	// application worker gives up.
+	maxWatcherStartAttempts = 5
+)
+
+// persistentWatcherError is returned when a broker watcher
+// has failed to start too many times. It is fatal to the
+// runner, and so stops the application worker.
+type persistentWatcherError struct {
+	error
+}
+
+func isPersistentWatcherError(err error) bool {
+	_, ok := errors.Cause(err).(persistentWatcherError)
+	return ok
+}
+
+// watcherBackoff returns how long to wait before trying to start a
+// watcher again after it has failed to start the given number of
+// times, in addition to the runner's restart delay.
+func watcherBackoff(failures int) time.Duration {
+	delay := watcherRestartDelay
+	for i := 1; i < failures && delay < maxWatcherRestartDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWatcherRestartDelay {
+		delay = maxWatcherRestartDelay
+	}
+	return delay
+}
+
+// startWatcherFunc returns a function for the application worker's
+// runner which starts the named broker watcher, forwarding its
+// changes to out.
+func (aw *applicationWorker) startWatcherFunc(
+	name string,
+	watch func(appName string) (watcher.NotifyWatcher, error),
+	out chan<- struct{},
+) func() (worker.Worker, error) {
+	// The runner calls the function again only after the worker it
+	// last started has stopped, so these need no locking.
+	var failures int
+	var started bool
+	return func() (worker.Worker, error) {
+		if failures > 0 {
+			select {
+			case <-aw.clock.After(watcherBackoff(failures)):
+			case <-aw.catacomb.Dying():
+				return nil, aw.catacomb.ErrDying()
+			}
+		}
+		if started {
+			logger.Infof("restarting %s watcher for %q", name, aw.application)
+		}
+		w, err := watch(aw.application)
+		if err != nil {
+			if strings.Contains(err.Error(), "unexpected EOF") {
+				logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
+				aw.catacomb.Kill(nil)
+				return nil, errors.Trace(err)
+			}
+			failures++
+			if failures >= maxWatcherStartAttempts {
+				return nil, persistentWatcherError{errors.Annotatef(err,
+					"failed to start %s watcher for %q after %d attempts", name, aw.application, failures,
+				)}
+			}
+			return nil, errors.Annotatef(err, "failed to start %s watcher for %q", name, aw.application)
+		}
+		failures = 0
+		started = true
+		return newWatcherForwarder(name, w, out)
+	}
+}
+
+// watcherForwarder passes on the changes from a broker watcher, so
+// that the application worker can keep receiving them on the same
+// channel however many times the watcher is restarted. The
+// forwarder stops when the watcher does.
+type watcherForwarder struct {
+	catacomb catacomb.Catacomb
+	name     string
+	watcher  watcher.NotifyWatcher
+	out      chan<- struct{}
+}
+
+func newWatcherForwarder(name string, w watcher.NotifyWatcher, out chan<- struct{}) (*watcherForwarder, error) {
+	f := &watcherForwarder{
+		name:    name,
+		watcher: w,
+		out:     out,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &f.catacomb,
+		Work: f.loop,
+		Init: []worker.Worker{w},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (f *watcherForwarder) Kill() {
+	f.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (f *watcherForwarder) Wait() error {
+	return f.catacomb.Wait()
+}
+
+func (f *watcherForwarder) loop() error {
+	for {
+		select {
+		case <-f.catacomb.Dying():
+			return f.catacomb.ErrDying()
+		case _, ok := <-f.watcher.Changes():
+			if !ok {
+				return errors.Errorf("%s watcher closed channel", f.name)
+			}
+			select {
+			case f.out <- struct{}{}:
+			case <-f.catacomb.Dying():
+				return f.catacomb.ErrDying()
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ package caasunitprovisioner
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
@@ -27,6 +28,10 @@ type Config struct {
 	ProvisioningStatusSetter ProvisioningStatusSetter
 	LifeGetter               LifeGetter
 	UnitUpdater              UnitUpdater
+
+	// Clock is used to delay restarting the broker
+	// watchers run for each application.
+	Clock clock.Clock
 }
 
 // Validate validates the worker configuration.
@@ -55,6 +60,9 @@ func (config Config) Validate() error {
 	if config.ProvisioningStatusSetter == nil {
 		return errors.NotValidf("missing ProvisioningStatusSetter")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
 	return nil
 }
 
@@ -170,6 +178,7 @@ func (p *provisioner) loop() error {
 					continue
 				}
 				w, err := newApplicationWorker(
+					p.config.Clock,
 					appId,
 					p.config.ServiceBroker,
 					p.config.ContainerBroker,
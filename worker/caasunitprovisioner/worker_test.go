@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
+	"github.com/juju/proxy"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -20,6 +21,7 @@ import (
 	apicaasunitprovisioner "github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
@@ -49,6 +51,7 @@ type WorkerSuite struct {
 	caasServiceChanges      chan struct{}
 	caasOperatorChanges     chan struct{}
 	containerSpecChanges    chan struct{}
+	proxySettingsChanges    chan struct{}
 	serviceDeleted          chan struct{}
 	serviceEnsured          chan struct{}
 	serviceUpdated          chan struct{}
@@ -97,6 +100,7 @@ containers:
 			StorageName: "database",
 			Size:        100,
 		}},
+		Scheduling: caas.SchedulingParams{SpreadAcrossNodes: true},
 	}
 )
 
@@ -109,6 +113,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.caasServiceChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
+	s.proxySettingsChanges = make(chan struct{}, 1)
 	s.serviceDeleted = make(chan struct{})
 	s.serviceEnsured = make(chan struct{})
 	s.serviceUpdated = make(chan struct{})
@@ -122,7 +127,8 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.podSpecGetter = mockProvisioningInfoGetterGetter{
-		watcher: watchertest.NewMockNotifyWatcher(s.containerSpecChanges),
+		watcher:      watchertest.NewMockNotifyWatcher(s.containerSpecChanges),
+		proxyWatcher: watchertest.NewMockNotifyWatcher(s.proxySettingsChanges),
 	}
 	s.podSpecGetter.setProvisioningInfo(apicaasunitprovisioner.ProvisioningInfo{
 		PodSpec:     containerSpec,
@@ -136,6 +142,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 			StorageName: "database",
 			Size:        100,
 		}},
+		Scheduling: apicaasunitprovisioner.SchedulingInfo{SpreadAcrossNodes: true},
 	})
 
 	s.unitUpdater = mockUnitUpdater{}
@@ -154,6 +161,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		serviceWatcher: watchertest.NewMockNotifyWatcher(s.caasServiceChanges),
 	}
 	s.statusSetter = mockProvisioningStatusSetter{}
+	s.clock = testclock.NewClock(time.Time{})
 
 	s.config = caasunitprovisioner.Config{
 		ApplicationGetter:        &s.applicationGetter,
@@ -164,6 +172,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		LifeGetter:               &s.lifeGetter,
 		UnitUpdater:              &s.unitUpdater,
 		ProvisioningStatusSetter: &s.statusSetter,
+		Clock:                    s.clock,
 	}
 }
 
@@ -202,6 +211,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasunitprovisioner.Config) {
 		config.ProvisioningStatusSetter = nil
 	}, `missing ProvisioningStatusSetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasunitprovisioner.Config) {
+		config.Clock = nil
+	}, `missing Clock not valid`)
 }
 
 func (s *WorkerSuite) testValidateConfig(c *gc.C, f func(*caasunitprovisioner.Config), expect string) {
@@ -271,10 +284,10 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 	defer workertest.CleanKill(c, w)
 
 	s.applicationGetter.CheckCallNames(c, "WatchApplications", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
-	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
+	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "WatchProxySettings", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
-	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
-	s.podSpecGetter.CheckCall(c, 2, "ProvisioningInfo", "gitlab")
+	s.podSpecGetter.CheckCall(c, 2, "ProvisioningInfo", "gitlab") // not found
+	s.podSpecGetter.CheckCall(c, 3, "ProvisioningInfo", "gitlab")
 	s.lifeGetter.CheckCallNames(c, "Life")
 	s.lifeGetter.CheckCall(c, 0, "Life", "gitlab")
 	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureService", "GetService")
@@ -407,6 +420,120 @@ func (s *WorkerSuite) TestScaleChangedInCluster(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestScaleExceedsQuota(c *gc.C) {
+	s.containerBroker.reportedOperatorStatus = status.Active
+	s.serviceBroker.ensureErrors = []error{errors.Trace(provider.QuotaExceededError{
+		Message:   `pods "gitlab-1" is forbidden: exceeded quota: compute-resources`,
+		Quota:     "compute-resources",
+		Requested: map[string]string{"limits.memory": "2Gi", "pods": "1"},
+		Used:      map[string]string{"limits.memory": "7Gi", "pods": "4"},
+		Limited:   map[string]string{"limits.memory": "8Gi", "pods": "5"},
+	})}
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	// The quota, and the capacity it has remaining, are reported
+	// as the application's status.
+	s.waitStatusCalls(c, 2)
+	s.statusSetter.CheckCall(c, 1, "SetOperatorStatus", "gitlab", status.Blocked,
+		`exceeded quota "compute-resources": requested limits.memory=2Gi,pods=1, remaining limits.memory=1Gi,pods=1`,
+		map[string]interface{}{"quota": "compute-resources"},
+	)
+
+	// The scale change is retried after a delay, and the status
+	// restored once the cluster has created the pods.
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitStatusCalls(c, 4)
+	s.statusSetter.CheckCall(c, 3, "SetOperatorStatus", "gitlab", status.Active,
+		"testing 1. 2. 3.", map[string]interface{}{"zip": "zap"},
+	)
+}
+
+func (s *WorkerSuite) TestPodsExceedQuota(c *gc.C) {
+	// The deployment is updated, but the cluster
+	// can't create its pods.
+	s.serviceBroker.serviceStatus = provider.QuotaExceededError{
+		Quota:     "object-counts",
+		Requested: map[string]string{"pods": "1"},
+		Used:      map[string]string{"pods": "5"},
+		Limited:   map[string]string{"pods": "5"},
+	}.Status()
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+
+	s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	s.waitStatusCalls(c, 2)
+	s.statusSetter.CheckCall(c, 1, "SetOperatorStatus", "gitlab", status.Blocked,
+		`exceeded quota "object-counts": requested pods=1, remaining pods=0`,
+		map[string]interface{}{"quota": "object-counts"},
+	)
+}
+
+// waitStatusCalls waits for the given number of
+// operator status updates to have been made.
+func (s *WorkerSuite) waitStatusCalls(c *gc.C, n int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.statusSetter.Calls()) >= n {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for %d status updates", n)
+}
+
 func (s *WorkerSuite) TestNewPodSpecChange(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -460,6 +587,53 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestProxySettingsChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	// Same proxy settings, nothing happens.
+	s.proxySettingsChanges <- struct{}{}
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service/unit ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	info := s.podSpecGetter.provisioningInfo
+	info.ProxySettings = proxy.Settings{
+		Http:    "http://proxy.example.com:3128",
+		NoProxy: "10.0.0.0/8",
+	}
+	s.podSpecGetter.setProvisioningInfo(info)
+	s.proxySettingsChanges <- struct{}{}
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	expectedParams := *expectedServiceParams
+	expectedParams.ProxySettings = info.ProxySettings
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", &expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
+func (s *WorkerSuite) TestProxySettingsNotSupported(c *gc.C) {
+	s.podSpecGetter.proxyWatcher = nil
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "WatchProxySettings", "ProvisioningInfo", "ProvisioningInfo")
+	s.serviceBroker.CheckCall(c, 1, "EnsureService",
+		"gitlab", expectedServiceParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestNewPodSpecChangeCrd(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -734,17 +908,32 @@ func (s *WorkerSuite) TestUnitsChange(c *gc.C) {
 	}
 	defer workertest.CleanKill(c, w)
 
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
-			break
-		}
-	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.waitContainerWatchers(c)
 
 	s.assertUnitChange(c, status.Allocating, status.Allocating)
 	s.assertUnitChange(c, status.Allocating, status.Unknown)
 }
 
+func (s *WorkerSuite) TestUnitsChangeUnitUpdateFails(c *gc.C) {
+	s.unitUpdater.unitErrors = []error{errors.New("boom")}
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.waitContainerWatchers(c)
+
+	// The unit failed to update, so its status is sent again
+	// rather than being suppressed as unchanged.
+	s.assertUnitChange(c, status.Allocating, status.Allocating)
+	s.assertUnitChange(c, status.Allocating, status.Allocating)
+}
+
 func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	w, err := caasunitprovisioner.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -756,12 +945,7 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 		c.Fatal("timed out sending applications change")
 	}
 
-	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
-			break
-		}
-	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.waitContainerWatchers(c)
 	s.containerBroker.ResetCalls()
 
 	select {
@@ -784,6 +968,81 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestBrokerWatcherRestarted(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.waitContainerWatchers(c)
+	s.containerBroker.ResetCalls()
+
+	// Kill the units watcher, having arranged for
+	// the broker to return a live one next time.
+	unitsWatcher := s.containerBroker.unitsWatcher
+	s.containerBroker.unitsWatcher = watchertest.NewMockNotifyWatcher(s.caasUnitsChanges)
+	unitsWatcher.KillErr(errors.New("splat"))
+
+	// The watcher is restarted after a delay.
+	s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) > 0 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchUnits")
+	workertest.CheckAlive(c, w)
+
+	s.assertUnitChange(c, status.Allocating, status.Allocating)
+}
+
+func (s *WorkerSuite) TestBrokerWatcherPersistentFailure(c *gc.C) {
+	s.serviceBroker.SetErrors(
+		errors.New("boom"),
+		errors.New("boom"),
+		errors.New("boom"),
+		errors.New("boom"),
+		errors.New("boom"),
+	)
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	// After each failure the runner's restart delay
+	// and the increasing backoff are waited out.
+	for i := 0; i < 8; i++ {
+		s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `failed to start deployment watcher for "gitlab" after 5 attempts: boom`)
+	s.serviceBroker.CheckCallNames(c, "WatchService", "WatchService", "WatchService", "WatchService", "WatchService")
+}
+
+// waitContainerWatchers waits for the application worker to start
+// the container broker's watchers, which are started concurrently.
+func (s *WorkerSuite) waitContainerWatchers(c *gc.C) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) >= 2 {
+			break
+		}
+	}
+	var funcNames []string
+	for _, call := range s.containerBroker.Calls() {
+		funcNames = append(funcNames, call.FuncName)
+	}
+	c.Assert(funcNames, jc.SameContents, []string{"WatchUnits", "WatchOperator"})
+}
+
 func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expectedUnitStatus status.Status) {
 	s.containerBroker.ResetCalls()
 	s.unitUpdater.ResetCalls()
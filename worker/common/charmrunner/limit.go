@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// TruncationMarker returns the marker that replaces output
+// discarded because it exceeded a limit.
+func TruncationMarker(omitted int64) string {
+	return fmt.Sprintf("[output truncated: %d bytes omitted]", omitted)
+}
+
+// TruncateOutput returns at most limit bytes of the output, followed
+// by a truncation marker if any of the output was discarded, and
+// whether the output was truncated. A limit of zero or less means
+// the output is returned unchanged.
+func TruncateOutput(output []byte, limit int64) ([]byte, bool) {
+	if limit <= 0 || int64(len(output)) <= limit {
+		return output, false
+	}
+	// Don't split a multi-byte character in text output, so
+	// that the truncated output is still valid UTF-8.
+	if utf8.Valid(output) {
+		for limit > 0 && !utf8.RuneStart(output[limit]) {
+			limit--
+		}
+	}
+	omitted := int64(len(output)) - limit
+	result := make([]byte, 0, limit+1+int64(len(TruncationMarker(omitted))))
+	result = append(result, output[:limit]...)
+	result = append(result, '\n')
+	result = append(result, TruncationMarker(omitted)...)
+	return result, true
+}
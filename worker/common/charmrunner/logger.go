@@ -23,6 +23,16 @@ func NewHookLogger(logger loggo.Logger, outReader io.ReadCloser) *HookLogger {
 	}
 }
 
+// NewLimitedHookLogger creates a new hook logger that logs at most
+// limit bytes of the hook's output. Once the limit is reached, a
+// truncation marker is logged and the remaining output is discarded.
+// A limit of zero or less means the output is not limited.
+func NewLimitedHookLogger(logger loggo.Logger, outReader io.ReadCloser, limit int64) *HookLogger {
+	l := NewHookLogger(logger, outReader)
+	l.limit = limit
+	return l
+}
+
 // HookLogger streams the output from a hook to a logger.
 type HookLogger struct {
 	r       io.ReadCloser
@@ -30,6 +40,10 @@ type HookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	limit   int64
+	logged  int64
+	omitted int64
 }
 
 // Run starts the hook logger.
@@ -50,9 +64,38 @@ func (l *HookLogger) Run() {
 			l.mu.Unlock()
 			return
 		}
-		l.logger.Debugf("%s", line)
+		l.logLine(line)
 		l.mu.Unlock()
 	}
+	l.mu.Lock()
+	if l.omitted > 0 && !l.stopped {
+		l.logger.Warningf("%s", TruncationMarker(l.omitted))
+	}
+	l.mu.Unlock()
+}
+
+// logLine logs the line, unless doing so would exceed the limit.
+// The output continues to be read once the limit is reached, so
+// that the hook is not blocked writing to the pipe.
+func (l *HookLogger) logLine(line []byte) {
+	size := int64(len(line)) + 1
+	if l.limit > 0 && (l.omitted > 0 || l.logged+size > l.limit) {
+		if l.omitted == 0 {
+			l.logger.Warningf("hook output exceeds the limit of %d bytes, discarding further output", l.limit)
+		}
+		l.omitted += size
+		return
+	}
+	l.logged += size
+	l.logger.Debugf("%s", line)
+}
+
+// Truncated reports whether any of the hook's output
+// was discarded because it exceeded the limit.
+func (l *HookLogger) Truncated() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.omitted > 0
 }
 
 // Stop stops the hook logger.
@@ -4,6 +4,7 @@
 package dblogpruner
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -149,6 +150,14 @@ func (w *pruneWorker) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			// Request logs are kept for as long as other logs.
+			requestsRemoved, err := state.PruneRequestLogs(w.config.State, minLogTime)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if requestsRemoved > 0 {
+				message = fmt.Sprintf("%s, pruned %d request log entries", message, requestsRemoved)
+			}
 			w.mu.Lock()
 			w.current.pruning = false
 			w.current.message = message
@@ -143,6 +143,32 @@ func (s *suite) TestPrunesOldLogs(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesOldRequestLogs(c *gc.C) {
+	maxLogAge := 24 * time.Hour
+	s.setupState(c, "24h", "1000P")
+
+	now := time.Now()
+	requestLogger, err := state.NewRequestLogger(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	defer requestLogger.Close()
+	err = requestLogger.Log([]state.RequestLogRecord{
+		{Time: now, Facade: "Client", Method: "keep"},
+		{Time: now.Add(-maxLogAge - 1), Facade: "Client", Method: "prune"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.startWorker(c)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		records, err := state.RequestLogs(s.state, now.Add(-2*maxLogAge))
+		c.Assert(err, jc.ErrorIsNil)
+		if len(records) == 1 {
+			c.Assert(records[0].Method, gc.Equals, "keep")
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
 type storageEngine struct {
 	Name string `bson:"name"`
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/agent"
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -29,6 +30,10 @@ type Deployer struct {
 	st       *apideployer.State
 	ctx      Context
 	deployed set.Strings
+
+	// limiters runs a worker for each deployed unit, which keeps the
+	// unit's resource limits in line with its constraints.
+	limiters *worker.Runner
 }
 
 // Context abstracts away the differences between different unit deployment
@@ -48,6 +53,10 @@ type Context interface {
 	// DeployedUnits returns the names of all units deployed by the manager.
 	DeployedUnits() ([]string, error)
 
+	// SetUnitLimits limits the resources used by the processes of the
+	// specified unit to those allowed by the given constraints.
+	SetUnitLimits(unitName string, cons constraints.Value) error
+
 	// AgentConfig returns the agent config for the machine agent that is
 	// running the deployer.
 	AgentConfig() agent.Config
@@ -60,6 +69,10 @@ func NewDeployer(st *apideployer.State, ctx Context) (worker.Worker, error) {
 		st:       st,
 		ctx:      ctx,
 		deployed: make(set.Strings),
+		limiters: worker.NewRunner(worker.RunnerParams{
+			IsFatal:      func(error) bool { return false },
+			RestartDelay: limiterRestartDelay,
+		}),
 	}
 	w, err := watcher.NewStringsWorker(watcher.StringsConfig{
 		Handler: d,
@@ -91,6 +104,9 @@ func (d *Deployer) SetUp() (watcher.StringsWatcher, error) {
 	}
 	for _, unitName := range deployed {
 		d.deployed.Add(unitName)
+		if err := d.startLimiter(unitName); err != nil {
+			return nil, err
+		}
 		if err := d.changed(unitName); err != nil {
 			return nil, err
 		}
@@ -167,7 +183,7 @@ func (d *Deployer) deploy(unit *apideployer.Unit) error {
 		return err
 	}
 	d.deployed.Add(unitName)
-	return nil
+	return d.startLimiter(unitName)
 }
 
 // recall will recall the named unit with the deployer's manager. It will
@@ -177,6 +193,9 @@ func (d *Deployer) recall(unitName string) error {
 		panic("must not recall a unit that is not deployed")
 	}
 	logger.Infof("recalling unit %q", unitName)
+	if err := d.limiters.StopWorker(unitName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := d.ctx.RecallUnit(unitName); err != nil {
 		return err
 	}
@@ -197,7 +216,15 @@ func (d *Deployer) remove(unit *apideployer.Unit) error {
 	return unit.Remove()
 }
 
+// startLimiter starts the worker that limits the resources used by
+// the named unit.
+func (d *Deployer) startLimiter(unitName string) error {
+	err := d.limiters.StartWorker(unitName, func() (worker.Worker, error) {
+		return newLimiter(d.st, d.ctx, unitName)
+	})
+	return errors.Trace(err)
+}
+
 func (d *Deployer) TearDown() error {
-	// Nothing to do here.
-	return nil
+	return worker.Stop(d.limiters)
 }
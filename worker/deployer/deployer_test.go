@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api"
 	apideployer "github.com/juju/juju/api/deployer"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/status"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -123,6 +124,28 @@ func (s *deployerSuite) TestInitialStatusMessages(c *gc.C) {
 	}))
 }
 
+func (s *deployerSuite) TestFollowsUnitConstraints(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := app.SetConstraints(constraints.MustParse("cpu-quota=50"))
+	c.Assert(err, jc.ErrorIsNil)
+	u0, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.getContextForMachine(c, s.machine.Tag())
+	limits := deployer.PatchSliceLimits(ctx)
+	dep, err := deployer.NewDeployer(s.deployerState, ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	defer stop(c, dep)
+
+	err = u0.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitFor(c, hasLimits(limits, "jujud-unit-wordpress-0.slice", 50, 0))
+
+	err = app.SetConstraints(constraints.MustParse("cpu-quota=25 mem-limit=512M"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitFor(c, hasLimits(limits, "jujud-unit-wordpress-0.slice", 25, 512))
+}
+
 func (s *deployerSuite) TestRemoveNonAlivePrincipals(c *gc.C) {
 	// Create an application, and a couple of units.
 	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
@@ -280,6 +303,13 @@ func isRemoved(st *state.State, name string) func(*gc.C) bool {
 	}
 }
 
+func hasLimits(limits *deployer.SliceLimits, slice string, cpuQuota, memoryLimit uint64) func(*gc.C) bool {
+	return func(c *gc.C) bool {
+		gotCPUQuota, gotMemoryLimit, ok := limits.Get(slice)
+		return ok && gotCPUQuota == cpuQuota && gotMemoryLimit == memoryLimit
+	}
+}
+
 func unitStatus(u *state.Unit, statusInfo status.StatusInfo) func(*gc.C) bool {
 	return func(c *gc.C) bool {
 		sInfo, err := u.Status()
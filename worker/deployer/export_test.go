@@ -4,6 +4,10 @@
 package deployer
 
 import (
+	"sync"
+
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/service/common"
@@ -30,5 +34,37 @@ func NewTestSimpleContext(agentConfig agent.Config, logDir string, data *svctest
 		listServices: func() ([]string, error) {
 			return data.InstalledNames(), nil
 		},
+		setSliceLimits: func(string, uint64, uint64) error {
+			return errors.NotSupportedf("resource limits")
+		},
+	}
+}
+
+// SliceLimits records the limits set on slices by a context patched
+// with PatchSliceLimits.
+type SliceLimits struct {
+	mu     sync.Mutex
+	limits map[string][2]uint64
+}
+
+// Get returns the CPU quota and memory limit set on the slice, and
+// whether any were set.
+func (l *SliceLimits) Get(slice string) (cpuQuota, memoryLimit uint64, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limits, ok := l.limits[slice]
+	return limits[0], limits[1], ok
+}
+
+// PatchSliceLimits causes the context to record the limits it sets
+// on slices, rather than setting them.
+func PatchSliceLimits(ctx *SimpleContext) *SliceLimits {
+	l := &SliceLimits{limits: make(map[string][2]uint64)}
+	ctx.setSliceLimits = func(slice string, cpuQuota, memoryLimit uint64) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.limits[slice] = [2]uint64{cpuQuota, memoryLimit}
+		return nil
 	}
+	return l
 }
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/watcher"
+)
+
+// limiterRestartDelay is how long a unit's limiter waits before
+// trying again after it fails.
+var limiterRestartDelay = time.Minute
+
+// limitsAPI defines the API that a limiter needs to follow the
+// constraints of a unit.
+type limitsAPI interface {
+	UnitConstraints(names.UnitTag) (constraints.Value, error)
+	WatchUnitConstraints(names.UnitTag) (watcher.NotifyWatcher, error)
+}
+
+// limiter is a worker that limits the resources used by a deployed
+// unit to those allowed by its constraints, reapplying the limits
+// whenever the constraints change.
+type limiter struct {
+	catacomb catacomb.Catacomb
+	api      limitsAPI
+	ctx      Context
+	unitName string
+}
+
+func newLimiter(api limitsAPI, ctx Context, unitName string) (worker.Worker, error) {
+	l := &limiter{
+		api:      api,
+		ctx:      ctx,
+		unitName: unitName,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &l.catacomb,
+		Work: l.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return l, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (l *limiter) Kill() {
+	l.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (l *limiter) Wait() error {
+	return l.catacomb.Wait()
+}
+
+func (l *limiter) loop() error {
+	tag := names.NewUnitTag(l.unitName)
+	w, err := l.api.WatchUnitConstraints(tag)
+	if errors.IsNotSupported(err) {
+		// The controller can't report changes to the constraints,
+		// so the limits are applied once only.
+		logger.Debugf("not following constraints of unit %q: %v", l.unitName, err)
+		if err := l.setLimits(tag); err != nil {
+			return errors.Trace(err)
+		}
+		<-l.catacomb.Dying()
+		return l.catacomb.ErrDying()
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := l.catacomb.Add(w); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-l.catacomb.Dying():
+			return l.catacomb.ErrDying()
+		case _, ok := <-w.Changes():
+			if !ok {
+				return errors.New("constraints watcher closed")
+			}
+			if err := l.setLimits(tag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (l *limiter) setLimits(tag names.UnitTag) error {
+	cons, err := l.api.UnitConstraints(tag)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not limiting resources of unit %q: %v", l.unitName, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err = l.ctx.SetUnitLimits(l.unitName, cons)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not limiting resources of unit %q: %v", l.unitName, err)
+		return nil
+	}
+	return errors.Annotatef(err, "limiting resources of unit %q", l.unitName)
+}
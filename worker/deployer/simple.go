@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	jujuversion "github.com/juju/juju/version"
@@ -44,6 +45,9 @@ type SimpleContext struct {
 
 	// listServices is a surrogate for service.ListServices.
 	listServices func() ([]string, error)
+
+	// setSliceLimits is a surrogate for service.SetSliceLimits.
+	setSliceLimits func(string, uint64, uint64) error
 }
 
 var _ Context = (*SimpleContext)(nil)
@@ -79,6 +83,7 @@ func NewSimpleContext(agentConfig agent.Config, api APICalls) *SimpleContext {
 		listServices: func() ([]string, error) {
 			return service.ListServices()
 		},
+		setSliceLimits: service.SetSliceLimits,
 	}
 }
 
@@ -245,16 +250,13 @@ func (ctx *SimpleContext) DeployedUnits() ([]string, error) {
 }
 
 // service returns a service.Service corresponding to the specified
-// unit.
+// unit, which runs in the unit's own slice.
 func (ctx *SimpleContext) service(unitName string, renderer shell.Renderer) (deployerService, error) {
-	// Service name can be at most 64 characters long, we limit it to 56 just to be safe.
-	tag, err := names.NewUnitTag(unitName).ShortenedString(56)
+	svcName, err := service.UnitAgentServiceName(unitName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	svcName := "jujud-" + tag
-
 	info := service.NewAgentInfo(
 		service.AgentKindUnit,
 		unitName,
@@ -269,9 +271,30 @@ func (ctx *SimpleContext) service(unitName string, renderer shell.Renderer) (dep
 	containerType := ctx.agentConfig.Value(agent.ContainerType)
 
 	conf := service.ContainerAgentConf(info, renderer, containerType)
+	conf.Slice, err = service.UnitAgentSliceName(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return ctx.discoverService(svcName, conf)
 }
 
+// SetUnitLimits limits the resources used by the processes in the
+// specified unit's slice to those allowed by the constraints.
+func (ctx *SimpleContext) SetUnitLimits(unitName string, cons constraints.Value) error {
+	slice, err := service.UnitAgentSliceName(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var cpuQuota, memoryLimit uint64
+	if cons.HasCpuQuota() {
+		cpuQuota = *cons.CpuQuota
+	}
+	if cons.HasMemLimit() {
+		memoryLimit = *cons.MemLimit
+	}
+	return errors.Trace(ctx.setSliceLimits(slice, cpuQuota, memoryLimit))
+}
+
 func removeOnErr(err *error, path string) {
 	if *err != nil {
 		if err := os.RemoveAll(path); err != nil {
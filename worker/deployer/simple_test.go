@@ -21,6 +21,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/core/constraints"
 	svctesting "github.com/juju/juju/service/common/testing"
 	"github.com/juju/juju/service/upstart"
 	"github.com/juju/juju/state/multiwatcher"
@@ -78,6 +79,35 @@ func (s *SimpleContextSuite) TestDeployRecall(c *gc.C) {
 	s.checkUnitRemoved(c, "foo/123")
 }
 
+func (s *SimpleContextSuite) TestDeployUnitSlice(c *gc.C) {
+	mgr := s.getContext(c)
+	err := mgr.DeployUnit("foo/123", "some-password")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkUnitInstalled(c, "foo/123", "some-password")
+
+	svcConf := s.data.GetInstalled("jujud-unit-foo-123").Conf()
+	c.Check(svcConf.Slice, gc.Equals, "jujud-unit-foo-123.slice")
+}
+
+func (s *SimpleContextSuite) TestSetUnitLimits(c *gc.C) {
+	mgr := s.getContext(c)
+	limits := deployer.PatchSliceLimits(mgr)
+
+	err := mgr.SetUnitLimits("foo/123", constraints.MustParse("cpu-quota=50 mem-limit=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	cpuQuota, memoryLimit, ok := limits.Get("jujud-unit-foo-123.slice")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cpuQuota, gc.Equals, uint64(50))
+	c.Check(memoryLimit, gc.Equals, uint64(1024))
+
+	err = mgr.SetUnitLimits("foo/123", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	cpuQuota, memoryLimit, ok = limits.Get("jujud-unit-foo-123.slice")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(cpuQuota, gc.Equals, uint64(0))
+	c.Check(memoryLimit, gc.Equals, uint64(0))
+}
+
 func (s *SimpleContextSuite) TestOldDeployedUnitsCanBeRecalled(c *gc.C) {
 	// After r1347 deployer tag is no longer part of the upstart conf filenames,
 	// now only the units' tags are used. This change is with the assumption only
@@ -5,7 +5,9 @@ package environ
 
 import (
 	"reflect"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1/catacomb"
@@ -16,6 +18,10 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.environ")
 
+// HealthCheckInterval is how often the health of an environ that
+// implements environs.HealthChecker is checked.
+const HealthCheckInterval = time.Minute
+
 // ConfigObserver exposes a model configuration and a watch constructor
 // that allows clients to be informed of changes to the configuration.
 type ConfigObserver interface {
@@ -31,6 +37,7 @@ type ConfigObserver interface {
 type Config struct {
 	Observer       ConfigObserver
 	NewEnvironFunc environs.NewEnvironFunc
+	Clock          clock.Clock
 }
 
 // Validate returns an error if the config cannot be used to start a Tracker.
@@ -41,6 +48,9 @@ func (config Config) Validate() error {
 	if config.NewEnvironFunc == nil {
 		return errors.NotValidf("nil NewEnvironFunc")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -117,6 +127,16 @@ func (t *Tracker) loop() error {
 		}
 		cloudWatcherChanges = cloudWatcher.Changes()
 	}
+
+	// Some environs can check the health of their cloud, and use
+	// the result until the next check. Their health is checked
+	// periodically, so that problems are found before the environ
+	// is next used.
+	var healthCheck <-chan time.Time
+	healthChecker, ok := t.environ.(environs.HealthChecker)
+	if ok {
+		healthCheck = t.config.Clock.After(0)
+	}
 	for {
 		logger.Debugf("waiting for environ watch notification")
 		select {
@@ -150,6 +170,11 @@ func (t *Tracker) loop() error {
 				return errors.Annotate(err, "cannot update environ cloud spec")
 			}
 			t.currentCloudSpec = cloudSpec
+		case <-healthCheck:
+			if err := healthChecker.CheckHealth(); err != nil {
+				logger.Warningf("cloud %q is unhealthy: %v", t.currentCloudSpec.Name, err)
+			}
+			healthCheck = t.config.Clock.After(HealthCheckInterval)
 		}
 	}
 }
@@ -6,6 +6,7 @@ package environ_test
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -40,6 +41,17 @@ func (s *TrackerSuite) TestValidateNewEnvironFunc(c *gc.C) {
 	})
 }
 
+func (s *TrackerSuite) TestValidateClock(c *gc.C) {
+	config := environ.Config{
+		Observer:       &runContext{},
+		NewEnvironFunc: newMockEnviron,
+	}
+	s.testValidate(c, config, func(err error) {
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	})
+}
+
 func (s *TrackerSuite) testValidate(c *gc.C, config environ.Config, check func(err error)) {
 	err := config.Validate()
 	check(err)
@@ -58,6 +70,7 @@ func (s *TrackerSuite) TestModelConfigFails(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Check(err, gc.ErrorMatches, "cannot create environ: no you")
@@ -71,6 +84,7 @@ func (s *TrackerSuite) TestModelConfigInvalid(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer: context,
+			Clock:    testclock.NewClock(time.Time{}),
 			NewEnvironFunc: func(environs.OpenParams) (environs.Environ, error) {
 				return nil, errors.NotValidf("config")
 			},
@@ -90,6 +104,7 @@ func (s *TrackerSuite) TestModelConfigValid(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Assert(err, jc.ErrorIsNil)
@@ -111,6 +126,7 @@ func (s *TrackerSuite) TestCloudSpec(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer: context,
+			Clock:    testclock.NewClock(time.Time{}),
 			NewEnvironFunc: func(args environs.OpenParams) (environs.Environ, error) {
 				c.Assert(args.Cloud, jc.DeepEquals, cloudSpec)
 				return nil, errors.NotValidf("cloud spec")
@@ -131,6 +147,7 @@ func (s *TrackerSuite) TestWatchFails(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Assert(err, jc.ErrorIsNil)
@@ -147,6 +164,7 @@ func (s *TrackerSuite) TestModelConfigWatchCloses(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Assert(err, jc.ErrorIsNil)
@@ -164,6 +182,7 @@ func (s *TrackerSuite) TestCloudSpecWatchCloses(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Assert(err, jc.ErrorIsNil)
@@ -185,6 +204,7 @@ func (s *TrackerSuite) TestWatchedModelConfigFails(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Check(err, jc.ErrorIsNil)
@@ -202,6 +222,7 @@ func (s *TrackerSuite) TestWatchedModelConfigIncompatible(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer: context,
+			Clock:    testclock.NewClock(time.Time{}),
 			NewEnvironFunc: func(environs.OpenParams) (environs.Environ, error) {
 				env := &mockEnviron{}
 				env.SetErrors(errors.New("SetConfig is broken"))
@@ -227,6 +248,7 @@ func (s *TrackerSuite) TestWatchedModelConfigUpdates(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Check(err, jc.ErrorIsNil)
@@ -265,6 +287,7 @@ func (s *TrackerSuite) TestWatchedCloudSpecUpdates(c *gc.C) {
 	fix.Run(c, func(context *runContext) {
 		tracker, err := environ.NewTracker(environ.Config{
 			Observer:       context,
+			Clock:          testclock.NewClock(time.Time{}),
 			NewEnvironFunc: newMockEnviron,
 		})
 		c.Check(err, jc.ErrorIsNil)
@@ -293,3 +316,35 @@ func (s *TrackerSuite) TestWatchedCloudSpecUpdates(c *gc.C) {
 		}
 	})
 }
+
+func (s *TrackerSuite) TestHealthCheckedPeriodically(c *gc.C) {
+	fix := &fixture{}
+	fix.Run(c, func(context *runContext) {
+		clock := testclock.NewClock(time.Time{})
+		checks := make(chan struct{}, 10)
+		tracker, err := environ.NewTracker(environ.Config{
+			Observer: context,
+			NewEnvironFunc: func(args environs.OpenParams) (environs.Environ, error) {
+				env, err := newMockEnviron(args)
+				return &healthCheckingEnviron{env.(*mockEnviron), checks}, err
+			},
+			Clock: clock,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		waitCheck := func() {
+			select {
+			case <-checks:
+			case <-time.After(coretesting.LongWait):
+				c.Fatalf("timed out waiting for health check")
+			}
+		}
+		// The health is checked when the tracker starts,
+		// and then every HealthCheckInterval.
+		c.Assert(clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+		waitCheck()
+		c.Assert(clock.WaitAdvance(environ.HealthCheckInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+		waitCheck()
+	})
+}
@@ -6,6 +6,7 @@ package environ_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -248,3 +249,15 @@ func (e *mockEnviron) SetCloudSpec(spec environs.CloudSpec) error {
 func newMockEnviron(args environs.OpenParams) (environs.Environ, error) {
 	return &mockEnviron{cfg: args.Config, spec: args.Cloud}, nil
 }
+
+// healthCheckingEnviron is a mockEnviron that implements
+// environs.HealthChecker, reporting each health check.
+type healthCheckingEnviron struct {
+	*mockEnviron
+	checks chan<- struct{}
+}
+
+func (e *healthCheckingEnviron) CheckHealth() error {
+	e.checks <- struct{}{}
+	return errors.New("unhealthy")
+}
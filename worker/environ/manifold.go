@@ -4,6 +4,7 @@
 package environ
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -18,6 +19,7 @@ import (
 type ManifoldConfig struct {
 	APICallerName  string
 	NewEnvironFunc environs.NewEnvironFunc
+	Clock          clock.Clock
 }
 
 // Manifold returns a Manifold that encapsulates a *Tracker and exposes it as
@@ -40,6 +42,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			w, err := NewTracker(Config{
 				Observer:       apiSt,
 				NewEnvironFunc: config.NewEnvironFunc,
+				Clock:          config.Clock,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -6,6 +6,7 @@ package fanconfigurer
 import (
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,6 +37,10 @@ type FanConfigurerFacade interface {
 
 type FanConfigurerConfig struct {
 	Facade FanConfigurerFacade
+
+	// Status, if not nil, records the FAN configuration
+	// applied and any errors hit applying it.
+	Status *Status
 }
 
 // processNewConfig acts on a new fan config.
@@ -51,14 +56,17 @@ func (fc *FanConfigurer) processNewConfig() error {
 	if len(fanConfig) == 0 {
 		logger.Debugf("Fan not enabled")
 		// TODO(wpk) 2017-08-05 We have to clean this up!
+		fc.config.Status.setConfig(nil)
 		return nil
 	}
+	fc.config.Status.setConfig(fanConfig)
 
 	for i, fan := range fanConfig {
 		logger.Debugf("Adding config for %d: %s %s", i, fan.Underlay, fan.Overlay)
 		line := fmt.Sprintf("fanatic enable-fan -u %s -o %s", fan.Underlay, fan.Overlay)
 		result, err := scriptrunner.RunCommand(line, os.Environ(), fc.clock, 5000*time.Millisecond)
 		logger.Debugf("Launched %s - result %v %v %d", line, string(result.Stdout), string(result.Stderr), result.Code)
+		fc.recordCommandFailure(line, result, err)
 		if err != nil {
 			return err
 		}
@@ -67,11 +75,30 @@ func (fc *FanConfigurer) processNewConfig() error {
 	// fanatic sometimes fails to bring up interface because of some weird interactions with iptables.
 	result, err := scriptrunner.RunCommand("fanctl up -a", os.Environ(), fc.clock, 5000*time.Millisecond)
 	logger.Debugf("Launched fanctl up -a - result %v %v %d", string(result.Stdout), string(result.Stderr), result.Code)
+	fc.recordCommandFailure("fanctl up -a", result, err)
 
 	return err
 }
 
+// recordCommandFailure records in the configurer's status
+// the failure, if any, of a command run to configure the FAN.
+func (fc *FanConfigurer) recordCommandFailure(command string, result *scriptrunner.ScriptResult, err error) {
+	var message string
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("%s: %v", command, err)
+	case result.Code != 0:
+		message = fmt.Sprintf("%s: exit code %d: %s", command, result.Code, strings.TrimSpace(string(result.Stderr)))
+	default:
+		return
+	}
+	fc.config.Status.recordError(fc.clock.Now(), message)
+}
+
 func NewFanConfigurer(config FanConfigurerConfig, clock clock.Clock) (*FanConfigurer, error) {
+	if config.Status == nil {
+		config.Status = NewStatus()
+	}
 	fc := &FanConfigurer{
 		config: config,
 		clock:  clock,
@@ -23,7 +23,12 @@ type ManifoldConfig struct {
 
 // Manifold returns a dependency manifold that runs a fan configurer
 // worker, using the resource names defined in the supplied config.
+//
+// The manifold outputs a *bool, true once the FAN has been configured,
+// and a **Status recording the configuration applied and the errors
+// hit applying it, which survives restarts of the worker.
 func Manifold(config ManifoldConfig) dependency.Manifold {
+	status := NewStatus()
 	return dependency.Manifold{
 		Inputs: []string{
 			config.APICallerName,
@@ -36,8 +41,10 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			switch outPointer := out.(type) {
 			case *bool:
 				*outPointer = true
+			case **Status:
+				*outPointer = inWorker.config.Status
 			default:
-				return errors.Errorf("out should be *bool; got %T", out)
+				return errors.Errorf("out should be *bool or **fanconfigurer.Status; got %T", out)
 			}
 			return nil
 		},
@@ -51,6 +58,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			fanconfigurer, err := NewFanConfigurer(FanConfigurerConfig{
 				Facade: facade,
+				Status: status,
 			}, config.Clock)
 			return fanconfigurer, errors.Annotate(err, "creating fanconfigurer orchestrator")
 		},
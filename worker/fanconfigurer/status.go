@@ -0,0 +1,142 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
+)
+
+// maxRecentErrors is the number of errors a Status remembers.
+const maxRecentErrors = 5
+
+// Error records an error hit configuring FAN networking.
+type Error struct {
+	Time    time.Time
+	Message string
+}
+
+// Status records the FAN configuration most recently applied by
+// a fan configurer, and the most recent errors it hit, so that the
+// machiner can report them. A Status outlives the workers that
+// record into it, so errors which caused a worker to be restarted
+// are not lost.
+type Status struct {
+	mu     sync.Mutex
+	config network.FanConfig
+	errors []Error
+
+	// changed is closed, and replaced, whenever the status changes.
+	changed chan struct{}
+}
+
+// NewStatus returns a new, empty Status.
+func NewStatus() *Status {
+	return &Status{changed: make(chan struct{})}
+}
+
+// Config returns the FAN configuration most recently applied.
+func (s *Status) Config() network.FanConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(network.FanConfig(nil), s.config...)
+}
+
+// Errors returns the most recent errors, oldest first.
+func (s *Status) Errors() []Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Error(nil), s.errors...)
+}
+
+// Watch returns a NotifyWatcher that notifies when the configuration
+// is applied or an error is recorded.
+func (s *Status) Watch() (watcher.NotifyWatcher, error) {
+	w := &statusWatcher{
+		status:  s,
+		changes: make(chan struct{}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (s *Status) changedChannel() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changed
+}
+
+// notifyChanged must be called with s.mu held.
+func (s *Status) notifyChanged() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Status) setConfig(config network.FanConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+	s.notifyChanged()
+}
+
+func (s *Status) recordError(now time.Time, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, Error{Time: now, Message: message})
+	if n := len(s.errors); n > maxRecentErrors {
+		s.errors = s.errors[n-maxRecentErrors:]
+	}
+	s.notifyChanged()
+}
+
+// statusWatcher is a NotifyWatcher for a Status.
+type statusWatcher struct {
+	catacomb catacomb.Catacomb
+	status   *Status
+	changes  chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *statusWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *statusWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *statusWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *statusWatcher) loop() error {
+	// The initial event is sent straight away.
+	out := w.changes
+	changed := w.status.changedChannel()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-changed:
+			changed = w.status.changedChannel()
+			out = w.changes
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package fanconfigurer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
+)
+
+type statusSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&statusSuite{})
+
+func (s *statusSuite) TestConfig(c *gc.C) {
+	config, err := network.ParseFanConfig("10.0.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+
+	status := NewStatus()
+	c.Assert(status.Config(), gc.HasLen, 0)
+	status.setConfig(config)
+	c.Assert(status.Config(), jc.DeepEquals, config)
+}
+
+func (s *statusSuite) TestErrorsKeepsMostRecent(c *gc.C) {
+	status := NewStatus()
+	start := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < maxRecentErrors+2; i++ {
+		status.recordError(start.Add(time.Duration(i)*time.Minute), fmt.Sprintf("error %d", i))
+	}
+	errs := status.Errors()
+	c.Assert(errs, gc.HasLen, maxRecentErrors)
+	c.Assert(errs[0], jc.DeepEquals, Error{Time: start.Add(2 * time.Minute), Message: "error 2"})
+	c.Assert(errs[maxRecentErrors-1], jc.DeepEquals, Error{
+		Time:    start.Add(time.Duration(maxRecentErrors+1) * time.Minute),
+		Message: fmt.Sprintf("error %d", maxRecentErrors+1),
+	})
+}
+
+func (s *statusSuite) TestWatch(c *gc.C) {
+	status := NewStatus()
+	w, err := status.Watch()
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := watchertest.NewNotifyWatcherC(c, w, nil)
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	status.setConfig(nil)
+	wc.AssertOneChange()
+
+	status.recordError(time.Now(), "boom")
+	status.recordError(time.Now(), "bang")
+	wc.AssertOneChange()
+}
@@ -141,6 +141,12 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
+	// applicationFirewaller is set in global mode if the environment
+	// can scope firewall rules to the machines of an application, in
+	// which case the ports of each application are opened only to its
+	// own machines rather than to the whole environment.
+	applicationFirewaller environs.ApplicationFirewaller
+
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc newCrossModelFacadeFunc
 	remoteRelationsWatcher     watcher.StringsWatcher
@@ -195,6 +201,9 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalIngressRuleRef = make(map[string]int)
+		if af, ok := cfg.EnvironFirewaller.(environs.ApplicationFirewaller); ok {
+			fw.applicationFirewaller = af
+		}
 	default:
 		return nil, errors.Errorf("invalid firewall-mode %q", cfg.Mode)
 	}
@@ -262,7 +271,9 @@ func (fw *Firewaller) loop() error {
 			if !reconciled {
 				reconciled = true
 				var err error
-				if fw.globalMode {
+				if fw.applicationFirewaller != nil {
+					err = fw.reconcileApplications()
+				} else if fw.globalMode {
 					err = fw.reconcileGlobal()
 				} else {
 					err = fw.reconcileInstances()
@@ -528,6 +539,36 @@ func (fw *Firewaller) reconcileGlobal() error {
 	return nil
 }
 
+// reconcileApplications compares the initially started watcher for
+// machines, units and applications with the ports opened in the
+// firewall of each application, and opens and closes the appropriate
+// ports for each application. Any ports opened for the whole
+// environment, before application firewalls were used, are closed.
+func (fw *Firewaller) reconcileApplications() error {
+	globalRules, err := fw.environFirewaller.IngressRules(fw.cloudCallContext)
+	if err != nil {
+		return err
+	}
+	if len(globalRules) > 0 {
+		logger.Infof("closing global ports %v", globalRules)
+		if err := fw.environFirewaller.ClosePorts(fw.cloudCallContext, globalRules); err != nil {
+			return err
+		}
+	}
+	for _, applicationd := range fw.applicationids {
+		name := applicationd.application.Name()
+		rules, err := fw.applicationFirewaller.ApplicationIngressRules(fw.cloudCallContext, name)
+		if err != nil {
+			return err
+		}
+		applicationd.ingressRules = rules
+		if err := fw.flushApplication(applicationd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // reconcileInstances compares the initially started watcher for machines,
 // units and applications with the opened and closed ports of the instances and
 // opens and closes the appropriate ports for each instance.
@@ -722,6 +763,9 @@ func portRangesEqual(a, b portRanges) bool {
 
 // flushUnits opens and closes ports for the passed unit data.
 func (fw *Firewaller) flushUnits(unitds []*unitData) error {
+	if fw.applicationFirewaller != nil {
+		return fw.flushApplications(unitds)
+	}
 	machineds := map[names.MachineTag]*machineData{}
 	for _, unitd := range unitds {
 		machineds[unitd.machined.tag] = unitd.machined
@@ -736,6 +780,13 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
+	if fw.applicationFirewaller != nil {
+		var unitds []*unitData
+		for _, unitd := range machined.unitds {
+			unitds = append(unitds, unitd)
+		}
+		return fw.flushApplications(unitds)
+	}
 	want, err := fw.gatherIngressRules(machined)
 	if err != nil {
 		return errors.Trace(err)
@@ -759,33 +810,44 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				logger.Debugf("no ingress rules for unknown %v on %v", unitTag, machined.tag)
 				continue
 			}
-
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
-				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
-			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
-				}
+			rules, err := fw.unitIngressRules(unitd, portRanges)
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
+			want = append(want, rules...)
 		}
 	}
 	return want, nil
 }
 
+// unitIngressRules returns the ingress rules for the port ranges
+// opened by the unit.
+func (fw *Firewaller) unitIngressRules(unitd *unitData, portRanges portRanges) ([]network.IngressRule, error) {
+	cidrs := set.NewStrings()
+	// If the unit is exposed, allow access from everywhere.
+	if unitd.applicationd.exposed {
+		cidrs.Add("0.0.0.0/0")
+	} else {
+		// Not exposed, so add any ingress rules required by remote relations.
+		if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("CIDRS for %v: %v", unitd.tag, cidrs.Values())
+	}
+	var rules []network.IngressRule
+	if cidrs.Size() > 0 {
+		for portRange := range portRanges {
+			sourceCidrs := cidrs.SortedValues()
+			rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
@@ -884,6 +946,65 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	return nil
 }
 
+// flushApplications opens and closes the ports of the applications
+// of the passed unit data.
+func (fw *Firewaller) flushApplications(unitds []*unitData) error {
+	applicationds := make(map[*applicationData]bool)
+	for _, unitd := range unitds {
+		if applicationds[unitd.applicationd] {
+			continue
+		}
+		applicationds[unitd.applicationd] = true
+		if err := fw.flushApplication(unitd.applicationd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushApplication opens and closes ports in the firewall of the
+// application, and adds the machines on which its units have opened
+// ports to the firewall. Machines are not removed from the firewall
+// when their units close their ports or are removed, so that their
+// instances need not be updated; the firewall has no rules for ports
+// that are not opened by a unit of the application.
+func (fw *Firewaller) flushApplication(applicationd *applicationData) error {
+	var want []network.IngressRule
+	machineIds := set.NewStrings()
+	for unitTag, unitd := range applicationd.unitds {
+		portRanges := unitd.machined.definedPorts[unitTag]
+		if len(portRanges) == 0 {
+			continue
+		}
+		rules, err := fw.unitIngressRules(unitd, portRanges)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		want = append(want, rules...)
+		machineIds.Add(unitd.machined.tag.Id())
+	}
+	toOpen, toClose := diffRanges(applicationd.ingressRules, want)
+	newMachineIds := machineIds.Difference(applicationd.machineIds).SortedValues()
+
+	// Open and close the ports.
+	name := applicationd.application.Name()
+	if len(toOpen) > 0 || len(newMachineIds) > 0 {
+		if err := fw.applicationFirewaller.OpenApplicationPorts(fw.cloudCallContext, name, newMachineIds, toOpen); err != nil {
+			return err
+		}
+		logger.Infof("opened port ranges %v for application %q on machines %v", toOpen, name, newMachineIds)
+	}
+	if len(toClose) > 0 {
+		if err := fw.applicationFirewaller.CloseApplicationPorts(fw.cloudCallContext, name, toClose); err != nil {
+			return err
+		}
+		logger.Infof("closed port ranges %v for application %q", toClose, name)
+	}
+	applicationd.ingressRules = want
+	applicationd.machineIds = applicationd.machineIds.Union(machineIds)
+	return nil
+}
+
 // flushInstancePorts opens and closes ports global on the machine.
 func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) (err error) {
 	defer func() {
@@ -968,10 +1089,18 @@ func (fw *Firewaller) machineLifeChanged(tag names.MachineTag) error {
 
 // forgetMachine cleans the machine data after the machine is removed.
 func (fw *Firewaller) forgetMachine(machined *machineData) error {
+	var unitds []*unitData
 	for _, unitd := range machined.unitds {
 		fw.forgetUnit(unitd)
+		unitds = append(unitds, unitd)
 	}
-	if err := fw.flushMachine(machined); err != nil {
+	if fw.applicationFirewaller != nil {
+		// The units have been forgotten, so the machine no longer
+		// refers to their applications.
+		if err := fw.flushApplications(unitds); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err := fw.flushMachine(machined); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -1099,6 +1228,12 @@ type applicationData struct {
 	application *firewaller.Application
 	exposed     bool
 	unitds      map[names.UnitTag]*unitData
+
+	// ingressRules and machineIds hold the rules in the application's
+	// firewall, and the machines added to it, when the environment
+	// supports application firewalls.
+	ingressRules []network.IngressRule
+	machineIds   set.Strings
 }
 
 // watchLoop watches the application's exposed flag for changes.
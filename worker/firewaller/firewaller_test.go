@@ -6,11 +6,13 @@ package firewaller_test
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -29,6 +31,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -1296,7 +1299,10 @@ func (s *GlobalModeSuite) TearDownTest(c *gc.C) {
 func (s *GlobalModeSuite) newFirewaller(c *gc.C) worker.Worker {
 	fwEnv, ok := s.Environ.(environs.Firewaller)
 	c.Assert(ok, gc.Equals, true)
+	return s.newFirewallerWithEnviron(c, fwEnv)
+}
 
+func (s *GlobalModeSuite) newFirewallerWithEnviron(c *gc.C, fwEnv firewaller.EnvironFirewaller) worker.Worker {
 	cfg := firewaller.Config{
 		ModelUUID:          s.State.ModelUUID(),
 		Mode:               config.FwGlobal,
@@ -1534,6 +1540,161 @@ func (s *GlobalModeSuite) TestRestartPortCount(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+// applicationFirewallEnviron implements environs.ApplicationFirewaller
+// on top of the dummy environ's global firewall.
+type applicationFirewallEnviron struct {
+	environs.Firewaller
+
+	mu         sync.Mutex
+	rules      map[string]map[corenetwork.PortRange]set.Strings
+	machineIds map[string]set.Strings
+}
+
+func newApplicationFirewallEnviron(fwEnv environs.Firewaller) *applicationFirewallEnviron {
+	return &applicationFirewallEnviron{
+		Firewaller: fwEnv,
+		rules:      make(map[string]map[corenetwork.PortRange]set.Strings),
+		machineIds: make(map[string]set.Strings),
+	}
+}
+
+func (e *applicationFirewallEnviron) OpenApplicationPorts(ctx context.ProviderCallContext, applicationName string, machineIds []string, rules []network.IngressRule) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.machineIds[applicationName] == nil {
+		e.machineIds[applicationName] = set.NewStrings()
+		e.rules[applicationName] = make(map[corenetwork.PortRange]set.Strings)
+	}
+	e.machineIds[applicationName] = e.machineIds[applicationName].Union(set.NewStrings(machineIds...))
+	for _, rule := range rules {
+		cidrs := e.rules[applicationName][rule.PortRange]
+		e.rules[applicationName][rule.PortRange] = cidrs.Union(set.NewStrings(rule.SourceCIDRs...))
+	}
+	return nil
+}
+
+func (e *applicationFirewallEnviron) CloseApplicationPorts(ctx context.ProviderCallContext, applicationName string, rules []network.IngressRule) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, rule := range rules {
+		cidrs := e.rules[applicationName][rule.PortRange].Difference(set.NewStrings(rule.SourceCIDRs...))
+		if cidrs.IsEmpty() {
+			delete(e.rules[applicationName], rule.PortRange)
+		} else {
+			e.rules[applicationName][rule.PortRange] = cidrs
+		}
+	}
+	return nil
+}
+
+func (e *applicationFirewallEnviron) ApplicationIngressRules(ctx context.ProviderCallContext, applicationName string) ([]network.IngressRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var rules []network.IngressRule
+	for portRange, cidrs := range e.rules[applicationName] {
+		rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+func (e *applicationFirewallEnviron) applicationMachineIds(applicationName string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.machineIds[applicationName].SortedValues()
+}
+
+// assertApplicationPorts retrieves the open ports of the application's
+// firewall and compares them to the expected.
+func (s *GlobalModeSuite) assertApplicationPorts(c *gc.C, fwEnv *applicationFirewallEnviron, applicationName string, expected []network.IngressRule) {
+	start := time.Now()
+	for {
+		s.BackingState.StartSync()
+		got, err := fwEnv.ApplicationIngressRules(s.callCtx, applicationName)
+		c.Assert(err, jc.ErrorIsNil)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+func (s *GlobalModeSuite) TestApplicationFirewalls(c *gc.C) {
+	fwEnv, ok := s.Environ.(environs.Firewaller)
+	c.Assert(ok, gc.Equals, true)
+	appFwEnv := newApplicationFirewallEnviron(fwEnv)
+
+	// Ports opened for the whole environment before the application
+	// firewalls were used are closed.
+	err := fwEnv.OpenPorts(s.callCtx, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewallerWithEnviron(c, appFwEnv)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	err = app1.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u1, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+	err = u1.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2 := s.AddTestingApplication(c, "moinmoin", s.charm)
+	err = app2.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, m2 := s.addUnit(c, app2)
+	s.startInstance(c, m2)
+	err = u2.OpenPorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertApplicationPorts(c, appFwEnv, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+	s.assertApplicationPorts(c, appFwEnv, "moinmoin", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+	})
+	c.Assert(appFwEnv.applicationMachineIds("wordpress"), jc.DeepEquals, []string{m1.Id()})
+	c.Assert(appFwEnv.applicationMachineIds("moinmoin"), jc.DeepEquals, []string{m2.Id()})
+	s.assertEnvironPorts(c, nil)
+
+	// Closing a port opened by a unit of one application leaves the
+	// firewall of the other application alone.
+	err = u1.ClosePorts("tcp", 80, 90)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, appFwEnv, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+	s.assertApplicationPorts(c, appFwEnv, "moinmoin", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 90, "0.0.0.0/0"),
+	})
+
+	// Unexposing an application closes its ports.
+	err = app2.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertApplicationPorts(c, appFwEnv, "moinmoin", nil)
+	s.assertApplicationPorts(c, appFwEnv, "wordpress", []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "0.0.0.0/0"),
+	})
+}
+
 type NoneModeSuite struct {
 	firewallerBaseSuite
 }
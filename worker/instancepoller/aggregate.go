@@ -166,11 +166,31 @@ func (a *aggregator) instInfo(id instance.Id, inst instances.Instance) (instance
 		return instanceInfo{}, err
 	}
 	return instanceInfo{
-		addr,
-		inst.Status(a.callContext),
+		addresses:   addr,
+		status:      inst.Status(a.callContext),
+		utilization: a.instUtilization(inst),
 	}, nil
 }
 
+// instUtilization returns the resource utilization of the instance,
+// or nil if the provider does not report it, or is not configured to.
+// Utilization is only informational, so failing to get it is not an
+// error.
+func (a *aggregator) instUtilization(inst instances.Instance) *instance.Utilization {
+	utilizer, ok := inst.(instances.InstanceUtilizer)
+	if !ok {
+		return nil
+	}
+	utilization, err := utilizer.Utilization(a.callContext)
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		logger.Debugf("cannot get utilization of instance %q: %v", inst.Id(), err)
+		return nil
+	}
+	return &utilization
+}
+
 func (a *aggregator) Kill() {
 	a.catacomb.Kill(nil)
 }
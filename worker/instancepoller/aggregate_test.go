@@ -54,6 +54,18 @@ func (t *testInstance) Status(ctx context.ProviderCallContext) instance.Status {
 	return instance.Status{Status: status.Unknown, Message: t.status}
 }
 
+// testUtilizingInstance is a testInstance whose provider
+// reports its resource utilization.
+type testUtilizingInstance struct {
+	*testInstance
+	utilization instance.Utilization
+	err         error
+}
+
+func (t *testUtilizingInstance) Utilization(ctx context.ProviderCallContext) (instance.Utilization, error) {
+	return t.utilization, t.err
+}
+
 type testInstanceGetter struct {
 	sync.RWMutex
 	// ids is set when the Instances method is called.
@@ -203,6 +215,45 @@ func (s *aggregateSuite) TestMultipleResponseHandling(c *gc.C) {
 
 // Test that advancing delay-time.Nanosecond and then killing causes all
 // pending reqs to fail.
+func (s *aggregateSuite) TestUtilization(c *gc.C) {
+	testGetter := new(testInstanceGetter)
+	clock := testclock.NewClock(time.Now())
+	delay := time.Minute
+	cfg := aggregatorConfigForTest(clock, delay, testGetter)
+
+	cpu := 42.5
+	testGetter.newTestInstance("plain", "running", []string{"192.168.1.1"})
+	testGetter.results["utilizing"] = &testUtilizingInstance{
+		testInstance: testGetter.newTestInstance("utilizing", "running", []string{"192.168.1.2"}),
+		utilization:  instance.Utilization{CPUPercent: &cpu},
+	}
+	testGetter.results["failing"] = &testUtilizingInstance{
+		testInstance: testGetter.newTestInstance("failing", "running", []string{"192.168.1.3"}),
+		err:          errors.New("no monitoring for you"),
+	}
+
+	aggregator, err := newAggregator(cfg)
+	c.Check(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, aggregator)
+
+	var wg sync.WaitGroup
+	checkInfo := func(id instance.Id, expect *instance.Utilization) {
+		defer wg.Done()
+		info, err := aggregator.instanceInfo(id)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(info.utilization, jc.DeepEquals, expect)
+	}
+	wg.Add(3)
+	go checkInfo("plain", nil)
+	go checkInfo("utilizing", &instance.Utilization{CPUPercent: &cpu})
+	go checkInfo("failing", nil)
+
+	waitAlarms(c, clock, 3)
+	clock.Advance(delay)
+	wg.Wait()
+	workertest.CleanKill(c, aggregator)
+}
+
 func (s *aggregateSuite) TestKillingWorkerKillsPendinReqs(c *gc.C) {
 	// Setup local variables.
 	testGetter := new(testInstanceGetter)
@@ -67,6 +67,68 @@ func (s *machineSuite) TestSetsInstanceInfoInitially(c *gc.C) {
 	c.Assert(m.instStatusInfo, gc.Equals, "running")
 }
 
+func (s *machineSuite) TestSetsInstanceUtilization(c *gc.C) {
+	cpu := 12.5
+	utilization := instance.Utilization{CPUPercent: &cpu}
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			c.Check(id, gc.Equals, instance.Id("i1234"))
+			return instanceInfo{
+				addresses:   testAddrs,
+				status:      instance.Status{Status: status.Unknown, Message: "running"},
+				utilization: &utilization,
+			}, nil
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+	}
+	died := make(chan machine)
+
+	clk := newTestClock()
+	go runMachine(context, m, nil, died, clk)
+	c.Assert(clk.WaitAdvance(LongPoll, coretesting.ShortWait, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.utilization, jc.DeepEquals, &utilization)
+}
+
+func (s *machineSuite) TestSetInstanceUtilizationErrorIgnored(c *gc.C) {
+	cpu := 12.5
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			return instanceInfo{
+				addresses:   testAddrs,
+				status:      instance.Status{Status: status.Unknown, Message: "running"},
+				utilization: &instance.Utilization{CPUPercent: &cpu},
+			}, nil
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:               names.NewMachineTag("99"),
+		instanceId:        "i1234",
+		refresh:           func() error { return nil },
+		life:              params.Alive,
+		setUtilizationErr: stderrors.New("boom"),
+	}
+	died := make(chan machine)
+
+	clk := newTestClock()
+	go runMachine(context, m, nil, died, clk)
+	c.Assert(clk.WaitAdvance(LongPoll, coretesting.ShortWait, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	c.Assert(m.addresses, gc.DeepEquals, testAddrs)
+	c.Assert(m.utilization, gc.IsNil)
+}
+
 func (s *machineSuite) TestSetsInstanceInfoDeadMachineInitially(c *gc.C) {
 	context := &testMachineContext{
 		getInstanceInfo: instanceInfoGetter(c, "i1234", testAddrs, "deleting", nil),
@@ -126,7 +188,7 @@ func (s *machineSuite) TestNoPollWhenNotProvisioned(c *gc.C) {
 		case polled <- struct{}{}:
 		default:
 		}
-		return instanceInfo{testAddrs, instance.Status{Status: status.Unknown, Message: "pending"}}, nil
+		return instanceInfo{testAddrs, instance.Status{Status: status.Unknown, Message: "pending"}, nil}, nil
 	}
 	context := &testMachineContext{
 		getInstanceInfo: getInstanceInfo,
@@ -216,7 +278,7 @@ func testRunMachine(
 		if addrs == nil {
 			return instanceInfo{}, fmt.Errorf("no instance addresses available")
 		}
-		return instanceInfo{addrs, instance.Status{Status: status.Unknown, Message: instStatus}}, nil
+		return instanceInfo{addrs, instance.Status{Status: status.Unknown, Message: instStatus}, nil}, nil
 	}
 	context := &testMachineContext{
 		getInstanceInfo: getInstanceInfo,
@@ -363,7 +425,7 @@ func instanceInfoGetter(
 
 	return func(id instance.Id) (instanceInfo, error) {
 		c.Check(id, gc.Equals, expectId)
-		return instanceInfo{addrs, instance.Status{Status: status.Unknown, Message: instanceStatus}}, err
+		return instanceInfo{addrs, instance.Status{Status: status.Unknown, Message: instanceStatus}, nil}, err
 	}
 }
 
@@ -393,19 +455,21 @@ func (context *testMachineContext) errDying() error {
 }
 
 type testMachine struct {
-	instanceId      instance.Id
-	instanceIdErr   error
-	tag             names.MachineTag
-	instStatus      status.Status
-	instStatusInfo  string
-	status          status.Status
-	refresh         func() error
-	setAddressesErr error
+	instanceId        instance.Id
+	instanceIdErr     error
+	tag               names.MachineTag
+	instStatus        status.Status
+	instStatusInfo    string
+	status            status.Status
+	refresh           func() error
+	setAddressesErr   error
+	setUtilizationErr error
 	// mu protects the following fields.
 	mu              sync.Mutex
 	life            params.Life
 	addresses       []network.Address
 	setAddressCount int
+	utilization     *instance.Utilization
 }
 
 func (m *testMachine) Tag() names.MachineTag {
@@ -485,6 +549,16 @@ func (m *testMachine) SetProviderAddresses(addrs ...network.Address) error {
 	return nil
 }
 
+func (m *testMachine) SetInstanceUtilization(utilization instance.Utilization) error {
+	if m.setUtilizationErr != nil {
+		return m.setUtilizationErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.utilization = &utilization
+	return nil
+}
+
 func (m *testMachine) String() string {
 	return m.tag.Id()
 }
@@ -41,6 +41,7 @@ type machine interface {
 	SetProviderAddresses(...network.Address) error
 	InstanceStatus() (params.StatusResult, error)
 	SetInstanceStatus(status.Status, string, map[string]interface{}) error
+	SetInstanceUtilization(instance.Utilization) error
 	String() string
 	Refresh() error
 	Life() params.Life
@@ -51,6 +52,10 @@ type machine interface {
 type instanceInfo struct {
 	addresses []network.Address
 	status    instance.Status
+
+	// utilization holds the resource utilization of the instance,
+	// or nil if the provider does not report it.
+	utilization *instance.Utilization
 }
 
 // lifetimeContext was extracted to allow the various context clients to get
@@ -298,6 +303,14 @@ func pollInstanceInfo(context machineContext, m machine) (instInfo instanceInfo,
 				return instanceInfo{}, err
 			}
 		}
+		// Utilization is only informational, so failing to record
+		// it doesn't stop the machine's addresses and status being
+		// polled.
+		if instInfo.utilization != nil {
+			if err := m.SetInstanceUtilization(*instInfo.utilization); err != nil {
+				logger.Warningf("cannot set instance utilization on %q: %v", m, err)
+			}
+		}
 	}
 	return instInfo, nil
 }
@@ -3,6 +3,7 @@
 package machiner
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/network"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/fanconfigurer"
 )
 
 var logger = loggo.GetLogger("juju.worker.machiner")
@@ -32,6 +34,20 @@ type Config struct {
 	// ClearMachineAddressesOnStart indicates whether or not to clear
 	// the machine's machine addresses when the worker starts.
 	ClearMachineAddressesOnStart bool
+
+	// FanStatus, if non-nil, provides the FAN configuration applied
+	// to the machine and the errors hit applying it, which the
+	// machiner reports along with the state of the FAN bridges.
+	FanStatus FanStatus
+}
+
+// FanStatus provides the FAN configuration applied to the machine
+// and the most recent errors hit applying it, and notifies when
+// either changes.
+type FanStatus interface {
+	Config() network.FanConfig
+	Errors() []fanconfigurer.Error
+	Watch() (watcher.NotifyWatcher, error)
 }
 
 // Validate reports whether or not the configuration is valid.
@@ -101,7 +117,22 @@ func (mr *Machiner) SetUp() (watcher.NotifyWatcher, error) {
 	}
 	logger.Infof("%q started", mr.config.Tag)
 
-	return m.Watch()
+	machineWatcher, err := m.Watch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if mr.config.FanStatus == nil {
+		return machineWatcher, nil
+	}
+	// The network status is reported again whenever the FAN status
+	// changes, so that errors configuring the FAN are reported as
+	// soon as they are hit.
+	fanWatcher, err := mr.config.FanStatus.Watch()
+	if err != nil {
+		_ = worker.Stop(machineWatcher)
+		return nil, errors.Trace(err)
+	}
+	return newMergedWatcher(machineWatcher, fanWatcher)
 }
 
 var interfaceAddrs = net.InterfaceAddrs
@@ -167,6 +198,14 @@ func (mr *Machiner) Handle(_ <-chan struct{}) error {
 		}
 		logger.Debugf("observed network config updated for %q to %+v", mr.config.Tag, observedConfig)
 
+		if mr.config.FanStatus != nil {
+			networkStatus := fanNetworkStatus(mr.config.FanStatus, observedConfig)
+			if err := mr.machine.SetNetworkStatus(networkStatus); errors.IsNotImplemented(err) {
+				logger.Debugf("not updating network status: %v", err)
+			} else if err != nil {
+				return errors.Annotate(err, "cannot update network status")
+			}
+		}
 		return nil
 	}
 	logger.Debugf("%q is now %s", mr.config.Tag, life)
@@ -196,6 +235,52 @@ func (mr *Machiner) Handle(_ <-chan struct{}) error {
 	return jworker.ErrTerminateAgent
 }
 
+// fanNetworkStatus returns the network status to report for the
+// machine, given the FAN status and the machine's observed network
+// config. Bridges are named as fanatic names them: "fan-" followed
+// by the first octet of the overlay.
+func fanNetworkStatus(fanStatus FanStatus, observedConfig []params.NetworkConfig) params.MachineNetworkStatus {
+	var result params.MachineNetworkStatus
+	for _, fan := range fanStatus.Config() {
+		overlayIP := fan.Overlay.IP.To4()
+		if overlayIP == nil {
+			// The FAN only supports IPv4, so there is no bridge.
+			continue
+		}
+		bridge := params.FanBridgeStatus{
+			Name:     fmt.Sprintf("fan-%d", overlayIP[0]),
+			Underlay: fan.Underlay.String(),
+			Overlay:  fan.Overlay.String(),
+		}
+		for _, config := range observedConfig {
+			if config.InterfaceName == bridge.Name {
+				bridge.Present = true
+				bridge.Up = !config.Disabled
+				continue
+			}
+			if config.CIDR == "" || bridge.LocalOverlay != "" {
+				continue
+			}
+			segment, err := network.CalculateOverlaySegment(config.CIDR, fan)
+			if err != nil {
+				logger.Debugf("cannot map %q onto FAN overlay %s: %v", config.CIDR, fan.Overlay, err)
+				continue
+			}
+			if segment != nil {
+				bridge.LocalOverlay = segment.String()
+			}
+		}
+		result.FanBridges = append(result.FanBridges, bridge)
+	}
+	for _, fanErr := range fanStatus.Errors() {
+		result.FanErrors = append(result.FanErrors, params.FanConfigError{
+			Time:    fanErr.Time,
+			Message: fanErr.Message,
+		})
+	}
+	return result
+}
+
 func (mr *Machiner) TearDown() error {
 	// Nothing to do here.
 	return nil
@@ -8,6 +8,7 @@ import (
 	"net"
 	"path/filepath"
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/machiner"
 )
 
@@ -432,6 +434,98 @@ func (s *MachinerSuite) TestAliveErrorGetObservedNetworkConfig(c *gc.C) {
 	)
 }
 
+func (s *MachinerSuite) TestSetNetworkStatus(c *gc.C) {
+	s.PatchValue(machiner.GetObservedNetworkConfig, func(common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return []params.NetworkConfig{{
+			InterfaceName: "eth0",
+			CIDR:          "10.0.5.0/24",
+		}, {
+			InterfaceName: "fan-252",
+			CIDR:          "252.0.0.0/8",
+		}}, nil
+	})
+	config, err := network.ParseFanConfig("10.0.0.0/16=252.0.0.0/8 192.168.0.0/16=253.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	errTime := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	fanStatus := &mockFanStatus{
+		config: config,
+		errors: []fanconfigurer.Error{{Time: errTime, Message: "fanatic: exit status 1"}},
+	}
+
+	mr := s.makeMachinerWithFanStatus(c, fanStatus)
+	s.accessor.machine.watcher.changes <- struct{}{}
+	waitForCall(c, &s.accessor.machine.Stub, "SetNetworkStatus")
+	c.Assert(stopWorker(mr), jc.ErrorIsNil)
+
+	s.accessor.machine.CheckCallNames(c,
+		"SetMachineAddresses",
+		"SetStatus",
+		"Watch",
+		"Refresh",
+		"Life",
+		"SetObservedNetworkConfig",
+		"SetNetworkStatus",
+	)
+	s.accessor.machine.CheckCall(c, 6, "SetNetworkStatus", params.MachineNetworkStatus{
+		FanBridges: []params.FanBridgeStatus{{
+			Name:         "fan-252",
+			Underlay:     "10.0.0.0/16",
+			Overlay:      "252.0.0.0/8",
+			LocalOverlay: "252.5.0.0/16",
+			Present:      true,
+			Up:           true,
+		}, {
+			Name:     "fan-253",
+			Underlay: "192.168.0.0/16",
+			Overlay:  "253.0.0.0/8",
+		}},
+		FanErrors: []params.FanConfigError{{Time: errTime, Message: "fanatic: exit status 1"}},
+	})
+}
+
+func (s *MachinerSuite) TestSetNetworkStatusNotImplemented(c *gc.C) {
+	s.accessor.machine.SetErrors(
+		nil, // SetMachineAddresses
+		nil, // SetStatus
+		nil, // Watch
+		nil, // Refresh
+		errors.NotImplementedf("SetNetworkStatus() (need V2+)"),
+	)
+
+	mr := s.makeMachinerWithFanStatus(c, &mockFanStatus{})
+	s.accessor.machine.watcher.changes <- struct{}{}
+	waitForCall(c, &s.accessor.machine.Stub, "SetNetworkStatus")
+	c.Assert(stopWorker(mr), jc.ErrorIsNil)
+
+	s.accessor.machine.CheckCallNames(c,
+		"SetMachineAddresses",
+		"SetStatus",
+		"Watch",
+		"Refresh",
+		"Life",
+		"SetNetworkStatus",
+	)
+}
+
+func (s *MachinerSuite) TestSetNetworkStatusOnFanStatusChange(c *gc.C) {
+	fanStatus := &mockFanStatus{}
+	fanStatus.watcher.changes = make(chan struct{})
+
+	mr := s.makeMachinerWithFanStatus(c, fanStatus)
+	fanStatus.watcher.changes <- struct{}{}
+	waitForCall(c, &s.accessor.machine.Stub, "SetNetworkStatus")
+	c.Assert(stopWorker(mr), jc.ErrorIsNil)
+
+	s.accessor.machine.CheckCallNames(c,
+		"SetMachineAddresses",
+		"SetStatus",
+		"Watch",
+		"Refresh",
+		"Life",
+		"SetNetworkStatus",
+	)
+}
+
 func (s *MachinerSuite) makeMachiner(
 	c *gc.C,
 	ignoreAddresses bool,
@@ -445,6 +539,34 @@ func (s *MachinerSuite) makeMachiner(
 	return w
 }
 
+func (s *MachinerSuite) makeMachinerWithFanStatus(c *gc.C, fanStatus machiner.FanStatus) worker.Worker {
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor: s.accessor,
+		Tag:             s.machineTag,
+		FanStatus:       fanStatus,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// waitForCall waits until the stub has recorded a call to the named
+// function.
+func waitForCall(c *gc.C, stub *gitjujutesting.Stub, funcName string) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		for _, call := range stub.Calls() {
+			if call.FuncName == funcName {
+				return
+			}
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("timed out waiting for call to %s", funcName)
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+}
+
 func stopWorker(w worker.Worker) error {
 	w.Kill()
 	return w.Wait()
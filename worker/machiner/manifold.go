@@ -13,6 +13,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
 	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/worker/fanconfigurer"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -48,7 +49,11 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if !fanConfigurerReady {
 				return nil, dependency.ErrMissing
 			}
-			return newWorker(agent, apiCaller)
+			var fanStatus *fanconfigurer.Status
+			if err := context.Get(config.FanConfigurerName, &fanStatus); err != nil {
+				return nil, err
+			}
+			return newWorker(agent, apiCaller, fanStatus)
 		},
 	}
 }
@@ -58,7 +63,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 // TODO(waigani) This function is currently covered by functional tests
 // under the machine agent. Add unit tests once infrastructure to do so is
 // in place.
-func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+func newWorker(a agent.Agent, apiCaller base.APICaller, fanStatus *fanconfigurer.Status) (worker.Worker, error) {
 	currentConfig := a.CurrentConfig()
 
 	// TODO(fwereade): this functionality should be on the
@@ -90,6 +95,7 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 		MachineAccessor:              accessor,
 		Tag:                          tag.(names.MachineTag),
 		ClearMachineAddressesOnStart: ignoreMachineAddresses,
+		FanStatus:                    fanStatus,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start machiner worker")
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/machiner"
 )
 
@@ -60,6 +61,11 @@ func (m *mockMachine) SetObservedNetworkConfig(netConfig []params.NetworkConfig)
 	return m.NextErr()
 }
 
+func (m *mockMachine) SetNetworkStatus(status params.MachineNetworkStatus) error {
+	m.MethodCall(m, "SetNetworkStatus", status)
+	return m.NextErr()
+}
+
 func (m *mockMachine) SetStatus(status status.Status, info string, data map[string]interface{}) error {
 	m.MethodCall(m, "SetStatus", status, info, data)
 	return m.NextErr()
@@ -85,3 +91,21 @@ func (a *mockMachineAccessor) Machine(tag names.MachineTag) (machiner.Machine, e
 	}
 	return &a.machine, nil
 }
+
+type mockFanStatus struct {
+	config  network.FanConfig
+	errors  []fanconfigurer.Error
+	watcher mockWatcher
+}
+
+func (s *mockFanStatus) Config() network.FanConfig {
+	return s.config
+}
+
+func (s *mockFanStatus) Errors() []fanconfigurer.Error {
+	return s.errors
+}
+
+func (s *mockFanStatus) Watch() (watcher.NotifyWatcher, error) {
+	return &s.watcher, nil
+}
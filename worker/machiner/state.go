@@ -25,6 +25,7 @@ type Machine interface {
 	SetStatus(machineStatus status.Status, info string, data map[string]interface{}) error
 	Watch() (watcher.NotifyWatcher, error)
 	SetObservedNetworkConfig(netConfig []params.NetworkConfig) error
+	SetNetworkStatus(status params.MachineNetworkStatus) error
 }
 
 type APIMachineAccessor struct {
@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machiner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// mergedWatcher is a NotifyWatcher that notifies when either the
+// machine or the FAN status changes. Unlike a MultiNotifyWatcher, it
+// fails when either of the watchers it merges fails.
+type mergedWatcher struct {
+	catacomb       catacomb.Catacomb
+	machineWatcher watcher.NotifyWatcher
+	fanWatcher     watcher.NotifyWatcher
+	changes        chan struct{}
+}
+
+func newMergedWatcher(machineWatcher, fanWatcher watcher.NotifyWatcher) (*mergedWatcher, error) {
+	w := &mergedWatcher{
+		machineWatcher: machineWatcher,
+		fanWatcher:     fanWatcher,
+		changes:        make(chan struct{}),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{machineWatcher, fanWatcher},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (w *mergedWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *mergedWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *mergedWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *mergedWatcher) loop() error {
+	var out chan struct{}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.machineWatcher.Changes():
+			if !ok {
+				return errors.New("machine watcher closed")
+			}
+			out = w.changes
+		case _, ok := <-w.fanWatcher.Changes():
+			if !ok {
+				return errors.New("FAN status watcher closed")
+			}
+			out = w.changes
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
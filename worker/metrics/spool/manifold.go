@@ -65,10 +65,11 @@ func (f *factory) Reader() (MetricReader, error) {
 // Recorder implements the MetricFactory interface.
 func (f *factory) Recorder(declaredMetrics map[string]corecharm.Metric, charmURL, unitTag string) (MetricRecorder, error) {
 	return NewJSONMetricRecorder(MetricRecorderConfig{
-		SpoolDir: f.spoolDir,
-		Metrics:  declaredMetrics,
-		CharmURL: charmURL,
-		UnitTag:  unitTag,
+		SpoolDir:   f.spoolDir,
+		Metrics:    declaredMetrics,
+		CharmURL:   charmURL,
+		UnitTag:    unitTag,
+		MaxBatches: DefaultMaxBatches,
 	})
 }
 
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -25,6 +26,12 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter.metrics")
 
+// DefaultMaxBatches is the default number of metric batches kept in
+// the spool directory while they cannot be sent to the controller:
+// a week's worth of batches collected every five minutes. When the
+// limit is reached, the oldest batches are removed.
+const DefaultMaxBatches = 7 * 24 * 12
+
 type errMetricsData struct {
 	error
 }
@@ -135,6 +142,7 @@ type JSONMetricRecorder struct {
 	uuid         utils.UUID
 	created      time.Time
 	unitTag      string
+	maxBatches   int
 
 	lock sync.Mutex
 
@@ -148,6 +156,11 @@ type MetricRecorderConfig struct {
 	Metrics  map[string]corecharm.Metric
 	CharmURL string
 	UnitTag  string
+
+	// MaxBatches, if non-zero, is the number of metric batches
+	// kept in the spool directory. The oldest batches are removed
+	// when the recorder is closed to keep within the limit.
+	MaxBatches int
 }
 
 // NewJSONMetricRecorder creates a new JSON metrics recorder.
@@ -165,6 +178,7 @@ func NewJSONMetricRecorder(config MetricRecorderConfig) (rec *JSONMetricRecorder
 		created:      time.Now().UTC(),
 		validMetrics: config.Metrics,
 		unitTag:      config.UnitTag,
+		maxBatches:   config.MaxBatches,
 	}
 	if err := recorder.open(); err != nil {
 		return nil, errors.Trace(err)
@@ -192,6 +206,43 @@ func (m *JSONMetricRecorder) Close() error {
 		return errors.Trace(err)
 	}
 
+	if m.maxBatches > 0 {
+		if err := pruneSpoolDir(m.spoolDir, m.maxBatches); err != nil {
+			logger.Errorf("failed to prune spool directory: %v", err)
+		}
+	}
+	return nil
+}
+
+// pruneSpoolDir removes the oldest metric batches from the
+// spool directory, leaving at most maxBatches.
+func pruneSpoolDir(spoolDir string, maxBatches int) error {
+	metaFiles, err := filepath.Glob(filepath.Join(spoolDir, "*.meta"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(metaFiles) <= maxBatches {
+		return nil
+	}
+	batches := make([]MetricBatch, 0, len(metaFiles))
+	for _, metaFile := range metaFiles {
+		batch, err := decodeBatch(metaFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		batches = append(batches, batch)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].Created.Before(batches[j].Created)
+	})
+	reader := &JSONMetricReader{dir: spoolDir}
+	excess := batches[:len(batches)-maxBatches]
+	logger.Warningf("metric spool full, removing %d oldest unsent batch(es)", len(excess))
+	for _, batch := range excess {
+		if err := reader.Remove(batch.UUID); err != nil {
+			return errors.Annotatef(err, "removing batch %q", batch.UUID)
+		}
+	}
 	return nil
 }
 
@@ -157,6 +157,38 @@ func (s *metricsRecorderSuite) TestInit(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *metricsRecorderSuite) TestMaxBatches(c *gc.C) {
+	for _, value := range []string{"1", "2", "3"} {
+		w, err := spool.NewJSONMetricRecorder(
+			spool.MetricRecorderConfig{
+				SpoolDir:   s.paths.GetMetricsSpoolDir(),
+				Metrics:    map[string]corecharm.Metric{"pings": {}},
+				CharmURL:   "local:precise/wordpress",
+				UnitTag:    s.unitTag,
+				MaxBatches: 2,
+			})
+		c.Assert(err, jc.ErrorIsNil)
+		err = w.AddMetric("pings", value, time.Now(), nil)
+		c.Assert(err, jc.ErrorIsNil)
+		err = w.Close()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	r, err := spool.NewJSONMetricReader(s.paths.GetMetricsSpoolDir())
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	batches, err := r.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 2)
+	var values []string
+	for _, batch := range batches {
+		c.Assert(batch.Metrics, gc.HasLen, 1)
+		values = append(values, batch.Metrics[0].Value)
+	}
+	// The oldest batch was removed.
+	c.Assert(values, jc.SameContents, []string{"2", "3"})
+}
+
 func (s *metricsRecorderSuite) TestMetricValidation(c *gc.C) {
 	tests := []struct {
 		about         string
@@ -4,6 +4,9 @@
 package modelcache
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
@@ -17,6 +20,7 @@ import (
 type Logger interface {
 	IsTraceEnabled() bool
 	Tracef(string, ...interface{})
+	Infof(string, ...interface{})
 	Errorf(string, ...interface{})
 }
 
@@ -28,6 +32,13 @@ type ManifoldConfig struct {
 
 	PrometheusRegisterer prometheus.Registerer
 
+	// SnapshotPath and SnapshotInterval configure the optional
+	// cache snapshot. See Config for details.
+	SnapshotPath     string
+	SnapshotInterval time.Duration
+
+	Clock clock.Clock
+
 	NewWorker func(Config) (worker.Worker, error)
 }
 
@@ -45,6 +56,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.NewWorker == nil {
 		return errors.NotValidf("missing NewWorker func")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
 	return nil
 }
 
@@ -82,6 +96,9 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		WatcherFactory:       func() BackingWatcher { return pool.SystemState().WatchAllModels(pool) },
 		PrometheusRegisterer: config.PrometheusRegisterer,
 		Cleanup:              func() { _ = stTracker.Done() },
+		SnapshotPath:         config.SnapshotPath,
+		SnapshotInterval:     config.SnapshotInterval,
+		Clock:                config.Clock,
 	})
 	if err != nil {
 		_ = stTracker.Done()
@@ -6,6 +6,7 @@ package modelcache_test
 import (
 	"unsafe"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -33,6 +34,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		StateName:            "state",
 		Logger:               loggo.GetLogger("test"),
 		PrometheusRegisterer: noopRegisterer{},
+		Clock:                clock.WallClock,
 		NewWorker: func(modelcache.Config) (worker.Worker, error) {
 			return nil, errors.New("boom")
 		},
@@ -80,6 +82,13 @@ func (s *ManifoldSuite) TestConfigValidationMissingNewWorker(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "missing NewWorker func not valid")
 }
 
+func (s *ManifoldSuite) TestConfigValidationMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	err := s.config.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "missing Clock not valid")
+}
+
 func (s *ManifoldSuite) TestManifoldCallsValidate(c *gc.C) {
 	context := dt.StubContext(nil, map[string]interface{}{})
 	s.config.Logger = nil
@@ -119,6 +128,7 @@ func (s *ManifoldSuite) TestNewWorkerArgs(c *gc.C) {
 	c.Check(config.WatcherFactory, gc.NotNil)
 	c.Check(config.Logger, gc.Equals, s.config.Logger)
 	c.Check(config.PrometheusRegisterer, gc.Equals, s.config.PrometheusRegisterer)
+	c.Check(config.Clock, gc.Equals, s.config.Clock)
 
 	c.Check(tracker.released, jc.IsFalse)
 	config.Cleanup()
@@ -4,9 +4,14 @@
 package modelcache
 
 import (
+	"bytes"
+	"os"
 	"sync"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/utils"
 	"github.com/kr/pretty"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
@@ -45,6 +50,20 @@ type Config struct {
 	// by a watcher that stops in an error state.
 	// Watcher acquisition my occur multiple times during a worker life-cycle.
 	WatcherFactory func() BackingWatcher
+
+	// SnapshotPath, if set, is the file to which a snapshot of the cache
+	// is periodically written. When the worker starts, the cache is
+	// warmed from any snapshot found there, so that it can be used
+	// before the all model watcher has finished loading the database.
+	SnapshotPath string
+
+	// SnapshotInterval is the period between cache snapshots.
+	// It is required if SnapshotPath is set.
+	SnapshotInterval time.Duration
+
+	// Clock is used to schedule cache snapshots, and to measure
+	// the time taken to apply changes to the cache.
+	Clock clock.Clock
 }
 
 // Validate ensures all the necessary values are specified
@@ -61,6 +80,12 @@ func (c *Config) Validate() error {
 	if c.Cleanup == nil {
 		return errors.NotValidf("missing cleanup func")
 	}
+	if c.Clock == nil {
+		return errors.NotValidf("missing clock")
+	}
+	if c.SnapshotPath != "" && c.SnapshotInterval <= 0 {
+		return errors.NotValidf("non-positive snapshot interval")
+	}
 	return nil
 }
 
@@ -85,8 +110,9 @@ func NewWorker(config Config) (worker.Worker, error) {
 	}
 	controller, err := cache.NewController(
 		cache.ControllerConfig{
-			Changes: w.changes,
-			Notify:  config.Notify,
+			Changes:  w.changes,
+			Notify:   config.Notify,
+			Snapshot: w.readSnapshot(),
 		})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -122,7 +148,7 @@ func (c *cacheWorker) loop() error {
 	defer c.config.PrometheusRegisterer.Unregister(allWatcherStarts)
 	defer c.config.PrometheusRegisterer.Unregister(collector)
 
-	watcherChanges := make(chan []multiwatcher.Delta)
+	watcherChanges := make(chan deltaBatch)
 	// This worker needs to be robust with respect to the multiwatcher errors.
 	// If we get an unexpected error we should get a new allWatcher.
 	// We don't want a weird error in the multiwatcher taking down the apiserver,
@@ -188,14 +214,25 @@ func (c *cacheWorker) loop() error {
 		}
 	}()
 
+	var snapshotTimer <-chan time.Time
+	if c.config.SnapshotPath != "" {
+		snapshotTimer = c.config.Clock.After(c.config.SnapshotInterval)
+		// Write a final snapshot so that the next worker
+		// starts with the most recent view of the model.
+		defer c.writeSnapshot()
+	}
+
 	for {
 		select {
 		case <-c.catacomb.Dying():
 			return c.catacomb.ErrDying()
-		case deltas := <-watcherChanges:
+		case <-snapshotTimer:
+			c.writeSnapshot()
+			snapshotTimer = c.config.Clock.After(c.config.SnapshotInterval)
+		case batch := <-watcherChanges:
 			// Translate multi-watcher deltas into cache changes
 			// and supply them via the changes channel.
-			for _, d := range deltas {
+			for _, d := range batch.deltas {
 				if logger := c.config.Logger; logger.IsTraceEnabled() {
 					logger.Tracef(pretty.Sprint(d))
 				}
@@ -209,13 +246,61 @@ func (c *cacheWorker) loop() error {
 				}
 			}
 
+			c.controller.ObserveChangeLag(c.config.Clock.Now().Sub(batch.received))
+
 			// Evict any stale residents.
 			c.controller.Sweep()
 		}
 	}
 }
 
-func (c *cacheWorker) processWatcher(watcherChanges chan<- []multiwatcher.Delta) error {
+// readSnapshot returns the cache snapshot written by a previous worker,
+// or nil if snapshots are not configured or none can be read.
+// A snapshot that cannot be read is not fatal, as the cache is
+// populated from the database regardless.
+func (c *cacheWorker) readSnapshot() *cache.Snapshot {
+	if c.config.SnapshotPath == "" {
+		return nil
+	}
+	f, err := os.Open(c.config.SnapshotPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.config.Logger.Errorf("cannot open cache snapshot: %v", err)
+		}
+		return nil
+	}
+	defer f.Close()
+
+	snapshot, err := cache.ReadSnapshot(f)
+	if err != nil {
+		c.config.Logger.Errorf("cannot read cache snapshot: %v", err)
+		return nil
+	}
+	c.config.Logger.Infof("warming model cache from snapshot with %d models", len(snapshot.Models))
+	return &snapshot
+}
+
+// writeSnapshot writes the current contents of the cache to the
+// configured snapshot path. Failure is logged, but not fatal.
+func (c *cacheWorker) writeSnapshot() {
+	var buf bytes.Buffer
+	if err := cache.WriteSnapshot(&buf, c.controller.Snapshot()); err != nil {
+		c.config.Logger.Errorf("cannot encode cache snapshot: %v", err)
+		return
+	}
+	if err := utils.AtomicWriteFile(c.config.SnapshotPath, buf.Bytes(), 0600); err != nil {
+		c.config.Logger.Errorf("cannot write cache snapshot: %v", err)
+	}
+}
+
+// deltaBatch is a set of deltas returned by the all model
+// watcher, along with the time at which they were received.
+type deltaBatch struct {
+	deltas   []multiwatcher.Delta
+	received time.Time
+}
+
+func (c *cacheWorker) processWatcher(watcherChanges chan<- deltaBatch) error {
 	for {
 		deltas, err := c.watcher.Next()
 		if err != nil {
@@ -225,7 +310,7 @@ func (c *cacheWorker) processWatcher(watcherChanges chan<- []multiwatcher.Delta)
 		select {
 		case <-c.catacomb.Dying():
 			return nil
-		case watcherChanges <- deltas:
+		case watcherChanges <- deltaBatch{deltas: deltas, received: c.config.Clock.Now()}:
 		}
 	}
 }
@@ -243,6 +328,10 @@ func (c *cacheWorker) translate(d multiwatcher.Delta) interface{} {
 		return c.translateUnit(d)
 	case "charm":
 		return c.translateCharm(d)
+	case "relation":
+		return c.translateRelation(d)
+	case "applicationOffer":
+		return c.translateOffer(d)
 	case "generation":
 		// Generation deltas are processed as cache branch changes,
 		// as only "in-flight" branches should ever be in the cache.
@@ -343,6 +432,7 @@ func (c *cacheWorker) translateMachine(d multiwatcher.Delta) interface{} {
 		Addresses:                coreNetworkAddresses(value.Addresses),
 		HasVote:                  value.HasVote,
 		WantsVote:                value.WantsVote,
+		Labels:                   value.Labels,
 	}
 }
 
@@ -379,6 +469,7 @@ func (c *cacheWorker) translateUnit(d multiwatcher.Delta) interface{} {
 		Subordinate:    value.Subordinate,
 		WorkloadStatus: coreStatus(value.WorkloadStatus),
 		AgentStatus:    coreStatus(value.AgentStatus),
+		Labels:         value.Labels,
 	}
 }
 
@@ -407,6 +498,72 @@ func (c *cacheWorker) translateCharm(d multiwatcher.Delta) interface{} {
 	}
 }
 
+func (c *cacheWorker) translateRelation(d multiwatcher.Delta) interface{} {
+	e := d.Entity
+	id := e.EntityId()
+
+	if d.Removed {
+		return cache.RemoveRelation{
+			ModelUUID: id.ModelUUID,
+			Key:       id.Id,
+		}
+	}
+
+	value, ok := e.(*multiwatcher.RelationInfo)
+	if !ok {
+		c.config.Logger.Errorf("unexpected type %T", e)
+		return nil
+	}
+
+	endpoints := make([]cache.RelationEndpoint, len(value.Endpoints))
+	for i, ep := range value.Endpoints {
+		endpoints[i] = cache.RelationEndpoint{
+			Application: ep.ApplicationName,
+			Name:        ep.Relation.Name,
+			Role:        ep.Relation.Role,
+			Interface:   ep.Relation.Interface,
+			Optional:    ep.Relation.Optional,
+			Limit:       ep.Relation.Limit,
+			Scope:       ep.Relation.Scope,
+		}
+	}
+
+	return cache.RelationChange{
+		ModelUUID: value.ModelUUID,
+		Key:       value.Key,
+		Id:        value.Id,
+		Endpoints: endpoints,
+	}
+}
+
+func (c *cacheWorker) translateOffer(d multiwatcher.Delta) interface{} {
+	e := d.Entity
+	id := e.EntityId()
+
+	if d.Removed {
+		return cache.RemoveOffer{
+			ModelUUID: id.ModelUUID,
+			OfferName: id.Id,
+		}
+	}
+
+	value, ok := e.(*multiwatcher.ApplicationOfferInfo)
+	if !ok {
+		c.config.Logger.Errorf("unexpected type %T", e)
+		return nil
+	}
+
+	return cache.OfferChange{
+		ModelUUID:            value.ModelUUID,
+		OfferUUID:            value.OfferUUID,
+		OfferName:            value.OfferName,
+		ApplicationName:      value.ApplicationName,
+		CharmName:            value.CharmName,
+		TotalConnectedCount:  value.TotalConnectedCount,
+		ActiveConnectedCount: value.ActiveConnectedCount,
+	}
+}
+
 func (c *cacheWorker) translateBranch(d multiwatcher.Delta) interface{} {
 	e := d.Entity
 	id := e.EntityId()
@@ -4,8 +4,14 @@
 package modelcache_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -47,6 +53,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		},
 		PrometheusRegisterer: noopRegisterer{},
 		Cleanup:              func() {},
+		Clock:                clock.WallClock,
 	}
 }
 
@@ -443,6 +450,61 @@ func (s *WorkerSuite) TestRemoveUnit(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestAddRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	relation := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.RelationChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained.Key, gc.Equals, relation.String())
+	c.Check(obtained.Id, gc.Equals, relation.Id())
+	c.Check(obtained.Endpoints, gc.HasLen, 2)
+
+	controller := s.getController(c, w)
+	modUUIDs := controller.ModelUUIDs()
+	c.Check(modUUIDs, gc.HasLen, 1)
+
+	mod, err := controller.Model(modUUIDs[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	cachedRelation, err := mod.Relation(relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cachedRelation.Id(), gc.Equals, relation.Id())
+}
+
+func (s *WorkerSuite) TestRemoveRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	relation := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+	_ = s.nextChange(c, changes)
+
+	controller := s.getController(c, w)
+	modUUID := controller.ModelUUIDs()[0]
+
+	c.Assert(relation.Destroy(), jc.ErrorIsNil)
+	s.State.StartSync()
+
+	// We will either get our relation event,
+	// or time-out after processing all the changes.
+	for {
+		change := s.nextChange(c, changes)
+		if _, ok := change.(cache.RemoveRelation); ok {
+			mod, err := controller.Model(modUUID)
+			c.Assert(err, jc.ErrorIsNil)
+
+			_, err = mod.Relation(relation.String())
+			c.Check(errors.IsNotFound(err), jc.IsTrue)
+			return
+		}
+	}
+}
+
 func (s *WorkerSuite) TestAddBranch(c *gc.C) {
 	changes := s.captureEvents(c, cachetest.BranchEvents)
 	w := s.start(c)
@@ -595,6 +657,70 @@ func (s *WorkerSuite) TestWatcherErrorStoppedKillsWorker(c *gc.C) {
 	c.Assert(err, jc.Satisfies, state.IsErrStopped)
 }
 
+func (s *WorkerSuite) TestConfigMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	err := s.config.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "missing clock not valid")
+}
+
+func (s *WorkerSuite) TestConfigSnapshotInvalidInterval(c *gc.C) {
+	s.config.SnapshotPath = filepath.Join(c.MkDir(), "model-cache.snapshot")
+	s.config.Clock = testclock.NewClock(time.Now())
+	err := s.config.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "non-positive snapshot interval not valid")
+}
+
+func (s *WorkerSuite) TestSnapshotWrittenOnStopAndRestored(c *gc.C) {
+	s.config.SnapshotPath = filepath.Join(c.MkDir(), "model-cache.snapshot")
+	s.config.SnapshotInterval = time.Hour
+	s.config.Clock = testclock.NewClock(time.Now())
+
+	changes := s.captureEvents(c, cachetest.ModelEvents)
+	w := s.start(c)
+	s.nextChange(c, changes)
+	workertest.CleanKill(c, w)
+
+	f, err := os.Open(s.config.SnapshotPath)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := cache.ReadSnapshot(f)
+	_ = f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Models, gc.HasLen, 1)
+	c.Check(snapshot.Models[0].Model.ModelUUID, gc.Equals, s.State.ModelUUID())
+
+	// The next worker is warmed from the snapshot
+	// before its watcher supplies any deltas.
+	s.notify = nil
+	s.config.WatcherFactory = func() modelcache.BackingWatcher {
+		return &blockingWatcher{stopped: make(chan struct{})}
+	}
+	w = s.start(c)
+	controller := s.getController(c, w)
+	c.Check(controller.ModelUUIDs(), jc.DeepEquals, []string{s.State.ModelUUID()})
+
+	// Until the watcher confirms it, the restored model is stale.
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.IsStale(), jc.IsTrue)
+}
+
+func (s *WorkerSuite) TestSnapshotUnreadableIgnored(c *gc.C) {
+	s.config.SnapshotPath = filepath.Join(c.MkDir(), "model-cache.snapshot")
+	s.config.SnapshotInterval = time.Hour
+	s.config.Clock = testclock.NewClock(time.Now())
+	err := ioutil.WriteFile(s.config.SnapshotPath, []byte("not a snapshot"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes := s.captureEvents(c, cachetest.ModelEvents)
+	s.start(c)
+	obtained := s.nextChange(c, changes)
+	expected, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkModel(c, obtained, expected)
+}
+
 func (s *WorkerSuite) captureEvents(c *gc.C, matchers ...func(interface{}) bool) <-chan interface{} {
 	events := make(chan interface{})
 	s.notify = func(change interface{}) {
@@ -658,3 +784,19 @@ func (w testingMultiwatcher) Next() ([]multiwatcher.Delta, error) {
 	}
 	return delta, err
 }
+
+// blockingWatcher is a BackingWatcher that never supplies deltas.
+type blockingWatcher struct {
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *blockingWatcher) Next() ([]multiwatcher.Delta, error) {
+	<-w.stopped
+	return nil, state.NewErrStopped()
+}
+
+func (w *blockingWatcher) Stop() error {
+	w.stopOnce.Do(func() { close(w.stopped) })
+	return nil
+}
@@ -10,9 +10,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 )
@@ -39,7 +41,26 @@ type peerGroupInfo struct {
 	extra       []replicaset.Member
 	maxMemberId int
 	mongoPort   int
-	haSpace     network.SpaceName
+
+	// mongoSpace is the space used for Mongo peer communication, and
+	// mongoSpaceKey is the controller config key that it was read from.
+	mongoSpace    network.SpaceName
+	mongoSpaceKey string
+
+	// memberOptions holds the configured options for the replica-set
+	// members of controller nodes, keyed by node ID.
+	memberOptions map[string]controller.HAMemberOptions
+
+	// unreachable holds how long the members of controller nodes have
+	// been unreachable, keyed by node ID. Members unreachable for at
+	// least removalTimeout are removed from the peer group, unless
+	// removalTimeout is zero.
+	unreachable    map[string]time.Duration
+	removalTimeout time.Duration
+
+	// removed holds the IDs of nodes whose members were recently removed
+	// for being unreachable, and which are kept out of the peer group.
+	removed set.Strings
 }
 
 // desiredChanges tracks the specific changes we are asking to be made to the peer group.
@@ -58,6 +79,10 @@ type desiredChanges struct {
 	// time. Also, when nodes are first added to the replicaset, we wait to give them voting rights for when they
 	// have managed to sync the data from the current primary.
 	nodeVoting map[string]bool
+
+	// removed holds the IDs of nodes whose members are removed from the
+	// peer group because they have been unreachable for too long.
+	removed []string
 }
 
 // peerGroupChanges tracks the process of computing the desiredChanges to the peer group.
@@ -81,19 +106,21 @@ func newPeerGroupInfo(
 	statuses []replicaset.MemberStatus,
 	members []replicaset.Member,
 	mongoPort int,
-	haSpace network.SpaceName,
+	mongoSpace network.SpaceName,
+	mongoSpaceKey string,
 ) (*peerGroupInfo, error) {
 	if len(members) == 0 {
 		return nil, fmt.Errorf("current member set is empty")
 	}
 
 	info := peerGroupInfo{
-		controllers: controllers,
-		statuses:    make(map[string]replicaset.MemberStatus),
-		recognised:  make(map[string]replicaset.Member),
-		maxMemberId: -1,
-		mongoPort:   mongoPort,
-		haSpace:     haSpace,
+		controllers:   controllers,
+		statuses:      make(map[string]replicaset.MemberStatus),
+		recognised:    make(map[string]replicaset.Member),
+		maxMemberId:   -1,
+		mongoPort:     mongoPort,
+		mongoSpace:    mongoSpace,
+		mongoSpaceKey: mongoSpaceKey,
 	}
 
 	// Iterate over the input members and associate them with a controller if
@@ -217,6 +244,7 @@ func (p *peerGroupChanges) computeDesiredPeerGroup() (desiredChanges, error) {
 	}
 
 	p.desired.members = p.initNewReplicaSet()
+	p.removeUnreachableMembers()
 	p.possiblePeerGroupChanges()
 	p.reviewPeerGroupChanges()
 	p.createNonVotingMember()
@@ -225,6 +253,7 @@ func (p *peerGroupChanges) computeDesiredPeerGroup() (desiredChanges, error) {
 	// this will trigger a peer group election.
 	p.getNodesVoting()
 	p.adjustVotes()
+	p.applyMemberOptions()
 
 	if err := p.updateAddresses(); err != nil {
 		return desiredChanges{}, errors.Trace(err)
@@ -297,6 +326,11 @@ func (p *peerGroupChanges) possiblePeerGroupChanges() {
 	sortAsInts(nodeIds)
 	logger.Debugf("assessing possible peer group changes:")
 	for _, id := range nodeIds {
+		if p.desired.members[id] == nil && p.isRemoved(id) {
+			logger.Debugf("node %q is kept out of the peer group while unreachable", id)
+			p.desired.nodeVoting[id] = false
+			continue
+		}
 		m := p.info.controllers[id]
 		member := p.desired.members[id]
 		isVoting := member != nil && isVotingMember(member)
@@ -334,6 +368,68 @@ func (p *peerGroupChanges) possiblePeerGroupChanges() {
 	logger.Debugf("assessed")
 }
 
+// removeUnreachableMembers removes from the desired peer group the
+// members of nodes that have been unreachable for at least the removal
+// timeout. The primary is never removed, and nothing is removed unless
+// the reachable voting members form a majority of the voting members, so
+// that a controller cut off from the others cannot remove the members of
+// healthy nodes.
+func (p *peerGroupChanges) removeUnreachableMembers() {
+	timeout := p.info.removalTimeout
+	if timeout <= 0 {
+		return
+	}
+	var candidates []string
+	for _, id := range p.sortedMemberIds() {
+		if p.info.unreachable[id] >= timeout && !isPrimaryMember(p.info, id) {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	voters, reachableVoters := 0, 0
+	hasPrimary := false
+	for id, m := range p.desired.members {
+		if !isVotingMember(m) {
+			continue
+		}
+		voters++
+		if p.info.statuses[id].Healthy {
+			reachableVoters++
+		}
+		if isPrimaryMember(p.info, id) {
+			hasPrimary = true
+		}
+	}
+	if !hasPrimary || reachableVoters*2 <= voters {
+		logger.Warningf("not removing unreachable nodes %v from the peer group: "+
+			"only %d of %d voting members are reachable", candidates, reachableVoters, voters)
+		return
+	}
+	for _, id := range candidates {
+		logger.Warningf("removing node %q from the peer group: unreachable for %v", id, p.info.unreachable[id])
+		delete(p.desired.members, id)
+		p.desired.removed = append(p.desired.removed, id)
+		p.desired.isChanged = true
+	}
+}
+
+// isRemoved returns whether the node is kept out of the peer
+// group for being unreachable.
+func (p *peerGroupChanges) isRemoved(id string) bool {
+	if p.info.removed != nil && p.info.removed.Contains(id) {
+		return true
+	}
+	for _, removed := range p.desired.removed {
+		if removed == id {
+			return true
+		}
+	}
+	return false
+}
+
 func isReady(status replicaset.MemberStatus) bool {
 	return status.Healthy && (status.State == replicaset.PrimaryState ||
 		status.State == replicaset.SecondaryState)
@@ -435,6 +531,93 @@ func (p *peerGroupChanges) adjustVotes() {
 	setVoting(p.toKeepCreateNonVotingMember, false)
 }
 
+// lowMemberPriority is the priority given to voting members configured
+// as low-priority, which are elected primary only if no member with the
+// default priority of 1 is available.
+const lowMemberPriority = 0.5
+
+func memberPriority(m *replicaset.Member) float64 {
+	if m.Priority == nil {
+		return 1
+	}
+	return *m.Priority
+}
+
+func memberHidden(m *replicaset.Member) bool {
+	return m.Hidden != nil && *m.Hidden
+}
+
+func memberDelay(m *replicaset.Member) time.Duration {
+	if m.SlaveDelay == nil {
+		return 0
+	}
+	return *m.SlaveDelay
+}
+
+// applyMemberOptions sets the priority, hidden flag and delay of each
+// member according to the configured member options. Hidden and delayed
+// members must have a priority of 0, so they are never elected primary.
+// If the primary is to become hidden or delayed it is first asked to
+// step down. Options that would leave no voting member able to become
+// primary are ignored.
+func (p *peerGroupChanges) applyMemberOptions() {
+	ids := p.sortedMemberIds()
+	electable := 0
+	for _, id := range ids {
+		opts := p.info.memberOptions[id]
+		if isVotingMember(p.desired.members[id]) && !opts.Hidden {
+			electable++
+		}
+	}
+	for _, id := range ids {
+		member := p.desired.members[id]
+		opts := p.info.memberOptions[id]
+		voting := isVotingMember(member)
+		if voting && opts.Hidden {
+			if electable == 0 {
+				logger.Warningf("ignoring %s for node %q: no voting member would be able to become primary",
+					controller.JujuHAMemberOptions, id)
+				opts = controller.HAMemberOptions{}
+			} else if isPrimaryMember(p.info, id) {
+				logger.Debugf("primary node %q is to be hidden, asking it to step down", id)
+				p.desired.stepDownPrimary = true
+				continue
+			}
+		}
+
+		priority := memberPriority(member)
+		switch {
+		case !voting || opts.Hidden:
+			priority = 0
+		case opts.LowPriority:
+			priority = lowMemberPriority
+		default:
+			priority = 1
+		}
+		if priority == memberPriority(member) &&
+			opts.Hidden == memberHidden(member) &&
+			opts.Delay == memberDelay(member) {
+			continue
+		}
+		logger.Debugf("setting node %q member priority=%v, hidden=%v, delay=%v", id, priority, opts.Hidden, opts.Delay)
+		member.Priority = nil
+		if priority != 1 {
+			member.Priority = &priority
+		}
+		member.Hidden = nil
+		if opts.Hidden {
+			hidden := true
+			member.Hidden = &hidden
+		}
+		member.SlaveDelay = nil
+		if opts.Delay > 0 {
+			delay := opts.Delay
+			member.SlaveDelay = &delay
+		}
+		p.desired.isChanged = true
+	}
+}
+
 // createMembers from a list of member IDs, instantiate a new replica-set
 // member and add it to members map with the given ID.
 func (p *peerGroupChanges) createNonVotingMember() {
@@ -474,10 +657,10 @@ func (p *peerGroupChanges) getNodesVoting() {
 }
 
 // updateAddresses updates the member addresses in the new replica-set, using
-// the HA space if one is configured.
+// the HA or DB space if one is configured.
 func (p *peerGroupChanges) updateAddresses() error {
 	var err error
-	if p.info.haSpace == "" {
+	if p.info.mongoSpace == "" {
 		err = p.updateAddressesFromInternal()
 	} else {
 		err = p.updateAddressesFromSpace()
@@ -567,11 +750,11 @@ func (p *peerGroupChanges) updateAddressesFromInternal() error {
 }
 
 // updateAddressesFromSpace updates the member addresses based on the
-// configured HA space.
+// configured HA or DB space.
 // If no addresses are available for any of the nodes, then such nodes
 // have their status set and are included in the detail of the returned error.
 func (p *peerGroupChanges) updateAddressesFromSpace() error {
-	space := p.info.haSpace
+	space := p.info.mongoSpace
 	var noAddresses []string
 
 	for _, id := range p.sortedMemberIds() {
@@ -580,7 +763,7 @@ func (p *peerGroupChanges) updateAddressesFromSpace() error {
 		if err != nil {
 			if errors.IsNotFound(err) {
 				noAddresses = append(noAddresses, id)
-				msg := fmt.Sprintf("no addresses in configured juju-ha-space %q", space)
+				msg := fmt.Sprintf("no addresses in configured %s %q", p.info.mongoSpaceKey, space)
 				if err := m.host.SetStatus(getStatusInfo(msg)); err != nil {
 					return errors.Trace(err)
 				}
@@ -596,7 +779,7 @@ func (p *peerGroupChanges) updateAddressesFromSpace() error {
 
 	if len(noAddresses) > 0 {
 		ids := strings.Join(noAddresses, ", ")
-		return fmt.Errorf("no usable Mongo addresses found in configured juju-ha-space %q for nodes: %s", space, ids)
+		return fmt.Errorf("no usable Mongo addresses found in configured %s %q for nodes: %s", p.info.mongoSpaceKey, space, ids)
 	}
 	return nil
 }
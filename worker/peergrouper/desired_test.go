@@ -9,12 +9,15 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/replicaset"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 )
 
@@ -351,7 +354,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 			trackerMap[m.Id()] = m
 		}
 
-		info, err := newPeerGroupInfo(trackerMap, test.statuses, test.members, mongoPort, network.SpaceName(""))
+		info, err := newPeerGroupInfo(trackerMap, test.statuses, test.members, mongoPort, network.SpaceName(""), controller.JujuHASpace)
 		c.Assert(err, jc.ErrorIsNil)
 
 		desired, err := desiredPeerGroup(info)
@@ -385,7 +388,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 
 		// Make sure that when the members are set as required, that there
 		// is no further change if desiredPeerGroup is called again.
-		info, err = newPeerGroupInfo(trackerMap, test.statuses, members, mongoPort, network.SpaceName(""))
+		info, err = newPeerGroupInfo(trackerMap, test.statuses, members, mongoPort, network.SpaceName(""), controller.JujuHASpace)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(info, gc.NotNil)
 
@@ -409,7 +412,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 }
 
 func (s *desiredPeerGroupSuite) TestNewPeerGroupInfoErrWhenNoMembers(c *gc.C) {
-	_, err := newPeerGroupInfo(nil, nil, nil, 666, network.SpaceName(""))
+	_, err := newPeerGroupInfo(nil, nil, nil, 666, network.SpaceName(""), controller.JujuHASpace)
 	c.Check(err, gc.ErrorMatches, "current member set is empty")
 }
 
@@ -441,6 +444,160 @@ func (s *desiredPeerGroupSuite) TestCheckExtraMembersReturnsFalseWhenEmpty(c *gc
 	c.Check(err, jc.ErrorIsNil)
 }
 
+// newThreeNodeInfo returns the peer group info for three controller
+// nodes that want the vote, with the given member statuses and members.
+func newThreeNodeInfo(c *gc.C, statuses string, members []replicaset.Member) *peerGroupInfo {
+	trackerMap := make(map[string]*controllerTracker)
+	for _, m := range mkMachines("10v 11v 12v", testIPv4) {
+		trackerMap[m.Id()] = m
+	}
+	info, err := newPeerGroupInfo(trackerMap, mkStatuses(statuses, testIPv4), members, mongoPort, network.SpaceName(""), controller.JujuHASpace)
+	c.Assert(err, jc.ErrorIsNil)
+	return info
+}
+
+func (s *desiredPeerGroupSuite) desiredWithOptions(
+	c *gc.C, members []replicaset.Member, options map[string]controller.HAMemberOptions,
+) (*peerGroupInfo, desiredChanges) {
+	info := newThreeNodeInfo(c, "0p 1s 2s", members)
+	info.memberOptions = options
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	return info, desired
+}
+
+func (s *desiredPeerGroupSuite) TestMemberOptionsApplied(c *gc.C) {
+	options := map[string]controller.HAMemberOptions{
+		"11": {LowPriority: true},
+		"12": {Hidden: true, Delay: time.Hour},
+	}
+	_, desired := s.desiredWithOptions(c, mkMembers("0v 1v 2v", testIPv4), options)
+	c.Assert(desired.isChanged, jc.IsTrue)
+	c.Assert(desired.stepDownPrimary, jc.IsFalse)
+
+	c.Check(desired.members["10"].Priority, gc.IsNil)
+	c.Check(desired.members["10"].Hidden, gc.IsNil)
+	c.Check(desired.members["11"].Priority, jc.DeepEquals, newFloat64(lowMemberPriority))
+	c.Check(desired.members["11"].Hidden, gc.IsNil)
+	c.Check(desired.members["12"].Priority, jc.DeepEquals, newFloat64(0))
+	c.Check(*desired.members["12"].Hidden, jc.IsTrue)
+	c.Check(*desired.members["12"].SlaveDelay, gc.Equals, time.Hour)
+	c.Check(desired.members["12"].Votes, gc.IsNil)
+
+	// Applying the same options again results in no change.
+	members := make([]replicaset.Member, 0, len(desired.members))
+	for _, m := range desired.members {
+		members = append(members, *m)
+	}
+	sort.Sort(membersById(members))
+	_, desired = s.desiredWithOptions(c, members, options)
+	c.Assert(desired.isChanged, jc.IsFalse)
+}
+
+func (s *desiredPeerGroupSuite) TestMemberOptionsRemoved(c *gc.C) {
+	members := mkMembers("0v 1v 2v", testIPv4)
+	members[1].Priority = newFloat64(lowMemberPriority)
+	hidden := true
+	delay := time.Hour
+	members[2].Priority = newFloat64(0)
+	members[2].Hidden = &hidden
+	members[2].SlaveDelay = &delay
+
+	_, desired := s.desiredWithOptions(c, members, nil)
+	c.Assert(desired.isChanged, jc.IsTrue)
+	c.Check(membersToTestMembers([]replicaset.Member{
+		*desired.members["10"], *desired.members["11"], *desired.members["12"],
+	}), jc.DeepEquals, membersToTestMembers(mkMembers("0v 1v 2v", testIPv4)))
+	c.Check(desired.members["12"].Hidden, gc.IsNil)
+	c.Check(desired.members["12"].SlaveDelay, gc.IsNil)
+}
+
+func (s *desiredPeerGroupSuite) TestMemberOptionsHiddenPrimarySteppedDown(c *gc.C) {
+	options := map[string]controller.HAMemberOptions{
+		"10": {Hidden: true},
+	}
+	_, desired := s.desiredWithOptions(c, mkMembers("0v 1v 2v", testIPv4), options)
+	c.Assert(desired.stepDownPrimary, jc.IsTrue)
+	c.Check(desired.members["10"].Priority, gc.IsNil)
+	c.Check(desired.members["10"].Hidden, gc.IsNil)
+}
+
+func (s *desiredPeerGroupSuite) TestMemberOptionsIgnoredWhenNoElectableMember(c *gc.C) {
+	options := map[string]controller.HAMemberOptions{
+		"10": {Hidden: true},
+		"11": {Hidden: true},
+		"12": {Hidden: true, Delay: time.Hour},
+	}
+	_, desired := s.desiredWithOptions(c, mkMembers("0v 1v 2v", testIPv4), options)
+	c.Assert(desired.isChanged, jc.IsFalse)
+	c.Assert(desired.stepDownPrimary, jc.IsFalse)
+	for _, id := range []string{"10", "11", "12"} {
+		c.Check(desired.members[id].Priority, gc.IsNil)
+		c.Check(desired.members[id].Hidden, gc.IsNil)
+		c.Check(desired.members[id].SlaveDelay, gc.IsNil)
+	}
+}
+
+func (s *desiredPeerGroupSuite) TestUnreachableMemberRemoved(c *gc.C) {
+	info := newThreeNodeInfo(c, "0p 1s 2H", mkMembers("0v 1v 2v", testIPv4))
+	info.removalTimeout = 30 * time.Minute
+	info.unreachable = map[string]time.Duration{"12": time.Hour}
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desired.isChanged, jc.IsTrue)
+	c.Assert(desired.removed, jc.DeepEquals, []string{"12"})
+	c.Assert(desired.members["12"], gc.IsNil)
+	// The vote is removed from another member to keep an odd number of voters.
+	c.Assert(desired.nodeVoting, jc.DeepEquals, map[string]bool{"10": true, "11": false, "12": false})
+}
+
+func (s *desiredPeerGroupSuite) TestUnreachableMemberWithinTimeoutKept(c *gc.C) {
+	info := newThreeNodeInfo(c, "0p 1s 2H", mkMembers("0v 1v 2v", testIPv4))
+	info.removalTimeout = 30 * time.Minute
+	info.unreachable = map[string]time.Duration{"12": 10 * time.Minute}
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desired.isChanged, jc.IsFalse)
+	c.Assert(desired.removed, gc.HasLen, 0)
+	c.Assert(desired.members["12"], gc.NotNil)
+}
+
+func (s *desiredPeerGroupSuite) TestUnreachableMemberKeptWithoutTimeout(c *gc.C) {
+	info := newThreeNodeInfo(c, "0p 1s 2H", mkMembers("0v 1v 2v", testIPv4))
+	info.unreachable = map[string]time.Duration{"12": 24 * time.Hour}
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desired.removed, gc.HasLen, 0)
+	c.Assert(desired.members["12"], gc.NotNil)
+}
+
+func (s *desiredPeerGroupSuite) TestUnreachableMembersKeptWithoutMajority(c *gc.C) {
+	info := newThreeNodeInfo(c, "0p 1H 2H", mkMembers("0v 1v 2v", testIPv4))
+	info.removalTimeout = 30 * time.Minute
+	info.unreachable = map[string]time.Duration{"11": time.Hour, "12": time.Hour}
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desired.isChanged, jc.IsFalse)
+	c.Assert(desired.removed, gc.HasLen, 0)
+	c.Assert(desired.members, gc.HasLen, 3)
+}
+
+func (s *desiredPeerGroupSuite) TestRemovedMemberNotAddedBack(c *gc.C) {
+	info := newThreeNodeInfo(c, "0p 1s", mkMembers("0v 1", testIPv4))
+	info.removalTimeout = 30 * time.Minute
+	info.removed = set.NewStrings("12")
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(desired.members["12"], gc.IsNil)
+	c.Assert(desired.nodeVoting["12"], jc.IsFalse)
+}
+
 func countVotes(members []replicaset.Member) int {
 	tot := 0
 	for _, m := range members {
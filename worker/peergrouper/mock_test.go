@@ -63,7 +63,9 @@ type errorPattern struct {
 // in path.Match.
 //
 // The standard form for errors is:
-//    Type.Function <arg>...
+//
+//	Type.Function <arg>...
+//
 // See individual functions for details.
 func (e *errorPatterns) setErrorFor(what string, err error) {
 	e.setErrorFuncFor(what, func() error {
@@ -309,6 +311,15 @@ func (st *fakeState) setHASpace(spaceName string) {
 	st.controllerConfig.Set(cfg)
 }
 
+func (st *fakeState) setDBSpace(spaceName string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	cfg := st.controllerConfig.Get().(controller.Config)
+	cfg[controller.JujuDBSpace] = spaceName
+	st.controllerConfig.Set(cfg)
+}
+
 type fakeController struct {
 	mu      sync.Mutex
 	errors  *errorPatterns
@@ -442,10 +453,11 @@ type fakeMongoSession struct {
 	// all members will be instantly reported as ready.
 	InstantlyReady bool
 
-	errors  *errorPatterns
-	checker invariantChecker
-	members voyeur.Value // of []replicaset.Member
-	status  voyeur.Value // of *replicaset.Status
+	errors   *errorPatterns
+	checker  invariantChecker
+	members  voyeur.Value // of []replicaset.Member
+	status   voyeur.Value // of *replicaset.Status
+	progress voyeur.Value // of map[int]MemberProgress
 }
 
 // newFakeMongoSession returns a mock implementation of mongoSession.
@@ -472,6 +484,21 @@ func (session *fakeMongoSession) CurrentStatus() (*replicaset.Status, error) {
 	return deepCopy(session.status.Get()).(*replicaset.Status), nil
 }
 
+// CurrentProgress implements mongoSession.CurrentProgress.
+func (session *fakeMongoSession) CurrentProgress() (map[int]MemberProgress, error) {
+	if err := session.errors.errorFor("Session.CurrentProgress"); err != nil {
+		return nil, err
+	}
+	progress, _ := session.progress.Get().(map[int]MemberProgress)
+	return deepCopy(progress).(map[int]MemberProgress), nil
+}
+
+// setProgress sets the replication progress of the current members
+// of the session.
+func (session *fakeMongoSession) setProgress(progress map[int]MemberProgress) {
+	session.progress.Set(deepCopy(progress))
+}
+
 // setStatus sets the status of the current members of the session.
 func (session *fakeMongoSession) setStatus(members []replicaset.MemberStatus) {
 	session.status.Set(deepCopy(&replicaset.Status{
@@ -4,8 +4,12 @@
 package peergrouper
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
 )
@@ -44,6 +48,31 @@ func (s MongoSessionShim) CurrentStatus() (*replicaset.Status, error) {
 	return replicaset.CurrentStatus(s.Session)
 }
 
+// CurrentProgress returns the replication progress of each replica set
+// member, keyed by member id. It reads the same replSetGetStatus
+// document as CurrentStatus, picking out the fields that are not
+// included in replicaset.MemberStatus.
+func (s MongoSessionShim) CurrentProgress() (map[int]MemberProgress, error) {
+	var status struct {
+		Members []struct {
+			Id            int       `bson:"_id"`
+			Optime        time.Time `bson:"optimeDate"`
+			LastHeartbeat time.Time `bson:"lastHeartbeat"`
+		} `bson:"members"`
+	}
+	if err := s.Session.Run(bson.D{{"replSetGetStatus", 1}}, &status); err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	progress := make(map[int]MemberProgress)
+	for _, m := range status.Members {
+		progress[m.Id] = MemberProgress{
+			Optime:        m.Optime,
+			LastHeartbeat: m.LastHeartbeat,
+		}
+	}
+	return progress, nil
+}
+
 func (s MongoSessionShim) CurrentMembers() ([]replicaset.Member, error) {
 	return replicaset.CurrentMembers(s.Session)
 }
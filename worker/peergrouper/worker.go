@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/replicaset"
@@ -24,7 +25,9 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/pubsub/apiserver"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
 )
 
 var logger = loggo.GetLogger("juju.worker.peergrouper")
@@ -65,12 +68,24 @@ type ControllerHost interface {
 
 type MongoSession interface {
 	CurrentStatus() (*replicaset.Status, error)
+	CurrentProgress() (map[int]MemberProgress, error)
 	CurrentMembers() ([]replicaset.Member, error)
 	Set([]replicaset.Member) error
 	StepDownPrimary() error
 	Refresh()
 }
 
+// MemberProgress holds the replication progress of a replica set
+// member, which is not included in replicaset.MemberStatus.
+type MemberProgress struct {
+	// Optime is the time of the last operation applied by the member.
+	Optime time.Time
+
+	// LastHeartbeat is the time at which the member last responded to
+	// a heartbeat. It is zero for the member the status was read from.
+	LastHeartbeat time.Time
+}
+
 type APIHostPortsSetter interface {
 	SetAPIHostPorts([][]network.HostPort) error
 }
@@ -124,6 +139,24 @@ type pgWorker struct {
 	// serverDetails holds the last server information broadcast via pub/sub.
 	// It is used to detect changes since the last publish.
 	serverDetails apiserver.Details
+
+	// replicaSetStatus and replicaSetMembers hold the replica set
+	// status and configuration most recently read from mongo. They
+	// are used to publish the health of this node.
+	replicaSetStatus   []replicaset.MemberStatus
+	replicaSetMembers  []replicaset.Member
+	replicaSetProgress map[int]MemberProgress
+
+	// unreachableSince records when the replica set member of each
+	// node was first seen to be unreachable, keyed by node ID.
+	unreachableSince map[string]time.Time
+
+	// removedAt records when the members of unreachable nodes were
+	// removed from the replica set, keyed by node ID. Such nodes are
+	// kept out of the replica set for the removal timeout, after which
+	// they are added back as non-voting members, and promoted as usual
+	// if they have become reachable again.
+	removedAt map[string]time.Time
 }
 
 // Config holds the configuration for a peergrouper worker.
@@ -184,6 +217,8 @@ func New(config Config) (worker.Worker, error) {
 		controllerChanges:  make(chan struct{}),
 		controllerTrackers: make(map[string]*controllerTracker),
 		detailsRequests:    make(chan string),
+		unreachableSince:   make(map[string]time.Time),
+		removedAt:          make(map[string]time.Time),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -293,6 +328,7 @@ func (w *pgWorker) loop() error {
 			failed = true
 		}
 		w.publishAPIServerDetails(servers, members)
+		w.publishNodeHealth()
 
 		if failed {
 			logger.Tracef("failed, waking up after: %v", retryInterval)
@@ -515,6 +551,47 @@ func (w *pgWorker) publishAPIServerDetails(
 	}
 }
 
+// publishNodeHealth publishes the replica set status most recently
+// read from mongo, along with the agent version and current time of
+// this machine. Unlike the API server details, the health is always
+// published, so that the time of the last message can be used to
+// tell whether the node is still reporting.
+func (w *pgWorker) publishNodeHealth() {
+	machineIds := make(map[int]string)
+	for _, m := range w.replicaSetMembers {
+		machineIds[m.Id] = m.Tags[jujuNodeKey]
+	}
+	// The lag of each member is measured against the primary.
+	var primaryOptime time.Time
+	for _, m := range w.replicaSetStatus {
+		if m.State == replicaset.PrimaryState {
+			primaryOptime = w.replicaSetProgress[m.Id].Optime
+		}
+	}
+	msg := controllermsg.NodeHealthMessage{
+		AgentVersion: jujuversion.Current.String(),
+		Time:         w.config.Clock.Now(),
+	}
+	for _, m := range w.replicaSetStatus {
+		member := controllermsg.ReplicaSetMember{
+			MachineID: machineIds[m.Id],
+			Address:   m.Address,
+			State:     m.State.String(),
+			Healthy:   m.Healthy,
+		}
+		if progress, ok := w.replicaSetProgress[m.Id]; ok {
+			member.LastHeartbeat = progress.LastHeartbeat
+			if !primaryOptime.IsZero() && !progress.Optime.IsZero() && progress.Optime.Before(primaryOptime) {
+				member.Lag = primaryOptime.Sub(progress.Optime)
+			}
+		}
+		msg.ReplicaSet = append(msg.ReplicaSet, member)
+	}
+	if _, err := w.config.Hub.Publish(controllermsg.NodeHealthTopic, msg); err != nil {
+		logger.Warningf("cannot publish node health: %v", err)
+	}
+}
+
 // replicaSetError holds an error returned as a result
 // of calling replicaset.Set. As this is expected to fail
 // in the normal course of things, it needs special treatment.
@@ -627,6 +704,9 @@ func (w *pgWorker) updateReplicaSet() (map[string]*replicaset.Member, error) {
 			return nil, &replicaSetError{err}
 		}
 		logger.Infof("successfully updated replica set")
+		if err := w.recordRemovedMembers(desired.removed, info.removalTimeout); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	if err := setHasVote(removed, false); err != nil {
 		return nil, errors.Annotate(err, "removing non-voters")
@@ -656,6 +736,23 @@ func (w *pgWorker) updateReplicaSet() (map[string]*replicaset.Member, error) {
 	return desired.members, nil
 }
 
+// recordRemovedMembers records that the members of the nodes were
+// removed from the replica set for being unreachable, and reports
+// the removal in the status of each node.
+func (w *pgWorker) recordRemovedMembers(ids []string, timeout time.Duration) error {
+	now := w.config.Clock.Now()
+	for _, id := range ids {
+		w.removedAt[id] = now
+		delete(w.unreachableSince, id)
+		msg := fmt.Sprintf("removed from replica set after being unreachable for more than %v", timeout)
+		logger.Warningf("controller node %q %s", id, msg)
+		if err := w.controllerTrackers[id].host.SetStatus(getStatusInfo(msg)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func prettyReplicaSetMembers(members map[string]*replicaset.Member) string {
 	var result []string
 	// Its easier to read if we sort by Id.
@@ -688,23 +785,77 @@ func (w *pgWorker) peerGroupInfo() (*peerGroupInfo, error) {
 		return nil, errors.Annotate(err, "cannot get replica set members")
 	}
 
-	haSpace, err := w.getHASpaceFromConfig()
+	config, err := w.config.State.ControllerConfig()
 	if err != nil {
 		return nil, err
 	}
+	mongoSpace, mongoSpaceKey := mongoSpaceFromConfig(config)
+
+	// The progress of the members is only used to report their
+	// health, so failing to read it isn't fatal.
+	progress, err := w.config.MongoSession.CurrentProgress()
+	if err != nil {
+		logger.Warningf("cannot get replica set progress: %v", err)
+	}
+
+	w.replicaSetStatus = sts.Members
+	w.replicaSetMembers = members
+	w.replicaSetProgress = progress
 
 	logger.Tracef("read peer group info: %# v\n%# v", pretty.Formatter(sts), pretty.Formatter(members))
-	return newPeerGroupInfo(w.controllerTrackers, sts.Members, members, w.config.MongoPort, haSpace)
+	info, err := newPeerGroupInfo(w.controllerTrackers, sts.Members, members, w.config.MongoPort, mongoSpace, mongoSpaceKey)
+	if err != nil {
+		return nil, err
+	}
+	info.memberOptions = config.JujuHAMemberOptions()
+	info.removalTimeout = config.JujuHARemovalTimeout()
+	w.trackUnreachableMembers(info)
+	return info, nil
 }
 
-// getHASpaceFromConfig returns a SpaceName from the controller config for
-// HA space. If unset, the empty space ("") will be returned.
-func (w *pgWorker) getHASpaceFromConfig() (network.SpaceName, error) {
-	config, err := w.config.State.ControllerConfig()
-	if err != nil {
-		return network.SpaceName(""), err
+// trackUnreachableMembers records how long the replica set member of
+// each node has been unreachable, and which nodes are still kept out of
+// the replica set after being removed, in the peer group info.
+func (w *pgWorker) trackUnreachableMembers(info *peerGroupInfo) {
+	now := w.config.Clock.Now()
+	info.unreachable = make(map[string]time.Duration)
+	for id := range w.unreachableSince {
+		if _, ok := info.statuses[id]; !ok {
+			delete(w.unreachableSince, id)
+		}
+	}
+	for id, status := range info.statuses {
+		if status.Healthy {
+			delete(w.unreachableSince, id)
+			continue
+		}
+		since, ok := w.unreachableSince[id]
+		if !ok {
+			since = now
+			w.unreachableSince[id] = since
+		}
+		info.unreachable[id] = now.Sub(since)
+	}
+
+	info.removed = set.NewStrings()
+	for id, removedAt := range w.removedAt {
+		if info.removalTimeout <= 0 || now.Sub(removedAt) >= info.removalTimeout {
+			delete(w.removedAt, id)
+			continue
+		}
+		info.removed.Add(id)
+	}
+}
+
+// mongoSpaceFromConfig returns a SpaceName from the controller config for
+// the space used by the Mongo replica-set, along with the config key that
+// it was read from. The DB space is used if set, otherwise the HA space.
+// If neither is set, the empty space ("") will be returned.
+func mongoSpaceFromConfig(config controller.Config) (network.SpaceName, string) {
+	if dbSpace := config.JujuDBSpace(); dbSpace != "" {
+		return network.SpaceName(dbSpace), controller.JujuDBSpace
 	}
-	return network.SpaceName(config.JujuHASpace()), nil
+	return network.SpaceName(config.JujuHASpace()), controller.JujuHASpace
 }
 
 // setHasVote sets the HasVote status of all the given nodes to hasVote.
@@ -25,8 +25,10 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/pubsub/apiserver"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
 )
 
 type TestIPVersion struct {
@@ -549,6 +551,82 @@ func (s *workerSuite) TestControllersArePublishedOverHub(c *gc.C) {
 	}
 }
 
+func (s *workerSuite) TestNodeHealthIsPublishedOverHub(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+
+	hub := pubsub.NewStructuredHub(nil)
+	event := make(chan controllermsg.NodeHealthMessage)
+	_, err := hub.Subscribe(controllermsg.NodeHealthTopic, func(topic string, data controllermsg.NodeHealthMessage, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		event <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.hub = hub
+
+	w := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case obtained := <-event:
+		c.Assert(obtained.AgentVersion, gc.Equals, jujuversion.Current.String())
+		c.Assert(obtained.Time.IsZero(), jc.IsFalse)
+		c.Assert(obtained.ReplicaSet, jc.DeepEquals, []controllermsg.ReplicaSetMember{{
+			MachineID: "10",
+			Address:   net.JoinHostPort(fmt.Sprintf(testIPv4.formatHost, 10), fmt.Sprint(mongoPort)),
+			State:     "PRIMARY",
+			Healthy:   true,
+		}})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for event")
+	}
+}
+
+func (s *workerSuite) TestNodeHealthIncludesReplicationProgress(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	err := st.session.Set(mkMembers("0v 1", testIPv4))
+	c.Assert(err, jc.ErrorIsNil)
+	st.session.setStatus(mkStatuses("0p 1s", testIPv4))
+	optime := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	heartbeat := optime.Add(2 * time.Second)
+	st.session.setProgress(map[int]MemberProgress{
+		0: {Optime: optime},
+		1: {Optime: optime.Add(-3 * time.Second), LastHeartbeat: heartbeat},
+	})
+
+	hub := pubsub.NewStructuredHub(nil)
+	event := make(chan controllermsg.NodeHealthMessage)
+	_, err = hub.Subscribe(controllermsg.NodeHealthTopic, func(topic string, data controllermsg.NodeHealthMessage, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		event <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.hub = hub
+
+	w := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case obtained := <-event:
+		c.Assert(obtained.ReplicaSet, jc.DeepEquals, []controllermsg.ReplicaSetMember{{
+			MachineID: "10",
+			Address:   net.JoinHostPort(fmt.Sprintf(testIPv4.formatHost, 10), fmt.Sprint(mongoPort)),
+			State:     "PRIMARY",
+			Healthy:   true,
+		}, {
+			MachineID:     "11",
+			Address:       net.JoinHostPort(fmt.Sprintf(testIPv4.formatHost, 11), fmt.Sprint(mongoPort)),
+			State:         "SECONDARY",
+			Healthy:       true,
+			Lag:           3 * time.Second,
+			LastHeartbeat: heartbeat,
+		}})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for event")
+	}
+}
+
 func (s *workerSuite) TestControllersPublishedWithControllerAPIPort(c *gc.C) {
 	st := NewFakeState()
 	InitState(c, st, 3, testIPv4)
@@ -725,6 +803,40 @@ func (s *workerSuite) doTestUsesConfiguredHASpace(c *gc.C, ipVersion TestIPVersi
 	c.Check(sInfo.Message, gc.Equals, "")
 }
 
+func (s *workerSuite) TestUsesConfiguredDBSpaceIPv4(c *gc.C) {
+	s.doTestUsesConfiguredDBSpace(c, testIPv4)
+}
+
+func (s *workerSuite) TestUsesConfiguredDBSpaceIPv6(c *gc.C) {
+	s.doTestUsesConfiguredDBSpace(c, testIPv6)
+}
+
+func (s *workerSuite) doTestUsesConfiguredDBSpace(c *gc.C, ipVersion TestIPVersion) {
+	st := haSpaceTestCommonSetup(c, ipVersion, "0v 1v 2v")
+
+	// The DB space takes precedence over the HA space
+	// for replica-set member addresses.
+	st.setHASpace("two")
+	st.setDBSpace("three")
+	s.runUntilPublish(c, st, "")
+	assertMemberAddresses(c, st, ipVersion.formatHost, 3)
+}
+
+func (s *workerSuite) TestErrorAndStatusForDBSpaceWithNoAddresses(c *gc.C) {
+	st := haSpaceTestCommonSetup(c, testIPv4, "0v")
+	st.setHASpace("two")
+	st.setDBSpace("nope")
+
+	err := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true).Wait()
+	errMsg := `computing desired peer group: updating member addresses: ` +
+		`no usable Mongo addresses found in configured juju-db-space "nope" for nodes: 1[012], 1[012], 1[012]`
+	c.Check(err, gc.ErrorMatches, errMsg)
+
+	sInfo, err := st.controller("10").Status()
+	c.Assert(err, gc.IsNil)
+	c.Check(sInfo.Message, gc.Equals, `no addresses in configured juju-db-space "nope"`)
+}
+
 // runUntilPublish runs a worker until addresses are published over the pub/sub
 // hub. Note that the replica-set is updated earlier than the publish,
 // so this sync can be used to check for those changes.
@@ -1161,3 +1273,37 @@ func (s *workerSuite) newWorker(
 		SupportsHA:         supportsHA,
 	})
 }
+
+func (s *workerSuite) TestTrackUnreachableMembers(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	w := &pgWorker{
+		config:           Config{Clock: clock},
+		unreachableSince: make(map[string]time.Time),
+		removedAt:        map[string]time.Time{"13": clock.Now()},
+	}
+	info := &peerGroupInfo{
+		statuses: map[string]replicaset.MemberStatus{
+			"10": {Id: 0, Healthy: true, State: replicaset.PrimaryState},
+			"11": {Id: 1, Healthy: false},
+		},
+		removalTimeout: time.Hour,
+	}
+	w.trackUnreachableMembers(info)
+	c.Check(info.unreachable, jc.DeepEquals, map[string]time.Duration{"11": 0})
+	c.Check(info.removed.SortedValues(), jc.DeepEquals, []string{"13"})
+
+	clock.Advance(30 * time.Minute)
+	w.trackUnreachableMembers(info)
+	c.Check(info.unreachable, jc.DeepEquals, map[string]time.Duration{"11": 30 * time.Minute})
+	c.Check(info.removed.SortedValues(), jc.DeepEquals, []string{"13"})
+
+	// A member that becomes reachable is no longer tracked, and removed
+	// nodes are allowed back after the removal timeout.
+	info.statuses["11"] = replicaset.MemberStatus{Id: 1, Healthy: true, State: replicaset.SecondaryState}
+	clock.Advance(30 * time.Minute)
+	w.trackUnreachableMembers(info)
+	c.Check(info.unreachable, gc.HasLen, 0)
+	c.Check(info.removed.IsEmpty(), jc.IsTrue)
+	c.Check(w.unreachableSince, gc.HasLen, 0)
+	c.Check(w.removedAt, gc.HasLen, 0)
+}
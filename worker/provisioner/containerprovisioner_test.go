@@ -6,6 +6,7 @@ package provisioner_test
 import (
 	"fmt"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/container/kvm/mock"
 	kvmtesting "github.com/juju/juju/container/kvm/testing"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
 	supportedversion "github.com/juju/juju/juju/version"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -178,3 +180,23 @@ func (s *kvmProvisionerSuite) TestKVMProvisionerObservesConfigChanges(c *gc.C) {
 	defer workertest.CleanKill(c, p)
 	s.assertProvisionerObservesConfigChanges(c, p)
 }
+
+func (s *kvmProvisionerSuite) TestKVMProvisionerObservesRetryStrategyChanges(c *gc.C) {
+	p := s.newKvmProvisioner(c)
+	defer workertest.CleanKill(c, p)
+
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		config.ProvisionerRetryCountKey: 7,
+		config.ProvisionerRetryDelayKey: "1m0s",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := "start instance retry strategy changed to 7 attempts every 1m0s"
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		s.BackingState.StartSync()
+		if strings.Contains(c.GetTestLog(), expected) {
+			return
+		}
+	}
+	c.Fatalf("provisioner did not pick up the retry strategy change")
+}
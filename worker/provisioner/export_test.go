@@ -44,6 +44,8 @@ var (
 
 var ClassifyMachine = classifyMachine
 
+var RetryStrategyFromConfig = retryStrategyFromConfig
+
 // GetCopyAvailabilityZoneMachines returns a copy of p.(*provisionerTask).availabilityZoneMachines
 func GetCopyAvailabilityZoneMachines(p ProvisionerTask) []AvailabilityZoneMachine {
 	task := p.(*provisionerTask)
@@ -62,7 +64,8 @@ func SetupToStartMachine(p ProvisionerTask, machine apiprovisioner.MachineProvis
 	environs.StartInstanceParams,
 	error,
 ) {
-	return p.(*provisionerTask).setupToStartMachine(machine, version)
+	startInstanceParams, _, err := p.(*provisionerTask).setupToStartMachine(machine, version)
+	return startInstanceParams, err
 }
 
 func (cs *ContainerSetup) SetGetNetConfig(getNetConf func(common.NetworkConfigSource) ([]params.NetworkConfig, error)) {
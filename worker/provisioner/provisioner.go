@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"reflect"
 	"sync"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/worker/common"
 )
 
@@ -87,6 +89,19 @@ func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
 	}
 }
 
+// retryStrategyFromConfig returns the retry strategy to use when starting
+// instances, taking any overrides from the model config into account.
+func retryStrategyFromConfig(cfg *config.Config) RetryStrategy {
+	strategy := NewRetryStrategy(retryStrategyDelay, retryStrategyCount)
+	if count, ok := cfg.ProvisionerRetryCount(); ok {
+		strategy.retryCount = count
+	}
+	if delay, ok := cfg.ProvisionerRetryDelay(); ok {
+		strategy.retryDelay = delay
+	}
+	return strategy
+}
+
 // configObserver is implemented so that tests can see when the environment
 // configuration changes.
 // The catacomb is set in export_test to the provider's member.
@@ -177,7 +192,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		retryStrategyFromConfig(modelCfg),
 		p.callContext,
 	)
 	if err != nil {
@@ -262,10 +277,15 @@ func (p *environProvisioner) loop() error {
 			if err != nil {
 				return errors.Annotate(err, "cannot load model configuration")
 			}
+			oldResourceTags, _ := p.environ.Config().ResourceTags()
 			if err := p.setConfig(modelConfig); err != nil {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
+			if resourceTags, _ := modelConfig.ResourceTags(); !reflect.DeepEqual(resourceTags, oldResourceTags) {
+				p.tagInstances(modelConfig)
+			}
 		}
 	}
 }
@@ -288,6 +308,38 @@ func (p *environProvisioner) setConfig(modelConfig *config.Config) error {
 	return nil
 }
 
+// tagInstances sets the model's resource tags on each of its
+// instances, so that changes to the resource-tags model config apply
+// to instances that have already been started. Environs that cannot
+// tag existing instances are left alone. Failures are logged rather
+// than stopping the provisioner.
+func (p *environProvisioner) tagInstances(modelConfig *config.Config) {
+	tagger, ok := p.environ.(environs.InstanceTagger)
+	if !ok {
+		return
+	}
+	controllerCfg, err := p.st.ControllerConfig()
+	if err != nil {
+		p.logger.Errorf("cannot tag instances: %v", err)
+		return
+	}
+	insts, err := p.environ.AllInstances(p.callContext)
+	if err != nil {
+		p.logger.Errorf("cannot tag instances: %v", err)
+		return
+	}
+	instanceTags := tags.ResourceTags(
+		names.NewModelTag(modelConfig.UUID()),
+		names.NewControllerTag(controllerCfg.ControllerUUID()),
+		modelConfig,
+	)
+	for _, inst := range insts {
+		if err := tagger.TagInstance(p.callContext, inst.Id(), instanceTags); err != nil {
+			p.logger.Errorf("cannot tag instance %q: %v", inst.Id(), err)
+		}
+	}
+}
+
 // NewContainerProvisioner returns a new Provisioner. When new machines
 // are added to the state, it allocates instances from the environment
 // and allocates them to the new machines.
@@ -364,6 +416,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
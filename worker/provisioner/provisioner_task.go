@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/lxdprofile"
@@ -52,6 +53,10 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetRetryStrategy sets the strategy used by the provisioner
+	// task when retrying failed attempts to start an instance.
+	SetRetryStrategy(strategy RetryStrategy)
 }
 
 type MachineGetter interface {
@@ -112,6 +117,7 @@ func NewProvisionerTask(
 		availabilityZoneMachines:   make([]*AvailabilityZoneMachine, 0),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
+		retryStrategyChan:          make(chan RetryStrategy, 1),
 		cloudCallCtx:               cloudCallContext,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
@@ -147,6 +153,7 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	retryStrategyChan          chan RetryStrategy
 	// instance id -> instance
 	instances map[instance.Id]instances.Instance
 	// machine id -> machine
@@ -204,10 +211,18 @@ func (task *provisionerTask) loop() error {
 					return errors.Annotate(err, "failed to process machines after safe mode disabled")
 				}
 			}
+		case strategy := <-task.retryStrategyChan:
+			if strategy == task.retryStartInstanceStrategy {
+				break
+			}
+			task.logger.Infof("start instance retry strategy changed to %d attempts every %v",
+				strategy.retryCount, strategy.retryDelay)
+			task.retryStartInstanceStrategy = strategy
 		case <-task.retryChanges:
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+			task.processPreemptedMachines()
 		}
 	}
 }
@@ -220,6 +235,14 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetRetryStrategy implements ProvisionerTask.SetRetryStrategy().
+func (task *provisionerTask) SetRetryStrategy(strategy RetryStrategy) {
+	select {
+	case task.retryStrategyChan <- strategy:
+	case <-task.catacomb.Dying():
+	}
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -253,6 +276,48 @@ func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	return task.startMachines(pending)
 }
 
+// processPreemptedMachines asks the broker to maintain the machines
+// whose preemptible or spot instances have been stopped by the cloud,
+// so that the instances are started again. Failures are recorded in
+// the instance status, and retried the next time round.
+func (task *provisionerTask) processPreemptedMachines() {
+	task.machinesMutex.RLock()
+	var machines []apiprovisioner.MachineProvisioner
+	for _, machine := range task.machines {
+		// Containers are never preempted by the cloud.
+		if machine.Life() == params.Alive && state.ContainerTypeFromId(machine.Id()) == "" {
+			machines = append(machines, machine)
+		}
+	}
+	task.machinesMutex.RUnlock()
+
+	for _, machine := range machines {
+		instanceStatus, _, err := machine.InstanceStatus()
+		if err != nil {
+			task.logger.Warningf("cannot read instance status of machine %q: %v", machine.Id(), err)
+			continue
+		}
+		if instanceStatus != status.Preempted {
+			continue
+		}
+		task.logger.Infof("restarting preempted instance of machine %q", machine.Id())
+		if err := machine.SetInstanceStatus(status.Provisioning, "restarting preempted instance", nil); err != nil {
+			task.logger.Errorf("cannot set instance status of machine %q: %v", machine.Id(), err)
+			continue
+		}
+		startInstanceParams := environs.StartInstanceParams{
+			InstanceConfig: &instancecfg.InstanceConfig{MachineId: machine.Id()},
+		}
+		if err := task.broker.MaintainInstance(task.cloudCallCtx, startInstanceParams); err != nil {
+			task.logger.Errorf("cannot restart preempted instance of machine %q: %v", machine.Id(), err)
+			message := fmt.Sprintf("restarting preempted instance: %v", err)
+			if err := machine.SetInstanceStatus(status.Preempted, message, nil); err != nil {
+				task.logger.Errorf("cannot set instance status of machine %q: %v", machine.Id(), err)
+			}
+		}
+	}
+}
+
 func (task *provisionerTask) processMachines(ids []string) error {
 	task.logger.Tracef("processMachines(%v)", ids)
 
@@ -830,10 +895,13 @@ func (task *provisionerTask) populateDistributionGroupZoneMap(machineIds []strin
 // the "available" zones, and any supplied zone constraints.
 // Machines in the same DistributionGroup are placed in different zones,
 // distributed based on lowest population of machines in that DistributionGroup.
+// If every suitable zone already holds a machine from the DistributionGroup,
+// the machine is placed anyway with a warning, or an error is returned if
+// failIfNotSpread is true.
 // Machines are not placed in a zone they are excluded from.
 // If availability zones are implemented and one isn't found, return NotFound error.
 func (task *provisionerTask) machineAvailabilityZoneDistribution(
-	machineId string, distGroupMachineIds []string, cons constraints.Value,
+	machineId string, distGroupMachineIds []string, cons constraints.Value, failIfNotSpread bool,
 ) (string, error) {
 	task.machinesMutex.Lock()
 	defer task.machinesMutex.Unlock()
@@ -853,6 +921,20 @@ func (task *provisionerTask) machineAvailabilityZoneDistribution(
 		for _, dgZoneMachines := range dgZoneMap {
 			if !dgZoneMachines.FailedMachineIds.Contains(machineId) &&
 				!dgZoneMachines.ExcludedMachineIds.Contains(machineId) {
+				others := dgZoneMachines.MachineIds.Difference(set.NewStrings(machineId))
+				if others.Size() > 0 {
+					if failIfNotSpread {
+						return "", errors.Errorf(
+							"cannot spread machine %v across availability zones: "+
+								"every suitable zone already has machines %v",
+							machineId, others.SortedValues(),
+						)
+					}
+					task.logger.Warningf(
+						"machine %v will share availability zone %s with machines %v",
+						machineId, dgZoneMachines.ZoneName, others.SortedValues(),
+					)
+				}
 				machineZone = dgZoneMachines.ZoneName
 				for _, azm := range task.availabilityZoneMachines {
 					if azm.ZoneName == dgZoneMachines.ZoneName {
@@ -996,16 +1078,17 @@ func (task *provisionerTask) setErrorStatus(message string, machine apiprovision
 // and StartInstanceParams to be used by startMachine.
 func (task *provisionerTask) setupToStartMachine(machine apiprovisioner.MachineProvisioner, version *version.Number) (
 	environs.StartInstanceParams,
+	*params.ProvisioningInfo,
 	error,
 ) {
 	pInfo, err := machine.ProvisioningInfo()
 	if err != nil {
-		return environs.StartInstanceParams{}, errors.Annotatef(err, "fetching provisioning info for machine %q", machine)
+		return environs.StartInstanceParams{}, nil, errors.Annotatef(err, "fetching provisioning info for machine %q", machine)
 	}
 
 	instanceCfg, err := task.constructInstanceConfig(machine, task.auth, pInfo)
 	if err != nil {
-		return environs.StartInstanceParams{}, errors.Annotatef(err, "creating instance config for machine %q", machine)
+		return environs.StartInstanceParams{}, nil, errors.Annotatef(err, "creating instance config for machine %q", machine)
 	}
 
 	assocProvInfoAndMachCfg(pInfo, instanceCfg)
@@ -1021,7 +1104,7 @@ func (task *provisionerTask) setupToStartMachine(machine apiprovisioner.MachineP
 		arch,
 	)
 	if err != nil {
-		return environs.StartInstanceParams{}, errors.Annotatef(err, "cannot find agent binaries for machine %q", machine)
+		return environs.StartInstanceParams{}, nil, errors.Annotatef(err, "cannot find agent binaries for machine %q", machine)
 	}
 
 	startInstanceParams, err := task.constructStartInstanceParams(
@@ -1032,10 +1115,10 @@ func (task *provisionerTask) setupToStartMachine(machine apiprovisioner.MachineP
 		possibleTools,
 	)
 	if err != nil {
-		return environs.StartInstanceParams{}, errors.Annotatef(err, "cannot construct params for machine %q", machine)
+		return environs.StartInstanceParams{}, nil, errors.Annotatef(err, "cannot construct params for machine %q", machine)
 	}
 
-	return startInstanceParams, nil
+	return startInstanceParams, pInfo, nil
 }
 
 // populateExcludedMachines, translates the results of DeriveAvailabilityZones
@@ -1072,10 +1155,11 @@ func (task *provisionerTask) startMachine(
 	if err != nil {
 		return err
 	}
-	startInstanceParams, err := task.setupToStartMachine(machine, v)
+	startInstanceParams, pInfo, err := task.setupToStartMachine(machine, v)
 	if err != nil {
 		return task.setErrorStatus("%v", machine, err)
 	}
+	failIfNotSpread := pInfo.SpreadPolicy == string(coreapplication.SpreadPolicyFail)
 
 	// Figure out if the zones available to use for a new instance are
 	// restricted based on placement, and if so exclude those machines
@@ -1101,7 +1185,7 @@ func (task *provisionerTask) startMachine(
 	// environs.IsAvailabilityZoneIndependent.
 	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; {
 		if startInstanceParams.AvailabilityZone, err = task.machineAvailabilityZoneDistribution(
-			machine.Id(), distributionGroupMachineIds, startInstanceParams.Constraints,
+			machine.Id(), distributionGroupMachineIds, startInstanceParams.Constraints, failIfNotSpread,
 		); err != nil {
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		}
@@ -203,6 +203,97 @@ func (s *ProvisionerTaskSuite) TestProvisionerRetries(c *gc.C) {
 	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
 }
 
+func (s *ProvisionerTaskSuite) TestProvisionerRetryStrategyChange(c *gc.C) {
+	s.instanceBroker.SetErrors(
+		errors.New("errors 1"),
+		errors.New("errors 2"),
+	)
+
+	task := s.newProvisionerTaskWithRetry(c,
+		config.HarvestAll,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+		provisioner.NewRetryStrategy(0*time.Second, 0),
+	)
+
+	// The strategy channel is buffered, so setting the strategy
+	// twice ensures the first has been consumed by the task.
+	task.SetRetryStrategy(provisioner.NewRetryStrategy(0*time.Second, 1))
+	task.SetRetryStrategy(provisioner.NewRetryStrategy(0*time.Second, 1))
+
+	m0 := &testMachine{
+		id: "0",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"StartInstance", "StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
+}
+
+func (s *ProvisionerTaskSuite) TestProvisionerRestartsPreemptedInstances(c *gc.C) {
+	task := s.newProvisionerTask(c,
+		config.HarvestAll,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+	)
+	defer workertest.CleanKill(c, task)
+
+	i0 := &testInstance{id: "zero"}
+	i1 := &testInstance{id: "one"}
+	s.instances = []instances.Instance{i0, i1}
+	m0 := &testMachine{
+		id:         "0",
+		life:       params.Alive,
+		instance:   i0,
+		instStatus: status.Preempted,
+	}
+	m1 := &testMachine{
+		id:         "1",
+		life:       params.Alive,
+		instance:   i1,
+		instStatus: status.Running,
+	}
+	s.machinesResults = []apiprovisioner.MachineResult{
+		{Machine: m0},
+		{Machine: m1},
+	}
+	s.sendModelMachinesChange(c, "0", "1")
+	s.waitForTask(c, []string{"AllRunningInstances"})
+
+	s.sendMachineErrorRetryChange(c)
+	s.waitForTask(c, []string{"MaintainInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "AllRunningInstances", "MaintainInstance")
+	args := s.instanceBroker.Calls()[1].Args[1].(environs.StartInstanceParams)
+	c.Check(args.InstanceConfig.MachineId, gc.Equals, "0")
+
+	instStatus, message, err := m0.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instStatus, gc.Equals, status.Provisioning)
+	c.Check(message, gc.Equals, "restarting preempted instance")
+}
+
+func (s *ProvisionerTaskSuite) TestRetryStrategyFromConfig(c *gc.C) {
+	cfg := coretesting.ModelConfig(c)
+	c.Assert(provisioner.RetryStrategyFromConfig(cfg), gc.Equals,
+		provisioner.NewRetryStrategy(*provisioner.RetryStrategyDelay, *provisioner.RetryStrategyCount))
+
+	cfg = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"provisioner-retry-count": 3,
+		"provisioner-retry-delay": "30s",
+	})
+	c.Assert(provisioner.RetryStrategyFromConfig(cfg), gc.Equals,
+		provisioner.NewRetryStrategy(30*time.Second, 3))
+}
+
 func (s *ProvisionerTaskSuite) TestZoneConstraintsNoZoneAvailable(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -330,6 +421,70 @@ func (s *ProvisionerTaskSuite) TestZoneConstraintsWithDistributionGroup(c *gc.C)
 	workertest.CleanKill(c, task)
 }
 
+func (s *ProvisionerTaskSuite) TestZoneSpreadPolicyFail(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	broker := s.setUpZonedEnviron(ctrl)
+	azConstraints := newAZConstraintStartInstanceParamsMatcher("az1")
+	broker.EXPECT().DeriveAvailabilityZones(s.callCtx, azConstraints).Return([]string{}, nil).Times(2)
+
+	// Only one of the machines can be started in az1; the other
+	// must not share the zone with it.
+	started := make(chan struct{})
+	broker.EXPECT().StartInstance(s.callCtx, azConstraints).Return(&environs.StartInstanceResult{
+		Instance: &testInstance{id: "instance-1"},
+	}, nil).Do(func(_ ...interface{}) {
+		go func() { started <- struct{}{} }()
+	})
+
+	task := s.newProvisionerTaskWithBroker(c, broker, map[names.MachineTag][]string{
+		names.NewMachineTag("0"): {"1"},
+		names.NewMachineTag("1"): {"0"},
+	})
+
+	m0 := &testMachine{
+		id:           "0",
+		constraints:  "zones=az1",
+		spreadPolicy: "fail",
+	}
+	m1 := &testMachine{
+		id:           "1",
+		constraints:  "zones=az1",
+		spreadPolicy: "fail",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+		{Machine: m1, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no matching call to StartInstance")
+	}
+
+	// Wait for the other machine to be marked as failed.
+	var msg string
+	timeout := time.After(coretesting.LongWait)
+	for msg == "" {
+		select {
+		case <-time.After(coretesting.ShortWait):
+			for _, m := range []*testMachine{m0, m1} {
+				if st, mMsg, _ := m.InstanceStatus(); st == status.ProvisioningError {
+					msg = mMsg
+				}
+			}
+		case <-timeout:
+			c.Fatalf("machine InstanceStatus was not set")
+		}
+	}
+	c.Check(msg, gc.Matches, `cannot spread machine [01] across availability zones: every suitable zone already has machines \[[01]\]`)
+
+	workertest.CleanKill(c, task)
+}
+
 // setUpZonedEnviron creates a mock environ with instances based on those set
 // on the test suite, and 3 availability zones.
 func (s *ProvisionerTaskSuite) setUpZonedEnviron(ctrl *gomock.Controller) *mocks.MockZonedEnviron {
@@ -527,7 +682,9 @@ type testMachine struct {
 
 	markForRemoval bool
 	constraints    string
+	spreadPolicy   string
 
+	instStatus    status.Status
 	instStatusMsg string
 	modStatusMsg  string
 }
@@ -570,8 +727,9 @@ func (m *testMachine) MachineTag() names.MachineTag {
 	return names.NewMachineTag(m.id)
 }
 
-func (m *testMachine) SetInstanceStatus(_ status.Status, message string, _ map[string]interface{}) error {
+func (m *testMachine) SetInstanceStatus(instStatus status.Status, message string, _ map[string]interface{}) error {
 	m.mu.Lock()
+	m.instStatus = instStatus
 	m.instStatusMsg = message
 	m.mu.Unlock()
 	return nil
@@ -580,7 +738,7 @@ func (m *testMachine) SetInstanceStatus(_ status.Status, message string, _ map[s
 func (m *testMachine) InstanceStatus() (status.Status, string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return status.Status(""), m.instStatusMsg, nil
+	return m.instStatus, m.instStatusMsg, nil
 }
 
 func (m *testMachine) SetModificationStatus(_ status.Status, message string, _ map[string]interface{}) error {
@@ -621,6 +779,7 @@ func (m *testMachine) ProvisioningInfo() (*params.ProvisioningInfo, error) {
 		ControllerConfig: coretesting.FakeControllerConfig(),
 		Series:           jujuversion.SupportedLTS(),
 		Constraints:      constraints.MustParse(m.constraints),
+		SpreadPolicy:     m.spreadPolicy,
 	}, nil
 }
 
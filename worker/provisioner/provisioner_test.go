@@ -1326,6 +1326,56 @@ func (s *ProvisionerSuite) TestEnvironProvisionerObservesConfigChanges(c *gc.C)
 	s.assertProvisionerObservesConfigChanges(c, p)
 }
 
+// taggingEnviron is an environ that reports the tags set on its
+// existing instances.
+type taggingEnviron struct {
+	environs.Environ
+	tagged chan map[string]string
+}
+
+func (e *taggingEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	if id == dummy.BootstrapInstanceId {
+		select {
+		case e.tagged <- tags:
+		default:
+		}
+	}
+	return nil
+}
+
+func (s *ProvisionerSuite) TestEnvironProvisionerTagsInstancesWhenResourceTagsChange(c *gc.C) {
+	environ := &taggingEnviron{
+		Environ: s.Environ,
+		tagged:  make(chan map[string]string, 1),
+	}
+	agentConfig := s.AgentConfigForTag(c, names.NewMachineTag("0"))
+	apiState := apiprovisioner.NewState(s.st)
+	p, err := provisioner.NewEnvironProvisioner(apiState, agentConfig, loggo.GetLogger("test"), environ, &credentialAPIForTest{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, p)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		config.ResourceTagsKey: "origin=test",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BackingState.StartSync()
+
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case tags := <-environ.tagged:
+			c.Assert(tags["origin"], gc.Equals, "test")
+			c.Assert(tags["juju-model-uuid"], gc.Equals, s.Model.UUID())
+			c.Assert(tags["juju-controller-uuid"], gc.Equals, s.ControllerConfig.ControllerUUID())
+			return
+		case <-time.After(coretesting.ShortWait):
+			s.BackingState.StartSync()
+		case <-timeout:
+			c.Fatalf("timed out waiting for instance to be tagged")
+		}
+	}
+}
+
 func (s *ProvisionerSuite) newProvisionerTask(
 	c *gc.C,
 	harvestingMethod config.HarvestMode,
@@ -5,6 +5,7 @@ package raftclusterer
 
 import (
 	"github.com/hashicorp/raft"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"gopkg.in/juju/worker.v1"
@@ -16,6 +17,7 @@ import (
 type ManifoldConfig struct {
 	RaftName       string
 	CentralHubName string
+	ClockName      string
 
 	NewWorker func(Config) (worker.Worker, error)
 }
@@ -31,9 +33,15 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	return config.NewWorker(Config{
-		Raft: r,
-		Hub:  hub,
+		Raft:  r,
+		Hub:   hub,
+		Clock: clock,
 	})
 }
 
@@ -42,6 +50,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		Inputs: []string{
 			config.RaftName,
 			config.CentralHubName,
+			config.ClockName,
 		},
 		Start: config.start,
 	}
@@ -4,7 +4,10 @@
 package raftclusterer_test
 
 import (
+	"time"
+
 	"github.com/hashicorp/raft"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"github.com/juju/testing"
@@ -24,6 +27,7 @@ type ManifoldSuite struct {
 	context  dependency.Context
 	raft     *raft.Raft
 	hub      *pubsub.StructuredHub
+	clock    *testclock.Clock
 	worker   worker.Worker
 	stub     testing.Stub
 }
@@ -35,6 +39,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 
 	s.raft = &raft.Raft{}
 	s.hub = &pubsub.StructuredHub{}
+	s.clock = testclock.NewClock(time.Time{})
 	s.stub.ResetCalls()
 
 	type mockWorker struct {
@@ -46,6 +51,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.manifold = raftclusterer.Manifold(raftclusterer.ManifoldConfig{
 		RaftName:       "raft",
 		CentralHubName: "central-hub",
+		ClockName:      "clock",
 		NewWorker:      s.newWorker,
 	})
 }
@@ -54,6 +60,7 @@ func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Co
 	resources := map[string]interface{}{
 		"raft":        s.raft,
 		"central-hub": s.hub,
+		"clock":       s.clock,
 	}
 	for k, v := range overlay {
 		resources[k] = v
@@ -70,7 +77,7 @@ func (s *ManifoldSuite) newWorker(config raftclusterer.Config) (worker.Worker, e
 }
 
 var expectedInputs = []string{
-	"raft", "central-hub",
+	"raft", "central-hub", "clock",
 }
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
@@ -97,8 +104,9 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	config := args[0].(raftclusterer.Config)
 
 	c.Assert(config, jc.DeepEquals, raftclusterer.Config{
-		Raft: s.raft,
-		Hub:  s.hub,
+		Raft:  s.raft,
+		Hub:   s.hub,
+		Clock: s.clock,
 	})
 }
 
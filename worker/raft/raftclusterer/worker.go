@@ -4,7 +4,11 @@
 package raftclusterer
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/hashicorp/raft"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
@@ -12,17 +16,23 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/pubsub/apiserver"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 )
 
 var (
 	logger = loggo.GetLogger("juju.worker.raft.raftclusterer")
 )
 
+// healthInterval is the interval at which the health of the raft
+// cluster is published.
+const healthInterval = 30 * time.Second
+
 // Config holds the configuration necessary to run a worker for
 // maintaining the raft cluster configuration.
 type Config struct {
-	Raft *raft.Raft
-	Hub  *pubsub.StructuredHub
+	Raft  *raft.Raft
+	Hub   *pubsub.StructuredHub
+	Clock clock.Clock
 }
 
 // Validate validates the raft worker configuration.
@@ -33,6 +43,9 @@ func (config Config) Validate() error {
 	if config.Raft == nil {
 		return errors.NotValidf("nil Raft")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	return nil
 }
 
@@ -100,6 +113,7 @@ func (w *Worker) loop() error {
 	if err != nil {
 		return errors.Annotate(err, "getting raft configuration")
 	}
+	w.publishHealth(servers)
 
 	for {
 		select {
@@ -110,10 +124,37 @@ func (w *Worker) loop() error {
 			if err != nil {
 				return errors.Annotate(err, "updating raft configuration")
 			}
+			w.publishHealth(servers)
+		case <-w.config.Clock.After(healthInterval):
+			w.publishHealth(servers)
 		}
 	}
 }
 
+// publishHealth publishes the leader, term and configured servers of
+// the raft cluster. As this worker only runs on the raft leader, the
+// local server is the leader.
+func (w *Worker) publishHealth(servers map[raft.ServerID]*raft.Server) {
+	localAddr := w.config.Raft.Leader()
+	var msg controllermsg.RaftHealthMessage
+	if term, err := strconv.ParseUint(w.config.Raft.Stats()["term"], 10, 64); err == nil {
+		msg.Term = term
+	}
+	for id, server := range servers {
+		if server.Address == localAddr {
+			msg.Leader = string(id)
+		}
+		msg.Servers = append(msg.Servers, controllermsg.RaftServer{
+			ID:       string(id),
+			Address:  string(server.Address),
+			Suffrage: server.Suffrage.String(),
+		})
+	}
+	if _, err := w.config.Hub.Publish(controllermsg.RaftHealthTopic, msg); err != nil {
+		logger.Warningf("cannot publish raft health: %v", err)
+	}
+}
+
 func (w *Worker) getConfiguration() (map[raft.ServerID]*raft.Server, uint64, error) {
 	future := w.config.Raft.GetConfiguration()
 	prevIndex, err := w.waitIndexFuture(future)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/raft"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/pubsub"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/centralhub"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	coretesting "github.com/juju/juju/testing"
 	jujuraft "github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/raftclusterer"
@@ -25,6 +27,7 @@ import (
 type workerFixture struct {
 	rafttest.RaftFixture
 	hub    *pubsub.StructuredHub
+	clock  *testclock.Clock
 	config raftclusterer.Config
 }
 
@@ -32,9 +35,11 @@ func (s *workerFixture) SetUpTest(c *gc.C) {
 	s.FSM = &jujuraft.SimpleFSM{}
 	s.RaftFixture.SetUpTest(c)
 	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.clock = testclock.NewClock(time.Time{})
 	s.config = raftclusterer.Config{
-		Raft: s.Raft,
-		Hub:  s.hub,
+		Raft:  s.Raft,
+		Hub:   s.hub,
+		Clock: s.clock,
 	}
 }
 
@@ -55,6 +60,9 @@ func (s *WorkerValidationSuite) TestValidateErrors(c *gc.C) {
 	}, {
 		func(cfg *raftclusterer.Config) { cfg.Hub = nil },
 		"nil Hub not valid",
+	}, {
+		func(cfg *raftclusterer.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
 	}}
 	for i, test := range tests {
 		c.Logf("test #%d (%s)", i, test.expect)
@@ -292,6 +300,32 @@ func (s *WorkerSuite) TestRequestsDetails(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestPublishesHealth(c *gc.C) {
+	health := make(chan controllermsg.RaftHealthMessage, 10)
+	unsubscribe, err := s.hub.Subscribe(
+		controllermsg.RaftHealthTopic,
+		func(topic string, msg controllermsg.RaftHealthMessage, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			health <- msg
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	err = s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case msg := <-health:
+		c.Assert(msg.Leader, gc.Equals, "0")
+		c.Assert(msg.Term, gc.Not(gc.Equals), uint64(0))
+		c.Assert(msg.Servers, gc.HasLen, 1)
+		c.Assert(msg.Servers[0].ID, gc.Equals, "0")
+		c.Assert(msg.Servers[0].Suffrage, gc.Equals, "Voter")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for raft health")
+	}
+}
+
 func (s *WorkerSuite) TestDemotesAServerWhenThereAre2(c *gc.C) {
 	// Create 3 servers: 0, 1 and 2, where all servers can connect
 	// bidirectionally.
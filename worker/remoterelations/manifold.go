@@ -70,6 +70,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		RelationsFacade:          facade,
 		NewRemoteModelFacadeFunc: remoteRelationsFacadeForModelFunc(config.NewControllerConnection),
 		Clock:                    clock.WallClock,
+		ControllerUUID:           agent.CurrentConfig().Controller().Id(),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
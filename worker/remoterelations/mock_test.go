@@ -32,6 +32,7 @@ type mockRelationsFacade struct {
 	relationsEndpoints                 map[string]*relationEndpointInfo
 	relationsUnitsWatchers             map[string]*mockRelationUnitsWatcher
 	controllerInfo                     map[string]*api.Info
+	egressSubnets                      []string
 }
 
 func newMockRelationsFacade(stub *testing.Stub) *mockRelationsFacade {
@@ -245,6 +246,14 @@ func (m *mockRelationsFacade) ControllerAPIInfoForModel(modelUUID string) (*api.
 	return m.controllerInfo[modelUUID], nil
 }
 
+func (m *mockRelationsFacade) ModelEgressSubnets() ([]string, error) {
+	m.stub.MethodCall(m, "ModelEgressSubnets")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.egressSubnets, nil
+}
+
 func (m *mockRelationsFacade) SetRemoteApplicationStatus(applicationName string, status status.Status, message string) error {
 	m.stub.MethodCall(m, "SetRemoteApplicationStatus", applicationName, status.String(), message)
 	return nil
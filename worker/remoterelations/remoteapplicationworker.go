@@ -28,6 +28,7 @@ type remoteApplicationWorker struct {
 	offerUUID             string
 	applicationName       string // name of the remote application proxy in the local model
 	localModelUUID        string // uuid of the model hosting the local application
+	localControllerUUID   string // uuid of the controller hosting the local model
 	remoteModelUUID       string // uuid of the model hosting the remote offer
 	isConsumerProxy       bool
 	localRelationChanges  chan params.RemoteRelationChangeEvent
@@ -516,6 +517,12 @@ func (w *remoteApplicationWorker) registerRemoteRelation(
 	}
 	relationToken = results[1].Token
 
+	// The offer may only be consumable from specific networks.
+	consumerNetworks, err := w.localModelFacade.ModelEgressSubnets()
+	if err != nil {
+		return fail(errors.Annotate(err, "getting model egress subnets"))
+	}
+
 	// This data goes to the remote model so we map local info
 	// from this model to the remote arg values and visa versa.
 	arg := params.RegisterRemoteRelationArg{
@@ -525,6 +532,9 @@ func (w *remoteApplicationWorker) registerRemoteRelation(
 		OfferUUID:         offerUUID,
 		RemoteEndpoint:    localEndpointInfo,
 		LocalEndpointName: remoteEndpointName,
+
+		ConsumerControllerUUID: w.localControllerUUID,
+		ConsumerNetworks:       consumerNetworks,
 	}
 	if w.offerMacaroon != nil {
 		arg.Macaroons = macaroon.Slice{w.offerMacaroon}
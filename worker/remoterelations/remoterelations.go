@@ -109,6 +109,9 @@ type RemoteRelationsFacade interface {
 
 	// SetRemoteApplicationStatus sets the status for the specified remote application.
 	SetRemoteApplicationStatus(applicationName string, status status.Status, message string) error
+
+	// ModelEgressSubnets returns the egress subnets configured for the local model.
+	ModelEgressSubnets() ([]string, error)
 }
 
 type newRemoteRelationsFacadeFunc func(*api.Info) (RemoteModelRelationsFacadeCloser, error)
@@ -119,6 +122,10 @@ type Config struct {
 	RelationsFacade          RemoteRelationsFacade
 	NewRemoteModelFacadeFunc newRemoteRelationsFacadeFunc
 	Clock                    clock.Clock
+
+	// ControllerUUID identifies the controller hosting the model to
+	// offers that restrict consumption to specific controllers.
+	ControllerUUID string
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -247,6 +254,7 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 				offerUUID:                         remoteApp.OfferUUID,
 				applicationName:                   remoteApp.Name,
 				localModelUUID:                    w.config.ModelUUID,
+				localControllerUUID:               w.config.ControllerUUID,
 				remoteModelUUID:                   remoteApp.ModelUUID,
 				isConsumerProxy:                   remoteApp.IsConsumerProxy,
 				offerMacaroon:                     remoteApp.Macaroon,
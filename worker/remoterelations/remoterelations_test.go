@@ -216,7 +216,7 @@ func (s *remoteRelationsSuite) TestRemoteNotFoundTerminatesOnChange(c *gc.C) {
 	defer workertest.CleanKill(c, w)
 
 	s.stub.ResetCalls()
-	s.stub.SetErrors(nil, nil, params.Error{Code: params.CodeNotFound})
+	s.stub.SetErrors(nil, nil, nil, params.Error{Code: params.CodeNotFound})
 
 	s.relationsFacade.relationsEndpoints["db2:db django:db"] = &relationEndpointInfo{
 		localApplicationName: "django",
@@ -238,6 +238,7 @@ func (s *remoteRelationsSuite) TestRemoteNotFoundTerminatesOnChange(c *gc.C) {
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -284,6 +285,7 @@ func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Work
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -345,7 +347,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsRevoked(c *gc.C) {
 	w := s.assertRemoteApplicationWorkers(c)
 	defer workertest.CleanKill(c, w)
 	s.stub.ResetCalls()
-	s.stub.SetErrors(nil, nil, &params.Error{
+	s.stub.SetErrors(nil, nil, nil, &params.Error{
 		Code:    params.CodeDischargeRequired,
 		Message: "message",
 	})
@@ -370,6 +372,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsRevoked(c *gc.C) {
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -426,6 +429,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationsDying(c *gc.C) {
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -668,6 +672,7 @@ func (s *remoteRelationsSuite) assertRemoteRelationsChangedError(c *gc.C, dying
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -783,6 +788,7 @@ func (s *remoteRelationsSuite) TestRemoteRelationSuspended(c *gc.C) {
 		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
 		{"ExportEntities", []interface{}{
 			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"ModelEgressSubnets", nil},
 		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
 			ApplicationToken: "token-django",
 			SourceModelTag:   "model-local-model-uuid",
@@ -12,9 +12,19 @@ import (
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending or attached
+// filesystems; the latter so that filesystems are grown when their
+// backing volumes are resized.
 func machineBlockDevicesChanged(ctx *context) error {
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
+	addVolumeTag := func(tag names.VolumeTag) {
+		for _, existing := range volumeTags {
+			if tag == existing {
+				return
+			}
+		}
+		volumeTags = append(volumeTags, tag)
+	}
 	// We must query volumes for both incomplete filesystems
 	// and incomplete filesystem attachments, because even
 	// though a filesystem attachment cannot exist without a
@@ -46,16 +56,14 @@ func machineBlockDevicesChanged(ctx *context) error {
 			// Backing-volume's block device is already attached.
 			continue
 		}
-		var found bool
-		for _, tag := range volumeTags {
-			if filesystem.Volume == tag {
-				found = true
-				break
-			}
-		}
-		if !found {
-			volumeTags = append(volumeTags, filesystem.Volume)
+		addVolumeTag(filesystem.Volume)
+	}
+	for _, attachment := range ctx.filesystemAttachments {
+		filesystem, ok := ctx.filesystems[attachment.Filesystem]
+		if !ok || filesystem.Volume == (names.VolumeTag{}) {
+			continue
 		}
+		addVolumeTag(filesystem.Volume)
 	}
 	if len(volumeTags) == 0 {
 		return nil
@@ -131,5 +139,5 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 			)
 		}
 	}
-	return nil
+	return growFilesystems(ctx, volumeTags)
 }
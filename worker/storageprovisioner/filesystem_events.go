@@ -510,5 +510,6 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 		Filesystem:   filesystemTag,
 		FilesystemId: in.FilesystemId,
 		Path:         in.MountPoint,
+		MountOptions: in.MountOptions,
 	}, nil
 }
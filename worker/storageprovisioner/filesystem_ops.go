@@ -182,6 +182,110 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	if err := setFilesystemAttachmentInfo(ctx, filesystemAttachments); err != nil {
 		return errors.Trace(err)
 	}
+	// Backing volumes may have been resized while the
+	// filesystems were detached, or the agent was down.
+	var volumeTags []names.VolumeTag
+	for _, attachment := range filesystemAttachments {
+		if filesystem, ok := ctx.filesystems[attachment.Filesystem]; ok && filesystem.Volume != (names.VolumeTag{}) {
+			volumeTags = append(volumeTags, filesystem.Volume)
+		}
+	}
+	return growFilesystems(ctx, volumeTags)
+}
+
+// growFilesystems grows the attached filesystems backed by the specified
+// volumes whose block devices are larger than the filesystems, as they
+// are once the volumes have been resized, and records their new sizes.
+func growFilesystems(ctx *context, volumeTags []names.VolumeTag) error {
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		return nil
+	}
+	volumes := make(map[names.VolumeTag]bool)
+	for _, tag := range volumeTags {
+		volumes[tag] = true
+	}
+	var resizeParams []storage.FilesystemAttachmentParams
+	for _, attachment := range ctx.filesystemAttachments {
+		filesystem, ok := ctx.filesystems[attachment.Filesystem]
+		if !ok || !volumes[filesystem.Volume] {
+			continue
+		}
+		blockDevice, ok := ctx.volumeBlockDevices[filesystem.Volume]
+		if !ok || blockDevice.Size <= filesystem.Size {
+			continue
+		}
+		resizeParams = append(resizeParams, storage.FilesystemAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:  attachment.Machine,
+				ReadOnly: attachment.ReadOnly,
+			},
+			Filesystem:   attachment.Filesystem,
+			FilesystemId: filesystem.FilesystemId,
+			Path:         attachment.Path,
+		})
+	}
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	logger.Debugf("growing filesystems: %+v", resizeParams)
+	results, err := resizer.ResizeFilesystems(ctx.config.CloudCallContext, resizeParams)
+	if err != nil {
+		return errors.Annotate(err, "growing filesystems")
+	}
+	var grown []storage.Filesystem
+	var statuses []params.EntityStatusArgs
+	for i, result := range results {
+		p := resizeParams[i]
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    p.Filesystem.String(),
+			Status: status.Attached.String(),
+		})
+		if result.Error != nil {
+			// The filesystem remains usable at its old size; we
+			// try again when the block devices next change.
+			statuses[len(statuses)-1].Info = errors.Annotate(result.Error, "growing filesystem").Error()
+			logger.Warningf("failed to grow %s: %v", names.ReadableString(p.Filesystem), result.Error)
+			continue
+		}
+		grown = append(grown, *result.Filesystem)
+	}
+	setStatus(ctx, statuses)
+	if len(grown) == 0 {
+		return nil
+	}
+
+	// Record the new sizes of the grown filesystems, preserving
+	// the information recorded in state when they were created.
+	tags := make([]names.FilesystemTag, len(grown))
+	for i, filesystem := range grown {
+		tags[i] = filesystem.Tag
+	}
+	filesystemResults, err := ctx.config.Filesystems.Filesystems(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem information")
+	}
+	filesystems := make([]params.Filesystem, len(grown))
+	for i, result := range filesystemResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting information for filesystem %s", tags[i].Id())
+		}
+		filesystems[i] = result.Result
+		filesystems[i].Info.Size = grown[i].Size
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystems)
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing filesystem %s to state",
+				tags[i].Id(),
+			)
+		}
+		ctx.filesystems[tags[i]] = grown[i]
+	}
 	return nil
 }
 
@@ -526,6 +630,7 @@ func filesystemAttachmentsFromStorage(in []storage.FilesystemAttachment) []param
 			params.FilesystemAttachmentInfo{
 				f.Path,
 				f.ReadOnly,
+				f.MountOptions,
 			},
 		}
 	}
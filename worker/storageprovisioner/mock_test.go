@@ -136,6 +136,7 @@ func (w *mockAttachmentPlansWatcher) Changes() watcher.MachineStorageIdsChannel
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	attachmentPlansWatcher *mockAttachmentPlansWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
@@ -143,6 +144,7 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	pendingResizes         map[string]uint64
 
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -164,6 +166,10 @@ func (w *mockVolumeAccessor) WatchVolumes(names.Tag) (watcher.StringsWatcher, er
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments(names.Tag) (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -266,6 +272,25 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.pendingResizes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: params.ResizeVolumeParams{
+			Provider: "dummy",
+			VolumeId: v.provisionedVolumes[tag.String()].Info.VolumeId,
+			Size:     size,
+		}})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -319,6 +344,7 @@ func (v *mockVolumeAccessor) VolumeAttachmentPlans([]params.MachineStorageId) ([
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		attachmentPlansWatcher: newMockAttachmentPlansWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
@@ -326,6 +352,7 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		pendingResizes:         make(map[string]uint64),
 	}
 }
 
@@ -552,6 +579,7 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	releaseVolumesFunc           func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
@@ -637,6 +665,14 @@ func (s *dummyVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volu
 	return make([]error, len(volumeIds)), nil
 }
 
+// ResizeVolumes resizes volumes.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]error, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	return make([]error, len(params)), nil
+}
+
 // AttachVolumes attaches volumes to machines.
 func (s *dummyVolumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	if s.provider != nil && s.provider.attachVolumesFunc != nil {
@@ -817,6 +853,16 @@ func (s *mockManagedFilesystemSource) AttachFilesystems(ctx context.ProviderCall
 	return results, nil
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem := s.filesystems[arg.Filesystem]
+		filesystem.Size = s.blockDevices[filesystem.Volume].Size
+		results[i].Filesystem = &filesystem
+	}
+	return results, nil
+}
+
 func (s *mockManagedFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemAttachmentParams) ([]error, error) {
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
@@ -64,6 +64,10 @@ type VolumeAccessor interface {
 	// initialization of the attachment, such as logging into the iSCSI target
 	WatchVolumeAttachmentPlans(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to model-scoped volumes,
+	// so that requests to resize them may be acted upon.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -85,6 +89,10 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -217,6 +225,7 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
@@ -287,6 +296,22 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges = volumesWatcher.Changes()
 	}
 
+	// Only the model-scoped provisioner resizes volumes; resizing
+	// storage in CAAS models is rejected by the API server.
+	if _, ok := w.config.Scope.(names.ModelTag); ok {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if params.IsCodeNotImplemented(err) {
+			logger.Debugf("controller does not support resizing volumes")
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+	}
+
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems(w.config.Scope)
 	if err != nil {
 		return errors.Annotate(err, "watching filesystems")
@@ -331,6 +356,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -384,6 +416,7 @@ func processSchedule(ctx *context) error {
 	ready := ctx.schedule.Ready(ctx.config.Clock.Now())
 	createVolumeOps := make(map[names.VolumeTag]*createVolumeOp)
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
@@ -398,6 +431,8 @@ func processSchedule(ctx *context) error {
 			createVolumeOps[key.(names.VolumeTag)] = op
 		case *removeVolumeOp:
 			removeVolumeOps[key.(names.VolumeTag)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
 		case *attachVolumeOp:
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
@@ -422,6 +457,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(detachVolumeOps) > 0 {
 		if err := detachVolumes(ctx, detachVolumeOps); err != nil {
 			return errors.Annotate(err, "detaching volumes")
@@ -1119,6 +1119,73 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestGrowVolumeBackedFilesystem(c *gc.C) {
+	attachmentInfoSet := make(chan interface{})
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(attachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		attachmentInfoSet <- attachments
+		return nil, nil
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return nil, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+
+	blockDeviceId := params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}
+	args.volumes.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       123,
+	}
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag:    "machine-0",
+		AttachmentTag: "filesystem-0-0",
+	}}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+	waitChannel(c, attachmentInfoSet, "waiting for filesystem attachment info to be set")
+
+	// The backing volume is resized, and its block device grows.
+	args.volumes.blockDevices[blockDeviceId] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	args.volumes.blockDevicesWatcher.changes <- struct{}{}
+
+	info := waitChannel(
+		c, filesystemInfoSet, "waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(info, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         246,
+		},
+	}})
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
@@ -1690,6 +1757,96 @@ func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	resizeVolume := names.NewVolumeTag("1")
+	otherVolume := names.NewVolumeTag("2")
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(resizeVolume)
+	volumeAccessor.provisionVolume(otherVolume)
+	volumeAccessor.pendingResizes[resizeVolume.String()] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(params []storage.VolumeResizeParams) ([]error, error) {
+		resizedChan <- params
+		return make([]error, len(params)), nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{
+		resizeVolume.Id(),
+		otherVolume.Id(),
+	}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      resizeVolume,
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(volume)
+	volumeAccessor.pendingResizes[volume.String()] = 2048
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(params []storage.VolumeResizeParams) ([]error, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []error{errors.New("badness")}, nil
+		}
+		return []error{nil}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{
+		volumes:  volumeAccessor,
+		clock:    clock,
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{volume.Id()}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(resizeVolumeTimes[1].Sub(resizeVolumeTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(resizeVolumeTimes[2].Sub(resizeVolumeTimes[1]), gc.Equals, time.Minute)
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+	})
+}
+
 func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	unprovisionedFilesystem := names.NewFilesystemTag("0")
 	provisionedDestroyFilesystem := names.NewFilesystemTag("1")
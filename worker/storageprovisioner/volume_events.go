@@ -55,6 +55,43 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when model-scoped volumes, which may
+// have been requested to be resized, have been seen to have changed.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		tag := tags[i]
+		// Any previously scheduled resize is superseded.
+		ctx.schedule.Remove(resizeVolumeKey{tag})
+		if params.IsCodeNotFound(result.Error) {
+			// The volume has been removed, or
+			// there is no resize pending for it.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting resize parameters for volume %s", tag.Id())
+		}
+		logger.Debugf("volume %s is to be resized to %dMiB", tag.Id(), result.Result.Size)
+		ops = append(ops, &resizeVolumeOp{
+			provider: storage.ProviderType(result.Result.Provider),
+			args: storage.VolumeResizeParams{
+				Tag:      tag,
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+		})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 func sortVolumeAttachmentPlans(ctx *context, ids []params.MachineStorageId) (
 	alive, dying, dead []params.VolumeAttachmentPlanResult, err error) {
 	plans, err := ctx.config.Volumes.VolumeAttachmentPlans(ids)
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -324,6 +326,107 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes grows volumes to the sizes they have been requested
+// to be resized to, and records their new sizes in state.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for tag, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      tag,
+			Provider: op.provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var resized []names.VolumeTag
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, volumeParams)
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// The provider cannot resize volumes, so there
+			// is no point in retrying.
+			for _, p := range volumeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   fmt.Sprintf("storage provider %q does not support resizing volumes", p.Provider),
+				})
+			}
+			continue
+		}
+		resizeParams := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		errs, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, err := range errs {
+			tag := resizeParams[i].Tag
+			if err == nil {
+				resized = append(resized, tag)
+				continue
+			}
+			// Failed to resize volume; reschedule and update status.
+			reschedule = append(reschedule, ops[tag])
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotate(err, "resizing volume").Error(),
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// Record the new sizes of the resized volumes. State
+	// completes the resize once the new size is recorded.
+	volumeResults, err := ctx.config.Volumes.Volumes(resized)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting information for volume %s", resized[i].Id())
+		}
+		volumes[i] = result.Result
+		if size := ops[resized[i]].args.Size; volumes[i].Info.Size < size {
+			volumes[i].Info.Size = size
+		}
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				resized[i].Id(),
+				result.Error,
+			)
+			continue
+		}
+		volume, err := volumeFromParams(volumes[i])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		updateVolume(ctx, volume)
+	}
+	return nil
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -552,6 +655,22 @@ func (op *removeVolumeOp) key() interface{} {
 	return op.tag
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.VolumeResizeParams
+}
+
+// resizeVolumeKey is the schedule key for resizeVolumeOp, which is
+// distinct from the keys of the other operations on the volume.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -6,6 +6,7 @@ package uniter
 import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/statusbuffer"
 )
 
 // setAgentStatus sets the unit's status if it has changed since last time this method was called.
@@ -18,7 +19,19 @@ func setAgentStatus(u *Uniter, agentStatus status.Status, info string, data map[
 	u.lastReportedStatus = agentStatus
 	u.lastReportedMessage = info
 	logger.Debugf("[AGENT-STATUS] %s: %s", agentStatus, info)
-	return u.unit.SetAgentStatus(agentStatus, info, data)
+	if u.statusBuffer == nil {
+		return u.unit.SetAgentStatus(agentStatus, info, data)
+	}
+	// Any status that could not be sent while the controller was
+	// unreachable is sent first; if the controller still cannot be
+	// reached, this status is kept to be sent with it later.
+	return u.statusBuffer.Send(u.unit, statusbuffer.Entry{
+		Kind:   statusbuffer.Agent,
+		Status: agentStatus,
+		Info:   info,
+		Data:   data,
+		Since:  u.clock.Now(),
+	})
 }
 
 // reportAgentError reports if there was an error performing an agent operation.
@@ -0,0 +1,55 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+func NewParallelActionDispatcher(
+	charmDir string,
+	stateFile string,
+	lock *sync.RWMutex,
+	addWorker func(worker.Worker) error,
+	failAction func(actionId, message string) error,
+) operation.ActionDispatcher {
+	return &parallelActionDispatcher{
+		charmDir:   charmDir,
+		stateFile:  stateFile,
+		lock:       lock,
+		addWorker:  addWorker,
+		failAction: failAction,
+	}
+}
+
+func FailInterruptedParallelActions(d operation.ActionDispatcher) error {
+	return d.(*parallelActionDispatcher).failInterrupted()
+}
+
+func NewCharmDirLockingDeployer(deployer charm.Deployer, lock *sync.RWMutex) charm.Deployer {
+	return &charmDirLockingDeployer{Deployer: deployer, lock: lock}
+}
+
+func NewOperationTracer(clk clock.Clock) (operation.Tracer, func() map[string]interface{}) {
+	t := newOperationTracer(clk)
+	return t, t.report
+}
+
+func NewHookTimeout(st modelConfigGetter, timeout time.Duration) (watcher.NotifyHandler, func() time.Duration) {
+	h := &hookTimeout{st: st, timeout: timeout}
+	return h, h.get
+}
+
+var (
+	NewOutputTruncationsCounter = newOutputTruncationsCounter
+	RegisterOutputTruncations   = registerOutputTruncations
+)
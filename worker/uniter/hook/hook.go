@@ -16,6 +16,10 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PreDestroy is run on the leader unit of an application that is
+	// being destroyed, before any of its units begin to tear down.
+	PreDestroy hooks.Kind = "pre-destroy"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -58,7 +62,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, PreDestroy:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// pendingHooks returns the hooks that the unit is waiting to run, in
+// the order the uniter resolver will run them, given the local and
+// remote state. Relation hooks are queued by the relations resolver,
+// and are only included once they have become the current operation.
+func pendingHooks(localState resolver.LocalState, remoteState remotestate.Snapshot) []string {
+	var pending []string
+	add := func(kind hooks.Kind) {
+		for _, p := range pending {
+			if p == string(kind) {
+				return
+			}
+		}
+		pending = append(pending, string(kind))
+	}
+
+	if localState.Kind == operation.RunHook && localState.Step != operation.Done && localState.Hook != nil {
+		add(localState.Hook.Kind)
+	}
+	if remoteState.Life == params.Dying {
+		if localState.Started {
+			add(hooks.Stop)
+		}
+		return pending
+	}
+	if !localState.Installed && !localState.Started {
+		add(hooks.Install)
+		return pending
+	}
+	if localState.Kind == operation.Upgrade ||
+		(localState.CharmURL != nil && charmModified(localState, remoteState)) {
+		add(hooks.UpgradeCharm)
+	}
+	if remoteState.Leader && !localState.Leader {
+		add(hooks.LeaderElected)
+	} else if !remoteState.Leader && localState.LeaderSettingsVersion != remoteState.LeaderSettingsVersion {
+		add(hooks.LeaderSettingsChanged)
+	}
+	if localState.ConfigHash != remoteState.ConfigHash ||
+		localState.TrustHash != remoteState.TrustHash ||
+		localState.AddressesHash != remoteState.AddressesHash {
+		add(hooks.ConfigChanged)
+	}
+	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
+		add(hooks.UpdateStatus)
+	}
+	return pending
+}
+
+// reportPendingHooks reports the hooks that the unit is waiting to run,
+// if they have changed since they were last reported. Failing to report
+// them must not stop the uniter, so errors are only logged.
+func (s *uniterResolver) reportPendingHooks(localState resolver.LocalState, remoteState remotestate.Snapshot) {
+	if s.config.ReportPendingHooks == nil {
+		return
+	}
+	pending := pendingHooks(localState, remoteState)
+	if s.hooksReported && stringsEqual(pending, s.reportedHooks) {
+		return
+	}
+	if err := s.config.ReportPendingHooks(pending); err != nil {
+		logger.Warningf("cannot report pending hooks: %v", err)
+		return
+	}
+	s.reportedHooks = pending
+	s.hooksReported = true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// reportPendingHooks records the hooks that the unit is waiting
+// to run in the controller, so that they can be shown to users.
+func (u *Uniter) reportPendingHooks(pending []string) error {
+	err := u.unit.SetPendingHooks(pending)
+	if errors.IsNotSupported(err) {
+		logger.Tracef("not reporting pending hooks: %v", err)
+		return nil
+	}
+	return errors.Trace(err)
+}
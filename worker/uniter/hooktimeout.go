@@ -0,0 +1,65 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// modelConfigGetter provides the model config, and notifies of
+// changes to it.
+type modelConfigGetter interface {
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
+
+// hookTimeout holds the model's hook-timeout. It is a NotifyHandler,
+// so that running it in a NotifyWorker keeps the timeout up to date
+// with the model config, and changes apply to the next hook run
+// without restarting the uniter.
+type hookTimeout struct {
+	st modelConfigGetter
+
+	mu      sync.Mutex
+	timeout time.Duration
+}
+
+// get returns the current hook timeout.
+func (h *hookTimeout) get() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.timeout
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *hookTimeout) SetUp() (watcher.NotifyWatcher, error) {
+	return h.st.WatchForModelConfigChanges()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *hookTimeout) Handle(_ <-chan struct{}) error {
+	modelConfig, err := h.st.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "reading hook timeout")
+	}
+	timeout := modelConfig.HookTimeout()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if timeout != h.timeout {
+		logger.Infof("hook timeout changed to %v", timeout)
+		h.timeout = timeout
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *hookTimeout) TearDown() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type HookTimeoutSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HookTimeoutSuite{})
+
+func (s *HookTimeoutSuite) TestFollowsModelConfig(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	st := &fakeModelConfigGetter{
+		watcher: watchertest.NewMockNotifyWatcher(changes),
+		config:  coretesting.CustomModelConfig(c, coretesting.Attrs{"hook-timeout": "5m"}),
+	}
+	handler, get := uniter.NewHookTimeout(st, time.Hour)
+	c.Assert(get(), gc.Equals, time.Hour)
+
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{Handler: handler})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	changes <- struct{}{}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if get() == 5*time.Minute {
+			return
+		}
+	}
+	c.Fatalf("hook timeout not updated, still %v", get())
+}
+
+type fakeModelConfigGetter struct {
+	watcher watcher.NotifyWatcher
+	config  *config.Config
+}
+
+func (f *fakeModelConfigGetter) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+func (f *fakeModelConfigGetter) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
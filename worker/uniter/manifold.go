@@ -6,6 +6,7 @@ package uniter
 import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -33,6 +34,10 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// PrometheusRegisterer, if set, is used to register
+	// the uniter's metrics.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -105,6 +110,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+				PrometheusRegisterer: manifoldConfig.PrometheusRegisterer,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
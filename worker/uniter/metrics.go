@@ -0,0 +1,45 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju_uniter"
+)
+
+// newOutputTruncationsCounter returns a counter of the number of times
+// that hook or action output has been truncated, by kind of output.
+func newOutputTruncationsCounter() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "hook_output_truncations_total",
+		Help:      "The number of times hook or action output exceeded the model's hook-output-limit.",
+	}, []string{"kind"})
+}
+
+// registerOutputTruncations registers the output truncations counter,
+// returning the counter to use and a function that undoes the
+// registration. Uniters in the same agent share a registerer, so if
+// another uniter has already registered the counter, that counter is
+// returned and left registered. Other registration errors are logged,
+// as the uniter can run without its metrics.
+func registerOutputTruncations(
+	registerer prometheus.Registerer,
+	counter *prometheus.CounterVec,
+) (*prometheus.CounterVec, func()) {
+	err := registerer.Register(counter)
+	if err == nil {
+		return counter, func() { registerer.Unregister(counter) }
+	}
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		if existing, ok := are.ExistingCollector.(*prometheus.CounterVec); ok {
+			return existing, func() {}
+		}
+	}
+	logger.Warningf("registering hook output truncations counter failed: %v", err)
+	return counter, func() {}
+}
@@ -0,0 +1,39 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+)
+
+type MetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) TestRegisterOutputTruncationsShared(c *gc.C) {
+	registry := prometheus.NewRegistry()
+	first, unregisterFirst := uniter.RegisterOutputTruncations(
+		registry, uniter.NewOutputTruncationsCounter(),
+	)
+	second, unregisterSecond := uniter.RegisterOutputTruncations(
+		registry, uniter.NewOutputTruncationsCounter(),
+	)
+	c.Assert(second, gc.Equals, first)
+
+	// The second uniter must not unregister the first's counter.
+	unregisterSecond()
+	err := registry.Register(uniter.NewOutputTruncationsCounter())
+	c.Assert(err, gc.FitsTypeOf, prometheus.AlreadyRegisteredError{})
+
+	unregisterFirst()
+	err = registry.Register(uniter.NewOutputTruncationsCounter())
+	c.Assert(err, jc.ErrorIsNil)
+}
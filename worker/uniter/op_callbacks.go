@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -63,10 +64,28 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreDestroy:
+		return opc.setPreDestroyed()
 	}
 	return nil
 }
 
+// setPreDestroyed tells the other units of the application that the
+// pre-destroy hook has been run, so that they may begin to tear down.
+// The hook is skipped if the unit is no longer the leader, in which
+// case the new leader runs it instead.
+func (opc *operationCallbacks) setPreDestroyed() error {
+	if !opc.u.leadershipTracker.ClaimLeader().Wait() {
+		return nil
+	}
+	err := opc.u.st.LeadershipSettings.Merge(
+		opc.u.unit.ApplicationName(),
+		opc.u.unit.Name(),
+		map[string]string{remotestate.PreDestroyedSetting: "true"},
+	)
+	return errors.Annotate(err, "recording pre-destroy hook completion")
+}
+
 func notifyHook(hook string, ctx runner.Context, method func(string)) {
 	if r, err := ctx.HookRelation(); err == nil {
 		remote, _ := ctx.RemoteUnitName()
@@ -4,6 +4,9 @@
 package operation
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -13,6 +16,10 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
+// DefaultPreDestroyTimeout is how long the pre-destroy hook may run
+// if no other timeout is configured.
+const DefaultPreDestroyTimeout = 5 * time.Minute
+
 // FactoryParams holds all the necessary parameters for a new operation factory.
 type FactoryParams struct {
 	Deployer       charm.Deployer
@@ -20,14 +27,73 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// SnapshotPaths holds the directories containing charm and unit
+	// state that are captured before hooks created with
+	// NewRunHookWithSnapshot are run.
+	SnapshotPaths []string
+
+	// SnapshotDir is the directory in which copies of SnapshotPaths
+	// are kept between hooks, so that only the files that have changed
+	// since the last snapshot need to be copied. If empty, no snapshots
+	// are taken.
+	SnapshotDir string
+
+	// HookTimeout returns how long a hook may run before it is
+	// killed. It is called for each hook, so that changes to the
+	// timeout apply to the next hook run. If it is nil or returns
+	// zero, hooks may run indefinitely.
+	HookTimeout func() time.Duration
+
+	// PreDestroyTimeout is how long the pre-destroy hook may run before
+	// it is killed and the unit carries on tearing down. If zero,
+	// DefaultPreDestroyTimeout is used.
+	PreDestroyTimeout time.Duration
+
+	// Recorder, if not nil, is notified of the execution of every
+	// operation created by the factory.
+	Recorder Recorder
+
+	// Tracer, if not nil, is notified as every operation created by
+	// the factory starts and finishes its Prepare, Execute and Commit
+	// phases.
+	Tracer Tracer
+
+	// Clock is used to time operations reported to the Recorder and
+	// Tracer. If nil, the wall clock is used.
+	Clock clock.Clock
+
+	// ActionDispatcher, if not nil, is offered every action before it is
+	// run, so that actions the charm declares as parallel may be run
+	// concurrently with other operations.
+	ActionDispatcher ActionDispatcher
+
+	// Bundles and CharmDir are used by the juju-upgrade-preview action
+	// to read the charm archives to compare, and to identify the deployed
+	// charm. If Bundles is nil, upgrade previews are not supported.
+	Bundles  charm.BundleReader
+	CharmDir string
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
-	return &factory{
+	f := &factory{
 		config: params,
 	}
+	if params.Recorder == nil && params.Tracer == nil {
+		return f
+	}
+	clk := params.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	return &recordingFactory{
+		Factory:  f,
+		recorder: params.Recorder,
+		tracer:   params.Tracer,
+		clock:    clk,
+	}
 }
 
 type factory struct {
@@ -89,13 +155,38 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 	if err := hookInfo.Validate(); err != nil {
 		return nil, err
 	}
+	var timeout time.Duration
+	if f.config.HookTimeout != nil {
+		timeout = f.config.HookTimeout()
+	}
+	if hookInfo.Kind == hook.PreDestroy {
+		// The pre-destroy hook must not be allowed to hold up the
+		// removal of the application indefinitely.
+		timeout = f.config.PreDestroyTimeout
+		if timeout == 0 {
+			timeout = DefaultPreDestroyTimeout
+		}
+	}
 	return &runHook{
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		timeout:       timeout,
 	}, nil
 }
 
+// NewRunHookWithSnapshot is part of the Factory interface.
+func (f *factory) NewRunHookWithSnapshot(hookInfo hook.Info) (Operation, error) {
+	op, err := f.NewRunHook(hookInfo)
+	if err != nil {
+		return nil, err
+	}
+	rh := op.(*runHook)
+	rh.snapshotDir = f.config.SnapshotDir
+	rh.snapshotPaths = f.config.SnapshotPaths
+	return rh, nil
+}
+
 // NewSkipHook is part of the Factory interface.
 func (f *factory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
 	hookOp, err := f.NewRunHook(hookInfo)
@@ -114,6 +205,23 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		dispatcher:    f.config.ActionDispatcher,
+		newPreflight:  f.newPreflightUpgrade,
+	}, nil
+}
+
+// newPreflightUpgrade returns a preflightUpgrade that compares the
+// supplied charm with the deployed charm.
+func (f *factory) newPreflightUpgrade(charmURL *corecharm.URL) (*preflightUpgrade, error) {
+	if f.config.Bundles == nil {
+		return nil, errors.NotSupportedf("upgrade preview")
+	}
+	return &preflightUpgrade{
+		charmURL:  charmURL,
+		charmDir:  f.config.CharmDir,
+		callbacks: f.config.Callbacks,
+		bundles:   f.config.Bundles,
+		abort:     f.config.Abort,
 	}, nil
 }
 
@@ -98,6 +98,12 @@ type Factory interface {
 	// NewRunHook creates an operation to execute the supplied hook.
 	NewRunHook(hookInfo hook.Info) (Operation, error)
 
+	// NewRunHookWithSnapshot creates an operation to execute the supplied
+	// hook. If the charm's rollback-on-error setting is true, charm and
+	// unit state are captured beforehand, and restored if the hook fails
+	// before the unit enters an error state.
+	NewRunHookWithSnapshot(hookInfo hook.Info) (Operation, error)
+
 	// NewSkipHook creates an operation to mark the supplied hook as
 	// completed successfully, without executing the hook.
 	NewSkipHook(hookInfo hook.Info) (Operation, error)
@@ -187,3 +193,13 @@ type StorageUpdater interface {
 	// with the specified tags.
 	UpdateStorage([]names.StorageTag) error
 }
+
+// ActionDispatcher runs actions concurrently with the operations run by an
+// Executor, so that long-running actions need not block hooks.
+type ActionDispatcher interface {
+	// Dispatch starts running the named action, using the supplied runner,
+	// in the background if the charm declares that the action may be run
+	// in parallel. It reports whether the action was dispatched; if not,
+	// the action must be run by the caller.
+	Dispatch(actionName string, rnr runner.Runner) (bool, error)
+}
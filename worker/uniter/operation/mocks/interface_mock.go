@@ -264,6 +264,19 @@ func (mr *MockFactoryMockRecorder) NewRunHook(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRunHook", reflect.TypeOf((*MockFactory)(nil).NewRunHook), arg0)
 }
 
+// NewRunHookWithSnapshot mocks base method
+func (m *MockFactory) NewRunHookWithSnapshot(arg0 hook.Info) (operation.Operation, error) {
+	ret := m.ctrl.Call(m, "NewRunHookWithSnapshot", arg0)
+	ret0, _ := ret[0].(operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRunHookWithSnapshot indicates an expected call of NewRunHookWithSnapshot
+func (mr *MockFactoryMockRecorder) NewRunHookWithSnapshot(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRunHookWithSnapshot", reflect.TypeOf((*MockFactory)(nil).NewRunHookWithSnapshot), arg0)
+}
+
 // NewSkipHook mocks base method
 func (m *MockFactory) NewSkipHook(arg0 hook.Info) (operation.Operation, error) {
 	ret := m.ctrl.Call(m, "NewSkipHook", arg0)
@@ -0,0 +1,210 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	corecharm "gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/worker/uniter/charm"
+)
+
+// CharmDelta describes the differences between the charm deployed
+// for a unit and a charm that the unit could be upgraded to.
+type CharmDelta struct {
+	// AddedFiles, RemovedFiles and ModifiedFiles hold the
+	// slash-separated paths of the files that the upgrade
+	// would add, remove or change.
+	AddedFiles    []string
+	RemovedFiles  []string
+	ModifiedFiles []string
+
+	// AddedOptions, RemovedOptions and ChangedOptions hold the
+	// names of the config options that the upgrade would add,
+	// remove, or change the type or default value of.
+	AddedOptions   []string
+	RemovedOptions []string
+	ChangedOptions []string
+}
+
+// Results returns the delta in a form suitable for recording as the
+// results of an action. Each list of paths or option names is recorded
+// one per line.
+func (d CharmDelta) Results() map[string]string {
+	result := make(map[string]string)
+	add := func(key string, values []string) {
+		if len(values) > 0 {
+			result[key] = strings.Join(values, "\n")
+		}
+	}
+	add("added-files", d.AddedFiles)
+	add("removed-files", d.RemovedFiles)
+	add("modified-files", d.ModifiedFiles)
+	add("added-options", d.AddedOptions)
+	add("removed-options", d.RemovedOptions)
+	add("changed-options", d.ChangedOptions)
+	return result
+}
+
+// preflightUpgrade stages a charm and reports how it differs from the
+// deployed charm, without deploying it. It's used to run the predefined
+// juju-upgrade-preview action.
+type preflightUpgrade struct {
+	charmURL *corecharm.URL
+	charmDir string
+
+	callbacks Callbacks
+	bundles   charm.BundleReader
+	abort     <-chan struct{}
+}
+
+// delta downloads and verifies both the deployed charm and the charm
+// that would be upgraded to, and returns the differences between them.
+func (p *preflightUpgrade) delta() (CharmDelta, error) {
+	var delta CharmDelta
+	currentURL, err := charm.ReadCharmURL(filepath.Join(p.charmDir, charm.CharmURLPath))
+	if err != nil {
+		return delta, errors.Annotate(err, "cannot read deployed charm URL")
+	}
+	current, err := p.readBundle(currentURL)
+	if err != nil {
+		return delta, errors.Trace(err)
+	}
+	target, err := p.readBundle(p.charmURL)
+	if err != nil {
+		return delta, errors.Trace(err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "preflight-upgrade")
+	if err != nil {
+		return delta, errors.Trace(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	currentDir := filepath.Join(tempDir, "current")
+	if err := current.ExpandTo(currentDir); err != nil {
+		return delta, errors.Annotate(err, "cannot expand deployed charm")
+	}
+	targetDir := filepath.Join(tempDir, "target")
+	if err := target.ExpandTo(targetDir); err != nil {
+		return delta, errors.Annotatef(err, "cannot expand charm %q", p.charmURL)
+	}
+	return charmDelta(currentDir, targetDir)
+}
+
+func (p *preflightUpgrade) readBundle(curl *corecharm.URL) (charm.Bundle, error) {
+	info, err := p.callbacks.GetArchiveInfo(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bundle, err := p.bundles.Read(info, p.abort)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %q", curl)
+	}
+	return bundle, nil
+}
+
+// charmDelta returns the differences between the charms expanded
+// into the supplied directories.
+func charmDelta(currentDir, targetDir string) (CharmDelta, error) {
+	var delta CharmDelta
+	currentFiles, err := fileDigests(currentDir)
+	if err != nil {
+		return delta, errors.Trace(err)
+	}
+	targetFiles, err := fileDigests(targetDir)
+	if err != nil {
+		return delta, errors.Trace(err)
+	}
+	for path, digest := range targetFiles {
+		if currentDigest, ok := currentFiles[path]; !ok {
+			delta.AddedFiles = append(delta.AddedFiles, path)
+		} else if currentDigest != digest {
+			delta.ModifiedFiles = append(delta.ModifiedFiles, path)
+		}
+	}
+	for path := range currentFiles {
+		if _, ok := targetFiles[path]; !ok {
+			delta.RemovedFiles = append(delta.RemovedFiles, path)
+		}
+	}
+
+	currentCharm, err := corecharm.ReadCharmDir(currentDir)
+	if err != nil {
+		return delta, errors.Annotate(err, "cannot read deployed charm")
+	}
+	targetCharm, err := corecharm.ReadCharmDir(targetDir)
+	if err != nil {
+		return delta, errors.Annotate(err, "cannot read new charm")
+	}
+	currentOptions := currentCharm.Config().Options
+	targetOptions := targetCharm.Config().Options
+	for name, option := range targetOptions {
+		if currentOption, ok := currentOptions[name]; !ok {
+			delta.AddedOptions = append(delta.AddedOptions, name)
+		} else if option.Type != currentOption.Type || !reflect.DeepEqual(option.Default, currentOption.Default) {
+			delta.ChangedOptions = append(delta.ChangedOptions, name)
+		}
+	}
+	for name := range currentOptions {
+		if _, ok := targetOptions[name]; !ok {
+			delta.RemovedOptions = append(delta.RemovedOptions, name)
+		}
+	}
+
+	for _, values := range [][]string{
+		delta.AddedFiles, delta.RemovedFiles, delta.ModifiedFiles,
+		delta.AddedOptions, delta.RemovedOptions, delta.ChangedOptions,
+	} {
+		sort.Strings(values)
+	}
+	return delta, nil
+}
+
+// fileDigests returns a digest of the content of every file and
+// symlink under dir, keyed on its slash-separated relative path.
+func fileDigests(dir string) (map[string]string, error) {
+	digests := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		var digest string
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			digest = "symlink:" + target
+		} else {
+			sum, _, err := utils.ReadFileSHA256(path)
+			if err != nil {
+				return err
+			}
+			digest = fmt.Sprintf("%s:%s", info.Mode().Perm(), sum)
+		}
+		digests[filepath.ToSlash(relPath)] = digest
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read files in %q", dir)
+	}
+	return digests, nil
+}
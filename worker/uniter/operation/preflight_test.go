@@ -0,0 +1,224 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	corecharm "gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type PreflightUpgradeSuite struct {
+	testing.IsolationSuite
+
+	charmDir  string
+	callbacks *preflightCallbacks
+	bundles   *fakeBundles
+}
+
+var _ = gc.Suite(&PreflightUpgradeSuite{})
+
+const preflightMetadata = "name: hive\nsummary: hive\ndescription: hive\n"
+
+func (s *PreflightUpgradeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+	err := charm.WriteCharmURL(filepath.Join(s.charmDir, charm.CharmURLPath), curl("cs:quantal/hive-23"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.callbacks = &preflightCallbacks{
+		RunActionCallbacks: RunActionCallbacks{MockFailAction: &MockFailAction{}},
+	}
+	s.bundles = &fakeBundles{bundles: map[string]fakeBundle{
+		"cs:quantal/hive-23": {
+			"metadata.yaml": preflightMetadata,
+			"config.yaml":   "options:\n  foo:\n    type: string\n    default: a\n  bar:\n    type: int\n",
+			"hooks/install": "#!/bin/sh\n",
+			"hooks/stop":    "#!/bin/sh\n",
+		},
+		"cs:quantal/hive-24": {
+			"metadata.yaml": preflightMetadata,
+			"config.yaml":   "options:\n  foo:\n    type: string\n    default: b\n  baz:\n    type: boolean\n",
+			"hooks/install": "#!/bin/sh\necho hi\n",
+			"hooks/start":   "#!/bin/sh\n",
+		},
+	}}
+}
+
+// runPreview runs the juju-upgrade-preview action for the supplied
+// charm URL, returning the context the action ran in.
+func (s *PreflightUpgradeSuite) runPreview(c *gc.C, bundles charm.BundleReader, charmURL string) *previewContext {
+	ctx := &previewContext{
+		MockContext: MockContext{
+			actionData: &context.ActionData{
+				Name:   actions.JujuUpgradePreviewActionName,
+				Params: map[string]interface{}{"charm-url": charmURL},
+			},
+		},
+		results: make(map[string]string),
+	}
+	runnerFactory := &MockRunnerFactory{
+		MockNewActionRunner: &MockNewActionRunner{
+			runner: &MockRunner{
+				MockRunAction: &MockRunAction{err: errors.New("should not call")},
+				context:       ctx,
+			},
+		},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     s.callbacks,
+		Bundles:       bundles,
+		CharmDir:      s.charmDir,
+		// The preview must never be dispatched to run in parallel.
+		ActionDispatcher: &mockActionDispatcher{dispatch: true},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	state, err := op.Prepare(operation.State{})
+	if err == operation.ErrSkipExecute {
+		return ctx
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	state, err = op.Execute(*state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Done,
+		ActionId: &someActionId,
+	})
+	c.Assert(s.callbacks.executingMessage, gc.Equals, "running action juju-upgrade-preview")
+	c.Assert(runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.IsNil)
+	return ctx
+}
+
+func (s *PreflightUpgradeSuite) TestReportsDelta(c *gc.C) {
+	ctx := s.runPreview(c, s.bundles, "cs:quantal/hive-24")
+	c.Assert(ctx.flushed, jc.IsTrue)
+	c.Assert(ctx.flushErr, jc.ErrorIsNil)
+	c.Assert(ctx.results, jc.DeepEquals, map[string]string{
+		"added-files":     "hooks/start",
+		"removed-files":   "hooks/stop",
+		"modified-files":  "config.yaml\nhooks/install",
+		"added-options":   "baz",
+		"removed-options": "bar",
+		"changed-options": "foo",
+	})
+}
+
+func (s *PreflightUpgradeSuite) TestSameCharm(c *gc.C) {
+	ctx := s.runPreview(c, s.bundles, "cs:quantal/hive-23")
+	c.Assert(ctx.flushed, jc.IsTrue)
+	c.Assert(ctx.flushErr, jc.ErrorIsNil)
+	c.Assert(ctx.results, gc.HasLen, 0)
+}
+
+func (s *PreflightUpgradeSuite) TestReadErrorFailsAction(c *gc.C) {
+	ctx := s.runPreview(c, s.bundles, "cs:quantal/hive-25")
+	c.Assert(ctx.flushed, jc.IsTrue)
+	c.Assert(ctx.flushErr, gc.ErrorMatches, `cannot read charm "cs:quantal/hive-25": charm not found`)
+	c.Assert(ctx.results, gc.HasLen, 0)
+}
+
+func (s *PreflightUpgradeSuite) TestInvalidCharmURLFailsAction(c *gc.C) {
+	ctx := s.runPreview(c, s.bundles, "not/a/valid/url/at/all")
+	c.Assert(ctx.flushed, jc.IsFalse)
+	c.Assert(*s.callbacks.MockFailAction.gotActionId, gc.Equals, someActionId)
+	c.Assert(*s.callbacks.MockFailAction.gotMessage, gc.Matches, `.*"not/a/valid/url/at/all".*`)
+}
+
+func (s *PreflightUpgradeSuite) TestNotSupportedWithoutBundles(c *gc.C) {
+	ctx := s.runPreview(c, nil, "cs:quantal/hive-24")
+	c.Assert(ctx.flushed, jc.IsFalse)
+	c.Assert(*s.callbacks.MockFailAction.gotActionId, gc.Equals, someActionId)
+	c.Assert(*s.callbacks.MockFailAction.gotMessage, gc.Equals, "upgrade preview not supported")
+}
+
+type preflightCallbacks struct {
+	RunActionCallbacks
+}
+
+func (cb *preflightCallbacks) GetArchiveInfo(charmURL *corecharm.URL) (charm.BundleInfo, error) {
+	return &bundleInfo{charmURL}, nil
+}
+
+type previewContext struct {
+	MockContext
+	results  map[string]string
+	flushed  bool
+	flushErr error
+}
+
+func (ctx *previewContext) UpdateActionResults(keys []string, value string) error {
+	ctx.results[strings.Join(keys, ".")] = value
+	return nil
+}
+
+func (ctx *previewContext) Flush(badge string, failure error) error {
+	ctx.flushed = true
+	ctx.flushErr = failure
+	return nil
+}
+
+type bundleInfo struct {
+	url *corecharm.URL
+}
+
+func (bi *bundleInfo) URL() *corecharm.URL {
+	return bi.url
+}
+
+func (bi *bundleInfo) ArchiveSha256() (string, error) {
+	return "", nil
+}
+
+type fakeBundles struct {
+	bundles map[string]fakeBundle
+}
+
+func (b *fakeBundles) Read(info charm.BundleInfo, abort <-chan struct{}) (charm.Bundle, error) {
+	bundle, ok := b.bundles[info.URL().String()]
+	if !ok {
+		return nil, errors.NotFoundf("charm")
+	}
+	return bundle, nil
+}
+
+// fakeBundle maps the paths of the files in a charm to their content.
+type fakeBundle map[string]string
+
+func (b fakeBundle) Manifest() (set.Strings, error) {
+	manifest := set.NewStrings()
+	for path := range b {
+		manifest.Add(path)
+	}
+	return manifest, nil
+}
+
+func (b fakeBundle) ExpandTo(dir string) error {
+	for path, content := range b {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
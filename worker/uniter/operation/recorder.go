@@ -0,0 +1,225 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	corecharm "gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+// Record describes the execution of a single operation.
+type Record struct {
+	// Kind is the kind of operation, eg "run-hook".
+	Kind string
+
+	// Description is the operation's short representation.
+	Description string
+
+	// Started is when the operation was prepared.
+	Started time.Time
+
+	// Completed is when the operation was committed, or failed.
+	Completed time.Time
+
+	// Err holds the reason that the operation failed, if it did.
+	Err error
+}
+
+// Recorder is notified of the completion of operations.
+type Recorder interface {
+	// RecordOperation records the execution of an operation. It is
+	// called once the operation has been committed, or has failed.
+	RecordOperation(Record)
+}
+
+// recordingFactory wraps every operation created by its embedded Factory
+// so that the operation's execution is reported to a Recorder, and its
+// phases to a Tracer. Either may be nil.
+type recordingFactory struct {
+	Factory
+	recorder Recorder
+	tracer   Tracer
+	clock    clock.Clock
+}
+
+func (f *recordingFactory) wrap(kind string, op Operation, err error) (Operation, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &recordedOperation{
+		Operation: op,
+		kind:      kind,
+		recorder:  f.recorder,
+		tracer:    f.tracer,
+		clock:     f.clock,
+	}, nil
+}
+
+// NewInstall is part of the Factory interface.
+func (f *recordingFactory) NewInstall(charmURL *corecharm.URL) (Operation, error) {
+	op, err := f.Factory.NewInstall(charmURL)
+	return f.wrap(string(Install), op, err)
+}
+
+// NewUpgrade is part of the Factory interface.
+func (f *recordingFactory) NewUpgrade(charmURL *corecharm.URL) (Operation, error) {
+	op, err := f.Factory.NewUpgrade(charmURL)
+	return f.wrap(string(Upgrade), op, err)
+}
+
+// NewNoOpUpgrade is part of the Factory interface.
+func (f *recordingFactory) NewNoOpUpgrade(charmURL *corecharm.URL) (Operation, error) {
+	op, err := f.Factory.NewNoOpUpgrade(charmURL)
+	return f.wrap(string(Upgrade), op, err)
+}
+
+// NewNoOpFinishUpgradeSeries is part of the Factory interface.
+func (f *recordingFactory) NewNoOpFinishUpgradeSeries() (Operation, error) {
+	op, err := f.Factory.NewNoOpFinishUpgradeSeries()
+	return f.wrap("finish-upgrade-series", op, err)
+}
+
+// NewRevertUpgrade is part of the Factory interface.
+func (f *recordingFactory) NewRevertUpgrade(charmURL *corecharm.URL) (Operation, error) {
+	op, err := f.Factory.NewRevertUpgrade(charmURL)
+	return f.wrap(string(Upgrade), op, err)
+}
+
+// NewResolvedUpgrade is part of the Factory interface.
+func (f *recordingFactory) NewResolvedUpgrade(charmURL *corecharm.URL) (Operation, error) {
+	op, err := f.Factory.NewResolvedUpgrade(charmURL)
+	return f.wrap(string(Upgrade), op, err)
+}
+
+// NewRunHook is part of the Factory interface.
+func (f *recordingFactory) NewRunHook(hookInfo hook.Info) (Operation, error) {
+	op, err := f.Factory.NewRunHook(hookInfo)
+	return f.wrap(string(RunHook), op, err)
+}
+
+// NewRunHookWithSnapshot is part of the Factory interface.
+func (f *recordingFactory) NewRunHookWithSnapshot(hookInfo hook.Info) (Operation, error) {
+	op, err := f.Factory.NewRunHookWithSnapshot(hookInfo)
+	return f.wrap(string(RunHook), op, err)
+}
+
+// NewSkipHook is part of the Factory interface.
+func (f *recordingFactory) NewSkipHook(hookInfo hook.Info) (Operation, error) {
+	op, err := f.Factory.NewSkipHook(hookInfo)
+	return f.wrap("skip-hook", op, err)
+}
+
+// NewAction is part of the Factory interface.
+func (f *recordingFactory) NewAction(actionId string) (Operation, error) {
+	op, err := f.Factory.NewAction(actionId)
+	return f.wrap(string(RunAction), op, err)
+}
+
+// NewFailAction is part of the Factory interface.
+func (f *recordingFactory) NewFailAction(actionId string) (Operation, error) {
+	op, err := f.Factory.NewFailAction(actionId)
+	return f.wrap("fail-action", op, err)
+}
+
+// NewCommands is part of the Factory interface.
+func (f *recordingFactory) NewCommands(args CommandArgs, sendResponse CommandResponseFunc) (Operation, error) {
+	op, err := f.Factory.NewCommands(args, sendResponse)
+	return f.wrap("run-commands", op, err)
+}
+
+// NewAcceptLeadership is part of the Factory interface.
+func (f *recordingFactory) NewAcceptLeadership() (Operation, error) {
+	op, err := f.Factory.NewAcceptLeadership()
+	return f.wrap("accept-leadership", op, err)
+}
+
+// NewResignLeadership is part of the Factory interface.
+func (f *recordingFactory) NewResignLeadership() (Operation, error) {
+	op, err := f.Factory.NewResignLeadership()
+	return f.wrap("resign-leadership", op, err)
+}
+
+// recordedOperation reports the execution of the operation it wraps
+// to a Recorder, once the operation has been committed or has failed,
+// and each of its phases to a Tracer.
+type recordedOperation struct {
+	Operation
+	kind     string
+	recorder Recorder
+	tracer   Tracer
+	clock    clock.Clock
+	started  time.Time
+}
+
+// Prepare is part of the Operation interface.
+func (op *recordedOperation) Prepare(state State) (*State, error) {
+	op.started = op.clock.Now()
+	newState, err := op.trace(PhasePrepare, op.started, op.Operation.Prepare, state)
+	if err != nil && errors.Cause(err) != ErrSkipExecute {
+		op.record(err)
+	}
+	return newState, err
+}
+
+// Execute is part of the Operation interface.
+func (op *recordedOperation) Execute(state State) (*State, error) {
+	newState, err := op.trace(PhaseExecute, op.clock.Now(), op.Operation.Execute, state)
+	if err != nil {
+		op.record(err)
+	}
+	return newState, err
+}
+
+// Commit is part of the Operation interface.
+func (op *recordedOperation) Commit(state State) (*State, error) {
+	newState, err := op.trace(PhaseCommit, op.clock.Now(), op.Operation.Commit, state)
+	op.record(err)
+	return newState, err
+}
+
+// trace runs the phase of the operation, which started at the given
+// time, reporting its start and finish to the tracer.
+func (op *recordedOperation) trace(
+	phase Phase, started time.Time, run func(State) (*State, error), state State,
+) (*State, error) {
+	if op.tracer == nil {
+		return run(state)
+	}
+	t := Transition{
+		Kind:        op.kind,
+		Description: op.Operation.String(),
+		Phase:       phase,
+		Started:     started,
+	}
+	op.tracer.StartPhase(t)
+	newState, err := run(state)
+	t.Finished = op.clock.Now()
+	t.Err = err
+	op.tracer.FinishPhase(t)
+	return newState, err
+}
+
+func (op *recordedOperation) record(err error) {
+	if op.recorder == nil {
+		return
+	}
+	completed := op.clock.Now()
+	started := op.started
+	if started.IsZero() {
+		// Skipped operations are committed without being prepared.
+		started = completed
+	}
+	op.recorder.RecordOperation(Record{
+		Kind:        op.kind,
+		Description: op.Operation.String(),
+		Started:     started,
+		Completed:   completed,
+		Err:         err,
+	})
+}
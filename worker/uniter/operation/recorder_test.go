@@ -0,0 +1,168 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type fakeRecorder struct {
+	records []operation.Record
+}
+
+func (r *fakeRecorder) RecordOperation(record operation.Record) {
+	r.records = append(r.records, record)
+}
+
+type fakeTracer struct {
+	started  []operation.Transition
+	finished []operation.Transition
+}
+
+func (t *fakeTracer) StartPhase(transition operation.Transition) {
+	t.started = append(t.started, transition)
+}
+
+func (t *fakeTracer) FinishPhase(transition operation.Transition) {
+	t.finished = append(t.finished, transition)
+}
+
+type RecorderSuite struct {
+	testing.IsolationSuite
+	clock    *testclock.Clock
+	recorder *fakeRecorder
+	factory  operation.Factory
+}
+
+var _ = gc.Suite(&RecorderSuite{})
+
+func (s *RecorderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.recorder = &fakeRecorder{}
+	s.factory = operation.NewFactory(operation.FactoryParams{
+		Recorder: s.recorder,
+		Clock:    s.clock,
+	})
+}
+
+func (s *RecorderSuite) TestRecordsCommittedOperation(c *gc.C) {
+	op, err := s.factory.NewAcceptLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "accept leadership")
+
+	state := operation.State{Kind: operation.Continue, Step: operation.Pending}
+	_, err = op.Prepare(state)
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+	c.Assert(s.recorder.records, gc.HasLen, 0)
+
+	s.clock.Advance(time.Second)
+	_, err = op.Commit(state)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.recorder.records, jc.DeepEquals, []operation.Record{{
+		Kind:        "accept-leadership",
+		Description: "accept leadership",
+		Started:     time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+		Completed:   time.Date(2019, 6, 1, 12, 0, 1, 0, time.UTC),
+	}})
+}
+
+func (s *RecorderSuite) TestRecordsFailedOperation(c *gc.C) {
+	op, err := s.factory.NewAcceptLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+
+	state := operation.State{Kind: operation.RunHook, Step: operation.Pending}
+	_, err = op.Prepare(state)
+	c.Assert(err, gc.Equals, operation.ErrCannotAcceptLeadership)
+	c.Assert(s.recorder.records, gc.HasLen, 1)
+	c.Assert(s.recorder.records[0].Kind, gc.Equals, "accept-leadership")
+	c.Assert(s.recorder.records[0].Err, gc.Equals, operation.ErrCannotAcceptLeadership)
+}
+
+func (s *RecorderSuite) TestRecordsSkippedOperation(c *gc.C) {
+	op, err := s.factory.NewResignLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Skipped operations are only committed.
+	_, err = op.Commit(operation.State{Kind: operation.Continue, Step: operation.Pending})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.recorder.records, gc.HasLen, 1)
+	c.Assert(s.recorder.records[0].Kind, gc.Equals, "resign-leadership")
+	c.Assert(s.recorder.records[0].Started, gc.Equals, s.recorder.records[0].Completed)
+}
+
+func (s *RecorderSuite) TestFactoryErrorsNotWrapped(c *gc.C) {
+	op, err := s.factory.NewAction("lol-something")
+	c.Assert(op, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `invalid action id "lol-something"`)
+	c.Assert(s.recorder.records, gc.HasLen, 0)
+}
+
+func (s *RecorderSuite) TestTracesPhases(c *gc.C) {
+	tracer := &fakeTracer{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		Tracer: tracer,
+		Clock:  s.clock,
+	})
+	op, err := factory.NewAcceptLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+
+	state := operation.State{Kind: operation.Continue, Step: operation.Pending}
+	_, err = op.Prepare(state)
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+	s.clock.Advance(time.Second)
+	_, err = op.Commit(state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	t0 := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	c.Assert(tracer.started, jc.DeepEquals, []operation.Transition{{
+		Kind:        "accept-leadership",
+		Description: "accept leadership",
+		Phase:       operation.PhasePrepare,
+		Started:     t0,
+	}, {
+		Kind:        "accept-leadership",
+		Description: "accept leadership",
+		Phase:       operation.PhaseCommit,
+		Started:     t1,
+	}})
+	c.Assert(tracer.finished, jc.DeepEquals, []operation.Transition{{
+		Kind:        "accept-leadership",
+		Description: "accept leadership",
+		Phase:       operation.PhasePrepare,
+		Started:     t0,
+		Finished:    t0,
+		Err:         operation.ErrSkipExecute,
+	}, {
+		Kind:        "accept-leadership",
+		Description: "accept leadership",
+		Phase:       operation.PhaseCommit,
+		Started:     t1,
+		Finished:    t1,
+	}})
+	c.Assert(tracer.finished[1].Duration(), gc.Equals, time.Duration(0))
+}
+
+func (s *RecorderSuite) TestTracerWithoutRecorder(c *gc.C) {
+	tracer := &fakeTracer{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		Tracer: tracer,
+		Clock:  s.clock,
+	})
+	op, err := factory.NewAcceptLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Prepare(operation.State{Kind: operation.RunHook, Step: operation.Pending})
+	c.Assert(err, gc.Equals, operation.ErrCannotAcceptLeadership)
+	c.Assert(tracer.finished, gc.HasLen, 1)
+	c.Assert(tracer.finished[0].Err, gc.Equals, operation.ErrCannotAcceptLeadership)
+}
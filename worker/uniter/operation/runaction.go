@@ -7,7 +7,9 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	corecharm "gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -17,10 +19,17 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	dispatcher    ActionDispatcher
+	newPreflight  func(*corecharm.URL) (*preflightUpgrade, error)
 
 	name   string
 	runner runner.Runner
 
+	// preflight is set when the action is the predefined
+	// juju-upgrade-preview action, which is run by the uniter
+	// itself rather than by the charm.
+	preflight *preflightUpgrade
+
 	RequiresMachineLock
 }
 
@@ -30,8 +39,11 @@ func (ra *runAction) String() string {
 }
 
 // Prepare ensures that the action is valid and can be executed. If not, it
-// will return ErrSkipExecute. It preserves any hook recorded in the supplied
-// state.
+// will return ErrSkipExecute. If the action may be run in parallel, it is
+// handed to the dispatcher and ErrSkipExecute is returned, so that the
+// operation completes without waiting for the action. The predefined
+// juju-upgrade-preview action is run by the uniter itself, and is never
+// dispatched. It preserves any hook recorded in the supplied state.
 // Prepare is part of the Operation interface.
 func (ra *runAction) Prepare(state State) (*State, error) {
 	rnr, err := ra.runnerFactory.NewActionRunner(ra.actionId)
@@ -54,6 +66,22 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if actionData.Name == actions.JujuUpgradePreviewActionName {
+		if ra.preflight, err = ra.prepareUpgradePreview(actionData.Params); err != nil {
+			if err := ra.callbacks.FailAction(ra.actionId, err.Error()); err != nil {
+				return nil, err
+			}
+			return nil, ErrSkipExecute
+		}
+	} else if ra.dispatcher != nil {
+		dispatched, err := ra.dispatcher.Dispatch(actionData.Name, rnr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot dispatch action %q", ra.actionId)
+		}
+		if dispatched {
+			return nil, ErrSkipExecute
+		}
+	}
 	ra.name = actionData.Name
 	ra.runner = rnr
 	return stateChange{
@@ -64,6 +92,17 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// prepareUpgradePreview returns the preflightUpgrade that runs the
+// juju-upgrade-preview action with the supplied parameters.
+func (ra *runAction) prepareUpgradePreview(params map[string]interface{}) (*preflightUpgrade, error) {
+	url, _ := params["charm-url"].(string)
+	charmURL, err := corecharm.ParseURL(url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ra.newPreflight(charmURL)
+}
+
 // Execute runs the action, and preserves any hook recorded in the supplied state.
 // Execute is part of the Operation interface.
 func (ra *runAction) Execute(state State) (*State, error) {
@@ -73,7 +112,12 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
-	err := ra.runner.RunAction(ra.name)
+	var err error
+	if ra.preflight != nil {
+		err = ra.runUpgradePreview()
+	} else {
+		err = ra.runner.RunAction(ra.name)
+	}
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -87,6 +131,21 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// runUpgradePreview records the differences between the deployed charm
+// and the charm being previewed as the results of the action.
+func (ra *runAction) runUpgradePreview() error {
+	ctx := ra.runner.Context()
+	delta, err := ra.preflight.delta()
+	if err == nil {
+		for key, value := range delta.Results() {
+			if err = ctx.UpdateActionResults([]string{key}, value); err != nil {
+				break
+			}
+		}
+	}
+	return ctx.Flush(ra.name, err)
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
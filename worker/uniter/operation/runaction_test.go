@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -181,6 +182,58 @@ func (s *RunActionSuite) TestPrepareSuccessDirtyState(c *gc.C) {
 	c.Assert(*runnerFactory.MockNewActionRunner.gotActionId, gc.Equals, someActionId)
 }
 
+func (s *RunActionSuite) TestPrepareDispatchesParallelAction(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
+	dispatcher := &mockActionDispatcher{dispatch: true}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory:    runnerFactory,
+		ActionDispatcher: dispatcher,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(overwriteState)
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+	c.Assert(newState, gc.IsNil)
+	c.Assert(dispatcher.gotName, gc.Equals, "some-action-name")
+	c.Assert(dispatcher.gotRunner, gc.Equals, runnerFactory.MockNewActionRunner.runner)
+	c.Assert(runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.IsNil)
+}
+
+func (s *RunActionSuite) TestPrepareDoesNotDispatchSerialAction(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
+	dispatcher := &mockActionDispatcher{}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory:    runnerFactory,
+		ActionDispatcher: dispatcher,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Pending,
+		ActionId: &someActionId,
+	})
+	c.Assert(dispatcher.gotName, gc.Equals, "some-action-name")
+}
+
+func (s *RunActionSuite) TestPrepareDispatchError(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(errors.New("should not call"))
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory:    runnerFactory,
+		ActionDispatcher: &mockActionDispatcher{err: errors.New("boom")},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Prepare(operation.State{})
+	c.Assert(err, gc.ErrorMatches, `cannot dispatch action "`+someActionId+`": boom`)
+	c.Assert(newState, gc.IsNil)
+}
+
 func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -287,3 +340,16 @@ func (s *RunActionSuite) TestNeedsGlobalMachineLock(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(op.NeedsGlobalMachineLock(), jc.IsTrue)
 }
+
+type mockActionDispatcher struct {
+	dispatch  bool
+	err       error
+	gotName   string
+	gotRunner runner.Runner
+}
+
+func (d *mockActionDispatcher) Dispatch(actionName string, rnr runner.Runner) (bool, error) {
+	d.gotName = actionName
+	d.gotRunner = rnr
+	return d.dispatch, d.err
+}
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -25,11 +26,18 @@ type runHook struct {
 	callbacks     Callbacks
 	runnerFactory runner.Factory
 
-	name   string
-	runner runner.Runner
+	name    string
+	runner  runner.Runner
+	timeout time.Duration
 
 	hookFound bool
 
+	// snapshotPaths holds the directories to capture in snapshotDir
+	// before the hook is run, if snapshotDir is not empty.
+	snapshotDir     string
+	snapshotPaths   []string
+	restoreSnapshot func() error
+
 	RequiresMachineLock
 }
 
@@ -61,7 +69,7 @@ func (rh *runHook) Prepare(state State) (*State, error) {
 		return nil, err
 	}
 
-	if hooks.Kind(name) == hooks.LeaderElected {
+	if hooks.Kind(name) == hooks.LeaderElected || rh.info.Kind == hook.PreDestroy {
 		// Check if leadership has changed between queueing of the hook and
 		// Actual execution. Skip execution if we are no longer the leader.
 		isLeader := false
@@ -113,12 +121,36 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	// to count so reset it here before running the hook.
 	rh.runner.Context().ResetExecutionSetUnitStatus()
 
+	if rh.snapshotDir != "" {
+		if err := rh.takeSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+
 	rh.hookFound = true
 	step := Done
 
-	err := rh.runner.RunHook(rh.name)
+	err := rh.runner.RunHookWithTimeout(rh.name, rh.timeout)
 	cause := errors.Cause(err)
 	switch {
+	case err != nil && rh.info.Kind == hook.PreDestroy && !charmrunner.IsMissingHookError(cause):
+		// A failed pre-destroy hook must not prevent the application
+		// from being removed, so the failure is logged and teardown
+		// carries on regardless.
+		logger.Warningf("hook %q failed, continuing with teardown: %v", rh.name, err)
+		err = nil
+	case charmrunner.IsHookTimeoutError(cause):
+		logger.Errorf("hook %q timed out: %v", rh.name, err)
+		rh.rollback()
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		// Record the timeout, so that it can be reported and
+		// retried differently from other hook failures.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	case charmrunner.IsMissingHookError(cause):
 		rh.hookFound = false
 		err = nil
@@ -130,6 +162,7 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.rollback()
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
@@ -154,6 +187,42 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// takeSnapshot brings the snapshot of the charm and unit state up to
+// date before the hook is run, if the charm's config asks for it to be
+// rolled back when the hook fails. Otherwise any earlier snapshot is
+// removed, so that it does not take up space.
+func (rh *runHook) takeSnapshot() error {
+	settings, err := rh.runner.Context().ConfigSettings()
+	if err != nil {
+		return errors.Annotatef(err, "reading config settings before %q hook", rh.name)
+	}
+	if !rollbackOnError(settings) {
+		if err := removeHookSnapshot(rh.snapshotDir); err != nil {
+			logger.Warningf("cannot remove snapshot: %v", err)
+		}
+		return nil
+	}
+	snap, err := takeHookSnapshot(rh.snapshotDir, rh.snapshotPaths)
+	if err != nil {
+		return errors.Annotatef(err, "taking snapshot before %q hook", rh.name)
+	}
+	rh.restoreSnapshot = snap.restore
+	return nil
+}
+
+// rollback restores the snapshot taken before a failed hook was run,
+// if there is one.
+func (rh *runHook) rollback() {
+	if rh.restoreSnapshot == nil {
+		return
+	}
+	if err := rh.restoreSnapshot(); err != nil {
+		logger.Errorf("cannot roll back after %q hook failed: %v", rh.name, err)
+		return
+	}
+	logger.Infof("rolled back charm and unit state after %q hook failed", rh.name)
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -283,6 +352,8 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Started = true
 	case hooks.Stop:
 		newState.Stopped = true
+	case hook.PreDestroy:
+		newState.PreDestroyed = true
 	}
 
 	return newState, nil
@@ -4,10 +4,16 @@
 package operation_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/core/relation"
@@ -137,6 +143,29 @@ func (s *RunHookSuite) TestPrepareHookError_LeaderElectedNotLeader(c *gc.C) {
 	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
 }
 
+func (s *RunHookSuite) TestPrepareHookError_PreDestroyNotLeader(c *gc.C) {
+	callbacks := &PrepareHookCallbacks{
+		MockPrepareHook: &MockPrepareHook{nil, string(hook.PreDestroy), nil},
+	}
+	runnerFactory := &MockRunnerFactory{
+		MockNewHookRunner: &MockNewHookRunner{
+			runner: &MockRunner{
+				context: &MockContext{isLeader: false},
+			},
+		},
+	}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+
+	op, err := operation.Factory.NewRunHook(factory, hook.Info{Kind: hook.PreDestroy})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrSkipExecute)
+}
+
 func (s *RunHookSuite) testPrepareRunnerError(c *gc.C, newHook newHook) {
 	callbacks := NewPrepareHookCallbacks()
 	runnerFactory := &MockRunnerFactory{
@@ -299,6 +328,107 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimeoutError(c *gc.C) {
+	runErr := charmrunner.NewHookTimeoutError("some-hook-name", time.Minute)
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
+func (s *RunHookSuite) TestExecutePreDestroyErrorIgnored(c *gc.C) {
+	for _, runErr := range []error{
+		errors.New("graaargh"),
+		charmrunner.NewHookTimeoutError("some-hook-name", time.Minute),
+	} {
+		c.Logf("error %v", runErr)
+		op, callbacks, _ := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hook.PreDestroy, runErr)
+		_, err := op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		newState, err := op.Execute(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(newState, gc.DeepEquals, &operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Done,
+			Hook: &hook.Info{Kind: hook.PreDestroy},
+		})
+		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+	}
+}
+
+func (s *RunHookSuite) TestExecutePreDestroyTimeout(c *gc.C) {
+	for i, test := range []struct {
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		{0, operation.DefaultPreDestroyTimeout},
+		{time.Minute, time.Minute},
+	} {
+		c.Logf("test %d", i)
+		runnerFactory := NewRunHookRunnerFactory(nil)
+		callbacks := &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory:     runnerFactory,
+			Callbacks:         callbacks,
+			HookTimeout:       func() time.Duration { return time.Hour },
+			PreDestroyTimeout: test.timeout,
+		})
+		op, err := factory.NewRunHook(hook.Info{Kind: hook.PreDestroy})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, err = op.Execute(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(runnerFactory.MockNewHookRunner.runner.MockRunHook.gotTimeout, gc.Equals, test.expected)
+	}
+}
+
+func (s *RunHookSuite) TestExecutePassesHookTimeout(c *gc.C) {
+	runnerFactory := NewRunHookRunnerFactory(nil)
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	timeout := 5 * time.Minute
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+		HookTimeout:   func() time.Duration { return timeout },
+	})
+	runHook := func() time.Duration {
+		op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, err = op.Execute(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		return runnerFactory.MockNewHookRunner.runner.MockRunHook.gotTimeout
+	}
+	c.Assert(runHook(), gc.Equals, 5*time.Minute)
+
+	// A changed timeout applies to the next hook run.
+	timeout = time.Minute
+	c.Assert(runHook(), gc.Equals, time.Minute)
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -574,6 +704,26 @@ func (s *RunHookSuite) TestCommitSuccess_Start_SetStarted(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_PreDestroy_SetPreDestroyed(c *gc.C) {
+	for i, newHook := range []newHook{
+		operation.Factory.NewRunHook,
+		operation.Factory.NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.PreDestroy},
+			operation.State{Started: true},
+			operation.State{
+				Started:      true,
+				PreDestroyed: true,
+				Kind:         operation.Continue,
+				Step:         operation.Pending,
+			},
+		)
+	}
+}
+
 func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	for i, newHook := range []newHook{
 		operation.Factory.NewRunHook,
@@ -844,3 +994,127 @@ func (s *RunHookSuite) TestNeedsGlobalMachineLock_Run(c *gc.C) {
 func (s *RunHookSuite) TestNeedsGlobalMachineLock_Skip(c *gc.C) {
 	s.testNeedsGlobalMachineLock(c, operation.Factory.NewSkipHook, false)
 }
+
+func (s *RunHookSuite) TestNeedsGlobalMachineLock_RunWithSnapshot(c *gc.C) {
+	s.testNeedsGlobalMachineLock(c, operation.Factory.NewRunHookWithSnapshot, true)
+}
+
+func (s *RunHookSuite) newSnapshotFactory(c *gc.C, rollback bool, paths ...string) (operation.Factory, *MockRunHook, *ExecuteHookCallbacks, string) {
+	runnerFactory := NewRunHookRunnerFactory(nil, func(ctx *MockContext) {
+		ctx.configSettings = corecharm.Settings{operation.RollbackOnErrorSetting: rollback}
+	})
+	callbacks := &ExecuteHookCallbacks{
+		PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+		MockNotifyHookCompleted: &MockNotify{},
+		MockNotifyHookFailed:    &MockNotify{},
+	}
+	snapshotDir := filepath.Join(c.MkDir(), "snapshot")
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+		SnapshotPaths: paths,
+		SnapshotDir:   snapshotDir,
+	})
+	return factory, runnerFactory.MockNewHookRunner.runner.MockRunHook, callbacks, snapshotDir
+}
+
+func (s *RunHookSuite) executeWithSnapshot(c *gc.C, factory operation.Factory) error {
+	op, err := factory.NewRunHookWithSnapshot(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Execute(operation.State{})
+	return err
+}
+
+func (s *RunHookSuite) testExecuteWithSnapshot(c *gc.C, rollback bool) string {
+	charmDir := filepath.Join(c.MkDir(), "charm")
+	stateFile := filepath.Join(charmDir, "state")
+	err := os.MkdirAll(charmDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(stateFile, []byte("before"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	createdDir := filepath.Join(c.MkDir(), "created")
+
+	factory, runHook, callbacks, _ := s.newSnapshotFactory(c, rollback, charmDir, createdDir)
+	runHook.err = errors.New("graaargh")
+	runHook.run = func() {
+		c.Check(ioutil.WriteFile(stateFile, []byte("after"), 0644), jc.ErrorIsNil)
+		c.Check(os.Mkdir(createdDir, 0755), jc.ErrorIsNil)
+	}
+	err = s.executeWithSnapshot(c, factory)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+
+	_, err = os.Stat(createdDir)
+	c.Assert(os.IsNotExist(err), gc.Equals, rollback)
+	content, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(content)
+}
+
+func (s *RunHookSuite) TestExecuteWithSnapshotRollsBack(c *gc.C) {
+	content := s.testExecuteWithSnapshot(c, true)
+	c.Assert(content, gc.Equals, "before")
+}
+
+func (s *RunHookSuite) TestExecuteWithSnapshotNoRollback(c *gc.C) {
+	content := s.testExecuteWithSnapshot(c, false)
+	c.Assert(content, gc.Equals, "after")
+}
+
+func (s *RunHookSuite) TestExecuteWithSnapshotCopiesChangedFiles(c *gc.C) {
+	charmDir := c.MkDir()
+	changedFile := filepath.Join(charmDir, "changed")
+	unchangedFile := filepath.Join(charmDir, "unchanged")
+	removedFile := filepath.Join(charmDir, "removed")
+	for _, path := range []string{changedFile, unchangedFile, removedFile} {
+		err := ioutil.WriteFile(path, []byte("before"), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	factory, runHook, _, snapshotDir := s.newSnapshotFactory(c, true, charmDir)
+	runHook.run = func() {
+		c.Check(ioutil.WriteFile(changedFile, []byte("changed"), 0644), jc.ErrorIsNil)
+		c.Check(os.Remove(removedFile), jc.ErrorIsNil)
+	}
+	err := s.executeWithSnapshot(c, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	copied, err := os.Stat(filepath.Join(snapshotDir, "0", "unchanged"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The second snapshot only copies the files changed by the first
+	// hook, and the failed second hook is rolled back to it.
+	runHook.err = errors.New("graaargh")
+	runHook.run = func() {
+		for _, path := range []string{changedFile, unchangedFile, removedFile} {
+			c.Check(ioutil.WriteFile(path, []byte("broken"), 0644), jc.ErrorIsNil)
+		}
+	}
+	err = s.executeWithSnapshot(c, factory)
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	recopied, err := os.Stat(filepath.Join(snapshotDir, "0", "unchanged"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(os.SameFile(copied, recopied), jc.IsTrue)
+
+	content, err := ioutil.ReadFile(changedFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "changed")
+	content, err = ioutil.ReadFile(unchangedFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "before")
+	_, err = os.Stat(removedFile)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *RunHookSuite) TestExecuteWithoutRollbackRemovesSnapshot(c *gc.C) {
+	charmDir := c.MkDir()
+	factory, _, _, snapshotDir := s.newSnapshotFactory(c, false, charmDir)
+	err := os.MkdirAll(filepath.Join(snapshotDir, "0"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.executeWithSnapshot(c, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(snapshotDir)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// RollbackOnErrorSetting is the name of the charm config setting which,
+// when true, causes the charm and unit state captured before a hook is
+// run to be restored if that hook fails.
+const RollbackOnErrorSetting = "rollback-on-error"
+
+// rollbackOnError reports whether the given charm config settings
+// request that state be restored after a failed hook.
+func rollbackOnError(settings charm.Settings) bool {
+	enabled, _ := settings[RollbackOnErrorSetting].(bool)
+	return enabled
+}
+
+// hookSnapshot holds copies of the directories holding charm and unit
+// state, taken before a hook is run. The copies are kept between hooks,
+// so that only the files that have changed since the last snapshot are
+// copied again.
+type hookSnapshot struct {
+	dir     string
+	paths   []string
+	missing map[string]bool
+}
+
+// takeHookSnapshot brings the copies in dir of each of the given
+// directories up to date. Directories that do not exist are recorded
+// so that they can be removed again when the snapshot is restored.
+func takeHookSnapshot(dir string, paths []string) (*hookSnapshot, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap := &hookSnapshot{
+		dir:     dir,
+		paths:   paths,
+		missing: make(map[string]bool),
+	}
+	for i, path := range paths {
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			snap.missing[path] = true
+			if err := os.RemoveAll(snap.copyPath(i)); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// A copy left incomplete by an error is brought up to
+		// date when the next snapshot is taken.
+		if err := syncTree(path, snap.copyPath(i)); err != nil {
+			return nil, errors.Annotatef(err, "copying %q", path)
+		}
+	}
+	return snap, nil
+}
+
+// removeHookSnapshot deletes the copies kept in dir.
+func removeHookSnapshot(dir string) error {
+	return errors.Trace(os.RemoveAll(dir))
+}
+
+func (s *hookSnapshot) copyPath(i int) string {
+	return filepath.Join(s.dir, fmt.Sprint(i))
+}
+
+// restore returns each snapshotted directory to the state of its copy,
+// copying back only the files that have changed since it was taken.
+func (s *hookSnapshot) restore() error {
+	for i, path := range s.paths {
+		if s.missing[path] {
+			if err := os.RemoveAll(path); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		if err := syncTree(s.copyPath(i), path); err != nil {
+			return errors.Annotatef(err, "restoring %q", path)
+		}
+	}
+	return nil
+}
+
+// syncTree makes dst a copy of src. Files are only copied if their
+// type, size, permissions or modification time differ between src and
+// dst, and anything in dst that is not in src is removed. Sockets,
+// devices and named pipes are not copied.
+func syncTree(src, dst string) error {
+	srcInfo, err := os.Lstat(src)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dstInfo, err := os.Lstat(dst)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	if exists && srcInfo.Mode()&os.ModeType != dstInfo.Mode()&os.ModeType {
+		if err := os.RemoveAll(dst); err != nil {
+			return errors.Trace(err)
+		}
+		exists = false
+	}
+	mode := srcInfo.Mode()
+	switch {
+	case mode.IsDir():
+		return syncDir(src, dst, srcInfo, exists)
+	case mode&os.ModeSymlink != 0:
+		return syncSymlink(src, dst, exists)
+	case mode.IsRegular():
+		if exists && sameFile(srcInfo, dstInfo) {
+			return nil
+		}
+		return copyFile(src, dst, srcInfo)
+	}
+	return nil
+}
+
+// sameFile reports whether the regular files described by a and b
+// are taken to have the same content.
+func sameFile(a, b os.FileInfo) bool {
+	return a.Size() == b.Size() && a.Mode() == b.Mode() && a.ModTime().Equal(b.ModTime())
+}
+
+func syncDir(src, dst string, srcInfo os.FileInfo, exists bool) error {
+	if !exists {
+		if err := os.Mkdir(dst, srcInfo.Mode().Perm()); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err := os.Chmod(dst, srcInfo.Mode().Perm()); err != nil {
+		return errors.Trace(err)
+	}
+	srcEntries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	names := make(map[string]bool)
+	for _, entry := range srcEntries {
+		names[entry.Name()] = true
+		if err := syncTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	dstEntries, err := ioutil.ReadDir(dst)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, entry := range dstEntries {
+		if names[entry.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dst, entry.Name())); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func syncSymlink(src, dst string, exists bool) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		if current, err := os.Readlink(dst); err == nil && current == target {
+			return nil
+		}
+		if err := os.Remove(dst); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(os.Symlink(target, dst))
+}
+
+// copyFile replaces dst with a copy of the regular file src, keeping
+// its permissions and modification time so that it is not copied
+// again until it changes.
+func copyFile(src, dst string, srcInfo os.FileInfo) error {
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, srcInfo.Mode().Perm())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.Trace(err)
+	}
+	if err := out.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Chmod(dst, srcInfo.Mode()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime()))
+}
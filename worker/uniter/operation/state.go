@@ -65,6 +65,10 @@ type State struct {
 	// Stopped indicates whether the stop hook has run.
 	Stopped bool `yaml:"stopped"`
 
+	// PreDestroyed indicates whether the pre-destroy hook has run, or
+	// has been skipped because the unit was not the leader.
+	PreDestroyed bool `yaml:"pre-destroyed,omitempty"`
+
 	// Installed indicates whether the install hook has run.
 	Installed bool `yaml:"installed"`
 
@@ -85,6 +89,10 @@ type State struct {
 	// upgrade is complete (instead of running an upgrade-charm hook).
 	Hook *hook.Info `yaml:"hook,omitempty"`
 
+	// HookTimedOut indicates that the hook identified by Hook failed
+	// because it ran for longer than the hook timeout and was killed.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
+
 	// ActionId holds action information relevant to the current operation. If
 	// Kind is Continue, it holds the last action that was executed; if Kind is
 	// RunAction, it holds the running action.
@@ -180,6 +188,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -188,6 +197,7 @@ func (change stateChange) apply(state State) *State {
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
+	state.HookTimedOut = change.HookTimedOut
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
 }
@@ -0,0 +1,61 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"time"
+)
+
+// Phase identifies one of the methods through which an operation is run.
+type Phase string
+
+const (
+	PhasePrepare Phase = "prepare"
+	PhaseExecute Phase = "execute"
+	PhaseCommit  Phase = "commit"
+)
+
+// Transition describes an operation starting or finishing one of its
+// phases.
+type Transition struct {
+	// Kind is the kind of operation, eg "run-hook".
+	Kind string
+
+	// Description is the operation's short representation.
+	Description string
+
+	// Phase is the phase being started or finished.
+	Phase Phase
+
+	// Started is when the phase was started.
+	Started time.Time
+
+	// Finished is when the phase finished. It is zero in the
+	// transitions passed to StartPhase.
+	Finished time.Time
+
+	// Err holds the error the phase returned, if any.
+	Err error
+}
+
+// Duration returns how long the phase took. It is zero if the
+// phase hasn't finished.
+func (t Transition) Duration() time.Duration {
+	if t.Finished.IsZero() {
+		return 0
+	}
+	return t.Finished.Sub(t.Started)
+}
+
+// Tracer is notified as every operation created by a factory moves
+// through its Prepare, Execute and Commit phases, so that the time
+// spent in each may be observed. Tracer methods are called on the
+// goroutine running the operation, and should not block.
+type Tracer interface {
+	// StartPhase is called before the operation's phase is run.
+	StartPhase(Transition)
+
+	// FinishPhase is called after the operation's phase has run.
+	FinishPhase(Transition)
+}
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -271,6 +273,7 @@ type MockContext struct {
 	status          jujuc.StatusInfo
 	isLeader        bool
 	relation        *MockRelation
+	configSettings  corecharm.Settings
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -307,6 +310,10 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) ConfigSettings() (corecharm.Settings, error) {
+	return mock.configSettings, nil
+}
+
 func (mock *MockContext) IsLeader() (bool, error) {
 	return mock.isLeader, nil
 }
@@ -355,10 +362,15 @@ type MockRunHook struct {
 	gotName         *string
 	err             error
 	setStatusCalled bool
+	run             func()
+	gotTimeout      time.Duration
 }
 
 func (mock *MockRunHook) Call(hookName string) error {
 	mock.gotName = &hookName
+	if mock.run != nil {
+		mock.run()
+	}
 	return mock.err
 }
 
@@ -386,6 +398,11 @@ func (r *MockRunner) RunHook(hookName string) error {
 	return r.MockRunHook.Call(hookName)
 }
 
+func (r *MockRunner) RunHookWithTimeout(hookName string, timeout time.Duration) error {
+	r.MockRunHook.gotTimeout = timeout
+	return r.RunHook(hookName)
+}
+
 func NewDeployCallbacks() *DeployCallbacks {
 	return &DeployCallbacks{
 		MockGetArchiveInfo:  &MockGetArchiveInfo{info: &MockBundleInfo{}},
@@ -0,0 +1,37 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// operationRecorder implements operation.Recorder, adding each operation
+// run by the uniter to the unit's operation history in the controller.
+type operationRecorder struct {
+	u *Uniter
+}
+
+// RecordOperation is part of the operation.Recorder interface.
+func (opr *operationRecorder) RecordOperation(record operation.Record) {
+	op := params.UnitOperation{
+		Kind:        record.Kind,
+		Description: record.Description,
+		Started:     record.Started,
+		Completed:   record.Completed,
+	}
+	if record.Err != nil {
+		op.Error = record.Err.Error()
+	}
+	// Failing to record an operation must not stop the uniter.
+	err := opr.u.unit.RecordOperations([]params.UnitOperation{op})
+	if errors.IsNotSupported(err) {
+		logger.Tracef("not recording operation %q: %v", record.Description, err)
+	} else if err != nil {
+		logger.Warningf("cannot record operation %q: %v", record.Description, err)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// maxTracedOperations is the number of completed operations whose
+// phase timings are kept for the engine report.
+const maxTracedOperations = 10
+
+// tracedOperation holds the timings of an operation's phases.
+type tracedOperation struct {
+	kind        string
+	description string
+	phases      []operation.Transition
+}
+
+func (op *tracedOperation) report() map[string]interface{} {
+	phases := make([]map[string]interface{}, len(op.phases))
+	for i, t := range op.phases {
+		phase := map[string]interface{}{
+			"phase":    string(t.Phase),
+			"started":  t.Started.Format(time.RFC3339),
+			"duration": t.Duration().String(),
+		}
+		if t.Err != nil {
+			phase["error"] = t.Err.Error()
+		}
+		phases[i] = phase
+	}
+	return map[string]interface{}{
+		"kind":        op.kind,
+		"description": op.description,
+		"phases":      phases,
+	}
+}
+
+// operationTracer implements operation.Tracer, keeping the phase the
+// uniter is currently running and the timings of its most recent
+// operations, so that they can be shown in the engine report.
+type operationTracer struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	current *tracedOperation
+	phase   *operation.Transition
+	recent  []*tracedOperation
+}
+
+func newOperationTracer(clk clock.Clock) *operationTracer {
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	return &operationTracer{clock: clk}
+}
+
+// StartPhase is part of the operation.Tracer interface.
+func (t *operationTracer) StartPhase(transition operation.Transition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil || transition.Phase == operation.PhasePrepare ||
+		t.current.description != transition.Description {
+		t.finishCurrent()
+		t.current = &tracedOperation{
+			kind:        transition.Kind,
+			description: transition.Description,
+		}
+	}
+	t.phase = &transition
+}
+
+// FinishPhase is part of the operation.Tracer interface.
+func (t *operationTracer) FinishPhase(transition operation.Transition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phase = nil
+	if t.current == nil {
+		return
+	}
+	t.current.phases = append(t.current.phases, transition)
+	// An operation is finished once committed, or when a phase
+	// fails. Operations which skip execution are left current until
+	// they're committed or another operation is started.
+	if transition.Phase == operation.PhaseCommit ||
+		(transition.Err != nil && errors.Cause(transition.Err) != operation.ErrSkipExecute) {
+		t.finishCurrent()
+	}
+}
+
+// finishCurrent moves the current operation, if any, to the recent
+// operations. It must be called with the mutex held.
+func (t *operationTracer) finishCurrent() {
+	if t.current == nil {
+		return
+	}
+	t.recent = append(t.recent, t.current)
+	if len(t.recent) > maxTracedOperations {
+		t.recent = t.recent[len(t.recent)-maxTracedOperations:]
+	}
+	t.current = nil
+}
+
+// report returns the operation and phase the uniter is running, if
+// any, with how long it has been running, and the phase timings of
+// the most recent operations, newest first.
+func (t *operationTracer) report() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make(map[string]interface{})
+	if t.current != nil {
+		current := t.current.report()
+		if t.phase != nil {
+			current["phase"] = string(t.phase.Phase)
+			current["elapsed"] = t.clock.Now().Sub(t.phase.Started).String()
+		}
+		result["current-operation"] = current
+	}
+	recent := make([]map[string]interface{}, 0, len(t.recent))
+	for i := len(t.recent) - 1; i >= 0; i-- {
+		recent = append(recent, t.recent[i].report())
+	}
+	result["recent-operations"] = recent
+	return result
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type OperationTracerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	tracer operation.Tracer
+	report func() map[string]interface{}
+}
+
+var _ = gc.Suite(&OperationTracerSuite{})
+
+func (s *OperationTracerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.tracer, s.report = uniter.NewOperationTracer(s.clock)
+}
+
+// runPhase traces a phase of the operation which takes the given time.
+func (s *OperationTracerSuite) runPhase(description string, phase operation.Phase, d time.Duration, err error) {
+	t := operation.Transition{
+		Kind:        "run-hook",
+		Description: description,
+		Phase:       phase,
+		Started:     s.clock.Now(),
+	}
+	s.tracer.StartPhase(t)
+	s.clock.Advance(d)
+	t.Finished = s.clock.Now()
+	t.Err = err
+	s.tracer.FinishPhase(t)
+}
+
+func (s *OperationTracerSuite) TestEmptyReport(c *gc.C) {
+	c.Assert(s.report(), jc.DeepEquals, map[string]interface{}{
+		"recent-operations": []map[string]interface{}{},
+	})
+}
+
+func (s *OperationTracerSuite) TestCurrentPhase(c *gc.C) {
+	s.runPhase("run install hook", operation.PhasePrepare, time.Second, nil)
+	s.tracer.StartPhase(operation.Transition{
+		Kind:        "run-hook",
+		Description: "run install hook",
+		Phase:       operation.PhaseExecute,
+		Started:     s.clock.Now(),
+	})
+	s.clock.Advance(5 * time.Minute)
+
+	c.Assert(s.report(), jc.DeepEquals, map[string]interface{}{
+		"current-operation": map[string]interface{}{
+			"kind":        "run-hook",
+			"description": "run install hook",
+			"phase":       "execute",
+			"elapsed":     "5m0s",
+			"phases": []map[string]interface{}{{
+				"phase":    "prepare",
+				"started":  "2019-06-01T12:00:00Z",
+				"duration": "1s",
+			}},
+		},
+		"recent-operations": []map[string]interface{}{},
+	})
+}
+
+func (s *OperationTracerSuite) TestRecentOperations(c *gc.C) {
+	s.runPhase("run install hook", operation.PhasePrepare, time.Second, nil)
+	s.runPhase("run install hook", operation.PhaseExecute, 2*time.Second, nil)
+	s.runPhase("run install hook", operation.PhaseCommit, time.Second, nil)
+	s.runPhase("run start hook", operation.PhasePrepare, time.Second, errors.New("boom"))
+
+	report := s.report()
+	c.Assert(report["current-operation"], gc.IsNil)
+	c.Assert(report["recent-operations"], jc.DeepEquals, []map[string]interface{}{{
+		"kind":        "run-hook",
+		"description": "run start hook",
+		"phases": []map[string]interface{}{{
+			"phase":    "prepare",
+			"started":  "2019-06-01T12:00:04Z",
+			"duration": "1s",
+			"error":    "boom",
+		}},
+	}, {
+		"kind":        "run-hook",
+		"description": "run install hook",
+		"phases": []map[string]interface{}{{
+			"phase":    "prepare",
+			"started":  "2019-06-01T12:00:00Z",
+			"duration": "1s",
+		}, {
+			"phase":    "execute",
+			"started":  "2019-06-01T12:00:01Z",
+			"duration": "2s",
+		}, {
+			"phase":    "commit",
+			"started":  "2019-06-01T12:00:03Z",
+			"duration": "1s",
+		}},
+	}})
+}
+
+func (s *OperationTracerSuite) TestRecentOperationsLimited(c *gc.C) {
+	for i := 0; i < 12; i++ {
+		s.runPhase("run update-status hook", operation.PhaseCommit, time.Second, nil)
+	}
+	c.Assert(s.report()["recent-operations"], gc.HasLen, 10)
+}
+
+func (s *OperationTracerSuite) TestSkippedExecutionLeftCurrent(c *gc.C) {
+	// The executor may annotate the error that skips execution.
+	s.runPhase("run install hook", operation.PhasePrepare, time.Second, errors.Trace(operation.ErrSkipExecute))
+
+	report := s.report()
+	c.Assert(report["current-operation"], gc.NotNil)
+	c.Assert(report["recent-operations"], gc.HasLen, 0)
+}
@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/yaml.v2"
+
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+// parallelActionDispatcher runs the actions that a charm declares with
+// "parallel: true" in its actions.yaml in the background, concurrently
+// with hooks and other operations, and without holding the machine lock.
+//
+// Parallel actions hold a read lock over the charm directory while they
+// run; charm deployments take the write lock, so that the charm is never
+// replaced underneath a running action.
+//
+// The operation that dispatches a parallel action completes as soon as
+// the action has started, so the ids of running actions are recorded in
+// stateFile; actions still recorded there when the uniter starts were
+// interrupted by an agent restart, and are failed.
+type parallelActionDispatcher struct {
+	charmDir   string
+	stateFile  string
+	lock       *sync.RWMutex
+	addWorker  func(worker.Worker) error
+	failAction func(actionId, message string) error
+
+	// mu serialises updates to stateFile.
+	mu sync.Mutex
+}
+
+// failInterrupted fails the parallel actions that were running when the
+// agent last stopped, and forgets them.
+func (d *parallelActionDispatcher) failInterrupted() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	actionIds, err := d.readRunning()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, actionId := range actionIds {
+		logger.Infof("failing parallel action %s interrupted by agent restart", actionId)
+		// The action may have finished before its record was removed,
+		// in which case it can't be failed; that's fine.
+		if err := d.failAction(actionId, "action interrupted by agent restart"); err != nil {
+			logger.Warningf("cannot fail action %s: %v", actionId, err)
+		}
+	}
+	if err := os.Remove(d.stateFile); err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// setRunning records whether the action with the given id is running.
+func (d *parallelActionDispatcher) setRunning(actionId string, running bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	actionIds, err := d.readRunning()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ids := set.NewStrings(actionIds...)
+	if running {
+		ids.Add(actionId)
+	} else {
+		ids.Remove(actionId)
+	}
+	return errors.Trace(utils.WriteYaml(d.stateFile, ids.SortedValues()))
+}
+
+// readRunning returns the ids of the actions recorded as running.
+func (d *parallelActionDispatcher) readRunning() ([]string, error) {
+	var actionIds []string
+	if err := utils.ReadYaml(d.stateFile, &actionIds); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Annotate(err, "reading running parallel actions")
+	}
+	return actionIds, nil
+}
+
+// Dispatch is part of the operation.ActionDispatcher interface.
+func (d *parallelActionDispatcher) Dispatch(actionName string, rnr runner.Runner) (bool, error) {
+	parallel, err := isParallelAction(d.charmDir, actionName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !parallel {
+		return false, nil
+	}
+	actionData, err := rnr.Context().ActionData()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	actionId := actionData.Tag.Id()
+	if err := d.setRunning(actionId, true); err != nil {
+		return false, errors.Trace(err)
+	}
+
+	// The action must not start until the worker running it has been
+	// accepted; if it isn't, the worker is stopped instead.
+	start := make(chan struct{})
+	d.lock.RLock()
+	w := jworker.NewSimpleWorker(func(stop <-chan struct{}) error {
+		defer d.lock.RUnlock()
+		defer func() {
+			if err := d.setRunning(actionId, false); err != nil {
+				logger.Errorf("cannot record parallel action %s finished: %v", actionId, err)
+			}
+		}()
+		select {
+		case <-stop:
+			return nil
+		case <-start:
+		}
+		logger.Debugf("running parallel action %q (%s)", actionName, actionId)
+		if err := rnr.RunAction(actionName); err != nil {
+			// This indicates an actual error -- an action merely
+			// failing is handled inside the runner. Don't take the
+			// uniter down with it; just make sure the action isn't
+			// left running.
+			logger.Errorf("running parallel action %q (%s): %v", actionName, actionId, err)
+			if err := d.failAction(actionId, err.Error()); err != nil {
+				logger.Errorf("cannot fail action %s: %v", actionId, err)
+			}
+		}
+		return nil
+	})
+	if err := d.addWorker(w); err != nil {
+		return false, errors.Trace(err)
+	}
+	close(start)
+	return true, nil
+}
+
+// isParallelAction reports whether the charm in charmDir declares the
+// named action with "parallel: true" in its actions.yaml.
+func isParallelAction(charmDir, actionName string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "actions.yaml"))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	var specs map[string]struct {
+		Parallel bool `yaml:"parallel"`
+	}
+	if err := yaml.Unmarshal(data, &specs); err != nil {
+		return false, errors.Annotate(err, "parsing actions.yaml")
+	}
+	return specs[actionName].Parallel, nil
+}
+
+// charmDirLockingDeployer is a charm.Deployer that waits for running
+// parallel actions to complete before deploying a charm, and prevents
+// new ones from starting until it is done.
+type charmDirLockingDeployer struct {
+	charm.Deployer
+	lock *sync.RWMutex
+}
+
+// Deploy is part of the charm.Deployer interface.
+func (d *charmDirLockingDeployer) Deploy() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.Deployer.Deploy()
+}
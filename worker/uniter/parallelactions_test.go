@@ -0,0 +1,260 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner"
+	runnercontext "github.com/juju/juju/worker/uniter/runner/context"
+)
+
+const parallelActionsYaml = `
+backup:
+  description: Take a backup.
+  parallel: true
+restart:
+  description: Restart the service.
+`
+
+type ParallelActionsSuite struct {
+	testing.IsolationSuite
+
+	charmDir  string
+	stateFile string
+	lock      sync.RWMutex
+	workers   []worker.Worker
+	addErr    error
+	failed    map[string]string
+}
+
+var _ = gc.Suite(&ParallelActionsSuite{})
+
+func (s *ParallelActionsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "actions.yaml"), []byte(parallelActionsYaml), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stateFile = filepath.Join(c.MkDir(), "parallel-actions")
+	s.lock = sync.RWMutex{}
+	s.workers = nil
+	s.addErr = nil
+	s.failed = make(map[string]string)
+}
+
+func (s *ParallelActionsSuite) TearDownTest(c *gc.C) {
+	for _, w := range s.workers {
+		workertest.CleanKill(c, w)
+	}
+	s.IsolationSuite.TearDownTest(c)
+}
+
+func (s *ParallelActionsSuite) dispatcher() operation.ActionDispatcher {
+	return uniter.NewParallelActionDispatcher(
+		s.charmDir,
+		s.stateFile,
+		&s.lock,
+		func(w worker.Worker) error {
+			if s.addErr != nil {
+				worker.Stop(w)
+				return s.addErr
+			}
+			s.workers = append(s.workers, w)
+			return nil
+		},
+		func(actionId, message string) error {
+			s.failed[actionId] = message
+			return nil
+		},
+	)
+}
+
+func (s *ParallelActionsSuite) TestSerialActionNotDispatched(c *gc.C) {
+	rnr := newParallelActionRunner(nil)
+	dispatched, err := s.dispatcher().Dispatch("restart", rnr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dispatched, jc.IsFalse)
+	c.Assert(s.workers, gc.HasLen, 0)
+}
+
+func (s *ParallelActionsSuite) TestNoActionsYaml(c *gc.C) {
+	s.charmDir = c.MkDir()
+	dispatched, err := s.dispatcher().Dispatch("backup", newParallelActionRunner(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dispatched, jc.IsFalse)
+}
+
+func (s *ParallelActionsSuite) TestParallelActionRunsInBackground(c *gc.C) {
+	rnr := newParallelActionRunner(nil)
+	dispatched, err := s.dispatcher().Dispatch("backup", rnr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dispatched, jc.IsTrue)
+	c.Assert(s.workers, gc.HasLen, 1)
+
+	select {
+	case name := <-rnr.started:
+		c.Assert(name, gc.Equals, "backup")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("action not started")
+	}
+
+	// A charm deployment waits for the action to complete.
+	deployer := &fakeDeployer{deployed: make(chan struct{})}
+	go uniter.NewCharmDirLockingDeployer(deployer, &s.lock).Deploy()
+	select {
+	case <-deployer.deployed:
+		c.Fatalf("charm deployed while action running")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	close(rnr.finish)
+	select {
+	case <-deployer.deployed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("charm not deployed after action completed")
+	}
+	workertest.CheckKill(c, s.workers[0])
+	c.Assert(s.failed, gc.HasLen, 0)
+}
+
+func (s *ParallelActionsSuite) TestParallelActionRunErrorFailsAction(c *gc.C) {
+	rnr := newParallelActionRunner(errors.New("boom"))
+	close(rnr.finish)
+	dispatched, err := s.dispatcher().Dispatch("backup", rnr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dispatched, jc.IsTrue)
+
+	err = s.workers[0].Wait()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.failed, jc.DeepEquals, map[string]string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479": "boom",
+	})
+}
+
+func (s *ParallelActionsSuite) TestParallelActionNotRunIfWorkerRejected(c *gc.C) {
+	s.addErr = errors.New("catacomb dying")
+	rnr := newParallelActionRunner(nil)
+	dispatched, err := s.dispatcher().Dispatch("backup", rnr)
+	c.Assert(err, gc.ErrorMatches, "catacomb dying")
+	c.Assert(dispatched, jc.IsFalse)
+	select {
+	case <-rnr.started:
+		c.Fatalf("action started")
+	default:
+	}
+
+	// The charm directory lock has been released.
+	deployer := &fakeDeployer{deployed: make(chan struct{})}
+	err = uniter.NewCharmDirLockingDeployer(deployer, &s.lock).Deploy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ParallelActionsSuite) TestInterruptedActionFailedOnRestart(c *gc.C) {
+	rnr := newParallelActionRunner(nil)
+	defer close(rnr.finish)
+	_, err := s.dispatcher().Dispatch("backup", rnr)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-rnr.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("action not started")
+	}
+
+	// The agent restarts while the action is running.
+	err = uniter.FailInterruptedParallelActions(s.dispatcher())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.failed, jc.DeepEquals, map[string]string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479": "action interrupted by agent restart",
+	})
+	_, err = os.Stat(s.stateFile)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ParallelActionsSuite) TestFinishedActionNotFailedOnRestart(c *gc.C) {
+	rnr := newParallelActionRunner(nil)
+	close(rnr.finish)
+	_, err := s.dispatcher().Dispatch("backup", rnr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.workers[0].Wait()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = uniter.FailInterruptedParallelActions(s.dispatcher())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.failed, gc.HasLen, 0)
+}
+
+func (s *ParallelActionsSuite) TestRejectedActionNotFailedOnRestart(c *gc.C) {
+	s.addErr = errors.New("catacomb dying")
+	_, err := s.dispatcher().Dispatch("backup", newParallelActionRunner(nil))
+	c.Assert(err, gc.ErrorMatches, "catacomb dying")
+
+	err = uniter.FailInterruptedParallelActions(s.dispatcher())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.failed, gc.HasLen, 0)
+}
+
+type parallelActionRunner struct {
+	runner.Runner
+	ctx     *parallelActionContext
+	started chan string
+	finish  chan struct{}
+	err     error
+}
+
+func newParallelActionRunner(err error) *parallelActionRunner {
+	tag := names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	return &parallelActionRunner{
+		ctx: &parallelActionContext{
+			actionData: runnercontext.NewActionData("backup", &tag, nil),
+		},
+		started: make(chan string, 1),
+		finish:  make(chan struct{}),
+		err:     err,
+	}
+}
+
+func (r *parallelActionRunner) Context() runner.Context {
+	return r.ctx
+}
+
+func (r *parallelActionRunner) RunAction(name string) error {
+	r.started <- name
+	<-r.finish
+	return r.err
+}
+
+type parallelActionContext struct {
+	runner.Context
+	actionData *runnercontext.ActionData
+}
+
+func (ctx *parallelActionContext) ActionData() (*runnercontext.ActionData, error) {
+	return ctx.actionData, nil
+}
+
+type fakeDeployer struct {
+	charm.Deployer
+	deployed chan struct{}
+}
+
+func (d *fakeDeployer) Deploy() error {
+	close(d.deployed)
+	return nil
+}
@@ -103,6 +103,18 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// StatusBufferFile holds agent status updates that could not be
+	// sent while the controller was unreachable.
+	StatusBufferFile string
+
+	// ParallelActionsFile holds the ids of the parallel actions that
+	// are running in the background.
+	ParallelActionsFile string
+
+	// SnapshotDir holds copies of the charm and unit state, which are
+	// restored if a hook fails and the charm asks to be rolled back.
+	SnapshotDir string
 }
 
 // NewPaths returns the set of filesystem paths that the supplied unit should
@@ -145,14 +157,17 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string) Paths
 			JujucServerSocket: socket("agent", true),
 		},
 		State: StatePaths{
-			BaseDir:         baseDir,
-			CharmDir:        join(baseDir, "charm"),
-			OperationsFile:  join(stateDir, "uniter"),
-			RelationsDir:    join(stateDir, "relations"),
-			BundlesDir:      join(stateDir, "bundles"),
-			DeployerDir:     join(stateDir, "deployer"),
-			StorageDir:      join(stateDir, "storage"),
-			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			BaseDir:             baseDir,
+			CharmDir:            join(baseDir, "charm"),
+			OperationsFile:      join(stateDir, "uniter"),
+			RelationsDir:        join(stateDir, "relations"),
+			BundlesDir:          join(stateDir, "bundles"),
+			DeployerDir:         join(stateDir, "deployer"),
+			StorageDir:          join(stateDir, "storage"),
+			MetricsSpoolDir:     join(stateDir, "spool", "metrics"),
+			StatusBufferFile:    join(stateDir, "status-buffer"),
+			ParallelActionsFile: join(stateDir, "parallel-actions"),
+			SnapshotDir:         join(stateDir, "snapshot"),
 		},
 	}
 }
@@ -44,14 +44,17 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			JujucServerSocket: `\\.\pipe\unit-some-application-323-agent`,
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:             relAgent(),
+			CharmDir:            relAgent("charm"),
+			OperationsFile:      relAgent("state", "uniter"),
+			RelationsDir:        relAgent("state", "relations"),
+			BundlesDir:          relAgent("state", "bundles"),
+			DeployerDir:         relAgent("state", "deployer"),
+			StorageDir:          relAgent("state", "storage"),
+			MetricsSpoolDir:     relAgent("state", "spool", "metrics"),
+			StatusBufferFile:    relAgent("state", "status-buffer"),
+			ParallelActionsFile: relAgent("state", "parallel-actions"),
+			SnapshotDir:         relAgent("state", "snapshot"),
 		},
 	})
 }
@@ -73,14 +76,17 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			JujucServerSocket: `\\.\pipe\unit-some-application-323-some-worker-agent`,
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:             relAgent(),
+			CharmDir:            relAgent("charm"),
+			OperationsFile:      relAgent("state", "uniter"),
+			RelationsDir:        relAgent("state", "relations"),
+			BundlesDir:          relAgent("state", "bundles"),
+			DeployerDir:         relAgent("state", "deployer"),
+			StorageDir:          relAgent("state", "storage"),
+			MetricsSpoolDir:     relAgent("state", "spool", "metrics"),
+			StatusBufferFile:    relAgent("state", "status-buffer"),
+			ParallelActionsFile: relAgent("state", "parallel-actions"),
+			SnapshotDir:         relAgent("state", "snapshot"),
 		},
 	})
 }
@@ -101,14 +107,17 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			JujucServerSocket: "@" + relAgent("agent.socket"),
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:             relAgent(),
+			CharmDir:            relAgent("charm"),
+			OperationsFile:      relAgent("state", "uniter"),
+			RelationsDir:        relAgent("state", "relations"),
+			BundlesDir:          relAgent("state", "bundles"),
+			DeployerDir:         relAgent("state", "deployer"),
+			StorageDir:          relAgent("state", "storage"),
+			MetricsSpoolDir:     relAgent("state", "spool", "metrics"),
+			StatusBufferFile:    relAgent("state", "status-buffer"),
+			ParallelActionsFile: relAgent("state", "parallel-actions"),
+			SnapshotDir:         relAgent("state", "snapshot"),
 		},
 	})
 }
@@ -130,14 +139,17 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			JujucServerSocket: "@" + relAgent(worker+"-agent.socket"),
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			OperationsFile:  relAgent("state", "uniter"),
-			RelationsDir:    relAgent("state", "relations"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			StorageDir:      relAgent("state", "storage"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:             relAgent(),
+			CharmDir:            relAgent("charm"),
+			OperationsFile:      relAgent("state", "uniter"),
+			RelationsDir:        relAgent("state", "relations"),
+			BundlesDir:          relAgent("state", "bundles"),
+			DeployerDir:         relAgent("state", "deployer"),
+			StorageDir:          relAgent("state", "storage"),
+			MetricsSpoolDir:     relAgent("state", "spool", "metrics"),
+			StatusBufferFile:    relAgent("state", "status-buffer"),
+			ParallelActionsFile: relAgent("state", "parallel-actions"),
+			SnapshotDir:         relAgent("state", "snapshot"),
 		},
 	})
 }
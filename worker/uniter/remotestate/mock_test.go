@@ -107,6 +107,7 @@ type mockState struct {
 	storageAttachmentWatchers   map[names.StorageTag]*mockNotifyWatcher
 	updateStatusInterval        time.Duration
 	updateStatusIntervalWatcher *mockNotifyWatcher
+	leaderSettings              map[string]string
 }
 
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
@@ -194,6 +195,13 @@ func (st *mockState) WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, err
 	return st.updateStatusIntervalWatcher, nil
 }
 
+func (st *mockState) ReadLeadershipSettings(appName string) (map[string]string, error) {
+	if appName != st.unit.application.tag.Id() {
+		return nil, &params.Error{Code: params.CodeNotFound}
+	}
+	return st.leaderSettings, nil
+}
+
 type mockUnit struct {
 	tag                              names.UnitTag
 	life                             params.Life
@@ -16,6 +16,15 @@ type Snapshot struct {
 	// Life is the lifecycle state of the unit.
 	Life params.Life
 
+	// ApplicationLife is the lifecycle state of the unit's application.
+	// It is only tracked in IAAS models.
+	ApplicationLife params.Life
+
+	// PreDestroyed reports whether the unit's dying application has
+	// had its pre-destroy hook run by the leader, or has waited long
+	// enough for it, so that the unit may begin to tear down.
+	PreDestroyed bool
+
 	// Relations contains the lifecycle states of
 	// each of the application's relations, keyed by
 	// relation IDs.
@@ -30,6 +30,7 @@ type State interface {
 	WatchStorageAttachment(names.StorageTag, names.UnitTag) (watcher.NotifyWatcher, error)
 	WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error)
 	UpdateStatusHookInterval() (time.Duration, error)
+	ReadLeadershipSettings(appName string) (map[string]string, error)
 }
 
 type Unit interface {
@@ -102,6 +103,10 @@ func (st apiState) Relation(tag names.RelationTag) (Relation, error) {
 	return apiRelation{r}, err
 }
 
+func (st apiState) ReadLeadershipSettings(appName string) (map[string]string, error) {
+	return st.State.LeadershipSettings.Read(appName)
+}
+
 func (st apiState) Unit(tag names.UnitTag) (Unit, error) {
 	u, err := st.State.Unit(tag)
 	return apiUnit{u}, err
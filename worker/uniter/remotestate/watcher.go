@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -22,6 +23,11 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter.remotestate")
 
+// PreDestroyedSetting is the leader setting that the leader of a dying
+// application sets once it has run the pre-destroy hook, so that the
+// application's other units may begin to tear down.
+const PreDestroyedSetting = "juju-pre-destroyed"
+
 // RemoteStateWatcher collects unit, application, and application config information
 // from separate state watchers, and updates a Snapshot which is sent on a
 // channel upon change.
@@ -39,6 +45,8 @@ type RemoteStateWatcher struct {
 	commandChannel            <-chan string
 	retryHookChannel          watcher.NotifyChannel
 	applicationChannel        watcher.NotifyChannel
+	clock                     clock.Clock
+	preDestroyTimeout         time.Duration
 
 	catacomb catacomb.Catacomb
 
@@ -58,6 +66,15 @@ type WatcherConfig struct {
 	ApplicationChannel  watcher.NotifyChannel
 	UnitTag             names.UnitTag
 	ModelType           model.ModelType
+
+	// Clock is used to time the wait for the pre-destroy hook. If nil,
+	// the wall clock is used.
+	Clock clock.Clock
+
+	// PreDestroyTimeout is how long a unit of a dying application waits
+	// for the leader to run the pre-destroy hook before it tears down
+	// regardless. If zero, the unit does not wait.
+	PreDestroyTimeout time.Duration
 }
 
 func (w WatcherConfig) validate() error {
@@ -73,6 +90,9 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 	if err := config.validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if config.Clock == nil {
+		config.Clock = clock.WallClock
+	}
 	w := &RemoteStateWatcher{
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
@@ -85,6 +105,8 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		retryHookChannel:          config.RetryHookChannel,
 		applicationChannel:        config.ApplicationChannel,
 		modelType:                 config.ModelType,
+		clock:                     config.Clock,
+		preDestroyTimeout:         config.PreDestroyTimeout,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -378,6 +400,20 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		updateStatusTimer = w.updateStatusChannel(updateStatusInterval).After()
 	}
 
+	// preDestroyTimer is started when the application is first seen
+	// to be dying, and bounds the wait for the pre-destroy hook.
+	var preDestroyTimer <-chan time.Time
+	checkPreDestroyed := func() error {
+		waiting, err := w.preDestroyChanged()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if waiting && preDestroyTimer == nil {
+			preDestroyTimer = w.clock.After(w.preDestroyTimeout)
+		}
+		return nil
+	}
+
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -401,6 +437,9 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.applicationChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			if err := checkPreDestroyed(); err != nil {
+				return errors.Trace(err)
+			}
 			observedEvent(&seenApplicationChange)
 
 		case hashes, ok := <-charmConfigw.Changes():
@@ -454,8 +493,15 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.leaderSettingsChanged(); err != nil {
 				return errors.Trace(err)
 			}
+			if err := checkPreDestroyed(); err != nil {
+				return errors.Trace(err)
+			}
 			observedEvent(&seenLeaderSettingsChange)
 
+		case <-preDestroyTimer:
+			logger.Warningf("pre-destroy hook not run within %v; tearing down regardless", w.preDestroyTimeout)
+			w.preDestroyTimedOut()
+
 		case actions, ok := <-actionsw.Changes():
 			logger.Debugf("got action change: %v ok=%t", actions, ok)
 			if !ok {
@@ -631,6 +677,7 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 		return errors.Trace(err)
 	}
 	w.mu.Lock()
+	w.current.ApplicationLife = w.application.Life()
 	w.current.CharmURL = url
 	w.current.ForceCharmUpgrade = force
 	w.current.CharmModifiedVersion = ver
@@ -663,6 +710,41 @@ func (w *RemoteStateWatcher) leaderSettingsChanged() error {
 	return nil
 }
 
+// preDestroyChanged records whether the leader of the unit's dying
+// application has run the pre-destroy hook. It reports whether the
+// unit is still waiting for the hook to be run.
+func (w *RemoteStateWatcher) preDestroyChanged() (bool, error) {
+	w.mu.Lock()
+	waiting := w.current.ApplicationLife == params.Dying && !w.current.PreDestroyed
+	w.mu.Unlock()
+	if !waiting {
+		return false, nil
+	}
+	if w.preDestroyTimeout == 0 {
+		w.preDestroyTimedOut()
+		return false, nil
+	}
+	settings, err := w.st.ReadLeadershipSettings(w.application.Tag().Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if settings[PreDestroyedSetting] == "" {
+		return true, nil
+	}
+	w.mu.Lock()
+	w.current.PreDestroyed = true
+	w.mu.Unlock()
+	return false, nil
+}
+
+// preDestroyTimedOut is called when the unit has waited as long as it
+// may for the pre-destroy hook to be run.
+func (w *RemoteStateWatcher) preDestroyTimedOut() {
+	w.mu.Lock()
+	w.current.PreDestroyed = true
+	w.mu.Unlock()
+}
+
 func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) {
 	w.mu.Lock()
 	w.current.Leader = isLeader
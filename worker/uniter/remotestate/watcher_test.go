@@ -96,6 +96,8 @@ func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
+		PreDestroyTimeout:   time.Minute,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -191,6 +193,7 @@ func (s *WatcherSuiteIAAS) TestSnapshot(c *gc.C) {
 	snap := s.watcher.Snapshot()
 	c.Assert(snap, jc.DeepEquals, remotestate.Snapshot{
 		Life:                  s.st.unit.life,
+		ApplicationLife:       s.st.unit.application.life,
 		Relations:             map[int]remotestate.RelationSnapshot{},
 		Storage:               map[names.StorageTag]remotestate.StorageSnapshot{},
 		CharmModifiedVersion:  s.st.unit.application.charmModifiedVersion,
@@ -283,6 +286,49 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuiteIAAS) TestPreDestroyed(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.application.life = params.Dying
+	s.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snap := s.watcher.Snapshot()
+	c.Assert(snap.ApplicationLife, gc.Equals, params.Dying)
+	c.Assert(snap.PreDestroyed, jc.IsFalse)
+
+	s.st.leaderSettings = map[string]string{remotestate.PreDestroyedSetting: "true"}
+	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreDestroyed, jc.IsTrue)
+}
+
+func (s *WatcherSuiteIAAS) TestPreDestroyTimeout(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.application.life = params.Dying
+	s.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreDestroyed, jc.IsFalse)
+
+	// Both the update-status timer and the pre-destroy timer are waiting.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreDestroyed, jc.IsTrue)
+}
+
+func (s *WatcherSuiteCAAS) TestPreDestroyedWithoutTimeout(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.st.unit.application.life = params.Dying
+	s.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().PreDestroyed, jc.IsTrue)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
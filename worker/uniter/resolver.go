@@ -30,11 +30,26 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+
+	// ShouldRetryTimedOutHooks reports whether hooks that were killed
+	// for exceeding the hook timeout are retried automatically. Such
+	// hooks are likely to hang again, so the uniter leaves them for
+	// the operator to resolve.
+	ShouldRetryTimedOutHooks bool
+
+	// ReportPendingHooks, if set, is called with the hooks that the
+	// unit is waiting to run whenever they change.
+	ReportPendingHooks func([]string) error
 }
 
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// reportedHooks holds the pending hooks last
+	// reported with ReportPendingHooks.
+	reportedHooks []string
+	hooksReported bool
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -63,6 +78,7 @@ func (s *uniterResolver) NextOp(
 	if remoteState.Life == params.Dead || localState.Stopped {
 		return nil, resolver.ErrTerminate
 	}
+	s.reportPendingHooks(localState, remoteState)
 
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
@@ -99,6 +115,28 @@ func (s *uniterResolver) NextOp(
 		s.retryHookTimerStarted = false
 	}
 
+	if preDestroyPending(localState, remoteState) {
+		// The leader runs the pre-destroy hook before it resigns
+		// leadership or tears anything down, so that the charm can
+		// deregister the application from external systems.
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PreDestroy})
+	}
+	if preDestroyAwaited(localState, remoteState) {
+		// The application's other units don't begin to tear down
+		// until the leader has run the pre-destroy hook, though they
+		// still run actions and commands in the meantime.
+		op, err := s.config.Actions.NextOp(localState, remoteState, opFactory)
+		if errors.Cause(err) != resolver.ErrNoOperation {
+			return op, err
+		}
+		op, err = s.config.Commands.NextOp(localState, remoteState, opFactory)
+		if errors.Cause(err) != resolver.ErrNoOperation {
+			return op, err
+		}
+		logger.Debugf("waiting for the leader to run the %q hook", hook.PreDestroy)
+		return nil, resolver.ErrWaiting
+	}
+
 	op, err = s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
@@ -208,7 +246,11 @@ func (s *uniterResolver) nextOpHookError(
 			s.retryHookTimerStarted = false
 			return opFactory.NewRunHook(*localState.Hook)
 		}
-		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+		shouldRetry := s.config.ShouldRetryHooks
+		if localState.HookTimedOut && !s.config.ShouldRetryTimedOutHooks {
+			shouldRetry = false
+		}
+		if !s.retryHookTimerStarted && shouldRetry {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
@@ -254,6 +296,30 @@ func charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool
 	return false
 }
 
+// preDestroyPending reports whether the pre-destroy hook should be run:
+// the unit's application is being destroyed, the unit is the leader and
+// is not in an error state, and the hook has not already been run. The
+// hook is run as soon as the application is dying, whether or not the
+// unit itself is yet.
+func preDestroyPending(localState resolver.LocalState, remoteState remotestate.Snapshot) bool {
+	return remoteState.ApplicationLife == params.Dying &&
+		remoteState.Leader &&
+		localState.Kind == operation.Continue &&
+		localState.Started &&
+		!localState.PreDestroyed
+}
+
+// preDestroyAwaited reports whether the unit must wait for the leader of
+// its dying application to run the pre-destroy hook before it begins to
+// tear down.
+func preDestroyAwaited(localState resolver.LocalState, remoteState remotestate.Snapshot) bool {
+	return remoteState.Life == params.Dying &&
+		remoteState.ApplicationLife == params.Dying &&
+		!remoteState.Leader &&
+		!remoteState.PreDestroyed &&
+		localState.Kind == operation.Continue
+}
+
 func (s *uniterResolver) nextOp(
 	localState resolver.LocalState,
 	remoteState remotestate.Snapshot,
@@ -301,7 +367,7 @@ func (s *uniterResolver) nextOp(
 	trustHashChanged := localState.TrustHash != remoteState.TrustHash
 	addressesHashChanged := localState.AddressesHash != remoteState.AddressesHash
 	if configHashChanged || trustHashChanged || addressesHashChanged {
-		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		return opFactory.NewRunHookWithSnapshot(hook.Info{Kind: hooks.ConfigChanged})
 	}
 
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
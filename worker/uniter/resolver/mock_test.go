@@ -58,6 +58,11 @@ func (f *mockOpFactory) NewRunHook(info hook.Info) (operation.Operation, error)
 	return f.op, f.NextErr()
 }
 
+func (f *mockOpFactory) NewRunHookWithSnapshot(info hook.Info) (operation.Operation, error) {
+	f.MethodCall(f, "NewRunHookWithSnapshot", info)
+	return f.op, f.NextErr()
+}
+
 func (f *mockOpFactory) NewSkipHook(info hook.Info) (operation.Operation, error) {
 	f.MethodCall(f, "NewSkipHook", info)
 	return f.op, f.NextErr()
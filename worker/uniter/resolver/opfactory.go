@@ -41,6 +41,14 @@ func (s *resolverOpFactory) NewRunHook(info hook.Info) (operation.Operation, err
 	return s.wrapHookOp(op, info), nil
 }
 
+func (s *resolverOpFactory) NewRunHookWithSnapshot(info hook.Info) (operation.Operation, error) {
+	op, err := s.Factory.NewRunHookWithSnapshot(info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.wrapHookOp(op, info), nil
+}
+
 func (s *resolverOpFactory) NewSkipHook(info hook.Info) (operation.Operation, error) {
 	op, err := s.Factory.NewSkipHook(info)
 	if err != nil {
@@ -63,6 +63,7 @@ func (s *ResolverOpFactorySuite) testUpdateStatusChanged(
 
 func (s *ResolverOpFactorySuite) TestConfigChanged(c *gc.C) {
 	s.testConfigChanged(c, resolver.ResolverOpFactory.NewRunHook)
+	s.testConfigChanged(c, resolver.ResolverOpFactory.NewRunHookWithSnapshot)
 	s.testConfigChanged(c, resolver.ResolverOpFactory.NewSkipHook)
 }
 
@@ -134,6 +134,93 @@ func (s *resolverSuite) TestNotStartedNotInstalled(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run install hook")
 }
 
+func (s *resolverSuite) TestReportPendingHooks(c *gc.C) {
+	var reported [][]string
+	s.resolverConfig.ReportPendingHooks = func(pending []string) error {
+		reported = append(reported, pending)
+		return nil
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Leader = true
+	s.remoteState.ConfigHash = "version1"
+	s.remoteState.UpdateStatusVersion = 1
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The same pending hooks are not reported again.
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+
+	localState.Leader = true
+	localState.ConfigHash = "version1"
+	localState.UpdateStatusVersion = 1
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	c.Assert(reported, jc.DeepEquals, [][]string{
+		{"leader-elected", "config-changed", "update-status"},
+		nil,
+	})
+}
+
+func (s *resolverSuite) TestReportPendingHooksCurrentHook(c *gc.C) {
+	var reported []string
+	s.resolverConfig.ReportPendingHooks = func(pending []string) error {
+		reported = pending
+		return nil
+	}
+	s.reportHookError = func(hook.Info) error { return nil }
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook:      &hook.Info{Kind: hooks.ConfigChanged},
+		},
+	}
+	s.remoteState.ConfigHash = "version1"
+	s.remoteState.UpdateStatusVersion = 1
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(reported, jc.DeepEquals, []string{"config-changed", "update-status"})
+}
+
+func (s *resolverSuite) TestReportPendingHooksNotInstalled(c *gc.C) {
+	var reported []string
+	s.resolverConfig.ReportPendingHooks = func(pending []string) error {
+		reported = pending
+		return nil
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	s.remoteState.ConfigHash = "version1"
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reported, jc.DeepEquals, []string{"install"})
+}
+
 func (s *iaasResolverSuite) TestUpgradeSeriesPrepareStatusChanged(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
@@ -229,6 +316,34 @@ func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c
 	s.stub.CheckNoCalls(c)
 }
 
+func (s *resolverSuite) TestHookTimeoutDoesNotStartRetryTimer(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Installed:    true,
+			Started:      true,
+			HookTimedOut: true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckNoCalls(c)
+
+	// Timed out hooks are retried if configured to do so.
+	s.resolverConfig.ShouldRetryTimedOutHooks = true
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+}
+
 func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 	s.reportHookError = func(hook.Info) error { return nil }
 	localState := resolver.LocalState{
@@ -351,6 +466,106 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
 
+func (s *resolverSuite) TestRunsPreDestroyWhenApplicationDying(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.ApplicationLife = params.Dying
+	s.remoteState.Leader = true
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-destroy hook")
+}
+
+func (s *resolverSuite) TestStopsAfterPreDestroy(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.Continue,
+			Installed:    true,
+			Started:      true,
+			PreDestroyed: true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.ApplicationLife = params.Dying
+	s.remoteState.Leader = true
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestRunsPreDestroyBeforeUnitDying(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Alive
+	s.remoteState.ApplicationLife = params.Dying
+	s.remoteState.Leader = true
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-destroy hook")
+}
+
+func (s *resolverSuite) TestWaitsForLeaderPreDestroy(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.ApplicationLife = params.Dying
+	s.remoteState.Leader = false
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrWaiting)
+
+	s.remoteState.PreDestroyed = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestNoPreDestroyWhenOnlyUnitDying(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.ApplicationLife = params.Alive
+	s.remoteState.Leader = true
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
 func (s *resolverSuite) TestRunsConfigChangedIfConfigHashChanges(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/statusbuffer"
 )
 
 // Paths exposes the paths needed by Context.
@@ -55,6 +56,9 @@ type Paths interface {
 
 // Clock defines the methods of the full clock.Clock that are needed here.
 type Clock interface {
+	// Now returns the current clock time.
+	Now() time.Time
+
 	// After waits for the duration to elapse and then sends the
 	// current time on the returned channel.
 	After(time.Duration) <-chan time.Time
@@ -138,6 +142,11 @@ type HookContext struct {
 	// id identifies the context.
 	id string
 
+	// hookName is the name of the hook, action or command run in
+	// the context. It is reported to the controller whenever the
+	// context fetches the model's cloud credential.
+	hookName string
+
 	// actionData contains the values relevant to the run of an Action:
 	// its tag, its parameters, and its results.
 	actionData *ActionData
@@ -193,6 +202,10 @@ type HookContext struct {
 	// machine.
 	assignedMachineTag names.MachineTag
 
+	// unitSlice is the name of the systemd slice in which the unit's
+	// agent runs, if it runs in one.
+	unitSlice string
+
 	// process is the process of the command that is being run in the local context,
 	// like a juju-run command or a hook
 	process HookProcess
@@ -223,6 +236,10 @@ type HookContext struct {
 	// clock is used for any time operations.
 	clock Clock
 
+	// statusBuffer, if set, holds workload status updates that could
+	// not be sent while the controller was unreachable.
+	statusBuffer *statusbuffer.Buffer
+
 	componentDir   func(string) string
 	componentFuncs map[string]ComponentFunc
 
@@ -372,11 +389,20 @@ func (ctx *HookContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error)
 func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	ctx.hasRunStatusSet = true
 	logger.Tracef("[WORKLOAD-STATUS] %s: %s", unitStatus.Status, unitStatus.Info)
-	return ctx.unit.SetUnitStatus(
-		status.Status(unitStatus.Status),
-		unitStatus.Info,
-		unitStatus.Data,
-	)
+	if ctx.statusBuffer == nil {
+		return ctx.unit.SetUnitStatus(
+			status.Status(unitStatus.Status),
+			unitStatus.Info,
+			unitStatus.Data,
+		)
+	}
+	return ctx.statusBuffer.Send(ctx.unit, statusbuffer.Entry{
+		Kind:   statusbuffer.Workload,
+		Status: status.Status(unitStatus.Status),
+		Info:   unitStatus.Info,
+		Data:   unitStatus.Data,
+		Since:  ctx.clock.Now(),
+	})
 }
 
 // SetApplicationStatus will set the given status to the application to which this
@@ -538,13 +564,23 @@ func (ctx *HookContext) SetPodSpec(specYaml string) error {
 // CloudSpec return the cloud specification for the running unit's model
 func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
 	var err error
-	ctx.cloudSpec, err = ctx.state.CloudSpec()
+	ctx.cloudSpec, err = ctx.state.CloudSpec(ctx.hookName)
 	if err != nil {
 		return nil, err
 	}
 	return ctx.cloudSpec, nil
 }
 
+// UnitLabels returns the labels attached to the unit.
+func (ctx *HookContext) UnitLabels() (map[string]string, error) {
+	return ctx.unit.Labels()
+}
+
+// UpdateUnitLabels sets and removes labels of the unit.
+func (ctx *HookContext) UpdateUnitLabels(set map[string]string, remove []string) error {
+	return ctx.unit.UpdateLabels(set, remove)
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -658,6 +694,7 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 		"JUJU_MACHINE_ID="+context.assignedMachineTag.Id(),
 		"JUJU_PRINCIPAL_UNIT="+context.principal,
 		"JUJU_AVAILABILITY_ZONE="+context.availabilityzone,
+		"JUJU_UNIT_SLICE="+context.unitSlice,
 		"JUJU_VERSION="+version.Current.String(),
 		"CLOUD_API_VERSION="+context.cloudAPIVersion,
 		// Some of these will be empty, but that is fine, better
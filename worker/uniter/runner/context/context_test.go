@@ -161,6 +161,22 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestGetUpdateUnitLabels(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	labels, err := ctx.UnitLabels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, gc.HasLen, 0)
+
+	err = ctx.UpdateUnitLabels(map[string]string{"env": "canary", "tier": "web"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.UpdateUnitLabels(nil, []string{"tier"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels, err = ctx.UnitLabels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, map[string]string{"env": "canary"})
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -16,8 +16,11 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/systemd"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/statusbuffer"
 )
 
 // CommandInfo specifies the information necessary to run a command.
@@ -60,6 +63,9 @@ type StorageContextAccessor interface {
 // creation time.
 type RelationsFunc func() map[int]*RelationInfo
 
+// isRunningSystemd is a surrogate for systemd.IsRunning.
+var isRunningSystemd = systemd.IsRunning
+
 type contextFactory struct {
 	// API connection fields; unit should be deprecated, but isn't yet.
 	unit    *uniter.Unit
@@ -76,6 +82,9 @@ type contextFactory struct {
 	clock      Clock
 	zone       string
 	principal  string
+	unitSlice  string
+
+	statusBuffer *statusbuffer.Buffer
 
 	// Callback to get relation state snapshot.
 	getRelationInfos RelationsFunc
@@ -95,6 +104,10 @@ type FactoryConfig struct {
 	Storage          StorageContextAccessor
 	Paths            Paths
 	Clock            Clock
+
+	// StatusBuffer, if set, holds workload status updates that
+	// could not be sent while the controller was unreachable.
+	StatusBuffer *statusbuffer.Buffer
 }
 
 // NewContextFactory returns a ContextFactory capable of creating execution contexts backed
@@ -111,6 +124,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 	var (
 		machineTag names.MachineTag
 		zone       string
+		unitSlice  string
 	)
 	if m.ModelType == model.IAAS {
 		machineTag, err = unit.AssignedMachine()
@@ -122,6 +136,14 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+
+		// Unit agents only run in their own slice under systemd.
+		if isRunningSystemd() {
+			unitSlice, err = service.UnitAgentSliceName(unit.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	}
 	principal, ok, err := unit.PrincipalName()
 	if err != nil {
@@ -145,7 +167,9 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		clock:            config.Clock,
 		zone:             zone,
 		principal:        principal,
+		unitSlice:        unitSlice,
 		modelType:        m.ModelType,
+		statusBuffer:     config.StatusBuffer,
 	}
 	return f, nil
 }
@@ -180,6 +204,8 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		componentFuncs:     registeredComponentFuncs,
 		availabilityzone:   f.zone,
 		principal:          f.principal,
+		unitSlice:          f.unitSlice,
+		statusBuffer:       f.statusBuffer,
 	}
 	if err := f.updateContext(ctx); err != nil {
 		return nil, err
@@ -197,6 +223,7 @@ func (f *contextFactory) ActionContext(actionData *ActionData) (*HookContext, er
 		return nil, errors.Trace(err)
 	}
 	ctx.actionData = actionData
+	ctx.hookName = actionData.Name
 	ctx.id = f.newId(actionData.Name)
 	return ctx, nil
 }
@@ -234,6 +261,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	ctx.hookName = hookName
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -250,7 +278,8 @@ func (f *contextFactory) CommandContext(commandInfo CommandInfo) (*HookContext,
 	}
 	ctx.relationId = relationId
 	ctx.remoteUnitName = remoteUnitName
-	ctx.id = f.newId("run-commands")
+	ctx.hookName = "run-commands"
+	ctx.id = f.newId(ctx.hookName)
 	return ctx, nil
 }
 
@@ -134,6 +134,16 @@ func (s *ContextFactorySuite) TestNewHookContextRetrievesSLALevel(c *gc.C) {
 	c.Assert(ctx.SLALevel(), gc.Equals, "essential")
 }
 
+func (s *ContextFactorySuite) TestContextHookName(c *gc.C) {
+	ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.ContextHookName(ctx), gc.Equals, "config-changed")
+
+	ctx, err = s.factory.CommandContext(context.CommandInfo{RelationId: -1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(context.ContextHookName(ctx), gc.Equals, "run-commands")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextLeadershipContext(c *gc.C) {
 	s.testLeadershipContextWiring(c, func() *context.HookContext {
 		ctx, err := s.factory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
@@ -182,6 +192,34 @@ func (s *ContextFactorySuite) TestRelationHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) newContextFactory(c *gc.C) context.ContextFactory {
+	contextFactory, err := context.NewContextFactory(context.FactoryConfig{
+		State:            s.uniter,
+		UnitTag:          s.unit.Tag().(names.UnitTag),
+		Tracker:          runnertesting.FakeTracker{},
+		GetRelationInfos: s.getRelationInfos,
+		Storage:          s.storage,
+		Paths:            s.paths,
+		Clock:            testclock.NewClock(time.Time{}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return contextFactory
+}
+
+func (s *ContextFactorySuite) TestHookContextUnitSlice(c *gc.C) {
+	s.PatchValue(context.IsRunningSystemd, func() bool { return true })
+	ctx, err := s.newContextFactory(c).HookContext(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(context.ContextUnitSlice(ctx), gc.Equals, "jujud-"+s.unit.Tag().String()+".slice")
+}
+
+func (s *ContextFactorySuite) TestHookContextNoUnitSliceWithoutSystemd(c *gc.C) {
+	s.PatchValue(context.IsRunningSystemd, func() bool { return false })
+	ctx, err := s.newContextFactory(c).HookContext(hook.Info{Kind: hooks.Install})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(context.ContextUnitSlice(ctx), gc.Equals, "")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
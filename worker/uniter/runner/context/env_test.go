@@ -76,6 +76,7 @@ func (s *EnvSuite) getContext(newProxyOnly bool) (ctx *context.HookContext, expe
 		"JUJU_API_ADDRESSES=he.re:12345 the.re:23456",
 		"JUJU_MACHINE_ID=42",
 		"JUJU_AVAILABILITY_ZONE=some-zone",
+		"JUJU_UNIT_SLICE=jujud-unit-this-unit-123.slice",
 		"JUJU_VERSION=1.2.3",
 		"CLOUD_API_VERSION=6.66",
 	}
@@ -19,6 +19,7 @@ var (
 	ValidatePortRange = validatePortRange
 	TryOpenPorts      = tryOpenPorts
 	TryClosePorts     = tryClosePorts
+	IsRunningSystemd  = &isRunningSystemd
 )
 
 func NewHookContext(
@@ -161,6 +162,7 @@ func NewModelHookContext(
 		availabilityzone:   availZone,
 		slaLevel:           slaLevel,
 		principal:          unitName,
+		unitSlice:          "jujud-unit-this-unit-123.slice",
 		cloudAPIVersion:    "6.66",
 	}
 }
@@ -173,6 +175,14 @@ func ContextMachineTag(hctx *HookContext) names.MachineTag {
 	return hctx.assignedMachineTag
 }
 
+func ContextUnitSlice(hctx *HookContext) string {
+	return hctx.unitSlice
+}
+
+func ContextHookName(hctx *HookContext) string {
+	return hctx.hookName
+}
+
 func UpdateCachedSettings(cf0 ContextFactory, relId int, unitName string, settings params.Settings) {
 	cf := cf0.(*contextFactory)
 	members := cf.relationCaches[relId].members
@@ -12,8 +12,21 @@ var (
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	HookKillGracePeriod     = &hookKillGracePeriod
 )
 
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func RunnerRuntime(rnr Runner) Runtime {
+	return rnr.(*runner).runtime
+}
+
+func NewRunnerWithLimits(ctx Context, paths context.Paths, limits OutputLimits) Runner {
+	return &runner{context: ctx, paths: paths, runtime: HostRuntime, limits: limits}
+}
+
+func RunnerOutputLimits(rnr Runner) OutputLimits {
+	return rnr.(*runner).limits
+}
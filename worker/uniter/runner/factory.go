@@ -33,19 +33,32 @@ type Factory interface {
 	NewActionRunner(actionId string) (Runner, error)
 }
 
+// FactoryParams holds all the necessary parameters for a new runner factory.
+type FactoryParams struct {
+	State          *uniter.State
+	Paths          context.Paths
+	ContextFactory context.ContextFactory
+
+	// SelectRuntime, if not nil, is called with the metadata of the
+	// deployed charm to choose the runtime used to run its hooks and
+	// actions. If it is nil, or selects a nil runtime, hooks and actions
+	// are run on the host.
+	SelectRuntime RuntimeSelector
+
+	// OutputLimits holds the limits applied to the output of
+	// hooks and actions.
+	OutputLimits OutputLimits
+}
+
 // NewFactory returns a Factory capable of creating runners for executing
 // charm hooks, actions and commands.
-func NewFactory(
-	state *uniter.State,
-	paths context.Paths,
-	contextFactory context.ContextFactory,
-) (
-	Factory, error,
-) {
+func NewFactory(params FactoryParams) (Factory, error) {
 	f := &factory{
-		state:          state,
-		paths:          paths,
-		contextFactory: contextFactory,
+		state:          params.State,
+		paths:          params.Paths,
+		contextFactory: params.ContextFactory,
+		selectRuntime:  params.SelectRuntime,
+		outputLimits:   params.OutputLimits,
 	}
 
 	return f, nil
@@ -58,7 +71,38 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths         context.Paths
+	selectRuntime RuntimeSelector
+	outputLimits  OutputLimits
+}
+
+// newRunner returns a Runner for the supplied context, which runs hooks
+// and actions using the runtime selected for the deployed charm.
+func (f *factory) newRunner(ctx Context) (Runner, error) {
+	if f.selectRuntime == nil {
+		return f.newRunnerWithRuntime(ctx, HostRuntime), nil
+	}
+	ch, err := getCharm(f.paths.GetCharmDir())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm metadata")
+	}
+	runtime, err := f.selectRuntime(ch.Meta())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot select hook runtime")
+	}
+	if runtime == nil {
+		runtime = HostRuntime
+	}
+	return f.newRunnerWithRuntime(ctx, runtime), nil
+}
+
+func (f *factory) newRunnerWithRuntime(ctx Context, runtime Runtime) Runner {
+	return &runner{
+		context: ctx,
+		paths:   f.paths,
+		runtime: runtime,
+		limits:  f.outputLimits,
+	}
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -81,7 +125,10 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner, err := f.newRunner(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return runner, nil
 }
 
@@ -124,7 +171,13 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner, err := f.newRunner(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return runner, nil
 }
 
@@ -13,6 +13,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
 
@@ -114,6 +115,75 @@ func (s *FactorySuite) TestNewHookRunner(c *gc.C) {
 	s.AssertPaths(c, rnr)
 }
 
+func (s *FactorySuite) TestNewHookRunnerHostRuntime(c *gc.C) {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerRuntime(rnr), gc.Equals, runner.HostRuntime)
+}
+
+func (s *FactorySuite) newFactoryWithRuntime(c *gc.C, selectRuntime runner.RuntimeSelector) runner.Factory {
+	factory, err := runner.NewFactory(runner.FactoryParams{
+		State:          s.uniter,
+		Paths:          s.paths,
+		ContextFactory: s.contextFactory,
+		SelectRuntime:  selectRuntime,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return factory
+}
+
+func (s *FactorySuite) TestNewHookRunnerSelectsRuntime(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	rt := &recordingRuntime{}
+	var selectedFor string
+	factory := s.newFactoryWithRuntime(c, func(meta *charm.Meta) (runner.Runtime, error) {
+		selectedFor = meta.Name
+		return rt, nil
+	})
+
+	rnr, err := factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertPaths(c, rnr)
+	c.Assert(selectedFor, gc.Equals, "dummy")
+	c.Assert(runner.RunnerRuntime(rnr), gc.Equals, rt)
+}
+
+func (s *FactorySuite) TestNewHookRunnerSelectsNoRuntime(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	factory := s.newFactoryWithRuntime(c, func(*charm.Meta) (runner.Runtime, error) {
+		return nil, nil
+	})
+
+	rnr, err := factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerRuntime(rnr), gc.Equals, runner.HostRuntime)
+}
+
+func (s *FactorySuite) TestNewHookRunnerSelectRuntimeError(c *gc.C) {
+	s.SetCharm(c, "dummy")
+	factory := s.newFactoryWithRuntime(c, func(*charm.Meta) (runner.Runtime, error) {
+		return nil, errors.New("no sidecar")
+	})
+
+	rnr, err := factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(rnr, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "cannot select hook runtime: no sidecar")
+}
+
+func (s *FactorySuite) TestNewHookRunnerOutputLimits(c *gc.C) {
+	factory, err := runner.NewFactory(runner.FactoryParams{
+		State:          s.uniter,
+		Paths:          s.paths,
+		ContextFactory: s.contextFactory,
+		OutputLimits:   runner.OutputLimits{MaxBytes: 1024},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rnr, err := factory.NewHookRunner(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runner.RunnerOutputLimits(rnr).MaxBytes, gc.Equals, int64(1024))
+}
+
 func (s *FactorySuite) TestNewHookRunnerWithBadHook(c *gc.C) {
 	rnr, err := s.factory.NewHookRunner(hook.Info{})
 	c.Assert(rnr, gc.IsNil)
@@ -173,11 +243,11 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		Clock:            testclock.NewClock(time.Time{}),
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	factory, err := runner.NewFactory(
-		uniter,
-		s.paths,
-		contextFactory,
-	)
+	factory, err := runner.NewFactory(runner.FactoryParams{
+		State:          uniter,
+		Paths:          s.paths,
+		ContextFactory: contextFactory,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	rnr, err := factory.NewHookRunner(hook.Info{
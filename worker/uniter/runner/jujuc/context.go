@@ -103,6 +103,12 @@ type ContextUnit interface {
 
 	// CloudSpec returns the unit's cloud specification
 	CloudSpec() (*params.CloudSpec, error)
+
+	// UnitLabels returns the labels attached to the executing unit.
+	UnitLabels() (map[string]string, error)
+
+	// UpdateUnitLabels sets and removes labels of the executing unit.
+	UpdateUnitLabels(set map[string]string, remove []string) error
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
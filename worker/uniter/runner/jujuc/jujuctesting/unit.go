@@ -18,6 +18,7 @@ type Unit struct {
 	GoalState      application.GoalState
 	ContainerSpec  string
 	CloudSpec      params.CloudSpec
+	Labels         map[string]string
 }
 
 // ContextUnit is a test double for jujuc.ContextUnit.
@@ -70,3 +71,30 @@ func (c *ContextUnit) CloudSpec() (*params.CloudSpec, error) {
 	c.info.CloudSpec = params.CloudSpec{}
 	return &c.info.CloudSpec, nil
 }
+
+// UnitLabels implements jujuc.ContextUnit.
+func (c *ContextUnit) UnitLabels() (map[string]string, error) {
+	c.stub.AddCall("UnitLabels")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.Labels, nil
+}
+
+// UpdateUnitLabels implements jujuc.ContextUnit.
+func (c *ContextUnit) UpdateUnitLabels(set map[string]string, remove []string) error {
+	c.stub.AddCall("UpdateUnitLabels", set, remove)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Labels == nil {
+		c.info.Labels = make(map[string]string)
+	}
+	for key, value := range set {
+		c.info.Labels[key] = value
+	}
+	for _, key := range remove {
+		delete(c.info.Labels, key)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	corelabels "github.com/juju/juju/core/labels"
+)
+
+// labelGetCommand implements the label-get command.
+type labelGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+	out cmd.Output
+}
+
+// NewLabelGetCommand returns a new labelGetCommand with the given context.
+func NewLabelGetCommand(ctx Context) (cmd.Command, error) {
+	return &labelGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *labelGetCommand) Info() *cmd.Info {
+	doc := `
+label-get prints the value of the unit's label with the given key. If no key
+is given, all of the unit's labels are printed.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "label-get",
+		Args:    "[<key>]",
+		Purpose: "print the labels of the unit",
+		Doc:     doc,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *labelGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *labelGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	if err := corelabels.ValidateKey(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.key = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *labelGetCommand) Run(ctx *cmd.Context) error {
+	labels, err := c.ctx.UnitLabels()
+	if err != nil {
+		return errors.Annotatef(err, "cannot read unit labels")
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, labels)
+	}
+	if value, ok := labels[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
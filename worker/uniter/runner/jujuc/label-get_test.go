@@ -0,0 +1,74 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type LabelGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&LabelGetSuite{})
+
+func (s *LabelGetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.Labels = map[string]string{"env": "canary", "tier": "web"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("label-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *LabelGetSuite) TestAll(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--format", "yaml"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "env: canary\ntier: web\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *LabelGetSuite) TestKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"env"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "canary\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *LabelGetSuite) TestMissingKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"zone"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *LabelGetSuite) TestInvalidKey(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"Env"})
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR label key \"Env\" not valid\n")
+}
+
+func (s *LabelGetSuite) TestError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("splat"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read unit labels: splat\n")
+}
@@ -0,0 +1,77 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	corelabels "github.com/juju/juju/core/labels"
+)
+
+// labelSetCommand implements the label-set command.
+type labelSetCommand struct {
+	cmd.CommandBase
+	ctx    Context
+	set    map[string]string
+	remove []string
+}
+
+// NewLabelSetCommand returns a new labelSetCommand with the given context.
+func NewLabelSetCommand(ctx Context) (cmd.Command, error) {
+	return &labelSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *labelSetCommand) Info() *cmd.Info {
+	doc := `
+label-set immediately changes the labels of the unit: each key=value argument
+sets a label, and each key- argument removes one. Other labels are left
+unchanged. Keys and values may contain lowercase letters, digits, dashes and
+underscores, and must begin and end with a letter or digit.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "label-set",
+		Args:    "<key>=<value> [...] [<key>- ...]",
+		Purpose: "change the labels of the unit",
+		Doc:     doc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *labelSetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no labels specified")
+	}
+	c.set = nil
+	c.remove = nil
+	for _, arg := range args {
+		if strings.HasSuffix(arg, "-") && !strings.Contains(arg, "=") {
+			key := strings.TrimSuffix(arg, "-")
+			if err := corelabels.ValidateKey(key); err != nil {
+				return errors.Trace(err)
+			}
+			c.remove = append(c.remove, key)
+			continue
+		}
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected key=value or key-, got %q", arg)
+		}
+		if c.set == nil {
+			c.set = make(map[string]string)
+		}
+		c.set[parts[0]] = parts[1]
+	}
+	return errors.Trace(corelabels.Validate(c.set))
+}
+
+// Run is part of the cmd.Command interface.
+func (c *labelSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.UpdateUnitLabels(c.set, c.remove)
+	return errors.Annotatef(err, "cannot write unit labels")
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type LabelSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&LabelSetSuite{})
+
+func (s *LabelSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.Labels = map[string]string{"env": "canary", "tier": "web"}
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("label-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *LabelSetSuite) TestNoArguments(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no labels specified\n")
+}
+
+func (s *LabelSetSuite) TestInvalidArguments(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"env"},
+		err:  `expected key=value or key-, got "env"`,
+	}, {
+		args: []string{"Env=prod"},
+		err:  `label key "Env" not valid`,
+	}, {
+		args: []string{"env=Prod"},
+		err:  `label value "Prod" not valid`,
+	}, {
+		args: []string{"-"},
+		err:  `label key "" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, test.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR "+test.err+"\n")
+	}
+}
+
+func (s *LabelSetSuite) TestSetAndRemove(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"env=prod", "tier-"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Unit.Labels, jc.DeepEquals, map[string]string{"env": "prod"})
+}
+
+func (s *LabelSetSuite) TestError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("splat"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"env=prod"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot write unit labels: splat\n")
+	c.Check(hctx.info.Unit.Labels, jc.DeepEquals, map[string]string{"env": "canary", "tier": "web"})
+}
@@ -45,6 +45,16 @@ func (c *RestrictedContext) CloudSpec() (*params.CloudSpec, error) {
 	return nil, ErrRestrictedContext
 }
 
+// UnitLabels implements hooks.Context.
+func (*RestrictedContext) UnitLabels() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// UpdateUnitLabels implements hooks.Context.
+func (*RestrictedContext) UpdateUnitLabels(map[string]string, []string) error {
+	return ErrRestrictedContext
+}
+
 // SetUnitStatus implements hooks.Context.
 func (*RestrictedContext) SetUnitStatus(StatusInfo) error { return ErrRestrictedContext }
 
@@ -67,6 +67,8 @@ var baseCommands = map[string]creator{
 	"pod-spec-set" + cmdSuffix:            NewPodSpecSetCommand,
 	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"label-get" + cmdSuffix:               NewLabelGetCommand,
+	"label-set" + cmdSuffix:               NewLabelSetCommand,
 }
 
 var storageCommands = map[string]creator{
@@ -7,7 +7,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 	"unicode/utf8"
@@ -28,6 +27,10 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter.runner")
 
+// hookKillGracePeriod is how long a hook that has timed out is given
+// to exit after being asked to terminate, before it is killed.
+var hookKillGracePeriod = 10 * time.Second
+
 // Runner is responsible for invoking commands in a context.
 type Runner interface {
 
@@ -37,6 +40,11 @@ type Runner interface {
 	// RunHook executes the hook with the supplied name.
 	RunHook(name string) error
 
+	// RunHookWithTimeout executes the hook with the supplied name,
+	// terminating it if it runs for longer than the given timeout.
+	// A zero timeout means that the hook may run indefinitely.
+	RunHookWithTimeout(name string, timeout time.Duration) error
+
 	// RunAction executes the action with the supplied name.
 	RunAction(name string) error
 
@@ -60,13 +68,42 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return NewRunnerWithRuntime(context, paths, HostRuntime)
+}
+
+// NewRunnerWithRuntime returns a Runner backed by the supplied context
+// and paths, which runs charm hooks and actions using the supplied
+// runtime. Commands and debug-hooks sessions are always run on the host.
+func NewRunnerWithRuntime(context Context, paths context.Paths, runtime Runtime) Runner {
+	return &runner{context: context, paths: paths, runtime: runtime}
+}
+
+// OutputLimits holds the limits on the output of hooks and actions
+// that is logged or recorded in action results.
+type OutputLimits struct {
+	// MaxBytes is the maximum number of bytes of output from each
+	// hook or action that is logged, and of each of the standard
+	// output and standard error of juju-run actions that is recorded
+	// in the action results. Zero means the output is not limited.
+	MaxBytes int64
+
+	// Truncated, if not nil, is called whenever output is truncated,
+	// with the kind of output: "log", "stdout" or "stderr".
+	Truncated func(kind string)
+}
+
+func (l OutputLimits) truncated(kind string) {
+	if l.Truncated != nil {
+		l.Truncated(kind)
+	}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	runtime Runtime
+	limits  OutputLimits
 }
 
 func (runner *runner) Context() Context {
@@ -160,12 +197,22 @@ func encodeBytes(input []byte) (value string, encoding string) {
 	return value, encoding
 }
 
+// truncateOutput limits the output recorded in action results.
+func (runner *runner) truncateOutput(kind string, output []byte) []byte {
+	output, truncated := charmrunner.TruncateOutput(output, runner.limits.MaxBytes)
+	if truncated {
+		logger.Warningf("juju-run %s truncated to %d bytes", kind, runner.limits.MaxBytes)
+		runner.limits.truncated(kind)
+	}
+	return output
+}
+
 func (runner *runner) updateActionResults(results *utilexec.ExecResponse) error {
 	if err := runner.context.UpdateActionResults([]string{"Code"}, fmt.Sprintf("%d", results.Code)); err != nil {
 		return errors.Trace(err)
 	}
 
-	stdout, encoding := encodeBytes(results.Stdout)
+	stdout, encoding := encodeBytes(runner.truncateOutput("stdout", results.Stdout))
 	if err := runner.context.UpdateActionResults([]string{"Stdout"}, stdout); err != nil {
 		return errors.Trace(err)
 	}
@@ -175,7 +222,7 @@ func (runner *runner) updateActionResults(results *utilexec.ExecResponse) error
 		}
 	}
 
-	stderr, encoding := encodeBytes(results.Stderr)
+	stderr, encoding := encodeBytes(runner.truncateOutput("stderr", results.Stderr))
 	if err := runner.context.UpdateActionResults([]string{"Stderr"}, stderr); err != nil {
 		return errors.Trace(err)
 	}
@@ -196,15 +243,20 @@ func (runner *runner) RunAction(actionName string) error {
 	if actionName == actions.JujuRunActionName {
 		return runner.runJujuRunAction()
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.RunHookWithTimeout(hookName, 0)
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+// RunHookWithTimeout exists to satisfy the Runner interface.
+func (runner *runner) RunHookWithTimeout(hookName string, timeout time.Duration) error {
+	return runner.runCharmHookWithLocation(hookName, "hooks", timeout)
+}
+
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -227,41 +279,80 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
 		return err
 	}
-	hookCmd := hookCommand(hook)
-	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
-	ps.Env = env
-	ps.Dir = charmDir
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
 	}
-	ps.Stdout = outWriter
-	ps.Stderr = outWriter
-	hookLogger := charmrunner.NewHookLogger(runner.getLogger(hookName), outReader)
+	hookLogger := charmrunner.NewLimitedHookLogger(runner.getLogger(hookName), outReader, runner.limits.MaxBytes)
 	go hookLogger.Run()
-	err = ps.Start()
+	ps, err := runner.runtime.Start(ProcessParams{
+		Args:   hookCommand(hook),
+		Env:    env,
+		Dir:    charmDir,
+		Output: outWriter,
+	})
 	outWriter.Close()
 	if err == nil {
-		// Record the *os.Process of the hook
-		runner.context.SetProcess(hookProcess{ps.Process})
+		// Record the process running the hook.
+		runner.context.SetProcess(ps)
 		// Block until execution finishes
-		err = ps.Wait()
+		err = waitHook(hookName, ps, timeout, clock.WallClock)
 	}
 	hookLogger.Stop()
+	if hookLogger.Truncated() {
+		runner.limits.truncated("log")
+	}
 	return errors.Trace(err)
 }
 
+// waitHook waits for the hook process to finish. If the hook runs for
+// longer than the timeout, it and any processes it started are sent
+// SIGTERM and then, if it has not exited after hookKillGracePeriod,
+// killed.
+func waitHook(hookName string, ps Process, timeout time.Duration, clock clock.Clock) error {
+	if timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.After(timeout):
+	}
+
+	timeoutErr := charmrunner.NewHookTimeoutError(hookName, timeout)
+	logger.Warningf("%v, terminating", timeoutErr)
+	// Not all platforms support SIGTERM, in which case we go
+	// straight to killing the hook.
+	if err := ps.Terminate(); err == nil {
+		select {
+		case <-done:
+			return timeoutErr
+		case <-clock.After(hookKillGracePeriod):
+		}
+		logger.Warningf("%s did not exit after %v, killing", hookName, hookKillGracePeriod)
+	}
+	if err := ps.Kill(); err != nil {
+		logger.Errorf("cannot kill %s: %v", hookName, err)
+	}
+	<-done
+	return timeoutErr
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/proxy"
 	envtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -253,6 +254,55 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookWithRuntime(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	rt := &recordingRuntime{}
+	err := runner.NewRunnerWithRuntime(ctx, s.paths, rt).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+
+	c.Assert(rt.params, gc.HasLen, 1)
+	params := rt.params[0]
+	c.Check(params.Dir, gc.Equals, s.paths.GetCharmDir())
+	c.Check(params.Env, jc.DeepEquals, []string{"VAR=value"})
+	c.Check(params.Args[len(params.Args)-1], gc.Equals, filepath.Join(s.paths.GetCharmDir(), "hooks", hookName))
+}
+
+func (s *RunMockContextSuite) TestRunHookRuntimeStartError(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	rt := &recordingRuntime{err: errors.New("no container")}
+	err := runner.NewRunnerWithRuntime(ctx, s.paths, rt).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "no container")
+}
+
+// recordingRuntime records the processes it is asked to start,
+// and starts them on the host.
+type recordingRuntime struct {
+	params []runner.ProcessParams
+	err    error
+}
+
+func (r *recordingRuntime) Start(params runner.ProcessParams) (runner.Process, error) {
+	r.params = append(r.params, params)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return runner.HostRuntime.Start(params)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -318,6 +368,54 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionTruncatesOutput(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "printf 0123456789; printf abc >&2",
+			"timeout": 0,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	var truncated []string
+	rnr := runner.NewRunnerWithLimits(ctx, s.paths, runner.OutputLimits{
+		MaxBytes:  4,
+		Truncated: func(kind string) { truncated = append(truncated, kind) },
+	})
+	err := rnr.RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "0123\n[output truncated: 6 bytes omitted]")
+	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "abc")
+	c.Assert(truncated, jc.DeepEquals, []string{"stdout"})
+}
+
+func (s *RunMockContextSuite) TestRunHookTruncatesLoggedOutput(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("hook-output-test", &tw), jc.ErrorIsNil)
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		stdout: strings.Repeat("a", 100),
+	}, s.paths.GetCharmDir())
+	var truncated []string
+	rnr := runner.NewRunnerWithLimits(ctx, s.paths, runner.OutputLimits{
+		MaxBytes:  10,
+		Truncated: func(kind string) { truncated = append(truncated, kind) },
+	})
+	err := rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(truncated, jc.DeepEquals, []string{"log"})
+	c.Assert(tw.Log(), jc.LogMatches, jc.SimpleMessages{{
+		loggo.WARNING, "hook output exceeds the limit of 10 bytes, discarding further output",
+	}, {
+		loggo.WARNING, `\[output truncated: \d+ bytes omitted\]`,
+	}})
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+func (s *RunMockContextSuite) testRunHookTimeout(c *gc.C, script string) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		script: script,
+	}, s.paths.GetCharmDir())
+
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHookWithTimeout("something-happened", 100*time.Millisecond)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < 5*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `something-happened timed out after 100ms`)
+	c.Assert(errors.Cause(ctx.flushFailure), jc.Satisfies, charmrunner.IsHookTimeoutError)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeoutTerminates(c *gc.C) {
+	s.testRunHookTimeout(c, "sleep 10")
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeoutKills(c *gc.C) {
+	s.PatchValue(runner.HookKillGracePeriod, 100*time.Millisecond)
+	s.testRunHookTimeout(c, "trap '' TERM; sleep 10")
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeoutTerminatesChildren(c *gc.C) {
+	// The hook's child outlives the hook itself if only the hook
+	// is signalled.
+	survived := filepath.Join(c.MkDir(), "survived")
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:    "hooks",
+		name:   hookName,
+		perm:   0700,
+		script: fmt.Sprintf("(sleep 1; touch %s) & wait", survived),
+	}, s.paths.GetCharmDir())
+
+	err := runner.NewRunner(ctx, s.paths).RunHookWithTimeout("something-happened", 100*time.Millisecond)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errors.Cause(ctx.flushFailure), jc.Satisfies, charmrunner.IsHookTimeoutError)
+
+	time.Sleep(2 * time.Second)
+	_, err = os.Stat(survived)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 3,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHookWithTimeout("something-happened", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 3")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
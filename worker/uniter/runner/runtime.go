@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+// Runtime starts the processes that implement charm hooks and actions.
+// The default runtime forks them on the host; alternative runtimes may
+// run them elsewhere, such as inside a workload container or a
+// confined environment.
+type Runtime interface {
+	// Start starts the described process and returns without
+	// waiting for it to finish.
+	Start(params ProcessParams) (Process, error)
+}
+
+// ProcessParams describes a hook or action process to be started
+// by a Runtime.
+type ProcessParams struct {
+	// Args holds the path to the hook or action, followed by
+	// any arguments needed to run it.
+	Args []string
+
+	// Env holds the environment of the process, in the form
+	// "key=value".
+	Env []string
+
+	// Dir is the working directory of the process.
+	Dir string
+
+	// Output receives the combined standard output and standard
+	// error of the process.
+	Output io.Writer
+}
+
+// Process represents a hook or action process started by a Runtime.
+type Process interface {
+	context.HookProcess
+
+	// Wait waits for the process to exit, returning an error
+	// if it could not be waited for or did not exit cleanly.
+	Wait() error
+
+	// Terminate asks the process to exit. It returns an error
+	// if the runtime cannot do so, in which case the process
+	// should be killed instead.
+	Terminate() error
+}
+
+// RuntimeSelector returns the runtime to use for the hooks and actions
+// of a charm with the given metadata. A nil runtime means that the
+// hooks and actions are run on the host.
+type RuntimeSelector func(meta *charm.Meta) (Runtime, error)
+
+// HostRuntime is the Runtime that runs hooks and actions
+// as child processes of the unit agent.
+var HostRuntime Runtime = hostRuntime{}
+
+type hostRuntime struct{}
+
+// Start is part of the Runtime interface.
+func (hostRuntime) Start(params ProcessParams) (Process, error) {
+	if len(params.Args) == 0 {
+		return nil, errors.New("no command specified")
+	}
+	ps := exec.Command(params.Args[0], params.Args[1:]...)
+	ps.Env = params.Env
+	ps.Dir = params.Dir
+	ps.Stdout = params.Output
+	ps.Stderr = params.Output
+	// The process is started in its own process group, so that
+	// any processes it starts are signalled along with it.
+	setProcessGroup(ps)
+	if err := ps.Start(); err != nil {
+		return nil, err
+	}
+	return hostProcess{ps}, nil
+}
+
+// hostProcess is a Process running on the host.
+type hostProcess struct {
+	cmd *exec.Cmd
+}
+
+// Pid is part of the Process interface.
+func (p hostProcess) Pid() int {
+	return p.cmd.Process.Pid
+}
+
+// Kill is part of the Process interface. The process is killed
+// along with any processes it started.
+func (p hostProcess) Kill() error {
+	return killProcessGroup(p.cmd.Process)
+}
+
+// Wait is part of the Process interface.
+func (p hostProcess) Wait() error {
+	return p.cmd.Wait()
+}
+
+// Terminate is part of the Process interface. The process is sent
+// SIGTERM along with any processes it started. Not all platforms
+// support SIGTERM, in which case an error is returned.
+func (p hostProcess) Terminate() error {
+	return terminateProcessGroup(p.cmd.Process)
+}
@@ -0,0 +1,25 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup does nothing on Windows, which has no process
+// groups to signal.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// terminateProcessGroup returns an error on Windows, which does not
+// support SIGTERM, so that the process is killed instead.
+func terminateProcessGroup(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
+
+// killProcessGroup kills only the process p on Windows.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
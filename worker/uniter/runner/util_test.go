@@ -112,11 +112,11 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	factory, err := runner.NewFactory(
-		s.uniter,
-		s.paths,
-		s.contextFactory,
-	)
+	factory, err := runner.NewFactory(runner.FactoryParams{
+		State:          s.uniter,
+		Paths:          s.paths,
+		ContextFactory: s.contextFactory,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
 }
@@ -202,6 +202,8 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// script holds additional commands to run before exiting.
+	script string
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -246,5 +248,8 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.script != "" {
+		printf(spec.script)
+	}
 	printf("exit %d", spec.code)
 }
@@ -0,0 +1,230 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statusbuffer provides a bounded, file-backed buffer of unit
+// agent and workload status updates that could not be sent to the
+// controller, so that they can be replayed with their original
+// timestamps once the controller can be reached again.
+package statusbuffer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.statusbuffer")
+
+// DefaultMaxEntries is the default number of status updates
+// held by a buffer. When the buffer is full, the oldest
+// updates are dropped to make room for new ones.
+const DefaultMaxEntries = 500
+
+// Kind identifies the status that a buffered update sets.
+type Kind string
+
+const (
+	// Agent identifies the status of the unit agent. Entries
+	// buffered before workload status was buffered have no kind,
+	// and set the agent status.
+	Agent Kind = ""
+
+	// Workload identifies the status of the unit's workload.
+	Workload Kind = "workload"
+)
+
+// Entry is a buffered status update.
+type Entry struct {
+	Kind   Kind                   `json:"kind,omitempty"`
+	Status status.Status          `json:"status"`
+	Info   string                 `json:"info,omitempty"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  time.Time              `json:"since"`
+}
+
+// StatusSetter is the interface used to send status updates,
+// and to replay buffered ones.
+type StatusSetter interface {
+	SetAgentStatus(status.Status, string, map[string]interface{}) error
+	SetAgentStatusAt(status.Status, string, map[string]interface{}, time.Time) error
+	SetUnitStatus(status.Status, string, map[string]interface{}) error
+	SetUnitStatusAt(status.Status, string, map[string]interface{}, time.Time) error
+}
+
+// isUnreachable reports whether the error returned when sending a
+// status update means that the controller could not be reached,
+// rather than that it rejected the update.
+func isUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := errors.Cause(err).(*params.Error)
+	return !ok
+}
+
+// Buffer holds status updates in a file, so that
+// they survive a restart of the unit agent.
+type Buffer struct {
+	path       string
+	maxEntries int
+
+	mu sync.Mutex
+}
+
+// New returns a buffer that stores at most maxEntries
+// status updates in the file at the specified path.
+func New(path string, maxEntries int) *Buffer {
+	return &Buffer{
+		path:       path,
+		maxEntries: maxEntries,
+	}
+}
+
+// Add appends a status update to the buffer, dropping the
+// oldest updates if the buffer is full.
+func (b *Buffer) Add(entry Entry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return errors.Trace(b.add(entry))
+}
+
+// Entries returns the buffered status updates, oldest first.
+func (b *Buffer) Entries() ([]Entry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.read()
+}
+
+// Replay sends the buffered status updates to the setter, oldest
+// first, and removes them from the buffer. An update rejected by
+// the controller is logged and dropped; any other error stops the
+// replay, leaving the remaining updates in the buffer.
+func (b *Buffer) Replay(setter StatusSetter) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.replay(setter)
+}
+
+// Send sends any buffered status updates to the setter, followed by
+// the given one, which is set at the current time. If the controller
+// cannot be reached, the update is added to the buffer so that it is
+// sent, with its original time, along with the others next time.
+func (b *Buffer) Send(setter StatusSetter, entry Entry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n, err := b.replay(setter)
+	if n > 0 {
+		logger.Infof("replayed %d buffered status update(s)", n)
+	}
+	if err == nil {
+		err = entry.send(setter)
+	}
+	if isUnreachable(err) {
+		if err := b.add(entry); err != nil {
+			logger.Errorf("cannot buffer %s status: %v", entry.kindName(), err)
+		}
+	}
+	return errors.Trace(err)
+}
+
+func (b *Buffer) add(entry Entry) error {
+	entries, err := b.read()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entries = append(entries, entry)
+	if dropped := len(entries) - b.maxEntries; dropped > 0 {
+		logger.Warningf("status buffer full, dropping %d oldest status update(s)", dropped)
+		entries = entries[dropped:]
+	}
+	return errors.Trace(b.write(entries))
+}
+
+func (b *Buffer) replay(setter StatusSetter) (int, error) {
+	entries, err := b.read()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	for i, entry := range entries {
+		err := entry.sendAt(setter)
+		if err == nil {
+			continue
+		}
+		if !isUnreachable(err) {
+			logger.Warningf("dropping buffered %s status %q set at %s: %v", entry.kindName(), entry.Status, entry.Since, err)
+			continue
+		}
+		if err := b.write(entries[i:]); err != nil {
+			logger.Errorf("cannot update status buffer: %v", err)
+		}
+		return i, errors.Annotate(err, "replaying buffered status")
+	}
+	if err := b.write(nil); err != nil {
+		return len(entries), errors.Trace(err)
+	}
+	return len(entries), nil
+}
+
+func (b *Buffer) read() ([]Entry, error) {
+	data, err := ioutil.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Annotatef(err, "reading status buffer %q", b.path)
+	}
+	return entries, nil
+}
+
+func (b *Buffer) write(entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(b.path, data, 0600))
+}
+
+// send sets the status in the entry at the current time.
+func (e Entry) send(setter StatusSetter) error {
+	if e.Kind == Workload {
+		return setter.SetUnitStatus(e.Status, e.Info, e.Data)
+	}
+	return setter.SetAgentStatus(e.Status, e.Info, e.Data)
+}
+
+// sendAt sets the status in the entry at the time it was buffered.
+func (e Entry) sendAt(setter StatusSetter) error {
+	if e.Kind == Workload {
+		return setter.SetUnitStatusAt(e.Status, e.Info, e.Data, e.Since)
+	}
+	return setter.SetAgentStatusAt(e.Status, e.Info, e.Data, e.Since)
+}
+
+func (e Entry) kindName() string {
+	if e.Kind == Workload {
+		return "workload"
+	}
+	return "agent"
+}
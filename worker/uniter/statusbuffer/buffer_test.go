@@ -0,0 +1,208 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusbuffer_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/statusbuffer"
+)
+
+type BufferSuite struct {
+	testing.IsolationSuite
+
+	path string
+	now  time.Time
+}
+
+var _ = gc.Suite(&BufferSuite{})
+
+func (s *BufferSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.path = filepath.Join(c.MkDir(), "status-buffer")
+	s.now = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *BufferSuite) entry(i int) statusbuffer.Entry {
+	return statusbuffer.Entry{
+		Status: status.Executing,
+		Info:   "running hook",
+		Data:   map[string]interface{}{"hook": "install"},
+		Since:  s.now.Add(time.Duration(i) * time.Minute),
+	}
+}
+
+func (s *BufferSuite) TestAdd(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+	c.Assert(buffer.Add(s.entry(0)), jc.ErrorIsNil)
+	c.Assert(buffer.Add(s.entry(1)), jc.ErrorIsNil)
+
+	// The entries are held on disk.
+	entries, err := statusbuffer.New(s.path, 10).Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []statusbuffer.Entry{s.entry(0), s.entry(1)})
+}
+
+func (s *BufferSuite) TestAddDropsOldest(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 2)
+	for i := 0; i < 4; i++ {
+		c.Assert(buffer.Add(s.entry(i)), jc.ErrorIsNil)
+	}
+	entries, err := buffer.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []statusbuffer.Entry{s.entry(2), s.entry(3)})
+}
+
+func (s *BufferSuite) TestReplay(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+	c.Assert(buffer.Add(s.entry(0)), jc.ErrorIsNil)
+	c.Assert(buffer.Add(s.entry(1)), jc.ErrorIsNil)
+
+	var setter mockSetter
+	n, err := buffer.Replay(&setter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 2)
+	setter.CheckCalls(c, []testing.StubCall{
+		{"SetAgentStatusAt", []interface{}{status.Executing, "running hook", map[string]interface{}{"hook": "install"}, s.now}},
+		{"SetAgentStatusAt", []interface{}{status.Executing, "running hook", map[string]interface{}{"hook": "install"}, s.now.Add(time.Minute)}},
+	})
+
+	_, err = os.Stat(s.path)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *BufferSuite) TestReplayEmpty(c *gc.C) {
+	var setter mockSetter
+	n, err := statusbuffer.New(s.path, 10).Replay(&setter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+	setter.CheckNoCalls(c)
+}
+
+func (s *BufferSuite) TestReplayKeepsUnsent(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+	for i := 0; i < 3; i++ {
+		c.Assert(buffer.Add(s.entry(i)), jc.ErrorIsNil)
+	}
+
+	var setter mockSetter
+	setter.SetErrors(
+		&params.Error{Message: "rejected"},
+		nil,
+		errors.New("connection is shut down"),
+	)
+	n, err := buffer.Replay(&setter)
+	c.Assert(err, gc.ErrorMatches, "replaying buffered status: connection is shut down")
+	c.Assert(n, gc.Equals, 2)
+
+	entries, err := buffer.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []statusbuffer.Entry{s.entry(2)})
+}
+
+func (s *BufferSuite) TestReplayWorkloadStatus(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+	workload := statusbuffer.Entry{
+		Kind:   statusbuffer.Workload,
+		Status: status.Maintenance,
+		Info:   "installing",
+		Since:  s.now.Add(time.Minute),
+	}
+	c.Assert(buffer.Add(s.entry(0)), jc.ErrorIsNil)
+	c.Assert(buffer.Add(workload), jc.ErrorIsNil)
+
+	var setter mockSetter
+	n, err := buffer.Replay(&setter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 2)
+	setter.CheckCalls(c, []testing.StubCall{
+		{"SetAgentStatusAt", []interface{}{status.Executing, "running hook", map[string]interface{}{"hook": "install"}, s.now}},
+		{"SetUnitStatusAt", []interface{}{status.Maintenance, "installing", map[string]interface{}(nil), s.now.Add(time.Minute)}},
+	})
+}
+
+func (s *BufferSuite) TestSendReplaysFirst(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+	c.Assert(buffer.Add(s.entry(0)), jc.ErrorIsNil)
+
+	var setter mockSetter
+	err := buffer.Send(&setter, statusbuffer.Entry{
+		Kind:   statusbuffer.Workload,
+		Status: status.Active,
+		Since:  s.now.Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	setter.CheckCalls(c, []testing.StubCall{
+		{"SetAgentStatusAt", []interface{}{status.Executing, "running hook", map[string]interface{}{"hook": "install"}, s.now}},
+		{"SetUnitStatus", []interface{}{status.Active, "", map[string]interface{}(nil)}},
+	})
+
+	entries, err := buffer.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *BufferSuite) TestSendBuffersWhenUnreachable(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+
+	var setter mockSetter
+	setter.SetErrors(errors.New("connection is shut down"))
+	err := buffer.Send(&setter, s.entry(0))
+	c.Assert(err, gc.ErrorMatches, "connection is shut down")
+
+	setter.SetErrors(errors.New("connection is shut down"))
+	err = buffer.Send(&setter, s.entry(1))
+	c.Assert(err, gc.ErrorMatches, "replaying buffered status: connection is shut down")
+	setter.CheckCallNames(c, "SetAgentStatus", "SetAgentStatusAt")
+
+	entries, err := buffer.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []statusbuffer.Entry{s.entry(0), s.entry(1)})
+}
+
+func (s *BufferSuite) TestSendNotBufferedWhenRejected(c *gc.C) {
+	buffer := statusbuffer.New(s.path, 10)
+
+	var setter mockSetter
+	setter.SetErrors(&params.Error{Message: "rejected"})
+	err := buffer.Send(&setter, s.entry(0))
+	c.Assert(err, gc.ErrorMatches, "rejected")
+
+	entries, err := buffer.Entries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+type mockSetter struct {
+	testing.Stub
+}
+
+func (m *mockSetter) SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error {
+	m.MethodCall(m, "SetAgentStatus", agentStatus, info, data)
+	return m.NextErr()
+}
+
+func (m *mockSetter) SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
+	m.MethodCall(m, "SetUnitStatus", unitStatus, info, data)
+	return m.NextErr()
+}
+
+func (m *mockSetter) SetUnitStatusAt(unitStatus status.Status, info string, data map[string]interface{}, since time.Time) error {
+	m.MethodCall(m, "SetUnitStatusAt", unitStatus, info, data, since)
+	return m.NextErr()
+}
+
+func (m *mockSetter) SetAgentStatusAt(agentStatus status.Status, info string, data map[string]interface{}, since time.Time) error {
+	m.MethodCall(m, "SetAgentStatusAt", agentStatus, info, data, since)
+	return m.NextErr()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v2"
@@ -41,6 +42,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/statusbuffer"
 	"github.com/juju/juju/worker/uniter/storage"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
 )
@@ -81,7 +83,13 @@ type Uniter struct {
 	lastReportedStatus  status.Status
 	lastReportedMessage string
 
+	// statusBuffer holds agent status updates that could not be
+	// sent to the controller, until they can be replayed.
+	statusBuffer *statusbuffer.Buffer
+
 	operationFactory     operation.Factory
+	operationTracer      *operationTracer
+	hookTimeout          *hookTimeout
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
 	translateResolverErr func(error) error
@@ -91,6 +99,10 @@ type Uniter struct {
 
 	hookLock machinelock.Lock
 
+	// parallelActionsLock is held for reading by running parallel
+	// actions, and for writing while a charm is deployed.
+	parallelActionsLock sync.RWMutex
+
 	// TODO(axw) move the runListener and run-command code outside of the
 	// uniter, and introduce a separate worker. Each worker would feed
 	// operations to a single, synchronized runner to execute.
@@ -116,6 +128,15 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// selectHookRuntime, if set, chooses where the charm's hooks and
+	// actions are run.
+	selectHookRuntime runner.RuntimeSelector
+
+	// prometheusRegisterer, if set, is used to register the
+	// uniter's metrics.
+	prometheusRegisterer prometheus.Registerer
+	outputTruncations    *prometheus.CounterVec
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -134,6 +155,8 @@ type UniterParams struct {
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
 	ApplicationChannel   watcher.NotifyChannel
+	SelectHookRuntime    runner.RuntimeSelector
+	PrometheusRegisterer prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -180,6 +203,10 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
 		applicationChannel:   uniterParams.ApplicationChannel,
+		selectHookRuntime:    uniterParams.SelectHookRuntime,
+		prometheusRegisterer: uniterParams.PrometheusRegisterer,
+		outputTruncations:    newOutputTruncationsCounter(),
+		operationTracer:      newOperationTracer(uniterParams.Clock),
 	}
 	startFunc := func() (worker.Worker, error) {
 		if err := catacomb.Invoke(catacomb.Plan{
@@ -199,6 +226,13 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 }
 
 func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
+	if u.prometheusRegisterer != nil {
+		var unregister func()
+		u.outputTruncations, unregister = registerOutputTruncations(
+			u.prometheusRegisterer, u.outputTruncations,
+		)
+		defer unregister()
+	}
 	if err := u.init(unitTag); err != nil {
 		switch cause := errors.Cause(err); cause {
 		case resolver.ErrLoopAborted:
@@ -295,6 +329,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				RetryHookChannel:    retryHookChan,
 				ApplicationChannel:  u.applicationChannel,
 				ModelType:           u.modelType,
+				Clock:               u.clock,
+				// The leader may take as long as the pre-destroy
+				// hook timeout to run the hook, once it has noticed
+				// that the application is dying.
+				PreDestroyTimeout: 2 * operation.DefaultPreDestroyTimeout,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -336,6 +375,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
+			ReportPendingHooks:  u.reportPendingHooks,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
 			Actions:             actions.NewResolver(),
@@ -484,6 +524,12 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		// and inescapable, whereas this one is not.
 		return u.stopUnitError()
 	}
+	u.statusBuffer = statusbuffer.New(u.paths.State.StatusBufferFile, statusbuffer.DefaultMaxEntries)
+	if n, err := u.statusBuffer.Replay(u.unit); err != nil {
+		return errors.Trace(err)
+	} else if n > 0 {
+		logger.Infof("replayed %d buffered status update(s)", n)
+	}
 	// If initialising for the first time after deploying, update the status.
 	currentStatus, err := u.unit.UnitStatus()
 	if err != nil {
@@ -533,18 +579,24 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	// Only IAAS models require the uniter to install charms.
 	// For CAAS models this is done by the operator.
 	var deployer charm.Deployer
+	var bundles charm.BundleReader
 	if u.modelType == model.IAAS {
 		if err := charm.ClearDownloads(u.paths.State.BundlesDir); err != nil {
 			logger.Warningf(err.Error())
 		}
+		bundles = charm.NewBundlesDir(u.paths.State.BundlesDir, u.downloader)
 		deployer, err = charm.NewDeployer(
 			u.paths.State.CharmDir,
 			u.paths.State.DeployerDir,
-			charm.NewBundlesDir(u.paths.State.BundlesDir, u.downloader),
+			bundles,
 		)
 		if err != nil {
 			return errors.Annotatef(err, "cannot create deployer")
 		}
+		deployer = &charmDirLockingDeployer{
+			Deployer: deployer,
+			lock:     &u.parallelActionsLock,
+		}
 	}
 	contextFactory, err := context.NewContextFactory(context.FactoryConfig{
 		State:            u.st,
@@ -554,22 +606,72 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Storage:          u.storage,
 		Paths:            u.paths,
 		Clock:            u.clock,
+		StatusBuffer:     u.statusBuffer,
 	})
 	if err != nil {
 		return err
 	}
-	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
-	)
+	modelConfig, err := u.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	runnerFactory, err := runner.NewFactory(runner.FactoryParams{
+		State:          u.st,
+		Paths:          u.paths,
+		ContextFactory: contextFactory,
+		SelectRuntime:  u.selectHookRuntime,
+		OutputLimits: runner.OutputLimits{
+			MaxBytes: modelConfig.HookOutputLimit(),
+			Truncated: func(kind string) {
+				u.outputTruncations.WithLabelValues(kind).Inc()
+			},
+		},
+	})
 	if err != nil {
 		return errors.Trace(err)
 	}
+	u.hookTimeout = &hookTimeout{
+		st:      u.st,
+		timeout: modelConfig.HookTimeout(),
+	}
+	hookTimeoutWorker, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: u.hookTimeout,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.catacomb.Add(hookTimeoutWorker); err != nil {
+		return errors.Trace(err)
+	}
+	actionDispatcher := &parallelActionDispatcher{
+		charmDir:   u.paths.GetCharmDir(),
+		stateFile:  u.paths.State.ParallelActionsFile,
+		lock:       &u.parallelActionsLock,
+		addWorker:  u.catacomb.Add,
+		failAction: (&operationCallbacks{u}).FailAction,
+	}
+	if err := actionDispatcher.failInterrupted(); err != nil {
+		return errors.Trace(err)
+	}
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		SnapshotPaths: []string{
+			u.paths.State.CharmDir,
+			u.paths.State.RelationsDir,
+			u.paths.State.StorageDir,
+		},
+		SnapshotDir:      u.paths.State.SnapshotDir,
+		HookTimeout:      u.hookTimeout.get,
+		Recorder:         &operationRecorder{u},
+		Tracer:           u.operationTracer,
+		Clock:            u.clock,
+		ActionDispatcher: actionDispatcher,
+		Bundles:          bundles,
+		CharmDir:         u.paths.State.CharmDir,
 	})
 
 	charmURL, err := u.getApplicationCharmURL()
@@ -632,6 +734,14 @@ func (u *Uniter) Wait() error {
 	return u.catacomb.Wait()
 }
 
+// Report is part of the dependency.Reporter interface. It shows the
+// operation the uniter is running, with the phase it has reached and
+// how long that phase has taken, and the phase timings of its most
+// recent operations.
+func (u *Uniter) Report() map[string]interface{} {
+	return u.operationTracer.report()
+}
+
 func (u *Uniter) getApplicationCharmURL() (*corecharm.URL, error) {
 	// TODO(fwereade): pretty sure there's no reason to make 2 API calls here.
 	app, err := u.st.Application(u.unit.ApplicationTag())
@@ -686,5 +796,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.operationExecutor.State().HookTimedOut {
+		statusData["timed-out"] = true
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
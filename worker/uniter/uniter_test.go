@@ -228,6 +228,26 @@ func (s *UniterSuite) TestUniterStartupStatus(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterRecordsOperations(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"operations are recorded in the unit's operation history",
+			quickStart{},
+			custom{func(c *gc.C, ctx *context) {
+				ops, err := ctx.unit.OperationHistory()
+				c.Assert(err, jc.ErrorIsNil)
+				descriptions := make(map[string]string)
+				for _, op := range ops {
+					c.Check(op.Error, gc.Equals, "")
+					descriptions[op.Description] = op.Kind
+				}
+				c.Assert(descriptions["run install hook"], gc.Equals, "run-hook")
+				c.Assert(descriptions["run leader-elected hook"], gc.Equals, "run-hook")
+			}},
+		),
+	})
+}
+
 func (s *UniterSuite) TestUniterInstallHook(c *gc.C) {
 	s.runUniterTests(c, []uniterTest{
 		ut(
@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradesteps
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	apiupgradesteps "github.com/juju/juju/api/upgradesteps"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/upgrades"
+)
+
+// setStepProgressFunc records the status of an upgrade step.
+type setStepProgressFunc func(step string, status state.UpgradeStepStatus, message string) error
+
+// stepProgressReporter records the progress of each upgrade step run
+// by the agent, so that the upgrade can be followed step by step.
+// Failure to record progress is logged, and does not affect the
+// upgrade.
+type stepProgressReporter struct {
+	setProgress setStepProgressFunc
+}
+
+var _ upgrades.StepProgressReporter = (*stepProgressReporter)(nil)
+
+// StepStarted is part of the upgrades.StepProgressReporter interface.
+func (r *stepProgressReporter) StepStarted(step string) {
+	r.report(step, state.UpgradeStepRunning, "")
+}
+
+// StepFinished is part of the upgrades.StepProgressReporter interface.
+func (r *stepProgressReporter) StepFinished(step string, err error) {
+	if err != nil {
+		r.report(step, state.UpgradeStepFailed, err.Error())
+		return
+	}
+	r.report(step, state.UpgradeStepCompleted, "")
+}
+
+func (r *stepProgressReporter) report(step string, status state.UpgradeStepStatus, message string) {
+	if err := r.setProgress(step, status, message); err != nil {
+		logger.Debugf("cannot record progress of upgrade step %q: %v", step, err)
+	}
+}
+
+// newStepProgressReporter returns a reporter recording the progress of
+// the agent's upgrade steps. Controllers record progress directly in
+// state, as agent API logins are refused while they are upgrading.
+// Other machine agents record progress through the API. Nil is returned
+// for agents that cannot record progress.
+func (w *upgradesteps) newStepProgressReporter() upgrades.StepProgressReporter {
+	tag, vers := w.tag, w.toVersion
+	if w.isController && w.pool != nil {
+		st := w.pool.SystemState()
+		return &stepProgressReporter{
+			setProgress: func(step string, status state.UpgradeStepStatus, message string) error {
+				return st.SetUpgradeStepProgress(tag, vers, step, status, message)
+			},
+		}
+	}
+	machineTag, ok := tag.(names.MachineTag)
+	if !ok || w.apiConn == nil {
+		return nil
+	}
+	client := apiupgradesteps.NewClient(w.apiConn)
+	return &stepProgressReporter{
+		setProgress: func(step string, status state.UpgradeStepStatus, message string) error {
+			return client.SetUpgradeStepProgress(machineTag, vers, step, string(status), message)
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradesteps
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type progressSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&progressSuite{})
+
+type recordedProgress struct {
+	step    string
+	status  state.UpgradeStepStatus
+	message string
+}
+
+func (s *progressSuite) TestStepProgressReporter(c *gc.C) {
+	var recorded []recordedProgress
+	reporter := &stepProgressReporter{
+		setProgress: func(step string, status state.UpgradeStepStatus, message string) error {
+			recorded = append(recorded, recordedProgress{step, status, message})
+			return errors.New("ignored")
+		},
+	}
+	reporter.StepStarted("first")
+	reporter.StepFinished("first", nil)
+	reporter.StepStarted("second")
+	reporter.StepFinished("second", errors.New("boom"))
+	c.Assert(recorded, jc.DeepEquals, []recordedProgress{
+		{"first", state.UpgradeStepRunning, ""},
+		{"first", state.UpgradeStepCompleted, ""},
+		{"second", state.UpgradeStepRunning, ""},
+		{"second", state.UpgradeStepFailed, "boom"},
+	})
+}
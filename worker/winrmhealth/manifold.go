@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmhealth
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/core/watcher"
+)
+
+// ManifoldConfig describes the resources used by the winrmhealth
+// worker.
+type ManifoldConfig struct {
+	APICallerName string
+	ClockName     string
+
+	Period                   time.Duration
+	CertificateExpiryWarning time.Duration
+	ProbeTimeout             time.Duration
+
+	NewFacade func(base.APICaller) (Facade, error)
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the configuration is not complete.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.ProbeTimeout <= 0 {
+		return errors.NotValidf("non-positive ProbeTimeout")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:                   facade,
+		Clock:                    clock,
+		Period:                   config.Period,
+		CertificateExpiryWarning: config.CertificateExpiryWarning,
+		Probe:                    NewListenerProbe(config.ProbeTimeout),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Manifold returns a Manifold that encapsulates the winrmhealth worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.ClockName,
+		},
+		Start: config.start,
+	}
+}
+
+// NewFacade returns a Facade backed by the InstancePoller facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return facadeShim{instancepoller.NewAPI(apiCaller)}, nil
+}
+
+type facadeShim struct {
+	api *instancepoller.API
+}
+
+// WatchModelMachines is part of the Facade interface.
+func (s facadeShim) WatchModelMachines() (watcher.StringsWatcher, error) {
+	return s.api.WatchModelMachines()
+}
+
+// Machine is part of the Facade interface.
+func (s facadeShim) Machine(tag names.MachineTag) (Machine, error) {
+	m, err := s.api.Machine(tag)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmhealth
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// httpsPort is the port of the WinRM HTTPS listener.
+const httpsPort = 5986
+
+// NewListenerProbe returns a ProbeFunc that connects to the WinRM
+// HTTPS listener on a host, and reads the expiry time of its
+// certificate. The listener's certificate is usually self-signed,
+// so it is not verified; no credentials are sent.
+func NewListenerProbe(timeout time.Duration) ProbeFunc {
+	return func(host string) (time.Time, error) {
+		dialer := &net.Dialer{Timeout: timeout}
+		addr := net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+			InsecureSkipVerify: true,
+		})
+		if err != nil {
+			return time.Time{}, errors.Trace(err)
+		}
+		defer conn.Close()
+		certs := conn.ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return time.Time{}, errors.Errorf("no certificate presented by %s", addr)
+		}
+		return certs[0].NotAfter, nil
+	}
+}
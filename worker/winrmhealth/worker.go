@@ -0,0 +1,338 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package winrmhealth provides a worker that checks the WinRM
+// listeners of manually provisioned Windows machines, and records
+// their health in each machine's instance status data. Without it, a
+// Windows machine whose agent has stopped looks the same whether or
+// not the machine itself can still be reached.
+package winrmhealth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jujuos "github.com/juju/os"
+	"github.com/juju/os/series"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/manual"
+)
+
+var logger = loggo.GetLogger("juju.worker.winrmhealth")
+
+const (
+	// DefaultPeriod is the default time between checks of a
+	// machine's WinRM listener.
+	DefaultPeriod = 5 * time.Minute
+
+	// DefaultCertificateExpiryWarning is the default time before a
+	// WinRM listener's certificate expires that it is reported.
+	DefaultCertificateExpiryWarning = 30 * 24 * time.Hour
+
+	// ListenerHealthKey is the instance status data key under which
+	// the health of a machine's WinRM listener is recorded.
+	ListenerHealthKey = "winrm-listener"
+
+	// CertificateExpiryKey is the instance status data key under
+	// which the expiry time of a machine's WinRM listener certificate
+	// is recorded.
+	CertificateExpiryKey = "winrm-certificate-expiry"
+
+	// healthy is the health recorded for a reachable listener whose
+	// certificate is not about to expire.
+	healthy = "reachable"
+)
+
+// Facade exposes the controller capabilities needed by the worker.
+type Facade interface {
+	WatchModelMachines() (watcher.StringsWatcher, error)
+	Machine(tag names.MachineTag) (Machine, error)
+}
+
+// Machine exposes the capabilities of a single machine needed by
+// the worker.
+type Machine interface {
+	Id() string
+	Life() params.Life
+	IsManual() (bool, error)
+	Series() (string, error)
+	InstanceId() (instance.Id, error)
+	InstanceStatus() (params.StatusResult, error)
+	SetInstanceStatus(status status.Status, message string, data map[string]interface{}) error
+}
+
+// ProbeFunc checks the WinRM listener on the host, returning the
+// time that its certificate expires.
+type ProbeFunc func(host string) (time.Time, error)
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks of each machine.
+	Period time.Duration
+
+	// CertificateExpiryWarning is how long before a listener's
+	// certificate expires that the expiry is reported.
+	CertificateExpiryWarning time.Duration
+
+	// Probe checks a WinRM listener.
+	Probe ProbeFunc
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.CertificateExpiryWarning < 0 {
+		return errors.NotValidf("negative CertificateExpiryWarning")
+	}
+	if config.Probe == nil {
+		return errors.NotValidf("nil Probe")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that checks the WinRM listeners of the
+// model's manually provisioned Windows machines once when started,
+// and subsequently every Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &healthWorker{
+		config:   config,
+		machines: make(map[string]*windowsMachine),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// windowsMachine is a manually provisioned Windows machine whose
+// WinRM listener is checked.
+type windowsMachine struct {
+	machine Machine
+	host    string
+}
+
+type healthWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// machines holds the machines to check, by id.
+	machines map[string]*windowsMachine
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *healthWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *healthWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *healthWorker) loop() error {
+	machinesWatcher, err := w.config.Facade.WatchModelMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(machinesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The first check is made once the initial set of machines
+	// has been read.
+	var check <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case ids, ok := <-machinesWatcher.Changes():
+			if !ok {
+				return errors.New("machines watcher closed")
+			}
+			for _, id := range ids {
+				if err := w.machineChanged(id); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if check == nil {
+				check = w.config.Clock.After(0)
+			}
+		case <-check:
+			if err := w.checkMachines(); err != nil {
+				return errors.Trace(err)
+			}
+			check = w.config.Clock.After(w.config.Period)
+		}
+	}
+}
+
+// machineChanged starts or stops checking the machine with the given
+// id, depending on whether it is a live, manually provisioned Windows
+// machine.
+func (w *healthWorker) machineChanged(id string) error {
+	delete(w.machines, id)
+	m, err := w.config.Facade.Machine(names.NewMachineTag(id))
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Life() == params.Dead {
+		return nil
+	}
+	isManual, err := m.IsManual()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isManual {
+		return nil
+	}
+	machineSeries, err := m.Series()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if os, err := series.GetOSFromSeries(machineSeries); err != nil || os != jujuos.Windows {
+		return nil
+	}
+	instId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	host := strings.TrimPrefix(string(instId), manual.ManualInstancePrefix)
+	if host == string(instId) || host == "" {
+		logger.Warningf("machine %s has unexpected instance id %q", id, instId)
+		return nil
+	}
+	logger.Debugf("checking the WinRM listener of machine %s on %q", id, host)
+	w.machines[id] = &windowsMachine{machine: m, host: host}
+	return nil
+}
+
+// listenerHealth holds the result of checking a machine's WinRM
+// listener.
+type listenerHealth struct {
+	id     string
+	health string
+	expiry time.Time
+}
+
+// checkMachines checks the WinRM listeners of all the machines
+// concurrently, and records each listener's health in the machine's
+// instance status data. The instance status itself is left alone,
+// since it is owned by whatever provisioned the machine.
+func (w *healthWorker) checkMachines() error {
+	results := make([]listenerHealth, 0, len(w.machines))
+	for id := range w.machines {
+		results = append(results, listenerHealth{id: id})
+	}
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(result *listenerHealth) {
+			defer wg.Done()
+			result.health, result.expiry = w.checkListener(w.machines[result.id].host)
+		}(&results[i])
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-w.catacomb.Dying():
+		return w.catacomb.ErrDying()
+	case <-done:
+	}
+
+	for _, result := range results {
+		if err := w.setHealth(w.machines[result.id].machine, result); params.IsCodeNotFound(err) {
+			delete(w.machines, result.id)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// setHealth records the health of the machine's WinRM listener in
+// its instance status data, if it has changed.
+func (w *healthWorker) setHealth(m Machine, result listenerHealth) error {
+	current, err := m.InstanceStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var expiry string
+	if !result.expiry.IsZero() {
+		expiry = result.expiry.UTC().Format(time.RFC3339)
+	}
+	if current.Data[ListenerHealthKey] == result.health && current.Data[CertificateExpiryKey] == expiry {
+		return nil
+	}
+	if result.health != healthy {
+		logger.Warningf("machine %s: WinRM listener %s", result.id, result.health)
+	}
+	data := make(map[string]interface{})
+	for k, v := range current.Data {
+		data[k] = v
+	}
+	data[ListenerHealthKey] = result.health
+	if expiry != "" {
+		data[CertificateExpiryKey] = expiry
+	} else {
+		delete(data, CertificateExpiryKey)
+	}
+	return errors.Trace(m.SetInstanceStatus(status.Status(current.Status), current.Info, data))
+}
+
+// checkListener returns the health of the WinRM listener on the
+// host, and the time that its certificate expires if it is known.
+func (w *healthWorker) checkListener(host string) (string, time.Time) {
+	expiry, err := w.config.Probe(host)
+	if err != nil {
+		return fmt.Sprintf("unreachable: %v", err), time.Time{}
+	}
+	now := w.config.Clock.Now()
+	switch {
+	case !expiry.After(now):
+		return "certificate expired", expiry
+	case expiry.Before(now.Add(w.config.CertificateExpiryWarning)):
+		return "certificate expiring", expiry
+	}
+	return healthy, expiry
+}
@@ -0,0 +1,350 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package winrmhealth_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/winrmhealth"
+)
+
+type WorkerSuite struct {
+	clock    *testclock.Clock
+	changes  chan []string
+	facade   *fakeFacade
+	probes   chan string
+	statuses chan instanceStatus
+
+	mu       sync.Mutex
+	expiry   time.Time
+	probeErr error
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(coretesting.ZeroTime())
+	s.changes = make(chan []string, 1)
+	s.probes = make(chan string, 10)
+	s.statuses = make(chan instanceStatus, 10)
+	s.expiry = coretesting.ZeroTime().Add(365 * 24 * time.Hour)
+	s.probeErr = nil
+	s.facade = &fakeFacade{
+		changes: s.changes,
+		machines: map[string]*fakeMachine{
+			"0": {
+				id: "0", manual: true, series: "win2016", instId: "manual:10.0.0.1",
+				current: params.StatusResult{Status: status.Running.String(), Info: "started"}, statuses: s.statuses,
+			},
+			"1": {id: "1", manual: true, series: "bionic", instId: "manual:10.0.0.2", statuses: s.statuses},
+			"2": {id: "2", manual: false, series: "win2016", instId: "i-win", statuses: s.statuses},
+		},
+	}
+}
+
+func (s *WorkerSuite) probe(host string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probes <- host
+	return s.expiry, s.probeErr
+}
+
+func (s *WorkerSuite) config() winrmhealth.Config {
+	return winrmhealth.Config{
+		Facade:                   s.facade,
+		Clock:                    s.clock,
+		Period:                   time.Minute,
+		CertificateExpiryWarning: 30 * 24 * time.Hour,
+		Probe:                    s.probe,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := winrmhealth.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.changes <- []string{"0", "1", "2", "3"}
+	return w
+}
+
+func (s *WorkerSuite) assertProbe(c *gc.C, expect string) {
+	select {
+	case host := <-s.probes:
+		c.Assert(host, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for probe")
+	}
+}
+
+func (s *WorkerSuite) assertStatus(c *gc.C, expect instanceStatus) {
+	select {
+	case st := <-s.statuses:
+		c.Assert(st, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for instance status")
+	}
+}
+
+func (s *WorkerSuite) assertNoStatus(c *gc.C) {
+	select {
+	case st := <-s.statuses:
+		c.Fatalf("unexpected instance status %#v", st)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		change func(*winrmhealth.Config)
+		err    string
+	}{
+		{func(cfg *winrmhealth.Config) { cfg.Facade = nil }, "nil Facade not valid"},
+		{func(cfg *winrmhealth.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *winrmhealth.Config) { cfg.Period = 0 }, "non-positive Period not valid"},
+		{func(cfg *winrmhealth.Config) { cfg.CertificateExpiryWarning = -1 }, "negative CertificateExpiryWarning not valid"},
+		{func(cfg *winrmhealth.Config) { cfg.Probe = nil }, "nil Probe not valid"},
+	} {
+		c.Logf("test %d", i)
+		config := s.config()
+		test.change(&config)
+		_, err := winrmhealth.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestChecksWindowsManualMachines(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProbe(c, "10.0.0.1")
+	s.assertStatus(c, instanceStatus{"0", status.Running, "started", map[string]interface{}{
+		winrmhealth.ListenerHealthKey:    "reachable",
+		winrmhealth.CertificateExpiryKey: s.expiry.UTC().Format(time.RFC3339),
+	}})
+
+	s.mu.Lock()
+	s.probeErr = errors.New("connection refused")
+	s.mu.Unlock()
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertProbe(c, "10.0.0.1")
+	s.assertStatus(c, instanceStatus{"0", status.Running, "started", map[string]interface{}{
+		winrmhealth.ListenerHealthKey: "unreachable: connection refused",
+	}})
+
+	select {
+	case host := <-s.probes:
+		c.Fatalf("unexpected probe of %q", host)
+	default:
+	}
+}
+
+func (s *WorkerSuite) TestInstanceStatusKept(c *gc.C) {
+	s.facade.machines["0"].current = params.StatusResult{
+		Status: status.ProvisioningError.String(),
+		Info:   "cannot start agent",
+		Data:   map[string]interface{}{"other": "value"},
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProbe(c, "10.0.0.1")
+	s.assertStatus(c, instanceStatus{"0", status.ProvisioningError, "cannot start agent", map[string]interface{}{
+		"other":                          "value",
+		winrmhealth.ListenerHealthKey:    "reachable",
+		winrmhealth.CertificateExpiryKey: s.expiry.UTC().Format(time.RFC3339),
+	}})
+}
+
+func (s *WorkerSuite) TestUnchangedHealthNotSet(c *gc.C) {
+	s.facade.machines["0"].current = params.StatusResult{
+		Status: status.Running.String(),
+		Info:   "started",
+		Data: map[string]interface{}{
+			winrmhealth.ListenerHealthKey:    "reachable",
+			winrmhealth.CertificateExpiryKey: s.expiry.UTC().Format(time.RFC3339),
+		},
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProbe(c, "10.0.0.1")
+	s.assertNoStatus(c)
+}
+
+func (s *WorkerSuite) TestProbesConcurrently(c *gc.C) {
+	s.facade.machines["3"] = &fakeMachine{
+		id: "3", manual: true, series: "win2016", instId: "manual:10.0.0.3",
+		current: params.StatusResult{Status: status.Running.String(), Info: "started"}, statuses: s.statuses,
+	}
+	release := make(chan struct{})
+	config := s.config()
+	config.Probe = func(host string) (time.Time, error) {
+		s.probes <- host
+		<-release
+		return s.expiry, nil
+	}
+	w, err := winrmhealth.NewWorker(config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.changes <- []string{"0", "3"}
+
+	// Neither probe finishes until both have started.
+	hosts := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case host := <-s.probes:
+			hosts[host] = true
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for probe")
+		}
+	}
+	c.Assert(hosts, jc.DeepEquals, map[string]bool{"10.0.0.1": true, "10.0.0.3": true})
+	close(release)
+
+	ids := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case st := <-s.statuses:
+			ids[st.id] = true
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for instance status")
+		}
+	}
+	c.Assert(ids, jc.DeepEquals, map[string]bool{"0": true, "3": true})
+}
+
+func (s *WorkerSuite) TestCertificateExpiring(c *gc.C) {
+	s.expiry = coretesting.ZeroTime().Add(7 * 24 * time.Hour)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProbe(c, "10.0.0.1")
+	s.assertStatus(c, instanceStatus{"0", status.Running, "started", map[string]interface{}{
+		winrmhealth.ListenerHealthKey:    "certificate expiring",
+		winrmhealth.CertificateExpiryKey: s.expiry.UTC().Format(time.RFC3339),
+	}})
+}
+
+func (s *WorkerSuite) TestCertificateExpired(c *gc.C) {
+	s.expiry = coretesting.ZeroTime().Add(-time.Hour)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertProbe(c, "10.0.0.1")
+	s.assertStatus(c, instanceStatus{"0", status.Running, "started", map[string]interface{}{
+		winrmhealth.ListenerHealthKey:    "certificate expired",
+		winrmhealth.CertificateExpiryKey: s.expiry.UTC().Format(time.RFC3339),
+	}})
+}
+
+func (s *WorkerSuite) TestDeadMachineNotChecked(c *gc.C) {
+	s.facade.setLife("0", params.Dead)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case host := <-s.probes:
+		c.Fatalf("unexpected probe of %q", host)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestWatcherError(c *gc.C) {
+	w := s.startWorker(c)
+	close(s.changes)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "machines watcher closed")
+}
+
+type instanceStatus struct {
+	id      string
+	status  status.Status
+	message string
+	data    map[string]interface{}
+}
+
+type fakeFacade struct {
+	mu       sync.Mutex
+	changes  chan []string
+	machines map[string]*fakeMachine
+}
+
+func (f *fakeFacade) WatchModelMachines() (watcher.StringsWatcher, error) {
+	return watchertest.NewMockStringsWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) Machine(tag names.MachineTag) (winrmhealth.Machine, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.machines[tag.Id()]
+	if !ok {
+		return nil, &params.Error{Code: params.CodeNotFound, Message: "machine not found"}
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (f *fakeFacade) setLife(id string, life params.Life) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.machines[id].life = life
+}
+
+type fakeMachine struct {
+	id       string
+	life     params.Life
+	manual   bool
+	series   string
+	instId   instance.Id
+	current  params.StatusResult
+	statuses chan<- instanceStatus
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) Life() params.Life {
+	if m.life == "" {
+		return params.Alive
+	}
+	return m.life
+}
+
+func (m *fakeMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+func (m *fakeMachine) Series() (string, error) {
+	return m.series, nil
+}
+
+func (m *fakeMachine) InstanceId() (instance.Id, error) {
+	return m.instId, nil
+}
+
+func (m *fakeMachine) InstanceStatus() (params.StatusResult, error) {
+	return m.current, nil
+}
+
+func (m *fakeMachine) SetInstanceStatus(st status.Status, message string, data map[string]interface{}) error {
+	m.current = params.StatusResult{Status: st.String(), Info: message, Data: data}
+	m.statuses <- instanceStatus{m.id, st, message, data}
+	return nil
+}